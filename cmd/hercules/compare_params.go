@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/src-d/hercules.v4"
+)
+
+// compareParamsCmd represents the compare-params command
+var compareParamsCmd = &cobra.Command{
+	Use:   "compare-params",
+	Short: "Measure how much the results of two runs with different parameters differ.",
+	Long: `The runs must analyse the same repository and must be saved with --pb. Every numeric value
+of every analysis, e.g. each cell of a burndown matrix, is matched with the value at the same
+position in the other run. The summary is printed in YAML for each analysis and each its field.`,
+	Args: cobra.MaximumNArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runs, _ := cmd.Flags().GetStringSlice("runs")
+		if len(runs) != 2 {
+			log.Fatalf("--runs must contain exactly two files, got %d", len(runs))
+		}
+		var repos []string
+		allErrors := map[string][]string{}
+		var results [2]map[string]interface{}
+		var commons [2]*hercules.CommonAnalysisResult
+		for i, fileName := range runs {
+			results[i], commons[i], allErrors[fileName] = loadMessage(fileName, &repos)
+			if commons[i] == nil {
+				printErrors(allErrors)
+				os.Exit(1)
+			}
+		}
+		printErrors(allErrors)
+		if repos[0] != repos[1] {
+			log.Printf("warning: comparing different repositories: %s and %s", repos[0], repos[1])
+		}
+		fmt.Println("compare_params:")
+		fmt.Printf("  a: %s\n", hercules.SafeYamlString(runs[0]))
+		fmt.Printf("  b: %s\n", hercules.SafeYamlString(runs[1]))
+		fmt.Printf("  commits: [%d, %d]\n", commons[0].CommitsNumber, commons[1].CommitsNumber)
+		printResultsDifference(os.Stdout, results[0], results[1])
+	},
+}
+
+// numbersDifference summarizes how much two sets of numbers at the matching positions differ.
+type numbersDifference struct {
+	// Values is the number of the positions which exist in both sets.
+	Values int
+	// Different is the number of the positions with different values.
+	Different int
+	// OnlyA and OnlyB are the numbers of the positions which exist only in one of the sets.
+	OnlyA int
+	OnlyB int
+	// Distance is the sum of absolute differences; a missing value is treated as zero.
+	Distance float64
+	// Magnitude is the maximum of the sums of absolute values of both sets.
+	Magnitude float64
+}
+
+// Relative returns Distance normalized by Magnitude: 0 means identical, 1 means nothing in common.
+func (diff numbersDifference) Relative() float64 {
+	if diff.Magnitude == 0 {
+		return 0
+	}
+	return diff.Distance / diff.Magnitude
+}
+
+// compareNumbers matches the numbers by their positions.
+func compareNumbers(a, b map[string]float64) numbersDifference {
+	diff := numbersDifference{}
+	var sumA, sumB float64
+	for key, valA := range a {
+		sumA += math.Abs(valA)
+		valB, exists := b[key]
+		if !exists {
+			diff.OnlyA++
+			diff.Distance += math.Abs(valA)
+			continue
+		}
+		diff.Values++
+		if valA != valB {
+			diff.Different++
+			diff.Distance += math.Abs(valA - valB)
+		}
+	}
+	for key, valB := range b {
+		sumB += math.Abs(valB)
+		if _, exists := a[key]; !exists {
+			diff.OnlyB++
+			diff.Distance += math.Abs(valB)
+		}
+	}
+	diff.Magnitude = math.Max(sumA, sumB)
+	return diff
+}
+
+// flattenNumbers collects all the numbers inside an arbitrary value into the map from their
+// positions, e.g. "[3][5]" for a matrix cell, to the values. Strings and unexported fields
+// are ignored.
+func flattenNumbers(prefix string, value reflect.Value, result map[string]float64) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !value.IsNil() {
+			flattenNumbers(prefix, value.Elem(), result)
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if field := value.Type().Field(i); field.PkgPath == "" {
+				flattenNumbers(prefix+"."+field.Name, value.Field(i), result)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			flattenNumbers(fmt.Sprintf("%s[%d]", prefix, i), value.Index(i), result)
+		}
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			flattenNumbers(fmt.Sprintf("%s[%v]", prefix, key.Interface()), value.MapIndex(key), result)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		result[prefix] = float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		result[prefix] = float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		result[prefix] = value.Float()
+	case reflect.Bool:
+		if value.Bool() {
+			result[prefix] = 1
+		} else {
+			result[prefix] = 0
+		}
+	}
+}
+
+// compareFields compares each exported field of the two analysis results separately.
+// The results which are not structs are compared as a whole under the empty name.
+func compareFields(a, b interface{}) map[string]numbersDifference {
+	valA, valB := reflect.Indirect(reflect.ValueOf(a)), reflect.Indirect(reflect.ValueOf(b))
+	result := map[string]numbersDifference{}
+	if valA.Kind() != reflect.Struct || valA.Type() != valB.Type() {
+		numbersA, numbersB := map[string]float64{}, map[string]float64{}
+		flattenNumbers("", valA, numbersA)
+		flattenNumbers("", valB, numbersB)
+		result[""] = compareNumbers(numbersA, numbersB)
+		return result
+	}
+	for i := 0; i < valA.NumField(); i++ {
+		field := valA.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		numbersA, numbersB := map[string]float64{}, map[string]float64{}
+		flattenNumbers("", valA.Field(i), numbersA)
+		flattenNumbers("", valB.Field(i), numbersB)
+		if len(numbersA) > 0 || len(numbersB) > 0 {
+			result[field.Name] = compareNumbers(numbersA, numbersB)
+		}
+	}
+	return result
+}
+
+func printNumbersDifference(writer io.Writer, indent string, diff numbersDifference) {
+	fmt.Fprintf(writer, "%svalues: %d\n", indent, diff.Values)
+	fmt.Fprintf(writer, "%sdifferent: %d\n", indent, diff.Different)
+	fmt.Fprintf(writer, "%sonly_a: %d\n", indent, diff.OnlyA)
+	fmt.Fprintf(writer, "%sonly_b: %d\n", indent, diff.OnlyB)
+	fmt.Fprintf(writer, "%sdistance: %g\n", indent, diff.Distance)
+	fmt.Fprintf(writer, "%srelative: %.6f\n", indent, diff.Relative())
+}
+
+// printResultsDifference writes the comparison of the analyses which exist in both runs in YAML.
+func printResultsDifference(writer io.Writer, a, b map[string]interface{}) {
+	var common, onlyA, onlyB []string
+	for key := range a {
+		if _, exists := b[key]; exists {
+			common = append(common, key)
+		} else {
+			onlyA = append(onlyA, key)
+		}
+	}
+	for key := range b {
+		if _, exists := a[key]; !exists {
+			onlyB = append(onlyB, key)
+		}
+	}
+	sort.Strings(common)
+	sort.Strings(onlyA)
+	sort.Strings(onlyB)
+	fmt.Fprintf(writer, "  only_a: [%s]\n", joinYamlStrings(onlyA))
+	fmt.Fprintf(writer, "  only_b: [%s]\n", joinYamlStrings(onlyB))
+	fmt.Fprintln(writer, "  analyses:")
+	for _, key := range common {
+		fields := compareFields(a[key], b[key])
+		total := numbersDifference{}
+		names := make([]string, 0, len(fields))
+		for name, diff := range fields {
+			names = append(names, name)
+			total.Values += diff.Values
+			total.Different += diff.Different
+			total.OnlyA += diff.OnlyA
+			total.OnlyB += diff.OnlyB
+			total.Distance += diff.Distance
+			total.Magnitude += diff.Magnitude
+		}
+		sort.Strings(names)
+		fmt.Fprintf(writer, "    %s:\n", hercules.SafeYamlString(key))
+		printNumbersDifference(writer, "      ", total)
+		if len(names) == 1 && names[0] == "" {
+			continue
+		}
+		fmt.Fprintln(writer, "      fields:")
+		for _, name := range names {
+			fmt.Fprintf(writer, "        %s:\n", name)
+			printNumbersDifference(writer, "          ", fields[name])
+		}
+	}
+}
+
+func joinYamlStrings(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = hercules.SafeYamlString(item)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func init() {
+	rootCmd.AddCommand(compareParamsCmd)
+	compareParamsCmd.SetUsageFunc(compareParamsCmd.UsageFunc())
+	compareParamsCmd.Flags().StringSlice("runs", nil,
+		"Two binary analysis results to compare, separated by a comma.")
+	compareParamsCmd.MarkFlagRequired("runs")
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCompareResult struct {
+	Matrix  [][]int64
+	Dict    []string
+	Weights map[int]float32
+	Enabled bool
+	hidden  int
+}
+
+func TestCompareNumbers(t *testing.T) {
+	diff := compareNumbers(
+		map[string]float64{"a": 1, "b": 2, "c": 3},
+		map[string]float64{"a": 1, "b": -2, "d": 4})
+	assert.Equal(t, diff, numbersDifference{
+		Values: 2, Different: 1, OnlyA: 1, OnlyB: 1, Distance: 11, Magnitude: 7})
+	assert.InDelta(t, diff.Relative(), 11.0/7, 1e-9)
+	assert.Equal(t, compareNumbers(nil, nil).Relative(), 0.0)
+}
+
+func TestFlattenNumbers(t *testing.T) {
+	result := map[string]float64{}
+	flattenNumbers("", reflect.ValueOf(&testCompareResult{
+		Matrix: [][]int64{{1, 2}, {3}}, Dict: []string{"x"}, Weights: map[int]float32{7: 0.5},
+		Enabled: true, hidden: 10}), result)
+	assert.Equal(t, result, map[string]float64{
+		".Matrix[0][0]": 1, ".Matrix[0][1]": 2, ".Matrix[1][0]": 3, ".Weights[7]": 0.5,
+		".Enabled": 1})
+}
+
+func TestPrintResultsDifference(t *testing.T) {
+	a := map[string]interface{}{
+		"Burndown": testCompareResult{Matrix: [][]int64{{10, 0}, {5, 5}}},
+		"Couples":  []int{1, 2},
+		"Shotness": 1,
+	}
+	b := map[string]interface{}{
+		"Burndown": testCompareResult{Matrix: [][]int64{{10, 0}, {3, 5}}, Enabled: true},
+		"Couples":  []int{1, 2},
+		"Devs":     1,
+	}
+	buffer := &bytes.Buffer{}
+	printResultsDifference(buffer, a, b)
+	assert.Equal(t, buffer.String(), `  only_a: ["Shotness"]
+  only_b: ["Devs"]
+  analyses:
+    "Burndown":
+      values: 5
+      different: 2
+      only_a: 0
+      only_b: 0
+      distance: 3
+      relative: 0.142857
+      fields:
+        Enabled:
+          values: 1
+          different: 1
+          only_a: 0
+          only_b: 0
+          distance: 1
+          relative: 1.000000
+        Matrix:
+          values: 4
+          different: 1
+          only_a: 0
+          only_b: 0
+          distance: 2
+          relative: 0.100000
+    "Couples":
+      values: 2
+      different: 0
+      only_a: 0
+      only_b: 0
+      distance: 0
+      relative: 0.000000
+`)
+}
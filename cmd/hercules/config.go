@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+)
+
+// envPrefix is the prefix of the environment variables which set the command line flags.
+// For example, HERCULES_BURNDOWN_PEOPLE=true is the same as --burndown-people=true.
+const envPrefix = "HERCULES_"
+
+// configEnvName is the environment variable with the path to the configuration file,
+// the alternative to --config.
+const configEnvName = envPrefix + "CONFIG"
+
+// loadConfig reads the configuration file which has the same structure as a preset:
+//
+//	plugins: [/opt/hercules/churn_analysis.so]
+//	flags:
+//	  burndown: true
+//	  granularity: 7
+//	  feature: [uast]
+//
+// The format is TOML if the file extension is ".toml" and YAML otherwise.
+func loadConfig(path string) (*preset, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	result := &preset{}
+	if strings.ToLower(filepath.Ext(path)) == ".toml" {
+		err = toml.Unmarshal(data, result)
+	} else {
+		err = yaml.Unmarshal(data, result)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return result, nil
+}
+
+// envPreset converts HERCULES_* environment variables to the preset. The variables which do not
+// correspond to any flag are ignored. The values of repeatable flags are separated by commas.
+func envPreset(flags *pflag.FlagSet, environ []string) *preset {
+	result := &preset{Flags: map[string]interface{}{}}
+	for _, pair := range environ {
+		if !strings.HasPrefix(pair, envPrefix) {
+			continue
+		}
+		eq := strings.Index(pair, "=")
+		if eq < 0 {
+			continue
+		}
+		name := strings.ToLower(strings.Replace(pair[len(envPrefix):eq], "_", "-", -1))
+		value := pair[eq+1:]
+		flag := flags.Lookup(name)
+		if flag == nil || name == "config" {
+			continue
+		}
+		if flag.Value.Type() == "string" {
+			result.Flags[name] = value
+			continue
+		}
+		var list []interface{}
+		for _, item := range strings.Split(value, ",") {
+			list = append(list, item)
+		}
+		result.Flags[name] = list
+	}
+	return result
+}
+
+// presetName returns the name of the preset to apply with the same precedence as for the other
+// flags: --preset, HERCULES_PRESET and the "preset" flag in the configuration file.
+// `config` may be nil.
+func presetName(flags *pflag.FlagSet, environ []string, config *preset) string {
+	if flag := flags.Lookup("preset"); flag != nil && flag.Changed {
+		return flag.Value.String()
+	}
+	if name, exists := envPreset(flags, environ).Flags["preset"]; exists {
+		return fmt.Sprint(name)
+	}
+	if config != nil {
+		if name, exists := config.Flags["preset"]; exists {
+			return fmt.Sprint(name)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpdir)
+	path := filepath.Join(tmpdir, "config.yml")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(
+		"plugins: [/tmp/plugin.so]\nflags:\n  burndown: true\n  languages: [go]\n"), 0666))
+	config, err := loadConfig(path)
+	assert.Nil(t, err)
+	assert.Equal(t, config.Plugins, []string{"/tmp/plugin.so"})
+	assert.Len(t, config.Flags, 2)
+	assert.Equal(t, config.Flags["burndown"], true)
+	path = filepath.Join(tmpdir, "config.toml")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(
+		"plugins = [\"/tmp/plugin.so\"]\n[flags]\ngranularity = 7\nlanguages = [\"go\"]\n"), 0666))
+	config, err = loadConfig(path)
+	assert.Nil(t, err)
+	assert.Equal(t, config.Plugins, []string{"/tmp/plugin.so"})
+	assert.Len(t, config.Flags, 2)
+	assert.Equal(t, config.Flags["granularity"], int64(7))
+	assert.Nil(t, ioutil.WriteFile(path, []byte("[flags"), 0666))
+	_, err = loadConfig(path)
+	assert.NotNil(t, err)
+	_, err = loadConfig(filepath.Join(tmpdir, "missing.yml"))
+	assert.NotNil(t, err)
+}
+
+func TestEnvPreset(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	burndownPeople := flags.Bool("burndown-people", false, "")
+	granularity := flags.Int("granularity", 30, "")
+	commits := flags.String("commits", "", "")
+	languages := flags.StringSlice("languages", []string{"all"}, "")
+	flags.String("config", "", "")
+	assert.Nil(t, flags.Parse([]string{"--granularity", "14"}))
+	env := envPreset(flags, []string{
+		"HOME=/root",
+		"HERCULES_BURNDOWN_PEOPLE=true",
+		"HERCULES_GRANULARITY=7",
+		"HERCULES_COMMITS=a,b.txt",
+		"HERCULES_LANGUAGES=go,python",
+		"HERCULES_CONFIG=/tmp/config.yml",
+		"HERCULES_UNKNOWN=1",
+	})
+	assert.Len(t, env.Flags, 4)
+	assert.Nil(t, env.apply(flags))
+	assert.True(t, *burndownPeople)
+	assert.Equal(t, *granularity, 14)
+	assert.Equal(t, *commits, "a,b.txt")
+	assert.Equal(t, *languages, []string{"go", "python"})
+}
+
+func TestPresetName(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("preset", "", "")
+	config := &preset{Flags: map[string]interface{}{"preset": "monthly"}}
+	assert.Equal(t, presetName(flags, nil, nil), "")
+	assert.Equal(t, presetName(flags, nil, config), "monthly")
+	environ := []string{"HERCULES_PRESET=weekly"}
+	assert.Equal(t, presetName(flags, environ, config), "weekly")
+	assert.Nil(t, flags.Parse([]string{"--preset", "research"}))
+	assert.Equal(t, presetName(flags, environ, config), "research")
+}
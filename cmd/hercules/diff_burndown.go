@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/src-d/hercules.v4"
+	"gopkg.in/src-d/hercules.v4/leaves"
+)
+
+// diffBurndownCmd represents the diff-burndown command
+var diffBurndownCmd = &cobra.Command{
+	Use:   "diff-burndown <old.pb> <new.pb>",
+	Short: "Compare the final states of two burndown results band by band.",
+	Long: `The results must be saved with --burndown --pb, e.g. at two different releases. The last
+samples are aligned to the same calendar bands which start at the earliest of the beginnings;
+the bands of different granularities are regrouped assuming that the lines are spread uniformly.
+The number of alive lines in each band in both results and the difference are printed in YAML
+together with the totals of the retired and the added lines. The directories and the languages
+are compared as well if both results contain them.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		granularity, _ := cmd.Flags().GetInt("granularity")
+		var repos []string
+		allErrors := map[string][]string{}
+		var burndowns [2]leaves.BurndownResult
+		var commons [2]*hercules.CommonAnalysisResult
+		for i, fileName := range args {
+			var results map[string]interface{}
+			results, commons[i], allErrors[fileName] = loadMessage(fileName, &repos)
+			if commons[i] == nil {
+				printErrors(allErrors)
+				os.Exit(1)
+			}
+			var exists bool
+			burndowns[i], exists = results["Burndown"].(leaves.BurndownResult)
+			if !exists {
+				printErrors(allErrors)
+				log.Fatalf("%s does not contain the burndown, run with --burndown", fileName)
+			}
+		}
+		printErrors(allErrors)
+		if repos[0] != repos[1] {
+			log.Printf("warning: comparing different repositories: %s and %s", repos[0], repos[1])
+		}
+		diff := leaves.DiffBurndown(burndowns[0], burndowns[1], commons[0], commons[1], granularity)
+		writeBurndownDiff(os.Stdout, args[0], args[1], diff)
+	},
+}
+
+// writeBurndownDiff prints the aligned burndown results and their difference in YAML.
+func writeBurndownDiff(writer io.Writer, oldName, newName string, diff leaves.BurndownDiff) {
+	fmt.Fprintln(writer, "diff_burndown:")
+	fmt.Fprintf(writer, "  old: %s\n", hercules.SafeYamlString(oldName))
+	fmt.Fprintf(writer, "  new: %s\n", hercules.SafeYamlString(newName))
+	fmt.Fprintf(writer, "  begin: %d\n", diff.BeginTime)
+	fmt.Fprintf(writer, "  granularity: %d\n", diff.Granularity)
+	writeBurndownBandsDiff(writer, "  ", diff.BeginTime, diff.Granularity, diff.Global)
+	for _, section := range []struct {
+		name  string
+		diffs map[string]leaves.BurndownBandsDiff
+	}{{"directories", diff.Directories}, {"languages", diff.Languages}} {
+		if len(section.diffs) == 0 {
+			continue
+		}
+		fmt.Fprintf(writer, "  %s:\n", section.name)
+		keys := make([]string, 0, len(section.diffs))
+		for key := range section.diffs {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(writer, "    %s:\n", hercules.SafeYamlString(key))
+			writeBurndownBandsDiff(writer, "      ", diff.BeginTime, diff.Granularity, section.diffs[key])
+		}
+	}
+}
+
+// writeBurndownBandsDiff prints the totals and the [old, new, delta] triples of each band
+// keyed by the date of the band's beginning.
+func writeBurndownBandsDiff(writer io.Writer, indent string, begin int64, granularity int,
+	diff leaves.BurndownBandsDiff) {
+	var oldTotal, newTotal int64
+	for i := range diff.Old {
+		oldTotal += diff.Old[i]
+		newTotal += diff.New[i]
+	}
+	fmt.Fprintf(writer, "%stotal: [%d, %d]\n", indent, oldTotal, newTotal)
+	fmt.Fprintf(writer, "%sretired: %d\n", indent, diff.Retired)
+	fmt.Fprintf(writer, "%sadded: %d\n", indent, diff.Added)
+	fmt.Fprintf(writer, "%sbands:\n", indent)
+	for i, delta := range diff.Delta() {
+		date := time.Unix(begin+int64(i*granularity)*86400, 0).UTC().Format("2006-01-02")
+		fmt.Fprintf(writer, "%s  \"%s\": [%d, %d, %d]\n", indent, date, diff.Old[i], diff.New[i], delta)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(diffBurndownCmd)
+	diffBurndownCmd.SetUsageFunc(diffBurndownCmd.UsageFunc())
+	diffBurndownCmd.Flags().Int("granularity", 0,
+		"Size of the common bands in days; defaults to the largest of the two granularities.")
+}
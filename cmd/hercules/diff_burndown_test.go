@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v4/leaves"
+)
+
+func TestWriteBurndownDiff(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	writeBurndownDiff(buffer, "old.pb", "new.pb", leaves.BurndownDiff{
+		BeginTime:   1500000000,
+		Granularity: 30,
+		Global: leaves.BurndownBandsDiff{
+			Old: []int64{80, 60}, New: []int64{0, 90}, Retired: 80, Added: 30},
+		Languages: map[string]leaves.BurndownBandsDiff{
+			"Python": {Old: []int64{0, 10}, New: []int64{0, 0}, Retired: 10},
+			"Go":     {Old: []int64{80, 50}, New: []int64{0, 90}, Retired: 80, Added: 40},
+		},
+	})
+	assert.Equal(t, buffer.String(), `diff_burndown:
+  old: "old.pb"
+  new: "new.pb"
+  begin: 1500000000
+  granularity: 30
+  total: [140, 90]
+  retired: 80
+  added: 30
+  bands:
+    "2017-07-14": [80, 0, -80]
+    "2017-08-13": [60, 90, 30]
+  languages:
+    "Go":
+      total: [130, 90]
+      retired: 80
+      added: 40
+      bands:
+        "2017-07-14": [80, 0, -80]
+        "2017-08-13": [50, 90, 40]
+    "Python":
+      total: [10, 0]
+      retired: 10
+      added: 0
+      bands:
+        "2017-07-14": [0, 0, 0]
+        "2017-08-13": [10, 0, -10]
+`)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/spf13/cobra"
+	"gopkg.in/src-d/hercules.v4"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	"gopkg.in/src-d/hercules.v4/leaves"
+)
+
+// graphKinds map the values of --kind to the analyses which contain the corresponding graphs.
+var graphKinds = map[string]string{
+	"files":       "Couples",
+	"directories": "Couples",
+	"people":      "Couples",
+	"shotness":    "Shotness",
+}
+
+// graphCmd represents the graph command
+var graphCmd = &cobra.Command{
+	Use:   "graph <result.pb>",
+	Short: "Export the couples as a weighted graph for Graphviz or Gephi.",
+	Long: `The result must be saved with --pb and contain --couples or --shotness. The nodes are
+weighted by the number of their changes and the edges by the number of the common changes.
+--kind selects the graph: the files, the directories (--couples-directory-depth), the people or
+the structural units (--shotness). --format is one of dot, gexf or graphml.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		kind, _ := cmd.Flags().GetString("kind")
+		buffer, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			log.Fatalf("Cannot read %s: %v", args[0], err)
+		}
+		graph, err := loadGraph(buffer, kind)
+		if err != nil {
+			log.Fatalf("%s: %v", args[0], err)
+		}
+		if err = graph.Write(os.Stdout, format); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+// loadGraph extracts the graph of the specified kind from the serialized AnalysisResults.
+func loadGraph(buffer []byte, kind string) (leaves.Graph, error) {
+	item, exists := graphKinds[kind]
+	if !exists {
+		return leaves.Graph{}, fmt.Errorf("unsupported graph kind: %s", kind)
+	}
+	message := pb.AnalysisResults{}
+	if err := proto.Unmarshal(buffer, &message); err != nil {
+		return leaves.Graph{}, err
+	}
+	contents, exists := message.Contents[item]
+	if !exists {
+		return leaves.Graph{}, fmt.Errorf("there is no %s, run with --%s", item, strings.ToLower(item))
+	}
+	deserialized, err := hercules.Registry.Summon(item)[0].(interface {
+		Deserialize(pbmessage []byte) (interface{}, error)
+	}).Deserialize(contents)
+	if err != nil {
+		return leaves.Graph{}, err
+	}
+	switch kind {
+	case "files":
+		return deserialized.(leaves.CouplesResult).FilesGraph(), nil
+	case "directories":
+		result := deserialized.(leaves.CouplesResult)
+		if result.DirectoriesMatrix == nil {
+			return leaves.Graph{}, fmt.Errorf("there are no directory couples, " +
+				"run with --couples-directory-depth")
+		}
+		return result.DirectoriesGraph(), nil
+	case "people":
+		return deserialized.(leaves.CouplesResult).PeopleGraph(), nil
+	default:
+		return deserialized.(leaves.ShotnessResult).Graph(), nil
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+	graphCmd.SetUsageFunc(graphCmd.UsageFunc())
+	graphCmd.Flags().String("format", "dot",
+		"Output format: "+strings.Join(leaves.GraphFormats, ", ")+".")
+	graphCmd.Flags().String("kind", "files",
+		"Which graph to export: files, directories, people or shotness.")
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	"gopkg.in/src-d/hercules.v4/leaves"
+)
+
+func TestLoadGraph(t *testing.T) {
+	couples := &leaves.CouplesAnalysis{}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, couples.Serialize(leaves.CouplesResult{
+		PeopleMatrix: []map[int]int64{{}},
+		Files:        []string{"a.go", "b.go"},
+		FilesMatrix:  []map[int]int64{{0: 2, 1: 1}, {0: 1, 1: 1}},
+	}, true, buffer))
+	message := pb.AnalysisResults{
+		Header:   &pb.Metadata{},
+		Contents: map[string][]byte{"Couples": buffer.Bytes()},
+	}
+	serialized, err := proto.Marshal(&message)
+	assert.Nil(t, err)
+	graph, err := loadGraph(serialized, "files")
+	assert.Nil(t, err)
+	assert.Equal(t, graph.Nodes, []leaves.GraphNode{{Label: "a.go", Weight: 2}, {Label: "b.go", Weight: 1}})
+	assert.Equal(t, graph.Edges, []leaves.GraphEdge{{Source: 0, Target: 1, Weight: 1}})
+	_, err = loadGraph(serialized, "directories")
+	assert.NotNil(t, err)
+	_, err = loadGraph(serialized, "shotness")
+	assert.NotNil(t, err)
+	_, err = loadGraph(serialized, "lines")
+	assert.NotNil(t, err)
+	_, err = loadGraph([]byte("garbage"), "files")
+	assert.NotNil(t, err)
+}
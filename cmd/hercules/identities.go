@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+	"gopkg.in/src-d/hercules.v4"
+)
+
+// identitiesCmd groups the commands which deal with the people dictionary.
+var identitiesCmd = &cobra.Command{
+	Use:   "identities",
+	Short: "Curate the developer identities.",
+	Long:  ``,
+}
+
+// identitiesReviewCmd represents the identities review command
+var identitiesReviewCmd = &cobra.Command{
+	Use:   "review <repository> [cache]",
+	Short: "Interactively review the automatically merged identities and write the people dictionary.",
+	Long: `Hercules merges the commit signatures which share the email or the name into the same
+developer. This command shows every such merge and asks to accept, reject or edit it. The result
+is written in the format which --people-dict expects.`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := cmd.Flags()
+		output, _ := flags.GetString("output")
+		firstParent, _ := flags.GetBool("first-parent")
+		disableStatus, _ := flags.GetBool("quiet")
+		cachePath := ""
+		if len(args) == 2 {
+			cachePath = args[1]
+		}
+		repository := loadRepository(args[0], cachePath, disableStatus)
+		commits, err := hercules.NewPipeline(repository).Commits(firstParent)
+		if err != nil {
+			log.Fatalf("failed to list the commits: %v", err)
+		}
+		people := reviewIdentities(hercules.ProposeIdentities(commits), os.Stdin, os.Stdout)
+		err = ioutil.WriteFile(output, []byte(strings.Join(people, "\n")+"\n"), 0666)
+		if err != nil {
+			log.Fatalf("failed to write %s: %v", output, err)
+		}
+		fmt.Printf("Wrote %d identities to %s\n", len(people), output)
+	},
+}
+
+// reviewIdentities asks about every merged identity and returns the lines of the people
+// dictionary. The identities which consist of a single signature are accepted without asking,
+// and so are the rest of the identities when the input ends.
+func reviewIdentities(
+	proposals []hercules.IdentityProposal, input io.Reader, output io.Writer) []string {
+	merged := 0
+	for _, proposal := range proposals {
+		if proposal.Merged() {
+			merged++
+		}
+	}
+	scanner := bufio.NewScanner(input)
+	var result []string
+	acceptAll := false
+	index := 0
+	for _, proposal := range proposals {
+		if !proposal.Merged() || acceptAll {
+			result = append(result, proposal.Identity)
+			continue
+		}
+		index++
+		fmt.Fprintf(output, "\n[%d/%d] %s\n", index, merged, proposal.Identity)
+		for _, sig := range proposal.Signatures {
+			fmt.Fprintf(output, "  %s <%s>: %d commits\n", sig.Name, sig.Email, sig.Commits)
+		}
+		for answered := false; !answered; {
+			fmt.Fprint(output, "[a]ccept, [r]eject, [e]dit, accept [A]ll the rest? ")
+			if !scanner.Scan() {
+				acceptAll = true
+				result = append(result, proposal.Identity)
+				break
+			}
+			answered = true
+			switch strings.TrimSpace(scanner.Text()) {
+			case "", "a":
+				result = append(result, proposal.Identity)
+			case "r":
+				result = append(result, proposal.Separate()...)
+			case "e":
+				edited := readIdentities(proposal, scanner, output)
+				if len(edited) == 0 {
+					answered = false
+				}
+				result = append(result, edited...)
+			case "A":
+				acceptAll = true
+				result = append(result, proposal.Identity)
+			default:
+				answered = false
+			}
+		}
+	}
+	return result
+}
+
+// readIdentities reads the people dictionary lines until an empty line.
+func readIdentities(
+	proposal hercules.IdentityProposal, scanner *bufio.Scanner, output io.Writer) []string {
+	fmt.Fprintln(output, "Enter the identities, one per line, names and emails separated by |.")
+	fmt.Fprintln(output, "Finish with an empty line. Rejecting the merge would give:")
+	for _, line := range proposal.Separate() {
+		fmt.Fprintf(output, "  %s\n", line)
+	}
+	var result []string
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			break
+		}
+		result = append(result, line)
+	}
+	return result
+}
+
+func init() {
+	rootCmd.AddCommand(identitiesCmd)
+	identitiesCmd.AddCommand(identitiesReviewCmd)
+	identitiesReviewCmd.SetUsageFunc(identitiesReviewCmd.UsageFunc())
+	irFlags := identitiesReviewCmd.Flags()
+	irFlags.StringP("output", "o", "", "Path to the resulting people dictionary. Required.")
+	identitiesReviewCmd.MarkFlagRequired("output")
+	irFlags.Bool("first-parent", false, "Follow only the first parent in the commit history - "+
+		"\"git log --first-parent\".")
+	irFlags.Bool("quiet", !terminal.IsTerminal(int(os.Stdin.Fd())),
+		"Do not print status updates to stderr.")
+}
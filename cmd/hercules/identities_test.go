@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v4"
+)
+
+func fixtureIdentityProposals() []hercules.IdentityProposal {
+	return []hercules.IdentityProposal{
+		{Identity: "alice|alice@corp.com|release@corp.com", Signatures: []hercules.IdentitySignature{
+			{Name: "alice", Email: "alice@corp.com", Commits: 10},
+			{Name: "alice", Email: "release@corp.com", Commits: 1},
+		}},
+		{Identity: "bob|bob@corp.com", Signatures: []hercules.IdentitySignature{
+			{Name: "bob", Email: "bob@corp.com", Commits: 5},
+		}},
+		{Identity: "carol|caroline|carol@corp.com", Signatures: []hercules.IdentitySignature{
+			{Name: "carol", Email: "carol@corp.com", Commits: 3},
+			{Name: "caroline", Email: "carol@corp.com", Commits: 2},
+		}},
+		{Identity: "dave|dave@corp.com|dave@home.com", Signatures: []hercules.IdentitySignature{
+			{Name: "dave", Email: "dave@corp.com", Commits: 3},
+			{Name: "dave", Email: "dave@home.com", Commits: 2},
+		}},
+	}
+}
+
+func TestReviewIdentities(t *testing.T) {
+	output := &bytes.Buffer{}
+	people := reviewIdentities(fixtureIdentityProposals(), strings.NewReader(
+		"r\nx\ne\ncarol|carol@corp.com\ncaroline\n\nA\n"), output)
+	assert.Equal(t, people, []string{
+		"alice|alice@corp.com", "release@corp.com", "bob|bob@corp.com",
+		"carol|carol@corp.com", "caroline", "dave|dave@corp.com|dave@home.com"})
+	assert.Contains(t, output.String(), "[1/3] alice|alice@corp.com|release@corp.com\n")
+	assert.Contains(t, output.String(), "  caroline <carol@corp.com>: 2 commits\n")
+	assert.Contains(t, output.String(), "Rejecting the merge would give:\n  carol|caroline|carol@corp.com\n")
+}
+
+func TestReviewIdentitiesEOF(t *testing.T) {
+	people := reviewIdentities(fixtureIdentityProposals(), strings.NewReader("a\n"), &bytes.Buffer{})
+	assert.Equal(t, people, []string{
+		"alice|alice@corp.com|release@corp.com", "bob|bob@corp.com",
+		"carol|caroline|carol@corp.com", "dave|dave@corp.com|dave@home.com"})
+}
@@ -0,0 +1,388 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/user"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// pluginsDirEnvName is the environment variable which overrides the directory with
+// the installed plugins, ~/.hercules/plugins by default.
+const pluginsDirEnvName = envPrefix + "PLUGINS_DIR"
+
+// pluginManifestFileName is the name of the file in the plugins directory which lists
+// the installed plugins. They are loaded on every run as if they were passed with --plugin.
+const pluginManifestFileName = "manifest.yml"
+
+// installedPlugin is a record in the plugins manifest.
+type installedPlugin struct {
+	// Name is the import path of the plugin, e.g. github.com/org/hercules-foo.
+	Name string `yaml:"name"`
+	// Path is the path to the shared library.
+	Path string `yaml:"path"`
+	// SHA256 is the checksum of the shared library, it is verified before loading.
+	SHA256 string `yaml:"sha256"`
+	// Source is the URL of the downloaded library or "build" if it was built from source.
+	Source string `yaml:"source"`
+	// Installed is the time of the installation in RFC 3339.
+	Installed string `yaml:"installed"`
+}
+
+type pluginManifest struct {
+	Plugins []*installedPlugin `yaml:"plugins"`
+}
+
+// pluginsDir returns the directory with the installed plugins and the manifest.
+func pluginsDir() (string, error) {
+	if dir := os.Getenv(pluginsDirEnvName); dir != "" {
+		return dir, nil
+	}
+	usr, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(usr.HomeDir, ".hercules", "plugins"), nil
+}
+
+// loadPluginManifest reads the manifest in the specified directory. It is empty if the file
+// does not exist.
+func loadPluginManifest(dir string) (*pluginManifest, error) {
+	manifest := &pluginManifest{}
+	data, err := ioutil.ReadFile(filepath.Join(dir, pluginManifestFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return manifest, nil
+		}
+		return nil, err
+	}
+	if err = yaml.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v",
+			filepath.Join(dir, pluginManifestFileName), err)
+	}
+	return manifest, nil
+}
+
+// save writes the manifest to the specified directory.
+func (manifest *pluginManifest) save(dir string) error {
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, pluginManifestFileName), data, 0666)
+}
+
+// add inserts the plugin or replaces the one with the same name.
+func (manifest *pluginManifest) add(plugin *installedPlugin) {
+	for i, existing := range manifest.Plugins {
+		if existing.Name == plugin.Name {
+			manifest.Plugins[i] = plugin
+			return
+		}
+	}
+	manifest.Plugins = append(manifest.Plugins, plugin)
+}
+
+// remove deletes the plugin with the specified name and returns it, or nil if there is no such.
+func (manifest *pluginManifest) remove(name string) *installedPlugin {
+	for i, existing := range manifest.Plugins {
+		if existing.Name == name {
+			manifest.Plugins = append(manifest.Plugins[:i], manifest.Plugins[i+1:]...)
+			return existing
+		}
+	}
+	return nil
+}
+
+// installedPluginPaths returns the paths to the shared libraries of the installed plugins
+// whose checksums match the manifest.
+func installedPluginPaths() []string {
+	dir, err := pluginsDir()
+	if err != nil {
+		return nil
+	}
+	manifest, err := loadPluginManifest(dir)
+	if err != nil {
+		log.Printf("Failed to read the installed plugins: %v\n", err)
+		return nil
+	}
+	var paths []string
+	for _, plugin := range manifest.Plugins {
+		checksum, err := fileChecksum(plugin.Path)
+		if err != nil {
+			log.Printf("Failed to verify plugin %s: %v\n", plugin.Name, err)
+			continue
+		}
+		if checksum != plugin.SHA256 {
+			log.Printf("Plugin %s was modified after the installation, skipped: "+
+				"sha256 %s != %s\n", plugin.Name, checksum, plugin.SHA256)
+			continue
+		}
+		paths = append(paths, plugin.Path)
+	}
+	return paths
+}
+
+// normalizePluginName converts the plugin reference to the import path.
+func normalizePluginName(name string) string {
+	for _, prefix := range []string{"https://", "http://"} {
+		name = strings.TrimPrefix(name, prefix)
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(name, "/"), ".git")
+}
+
+// pluginFileName returns the name of the prebuilt shared library for the current platform,
+// e.g. hercules-foo_linux_amd64.so.
+func pluginFileName(name string) string {
+	return fmt.Sprintf("%s_%s_%s.%s", path.Base(name), runtime.GOOS, runtime.GOARCH,
+		ShlibExts[runtime.GOOS])
+}
+
+// defaultPluginURL returns the URL of the prebuilt shared library attached to the latest
+// GitHub release of the plugin.
+func defaultPluginURL(name string) string {
+	return "https://" + name + "/releases/latest/download/" + pluginFileName(name)
+}
+
+// fileChecksum returns the hex SHA-256 of the file.
+func fileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// fetchChecksum downloads the checksum file in the sha256sum format.
+func fetchChecksum(client *http.Client, url string) (string, error) {
+	response, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GET %s: %s", url, response.Status)
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(response.Body, 1<<16))
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("%s is empty", url)
+	}
+	return strings.ToLower(fields[0]), nil
+}
+
+// downloadPlugin saves the shared library from `url` to `target` and checks its SHA-256.
+// If `checksum` is empty, it is read from `url` + ".sha256". Returns the actual checksum.
+func downloadPlugin(client *http.Client, url, checksum, target string) (string, error) {
+	if checksum == "" {
+		var err error
+		checksum, err = fetchChecksum(client, url+".sha256")
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch the checksum, specify it with --sha256: %v", err)
+		}
+	}
+	response, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GET %s: %s", url, response.Status)
+	}
+	temp, err := ioutil.TempFile(filepath.Dir(target), ".download-")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(temp.Name())
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(temp, hash), response.Body)
+	temp.Close()
+	if err != nil {
+		return "", err
+	}
+	actual := hex.EncodeToString(hash.Sum(nil))
+	if actual != strings.ToLower(checksum) {
+		return "", fmt.Errorf("checksum mismatch for %s: sha256 %s != %s", url, actual, checksum)
+	}
+	if err = os.Chmod(temp.Name(), 0755); err != nil {
+		return "", err
+	}
+	return actual, os.Rename(temp.Name(), target)
+}
+
+// buildPlugin compiles the plugin from source with the Go toolchain. The plugin must be built
+// with the same Go version and the same versions of the shared packages as hercules.
+func buildPlugin(name, target string) (string, error) {
+	for _, args := range [][]string{
+		{"get", "-d", name},
+		{"build", "-buildmode=plugin", "-o", target, name},
+	} {
+		cmd := exec.Command("go", args...)
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return "", fmt.Errorf("go %s: %v", strings.Join(args, " "), err)
+		}
+	}
+	return fileChecksum(target)
+}
+
+// installPlugin downloads or builds the plugin to `dir` and records it in the manifest.
+func installPlugin(client *http.Client, dir, name, url, checksum string, build bool) (
+	*installedPlugin, error) {
+	name = normalizePluginName(name)
+	if name == "" {
+		return nil, errors.New("the plugin name is empty")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	manifest, err := loadPluginManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	plugin := &installedPlugin{
+		Name:      name,
+		Path:      filepath.Join(dir, pluginFileName(name)),
+		Installed: time.Now().UTC().Format(time.RFC3339),
+	}
+	if build {
+		plugin.Source = "build"
+		plugin.SHA256, err = buildPlugin(name, plugin.Path)
+	} else {
+		if url == "" {
+			url = defaultPluginURL(name)
+		}
+		plugin.Source = url
+		plugin.SHA256, err = downloadPlugin(client, url, checksum, plugin.Path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	manifest.add(plugin)
+	return plugin, manifest.save(dir)
+}
+
+// pluginCmd groups the commands which manage the installed plugins.
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Manage the installed plugins which are loaded automatically.",
+	Long:  ``,
+}
+
+// pluginInstallCmd represents the plugin install command
+var pluginInstallCmd = &cobra.Command{
+	Use:   "install <import path>",
+	Short: "Download or build the plugin and load it automatically in the subsequent runs.",
+	Long: `By default, the prebuilt shared library for the current platform is downloaded from the
+latest GitHub release of the plugin, e.g. for github.com/org/hercules-foo on Linux x86-64:
+
+  https://github.com/org/hercules-foo/releases/latest/download/hercules-foo_linux_amd64.so
+
+Its SHA-256 is checked against --sha256 or the .sha256 file next to it. --build compiles
+the plugin from source instead, which requires the same Go version as hercules. The installed
+plugins are recorded in the manifest in ~/.hercules/plugins or $` + pluginsDirEnvName + `
+and are skipped if their checksums change.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := cmd.Flags()
+		url, _ := flags.GetString("url")
+		checksum, _ := flags.GetString("sha256")
+		build, _ := flags.GetBool("build")
+		dir, err := pluginsDir()
+		if err != nil {
+			log.Fatalf("failed to locate the plugins directory: %v", err)
+		}
+		plugin, err := installPlugin(http.DefaultClient, dir, args[0], url, checksum, build)
+		if err != nil {
+			log.Fatalf("failed to install %s: %v", args[0], err)
+		}
+		fmt.Printf("Installed %s to %s\n", plugin.Name, plugin.Path)
+	},
+}
+
+// pluginListCmd represents the plugin list command
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Print the installed plugins.",
+	Long:  ``,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, err := pluginsDir()
+		if err != nil {
+			log.Fatalf("failed to locate the plugins directory: %v", err)
+		}
+		manifest, err := loadPluginManifest(dir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, plugin := range manifest.Plugins {
+			fmt.Printf("%s\t%s\t%s\n", plugin.Name, plugin.Path, plugin.Source)
+		}
+	},
+}
+
+// pluginRemoveCmd represents the plugin remove command
+var pluginRemoveCmd = &cobra.Command{
+	Use:   "remove <import path>",
+	Short: "Delete the installed plugin.",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, err := pluginsDir()
+		if err != nil {
+			log.Fatalf("failed to locate the plugins directory: %v", err)
+		}
+		manifest, err := loadPluginManifest(dir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		plugin := manifest.remove(normalizePluginName(args[0]))
+		if plugin == nil {
+			log.Fatalf("plugin %s is not installed", args[0])
+		}
+		if err = manifest.save(dir); err != nil {
+			log.Fatal(err)
+		}
+		if err = os.Remove(plugin.Path); err != nil && !os.IsNotExist(err) {
+			log.Fatal(err)
+		}
+		fmt.Printf("Removed %s\n", plugin.Name)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pluginCmd)
+	pluginCmd.AddCommand(pluginInstallCmd, pluginListCmd, pluginRemoveCmd)
+	pluginInstallCmd.SetUsageFunc(pluginInstallCmd.UsageFunc())
+	piFlags := pluginInstallCmd.Flags()
+	piFlags.String("url", "", "Download the shared library from this URL instead of "+
+		"the latest GitHub release.")
+	piFlags.String("sha256", "", "Expected SHA-256 of the shared library. By default, it is "+
+		"downloaded from the same URL with the .sha256 suffix.")
+	piFlags.Bool("build", false, "Build the plugin from source with the Go toolchain "+
+		"instead of downloading.")
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginNames(t *testing.T) {
+	assert.Equal(t, normalizePluginName("https://github.com/org/hercules-foo.git/"),
+		"github.com/org/hercules-foo")
+	assert.Equal(t, normalizePluginName("github.com/org/hercules-foo"),
+		"github.com/org/hercules-foo")
+	fileName := "hercules-foo_" + runtime.GOOS + "_" + runtime.GOARCH + "." + ShlibExts[runtime.GOOS]
+	assert.Equal(t, pluginFileName("github.com/org/hercules-foo"), fileName)
+	assert.Equal(t, defaultPluginURL("github.com/org/hercules-foo"),
+		"https://github.com/org/hercules-foo/releases/latest/download/"+fileName)
+}
+
+func TestPluginManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	manifest, err := loadPluginManifest(dir)
+	assert.Nil(t, err)
+	assert.Len(t, manifest.Plugins, 0)
+	manifest.add(&installedPlugin{Name: "a", Path: "a.so"})
+	manifest.add(&installedPlugin{Name: "b", Path: "b.so"})
+	manifest.add(&installedPlugin{Name: "a", Path: "a2.so"})
+	assert.Nil(t, manifest.save(dir))
+	manifest, err = loadPluginManifest(dir)
+	assert.Nil(t, err)
+	assert.Len(t, manifest.Plugins, 2)
+	assert.Equal(t, manifest.Plugins[0].Path, "a2.so")
+	assert.Equal(t, manifest.remove("b").Path, "b.so")
+	assert.Nil(t, manifest.remove("b"))
+	assert.Len(t, manifest.Plugins, 1)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, pluginManifestFileName), []byte("{"), 0666))
+	_, err = loadPluginManifest(dir)
+	assert.NotNil(t, err)
+}
+
+func TestInstallPlugin(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	payload := []byte("shared library")
+	hash := sha256.Sum256(payload)
+	checksum := hex.EncodeToString(hash[:])
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/foo.so":
+			w.Write(payload)
+		case "/foo.so.sha256":
+			w.Write([]byte(checksum + "  foo.so\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	plugin, err := installPlugin(server.Client(), dir, "github.com/org/hercules-foo",
+		server.URL+"/foo.so", "", false)
+	assert.Nil(t, err)
+	assert.Equal(t, plugin.Name, "github.com/org/hercules-foo")
+	assert.Equal(t, plugin.SHA256, checksum)
+	assert.Equal(t, plugin.Source, server.URL+"/foo.so")
+	data, err := ioutil.ReadFile(plugin.Path)
+	assert.Nil(t, err)
+	assert.Equal(t, data, payload)
+
+	os.Setenv(pluginsDirEnvName, dir)
+	defer os.Unsetenv(pluginsDirEnvName)
+	assert.Equal(t, installedPluginPaths(), []string{plugin.Path})
+	assert.Nil(t, ioutil.WriteFile(plugin.Path, []byte("tampered"), 0755))
+	assert.Len(t, installedPluginPaths(), 0)
+
+	_, err = installPlugin(server.Client(), dir, "github.com/org/hercules-bar",
+		server.URL+"/foo.so", "0000", false)
+	assert.Contains(t, err.Error(), "checksum mismatch")
+	_, err = os.Stat(filepath.Join(dir, pluginFileName("github.com/org/hercules-bar")))
+	assert.True(t, os.IsNotExist(err))
+	_, err = installPlugin(server.Client(), dir, "github.com/org/hercules-bar",
+		server.URL+"/bar.so", "", false)
+	assert.Contains(t, err.Error(), "--sha256")
+	manifest, err := loadPluginManifest(dir)
+	assert.Nil(t, err)
+	assert.Len(t, manifest.Plugins, 1)
+}
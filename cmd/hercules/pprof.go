@@ -0,0 +1,19 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	_ "net/http/pprof" // registers /debug/pprof handlers in http.DefaultServeMux
+)
+
+// servePprof starts the net/http/pprof server in the background. The address is taken in the
+// host:port format, e.g. ":6060"; the port 0 picks a random free port. Returns the actual address
+// the server listens on.
+func servePprof(address string) (net.Addr, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	go http.Serve(listener, nil)
+	return listener.Addr(), nil
+}
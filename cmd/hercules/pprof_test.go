@@ -0,0 +1,29 @@
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServePprof(t *testing.T) {
+	addr, err := servePprof("127.0.0.1:0")
+	assert.Nil(t, err)
+	if err != nil {
+		assert.FailNow(t, "servePprof")
+	}
+	response, err := http.Get("http://" + addr.String() + "/debug/pprof/heap?debug=1")
+	assert.Nil(t, err)
+	if err != nil {
+		assert.FailNow(t, "http.Get")
+	}
+	defer response.Body.Close()
+	assert.Equal(t, response.StatusCode, http.StatusOK)
+	body, err := ioutil.ReadAll(response.Body)
+	assert.Nil(t, err)
+	assert.Contains(t, string(body), "heap profile")
+	_, err = servePprof(addr.String())
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+)
+
+// presetsFileName is the name of the YAML file with the named analysis presets.
+// It is looked up in the current directory (usually the analysed repository) and then
+// in the home directory. Example:
+//
+//	presets:
+//	  weekly:
+//	    flags:
+//	      burndown: true
+//	      burndown-people: true
+//	      granularity: 7
+//	      first-parent: true
+//	  research:
+//	    plugins: [/opt/hercules/churn_analysis.so]
+//	    flags:
+//	      churn: true
+//	      feature: [uast]
+const presetsFileName = ".hercules.yml"
+
+// preset is a named set of command line options.
+type preset struct {
+	// Flags map the command line flag names to their values. The flags which are
+	// explicitly set in the command line take precedence.
+	Flags map[string]interface{} `yaml:"flags"`
+	// Plugins are the paths to the plugins to load, the same as --plugin.
+	Plugins []string `yaml:"plugins"`
+}
+
+type presetsFile struct {
+	Presets map[string]*preset `yaml:"presets"`
+}
+
+// presetsPaths returns the paths to the files with presets in the lookup order.
+func presetsPaths() []string {
+	paths := []string{presetsFileName}
+	if usr, err := user.Current(); err == nil {
+		paths = append(paths, filepath.Join(usr.HomeDir, presetsFileName))
+	}
+	return paths
+}
+
+// loadPreset finds the preset with the specified name in the first file which defines it.
+func loadPreset(name string, paths []string) (*preset, error) {
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		file := presetsFile{}
+		if err = yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+		}
+		if result, exists := file.Presets[name]; exists && result != nil {
+			return result, nil
+		}
+	}
+	return nil, fmt.Errorf("preset \"%s\" was not found in %s", name, strings.Join(paths, ", "))
+}
+
+// apply sets the flags from the preset which were not specified in the command line.
+func (p *preset) apply(flags *pflag.FlagSet) error {
+	names := make([]string, 0, len(p.Flags))
+	for name := range p.Flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		flag := flags.Lookup(name)
+		if flag == nil {
+			return fmt.Errorf("unknown flag in the preset: %s", name)
+		}
+		if flag.Changed {
+			continue
+		}
+		var values []string
+		if list, isList := p.Flags[name].([]interface{}); isList {
+			for _, item := range list {
+				values = append(values, fmt.Sprint(item))
+			}
+		} else {
+			values = []string{fmt.Sprint(p.Flags[name])}
+		}
+		if flag.Value.Type() != "stringSlice" {
+			// repeatable flags such as --feature are set several times
+			for _, value := range values {
+				if err := flags.Set(name, value); err != nil {
+					return fmt.Errorf("invalid value of %s in the preset: %v", name, err)
+				}
+			}
+			continue
+		}
+		if err := flags.Set(name, strings.Join(values, ",")); err != nil {
+			return fmt.Errorf("invalid value of %s in the preset: %v", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPresets = `presets:
+  weekly:
+    flags:
+      burndown: true
+      granularity: 7
+      languages: [go, python]
+    plugins: [/tmp/plugin.so]
+  empty:
+`
+
+func TestLoadPreset(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpdir)
+	path1 := filepath.Join(tmpdir, "first.yml")
+	path2 := filepath.Join(tmpdir, "second.yml")
+	assert.Nil(t, ioutil.WriteFile(path2, []byte(testPresets), 0666))
+	p, err := loadPreset("weekly", []string{path1, path2})
+	assert.Nil(t, err)
+	assert.Equal(t, p.Plugins, []string{"/tmp/plugin.so"})
+	assert.Len(t, p.Flags, 3)
+	assert.Equal(t, p.Flags["burndown"], true)
+	_, err = loadPreset("empty", []string{path1, path2})
+	assert.NotNil(t, err)
+	_, err = loadPreset("monthly", []string{path1, path2})
+	assert.NotNil(t, err)
+	assert.Nil(t, ioutil.WriteFile(path1, []byte("presets:\n  weekly:\n    flags:\n      couples: true\n"), 0666))
+	p, err = loadPreset("weekly", []string{path1, path2})
+	assert.Nil(t, err)
+	assert.Len(t, p.Flags, 1)
+	assert.Nil(t, ioutil.WriteFile(path1, []byte("{"), 0666))
+	_, err = loadPreset("weekly", []string{path1, path2})
+	assert.NotNil(t, err)
+}
+
+func TestPresetApply(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	burndown := flags.Bool("burndown", false, "")
+	granularity := flags.Int("granularity", 30, "")
+	sampling := flags.Int("sampling", 30, "")
+	languages := flags.StringSlice("languages", []string{"all"}, "")
+	assert.Nil(t, flags.Parse([]string{"--granularity", "14"}))
+	p := &preset{Flags: map[string]interface{}{
+		"burndown":    true,
+		"granularity": 7,
+		"sampling":    7,
+		"languages":   []interface{}{"go", "python"},
+	}}
+	assert.Nil(t, p.apply(flags))
+	assert.True(t, *burndown)
+	assert.Equal(t, *granularity, 14)
+	assert.Equal(t, *sampling, 7)
+	assert.Equal(t, *languages, []string{"go", "python"})
+	p.Flags["xxx"] = 1
+	assert.NotNil(t, p.apply(flags))
+	delete(p.Flags, "xxx")
+	p.Flags["burndown"] = "maybe"
+	flags.Lookup("burndown").Changed = false
+	assert.NotNil(t, p.apply(flags))
+}
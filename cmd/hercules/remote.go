@@ -0,0 +1,44 @@
+package main
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+
+	"gopkg.in/src-d/go-git.v4"
+)
+
+// remoteStore is the temporary object store of a remote repository. It exists only during
+// the analysis and is deleted by Close().
+type remoteStore struct {
+	Repository *git.Repository
+	path       string
+}
+
+// Close deletes the object store from disk.
+func (store *remoteStore) Close() error {
+	return os.RemoveAll(store.path)
+}
+
+// streamRemoteRepository negotiates the objects reachable from the remote HEAD over the smart
+// protocol and writes the received packfile as is to a new temporary bare object store inside
+// `cacheDir`. Unlike the regular clone, there is no working tree, no tags and no other branches,
+// and the objects are neither unpacked nor loaded into memory: the analysis reads them lazily
+// from the packfile. The caller must Close() the store after the analysis.
+func streamRemoteRepository(uri string, cacheDir string, progress io.Writer) (*remoteStore, error) {
+	if err := os.MkdirAll(cacheDir, 0777); err != nil {
+		return nil, err
+	}
+	path, err := ioutil.TempDir(cacheDir, "hercules-remote-")
+	if err != nil {
+		return nil, err
+	}
+	repository, err := git.PlainClone(path, true, &git.CloneOptions{
+		URL: uri, SingleBranch: true, Tags: git.NoTags, Progress: progress,
+	})
+	if err != nil {
+		os.RemoveAll(path)
+		return nil, err
+	}
+	return &remoteStore{Repository: repository, path: path}, nil
+}
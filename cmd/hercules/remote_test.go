@@ -0,0 +1,62 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+func TestStreamRemoteRepository(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpdir)
+	origin, err := git.PlainInit(filepath.Join(tmpdir, "origin"), false)
+	assert.Nil(t, err)
+	worktree, err := origin.Worktree()
+	assert.Nil(t, err)
+	for _, content := range []string{"one", "two"} {
+		assert.Nil(t, ioutil.WriteFile(
+			filepath.Join(tmpdir, "origin", "file.txt"), []byte(content), 0666))
+		_, err := worktree.Add("file.txt")
+		assert.Nil(t, err)
+		_, err = worktree.Commit(content, &git.CommitOptions{Author: &object.Signature{
+			Name: "test", Email: "test@test.com", When: time.Now()}})
+		assert.Nil(t, err)
+	}
+	cacheDir := filepath.Join(tmpdir, "cache")
+	store, err := streamRemoteRepository("file://"+filepath.Join(tmpdir, "origin"), cacheDir, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Dir(store.path), cacheDir)
+	assert.True(t, strings.HasPrefix(filepath.Base(store.path), "hercules-remote-"))
+	head, err := store.Repository.Head()
+	assert.Nil(t, err)
+	originHead, _ := origin.Head()
+	assert.Equal(t, head.Hash(), originHead.Hash())
+	commits, err := store.Repository.Log(&git.LogOptions{From: head.Hash()})
+	assert.Nil(t, err)
+	count := 0
+	assert.Nil(t, commits.ForEach(func(*object.Commit) error {
+		count++
+		return nil
+	}))
+	assert.Equal(t, count, 2)
+	// no working tree
+	_, err = os.Stat(filepath.Join(store.path, "file.txt"))
+	assert.True(t, os.IsNotExist(err))
+	assert.Nil(t, store.Close())
+	_, err = os.Stat(store.path)
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = streamRemoteRepository("file://"+filepath.Join(tmpdir, "missing"), cacheDir, nil)
+	assert.NotNil(t, err)
+	files, err := ioutil.ReadDir(cacheDir)
+	assert.Nil(t, err)
+	assert.Len(t, files, 0)
+}
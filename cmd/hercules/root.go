@@ -2,16 +2,16 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
-	"net/http"
-	_ "net/http/pprof"
 	"os"
 	"path/filepath"
 	"plugin"
 	"runtime/pprof"
+	"sort"
 	"strings"
 	_ "unsafe" // for go:linkname
 
@@ -123,16 +123,61 @@ func (apf *arrayPluginFlags) Type() string {
 	return "path"
 }
 
+// cmdlinePreset is the preset chosen with --preset. It is nil if there is no such flag.
+var cmdlinePreset *preset
+
+// cmdlineConfig is the configuration file loaded with --config. It is nil if there is no such flag.
+var cmdlineConfig *preset
+
 func loadPlugins() {
 	pluginFlags := arrayPluginFlags{}
 	fs := pflag.NewFlagSet(os.Args[0], pflag.ContinueOnError)
 	fs.SetOutput(ioutil.Discard)
+	fs.ParseErrorsWhitelist.UnknownFlags = true
 	pluginFlagName := "plugin"
 	const pluginDesc = "Load the specified plugin by the full or relative path. " +
 		"Can be specified multiple times."
 	fs.Var(&pluginFlags, pluginFlagName, pluginDesc)
 	pflag.Var(&pluginFlags, pluginFlagName, pluginDesc)
+	presetFlagName := "preset"
+	presetDesc := fmt.Sprintf("Apply the named preset from %s in the current or the home "+
+		"directory. The flags which are specified explicitly take precedence. Same as %sPRESET.",
+		presetsFileName, envPrefix)
+	fs.String(presetFlagName, "", presetDesc)
+	pflag.String(presetFlagName, "", presetDesc)
+	var configPath string
+	configFlagName := "config"
+	configDesc := fmt.Sprintf("Read the flags from the specified YAML or TOML file. Same as "+
+		"%s. Precedence: command line, %s* environment variables, --config, --preset.",
+		configEnvName, envPrefix)
+	fs.StringVar(&configPath, configFlagName, os.Getenv(configEnvName), configDesc)
+	pflag.StringVar(&configPath, configFlagName, os.Getenv(configEnvName), configDesc)
 	fs.Parse(os.Args[1:])
+	if configPath != "" {
+		var err error
+		cmdlineConfig, err = loadConfig(configPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		for _, path := range cmdlineConfig.Plugins {
+			pluginFlags[path] = true
+		}
+	}
+	if name := presetName(fs, os.Environ(), cmdlineConfig); name != "" {
+		var err error
+		cmdlinePreset, err = loadPreset(name, presetsPaths())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		for _, path := range cmdlinePreset.Plugins {
+			pluginFlags[path] = true
+		}
+	}
+	for _, path := range installedPluginPaths() {
+		pluginFlags[path] = true
+	}
 	for path := range pluginFlags {
 		_, err := plugin.Open(path)
 		if err != nil {
@@ -152,14 +197,49 @@ targets can be added using the --plugin system.`,
 	Args: cobra.RangeArgs(1, 2),
 	Run: func(cmd *cobra.Command, args []string) {
 		flags := cmd.Flags()
+		// each source skips the flags which were set by the previous ones
+		sources := []*preset{envPreset(flags, os.Environ()), cmdlineConfig, cmdlinePreset}
+		for _, source := range sources {
+			if source == nil {
+				continue
+			}
+			if err := source.apply(flags); err != nil {
+				log.Fatalln(err)
+			}
+		}
 		firstParent, _ := flags.GetBool("first-parent")
 		commitsFile, _ := flags.GetString("commits")
 		protobuf, _ := flags.GetBool("pb")
+		jsonOutput, _ := flags.GetBool("json")
+		csvDir, _ := flags.GetString("csv-dir")
+		if protobuf && jsonOutput {
+			log.Fatalln("--pb and --json are mutually exclusive")
+		}
 		profile, _ := flags.GetBool("profile")
+		pprofAddress, _ := flags.GetString("pprof")
 		disableStatus, _ := flags.GetBool("quiet")
+		var annotations []hercules.Annotation
+		if annotationsFile, _ := flags.GetString("annotations"); annotationsFile != "" {
+			var err error
+			annotations, err = hercules.LoadAnnotations(annotationsFile)
+			if err != nil {
+				log.Fatalf("failed to load the annotations: %v", err)
+			}
+		}
 
+		if profile && pprofAddress == "" {
+			pprofAddress = "localhost:6060"
+		}
+		if pprofAddress != "" {
+			addr, err := servePprof(pprofAddress)
+			if err != nil {
+				log.Fatalf("failed to start the pprof server: %v", err)
+			}
+			if !disableStatus {
+				fmt.Fprintf(os.Stderr, "pprof: http://%s/debug/pprof/\n", addr)
+			}
+		}
 		if profile {
-			go http.ListenAndServe("localhost:6060", nil)
 			prof, _ := os.Create("hercules.pprof")
 			pprof.StartCPUProfile(prof)
 			defer pprof.StopCPUProfile()
@@ -169,7 +249,31 @@ targets can be added using the --plugin system.`,
 		if len(args) == 2 {
 			cachePath = args[1]
 		}
-		repository := loadRepository(uri, cachePath, disableStatus)
+		var repository *git.Repository
+		if vcs, _ := flags.GetString("vcs"); vcs != "" {
+			var err error
+			repository, err = hercules.ImportHistory(vcs, uri)
+			if err != nil {
+				log.Fatalf("failed to import %s: %v", uri, err)
+			}
+		} else if remoteCacheDir, _ := flags.GetString("remote-cache-dir");
+			remoteCacheDir != "" && strings.Contains(uri, "://") {
+			var progress io.Writer
+			if !disableStatus {
+				progress = oneLineWriter{Writer: os.Stderr}
+			}
+			store, err := streamRemoteRepository(uri, remoteCacheDir, progress)
+			if !disableStatus {
+				fmt.Fprint(os.Stderr, strings.Repeat(" ", 80)+"\r")
+			}
+			if err != nil {
+				log.Panicf("failed to fetch %s: %v", uri, err)
+			}
+			defer store.Close()
+			repository = store.Repository
+		} else {
+			repository = loadRepository(uri, cachePath, disableStatus)
+		}
 
 		// core logic
 		pipeline := hercules.NewPipeline(repository)
@@ -209,10 +313,25 @@ targets can be added using the --plugin system.`,
 			log.Panicf("failed to list the commits: %v", err)
 		}
 		cmdlineFacts[hercules.ConfigPipelineCommits] = commits
+		if fast, _ := cmdlineFacts[hercules.ConfigPipelineFast].(bool); fast {
+			chosen := false
+			for _, valPtr := range cmdlineDeployed {
+				chosen = chosen || *valPtr
+			}
+			if !chosen {
+				// the metadata-level analyses which do not need the file contents
+				*cmdlineDeployed["Activity"] = true
+				*cmdlineDeployed["Couples"] = true
+			}
+		}
 		var deployed []hercules.LeafPipelineItem
 		for name, valPtr := range cmdlineDeployed {
 			if *valPtr {
-				item := pipeline.DeployItem(hercules.Registry.Summon(name)[0])
+				leaf := hercules.Registry.Summon(name)[0]
+				if dynamic, ok := leaf.(hercules.DynamicPipelineItem); ok {
+					dynamic.ConfigureRequirements(cmdlineFacts)
+				}
+				item := pipeline.DeployItem(leaf)
 				deployed = append(deployed, item.(hercules.LeafPipelineItem))
 			}
 		}
@@ -220,9 +339,25 @@ targets can be added using the --plugin system.`,
 		if dryRun, _ := cmdlineFacts[hercules.ConfigPipelineDryRun].(bool); dryRun {
 			return
 		}
-		results, err := pipeline.Run(commits)
-		if err != nil {
-			panic(err)
+		results := pipeline.LoadCheckpoint(commits)
+		checkpointed := results != nil
+		if !checkpointed {
+			results, err = pipeline.Run(commits)
+			if err != nil {
+				panic(err)
+			}
+		}
+		commonResult := results[nil].(*hercules.CommonAnalysisResult)
+		commonResult.Annotations = annotations
+		commonResult.People, _ = cmdlineFacts[hercules.FactIdentityDetectorReversedPeopleDict].([]string)
+		// the checkpoint keeps the files which were not parsed during the original run
+		if unparsed, exists := cmdlineFacts[hercules.FactUASTUnparsedFiles].(*hercules.UnparsedFiles); exists && !checkpointed {
+			commonResult.UnparsedFiles = unparsed.Files()
+		}
+		if !checkpointed {
+			if err = pipeline.SaveCheckpoint(commits, results); err != nil {
+				log.Printf("failed to save the checkpoint: %v", err)
+			}
 		}
 		if !disableStatus {
 			fmt.Fprint(os.Stderr, "\r"+strings.Repeat(" ", 80)+"\r")
@@ -231,27 +366,51 @@ targets can be added using the --plugin system.`,
 				fmt.Fprint(os.Stderr, "writing...\r")
 			}
 		}
-		if !protobuf {
-			printResults(uri, deployed, results)
-		} else {
+		if protobuf {
 			protobufResults(uri, deployed, results)
+		} else if jsonOutput {
+			jsonResults(uri, deployed, results)
+		} else {
+			printResults(uri, deployed, results)
+		}
+		if csvDir != "" {
+			csvResults(csvDir, uri, deployed, results)
 		}
 	},
 }
 
+// resultsFormatVersion is the version of the header which is written by the YAML, JSON and CSV
+// outputs. The Protocol Buffers output is versioned separately.
+const resultsFormatVersion = 3
+
 func printResults(
 	uri string, deployed []hercules.LeafPipelineItem,
 	results map[hercules.LeafPipelineItem]interface{}) {
 	commonResult := results[nil].(*hercules.CommonAnalysisResult)
 
 	fmt.Println("hercules:")
-	fmt.Println("  version: 3")
+	fmt.Println("  version:", resultsFormatVersion)
 	fmt.Println("  hash:", hercules.BinaryGitHash)
 	fmt.Println("  repository:", uri)
 	fmt.Println("  begin_unix_time:", commonResult.BeginTime)
 	fmt.Println("  end_unix_time:", commonResult.EndTime)
 	fmt.Println("  commits:", commonResult.CommitsNumber)
 	fmt.Println("  run_time:", commonResult.RunTime.Nanoseconds()/1e6)
+	if commonResult.ProfilePerItem != nil {
+		printProfile(commonResult.ProfilePerItem)
+	}
+	if len(commonResult.Annotations) > 0 {
+		printAnnotations(commonResult.Annotations)
+	}
+	if len(commonResult.People) > 0 {
+		fmt.Println("  people:")
+		for _, person := range commonResult.People {
+			fmt.Println("    - " + hercules.SafeYamlString(person))
+		}
+	}
+	if len(commonResult.UnparsedFiles) > 0 {
+		printUnparsedFiles(commonResult.UnparsedFiles)
+	}
 
 	for _, item := range deployed {
 		result := results[item]
@@ -262,6 +421,46 @@ func printResults(
 	}
 }
 
+// printProfile writes the resource usage of each pipeline item collected with --self-profile.
+func printProfile(profile map[string]*hercules.ItemProfile) {
+	names := make([]string, 0, len(profile))
+	for name := range profile {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Println("  profile:")
+	for _, name := range names {
+		item := profile[name]
+		fmt.Printf("    %s: {wall_time: %.3f, cpu_time: %.3f, allocations: %d, "+
+			"allocated_bytes: %d, calls: %d}\n", name, item.WallTime.Seconds(),
+			item.CPUTime.Seconds(), item.Allocations, item.AllocatedBytes, item.Calls)
+	}
+}
+
+// printUnparsedFiles writes the files which the structural analyses could not parse.
+func printUnparsedFiles(files map[string]hercules.UnparsedFile) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Println("  unparsed_files:")
+	for _, name := range names {
+		file := files[name]
+		fmt.Printf("    %s: {language: %s, reason: %s}\n", hercules.SafeYamlString(name),
+			hercules.SafeYamlString(file.Language), hercules.SafeYamlString(file.Reason))
+	}
+}
+
+// printAnnotations writes the events supplied with --annotations.
+func printAnnotations(annotations []hercules.Annotation) {
+	fmt.Println("  annotations:")
+	for _, annotation := range annotations {
+		fmt.Printf("    - {unix_time: %d, label: %s}\n",
+			annotation.Time, hercules.SafeYamlString(annotation.Label))
+	}
+}
+
 func protobufResults(
 	uri string, deployed []hercules.LeafPipelineItem,
 	results map[hercules.LeafPipelineItem]interface{}) {
@@ -294,6 +493,98 @@ func protobufResults(
 	os.Stdout.Write(serialized)
 }
 
+// jsonResults writes the results as a single JSON object. The header is under the "hercules" key
+// and follows the Metadata message, each analysis is under its name and follows its own
+// Protocol Buffers message. The leaves which cannot write JSON are skipped with a warning.
+func jsonResults(
+	uri string, deployed []hercules.LeafPipelineItem,
+	results map[hercules.LeafPipelineItem]interface{}) {
+
+	header := pb.Metadata{
+		Version:    resultsFormatVersion,
+		Hash:       hercules.BinaryGitHash,
+		Repository: uri,
+	}
+	results[nil].(*hercules.CommonAnalysisResult).FillMetadata(&header)
+	// encoding/json sorts the keys, so the output is stable
+	contents := map[string]json.RawMessage{}
+	buffer := &bytes.Buffer{}
+	if err := hercules.MessageToJSON(&header, buffer); err != nil {
+		panic(err)
+	}
+	contents["hercules"] = buffer.Bytes()
+	for _, item := range deployed {
+		jsonItem, ok := item.(hercules.JSONSerializablePipelineItem)
+		if !ok {
+			log.Printf("Warning: %s does not support JSON and is skipped\n", item.Name())
+			continue
+		}
+		buffer := &bytes.Buffer{}
+		if err := jsonItem.SerializeJSON(results[item], buffer); err != nil {
+			panic(err)
+		}
+		contents[item.Name()] = buffer.Bytes()
+	}
+	serialized, err := json.Marshal(contents)
+	if err != nil {
+		panic(err)
+	}
+	os.Stdout.Write(serialized)
+	os.Stdout.Write([]byte{'\n'})
+}
+
+// csvResults writes the tidy CSV tables of each analysis to the directory. The main table
+// of an analysis is written to <name>.csv and the others to <name>_<table>.csv; the header
+// goes to hercules.csv and hercules_<table>.csv. The leaves which cannot export CSV are
+// skipped with a warning.
+func csvResults(
+	dir string, uri string, deployed []hercules.LeafPipelineItem,
+	results map[hercules.LeafPipelineItem]interface{}) {
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		log.Fatalf("failed to create %s: %v", dir, err)
+	}
+	writeTables := func(name string, tables []hercules.CSVTable) {
+		for _, table := range tables {
+			fileName := name
+			if table.Name != "" {
+				fileName += "_" + table.Name
+			}
+			fileName = filepath.Join(dir, fileName+".csv")
+			file, err := os.Create(fileName)
+			if err != nil {
+				log.Fatalf("failed to create %s: %v", fileName, err)
+			}
+			err = hercules.WriteCSVTable(table, file)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				log.Fatalf("failed to write %s: %v", fileName, err)
+			}
+		}
+	}
+	header := pb.Metadata{
+		Version:    resultsFormatVersion,
+		Hash:       hercules.BinaryGitHash,
+		Repository: uri,
+	}
+	results[nil].(*hercules.CommonAnalysisResult).FillMetadata(&header)
+	writeTables("hercules", hercules.MessageToCSV(&header))
+	for _, item := range deployed {
+		csvItem, ok := item.(hercules.CSVExportablePipelineItem)
+		if !ok {
+			log.Printf("Warning: %s does not support CSV and is skipped\n", item.Name())
+			continue
+		}
+		tables, err := csvItem.ExportCSV(results[item])
+		if err != nil {
+			panic(err)
+		}
+		writeTables(item.Name(), tables)
+	}
+}
+
 // animate the private function defined in Cobra
 //go:linkname tmpl github.com/spf13/cobra.tmpl
 func tmpl(w io.Writer, text string, data interface{}) error
@@ -416,9 +707,28 @@ func init() {
 	rootFlags.Bool("first-parent", false, "Follow only the first parent in the commit history - " +
 		"\"git log --first-parent\".")
 	rootFlags.Bool("pb", false, "The output format will be Protocol Buffers instead of YAML.")
+	rootFlags.Bool("json", false, "The output format will be JSON instead of YAML. "+
+		"The schema follows the Protocol Buffers messages.")
+	rootFlags.String("csv-dir", "", "Additionally write each analysis result as tidy CSV "+
+		"tables (tick, entity, metric, value) to this directory.")
+	rootCmd.MarkFlagFilename("csv-dir")
 	rootFlags.Bool("quiet", !terminal.IsTerminal(int(os.Stdin.Fd())),
 		"Do not print status updates to stderr.")
 	rootFlags.Bool("profile", false, "Collect the profile to hercules.pprof.")
+	rootFlags.String("remote-cache-dir", "", "Stream the objects of the remote repository into "+
+		"a temporary object store in this directory instead of cloning it. Only the packfile of "+
+		"the default branch is downloaded, without tags and the working tree. The store is "+
+		"deleted after the analysis.")
+	rootCmd.MarkFlagFilename("remote-cache-dir")
+	rootFlags.String("vcs", "", "Read the history with the specified adapter instead of Git. "+
+		"The repository argument is passed to the adapter as is. Supported: "+
+		strings.Join(hercules.VCSAdapters(), ", ")+".")
+	rootFlags.String("annotations", "", "Path to the text file with the project events "+
+		"which are embedded into the results, e.g. releases or reorgs. Each line is the date "+
+		"(YYYY-MM-DD or RFC3339) and the label separated by a space.")
+	rootCmd.MarkFlagFilename("annotations")
+	rootFlags.String("pprof", "", "Start the net/http/pprof server on the specified address, " +
+		"e.g. :6060, to collect heap and CPU profiles while running.")
 	cmdlineFacts, cmdlineDeployed = hercules.Registry.AddFlags(rootFlags)
 	rootCmd.SetUsageFunc(formatUsage)
 	rootCmd.AddCommand(versionCmd)
@@ -12,6 +12,7 @@ import (
 	"gopkg.in/src-d/go-billy.v4/osfs"
 	"gopkg.in/src-d/go-git.v4/storage/filesystem"
 	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4"
 )
 
 func TestLoadRepository(t *testing.T) {
@@ -54,3 +55,17 @@ func TestLoadRepository(t *testing.T) {
 	assert.Panics(t, func() { loadRepository(filepath.Dir(filename), "", true) })
 	assert.Panics(t, func() { loadRepository("/xxx", "", true) })
 }
+
+func TestLeavesSerializeJSON(t *testing.T) {
+	for _, leaf := range hercules.Registry.GetLeaves() {
+		_, ok := leaf.(hercules.JSONSerializablePipelineItem)
+		assert.True(t, ok, leaf.Name())
+	}
+}
+
+func TestLeavesExportCSV(t *testing.T) {
+	for _, leaf := range hercules.Registry.GetLeaves() {
+		_, ok := leaf.(hercules.CSVExportablePipelineItem)
+		assert.True(t, ok, leaf.Name())
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/src-d/hercules.v4/leaves"
+)
+
+// treeSnapshotCmd represents the tree-snapshot command
+var treeSnapshotCmd = &cobra.Command{
+	Use:   "tree-snapshot <results.pb>",
+	Short: "Export the final directory tree with the line ages and owners as nested JSON.",
+	Long: `The results must be saved with --burndown --burndown-tree --pb; add --burndown-people
+to include the owners. Every node contains the number of lines, the 10th, 50th and 90th percentiles
+of the line ages in days and the top owners. The output can be fed directly into treemap
+or sunburst visualizations.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		topOwners, _ := cmd.Flags().GetInt("top-owners")
+		var repos []string
+		results, _, errs := loadMessage(args[0], &repos)
+		printErrors(map[string][]string{args[0]: errs})
+		if results == nil {
+			os.Exit(1)
+		}
+		burndown, exists := results["Burndown"].(leaves.BurndownResult)
+		if !exists || len(burndown.FileSnapshots) == 0 {
+			log.Fatalf("%s does not contain the file snapshots, run with --burndown --burndown-tree",
+				args[0])
+		}
+		tree := leaves.BuildBurndownTree(burndown, topOwners)
+		if len(repos) > 0 {
+			tree.Name = repos[0]
+		}
+		if err := writeTreeSnapshot(os.Stdout, tree); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func writeTreeSnapshot(writer io.Writer, tree *leaves.BurndownTreeNode) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(tree)
+}
+
+func init() {
+	rootCmd.AddCommand(treeSnapshotCmd)
+	treeSnapshotCmd.SetUsageFunc(treeSnapshotCmd.UsageFunc())
+	treeSnapshotCmd.Flags().Int("top-owners", 3, "Maximum number of owners in each node.")
+}
@@ -206,6 +206,16 @@ func (churn *ChurnAnalysis) Serialize(result interface{}, binary bool, writer io
 	return nil
 }
 
+// SerializeJSON converts the result from Finalize() to JSON.
+func (churn *ChurnAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return hercules.SerializeJSON(churn, result, &ChurnAnalysisResultMessage{}, writer)
+}
+
+// ExportCSV converts the result from Finalize() to the tidy tables.
+func (churn *ChurnAnalysis) ExportCSV(result interface{}) ([]hercules.CSVTable, error) {
+	return hercules.ExportCSV(churn, result, &ChurnAnalysisResultMessage{})
+}
+
 func (churn *ChurnAnalysis) serializeText(result *ChurnAnalysisResult, writer io.Writer) {
 	fmt.Fprintln(writer, "  global:")
 	printEdits(result.Global, writer, 4)
@@ -1,6 +1,9 @@
 package hercules
 
 import (
+	"io"
+
+	"github.com/gogo/protobuf/proto"
 	git "gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
 	"gopkg.in/src-d/hercules.v4/internal/core"
@@ -42,6 +45,27 @@ type LeafPipelineItem = core.LeafPipelineItem
 // ResultMergeablePipelineItem specifies the methods to combine several analysis results together.
 type ResultMergeablePipelineItem = core.ResultMergeablePipelineItem
 
+// JSONSerializablePipelineItem is implemented by the LeafPipelineItem-s which can write
+// their results as JSON.
+type JSONSerializablePipelineItem = core.JSONSerializablePipelineItem
+
+// CSVExportablePipelineItem is implemented by the LeafPipelineItem-s which can export
+// their results as tidy CSV tables.
+type CSVExportablePipelineItem = core.CSVExportablePipelineItem
+
+// CSVTable is a named list of CSVRow-s which is written to a separate file.
+type CSVTable = core.CSVTable
+
+// CSVRow is a single observation in the long ("tidy") format.
+type CSVRow = core.CSVRow
+
+// DynamicPipelineItem is implemented by the PipelineItem-s which Requires() depend on
+// the configuration.
+type DynamicPipelineItem = core.DynamicPipelineItem
+
+// ContentPipelineItem is implemented by the PipelineItem-s which read the file contents.
+type ContentPipelineItem = core.ContentPipelineItem
+
 // CommonAnalysisResult holds the information which is always extracted at Pipeline.Run().
 type CommonAnalysisResult = core.CommonAnalysisResult
 
@@ -60,6 +84,10 @@ func MetadataToCommonAnalysisResult(meta *core.Metadata) *CommonAnalysisResult {
 // See the extended example of how a Pipeline works in doc.go
 type Pipeline = core.Pipeline
 
+// PipelinePlan is the immutable resolved Pipeline which creates independent Pipeline-s
+// to analyse several repositories concurrently. See Pipeline.Plan().
+type PipelinePlan = core.PipelinePlan
+
 const (
 	// ConfigPipelineDumpPath is the name of the Pipeline configuration option (Pipeline.Initialize())
 	// which enables saving the items DAG to the specified file.
@@ -72,8 +100,99 @@ const (
 	// ConfigPipelineCommits is the name of the Pipeline configuration option (Pipeline.Initialize())
 	// which allows to specify the custom commit sequence. By default, Pipeline.Commits() is used.
 	ConfigPipelineCommits = core.ConfigPipelineCommits
+	// ConfigPipelineWorkers is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the maximum number of goroutines which all the items may use to parallelize
+	// their work. Zero means runtime.GOMAXPROCS(0).
+	ConfigPipelineWorkers = core.ConfigPipelineWorkers
+	// FactWorkerPool is the name of the fact which is set by Pipeline.Initialize() before
+	// any Configure() call. It contains the *WorkerPool shared by all the items.
+	FactWorkerPool = core.FactWorkerPool
+	// ConfigPipelineSelfProfile is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which enables measuring the wall time, CPU time and allocations of each PipelineItem.
+	ConfigPipelineSelfProfile = core.ConfigPipelineSelfProfile
+	// ConfigPipelineCachePath is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the directory to keep the intermediate results between runs.
+	ConfigPipelineCachePath = core.ConfigPipelineCachePath
+	// FactPersistentCache contains the Storage shared by all the items.
+	FactPersistentCache = core.FactPersistentCache
+	// ConfigPipelineCheckpoint is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which enables reusing the results of the identical runs from the persistent cache.
+	ConfigPipelineCheckpoint = core.ConfigPipelineCheckpoint
+	// ConfigPipelineFast is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which excludes the items that read the file contents.
+	ConfigPipelineFast = core.ConfigPipelineFast
 )
 
+// ItemProfile is the resource usage of a PipelineItem collected with ConfigPipelineSelfProfile.
+type ItemProfile = core.ItemProfile
+
+// UnparsedFile explains why the structural analyses degraded on a file.
+type UnparsedFile = core.UnparsedFile
+
+// Annotation is a labelled moment in the project history, e.g. a release or a reorg.
+type Annotation = core.Annotation
+
+// LoadAnnotations reads the annotations file: each line is the date and the label.
+func LoadAnnotations(path string) ([]Annotation, error) {
+	return core.LoadAnnotations(path)
+}
+
+// Storage keeps arbitrary binary values under string keys between runs.
+type Storage = core.Storage
+
+// StorageFactory creates a Storage from the parsed URI.
+type StorageFactory = core.StorageFactory
+
+// OpenStorage creates the Storage which corresponds to the URI.
+func OpenStorage(uri string) (Storage, error) {
+	return core.OpenStorage(uri)
+}
+
+// RegisterStorage adds the support of the new URI scheme to OpenStorage().
+func RegisterStorage(scheme string, factory StorageFactory) {
+	core.RegisterStorage(scheme, factory)
+}
+
+// VCSAdapter reads the history of a version control system other than Git.
+type VCSAdapter = core.VCSAdapter
+
+// Revision is a single commit in the history imported by VCSAdapter.
+type Revision = core.Revision
+
+// FileChange is a single file modification in Revision.
+type FileChange = core.FileChange
+
+// HistoryBuilder converts the history imported by VCSAdapter into Git objects.
+type HistoryBuilder = core.HistoryBuilder
+
+// NewHistoryBuilder creates an empty HistoryBuilder.
+func NewHistoryBuilder() *HistoryBuilder {
+	return core.NewHistoryBuilder()
+}
+
+// RegisterVCSAdapter makes the adapter available in ImportHistory().
+func RegisterVCSAdapter(name string, adapter VCSAdapter) {
+	core.RegisterVCSAdapter(name, adapter)
+}
+
+// VCSAdapters returns the sorted names of the registered VCSAdapter-s.
+func VCSAdapters() []string {
+	return core.VCSAdapters()
+}
+
+// ImportHistory converts the history read by the named VCSAdapter into a Git repository.
+func ImportHistory(adapter string, source string) (*git.Repository, error) {
+	return core.ImportHistory(adapter, source)
+}
+
+// WorkerPool limits the number of goroutines which PipelineItem-s use to parallelize their work.
+type WorkerPool = core.WorkerPool
+
+// NewWorkerPool creates a new WorkerPool which runs at most `size` concurrent jobs.
+func NewWorkerPool(size int) *WorkerPool {
+	return core.NewWorkerPool(size)
+}
+
 // NewPipeline initializes a new instance of Pipeline struct.
 func NewPipeline(repository *git.Repository) *Pipeline {
 	return core.NewPipeline(repository)
@@ -114,6 +233,9 @@ const (
 	DependencyIsMerge = core.DependencyIsMerge
 	// DependencyAuthor is the name of the dependency provided by identity.Detector.
 	DependencyAuthor = identity.DependencyAuthor
+	// DependencyCommitter is the name of the dependency provided by identity.Detector.
+	// It is the index of the committer of the commit.
+	DependencyCommitter = identity.DependencyCommitter
 	// DependencyBlobCache identifies the dependency provided by BlobCache.
 	DependencyBlobCache = plumbing.DependencyBlobCache
 	// DependencyDay is the name of the dependency which DaysSinceStart provides - the number
@@ -121,12 +243,21 @@ const (
 	DependencyDay = plumbing.DependencyDay
 	// DependencyFileDiff is the name of the dependency provided by FileDiff.
 	DependencyFileDiff = plumbing.DependencyFileDiff
+	// DependencyLanguages is the name of the dependency provided by LanguagesDetection:
+	// the programming languages of the changed files.
+	DependencyLanguages = plumbing.DependencyLanguages
+	// DependencyTags is the name of the dependency provided by TagsDetector:
+	// the tags which point to the current commit.
+	DependencyTags = plumbing.DependencyTags
 	// DependencyTreeChanges is the name of the dependency provided by TreeDiff.
 	DependencyTreeChanges = plumbing.DependencyTreeChanges
 	// DependencyUastChanges is the name of the dependency provided by Changes.
 	DependencyUastChanges = uast.DependencyUastChanges
 	// DependencyUasts is the name of the dependency provided by Extractor.
 	DependencyUasts = uast.DependencyUasts
+	// DependencyStructuralDiff is the name of the dependency provided by StructuralDiff.
+	// It tells the real logic changes apart from the formatting.
+	DependencyStructuralDiff = uast.DependencyStructuralDiff
 	// FactCommitsByDay contains the mapping between day indices and the corresponding commits.
 	FactCommitsByDay = plumbing.FactCommitsByDay
 	// FactIdentityDetectorPeopleCount is the name of the fact which is inserted in
@@ -141,16 +272,84 @@ const (
 	// identity.Detector.Configure(). It corresponds to identity.Detector.ReversedPeopleDict -
 	// the mapping from the author indices to the main signature.
 	FactIdentityDetectorReversedPeopleDict = identity.FactIdentityDetectorReversedPeopleDict
+	// FactIdentityDetectorAttribution is the name of the fact which is inserted in
+	// identity.Detector.Configure(). It tells whether the commits are attributed to the authors
+	// or to the committers.
+	FactIdentityDetectorAttribution = identity.FactIdentityDetectorAttribution
+	// FactUASTUnparsedFiles is the name of the fact which is inserted in uast.Extractor.Configure().
+	// It is *UnparsedFiles which is filled during Pipeline.Run().
+	FactUASTUnparsedFiles = uast.FactUASTUnparsedFiles
 )
 
+// UnparsedFiles collects the files which could not be converted to UASTs.
+type UnparsedFiles = uast.UnparsedFiles
+
+// IdentityProposal is an automatically detected identity together with the merged signatures.
+type IdentityProposal = identity.IdentityProposal
+
+// IdentitySignature is a distinct author name and email pair.
+type IdentitySignature = identity.Signature
+
+// IdentityResolver maps the commit signatures to the developers. Plugins can replace
+// the built-in heuristics with RegisterIdentityResolver().
+type IdentityResolver = identity.Resolver
+
+// RegisterIdentityResolver adds another IdentityResolver which is activated
+// with --identity-resolver.
+func RegisterIdentityResolver(factory func() IdentityResolver) {
+	identity.RegisterResolver(factory)
+}
+
+// ProposeIdentities detects the identities in the commits the same way as identity.Detector
+// does by default and returns them for the review.
+func ProposeIdentities(commits []*object.Commit) []IdentityProposal {
+	detector := identity.Detector{}
+	detector.GeneratePeopleDict(commits)
+	return detector.Proposals(commits)
+}
+
 // FileDiffData is the type of the dependency provided by plumbing.FileDiff.
 type FileDiffData = plumbing.FileDiffData
 
+// StructuralDiffData is the type of the dependency provided by uast.StructuralDiff.
+type StructuralDiffData = uast.StructuralDiffData
+
+// StructuralChange is a node-level difference in StructuralDiffData.
+type StructuralChange = uast.StructuralChange
+
 // CountLines returns the number of lines in a *object.Blob.
 func CountLines(file *object.Blob) (int, error) {
 	return plumbing.CountLines(file)
 }
 
+// MessageToJSON writes the Protocol Buffers message as JSON with the stable schema.
+func MessageToJSON(message proto.Message, writer io.Writer) error {
+	return core.MessageToJSON(message, writer)
+}
+
+// SerializeJSON writes the result of the leaf as JSON. `message` is an empty Protocol Buffers
+// message of the type which the leaf writes in the binary mode.
+func SerializeJSON(item LeafPipelineItem, result interface{}, message proto.Message,
+	writer io.Writer) error {
+	return core.SerializeJSON(item, result, message, writer)
+}
+
+// ExportCSV converts the result of the leaf to the tidy tables. `message` is an empty
+// Protocol Buffers message of the type which the leaf writes in the binary mode.
+func ExportCSV(item LeafPipelineItem, result interface{}, message proto.Message) ([]CSVTable, error) {
+	return core.ExportCSV(item, result, message)
+}
+
+// MessageToCSV flattens the Protocol Buffers message to the tidy tables.
+func MessageToCSV(message proto.Message) []CSVTable {
+	return core.MessageToCSV(message)
+}
+
+// WriteCSVTable writes the rows with the header "tick,entity,metric,value".
+func WriteCSVTable(table CSVTable, writer io.Writer) error {
+	return core.WriteCSVTable(table, writer)
+}
+
 // SafeYamlString escapes the string so that it can be reliably used in YAML.
 func SafeYamlString(str string) string {
 	return yaml.SafeString(str)
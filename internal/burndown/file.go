@@ -281,6 +281,42 @@ func (file *File) Merge(day int, others... *File) {
 	file.tree = tree
 }
 
+// ForEach calls `callback` for each line interval in the order of the lines.
+// line is the index of the first line in the interval, length is the number of lines
+// and value is their time.
+func (file *File) ForEach(callback func(line, length, value int)) {
+	iter := file.tree.Min()
+	for !iter.Limit() {
+		node := iter.Item()
+		iter = iter.Next()
+		if iter.Limit() {
+			break
+		}
+		if length := iter.Item().Key - node.Key; length > 0 {
+			callback(node.Key, length, node.Value)
+		}
+	}
+}
+
+// Times returns the times of the line intervals which intersect with `length` lines
+// starting from `pos`, in the order of the lines.
+func (file *File) Times(pos int, length int) []int {
+	var times []int
+	if length <= 0 {
+		return times
+	}
+	iter := file.tree.FindLE(pos)
+	if iter.NegativeLimit() {
+		iter = file.tree.Min()
+	}
+	for ; !iter.Limit() && iter.Item().Key < pos+length; iter = iter.Next() {
+		if value := iter.Item().Value; value != TreeEnd {
+			times = append(times, value)
+		}
+	}
+	return times
+}
+
 // Dump formats the underlying line interval tree into a string.
 // Useful for error messages, panic()-s and debugging.
 func (file *File) Dump() string {
@@ -575,6 +575,35 @@ func TestFileFlatten(t *testing.T) {
 	assert.Len(t, lines, 130)
 }
 
+func TestFileForEach(t *testing.T) {
+	file, _ := fixtureFile()
+	file.Update(1, 20, 30, 0)
+	file.Update(4, 20, 10, 0)
+	// 0 0 | 20 4 | 30 1 | 60 0 | 140 -1
+	var intervals [][3]int
+	file.ForEach(func(line, length, value int) {
+		intervals = append(intervals, [3]int{line, length, value})
+	})
+	assert.Equal(t, [][3]int{{0, 20, 0}, {20, 10, 4}, {30, 30, 1}, {60, 80, 0}}, intervals)
+	file = NewFile(0, 0)
+	file.ForEach(func(line, length, value int) {
+		assert.Fail(t, "the file is empty")
+	})
+}
+
+func TestFileTimes(t *testing.T) {
+	file, _ := fixtureFile()
+	file.Update(1, 20, 30, 0)
+	file.Update(4, 20, 10, 0)
+	// 0 0 | 20 4 | 30 1 | 60 0 | 140 -1
+	assert.Equal(t, file.Times(0, 20), []int{0})
+	assert.Equal(t, file.Times(15, 10), []int{0, 4})
+	assert.Equal(t, file.Times(25, 40), []int{4, 1, 0})
+	assert.Equal(t, file.Times(135, 10), []int{0})
+	assert.Nil(t, file.Times(140, 1))
+	assert.Nil(t, file.Times(10, 0))
+}
+
 func TestFileMergeMark(t *testing.T) {
 	file, status := fixtureFile()
 	// 0 0 | 100 -1                             [0]: 100
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+)
+
+// Annotation is a labelled moment in the history of the project, e.g. "v2 rewrite started"
+// or "team reorg". The annotations are embedded into the results so that the charts carry
+// the organizational context.
+type Annotation struct {
+	// Time is the UNIX timestamp of the event.
+	Time int64
+	// Label is the description of the event.
+	Label string
+}
+
+// annotationTimeLayouts are the supported formats of the first column of the annotations file.
+var annotationTimeLayouts = []string{"2006-01-02", time.RFC3339}
+
+// ParseAnnotations reads the annotations, one per line: the date in YYYY-MM-DD or RFC3339 format
+// and the label separated by whitespace, e.g.
+//
+//	2017-03-01 v2 rewrite started
+//	2018-01-15T12:00:00+01:00 team reorg
+//
+// Empty lines and the lines which start with "#" are skipped. The result is sorted by time.
+func ParseAnnotations(reader io.Reader) ([]Annotation, error) {
+	var result []Annotation
+	scanner := bufio.NewScanner(reader)
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, " ", 2)
+		if len(parts) < 2 || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("line %d: the label is missing", lineno)
+		}
+		var when time.Time
+		var err error
+		for _, layout := range annotationTimeLayouts {
+			if when, err = time.Parse(layout, parts[0]); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q", lineno, parts[0])
+		}
+		result = append(result, Annotation{Time: when.Unix(), Label: strings.TrimSpace(parts[1])})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sortAnnotations(result)
+	return result, nil
+}
+
+// LoadAnnotations reads the annotations from the file. See ParseAnnotations() for the format.
+func LoadAnnotations(path string) ([]Annotation, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	result, err := ParseAnnotations(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return result, nil
+}
+
+func sortAnnotations(annotations []Annotation) {
+	sort.SliceStable(annotations, func(i, j int) bool {
+		return annotations[i].Time < annotations[j].Time
+	})
+}
+
+// mergeAnnotations joins two lists of annotations excluding the duplicates.
+func mergeAnnotations(a, b []Annotation) []Annotation {
+	if len(b) == 0 {
+		return a
+	}
+	seen := map[Annotation]bool{}
+	var result []Annotation
+	for _, list := range [...][]Annotation{a, b} {
+		for _, annotation := range list {
+			if !seen[annotation] {
+				seen[annotation] = true
+				result = append(result, annotation)
+			}
+		}
+	}
+	sortAnnotations(result)
+	return result
+}
+
+func annotationsToProtobuf(annotations []Annotation) []*pb.Annotation {
+	if annotations == nil {
+		return nil
+	}
+	result := make([]*pb.Annotation, len(annotations))
+	for i, annotation := range annotations {
+		result[i] = &pb.Annotation{UnixTime: annotation.Time, Label: annotation.Label}
+	}
+	return result
+}
+
+func annotationsFromProtobuf(annotations []*pb.Annotation) []Annotation {
+	if annotations == nil {
+		return nil
+	}
+	result := make([]Annotation, len(annotations))
+	for i, annotation := range annotations {
+		result[i] = Annotation{Time: annotation.UnixTime, Label: annotation.Label}
+	}
+	return result
+}
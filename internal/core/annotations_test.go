@@ -0,0 +1,59 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAnnotations(t *testing.T) {
+	annotations, err := ParseAnnotations(strings.NewReader(`
+# the project events
+2018-01-15T12:00:00+01:00   team reorg
+2017-03-01 v2 rewrite started
+
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, annotations, []Annotation{
+		{Time: 1488326400, Label: "v2 rewrite started"},
+		{Time: 1516014000, Label: "team reorg"},
+	})
+	annotations, err = ParseAnnotations(strings.NewReader(""))
+	assert.Nil(t, err)
+	assert.Len(t, annotations, 0)
+}
+
+func TestParseAnnotationsErrors(t *testing.T) {
+	_, err := ParseAnnotations(strings.NewReader("2017-03-01 release\n2017-03-02\n"))
+	assert.EqualError(t, err, "line 2: the label is missing")
+	_, err = ParseAnnotations(strings.NewReader("March release\n"))
+	assert.EqualError(t, err, "line 1: invalid date \"March\"")
+}
+
+func TestLoadAnnotations(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.txt")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("2017-03-01 release\n"), 0666))
+	annotations, err := LoadAnnotations(path)
+	assert.Nil(t, err)
+	assert.Equal(t, annotations, []Annotation{{Time: 1488326400, Label: "release"}})
+	_, err = LoadAnnotations(filepath.Join(dir, "missing.txt"))
+	assert.NotNil(t, err)
+	assert.Nil(t, ioutil.WriteFile(path, []byte("release\n"), 0666))
+	_, err = LoadAnnotations(path)
+	assert.Contains(t, err.Error(), "events.txt: line 1")
+}
+
+func TestMergeAnnotations(t *testing.T) {
+	a := []Annotation{{Time: 2, Label: "b"}}
+	assert.Equal(t, mergeAnnotations(a, nil), a)
+	assert.Equal(t, mergeAnnotations(nil, a), a)
+	assert.Equal(t, mergeAnnotations(a, []Annotation{{Time: 1, Label: "a"}, {Time: 2, Label: "b"}}),
+		[]Annotation{{Time: 1, Label: "a"}, {Time: 2, Label: "b"}})
+}
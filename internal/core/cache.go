@@ -0,0 +1,71 @@
+package core
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// DiskCache is the Storage which keeps the values in a local directory.
+// nil *DiskCache is valid and never stores anything.
+type DiskCache struct {
+	root string
+}
+
+// NewDiskCache creates a new DiskCache in the specified directory. The directory is created
+// if it does not exist.
+func NewDiskCache(root string) (*DiskCache, error) {
+	if err := os.MkdirAll(root, 0777); err != nil {
+		return nil, err
+	}
+	return &DiskCache{root: root}, nil
+}
+
+// path returns the file name which corresponds to the key. The keys are hashed so that they
+// can contain any characters; the files are spread between 256 subdirectories.
+func (cache *DiskCache) path(key string) string {
+	hash := sha1.Sum([]byte(key))
+	name := hex.EncodeToString(hash[:])
+	return filepath.Join(cache.root, name[:2], name[2:])
+}
+
+// Get returns the value which was stored under the key or nil if there is no such value.
+func (cache *DiskCache) Get(key string) ([]byte, error) {
+	if cache == nil {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(cache.path(key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Set stores the value under the key. Concurrent writers of the same key do not corrupt it
+// because the value is written to a temporary file first and then renamed.
+func (cache *DiskCache) Set(key string, value []byte) error {
+	if cache == nil {
+		return nil
+	}
+	path := cache.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return err
+	}
+	file, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(value)
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskCache(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpdir)
+	cache, err := NewDiskCache(filepath.Join(tmpdir, "cache"))
+	assert.Nil(t, err)
+	value, err := cache.Get("key")
+	assert.Nil(t, value)
+	assert.Nil(t, err)
+	assert.Nil(t, cache.Set("key", []byte("value")))
+	value, err = cache.Get("key")
+	assert.Equal(t, value, []byte("value"))
+	assert.Nil(t, err)
+	assert.Nil(t, cache.Set("key", []byte("another")))
+	value, _ = cache.Get("key")
+	assert.Equal(t, value, []byte("another"))
+	value, err = cache.Get("other/key")
+	assert.Nil(t, value)
+	assert.Nil(t, err)
+	// the values persist
+	cache, err = NewDiskCache(filepath.Join(tmpdir, "cache"))
+	assert.Nil(t, err)
+	value, _ = cache.Get("key")
+	assert.Equal(t, value, []byte("another"))
+	_, err = NewDiskCache(filepath.Join(tmpdir, "cache", cache.path("key")[len(cache.root)+1:]))
+	assert.NotNil(t, err)
+}
+
+func TestDiskCacheNil(t *testing.T) {
+	var cache *DiskCache
+	assert.Nil(t, cache.Set("key", []byte("value")))
+	value, err := cache.Get("key")
+	assert.Nil(t, value)
+	assert.Nil(t, err)
+}
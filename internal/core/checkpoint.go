@@ -0,0 +1,159 @@
+package core
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"os"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+)
+
+// checkpointer keeps the results of Pipeline.Run() in the persistent cache so that the same
+// analyses of the same commits are not repeated.
+type checkpointer struct {
+	storage Storage
+	// config is the hash of the pipeline items and their configuration.
+	config []byte
+	// leaves are the items which results are stored, by name.
+	leaves map[string]ResultMergeablePipelineItem
+}
+
+// newCheckpointer returns nil if the results of some leaves cannot be restored:
+// they do not implement ResultMergeablePipelineItem or their names clash.
+func newCheckpointer(storage Storage, items []PipelineItem,
+	facts map[string]interface{}) *checkpointer {
+	leaves := map[string]ResultMergeablePipelineItem{}
+	for _, item := range items {
+		leaf, isLeaf := item.(LeafPipelineItem)
+		if !isLeaf {
+			continue
+		}
+		mergeable, ok := leaf.(ResultMergeablePipelineItem)
+		if !ok {
+			log.Printf("checkpoints are disabled: %s cannot deserialize its results\n", leaf.Name())
+			return nil
+		}
+		if _, exists := leaves[leaf.Name()]; exists {
+			log.Printf("checkpoints are disabled: %s is deployed twice\n", leaf.Name())
+			return nil
+		}
+		leaves[leaf.Name()] = mergeable
+	}
+	config := sha1.New()
+	for _, item := range items {
+		fmt.Fprintf(config, "%s\n", item.Name())
+		for _, opt := range item.ListConfigurationOptions() {
+			val, exists := facts[opt.Name]
+			if !exists {
+				val = opt.Default
+			}
+			fmt.Fprintf(config, "%s=%v\n", opt.Name, val)
+			if path, isString := val.(string); isString && path != "" {
+				hashFile(config, path)
+			}
+		}
+	}
+	return &checkpointer{storage: storage, config: config.Sum(nil), leaves: leaves}
+}
+
+// hashFile mixes the contents of the file into the hash if `path` points to a regular file,
+// e.g. the people dictionary, so that editing it invalidates the checkpoints.
+func hashFile(digest hash.Hash, path string) {
+	if stat, err := os.Stat(path); err != nil || !stat.Mode().IsRegular() {
+		return
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	io.Copy(digest, file)
+}
+
+// key identifies the results of the analysed commits.
+func (cp *checkpointer) key(commits []*object.Commit) string {
+	hash := sha1.New()
+	hash.Write(cp.config)
+	for _, commit := range commits {
+		hash.Write(commit.Hash[:])
+	}
+	return "Pipeline/" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// load returns nil if the results do not exist or cannot be deserialized.
+func (cp *checkpointer) load(commits []*object.Commit) map[LeafPipelineItem]interface{} {
+	key := cp.key(commits)
+	data, err := cp.storage.Get(key)
+	if err != nil {
+		log.Printf("failed to read the checkpoint %s: %v\n", key, err)
+		return nil
+	}
+	if data == nil {
+		return nil
+	}
+	message := pb.AnalysisResults{}
+	if err = proto.Unmarshal(data, &message); err != nil || message.Header == nil {
+		log.Printf("ignored the corrupted checkpoint %s: %v\n", key, err)
+		return nil
+	}
+	results := map[LeafPipelineItem]interface{}{nil: MetadataToCommonAnalysisResult(message.Header)}
+	for name, leaf := range cp.leaves {
+		contents, exists := message.Contents[name]
+		if !exists {
+			log.Printf("ignored the checkpoint %s: %s is missing\n", key, name)
+			return nil
+		}
+		if results[leaf], err = leaf.Deserialize(contents); err != nil {
+			log.Printf("ignored the checkpoint %s: %s: %v\n", key, name, err)
+			return nil
+		}
+	}
+	return results
+}
+
+func (cp *checkpointer) save(commits []*object.Commit,
+	results map[LeafPipelineItem]interface{}) error {
+	message := pb.AnalysisResults{Header: &pb.Metadata{}, Contents: map[string][]byte{}}
+	if common, exists := results[nil].(*CommonAnalysisResult); exists {
+		common.FillMetadata(message.Header)
+	}
+	for name, leaf := range cp.leaves {
+		buffer := &bytes.Buffer{}
+		if err := leaf.Serialize(results[leaf], true, buffer); err != nil {
+			return err
+		}
+		message.Contents[name] = buffer.Bytes()
+	}
+	data, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	return cp.storage.Set(cp.key(commits), data)
+}
+
+// LoadCheckpoint returns the results which were saved by SaveCheckpoint() after analysing
+// the same commits with the same items and configuration. It returns nil if there are no such
+// results or ConfigPipelineCheckpoint is disabled; Run() must be called then.
+func (pipeline *Pipeline) LoadCheckpoint(commits []*object.Commit) map[LeafPipelineItem]interface{} {
+	if pipeline.checkpointer == nil {
+		return nil
+	}
+	return pipeline.checkpointer.load(commits)
+}
+
+// SaveCheckpoint stores the results of Run() in the persistent cache. It does nothing if
+// ConfigPipelineCheckpoint is disabled.
+func (pipeline *Pipeline) SaveCheckpoint(commits []*object.Commit,
+	results map[LeafPipelineItem]interface{}) error {
+	if pipeline.checkpointer == nil {
+		return nil
+	}
+	return pipeline.checkpointer.save(commits, results)
+}
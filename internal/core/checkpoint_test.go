@@ -0,0 +1,221 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// checkpointTestItem counts the consumed commits.
+type checkpointTestItem struct {
+	NoopMerger
+	Option   string
+	Consumed int
+}
+
+func (item *checkpointTestItem) Name() string {
+	return "CheckpointTest"
+}
+
+func (item *checkpointTestItem) Provides() []string {
+	return []string{}
+}
+
+func (item *checkpointTestItem) Requires() []string {
+	return []string{}
+}
+
+func (item *checkpointTestItem) ListConfigurationOptions() []ConfigurationOption {
+	return []ConfigurationOption{{
+		Name: "CheckpointTest.Option", Flag: "checkpoint-test-option",
+		Type: StringConfigurationOption, Default: "default"}}
+}
+
+func (item *checkpointTestItem) Configure(facts map[string]interface{}) {
+	item.Option, _ = facts["CheckpointTest.Option"].(string)
+}
+
+func (item *checkpointTestItem) Initialize(repository *git.Repository) {
+	item.Consumed = 0
+}
+
+func (item *checkpointTestItem) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	item.Consumed++
+	return nil, nil
+}
+
+func (item *checkpointTestItem) Fork(n int) []PipelineItem {
+	return ForkSamePipelineItem(item, n)
+}
+
+func (item *checkpointTestItem) Flag() string {
+	return "checkpoint-test"
+}
+
+func (item *checkpointTestItem) Description() string {
+	return "Counts the commits."
+}
+
+func (item *checkpointTestItem) Finalize() interface{} {
+	return item.Consumed
+}
+
+func (item *checkpointTestItem) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	_, err := fmt.Fprint(writer, result.(int))
+	return err
+}
+
+func (item *checkpointTestItem) Deserialize(message []byte) (interface{}, error) {
+	return strconv.Atoi(string(message))
+}
+
+func (item *checkpointTestItem) MergeResults(r1, r2 interface{}, c1, c2 *CommonAnalysisResult) interface{} {
+	return r1.(int) + r2.(int)
+}
+
+func fixtureCheckpointCommits() []*object.Commit {
+	var commits []*object.Commit
+	var parents []plumbing.Hash
+	for i := 1; i <= 3; i++ {
+		signature := object.Signature{When: time.Unix(int64(i*1000), 0)}
+		commit := &object.Commit{
+			Hash:         plumbing.NewHash(strings.Repeat(strconv.Itoa(i), 40)),
+			Author:       signature,
+			Committer:    signature,
+			ParentHashes: parents,
+		}
+		commits = append(commits, commit)
+		parents = []plumbing.Hash{commit.Hash}
+	}
+	return commits
+}
+
+func TestPipelineCheckpoint(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpdir)
+	commits := fixtureCheckpointCommits()
+	run := func(facts map[string]interface{}) (*checkpointTestItem, map[LeafPipelineItem]interface{}, bool) {
+		pipeline := NewPipeline(nil)
+		item := &checkpointTestItem{}
+		pipeline.AddItem(item)
+		facts[ConfigPipelineCommits] = commits
+		facts[ConfigPipelineCachePath] = filepath.Join(tmpdir, "cache")
+		facts[ConfigPipelineCheckpoint] = true
+		pipeline.Initialize(facts)
+		if results := pipeline.LoadCheckpoint(commits); results != nil {
+			return item, results, true
+		}
+		results, err := pipeline.Run(commits)
+		assert.Nil(t, err)
+		assert.Nil(t, pipeline.SaveCheckpoint(commits, results))
+		return item, results, false
+	}
+	item, results, checkpointed := run(map[string]interface{}{})
+	assert.False(t, checkpointed)
+	assert.Equal(t, item.Consumed, 3)
+	assert.Equal(t, results[item], 3)
+	item, results, checkpointed = run(map[string]interface{}{})
+	assert.True(t, checkpointed)
+	assert.Equal(t, item.Consumed, 0)
+	assert.Equal(t, results[item], 3)
+	common := results[nil].(*CommonAnalysisResult)
+	assert.Equal(t, common.BeginTime, int64(1000))
+	assert.Equal(t, common.EndTime, int64(3000))
+	assert.Equal(t, common.CommitsNumber, 3)
+	// another option value
+	_, _, checkpointed = run(map[string]interface{}{"CheckpointTest.Option": "other"})
+	assert.False(t, checkpointed)
+	// the option refers to a file which changes
+	path := filepath.Join(tmpdir, "people")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("one"), 0666))
+	_, _, checkpointed = run(map[string]interface{}{"CheckpointTest.Option": path})
+	assert.False(t, checkpointed)
+	_, _, checkpointed = run(map[string]interface{}{"CheckpointTest.Option": path})
+	assert.True(t, checkpointed)
+	assert.Nil(t, ioutil.WriteFile(path, []byte("two"), 0666))
+	_, _, checkpointed = run(map[string]interface{}{"CheckpointTest.Option": path})
+	assert.False(t, checkpointed)
+	// other commits
+	pipeline := NewPipeline(nil)
+	pipeline.AddItem(&checkpointTestItem{})
+	pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommits:    commits,
+		ConfigPipelineCachePath:  filepath.Join(tmpdir, "cache"),
+		ConfigPipelineCheckpoint: true,
+	})
+	assert.NotNil(t, pipeline.LoadCheckpoint(commits))
+	assert.Nil(t, pipeline.LoadCheckpoint(commits[:2]))
+}
+
+type failingStorage struct {
+	data map[string][]byte
+}
+
+func (storage failingStorage) Get(key string) ([]byte, error) {
+	if storage.data == nil {
+		return nil, errors.New("get")
+	}
+	return storage.data[key], nil
+}
+
+func (storage failingStorage) Set(key string, value []byte) error {
+	if storage.data == nil {
+		return errors.New("set")
+	}
+	storage.data[key] = value
+	return nil
+}
+
+func TestPipelineCheckpointErrors(t *testing.T) {
+	commits := fixtureCheckpointCommits()
+	item := &checkpointTestItem{}
+	// disabled
+	pipeline := NewPipeline(nil)
+	pipeline.AddItem(item)
+	pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommits: commits, ConfigPipelineCheckpoint: true})
+	assert.Nil(t, pipeline.checkpointer)
+	assert.Nil(t, pipeline.LoadCheckpoint(commits))
+	assert.Nil(t, pipeline.SaveCheckpoint(commits, nil))
+	// Get() and Set() fail
+	pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommits: commits, ConfigPipelineCheckpoint: true,
+		FactPersistentCache: failingStorage{}})
+	assert.NotNil(t, pipeline.checkpointer)
+	assert.Nil(t, pipeline.LoadCheckpoint(commits))
+	results := map[LeafPipelineItem]interface{}{item: 3, nil: &CommonAnalysisResult{}}
+	assert.EqualError(t, pipeline.SaveCheckpoint(commits, results), "set")
+	// corrupted data
+	storage := failingStorage{data: map[string][]byte{}}
+	pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommits: commits, ConfigPipelineCheckpoint: true,
+		FactPersistentCache: storage})
+	key := pipeline.checkpointer.key(commits)
+	storage.data[key] = []byte("garbage")
+	assert.Nil(t, pipeline.LoadCheckpoint(commits))
+	assert.Nil(t, pipeline.SaveCheckpoint(commits, results))
+	assert.NotNil(t, pipeline.LoadCheckpoint(commits))
+	results[item] = -1
+	assert.Nil(t, pipeline.SaveCheckpoint(commits, results))
+	storage.data[key] = []byte(strings.Replace(string(storage.data[key]), "-1", "xx", 1))
+	assert.Nil(t, pipeline.LoadCheckpoint(commits))
+	// the leaf which cannot deserialize its results
+	pipeline.AddItem(&testPipelineItem{})
+	pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommits: commits, ConfigPipelineCheckpoint: true,
+		FactPersistentCache: storage})
+	assert.Nil(t, pipeline.checkpointer)
+}
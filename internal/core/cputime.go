@@ -0,0 +1,18 @@
+//go:build !windows
+// +build !windows
+
+package core
+
+import (
+	"syscall"
+	"time"
+)
+
+// processCPUTime returns the user and system CPU time consumed by the process.
+func processCPUTime() time.Duration {
+	usage := syscall.Rusage{}
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return 0
+	}
+	return time.Duration(usage.Utime.Nano() + usage.Stime.Nano())
+}
@@ -0,0 +1,8 @@
+package core
+
+import "time"
+
+// processCPUTime is not implemented on Windows and always returns 0.
+func processCPUTime() time.Duration {
+	return 0
+}
@@ -0,0 +1,238 @@
+package core
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// CSVRow is a single observation in the long ("tidy") format.
+type CSVRow struct {
+	// Tick is the index of the tick or -1 if the value does not belong to a tick.
+	Tick int
+	// Entity identifies the measured object, e.g. a file or a developer. Nested entities
+	// are joined with CSVEntitySeparator. It is empty for the global values.
+	Entity string
+	// Metric is the name of the value. The names of the nested fields are joined with dots.
+	Metric string
+	// Value is the textual representation of the value.
+	Value string
+}
+
+// CSVTable is a named list of CSVRow-s which is written to a separate file.
+type CSVTable struct {
+	// Name is empty for the main table.
+	Name string
+	Rows []CSVRow
+}
+
+const (
+	// CSVEntitySeparator joins the nested entities in CSVRow.Entity.
+	CSVEntitySeparator = ":"
+	// csvTicksField is the name of the repeated fields which are indexed by ticks.
+	csvTicksField = "ticks"
+	// csvTickField is the name of the fields which contain the tick of the enclosing message.
+	csvTickField = "tick"
+)
+
+// ExportCSV converts the result of the leaf to the tidy tables. `message` must be an empty
+// Protocol Buffers message of the type which the leaf writes in the binary mode.
+// It is the typical implementation of CSVExportablePipelineItem.ExportCSV().
+func ExportCSV(item LeafPipelineItem, result interface{}, message proto.Message) ([]CSVTable, error) {
+	buffer := &bytes.Buffer{}
+	if err := item.Serialize(result, true, buffer); err != nil {
+		return nil, err
+	}
+	if err := proto.Unmarshal(buffer.Bytes(), message); err != nil {
+		return nil, err
+	}
+	return MessageToCSV(message), nil
+}
+
+// MessageToCSV flattens the Protocol Buffers message to the tidy tables. The scalar fields
+// of the message go to the main table and every repeated or map field goes to its own table.
+// The nested messages extend the metric names, the map keys and the indexes of the repeated
+// fields extend the entities. There are two exceptions: the indexes of the repeated fields
+// named "ticks" become the ticks, and so do the values of the integer fields named "tick".
+func MessageToCSV(message proto.Message) []CSVTable {
+	value := reflect.ValueOf(message)
+	for value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	tables := []CSVTable{{}}
+	for _, field := range csvFields(value) {
+		if isCSVScalar(field.value) {
+			tables[0].Rows = append(tables[0].Rows, CSVRow{
+				Tick: -1, Metric: field.name, Value: formatCSVScalar(field.value)})
+			continue
+		}
+		table := CSVTable{Name: field.name}
+		flattenCSV(field.value, field.name, -1, nil, nil, &table.Rows)
+		tables = append(tables, table)
+	}
+	return tables
+}
+
+// WriteCSVTable writes the rows with the header "tick,entity,metric,value".
+// The missing ticks are written as empty cells.
+func WriteCSVTable(table CSVTable, writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Write([]string{"tick", "entity", "metric", "value"})
+	for _, row := range table.Rows {
+		tick := ""
+		if row.Tick >= 0 {
+			tick = strconv.Itoa(row.Tick)
+		}
+		csvWriter.Write([]string{tick, row.Entity, row.Metric, row.Value})
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+type csvField struct {
+	name  string
+	value reflect.Value
+}
+
+// csvFields lists the Protocol Buffers fields of the message struct in the order of declaration.
+func csvFields(message reflect.Value) []csvField {
+	var fields []csvField
+	for i := 0; i < message.NumField(); i++ {
+		tag := message.Type().Field(i).Tag.Get("protobuf")
+		if tag == "" {
+			continue
+		}
+		for _, part := range strings.Split(tag, ",") {
+			if strings.HasPrefix(part, "name=") {
+				fields = append(fields, csvField{name: part[len("name="):], value: message.Field(i)})
+				break
+			}
+		}
+	}
+	return fields
+}
+
+func flattenCSV(value reflect.Value, name string, tick int, entity, metric []string,
+	rows *[]CSVRow) {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+	if isCSVScalar(value) {
+		row := CSVRow{
+			Tick:   tick,
+			Entity: strings.Join(entity, CSVEntitySeparator),
+			Metric: strings.Join(metric, "."),
+			Value:  formatCSVScalar(value),
+		}
+		if row.Metric == "" {
+			// repeated or map scalars
+			row.Metric = name
+		}
+		*rows = append(*rows, row)
+		return
+	}
+	switch value.Kind() {
+	case reflect.Struct:
+		fields := csvFields(value)
+		if tick < 0 {
+			for i, field := range fields {
+				if field.name == csvTickField && isCSVInteger(field.value) {
+					tick = int(field.value.Int())
+					fields = append(fields[:i:i], fields[i+1:]...)
+					break
+				}
+			}
+		}
+		for _, field := range fields {
+			flattenCSV(field.value, field.name, tick, entity, extendCSVPath(metric, field.name), rows)
+		}
+	case reflect.Map:
+		keys := make([]string, 0, value.Len())
+		values := map[string]reflect.Value{}
+		for _, key := range value.MapKeys() {
+			str := formatCSVScalar(key)
+			keys = append(keys, str)
+			values[str] = value.MapIndex(key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			// the integer keys are sorted numerically
+			ki, erri := strconv.Atoi(keys[i])
+			kj, errj := strconv.Atoi(keys[j])
+			if erri == nil && errj == nil {
+				return ki < kj
+			}
+			return keys[i] < keys[j]
+		})
+		for _, key := range keys {
+			flattenCSV(values[key], name, tick, extendCSVPath(entity, key), metric, rows)
+		}
+	case reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			if name == csvTicksField && tick < 0 {
+				flattenCSV(value.Index(i), name, i, entity, metric, rows)
+			} else {
+				flattenCSV(value.Index(i), name, tick, extendCSVPath(entity, strconv.Itoa(i)),
+					metric, rows)
+			}
+		}
+	}
+}
+
+// extendCSVPath appends the element to a copy of the path.
+func extendCSVPath(path []string, element string) []string {
+	result := make([]string, len(path), len(path)+1)
+	copy(result, path)
+	return append(result, element)
+}
+
+func isCSVInteger(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isCSVScalar(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	case reflect.Slice:
+		// bytes
+		return value.Type().Elem().Kind() == reflect.Uint8
+	}
+	return false
+}
+
+func formatCSVScalar(value reflect.Value) string {
+	switch value.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool())
+	case reflect.String:
+		return value.String()
+	case reflect.Float32:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 64)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10)
+	case reflect.Slice:
+		return hex.EncodeToString(value.Bytes())
+	}
+	return fmt.Sprint(value.Interface())
+}
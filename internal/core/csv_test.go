@@ -0,0 +1,108 @@
+package core
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+)
+
+func TestMessageToCSVTicks(t *testing.T) {
+	tables := MessageToCSV(&pb.DAGShapeAnalysisResults{
+		Ticks: []*pb.DAGShapeTick{
+			{Commits: 3, Merges: 1},
+			{Commits: 2, LongestChain: 2}},
+		Sampling: 10,
+	})
+	assert.Equal(t, tables, []CSVTable{
+		{Rows: []CSVRow{{Tick: -1, Metric: "sampling", Value: "10"}}},
+		{Name: "ticks", Rows: []CSVRow{
+			{Tick: 0, Metric: "commits", Value: "3"},
+			{Tick: 0, Metric: "merges", Value: "1"},
+			{Tick: 0, Metric: "parents", Value: "0"},
+			{Tick: 0, Metric: "max_branches", Value: "0"},
+			{Tick: 0, Metric: "longest_chain", Value: "0"},
+			{Tick: 1, Metric: "commits", Value: "2"},
+			{Tick: 1, Metric: "merges", Value: "0"},
+			{Tick: 1, Metric: "parents", Value: "0"},
+			{Tick: 1, Metric: "max_branches", Value: "0"},
+			{Tick: 1, Metric: "longest_chain", Value: "2"},
+		}},
+	})
+}
+
+func TestMessageToCSVEntities(t *testing.T) {
+	tables := MessageToCSV(&pb.RenameFrequencyAnalysisResults{
+		Ticks: []int32{4, 0},
+		Chains: []*pb.RenameChain{
+			{Names: []string{"a", "b"}, Days: []int32{7}, Deleted: true}},
+		Directories:    map[string]int32{"src": 2, "lib": 1},
+		Sampling:       30,
+		DirectoryDepth: 1,
+	})
+	assert.Equal(t, tables, []CSVTable{
+		{Rows: []CSVRow{
+			{Tick: -1, Metric: "sampling", Value: "30"},
+			{Tick: -1, Metric: "directory_depth", Value: "1"}}},
+		{Name: "ticks", Rows: []CSVRow{
+			{Tick: 0, Metric: "ticks", Value: "4"},
+			{Tick: 1, Metric: "ticks", Value: "0"}}},
+		{Name: "chains", Rows: []CSVRow{
+			{Tick: -1, Entity: "0:0", Metric: "names", Value: "a"},
+			{Tick: -1, Entity: "0:1", Metric: "names", Value: "b"},
+			{Tick: -1, Entity: "0:0", Metric: "days", Value: "7"},
+			{Tick: -1, Entity: "0", Metric: "deleted", Value: "true"}}},
+		{Name: "directories", Rows: []CSVRow{
+			{Tick: -1, Entity: "lib", Metric: "directories", Value: "1"},
+			{Tick: -1, Entity: "src", Metric: "directories", Value: "2"}}},
+	})
+}
+
+func TestMessageToCSVTickField(t *testing.T) {
+	tables := MessageToCSV(&pb.SensitivePathsAnalysisResults{
+		Changes: []*pb.SensitivePathChange{
+			{Commit: "abc", Author: -1, Tick: 5, File: "auth/a.go", Churn: 2}},
+	})
+	assert.Equal(t, tables[1], CSVTable{Name: "changes", Rows: []CSVRow{
+		{Tick: 5, Entity: "0", Metric: "commit", Value: "abc"},
+		{Tick: 5, Entity: "0", Metric: "author", Value: "-1"},
+		{Tick: 5, Entity: "0", Metric: "file", Value: "auth/a.go"},
+		{Tick: 5, Entity: "0", Metric: "churn", Value: "2"},
+	}})
+}
+
+func TestMessageToCSVMetadata(t *testing.T) {
+	tables := MessageToCSV(&pb.Metadata{
+		RunTimePerItem: map[string]float64{"b": 0.5, "a": 1.25},
+		Annotations:    []*pb.Annotation{{UnixTime: 100, Label: "v1"}},
+	})
+	assert.Equal(t, tables[1], CSVTable{Name: "run_time_per_item", Rows: []CSVRow{
+		{Tick: -1, Entity: "a", Metric: "run_time_per_item", Value: "1.25"},
+		{Tick: -1, Entity: "b", Metric: "run_time_per_item", Value: "0.5"},
+	}})
+	assert.Equal(t, tables[3].Name, "annotations")
+	assert.Len(t, tables[3].Rows, 2)
+	assert.Equal(t, tables[3].Rows[1], CSVRow{Tick: -1, Entity: "0", Metric: "label", Value: "v1"})
+}
+
+func TestWriteCSVTable(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, WriteCSVTable(CSVTable{Rows: []CSVRow{
+		{Tick: -1, Metric: "sampling", Value: "30"},
+		{Tick: 2, Entity: "a,b", Metric: "lines", Value: "7"}}}, buffer))
+	assert.Equal(t, buffer.String(), "tick,entity,metric,value\n,,sampling,30\n2,\"a,b\",lines,7\n")
+}
+
+func TestExportCSV(t *testing.T) {
+	item := &testPipelineItem{}
+	tables, err := ExportCSV(item, item.Finalize(), &pb.DAGShapeAnalysisResults{})
+	assert.Nil(t, err)
+	assert.Equal(t, tables, []CSVTable{
+		{Rows: []CSVRow{{Tick: -1, Metric: "sampling", Value: "0"}}},
+		{Name: "ticks"},
+	})
+	tables, err = ExportCSV(&failingSerializeItem{}, nil, &pb.DAGShapeAnalysisResults{})
+	assert.NotNil(t, err)
+	assert.Nil(t, tables)
+}
@@ -0,0 +1,34 @@
+package core
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+)
+
+// MessageToJSON writes the Protocol Buffers message as JSON. The field names are the same
+// as in the .proto definition and the fields with the default values are written too,
+// so that the schema does not depend on the data. The 64-bit integers are quoted
+// according to the Protocol Buffers JSON mapping.
+func MessageToJSON(message proto.Message, writer io.Writer) error {
+	marshaler := jsonpb.Marshaler{OrigName: true, EmitDefaults: true}
+	return marshaler.Marshal(writer, message)
+}
+
+// SerializeJSON writes the result of the leaf as JSON. The schema is the Protocol Buffers
+// message which the leaf writes in the binary mode, so `message` must be an empty message
+// of that type. It is the typical implementation of
+// JSONSerializablePipelineItem.SerializeJSON().
+func SerializeJSON(item LeafPipelineItem, result interface{}, message proto.Message,
+	writer io.Writer) error {
+	buffer := &bytes.Buffer{}
+	if err := item.Serialize(result, true, buffer); err != nil {
+		return err
+	}
+	if err := proto.Unmarshal(buffer.Bytes(), message); err != nil {
+		return err
+	}
+	return MessageToJSON(message, writer)
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+)
+
+func TestMessageToJSON(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, MessageToJSON(&pb.Metadata{
+		Version: 2, Repository: "test", BeginUnixTime: 100, People: []string{"alice"}}, buffer))
+	parsed := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(buffer.Bytes(), &parsed))
+	assert.Equal(t, parsed["version"], 2.0)
+	assert.Equal(t, parsed["repository"], "test")
+	// int64 is quoted
+	assert.Equal(t, parsed["begin_unix_time"], "100")
+	assert.Equal(t, parsed["people"], []interface{}{"alice"})
+	// the default values are present
+	assert.Equal(t, parsed["hash"], "")
+	assert.Equal(t, parsed["commits"], 0.0)
+	assert.Equal(t, parsed["annotations"], []interface{}{})
+}
+
+func TestSerializeJSON(t *testing.T) {
+	item := &testPipelineItem{}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, SerializeJSON(item, item.Finalize(), &pb.Metadata{}, buffer))
+	parsed := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(buffer.Bytes(), &parsed))
+	assert.Equal(t, parsed["version"], 0.0)
+	assert.Contains(t, parsed, "run_time_per_item")
+	buffer.Reset()
+	assert.NotNil(t, SerializeJSON(&failingSerializeItem{}, nil, &pb.Metadata{}, buffer))
+	assert.Equal(t, buffer.Len(), 0)
+}
+
+// failingSerializeItem writes garbage instead of a Protocol Buffers message.
+type failingSerializeItem struct {
+	testPipelineItem
+}
+
+func (item *failingSerializeItem) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	writer.Write([]byte{0xff, 0xff, 0xff})
+	return nil
+}
@@ -68,6 +68,9 @@ type ConfigurationOption struct {
 	Type ConfigurationOptionType
 	// Default is the initial value of the configuration option.
 	Default interface{}
+	// NoValue is the value of the flag if it is specified without one, e.g. "--flag" instead
+	// of "--flag=2". Empty means that the value is required, except for the bool options.
+	NoValue string
 }
 
 // FormatDefault converts the default value of ConfigurationOption to string.
@@ -147,6 +150,42 @@ type ResultMergeablePipelineItem interface {
 	MergeResults(r1, r2 interface{}, c1, c2 *CommonAnalysisResult) interface{}
 }
 
+// JSONSerializablePipelineItem is implemented by the LeafPipelineItem-s which can write
+// their results as JSON. All the built-in analyses implement it; the plugins may.
+type JSONSerializablePipelineItem interface {
+	LeafPipelineItem
+	// SerializeJSON encodes the object returned by Finalize() to JSON. SerializeJSON()
+	// helps to derive the JSON from the Protocol Buffers message.
+	SerializeJSON(result interface{}, writer io.Writer) error
+}
+
+// CSVExportablePipelineItem is implemented by the LeafPipelineItem-s which can export
+// their results as tidy CSV tables.
+type CSVExportablePipelineItem interface {
+	LeafPipelineItem
+	// ExportCSV converts the object returned by Finalize() to the tables. ExportCSV() helps to
+	// derive the tables from the Protocol Buffers message.
+	ExportCSV(result interface{}) ([]CSVTable, error)
+}
+
+// DynamicPipelineItem is implemented by the PipelineItem-s which Requires() depend on
+// the configuration. ConfigureRequirements() must be called before Pipeline.DeployItem() so that
+// the right upstream items are deployed; Configure() is called in Pipeline.Initialize() as usual.
+type DynamicPipelineItem interface {
+	PipelineItem
+	// ConfigureRequirements sets only the properties which change the result of Requires().
+	ConfigureRequirements(facts map[string]interface{})
+}
+
+// ContentPipelineItem is implemented by the PipelineItem-s which read the file contents.
+// They are excluded from the pipeline if ConfigPipelineFast is enabled.
+type ContentPipelineItem interface {
+	PipelineItem
+	// ContentOptional returns true if the dependent items can work without this item because
+	// its upstream provides the same entities, e.g. RenameAnalysis refines TreeDiff.
+	ContentOptional() bool
+}
+
 // CommonAnalysisResult holds the information which is always extracted at Pipeline.Run().
 type CommonAnalysisResult struct {
 	// BeginTime is the time of the first commit in the analysed sequence.
@@ -159,6 +198,28 @@ type CommonAnalysisResult struct {
 	RunTime time.Duration
 	// RunTimePerItem is the time elapsed by each PipelineItem.
 	RunTimePerItem map[string]float64
+	// ProfilePerItem is the resource usage of each PipelineItem. It is nil unless
+	// ConfigPipelineSelfProfile is enabled.
+	ProfilePerItem map[string]*ItemProfile
+	// Annotations are the labelled moments in the project history which are not produced by
+	// the pipeline but supplied by the user, e.g. with LoadAnnotations().
+	Annotations []Annotation
+	// People are the developer identities after the merging, e.g.
+	// FactIdentityDetectorReversedPeopleDict. They are not produced by the pipeline
+	// but supplied by the caller so that the downstream tools can audit them.
+	People []string
+	// UnparsedFiles are the files which could not be converted to UASTs, e.g. because
+	// the parser does not support their language or is unavailable. The structural analyses
+	// either skip them or fall back to the line-based heuristics.
+	UnparsedFiles map[string]UnparsedFile
+}
+
+// UnparsedFile explains why the structural analyses degraded on a file.
+type UnparsedFile struct {
+	// Language is the name of the language of the file, empty if it is unknown.
+	Language string
+	// Reason is "unsupported", "unavailable" or the parsing error.
+	Reason string
 }
 
 // BeginTimeAsTime converts the UNIX timestamp of the beginning to Go time.
@@ -189,6 +250,34 @@ func (car *CommonAnalysisResult) Merge(other *CommonAnalysisResult) {
 	for key, val := range other.RunTimePerItem {
 		car.RunTimePerItem[key] += val
 	}
+	if other.ProfilePerItem != nil && car.ProfilePerItem == nil {
+		car.ProfilePerItem = map[string]*ItemProfile{}
+	}
+	for key, val := range other.ProfilePerItem {
+		profile := car.ProfilePerItem[key]
+		if profile == nil {
+			profile = &ItemProfile{}
+			car.ProfilePerItem[key] = profile
+		}
+		profile.Add(val)
+	}
+	car.Annotations = mergeAnnotations(car.Annotations, other.Annotations)
+	known := map[string]bool{}
+	for _, person := range car.People {
+		known[person] = true
+	}
+	for _, person := range other.People {
+		if !known[person] {
+			known[person] = true
+			car.People = append(car.People, person)
+		}
+	}
+	if other.UnparsedFiles != nil && car.UnparsedFiles == nil {
+		car.UnparsedFiles = map[string]UnparsedFile{}
+	}
+	for file, val := range other.UnparsedFiles {
+		car.UnparsedFiles[file] = val
+	}
 }
 
 // FillMetadata copies the data to a Protobuf message.
@@ -198,6 +287,20 @@ func (car *CommonAnalysisResult) FillMetadata(meta *pb.Metadata) *pb.Metadata {
 	meta.Commits = int32(car.CommitsNumber)
 	meta.RunTime = car.RunTime.Nanoseconds() / 1e6
 	meta.RunTimePerItem = car.RunTimePerItem
+	if car.ProfilePerItem != nil {
+		meta.ProfilePerItem = map[string]*pb.ItemProfile{}
+		for key, val := range car.ProfilePerItem {
+			meta.ProfilePerItem[key] = val.ToProtobuf()
+		}
+	}
+	meta.Annotations = annotationsToProtobuf(car.Annotations)
+	meta.People = car.People
+	if len(car.UnparsedFiles) > 0 {
+		meta.UnparsedFiles = map[string]*pb.UnparsedFile{}
+		for file, val := range car.UnparsedFiles {
+			meta.UnparsedFiles[file] = &pb.UnparsedFile{Language: val.Language, Reason: val.Reason}
+		}
+	}
 	return meta
 }
 
@@ -206,13 +309,28 @@ type Metadata = pb.Metadata
 
 // MetadataToCommonAnalysisResult copies the data from a Protobuf message.
 func MetadataToCommonAnalysisResult(meta *Metadata) *CommonAnalysisResult {
-	return &CommonAnalysisResult{
+	result := &CommonAnalysisResult{
 		BeginTime:      meta.BeginUnixTime,
 		EndTime:        meta.EndUnixTime,
 		CommitsNumber:  int(meta.Commits),
 		RunTime:        time.Duration(meta.RunTime * 1e6),
 		RunTimePerItem: meta.RunTimePerItem,
+		Annotations:    annotationsFromProtobuf(meta.Annotations),
+		People:         meta.People,
+	}
+	if meta.ProfilePerItem != nil {
+		result.ProfilePerItem = map[string]*ItemProfile{}
+		for key, val := range meta.ProfilePerItem {
+			result.ProfilePerItem[key] = ItemProfileFromProtobuf(val)
+		}
 	}
+	if len(meta.UnparsedFiles) > 0 {
+		result.UnparsedFiles = map[string]UnparsedFile{}
+		for file, val := range meta.UnparsedFiles {
+			result.UnparsedFiles[file] = UnparsedFile{Language: val.Language, Reason: val.Reason}
+		}
+	}
+	return result
 }
 
 // Pipeline is the core Hercules entity which carries several PipelineItems and executes them.
@@ -235,6 +353,12 @@ type Pipeline struct {
 
 	// Feature flags which enable the corresponding items.
 	features map[string]bool
+
+	// selfProfile enables collecting CommonAnalysisResult.ProfilePerItem in Run().
+	selfProfile bool
+
+	// checkpointer is not nil if ConfigPipelineCheckpoint is enabled.
+	checkpointer *checkpointer
 }
 
 const (
@@ -249,6 +373,33 @@ const (
 	// ConfigPipelineCommits is the name of the Pipeline configuration option (Pipeline.Initialize())
 	// which allows to specify the custom commit sequence. By default, Pipeline.Commits() is used.
 	ConfigPipelineCommits = "commits"
+	// ConfigPipelineWorkers is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the maximum number of goroutines which all the items may use to parallelize
+	// their work. Zero means runtime.GOMAXPROCS(0).
+	ConfigPipelineWorkers = "Pipeline.Workers"
+	// FactWorkerPool is the name of the fact which is set by Pipeline.Initialize() before
+	// any Configure() call. It contains the *WorkerPool shared by all the items.
+	FactWorkerPool = "Pipeline.WorkerPool"
+	// ConfigPipelineSelfProfile is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which enables measuring the wall time, CPU time and allocations of each PipelineItem.
+	// The results are written to CommonAnalysisResult.ProfilePerItem.
+	ConfigPipelineSelfProfile = "Pipeline.SelfProfile"
+	// ConfigPipelineCachePath is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the Storage URI to keep the intermediate results between runs, e.g. tree diffs.
+	// See OpenStorage() for the supported URIs.
+	ConfigPipelineCachePath = "Pipeline.CachePath"
+	// FactPersistentCache is the name of the fact which is set by Pipeline.Initialize() before
+	// any Configure() call. It contains the Storage shared by all the items and does not exist
+	// if ConfigPipelineCachePath is not set.
+	FactPersistentCache = "Pipeline.PersistentCache"
+	// ConfigPipelineCheckpoint is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which enables Pipeline.SaveCheckpoint() and Pipeline.LoadCheckpoint(). They keep the results
+	// in the persistent cache so that the identical run does not analyse the commits again.
+	ConfigPipelineCheckpoint = "Pipeline.Checkpoint"
+	// ConfigPipelineFast is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which excludes all the ContentPipelineItem-s so that the file contents are never read.
+	// The analyses which need the contents, e.g. the burndown, cannot run in this mode.
+	ConfigPipelineFast = "Pipeline.Fast"
 	// DependencyCommit is the name of one of the three items in `deps` supplied to PipelineItem.Consume()
 	// which always exists. It corresponds to the currently analyzed commit.
 	DependencyCommit = "commit"
@@ -577,10 +728,16 @@ func (pipeline *Pipeline) resolve(dumpPath string) {
 // Initialize prepares the pipeline for the execution (Run()). This function
 // resolves the execution DAG, Configure()-s and Initialize()-s the items in it in the
 // topological dependency order. `facts` are passed inside Configure(). They are mutable.
+// The facts which were set with SetFact() and are missing in `facts` are added.
 func (pipeline *Pipeline) Initialize(facts map[string]interface{}) {
 	if facts == nil {
 		facts = map[string]interface{}{}
 	}
+	for key, val := range pipeline.facts {
+		if _, exists := facts[key]; !exists {
+			facts[key] = val
+		}
+	}
 	if _, exists := facts[ConfigPipelineCommits]; !exists {
 		var err error
 		facts[ConfigPipelineCommits], err = pipeline.Commits(false)
@@ -588,11 +745,39 @@ func (pipeline *Pipeline) Initialize(facts map[string]interface{}) {
 			log.Panicf("failed to list the commits: %v", err)
 		}
 	}
+	if _, exists := facts[FactWorkerPool].(*WorkerPool); !exists {
+		workers, _ := facts[ConfigPipelineWorkers].(int)
+		facts[FactWorkerPool] = NewWorkerPool(workers)
+	}
+	if _, exists := facts[FactPersistentCache].(Storage); !exists {
+		if uri, _ := facts[ConfigPipelineCachePath].(string); uri != "" {
+			storage, err := OpenStorage(uri)
+			if err != nil {
+				log.Printf("failed to open the persistent cache: %v", err)
+			} else {
+				facts[FactPersistentCache] = storage
+			}
+		}
+	}
+	pipeline.selfProfile, _ = facts[ConfigPipelineSelfProfile].(bool)
+	if fast, _ := facts[ConfigPipelineFast].(bool); fast {
+		if err := pipeline.excludeContentItems(); err != nil {
+			log.Panicf("the fast mode is not possible: %v", err)
+		}
+	}
 	dumpPath, _ := facts[ConfigPipelineDumpPath].(string)
 	pipeline.resolve(dumpPath)
 	if dryRun, _ := facts[ConfigPipelineDryRun].(bool); dryRun {
 		return
 	}
+	pipeline.checkpointer = nil
+	if checkpoint, _ := facts[ConfigPipelineCheckpoint].(bool); checkpoint {
+		if storage, exists := facts[FactPersistentCache].(Storage); exists {
+			pipeline.checkpointer = newCheckpointer(storage, pipeline.items, facts)
+		} else {
+			log.Println("checkpoints are disabled: there is no persistent cache")
+		}
+	}
 	for _, item := range pipeline.items {
 		item.Configure(facts)
 	}
@@ -601,6 +786,70 @@ func (pipeline *Pipeline) Initialize(facts map[string]interface{}) {
 	}
 }
 
+// excludeContentItems removes the ContentPipelineItem-s from the pipeline. The optional items
+// are removed first, then the rest if nothing depends on them.
+func (pipeline *Pipeline) excludeContentItems() error {
+	for _, item := range append([]PipelineItem{}, pipeline.items...) {
+		if citem, ok := item.(ContentPipelineItem); ok && citem.ContentOptional() {
+			pipeline.RemoveItem(item)
+		}
+	}
+	for removed := true; removed; {
+		removed = false
+		required := map[string]bool{}
+		for _, item := range pipeline.items {
+			for _, dep := range item.Requires() {
+				required[dep] = true
+			}
+		}
+		for _, item := range append([]PipelineItem{}, pipeline.items...) {
+			if _, ok := item.(ContentPipelineItem); !ok {
+				continue
+			}
+			needed := false
+			for _, dep := range item.Provides() {
+				needed = needed || required[dep]
+			}
+			if !needed {
+				pipeline.RemoveItem(item)
+				removed = true
+			}
+		}
+	}
+	providers := map[string][]PipelineItem{}
+	for _, item := range pipeline.items {
+		for _, dep := range item.Provides() {
+			providers[dep] = append(providers[dep], item)
+		}
+	}
+	var readsContent func(item PipelineItem, visited map[string]bool) bool
+	readsContent = func(item PipelineItem, visited map[string]bool) bool {
+		if _, ok := item.(ContentPipelineItem); ok {
+			return true
+		}
+		visited[item.Name()] = true
+		for _, dep := range item.Requires() {
+			for _, provider := range providers[dep] {
+				if !visited[provider.Name()] && readsContent(provider, visited) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	var blocked []string
+	for _, item := range pipeline.items {
+		if _, ok := item.(LeafPipelineItem); ok && readsContent(item, map[string]bool{}) {
+			blocked = append(blocked, item.Name())
+		}
+	}
+	if len(blocked) > 0 {
+		sort.Strings(blocked)
+		return fmt.Errorf("%s need the file contents", strings.Join(blocked, ", "))
+	}
+	return nil
+}
+
 // Run method executes the pipeline.
 //
 // `commits` is a slice with the git commits to analyse. Multiple branches are supported.
@@ -620,6 +869,10 @@ func (pipeline *Pipeline) Run(commits []*object.Commit) (map[LeafPipelineItem]in
 	rootClone := cloneItems(pipeline.items, 1)[0]
 	var newestTime int64
 	runTimePerItem := map[string]float64{}
+	var profiler selfProfiler
+	if pipeline.selfProfile {
+		profiler = selfProfiler{}
+	}
 
 	commitIndex := 0
 	for index, step := range plan {
@@ -640,7 +893,11 @@ func (pipeline *Pipeline) Run(commits []*object.Commit) (map[LeafPipelineItem]in
 			}
 			for _, item := range branches[firstItem] {
 				startTime := time.Now()
-				update, err := item.Consume(state)
+				var update map[string]interface{}
+				var err error
+				profiler.measure(item.Name(), func() {
+					update, err = item.Consume(state)
+				})
 				runTimePerItem[item.Name()] += time.Now().Sub(startTime).Seconds()
 				if err != nil {
 					log.Printf("%s failed on commit #%d (%d) %s\n",
@@ -684,7 +941,9 @@ func (pipeline *Pipeline) Run(commits []*object.Commit) (map[LeafPipelineItem]in
 	result := map[LeafPipelineItem]interface{}{}
 	for index, item := range getMasterBranch(branches) {
 		if casted, ok := item.(LeafPipelineItem); ok {
-			result[pipeline.items[index].(LeafPipelineItem)] = casted.Finalize()
+			profiler.measure(item.Name(), func() {
+				result[pipeline.items[index].(LeafPipelineItem)] = casted.Finalize()
+			})
 		}
 	}
 	onProgress(progressSteps, progressSteps)
@@ -694,6 +953,7 @@ func (pipeline *Pipeline) Run(commits []*object.Commit) (map[LeafPipelineItem]in
 		CommitsNumber:  len(commits),
 		RunTime:        time.Since(startRunTime),
 		RunTimePerItem: runTimePerItem,
+		ProfilePerItem: profiler,
 	}
 	return result, nil
 }
@@ -51,6 +51,7 @@ func (item *testPipelineItem) ListConfigurationOptions() []ConfigurationOption {
 		Flag:        "test-option",
 		Type:        IntConfigurationOption,
 		Default:     10,
+		NoValue:     "5",
 	}}
 	return options[:]
 }
@@ -248,6 +249,28 @@ func TestPipelineRun(t *testing.T) {
 	assert.Equal(t, 1, len(result))
 }
 
+func TestPipelineRunSelfProfile(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	item := &testPipelineItem{}
+	pipeline.AddItem(item)
+	pipeline.Initialize(map[string]interface{}{ConfigPipelineSelfProfile: true})
+	commits := make([]*object.Commit, 1)
+	commits[0], _ = test.Repository.CommitObject(plumbing.NewHash(
+		"af9ddc0db70f09f3f27b4b98e415592a7485171c"))
+	result, err := pipeline.Run(commits)
+	assert.Nil(t, err)
+	common := result[nil].(*CommonAnalysisResult)
+	assert.Len(t, common.ProfilePerItem, 1)
+	profile := common.ProfilePerItem[item.Name()]
+	assert.Equal(t, profile.Calls, int64(2))
+	assert.True(t, profile.WallTime > 0)
+	assert.True(t, profile.Allocations >= 0)
+	pipeline.Initialize(map[string]interface{}{})
+	result, err = pipeline.Run(commits)
+	assert.Nil(t, err)
+	assert.Nil(t, result[nil].(*CommonAnalysisResult).ProfilePerItem)
+}
+
 func TestPipelineRunBranches(t *testing.T) {
 	pipeline := NewPipeline(test.Repository)
 	item := &testPipelineItem{}
@@ -430,6 +453,26 @@ func TestCommonAnalysisResultMerge(t *testing.T) {
 	assert.Equal(t, c1.CommitsNumber, 3)
 	assert.Equal(t, c1.RunTime.Nanoseconds(), int64(300))
 	assert.Equal(t, c1.RunTimePerItem, map[string]float64{"one": 1, "two": 6, "three": 8})
+	c2.ProfilePerItem = map[string]*ItemProfile{"two": {Calls: 2, Allocations: 10}}
+	c1.Merge(&c2)
+	assert.Equal(t, c1.ProfilePerItem, map[string]*ItemProfile{"two": {Calls: 2, Allocations: 10}})
+	c1.Merge(&c2)
+	assert.Equal(t, c1.ProfilePerItem, map[string]*ItemProfile{"two": {Calls: 4, Allocations: 20}})
+	c1.Annotations = []Annotation{{Time: 200, Label: "two"}}
+	c2.Annotations = []Annotation{{Time: 100, Label: "one"}, {Time: 200, Label: "two"}}
+	c1.Merge(&c2)
+	assert.Equal(t, c1.Annotations, []Annotation{{Time: 100, Label: "one"}, {Time: 200, Label: "two"}})
+	c1.People = []string{"alice|alice@corp.com", "bob|bob@corp.com"}
+	c2.People = []string{"carol|carol@corp.com", "alice|alice@corp.com"}
+	c1.Merge(&c2)
+	assert.Equal(t, c1.People, []string{"alice|alice@corp.com", "bob|bob@corp.com", "carol|carol@corp.com"})
+	c2.UnparsedFiles = map[string]UnparsedFile{"a.cob": {Language: "COBOL", Reason: "unsupported"}}
+	c1.Merge(&c2)
+	c2.UnparsedFiles = map[string]UnparsedFile{"b.go": {Language: "Go", Reason: "unavailable"}}
+	c1.Merge(&c2)
+	assert.Equal(t, c1.UnparsedFiles, map[string]UnparsedFile{
+		"a.cob": {Language: "COBOL", Reason: "unsupported"},
+		"b.go":  {Language: "Go", Reason: "unavailable"}})
 }
 
 func TestCommonAnalysisResultMetadata(t *testing.T) {
@@ -443,6 +486,24 @@ func TestCommonAnalysisResultMetadata(t *testing.T) {
 	assert.Equal(t, c1.CommitsNumber, 1)
 	assert.Equal(t, c1.RunTime.Nanoseconds(), int64(100*1e6))
 	assert.Equal(t, c1.RunTimePerItem, map[string]float64{"one": 1, "two": 2})
+	assert.Nil(t, c1.ProfilePerItem)
+	c1.ProfilePerItem = map[string]*ItemProfile{"one": {
+		WallTime: time.Second, CPUTime: 2 * time.Second, Allocations: 3, AllocatedBytes: 4, Calls: 5}}
+	c1 = MetadataToCommonAnalysisResult(c1.FillMetadata(&pb.Metadata{}))
+	assert.Equal(t, c1.ProfilePerItem, map[string]*ItemProfile{"one": {
+		WallTime: time.Second, CPUTime: 2 * time.Second, Allocations: 3, AllocatedBytes: 4, Calls: 5}})
+	assert.Nil(t, c1.Annotations)
+	c1.Annotations = []Annotation{{Time: 1513620635, Label: "release"}}
+	c1 = MetadataToCommonAnalysisResult(c1.FillMetadata(&pb.Metadata{}))
+	assert.Equal(t, c1.Annotations, []Annotation{{Time: 1513620635, Label: "release"}})
+	c1.People = []string{"alice|alice@corp.com"}
+	c1 = MetadataToCommonAnalysisResult(c1.FillMetadata(&pb.Metadata{}))
+	assert.Equal(t, c1.People, []string{"alice|alice@corp.com"})
+	assert.Nil(t, c1.UnparsedFiles)
+	c1.UnparsedFiles = map[string]UnparsedFile{"a.cob": {Language: "COBOL", Reason: "unsupported"}}
+	c1 = MetadataToCommonAnalysisResult(c1.FillMetadata(&pb.Metadata{}))
+	assert.Equal(t, c1.UnparsedFiles, map[string]UnparsedFile{
+		"a.cob": {Language: "COBOL", Reason: "unsupported"}})
 }
 
 func TestConfigurationOptionTypeString(t *testing.T) {
@@ -689,3 +750,95 @@ func TestPrepareRunPlanBig(t *testing.T) {
 		}()
 	}
 }
+
+type fastTestPipelineItem struct {
+	NoopMerger
+	name     string
+	provides []string
+	requires []string
+}
+
+func (item *fastTestPipelineItem) Name() string {
+	return item.name
+}
+
+func (item *fastTestPipelineItem) Provides() []string {
+	return item.provides
+}
+
+func (item *fastTestPipelineItem) Requires() []string {
+	return item.requires
+}
+
+func (item *fastTestPipelineItem) ListConfigurationOptions() []ConfigurationOption {
+	return nil
+}
+
+func (item *fastTestPipelineItem) Configure(facts map[string]interface{}) {
+}
+
+func (item *fastTestPipelineItem) Initialize(repository *git.Repository) {
+}
+
+func (item *fastTestPipelineItem) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+func (item *fastTestPipelineItem) Fork(n int) []PipelineItem {
+	return ForkSamePipelineItem(item, n)
+}
+
+type contentTestPipelineItem struct {
+	fastTestPipelineItem
+	optional bool
+}
+
+func (item *contentTestPipelineItem) ContentOptional() bool {
+	return item.optional
+}
+
+type fastTestLeafPipelineItem struct {
+	fastTestPipelineItem
+}
+
+func (item *fastTestLeafPipelineItem) Flag() string {
+	return item.name
+}
+
+func (item *fastTestLeafPipelineItem) Description() string {
+	return ""
+}
+
+func (item *fastTestLeafPipelineItem) Finalize() interface{} {
+	return nil
+}
+
+func (item *fastTestLeafPipelineItem) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	return nil
+}
+
+func TestPipelineExcludeContentItems(t *testing.T) {
+	newItem := func(name string, provides, requires []string) fastTestPipelineItem {
+		return fastTestPipelineItem{name: name, provides: provides, requires: requires}
+	}
+	blobs := &contentTestPipelineItem{fastTestPipelineItem: newItem("Blobs", []string{"blobs"}, nil)}
+	renames := &contentTestPipelineItem{optional: true, fastTestPipelineItem: newItem(
+		"Renames", []string{"changes"}, []string{"blobs", "changes"})}
+	treeDiff := &fastTestPipelineItem{name: "TreeDiff", provides: []string{"changes"}}
+	files := &fastTestLeafPipelineItem{newItem("Files", nil, []string{"changes"})}
+	pipeline := NewPipeline(nil)
+	for _, item := range []PipelineItem{blobs, renames, treeDiff, files} {
+		pipeline.AddItem(item)
+	}
+	assert.Nil(t, pipeline.excludeContentItems())
+	assert.Equal(t, pipeline.items, []PipelineItem{treeDiff, files})
+
+	diff := &fastTestPipelineItem{name: "Diff", provides: []string{"diff"}, requires: []string{"blobs"}}
+	lines := &fastTestLeafPipelineItem{newItem("Lines", nil, []string{"diff", "changes"})}
+	pipeline = NewPipeline(nil)
+	for _, item := range []PipelineItem{blobs, renames, treeDiff, diff, files, lines} {
+		pipeline.AddItem(item)
+	}
+	assert.EqualError(t, pipeline.excludeContentItems(), "Lines need the file contents")
+	assert.Equal(t, pipeline.items, []PipelineItem{blobs, treeDiff, diff, files, lines})
+}
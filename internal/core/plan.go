@@ -0,0 +1,84 @@
+package core
+
+import (
+	"gopkg.in/src-d/go-git.v4"
+)
+
+// PipelinePlan is the immutable result of the pipeline assembly: the resolved sequence of
+// the items and the default facts. It is safe for concurrent use: NewPipeline() creates
+// independent Pipeline-s with their own items, so that several repositories can be analysed
+// in parallel from a single configuration.
+type PipelinePlan struct {
+	// prototypes are the copies of the original items in the execution order.
+	// They are never Configure()-d or Initialize()-d.
+	prototypes []PipelineItem
+	// facts are the defaults for Pipeline.Initialize() of each created Pipeline.
+	facts map[string]interface{}
+	// features are copied to each created Pipeline.
+	features map[string]bool
+}
+
+// Plan resolves the execution DAG of the deployed items and freezes it in a PipelinePlan.
+// `facts` become the defaults of every Pipeline created with PipelinePlan.NewPipeline();
+// they must not contain the repository-specific values such as ConfigPipelineCommits.
+// If `facts` contain a *WorkerPool in FactWorkerPool, it is shared by all the runs and thus
+// bounds the overall number of goroutines. Plan() must be called before Initialize();
+// the pipeline itself is not changed.
+func (pipeline *Pipeline) Plan(facts map[string]interface{}) (*PipelinePlan, error) {
+	draft := &Pipeline{items: append([]PipelineItem{}, pipeline.items...)}
+	if fast, _ := facts[ConfigPipelineFast].(bool); fast {
+		if err := draft.excludeContentItems(); err != nil {
+			return nil, err
+		}
+	}
+	dumpPath, _ := facts[ConfigPipelineDumpPath].(string)
+	draft.resolve(dumpPath)
+	plan := &PipelinePlan{
+		prototypes: make([]PipelineItem, len(draft.items)),
+		facts:      map[string]interface{}{},
+		features:   map[string]bool{},
+	}
+	for i, item := range draft.items {
+		plan.prototypes[i] = ForkCopyPipelineItem(item, 1)[0]
+	}
+	for key, val := range pipeline.facts {
+		plan.facts[key] = val
+	}
+	for key, val := range facts {
+		plan.facts[key] = val
+	}
+	// the DAG has already been written
+	delete(plan.facts, ConfigPipelineDumpPath)
+	for key, val := range pipeline.features {
+		plan.features[key] = val
+	}
+	return plan, nil
+}
+
+// Names returns the names of the items in the execution order.
+func (plan *PipelinePlan) Names() []string {
+	names := make([]string, len(plan.prototypes))
+	for i, item := range plan.prototypes {
+		names[i] = item.Name()
+	}
+	return names
+}
+
+// NewPipeline creates a new Pipeline which analyses the specified repository. The items are
+// copied by value from the originals which were passed to DeployItem() or AddItem(), so
+// the public fields are preserved. The facts passed to Plan() are the defaults
+// for Pipeline.Initialize(). go-git's object caches are not thread safe, so the concurrently
+// running Pipeline-s must not share the same *git.Repository.
+func (plan *PipelinePlan) NewPipeline(repository *git.Repository) *Pipeline {
+	pipeline := NewPipeline(repository)
+	for _, item := range plan.prototypes {
+		pipeline.AddItem(ForkCopyPipelineItem(item, 1)[0])
+	}
+	for key, val := range plan.facts {
+		pipeline.SetFact(key, val)
+	}
+	for key, val := range plan.features {
+		pipeline.features[key] = val
+	}
+	return pipeline
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func TestPipelinePlanConcurrentRuns(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	pipeline.SetFeature("power")
+	pipeline.SetFact("default", 1)
+	item1 := &dependingTestPipelineItem{}
+	item2 := &testPipelineItem{}
+	pipeline.AddItem(item1)
+	pipeline.AddItem(item2)
+	plan, err := pipeline.Plan(map[string]interface{}{"fact": "value"})
+	assert.Nil(t, err)
+	assert.Equal(t, plan.Names(), []string{"Test", "Test2"})
+	// the original items stay untouched
+	assert.Equal(t, pipeline.items, []PipelineItem{item1, item2})
+	assert.False(t, item2.Initialized)
+
+	commits := make([]*object.Commit, 1)
+	commits[0], _ = test.Repository.CommitObject(plumbing.NewHash(
+		"af9ddc0db70f09f3f27b4b98e415592a7485171c"))
+	const runs = 4
+	runners := make([]*Pipeline, runs)
+	results := make([]map[LeafPipelineItem]interface{}, runs)
+	errs := make([]error, runs)
+	wg := sync.WaitGroup{}
+	for i := 0; i < runs; i++ {
+		runners[i] = plan.NewPipeline(test.Repository)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			runners[i].Initialize(map[string]interface{}{"default": 2})
+			results[i], errs[i] = runners[i].Run(commits)
+		}(i)
+	}
+	wg.Wait()
+	seen := map[PipelineItem]bool{}
+	for i := 0; i < runs; i++ {
+		assert.Nil(t, errs[i])
+		assert.Equal(t, runners[i].GetFact("fact"), "value")
+		assert.Equal(t, runners[i].GetFact("default"), 1)
+		power, _ := runners[i].GetFeature("power")
+		assert.True(t, power)
+		assert.Len(t, results[i], 3)
+		for item := range results[i] {
+			if item == nil {
+				continue
+			}
+			assert.False(t, seen[item])
+			seen[item] = true
+			assert.True(t, item != LeafPipelineItem(item1))
+			assert.True(t, item != LeafPipelineItem(item2))
+			if casted, ok := item.(*testPipelineItem); ok {
+				assert.True(t, casted.Initialized)
+				assert.True(t, casted.CommitMatches)
+			}
+		}
+	}
+	assert.False(t, item2.Initialized)
+}
+
+func TestPipelinePlanFast(t *testing.T) {
+	blobs := &contentTestPipelineItem{fastTestPipelineItem: fastTestPipelineItem{
+		name: "Blobs", provides: []string{"blobs"}}}
+	diff := &fastTestPipelineItem{name: "Diff", provides: []string{"diff"}, requires: []string{"blobs"}}
+	lines := &fastTestLeafPipelineItem{fastTestPipelineItem{name: "Lines", requires: []string{"diff"}}}
+	pipeline := NewPipeline(nil)
+	for _, item := range []PipelineItem{lines, diff, blobs} {
+		pipeline.AddItem(item)
+	}
+	plan, err := pipeline.Plan(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, plan.Names(), []string{"Blobs", "Diff", "Lines"})
+	plan, err = pipeline.Plan(map[string]interface{}{ConfigPipelineFast: true})
+	assert.Nil(t, plan)
+	assert.EqualError(t, err, "Lines need the file contents")
+	assert.Equal(t, pipeline.items, []PipelineItem{lines, diff, blobs})
+	pipeline.RemoveItem(lines)
+	plan, err = pipeline.Plan(map[string]interface{}{ConfigPipelineFast: true})
+	assert.Nil(t, err)
+	assert.Equal(t, plan.Names(), []string{"Blobs", "Diff"})
+	runner := plan.NewPipeline(nil)
+	assert.Equal(t, runner.Len(), 2)
+	assert.True(t, runner.items[0] != PipelineItem(blobs))
+	assert.Equal(t, runner.GetFact(ConfigPipelineFast), true)
+}
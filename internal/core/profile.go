@@ -0,0 +1,102 @@
+package core
+
+import (
+	"runtime"
+	"time"
+
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+)
+
+// ItemProfile is the resource usage of a PipelineItem which is collected in Pipeline.Run()
+// if ConfigPipelineSelfProfile is enabled.
+type ItemProfile struct {
+	// WallTime is the elapsed real time.
+	WallTime time.Duration
+	// CPUTime is the CPU time of the whole process while the item was running. It includes
+	// the helper goroutines of the item and the garbage collector.
+	CPUTime time.Duration
+	// Allocations is the number of allocated heap objects.
+	Allocations int64
+	// AllocatedBytes is the number of allocated heap bytes.
+	AllocatedBytes int64
+	// Calls is the number of Consume() and Finalize() invocations.
+	Calls int64
+}
+
+// Add sums two ItemProfile-s.
+func (profile *ItemProfile) Add(other *ItemProfile) {
+	profile.WallTime += other.WallTime
+	profile.CPUTime += other.CPUTime
+	profile.Allocations += other.Allocations
+	profile.AllocatedBytes += other.AllocatedBytes
+	profile.Calls += other.Calls
+}
+
+// ToProtobuf converts ItemProfile to the corresponding Protobuf message.
+func (profile *ItemProfile) ToProtobuf() *pb.ItemProfile {
+	return &pb.ItemProfile{
+		WallTime:       profile.WallTime.Seconds(),
+		CpuTime:        profile.CPUTime.Seconds(),
+		Allocations:    profile.Allocations,
+		AllocatedBytes: profile.AllocatedBytes,
+		Calls:          profile.Calls,
+	}
+}
+
+// ItemProfileFromProtobuf converts the Protobuf message to ItemProfile.
+func ItemProfileFromProtobuf(message *pb.ItemProfile) *ItemProfile {
+	return &ItemProfile{
+		WallTime:       time.Duration(message.WallTime * float64(time.Second)),
+		CPUTime:        time.Duration(message.CpuTime * float64(time.Second)),
+		Allocations:    message.Allocations,
+		AllocatedBytes: message.AllocatedBytes,
+		Calls:          message.Calls,
+	}
+}
+
+// resourceSnapshot is the state of the process resource counters at some moment.
+type resourceSnapshot struct {
+	wall    time.Time
+	cpu     time.Duration
+	mallocs uint64
+	bytes   uint64
+}
+
+func takeResourceSnapshot() resourceSnapshot {
+	// ReadMemStats stops the world, this is why self-profiling is disabled by default
+	stats := runtime.MemStats{}
+	runtime.ReadMemStats(&stats)
+	return resourceSnapshot{
+		wall:    time.Now(),
+		cpu:     processCPUTime(),
+		mallocs: stats.Mallocs,
+		bytes:   stats.TotalAlloc,
+	}
+}
+
+// selfProfiler accumulates ItemProfile-s by PipelineItem names.
+type selfProfiler map[string]*ItemProfile
+
+// measure invokes `action` and records the consumed resources under the specified name.
+// A nil selfProfiler simply invokes `action`.
+func (profiler selfProfiler) measure(name string, action func()) {
+	if profiler == nil {
+		action()
+		return
+	}
+	start := takeResourceSnapshot()
+	action()
+	finish := takeResourceSnapshot()
+	profile := profiler[name]
+	if profile == nil {
+		profile = &ItemProfile{}
+		profiler[name] = profile
+	}
+	profile.Add(&ItemProfile{
+		WallTime:       finish.wall.Sub(start.wall),
+		CPUTime:        finish.cpu - start.cpu,
+		Allocations:    int64(finish.mallocs - start.mallocs),
+		AllocatedBytes: int64(finish.bytes - start.bytes),
+		Calls:          1,
+	})
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+)
+
+func TestSelfProfilerMeasure(t *testing.T) {
+	var profiler selfProfiler
+	called := 0
+	profiler.measure("nil", func() { called++ })
+	assert.Equal(t, called, 1)
+	profiler = selfProfiler{}
+	var sink [][]byte
+	for i := 0; i < 2; i++ {
+		profiler.measure("item", func() {
+			for j := 0; j < 100; j++ {
+				sink = append(sink, make([]byte, 1024))
+			}
+			time.Sleep(time.Millisecond)
+		})
+	}
+	assert.Len(t, sink, 200)
+	assert.Len(t, profiler, 1)
+	profile := profiler["item"]
+	assert.Equal(t, profile.Calls, int64(2))
+	assert.True(t, profile.WallTime >= 2*time.Millisecond)
+	assert.True(t, profile.CPUTime >= 0)
+	assert.True(t, profile.Allocations >= 200)
+	assert.True(t, profile.AllocatedBytes >= 200*1024)
+}
+
+func TestItemProfileProtobuf(t *testing.T) {
+	profile := &ItemProfile{
+		WallTime: 1500 * time.Millisecond, CPUTime: 3 * time.Second,
+		Allocations: 10, AllocatedBytes: 20, Calls: 30}
+	meta := pb.Metadata{ProfilePerItem: map[string]*pb.ItemProfile{"item": profile.ToProtobuf()}}
+	data, err := proto.Marshal(&meta)
+	assert.Nil(t, err)
+	meta = pb.Metadata{}
+	assert.Nil(t, proto.Unmarshal(data, &meta))
+	assert.Equal(t, meta.ProfilePerItem["item"].WallTime, 1.5)
+	assert.Equal(t, ItemProfileFromProtobuf(meta.ProfilePerItem["item"]), profile)
+	profile.Add(profile)
+	assert.Equal(t, profile, &ItemProfile{
+		WallTime: 3 * time.Second, CPUTime: 6 * time.Second,
+		Allocations: 20, AllocatedBytes: 40, Calls: 60})
+}
@@ -185,6 +185,9 @@ func (registry *PipelineItemRegistry) AddFlags(flagSet *pflag.FlagSet) (
 				ptr := (**[]string)(getPtr())
 				*ptr = flagSet.StringSlice(opt.Flag, opt.Default.([]string), formatHelp(opt.Description))
 			}
+			if opt.NoValue != "" {
+				flagSet.Lookup(opt.Flag).NoOptDefVal = opt.NoValue
+			}
 			flags[opt.Name] = iface
 		}
 		if fpi, ok := itemIface.(FeaturedPipelineItem); ok {
@@ -208,6 +211,37 @@ func (registry *PipelineItemRegistry) AddFlags(flagSet *pflag.FlagSet) (
 		*ptr2 = flagSet.Bool("dry-run", false, "Do not run any analyses - only resolve the DAG. "+
 			"Useful for --dump-dag.")
 		flags[ConfigPipelineDryRun] = iface
+		iface = interface{}(0)
+		ptr3 := (**int)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr3 = flagSet.Int("workers", 0, "Maximum number of goroutines to prefetch blobs, "+
+			"calculate diffs, score rename candidates and extract UASTs. "+
+			"0 means all the available CPUs.")
+		flags[ConfigPipelineWorkers] = iface
+		iface = interface{}(true)
+		ptr4 := (**bool)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr4 = flagSet.Bool("self-profile", false, "Measure the wall time, CPU time and "+
+			"allocations of each pipeline item and include them in the output metadata.")
+		flags[ConfigPipelineSelfProfile] = iface
+		iface = interface{}("")
+		ptr5 := (**string)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr5 = flagSet.String("persistent-cache", "", "Keep the tree diffs, the detected "+
+			"renames, the UASTs, the spilled blobs and the checkpoints in the specified storage "+
+			"to reuse them in the subsequent runs: "+
+			"a local directory, redis://host:port/db or s3://bucket/prefix.")
+		flags[ConfigPipelineCachePath] = iface
+		iface = interface{}(true)
+		ptr6 := (**bool)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr6 = flagSet.Bool("fast", false, "Never read the file contents: skip the diffs, the "+
+			"blob cache and the rename detection. Only the analyses which need the commit metadata "+
+			"and the changed file names can run, e.g. --activity and --couples. If no analysis is "+
+			"chosen, both are enabled.")
+		flags[ConfigPipelineFast] = iface
+		iface = interface{}(true)
+		ptr7 := (**bool)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr7 = flagSet.Bool("checkpoint", false, "Save the results in the persistent cache "+
+			"(--persistent-cache) and reuse them if the same analyses with the same options run "+
+			"on the same commits again.")
+		flags[ConfigPipelineCheckpoint] = iface
 	}
 	features := []string{}
 	for f := range registry.featureFlags.Choices {
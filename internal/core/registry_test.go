@@ -129,22 +129,32 @@ func TestRegistryAddFlags(t *testing.T) {
 		Run:   func(cmd *cobra.Command, args []string) {},
 	}
 	facts, deployed := reg.AddFlags(testCmd.Flags())
-	assert.Len(t, facts, 4)
+	assert.Len(t, facts, 9)
 	assert.IsType(t, 0, facts[(&testPipelineItem{}).ListConfigurationOptions()[0].Name])
 	assert.IsType(t, true, facts[(&dummyPipelineItem{}).ListConfigurationOptions()[0].Name])
 	assert.Contains(t, facts, ConfigPipelineDryRun)
 	assert.Contains(t, facts, ConfigPipelineDumpPath)
+	assert.Contains(t, facts, ConfigPipelineWorkers)
+	assert.Contains(t, facts, ConfigPipelineSelfProfile)
+	assert.Contains(t, facts, ConfigPipelineCachePath)
+	assert.Contains(t, facts, ConfigPipelineFast)
+	assert.Contains(t, facts, ConfigPipelineCheckpoint)
 	assert.Len(t, deployed, 1)
 	assert.Contains(t, deployed, (&testPipelineItem{}).Name())
 	assert.NotNil(t, testCmd.Flags().Lookup((&testPipelineItem{}).Flag()))
 	assert.NotNil(t, testCmd.Flags().Lookup("feature"))
 	assert.NotNil(t, testCmd.Flags().Lookup("dump-dag"))
 	assert.NotNil(t, testCmd.Flags().Lookup("dry-run"))
+	assert.NotNil(t, testCmd.Flags().Lookup("self-profile"))
+	assert.NotNil(t, testCmd.Flags().Lookup("persistent-cache"))
+	assert.NotNil(t, testCmd.Flags().Lookup("checkpoint"))
 	assert.NotNil(t, testCmd.Flags().Lookup(
 		(&testPipelineItem{}).ListConfigurationOptions()[0].Flag))
 	assert.NotNil(t, testCmd.Flags().Lookup(
 		(&dummyPipelineItem{}).ListConfigurationOptions()[0].Flag))
 	testCmd.UsageString() // to test that nothing is broken
+	assert.Nil(t, testCmd.ParseFlags([]string{"--test-option"}))
+	assert.Equal(t, facts[(&testPipelineItem{}).ListConfigurationOptions()[0].Name].(int), 5)
 }
 
 func TestRegistryFeatures(t *testing.T) {
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Storage keeps arbitrary binary values under string keys so that they can be shared between
+// runs and between the workers which analyse the same repositories. PipelineItem-s use it to skip
+// the expensive calculations which were done before, e.g. tree diffs. The instance shared by all
+// the items is passed in FactPersistentCache. The implementations must be safe for concurrent use.
+type Storage interface {
+	// Get returns the value which was stored under the key. Both the value and the error are nil
+	// if the key does not exist.
+	Get(key string) ([]byte, error)
+	// Set stores the value under the key, overwriting the previous value.
+	Set(key string, value []byte) error
+}
+
+// StorageFactory creates a Storage from the parsed URI.
+type StorageFactory func(uri *url.URL) (Storage, error)
+
+// storageFactories maps URI schemes to the Storage constructors.
+var storageFactories = map[string]StorageFactory{
+	"file": func(uri *url.URL) (Storage, error) {
+		return NewDiskCache(uri.Host + uri.Path)
+	},
+}
+
+// RegisterStorage adds the support of the new URI scheme to OpenStorage(). Plugins can use it
+// to provide their own backends.
+func RegisterStorage(scheme string, factory StorageFactory) {
+	storageFactories[scheme] = factory
+}
+
+// OpenStorage creates the Storage which corresponds to the URI. The supported schemes are
+// file:// and those added with RegisterStorage(); a string without "://" is a local directory.
+func OpenStorage(uri string) (Storage, error) {
+	if !strings.Contains(uri, "://") {
+		return NewDiskCache(uri)
+	}
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	factory, exists := storageFactories[parsed.Scheme]
+	if !exists {
+		return nil, fmt.Errorf("unsupported storage scheme: %s", parsed.Scheme)
+	}
+	return factory(parsed)
+}
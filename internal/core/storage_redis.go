@@ -0,0 +1,128 @@
+package core
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/src-d/hercules.v4/internal/resp"
+)
+
+// RedisStorage is the Storage which keeps the values in Redis. It executes the commands over
+// a single connection which is reopened after any network error.
+type RedisStorage struct {
+	// Prefix is prepended to all the keys.
+	Prefix string
+	// TTL is the expiration time of the stored values; zero means no expiration.
+	TTL time.Duration
+
+	address  string
+	password string
+	database int
+	lock     sync.Mutex
+	conn     net.Conn
+	client   *resp.Conn
+}
+
+// NewRedisStorage creates a new RedisStorage from the URI in the format
+// redis://[:password@]host[:port][/database][?prefix=hercules:&ttl=720h].
+func NewRedisStorage(uri *url.URL) (Storage, error) {
+	storage := &RedisStorage{address: uri.Host, Prefix: "hercules:"}
+	if uri.Port() == "" {
+		storage.address = net.JoinHostPort(uri.Hostname(), "6379")
+	}
+	if uri.User != nil {
+		storage.password, _ = uri.User.Password()
+	}
+	if db := strings.Trim(uri.Path, "/"); db != "" {
+		var err error
+		if storage.database, err = strconv.Atoi(db); err != nil {
+			return nil, fmt.Errorf("invalid Redis database %q", db)
+		}
+	}
+	query := uri.Query()
+	if prefix, exists := query["prefix"]; exists {
+		storage.Prefix = prefix[0]
+	}
+	if ttl := query.Get("ttl"); ttl != "" {
+		var err error
+		if storage.TTL, err = time.ParseDuration(ttl); err != nil {
+			return nil, err
+		}
+	}
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	if err := storage.connect(); err != nil {
+		return nil, err
+	}
+	return storage, nil
+}
+
+// Get returns the value which was stored under the key or nil if there is no such value.
+func (storage *RedisStorage) Get(key string) ([]byte, error) {
+	return storage.do("GET", storage.Prefix+key)
+}
+
+// Set stores the value under the key.
+func (storage *RedisStorage) Set(key string, value []byte) error {
+	args := []string{"SET", storage.Prefix + key, string(value)}
+	if storage.TTL > 0 {
+		args = append(args, "PX", strconv.FormatInt(int64(storage.TTL/time.Millisecond), 10))
+	}
+	_, err := storage.do(args...)
+	return err
+}
+
+// connect opens the connection, authenticates and selects the database.
+// The caller must hold the lock.
+func (storage *RedisStorage) connect() error {
+	conn, err := net.DialTimeout("tcp", storage.address, 10*time.Second)
+	if err != nil {
+		return err
+	}
+	storage.conn = conn
+	storage.client = resp.NewConn(conn)
+	if storage.password != "" {
+		if _, err = storage.client.Do("AUTH", storage.password); err != nil {
+			storage.close()
+			return err
+		}
+	}
+	if storage.database != 0 {
+		if _, err = storage.client.Do("SELECT", strconv.Itoa(storage.database)); err != nil {
+			storage.close()
+			return err
+		}
+	}
+	return nil
+}
+
+func (storage *RedisStorage) close() {
+	storage.conn.Close()
+	storage.conn = nil
+	storage.client = nil
+}
+
+// do executes the command and reconnects if the connection was lost.
+func (storage *RedisStorage) do(args ...string) ([]byte, error) {
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	if storage.conn == nil {
+		if err := storage.connect(); err != nil {
+			return nil, err
+		}
+	}
+	reply, err := storage.client.Do(args...)
+	if _, isReplyError := err.(resp.Error); err != nil && !isReplyError {
+		storage.close()
+	}
+	return reply, err
+}
+
+func init() {
+	RegisterStorage("redis", NewRedisStorage)
+}
@@ -0,0 +1,141 @@
+package core
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/src-d/hercules.v4/internal/sigv4"
+)
+
+// S3Storage is the Storage which keeps the values as objects in an S3 bucket. It works with
+// any S3-compatible service, e.g. Minio. The requests are signed with AWS Signature Version 4.
+type S3Storage struct {
+	// Endpoint is the base URL of the service, e.g. https://s3.us-east-1.amazonaws.com
+	Endpoint string
+	// Bucket is the name of the bucket.
+	Bucket string
+	// Prefix is prepended to all the object names.
+	Prefix string
+	// Region is the bucket's region.
+	Region string
+	// AccessKeyID, SecretAccessKey and SessionToken are the credentials.
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	client *http.Client
+	// now is replaced in the tests
+	now func() time.Time
+}
+
+// NewS3Storage creates a new S3Storage from the URI in the format
+// s3://bucket[/prefix][?region=us-east-1&endpoint=http://localhost:9000].
+// The credentials are taken from the standard AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+// AWS_SESSION_TOKEN environment variables; the region defaults to AWS_REGION.
+func NewS3Storage(uri *url.URL) (Storage, error) {
+	query := uri.Query()
+	storage := &S3Storage{
+		Endpoint:        query.Get("endpoint"),
+		Bucket:          uri.Host,
+		Prefix:          strings.TrimPrefix(uri.Path, "/"),
+		Region:          query.Get("region"),
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		client:          &http.Client{Timeout: time.Minute},
+		now:             time.Now,
+	}
+	if storage.Bucket == "" {
+		return nil, errors.New("s3: the bucket is not specified")
+	}
+	if storage.Region == "" {
+		storage.Region = os.Getenv("AWS_REGION")
+	}
+	if storage.Region == "" {
+		storage.Region = "us-east-1"
+	}
+	if storage.Endpoint == "" {
+		storage.Endpoint = "https://s3." + storage.Region + ".amazonaws.com"
+	}
+	if storage.AccessKeyID == "" || storage.SecretAccessKey == "" {
+		return nil, errors.New("s3: AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set")
+	}
+	return storage, nil
+}
+
+// objectURL returns the path-style URL of the object which corresponds to the key.
+func (storage *S3Storage) objectURL(key string) string {
+	hash := sha1.Sum([]byte(key))
+	return strings.TrimRight(storage.Endpoint, "/") + "/" + storage.Bucket + "/" +
+		storage.Prefix + hex.EncodeToString(hash[:])
+}
+
+// Get returns the value which was stored under the key or nil if there is no such value.
+func (storage *S3Storage) Get(key string) ([]byte, error) {
+	response, err := storage.request("GET", storage.objectURL(key), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("s3: GET %s: %s", key, response.Status)
+	}
+	return body, nil
+}
+
+// Set stores the value under the key.
+func (storage *S3Storage) Set(key string, value []byte) error {
+	response, err := storage.request("PUT", storage.objectURL(key), value)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	ioutil.ReadAll(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("s3: PUT %s: %s", key, response.Status)
+	}
+	return nil
+}
+
+func (storage *S3Storage) request(method, url string, body []byte) (*http.Response, error) {
+	request, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	storage.sign(request, body)
+	return storage.client.Do(request)
+}
+
+// sign adds the AWS Signature Version 4 to the request.
+func (storage *S3Storage) sign(request *http.Request, body []byte) {
+	signer := sigv4.Signer{
+		Credentials: sigv4.Credentials{
+			AccessKeyID:     storage.AccessKeyID,
+			SecretAccessKey: storage.SecretAccessKey,
+			SessionToken:    storage.SessionToken,
+		},
+		Region:  storage.Region,
+		Service: "s3",
+	}
+	signer.Sign(request, body, storage.now())
+}
+
+func init() {
+	RegisterStorage("s3", NewS3Storage)
+}
@@ -0,0 +1,393 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+)
+
+// VCSAdapter reads the history of a version control system other than Git, e.g. a Mercurial
+// or Subversion dump, and feeds it to HistoryBuilder. The result is a regular *git.Repository,
+// so the whole pipeline works the same way as with the native Git repositories.
+type VCSAdapter interface {
+	// Import reads the history from `source` - the adapter decides what it means, e.g. a file
+	// path or "-" for stdin - and adds the revisions to `builder` in topological order.
+	Import(source string, builder *HistoryBuilder) error
+}
+
+// Revision is a single commit in the imported history.
+type Revision struct {
+	// ID is the identifier of the revision in the original VCS.
+	ID string
+	// Parents are the IDs of the parent revisions. The changes are applied to the tree
+	// of the first parent.
+	Parents []string
+	// Author is the person who made the change.
+	Author object.Signature
+	// Committer is the person who applied the change. Equals to Author if empty.
+	Committer object.Signature
+	// Message is the commit message.
+	Message string
+	// Reset discards the tree of the first parent and starts from the empty one.
+	Reset bool
+	// Changes are the file modifications made in this revision. Renames are represented
+	// as a deletion and an addition, RenameAnalysis detects them later.
+	Changes []FileChange
+}
+
+// FileChange is a single file modification in Revision.
+type FileChange struct {
+	// Path is the slash-separated file path relative to the repository root.
+	Path string
+	// Blob is the hash returned by HistoryBuilder.AddBlob(). Ignored if Delete is true
+	// or From is set.
+	Blob plumbing.Hash
+	// From is the path of the file in the tree being built to copy to Path, including the
+	// preceding changes of the same revision.
+	From string
+	// Mode is the file mode. filemode.Regular is used if it is empty.
+	Mode filemode.FileMode
+	// Delete removes the file.
+	Delete bool
+}
+
+// HistoryBuilder converts the foreign history into Git objects in memory.
+// VCSAdapter-s call AddBlob() and AddRevision().
+type HistoryBuilder struct {
+	storage   *memory.Storage
+	revisions map[string]plumbing.Hash
+	trees     map[plumbing.Hash]plumbing.Hash
+	last      plumbing.Hash
+	lastID    string
+	root      *treeDir
+	head      plumbing.ReferenceName
+}
+
+// treeDir is a directory in the tree of the revision which is being built. The subdirectories
+// are loaded from the storage only when they change.
+type treeDir struct {
+	hash    plumbing.Hash
+	loaded  bool
+	entries map[string]object.TreeEntry
+	dirs    map[string]*treeDir
+}
+
+func newTreeDir() *treeDir {
+	return &treeDir{
+		loaded: true, entries: map[string]object.TreeEntry{}, dirs: map[string]*treeDir{}}
+}
+
+// NewHistoryBuilder creates an empty HistoryBuilder.
+func NewHistoryBuilder() *HistoryBuilder {
+	return &HistoryBuilder{
+		storage:   memory.NewStorage(),
+		revisions: map[string]plumbing.Hash{},
+		trees:     map[plumbing.Hash]plumbing.Hash{},
+	}
+}
+
+// AddBlob stores the file contents and returns the hash to use in FileChange.
+func (builder *HistoryBuilder) AddBlob(contents []byte) (plumbing.Hash, error) {
+	obj := builder.storage.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+	obj.SetSize(int64(len(contents)))
+	writer, err := obj.Writer()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if _, err = writer.Write(contents); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if err = writer.Close(); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return builder.storage.SetEncodedObject(obj)
+}
+
+// Lookup returns the hash of the Git commit which corresponds to the imported revision.
+func (builder *HistoryBuilder) Lookup(id string) (plumbing.Hash, bool) {
+	hash, exists := builder.revisions[id]
+	return hash, exists
+}
+
+// AddRevision writes the commit which corresponds to the revision and returns its hash.
+// The parents must have been added before.
+func (builder *HistoryBuilder) AddRevision(revision Revision) (plumbing.Hash, error) {
+	if _, exists := builder.revisions[revision.ID]; exists {
+		return plumbing.ZeroHash, fmt.Errorf("duplicate revision %s", revision.ID)
+	}
+	parents := make([]plumbing.Hash, len(revision.Parents))
+	for i, id := range revision.Parents {
+		hash, exists := builder.revisions[id]
+		if !exists {
+			return plumbing.ZeroHash, fmt.Errorf("revision %s: unknown parent %s", revision.ID, id)
+		}
+		parents[i] = hash
+	}
+	switch {
+	case revision.Reset || len(parents) == 0:
+		builder.root = newTreeDir()
+	case parents[0] != builder.last:
+		// the history forked, restart from the tree of the first parent
+		builder.root = &treeDir{hash: builder.trees[parents[0]]}
+	}
+	for _, change := range revision.Changes {
+		var err error
+		if change.Delete {
+			err = builder.remove(builder.root, strings.Split(change.Path, "/"))
+		} else {
+			entry := object.TreeEntry{Mode: change.Mode, Hash: change.Blob}
+			if change.From != "" {
+				entry, err = builder.find(builder.root, strings.Split(change.From, "/"))
+				if err != nil {
+					err = fmt.Errorf("copy from %s: %v", change.From, err)
+				}
+			}
+			if entry.Mode == filemode.Empty {
+				entry.Mode = filemode.Regular
+			}
+			if err == nil {
+				err = builder.put(builder.root, strings.Split(change.Path, "/"), entry)
+			}
+		}
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("revision %s: %s: %v", revision.ID, change.Path, err)
+		}
+	}
+	treeHash, err := builder.writeTree(builder.root)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	commit := &object.Commit{
+		Author:       revision.Author,
+		Committer:    revision.Committer,
+		Message:      revision.Message,
+		TreeHash:     treeHash,
+		ParentHashes: parents,
+	}
+	if commit.Committer.Name == "" && commit.Committer.Email == "" {
+		commit.Committer = commit.Author
+	}
+	obj := builder.storage.NewEncodedObject()
+	if err = commit.Encode(obj); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	hash, err := builder.storage.SetEncodedObject(obj)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	builder.revisions[revision.ID] = hash
+	builder.trees[hash] = treeHash
+	builder.last = hash
+	builder.lastID = revision.ID
+	return hash, nil
+}
+
+// SetReference points the branch or the tag, e.g. "refs/heads/default", to the revision.
+// The first branch becomes HEAD.
+func (builder *HistoryBuilder) SetReference(name plumbing.ReferenceName, id string) error {
+	hash, exists := builder.revisions[id]
+	if !exists {
+		return fmt.Errorf("reference %s: unknown revision %s", name, id)
+	}
+	if builder.head == "" && name.IsBranch() {
+		builder.head = name
+	}
+	return builder.storage.SetReference(plumbing.NewHashReference(name, hash))
+}
+
+// Repository finishes the import and returns the Git repository with the converted history.
+// If no branches were set, refs/heads/master points to the last added revision.
+func (builder *HistoryBuilder) Repository() (*git.Repository, error) {
+	if builder.head == "" {
+		if builder.last == plumbing.ZeroHash {
+			return nil, fmt.Errorf("the imported history is empty")
+		}
+		if err := builder.SetReference(plumbing.Master, builder.lastID); err != nil {
+			return nil, err
+		}
+	}
+	err := builder.storage.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, builder.head))
+	if err != nil {
+		return nil, err
+	}
+	return git.Open(builder.storage, nil)
+}
+
+// load reads the directory contents from the storage if they have not been read yet.
+func (builder *HistoryBuilder) load(dir *treeDir) error {
+	if dir.loaded {
+		return nil
+	}
+	dir.loaded = true
+	dir.entries = map[string]object.TreeEntry{}
+	dir.dirs = map[string]*treeDir{}
+	if dir.hash == plumbing.ZeroHash {
+		return nil
+	}
+	tree, err := object.GetTree(builder.storage, dir.hash)
+	if err != nil {
+		return err
+	}
+	for _, entry := range tree.Entries {
+		if entry.Mode == filemode.Dir {
+			dir.dirs[entry.Name] = &treeDir{hash: entry.Hash}
+		} else {
+			dir.entries[entry.Name] = entry
+		}
+	}
+	return nil
+}
+
+// find returns the file entry at the path in the tree being built.
+func (builder *HistoryBuilder) find(dir *treeDir, path []string) (object.TreeEntry, error) {
+	for ; len(path) > 1; path = path[1:] {
+		if err := builder.load(dir); err != nil {
+			return object.TreeEntry{}, err
+		}
+		if dir = dir.dirs[path[0]]; dir == nil {
+			return object.TreeEntry{}, fmt.Errorf("file not found")
+		}
+	}
+	if err := builder.load(dir); err != nil {
+		return object.TreeEntry{}, err
+	}
+	entry, exists := dir.entries[path[0]]
+	if !exists {
+		return object.TreeEntry{}, fmt.Errorf("file not found")
+	}
+	return entry, nil
+}
+
+func (builder *HistoryBuilder) put(dir *treeDir, path []string, entry object.TreeEntry) error {
+	if err := builder.load(dir); err != nil {
+		return err
+	}
+	dir.hash = plumbing.ZeroHash
+	name := path[0]
+	if name == "" {
+		return fmt.Errorf("invalid path")
+	}
+	if len(path) == 1 {
+		delete(dir.dirs, name)
+		entry.Name = name
+		dir.entries[name] = entry
+		return nil
+	}
+	delete(dir.entries, name)
+	sub := dir.dirs[name]
+	if sub == nil {
+		sub = newTreeDir()
+		dir.dirs[name] = sub
+	}
+	return builder.put(sub, path[1:], entry)
+}
+
+func (builder *HistoryBuilder) remove(dir *treeDir, path []string) error {
+	if err := builder.load(dir); err != nil {
+		return err
+	}
+	name := path[0]
+	if len(path) == 1 {
+		_, isFile := dir.entries[name]
+		_, isDir := dir.dirs[name]
+		if isFile || isDir {
+			dir.hash = plumbing.ZeroHash
+		}
+		delete(dir.entries, name)
+		delete(dir.dirs, name)
+		return nil
+	}
+	sub := dir.dirs[name]
+	if sub == nil {
+		return nil
+	}
+	if err := builder.remove(sub, path[1:]); err != nil {
+		return err
+	}
+	if sub.hash == plumbing.ZeroHash {
+		dir.hash = plumbing.ZeroHash
+		if len(sub.entries) == 0 && len(sub.dirs) == 0 {
+			delete(dir.dirs, name)
+		}
+	}
+	return nil
+}
+
+// writeTree stores the changed directories and returns the hash of `dir`.
+func (builder *HistoryBuilder) writeTree(dir *treeDir) (plumbing.Hash, error) {
+	if dir.hash != plumbing.ZeroHash {
+		return dir.hash, nil
+	}
+	entries := make([]object.TreeEntry, 0, len(dir.entries)+len(dir.dirs))
+	for _, entry := range dir.entries {
+		entries = append(entries, entry)
+	}
+	for name, sub := range dir.dirs {
+		hash, err := builder.writeTree(sub)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		entries = append(entries, object.TreeEntry{Name: name, Mode: filemode.Dir, Hash: hash})
+	}
+	// Git compares the directory names as if they ended with a slash
+	sortKey := func(entry object.TreeEntry) string {
+		if entry.Mode == filemode.Dir {
+			return entry.Name + "/"
+		}
+		return entry.Name
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return sortKey(entries[i]) < sortKey(entries[j])
+	})
+	obj := builder.storage.NewEncodedObject()
+	if err := (&object.Tree{Entries: entries}).Encode(obj); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	hash, err := builder.storage.SetEncodedObject(obj)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	dir.hash = hash
+	return hash, nil
+}
+
+// vcsAdapters maps the names to the registered VCSAdapter-s.
+var vcsAdapters = map[string]VCSAdapter{}
+
+// RegisterVCSAdapter makes the adapter available in ImportHistory(). Plugins can use it
+// to support more version control systems.
+func RegisterVCSAdapter(name string, adapter VCSAdapter) {
+	vcsAdapters[name] = adapter
+}
+
+// VCSAdapters returns the sorted names of the registered VCSAdapter-s.
+func VCSAdapters() []string {
+	names := make([]string, 0, len(vcsAdapters))
+	for name := range vcsAdapters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ImportHistory converts the history read by the named VCSAdapter into a Git repository
+// in memory.
+func ImportHistory(adapter string, source string) (*git.Repository, error) {
+	impl, exists := vcsAdapters[adapter]
+	if !exists {
+		return nil, fmt.Errorf("unknown VCS adapter %q, supported: %s",
+			adapter, strings.Join(VCSAdapters(), ", "))
+	}
+	builder := NewHistoryBuilder()
+	if err := impl.Import(source, builder); err != nil {
+		return nil, err
+	}
+	return builder.Repository()
+}
@@ -0,0 +1,471 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// FastExportAdapter reads the stream produced by `git fast-export` or any other tool which
+// speaks the fast-import format, e.g. hg-fast-export or svn-fast-export. The source is the path
+// to the file with the stream or "-" for stdin. Run fast-export with -M to include the renames.
+// It is a VCSAdapter.
+type FastExportAdapter struct {
+}
+
+// fastExportReader parses the fast-import stream. The format is described in
+// https://git-scm.com/docs/git-fast-import
+type fastExportReader struct {
+	input   *bufio.Reader
+	builder *HistoryBuilder
+	line    string
+	lineno  int
+	eof     bool
+	// marks map ":<idnum>" to the blob hashes
+	blobs map[string]plumbing.Hash
+	// tips map the branch names to the IDs of the latest revisions
+	tips map[string]string
+	// revisions is the number of the added revisions, used to generate IDs
+	revisions int
+}
+
+// Import reads the fast-import stream from `source` and adds the revisions to `builder`.
+func (adapter FastExportAdapter) Import(source string, builder *HistoryBuilder) error {
+	var input io.Reader
+	if source == "-" {
+		input = os.Stdin
+	} else {
+		file, err := os.Open(source)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+	return ReadFastExport(input, builder)
+}
+
+// ReadFastExport parses the fast-import stream and adds the revisions to `builder`.
+// The branches are created for the final tips, refs/heads/master becomes HEAD if it exists.
+func ReadFastExport(input io.Reader, builder *HistoryBuilder) error {
+	reader := &fastExportReader{
+		input:   bufio.NewReader(input),
+		builder: builder,
+		blobs:   map[string]plumbing.Hash{},
+		tips:    map[string]string{},
+	}
+	if err := reader.read(); err != nil {
+		return fmt.Errorf("line %d: %v", reader.lineno, err)
+	}
+	refs := make([]string, 0, len(reader.tips))
+	for ref := range reader.tips {
+		if ref != string(plumbing.Master) && reader.tips[ref] != "" {
+			refs = append(refs, ref)
+		}
+	}
+	sort.Strings(refs)
+	if reader.tips[string(plumbing.Master)] != "" {
+		refs = append([]string{string(plumbing.Master)}, refs...)
+	}
+	for _, ref := range refs {
+		if err := builder.SetReference(plumbing.ReferenceName(ref), reader.tips[ref]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// next reads the following line without the trailing "\n".
+func (reader *fastExportReader) next() error {
+	line, err := reader.input.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	if err == io.EOF {
+		reader.eof = true
+		reader.line = ""
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	reader.lineno++
+	reader.line = strings.TrimSuffix(line, "\n")
+	return nil
+}
+
+func (reader *fastExportReader) read() error {
+	if err := reader.next(); err != nil {
+		return err
+	}
+	for !reader.eof {
+		command := reader.line
+		var err error
+		switch {
+		case command == "" || strings.HasPrefix(command, "#") || command == "checkpoint" ||
+			strings.HasPrefix(command, "feature ") || strings.HasPrefix(command, "option ") ||
+			strings.HasPrefix(command, "progress "):
+			err = reader.next()
+		case command == "done":
+			return nil
+		case command == "blob":
+			err = reader.readBlob()
+		case strings.HasPrefix(command, "commit "):
+			err = reader.readCommit(strings.TrimPrefix(command, "commit "))
+		case strings.HasPrefix(command, "reset "):
+			err = reader.readReset(strings.TrimPrefix(command, "reset "))
+		case strings.HasPrefix(command, "tag "):
+			err = reader.skipTag()
+		default:
+			err = fmt.Errorf("unsupported command: %s", command)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readData reads "data <count>" or "data <<<delimiter>" and the following contents.
+func (reader *fastExportReader) readData() ([]byte, error) {
+	if !strings.HasPrefix(reader.line, "data ") {
+		return nil, fmt.Errorf("expected data, got %s", reader.line)
+	}
+	arg := strings.TrimPrefix(reader.line, "data ")
+	var data []byte
+	if strings.HasPrefix(arg, "<<") {
+		delimiter := arg[2:]
+		buffer := &bytes.Buffer{}
+		for {
+			if err := reader.next(); err != nil {
+				return nil, err
+			}
+			if reader.eof {
+				return nil, fmt.Errorf("unterminated data")
+			}
+			if reader.line == delimiter {
+				break
+			}
+			buffer.WriteString(reader.line)
+			buffer.WriteByte('\n')
+		}
+		data = buffer.Bytes()
+	} else {
+		size, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid data size: %s", arg)
+		}
+		data = make([]byte, size)
+		if _, err = io.ReadFull(reader.input, data); err != nil {
+			return nil, err
+		}
+		reader.lineno += bytes.Count(data, []byte{'\n'})
+	}
+	// the optional LF after the data
+	if err := reader.next(); err != nil {
+		return nil, err
+	}
+	if reader.line == "" && !reader.eof {
+		if err := reader.next(); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+func (reader *fastExportReader) readBlob() error {
+	if err := reader.next(); err != nil {
+		return err
+	}
+	mark := ""
+	if strings.HasPrefix(reader.line, "mark ") {
+		mark = strings.TrimPrefix(reader.line, "mark ")
+		if err := reader.next(); err != nil {
+			return err
+		}
+	}
+	if strings.HasPrefix(reader.line, "original-oid ") {
+		if err := reader.next(); err != nil {
+			return err
+		}
+	}
+	data, err := reader.readData()
+	if err != nil {
+		return err
+	}
+	hash, err := reader.builder.AddBlob(data)
+	if err != nil {
+		return err
+	}
+	if mark != "" {
+		reader.blobs[mark] = hash
+	}
+	return nil
+}
+
+// revision resolves the commit-ish in "from" and "merge" to the revision ID.
+func (reader *fastExportReader) revision(commitish string) (string, error) {
+	if _, exists := reader.builder.Lookup(commitish); exists {
+		return commitish, nil
+	}
+	if id, exists := reader.tips[commitish]; exists && id != "" {
+		return id, nil
+	}
+	return "", fmt.Errorf("unknown commit %s", commitish)
+}
+
+func (reader *fastExportReader) readReset(ref string) error {
+	if err := reader.next(); err != nil {
+		return err
+	}
+	reader.tips[ref] = ""
+	if strings.HasPrefix(reader.line, "from ") {
+		id, err := reader.revision(strings.TrimPrefix(reader.line, "from "))
+		if err != nil {
+			return err
+		}
+		reader.tips[ref] = id
+		if err = reader.next(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (reader *fastExportReader) skipTag() error {
+	if err := reader.next(); err != nil {
+		return err
+	}
+	for strings.HasPrefix(reader.line, "mark ") || strings.HasPrefix(reader.line, "from ") ||
+		strings.HasPrefix(reader.line, "original-oid ") || strings.HasPrefix(reader.line, "tagger ") {
+		if err := reader.next(); err != nil {
+			return err
+		}
+	}
+	_, err := reader.readData()
+	return err
+}
+
+func (reader *fastExportReader) readCommit(ref string) error {
+	if err := reader.next(); err != nil {
+		return err
+	}
+	reader.revisions++
+	revision := Revision{ID: fmt.Sprintf("#%d", reader.revisions)}
+	if strings.HasPrefix(reader.line, "mark ") {
+		revision.ID = strings.TrimPrefix(reader.line, "mark ")
+		if err := reader.next(); err != nil {
+			return err
+		}
+	}
+	var err error
+headers:
+	for {
+		switch {
+		case strings.HasPrefix(reader.line, "original-oid "), strings.HasPrefix(reader.line, "encoding "):
+		case strings.HasPrefix(reader.line, "author "):
+			revision.Author, err = parseFastExportSignature(strings.TrimPrefix(reader.line, "author "))
+		case strings.HasPrefix(reader.line, "committer "):
+			revision.Committer, err = parseFastExportSignature(strings.TrimPrefix(reader.line, "committer "))
+		default:
+			break headers
+		}
+		if err != nil {
+			return err
+		}
+		if err = reader.next(); err != nil {
+			return err
+		}
+	}
+	if revision.Author.Email == "" && revision.Author.Name == "" {
+		revision.Author = revision.Committer
+	}
+	message, err := reader.readData()
+	if err != nil {
+		return err
+	}
+	revision.Message = string(message)
+	if strings.HasPrefix(reader.line, "from ") {
+		parent, err := reader.revision(strings.TrimPrefix(reader.line, "from "))
+		if err != nil {
+			return err
+		}
+		revision.Parents = append(revision.Parents, parent)
+		if err = reader.next(); err != nil {
+			return err
+		}
+	} else if tip := reader.tips[ref]; tip != "" {
+		revision.Parents = append(revision.Parents, tip)
+	}
+	for strings.HasPrefix(reader.line, "merge ") {
+		parent, err := reader.revision(strings.TrimPrefix(reader.line, "merge "))
+		if err != nil {
+			return err
+		}
+		revision.Parents = append(revision.Parents, parent)
+		if err = reader.next(); err != nil {
+			return err
+		}
+	}
+changes:
+	for !reader.eof {
+		line := reader.line
+		var err error
+		switch {
+		case line == "":
+			err = reader.next()
+		case line == "deleteall":
+			revision.Reset = true
+			revision.Changes = nil
+			err = reader.next()
+		case strings.HasPrefix(line, "M "):
+			err = reader.readModify(&revision, line[2:])
+		case strings.HasPrefix(line, "D "):
+			var path string
+			if path, _, err = parseFastExportPath(line[2:], true); err == nil {
+				revision.Changes = append(revision.Changes, FileChange{Path: path, Delete: true})
+				err = reader.next()
+			}
+		case strings.HasPrefix(line, "C "), strings.HasPrefix(line, "R "):
+			var from, to, rest string
+			if from, rest, err = parseFastExportPath(line[2:], false); err != nil {
+				break
+			}
+			if to, _, err = parseFastExportPath(strings.TrimPrefix(rest, " "), true); err != nil {
+				break
+			}
+			revision.Changes = append(revision.Changes, FileChange{Path: to, From: from})
+			if line[0] == 'R' {
+				revision.Changes = append(revision.Changes, FileChange{Path: from, Delete: true})
+			}
+			err = reader.next()
+		case strings.HasPrefix(line, "N "):
+			// notes are not analysed
+			if strings.HasPrefix(line, "N inline ") {
+				if err = reader.next(); err == nil {
+					_, err = reader.readData()
+				}
+			} else {
+				err = reader.next()
+			}
+		default:
+			break changes
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if _, err := reader.builder.AddRevision(revision); err != nil {
+		return err
+	}
+	reader.tips[ref] = revision.ID
+	return nil
+}
+
+// readModify parses "M <mode> <dataref> <path>" and the inline data.
+func (reader *fastExportReader) readModify(revision *Revision, args string) error {
+	parts := strings.SplitN(args, " ", 3)
+	if len(parts) != 3 {
+		return fmt.Errorf("invalid modification: %s", args)
+	}
+	mode, err := filemode.New(parts[0])
+	if err != nil {
+		return err
+	}
+	path, _, err := parseFastExportPath(parts[2], true)
+	if err != nil {
+		return err
+	}
+	if err = reader.next(); err != nil {
+		return err
+	}
+	var blob plumbing.Hash
+	switch dataref := parts[1]; {
+	case dataref == "inline":
+		data, err := reader.readData()
+		if err != nil {
+			return err
+		}
+		if blob, err = reader.builder.AddBlob(data); err != nil {
+			return err
+		}
+	case strings.HasPrefix(dataref, ":"):
+		var exists bool
+		if blob, exists = reader.blobs[dataref]; !exists {
+			return fmt.Errorf("unknown blob %s", dataref)
+		}
+	case mode == filemode.Submodule && len(dataref) == 40:
+		blob = plumbing.NewHash(dataref)
+	default:
+		return fmt.Errorf("unsupported data reference %s, export with marks", dataref)
+	}
+	revision.Changes = append(revision.Changes, FileChange{Path: path, Blob: blob, Mode: mode})
+	return nil
+}
+
+// parseFastExportSignature parses "Name <email> <unix time> <timezone>".
+func parseFastExportSignature(line string) (object.Signature, error) {
+	start := strings.IndexByte(line, '<')
+	end := strings.IndexByte(line, '>')
+	if start < 0 || end < start {
+		return object.Signature{}, fmt.Errorf("invalid signature: %s", line)
+	}
+	signature := object.Signature{
+		Name:  strings.TrimSpace(line[:start]),
+		Email: line[start+1 : end],
+	}
+	fields := strings.Fields(line[end+1:])
+	if len(fields) != 2 {
+		return object.Signature{}, fmt.Errorf("invalid signature date: %s", line)
+	}
+	timestamp, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return object.Signature{}, fmt.Errorf("invalid signature date: %s", line)
+	}
+	zone := fields[1]
+	offset, err := strconv.Atoi(zone)
+	if err != nil || len(zone) != 5 {
+		return object.Signature{}, fmt.Errorf("invalid signature timezone: %s", line)
+	}
+	seconds := (offset/100)*3600 + (offset%100)*60
+	signature.When = time.Unix(timestamp, 0).In(time.FixedZone("", seconds))
+	return signature, nil
+}
+
+// parseFastExportPath parses the path which is optionally quoted in C style. It returns the path
+// and the rest of the line. The unquoted path spans till the end of the line if `last` is true.
+func parseFastExportPath(line string, last bool) (string, string, error) {
+	if !strings.HasPrefix(line, "\"") {
+		if index := strings.IndexByte(line, ' '); index >= 0 && !last {
+			return line[:index], line[index:], nil
+		}
+		return line, "", nil
+	}
+	for i := 1; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '"':
+			path, err := strconv.Unquote(line[:i+1])
+			if err != nil {
+				return "", "", fmt.Errorf("invalid path: %s", line)
+			}
+			return path, line[i+1:], nil
+		}
+	}
+	return "", "", fmt.Errorf("invalid path: %s", line)
+}
+
+func init() {
+	RegisterVCSAdapter("fast-export", FastExportAdapter{})
+}
@@ -0,0 +1,179 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+const fixtureFastExportStream = `feature done
+blob
+mark :1
+data 2
+a
+
+blob
+mark :2
+data 2
+b
+
+reset refs/heads/master
+commit refs/heads/master
+mark :3
+author Alice <alice@corp.com> 1500000000 +0200
+committer Bob <bob@corp.com> 1500000100 -0130
+data 6
+first
+M 100644 :1 a.txt
+M 100755 :2 "dir one/b\303\274.sh"
+
+commit refs/heads/feature
+mark :4
+committer Bob <bob@corp.com> 1500000200 +0000
+data <<EOF
+second
+line
+EOF
+from :3
+R "dir one/b\303\274.sh" c.sh
+C a.txt dir two/a copy.txt
+M 100644 inline d.txt
+data 2
+d
+
+tag v1
+from :4
+tagger Bob <bob@corp.com> 1500000200 +0000
+data 3
+v1
+
+commit refs/heads/master
+mark :5
+author Alice <alice@corp.com> 1500000300 +0000
+committer Alice <alice@corp.com> 1500000300 +0000
+data 6
+merge
+merge :4
+D a.txt
+
+commit refs/heads/orphan
+mark :6
+author Carol <carol@corp.com> 1500000400 +0000
+committer Carol <carol@corp.com> 1500000400 +0000
+data 7
+orphan
+from :5
+deleteall
+M 100644 :2 e.txt
+
+done
+`
+
+func TestReadFastExport(t *testing.T) {
+	builder := NewHistoryBuilder()
+	assert.Nil(t, ReadFastExport(strings.NewReader(fixtureFastExportStream), builder))
+	h3, _ := builder.Lookup(":3")
+	assert.Equal(t, listTreeFiles(t, builder, h3), []string{"a.txt:7898", "dir one/bü.sh:6178"})
+	commit, _ := object.GetCommit(builder.storage, h3)
+	assert.Equal(t, commit.Author.Name, "Alice")
+	assert.Equal(t, commit.Author.When.Unix(), int64(1500000000))
+	_, offset := commit.Author.When.Zone()
+	assert.Equal(t, offset, 7200)
+	_, offset = commit.Committer.When.Zone()
+	assert.Equal(t, offset, -5400)
+	assert.Equal(t, commit.Message, "first\n")
+	assert.Len(t, commit.ParentHashes, 0)
+
+	h4, _ := builder.Lookup(":4")
+	assert.Equal(t, listTreeFiles(t, builder, h4), []string{
+		"a.txt:7898", "c.sh:6178", "d.txt:4bcf", "dir two/a copy.txt:7898"})
+	commit, _ = object.GetCommit(builder.storage, h4)
+	assert.Equal(t, commit.Author.Name, "Bob")
+	assert.Equal(t, commit.Message, "second\nline\n")
+	assert.Equal(t, commit.ParentHashes, []plumbing.Hash{h3})
+	file, _ := commit.File("c.sh")
+	assert.Equal(t, file.Mode.String(), "0100755")
+
+	h5, _ := builder.Lookup(":5")
+	assert.Equal(t, listTreeFiles(t, builder, h5), []string{"dir one/bü.sh:6178"})
+	commit, _ = object.GetCommit(builder.storage, h5)
+	assert.Equal(t, commit.ParentHashes, []plumbing.Hash{h3, h4})
+
+	h6, _ := builder.Lookup(":6")
+	assert.Equal(t, listTreeFiles(t, builder, h6), []string{"e.txt:6178"})
+
+	repository, err := builder.Repository()
+	assert.Nil(t, err)
+	head, _ := repository.Head()
+	assert.Equal(t, head.Name(), plumbing.Master)
+	assert.Equal(t, head.Hash(), h5)
+	ref, err := repository.Reference("refs/heads/feature", false)
+	assert.Nil(t, err)
+	assert.Equal(t, ref.Hash(), h4)
+	ref, err = repository.Reference("refs/heads/orphan", false)
+	assert.Nil(t, err)
+	assert.Equal(t, ref.Hash(), h6)
+}
+
+func TestReadFastExportErrors(t *testing.T) {
+	check := func(stream, message string) {
+		err := ReadFastExport(strings.NewReader(stream), NewHistoryBuilder())
+		assert.EqualError(t, err, message)
+	}
+	check("blah\n", "line 1: unsupported command: blah")
+	check("blob\ndata x\n", "line 2: invalid data size: x")
+	check("blob\nmark :1\n", "line 2: expected data, got ")
+	check("blob\ndata <<EOF\nabc\n", "line 3: unterminated data")
+	check("commit refs/heads/master\ncommitter Bob <bob@corp.com> 1 +0000\ndata 0\nfrom :7\n",
+		"line 4: unknown commit :7")
+	check("commit refs/heads/master\ncommitter Bob <bob@corp.com> 1 +0000\ndata 0\nM 100644 :7 a\n",
+		"line 4: unknown blob :7")
+	check("commit refs/heads/master\ncommitter Bob <bob@corp.com> 1 +0000\ndata 0\n"+
+		"M 100644 0123456789012345678901234567890123456789 a\n",
+		"line 4: unsupported data reference 0123456789012345678901234567890123456789, export with marks")
+	check("commit refs/heads/master\ncommitter Bob bob@corp.com 1 +0000\n",
+		"line 2: invalid signature: Bob bob@corp.com 1 +0000")
+	check("commit refs/heads/master\ncommitter Bob <bob@corp.com> 1 UTC\n",
+		"line 2: invalid signature timezone: Bob <bob@corp.com> 1 UTC")
+	check("commit refs/heads/master\ncommitter Bob <bob@corp.com> 1 +0000\ndata 0\nR a b\n",
+		"line 4: revision #1: b: copy from a: file not found")
+}
+
+func TestFastExportAdapter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "stream")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(fixtureFastExportStream), 0666))
+	assert.Contains(t, VCSAdapters(), "fast-export")
+	repository, err := ImportHistory("fast-export", path)
+	assert.Nil(t, err)
+	commits, err := NewPipeline(repository).Commits(false)
+	assert.Nil(t, err)
+	assert.Len(t, commits, 3)
+	_, err = ImportHistory("fast-export", filepath.Join(dir, "missing"))
+	assert.NotNil(t, err)
+}
+
+func TestParseFastExportPath(t *testing.T) {
+	path, rest, err := parseFastExportPath("a b c", true)
+	assert.Nil(t, err)
+	assert.Equal(t, path, "a b c")
+	assert.Equal(t, rest, "")
+	path, rest, err = parseFastExportPath("a b c", false)
+	assert.Nil(t, err)
+	assert.Equal(t, path, "a")
+	assert.Equal(t, rest, " b c")
+	path, rest, err = parseFastExportPath(`"a \"b\"" c`, false)
+	assert.Nil(t, err)
+	assert.Equal(t, path, `a "b"`)
+	assert.Equal(t, rest, " c")
+	_, _, err = parseFastExportPath(`"a`, true)
+	assert.NotNil(t, err)
+}
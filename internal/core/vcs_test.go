@@ -0,0 +1,143 @@
+package core
+
+import (
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+type fakeVCSAdapter struct {
+	source string
+	fail   bool
+}
+
+func (adapter *fakeVCSAdapter) Import(source string, builder *HistoryBuilder) error {
+	adapter.source = source
+	if adapter.fail {
+		return errors.New("fail")
+	}
+	blob, _ := builder.AddBlob([]byte("hello\n"))
+	_, err := builder.AddRevision(Revision{
+		ID: "1", Author: object.Signature{Name: "Bob", Email: "bob@corp.com", When: time.Unix(100, 0)},
+		Changes: []FileChange{{Path: "README", Blob: blob}}})
+	return err
+}
+
+func listTreeFiles(t *testing.T, builder *HistoryBuilder, hash plumbing.Hash) []string {
+	commit, err := object.GetCommit(builder.storage, hash)
+	assert.Nil(t, err)
+	files, err := commit.Files()
+	assert.Nil(t, err)
+	var result []string
+	files.ForEach(func(file *object.File) error {
+		result = append(result, file.Name+":"+file.Hash.String()[:4])
+		return nil
+	})
+	sort.Strings(result)
+	return result
+}
+
+func TestHistoryBuilder(t *testing.T) {
+	builder := NewHistoryBuilder()
+	a, err := builder.AddBlob([]byte("a\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, a.String(), "78981922613b2afb6025042ff6bd878ac1994e85")
+	b, _ := builder.AddBlob([]byte("b\n"))
+	author := object.Signature{Name: "Alice", Email: "alice@corp.com", When: time.Unix(100, 0)}
+	h1, err := builder.AddRevision(Revision{ID: "r1", Author: author, Message: "first",
+		Changes: []FileChange{{Path: "a.txt", Blob: a}, {Path: "src/b.txt", Blob: b},
+			{Path: "src/deep/c.sh", Blob: a, Mode: filemode.Executable}}})
+	assert.Nil(t, err)
+	assert.Equal(t, listTreeFiles(t, builder, h1), []string{"a.txt:7898", "src/b.txt:6178", "src/deep/c.sh:7898"})
+	// rename and delete the whole directory
+	h2, err := builder.AddRevision(Revision{ID: "r2", Parents: []string{"r1"}, Author: author,
+		Changes: []FileChange{{Path: "src/b.txt", Delete: true}, {Path: "b.txt", Blob: b},
+			{Path: "src/deep/c.sh", Delete: true}}})
+	assert.Nil(t, err)
+	assert.Equal(t, listTreeFiles(t, builder, h2), []string{"a.txt:7898", "b.txt:6178"})
+	tree, _ := object.GetTree(builder.storage, builder.trees[h2])
+	assert.Len(t, tree.Entries, 2)
+	// fork from r1
+	h3, err := builder.AddRevision(Revision{ID: "r3", Parents: []string{"r1"}, Author: author,
+		Changes: []FileChange{{Path: "src/b.txt", Blob: a}}})
+	assert.Nil(t, err)
+	assert.Equal(t, listTreeFiles(t, builder, h3), []string{"a.txt:7898", "src/b.txt:7898", "src/deep/c.sh:7898"})
+	// merge
+	h4, err := builder.AddRevision(Revision{ID: "r4", Parents: []string{"r2", "r3"}, Author: author,
+		Changes: []FileChange{{Path: "c", Blob: a}}})
+	assert.Nil(t, err)
+	assert.Equal(t, listTreeFiles(t, builder, h4), []string{"a.txt:7898", "b.txt:6178", "c:7898"})
+	commit, _ := object.GetCommit(builder.storage, h4)
+	assert.Equal(t, commit.ParentHashes, []plumbing.Hash{h2, h3})
+	assert.Equal(t, commit.Committer, commit.Author)
+	h5, err := builder.AddRevision(Revision{ID: "r5", Parents: []string{"r4"}, Author: author,
+		Reset: true, Changes: []FileChange{{Path: "d", Blob: b}}})
+	assert.Nil(t, err)
+	assert.Equal(t, listTreeFiles(t, builder, h5), []string{"d:6178"})
+	hash, exists := builder.Lookup("r3")
+	assert.True(t, exists)
+	assert.Equal(t, hash, h3)
+
+	_, err = builder.AddRevision(Revision{ID: "r5"})
+	assert.EqualError(t, err, "duplicate revision r5")
+	_, err = builder.AddRevision(Revision{ID: "r6", Parents: []string{"r0"}})
+	assert.EqualError(t, err, "revision r6: unknown parent r0")
+	_, err = builder.AddRevision(Revision{ID: "r7", Changes: []FileChange{{Path: "a//b", Blob: a}}})
+	assert.EqualError(t, err, "revision r7: a//b: invalid path")
+
+	repository, err := builder.Repository()
+	assert.Nil(t, err)
+	head, err := repository.Head()
+	assert.Nil(t, err)
+	assert.Equal(t, head.Name(), plumbing.Master)
+	assert.Equal(t, head.Hash(), h5)
+	commits, err := NewPipeline(repository).Commits(false)
+	assert.Nil(t, err)
+	assert.Len(t, commits, 5)
+}
+
+func TestHistoryBuilderReferences(t *testing.T) {
+	builder := NewHistoryBuilder()
+	_, err := builder.Repository()
+	assert.EqualError(t, err, "the imported history is empty")
+	author := object.Signature{Name: "Alice", Email: "alice@corp.com", When: time.Unix(100, 0)}
+	h1, _ := builder.AddRevision(Revision{ID: "1", Author: author})
+	builder.AddRevision(Revision{ID: "2", Parents: []string{"1"}, Author: author})
+	assert.EqualError(t, builder.SetReference("refs/heads/default", "3"),
+		"reference refs/heads/default: unknown revision 3")
+	assert.Nil(t, builder.SetReference("refs/tags/v1", "2"))
+	assert.Nil(t, builder.SetReference("refs/heads/default", "1"))
+	repository, err := builder.Repository()
+	assert.Nil(t, err)
+	head, _ := repository.Head()
+	assert.Equal(t, head.Name(), plumbing.ReferenceName("refs/heads/default"))
+	assert.Equal(t, head.Hash(), h1)
+}
+
+func TestImportHistory(t *testing.T) {
+	adapter := &fakeVCSAdapter{}
+	RegisterVCSAdapter("fake", adapter)
+	defer delete(vcsAdapters, "fake")
+	assert.Contains(t, VCSAdapters(), "fake")
+	repository, err := ImportHistory("fake", "/some/path")
+	assert.Nil(t, err)
+	assert.Equal(t, adapter.source, "/some/path")
+	head, _ := repository.Head()
+	commit, _ := repository.CommitObject(head.Hash())
+	assert.Equal(t, commit.Author.Email, "bob@corp.com")
+	file, err := commit.File("README")
+	assert.Nil(t, err)
+	contents, _ := file.Contents()
+	assert.Equal(t, contents, "hello\n")
+	adapter.fail = true
+	_, err = ImportHistory("fake", "")
+	assert.EqualError(t, err, "fail")
+	_, err = ImportHistory("missing", "")
+	assert.Contains(t, err.Error(), "unknown VCS adapter \"missing\"")
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"runtime"
+	"sync"
+)
+
+// WorkerPool limits the number of goroutines which PipelineItem-s use to parallelize their work,
+// e.g. calculating diffs or extracting UASTs. A single instance is shared by all
+// the items in a Pipeline through FactWorkerPool, so the overall CPU consumption is bounded
+// by ConfigPipelineWorkers regardless of how many items run in parallel internally.
+// nil *WorkerPool is valid and runs everything sequentially in the caller's goroutine.
+type WorkerPool struct {
+	size   int
+	tokens chan struct{}
+}
+
+// NewWorkerPool creates a new WorkerPool which runs at most `size` concurrent jobs.
+// If `size` is not positive, it is set to runtime.GOMAXPROCS(0).
+func NewWorkerPool(size int) *WorkerPool {
+	if size <= 0 {
+		size = runtime.GOMAXPROCS(0)
+	}
+	// the caller's goroutine always takes part in the work, so we need one token less
+	return &WorkerPool{size: size, tokens: make(chan struct{}, size-1)}
+}
+
+// Size returns the maximum number of concurrent jobs.
+func (pool *WorkerPool) Size() int {
+	if pool == nil {
+		return 1
+	}
+	return pool.size
+}
+
+// ForEach calls `job` for every index in [0, n) and blocks until all of them finish.
+// The calls are distributed among the free workers of the pool; if there are none,
+// the job is executed in the caller's goroutine. Thus nested ForEach() calls never deadlock
+// and the total number of busy goroutines never exceeds Size().
+func (pool *WorkerPool) ForEach(n int, job func(index int)) {
+	if pool == nil || pool.size == 1 || n == 1 {
+		for i := 0; i < n; i++ {
+			job(i)
+		}
+		return
+	}
+	wg := sync.WaitGroup{}
+	for i := 0; i < n; i++ {
+		select {
+		case pool.tokens <- struct{}{}:
+			wg.Add(1)
+			go func(index int) {
+				defer func() {
+					<-pool.tokens
+					wg.Done()
+				}()
+				job(index)
+			}(i)
+		default:
+			job(i)
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPoolSize(t *testing.T) {
+	assert.Equal(t, NewWorkerPool(3).Size(), 3)
+	assert.Equal(t, NewWorkerPool(0).Size(), runtime.GOMAXPROCS(0))
+	assert.Equal(t, NewWorkerPool(-1).Size(), runtime.GOMAXPROCS(0))
+	var pool *WorkerPool
+	assert.Equal(t, pool.Size(), 1)
+}
+
+func TestWorkerPoolForEach(t *testing.T) {
+	for _, pool := range []*WorkerPool{nil, NewWorkerPool(1), NewWorkerPool(4)} {
+		visited := make([]int, 100)
+		pool.ForEach(len(visited), func(index int) {
+			visited[index]++
+		})
+		for _, v := range visited {
+			assert.Equal(t, v, 1)
+		}
+	}
+}
+
+func TestWorkerPoolForEachBudget(t *testing.T) {
+	pool := NewWorkerPool(3)
+	lock := sync.Mutex{}
+	busy := 0
+	maxBusy := 0
+	job := func(int) {
+		lock.Lock()
+		busy++
+		if busy > maxBusy {
+			maxBusy = busy
+		}
+		lock.Unlock()
+		runtime.Gosched()
+		lock.Lock()
+		busy--
+		lock.Unlock()
+	}
+	pool.ForEach(50, func(int) {
+		// nested calls must not deadlock
+		pool.ForEach(3, job)
+	})
+	assert.True(t, maxBusy <= 3)
+	assert.True(t, maxBusy >= 1)
+}
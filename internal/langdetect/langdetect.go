@@ -0,0 +1,175 @@
+// Package langdetect guesses the natural language of short texts such as commit messages.
+// It does not depend on any models: non-Latin languages are recognized by their scripts
+// and Latin ones by the most frequent words and the specific letters.
+package langdetect
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Undetermined is the ISO 639 code which Detect() returns if it cannot decide.
+const Undetermined = "und"
+
+// MinLetters is the minimum number of letters in the text which is required for the detection.
+const MinLetters = 4
+
+var (
+	urlRegexp     = regexp.MustCompile(`\w+://\S+`)
+	codeRegexp    = regexp.MustCompile("`[^`]*`")
+	trailerRegexp = regexp.MustCompile(`(?m)^[\w-]+: .*$`)
+	wordRegexp    = regexp.MustCompile(`\pL+`)
+)
+
+// latinWords are the most frequent words of the languages which use the Latin script.
+// Short imperative verbs are included for English because the commit subjects seldom
+// contain any articles or prepositions.
+var latinWords = map[string][]string{
+	"en": {"the", "a", "an", "and", "of", "to", "in", "for", "is", "with", "on", "it", "this",
+		"that", "from", "not", "be", "are", "was", "by", "fix", "fixed", "add", "added", "remove",
+		"removed", "update", "updated", "use", "when", "into", "should", "now", "instead"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "mit", "für", "von", "zu", "den", "ein",
+		"eine", "auf", "im", "dem", "des", "bei", "wird", "auch", "hinzugefügt", "entfernt"},
+	"fr": {"le", "la", "les", "et", "des", "du", "un", "une", "pour", "dans", "est", "pas",
+		"sur", "au", "avec", "ajout", "ajouté", "correction", "suppression"},
+	"es": {"el", "la", "los", "las", "y", "de", "del", "en", "para", "con", "por", "una", "que",
+		"se", "es", "al", "añadido", "corrección", "agregar"},
+	"pt": {"o", "os", "as", "e", "do", "da", "dos", "das", "em", "para", "com", "um", "uma",
+		"não", "no", "na", "ao", "adicionado", "correção"},
+	"it": {"il", "lo", "gli", "e", "di", "del", "della", "per", "con", "un", "una", "che", "non",
+		"è", "nel", "alla", "aggiunto", "aggiunta", "rimosso"},
+	"nl": {"de", "het", "een", "en", "van", "voor", "met", "niet", "op", "is", "te", "dat",
+		"toegevoegd", "verwijderd", "bij"},
+	"pl": {"i", "w", "na", "z", "do", "nie", "się", "dla", "jest", "oraz", "poprawka",
+		"dodano", "usunięto"},
+	"tr": {"ve", "bir", "bu", "için", "ile", "da", "de", "eklendi", "düzeltildi", "değil"},
+	"sv": {"och", "att", "för", "med", "som", "är", "på", "inte", "av", "till", "lagt"},
+}
+
+// latinLetters are the letters which are specific to a language.
+var latinLetters = map[rune][]string{
+	'ß': {"de"}, 'ä': {"de", "sv"}, 'ö': {"de", "sv", "tr"}, 'ü': {"de", "tr"},
+	'ç': {"fr", "pt", "tr"}, 'è': {"fr", "it"}, 'ê': {"fr", "pt"}, 'à': {"fr", "it", "pt"},
+	'ñ': {"es"}, 'ã': {"pt"}, 'õ': {"pt"},
+	'ł': {"pl"}, 'ą': {"pl"}, 'ę': {"pl"}, 'ś': {"pl"}, 'ż': {"pl"}, 'ź': {"pl"}, 'ć': {"pl"},
+	'ğ': {"tr"}, 'ş': {"tr"}, 'ı': {"tr"}, 'å': {"sv"},
+}
+
+var latinIndex = func() map[string][]string {
+	index := map[string][]string{}
+	for lang, words := range latinWords {
+		for _, word := range words {
+			index[word] = append(index[word], lang)
+		}
+	}
+	return index
+}()
+
+// scripts map the Unicode scripts to the languages. Han is resolved separately
+// because it is shared by Chinese and Japanese.
+var scripts = []struct {
+	table *unicode.RangeTable
+	lang  string
+}{
+	{unicode.Hiragana, "ja"}, {unicode.Katakana, "ja"}, {unicode.Hangul, "ko"},
+	{unicode.Cyrillic, "ru"}, {unicode.Greek, "el"}, {unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"}, {unicode.Devanagari, "hi"}, {unicode.Thai, "th"},
+	{unicode.Georgian, "ka"}, {unicode.Armenian, "hy"},
+}
+
+// Clean removes the parts of a commit message which do not carry the natural language:
+// the trailers such as "Signed-off-by:", URLs and the inline code.
+func Clean(message string) string {
+	message = trailerRegexp.ReplaceAllString(message, "")
+	message = urlRegexp.ReplaceAllString(message, "")
+	return codeRegexp.ReplaceAllString(message, "")
+}
+
+// Detect returns the ISO 639-1 code of the language of the text or Undetermined.
+func Detect(text string) string {
+	text = strings.ToLower(text)
+	counts := map[string]int{}
+	latin, han, letters := 0, 0, 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.Is(unicode.Latin, r) {
+			latin++
+			continue
+		}
+		if unicode.Is(unicode.Han, r) {
+			han++
+			continue
+		}
+		for _, script := range scripts {
+			if unicode.Is(script.table, r) {
+				counts[script.lang]++
+				break
+			}
+		}
+	}
+	if letters < MinLetters {
+		return Undetermined
+	}
+	best, bestCount := "", 0
+	for lang, count := range counts {
+		if count > bestCount || (count == bestCount && lang < best) {
+			best, bestCount = lang, count
+		}
+	}
+	if han > 0 && han+counts["ja"] >= bestCount {
+		// kana mixed with kanji is Japanese
+		if counts["ja"] > 0 {
+			best = "ja"
+		} else {
+			best = "zh"
+		}
+		bestCount = han + counts["ja"]
+	}
+	if bestCount > latin {
+		if best == "ru" {
+			return detectCyrillic(text)
+		}
+		return best
+	}
+	return detectLatin(text)
+}
+
+// detectCyrillic distinguishes Ukrainian and Belarusian from Russian by the specific letters.
+func detectCyrillic(text string) string {
+	switch {
+	case strings.ContainsAny(text, "іїєґ"):
+		if strings.ContainsRune(text, 'ў') {
+			return "be"
+		}
+		return "uk"
+	case strings.ContainsRune(text, 'ў'):
+		return "be"
+	}
+	return "ru"
+}
+
+// detectLatin scores the languages by the frequent words and the specific letters.
+func detectLatin(text string) string {
+	scores := map[string]int{}
+	for _, word := range wordRegexp.FindAllString(text, -1) {
+		for _, lang := range latinIndex[word] {
+			scores[lang] += 2
+		}
+	}
+	for _, r := range text {
+		for _, lang := range latinLetters[r] {
+			scores[lang]++
+		}
+	}
+	best, bestScore := Undetermined, 0
+	for lang, score := range scores {
+		if score > bestScore || (score == bestScore && lang < best) {
+			best, bestScore = lang, score
+		}
+	}
+	return best
+}
@@ -0,0 +1,45 @@
+package langdetect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetect(t *testing.T) {
+	cases := map[string]string{
+		"Fix the crash in the parser when the input is empty":       "en",
+		"Add support for .mailmap":                                  "en",
+		"Fehler in der Konfiguration behoben und Tests hinzugefügt": "de",
+		"Ajout de la gestion des erreurs dans le parseur":           "fr",
+		"Corrección del error en la página de inicio":               "es",
+		"Correção do erro na página inicial":                        "pt",
+		"Aggiunto il supporto per la configurazione":                "it",
+		"Ondersteuning voor het nieuwe formaat toegevoegd":          "nl",
+		"Poprawka błędu w obsłudze plików":                          "pl",
+		"Hata düzeltildi ve testler eklendi":                        "tr",
+		"Исправлена ошибка в парсере":                               "ru",
+		"Виправлено помилку в парсері":                              "uk",
+		"修复解析器中的错误":                                                 "zh",
+		"パーサーのバグを修正":                                                "ja",
+		"파서 버그 수정":                                                  "ko",
+		"Διόρθωση σφάλματος":                                        "el",
+		"v1.2.3":                                                    Undetermined,
+		"wip":                                                       Undetermined,
+		"asdfgh qwerty":                                             Undetermined,
+	}
+	for text, lang := range cases {
+		assert.Equal(t, Detect(text), lang, text)
+	}
+}
+
+func TestClean(t *testing.T) {
+	message := "Исправлена ошибка в `parse()`\n\nSee https://example.com/issue/1\n\n" +
+		"Signed-off-by: John Smith <john@example.com>\n"
+	cleaned := Clean(message)
+	assert.NotContains(t, cleaned, "parse")
+	assert.NotContains(t, cleaned, "example.com")
+	assert.NotContains(t, cleaned, "Signed-off-by")
+	assert.Equal(t, Detect(cleaned), "ru")
+	assert.Equal(t, Detect("Update README\n\nSigned-off-by: Jan de Vries <jan@example.com>"), "en")
+}
@@ -9,12 +9,20 @@ It is generated from these files:
 
 It has these top-level messages:
 	Metadata
+	UnparsedFile
+	Annotation
+	ItemProfile
 	BurndownSparseMatrixRow
 	BurndownSparseMatrix
+	BurndownCohort
+	BurndownSurvival
 	BurndownAnalysisResults
+	FileSnapshot
 	CompressedSparseRowMatrix
 	Couples
 	TouchedFiles
+	CouplesSignificance
+	CouplesDecay
 	CouplesAnalysisResults
 	UASTChange
 	UASTChangesSaverResults
@@ -24,6 +32,117 @@ It has these top-level messages:
 	FileHistoryResultMessage
 	Sentiment
 	CommentSentimentResults
+	RecordedColumn
+	RecordedStream
+	RecorderResults
+	ActivityDay
+	ActiveDevelopers
+	ActivityAnalysisResults
+	LanguageCounts
+	CommitLanguagesAnalysisResults
+	ImpactChurnDay
+	ImpactChurnFile
+	ImpactChurnAnalysisResults
+	DAGShapeTick
+	DAGShapeAnalysisResults
+	RenameChain
+	RenameFrequencyAnalysisResults
+	RewriteDepthFile
+	RewriteHotspot
+	RewriteDepthAnalysisResults
+	SensitivePathChange
+	SensitivePathsAnalysisResults
+	ContributorsTick
+	ContributorsAnalysisResults
+	StewardshipCounts
+	StewardshipTick
+	StewardshipAnalysisResults
+	TeamAlignmentDirectory
+	TeamAlignmentTick
+	TeamAlignmentAnalysisResults
+	DefectFeaturesAnalysisResults
+	CocomoTick
+	CocomoAnalysisResults
+	ReviewLatencyStats
+	ReviewLatencyAnalysisResults
+	IssueTicket
+	IssueReferencesAnalysisResults
+	ConventionalCommitsStats
+	ConventionalCommitsAnalysisResults
+	LanguageLines
+	LanguageLinesAnalysisResults
+	RepositorySizeTick
+	RepositoryBlob
+	RepositorySizeAnalysisResults
+	FileAge
+	FileAgeAnalysisResults
+	RefactoringStats
+	RefactoringAnalysisResults
+	RevertsTick
+	RevertedCommit
+	RevertsAnalysisResults
+	BranchMerge
+	BranchLifetimeAnalysisResults
+	Release
+	ReleaseCadenceAnalysisResults
+	GiniTick
+	GiniAnalysisResults
+	OnboardingDeveloper
+	OnboardingDirectory
+	OnboardingCohort
+	OnboardingAnalysisResults
+	TenureTick
+	TenureSpan
+	TenureDeveloper
+	TenureAnalysisResults
+	WorkPattern
+	WorkPatternsStreak
+	WorkPatternsAnalysisResults
+	KnowledgeMapVector
+	KnowledgeMapAnalysisResults
+	DeadCodeTick
+	DeadCodeOrphan
+	DeadCodeAnalysisResults
+	CommitSentiment
+	CommitSentimentAnalysisResults
+	Hotspot
+	HotspotsAnalysisResults
+	TestRatioTick
+	TestRatioAnalysisResults
+	VocabularyTick
+	VocabularyTerms
+	VocabularyAnalysisResults
+	ImportGraphTick
+	ImportGraphEdge
+	ImportGraphAnalysisResults
+	APISurfaceTick
+	APIBreakingChange
+	APISurfaceAnalysisResults
+	ClonesTick
+	ClonesCommit
+	ClonesAnalysisResults
+	TechDebtTick
+	TechDebtMarker
+	TechDebtAnalysisResults
+	CommentDensity
+	CommentDensitySeries
+	CommentDensityAnalysisResults
+	Complexity
+	ComplexitySeries
+	ComplexityFunction
+	ComplexityCommit
+	ComplexityAnalysisResults
+	FunctionChurnDay
+	FunctionChurn
+	FunctionChurnAnalysisResults
+	HistoryRewrite
+	HistoryRewritesAnalysisResults
+	ChangeEntropyDay
+	ChangeEntropyCommit
+	ChangeEntropyAnalysisResults
+	ExpertiseVector
+	ExpertiseAnalysisResults
+	OwnershipAnalysisResults
 	AnalysisResults
 */
 package pb
@@ -60,6 +179,14 @@ type Metadata struct {
 	RunTime int64 `protobuf:"varint,7,opt,name=run_time,json=runTime,proto3" json:"run_time,omitempty"`
 	// time taken by each pipeline item in seconds
 	RunTimePerItem map[string]float64 `protobuf:"bytes,8,rep,name=run_time_per_item,json=runTimePerItem" json:"run_time_per_item,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// resource usage of each pipeline item, collected with --self-profile
+	ProfilePerItem map[string]*ItemProfile `protobuf:"bytes,9,rep,name=profile_per_item,json=profilePerItem" json:"profile_per_item,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// labelled moments in the project history, e.g. releases and reorgs
+	Annotations []*Annotation `protobuf:"bytes,10,rep,name=annotations" json:"annotations,omitempty"`
+	// identities after the merging, each is the names and the emails separated by "|"
+	People []string `protobuf:"bytes,11,rep,name=people" json:"people,omitempty"`
+	// files which could not be converted to UASTs
+	UnparsedFiles map[string]*UnparsedFile `protobuf:"bytes,12,rep,name=unparsed_files,json=unparsedFiles" json:"unparsed_files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *Metadata) Reset()                    { *m = Metadata{} }
@@ -123,6 +250,139 @@ func (m *Metadata) GetRunTimePerItem() map[string]float64 {
 	return nil
 }
 
+func (m *Metadata) GetProfilePerItem() map[string]*ItemProfile {
+	if m != nil {
+		return m.ProfilePerItem
+	}
+	return nil
+}
+
+func (m *Metadata) GetAnnotations() []*Annotation {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+func (m *Metadata) GetPeople() []string {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+func (m *Metadata) GetUnparsedFiles() map[string]*UnparsedFile {
+	if m != nil {
+		return m.UnparsedFiles
+	}
+	return nil
+}
+
+type UnparsedFile struct {
+	// language of the file, empty if unknown
+	Language string `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	// "unsupported", "unavailable" or the parsing error
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (m *UnparsedFile) Reset()                    { *m = UnparsedFile{} }
+func (m *UnparsedFile) String() string            { return proto.CompactTextString(m) }
+func (*UnparsedFile) ProtoMessage()               {}
+func (*UnparsedFile) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{1} }
+
+func (m *UnparsedFile) GetLanguage() string {
+	if m != nil {
+		return m.Language
+	}
+	return ""
+}
+
+func (m *UnparsedFile) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type Annotation struct {
+	// UNIX timestamp of the event
+	UnixTime int64 `protobuf:"varint,1,opt,name=unix_time,json=unixTime,proto3" json:"unix_time,omitempty"`
+	// description of the event
+	Label string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+}
+
+func (m *Annotation) Reset()                    { *m = Annotation{} }
+func (m *Annotation) String() string            { return proto.CompactTextString(m) }
+func (*Annotation) ProtoMessage()               {}
+func (*Annotation) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{2} }
+
+func (m *Annotation) GetUnixTime() int64 {
+	if m != nil {
+		return m.UnixTime
+	}
+	return 0
+}
+
+func (m *Annotation) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+type ItemProfile struct {
+	// elapsed real time in seconds
+	WallTime float64 `protobuf:"fixed64,1,opt,name=wall_time,json=wallTime,proto3" json:"wall_time,omitempty"`
+	// CPU time of the whole process in seconds while the item was running
+	CpuTime float64 `protobuf:"fixed64,2,opt,name=cpu_time,json=cpuTime,proto3" json:"cpu_time,omitempty"`
+	// number of allocated heap objects
+	Allocations int64 `protobuf:"varint,3,opt,name=allocations,proto3" json:"allocations,omitempty"`
+	// number of allocated heap bytes
+	AllocatedBytes int64 `protobuf:"varint,4,opt,name=allocated_bytes,json=allocatedBytes,proto3" json:"allocated_bytes,omitempty"`
+	// number of Consume() and Finalize() calls
+	Calls int64 `protobuf:"varint,5,opt,name=calls,proto3" json:"calls,omitempty"`
+}
+
+func (m *ItemProfile) Reset()                    { *m = ItemProfile{} }
+func (m *ItemProfile) String() string            { return proto.CompactTextString(m) }
+func (*ItemProfile) ProtoMessage()               {}
+func (*ItemProfile) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{3} }
+
+func (m *ItemProfile) GetWallTime() float64 {
+	if m != nil {
+		return m.WallTime
+	}
+	return 0
+}
+
+func (m *ItemProfile) GetCpuTime() float64 {
+	if m != nil {
+		return m.CpuTime
+	}
+	return 0
+}
+
+func (m *ItemProfile) GetAllocations() int64 {
+	if m != nil {
+		return m.Allocations
+	}
+	return 0
+}
+
+func (m *ItemProfile) GetAllocatedBytes() int64 {
+	if m != nil {
+		return m.AllocatedBytes
+	}
+	return 0
+}
+
+func (m *ItemProfile) GetCalls() int64 {
+	if m != nil {
+		return m.Calls
+	}
+	return 0
+}
+
 type BurndownSparseMatrixRow struct {
 	// the first `len(column)` elements are stored,
 	// the rest `number_of_columns - len(column)` values are zeros
@@ -132,7 +392,7 @@ type BurndownSparseMatrixRow struct {
 func (m *BurndownSparseMatrixRow) Reset()                    { *m = BurndownSparseMatrixRow{} }
 func (m *BurndownSparseMatrixRow) String() string            { return proto.CompactTextString(m) }
 func (*BurndownSparseMatrixRow) ProtoMessage()               {}
-func (*BurndownSparseMatrixRow) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{1} }
+func (*BurndownSparseMatrixRow) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{4} }
 
 func (m *BurndownSparseMatrixRow) GetColumns() []uint32 {
 	if m != nil {
@@ -147,12 +407,15 @@ type BurndownSparseMatrix struct {
 	NumberOfColumns int32  `protobuf:"varint,3,opt,name=number_of_columns,json=numberOfColumns,proto3" json:"number_of_columns,omitempty"`
 	// `len(row)` matches `number_of_rows`
 	Rows []*BurndownSparseMatrixRow `protobuf:"bytes,4,rep,name=rows" json:"rows,omitempty"`
+	// the number of lines which existed before the first analysed commit in each row,
+	// this is included if `--burndown-boundary` is not "commit"
+	PreHistory []int64 `protobuf:"varint,5,rep,packed,name=pre_history,json=preHistory" json:"pre_history,omitempty"`
 }
 
 func (m *BurndownSparseMatrix) Reset()                    { *m = BurndownSparseMatrix{} }
 func (m *BurndownSparseMatrix) String() string            { return proto.CompactTextString(m) }
 func (*BurndownSparseMatrix) ProtoMessage()               {}
-func (*BurndownSparseMatrix) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{2} }
+func (*BurndownSparseMatrix) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{5} }
 
 func (m *BurndownSparseMatrix) GetName() string {
 	if m != nil {
@@ -182,6 +445,83 @@ func (m *BurndownSparseMatrix) GetRows() []*BurndownSparseMatrixRow {
 	return nil
 }
 
+func (m *BurndownSparseMatrix) GetPreHistory() []int64 {
+	if m != nil {
+		return m.PreHistory
+	}
+	return nil
+}
+
+type BurndownCohort struct {
+	// the number of lines at the end of the band
+	Lines int64 `protobuf:"varint,1,opt,name=lines,proto3" json:"lines,omitempty"`
+	// the fraction of the lines alive at each sample since the end of the band
+	Curve []float32 `protobuf:"fixed32,2,rep,packed,name=curve" json:"curve,omitempty"`
+	// median lifetime in days, negative if more than half of the lines are alive
+	HalfLife float32 `protobuf:"fixed32,3,opt,name=half_life,json=halfLife,proto3" json:"half_life,omitempty"`
+}
+
+func (m *BurndownCohort) Reset()                    { *m = BurndownCohort{} }
+func (m *BurndownCohort) String() string            { return proto.CompactTextString(m) }
+func (*BurndownCohort) ProtoMessage()               {}
+func (*BurndownCohort) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{6} }
+
+func (m *BurndownCohort) GetLines() int64 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *BurndownCohort) GetCurve() []float32 {
+	if m != nil {
+		return m.Curve
+	}
+	return nil
+}
+
+func (m *BurndownCohort) GetHalfLife() float32 {
+	if m != nil {
+		return m.HalfLife
+	}
+	return 0
+}
+
+type BurndownSurvival struct {
+	// the fraction of the lines alive at each age pooled over all the cohorts
+	Curve []float32 `protobuf:"fixed32,1,rep,packed,name=curve" json:"curve,omitempty"`
+	// median line lifetime in days, negative if more than half of the lines are alive
+	HalfLife float32 `protobuf:"fixed32,2,opt,name=half_life,json=halfLife,proto3" json:"half_life,omitempty"`
+	// indexed by band
+	Cohorts []*BurndownCohort `protobuf:"bytes,3,rep,name=cohorts" json:"cohorts,omitempty"`
+}
+
+func (m *BurndownSurvival) Reset()                    { *m = BurndownSurvival{} }
+func (m *BurndownSurvival) String() string            { return proto.CompactTextString(m) }
+func (*BurndownSurvival) ProtoMessage()               {}
+func (*BurndownSurvival) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{7} }
+
+func (m *BurndownSurvival) GetCurve() []float32 {
+	if m != nil {
+		return m.Curve
+	}
+	return nil
+}
+
+func (m *BurndownSurvival) GetHalfLife() float32 {
+	if m != nil {
+		return m.HalfLife
+	}
+	return 0
+}
+
+func (m *BurndownSurvival) GetCohorts() []*BurndownCohort {
+	if m != nil {
+		return m.Cohorts
+	}
+	return nil
+}
+
 type BurndownAnalysisResults struct {
 	// how many days are in each band [burndown_project, burndown_file, burndown_developer]
 	Granularity int32 `protobuf:"varint,1,opt,name=granularity,proto3" json:"granularity,omitempty"`
@@ -195,12 +535,20 @@ type BurndownAnalysisResults struct {
 	People []*BurndownSparseMatrix `protobuf:"bytes,5,rep,name=people" json:"people,omitempty"`
 	// rows and cols order correspond to `burndown_developer`
 	PeopleInteraction *CompressedSparseRowMatrix `protobuf:"bytes,6,opt,name=people_interaction,json=peopleInteraction" json:"people_interaction,omitempty"`
+	// this is included if `-burndown-tree` was specified
+	Snapshots []*FileSnapshot `protobuf:"bytes,7,rep,name=snapshots" json:"snapshots,omitempty"`
+	// this is included if `-burndown-dirs` was specified
+	Directories []*BurndownSparseMatrix `protobuf:"bytes,8,rep,name=directories" json:"directories,omitempty"`
+	// this is included if `-burndown-languages` was specified
+	Languages []*BurndownSparseMatrix `protobuf:"bytes,9,rep,name=languages" json:"languages,omitempty"`
+	// this is included if `-burndown-survival` was specified
+	Survival *BurndownSurvival `protobuf:"bytes,10,opt,name=survival" json:"survival,omitempty"`
 }
 
 func (m *BurndownAnalysisResults) Reset()                    { *m = BurndownAnalysisResults{} }
 func (m *BurndownAnalysisResults) String() string            { return proto.CompactTextString(m) }
 func (*BurndownAnalysisResults) ProtoMessage()               {}
-func (*BurndownAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{3} }
+func (*BurndownAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{8} }
 
 func (m *BurndownAnalysisResults) GetGranularity() int32 {
 	if m != nil {
@@ -244,6 +592,68 @@ func (m *BurndownAnalysisResults) GetPeopleInteraction() *CompressedSparseRowMat
 	return nil
 }
 
+func (m *BurndownAnalysisResults) GetSnapshots() []*FileSnapshot {
+	if m != nil {
+		return m.Snapshots
+	}
+	return nil
+}
+
+func (m *BurndownAnalysisResults) GetDirectories() []*BurndownSparseMatrix {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+func (m *BurndownAnalysisResults) GetLanguages() []*BurndownSparseMatrix {
+	if m != nil {
+		return m.Languages
+	}
+	return nil
+}
+
+func (m *BurndownAnalysisResults) GetSurvival() *BurndownSurvival {
+	if m != nil {
+		return m.Survival
+	}
+	return nil
+}
+
+type FileSnapshot struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// age of the lines in days -> number of lines
+	Ages map[int32]int64 `protobuf:"bytes,2,rep,name=ages" json:"ages,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// index in `people` -> number of lines, -1 means an unidentified developer
+	Owners map[int32]int64 `protobuf:"bytes,3,rep,name=owners" json:"owners,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (m *FileSnapshot) Reset()                    { *m = FileSnapshot{} }
+func (m *FileSnapshot) String() string            { return proto.CompactTextString(m) }
+func (*FileSnapshot) ProtoMessage()               {}
+func (*FileSnapshot) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{9} }
+
+func (m *FileSnapshot) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FileSnapshot) GetAges() map[int32]int64 {
+	if m != nil {
+		return m.Ages
+	}
+	return nil
+}
+
+func (m *FileSnapshot) GetOwners() map[int32]int64 {
+	if m != nil {
+		return m.Owners
+	}
+	return nil
+}
+
 type CompressedSparseRowMatrix struct {
 	NumberOfRows    int32 `protobuf:"varint,1,opt,name=number_of_rows,json=numberOfRows,proto3" json:"number_of_rows,omitempty"`
 	NumberOfColumns int32 `protobuf:"varint,2,opt,name=number_of_columns,json=numberOfColumns,proto3" json:"number_of_columns,omitempty"`
@@ -256,7 +666,7 @@ type CompressedSparseRowMatrix struct {
 func (m *CompressedSparseRowMatrix) Reset()                    { *m = CompressedSparseRowMatrix{} }
 func (m *CompressedSparseRowMatrix) String() string            { return proto.CompactTextString(m) }
 func (*CompressedSparseRowMatrix) ProtoMessage()               {}
-func (*CompressedSparseRowMatrix) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{4} }
+func (*CompressedSparseRowMatrix) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{10} }
 
 func (m *CompressedSparseRowMatrix) GetNumberOfRows() int32 {
 	if m != nil {
@@ -303,7 +713,7 @@ type Couples struct {
 func (m *Couples) Reset()                    { *m = Couples{} }
 func (m *Couples) String() string            { return proto.CompactTextString(m) }
 func (*Couples) ProtoMessage()               {}
-func (*Couples) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{5} }
+func (*Couples) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{11} }
 
 func (m *Couples) GetIndex() []string {
 	if m != nil {
@@ -326,7 +736,7 @@ type TouchedFiles struct {
 func (m *TouchedFiles) Reset()                    { *m = TouchedFiles{} }
 func (m *TouchedFiles) String() string            { return proto.CompactTextString(m) }
 func (*TouchedFiles) ProtoMessage()               {}
-func (*TouchedFiles) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{6} }
+func (*TouchedFiles) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{12} }
 
 func (m *TouchedFiles) GetFiles() []int32 {
 	if m != nil {
@@ -335,17 +745,127 @@ func (m *TouchedFiles) GetFiles() []int32 {
 	return nil
 }
 
+type CouplesSignificance struct {
+	// number of non-merge commits which were analysed
+	Commits int32 `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
+	// order corresponds to `file_couples::matrix::data`
+	Lift []float32 `protobuf:"fixed32,2,rep,packed,name=lift" json:"lift,omitempty"`
+	// order corresponds to `file_couples::matrix::data`
+	ChiSquare []float32 `protobuf:"fixed32,3,rep,packed,name=chi_square,json=chiSquare" json:"chi_square,omitempty"`
+	// order corresponds to `file_couples::matrix::data`
+	Jaccard []float32 `protobuf:"fixed32,4,rep,packed,name=jaccard" json:"jaccard,omitempty"`
+	// the fraction of the commits of the row's file which also changed the column's file;
+	// order corresponds to `file_couples::matrix::data`
+	Confidence []float32 `protobuf:"fixed32,5,rep,packed,name=confidence" json:"confidence,omitempty"`
+}
+
+func (m *CouplesSignificance) Reset()                    { *m = CouplesSignificance{} }
+func (m *CouplesSignificance) String() string            { return proto.CompactTextString(m) }
+func (*CouplesSignificance) ProtoMessage()               {}
+func (*CouplesSignificance) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{13} }
+
+func (m *CouplesSignificance) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *CouplesSignificance) GetLift() []float32 {
+	if m != nil {
+		return m.Lift
+	}
+	return nil
+}
+
+func (m *CouplesSignificance) GetChiSquare() []float32 {
+	if m != nil {
+		return m.ChiSquare
+	}
+	return nil
+}
+
+func (m *CouplesSignificance) GetJaccard() []float32 {
+	if m != nil {
+		return m.Jaccard
+	}
+	return nil
+}
+
+func (m *CouplesSignificance) GetConfidence() []float32 {
+	if m != nil {
+		return m.Confidence
+	}
+	return nil
+}
+
+type CouplesDecay struct {
+	// in days, 0 means no decay
+	HalfLife int32 `protobuf:"varint,1,opt,name=half_life,json=halfLife,proto3" json:"half_life,omitempty"`
+	// in days, 0 means the whole history
+	Window int32 `protobuf:"varint,2,opt,name=window,proto3" json:"window,omitempty"`
+	// CSR matrix of the weighted common commits, the rows and the columns correspond
+	// to `file_couples::index`
+	Indptr  []int64   `protobuf:"varint,3,rep,packed,name=indptr" json:"indptr,omitempty"`
+	Indices []int32   `protobuf:"varint,4,rep,packed,name=indices" json:"indices,omitempty"`
+	Data    []float32 `protobuf:"fixed32,5,rep,packed,name=data" json:"data,omitempty"`
+}
+
+func (m *CouplesDecay) Reset()                    { *m = CouplesDecay{} }
+func (m *CouplesDecay) String() string            { return proto.CompactTextString(m) }
+func (*CouplesDecay) ProtoMessage()               {}
+func (*CouplesDecay) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{14} }
+
+func (m *CouplesDecay) GetHalfLife() int32 {
+	if m != nil {
+		return m.HalfLife
+	}
+	return 0
+}
+
+func (m *CouplesDecay) GetWindow() int32 {
+	if m != nil {
+		return m.Window
+	}
+	return 0
+}
+
+func (m *CouplesDecay) GetIndptr() []int64 {
+	if m != nil {
+		return m.Indptr
+	}
+	return nil
+}
+
+func (m *CouplesDecay) GetIndices() []int32 {
+	if m != nil {
+		return m.Indices
+	}
+	return nil
+}
+
+func (m *CouplesDecay) GetData() []float32 {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
 type CouplesAnalysisResults struct {
 	FileCouples   *Couples `protobuf:"bytes,6,opt,name=file_couples,json=fileCouples" json:"file_couples,omitempty"`
 	PeopleCouples *Couples `protobuf:"bytes,7,opt,name=people_couples,json=peopleCouples" json:"people_couples,omitempty"`
 	// order corresponds to `people_couples::index`
-	PeopleFiles []*TouchedFiles `protobuf:"bytes,8,rep,name=people_files,json=peopleFiles" json:"people_files,omitempty"`
+	PeopleFiles      []*TouchedFiles      `protobuf:"bytes,8,rep,name=people_files,json=peopleFiles" json:"people_files,omitempty"`
+	FileSignificance *CouplesSignificance `protobuf:"bytes,9,opt,name=file_significance,json=fileSignificance" json:"file_significance,omitempty"`
+	FileDecay        *CouplesDecay        `protobuf:"bytes,10,opt,name=file_decay,json=fileDecay" json:"file_decay,omitempty"`
+	// the directories truncated to the configured depth
+	DirectoryCouples *Couples `protobuf:"bytes,11,opt,name=directory_couples,json=directoryCouples" json:"directory_couples,omitempty"`
 }
 
 func (m *CouplesAnalysisResults) Reset()                    { *m = CouplesAnalysisResults{} }
 func (m *CouplesAnalysisResults) String() string            { return proto.CompactTextString(m) }
 func (*CouplesAnalysisResults) ProtoMessage()               {}
-func (*CouplesAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{7} }
+func (*CouplesAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{15} }
 
 func (m *CouplesAnalysisResults) GetFileCouples() *Couples {
 	if m != nil {
@@ -368,6 +888,27 @@ func (m *CouplesAnalysisResults) GetPeopleFiles() []*TouchedFiles {
 	return nil
 }
 
+func (m *CouplesAnalysisResults) GetFileSignificance() *CouplesSignificance {
+	if m != nil {
+		return m.FileSignificance
+	}
+	return nil
+}
+
+func (m *CouplesAnalysisResults) GetFileDecay() *CouplesDecay {
+	if m != nil {
+		return m.FileDecay
+	}
+	return nil
+}
+
+func (m *CouplesAnalysisResults) GetDirectoryCouples() *Couples {
+	if m != nil {
+		return m.DirectoryCouples
+	}
+	return nil
+}
+
 type UASTChange struct {
 	FileName   string `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
 	SrcBefore  string `protobuf:"bytes,2,opt,name=src_before,json=srcBefore,proto3" json:"src_before,omitempty"`
@@ -379,7 +920,7 @@ type UASTChange struct {
 func (m *UASTChange) Reset()                    { *m = UASTChange{} }
 func (m *UASTChange) String() string            { return proto.CompactTextString(m) }
 func (*UASTChange) ProtoMessage()               {}
-func (*UASTChange) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{8} }
+func (*UASTChange) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{16} }
 
 func (m *UASTChange) GetFileName() string {
 	if m != nil {
@@ -423,7 +964,7 @@ type UASTChangesSaverResults struct {
 func (m *UASTChangesSaverResults) Reset()                    { *m = UASTChangesSaverResults{} }
 func (m *UASTChangesSaverResults) String() string            { return proto.CompactTextString(m) }
 func (*UASTChangesSaverResults) ProtoMessage()               {}
-func (*UASTChangesSaverResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{9} }
+func (*UASTChangesSaverResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{17} }
 
 func (m *UASTChangesSaverResults) GetChanges() []*UASTChange {
 	if m != nil {
@@ -443,7 +984,7 @@ type ShotnessRecord struct {
 func (m *ShotnessRecord) Reset()                    { *m = ShotnessRecord{} }
 func (m *ShotnessRecord) String() string            { return proto.CompactTextString(m) }
 func (*ShotnessRecord) ProtoMessage()               {}
-func (*ShotnessRecord) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{10} }
+func (*ShotnessRecord) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{18} }
 
 func (m *ShotnessRecord) GetInternalRole() string {
 	if m != nil {
@@ -487,7 +1028,7 @@ type ShotnessAnalysisResults struct {
 func (m *ShotnessAnalysisResults) Reset()                    { *m = ShotnessAnalysisResults{} }
 func (m *ShotnessAnalysisResults) String() string            { return proto.CompactTextString(m) }
 func (*ShotnessAnalysisResults) ProtoMessage()               {}
-func (*ShotnessAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{11} }
+func (*ShotnessAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{19} }
 
 func (m *ShotnessAnalysisResults) GetRecords() []*ShotnessRecord {
 	if m != nil {
@@ -503,7 +1044,7 @@ type FileHistory struct {
 func (m *FileHistory) Reset()                    { *m = FileHistory{} }
 func (m *FileHistory) String() string            { return proto.CompactTextString(m) }
 func (*FileHistory) ProtoMessage()               {}
-func (*FileHistory) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{12} }
+func (*FileHistory) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{20} }
 
 func (m *FileHistory) GetCommits() []string {
 	if m != nil {
@@ -519,7 +1060,7 @@ type FileHistoryResultMessage struct {
 func (m *FileHistoryResultMessage) Reset()                    { *m = FileHistoryResultMessage{} }
 func (m *FileHistoryResultMessage) String() string            { return proto.CompactTextString(m) }
 func (*FileHistoryResultMessage) ProtoMessage()               {}
-func (*FileHistoryResultMessage) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{13} }
+func (*FileHistoryResultMessage) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{21} }
 
 func (m *FileHistoryResultMessage) GetFiles() map[string]*FileHistory {
 	if m != nil {
@@ -537,7 +1078,7 @@ type Sentiment struct {
 func (m *Sentiment) Reset()                    { *m = Sentiment{} }
 func (m *Sentiment) String() string            { return proto.CompactTextString(m) }
 func (*Sentiment) ProtoMessage()               {}
-func (*Sentiment) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{14} }
+func (*Sentiment) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{22} }
 
 func (m *Sentiment) GetValue() float32 {
 	if m != nil {
@@ -567,7 +1108,7 @@ type CommentSentimentResults struct {
 func (m *CommentSentimentResults) Reset()                    { *m = CommentSentimentResults{} }
 func (m *CommentSentimentResults) String() string            { return proto.CompactTextString(m) }
 func (*CommentSentimentResults) ProtoMessage()               {}
-func (*CommentSentimentResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{15} }
+func (*CommentSentimentResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{23} }
 
 func (m *CommentSentimentResults) GetSentimentByDay() map[int32]*Sentiment {
 	if m != nil {
@@ -576,6 +1117,4396 @@ func (m *CommentSentimentResults) GetSentimentByDay() map[int32]*Sentiment {
 	return nil
 }
 
+type RecordedColumn struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// values of an integer column or indexes in `dictionary` of a string column
+	Values []int64 `protobuf:"varint,2,rep,packed,name=values" json:"values,omitempty"`
+	// unique values of a string column, empty for integer columns
+	Dictionary []string `protobuf:"bytes,3,rep,name=dictionary" json:"dictionary,omitempty"`
+}
+
+func (m *RecordedColumn) Reset()                    { *m = RecordedColumn{} }
+func (m *RecordedColumn) String() string            { return proto.CompactTextString(m) }
+func (*RecordedColumn) ProtoMessage()               {}
+func (*RecordedColumn) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{24} }
+
+func (m *RecordedColumn) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RecordedColumn) GetValues() []int64 {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *RecordedColumn) GetDictionary() []string {
+	if m != nil {
+		return m.Dictionary
+	}
+	return nil
+}
+
+type RecordedStream struct {
+	// name of the recorded dependency
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// all columns have the same length, "commit" indexes `RecorderResults::commits`
+	Columns []*RecordedColumn `protobuf:"bytes,2,rep,name=columns" json:"columns,omitempty"`
+}
+
+func (m *RecordedStream) Reset()                    { *m = RecordedStream{} }
+func (m *RecordedStream) String() string            { return proto.CompactTextString(m) }
+func (*RecordedStream) ProtoMessage()               {}
+func (*RecordedStream) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{25} }
+
+func (m *RecordedStream) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RecordedStream) GetColumns() []*RecordedColumn {
+	if m != nil {
+		return m.Columns
+	}
+	return nil
+}
+
+type RecorderResults struct {
+	// hashes of the recorded commits
+	Commits []string          `protobuf:"bytes,1,rep,name=commits" json:"commits,omitempty"`
+	Streams []*RecordedStream `protobuf:"bytes,2,rep,name=streams" json:"streams,omitempty"`
+}
+
+func (m *RecorderResults) Reset()                    { *m = RecorderResults{} }
+func (m *RecorderResults) String() string            { return proto.CompactTextString(m) }
+func (*RecorderResults) ProtoMessage()               {}
+func (*RecorderResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{26} }
+
+func (m *RecorderResults) GetCommits() []string {
+	if m != nil {
+		return m.Commits
+	}
+	return nil
+}
+
+func (m *RecorderResults) GetStreams() []*RecordedStream {
+	if m != nil {
+		return m.Streams
+	}
+	return nil
+}
+
+type ActivityDay struct {
+	// developer index in `ActivityAnalysisResults::dev_index` -> number of commits
+	Commits map[int32]int32 `protobuf:"bytes,1,rep,name=commits" json:"commits,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (m *ActivityDay) Reset()                    { *m = ActivityDay{} }
+func (m *ActivityDay) String() string            { return proto.CompactTextString(m) }
+func (*ActivityDay) ProtoMessage()               {}
+func (*ActivityDay) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{27} }
+
+func (m *ActivityDay) GetCommits() map[int32]int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return nil
+}
+
+type ActiveDevelopers struct {
+	// indices in `dev_index`
+	Developers []int32 `protobuf:"varint,1,rep,packed,name=developers" json:"developers,omitempty"`
+}
+
+func (m *ActiveDevelopers) Reset()                    { *m = ActiveDevelopers{} }
+func (m *ActiveDevelopers) String() string            { return proto.CompactTextString(m) }
+func (*ActiveDevelopers) ProtoMessage()               {}
+func (*ActiveDevelopers) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{28} }
+
+func (m *ActiveDevelopers) GetDevelopers() []int32 {
+	if m != nil {
+		return m.Developers
+	}
+	return nil
+}
+
+type ActivityAnalysisResults struct {
+	// day since the beginning of the history -> commits of each developer
+	Days map[int32]*ActivityDay `protobuf:"bytes,1,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// the following two correspond to `dev_index`, the last element is the unmatched identities
+	PeopleCommits []int32  `protobuf:"varint,2,rep,packed,name=people_commits,json=peopleCommits" json:"people_commits,omitempty"`
+	PeopleFiles   []int32  `protobuf:"varint,3,rep,packed,name=people_files,json=peopleFiles" json:"people_files,omitempty"`
+	DevIndex      []string `protobuf:"bytes,4,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+	// length of the time window in days which defines the active developers
+	ActiveWindow int32 `protobuf:"varint,5,opt,name=active_window,json=activeWindow,proto3" json:"active_window,omitempty"`
+	// window index -> active developers, windows without any are omitted
+	Active map[int32]*ActiveDevelopers `protobuf:"bytes,6,rep,name=active" json:"active,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *ActivityAnalysisResults) Reset()                    { *m = ActivityAnalysisResults{} }
+func (m *ActivityAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*ActivityAnalysisResults) ProtoMessage()               {}
+func (*ActivityAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{29} }
+
+func (m *ActivityAnalysisResults) GetDays() map[int32]*ActivityDay {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+func (m *ActivityAnalysisResults) GetPeopleCommits() []int32 {
+	if m != nil {
+		return m.PeopleCommits
+	}
+	return nil
+}
+
+func (m *ActivityAnalysisResults) GetPeopleFiles() []int32 {
+	if m != nil {
+		return m.PeopleFiles
+	}
+	return nil
+}
+
+func (m *ActivityAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+func (m *ActivityAnalysisResults) GetActiveWindow() int32 {
+	if m != nil {
+		return m.ActiveWindow
+	}
+	return 0
+}
+
+func (m *ActivityAnalysisResults) GetActive() map[int32]*ActiveDevelopers {
+	if m != nil {
+		return m.Active
+	}
+	return nil
+}
+
+type LanguageCounts struct {
+	// ISO 639-1 language code or "und" -> number of commits
+	Languages map[string]int32 `protobuf:"bytes,1,rep,name=languages" json:"languages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (m *LanguageCounts) Reset()                    { *m = LanguageCounts{} }
+func (m *LanguageCounts) String() string            { return proto.CompactTextString(m) }
+func (*LanguageCounts) ProtoMessage()               {}
+func (*LanguageCounts) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{30} }
+
+func (m *LanguageCounts) GetLanguages() map[string]int32 {
+	if m != nil {
+		return m.Languages
+	}
+	return nil
+}
+
+type CommitLanguagesAnalysisResults struct {
+	// day since the beginning of the history -> languages of the commit messages
+	Days map[int32]*LanguageCounts `protobuf:"bytes,1,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// corresponds to `dev_index`, the last element is the unmatched identities
+	People   []*LanguageCounts `protobuf:"bytes,2,rep,name=people" json:"people,omitempty"`
+	DevIndex []string          `protobuf:"bytes,3,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *CommitLanguagesAnalysisResults) Reset()         { *m = CommitLanguagesAnalysisResults{} }
+func (m *CommitLanguagesAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*CommitLanguagesAnalysisResults) ProtoMessage()    {}
+func (*CommitLanguagesAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptorPb, []int{31}
+}
+
+func (m *CommitLanguagesAnalysisResults) GetDays() map[int32]*LanguageCounts {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+func (m *CommitLanguagesAnalysisResults) GetPeople() []*LanguageCounts {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+func (m *CommitLanguagesAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type ImpactChurnDay struct {
+	Added   int32 `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	Removed int32 `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"`
+	// the sum of the changed lines multiplied by (1 + fan-in) of each file
+	Weighted float64 `protobuf:"fixed64,3,opt,name=weighted,proto3" json:"weighted,omitempty"`
+}
+
+func (m *ImpactChurnDay) Reset()                    { *m = ImpactChurnDay{} }
+func (m *ImpactChurnDay) String() string            { return proto.CompactTextString(m) }
+func (*ImpactChurnDay) ProtoMessage()               {}
+func (*ImpactChurnDay) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{32} }
+
+func (m *ImpactChurnDay) GetAdded() int32 {
+	if m != nil {
+		return m.Added
+	}
+	return 0
+}
+
+func (m *ImpactChurnDay) GetRemoved() int32 {
+	if m != nil {
+		return m.Removed
+	}
+	return 0
+}
+
+func (m *ImpactChurnDay) GetWeighted() float64 {
+	if m != nil {
+		return m.Weighted
+	}
+	return 0
+}
+
+type ImpactChurnFile struct {
+	// total number of added and removed lines
+	Lines int32 `protobuf:"varint,1,opt,name=lines,proto3" json:"lines,omitempty"`
+	// fan-in at the time of the last change
+	FanIn    int32   `protobuf:"varint,2,opt,name=fan_in,json=fanIn,proto3" json:"fan_in,omitempty"`
+	Weighted float64 `protobuf:"fixed64,3,opt,name=weighted,proto3" json:"weighted,omitempty"`
+}
+
+func (m *ImpactChurnFile) Reset()                    { *m = ImpactChurnFile{} }
+func (m *ImpactChurnFile) String() string            { return proto.CompactTextString(m) }
+func (*ImpactChurnFile) ProtoMessage()               {}
+func (*ImpactChurnFile) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{33} }
+
+func (m *ImpactChurnFile) GetLines() int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *ImpactChurnFile) GetFanIn() int32 {
+	if m != nil {
+		return m.FanIn
+	}
+	return 0
+}
+
+func (m *ImpactChurnFile) GetWeighted() float64 {
+	if m != nil {
+		return m.Weighted
+	}
+	return 0
+}
+
+type ImpactChurnAnalysisResults struct {
+	// day since the beginning of the history -> churn
+	Days map[int32]*ImpactChurnDay `protobuf:"bytes,1,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// file path -> churn
+	Files map[string]*ImpactChurnFile `protobuf:"bytes,2,rep,name=files" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// "coupling" or "imports"
+	FanInMode string `protobuf:"bytes,3,opt,name=fan_in_mode,json=fanInMode,proto3" json:"fan_in_mode,omitempty"`
+}
+
+func (m *ImpactChurnAnalysisResults) Reset()                    { *m = ImpactChurnAnalysisResults{} }
+func (m *ImpactChurnAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*ImpactChurnAnalysisResults) ProtoMessage()               {}
+func (*ImpactChurnAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{34} }
+
+func (m *ImpactChurnAnalysisResults) GetDays() map[int32]*ImpactChurnDay {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+func (m *ImpactChurnAnalysisResults) GetFiles() map[string]*ImpactChurnFile {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *ImpactChurnAnalysisResults) GetFanInMode() string {
+	if m != nil {
+		return m.FanInMode
+	}
+	return ""
+}
+
+type DAGShapeTick struct {
+	Commits int32 `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
+	Merges  int32 `protobuf:"varint,2,opt,name=merges,proto3" json:"merges,omitempty"`
+	// the total number of parents of the commits
+	Parents      int32 `protobuf:"varint,3,opt,name=parents,proto3" json:"parents,omitempty"`
+	MaxBranches  int32 `protobuf:"varint,4,opt,name=max_branches,json=maxBranches,proto3" json:"max_branches,omitempty"`
+	LongestChain int32 `protobuf:"varint,5,opt,name=longest_chain,json=longestChain,proto3" json:"longest_chain,omitempty"`
+}
+
+func (m *DAGShapeTick) Reset()                    { *m = DAGShapeTick{} }
+func (m *DAGShapeTick) String() string            { return proto.CompactTextString(m) }
+func (*DAGShapeTick) ProtoMessage()               {}
+func (*DAGShapeTick) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{35} }
+
+func (m *DAGShapeTick) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *DAGShapeTick) GetMerges() int32 {
+	if m != nil {
+		return m.Merges
+	}
+	return 0
+}
+
+func (m *DAGShapeTick) GetParents() int32 {
+	if m != nil {
+		return m.Parents
+	}
+	return 0
+}
+
+func (m *DAGShapeTick) GetMaxBranches() int32 {
+	if m != nil {
+		return m.MaxBranches
+	}
+	return 0
+}
+
+func (m *DAGShapeTick) GetLongestChain() int32 {
+	if m != nil {
+		return m.LongestChain
+	}
+	return 0
+}
+
+type DAGShapeAnalysisResults struct {
+	Ticks    []*DAGShapeTick `protobuf:"bytes,1,rep,name=ticks" json:"ticks,omitempty"`
+	Sampling int32           `protobuf:"varint,2,opt,name=sampling,proto3" json:"sampling,omitempty"`
+}
+
+func (m *DAGShapeAnalysisResults) Reset()                    { *m = DAGShapeAnalysisResults{} }
+func (m *DAGShapeAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*DAGShapeAnalysisResults) ProtoMessage()               {}
+func (*DAGShapeAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{36} }
+
+func (m *DAGShapeAnalysisResults) GetTicks() []*DAGShapeTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *DAGShapeAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+type RenameChain struct {
+	// the consecutive names of the file, the oldest first
+	Names []string `protobuf:"bytes,1,rep,name=names" json:"names,omitempty"`
+	// the days of the renames, names[i+1] appeared on days[i]
+	Days    []int32 `protobuf:"varint,2,rep,packed,name=days" json:"days,omitempty"`
+	Deleted bool    `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
+}
+
+func (m *RenameChain) Reset()                    { *m = RenameChain{} }
+func (m *RenameChain) String() string            { return proto.CompactTextString(m) }
+func (*RenameChain) ProtoMessage()               {}
+func (*RenameChain) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{37} }
+
+func (m *RenameChain) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+func (m *RenameChain) GetDays() []int32 {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+func (m *RenameChain) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+type RenameFrequencyAnalysisResults struct {
+	// the numbers of renames in each tick
+	Ticks []int32 `protobuf:"varint,1,rep,packed,name=ticks" json:"ticks,omitempty"`
+	// the most renamed files first
+	Chains []*RenameChain `protobuf:"bytes,2,rep,name=chains" json:"chains,omitempty"`
+	// the numbers of commits which moved the files out of each directory
+	Directories    map[string]int32 `protobuf:"bytes,3,rep,name=directories" json:"directories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Sampling       int32            `protobuf:"varint,4,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	DirectoryDepth int32            `protobuf:"varint,5,opt,name=directory_depth,json=directoryDepth,proto3" json:"directory_depth,omitempty"`
+}
+
+func (m *RenameFrequencyAnalysisResults) Reset()         { *m = RenameFrequencyAnalysisResults{} }
+func (m *RenameFrequencyAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*RenameFrequencyAnalysisResults) ProtoMessage()    {}
+func (*RenameFrequencyAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptorPb, []int{38}
+}
+
+func (m *RenameFrequencyAnalysisResults) GetTicks() []int32 {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *RenameFrequencyAnalysisResults) GetChains() []*RenameChain {
+	if m != nil {
+		return m.Chains
+	}
+	return nil
+}
+
+func (m *RenameFrequencyAnalysisResults) GetDirectories() map[string]int32 {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+func (m *RenameFrequencyAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *RenameFrequencyAnalysisResults) GetDirectoryDepth() int32 {
+	if m != nil {
+		return m.DirectoryDepth
+	}
+	return 0
+}
+
+type RewriteDepthFile struct {
+	// the number of lines with each rewrite depth, the index is the depth
+	Lines []int32 `protobuf:"varint,1,rep,packed,name=lines" json:"lines,omitempty"`
+}
+
+func (m *RewriteDepthFile) Reset()                    { *m = RewriteDepthFile{} }
+func (m *RewriteDepthFile) String() string            { return proto.CompactTextString(m) }
+func (*RewriteDepthFile) ProtoMessage()               {}
+func (*RewriteDepthFile) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{39} }
+
+func (m *RewriteDepthFile) GetLines() []int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return nil
+}
+
+type RewriteHotspot struct {
+	File string `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	// the number of the first line, starting from 1
+	Line   int32 `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`
+	Length int32 `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`
+	Depth  int32 `protobuf:"varint,4,opt,name=depth,proto3" json:"depth,omitempty"`
+}
+
+func (m *RewriteHotspot) Reset()                    { *m = RewriteHotspot{} }
+func (m *RewriteHotspot) String() string            { return proto.CompactTextString(m) }
+func (*RewriteHotspot) ProtoMessage()               {}
+func (*RewriteHotspot) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{40} }
+
+func (m *RewriteHotspot) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *RewriteHotspot) GetLine() int32 {
+	if m != nil {
+		return m.Line
+	}
+	return 0
+}
+
+func (m *RewriteHotspot) GetLength() int32 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+func (m *RewriteHotspot) GetDepth() int32 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
+}
+
+type RewriteDepthAnalysisResults struct {
+	Files map[string]*RewriteDepthFile `protobuf:"bytes,1,rep,name=files" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// the deepest line ranges, the deepest first
+	Hotspots     []*RewriteHotspot `protobuf:"bytes,2,rep,name=hotspots" json:"hotspots,omitempty"`
+	HotspotDepth int32             `protobuf:"varint,3,opt,name=hotspot_depth,json=hotspotDepth,proto3" json:"hotspot_depth,omitempty"`
+	MaxHotspots  int32             `protobuf:"varint,4,opt,name=max_hotspots,json=maxHotspots,proto3" json:"max_hotspots,omitempty"`
+}
+
+func (m *RewriteDepthAnalysisResults) Reset()                    { *m = RewriteDepthAnalysisResults{} }
+func (m *RewriteDepthAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*RewriteDepthAnalysisResults) ProtoMessage()               {}
+func (*RewriteDepthAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{41} }
+
+func (m *RewriteDepthAnalysisResults) GetFiles() map[string]*RewriteDepthFile {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *RewriteDepthAnalysisResults) GetHotspots() []*RewriteHotspot {
+	if m != nil {
+		return m.Hotspots
+	}
+	return nil
+}
+
+func (m *RewriteDepthAnalysisResults) GetHotspotDepth() int32 {
+	if m != nil {
+		return m.HotspotDepth
+	}
+	return 0
+}
+
+func (m *RewriteDepthAnalysisResults) GetMaxHotspots() int32 {
+	if m != nil {
+		return m.MaxHotspots
+	}
+	return 0
+}
+
+type SensitivePathChange struct {
+	Commit string `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	// -1 means an unmatched identity
+	Author int32  `protobuf:"varint,2,opt,name=author,proto3" json:"author,omitempty"`
+	Tick   int32  `protobuf:"varint,3,opt,name=tick,proto3" json:"tick,omitempty"`
+	File   string `protobuf:"bytes,4,opt,name=file,proto3" json:"file,omitempty"`
+	// the number of added and removed lines
+	Churn int32 `protobuf:"varint,5,opt,name=churn,proto3" json:"churn,omitempty"`
+}
+
+func (m *SensitivePathChange) Reset()                    { *m = SensitivePathChange{} }
+func (m *SensitivePathChange) String() string            { return proto.CompactTextString(m) }
+func (*SensitivePathChange) ProtoMessage()               {}
+func (*SensitivePathChange) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{42} }
+
+func (m *SensitivePathChange) GetCommit() string {
+	if m != nil {
+		return m.Commit
+	}
+	return ""
+}
+
+func (m *SensitivePathChange) GetAuthor() int32 {
+	if m != nil {
+		return m.Author
+	}
+	return 0
+}
+
+func (m *SensitivePathChange) GetTick() int32 {
+	if m != nil {
+		return m.Tick
+	}
+	return 0
+}
+
+func (m *SensitivePathChange) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *SensitivePathChange) GetChurn() int32 {
+	if m != nil {
+		return m.Churn
+	}
+	return 0
+}
+
+type SensitivePathsAnalysisResults struct {
+	// the changes in the chronological order
+	Changes  []*SensitivePathChange `protobuf:"bytes,1,rep,name=changes" json:"changes,omitempty"`
+	Sampling int32                  `protobuf:"varint,2,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	Patterns []string               `protobuf:"bytes,3,rep,name=patterns" json:"patterns,omitempty"`
+	DevIndex []string               `protobuf:"bytes,4,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *SensitivePathsAnalysisResults) Reset()         { *m = SensitivePathsAnalysisResults{} }
+func (m *SensitivePathsAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*SensitivePathsAnalysisResults) ProtoMessage()    {}
+func (*SensitivePathsAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptorPb, []int{43}
+}
+
+func (m *SensitivePathsAnalysisResults) GetChanges() []*SensitivePathChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func (m *SensitivePathsAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *SensitivePathsAnalysisResults) GetPatterns() []string {
+	if m != nil {
+		return m.Patterns
+	}
+	return nil
+}
+
+func (m *SensitivePathsAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type ContributorsTick struct {
+	// the developer indices in each bucket
+	Core    []int32 `protobuf:"varint,1,rep,packed,name=core" json:"core,omitempty"`
+	Regular []int32 `protobuf:"varint,2,rep,packed,name=regular" json:"regular,omitempty"`
+	DriveBy []int32 `protobuf:"varint,3,rep,packed,name=drive_by,json=driveBy" json:"drive_by,omitempty"`
+}
+
+func (m *ContributorsTick) Reset()                    { *m = ContributorsTick{} }
+func (m *ContributorsTick) String() string            { return proto.CompactTextString(m) }
+func (*ContributorsTick) ProtoMessage()               {}
+func (*ContributorsTick) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{44} }
+
+func (m *ContributorsTick) GetCore() []int32 {
+	if m != nil {
+		return m.Core
+	}
+	return nil
+}
+
+func (m *ContributorsTick) GetRegular() []int32 {
+	if m != nil {
+		return m.Regular
+	}
+	return nil
+}
+
+func (m *ContributorsTick) GetDriveBy() []int32 {
+	if m != nil {
+		return m.DriveBy
+	}
+	return nil
+}
+
+type ContributorsAnalysisResults struct {
+	Ticks          []*ContributorsTick `protobuf:"bytes,1,rep,name=ticks" json:"ticks,omitempty"`
+	Sampling       int32               `protobuf:"varint,2,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	CoreCommits    int32               `protobuf:"varint,3,opt,name=core_commits,json=coreCommits,proto3" json:"core_commits,omitempty"`
+	CoreSpan       int32               `protobuf:"varint,4,opt,name=core_span,json=coreSpan,proto3" json:"core_span,omitempty"`
+	DriveByCommits int32               `protobuf:"varint,5,opt,name=drive_by_commits,json=driveByCommits,proto3" json:"drive_by_commits,omitempty"`
+	DriveBySpan    int32               `protobuf:"varint,6,opt,name=drive_by_span,json=driveBySpan,proto3" json:"drive_by_span,omitempty"`
+	DevIndex       []string            `protobuf:"bytes,7,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *ContributorsAnalysisResults) Reset()                    { *m = ContributorsAnalysisResults{} }
+func (m *ContributorsAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*ContributorsAnalysisResults) ProtoMessage()               {}
+func (*ContributorsAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{45} }
+
+func (m *ContributorsAnalysisResults) GetTicks() []*ContributorsTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *ContributorsAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *ContributorsAnalysisResults) GetCoreCommits() int32 {
+	if m != nil {
+		return m.CoreCommits
+	}
+	return 0
+}
+
+func (m *ContributorsAnalysisResults) GetCoreSpan() int32 {
+	if m != nil {
+		return m.CoreSpan
+	}
+	return 0
+}
+
+func (m *ContributorsAnalysisResults) GetDriveByCommits() int32 {
+	if m != nil {
+		return m.DriveByCommits
+	}
+	return 0
+}
+
+func (m *ContributorsAnalysisResults) GetDriveBySpan() int32 {
+	if m != nil {
+		return m.DriveBySpan
+	}
+	return 0
+}
+
+func (m *ContributorsAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type StewardshipCounts struct {
+	// the number of changed lines which the developer wrote
+	Self int64 `protobuf:"varint,1,opt,name=self,proto3" json:"self,omitempty"`
+	// the number of changed lines which the others wrote
+	Others int64 `protobuf:"varint,2,opt,name=others,proto3" json:"others,omitempty"`
+}
+
+func (m *StewardshipCounts) Reset()                    { *m = StewardshipCounts{} }
+func (m *StewardshipCounts) String() string            { return proto.CompactTextString(m) }
+func (*StewardshipCounts) ProtoMessage()               {}
+func (*StewardshipCounts) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{46} }
+
+func (m *StewardshipCounts) GetSelf() int64 {
+	if m != nil {
+		return m.Self
+	}
+	return 0
+}
+
+func (m *StewardshipCounts) GetOthers() int64 {
+	if m != nil {
+		return m.Others
+	}
+	return 0
+}
+
+type StewardshipTick struct {
+	// developer index -> counts; -1 means an unmatched identity
+	People map[int32]*StewardshipCounts `protobuf:"bytes,1,rep,name=people" json:"people,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *StewardshipTick) Reset()                    { *m = StewardshipTick{} }
+func (m *StewardshipTick) String() string            { return proto.CompactTextString(m) }
+func (*StewardshipTick) ProtoMessage()               {}
+func (*StewardshipTick) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{47} }
+
+func (m *StewardshipTick) GetPeople() map[int32]*StewardshipCounts {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+type StewardshipAnalysisResults struct {
+	Ticks    []*StewardshipTick `protobuf:"bytes,1,rep,name=ticks" json:"ticks,omitempty"`
+	Sampling int32              `protobuf:"varint,2,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	DevIndex []string           `protobuf:"bytes,3,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *StewardshipAnalysisResults) Reset()                    { *m = StewardshipAnalysisResults{} }
+func (m *StewardshipAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*StewardshipAnalysisResults) ProtoMessage()               {}
+func (*StewardshipAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{48} }
+
+func (m *StewardshipAnalysisResults) GetTicks() []*StewardshipTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *StewardshipAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *StewardshipAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type TeamAlignmentDirectory struct {
+	Directory string `protobuf:"bytes,1,opt,name=directory,proto3" json:"directory,omitempty"`
+	// team index -> number of changed files; -1 means an unmatched identity
+	Edits map[int32]int32 `protobuf:"bytes,2,rep,name=edits" json:"edits,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// the team with the most edits
+	Owner int32 `protobuf:"varint,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	// the number of edits by the teams other than the owner
+	CrossTeamEdits int32 `protobuf:"varint,4,opt,name=cross_team_edits,json=crossTeamEdits,proto3" json:"cross_team_edits,omitempty"`
+}
+
+func (m *TeamAlignmentDirectory) Reset()                    { *m = TeamAlignmentDirectory{} }
+func (m *TeamAlignmentDirectory) String() string            { return proto.CompactTextString(m) }
+func (*TeamAlignmentDirectory) ProtoMessage()               {}
+func (*TeamAlignmentDirectory) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{49} }
+
+func (m *TeamAlignmentDirectory) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+func (m *TeamAlignmentDirectory) GetEdits() map[int32]int32 {
+	if m != nil {
+		return m.Edits
+	}
+	return nil
+}
+
+func (m *TeamAlignmentDirectory) GetOwner() int32 {
+	if m != nil {
+		return m.Owner
+	}
+	return 0
+}
+
+func (m *TeamAlignmentDirectory) GetCrossTeamEdits() int32 {
+	if m != nil {
+		return m.CrossTeamEdits
+	}
+	return 0
+}
+
+type TeamAlignmentTick struct {
+	Directories []*TeamAlignmentDirectory `protobuf:"bytes,1,rep,name=directories" json:"directories,omitempty"`
+}
+
+func (m *TeamAlignmentTick) Reset()                    { *m = TeamAlignmentTick{} }
+func (m *TeamAlignmentTick) String() string            { return proto.CompactTextString(m) }
+func (*TeamAlignmentTick) ProtoMessage()               {}
+func (*TeamAlignmentTick) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{50} }
+
+func (m *TeamAlignmentTick) GetDirectories() []*TeamAlignmentDirectory {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+type TeamAlignmentAnalysisResults struct {
+	Ticks    []*TeamAlignmentTick `protobuf:"bytes,1,rep,name=ticks" json:"ticks,omitempty"`
+	Sampling int32                `protobuf:"varint,2,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	Depth    int32                `protobuf:"varint,3,opt,name=depth,proto3" json:"depth,omitempty"`
+	// the team names, see --teams
+	DevIndex []string `protobuf:"bytes,4,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *TeamAlignmentAnalysisResults) Reset()                    { *m = TeamAlignmentAnalysisResults{} }
+func (m *TeamAlignmentAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*TeamAlignmentAnalysisResults) ProtoMessage()               {}
+func (*TeamAlignmentAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{51} }
+
+func (m *TeamAlignmentAnalysisResults) GetTicks() []*TeamAlignmentTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *TeamAlignmentAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *TeamAlignmentAnalysisResults) GetDepth() int32 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
+}
+
+func (m *TeamAlignmentAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type DefectFeaturesAnalysisResults struct {
+	// the file names referenced by the file column
+	Files []string `protobuf:"bytes,1,rep,name=files" json:"files,omitempty"`
+	// the columns of the feature table, each row is a file changed in a tick
+	Tick        []int32  `protobuf:"varint,2,rep,packed,name=tick" json:"tick,omitempty"`
+	File        []int32  `protobuf:"varint,3,rep,packed,name=file" json:"file,omitempty"`
+	Commits     []int32  `protobuf:"varint,4,rep,packed,name=commits" json:"commits,omitempty"`
+	Churn       []int32  `protobuf:"varint,5,rep,packed,name=churn" json:"churn,omitempty"`
+	Authors     []int32  `protobuf:"varint,6,rep,packed,name=authors" json:"authors,omitempty"`
+	Age         []int32  `protobuf:"varint,7,rep,packed,name=age" json:"age,omitempty"`
+	Lines       []int32  `protobuf:"varint,8,rep,packed,name=lines" json:"lines,omitempty"`
+	Complexity  []int32  `protobuf:"varint,9,rep,packed,name=complexity" json:"complexity,omitempty"`
+	Coupling    []int32  `protobuf:"varint,10,rep,packed,name=coupling" json:"coupling,omitempty"`
+	PastFixes   []int32  `protobuf:"varint,11,rep,packed,name=past_fixes,json=pastFixes" json:"past_fixes,omitempty"`
+	Fixes       []int32  `protobuf:"varint,12,rep,packed,name=fixes" json:"fixes,omitempty"`
+	Sampling    int32    `protobuf:"varint,13,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	FixPatterns []string `protobuf:"bytes,14,rep,name=fix_patterns,json=fixPatterns" json:"fix_patterns,omitempty"`
+}
+
+func (m *DefectFeaturesAnalysisResults) Reset()         { *m = DefectFeaturesAnalysisResults{} }
+func (m *DefectFeaturesAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*DefectFeaturesAnalysisResults) ProtoMessage()    {}
+func (*DefectFeaturesAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptorPb, []int{52}
+}
+
+func (m *DefectFeaturesAnalysisResults) GetFiles() []string {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *DefectFeaturesAnalysisResults) GetTick() []int32 {
+	if m != nil {
+		return m.Tick
+	}
+	return nil
+}
+
+func (m *DefectFeaturesAnalysisResults) GetFile() []int32 {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+func (m *DefectFeaturesAnalysisResults) GetCommits() []int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return nil
+}
+
+func (m *DefectFeaturesAnalysisResults) GetChurn() []int32 {
+	if m != nil {
+		return m.Churn
+	}
+	return nil
+}
+
+func (m *DefectFeaturesAnalysisResults) GetAuthors() []int32 {
+	if m != nil {
+		return m.Authors
+	}
+	return nil
+}
+
+func (m *DefectFeaturesAnalysisResults) GetAge() []int32 {
+	if m != nil {
+		return m.Age
+	}
+	return nil
+}
+
+func (m *DefectFeaturesAnalysisResults) GetLines() []int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return nil
+}
+
+func (m *DefectFeaturesAnalysisResults) GetComplexity() []int32 {
+	if m != nil {
+		return m.Complexity
+	}
+	return nil
+}
+
+func (m *DefectFeaturesAnalysisResults) GetCoupling() []int32 {
+	if m != nil {
+		return m.Coupling
+	}
+	return nil
+}
+
+func (m *DefectFeaturesAnalysisResults) GetPastFixes() []int32 {
+	if m != nil {
+		return m.PastFixes
+	}
+	return nil
+}
+
+func (m *DefectFeaturesAnalysisResults) GetFixes() []int32 {
+	if m != nil {
+		return m.Fixes
+	}
+	return nil
+}
+
+func (m *DefectFeaturesAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *DefectFeaturesAnalysisResults) GetFixPatterns() []string {
+	if m != nil {
+		return m.FixPatterns
+	}
+	return nil
+}
+
+type CocomoTick struct {
+	// the number of lines at the end of the tick
+	Lines   int32 `protobuf:"varint,1,opt,name=lines,proto3" json:"lines,omitempty"`
+	Added   int32 `protobuf:"varint,2,opt,name=added,proto3" json:"added,omitempty"`
+	Removed int32 `protobuf:"varint,3,opt,name=removed,proto3" json:"removed,omitempty"`
+	// person-months
+	Effort      float64 `protobuf:"fixed64,4,opt,name=effort,proto3" json:"effort,omitempty"`
+	ChurnEffort float64 `protobuf:"fixed64,5,opt,name=churn_effort,json=churnEffort,proto3" json:"churn_effort,omitempty"`
+}
+
+func (m *CocomoTick) Reset()                    { *m = CocomoTick{} }
+func (m *CocomoTick) String() string            { return proto.CompactTextString(m) }
+func (*CocomoTick) ProtoMessage()               {}
+func (*CocomoTick) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{53} }
+
+func (m *CocomoTick) GetLines() int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *CocomoTick) GetAdded() int32 {
+	if m != nil {
+		return m.Added
+	}
+	return 0
+}
+
+func (m *CocomoTick) GetRemoved() int32 {
+	if m != nil {
+		return m.Removed
+	}
+	return 0
+}
+
+func (m *CocomoTick) GetEffort() float64 {
+	if m != nil {
+		return m.Effort
+	}
+	return 0
+}
+
+func (m *CocomoTick) GetChurnEffort() float64 {
+	if m != nil {
+		return m.ChurnEffort
+	}
+	return 0
+}
+
+type CocomoAnalysisResults struct {
+	Ticks       []*CocomoTick `protobuf:"bytes,1,rep,name=ticks" json:"ticks,omitempty"`
+	Sampling    int32         `protobuf:"varint,2,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	Coefficient float64       `protobuf:"fixed64,3,opt,name=coefficient,proto3" json:"coefficient,omitempty"`
+	Exponent    float64       `protobuf:"fixed64,4,opt,name=exponent,proto3" json:"exponent,omitempty"`
+	MonthlyCost float64       `protobuf:"fixed64,5,opt,name=monthly_cost,json=monthlyCost,proto3" json:"monthly_cost,omitempty"`
+}
+
+func (m *CocomoAnalysisResults) Reset()                    { *m = CocomoAnalysisResults{} }
+func (m *CocomoAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*CocomoAnalysisResults) ProtoMessage()               {}
+func (*CocomoAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{54} }
+
+func (m *CocomoAnalysisResults) GetTicks() []*CocomoTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *CocomoAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *CocomoAnalysisResults) GetCoefficient() float64 {
+	if m != nil {
+		return m.Coefficient
+	}
+	return 0
+}
+
+func (m *CocomoAnalysisResults) GetExponent() float64 {
+	if m != nil {
+		return m.Exponent
+	}
+	return 0
+}
+
+func (m *CocomoAnalysisResults) GetMonthlyCost() float64 {
+	if m != nil {
+		return m.MonthlyCost
+	}
+	return 0
+}
+
+type ReviewLatencyStats struct {
+	Commits int32 `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
+	// the number of commits in each bucket of the latency
+	Histogram []int32 `protobuf:"varint,2,rep,packed,name=histogram" json:"histogram,omitempty"`
+	// the sum of the latencies in seconds
+	Total int64 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	// the maximum latency in seconds
+	Max int64 `protobuf:"varint,4,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (m *ReviewLatencyStats) Reset()                    { *m = ReviewLatencyStats{} }
+func (m *ReviewLatencyStats) String() string            { return proto.CompactTextString(m) }
+func (*ReviewLatencyStats) ProtoMessage()               {}
+func (*ReviewLatencyStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{55} }
+
+func (m *ReviewLatencyStats) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *ReviewLatencyStats) GetHistogram() []int32 {
+	if m != nil {
+		return m.Histogram
+	}
+	return nil
+}
+
+func (m *ReviewLatencyStats) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *ReviewLatencyStats) GetMax() int64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+type ReviewLatencyAnalysisResults struct {
+	Ticks []*ReviewLatencyStats `protobuf:"bytes,1,rep,name=ticks" json:"ticks,omitempty"`
+	// developer index -> stats, -1 means an unmatched identity
+	People   map[int32]*ReviewLatencyStats `protobuf:"bytes,2,rep,name=people" json:"people,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	Sampling int32                         `protobuf:"varint,3,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	// the upper bounds of the histogram buckets in seconds
+	Buckets  []int64  `protobuf:"varint,4,rep,packed,name=buckets" json:"buckets,omitempty"`
+	DevIndex []string `protobuf:"bytes,5,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *ReviewLatencyAnalysisResults) Reset()                    { *m = ReviewLatencyAnalysisResults{} }
+func (m *ReviewLatencyAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*ReviewLatencyAnalysisResults) ProtoMessage()               {}
+func (*ReviewLatencyAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{56} }
+
+func (m *ReviewLatencyAnalysisResults) GetTicks() []*ReviewLatencyStats {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *ReviewLatencyAnalysisResults) GetPeople() map[int32]*ReviewLatencyStats {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+func (m *ReviewLatencyAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *ReviewLatencyAnalysisResults) GetBuckets() []int64 {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+func (m *ReviewLatencyAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type IssueTicket struct {
+	// commit hashes
+	Commits  []string `protobuf:"bytes,1,rep,name=commits" json:"commits,omitempty"`
+	Churn    int32    `protobuf:"varint,2,opt,name=churn,proto3" json:"churn,omitempty"`
+	FirstDay int32    `protobuf:"varint,3,opt,name=first_day,json=firstDay,proto3" json:"first_day,omitempty"`
+	LastDay  int32    `protobuf:"varint,4,opt,name=last_day,json=lastDay,proto3" json:"last_day,omitempty"`
+}
+
+func (m *IssueTicket) Reset()                    { *m = IssueTicket{} }
+func (m *IssueTicket) String() string            { return proto.CompactTextString(m) }
+func (*IssueTicket) ProtoMessage()               {}
+func (*IssueTicket) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{57} }
+
+func (m *IssueTicket) GetCommits() []string {
+	if m != nil {
+		return m.Commits
+	}
+	return nil
+}
+
+func (m *IssueTicket) GetChurn() int32 {
+	if m != nil {
+		return m.Churn
+	}
+	return 0
+}
+
+func (m *IssueTicket) GetFirstDay() int32 {
+	if m != nil {
+		return m.FirstDay
+	}
+	return 0
+}
+
+func (m *IssueTicket) GetLastDay() int32 {
+	if m != nil {
+		return m.LastDay
+	}
+	return 0
+}
+
+type IssueReferencesAnalysisResults struct {
+	// ticket key -> referencing commits
+	Tickets           map[string]*IssueTicket `protobuf:"bytes,1,rep,name=tickets" json:"tickets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	Commits           int32                   `protobuf:"varint,2,opt,name=commits,proto3" json:"commits,omitempty"`
+	ReferencedCommits int32                   `protobuf:"varint,3,opt,name=referenced_commits,json=referencedCommits,proto3" json:"referenced_commits,omitempty"`
+	Patterns          []string                `protobuf:"bytes,4,rep,name=patterns" json:"patterns,omitempty"`
+}
+
+func (m *IssueReferencesAnalysisResults) Reset()         { *m = IssueReferencesAnalysisResults{} }
+func (m *IssueReferencesAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*IssueReferencesAnalysisResults) ProtoMessage()    {}
+func (*IssueReferencesAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptorPb, []int{58}
+}
+
+func (m *IssueReferencesAnalysisResults) GetTickets() map[string]*IssueTicket {
+	if m != nil {
+		return m.Tickets
+	}
+	return nil
+}
+
+func (m *IssueReferencesAnalysisResults) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *IssueReferencesAnalysisResults) GetReferencedCommits() int32 {
+	if m != nil {
+		return m.ReferencedCommits
+	}
+	return 0
+}
+
+func (m *IssueReferencesAnalysisResults) GetPatterns() []string {
+	if m != nil {
+		return m.Patterns
+	}
+	return nil
+}
+
+type ConventionalCommitsStats struct {
+	Commits   int32 `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
+	Compliant int32 `protobuf:"varint,2,opt,name=compliant,proto3" json:"compliant,omitempty"`
+	Breaking  int32 `protobuf:"varint,3,opt,name=breaking,proto3" json:"breaking,omitempty"`
+	// change type -> number of compliant commits
+	Types map[string]int32 `protobuf:"bytes,4,rep,name=types" json:"types,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (m *ConventionalCommitsStats) Reset()                    { *m = ConventionalCommitsStats{} }
+func (m *ConventionalCommitsStats) String() string            { return proto.CompactTextString(m) }
+func (*ConventionalCommitsStats) ProtoMessage()               {}
+func (*ConventionalCommitsStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{59} }
+
+func (m *ConventionalCommitsStats) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *ConventionalCommitsStats) GetCompliant() int32 {
+	if m != nil {
+		return m.Compliant
+	}
+	return 0
+}
+
+func (m *ConventionalCommitsStats) GetBreaking() int32 {
+	if m != nil {
+		return m.Breaking
+	}
+	return 0
+}
+
+func (m *ConventionalCommitsStats) GetTypes() map[string]int32 {
+	if m != nil {
+		return m.Types
+	}
+	return nil
+}
+
+type ConventionalCommitsAnalysisResults struct {
+	Ticks []*ConventionalCommitsStats `protobuf:"bytes,1,rep,name=ticks" json:"ticks,omitempty"`
+	// developer index -> stats, -1 means an unmatched identity
+	People   map[int32]*ConventionalCommitsStats `protobuf:"bytes,2,rep,name=people" json:"people,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	Sampling int32                               `protobuf:"varint,3,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	DevIndex []string                            `protobuf:"bytes,4,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *ConventionalCommitsAnalysisResults) Reset()         { *m = ConventionalCommitsAnalysisResults{} }
+func (m *ConventionalCommitsAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*ConventionalCommitsAnalysisResults) ProtoMessage()    {}
+func (*ConventionalCommitsAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptorPb, []int{60}
+}
+
+func (m *ConventionalCommitsAnalysisResults) GetTicks() []*ConventionalCommitsStats {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *ConventionalCommitsAnalysisResults) GetPeople() map[int32]*ConventionalCommitsStats {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+func (m *ConventionalCommitsAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *ConventionalCommitsAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type LanguageLines struct {
+	// the number of lines at the end of each tick
+	Ticks []int32 `protobuf:"varint,1,rep,packed,name=ticks" json:"ticks,omitempty"`
+}
+
+func (m *LanguageLines) Reset()                    { *m = LanguageLines{} }
+func (m *LanguageLines) String() string            { return proto.CompactTextString(m) }
+func (*LanguageLines) ProtoMessage()               {}
+func (*LanguageLines) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{61} }
+
+func (m *LanguageLines) GetTicks() []int32 {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+type LanguageLinesAnalysisResults struct {
+	// language -> lines over time
+	Languages map[string]*LanguageLines `protobuf:"bytes,1,rep,name=languages" json:"languages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	Sampling  int32                     `protobuf:"varint,2,opt,name=sampling,proto3" json:"sampling,omitempty"`
+}
+
+func (m *LanguageLinesAnalysisResults) Reset()                    { *m = LanguageLinesAnalysisResults{} }
+func (m *LanguageLinesAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*LanguageLinesAnalysisResults) ProtoMessage()               {}
+func (*LanguageLinesAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{62} }
+
+func (m *LanguageLinesAnalysisResults) GetLanguages() map[string]*LanguageLines {
+	if m != nil {
+		return m.Languages
+	}
+	return nil
+}
+
+func (m *LanguageLinesAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+type RepositorySizeTick struct {
+	TextBytes   int64 `protobuf:"varint,1,opt,name=text_bytes,json=textBytes,proto3" json:"text_bytes,omitempty"`
+	BinaryBytes int64 `protobuf:"varint,2,opt,name=binary_bytes,json=binaryBytes,proto3" json:"binary_bytes,omitempty"`
+}
+
+func (m *RepositorySizeTick) Reset()                    { *m = RepositorySizeTick{} }
+func (m *RepositorySizeTick) String() string            { return proto.CompactTextString(m) }
+func (*RepositorySizeTick) ProtoMessage()               {}
+func (*RepositorySizeTick) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{63} }
+
+func (m *RepositorySizeTick) GetTextBytes() int64 {
+	if m != nil {
+		return m.TextBytes
+	}
+	return 0
+}
+
+func (m *RepositorySizeTick) GetBinaryBytes() int64 {
+	if m != nil {
+		return m.BinaryBytes
+	}
+	return 0
+}
+
+type RepositoryBlob struct {
+	File   string `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Commit string `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	Day    int32  `protobuf:"varint,3,opt,name=day,proto3" json:"day,omitempty"`
+	Size   int64  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Binary bool   `protobuf:"varint,5,opt,name=binary,proto3" json:"binary,omitempty"`
+}
+
+func (m *RepositoryBlob) Reset()                    { *m = RepositoryBlob{} }
+func (m *RepositoryBlob) String() string            { return proto.CompactTextString(m) }
+func (*RepositoryBlob) ProtoMessage()               {}
+func (*RepositoryBlob) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{64} }
+
+func (m *RepositoryBlob) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *RepositoryBlob) GetCommit() string {
+	if m != nil {
+		return m.Commit
+	}
+	return ""
+}
+
+func (m *RepositoryBlob) GetDay() int32 {
+	if m != nil {
+		return m.Day
+	}
+	return 0
+}
+
+func (m *RepositoryBlob) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *RepositoryBlob) GetBinary() bool {
+	if m != nil {
+		return m.Binary
+	}
+	return false
+}
+
+type RepositorySizeAnalysisResults struct {
+	Ticks []*RepositorySizeTick `protobuf:"bytes,1,rep,name=ticks" json:"ticks,omitempty"`
+	// the largest blobs in each tick
+	Largest  []*RepositoryBlob `protobuf:"bytes,2,rep,name=largest" json:"largest,omitempty"`
+	Top      int32             `protobuf:"varint,3,opt,name=top,proto3" json:"top,omitempty"`
+	Sampling int32             `protobuf:"varint,4,opt,name=sampling,proto3" json:"sampling,omitempty"`
+}
+
+func (m *RepositorySizeAnalysisResults) Reset()         { *m = RepositorySizeAnalysisResults{} }
+func (m *RepositorySizeAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*RepositorySizeAnalysisResults) ProtoMessage()    {}
+func (*RepositorySizeAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptorPb, []int{65}
+}
+
+func (m *RepositorySizeAnalysisResults) GetTicks() []*RepositorySizeTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *RepositorySizeAnalysisResults) GetLargest() []*RepositoryBlob {
+	if m != nil {
+		return m.Largest
+	}
+	return nil
+}
+
+func (m *RepositorySizeAnalysisResults) GetTop() int32 {
+	if m != nil {
+		return m.Top
+	}
+	return 0
+}
+
+func (m *RepositorySizeAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+type FileAge struct {
+	Created  int32 `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Modified int32 `protobuf:"varint,2,opt,name=modified,proto3" json:"modified,omitempty"`
+	// index in dev_index, -1 means none
+	LastActiveAuthor int32 `protobuf:"varint,3,opt,name=last_active_author,json=lastActiveAuthor,proto3" json:"last_active_author,omitempty"`
+}
+
+func (m *FileAge) Reset()                    { *m = FileAge{} }
+func (m *FileAge) String() string            { return proto.CompactTextString(m) }
+func (*FileAge) ProtoMessage()               {}
+func (*FileAge) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{66} }
+
+func (m *FileAge) GetCreated() int32 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *FileAge) GetModified() int32 {
+	if m != nil {
+		return m.Modified
+	}
+	return 0
+}
+
+func (m *FileAge) GetLastActiveAuthor() int32 {
+	if m != nil {
+		return m.LastActiveAuthor
+	}
+	return 0
+}
+
+type FileAgeAnalysisResults struct {
+	// file name -> age
+	Files map[string]*FileAge `protobuf:"bytes,1,rep,name=files" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// sorted file names
+	Orphaned     []string `protobuf:"bytes,2,rep,name=orphaned" json:"orphaned,omitempty"`
+	LastDay      int32    `protobuf:"varint,3,opt,name=last_day,json=lastDay,proto3" json:"last_day,omitempty"`
+	InactiveDays int32    `protobuf:"varint,4,opt,name=inactive_days,json=inactiveDays,proto3" json:"inactive_days,omitempty"`
+	Significance float32  `protobuf:"fixed32,5,opt,name=significance,proto3" json:"significance,omitempty"`
+	DevIndex     []string `protobuf:"bytes,6,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *FileAgeAnalysisResults) Reset()                    { *m = FileAgeAnalysisResults{} }
+func (m *FileAgeAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*FileAgeAnalysisResults) ProtoMessage()               {}
+func (*FileAgeAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{67} }
+
+func (m *FileAgeAnalysisResults) GetFiles() map[string]*FileAge {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *FileAgeAnalysisResults) GetOrphaned() []string {
+	if m != nil {
+		return m.Orphaned
+	}
+	return nil
+}
+
+func (m *FileAgeAnalysisResults) GetLastDay() int32 {
+	if m != nil {
+		return m.LastDay
+	}
+	return 0
+}
+
+func (m *FileAgeAnalysisResults) GetInactiveDays() int32 {
+	if m != nil {
+		return m.InactiveDays
+	}
+	return 0
+}
+
+func (m *FileAgeAnalysisResults) GetSignificance() float32 {
+	if m != nil {
+		return m.Significance
+	}
+	return 0
+}
+
+func (m *FileAgeAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type RefactoringStats struct {
+	Commits          int32 `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
+	Refactorings     int32 `protobuf:"varint,2,opt,name=refactorings,proto3" json:"refactorings,omitempty"`
+	Lines            int32 `protobuf:"varint,3,opt,name=lines,proto3" json:"lines,omitempty"`
+	RefactoringLines int32 `protobuf:"varint,4,opt,name=refactoring_lines,json=refactoringLines,proto3" json:"refactoring_lines,omitempty"`
+}
+
+func (m *RefactoringStats) Reset()                    { *m = RefactoringStats{} }
+func (m *RefactoringStats) String() string            { return proto.CompactTextString(m) }
+func (*RefactoringStats) ProtoMessage()               {}
+func (*RefactoringStats) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{68} }
+
+func (m *RefactoringStats) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *RefactoringStats) GetRefactorings() int32 {
+	if m != nil {
+		return m.Refactorings
+	}
+	return 0
+}
+
+func (m *RefactoringStats) GetLines() int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *RefactoringStats) GetRefactoringLines() int32 {
+	if m != nil {
+		return m.RefactoringLines
+	}
+	return 0
+}
+
+type RefactoringAnalysisResults struct {
+	Ticks []*RefactoringStats `protobuf:"bytes,1,rep,name=ticks" json:"ticks,omitempty"`
+	// developer index -> stats; -1 means an unmatched identity
+	People    map[int32]*RefactoringStats `protobuf:"bytes,2,rep,name=people" json:"people,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	Threshold float32                     `protobuf:"fixed32,3,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Sampling  int32                       `protobuf:"varint,4,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	DevIndex  []string                    `protobuf:"bytes,5,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *RefactoringAnalysisResults) Reset()                    { *m = RefactoringAnalysisResults{} }
+func (m *RefactoringAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*RefactoringAnalysisResults) ProtoMessage()               {}
+func (*RefactoringAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{69} }
+
+func (m *RefactoringAnalysisResults) GetTicks() []*RefactoringStats {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *RefactoringAnalysisResults) GetPeople() map[int32]*RefactoringStats {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+func (m *RefactoringAnalysisResults) GetThreshold() float32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *RefactoringAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *RefactoringAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type RevertsTick struct {
+	Commits int32 `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
+	Reverts int32 `protobuf:"varint,2,opt,name=reverts,proto3" json:"reverts,omitempty"`
+}
+
+func (m *RevertsTick) Reset()                    { *m = RevertsTick{} }
+func (m *RevertsTick) String() string            { return proto.CompactTextString(m) }
+func (*RevertsTick) ProtoMessage()               {}
+func (*RevertsTick) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{70} }
+
+func (m *RevertsTick) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *RevertsTick) GetReverts() int32 {
+	if m != nil {
+		return m.Reverts
+	}
+	return 0
+}
+
+type RevertedCommit struct {
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// the commit which reverted `hash`
+	Revert string `protobuf:"bytes,2,opt,name=revert,proto3" json:"revert,omitempty"`
+	Day    int32  `protobuf:"varint,3,opt,name=day,proto3" json:"day,omitempty"`
+	// hours between the reverted commit and the revert
+	Hours int32 `protobuf:"varint,4,opt,name=hours,proto3" json:"hours,omitempty"`
+}
+
+func (m *RevertedCommit) Reset()                    { *m = RevertedCommit{} }
+func (m *RevertedCommit) String() string            { return proto.CompactTextString(m) }
+func (*RevertedCommit) ProtoMessage()               {}
+func (*RevertedCommit) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{71} }
+
+func (m *RevertedCommit) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *RevertedCommit) GetRevert() string {
+	if m != nil {
+		return m.Revert
+	}
+	return ""
+}
+
+func (m *RevertedCommit) GetDay() int32 {
+	if m != nil {
+		return m.Day
+	}
+	return 0
+}
+
+func (m *RevertedCommit) GetHours() int32 {
+	if m != nil {
+		return m.Hours
+	}
+	return 0
+}
+
+type RevertsAnalysisResults struct {
+	Ticks []*RevertsTick `protobuf:"bytes,1,rep,name=ticks" json:"ticks,omitempty"`
+	// sorted by hours
+	Reverted []*RevertedCommit `protobuf:"bytes,2,rep,name=reverted" json:"reverted,omitempty"`
+	// file name -> number of reverted commits
+	Files    map[string]int32 `protobuf:"bytes,3,rep,name=files" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Sampling int32            `protobuf:"varint,4,opt,name=sampling,proto3" json:"sampling,omitempty"`
+}
+
+func (m *RevertsAnalysisResults) Reset()                    { *m = RevertsAnalysisResults{} }
+func (m *RevertsAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*RevertsAnalysisResults) ProtoMessage()               {}
+func (*RevertsAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{72} }
+
+func (m *RevertsAnalysisResults) GetTicks() []*RevertsTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *RevertsAnalysisResults) GetReverted() []*RevertedCommit {
+	if m != nil {
+		return m.Reverted
+	}
+	return nil
+}
+
+func (m *RevertsAnalysisResults) GetFiles() map[string]int32 {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *RevertsAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+type BranchMerge struct {
+	Day     int32 `protobuf:"varint,1,opt,name=day,proto3" json:"day,omitempty"`
+	Commits int32 `protobuf:"varint,2,opt,name=commits,proto3" json:"commits,omitempty"`
+	// hours between the earliest side commit and the merge
+	Lifetime int32 `protobuf:"varint,3,opt,name=lifetime,proto3" json:"lifetime,omitempty"`
+	// hours between the last side commit and the merge
+	Latency int32 `protobuf:"varint,4,opt,name=latency,proto3" json:"latency,omitempty"`
+}
+
+func (m *BranchMerge) Reset()                    { *m = BranchMerge{} }
+func (m *BranchMerge) String() string            { return proto.CompactTextString(m) }
+func (*BranchMerge) ProtoMessage()               {}
+func (*BranchMerge) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{73} }
+
+func (m *BranchMerge) GetDay() int32 {
+	if m != nil {
+		return m.Day
+	}
+	return 0
+}
+
+func (m *BranchMerge) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *BranchMerge) GetLifetime() int32 {
+	if m != nil {
+		return m.Lifetime
+	}
+	return 0
+}
+
+func (m *BranchMerge) GetLatency() int32 {
+	if m != nil {
+		return m.Latency
+	}
+	return 0
+}
+
+type BranchLifetimeAnalysisResults struct {
+	// sorted by day
+	Merges   []*BranchMerge `protobuf:"bytes,1,rep,name=merges" json:"merges,omitempty"`
+	Sampling int32          `protobuf:"varint,2,opt,name=sampling,proto3" json:"sampling,omitempty"`
+}
+
+func (m *BranchLifetimeAnalysisResults) Reset()         { *m = BranchLifetimeAnalysisResults{} }
+func (m *BranchLifetimeAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*BranchLifetimeAnalysisResults) ProtoMessage()    {}
+func (*BranchLifetimeAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptorPb, []int{74}
+}
+
+func (m *BranchLifetimeAnalysisResults) GetMerges() []*BranchMerge {
+	if m != nil {
+		return m.Merges
+	}
+	return nil
+}
+
+func (m *BranchLifetimeAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+type Release struct {
+	Tag  string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Hash string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Day  int32  `protobuf:"varint,3,opt,name=day,proto3" json:"day,omitempty"`
+	// days since the previous release or since the beginning of the history
+	Days int32 `protobuf:"varint,4,opt,name=days,proto3" json:"days,omitempty"`
+	// number of commits since the previous release including the released one
+	Commits int32 `protobuf:"varint,5,opt,name=commits,proto3" json:"commits,omitempty"`
+	// number of changed lines in `commits`
+	Churn int32 `protobuf:"varint,6,opt,name=churn,proto3" json:"churn,omitempty"`
+}
+
+func (m *Release) Reset()                    { *m = Release{} }
+func (m *Release) String() string            { return proto.CompactTextString(m) }
+func (*Release) ProtoMessage()               {}
+func (*Release) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{75} }
+
+func (m *Release) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *Release) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *Release) GetDay() int32 {
+	if m != nil {
+		return m.Day
+	}
+	return 0
+}
+
+func (m *Release) GetDays() int32 {
+	if m != nil {
+		return m.Days
+	}
+	return 0
+}
+
+func (m *Release) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *Release) GetChurn() int32 {
+	if m != nil {
+		return m.Churn
+	}
+	return 0
+}
+
+type ReleaseCadenceAnalysisResults struct {
+	// sorted by day
+	Releases          []*Release `protobuf:"bytes,1,rep,name=releases" json:"releases,omitempty"`
+	UnreleasedCommits int32      `protobuf:"varint,2,opt,name=unreleased_commits,json=unreleasedCommits,proto3" json:"unreleased_commits,omitempty"`
+	UnreleasedChurn   int32      `protobuf:"varint,3,opt,name=unreleased_churn,json=unreleasedChurn,proto3" json:"unreleased_churn,omitempty"`
+	TagPattern        string     `protobuf:"bytes,4,opt,name=tag_pattern,json=tagPattern,proto3" json:"tag_pattern,omitempty"`
+}
+
+func (m *ReleaseCadenceAnalysisResults) Reset()         { *m = ReleaseCadenceAnalysisResults{} }
+func (m *ReleaseCadenceAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*ReleaseCadenceAnalysisResults) ProtoMessage()    {}
+func (*ReleaseCadenceAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptorPb, []int{76}
+}
+
+func (m *ReleaseCadenceAnalysisResults) GetReleases() []*Release {
+	if m != nil {
+		return m.Releases
+	}
+	return nil
+}
+
+func (m *ReleaseCadenceAnalysisResults) GetUnreleasedCommits() int32 {
+	if m != nil {
+		return m.UnreleasedCommits
+	}
+	return 0
+}
+
+func (m *ReleaseCadenceAnalysisResults) GetUnreleasedChurn() int32 {
+	if m != nil {
+		return m.UnreleasedChurn
+	}
+	return 0
+}
+
+func (m *ReleaseCadenceAnalysisResults) GetTagPattern() string {
+	if m != nil {
+		return m.TagPattern
+	}
+	return ""
+}
+
+type GiniTick struct {
+	// developer index in `GiniAnalysisResults::dev_index` -> number of commits
+	Commits map[int32]int32 `protobuf:"bytes,1,rep,name=commits" json:"commits,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// developer index in `GiniAnalysisResults::dev_index` -> number of changed lines
+	Lines       map[int32]int32 `protobuf:"bytes,2,rep,name=lines" json:"lines,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	CommitsGini float64         `protobuf:"fixed64,3,opt,name=commits_gini,json=commitsGini,proto3" json:"commits_gini,omitempty"`
+	LinesGini   float64         `protobuf:"fixed64,4,opt,name=lines_gini,json=linesGini,proto3" json:"lines_gini,omitempty"`
+}
+
+func (m *GiniTick) Reset()                    { *m = GiniTick{} }
+func (m *GiniTick) String() string            { return proto.CompactTextString(m) }
+func (*GiniTick) ProtoMessage()               {}
+func (*GiniTick) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{77} }
+
+func (m *GiniTick) GetCommits() map[int32]int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return nil
+}
+
+func (m *GiniTick) GetLines() map[int32]int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return nil
+}
+
+func (m *GiniTick) GetCommitsGini() float64 {
+	if m != nil {
+		return m.CommitsGini
+	}
+	return 0
+}
+
+func (m *GiniTick) GetLinesGini() float64 {
+	if m != nil {
+		return m.LinesGini
+	}
+	return 0
+}
+
+type GiniAnalysisResults struct {
+	Ticks []*GiniTick `protobuf:"bytes,1,rep,name=ticks" json:"ticks,omitempty"`
+	// the whole history
+	Total    *GiniTick `protobuf:"bytes,2,opt,name=total" json:"total,omitempty"`
+	Sampling int32     `protobuf:"varint,3,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	DevIndex []string  `protobuf:"bytes,4,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *GiniAnalysisResults) Reset()                    { *m = GiniAnalysisResults{} }
+func (m *GiniAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*GiniAnalysisResults) ProtoMessage()               {}
+func (*GiniAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{78} }
+
+func (m *GiniAnalysisResults) GetTicks() []*GiniTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *GiniAnalysisResults) GetTotal() *GiniTick {
+	if m != nil {
+		return m.Total
+	}
+	return nil
+}
+
+func (m *GiniAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *GiniAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type OnboardingDeveloper struct {
+	// sorted days of the first `OnboardingAnalysisResults::commits` commits
+	CommitDays []int32 `protobuf:"varint,1,rep,packed,name=commit_days,json=commitDays" json:"commit_days,omitempty"`
+	// directory -> day of the first change in it
+	Directories map[string]int32 `protobuf:"bytes,2,rep,name=directories" json:"directories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (m *OnboardingDeveloper) Reset()                    { *m = OnboardingDeveloper{} }
+func (m *OnboardingDeveloper) String() string            { return proto.CompactTextString(m) }
+func (*OnboardingDeveloper) ProtoMessage()               {}
+func (*OnboardingDeveloper) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{79} }
+
+func (m *OnboardingDeveloper) GetCommitDays() []int32 {
+	if m != nil {
+		return m.CommitDays
+	}
+	return nil
+}
+
+func (m *OnboardingDeveloper) GetDirectories() map[string]int32 {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+type OnboardingDirectory struct {
+	Developers int32 `protobuf:"varint,1,opt,name=developers,proto3" json:"developers,omitempty"`
+	// median number of days from the first commit to the first change in the directory
+	MedianDays float64 `protobuf:"fixed64,2,opt,name=median_days,json=medianDays,proto3" json:"median_days,omitempty"`
+}
+
+func (m *OnboardingDirectory) Reset()                    { *m = OnboardingDirectory{} }
+func (m *OnboardingDirectory) String() string            { return proto.CompactTextString(m) }
+func (*OnboardingDirectory) ProtoMessage()               {}
+func (*OnboardingDirectory) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{80} }
+
+func (m *OnboardingDirectory) GetDevelopers() int32 {
+	if m != nil {
+		return m.Developers
+	}
+	return 0
+}
+
+func (m *OnboardingDirectory) GetMedianDays() float64 {
+	if m != nil {
+		return m.MedianDays
+	}
+	return 0
+}
+
+type OnboardingCohort struct {
+	Developers int32 `protobuf:"varint,1,opt,name=developers,proto3" json:"developers,omitempty"`
+	// number of developers who made `OnboardingAnalysisResults::commits` commits
+	Reached int32 `protobuf:"varint,2,opt,name=reached,proto3" json:"reached,omitempty"`
+	// median number of days from the first commit to the last counted one, -1 if nobody reached it
+	MedianDays  float64                         `protobuf:"fixed64,3,opt,name=median_days,json=medianDays,proto3" json:"median_days,omitempty"`
+	Directories map[string]*OnboardingDirectory `protobuf:"bytes,4,rep,name=directories" json:"directories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *OnboardingCohort) Reset()                    { *m = OnboardingCohort{} }
+func (m *OnboardingCohort) String() string            { return proto.CompactTextString(m) }
+func (*OnboardingCohort) ProtoMessage()               {}
+func (*OnboardingCohort) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{81} }
+
+func (m *OnboardingCohort) GetDevelopers() int32 {
+	if m != nil {
+		return m.Developers
+	}
+	return 0
+}
+
+func (m *OnboardingCohort) GetReached() int32 {
+	if m != nil {
+		return m.Reached
+	}
+	return 0
+}
+
+func (m *OnboardingCohort) GetMedianDays() float64 {
+	if m != nil {
+		return m.MedianDays
+	}
+	return 0
+}
+
+func (m *OnboardingCohort) GetDirectories() map[string]*OnboardingDirectory {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+type OnboardingAnalysisResults struct {
+	// number of commits which defines an onboarded developer
+	Commits int32 `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
+	// length of the joining cohorts in days
+	CohortDays     int32               `protobuf:"varint,2,opt,name=cohort_days,json=cohortDays,proto3" json:"cohort_days,omitempty"`
+	DirectoryDepth int32               `protobuf:"varint,3,opt,name=directory_depth,json=directoryDepth,proto3" json:"directory_depth,omitempty"`
+	Cohorts        []*OnboardingCohort `protobuf:"bytes,4,rep,name=cohorts" json:"cohorts,omitempty"`
+	// corresponds to `dev_index`, the unmatched identities are ignored
+	People   []*OnboardingDeveloper `protobuf:"bytes,5,rep,name=people" json:"people,omitempty"`
+	DevIndex []string               `protobuf:"bytes,6,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *OnboardingAnalysisResults) Reset()                    { *m = OnboardingAnalysisResults{} }
+func (m *OnboardingAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*OnboardingAnalysisResults) ProtoMessage()               {}
+func (*OnboardingAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{82} }
+
+func (m *OnboardingAnalysisResults) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *OnboardingAnalysisResults) GetCohortDays() int32 {
+	if m != nil {
+		return m.CohortDays
+	}
+	return 0
+}
+
+func (m *OnboardingAnalysisResults) GetDirectoryDepth() int32 {
+	if m != nil {
+		return m.DirectoryDepth
+	}
+	return 0
+}
+
+func (m *OnboardingAnalysisResults) GetCohorts() []*OnboardingCohort {
+	if m != nil {
+		return m.Cohorts
+	}
+	return nil
+}
+
+func (m *OnboardingAnalysisResults) GetPeople() []*OnboardingDeveloper {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+func (m *OnboardingAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type TenureTick struct {
+	New      int32 `protobuf:"varint,1,opt,name=new,proto3" json:"new,omitempty"`
+	Active   int32 `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	Departed int32 `protobuf:"varint,3,opt,name=departed,proto3" json:"departed,omitempty"`
+}
+
+func (m *TenureTick) Reset()                    { *m = TenureTick{} }
+func (m *TenureTick) String() string            { return proto.CompactTextString(m) }
+func (*TenureTick) ProtoMessage()               {}
+func (*TenureTick) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{83} }
+
+func (m *TenureTick) GetNew() int32 {
+	if m != nil {
+		return m.New
+	}
+	return 0
+}
+
+func (m *TenureTick) GetActive() int32 {
+	if m != nil {
+		return m.Active
+	}
+	return 0
+}
+
+func (m *TenureTick) GetDeparted() int32 {
+	if m != nil {
+		return m.Departed
+	}
+	return 0
+}
+
+type TenureSpan struct {
+	// days of the first and the last commits in the span
+	Begin int32 `protobuf:"varint,1,opt,name=begin,proto3" json:"begin,omitempty"`
+	End   int32 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (m *TenureSpan) Reset()                    { *m = TenureSpan{} }
+func (m *TenureSpan) String() string            { return proto.CompactTextString(m) }
+func (*TenureSpan) ProtoMessage()               {}
+func (*TenureSpan) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{84} }
+
+func (m *TenureSpan) GetBegin() int32 {
+	if m != nil {
+		return m.Begin
+	}
+	return 0
+}
+
+func (m *TenureSpan) GetEnd() int32 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+type TenureDeveloper struct {
+	// sorted unique days of the commits
+	Days    []int32       `protobuf:"varint,1,rep,packed,name=days" json:"days,omitempty"`
+	Commits int32         `protobuf:"varint,2,opt,name=commits,proto3" json:"commits,omitempty"`
+	Spans   []*TenureSpan `protobuf:"bytes,3,rep,name=spans" json:"spans,omitempty"`
+}
+
+func (m *TenureDeveloper) Reset()                    { *m = TenureDeveloper{} }
+func (m *TenureDeveloper) String() string            { return proto.CompactTextString(m) }
+func (*TenureDeveloper) ProtoMessage()               {}
+func (*TenureDeveloper) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{85} }
+
+func (m *TenureDeveloper) GetDays() []int32 {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+func (m *TenureDeveloper) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *TenureDeveloper) GetSpans() []*TenureSpan {
+	if m != nil {
+		return m.Spans
+	}
+	return nil
+}
+
+type TenureAnalysisResults struct {
+	Ticks []*TenureTick `protobuf:"bytes,1,rep,name=ticks" json:"ticks,omitempty"`
+	// corresponds to `dev_index`, the unmatched identities are ignored
+	People   []*TenureDeveloper `protobuf:"bytes,2,rep,name=people" json:"people,omitempty"`
+	Sampling int32              `protobuf:"varint,3,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	// number of days without commits after which a developer is considered departed
+	InactiveDays int32    `protobuf:"varint,4,opt,name=inactive_days,json=inactiveDays,proto3" json:"inactive_days,omitempty"`
+	LastDay      int32    `protobuf:"varint,5,opt,name=last_day,json=lastDay,proto3" json:"last_day,omitempty"`
+	DevIndex     []string `protobuf:"bytes,6,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *TenureAnalysisResults) Reset()                    { *m = TenureAnalysisResults{} }
+func (m *TenureAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*TenureAnalysisResults) ProtoMessage()               {}
+func (*TenureAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{86} }
+
+func (m *TenureAnalysisResults) GetTicks() []*TenureTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *TenureAnalysisResults) GetPeople() []*TenureDeveloper {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+func (m *TenureAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *TenureAnalysisResults) GetInactiveDays() int32 {
+	if m != nil {
+		return m.InactiveDays
+	}
+	return 0
+}
+
+func (m *TenureAnalysisResults) GetLastDay() int32 {
+	if m != nil {
+		return m.LastDay
+	}
+	return 0
+}
+
+func (m *TenureAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type WorkPattern struct {
+	// number of commits in each hour of the day, 24 elements
+	Hours []int32 `protobuf:"varint,1,rep,packed,name=hours" json:"hours,omitempty"`
+	// number of commits on each day of the week starting from Sunday, 7 elements
+	Weekdays []int32 `protobuf:"varint,2,rep,packed,name=weekdays" json:"weekdays,omitempty"`
+	// number of commits on the weekends or outside of the working hours
+	OffHours int32 `protobuf:"varint,3,opt,name=off_hours,json=offHours,proto3" json:"off_hours,omitempty"`
+	// sorted unique days of the off-hours commits
+	OffHoursDays []int32 `protobuf:"varint,4,rep,packed,name=off_hours_days,json=offHoursDays" json:"off_hours_days,omitempty"`
+}
+
+func (m *WorkPattern) Reset()                    { *m = WorkPattern{} }
+func (m *WorkPattern) String() string            { return proto.CompactTextString(m) }
+func (*WorkPattern) ProtoMessage()               {}
+func (*WorkPattern) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{87} }
+
+func (m *WorkPattern) GetHours() []int32 {
+	if m != nil {
+		return m.Hours
+	}
+	return nil
+}
+
+func (m *WorkPattern) GetWeekdays() []int32 {
+	if m != nil {
+		return m.Weekdays
+	}
+	return nil
+}
+
+func (m *WorkPattern) GetOffHours() int32 {
+	if m != nil {
+		return m.OffHours
+	}
+	return 0
+}
+
+func (m *WorkPattern) GetOffHoursDays() []int32 {
+	if m != nil {
+		return m.OffHoursDays
+	}
+	return nil
+}
+
+type WorkPatternsStreak struct {
+	// index in `dev_index`
+	Developer int32 `protobuf:"varint,1,opt,name=developer,proto3" json:"developer,omitempty"`
+	// days of the first and the last off-hours commits in the series
+	BeginDay int32 `protobuf:"varint,2,opt,name=begin_day,json=beginDay,proto3" json:"begin_day,omitempty"`
+	EndDay   int32 `protobuf:"varint,3,opt,name=end_day,json=endDay,proto3" json:"end_day,omitempty"`
+	// number of consecutive weeks with the off-hours commits
+	Weeks int32 `protobuf:"varint,4,opt,name=weeks,proto3" json:"weeks,omitempty"`
+}
+
+func (m *WorkPatternsStreak) Reset()                    { *m = WorkPatternsStreak{} }
+func (m *WorkPatternsStreak) String() string            { return proto.CompactTextString(m) }
+func (*WorkPatternsStreak) ProtoMessage()               {}
+func (*WorkPatternsStreak) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{88} }
+
+func (m *WorkPatternsStreak) GetDeveloper() int32 {
+	if m != nil {
+		return m.Developer
+	}
+	return 0
+}
+
+func (m *WorkPatternsStreak) GetBeginDay() int32 {
+	if m != nil {
+		return m.BeginDay
+	}
+	return 0
+}
+
+func (m *WorkPatternsStreak) GetEndDay() int32 {
+	if m != nil {
+		return m.EndDay
+	}
+	return 0
+}
+
+func (m *WorkPatternsStreak) GetWeeks() int32 {
+	if m != nil {
+		return m.Weeks
+	}
+	return 0
+}
+
+type WorkPatternsAnalysisResults struct {
+	// "author" means the author's own time zone, otherwise an IANA name
+	Timezone string `protobuf:"bytes,1,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// the working hours are [work_day_start, work_day_end) on the weekdays
+	WorkDayStart int32 `protobuf:"varint,2,opt,name=work_day_start,json=workDayStart,proto3" json:"work_day_start,omitempty"`
+	WorkDayEnd   int32 `protobuf:"varint,3,opt,name=work_day_end,json=workDayEnd,proto3" json:"work_day_end,omitempty"`
+	// minimum length of the reported streaks in weeks
+	SustainedWeeks int32 `protobuf:"varint,4,opt,name=sustained_weeks,json=sustainedWeeks,proto3" json:"sustained_weeks,omitempty"`
+	// corresponds to `dev_index`, the last element is the unmatched identities
+	People   []*WorkPattern        `protobuf:"bytes,5,rep,name=people" json:"people,omitempty"`
+	Streaks  []*WorkPatternsStreak `protobuf:"bytes,6,rep,name=streaks" json:"streaks,omitempty"`
+	DevIndex []string              `protobuf:"bytes,7,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *WorkPatternsAnalysisResults) Reset()                    { *m = WorkPatternsAnalysisResults{} }
+func (m *WorkPatternsAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*WorkPatternsAnalysisResults) ProtoMessage()               {}
+func (*WorkPatternsAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{89} }
+
+func (m *WorkPatternsAnalysisResults) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+func (m *WorkPatternsAnalysisResults) GetWorkDayStart() int32 {
+	if m != nil {
+		return m.WorkDayStart
+	}
+	return 0
+}
+
+func (m *WorkPatternsAnalysisResults) GetWorkDayEnd() int32 {
+	if m != nil {
+		return m.WorkDayEnd
+	}
+	return 0
+}
+
+func (m *WorkPatternsAnalysisResults) GetSustainedWeeks() int32 {
+	if m != nil {
+		return m.SustainedWeeks
+	}
+	return 0
+}
+
+func (m *WorkPatternsAnalysisResults) GetPeople() []*WorkPattern {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+func (m *WorkPatternsAnalysisResults) GetStreaks() []*WorkPatternsStreak {
+	if m != nil {
+		return m.Streaks
+	}
+	return nil
+}
+
+func (m *WorkPatternsAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type KnowledgeMapVector struct {
+	// order corresponds to `directories`
+	Values []float64 `protobuf:"fixed64,1,rep,packed,name=values" json:"values,omitempty"`
+}
+
+func (m *KnowledgeMapVector) Reset()                    { *m = KnowledgeMapVector{} }
+func (m *KnowledgeMapVector) String() string            { return proto.CompactTextString(m) }
+func (*KnowledgeMapVector) ProtoMessage()               {}
+func (*KnowledgeMapVector) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{90} }
+
+func (m *KnowledgeMapVector) GetValues() []float64 {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type KnowledgeMapAnalysisResults struct {
+	// number of days after which the weight of a change halves, 0 means no recency weighting
+	HalfLife int32 `protobuf:"varint,1,opt,name=half_life,json=halfLife,proto3" json:"half_life,omitempty"`
+	// number of path components in `directories`
+	DirectoryDepth int32 `protobuf:"varint,2,opt,name=directory_depth,json=directoryDepth,proto3" json:"directory_depth,omitempty"`
+	// "." is the root
+	Directories []string `protobuf:"bytes,3,rep,name=directories" json:"directories,omitempty"`
+	// the following two correspond to `dev_index`, the last element is the unmatched identities
+	// weighted number of changed lines
+	PeopleLines []*KnowledgeMapVector `protobuf:"bytes,4,rep,name=people_lines,json=peopleLines" json:"people_lines,omitempty"`
+	// weighted number of commits
+	PeopleCommits []*KnowledgeMapVector `protobuf:"bytes,5,rep,name=people_commits,json=peopleCommits" json:"people_commits,omitempty"`
+	DevIndex      []string              `protobuf:"bytes,6,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *KnowledgeMapAnalysisResults) Reset()                    { *m = KnowledgeMapAnalysisResults{} }
+func (m *KnowledgeMapAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*KnowledgeMapAnalysisResults) ProtoMessage()               {}
+func (*KnowledgeMapAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{91} }
+
+func (m *KnowledgeMapAnalysisResults) GetHalfLife() int32 {
+	if m != nil {
+		return m.HalfLife
+	}
+	return 0
+}
+
+func (m *KnowledgeMapAnalysisResults) GetDirectoryDepth() int32 {
+	if m != nil {
+		return m.DirectoryDepth
+	}
+	return 0
+}
+
+func (m *KnowledgeMapAnalysisResults) GetDirectories() []string {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+func (m *KnowledgeMapAnalysisResults) GetPeopleLines() []*KnowledgeMapVector {
+	if m != nil {
+		return m.PeopleLines
+	}
+	return nil
+}
+
+func (m *KnowledgeMapAnalysisResults) GetPeopleCommits() []*KnowledgeMapVector {
+	if m != nil {
+		return m.PeopleCommits
+	}
+	return nil
+}
+
+func (m *KnowledgeMapAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type DeadCodeTick struct {
+	// number of distinct declared names at the end of the tick
+	Definitions int32 `protobuf:"varint,1,opt,name=definitions,proto3" json:"definitions,omitempty"`
+	// number of declared names without references at the end of the tick
+	Unreferenced int32 `protobuf:"varint,2,opt,name=unreferenced,proto3" json:"unreferenced,omitempty"`
+	// number of names which lost their last reference
+	Orphaned int32 `protobuf:"varint,3,opt,name=orphaned,proto3" json:"orphaned,omitempty"`
+	// number of unreferenced names which were referenced again or deleted
+	Resolved int32 `protobuf:"varint,4,opt,name=resolved,proto3" json:"resolved,omitempty"`
+}
+
+func (m *DeadCodeTick) Reset()                    { *m = DeadCodeTick{} }
+func (m *DeadCodeTick) String() string            { return proto.CompactTextString(m) }
+func (*DeadCodeTick) ProtoMessage()               {}
+func (*DeadCodeTick) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{92} }
+
+func (m *DeadCodeTick) GetDefinitions() int32 {
+	if m != nil {
+		return m.Definitions
+	}
+	return 0
+}
+
+func (m *DeadCodeTick) GetUnreferenced() int32 {
+	if m != nil {
+		return m.Unreferenced
+	}
+	return 0
+}
+
+func (m *DeadCodeTick) GetOrphaned() int32 {
+	if m != nil {
+		return m.Orphaned
+	}
+	return 0
+}
+
+func (m *DeadCodeTick) GetResolved() int32 {
+	if m != nil {
+		return m.Resolved
+	}
+	return 0
+}
+
+type DeadCodeOrphan struct {
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// day since the beginning of the history
+	Day    int32  `protobuf:"varint,2,opt,name=day,proto3" json:"day,omitempty"`
+	File   string `protobuf:"bytes,3,opt,name=file,proto3" json:"file,omitempty"`
+	Symbol string `protobuf:"bytes,4,opt,name=symbol,proto3" json:"symbol,omitempty"`
+}
+
+func (m *DeadCodeOrphan) Reset()                    { *m = DeadCodeOrphan{} }
+func (m *DeadCodeOrphan) String() string            { return proto.CompactTextString(m) }
+func (*DeadCodeOrphan) ProtoMessage()               {}
+func (*DeadCodeOrphan) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{93} }
+
+func (m *DeadCodeOrphan) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *DeadCodeOrphan) GetDay() int32 {
+	if m != nil {
+		return m.Day
+	}
+	return 0
+}
+
+func (m *DeadCodeOrphan) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *DeadCodeOrphan) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+type DeadCodeAnalysisResults struct {
+	// tick size in days
+	Sampling int32           `protobuf:"varint,1,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	Ticks    []*DeadCodeTick `protobuf:"bytes,2,rep,name=ticks" json:"ticks,omitempty"`
+	// sorted by day
+	Orphans []*DeadCodeOrphan `protobuf:"bytes,3,rep,name=orphans" json:"orphans,omitempty"`
+}
+
+func (m *DeadCodeAnalysisResults) Reset()                    { *m = DeadCodeAnalysisResults{} }
+func (m *DeadCodeAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*DeadCodeAnalysisResults) ProtoMessage()               {}
+func (*DeadCodeAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{94} }
+
+func (m *DeadCodeAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *DeadCodeAnalysisResults) GetTicks() []*DeadCodeTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *DeadCodeAnalysisResults) GetOrphans() []*DeadCodeOrphan {
+	if m != nil {
+		return m.Orphans
+	}
+	return nil
+}
+
+type CommitSentiment struct {
+	Positive int32 `protobuf:"varint,1,opt,name=positive,proto3" json:"positive,omitempty"`
+	Negative int32 `protobuf:"varint,2,opt,name=negative,proto3" json:"negative,omitempty"`
+	Neutral  int32 `protobuf:"varint,3,opt,name=neutral,proto3" json:"neutral,omitempty"`
+	// sum of the sentiment values of the positive and the negative messages
+	Sum float64 `protobuf:"fixed64,4,opt,name=sum,proto3" json:"sum,omitempty"`
+}
+
+func (m *CommitSentiment) Reset()                    { *m = CommitSentiment{} }
+func (m *CommitSentiment) String() string            { return proto.CompactTextString(m) }
+func (*CommitSentiment) ProtoMessage()               {}
+func (*CommitSentiment) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{95} }
+
+func (m *CommitSentiment) GetPositive() int32 {
+	if m != nil {
+		return m.Positive
+	}
+	return 0
+}
+
+func (m *CommitSentiment) GetNegative() int32 {
+	if m != nil {
+		return m.Negative
+	}
+	return 0
+}
+
+func (m *CommitSentiment) GetNeutral() int32 {
+	if m != nil {
+		return m.Neutral
+	}
+	return 0
+}
+
+func (m *CommitSentiment) GetSum() float64 {
+	if m != nil {
+		return m.Sum
+	}
+	return 0
+}
+
+type CommitSentimentAnalysisResults struct {
+	// tick size in days
+	Sampling int32 `protobuf:"varint,1,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	// name of the model which evaluated the commit messages
+	Model string             `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	Ticks []*CommitSentiment `protobuf:"bytes,3,rep,name=ticks" json:"ticks,omitempty"`
+	// sentiment of each developer, the last element is the unmatched identities
+	People   []*CommitSentiment `protobuf:"bytes,4,rep,name=people" json:"people,omitempty"`
+	DevIndex []string           `protobuf:"bytes,5,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *CommitSentimentAnalysisResults) Reset()         { *m = CommitSentimentAnalysisResults{} }
+func (m *CommitSentimentAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*CommitSentimentAnalysisResults) ProtoMessage()    {}
+func (*CommitSentimentAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptorPb, []int{96}
+}
+
+func (m *CommitSentimentAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *CommitSentimentAnalysisResults) GetModel() string {
+	if m != nil {
+		return m.Model
+	}
+	return ""
+}
+
+func (m *CommitSentimentAnalysisResults) GetTicks() []*CommitSentiment {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *CommitSentimentAnalysisResults) GetPeople() []*CommitSentiment {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+func (m *CommitSentimentAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type Hotspot struct {
+	File    string `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Commits int32  `protobuf:"varint,2,opt,name=commits,proto3" json:"commits,omitempty"`
+	Churn   int32  `protobuf:"varint,3,opt,name=churn,proto3" json:"churn,omitempty"`
+	Lines   int32  `protobuf:"varint,4,opt,name=lines,proto3" json:"lines,omitempty"`
+	// indentation complexity
+	Complexity int32 `protobuf:"varint,5,opt,name=complexity,proto3" json:"complexity,omitempty"`
+	// normalized commits * normalized complexity
+	Score float64 `protobuf:"fixed64,6,opt,name=score,proto3" json:"score,omitempty"`
+}
+
+func (m *Hotspot) Reset()                    { *m = Hotspot{} }
+func (m *Hotspot) String() string            { return proto.CompactTextString(m) }
+func (*Hotspot) ProtoMessage()               {}
+func (*Hotspot) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{97} }
+
+func (m *Hotspot) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *Hotspot) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *Hotspot) GetChurn() int32 {
+	if m != nil {
+		return m.Churn
+	}
+	return 0
+}
+
+func (m *Hotspot) GetLines() int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *Hotspot) GetComplexity() int32 {
+	if m != nil {
+		return m.Complexity
+	}
+	return 0
+}
+
+func (m *Hotspot) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+type HotspotsAnalysisResults struct {
+	// sorted by score in the descending order
+	Hotspots []*Hotspot `protobuf:"bytes,1,rep,name=hotspots" json:"hotspots,omitempty"`
+}
+
+func (m *HotspotsAnalysisResults) Reset()                    { *m = HotspotsAnalysisResults{} }
+func (m *HotspotsAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*HotspotsAnalysisResults) ProtoMessage()               {}
+func (*HotspotsAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{98} }
+
+func (m *HotspotsAnalysisResults) GetHotspots() []*Hotspot {
+	if m != nil {
+		return m.Hotspots
+	}
+	return nil
+}
+
+type TestRatioTick struct {
+	// number of lines at the end of the tick
+	TestLines       int32 `protobuf:"varint,1,opt,name=test_lines,json=testLines,proto3" json:"test_lines,omitempty"`
+	ProductionLines int32 `protobuf:"varint,2,opt,name=production_lines,json=productionLines,proto3" json:"production_lines,omitempty"`
+	// number of added and removed lines during the tick
+	TestChurn       int32 `protobuf:"varint,3,opt,name=test_churn,json=testChurn,proto3" json:"test_churn,omitempty"`
+	ProductionChurn int32 `protobuf:"varint,4,opt,name=production_churn,json=productionChurn,proto3" json:"production_churn,omitempty"`
+}
+
+func (m *TestRatioTick) Reset()                    { *m = TestRatioTick{} }
+func (m *TestRatioTick) String() string            { return proto.CompactTextString(m) }
+func (*TestRatioTick) ProtoMessage()               {}
+func (*TestRatioTick) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{99} }
+
+func (m *TestRatioTick) GetTestLines() int32 {
+	if m != nil {
+		return m.TestLines
+	}
+	return 0
+}
+
+func (m *TestRatioTick) GetProductionLines() int32 {
+	if m != nil {
+		return m.ProductionLines
+	}
+	return 0
+}
+
+func (m *TestRatioTick) GetTestChurn() int32 {
+	if m != nil {
+		return m.TestChurn
+	}
+	return 0
+}
+
+func (m *TestRatioTick) GetProductionChurn() int32 {
+	if m != nil {
+		return m.ProductionChurn
+	}
+	return 0
+}
+
+type TestRatioAnalysisResults struct {
+	// tick size in days
+	Sampling int32            `protobuf:"varint,1,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	Ticks    []*TestRatioTick `protobuf:"bytes,2,rep,name=ticks" json:"ticks,omitempty"`
+}
+
+func (m *TestRatioAnalysisResults) Reset()                    { *m = TestRatioAnalysisResults{} }
+func (m *TestRatioAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*TestRatioAnalysisResults) ProtoMessage()               {}
+func (*TestRatioAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{100} }
+
+func (m *TestRatioAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *TestRatioAnalysisResults) GetTicks() []*TestRatioTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+type VocabularyTick struct {
+	// number of distinct terms at the end of the tick
+	Terms      int32 `protobuf:"varint,1,opt,name=terms,proto3" json:"terms,omitempty"`
+	Introduced int32 `protobuf:"varint,2,opt,name=introduced,proto3" json:"introduced,omitempty"`
+	Retired    int32 `protobuf:"varint,3,opt,name=retired,proto3" json:"retired,omitempty"`
+}
+
+func (m *VocabularyTick) Reset()                    { *m = VocabularyTick{} }
+func (m *VocabularyTick) String() string            { return proto.CompactTextString(m) }
+func (*VocabularyTick) ProtoMessage()               {}
+func (*VocabularyTick) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{101} }
+
+func (m *VocabularyTick) GetTerms() int32 {
+	if m != nil {
+		return m.Terms
+	}
+	return 0
+}
+
+func (m *VocabularyTick) GetIntroduced() int32 {
+	if m != nil {
+		return m.Introduced
+	}
+	return 0
+}
+
+func (m *VocabularyTick) GetRetired() int32 {
+	if m != nil {
+		return m.Retired
+	}
+	return 0
+}
+
+type VocabularyTerms struct {
+	// term -> number of occurrences
+	Terms map[string]int32 `protobuf:"bytes,1,rep,name=terms" json:"terms,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (m *VocabularyTerms) Reset()                    { *m = VocabularyTerms{} }
+func (m *VocabularyTerms) String() string            { return proto.CompactTextString(m) }
+func (*VocabularyTerms) ProtoMessage()               {}
+func (*VocabularyTerms) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{102} }
+
+func (m *VocabularyTerms) GetTerms() map[string]int32 {
+	if m != nil {
+		return m.Terms
+	}
+	return nil
+}
+
+type VocabularyAnalysisResults struct {
+	// tick size in days
+	Sampling int32             `protobuf:"varint,1,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	Ticks    []*VocabularyTick `protobuf:"bytes,2,rep,name=ticks" json:"ticks,omitempty"`
+	// the vocabulary in the end of the history
+	Terms map[string]int32 `protobuf:"bytes,3,rep,name=terms" json:"terms,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// term occurrences added by each developer, the last element is the unmatched identities
+	People   []*VocabularyTerms `protobuf:"bytes,4,rep,name=people" json:"people,omitempty"`
+	DevIndex []string           `protobuf:"bytes,5,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *VocabularyAnalysisResults) Reset()                    { *m = VocabularyAnalysisResults{} }
+func (m *VocabularyAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*VocabularyAnalysisResults) ProtoMessage()               {}
+func (*VocabularyAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{103} }
+
+func (m *VocabularyAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *VocabularyAnalysisResults) GetTicks() []*VocabularyTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *VocabularyAnalysisResults) GetTerms() map[string]int32 {
+	if m != nil {
+		return m.Terms
+	}
+	return nil
+}
+
+func (m *VocabularyAnalysisResults) GetPeople() []*VocabularyTerms {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+func (m *VocabularyAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type ImportGraphTick struct {
+	// number of modules
+	Nodes int32 `protobuf:"varint,1,opt,name=nodes,proto3" json:"nodes,omitempty"`
+	// number of dependent pairs of modules
+	Edges int32 `protobuf:"varint,2,opt,name=edges,proto3" json:"edges,omitempty"`
+	// number of strongly connected components with more than one module
+	Cycles      int32 `protobuf:"varint,3,opt,name=cycles,proto3" json:"cycles,omitempty"`
+	CyclicNodes int32 `protobuf:"varint,4,opt,name=cyclic_nodes,json=cyclicNodes,proto3" json:"cyclic_nodes,omitempty"`
+}
+
+func (m *ImportGraphTick) Reset()                    { *m = ImportGraphTick{} }
+func (m *ImportGraphTick) String() string            { return proto.CompactTextString(m) }
+func (*ImportGraphTick) ProtoMessage()               {}
+func (*ImportGraphTick) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{104} }
+
+func (m *ImportGraphTick) GetNodes() int32 {
+	if m != nil {
+		return m.Nodes
+	}
+	return 0
+}
+
+func (m *ImportGraphTick) GetEdges() int32 {
+	if m != nil {
+		return m.Edges
+	}
+	return 0
+}
+
+func (m *ImportGraphTick) GetCycles() int32 {
+	if m != nil {
+		return m.Cycles
+	}
+	return 0
+}
+
+func (m *ImportGraphTick) GetCyclicNodes() int32 {
+	if m != nil {
+		return m.CyclicNodes
+	}
+	return 0
+}
+
+type ImportGraphEdge struct {
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// number of files in `from` which import `to`
+	Imports int32 `protobuf:"varint,3,opt,name=imports,proto3" json:"imports,omitempty"`
+}
+
+func (m *ImportGraphEdge) Reset()                    { *m = ImportGraphEdge{} }
+func (m *ImportGraphEdge) String() string            { return proto.CompactTextString(m) }
+func (*ImportGraphEdge) ProtoMessage()               {}
+func (*ImportGraphEdge) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{105} }
+
+func (m *ImportGraphEdge) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *ImportGraphEdge) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *ImportGraphEdge) GetImports() int32 {
+	if m != nil {
+		return m.Imports
+	}
+	return 0
+}
+
+type ImportGraphAnalysisResults struct {
+	// tick size in days
+	Sampling int32              `protobuf:"varint,1,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	Ticks    []*ImportGraphTick `protobuf:"bytes,2,rep,name=ticks" json:"ticks,omitempty"`
+	// the graph in the end of the history
+	Edges []*ImportGraphEdge `protobuf:"bytes,3,rep,name=edges" json:"edges,omitempty"`
+}
+
+func (m *ImportGraphAnalysisResults) Reset()                    { *m = ImportGraphAnalysisResults{} }
+func (m *ImportGraphAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*ImportGraphAnalysisResults) ProtoMessage()               {}
+func (*ImportGraphAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{106} }
+
+func (m *ImportGraphAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *ImportGraphAnalysisResults) GetTicks() []*ImportGraphTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *ImportGraphAnalysisResults) GetEdges() []*ImportGraphEdge {
+	if m != nil {
+		return m.Edges
+	}
+	return nil
+}
+
+type APISurfaceTick struct {
+	// number of exported symbols at the end of the tick
+	Symbols int32 `protobuf:"varint,1,opt,name=symbols,proto3" json:"symbols,omitempty"`
+	Added   int32 `protobuf:"varint,2,opt,name=added,proto3" json:"added,omitempty"`
+	Removed int32 `protobuf:"varint,3,opt,name=removed,proto3" json:"removed,omitempty"`
+	// number of exported functions with a different number of arguments
+	Changed int32 `protobuf:"varint,4,opt,name=changed,proto3" json:"changed,omitempty"`
+}
+
+func (m *APISurfaceTick) Reset()                    { *m = APISurfaceTick{} }
+func (m *APISurfaceTick) String() string            { return proto.CompactTextString(m) }
+func (*APISurfaceTick) ProtoMessage()               {}
+func (*APISurfaceTick) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{107} }
+
+func (m *APISurfaceTick) GetSymbols() int32 {
+	if m != nil {
+		return m.Symbols
+	}
+	return 0
+}
+
+func (m *APISurfaceTick) GetAdded() int32 {
+	if m != nil {
+		return m.Added
+	}
+	return 0
+}
+
+func (m *APISurfaceTick) GetRemoved() int32 {
+	if m != nil {
+		return m.Removed
+	}
+	return 0
+}
+
+func (m *APISurfaceTick) GetChanged() int32 {
+	if m != nil {
+		return m.Changed
+	}
+	return 0
+}
+
+type APIBreakingChange struct {
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// day since the beginning of the history
+	Day  int32  `protobuf:"varint,2,opt,name=day,proto3" json:"day,omitempty"`
+	File string `protobuf:"bytes,3,opt,name=file,proto3" json:"file,omitempty"`
+	// qualified with the names of the enclosing types
+	Symbol string `protobuf:"bytes,4,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// false means that the number of arguments changed
+	Removed bool `protobuf:"varint,5,opt,name=removed,proto3" json:"removed,omitempty"`
+}
+
+func (m *APIBreakingChange) Reset()                    { *m = APIBreakingChange{} }
+func (m *APIBreakingChange) String() string            { return proto.CompactTextString(m) }
+func (*APIBreakingChange) ProtoMessage()               {}
+func (*APIBreakingChange) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{108} }
+
+func (m *APIBreakingChange) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *APIBreakingChange) GetDay() int32 {
+	if m != nil {
+		return m.Day
+	}
+	return 0
+}
+
+func (m *APIBreakingChange) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *APIBreakingChange) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *APIBreakingChange) GetRemoved() bool {
+	if m != nil {
+		return m.Removed
+	}
+	return false
+}
+
+type APISurfaceAnalysisResults struct {
+	// tick size in days
+	Sampling int32             `protobuf:"varint,1,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	Ticks    []*APISurfaceTick `protobuf:"bytes,2,rep,name=ticks" json:"ticks,omitempty"`
+	// sorted by day
+	BreakingChanges []*APIBreakingChange `protobuf:"bytes,3,rep,name=breaking_changes,json=breakingChanges" json:"breaking_changes,omitempty"`
+}
+
+func (m *APISurfaceAnalysisResults) Reset()                    { *m = APISurfaceAnalysisResults{} }
+func (m *APISurfaceAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*APISurfaceAnalysisResults) ProtoMessage()               {}
+func (*APISurfaceAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{109} }
+
+func (m *APISurfaceAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *APISurfaceAnalysisResults) GetTicks() []*APISurfaceTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *APISurfaceAnalysisResults) GetBreakingChanges() []*APIBreakingChange {
+	if m != nil {
+		return m.BreakingChanges
+	}
+	return nil
+}
+
+type ClonesTick struct {
+	// number of lines which contain letters or digits
+	Lines int32 `protobuf:"varint,1,opt,name=lines,proto3" json:"lines,omitempty"`
+	// number of such lines which belong to the duplicated blocks
+	DuplicatedLines int32 `protobuf:"varint,2,opt,name=duplicated_lines,json=duplicatedLines,proto3" json:"duplicated_lines,omitempty"`
+}
+
+func (m *ClonesTick) Reset()                    { *m = ClonesTick{} }
+func (m *ClonesTick) String() string            { return proto.CompactTextString(m) }
+func (*ClonesTick) ProtoMessage()               {}
+func (*ClonesTick) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{110} }
+
+func (m *ClonesTick) GetLines() int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *ClonesTick) GetDuplicatedLines() int32 {
+	if m != nil {
+		return m.DuplicatedLines
+	}
+	return 0
+}
+
+type ClonesCommit struct {
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// day since the beginning of the history
+	Day int32 `protobuf:"varint,2,opt,name=day,proto3" json:"day,omitempty"`
+	// number of inserted lines which belong to the duplicated blocks
+	Lines int32    `protobuf:"varint,3,opt,name=lines,proto3" json:"lines,omitempty"`
+	Files []string `protobuf:"bytes,4,rep,name=files" json:"files,omitempty"`
+}
+
+func (m *ClonesCommit) Reset()                    { *m = ClonesCommit{} }
+func (m *ClonesCommit) String() string            { return proto.CompactTextString(m) }
+func (*ClonesCommit) ProtoMessage()               {}
+func (*ClonesCommit) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{111} }
+
+func (m *ClonesCommit) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *ClonesCommit) GetDay() int32 {
+	if m != nil {
+		return m.Day
+	}
+	return 0
+}
+
+func (m *ClonesCommit) GetLines() int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *ClonesCommit) GetFiles() []string {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+type ClonesAnalysisResults struct {
+	// tick size in days
+	Sampling int32 `protobuf:"varint,1,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	// tick -> duplication at the end of the tick
+	Ticks []*ClonesTick `protobuf:"bytes,2,rep,name=ticks" json:"ticks,omitempty"`
+	// sorted by the number of lines in descending order
+	Commits []*ClonesCommit `protobuf:"bytes,3,rep,name=commits" json:"commits,omitempty"`
+}
+
+func (m *ClonesAnalysisResults) Reset()                    { *m = ClonesAnalysisResults{} }
+func (m *ClonesAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*ClonesAnalysisResults) ProtoMessage()               {}
+func (*ClonesAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{112} }
+
+func (m *ClonesAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *ClonesAnalysisResults) GetTicks() []*ClonesTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *ClonesAnalysisResults) GetCommits() []*ClonesCommit {
+	if m != nil {
+		return m.Commits
+	}
+	return nil
+}
+
+type TechDebtTick struct {
+	// number of outstanding markers at the end of the tick
+	Open     int32 `protobuf:"varint,1,opt,name=open,proto3" json:"open,omitempty"`
+	Added    int32 `protobuf:"varint,2,opt,name=added,proto3" json:"added,omitempty"`
+	Resolved int32 `protobuf:"varint,3,opt,name=resolved,proto3" json:"resolved,omitempty"`
+}
+
+func (m *TechDebtTick) Reset()                    { *m = TechDebtTick{} }
+func (m *TechDebtTick) String() string            { return proto.CompactTextString(m) }
+func (*TechDebtTick) ProtoMessage()               {}
+func (*TechDebtTick) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{113} }
+
+func (m *TechDebtTick) GetOpen() int32 {
+	if m != nil {
+		return m.Open
+	}
+	return 0
+}
+
+func (m *TechDebtTick) GetAdded() int32 {
+	if m != nil {
+		return m.Added
+	}
+	return 0
+}
+
+func (m *TechDebtTick) GetResolved() int32 {
+	if m != nil {
+		return m.Resolved
+	}
+	return 0
+}
+
+type TechDebtMarker struct {
+	// e.g. "TODO"
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Text string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	File string `protobuf:"bytes,3,opt,name=file,proto3" json:"file,omitempty"`
+	// 1-based
+	Line int32 `protobuf:"varint,4,opt,name=line,proto3" json:"line,omitempty"`
+	// index in `dev_index`; -1 means unmatched
+	Author int32 `protobuf:"varint,5,opt,name=author,proto3" json:"author,omitempty"`
+	// day since the beginning of the history when the marker was introduced
+	Day int32 `protobuf:"varint,6,opt,name=day,proto3" json:"day,omitempty"`
+}
+
+func (m *TechDebtMarker) Reset()                    { *m = TechDebtMarker{} }
+func (m *TechDebtMarker) String() string            { return proto.CompactTextString(m) }
+func (*TechDebtMarker) ProtoMessage()               {}
+func (*TechDebtMarker) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{114} }
+
+func (m *TechDebtMarker) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *TechDebtMarker) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+func (m *TechDebtMarker) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *TechDebtMarker) GetLine() int32 {
+	if m != nil {
+		return m.Line
+	}
+	return 0
+}
+
+func (m *TechDebtMarker) GetAuthor() int32 {
+	if m != nil {
+		return m.Author
+	}
+	return 0
+}
+
+func (m *TechDebtMarker) GetDay() int32 {
+	if m != nil {
+		return m.Day
+	}
+	return 0
+}
+
+type TechDebtAnalysisResults struct {
+	// tick size in days
+	Sampling int32           `protobuf:"varint,1,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	Ticks    []*TechDebtTick `protobuf:"bytes,2,rep,name=ticks" json:"ticks,omitempty"`
+	// marker -> number of outstanding occurrences
+	Kinds map[string]int32 `protobuf:"bytes,3,rep,name=kinds" json:"kinds,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// sorted by the day of the introduction
+	Oldest []*TechDebtMarker `protobuf:"bytes,4,rep,name=oldest" json:"oldest,omitempty"`
+	// sorted numbers of days which the resolved markers lived
+	Lifetimes []int32  `protobuf:"varint,5,rep,packed,name=lifetimes" json:"lifetimes,omitempty"`
+	DevIndex  []string `protobuf:"bytes,6,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *TechDebtAnalysisResults) Reset()                    { *m = TechDebtAnalysisResults{} }
+func (m *TechDebtAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*TechDebtAnalysisResults) ProtoMessage()               {}
+func (*TechDebtAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{115} }
+
+func (m *TechDebtAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *TechDebtAnalysisResults) GetTicks() []*TechDebtTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *TechDebtAnalysisResults) GetKinds() map[string]int32 {
+	if m != nil {
+		return m.Kinds
+	}
+	return nil
+}
+
+func (m *TechDebtAnalysisResults) GetOldest() []*TechDebtMarker {
+	if m != nil {
+		return m.Oldest
+	}
+	return nil
+}
+
+func (m *TechDebtAnalysisResults) GetLifetimes() []int32 {
+	if m != nil {
+		return m.Lifetimes
+	}
+	return nil
+}
+
+func (m *TechDebtAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type CommentDensity struct {
+	Lines int32 `protobuf:"varint,1,opt,name=lines,proto3" json:"lines,omitempty"`
+	// number of lines which contain comments
+	CommentLines int32 `protobuf:"varint,2,opt,name=comment_lines,json=commentLines,proto3" json:"comment_lines,omitempty"`
+	// number of comment nodes
+	Comments int32 `protobuf:"varint,3,opt,name=comments,proto3" json:"comments,omitempty"`
+}
+
+func (m *CommentDensity) Reset()                    { *m = CommentDensity{} }
+func (m *CommentDensity) String() string            { return proto.CompactTextString(m) }
+func (*CommentDensity) ProtoMessage()               {}
+func (*CommentDensity) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{116} }
+
+func (m *CommentDensity) GetLines() int32 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *CommentDensity) GetCommentLines() int32 {
+	if m != nil {
+		return m.CommentLines
+	}
+	return 0
+}
+
+func (m *CommentDensity) GetComments() int32 {
+	if m != nil {
+		return m.Comments
+	}
+	return 0
+}
+
+type CommentDensitySeries struct {
+	// tick -> comment density at the end of the tick
+	Ticks []*CommentDensity `protobuf:"bytes,1,rep,name=ticks" json:"ticks,omitempty"`
+}
+
+func (m *CommentDensitySeries) Reset()                    { *m = CommentDensitySeries{} }
+func (m *CommentDensitySeries) String() string            { return proto.CompactTextString(m) }
+func (*CommentDensitySeries) ProtoMessage()               {}
+func (*CommentDensitySeries) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{117} }
+
+func (m *CommentDensitySeries) GetTicks() []*CommentDensity {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+type CommentDensityAnalysisResults struct {
+	// tick size in days
+	Sampling int32 `protobuf:"varint,1,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	// tick -> comment density of the whole project
+	Project []*CommentDensity `protobuf:"bytes,2,rep,name=project" json:"project,omitempty"`
+	// directory -> series
+	Directories map[string]*CommentDensitySeries `protobuf:"bytes,3,rep,name=directories" json:"directories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *CommentDensityAnalysisResults) Reset()         { *m = CommentDensityAnalysisResults{} }
+func (m *CommentDensityAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*CommentDensityAnalysisResults) ProtoMessage()    {}
+func (*CommentDensityAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptorPb, []int{118}
+}
+
+func (m *CommentDensityAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *CommentDensityAnalysisResults) GetProject() []*CommentDensity {
+	if m != nil {
+		return m.Project
+	}
+	return nil
+}
+
+func (m *CommentDensityAnalysisResults) GetDirectories() map[string]*CommentDensitySeries {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+type Complexity struct {
+	Functions  int32 `protobuf:"varint,1,opt,name=functions,proto3" json:"functions,omitempty"`
+	Cyclomatic int32 `protobuf:"varint,2,opt,name=cyclomatic,proto3" json:"cyclomatic,omitempty"`
+	Cognitive  int32 `protobuf:"varint,3,opt,name=cognitive,proto3" json:"cognitive,omitempty"`
+}
+
+func (m *Complexity) Reset()                    { *m = Complexity{} }
+func (m *Complexity) String() string            { return proto.CompactTextString(m) }
+func (*Complexity) ProtoMessage()               {}
+func (*Complexity) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{119} }
+
+func (m *Complexity) GetFunctions() int32 {
+	if m != nil {
+		return m.Functions
+	}
+	return 0
+}
+
+func (m *Complexity) GetCyclomatic() int32 {
+	if m != nil {
+		return m.Cyclomatic
+	}
+	return 0
+}
+
+func (m *Complexity) GetCognitive() int32 {
+	if m != nil {
+		return m.Cognitive
+	}
+	return 0
+}
+
+type ComplexitySeries struct {
+	// tick -> complexity at the end of the tick
+	Ticks map[int32]*Complexity `protobuf:"bytes,1,rep,name=ticks" json:"ticks,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *ComplexitySeries) Reset()                    { *m = ComplexitySeries{} }
+func (m *ComplexitySeries) String() string            { return proto.CompactTextString(m) }
+func (*ComplexitySeries) ProtoMessage()               {}
+func (*ComplexitySeries) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{120} }
+
+func (m *ComplexitySeries) GetTicks() map[int32]*Complexity {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+type ComplexityFunction struct {
+	File string `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// tick -> complexity at the end of the tick
+	Ticks map[int32]*Complexity `protobuf:"bytes,3,rep,name=ticks" json:"ticks,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *ComplexityFunction) Reset()                    { *m = ComplexityFunction{} }
+func (m *ComplexityFunction) String() string            { return proto.CompactTextString(m) }
+func (*ComplexityFunction) ProtoMessage()               {}
+func (*ComplexityFunction) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{121} }
+
+func (m *ComplexityFunction) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *ComplexityFunction) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ComplexityFunction) GetTicks() map[int32]*Complexity {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+type ComplexityCommit struct {
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// day since the beginning of the history
+	Day   int32       `protobuf:"varint,2,opt,name=day,proto3" json:"day,omitempty"`
+	Delta *Complexity `protobuf:"bytes,3,opt,name=delta" json:"delta,omitempty"`
+}
+
+func (m *ComplexityCommit) Reset()                    { *m = ComplexityCommit{} }
+func (m *ComplexityCommit) String() string            { return proto.CompactTextString(m) }
+func (*ComplexityCommit) ProtoMessage()               {}
+func (*ComplexityCommit) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{122} }
+
+func (m *ComplexityCommit) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *ComplexityCommit) GetDay() int32 {
+	if m != nil {
+		return m.Day
+	}
+	return 0
+}
+
+func (m *ComplexityCommit) GetDelta() *Complexity {
+	if m != nil {
+		return m.Delta
+	}
+	return nil
+}
+
+type ComplexityAnalysisResults struct {
+	// tick size in days
+	Sampling int32 `protobuf:"varint,1,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	// tick -> total complexity
+	Project []*Complexity `protobuf:"bytes,2,rep,name=project" json:"project,omitempty"`
+	// file path -> complexity at the ticks when the file changed
+	Files     map[string]*ComplexitySeries `protobuf:"bytes,3,rep,name=files" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	Functions []*ComplexityFunction        `protobuf:"bytes,4,rep,name=functions" json:"functions,omitempty"`
+	// sorted by the cyclomatic complexity increase in descending order
+	Commits []*ComplexityCommit `protobuf:"bytes,5,rep,name=commits" json:"commits,omitempty"`
+}
+
+func (m *ComplexityAnalysisResults) Reset()                    { *m = ComplexityAnalysisResults{} }
+func (m *ComplexityAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*ComplexityAnalysisResults) ProtoMessage()               {}
+func (*ComplexityAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{123} }
+
+func (m *ComplexityAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *ComplexityAnalysisResults) GetProject() []*Complexity {
+	if m != nil {
+		return m.Project
+	}
+	return nil
+}
+
+func (m *ComplexityAnalysisResults) GetFiles() map[string]*ComplexitySeries {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *ComplexityAnalysisResults) GetFunctions() []*ComplexityFunction {
+	if m != nil {
+		return m.Functions
+	}
+	return nil
+}
+
+func (m *ComplexityAnalysisResults) GetCommits() []*ComplexityCommit {
+	if m != nil {
+		return m.Commits
+	}
+	return nil
+}
+
+type FunctionChurnDay struct {
+	Added   int32 `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	Removed int32 `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"`
+}
+
+func (m *FunctionChurnDay) Reset()                    { *m = FunctionChurnDay{} }
+func (m *FunctionChurnDay) String() string            { return proto.CompactTextString(m) }
+func (*FunctionChurnDay) ProtoMessage()               {}
+func (*FunctionChurnDay) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{124} }
+
+func (m *FunctionChurnDay) GetAdded() int32 {
+	if m != nil {
+		return m.Added
+	}
+	return 0
+}
+
+func (m *FunctionChurnDay) GetRemoved() int32 {
+	if m != nil {
+		return m.Removed
+	}
+	return 0
+}
+
+type FunctionChurn struct {
+	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	File         string `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	InternalRole string `protobuf:"bytes,3,opt,name=internal_role,json=internalRole,proto3" json:"internal_role,omitempty"`
+	// number of commits which changed the function
+	Commits int32 `protobuf:"varint,4,opt,name=commits,proto3" json:"commits,omitempty"`
+	// day since the beginning of the history -> churn
+	Days map[int32]*FunctionChurnDay `protobuf:"bytes,5,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *FunctionChurn) Reset()                    { *m = FunctionChurn{} }
+func (m *FunctionChurn) String() string            { return proto.CompactTextString(m) }
+func (*FunctionChurn) ProtoMessage()               {}
+func (*FunctionChurn) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{125} }
+
+func (m *FunctionChurn) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FunctionChurn) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *FunctionChurn) GetInternalRole() string {
+	if m != nil {
+		return m.InternalRole
+	}
+	return ""
+}
+
+func (m *FunctionChurn) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *FunctionChurn) GetDays() map[int32]*FunctionChurnDay {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+type FunctionChurnAnalysisResults struct {
+	// sorted by the total number of changed lines in descending order
+	Functions []*FunctionChurn `protobuf:"bytes,1,rep,name=functions" json:"functions,omitempty"`
+}
+
+func (m *FunctionChurnAnalysisResults) Reset()         { *m = FunctionChurnAnalysisResults{} }
+func (m *FunctionChurnAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*FunctionChurnAnalysisResults) ProtoMessage()    {}
+func (*FunctionChurnAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptorPb, []int{126}
+}
+
+func (m *FunctionChurnAnalysisResults) GetFunctions() []*FunctionChurn {
+	if m != nil {
+		return m.Functions
+	}
+	return nil
+}
+
+type HistoryRewrite struct {
+	// the rewritten reference, e.g. refs/heads/master
+	Ref string `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	// Unix time of the reflog entry, 0 for refs/original
+	Time int64 `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	// who moved the reference, index in `dev_index`; -1 means unmatched
+	Actor int32  `protobuf:"varint,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Old   string `protobuf:"bytes,4,opt,name=old,proto3" json:"old,omitempty"`
+	New   string `protobuf:"bytes,5,opt,name=new,proto3" json:"new,omitempty"`
+	// reflog message, e.g. "rebase finished" or "update by push"
+	Message string `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	// number of commits which are reachable from `old` and not from `new` or any live reference
+	Commits int32 `protobuf:"varint,7,opt,name=commits,proto3" json:"commits,omitempty"`
+	Added   int32 `protobuf:"varint,8,opt,name=added,proto3" json:"added,omitempty"`
+	Removed int32 `protobuf:"varint,9,opt,name=removed,proto3" json:"removed,omitempty"`
+	// developer index -> number of rewritten commits
+	Authors map[int32]int32 `protobuf:"bytes,10,rep,name=authors" json:"authors,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// the old commit was garbage collected and the rewritten work is unknown
+	Pruned bool `protobuf:"varint,11,opt,name=pruned,proto3" json:"pruned,omitempty"`
+}
+
+func (m *HistoryRewrite) Reset()                    { *m = HistoryRewrite{} }
+func (m *HistoryRewrite) String() string            { return proto.CompactTextString(m) }
+func (*HistoryRewrite) ProtoMessage()               {}
+func (*HistoryRewrite) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{127} }
+
+func (m *HistoryRewrite) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+func (m *HistoryRewrite) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *HistoryRewrite) GetActor() int32 {
+	if m != nil {
+		return m.Actor
+	}
+	return 0
+}
+
+func (m *HistoryRewrite) GetOld() string {
+	if m != nil {
+		return m.Old
+	}
+	return ""
+}
+
+func (m *HistoryRewrite) GetNew() string {
+	if m != nil {
+		return m.New
+	}
+	return ""
+}
+
+func (m *HistoryRewrite) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *HistoryRewrite) GetCommits() int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return 0
+}
+
+func (m *HistoryRewrite) GetAdded() int32 {
+	if m != nil {
+		return m.Added
+	}
+	return 0
+}
+
+func (m *HistoryRewrite) GetRemoved() int32 {
+	if m != nil {
+		return m.Removed
+	}
+	return 0
+}
+
+func (m *HistoryRewrite) GetAuthors() map[int32]int32 {
+	if m != nil {
+		return m.Authors
+	}
+	return nil
+}
+
+func (m *HistoryRewrite) GetPruned() bool {
+	if m != nil {
+		return m.Pruned
+	}
+	return false
+}
+
+type HistoryRewritesAnalysisResults struct {
+	Rewrites []*HistoryRewrite `protobuf:"bytes,1,rep,name=rewrites" json:"rewrites,omitempty"`
+	DevIndex []string          `protobuf:"bytes,2,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *HistoryRewritesAnalysisResults) Reset()         { *m = HistoryRewritesAnalysisResults{} }
+func (m *HistoryRewritesAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*HistoryRewritesAnalysisResults) ProtoMessage()    {}
+func (*HistoryRewritesAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptorPb, []int{128}
+}
+
+func (m *HistoryRewritesAnalysisResults) GetRewrites() []*HistoryRewrite {
+	if m != nil {
+		return m.Rewrites
+	}
+	return nil
+}
+
+func (m *HistoryRewritesAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type ChangeEntropyDay struct {
+	// number of file changes
+	Changes int32 `protobuf:"varint,1,opt,name=changes,proto3" json:"changes,omitempty"`
+	// number of distinct changed files and their directories
+	Files       int32 `protobuf:"varint,2,opt,name=files,proto3" json:"files,omitempty"`
+	Directories int32 `protobuf:"varint,3,opt,name=directories,proto3" json:"directories,omitempty"`
+	// Shannon entropy in bits of the changes across the files and the directories
+	FileEntropy      float64 `protobuf:"fixed64,4,opt,name=file_entropy,json=fileEntropy,proto3" json:"file_entropy,omitempty"`
+	DirectoryEntropy float64 `protobuf:"fixed64,5,opt,name=directory_entropy,json=directoryEntropy,proto3" json:"directory_entropy,omitempty"`
+}
+
+func (m *ChangeEntropyDay) Reset()                    { *m = ChangeEntropyDay{} }
+func (m *ChangeEntropyDay) String() string            { return proto.CompactTextString(m) }
+func (*ChangeEntropyDay) ProtoMessage()               {}
+func (*ChangeEntropyDay) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{129} }
+
+func (m *ChangeEntropyDay) GetChanges() int32 {
+	if m != nil {
+		return m.Changes
+	}
+	return 0
+}
+
+func (m *ChangeEntropyDay) GetFiles() int32 {
+	if m != nil {
+		return m.Files
+	}
+	return 0
+}
+
+func (m *ChangeEntropyDay) GetDirectories() int32 {
+	if m != nil {
+		return m.Directories
+	}
+	return 0
+}
+
+func (m *ChangeEntropyDay) GetFileEntropy() float64 {
+	if m != nil {
+		return m.FileEntropy
+	}
+	return 0
+}
+
+func (m *ChangeEntropyDay) GetDirectoryEntropy() float64 {
+	if m != nil {
+		return m.DirectoryEntropy
+	}
+	return 0
+}
+
+type ChangeEntropyCommit struct {
+	Hash        string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Day         int32  `protobuf:"varint,2,opt,name=day,proto3" json:"day,omitempty"`
+	Files       int32  `protobuf:"varint,3,opt,name=files,proto3" json:"files,omitempty"`
+	Directories int32  `protobuf:"varint,4,opt,name=directories,proto3" json:"directories,omitempty"`
+	// Shannon entropy in bits of the changes across the files and the directories
+	FileEntropy      float64 `protobuf:"fixed64,5,opt,name=file_entropy,json=fileEntropy,proto3" json:"file_entropy,omitempty"`
+	DirectoryEntropy float64 `protobuf:"fixed64,6,opt,name=directory_entropy,json=directoryEntropy,proto3" json:"directory_entropy,omitempty"`
+}
+
+func (m *ChangeEntropyCommit) Reset()                    { *m = ChangeEntropyCommit{} }
+func (m *ChangeEntropyCommit) String() string            { return proto.CompactTextString(m) }
+func (*ChangeEntropyCommit) ProtoMessage()               {}
+func (*ChangeEntropyCommit) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{130} }
+
+func (m *ChangeEntropyCommit) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *ChangeEntropyCommit) GetDay() int32 {
+	if m != nil {
+		return m.Day
+	}
+	return 0
+}
+
+func (m *ChangeEntropyCommit) GetFiles() int32 {
+	if m != nil {
+		return m.Files
+	}
+	return 0
+}
+
+func (m *ChangeEntropyCommit) GetDirectories() int32 {
+	if m != nil {
+		return m.Directories
+	}
+	return 0
+}
+
+func (m *ChangeEntropyCommit) GetFileEntropy() float64 {
+	if m != nil {
+		return m.FileEntropy
+	}
+	return 0
+}
+
+func (m *ChangeEntropyCommit) GetDirectoryEntropy() float64 {
+	if m != nil {
+		return m.DirectoryEntropy
+	}
+	return 0
+}
+
+type ChangeEntropyAnalysisResults struct {
+	// day since the beginning of the history -> scatter of the changes
+	Days map[int32]*ChangeEntropyDay `protobuf:"bytes,1,rep,name=days" json:"days,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	// scatter of the changes in each tick of `sampling` days
+	Ticks []*ChangeEntropyDay `protobuf:"bytes,2,rep,name=ticks" json:"ticks,omitempty"`
+	// sorted by day
+	Commits  []*ChangeEntropyCommit `protobuf:"bytes,3,rep,name=commits" json:"commits,omitempty"`
+	Sampling int32                  `protobuf:"varint,4,opt,name=sampling,proto3" json:"sampling,omitempty"`
+}
+
+func (m *ChangeEntropyAnalysisResults) Reset()         { *m = ChangeEntropyAnalysisResults{} }
+func (m *ChangeEntropyAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*ChangeEntropyAnalysisResults) ProtoMessage()    {}
+func (*ChangeEntropyAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptorPb, []int{131}
+}
+
+func (m *ChangeEntropyAnalysisResults) GetDays() map[int32]*ChangeEntropyDay {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+func (m *ChangeEntropyAnalysisResults) GetTicks() []*ChangeEntropyDay {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *ChangeEntropyAnalysisResults) GetCommits() []*ChangeEntropyCommit {
+	if m != nil {
+		return m.Commits
+	}
+	return nil
+}
+
+func (m *ChangeEntropyAnalysisResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+type ExpertiseVector struct {
+	// order corresponds to `languages` or `directories`, sums to 1 unless the developer changed nothing
+	Shares []float64 `protobuf:"fixed64,1,rep,packed,name=shares" json:"shares,omitempty"`
+}
+
+func (m *ExpertiseVector) Reset()                    { *m = ExpertiseVector{} }
+func (m *ExpertiseVector) String() string            { return proto.CompactTextString(m) }
+func (*ExpertiseVector) ProtoMessage()               {}
+func (*ExpertiseVector) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{132} }
+
+func (m *ExpertiseVector) GetShares() []float64 {
+	if m != nil {
+		return m.Shares
+	}
+	return nil
+}
+
+type ExpertiseAnalysisResults struct {
+	// number of days after which the weight of a change halves, 0 means no recency weighting
+	HalfLife  int32    `protobuf:"varint,1,opt,name=half_life,json=halfLife,proto3" json:"half_life,omitempty"`
+	Languages []string `protobuf:"bytes,2,rep,name=languages" json:"languages,omitempty"`
+	// top-level directories, "." is the root
+	Directories []string `protobuf:"bytes,3,rep,name=directories" json:"directories,omitempty"`
+	// the following three correspond to `dev_index`, the last element is the unmatched identities
+	PeopleLanguages   []*ExpertiseVector `protobuf:"bytes,4,rep,name=people_languages,json=peopleLanguages" json:"people_languages,omitempty"`
+	PeopleDirectories []*ExpertiseVector `protobuf:"bytes,5,rep,name=people_directories,json=peopleDirectories" json:"people_directories,omitempty"`
+	// weighted number of changed lines
+	PeopleWeights []float64 `protobuf:"fixed64,6,rep,packed,name=people_weights,json=peopleWeights" json:"people_weights,omitempty"`
+	DevIndex      []string  `protobuf:"bytes,7,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *ExpertiseAnalysisResults) Reset()                    { *m = ExpertiseAnalysisResults{} }
+func (m *ExpertiseAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*ExpertiseAnalysisResults) ProtoMessage()               {}
+func (*ExpertiseAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{133} }
+
+func (m *ExpertiseAnalysisResults) GetHalfLife() int32 {
+	if m != nil {
+		return m.HalfLife
+	}
+	return 0
+}
+
+func (m *ExpertiseAnalysisResults) GetLanguages() []string {
+	if m != nil {
+		return m.Languages
+	}
+	return nil
+}
+
+func (m *ExpertiseAnalysisResults) GetDirectories() []string {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+func (m *ExpertiseAnalysisResults) GetPeopleLanguages() []*ExpertiseVector {
+	if m != nil {
+		return m.PeopleLanguages
+	}
+	return nil
+}
+
+func (m *ExpertiseAnalysisResults) GetPeopleDirectories() []*ExpertiseVector {
+	if m != nil {
+		return m.PeopleDirectories
+	}
+	return nil
+}
+
+func (m *ExpertiseAnalysisResults) GetPeopleWeights() []float64 {
+	if m != nil {
+		return m.PeopleWeights
+	}
+	return nil
+}
+
+func (m *ExpertiseAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type OwnershipAnalysisResults struct {
+	// "." is the root
+	Directories []string `protobuf:"bytes,1,rep,name=directories" json:"directories,omitempty"`
+	// rows correspond to `directories`, columns to `dev_index` and the last one to the unmatched identities
+	DirectoryOwners *CompressedSparseRowMatrix `protobuf:"bytes,2,opt,name=directory_owners,json=directoryOwners" json:"directory_owners,omitempty"`
+	// this is included if `--ownership-files` was specified
+	Files []string `protobuf:"bytes,3,rep,name=files" json:"files,omitempty"`
+	// rows correspond to `files`, columns are the same as in `directory_owners`
+	FileOwners *CompressedSparseRowMatrix `protobuf:"bytes,4,opt,name=file_owners,json=fileOwners" json:"file_owners,omitempty"`
+	DevIndex   []string                   `protobuf:"bytes,5,rep,name=dev_index,json=devIndex" json:"dev_index,omitempty"`
+}
+
+func (m *OwnershipAnalysisResults) Reset()                    { *m = OwnershipAnalysisResults{} }
+func (m *OwnershipAnalysisResults) String() string            { return proto.CompactTextString(m) }
+func (*OwnershipAnalysisResults) ProtoMessage()               {}
+func (*OwnershipAnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{134} }
+
+func (m *OwnershipAnalysisResults) GetDirectories() []string {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+func (m *OwnershipAnalysisResults) GetDirectoryOwners() *CompressedSparseRowMatrix {
+	if m != nil {
+		return m.DirectoryOwners
+	}
+	return nil
+}
+
+func (m *OwnershipAnalysisResults) GetFiles() []string {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *OwnershipAnalysisResults) GetFileOwners() *CompressedSparseRowMatrix {
+	if m != nil {
+		return m.FileOwners
+	}
+	return nil
+}
+
+func (m *OwnershipAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
 type AnalysisResults struct {
 	Header *Metadata `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	// the mapped values are dynamic messages which require the second parsing pass.
@@ -585,7 +5516,7 @@ type AnalysisResults struct {
 func (m *AnalysisResults) Reset()                    { *m = AnalysisResults{} }
 func (m *AnalysisResults) String() string            { return proto.CompactTextString(m) }
 func (*AnalysisResults) ProtoMessage()               {}
-func (*AnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{16} }
+func (*AnalysisResults) Descriptor() ([]byte, []int) { return fileDescriptorPb, []int{135} }
 
 func (m *AnalysisResults) GetHeader() *Metadata {
 	if m != nil {
@@ -603,12 +5534,20 @@ func (m *AnalysisResults) GetContents() map[string][]byte {
 
 func init() {
 	proto.RegisterType((*Metadata)(nil), "Metadata")
+	proto.RegisterType((*UnparsedFile)(nil), "UnparsedFile")
+	proto.RegisterType((*Annotation)(nil), "Annotation")
+	proto.RegisterType((*ItemProfile)(nil), "ItemProfile")
 	proto.RegisterType((*BurndownSparseMatrixRow)(nil), "BurndownSparseMatrixRow")
 	proto.RegisterType((*BurndownSparseMatrix)(nil), "BurndownSparseMatrix")
+	proto.RegisterType((*BurndownCohort)(nil), "BurndownCohort")
+	proto.RegisterType((*BurndownSurvival)(nil), "BurndownSurvival")
 	proto.RegisterType((*BurndownAnalysisResults)(nil), "BurndownAnalysisResults")
+	proto.RegisterType((*FileSnapshot)(nil), "FileSnapshot")
 	proto.RegisterType((*CompressedSparseRowMatrix)(nil), "CompressedSparseRowMatrix")
 	proto.RegisterType((*Couples)(nil), "Couples")
 	proto.RegisterType((*TouchedFiles)(nil), "TouchedFiles")
+	proto.RegisterType((*CouplesSignificance)(nil), "CouplesSignificance")
+	proto.RegisterType((*CouplesDecay)(nil), "CouplesDecay")
 	proto.RegisterType((*CouplesAnalysisResults)(nil), "CouplesAnalysisResults")
 	proto.RegisterType((*UASTChange)(nil), "UASTChange")
 	proto.RegisterType((*UASTChangesSaverResults)(nil), "UASTChangesSaverResults")
@@ -618,80 +5557,538 @@ func init() {
 	proto.RegisterType((*FileHistoryResultMessage)(nil), "FileHistoryResultMessage")
 	proto.RegisterType((*Sentiment)(nil), "Sentiment")
 	proto.RegisterType((*CommentSentimentResults)(nil), "CommentSentimentResults")
+	proto.RegisterType((*RecordedColumn)(nil), "RecordedColumn")
+	proto.RegisterType((*RecordedStream)(nil), "RecordedStream")
+	proto.RegisterType((*RecorderResults)(nil), "RecorderResults")
+	proto.RegisterType((*ActivityDay)(nil), "ActivityDay")
+	proto.RegisterType((*ActiveDevelopers)(nil), "ActiveDevelopers")
+	proto.RegisterType((*ActivityAnalysisResults)(nil), "ActivityAnalysisResults")
+	proto.RegisterType((*LanguageCounts)(nil), "LanguageCounts")
+	proto.RegisterType((*CommitLanguagesAnalysisResults)(nil), "CommitLanguagesAnalysisResults")
+	proto.RegisterType((*ImpactChurnDay)(nil), "ImpactChurnDay")
+	proto.RegisterType((*ImpactChurnFile)(nil), "ImpactChurnFile")
+	proto.RegisterType((*ImpactChurnAnalysisResults)(nil), "ImpactChurnAnalysisResults")
+	proto.RegisterType((*DAGShapeTick)(nil), "DAGShapeTick")
+	proto.RegisterType((*DAGShapeAnalysisResults)(nil), "DAGShapeAnalysisResults")
+	proto.RegisterType((*RenameChain)(nil), "RenameChain")
+	proto.RegisterType((*RenameFrequencyAnalysisResults)(nil), "RenameFrequencyAnalysisResults")
+	proto.RegisterType((*RewriteDepthFile)(nil), "RewriteDepthFile")
+	proto.RegisterType((*RewriteHotspot)(nil), "RewriteHotspot")
+	proto.RegisterType((*RewriteDepthAnalysisResults)(nil), "RewriteDepthAnalysisResults")
+	proto.RegisterType((*SensitivePathChange)(nil), "SensitivePathChange")
+	proto.RegisterType((*SensitivePathsAnalysisResults)(nil), "SensitivePathsAnalysisResults")
+	proto.RegisterType((*ContributorsTick)(nil), "ContributorsTick")
+	proto.RegisterType((*ContributorsAnalysisResults)(nil), "ContributorsAnalysisResults")
+	proto.RegisterType((*StewardshipCounts)(nil), "StewardshipCounts")
+	proto.RegisterType((*StewardshipTick)(nil), "StewardshipTick")
+	proto.RegisterType((*StewardshipAnalysisResults)(nil), "StewardshipAnalysisResults")
+	proto.RegisterType((*TeamAlignmentDirectory)(nil), "TeamAlignmentDirectory")
+	proto.RegisterType((*TeamAlignmentTick)(nil), "TeamAlignmentTick")
+	proto.RegisterType((*TeamAlignmentAnalysisResults)(nil), "TeamAlignmentAnalysisResults")
+	proto.RegisterType((*DefectFeaturesAnalysisResults)(nil), "DefectFeaturesAnalysisResults")
+	proto.RegisterType((*CocomoTick)(nil), "CocomoTick")
+	proto.RegisterType((*CocomoAnalysisResults)(nil), "CocomoAnalysisResults")
+	proto.RegisterType((*ReviewLatencyStats)(nil), "ReviewLatencyStats")
+	proto.RegisterType((*ReviewLatencyAnalysisResults)(nil), "ReviewLatencyAnalysisResults")
+	proto.RegisterType((*IssueTicket)(nil), "IssueTicket")
+	proto.RegisterType((*IssueReferencesAnalysisResults)(nil), "IssueReferencesAnalysisResults")
+	proto.RegisterType((*ConventionalCommitsStats)(nil), "ConventionalCommitsStats")
+	proto.RegisterType((*ConventionalCommitsAnalysisResults)(nil), "ConventionalCommitsAnalysisResults")
+	proto.RegisterType((*LanguageLines)(nil), "LanguageLines")
+	proto.RegisterType((*LanguageLinesAnalysisResults)(nil), "LanguageLinesAnalysisResults")
+	proto.RegisterType((*RepositorySizeTick)(nil), "RepositorySizeTick")
+	proto.RegisterType((*RepositoryBlob)(nil), "RepositoryBlob")
+	proto.RegisterType((*RepositorySizeAnalysisResults)(nil), "RepositorySizeAnalysisResults")
+	proto.RegisterType((*FileAge)(nil), "FileAge")
+	proto.RegisterType((*FileAgeAnalysisResults)(nil), "FileAgeAnalysisResults")
+	proto.RegisterType((*RefactoringStats)(nil), "RefactoringStats")
+	proto.RegisterType((*RefactoringAnalysisResults)(nil), "RefactoringAnalysisResults")
+	proto.RegisterType((*RevertsTick)(nil), "RevertsTick")
+	proto.RegisterType((*RevertedCommit)(nil), "RevertedCommit")
+	proto.RegisterType((*RevertsAnalysisResults)(nil), "RevertsAnalysisResults")
+	proto.RegisterType((*BranchMerge)(nil), "BranchMerge")
+	proto.RegisterType((*BranchLifetimeAnalysisResults)(nil), "BranchLifetimeAnalysisResults")
+	proto.RegisterType((*Release)(nil), "Release")
+	proto.RegisterType((*ReleaseCadenceAnalysisResults)(nil), "ReleaseCadenceAnalysisResults")
+	proto.RegisterType((*GiniTick)(nil), "GiniTick")
+	proto.RegisterType((*GiniAnalysisResults)(nil), "GiniAnalysisResults")
+	proto.RegisterType((*OnboardingDeveloper)(nil), "OnboardingDeveloper")
+	proto.RegisterType((*OnboardingDirectory)(nil), "OnboardingDirectory")
+	proto.RegisterType((*OnboardingCohort)(nil), "OnboardingCohort")
+	proto.RegisterType((*OnboardingAnalysisResults)(nil), "OnboardingAnalysisResults")
+	proto.RegisterType((*TenureTick)(nil), "TenureTick")
+	proto.RegisterType((*TenureSpan)(nil), "TenureSpan")
+	proto.RegisterType((*TenureDeveloper)(nil), "TenureDeveloper")
+	proto.RegisterType((*TenureAnalysisResults)(nil), "TenureAnalysisResults")
+	proto.RegisterType((*WorkPattern)(nil), "WorkPattern")
+	proto.RegisterType((*WorkPatternsStreak)(nil), "WorkPatternsStreak")
+	proto.RegisterType((*WorkPatternsAnalysisResults)(nil), "WorkPatternsAnalysisResults")
+	proto.RegisterType((*KnowledgeMapVector)(nil), "KnowledgeMapVector")
+	proto.RegisterType((*KnowledgeMapAnalysisResults)(nil), "KnowledgeMapAnalysisResults")
+	proto.RegisterType((*DeadCodeTick)(nil), "DeadCodeTick")
+	proto.RegisterType((*DeadCodeOrphan)(nil), "DeadCodeOrphan")
+	proto.RegisterType((*DeadCodeAnalysisResults)(nil), "DeadCodeAnalysisResults")
+	proto.RegisterType((*CommitSentiment)(nil), "CommitSentiment")
+	proto.RegisterType((*CommitSentimentAnalysisResults)(nil), "CommitSentimentAnalysisResults")
+	proto.RegisterType((*Hotspot)(nil), "Hotspot")
+	proto.RegisterType((*HotspotsAnalysisResults)(nil), "HotspotsAnalysisResults")
+	proto.RegisterType((*TestRatioTick)(nil), "TestRatioTick")
+	proto.RegisterType((*TestRatioAnalysisResults)(nil), "TestRatioAnalysisResults")
+	proto.RegisterType((*VocabularyTick)(nil), "VocabularyTick")
+	proto.RegisterType((*VocabularyTerms)(nil), "VocabularyTerms")
+	proto.RegisterType((*VocabularyAnalysisResults)(nil), "VocabularyAnalysisResults")
+	proto.RegisterType((*ImportGraphTick)(nil), "ImportGraphTick")
+	proto.RegisterType((*ImportGraphEdge)(nil), "ImportGraphEdge")
+	proto.RegisterType((*ImportGraphAnalysisResults)(nil), "ImportGraphAnalysisResults")
+	proto.RegisterType((*APISurfaceTick)(nil), "APISurfaceTick")
+	proto.RegisterType((*APIBreakingChange)(nil), "APIBreakingChange")
+	proto.RegisterType((*APISurfaceAnalysisResults)(nil), "APISurfaceAnalysisResults")
+	proto.RegisterType((*ClonesTick)(nil), "ClonesTick")
+	proto.RegisterType((*ClonesCommit)(nil), "ClonesCommit")
+	proto.RegisterType((*ClonesAnalysisResults)(nil), "ClonesAnalysisResults")
+	proto.RegisterType((*TechDebtTick)(nil), "TechDebtTick")
+	proto.RegisterType((*TechDebtMarker)(nil), "TechDebtMarker")
+	proto.RegisterType((*TechDebtAnalysisResults)(nil), "TechDebtAnalysisResults")
+	proto.RegisterType((*CommentDensity)(nil), "CommentDensity")
+	proto.RegisterType((*CommentDensitySeries)(nil), "CommentDensitySeries")
+	proto.RegisterType((*CommentDensityAnalysisResults)(nil), "CommentDensityAnalysisResults")
+	proto.RegisterType((*Complexity)(nil), "Complexity")
+	proto.RegisterType((*ComplexitySeries)(nil), "ComplexitySeries")
+	proto.RegisterType((*ComplexityFunction)(nil), "ComplexityFunction")
+	proto.RegisterType((*ComplexityCommit)(nil), "ComplexityCommit")
+	proto.RegisterType((*ComplexityAnalysisResults)(nil), "ComplexityAnalysisResults")
+	proto.RegisterType((*FunctionChurnDay)(nil), "FunctionChurnDay")
+	proto.RegisterType((*FunctionChurn)(nil), "FunctionChurn")
+	proto.RegisterType((*FunctionChurnAnalysisResults)(nil), "FunctionChurnAnalysisResults")
+	proto.RegisterType((*HistoryRewrite)(nil), "HistoryRewrite")
+	proto.RegisterType((*HistoryRewritesAnalysisResults)(nil), "HistoryRewritesAnalysisResults")
+	proto.RegisterType((*ChangeEntropyDay)(nil), "ChangeEntropyDay")
+	proto.RegisterType((*ChangeEntropyCommit)(nil), "ChangeEntropyCommit")
+	proto.RegisterType((*ChangeEntropyAnalysisResults)(nil), "ChangeEntropyAnalysisResults")
+	proto.RegisterType((*ExpertiseVector)(nil), "ExpertiseVector")
+	proto.RegisterType((*ExpertiseAnalysisResults)(nil), "ExpertiseAnalysisResults")
+	proto.RegisterType((*OwnershipAnalysisResults)(nil), "OwnershipAnalysisResults")
 	proto.RegisterType((*AnalysisResults)(nil), "AnalysisResults")
 }
 
 func init() { proto.RegisterFile("pb.proto", fileDescriptorPb) }
 
 var fileDescriptorPb = []byte{
-	// 1098 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xcd, 0x92, 0xdb, 0xc4,
-	0x13, 0x2f, 0x59, 0xfe, 0x6c, 0xd9, 0xbb, 0xc9, 0x24, 0xff, 0xac, 0xe2, 0x7f, 0x6d, 0x30, 0x22,
-	0x80, 0x21, 0x41, 0xa1, 0x9c, 0x0b, 0x84, 0x0b, 0x1b, 0x87, 0x54, 0x72, 0x58, 0xa0, 0xc6, 0x1b,
-	0x38, 0xaa, 0x64, 0x69, 0x76, 0x2d, 0xb0, 0x66, 0x54, 0x33, 0xd2, 0xee, 0xfa, 0x65, 0xb8, 0x51,
-	0x45, 0x51, 0x45, 0x71, 0xe0, 0x05, 0x78, 0x1a, 0x2e, 0xbc, 0x04, 0x35, 0x5f, 0xb6, 0xec, 0xf2,
-	0x06, 0x6e, 0xea, 0xee, 0x5f, 0xf7, 0x74, 0xff, 0xba, 0xa7, 0x47, 0xd0, 0x2d, 0xe6, 0x61, 0xc1,
-	0x59, 0xc9, 0x82, 0xbf, 0x1a, 0xd0, 0x3d, 0x25, 0x65, 0x9c, 0xc6, 0x65, 0x8c, 0x7c, 0xe8, 0x5c,
-	0x12, 0x2e, 0x32, 0x46, 0x7d, 0x67, 0xe4, 0x8c, 0x5b, 0xd8, 0x8a, 0x08, 0x41, 0x73, 0x11, 0x8b,
-	0x85, 0xdf, 0x18, 0x39, 0xe3, 0x1e, 0x56, 0xdf, 0xe8, 0x01, 0x00, 0x27, 0x05, 0x13, 0x59, 0xc9,
-	0xf8, 0xca, 0x77, 0x95, 0xa5, 0xa6, 0x41, 0x1f, 0xc0, 0xe1, 0x9c, 0x5c, 0x64, 0x34, 0xaa, 0x68,
-	0x76, 0x1d, 0x95, 0x59, 0x4e, 0xfc, 0xe6, 0xc8, 0x19, 0xbb, 0x78, 0xa0, 0xd4, 0x6f, 0x68, 0x76,
-	0x7d, 0x96, 0xe5, 0x04, 0x05, 0x30, 0x20, 0x34, 0xad, 0xa1, 0x5a, 0x0a, 0xe5, 0x11, 0x9a, 0xae,
-	0x31, 0x3e, 0x74, 0x12, 0x96, 0xe7, 0x59, 0x29, 0xfc, 0xb6, 0xce, 0xcc, 0x88, 0xe8, 0x3e, 0x74,
-	0x79, 0x45, 0xb5, 0x63, 0x47, 0x39, 0x76, 0x78, 0x45, 0x95, 0xd3, 0x2b, 0xb8, 0x6d, 0x4d, 0x51,
-	0x41, 0x78, 0x94, 0x95, 0x24, 0xf7, 0xbb, 0x23, 0x77, 0xec, 0x4d, 0x8e, 0x43, 0x5b, 0x74, 0x88,
-	0x35, 0xfa, 0x5b, 0xc2, 0x5f, 0x97, 0x24, 0xff, 0x8a, 0x96, 0x7c, 0x85, 0x0f, 0xf8, 0x96, 0x72,
-	0x78, 0x02, 0x77, 0xf6, 0xc0, 0xd0, 0x2d, 0x70, 0x7f, 0x24, 0x2b, 0xc5, 0x55, 0x0f, 0xcb, 0x4f,
-	0x74, 0x17, 0x5a, 0x97, 0xf1, 0xb2, 0x22, 0x8a, 0x28, 0x07, 0x6b, 0xe1, 0x59, 0xe3, 0x33, 0x27,
-	0x78, 0x0a, 0x47, 0xcf, 0x2b, 0x4e, 0x53, 0x76, 0x45, 0x67, 0x45, 0xcc, 0x05, 0x39, 0x8d, 0x4b,
-	0x9e, 0x5d, 0x63, 0x76, 0xa5, 0x8b, 0x5b, 0x56, 0x39, 0x15, 0xbe, 0x33, 0x72, 0xc7, 0x03, 0x6c,
-	0xc5, 0xe0, 0x57, 0x07, 0xee, 0xee, 0xf3, 0x92, 0xfd, 0xa0, 0x71, 0x4e, 0xcc, 0xd1, 0xea, 0x1b,
-	0x3d, 0x84, 0x03, 0x5a, 0xe5, 0x73, 0xc2, 0x23, 0x76, 0x1e, 0x71, 0x76, 0x25, 0x54, 0x12, 0x2d,
-	0xdc, 0xd7, 0xda, 0x6f, 0xce, 0x31, 0xbb, 0x12, 0xe8, 0x63, 0xb8, 0xbd, 0x41, 0xd9, 0x63, 0x5d,
-	0x05, 0x3c, 0xb4, 0xc0, 0xa9, 0x56, 0xa3, 0xc7, 0xd0, 0x54, 0x71, 0x9a, 0x8a, 0x33, 0x3f, 0xbc,
-	0xa1, 0x00, 0xac, 0x50, 0xc1, 0xef, 0x8d, 0x4d, 0x89, 0x27, 0x34, 0x5e, 0xae, 0x44, 0x26, 0x30,
-	0x11, 0xd5, 0xb2, 0x14, 0x68, 0x04, 0xde, 0x05, 0x8f, 0x69, 0xb5, 0x8c, 0x79, 0x56, 0xae, 0xcc,
-	0x74, 0xd5, 0x55, 0x68, 0x08, 0x5d, 0x11, 0xe7, 0xc5, 0x32, 0xa3, 0x17, 0x26, 0xef, 0xb5, 0x8c,
-	0x9e, 0x40, 0xa7, 0xe0, 0xec, 0x07, 0x92, 0x94, 0x2a, 0x53, 0x6f, 0xf2, 0xbf, 0xfd, 0xa9, 0x58,
-	0x14, 0x7a, 0x04, 0xad, 0xf3, 0x6c, 0x49, 0x6c, 0xe6, 0x37, 0xc0, 0x35, 0x06, 0x7d, 0x02, 0xed,
-	0x82, 0xb0, 0x62, 0x29, 0x07, 0xef, 0x2d, 0x68, 0x03, 0x42, 0xaf, 0x01, 0xe9, 0xaf, 0x28, 0xa3,
-	0x25, 0xe1, 0x71, 0x52, 0xca, 0xfb, 0xd2, 0x56, 0x79, 0x0d, 0xc3, 0x29, 0xcb, 0x0b, 0x4e, 0x84,
-	0x20, 0xa9, 0x76, 0xc6, 0xec, 0xca, 0xf8, 0xdf, 0xd6, 0x5e, 0xaf, 0x37, 0x4e, 0xc1, 0x1f, 0x0e,
-	0xdc, 0xbf, 0xd1, 0x61, 0x4f, 0x3f, 0x9d, 0xff, 0xda, 0xcf, 0xc6, 0xfe, 0x7e, 0x22, 0x68, 0xca,
-	0x91, 0xf7, 0xdd, 0x91, 0x3b, 0x76, 0x71, 0xd3, 0xde, 0xf9, 0x8c, 0xa6, 0x59, 0x62, 0xc8, 0x6a,
-	0x61, 0x2b, 0xa2, 0x7b, 0xd0, 0xce, 0x68, 0x5a, 0x94, 0x5c, 0xf1, 0xe2, 0x62, 0x23, 0x05, 0x33,
-	0xe8, 0x4c, 0x59, 0x55, 0x48, 0xea, 0xee, 0x42, 0x2b, 0xa3, 0x29, 0xb9, 0x56, 0x73, 0xdb, 0xc3,
-	0x5a, 0x40, 0x13, 0x68, 0xe7, 0xaa, 0x04, 0x95, 0xc7, 0xdb, 0x59, 0x31, 0xc8, 0xe0, 0x21, 0xf4,
-	0xcf, 0x58, 0x95, 0x2c, 0x48, 0xfa, 0x32, 0x33, 0x91, 0x75, 0x07, 0x1d, 0x95, 0x94, 0x16, 0x82,
-	0x5f, 0x1c, 0xb8, 0x67, 0xce, 0xde, 0x9d, 0xb0, 0x47, 0xd0, 0x97, 0x98, 0x28, 0xd1, 0x66, 0xd3,
-	0x90, 0x6e, 0x68, 0xe0, 0xd8, 0x93, 0x56, 0x9b, 0xf7, 0x13, 0x38, 0x30, 0x3d, 0xb4, 0xf0, 0xce,
-	0x0e, 0x7c, 0xa0, 0xed, 0xd6, 0xe1, 0x53, 0xe8, 0x1b, 0x07, 0x9d, 0x95, 0xde, 0x22, 0x83, 0xb0,
-	0x9e, 0x33, 0xf6, 0x34, 0x44, 0x09, 0xc1, 0xcf, 0x0e, 0xc0, 0x9b, 0x93, 0xd9, 0xd9, 0x74, 0x11,
-	0xd3, 0x0b, 0x82, 0xfe, 0x0f, 0x3d, 0x95, 0x5e, 0xed, 0xd6, 0x76, 0xa5, 0xe2, 0x6b, 0x79, 0x73,
-	0x8f, 0x01, 0x04, 0x4f, 0xa2, 0x39, 0x39, 0x67, 0x9c, 0x98, 0x1d, 0xdb, 0x13, 0x3c, 0x79, 0xae,
-	0x14, 0xd2, 0x57, 0x9a, 0xe3, 0xf3, 0x92, 0x70, 0xb3, 0x67, 0xbb, 0x82, 0x27, 0x27, 0x52, 0x46,
-	0xef, 0x80, 0x57, 0xc5, 0xa2, 0xb4, 0xce, 0x4d, 0xbd, 0x86, 0xa5, 0xca, 0x78, 0x1f, 0x83, 0x92,
-	0x8c, 0x7b, 0x4b, 0x07, 0x97, 0x1a, 0xe5, 0x1f, 0x7c, 0x09, 0x47, 0x9b, 0x34, 0xc5, 0x2c, 0xbe,
-	0x24, 0xdc, 0x52, 0xfa, 0x3e, 0x74, 0x12, 0xad, 0x56, 0x5d, 0xf0, 0x26, 0x5e, 0xb8, 0x81, 0x62,
-	0x6b, 0x0b, 0xfe, 0x76, 0xe0, 0x60, 0xb6, 0x60, 0x25, 0x25, 0x42, 0x60, 0x92, 0x30, 0x9e, 0xa2,
-	0xf7, 0x60, 0xa0, 0x2e, 0x07, 0x8d, 0x97, 0x11, 0x67, 0x4b, 0x5b, 0x71, 0xdf, 0x2a, 0x31, 0x5b,
-	0x12, 0xd9, 0x62, 0x69, 0x93, 0xd3, 0xaa, 0x5a, 0xac, 0x84, 0xf5, 0x66, 0x73, 0x6b, 0x9b, 0x0d,
-	0x41, 0x53, 0x72, 0x65, 0x8a, 0x53, 0xdf, 0xe8, 0x73, 0xe8, 0x26, 0xac, 0x92, 0xf1, 0x84, 0xb9,
-	0xb7, 0xc7, 0xe1, 0x76, 0x16, 0xb2, 0x97, 0xca, 0xae, 0x77, 0xfa, 0x1a, 0x3e, 0xfc, 0x02, 0x06,
-	0x5b, 0xa6, 0xfa, 0x1e, 0x6f, 0xed, 0xd9, 0xe3, 0xad, 0xfa, 0x1e, 0x7f, 0x01, 0x47, 0xf6, 0x98,
-	0xdd, 0x11, 0xfc, 0x08, 0x3a, 0x5c, 0x9d, 0x6c, 0xf9, 0x3a, 0xdc, 0xc9, 0x08, 0x5b, 0x7b, 0xf0,
-	0x21, 0x78, 0x72, 0x4c, 0x5e, 0x65, 0x42, 0x3d, 0x95, 0xb5, 0xe7, 0x4d, 0xdf, 0x24, 0x2b, 0x06,
-	0x3f, 0x39, 0xe0, 0xd7, 0x90, 0xfa, 0xa8, 0x53, 0x22, 0x44, 0x7c, 0x41, 0xd0, 0xb3, 0xfa, 0x25,
-	0xf1, 0x26, 0x0f, 0xc3, 0x9b, 0x90, 0xca, 0x60, 0x78, 0xd0, 0x2e, 0xc3, 0x97, 0x00, 0x1b, 0xe5,
-	0x9e, 0x97, 0x2c, 0xa8, 0x33, 0xe0, 0x4d, 0xfa, 0x5b, 0xb1, 0x6b, 0x7c, 0x7c, 0x0f, 0xbd, 0x19,
-	0xa1, 0xf2, 0x8d, 0xa5, 0xe5, 0x86, 0x36, 0x19, 0xa8, 0x61, 0x60, 0x72, 0xb5, 0xcb, 0x72, 0x08,
-	0x2d, 0x75, 0xaf, 0x7b, 0x78, 0x2d, 0xd7, 0x2b, 0x77, 0xb7, 0x2b, 0xff, 0xd3, 0x81, 0xa3, 0xa9,
-	0x86, 0xad, 0x0f, 0xb0, 0x4c, 0x7f, 0x07, 0xb7, 0x84, 0xd5, 0x45, 0xf3, 0x55, 0x94, 0xc6, 0x2b,
-	0xc3, 0xc1, 0xe3, 0xf0, 0x06, 0x9f, 0x70, 0xad, 0x78, 0xbe, 0x7a, 0x11, 0xaf, 0xcc, 0x3b, 0x2f,
-	0xb6, 0x94, 0xc3, 0x53, 0xb8, 0xb3, 0x07, 0xb6, 0x67, 0x3e, 0x46, 0xdb, 0xec, 0xc0, 0x26, 0x7a,
-	0x9d, 0x9b, 0xdf, 0x1c, 0x38, 0xdc, 0x1d, 0x92, 0x77, 0xa1, 0xbd, 0x20, 0x71, 0x4a, 0xb8, 0x0a,
-	0xe7, 0x4d, 0x7a, 0xeb, 0x3f, 0x11, 0x6c, 0x0c, 0xe8, 0x99, 0xe4, 0x8b, 0x96, 0x6b, 0xbe, 0xbc,
-	0xc9, 0x83, 0x70, 0x27, 0x4c, 0x38, 0x35, 0x80, 0xf5, 0x6c, 0x6b, 0x51, 0xcf, 0x76, 0xcd, 0xf4,
-	0x6f, 0xff, 0x28, 0xfd, 0x5a, 0xbe, 0xf3, 0xb6, 0xfa, 0x27, 0x7c, 0xfa, 0x4f, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0x0a, 0xd8, 0x24, 0x36, 0x1f, 0x0a, 0x00, 0x00,
+	// 6655 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x3c, 0x5b, 0x8c, 0x1b, 0xc9,
+	0x71, 0x18, 0x72, 0xb9, 0x4b, 0x16, 0xb9, 0xbb, 0xdc, 0xd1, 0x9e, 0x44, 0x51, 0x0f, 0xef, 0xcd,
+	0x49, 0x27, 0xc9, 0xd2, 0xcd, 0xd9, 0x3a, 0xc7, 0xbe, 0x97, 0x73, 0x59, 0xed, 0x4a, 0x77, 0xb2,
+	0xa5, 0x93, 0x3c, 0xbb, 0x77, 0x87, 0xd8, 0x06, 0xe8, 0x59, 0x4e, 0x93, 0x1c, 0x8b, 0x9c, 0xa1,
+	0x67, 0x86, 0xbb, 0xcb, 0x03, 0x62, 0x03, 0x09, 0x02, 0xc4, 0x81, 0x0d, 0x18, 0x08, 0x60, 0x23,
+	0xc0, 0xc5, 0x08, 0xf2, 0xfa, 0x09, 0x8c, 0x04, 0x70, 0x82, 0xc0, 0x5f, 0x71, 0x90, 0x9f, 0x00,
+	0xfe, 0xc9, 0x47, 0x7e, 0x0d, 0xe4, 0x23, 0x5f, 0xc9, 0x47, 0x02, 0x04, 0x48, 0xe0, 0xaf, 0x04,
+	0x55, 0xdd, 0x3d, 0xd3, 0x3d, 0x1c, 0x72, 0x77, 0x7d, 0xc9, 0x0f, 0xc1, 0xaa, 0xae, 0xae, 0xae,
+	0xae, 0xee, 0xae, 0xae, 0xae, 0xae, 0x1e, 0xa8, 0x8e, 0x0f, 0xec, 0x71, 0x14, 0x26, 0xa1, 0xf5,
+	0xf3, 0x0a, 0x54, 0x1f, 0xb3, 0xc4, 0xf5, 0xdc, 0xc4, 0x35, 0x5b, 0xb0, 0x72, 0xc8, 0xa2, 0xd8,
+	0x0f, 0x83, 0x96, 0xb1, 0x65, 0xdc, 0xac, 0x38, 0x12, 0x34, 0x4d, 0x58, 0x1a, 0xb8, 0xf1, 0xa0,
+	0x55, 0xda, 0x32, 0x6e, 0xd6, 0x1c, 0xfa, 0x6f, 0x5e, 0x05, 0x88, 0xd8, 0x38, 0x8c, 0xfd, 0x24,
+	0x8c, 0xa6, 0xad, 0x32, 0x95, 0x28, 0x18, 0xf3, 0x45, 0x58, 0x3f, 0x60, 0x7d, 0x3f, 0xe8, 0x4c,
+	0x02, 0xff, 0xb8, 0x93, 0xf8, 0x23, 0xd6, 0x5a, 0xda, 0x32, 0x6e, 0x96, 0x9d, 0x55, 0x42, 0xbf,
+	0x17, 0xf8, 0xc7, 0xfb, 0xfe, 0x88, 0x99, 0x16, 0xac, 0xb2, 0xc0, 0x53, 0xa8, 0x2a, 0x44, 0x55,
+	0x67, 0x81, 0x97, 0xd2, 0xb4, 0x60, 0xa5, 0x1b, 0x8e, 0x46, 0x7e, 0x12, 0xb7, 0x96, 0xb9, 0x64,
+	0x02, 0x34, 0x2f, 0x42, 0x35, 0x9a, 0x04, 0xbc, 0xe2, 0x0a, 0x55, 0x5c, 0x89, 0x26, 0x01, 0x55,
+	0x7a, 0x07, 0x36, 0x64, 0x51, 0x67, 0xcc, 0xa2, 0x8e, 0x9f, 0xb0, 0x51, 0xab, 0xba, 0x55, 0xbe,
+	0x59, 0xbf, 0x7b, 0xc5, 0x96, 0x9d, 0xb6, 0x1d, 0x4e, 0xfd, 0x94, 0x45, 0x0f, 0x13, 0x36, 0xba,
+	0x1f, 0x24, 0xd1, 0xd4, 0x59, 0x8b, 0x34, 0xa4, 0xf9, 0x36, 0x34, 0xc7, 0x51, 0xd8, 0xf3, 0x87,
+	0x0a, 0xa3, 0x5a, 0x9e, 0xd1, 0x53, 0x4e, 0xa1, 0x33, 0x1a, 0x6b, 0x48, 0xf3, 0x25, 0xa8, 0xbb,
+	0x41, 0x10, 0x26, 0x6e, 0xe2, 0x87, 0x41, 0xdc, 0x02, 0xe2, 0x51, 0xb7, 0xb7, 0x53, 0x9c, 0xa3,
+	0x96, 0x9b, 0xe7, 0x61, 0x79, 0xcc, 0xc2, 0xf1, 0x90, 0xb5, 0xea, 0x5b, 0xe5, 0x9b, 0x35, 0x47,
+	0x40, 0xe6, 0x0e, 0xac, 0x4d, 0x82, 0xb1, 0x1b, 0xc5, 0xcc, 0xeb, 0x20, 0xfb, 0xb8, 0xd5, 0x20,
+	0x4e, 0x97, 0x33, 0x69, 0xde, 0x13, 0xe5, 0x0f, 0xb0, 0x98, 0x0b, 0xb3, 0x3a, 0x51, 0x71, 0xed,
+	0x6d, 0x38, 0x57, 0xd0, 0x77, 0xb3, 0x09, 0xe5, 0x67, 0x6c, 0x4a, 0x13, 0xa0, 0xe6, 0xe0, 0x5f,
+	0x73, 0x13, 0x2a, 0x87, 0xee, 0x70, 0xc2, 0x68, 0xf4, 0x0d, 0x87, 0x03, 0xaf, 0x97, 0x5e, 0x35,
+	0xda, 0x4f, 0xe0, 0x5c, 0x41, 0xaf, 0x0b, 0x58, 0x58, 0x2a, 0x8b, 0xfa, 0xdd, 0x86, 0x8d, 0xc4,
+	0xa2, 0xaa, 0xce, 0xd0, 0x9c, 0x15, 0xbc, 0x80, 0xdf, 0x0b, 0x3a, 0xbf, 0x55, 0xad, 0xbb, 0x0a,
+	0x43, 0xeb, 0x1e, 0x34, 0xd4, 0x22, 0xb3, 0x0d, 0xd5, 0xa1, 0x1b, 0xf4, 0x27, 0x6e, 0x9f, 0x09,
+	0x7e, 0x29, 0x8c, 0xda, 0x8e, 0x98, 0x1b, 0x87, 0x81, 0x98, 0xe6, 0x02, 0xb2, 0xde, 0x02, 0xc8,
+	0x06, 0xc8, 0xbc, 0x04, 0xb5, 0x6c, 0xaa, 0x1a, 0x34, 0xe3, 0xaa, 0x13, 0x39, 0x4f, 0x37, 0xa1,
+	0x32, 0x74, 0x0f, 0xd8, 0x50, 0x70, 0xe0, 0x80, 0xf5, 0x67, 0x06, 0xd4, 0x95, 0x0e, 0x23, 0x8b,
+	0x23, 0x77, 0x38, 0xcc, 0x58, 0x18, 0x4e, 0x15, 0x11, 0xc4, 0xe2, 0x22, 0x54, 0xbb, 0xe3, 0x09,
+	0x2f, 0xe3, 0x0a, 0x5f, 0xe9, 0x8e, 0x27, 0x54, 0xb4, 0x05, 0x75, 0x77, 0x38, 0x0c, 0xbb, 0x62,
+	0xf6, 0x94, 0xf9, 0x3a, 0x51, 0x50, 0xe6, 0x0d, 0x58, 0x17, 0x20, 0xf3, 0x3a, 0x07, 0xd3, 0x84,
+	0xc5, 0x62, 0xcd, 0xad, 0xa5, 0xe8, 0x7b, 0x88, 0x45, 0x41, 0xbb, 0xee, 0x70, 0x18, 0x8b, 0xc5,
+	0xc6, 0x01, 0xeb, 0x15, 0xb8, 0x70, 0x6f, 0x12, 0x05, 0x5e, 0x78, 0x14, 0xec, 0x91, 0xd2, 0x1e,
+	0xbb, 0x49, 0xe4, 0x1f, 0x3b, 0xe1, 0x11, 0x5f, 0x81, 0xc3, 0xc9, 0x28, 0x88, 0x5b, 0xc6, 0x56,
+	0xf9, 0xe6, 0xaa, 0x23, 0x41, 0xeb, 0x67, 0x06, 0x6c, 0x16, 0xd5, 0x42, 0xa3, 0x11, 0xb8, 0x23,
+	0xa9, 0x67, 0xfa, 0x6f, 0x5e, 0x83, 0xb5, 0x60, 0x32, 0x3a, 0x60, 0x51, 0x27, 0xec, 0x75, 0xa2,
+	0xf0, 0x28, 0xa6, 0x3e, 0x56, 0x9c, 0x06, 0xc7, 0x3e, 0xe9, 0x39, 0xe1, 0x51, 0x6c, 0x7e, 0x12,
+	0x36, 0x32, 0x2a, 0xd9, 0x6c, 0x99, 0x08, 0xd7, 0x25, 0xe1, 0x0e, 0x47, 0x9b, 0x77, 0x60, 0x89,
+	0xf8, 0x2c, 0xd1, 0x0a, 0x68, 0xd9, 0x73, 0x3a, 0xe0, 0x10, 0x95, 0xf9, 0x09, 0xa8, 0x8f, 0x23,
+	0xd6, 0x19, 0xf8, 0x31, 0x59, 0xad, 0xca, 0x56, 0xf9, 0x66, 0xd9, 0x81, 0x71, 0xc4, 0xde, 0xe1,
+	0x18, 0xeb, 0xd7, 0x61, 0x4d, 0x72, 0xd8, 0x09, 0x07, 0x61, 0x94, 0xd0, 0x98, 0xfa, 0x01, 0x8b,
+	0xc5, 0x60, 0x73, 0x80, 0x14, 0x38, 0x89, 0x0e, 0x71, 0x8c, 0xca, 0x37, 0x4b, 0x0e, 0x07, 0x70,
+	0x64, 0x07, 0xee, 0xb0, 0xd7, 0x19, 0xfa, 0x3d, 0x46, 0x02, 0x97, 0x9c, 0x2a, 0x22, 0x1e, 0xf9,
+	0x3d, 0x66, 0x8d, 0xa1, 0x99, 0x0a, 0x37, 0x89, 0x0e, 0xfd, 0x43, 0x77, 0x98, 0xb1, 0x31, 0xe6,
+	0xb2, 0x29, 0xe9, 0x6c, 0xcc, 0x5b, 0x38, 0x12, 0x28, 0x19, 0xaa, 0x04, 0xfb, 0xbc, 0x6e, 0xeb,
+	0x12, 0x3b, 0xb2, 0xdc, 0xfa, 0x45, 0x39, 0x1b, 0xd0, 0xed, 0xc0, 0x1d, 0x4e, 0x63, 0x3f, 0x76,
+	0x58, 0x3c, 0x19, 0x26, 0x31, 0x4e, 0xa6, 0x7e, 0xe4, 0x06, 0x93, 0xa1, 0x1b, 0xf9, 0xc9, 0x54,
+	0x18, 0x7c, 0x15, 0x85, 0x6b, 0x25, 0x76, 0x47, 0xe3, 0xa1, 0x1f, 0xf4, 0xc5, 0x28, 0xa5, 0xb0,
+	0xf9, 0x32, 0xac, 0x8c, 0xa3, 0xf0, 0xeb, 0xac, 0x9b, 0x50, 0x37, 0xeb, 0x77, 0x9f, 0x2b, 0x56,
+	0xbc, 0xa4, 0x32, 0x6f, 0x43, 0x85, 0x5b, 0x2a, 0x3e, 0x4e, 0x73, 0xc8, 0x39, 0x8d, 0xf9, 0x52,
+	0x6a, 0xf7, 0x2a, 0x8b, 0xa8, 0x05, 0x91, 0xf9, 0x10, 0x4c, 0xfe, 0xaf, 0xe3, 0x07, 0x09, 0x8b,
+	0xdc, 0x2e, 0x2e, 0x06, 0xda, 0x28, 0xea, 0x77, 0xdb, 0xf6, 0x4e, 0x38, 0x1a, 0x47, 0x2c, 0x8e,
+	0x99, 0xc7, 0x2b, 0x3b, 0xe1, 0x91, 0xa8, 0xbf, 0xc1, 0x6b, 0x3d, 0xcc, 0x2a, 0x99, 0xb7, 0xa1,
+	0x16, 0x07, 0xee, 0x38, 0x1e, 0x84, 0x49, 0xdc, 0x5a, 0xa1, 0xc6, 0x57, 0x6d, 0xb4, 0x1c, 0x7b,
+	0x02, 0xeb, 0x64, 0xe5, 0xe6, 0xe7, 0xa0, 0xee, 0xf9, 0x11, 0xeb, 0x26, 0x61, 0xe4, 0xb3, 0xb8,
+	0x55, 0x5d, 0x24, 0xab, 0x4a, 0x69, 0xbe, 0x02, 0x35, 0x69, 0x75, 0xe2, 0x56, 0x6d, 0x51, 0xb5,
+	0x8c, 0xce, 0x7c, 0x09, 0xaa, 0xb1, 0x98, 0x36, 0x2d, 0xa0, 0xbe, 0x6d, 0xd8, 0xf9, 0xf9, 0xe4,
+	0xa4, 0x24, 0xd6, 0x7f, 0x19, 0xd0, 0x50, 0x05, 0x2f, 0x5c, 0x8e, 0xb7, 0x61, 0x89, 0x64, 0x28,
+	0x91, 0x0c, 0x17, 0xb4, 0x9e, 0xda, 0xdb, 0x7d, 0xb9, 0x73, 0x10, 0x91, 0xf9, 0x69, 0x58, 0x0e,
+	0x8f, 0x02, 0x16, 0xc9, 0x79, 0x77, 0x51, 0x27, 0x7f, 0x42, 0x65, 0xbc, 0x82, 0x20, 0x6c, 0x7f,
+	0x0e, 0x6a, 0xdb, 0xfd, 0x02, 0x33, 0x5e, 0x29, 0xd8, 0x59, 0xca, 0xea, 0x46, 0xf0, 0x1a, 0xd4,
+	0x15, 0x7e, 0x67, 0xa9, 0x6a, 0xfd, 0xd8, 0x80, 0x8b, 0x73, 0xc7, 0xbc, 0xc0, 0x00, 0x19, 0xa7,
+	0x35, 0x40, 0xa5, 0x62, 0x03, 0x64, 0xc2, 0x12, 0xee, 0xb8, 0xa4, 0x94, 0xb2, 0xb3, 0x24, 0x3d,
+	0x29, 0x3f, 0xf0, 0xfc, 0xae, 0x98, 0xef, 0x15, 0x47, 0x82, 0xb8, 0xc9, 0xf8, 0x81, 0x37, 0x4e,
+	0x22, 0x61, 0x7b, 0x04, 0x64, 0xed, 0xc1, 0xca, 0x4e, 0x38, 0x19, 0x0f, 0xb9, 0x69, 0xf1, 0x03,
+	0x8f, 0x1d, 0x93, 0x4d, 0xa8, 0x39, 0x1c, 0x30, 0xef, 0xc2, 0xf2, 0x88, 0xba, 0xd0, 0x2a, 0x9d,
+	0x38, 0xb1, 0x05, 0xa5, 0x75, 0x0d, 0x1a, 0xfb, 0xe1, 0xa4, 0x3b, 0x10, 0xbb, 0x29, 0x72, 0xe6,
+	0x8b, 0xd0, 0x20, 0xa1, 0x38, 0x60, 0x7d, 0x64, 0xc0, 0x39, 0xd1, 0xf6, 0x9e, 0xdf, 0x0f, 0xfc,
+	0x9e, 0xdf, 0x75, 0x83, 0xae, 0xe6, 0x74, 0x19, 0xba, 0xd3, 0x65, 0xc2, 0xd2, 0xd0, 0xef, 0x25,
+	0xc2, 0xf6, 0xd1, 0x7f, 0xf3, 0x0a, 0x40, 0x77, 0xe0, 0x77, 0xe2, 0x6f, 0x4c, 0xdc, 0x88, 0x91,
+	0x32, 0x4a, 0x4e, 0xad, 0x3b, 0xf0, 0xf7, 0x08, 0x81, 0xcc, 0xbe, 0xee, 0x76, 0xbb, 0x6e, 0xe4,
+	0x91, 0x46, 0x4a, 0x8e, 0x04, 0xd1, 0x8f, 0xec, 0x86, 0x41, 0xcf, 0xf7, 0x58, 0xd0, 0xe5, 0x0b,
+	0xbe, 0xe4, 0x28, 0x18, 0xeb, 0xdb, 0x06, 0x34, 0x84, 0x78, 0xbb, 0xac, 0xeb, 0x4e, 0x75, 0xeb,
+	0xc8, 0x25, 0xcb, 0xac, 0xe3, 0x79, 0x58, 0x3e, 0xf2, 0x71, 0x4d, 0x88, 0xe1, 0x12, 0x90, 0xa2,
+	0xf7, 0xb2, 0xaa, 0xf7, 0x05, 0x23, 0x25, 0xc7, 0x95, 0x4b, 0x44, 0xff, 0xad, 0x7f, 0x2c, 0xc1,
+	0x79, 0x21, 0x4b, 0xde, 0x9e, 0xde, 0x86, 0x06, 0x39, 0x88, 0x5d, 0x5e, 0x2c, 0xcc, 0x4f, 0xd5,
+	0x16, 0xe4, 0x4e, 0x1d, 0x4b, 0x05, 0x60, 0xbe, 0x0c, 0x6b, 0xc2, 0x62, 0x49, 0xf2, 0x95, 0x1c,
+	0xf9, 0x2a, 0x2f, 0x97, 0x15, 0x3e, 0x05, 0x0d, 0x51, 0x81, 0x0f, 0x60, 0x55, 0x98, 0x26, 0x75,
+	0x78, 0x9d, 0x3a, 0x27, 0x21, 0xc0, 0xdc, 0x86, 0x0d, 0x92, 0x27, 0x56, 0x86, 0xb4, 0x55, 0xa3,
+	0x56, 0x36, 0xed, 0x82, 0xe1, 0x76, 0x9a, 0x48, 0xae, 0x62, 0xcc, 0x3b, 0x00, 0xc4, 0xc2, 0x43,
+	0xb5, 0x0b, 0x9b, 0xb3, 0x6a, 0xab, 0x63, 0xe1, 0xd4, 0x90, 0x80, 0xfe, 0x9a, 0xbf, 0x02, 0x1b,
+	0xd2, 0xc6, 0x4d, 0xd3, 0x6e, 0xd5, 0x73, 0xdd, 0x6a, 0xa6, 0x24, 0x02, 0x63, 0xfd, 0xa9, 0x01,
+	0xf0, 0xde, 0xf6, 0xde, 0xfe, 0xce, 0xc0, 0x0d, 0xfa, 0xb4, 0xf5, 0x51, 0x9b, 0x8a, 0xa9, 0xaa,
+	0x22, 0xe2, 0x5d, 0x34, 0x57, 0x57, 0x00, 0xe2, 0xa8, 0xdb, 0x39, 0x60, 0xbd, 0x30, 0x62, 0xc2,
+	0xc7, 0xaa, 0xc5, 0x51, 0xf7, 0x1e, 0x21, 0xb0, 0x2e, 0x16, 0xbb, 0xbd, 0x84, 0x45, 0xe2, 0x40,
+	0x52, 0x8d, 0xa3, 0xee, 0x36, 0xc2, 0xb8, 0xf3, 0x4f, 0xdc, 0x38, 0x91, 0x95, 0x97, 0xa8, 0x18,
+	0x10, 0x25, 0x6a, 0x5f, 0x01, 0x82, 0x44, 0xf5, 0x0a, 0x67, 0x8e, 0x18, 0xaa, 0x6f, 0xfd, 0x1a,
+	0x5c, 0xc8, 0xc4, 0x8c, 0xf7, 0xdc, 0x43, 0x16, 0xc9, 0xa1, 0xbf, 0x0e, 0x2b, 0x5d, 0x8e, 0x6e,
+	0x19, 0xc2, 0xa3, 0xcf, 0x48, 0x1d, 0x59, 0x66, 0xfd, 0x9b, 0x01, 0x6b, 0x7b, 0x83, 0x30, 0x09,
+	0x58, 0x1c, 0x3b, 0xac, 0x1b, 0x46, 0x9e, 0xf9, 0x02, 0xac, 0xd2, 0x96, 0x15, 0xb8, 0xc3, 0x4e,
+	0x14, 0x0e, 0x65, 0x8f, 0x1b, 0x12, 0xe9, 0x84, 0x43, 0x72, 0x2a, 0xb1, 0x8c, 0x5b, 0xe9, 0x8a,
+	0xc3, 0x81, 0xd4, 0x9c, 0x97, 0x15, 0x73, 0x6e, 0xc2, 0x12, 0xea, 0x4a, 0x74, 0x8e, 0xfe, 0x9b,
+	0xaf, 0x41, 0xb5, 0x1b, 0x4e, 0x90, 0x5f, 0x2c, 0x76, 0xd3, 0x2b, 0xb6, 0x2e, 0x85, 0xbd, 0x23,
+	0xca, 0xb9, 0xed, 0x4e, 0xc9, 0xdb, 0x6f, 0xc0, 0xaa, 0x56, 0x74, 0x92, 0x19, 0xae, 0xa8, 0x66,
+	0x78, 0x17, 0x2e, 0xc8, 0x66, 0xf2, 0x4b, 0xe5, 0x16, 0xac, 0x44, 0xd4, 0xb2, 0xd4, 0xd7, 0x7a,
+	0x4e, 0x22, 0x47, 0x96, 0x5b, 0x37, 0xa0, 0x8e, 0xd3, 0x59, 0x78, 0x67, 0xba, 0x49, 0x42, 0xe3,
+	0x28, 0x41, 0xeb, 0x87, 0x06, 0xb4, 0x14, 0x4a, 0xde, 0xd4, 0x63, 0x16, 0xc7, 0xe8, 0xd9, 0xbf,
+	0xae, 0xda, 0xbd, 0xfa, 0xdd, 0x6b, 0xf6, 0x3c, 0x4a, 0x5b, 0x39, 0x2e, 0xf1, 0x2a, 0xed, 0x07,
+	0x00, 0x0b, 0x8f, 0x22, 0x33, 0x47, 0x1b, 0x95, 0xb7, 0xa2, 0x8f, 0x0f, 0xa0, 0xb6, 0xc7, 0x02,
+	0x74, 0xeb, 0x83, 0x24, 0x53, 0x9b, 0x41, 0xce, 0x1d, 0x07, 0xd0, 0xe1, 0xc2, 0xee, 0xb0, 0x20,
+	0xe1, 0x63, 0x5d, 0x73, 0x52, 0x58, 0xed, 0x79, 0x59, 0xef, 0xf9, 0x4f, 0x0d, 0xb8, 0xb0, 0xc3,
+	0xc9, 0xd2, 0x06, 0xa4, 0xa6, 0xdf, 0x87, 0x66, 0x2c, 0x71, 0x9d, 0x83, 0x69, 0xc7, 0x73, 0xa7,
+	0x42, 0x07, 0x77, 0xec, 0x39, 0x75, 0xec, 0x14, 0x71, 0x6f, 0xba, 0xeb, 0x4e, 0xc5, 0x39, 0x36,
+	0xd6, 0x90, 0xed, 0xc7, 0x70, 0xae, 0x80, 0xac, 0x60, 0x7e, 0x6c, 0xe9, 0xda, 0x81, 0x8c, 0xbb,
+	0xaa, 0x9b, 0xaf, 0xc2, 0x1a, 0x1f, 0x78, 0xe6, 0xf1, 0x5d, 0xb5, 0xd0, 0x59, 0x39, 0x0f, 0xcb,
+	0x54, 0x85, 0x2b, 0xa7, 0xec, 0x08, 0x08, 0x37, 0x10, 0xcf, 0x27, 0xf7, 0xcd, 0x8d, 0xa6, 0x42,
+	0x3b, 0x0a, 0xc6, 0x7a, 0x92, 0x71, 0xdf, 0x4b, 0x22, 0xe6, 0x8e, 0x0a, 0xb9, 0xdf, 0xca, 0x0e,
+	0x38, 0x25, 0x31, 0x29, 0x75, 0x99, 0xb2, 0x13, 0xcf, 0xfb, 0xb0, 0x2e, 0x8a, 0x52, 0x13, 0x30,
+	0x77, 0x62, 0x22, 0xdf, 0x98, 0x5a, 0x9d, 0xe5, 0xcb, 0xa5, 0x71, 0x64, 0xb9, 0xf5, 0x4d, 0xa8,
+	0x6f, 0x77, 0x13, 0xff, 0xd0, 0x4f, 0x50, 0xa5, 0xe6, 0x2b, 0x3a, 0x4f, 0x74, 0xb8, 0x94, 0x62,
+	0x1a, 0x3f, 0x3f, 0x11, 0x93, 0x55, 0x52, 0xb6, 0x5f, 0xc7, 0xcd, 0x32, 0x2b, 0x38, 0xd3, 0x92,
+	0xbd, 0x0b, 0x4d, 0x6a, 0x80, 0xed, 0xb2, 0x43, 0x36, 0x0c, 0xc7, 0x2c, 0xe2, 0xca, 0x4d, 0x21,
+	0xe1, 0x37, 0x28, 0x18, 0xeb, 0x2f, 0xcb, 0x70, 0x41, 0x4a, 0x95, 0x5f, 0xe7, 0x9f, 0xc5, 0x1d,
+	0x74, 0x2a, 0xa5, 0xb7, 0xec, 0x39, 0x74, 0xf6, 0xae, 0x3b, 0x95, 0x8e, 0x26, 0xd2, 0x9b, 0xd7,
+	0x95, 0xdd, 0x91, 0xf7, 0x9f, 0x5b, 0xbe, 0x74, 0x4f, 0xe4, 0x9a, 0x7d, 0x3e, 0xb7, 0x27, 0x96,
+	0x89, 0x48, 0xdb, 0x04, 0x2f, 0x41, 0xcd, 0x63, 0x87, 0x1d, 0xee, 0x4e, 0x2d, 0xf1, 0x25, 0xe5,
+	0xb1, 0xc3, 0x87, 0x08, 0xa3, 0xf1, 0x75, 0xa9, 0xbb, 0x1d, 0xe1, 0x31, 0x54, 0xb8, 0x27, 0xc8,
+	0x91, 0x1f, 0x10, 0xce, 0x7c, 0x13, 0x96, 0x39, 0xdc, 0x5a, 0x16, 0xb6, 0x63, 0x5e, 0x2f, 0x08,
+	0xcf, 0x84, 0xff, 0xcb, 0xeb, 0xb4, 0xef, 0x43, 0x2d, 0xed, 0x5c, 0xc1, 0x50, 0xcc, 0xd8, 0x0e,
+	0x65, 0x7c, 0x55, 0x6f, 0xf8, 0x11, 0xd4, 0x15, 0xee, 0x05, 0x8c, 0x6e, 0xe8, 0x8c, 0x36, 0xec,
+	0xfc, 0x38, 0xaa, 0xc3, 0xfc, 0x1d, 0x03, 0xd6, 0x1e, 0x89, 0x63, 0x05, 0xd9, 0xf7, 0xd8, 0x7c,
+	0x53, 0x3d, 0x90, 0xf0, 0xe1, 0xba, 0x6a, 0xeb, 0x34, 0x29, 0x28, 0x86, 0x2a, 0xab, 0xd0, 0x7e,
+	0x13, 0xd6, 0xf4, 0xc2, 0x93, 0x82, 0x48, 0xda, 0xac, 0xfb, 0x77, 0x03, 0xae, 0xf2, 0x21, 0x4d,
+	0x99, 0xe4, 0x27, 0xd2, 0xe7, 0xb5, 0x89, 0x74, 0xcb, 0x5e, 0x4c, 0x3e, 0x33, 0x9f, 0x6e, 0xa4,
+	0xc7, 0x49, 0xb9, 0x02, 0xf5, 0xae, 0xa5, 0x07, 0x49, 0x6d, 0xba, 0x94, 0xf5, 0xe9, 0xd2, 0x7e,
+	0x67, 0xf1, 0x58, 0x5e, 0xd7, 0x87, 0x60, 0xa6, 0x0d, 0xdd, 0xdc, 0x3d, 0x1c, 0x8d, 0xdd, 0x6e,
+	0xb2, 0x33, 0x98, 0x44, 0x01, 0x2e, 0xf5, 0x4d, 0xa8, 0xb8, 0x9e, 0xc7, 0x3c, 0xc1, 0x90, 0x03,
+	0x68, 0x54, 0x22, 0x36, 0x0a, 0x0f, 0x99, 0x27, 0xb4, 0x26, 0x41, 0xdc, 0x29, 0x8e, 0x98, 0xdf,
+	0x1f, 0x24, 0xcc, 0x6b, 0x95, 0x45, 0x00, 0x49, 0xc0, 0xd6, 0x97, 0x61, 0x5d, 0xe1, 0x4e, 0x51,
+	0x2f, 0x2d, 0x84, 0x51, 0x91, 0x21, 0x8c, 0xe7, 0x60, 0xb9, 0xe7, 0x06, 0x1d, 0x3f, 0x90, 0x63,
+	0xd2, 0x73, 0x83, 0x87, 0xc1, 0x42, 0xde, 0x3f, 0x2b, 0x41, 0x5b, 0x61, 0x9e, 0x1f, 0xa7, 0xd7,
+	0xb4, 0x71, 0xba, 0x6e, 0xcf, 0x27, 0x9d, 0x19, 0xa3, 0x37, 0xe5, 0x16, 0xcd, 0x87, 0xe8, 0xc5,
+	0x45, 0x75, 0x67, 0x36, 0x69, 0xf3, 0x2a, 0xd4, 0x79, 0x57, 0x3a, 0xa3, 0xd0, 0x93, 0x3e, 0x51,
+	0x8d, 0xfa, 0xf3, 0x38, 0xf4, 0xd8, 0x99, 0xc7, 0x4e, 0x1f, 0x1e, 0x75, 0x29, 0x7e, 0xe1, 0x04,
+	0x77, 0xe0, 0x45, 0x9d, 0x55, 0xd3, 0xce, 0x8d, 0x85, 0x3a, 0x0f, 0xfe, 0xc8, 0x80, 0xc6, 0xee,
+	0xf6, 0xdb, 0x7b, 0x03, 0x77, 0xcc, 0xf6, 0xfd, 0xee, 0xb3, 0x05, 0x27, 0xae, 0xf3, 0xb0, 0x3c,
+	0x62, 0x11, 0x3f, 0xaa, 0xd3, 0xb1, 0x86, 0x43, 0x58, 0x63, 0xec, 0x46, 0xe4, 0x31, 0xf0, 0xf8,
+	0x98, 0x04, 0xd1, 0x3a, 0x8e, 0xdc, 0xe3, 0xce, 0x41, 0xe4, 0x06, 0xdd, 0x81, 0x88, 0x03, 0x56,
+	0x9c, 0xfa, 0xc8, 0x3d, 0xbe, 0x27, 0x50, 0x68, 0x00, 0x87, 0x21, 0xba, 0xa6, 0x49, 0xa7, 0x3b,
+	0x70, 0xfd, 0x40, 0x1a, 0x40, 0x81, 0xdc, 0x41, 0x9c, 0xf5, 0x65, 0xb8, 0x20, 0x65, 0xcc, 0x0f,
+	0xf7, 0x0b, 0x50, 0x49, 0xfc, 0xee, 0x33, 0x39, 0xde, 0xab, 0xb6, 0xda, 0x19, 0x87, 0x97, 0x2d,
+	0x8a, 0x22, 0x59, 0x5f, 0x82, 0xba, 0xc3, 0x70, 0xf7, 0xa5, 0xa6, 0x70, 0x9a, 0x22, 0x20, 0xb7,
+	0x50, 0x0e, 0xf0, 0x73, 0xd8, 0x54, 0xee, 0x01, 0xf4, 0x1f, 0xbb, 0xed, 0xb1, 0x21, 0x93, 0x53,
+	0xb4, 0xea, 0x48, 0xd0, 0xfa, 0xeb, 0x12, 0x5c, 0xe5, 0x3c, 0x1f, 0x44, 0xec, 0x1b, 0x13, 0x16,
+	0x74, 0x67, 0xb6, 0xa5, 0x4d, 0x55, 0xec, 0x8a, 0x94, 0xf3, 0x1a, 0x2c, 0x93, 0x12, 0xe4, 0x0c,
+	0x6c, 0xd8, 0x8a, 0x68, 0x8e, 0x28, 0x33, 0x1d, 0x3d, 0xe4, 0xc3, 0x03, 0x21, 0x9f, 0xb2, 0x17,
+	0xb7, 0x68, 0xef, 0x66, 0x55, 0xf8, 0xb4, 0x55, 0x99, 0x68, 0x1a, 0x5a, 0xca, 0xc5, 0xd9, 0x6e,
+	0xc0, 0x7a, 0x76, 0xa8, 0xf2, 0xd8, 0x38, 0x19, 0x88, 0x41, 0x5a, 0x4b, 0xd1, 0xbb, 0x88, 0x6d,
+	0xff, 0x2a, 0x34, 0xf3, 0xad, 0x9c, 0xc9, 0x0a, 0xdf, 0x84, 0xa6, 0xc3, 0x8e, 0x22, 0x3f, 0x61,
+	0xc4, 0x2f, 0x6f, 0x36, 0xca, 0xa9, 0xd9, 0xb0, 0x7a, 0xb0, 0x26, 0x28, 0xdf, 0x09, 0x93, 0x78,
+	0xcc, 0x23, 0x4b, 0x74, 0xec, 0x30, 0x94, 0x63, 0x07, 0x85, 0x08, 0x02, 0xd9, 0x10, 0xfd, 0xc7,
+	0x49, 0x3c, 0x64, 0x41, 0x3f, 0x19, 0x88, 0xb9, 0x2a, 0x20, 0x6c, 0x87, 0x77, 0x8d, 0xf7, 0x9e,
+	0x03, 0xd6, 0x0f, 0x4b, 0x70, 0x49, 0x15, 0x69, 0x76, 0x53, 0xd0, 0x9c, 0xfa, 0x1b, 0xf6, 0x02,
+	0xe2, 0x02, 0x93, 0x71, 0x1b, 0xaa, 0x03, 0x2e, 0xbf, 0xea, 0x98, 0xa9, 0xfd, 0x72, 0x52, 0x02,
+	0x5c, 0x29, 0xe2, 0xbf, 0x18, 0x04, 0xde, 0x81, 0x86, 0x40, 0x52, 0x93, 0x72, 0xc5, 0xa5, 0x5c,
+	0xb3, 0x15, 0x27, 0x18, 0xc6, 0xed, 0x2f, 0x9e, 0x60, 0x3d, 0x66, 0xf6, 0xf1, 0xfc, 0x98, 0xa8,
+	0x43, 0xf6, 0x5b, 0x06, 0x79, 0xe1, 0xb1, 0x8f, 0x5b, 0xfd, 0x53, 0x37, 0x19, 0x88, 0x23, 0xf4,
+	0x79, 0x58, 0xe6, 0x66, 0x43, 0x70, 0x16, 0x10, 0xe2, 0xdd, 0x49, 0x32, 0x08, 0x23, 0x69, 0x43,
+	0x38, 0x84, 0x43, 0x85, 0x4b, 0x40, 0xf4, 0x89, 0xfe, 0x17, 0x9e, 0x24, 0x31, 0x56, 0x8d, 0x66,
+	0x4c, 0xcc, 0x40, 0x0e, 0x58, 0x7f, 0x62, 0xc0, 0x15, 0x4d, 0x8a, 0x99, 0xdd, 0xdb, 0xce, 0x1f,
+	0x8f, 0x37, 0xed, 0x02, 0xb1, 0xd3, 0x73, 0xf2, 0xc2, 0xb8, 0x73, 0x1b, 0xaa, 0x63, 0x37, 0xc1,
+	0xc3, 0xb1, 0x3c, 0x07, 0xa5, 0xf0, 0x42, 0x67, 0xcf, 0xfa, 0x0a, 0x34, 0x77, 0xc2, 0x20, 0x89,
+	0xfc, 0x83, 0x49, 0x12, 0x46, 0xf1, 0xbe, 0xe8, 0x64, 0x17, 0x63, 0x01, 0x7c, 0x7a, 0xd3, 0x7f,
+	0xbe, 0xe7, 0xf6, 0x31, 0x04, 0x2e, 0x0c, 0x8e, 0x04, 0xf1, 0x62, 0xc6, 0x8b, 0xd0, 0x5b, 0x3c,
+	0x98, 0x0a, 0x57, 0x73, 0x85, 0xe0, 0x7b, 0x53, 0xeb, 0xbb, 0x25, 0xb8, 0xa4, 0x72, 0xcf, 0x6b,
+	0xe0, 0x86, 0x6e, 0x28, 0x37, 0xec, 0xbc, 0x28, 0xa7, 0x30, 0x96, 0x38, 0xbd, 0x50, 0xc2, 0x4e,
+	0x76, 0x0c, 0xa4, 0xe9, 0x85, 0x38, 0xe9, 0x11, 0x5f, 0x82, 0x1a, 0x91, 0xc4, 0x63, 0x37, 0x90,
+	0xa6, 0x04, 0x11, 0x7b, 0x63, 0x37, 0x30, 0x6f, 0x42, 0x53, 0xca, 0x9f, 0xf2, 0x90, 0xb6, 0x84,
+	0xf7, 0x43, 0xb2, 0xb1, 0x60, 0x35, 0xa5, 0x24, 0x56, 0xfc, 0xce, 0xb5, 0x2e, 0xc8, 0x88, 0x9b,
+	0xa6, 0xec, 0x95, 0x9c, 0xb2, 0xdf, 0x82, 0x8d, 0xbd, 0x84, 0x1d, 0xb9, 0x91, 0x17, 0x0f, 0xfc,
+	0xb1, 0xf0, 0x31, 0x4d, 0x58, 0x8a, 0xd9, 0xb0, 0x27, 0xae, 0x51, 0xe8, 0x3f, 0x4e, 0xc9, 0x30,
+	0x19, 0xe0, 0xc9, 0x82, 0x87, 0x71, 0x05, 0x64, 0x7d, 0xdf, 0x80, 0x75, 0x85, 0x03, 0x8d, 0xd6,
+	0x67, 0x52, 0x2f, 0xce, 0x10, 0x97, 0x9d, 0x39, 0x0a, 0xfb, 0x29, 0x15, 0x0b, 0x0f, 0x9c, 0xd3,
+	0xb6, 0x1f, 0x43, 0x5d, 0x41, 0x17, 0xec, 0xfd, 0x37, 0xf5, 0x25, 0x67, 0xda, 0x33, 0x92, 0xab,
+	0x6b, 0xee, 0x37, 0xa0, 0xad, 0x94, 0xe7, 0xc7, 0xf9, 0x45, 0x7d, 0x9c, 0x9b, 0x79, 0x09, 0x4f,
+	0x33, 0xcc, 0x8b, 0x7c, 0x50, 0xeb, 0x5f, 0x0d, 0x38, 0xbf, 0xcf, 0xdc, 0xd1, 0xf6, 0xd0, 0xef,
+	0x07, 0x78, 0x8a, 0x96, 0x36, 0x7f, 0x6a, 0x5e, 0x86, 0x5a, 0xba, 0x25, 0x88, 0x85, 0x9f, 0x21,
+	0xcc, 0x57, 0xa1, 0xc2, 0x3c, 0x3f, 0x35, 0x75, 0x96, 0x5d, 0xcc, 0xc5, 0xbe, 0xef, 0xa5, 0x47,
+	0x4a, 0x5e, 0x01, 0x57, 0x3d, 0x05, 0xf3, 0xc5, 0x7c, 0xe3, 0x00, 0x4e, 0xa6, 0x6e, 0x14, 0xc6,
+	0x71, 0x27, 0x61, 0xee, 0xa8, 0xc3, 0x59, 0xf3, 0x09, 0xb7, 0x46, 0x78, 0x64, 0x4f, 0xbc, 0xda,
+	0xaf, 0x02, 0x64, 0x4c, 0xcf, 0x74, 0x1c, 0x7d, 0x17, 0x36, 0x34, 0x29, 0x69, 0x16, 0xbc, 0xa6,
+	0x6f, 0xc0, 0x86, 0xb8, 0xb8, 0x28, 0xee, 0x8e, 0xb6, 0xcf, 0x5a, 0x3f, 0x30, 0xe0, 0xb2, 0x46,
+	0x97, 0x1f, 0xbe, 0x9b, 0xfa, 0xf0, 0x99, 0xf6, 0x4c, 0xf3, 0xa7, 0x19, 0xc0, 0x74, 0x37, 0x2b,
+	0x2b, 0xbb, 0xd9, 0x62, 0xe3, 0xf4, 0x3f, 0x25, 0xb8, 0xb2, 0xcb, 0x7a, 0xac, 0x9b, 0x3c, 0x60,
+	0x6e, 0x32, 0x89, 0x66, 0x4f, 0x40, 0x5a, 0xe4, 0xbe, 0x26, 0xf7, 0x30, 0x69, 0xb9, 0x85, 0x6b,
+	0xa4, 0x59, 0x6e, 0x6e, 0xa2, 0xe8, 0xbf, 0xea, 0x57, 0x8a, 0x20, 0xb7, 0x00, 0x55, 0x9b, 0x5e,
+	0x4e, 0x6d, 0x3a, 0xd2, 0xf3, 0xbd, 0x21, 0xa6, 0x53, 0x6f, 0xc5, 0x91, 0x20, 0x8e, 0x1f, 0x5e,
+	0x9d, 0xaf, 0x10, 0x16, 0xff, 0x66, 0x4e, 0x42, 0x55, 0x71, 0x12, 0x78, 0x50, 0x7f, 0x34, 0x1e,
+	0xb2, 0x63, 0xbc, 0x5c, 0xac, 0x51, 0x91, 0x82, 0xe1, 0xa1, 0xae, 0x09, 0x57, 0x20, 0x50, 0x69,
+	0x0a, 0x63, 0x20, 0x76, 0x8c, 0x81, 0xd8, 0x9e, 0x7f, 0x4c, 0x11, 0x64, 0x2c, 0xad, 0x21, 0xe6,
+	0x01, 0x22, 0xb8, 0x2a, 0x8e, 0x45, 0xce, 0x03, 0x5d, 0x62, 0x1c, 0xe7, 0x36, 0x8d, 0xd5, 0x59,
+	0xcb, 0xd9, 0xf3, 0x8f, 0x3b, 0xe9, 0xc6, 0xb1, 0x46, 0x3a, 0xac, 0xf7, 0xfc, 0xe3, 0xa7, 0x02,
+	0x65, 0x7d, 0xd7, 0x00, 0xd8, 0x09, 0xbb, 0xe1, 0x28, 0xa4, 0x59, 0x56, 0x7c, 0x60, 0x4a, 0x4f,
+	0x69, 0xa5, 0x39, 0xa7, 0xb4, 0xb2, 0x7e, 0x4a, 0x3b, 0x0f, 0xcb, 0xac, 0xd7, 0x0b, 0xa3, 0x84,
+	0x96, 0x86, 0xe1, 0x08, 0x88, 0x2c, 0x39, 0xea, 0xb9, 0x23, 0x4a, 0x2b, 0x54, 0x5a, 0x27, 0xdc,
+	0x7d, 0x42, 0x59, 0x7f, 0x63, 0xc0, 0x73, 0x5c, 0x9e, 0xfc, 0x4c, 0x78, 0x5e, 0x9f, 0xa4, 0x75,
+	0x3b, 0x13, 0xfb, 0x34, 0xb3, 0x73, 0x0b, 0xea, 0xdd, 0x90, 0xf5, 0x7a, 0x7e, 0xd7, 0x67, 0x41,
+	0x22, 0x0e, 0x78, 0x2a, 0x0a, 0x6b, 0xb3, 0xe3, 0x71, 0x18, 0xb0, 0x40, 0xca, 0x9d, 0xc2, 0xe4,
+	0xe2, 0x84, 0x41, 0x32, 0x18, 0xe2, 0x16, 0x12, 0xa7, 0x92, 0x0b, 0xdc, 0x4e, 0x18, 0x27, 0x56,
+	0x02, 0xa6, 0xc3, 0x0e, 0x7d, 0x76, 0xf4, 0xc8, 0x4d, 0xd0, 0x17, 0xde, 0x4b, 0xdc, 0x7c, 0x7c,
+	0x4c, 0x3b, 0xd9, 0x5c, 0x86, 0x1a, 0xdd, 0xc6, 0xf7, 0x23, 0x77, 0x24, 0x26, 0x72, 0x86, 0x20,
+	0x5f, 0x3d, 0x4c, 0xdc, 0xa1, 0x48, 0x76, 0xe0, 0x00, 0xce, 0xc2, 0x91, 0x7b, 0x2c, 0x52, 0x1b,
+	0xf0, 0xaf, 0xf5, 0x17, 0x25, 0xb8, 0xac, 0x35, 0x3b, 0x1b, 0x73, 0xd6, 0xd4, 0x76, 0xce, 0x9e,
+	0x15, 0x52, 0xaa, 0x6f, 0x3b, 0x17, 0x2e, 0xb8, 0x65, 0x2f, 0xe2, 0x5c, 0xb4, 0xeb, 0x68, 0x23,
+	0x50, 0xce, 0x8d, 0x40, 0x0b, 0x56, 0x0e, 0x26, 0xdd, 0x67, 0x4c, 0x2c, 0xc6, 0xb2, 0x23, 0x41,
+	0xdd, 0x46, 0x54, 0x72, 0xe1, 0x87, 0x77, 0x4f, 0xda, 0xc8, 0x6e, 0xe9, 0x1b, 0x59, 0x71, 0x0f,
+	0x33, 0xeb, 0x3a, 0x81, 0xfa, 0xc3, 0x38, 0x9e, 0xd0, 0x59, 0x8d, 0x25, 0x0b, 0x02, 0x98, 0xa9,
+	0x89, 0x28, 0x29, 0x6e, 0x1f, 0xbf, 0xa7, 0x89, 0xe2, 0x84, 0x42, 0xca, 0xa2, 0x8b, 0x84, 0xc0,
+	0x70, 0xc6, 0x45, 0xcc, 0xb2, 0x11, 0x65, 0x7c, 0x57, 0x58, 0x41, 0x78, 0xd7, 0x9d, 0x5a, 0x3f,
+	0x28, 0xc1, 0x55, 0x6a, 0xd7, 0x61, 0x3d, 0x16, 0xb1, 0xa0, 0x3b, 0x6b, 0xeb, 0x1e, 0xc0, 0x4a,
+	0xe2, 0x73, 0x05, 0xc9, 0x58, 0xf5, 0xe2, 0x1a, 0x36, 0xef, 0x83, 0x0c, 0x85, 0x8a, 0xca, 0x6a,
+	0x97, 0x4a, 0xfa, 0x9c, 0x7b, 0x09, 0xcc, 0x48, 0x32, 0xf3, 0x72, 0x0e, 0xd5, 0x46, 0x56, 0x22,
+	0xfd, 0x21, 0xd5, 0xe9, 0x5c, 0xd2, 0x9d, 0xce, 0xf6, 0x3b, 0xd0, 0x50, 0x5b, 0x3f, 0x55, 0xee,
+	0x53, 0xa6, 0x76, 0x75, 0x40, 0xfe, 0xc5, 0x80, 0xd6, 0x4e, 0x18, 0x1c, 0xb2, 0x80, 0x02, 0xd7,
+	0x43, 0xd1, 0xfa, 0x29, 0xd6, 0x0f, 0xd9, 0x55, 0xdf, 0x0d, 0x12, 0xd1, 0xcf, 0x0c, 0x81, 0xa2,
+	0x1f, 0x44, 0xcc, 0x7d, 0xa6, 0x4c, 0x44, 0x09, 0xe3, 0xad, 0x48, 0x32, 0x1d, 0xa7, 0x29, 0x19,
+	0xd7, 0xec, 0x79, 0xad, 0xdb, 0xfb, 0x48, 0x26, 0xbc, 0x02, 0xaa, 0x82, 0xbb, 0x7a, 0x86, 0x3c,
+	0xd3, 0x41, 0xf3, 0x47, 0x25, 0xb0, 0x0a, 0x1a, 0xca, 0x4f, 0x82, 0x97, 0xf5, 0xf5, 0x7a, 0x71,
+	0xae, 0x70, 0x72, 0xd5, 0xbe, 0x9d, 0x5b, 0xb5, 0x2f, 0xdb, 0x27, 0xb7, 0x72, 0xe6, 0xb5, 0xbb,
+	0x68, 0x17, 0x6f, 0xef, 0x9f, 0xb4, 0x42, 0x5f, 0xd6, 0x67, 0xc2, 0xa2, 0x3e, 0x65, 0xfa, 0xba,
+	0x0e, 0xab, 0x32, 0x92, 0xf8, 0x48, 0xee, 0x42, 0xb3, 0xe1, 0x0b, 0xeb, 0x9f, 0x0c, 0xb8, 0xac,
+	0xd1, 0xe5, 0x15, 0xfa, 0x85, 0xd9, 0x10, 0xef, 0x1d, 0x7b, 0x51, 0x8d, 0xf9, 0x01, 0xdf, 0x45,
+	0x1b, 0x4c, 0xfb, 0xd1, 0x29, 0x82, 0xc1, 0xd7, 0x74, 0x45, 0xac, 0xe9, 0x72, 0xa8, 0xbd, 0x7f,
+	0x1f, 0x77, 0x13, 0x99, 0x52, 0xba, 0xe7, 0x7f, 0xc8, 0xe3, 0x64, 0x57, 0x00, 0x12, 0x76, 0x9c,
+	0x88, 0x0c, 0x37, 0x7e, 0xa0, 0xa8, 0x21, 0x86, 0x27, 0xb7, 0x3d, 0x0f, 0x8d, 0x03, 0x1f, 0xaf,
+	0x7e, 0x04, 0x01, 0x3f, 0x5b, 0xd4, 0x39, 0x8e, 0x48, 0xac, 0x0f, 0x61, 0x2d, 0xe3, 0x7b, 0x6f,
+	0x18, 0x1e, 0x14, 0x06, 0x31, 0xb2, 0x93, 0x74, 0x49, 0x3b, 0x49, 0x37, 0xa1, 0x9c, 0x99, 0x3d,
+	0xfc, 0x8b, 0xb5, 0x63, 0xff, 0x43, 0x99, 0xe1, 0x4a, 0xff, 0xb1, 0x36, 0x6f, 0x92, 0xb6, 0xc9,
+	0xaa, 0x23, 0x20, 0xeb, 0x8f, 0x0d, 0xb8, 0xa2, 0x77, 0xea, 0x14, 0x9b, 0x55, 0x5e, 0x07, 0x72,
+	0xda, 0xdf, 0x82, 0x95, 0xa1, 0x8b, 0xa1, 0xc0, 0x44, 0x89, 0x62, 0xa8, 0x1d, 0x73, 0x64, 0x39,
+	0x4a, 0x9d, 0x84, 0x63, 0x29, 0x75, 0x12, 0x8e, 0x17, 0x45, 0x9e, 0xac, 0x11, 0xac, 0x60, 0xc0,
+	0x61, 0xbb, 0xcf, 0xdd, 0xc7, 0x88, 0xb9, 0x49, 0x1a, 0x9f, 0x96, 0x20, 0x32, 0x18, 0x85, 0x9e,
+	0xdf, 0xf3, 0x53, 0xa7, 0x28, 0x85, 0xcd, 0x3b, 0x60, 0xd2, 0x26, 0x20, 0xee, 0x58, 0x44, 0xe8,
+	0x81, 0xb7, 0xde, 0xc4, 0x12, 0x7e, 0x47, 0xb1, 0x4d, 0x78, 0xeb, 0xc7, 0x25, 0x38, 0x2f, 0xda,
+	0xcb, 0x6b, 0xe3, 0x55, 0x3d, 0xd0, 0x63, 0xd9, 0xc5, 0x74, 0x05, 0x31, 0x9e, 0x36, 0x54, 0xc3,
+	0x68, 0x3c, 0x70, 0x03, 0x12, 0x8f, 0x56, 0xab, 0x84, 0xb5, 0x3d, 0xaa, 0xac, 0xed, 0x51, 0xfc,
+	0x56, 0x5e, 0x88, 0x4d, 0xa1, 0x47, 0xae, 0x9b, 0x86, 0x44, 0x62, 0x28, 0xd9, 0xb4, 0xa0, 0xa1,
+	0xa5, 0x56, 0x54, 0xe8, 0x26, 0x57, 0xc3, 0xe9, 0xe6, 0x62, 0x39, 0x67, 0x2e, 0xee, 0x9d, 0x10,
+	0x0b, 0xba, 0xaa, 0x2f, 0x92, 0xaa, 0xec, 0xb6, 0xba, 0x3c, 0x7e, 0xcf, 0xc0, 0xb0, 0x5d, 0xcf,
+	0xa5, 0x23, 0x4e, 0xd0, 0x3f, 0x69, 0xaf, 0xb0, 0xa0, 0x11, 0x65, 0xd4, 0x69, 0xee, 0xa5, 0x8a,
+	0xcb, 0x5c, 0xdf, 0xb2, 0xea, 0xfa, 0xde, 0x86, 0x0d, 0x85, 0xaa, 0xc3, 0x29, 0xb8, 0x5a, 0x9a,
+	0x4a, 0x01, 0xad, 0x5f, 0xeb, 0xcf, 0x4b, 0xd0, 0x56, 0xa4, 0x3a, 0x31, 0x1a, 0x92, 0xef, 0x81,
+	0x9c, 0xdb, 0x6f, 0xe5, 0x4c, 0xfa, 0x0d, 0x7b, 0x3e, 0xd7, 0x42, 0x53, 0x7e, 0x19, 0x6a, 0xc9,
+	0x20, 0x62, 0xf1, 0x20, 0x1c, 0x7a, 0x22, 0x1d, 0x33, 0x43, 0x2c, 0x8c, 0xbb, 0x2e, 0x74, 0xc5,
+	0x1e, 0x9d, 0x64, 0xe8, 0x0b, 0xc2, 0x78, 0xf9, 0x1e, 0x66, 0x63, 0xb8, 0x8d, 0x41, 0xf0, 0x43,
+	0x16, 0x25, 0xf1, 0x09, 0x77, 0x00, 0x74, 0xd0, 0x20, 0xc2, 0xec, 0x3a, 0x88, 0x40, 0xcb, 0x43,
+	0x6b, 0x86, 0x7f, 0xa5, 0xcf, 0x92, 0x26, 0xec, 0x1b, 0x4a, 0xc2, 0x3e, 0xe5, 0x37, 0x23, 0x55,
+	0x96, 0xdf, 0x8c, 0x50, 0x81, 0x35, 0xdb, 0x84, 0xca, 0x20, 0x9c, 0x44, 0x72, 0x84, 0x39, 0x60,
+	0xfd, 0xc2, 0x80, 0xf3, 0x42, 0xd2, 0xfc, 0x90, 0x5a, 0xfa, 0x90, 0x36, 0x6c, 0x41, 0xa7, 0x5a,
+	0xaa, 0xdb, 0x50, 0x8d, 0x84, 0x90, 0x8a, 0xa9, 0x52, 0xa5, 0x76, 0x52, 0x82, 0x6c, 0xcd, 0x97,
+	0xc5, 0x9a, 0x2f, 0x6e, 0xb8, 0x78, 0xcd, 0xcf, 0x1b, 0x55, 0xf4, 0x5a, 0x16, 0x2e, 0xb9, 0xf9,
+	0x5e, 0x4b, 0x08, 0x75, 0x7e, 0x6d, 0xf2, 0x18, 0xef, 0x5d, 0xa4, 0xca, 0x8c, 0x4c, 0x65, 0xf3,
+	0x9d, 0x4d, 0x4c, 0x39, 0xf7, 0x7b, 0x8c, 0x12, 0xba, 0x85, 0x3f, 0x21, 0x61, 0xac, 0x35, 0xe4,
+	0xfe, 0x79, 0xe6, 0x27, 0x13, 0x68, 0xb9, 0x70, 0x85, 0x37, 0xf8, 0x48, 0xd0, 0xe6, 0x55, 0x7e,
+	0x2d, 0xbd, 0x11, 0x92, 0x3a, 0x57, 0x04, 0x4c, 0xef, 0x87, 0x16, 0xdd, 0xbe, 0xfc, 0xb6, 0x01,
+	0x2b, 0x0e, 0x1b, 0x32, 0x37, 0xa6, 0x0e, 0x25, 0x6e, 0x5f, 0xea, 0x22, 0x71, 0xfb, 0x85, 0x4f,
+	0x3e, 0x0a, 0xf7, 0x3d, 0xc5, 0x42, 0xa6, 0x97, 0x33, 0x7a, 0x7c, 0x71, 0xf6, 0x28, 0xb1, 0xac,
+	0x46, 0x90, 0x7f, 0x4a, 0xfb, 0x21, 0xc9, 0xb1, 0xe3, 0x52, 0xce, 0xdf, 0x6c, 0x5f, 0xab, 0x11,
+	0x27, 0x90, 0xbd, 0xad, 0xda, 0xa2, 0x86, 0x93, 0x96, 0xa0, 0x57, 0x3f, 0x09, 0x04, 0xe4, 0x75,
+	0xf4, 0xd1, 0xd8, 0xc8, 0x4a, 0x76, 0xd2, 0xc4, 0x8c, 0xa6, 0x4a, 0x4e, 0x72, 0x89, 0x1c, 0x73,
+	0x85, 0x18, 0xd1, 0x98, 0x3b, 0x96, 0xb8, 0x7d, 0x19, 0x40, 0x90, 0xb9, 0x63, 0x89, 0xdb, 0x17,
+	0xf1, 0x03, 0xeb, 0x0f, 0x4b, 0x50, 0x7d, 0xdb, 0x0f, 0x7c, 0x5a, 0xc1, 0x9f, 0xca, 0xe7, 0x6d,
+	0x9c, 0xb7, 0x65, 0x59, 0x71, 0xd2, 0x86, 0xf9, 0x49, 0x69, 0x73, 0x4b, 0x22, 0x3e, 0x9e, 0xd2,
+	0x93, 0x41, 0x15, 0xf3, 0x9b, 0x48, 0x78, 0x18, 0x98, 0xaa, 0x75, 0xfa, 0x7e, 0xe0, 0x67, 0x27,
+	0x78, 0xc2, 0x61, 0x45, 0x74, 0x8f, 0x88, 0x96, 0x13, 0xf0, 0x33, 0x7c, 0x8d, 0x30, 0x58, 0xfc,
+	0x71, 0x52, 0x44, 0x70, 0x05, 0x65, 0x22, 0x9d, 0xa5, 0xa6, 0xf5, 0x3d, 0x03, 0xce, 0x61, 0xf3,
+	0xf9, 0xb1, 0xfd, 0x84, 0x6e, 0x3a, 0x6a, 0x69, 0xdf, 0xa5, 0xdd, 0xf8, 0x84, 0x0c, 0x01, 0x70,
+	0x63, 0xaa, 0x11, 0x20, 0xfe, 0x97, 0x76, 0xd8, 0xad, 0xbf, 0x35, 0xe0, 0xdc, 0x93, 0xe0, 0x20,
+	0x74, 0x23, 0xcf, 0x0f, 0xfa, 0x69, 0xb2, 0x04, 0x0e, 0x37, 0x57, 0x67, 0x27, 0xbd, 0xcd, 0xe6,
+	0xd1, 0xab, 0x91, 0x9f, 0xd0, 0xde, 0xff, 0xb6, 0x1e, 0x84, 0x2c, 0x89, 0xeb, 0xee, 0x02, 0x5e,
+	0x8b, 0xaf, 0xfe, 0x3e, 0xf6, 0xad, 0xdd, 0xfb, 0x5a, 0x07, 0xd2, 0x68, 0x6f, 0x3e, 0x69, 0xc7,
+	0xd0, 0x93, 0x76, 0xb0, 0x83, 0x23, 0xe6, 0xf9, 0x6e, 0xd0, 0x11, 0x37, 0xab, 0x38, 0x43, 0x80,
+	0xa3, 0xb0, 0x83, 0xd6, 0xb7, 0x4b, 0xd0, 0xcc, 0x18, 0x8b, 0x87, 0x10, 0x27, 0x71, 0xa5, 0xfd,
+	0xc9, 0xc5, 0x74, 0xd4, 0x6c, 0x7f, 0x22, 0x30, 0xdf, 0x5e, 0x39, 0xdf, 0x9e, 0xb9, 0xab, 0x2b,
+	0x74, 0x49, 0x18, 0xfd, 0xbc, 0x08, 0x27, 0x68, 0x73, 0xff, 0x54, 0xda, 0xfc, 0xa4, 0xbe, 0x39,
+	0x6f, 0xda, 0x05, 0x1a, 0x54, 0x75, 0xfc, 0xdf, 0x06, 0x5c, 0xcc, 0x48, 0xf2, 0xd3, 0x77, 0xfe,
+	0x76, 0x4d, 0xb3, 0x08, 0xa5, 0xce, 0x94, 0x4c, 0xb3, 0x08, 0x51, 0xbb, 0x3c, 0x2d, 0x65, 0xe6,
+	0x6e, 0xb7, 0x5c, 0x74, 0xb7, 0x6b, 0xde, 0xce, 0x5e, 0x7c, 0x2c, 0x09, 0x97, 0x29, 0xaf, 0x99,
+	0xf4, 0xcd, 0x87, 0x79, 0x27, 0xf7, 0x76, 0x62, 0xb3, 0x68, 0x5a, 0x16, 0x67, 0xbc, 0xe4, 0x3c,
+	0x54, 0xcb, 0x01, 0xd8, 0x67, 0xc1, 0x24, 0xe2, 0x87, 0xae, 0x26, 0x94, 0x03, 0x76, 0x24, 0x17,
+	0x7b, 0xc0, 0x28, 0xa7, 0x5a, 0xe4, 0x46, 0xc9, 0x0b, 0x45, 0x82, 0x70, 0x41, 0x7a, 0x6c, 0xec,
+	0x46, 0x49, 0x1a, 0x12, 0x4d, 0x61, 0xeb, 0x33, 0x92, 0x27, 0xdd, 0x22, 0x6d, 0x42, 0x85, 0x1e,
+	0x03, 0x0a, 0xae, 0x1c, 0xc0, 0x96, 0x58, 0x20, 0x27, 0x11, 0xfe, 0xb5, 0x0e, 0x60, 0x9d, 0xd7,
+	0xca, 0x16, 0xa9, 0xa9, 0xe4, 0x9a, 0x14, 0xec, 0x3c, 0xb9, 0x4d, 0xf8, 0x79, 0xa8, 0xe0, 0x4d,
+	0x96, 0xf4, 0x27, 0xea, 0x76, 0x26, 0x84, 0xc3, 0x4b, 0xac, 0x9f, 0x1b, 0xf0, 0x1c, 0xc7, 0x9e,
+	0x18, 0x72, 0xcd, 0xb4, 0x22, 0x8d, 0xd4, 0xcd, 0x9c, 0xab, 0xda, 0xb4, 0x73, 0xf2, 0x9e, 0x2a,
+	0xbc, 0x70, 0xaa, 0x83, 0x87, 0x7a, 0x70, 0xa9, 0xe8, 0x07, 0x97, 0x85, 0xa3, 0xf9, 0x9b, 0x06,
+	0xd4, 0x3f, 0x08, 0xa3, 0x67, 0x62, 0xcf, 0xca, 0x9c, 0x3c, 0x11, 0x47, 0x20, 0x80, 0x67, 0xff,
+	0xb0, 0x67, 0x4a, 0xc6, 0x45, 0x0a, 0x23, 0xfb, 0xb0, 0xd7, 0xeb, 0xf0, 0x5a, 0x42, 0xf6, 0xb0,
+	0xd7, 0x7b, 0x87, 0x2a, 0x5e, 0x83, 0xb5, 0xb4, 0x50, 0x0a, 0x8f, 0xd5, 0x1b, 0x92, 0x82, 0x0c,
+	0xcb, 0x37, 0xc1, 0x54, 0x64, 0x88, 0x29, 0x03, 0xf2, 0x19, 0xdd, 0x5d, 0x49, 0x45, 0x89, 0xa9,
+	0x90, 0x21, 0xb0, 0x59, 0xfe, 0x90, 0x14, 0x7b, 0x2c, 0x9c, 0x18, 0x42, 0x60, 0x97, 0x2f, 0xc0,
+	0x0a, 0xbe, 0x1e, 0xcd, 0xdc, 0x92, 0x65, 0x16, 0x78, 0x22, 0xa5, 0x0a, 0x05, 0x4f, 0x7d, 0x58,
+	0x02, 0xac, 0x8f, 0x4a, 0x70, 0x49, 0x15, 0x20, 0x3f, 0xd4, 0x6d, 0xa8, 0xa2, 0xb3, 0xf5, 0x61,
+	0x18, 0xa4, 0xd9, 0xe7, 0x12, 0xc6, 0x1e, 0x1e, 0x85, 0xd1, 0x33, 0x6c, 0xab, 0x13, 0x27, 0x6e,
+	0x24, 0xc3, 0x6d, 0x0d, 0xc4, 0xee, 0xba, 0x18, 0x62, 0x8d, 0x12, 0x73, 0x0b, 0x1a, 0x29, 0x15,
+	0xce, 0x62, 0x2e, 0x15, 0x08, 0x9a, 0xfb, 0x81, 0x87, 0xeb, 0x3e, 0x9e, 0xc4, 0x89, 0xeb, 0x07,
+	0xcc, 0xeb, 0xa8, 0x32, 0xae, 0xa5, 0xe8, 0x0f, 0x10, 0x8b, 0x2e, 0x9e, 0xb6, 0x94, 0x1b, 0xb6,
+	0x22, 0x7a, 0x3a, 0xa1, 0x5e, 0x12, 0x09, 0xa6, 0xcf, 0x62, 0x91, 0xa2, 0x78, 0xce, 0x9e, 0x55,
+	0xb1, 0x23, 0x69, 0x16, 0x5f, 0xdc, 0xde, 0x01, 0xf3, 0x8b, 0x41, 0x78, 0x34, 0x64, 0x5e, 0x9f,
+	0x3d, 0x76, 0xc7, 0xef, 0x93, 0x15, 0x52, 0x12, 0x6f, 0x71, 0xaa, 0x18, 0x32, 0xf1, 0xd6, 0xfa,
+	0x7e, 0x09, 0x2e, 0xa9, 0xe4, 0x79, 0x65, 0x2e, 0x7c, 0xa8, 0x51, 0x60, 0xfd, 0x4a, 0x85, 0xd6,
+	0x6f, 0x6b, 0x36, 0xe5, 0xa6, 0xa6, 0x27, 0xd0, 0x7c, 0x36, 0x4d, 0x04, 0x95, 0xe7, 0x52, 0xae,
+	0x86, 0xd9, 0xae, 0xc8, 0xec, 0x50, 0x1e, 0x49, 0x7b, 0x7d, 0x26, 0xcf, 0xb4, 0x32, 0xbf, 0x66,
+	0x2e, 0xf9, 0x74, 0xe1, 0x52, 0xfb, 0x0e, 0x26, 0x76, 0x31, 0xd7, 0xdb, 0x09, 0x3d, 0x6e, 0x3b,
+	0xb1, 0x0f, 0xac, 0xe7, 0x07, 0x3e, 0x7f, 0xb9, 0x29, 0x1e, 0xdb, 0x29, 0x28, 0x3c, 0x9a, 0x4f,
+	0x82, 0x2c, 0xf4, 0x2c, 0xa7, 0x96, 0x8a, 0xd3, 0xc2, 0x19, 0x72, 0xf9, 0x09, 0x18, 0xcb, 0x22,
+	0x16, 0x87, 0x43, 0xbc, 0x86, 0x12, 0xc7, 0x1e, 0x09, 0x5b, 0x07, 0xb0, 0x26, 0xa5, 0x79, 0x42,
+	0xf4, 0x85, 0xc7, 0x43, 0xe1, 0xdc, 0x97, 0x34, 0xe7, 0x5e, 0x5c, 0x25, 0x6a, 0x21, 0xb1, 0x78,
+	0x3a, 0x3a, 0x08, 0x87, 0xc2, 0x0b, 0x16, 0x10, 0x1e, 0x26, 0x2e, 0xc8, 0x46, 0x0a, 0x16, 0x55,
+	0x6a, 0xf2, 0x8c, 0x19, 0x93, 0x27, 0x6c, 0x6b, 0x49, 0xe6, 0x90, 0x29, 0x7a, 0x53, 0x82, 0x5c,
+	0xbc, 0xa3, 0xd9, 0x93, 0x47, 0xbd, 0x43, 0x8e, 0x2c, 0xb7, 0x26, 0xb0, 0xce, 0x87, 0x28, 0x4b,
+	0xb6, 0xc7, 0xf0, 0x7d, 0xc8, 0xd3, 0x4d, 0x64, 0xf3, 0x12, 0xc6, 0xb2, 0x80, 0xf5, 0x5d, 0x65,
+	0x13, 0x4b, 0x61, 0xdc, 0x4d, 0x02, 0x36, 0x49, 0x22, 0x71, 0xfb, 0x54, 0x71, 0x24, 0x88, 0xaa,
+	0x8a, 0x27, 0x23, 0xe1, 0x59, 0xe3, 0x5f, 0xeb, 0xef, 0xd2, 0x24, 0xd6, 0xb4, 0xdd, 0xb3, 0x68,
+	0x61, 0x13, 0x2a, 0x98, 0xb8, 0x98, 0xbe, 0x1b, 0x26, 0x20, 0x4b, 0x27, 0x28, 0x8b, 0x3d, 0x25,
+	0xd7, 0xc2, 0xec, 0xe6, 0xb3, 0x34, 0x87, 0xb0, 0x70, 0xbb, 0xcf, 0x85, 0x35, 0xac, 0xdf, 0x37,
+	0x60, 0x65, 0x51, 0x4a, 0xd7, 0xfc, 0xdd, 0x35, 0x3d, 0xd7, 0x95, 0xd5, 0x2b, 0xa2, 0x34, 0x92,
+	0xb4, 0xb4, 0x65, 0xcc, 0xbb, 0x19, 0xae, 0x48, 0xaf, 0x48, 0x62, 0xb0, 0x56, 0x4c, 0x59, 0x39,
+	0xcb, 0xa4, 0x5d, 0x0e, 0x58, 0x6f, 0xc1, 0x05, 0x21, 0x5a, 0x5c, 0x70, 0x38, 0x4c, 0x53, 0xae,
+	0xe4, 0xe1, 0x70, 0x26, 0x83, 0x0b, 0x83, 0xae, 0xab, 0xfb, 0x2c, 0x4e, 0x1c, 0x37, 0xf1, 0xc3,
+	0x2c, 0x88, 0x1c, 0x27, 0x1d, 0xf5, 0xa2, 0xb7, 0x86, 0x18, 0x6e, 0x1c, 0x6e, 0xd1, 0x9b, 0x7f,
+	0x6f, 0x42, 0xcf, 0x08, 0x3a, 0xf2, 0x78, 0x46, 0xc7, 0xc3, 0x0c, 0xcf, 0x49, 0x25, 0x27, 0x55,
+	0x07, 0xc4, 0x89, 0x9f, 0x1e, 0x75, 0x4e, 0x9c, 0x68, 0x29, 0xcf, 0x89, 0x48, 0xad, 0xaf, 0x42,
+	0x2b, 0x15, 0xf2, 0x2c, 0xf3, 0xe7, 0x9a, 0xbe, 0x8a, 0xd6, 0x6c, 0xad, 0xab, 0xf2, 0x8e, 0xe0,
+	0x6b, 0xb0, 0xf6, 0x7e, 0xd8, 0x75, 0x0f, 0x30, 0x9d, 0x69, 0x2a, 0xef, 0xb9, 0x13, 0x16, 0x8d,
+	0x64, 0xf7, 0x39, 0x80, 0x43, 0xe4, 0x07, 0x09, 0x89, 0x96, 0x5a, 0x22, 0x05, 0xc3, 0x1d, 0xfd,
+	0xc4, 0x8f, 0xd4, 0x1b, 0x6f, 0x02, 0xad, 0x6f, 0xc2, 0xba, 0xd2, 0x02, 0x31, 0xfb, 0x74, 0xd6,
+	0x04, 0x8a, 0x76, 0xc9, 0xce, 0x11, 0xd8, 0xf4, 0x2b, 0x2f, 0x97, 0xf0, 0x3f, 0x5d, 0x2e, 0xa5,
+	0xc8, 0x33, 0x9d, 0x87, 0x3e, 0x2a, 0xc1, 0xc5, 0x8c, 0xff, 0x59, 0x34, 0x78, 0x5d, 0xd7, 0xe0,
+	0xba, 0xad, 0x6b, 0x4a, 0x2e, 0xb5, 0x37, 0x64, 0x6f, 0xca, 0xe2, 0xcc, 0x37, 0xb7, 0xb5, 0xd9,
+	0x7e, 0x15, 0xac, 0xd3, 0x9c, 0x2e, 0x4e, 0xb5, 0x4e, 0x3f, 0x86, 0x7a, 0x8e, 0x29, 0x35, 0x3c,
+	0x8c, 0x92, 0xb7, 0x23, 0x77, 0x3c, 0x90, 0x33, 0x20, 0x08, 0xbd, 0x2c, 0xd3, 0x81, 0x00, 0xc4,
+	0xe2, 0xee, 0x27, 0x67, 0x3c, 0x07, 0xe8, 0x3a, 0x64, 0xda, 0x1d, 0xa6, 0xb1, 0x61, 0x01, 0x51,
+	0x48, 0x62, 0xda, 0x1d, 0xfa, 0xdd, 0x0e, 0x67, 0x25, 0x12, 0x1f, 0x39, 0xee, 0x5d, 0x44, 0x59,
+	0x4f, 0xb4, 0x96, 0xef, 0x7b, 0x7d, 0xfe, 0x58, 0x2d, 0x0a, 0x47, 0xa9, 0x89, 0x89, 0xc2, 0x91,
+	0xb9, 0x06, 0xa5, 0x24, 0x14, 0x46, 0xb0, 0x94, 0x84, 0x38, 0xd3, 0x7c, 0xaa, 0x26, 0x9b, 0x94,
+	0xa0, 0xf5, 0x3b, 0x06, 0xb4, 0x15, 0x8e, 0x67, 0x19, 0xea, 0x17, 0xf5, 0xa1, 0x6e, 0xda, 0x0a,
+	0x1f, 0x75, 0xac, 0x5f, 0x94, 0x4a, 0x28, 0xcf, 0xd2, 0x61, 0x0f, 0x84, 0x5a, 0xac, 0x04, 0xd6,
+	0xb6, 0x9f, 0x3e, 0xdc, 0x9b, 0x44, 0x3d, 0xb7, 0x9b, 0xe6, 0x71, 0xf3, 0x6d, 0x31, 0x3d, 0x14,
+	0x0a, 0xf0, 0xcc, 0x29, 0x24, 0x2d, 0x99, 0x3b, 0x29, 0x77, 0x75, 0x09, 0x5a, 0xdf, 0x82, 0x8d,
+	0xed, 0xa7, 0x0f, 0xef, 0x89, 0xcb, 0x5c, 0x91, 0xfa, 0xf9, 0x7f, 0xbe, 0xaf, 0xab, 0xa2, 0xf1,
+	0x5b, 0x2c, 0x09, 0x5a, 0x7f, 0x60, 0xc0, 0xc5, 0xac, 0xdf, 0x1f, 0x6b, 0xad, 0xe9, 0xea, 0x93,
+	0xfa, 0xff, 0x3c, 0x34, 0xe5, 0x5d, 0x75, 0x47, 0x26, 0x90, 0x96, 0x45, 0x66, 0xd6, 0x4c, 0xd7,
+	0x9d, 0xf5, 0x03, 0x0d, 0x8e, 0xad, 0xc7, 0x00, 0x3b, 0xc3, 0x30, 0x60, 0xf1, 0x82, 0x8c, 0x9e,
+	0x5b, 0xd0, 0xf4, 0x30, 0xeb, 0x88, 0x7f, 0x30, 0x43, 0x33, 0xf2, 0x19, 0x9e, 0x5f, 0x6a, 0x7c,
+	0x0d, 0x1a, 0x9c, 0xdd, 0x82, 0x08, 0xfb, 0xac, 0xaa, 0x8b, 0x6f, 0x53, 0x36, 0xd5, 0x8f, 0x21,
+	0xc8, 0x6c, 0x2e, 0xeb, 0x5b, 0xf0, 0x1c, 0x6f, 0xe1, 0x2c, 0xba, 0x7c, 0x5e, 0xd7, 0x65, 0xdd,
+	0xce, 0xfa, 0x2c, 0xf5, 0x78, 0x43, 0x7f, 0x3a, 0x48, 0x6f, 0x78, 0x95, 0x9e, 0x64, 0x2f, 0x09,
+	0xf7, 0xa1, 0xb1, 0xcf, 0xba, 0x83, 0x5d, 0x76, 0x90, 0xc8, 0xfc, 0xd8, 0x70, 0xcc, 0xe4, 0xe1,
+	0x9c, 0xfe, 0xcf, 0x99, 0xc0, 0xaa, 0xf7, 0x59, 0xce, 0x79, 0x9f, 0xbf, 0x6b, 0xc0, 0x9a, 0x64,
+	0xfb, 0xd8, 0x8d, 0x9e, 0xf1, 0xb3, 0xfb, 0x33, 0x3f, 0xf0, 0xa4, 0xee, 0xf0, 0x3f, 0xe2, 0xf0,
+	0x06, 0x57, 0xc6, 0x9b, 0xf1, 0x7f, 0xe1, 0x44, 0x95, 0x89, 0xe5, 0x4b, 0x7a, 0x62, 0xb9, 0xb8,
+	0x5e, 0xac, 0x68, 0x99, 0xcd, 0x62, 0x3c, 0x96, 0xd3, 0xf1, 0xc0, 0x6b, 0xc6, 0x0b, 0x52, 0x98,
+	0x8f, 0xe5, 0xa6, 0xaa, 0x8a, 0x92, 0x8a, 0x7e, 0x0d, 0x2a, 0xd8, 0x15, 0xa9, 0xe6, 0x17, 0xec,
+	0x39, 0x2d, 0xd9, 0x5f, 0x44, 0x2a, 0xb1, 0x35, 0x50, 0x0d, 0x7c, 0xa2, 0x14, 0x0e, 0x3d, 0x16,
+	0x27, 0x62, 0x6b, 0x58, 0xb7, 0x75, 0x95, 0x39, 0xa2, 0x18, 0x8f, 0xca, 0xf2, 0xf6, 0x20, 0x16,
+	0x49, 0x7b, 0x19, 0x62, 0xf1, 0x85, 0xe3, 0xab, 0x00, 0x59, 0xc3, 0x67, 0xda, 0x37, 0xfa, 0xb0,
+	0x26, 0x5e, 0x8b, 0xee, 0x52, 0xe2, 0xf6, 0x74, 0xce, 0x72, 0x7a, 0x01, 0x56, 0xc5, 0x83, 0x55,
+	0x6d, 0x2d, 0x35, 0x04, 0x92, 0x7b, 0x4b, 0xea, 0x2b, 0xd7, 0xb2, 0xcc, 0x51, 0xe6, 0xb0, 0xf5,
+	0x79, 0xd8, 0xd4, 0x1b, 0xda, 0x63, 0x74, 0xc2, 0xbb, 0xae, 0x47, 0x60, 0xd6, 0x6d, 0x9d, 0x4a,
+	0x3a, 0x38, 0xdf, 0x2b, 0xc1, 0x15, 0xbd, 0xe4, 0x2c, 0x63, 0x7c, 0x2b, 0xfb, 0xa6, 0x49, 0xa9,
+	0xb8, 0x19, 0x59, 0x6e, 0x7e, 0xa9, 0xe8, 0x19, 0xc8, 0xcb, 0xf6, 0xc2, 0xb6, 0x4f, 0x08, 0x5e,
+	0xbe, 0x77, 0xaa, 0xe0, 0xe5, 0x6d, 0x3d, 0x78, 0xf9, 0x9c, 0x5d, 0xa4, 0x2e, 0x75, 0xe8, 0x06,
+	0x98, 0xd7, 0x98, 0x3a, 0xd7, 0x97, 0xa1, 0xd6, 0x9b, 0x04, 0x5d, 0xf5, 0x14, 0x9a, 0x21, 0xc8,
+	0x35, 0x9f, 0x76, 0x87, 0xe1, 0xc8, 0x4d, 0xfc, 0x6e, 0x1a, 0xb0, 0x4c, 0x31, 0x3c, 0xd5, 0xa8,
+	0x1f, 0xf0, 0x93, 0x54, 0x59, 0xa6, 0x1a, 0x09, 0x04, 0xa6, 0x50, 0x36, 0xb3, 0xa6, 0xc4, 0xc0,
+	0xdd, 0xd5, 0x07, 0xee, 0xb2, 0x9d, 0xa7, 0xa0, 0xdc, 0xad, 0xd4, 0x4d, 0xc2, 0xff, 0xed, 0xfb,
+	0x00, 0x19, 0xb2, 0xe0, 0x8e, 0xe1, 0x79, 0x5d, 0x07, 0x75, 0x85, 0xa7, 0xda, 0xf3, 0x9f, 0x18,
+	0x60, 0x66, 0x25, 0x0f, 0x44, 0x2f, 0xe7, 0x3d, 0x56, 0xa1, 0xf7, 0xc0, 0x25, 0xe5, 0x3d, 0xf0,
+	0x67, 0xf4, 0xc3, 0xd7, 0x55, 0x7b, 0x96, 0xd7, 0xff, 0x9f, 0xec, 0x5f, 0x51, 0x55, 0x79, 0xa6,
+	0x0d, 0xe7, 0x79, 0xcc, 0x3e, 0x1e, 0xd2, 0xe7, 0x48, 0x66, 0x1b, 0xa0, 0x12, 0xeb, 0x1f, 0x4a,
+	0x70, 0x31, 0xc3, 0x9e, 0x6d, 0xe3, 0xce, 0xad, 0x10, 0x8d, 0xbd, 0x2c, 0x43, 0x27, 0x59, 0xbd,
+	0xbc, 0xbd, 0x6e, 0xcf, 0x6d, 0xad, 0xe0, 0xfe, 0xf6, 0xd3, 0xea, 0x14, 0x95, 0x91, 0x9c, 0x59,
+	0xdd, 0xab, 0xf3, 0xf6, 0xb6, 0x7a, 0xe1, 0x28, 0x1f, 0x58, 0xe8, 0xda, 0xcb, 0x1e, 0x48, 0x9f,
+	0xf9, 0x09, 0x4e, 0x7e, 0xc6, 0xea, 0x9f, 0x17, 0x6b, 0x4a, 0x81, 0x7e, 0xd9, 0xb7, 0x9c, 0xd6,
+	0x7f, 0x18, 0xb0, 0xaa, 0x31, 0x29, 0x7c, 0x9e, 0x2e, 0xa7, 0x6d, 0x49, 0x99, 0xb6, 0x33, 0x5f,
+	0x8f, 0x28, 0x17, 0x7c, 0x3d, 0x42, 0xcb, 0xfd, 0xd6, 0x4e, 0xed, 0x77, 0x44, 0x04, 0xbd, 0x22,
+	0xbe, 0x9c, 0xa5, 0x09, 0x91, 0x7f, 0xa0, 0xd9, 0xfe, 0xc2, 0xe2, 0x27, 0x94, 0x33, 0x6a, 0xcb,
+	0xeb, 0x45, 0x55, 0xdb, 0x23, 0xb8, 0xac, 0x15, 0xe7, 0xe7, 0xe0, 0x1d, 0xdd, 0x4c, 0xf1, 0x23,
+	0xad, 0x56, 0x43, 0x19, 0x7e, 0xeb, 0x9f, 0x4b, 0xb0, 0x96, 0x7e, 0xcc, 0x81, 0x9e, 0x4b, 0xa1,
+	0x7c, 0x11, 0xeb, 0xc9, 0x61, 0x8d, 0x58, 0x8f, 0xa7, 0xca, 0x8f, 0xe4, 0xe7, 0x82, 0xe8, 0x3f,
+	0x8d, 0x14, 0xda, 0x5b, 0xe9, 0x9c, 0x11, 0x80, 0x75, 0x31, 0x5d, 0x84, 0xbb, 0xc1, 0xf8, 0x57,
+	0xde, 0x7c, 0xf0, 0x4f, 0x82, 0xe0, 0x5f, 0x54, 0xea, 0x88, 0x7f, 0x31, 0x82, 0x9c, 0x8b, 0x9a,
+	0x23, 0x41, 0x55, 0xdd, 0x2b, 0x33, 0x41, 0x12, 0x3e, 0x2f, 0xaa, 0x73, 0xe6, 0x45, 0x4d, 0x77,
+	0xfd, 0x3f, 0x9b, 0x25, 0xe1, 0x83, 0x30, 0x9e, 0x7a, 0x2f, 0x6d, 0x9e, 0x3a, 0x25, 0x2f, 0x93,
+	0x05, 0x31, 0x7d, 0x34, 0x30, 0x9a, 0x60, 0x8c, 0xb0, 0xce, 0xd3, 0xce, 0x38, 0x84, 0xd7, 0xbe,
+	0x6a, 0x85, 0x33, 0x5d, 0xde, 0x7e, 0x1d, 0xae, 0xea, 0x6d, 0x17, 0x7c, 0xfe, 0xa6, 0x1a, 0x89,
+	0xa2, 0x74, 0x93, 0xd6, 0xab, 0x38, 0x29, 0x81, 0xee, 0xa6, 0x94, 0x72, 0x61, 0xa8, 0xbf, 0xc2,
+	0x7d, 0x84, 0x7c, 0x78, 0x94, 0x33, 0x1c, 0xd3, 0xb7, 0x10, 0x5a, 0xea, 0x1b, 0x32, 0xe5, 0x1c,
+	0xa4, 0xf8, 0xd2, 0xf2, 0x11, 0x33, 0x02, 0xb3, 0x41, 0x63, 0x1e, 0x70, 0xcd, 0x50, 0xfc, 0x51,
+	0xc0, 0x90, 0x75, 0x18, 0x6f, 0x44, 0x04, 0xf3, 0xe8, 0x2b, 0x3d, 0xa2, 0x5d, 0x4c, 0x7a, 0xca,
+	0x42, 0xd4, 0x92, 0x8e, 0xa7, 0xbc, 0x67, 0xdf, 0xb1, 0x11, 0xc4, 0xd6, 0xdf, 0xe3, 0x57, 0x94,
+	0x54, 0xb1, 0xcf, 0x7a, 0x4e, 0x90, 0x26, 0x73, 0x7e, 0x2f, 0x96, 0x4e, 0xee, 0x45, 0xe5, 0x94,
+	0xbd, 0x58, 0x9e, 0xd3, 0x8b, 0x8f, 0x4a, 0x70, 0x59, 0xeb, 0x45, 0x7e, 0x9c, 0xdf, 0xd0, 0x9e,
+	0x78, 0xdf, 0xb0, 0x17, 0x11, 0x17, 0x3c, 0xc4, 0xd7, 0xbc, 0xe8, 0x0d, 0x3b, 0x3f, 0xce, 0xd2,
+	0x93, 0xb6, 0xf3, 0x47, 0x96, 0x4d, 0xbb, 0x40, 0xb7, 0x5a, 0x8e, 0xcd, 0xdc, 0xa4, 0x9f, 0xb3,
+	0x1a, 0xae, 0x59, 0x99, 0xb2, 0x75, 0x70, 0x0b, 0xd6, 0xef, 0x1f, 0x8f, 0x59, 0x94, 0xf8, 0x31,
+	0xcb, 0x2e, 0x47, 0xe2, 0x81, 0x1b, 0x65, 0x97, 0x23, 0x1c, 0xb2, 0x7e, 0x52, 0x82, 0x56, 0x4a,
+	0x7b, 0xa6, 0x9b, 0x91, 0xcb, 0x6a, 0xa6, 0x2e, 0x5f, 0x1d, 0x19, 0xe2, 0x14, 0xd7, 0x21, 0x6f,
+	0x40, 0x53, 0x5e, 0x87, 0xa4, 0x6c, 0x64, 0xc0, 0x29, 0x27, 0xbd, 0xb3, 0x2e, 0xee, 0x43, 0x52,
+	0xf6, 0x6f, 0xa5, 0xdf, 0xd2, 0x53, 0x5b, 0xa9, 0xcc, 0xa9, 0x2e, 0xbe, 0xa0, 0xa7, 0x38, 0xae,
+	0xca, 0xc7, 0x3b, 0xf8, 0x57, 0x03, 0xf8, 0xad, 0x94, 0x21, 0xef, 0x4f, 0x3e, 0xe0, 0xc8, 0xc5,
+	0xd7, 0x50, 0xff, 0x69, 0x40, 0x8b, 0x7f, 0xfe, 0xad, 0xe0, 0x91, 0xdd, 0xd6, 0xec, 0x0b, 0xb0,
+	0x9c, 0x02, 0xee, 0x43, 0x36, 0xb1, 0x3b, 0xe2, 0x93, 0x75, 0x27, 0x7f, 0x34, 0x2d, 0xbb, 0x8e,
+	0xe2, 0x4d, 0xab, 0x6b, 0x52, 0x79, 0x73, 0xf5, 0x06, 0xd0, 0xea, 0x92, 0x7c, 0x97, 0x4e, 0xe4,
+	0x4b, 0xdf, 0xd0, 0x12, 0x2c, 0x17, 0xc6, 0xdf, 0x7f, 0x64, 0xc0, 0xfa, 0xec, 0xd5, 0xf3, 0xf2,
+	0x80, 0xb9, 0x9e, 0xb8, 0x16, 0xc5, 0xec, 0x17, 0xf9, 0x85, 0x57, 0x47, 0x14, 0x98, 0xaf, 0xe3,
+	0x79, 0x2a, 0x48, 0xd2, 0xaf, 0x06, 0xa1, 0xaf, 0x9a, 0x5f, 0x88, 0x3b, 0x82, 0x20, 0xfd, 0xc2,
+	0x13, 0x07, 0xf9, 0x17, 0x9e, 0x94, 0xa2, 0x93, 0x4e, 0x85, 0x0d, 0x65, 0x31, 0x1c, 0x2c, 0xd3,
+	0x27, 0x84, 0x5f, 0xf9, 0xdf, 0x01, 0x00, 0xc9, 0xba, 0x1d, 0xd8, 0x4e, 0x58, 0x00, 0x00,
 }
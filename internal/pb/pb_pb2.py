@@ -19,7 +19,7 @@ DESCRIPTOR = _descriptor.FileDescriptor(
   name='pb.proto',
   package='',
   syntax='proto3',
-  serialized_pb=_b('\n\x08pb.proto\"\x81\x02\n\x08Metadata\x12\x0f\n\x07version\x18\x01 \x01(\x05\x12\x0c\n\x04hash\x18\x02 \x01(\t\x12\x12\n\nrepository\x18\x03 \x01(\t\x12\x17\n\x0f\x62\x65gin_unix_time\x18\x04 \x01(\x03\x12\x15\n\rend_unix_time\x18\x05 \x01(\x03\x12\x0f\n\x07\x63ommits\x18\x06 \x01(\x05\x12\x10\n\x08run_time\x18\x07 \x01(\x03\x12\x38\n\x11run_time_per_item\x18\x08 \x03(\x0b\x32\x1d.Metadata.RunTimePerItemEntry\x1a\x35\n\x13RunTimePerItemEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x01:\x02\x38\x01\"*\n\x17\x42urndownSparseMatrixRow\x12\x0f\n\x07\x63olumns\x18\x01 \x03(\r\"\x7f\n\x14\x42urndownSparseMatrix\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x16\n\x0enumber_of_rows\x18\x02 \x01(\x05\x12\x19\n\x11number_of_columns\x18\x03 \x01(\x05\x12&\n\x04rows\x18\x04 \x03(\x0b\x32\x18.BurndownSparseMatrixRow\"\xed\x01\n\x17\x42urndownAnalysisResults\x12\x13\n\x0bgranularity\x18\x01 \x01(\x05\x12\x10\n\x08sampling\x18\x02 \x01(\x05\x12&\n\x07project\x18\x03 \x01(\x0b\x32\x15.BurndownSparseMatrix\x12$\n\x05\x66iles\x18\x04 \x03(\x0b\x32\x15.BurndownSparseMatrix\x12%\n\x06people\x18\x05 \x03(\x0b\x32\x15.BurndownSparseMatrix\x12\x36\n\x12people_interaction\x18\x06 \x01(\x0b\x32\x1a.CompressedSparseRowMatrix\"}\n\x19\x43ompressedSparseRowMatrix\x12\x16\n\x0enumber_of_rows\x18\x01 \x01(\x05\x12\x19\n\x11number_of_columns\x18\x02 \x01(\x05\x12\x0c\n\x04\x64\x61ta\x18\x03 \x03(\x03\x12\x0f\n\x07indices\x18\x04 \x03(\x05\x12\x0e\n\x06indptr\x18\x05 \x03(\x03\"D\n\x07\x43ouples\x12\r\n\x05index\x18\x01 \x03(\t\x12*\n\x06matrix\x18\x02 \x01(\x0b\x32\x1a.CompressedSparseRowMatrix\"\x1d\n\x0cTouchedFiles\x12\r\n\x05\x66iles\x18\x01 \x03(\x05\"\x7f\n\x16\x43ouplesAnalysisResults\x12\x1e\n\x0c\x66ile_couples\x18\x06 \x01(\x0b\x32\x08.Couples\x12 \n\x0epeople_couples\x18\x07 \x01(\x0b\x32\x08.Couples\x12#\n\x0cpeople_files\x18\x08 \x03(\x0b\x32\r.TouchedFiles\"o\n\nUASTChange\x12\x11\n\tfile_name\x18\x01 \x01(\t\x12\x12\n\nsrc_before\x18\x02 \x01(\t\x12\x11\n\tsrc_after\x18\x03 \x01(\t\x12\x13\n\x0buast_before\x18\x04 \x01(\t\x12\x12\n\nuast_after\x18\x05 \x01(\t\"7\n\x17UASTChangesSaverResults\x12\x1c\n\x07\x63hanges\x18\x01 \x03(\x0b\x32\x0b.UASTChange\"\xb4\x01\n\x0eShotnessRecord\x12\x15\n\rinternal_role\x18\x01 \x01(\t\x12\r\n\x05roles\x18\x02 \x03(\x05\x12\x0c\n\x04name\x18\x03 \x01(\t\x12\x0c\n\x04\x66ile\x18\x04 \x01(\t\x12/\n\x08\x63ounters\x18\x05 \x03(\x0b\x32\x1d.ShotnessRecord.CountersEntry\x1a/\n\rCountersEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\";\n\x17ShotnessAnalysisResults\x12 \n\x07records\x18\x01 \x03(\x0b\x32\x0f.ShotnessRecord\"\x1e\n\x0b\x46ileHistory\x12\x0f\n\x07\x63ommits\x18\x01 \x03(\t\"\x8b\x01\n\x18\x46ileHistoryResultMessage\x12\x33\n\x05\x66iles\x18\x01 \x03(\x0b\x32$.FileHistoryResultMessage.FilesEntry\x1a:\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1b\n\x05value\x18\x02 \x01(\x0b\x32\x0c.FileHistory:\x02\x38\x01\"=\n\tSentiment\x12\r\n\x05value\x18\x01 \x01(\x02\x12\x10\n\x08\x63omments\x18\x02 \x03(\t\x12\x0f\n\x07\x63ommits\x18\x03 \x03(\t\"\xa4\x01\n\x17\x43ommentSentimentResults\x12\x46\n\x10sentiment_by_day\x18\x01 \x03(\x0b\x32,.CommentSentimentResults.SentimentByDayEntry\x1a\x41\n\x13SentimentByDayEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.Sentiment:\x02\x38\x01\"\x8f\x01\n\x0f\x41nalysisResults\x12\x19\n\x06header\x18\x01 \x01(\x0b\x32\t.Metadata\x12\x30\n\x08\x63ontents\x18\x02 \x03(\x0b\x32\x1e.AnalysisResults.ContentsEntry\x1a/\n\rContentsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01\x62\x06proto3')
+  serialized_pb=_b('\n\x08pb.proto\"\xac\x04\n\x08Metadata\x12\x0f\n\x07version\x18\x01 \x01(\x05\x12\x0c\n\x04hash\x18\x02 \x01(\t\x12\x12\n\nrepository\x18\x03 \x01(\t\x12\x17\n\x0f\x62\x65gin_unix_time\x18\x04 \x01(\x03\x12\x15\n\rend_unix_time\x18\x05 \x01(\x03\x12\x0f\n\x07\x63ommits\x18\x06 \x01(\x05\x12\x10\n\x08run_time\x18\x07 \x01(\x03\x12\x38\n\x11run_time_per_item\x18\x08 \x03(\x0b\x32\x1d.Metadata.RunTimePerItemEntry\x12\x37\n\x10profile_per_item\x18\t \x03(\x0b\x32\x1d.Metadata.ProfilePerItemEntry\x12 \n\x0b\x61nnotations\x18\n \x03(\x0b\x32\x0b.Annotation\x12\x0e\n\x06people\x18\x0b \x03(\t\x12\x34\n\x0eunparsed_files\x18\x0c \x03(\x0b\x32\x1c.Metadata.UnparsedFilesEntry\x1a\x35\n\x13RunTimePerItemEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x01:\x02\x38\x01\x1a\x43\n\x13ProfilePerItemEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1b\n\x05value\x18\x02 \x01(\x0b\x32\x0c.ItemProfile:\x02\x38\x01\x1a\x43\n\x12UnparsedFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1c\n\x05value\x18\x02 \x01(\x0b\x32\r.UnparsedFile:\x02\x38\x01\"0\n\x0cUnparsedFile\x12\x10\n\x08language\x18\x01 \x01(\t\x12\x0e\n\x06reason\x18\x02 \x01(\t\".\n\nAnnotation\x12\x11\n\tunix_time\x18\x01 \x01(\x03\x12\r\n\x05label\x18\x02 \x01(\t\"o\n\x0bItemProfile\x12\x11\n\twall_time\x18\x01 \x01(\x01\x12\x10\n\x08\x63pu_time\x18\x02 \x01(\x01\x12\x13\n\x0b\x61llocations\x18\x03 \x01(\x03\x12\x17\n\x0f\x61llocated_bytes\x18\x04 \x01(\x03\x12\r\n\x05\x63\x61lls\x18\x05 \x01(\x03\"*\n\x17\x42urndownSparseMatrixRow\x12\x0f\n\x07\x63olumns\x18\x01 \x03(\r\"\x94\x01\n\x14\x42urndownSparseMatrix\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x16\n\x0enumber_of_rows\x18\x02 \x01(\x05\x12\x19\n\x11number_of_columns\x18\x03 \x01(\x05\x12&\n\x04rows\x18\x04 \x03(\x0b\x32\x18.BurndownSparseMatrixRow\x12\x13\n\x0bpre_history\x18\x05 \x03(\x03\"A\n\x0e\x42urndownCohort\x12\r\n\x05lines\x18\x01 \x01(\x03\x12\r\n\x05\x63urve\x18\x02 \x03(\x02\x12\x11\n\thalf_life\x18\x03 \x01(\x02\"V\n\x10\x42urndownSurvival\x12\r\n\x05\x63urve\x18\x01 \x03(\x02\x12\x11\n\thalf_life\x18\x02 \x01(\x02\x12 \n\x07\x63ohorts\x18\x03 \x03(\x0b\x32\x0f.BurndownCohort\"\x8a\x03\n\x17\x42urndownAnalysisResults\x12\x13\n\x0bgranularity\x18\x01 \x01(\x05\x12\x10\n\x08sampling\x18\x02 \x01(\x05\x12&\n\x07project\x18\x03 \x01(\x0b\x32\x15.BurndownSparseMatrix\x12$\n\x05\x66iles\x18\x04 \x03(\x0b\x32\x15.BurndownSparseMatrix\x12%\n\x06people\x18\x05 \x03(\x0b\x32\x15.BurndownSparseMatrix\x12\x36\n\x12people_interaction\x18\x06 \x01(\x0b\x32\x1a.CompressedSparseRowMatrix\x12 \n\tsnapshots\x18\x07 \x03(\x0b\x32\r.FileSnapshot\x12*\n\x0b\x64irectories\x18\x08 \x03(\x0b\x32\x15.BurndownSparseMatrix\x12(\n\tlanguages\x18\t \x03(\x0b\x32\x15.BurndownSparseMatrix\x12#\n\x08survival\x18\n \x01(\x0b\x32\x11.BurndownSurvival\"\xca\x01\n\x0c\x46ileSnapshot\x12\x0c\n\x04name\x18\x01 \x01(\t\x12%\n\x04\x61ges\x18\x02 \x03(\x0b\x32\x17.FileSnapshot.AgesEntry\x12)\n\x06owners\x18\x03 \x03(\x0b\x32\x19.FileSnapshot.OwnersEntry\x1a+\n\tAgesEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x03:\x02\x38\x01\x1a-\n\x0bOwnersEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x03:\x02\x38\x01\"}\n\x19\x43ompressedSparseRowMatrix\x12\x16\n\x0enumber_of_rows\x18\x01 \x01(\x05\x12\x19\n\x11number_of_columns\x18\x02 \x01(\x05\x12\x0c\n\x04\x64\x61ta\x18\x03 \x03(\x03\x12\x0f\n\x07indices\x18\x04 \x03(\x05\x12\x0e\n\x06indptr\x18\x05 \x03(\x03\"D\n\x07\x43ouples\x12\r\n\x05index\x18\x01 \x03(\t\x12*\n\x06matrix\x18\x02 \x01(\x0b\x32\x1a.CompressedSparseRowMatrix\"\x1d\n\x0cTouchedFiles\x12\r\n\x05\x66iles\x18\x01 \x03(\x05\"m\n\x13\x43ouplesSignificance\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\x0c\n\x04lift\x18\x02 \x03(\x02\x12\x12\n\nchi_square\x18\x03 \x03(\x02\x12\x0f\n\x07jaccard\x18\x04 \x03(\x02\x12\x12\n\nconfidence\x18\x05 \x03(\x02\"`\n\x0c\x43ouplesDecay\x12\x11\n\thalf_life\x18\x01 \x01(\x05\x12\x0e\n\x06window\x18\x02 \x01(\x05\x12\x0e\n\x06indptr\x18\x03 \x03(\x03\x12\x0f\n\x07indices\x18\x04 \x03(\x05\x12\x0c\n\x04\x64\x61ta\x18\x05 \x03(\x02\"\xf8\x01\n\x16\x43ouplesAnalysisResults\x12\x1e\n\x0c\x66ile_couples\x18\x06 \x01(\x0b\x32\x08.Couples\x12 \n\x0epeople_couples\x18\x07 \x01(\x0b\x32\x08.Couples\x12#\n\x0cpeople_files\x18\x08 \x03(\x0b\x32\r.TouchedFiles\x12/\n\x11\x66ile_significance\x18\t \x01(\x0b\x32\x14.CouplesSignificance\x12!\n\nfile_decay\x18\n \x01(\x0b\x32\r.CouplesDecay\x12#\n\x11\x64irectory_couples\x18\x0b \x01(\x0b\x32\x08.Couples\"o\n\nUASTChange\x12\x11\n\tfile_name\x18\x01 \x01(\t\x12\x12\n\nsrc_before\x18\x02 \x01(\t\x12\x11\n\tsrc_after\x18\x03 \x01(\t\x12\x13\n\x0buast_before\x18\x04 \x01(\t\x12\x12\n\nuast_after\x18\x05 \x01(\t\"7\n\x17UASTChangesSaverResults\x12\x1c\n\x07\x63hanges\x18\x01 \x03(\x0b\x32\x0b.UASTChange\"\xb4\x01\n\x0eShotnessRecord\x12\x15\n\rinternal_role\x18\x01 \x01(\t\x12\r\n\x05roles\x18\x02 \x03(\x05\x12\x0c\n\x04name\x18\x03 \x01(\t\x12\x0c\n\x04\x66ile\x18\x04 \x01(\t\x12/\n\x08\x63ounters\x18\x05 \x03(\x0b\x32\x1d.ShotnessRecord.CountersEntry\x1a/\n\rCountersEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\";\n\x17ShotnessAnalysisResults\x12 \n\x07records\x18\x01 \x03(\x0b\x32\x0f.ShotnessRecord\"\x1e\n\x0b\x46ileHistory\x12\x0f\n\x07\x63ommits\x18\x01 \x03(\t\"\x8b\x01\n\x18\x46ileHistoryResultMessage\x12\x33\n\x05\x66iles\x18\x01 \x03(\x0b\x32$.FileHistoryResultMessage.FilesEntry\x1a:\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1b\n\x05value\x18\x02 \x01(\x0b\x32\x0c.FileHistory:\x02\x38\x01\"=\n\tSentiment\x12\r\n\x05value\x18\x01 \x01(\x02\x12\x10\n\x08\x63omments\x18\x02 \x03(\t\x12\x0f\n\x07\x63ommits\x18\x03 \x03(\t\"\xa4\x01\n\x17\x43ommentSentimentResults\x12\x46\n\x10sentiment_by_day\x18\x01 \x03(\x0b\x32,.CommentSentimentResults.SentimentByDayEntry\x1a\x41\n\x13SentimentByDayEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.Sentiment:\x02\x38\x01\"B\n\x0eRecordedColumn\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x0e\n\x06values\x18\x02 \x03(\x03\x12\x12\n\ndictionary\x18\x03 \x03(\t\"@\n\x0eRecordedStream\x12\x0c\n\x04name\x18\x01 \x01(\t\x12 \n\x07\x63olumns\x18\x02 \x03(\x0b\x32\x0f.RecordedColumn\"D\n\x0fRecorderResults\x12\x0f\n\x07\x63ommits\x18\x01 \x03(\t\x12 \n\x07streams\x18\x02 \x03(\x0b\x32\x0f.RecordedStream\"i\n\x0b\x41\x63tivityDay\x12*\n\x07\x63ommits\x18\x01 \x03(\x0b\x32\x19.ActivityDay.CommitsEntry\x1a.\n\x0c\x43ommitsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"&\n\x10\x41\x63tiveDevelopers\x12\x12\n\ndevelopers\x18\x01 \x03(\x05\"\xd6\x02\n\x17\x41\x63tivityAnalysisResults\x12\x30\n\x04\x64\x61ys\x18\x01 \x03(\x0b\x32\".ActivityAnalysisResults.DaysEntry\x12\x16\n\x0epeople_commits\x18\x02 \x03(\x05\x12\x14\n\x0cpeople_files\x18\x03 \x03(\x05\x12\x11\n\tdev_index\x18\x04 \x03(\t\x12\x15\n\ractive_window\x18\x05 \x01(\x05\x12\x34\n\x06\x61\x63tive\x18\x06 \x03(\x0b\x32$.ActivityAnalysisResults.ActiveEntry\x1a\x39\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x1b\n\x05value\x18\x02 \x01(\x0b\x32\x0c.ActivityDay:\x02\x38\x01\x1a@\n\x0b\x41\x63tiveEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12 \n\x05value\x18\x02 \x01(\x0b\x32\x11.ActiveDevelopers:\x02\x38\x01\"u\n\x0eLanguageCounts\x12\x31\n\tlanguages\x18\x01 \x03(\x0b\x32\x1e.LanguageCounts.LanguagesEntry\x1a\x30\n\x0eLanguagesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"\xcb\x01\n\x1e\x43ommitLanguagesAnalysisResults\x12\x37\n\x04\x64\x61ys\x18\x01 \x03(\x0b\x32).CommitLanguagesAnalysisResults.DaysEntry\x12\x1f\n\x06people\x18\x02 \x03(\x0b\x32\x0f.LanguageCounts\x12\x11\n\tdev_index\x18\x03 \x03(\t\x1a<\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x1e\n\x05value\x18\x02 \x01(\x0b\x32\x0f.LanguageCounts:\x02\x38\x01\"B\n\x0eImpactChurnDay\x12\r\n\x05\x61\x64\x64\x65\x64\x18\x01 \x01(\x05\x12\x0f\n\x07removed\x18\x02 \x01(\x05\x12\x10\n\x08weighted\x18\x03 \x01(\x01\"B\n\x0fImpactChurnFile\x12\r\n\x05lines\x18\x01 \x01(\x05\x12\x0e\n\x06\x66\x61n_in\x18\x02 \x01(\x05\x12\x10\n\x08weighted\x18\x03 \x01(\x01\"\x9b\x02\n\x1aImpactChurnAnalysisResults\x12\x33\n\x04\x64\x61ys\x18\x01 \x03(\x0b\x32%.ImpactChurnAnalysisResults.DaysEntry\x12\x35\n\x05\x66iles\x18\x02 \x03(\x0b\x32&.ImpactChurnAnalysisResults.FilesEntry\x12\x13\n\x0b\x66\x61n_in_mode\x18\x03 \x01(\t\x1a<\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x1e\n\x05value\x18\x02 \x01(\x0b\x32\x0f.ImpactChurnDay:\x02\x38\x01\x1a>\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1f\n\x05value\x18\x02 \x01(\x0b\x32\x10.ImpactChurnFile:\x02\x38\x01\"m\n\x0c\x44\x41GShapeTick\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\x0e\n\x06merges\x18\x02 \x01(\x05\x12\x0f\n\x07parents\x18\x03 \x01(\x05\x12\x14\n\x0cmax_branches\x18\x04 \x01(\x05\x12\x15\n\rlongest_chain\x18\x05 \x01(\x05\"I\n\x17\x44\x41GShapeAnalysisResults\x12\x1c\n\x05ticks\x18\x01 \x03(\x0b\x32\r.DAGShapeTick\x12\x10\n\x08sampling\x18\x02 \x01(\x05\";\n\x0bRenameChain\x12\r\n\x05names\x18\x01 \x03(\t\x12\x0c\n\x04\x64\x61ys\x18\x02 \x03(\x05\x12\x0f\n\x07\x64\x65leted\x18\x03 \x01(\x08\"\xf3\x01\n\x1eRenameFrequencyAnalysisResults\x12\r\n\x05ticks\x18\x01 \x03(\x05\x12\x1c\n\x06\x63hains\x18\x02 \x03(\x0b\x32\x0c.RenameChain\x12\x45\n\x0b\x64irectories\x18\x03 \x03(\x0b\x32\x30.RenameFrequencyAnalysisResults.DirectoriesEntry\x12\x10\n\x08sampling\x18\x04 \x01(\x05\x12\x17\n\x0f\x64irectory_depth\x18\x05 \x01(\x05\x1a\x32\n\x10\x44irectoriesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"!\n\x10RewriteDepthFile\x12\r\n\x05lines\x18\x01 \x03(\x05\"K\n\x0eRewriteHotspot\x12\x0c\n\x04\x66ile\x18\x01 \x01(\t\x12\x0c\n\x04line\x18\x02 \x01(\x05\x12\x0e\n\x06length\x18\x03 \x01(\x05\x12\r\n\x05\x64\x65pth\x18\x04 \x01(\x05\"\xe6\x01\n\x1bRewriteDepthAnalysisResults\x12\x36\n\x05\x66iles\x18\x01 \x03(\x0b\x32\'.RewriteDepthAnalysisResults.FilesEntry\x12!\n\x08hotspots\x18\x02 \x03(\x0b\x32\x0f.RewriteHotspot\x12\x15\n\rhotspot_depth\x18\x03 \x01(\x05\x12\x14\n\x0cmax_hotspots\x18\x04 \x01(\x05\x1a?\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12 \n\x05value\x18\x02 \x01(\x0b\x32\x11.RewriteDepthFile:\x02\x38\x01\"`\n\x13SensitivePathChange\x12\x0e\n\x06\x63ommit\x18\x01 \x01(\t\x12\x0e\n\x06\x61uthor\x18\x02 \x01(\x05\x12\x0c\n\x04tick\x18\x03 \x01(\x05\x12\x0c\n\x04\x66ile\x18\x04 \x01(\t\x12\r\n\x05\x63hurn\x18\x05 \x01(\x05\"}\n\x1dSensitivePathsAnalysisResults\x12%\n\x07\x63hanges\x18\x01 \x03(\x0b\x32\x14.SensitivePathChange\x12\x10\n\x08sampling\x18\x02 \x01(\x05\x12\x10\n\x08patterns\x18\x03 \x03(\t\x12\x11\n\tdev_index\x18\x04 \x03(\t\"C\n\x10\x43ontributorsTick\x12\x0c\n\x04\x63ore\x18\x01 \x03(\x05\x12\x0f\n\x07regular\x18\x02 \x03(\x05\x12\x10\n\x08\x64rive_by\x18\x03 \x03(\x05\"\xbe\x01\n\x1b\x43ontributorsAnalysisResults\x12 \n\x05ticks\x18\x01 \x03(\x0b\x32\x11.ContributorsTick\x12\x10\n\x08sampling\x18\x02 \x01(\x05\x12\x14\n\x0c\x63ore_commits\x18\x03 \x01(\x05\x12\x11\n\tcore_span\x18\x04 \x01(\x05\x12\x18\n\x10\x64rive_by_commits\x18\x05 \x01(\x05\x12\x15\n\rdrive_by_span\x18\x06 \x01(\x05\x12\x11\n\tdev_index\x18\x07 \x03(\t\"1\n\x11StewardshipCounts\x12\x0c\n\x04self\x18\x01 \x01(\x03\x12\x0e\n\x06others\x18\x02 \x01(\x03\"\x82\x01\n\x0fStewardshipTick\x12,\n\x06people\x18\x01 \x03(\x0b\x32\x1c.StewardshipTick.PeopleEntry\x1a\x41\n\x0bPeopleEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12!\n\x05value\x18\x02 \x01(\x0b\x32\x12.StewardshipCounts:\x02\x38\x01\"b\n\x1aStewardshipAnalysisResults\x12\x1f\n\x05ticks\x18\x01 \x03(\x0b\x32\x10.StewardshipTick\x12\x10\n\x08sampling\x18\x02 \x01(\x05\x12\x11\n\tdev_index\x18\x03 \x03(\t\"\xb5\x01\n\x16TeamAlignmentDirectory\x12\x11\n\tdirectory\x18\x01 \x01(\t\x12\x31\n\x05\x65\x64its\x18\x02 \x03(\x0b\x32\".TeamAlignmentDirectory.EditsEntry\x12\r\n\x05owner\x18\x03 \x01(\x05\x12\x18\n\x10\x63ross_team_edits\x18\x04 \x01(\x05\x1a,\n\nEditsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"A\n\x11TeamAlignmentTick\x12,\n\x0b\x64irectories\x18\x01 \x03(\x0b\x32\x17.TeamAlignmentDirectory\"u\n\x1cTeamAlignmentAnalysisResults\x12!\n\x05ticks\x18\x01 \x03(\x0b\x32\x12.TeamAlignmentTick\x12\x10\n\x08sampling\x18\x02 \x01(\x05\x12\r\n\x05\x64\x65pth\x18\x03 \x01(\x05\x12\x11\n\tdev_index\x18\x04 \x03(\t\"\x88\x02\n\x1d\x44\x65\x66\x65\x63tFeaturesAnalysisResults\x12\r\n\x05\x66iles\x18\x01 \x03(\t\x12\x0c\n\x04tick\x18\x02 \x03(\x05\x12\x0c\n\x04\x66ile\x18\x03 \x03(\x05\x12\x0f\n\x07\x63ommits\x18\x04 \x03(\x05\x12\r\n\x05\x63hurn\x18\x05 \x03(\x05\x12\x0f\n\x07\x61uthors\x18\x06 \x03(\x05\x12\x0b\n\x03\x61ge\x18\x07 \x03(\x05\x12\r\n\x05lines\x18\x08 \x03(\x05\x12\x12\n\ncomplexity\x18\t \x03(\x05\x12\x10\n\x08\x63oupling\x18\n \x03(\x05\x12\x12\n\npast_fixes\x18\x0b \x03(\x05\x12\r\n\x05\x66ixes\x18\x0c \x03(\x05\x12\x10\n\x08sampling\x18\r \x01(\x05\x12\x14\n\x0c\x66ix_patterns\x18\x0e \x03(\t\"a\n\nCocomoTick\x12\r\n\x05lines\x18\x01 \x01(\x05\x12\r\n\x05\x61\x64\x64\x65\x64\x18\x02 \x01(\x05\x12\x0f\n\x07removed\x18\x03 \x01(\x05\x12\x0e\n\x06\x65\x66\x66ort\x18\x04 \x01(\x01\x12\x14\n\x0c\x63hurn_effort\x18\x05 \x01(\x01\"\x82\x01\n\x15\x43ocomoAnalysisResults\x12\x1a\n\x05ticks\x18\x01 \x03(\x0b\x32\x0b.CocomoTick\x12\x10\n\x08sampling\x18\x02 \x01(\x05\x12\x13\n\x0b\x63oefficient\x18\x03 \x01(\x01\x12\x10\n\x08\x65xponent\x18\x04 \x01(\x01\x12\x14\n\x0cmonthly_cost\x18\x05 \x01(\x01\"T\n\x12ReviewLatencyStats\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\x11\n\thistogram\x18\x02 \x03(\x05\x12\r\n\x05total\x18\x03 \x01(\x03\x12\x0b\n\x03max\x18\x04 \x01(\x03\"\xf7\x01\n\x1cReviewLatencyAnalysisResults\x12\"\n\x05ticks\x18\x01 \x03(\x0b\x32\x13.ReviewLatencyStats\x12\x39\n\x06people\x18\x02 \x03(\x0b\x32).ReviewLatencyAnalysisResults.PeopleEntry\x12\x10\n\x08sampling\x18\x03 \x01(\x05\x12\x0f\n\x07\x62uckets\x18\x04 \x03(\x03\x12\x11\n\tdev_index\x18\x05 \x03(\t\x1a\x42\n\x0bPeopleEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.ReviewLatencyStats:\x02\x38\x01\"R\n\x0bIssueTicket\x12\x0f\n\x07\x63ommits\x18\x01 \x03(\t\x12\r\n\x05\x63hurn\x18\x02 \x01(\x05\x12\x11\n\tfirst_day\x18\x03 \x01(\x05\x12\x10\n\x08last_day\x18\x04 \x01(\x05\"\xdc\x01\n\x1eIssueReferencesAnalysisResults\x12=\n\x07tickets\x18\x01 \x03(\x0b\x32,.IssueReferencesAnalysisResults.TicketsEntry\x12\x0f\n\x07\x63ommits\x18\x02 \x01(\x05\x12\x1a\n\x12referenced_commits\x18\x03 \x01(\x05\x12\x10\n\x08patterns\x18\x04 \x03(\t\x1a<\n\x0cTicketsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1b\n\x05value\x18\x02 \x01(\x0b\x32\x0c.IssueTicket:\x02\x38\x01\"\xb3\x01\n\x18\x43onventionalCommitsStats\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\x11\n\tcompliant\x18\x02 \x01(\x05\x12\x10\n\x08\x62reaking\x18\x03 \x01(\x05\x12\x33\n\x05types\x18\x04 \x03(\x0b\x32$.ConventionalCommitsStats.TypesEntry\x1a,\n\nTypesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"\xfe\x01\n\"ConventionalCommitsAnalysisResults\x12(\n\x05ticks\x18\x01 \x03(\x0b\x32\x19.ConventionalCommitsStats\x12?\n\x06people\x18\x02 \x03(\x0b\x32/.ConventionalCommitsAnalysisResults.PeopleEntry\x12\x10\n\x08sampling\x18\x03 \x01(\x05\x12\x11\n\tdev_index\x18\x04 \x03(\t\x1aH\n\x0bPeopleEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12(\n\x05value\x18\x02 \x01(\x0b\x32\x19.ConventionalCommitsStats:\x02\x38\x01\"\x1e\n\rLanguageLines\x12\r\n\x05ticks\x18\x01 \x03(\x05\"\xb3\x01\n\x1cLanguageLinesAnalysisResults\x12?\n\tlanguages\x18\x01 \x03(\x0b\x32,.LanguageLinesAnalysisResults.LanguagesEntry\x12\x10\n\x08sampling\x18\x02 \x01(\x05\x1a@\n\x0eLanguagesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1d\n\x05value\x18\x02 \x01(\x0b\x32\x0e.LanguageLines:\x02\x38\x01\">\n\x12RepositorySizeTick\x12\x12\n\ntext_bytes\x18\x01 \x01(\x03\x12\x14\n\x0c\x62inary_bytes\x18\x02 \x01(\x03\"Y\n\x0eRepositoryBlob\x12\x0c\n\x04\x66ile\x18\x01 \x01(\t\x12\x0e\n\x06\x63ommit\x18\x02 \x01(\t\x12\x0b\n\x03\x64\x61y\x18\x03 \x01(\x05\x12\x0c\n\x04size\x18\x04 \x01(\x03\x12\x0e\n\x06\x62inary\x18\x05 \x01(\x08\"\x84\x01\n\x1dRepositorySizeAnalysisResults\x12\"\n\x05ticks\x18\x01 \x03(\x0b\x32\x13.RepositorySizeTick\x12 \n\x07largest\x18\x02 \x03(\x0b\x32\x0f.RepositoryBlob\x12\x0b\n\x03top\x18\x03 \x01(\x05\x12\x10\n\x08sampling\x18\x04 \x01(\x05\"H\n\x07\x46ileAge\x12\x0f\n\x07\x63reated\x18\x01 \x01(\x05\x12\x10\n\x08modified\x18\x02 \x01(\x05\x12\x1a\n\x12last_active_author\x18\x03 \x01(\x05\"\xe7\x01\n\x16\x46ileAgeAnalysisResults\x12\x31\n\x05\x66iles\x18\x01 \x03(\x0b\x32\".FileAgeAnalysisResults.FilesEntry\x12\x10\n\x08orphaned\x18\x02 \x03(\t\x12\x10\n\x08last_day\x18\x03 \x01(\x05\x12\x15\n\rinactive_days\x18\x04 \x01(\x05\x12\x14\n\x0csignificance\x18\x05 \x01(\x02\x12\x11\n\tdev_index\x18\x06 \x03(\t\x1a\x36\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x17\n\x05value\x18\x02 \x01(\x0b\x32\x08.FileAge:\x02\x38\x01\"c\n\x10RefactoringStats\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\x14\n\x0crefactorings\x18\x02 \x01(\x05\x12\r\n\x05lines\x18\x03 \x01(\x05\x12\x19\n\x11refactoring_lines\x18\x04 \x01(\x05\"\xf1\x01\n\x1aRefactoringAnalysisResults\x12 \n\x05ticks\x18\x01 \x03(\x0b\x32\x11.RefactoringStats\x12\x37\n\x06people\x18\x02 \x03(\x0b\x32\'.RefactoringAnalysisResults.PeopleEntry\x12\x11\n\tthreshold\x18\x03 \x01(\x02\x12\x10\n\x08sampling\x18\x04 \x01(\x05\x12\x11\n\tdev_index\x18\x05 \x03(\t\x1a@\n\x0bPeopleEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12 \n\x05value\x18\x02 \x01(\x0b\x32\x11.RefactoringStats:\x02\x38\x01\"/\n\x0bRevertsTick\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\x0f\n\x07reverts\x18\x02 \x01(\x05\"J\n\x0eRevertedCommit\x12\x0c\n\x04hash\x18\x01 \x01(\t\x12\x0e\n\x06revert\x18\x02 \x01(\t\x12\x0b\n\x03\x64\x61y\x18\x03 \x01(\x05\x12\r\n\x05hours\x18\x04 \x01(\x05\"\xcb\x01\n\x16RevertsAnalysisResults\x12\x1b\n\x05ticks\x18\x01 \x03(\x0b\x32\x0c.RevertsTick\x12!\n\x08reverted\x18\x02 \x03(\x0b\x32\x0f.RevertedCommit\x12\x31\n\x05\x66iles\x18\x03 \x03(\x0b\x32\".RevertsAnalysisResults.FilesEntry\x12\x10\n\x08sampling\x18\x04 \x01(\x05\x1a,\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"N\n\x0b\x42ranchMerge\x12\x0b\n\x03\x64\x61y\x18\x01 \x01(\x05\x12\x0f\n\x07\x63ommits\x18\x02 \x01(\x05\x12\x10\n\x08lifetime\x18\x03 \x01(\x05\x12\x0f\n\x07latency\x18\x04 \x01(\x05\"O\n\x1d\x42ranchLifetimeAnalysisResults\x12\x1c\n\x06merges\x18\x01 \x03(\x0b\x32\x0c.BranchMerge\x12\x10\n\x08sampling\x18\x02 \x01(\x05\"_\n\x07Release\x12\x0b\n\x03tag\x18\x01 \x01(\t\x12\x0c\n\x04hash\x18\x02 \x01(\t\x12\x0b\n\x03\x64\x61y\x18\x03 \x01(\x05\x12\x0c\n\x04\x64\x61ys\x18\x04 \x01(\x05\x12\x0f\n\x07\x63ommits\x18\x05 \x01(\x05\x12\r\n\x05\x63hurn\x18\x06 \x01(\x05\"\x86\x01\n\x1dReleaseCadenceAnalysisResults\x12\x1a\n\x08releases\x18\x01 \x03(\x0b\x32\x08.Release\x12\x1a\n\x12unreleased_commits\x18\x02 \x01(\x05\x12\x18\n\x10unreleased_churn\x18\x03 \x01(\x05\x12\x13\n\x0btag_pattern\x18\x04 \x01(\t\"\xe0\x01\n\x08GiniTick\x12\'\n\x07\x63ommits\x18\x01 \x03(\x0b\x32\x16.GiniTick.CommitsEntry\x12#\n\x05lines\x18\x02 \x03(\x0b\x32\x14.GiniTick.LinesEntry\x12\x14\n\x0c\x63ommits_gini\x18\x03 \x01(\x01\x12\x12\n\nlines_gini\x18\x04 \x01(\x01\x1a.\n\x0c\x43ommitsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\x1a,\n\nLinesEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"n\n\x13GiniAnalysisResults\x12\x18\n\x05ticks\x18\x01 \x03(\x0b\x32\t.GiniTick\x12\x18\n\x05total\x18\x02 \x01(\x0b\x32\t.GiniTick\x12\x10\n\x08sampling\x18\x03 \x01(\x05\x12\x11\n\tdev_index\x18\x04 \x03(\t\"\x9a\x01\n\x13OnboardingDeveloper\x12\x13\n\x0b\x63ommit_days\x18\x01 \x03(\x05\x12:\n\x0b\x64irectories\x18\x02 \x03(\x0b\x32%.OnboardingDeveloper.DirectoriesEntry\x1a\x32\n\x10\x44irectoriesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\">\n\x13OnboardingDirectory\x12\x12\n\ndevelopers\x18\x01 \x01(\x05\x12\x13\n\x0bmedian_days\x18\x02 \x01(\x01\"\xcf\x01\n\x10OnboardingCohort\x12\x12\n\ndevelopers\x18\x01 \x01(\x05\x12\x0f\n\x07reached\x18\x02 \x01(\x05\x12\x13\n\x0bmedian_days\x18\x03 \x01(\x01\x12\x37\n\x0b\x64irectories\x18\x04 \x03(\x0b\x32\".OnboardingCohort.DirectoriesEntry\x1aH\n\x10\x44irectoriesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12#\n\x05value\x18\x02 \x01(\x0b\x32\x14.OnboardingDirectory:\x02\x38\x01\"\xb7\x01\n\x19OnboardingAnalysisResults\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\x13\n\x0b\x63ohort_days\x18\x02 \x01(\x05\x12\x17\n\x0f\x64irectory_depth\x18\x03 \x01(\x05\x12\"\n\x07\x63ohorts\x18\x04 \x03(\x0b\x32\x11.OnboardingCohort\x12$\n\x06people\x18\x05 \x03(\x0b\x32\x14.OnboardingDeveloper\x12\x11\n\tdev_index\x18\x06 \x03(\t\";\n\nTenureTick\x12\x0b\n\x03new\x18\x01 \x01(\x05\x12\x0e\n\x06\x61\x63tive\x18\x02 \x01(\x05\x12\x10\n\x08\x64\x65parted\x18\x03 \x01(\x05\"(\n\nTenureSpan\x12\r\n\x05\x62\x65gin\x18\x01 \x01(\x05\x12\x0b\n\x03\x65nd\x18\x02 \x01(\x05\"L\n\x0fTenureDeveloper\x12\x0c\n\x04\x64\x61ys\x18\x01 \x03(\x05\x12\x0f\n\x07\x63ommits\x18\x02 \x01(\x05\x12\x1a\n\x05spans\x18\x03 \x03(\x0b\x32\x0b.TenureSpan\"\xa3\x01\n\x15TenureAnalysisResults\x12\x1a\n\x05ticks\x18\x01 \x03(\x0b\x32\x0b.TenureTick\x12 \n\x06people\x18\x02 \x03(\x0b\x32\x10.TenureDeveloper\x12\x10\n\x08sampling\x18\x03 \x01(\x05\x12\x15\n\rinactive_days\x18\x04 \x01(\x05\x12\x10\n\x08last_day\x18\x05 \x01(\x05\x12\x11\n\tdev_index\x18\x06 \x03(\t\"Y\n\x0bWorkPattern\x12\r\n\x05hours\x18\x01 \x03(\x05\x12\x10\n\x08weekdays\x18\x02 \x03(\x05\x12\x11\n\toff_hours\x18\x03 \x01(\x05\x12\x16\n\x0eoff_hours_days\x18\x04 \x03(\x05\"Z\n\x12WorkPatternsStreak\x12\x11\n\tdeveloper\x18\x01 \x01(\x05\x12\x11\n\tbegin_day\x18\x02 \x01(\x05\x12\x0f\n\x07\x65nd_day\x18\x03 \x01(\x05\x12\r\n\x05weeks\x18\x04 \x01(\x05\"\xcd\x01\n\x1bWorkPatternsAnalysisResults\x12\x10\n\x08timezone\x18\x01 \x01(\t\x12\x16\n\x0ework_day_start\x18\x02 \x01(\x05\x12\x14\n\x0cwork_day_end\x18\x03 \x01(\x05\x12\x17\n\x0fsustained_weeks\x18\x04 \x01(\x05\x12\x1c\n\x06people\x18\x05 \x03(\x0b\x32\x0c.WorkPattern\x12$\n\x07streaks\x18\x06 \x03(\x0b\x32\x13.WorkPatternsStreak\x12\x11\n\tdev_index\x18\x07 \x03(\t\"$\n\x12KnowledgeMapVector\x12\x0e\n\x06values\x18\x01 \x03(\x01\"\xc9\x01\n\x1bKnowledgeMapAnalysisResults\x12\x11\n\thalf_life\x18\x01 \x01(\x05\x12\x17\n\x0f\x64irectory_depth\x18\x02 \x01(\x05\x12\x13\n\x0b\x64irectories\x18\x03 \x03(\t\x12)\n\x0cpeople_lines\x18\x04 \x03(\x0b\x32\x13.KnowledgeMapVector\x12+\n\x0epeople_commits\x18\x05 \x03(\x0b\x32\x13.KnowledgeMapVector\x12\x11\n\tdev_index\x18\x06 \x03(\t\"]\n\x0c\x44\x65\x61\x64\x43odeTick\x12\x13\n\x0b\x64\x65\x66initions\x18\x01 \x01(\x05\x12\x14\n\x0cunreferenced\x18\x02 \x01(\x05\x12\x10\n\x08orphaned\x18\x03 \x01(\x05\x12\x10\n\x08resolved\x18\x04 \x01(\x05\"I\n\x0e\x44\x65\x61\x64\x43odeOrphan\x12\x0c\n\x04hash\x18\x01 \x01(\t\x12\x0b\n\x03\x64\x61y\x18\x02 \x01(\x05\x12\x0c\n\x04\x66ile\x18\x03 \x01(\t\x12\x0e\n\x06symbol\x18\x04 \x01(\t\"k\n\x17\x44\x65\x61\x64\x43odeAnalysisResults\x12\x10\n\x08sampling\x18\x01 \x01(\x05\x12\x1c\n\x05ticks\x18\x02 \x03(\x0b\x32\r.DeadCodeTick\x12 \n\x07orphans\x18\x03 \x03(\x0b\x32\x0f.DeadCodeOrphan\"S\n\x0f\x43ommitSentiment\x12\x10\n\x08positive\x18\x01 \x01(\x05\x12\x10\n\x08negative\x18\x02 \x01(\x05\x12\x0f\n\x07neutral\x18\x03 \x01(\x05\x12\x0b\n\x03sum\x18\x04 \x01(\x01\"\x97\x01\n\x1e\x43ommitSentimentAnalysisResults\x12\x10\n\x08sampling\x18\x01 \x01(\x05\x12\r\n\x05model\x18\x02 \x01(\t\x12\x1f\n\x05ticks\x18\x03 \x03(\x0b\x32\x10.CommitSentiment\x12 \n\x06people\x18\x04 \x03(\x0b\x32\x10.CommitSentiment\x12\x11\n\tdev_index\x18\x05 \x03(\t\"i\n\x07Hotspot\x12\x0c\n\x04\x66ile\x18\x01 \x01(\t\x12\x0f\n\x07\x63ommits\x18\x02 \x01(\x05\x12\r\n\x05\x63hurn\x18\x03 \x01(\x05\x12\r\n\x05lines\x18\x04 \x01(\x05\x12\x12\n\ncomplexity\x18\x05 \x01(\x05\x12\r\n\x05score\x18\x06 \x01(\x01\"5\n\x17HotspotsAnalysisResults\x12\x1a\n\x08hotspots\x18\x01 \x03(\x0b\x32\x08.Hotspot\"k\n\rTestRatioTick\x12\x12\n\ntest_lines\x18\x01 \x01(\x05\x12\x18\n\x10production_lines\x18\x02 \x01(\x05\x12\x12\n\ntest_churn\x18\x03 \x01(\x05\x12\x18\n\x10production_churn\x18\x04 \x01(\x05\"K\n\x18TestRatioAnalysisResults\x12\x10\n\x08sampling\x18\x01 \x01(\x05\x12\x1d\n\x05ticks\x18\x02 \x03(\x0b\x32\x0e.TestRatioTick\"D\n\x0eVocabularyTick\x12\r\n\x05terms\x18\x01 \x01(\x05\x12\x12\n\nintroduced\x18\x02 \x01(\x05\x12\x0f\n\x07retired\x18\x03 \x01(\x05\"k\n\x0fVocabularyTerms\x12*\n\x05terms\x18\x01 \x03(\x0b\x32\x1b.VocabularyTerms.TermsEntry\x1a,\n\nTermsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"\xe6\x01\n\x19VocabularyAnalysisResults\x12\x10\n\x08sampling\x18\x01 \x01(\x05\x12\x1e\n\x05ticks\x18\x02 \x03(\x0b\x32\x0f.VocabularyTick\x12\x34\n\x05terms\x18\x03 \x03(\x0b\x32%.VocabularyAnalysisResults.TermsEntry\x12 \n\x06people\x18\x04 \x03(\x0b\x32\x10.VocabularyTerms\x12\x11\n\tdev_index\x18\x05 \x03(\t\x1a,\n\nTermsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"U\n\x0fImportGraphTick\x12\r\n\x05nodes\x18\x01 \x01(\x05\x12\r\n\x05\x65\x64ges\x18\x02 \x01(\x05\x12\x0e\n\x06\x63ycles\x18\x03 \x01(\x05\x12\x14\n\x0c\x63yclic_nodes\x18\x04 \x01(\x05\"<\n\x0fImportGraphEdge\x12\x0c\n\x04\x66rom\x18\x01 \x01(\t\x12\n\n\x02to\x18\x02 \x01(\t\x12\x0f\n\x07imports\x18\x03 \x01(\x05\"p\n\x1aImportGraphAnalysisResults\x12\x10\n\x08sampling\x18\x01 \x01(\x05\x12\x1f\n\x05ticks\x18\x02 \x03(\x0b\x32\x10.ImportGraphTick\x12\x1f\n\x05\x65\x64ges\x18\x03 \x03(\x0b\x32\x10.ImportGraphEdge\"R\n\x0e\x41PISurfaceTick\x12\x0f\n\x07symbols\x18\x01 \x01(\x05\x12\r\n\x05\x61\x64\x64\x65\x64\x18\x02 \x01(\x05\x12\x0f\n\x07removed\x18\x03 \x01(\x05\x12\x0f\n\x07\x63hanged\x18\x04 \x01(\x05\"]\n\x11\x41PIBreakingChange\x12\x0c\n\x04hash\x18\x01 \x01(\t\x12\x0b\n\x03\x64\x61y\x18\x02 \x01(\x05\x12\x0c\n\x04\x66ile\x18\x03 \x01(\t\x12\x0e\n\x06symbol\x18\x04 \x01(\t\x12\x0f\n\x07removed\x18\x05 \x01(\x08\"{\n\x19\x41PISurfaceAnalysisResults\x12\x10\n\x08sampling\x18\x01 \x01(\x05\x12\x1e\n\x05ticks\x18\x02 \x03(\x0b\x32\x0f.APISurfaceTick\x12,\n\x10\x62reaking_changes\x18\x03 \x03(\x0b\x32\x12.APIBreakingChange\"5\n\nClonesTick\x12\r\n\x05lines\x18\x01 \x01(\x05\x12\x18\n\x10\x64uplicated_lines\x18\x02 \x01(\x05\"G\n\x0c\x43lonesCommit\x12\x0c\n\x04hash\x18\x01 \x01(\t\x12\x0b\n\x03\x64\x61y\x18\x02 \x01(\x05\x12\r\n\x05lines\x18\x03 \x01(\x05\x12\r\n\x05\x66iles\x18\x04 \x03(\t\"e\n\x15\x43lonesAnalysisResults\x12\x10\n\x08sampling\x18\x01 \x01(\x05\x12\x1a\n\x05ticks\x18\x02 \x03(\x0b\x32\x0b.ClonesTick\x12\x1e\n\x07\x63ommits\x18\x03 \x03(\x0b\x32\r.ClonesCommit\"=\n\x0cTechDebtTick\x12\x0c\n\x04open\x18\x01 \x01(\x05\x12\r\n\x05\x61\x64\x64\x65\x64\x18\x02 \x01(\x05\x12\x10\n\x08resolved\x18\x03 \x01(\x05\"e\n\x0eTechDebtMarker\x12\x0c\n\x04kind\x18\x01 \x01(\t\x12\x0c\n\x04text\x18\x02 \x01(\t\x12\x0c\n\x04\x66ile\x18\x03 \x01(\t\x12\x0c\n\x04line\x18\x04 \x01(\x05\x12\x0e\n\x06\x61uthor\x18\x05 \x01(\x05\x12\x0b\n\x03\x64\x61y\x18\x06 \x01(\x05\"\xf2\x01\n\x17TechDebtAnalysisResults\x12\x10\n\x08sampling\x18\x01 \x01(\x05\x12\x1c\n\x05ticks\x18\x02 \x03(\x0b\x32\r.TechDebtTick\x12\x32\n\x05kinds\x18\x03 \x03(\x0b\x32#.TechDebtAnalysisResults.KindsEntry\x12\x1f\n\x06oldest\x18\x04 \x03(\x0b\x32\x0f.TechDebtMarker\x12\x11\n\tlifetimes\x18\x05 \x03(\x05\x12\x11\n\tdev_index\x18\x06 \x03(\t\x1a,\n\nKindsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"H\n\x0e\x43ommentDensity\x12\r\n\x05lines\x18\x01 \x01(\x05\x12\x15\n\rcomment_lines\x18\x02 \x01(\x05\x12\x10\n\x08\x63omments\x18\x03 \x01(\x05\"6\n\x14\x43ommentDensitySeries\x12\x1e\n\x05ticks\x18\x01 \x03(\x0b\x32\x0f.CommentDensity\"\xe4\x01\n\x1d\x43ommentDensityAnalysisResults\x12\x10\n\x08sampling\x18\x01 \x01(\x05\x12 \n\x07project\x18\x02 \x03(\x0b\x32\x0f.CommentDensity\x12\x44\n\x0b\x64irectories\x18\x03 \x03(\x0b\x32/.CommentDensityAnalysisResults.DirectoriesEntry\x1aI\n\x10\x44irectoriesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12$\n\x05value\x18\x02 \x01(\x0b\x32\x15.CommentDensitySeries:\x02\x38\x01\"F\n\nComplexity\x12\x11\n\tfunctions\x18\x01 \x01(\x05\x12\x12\n\ncyclomatic\x18\x02 \x01(\x05\x12\x11\n\tcognitive\x18\x03 \x01(\x05\"z\n\x10\x43omplexitySeries\x12+\n\x05ticks\x18\x01 \x03(\x0b\x32\x1c.ComplexitySeries.TicksEntry\x1a\x39\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x1a\n\x05value\x18\x02 \x01(\x0b\x32\x0b.Complexity:\x02\x38\x01\"\x9a\x01\n\x12\x43omplexityFunction\x12\x0c\n\x04\x66ile\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12-\n\x05ticks\x18\x03 \x03(\x0b\x32\x1e.ComplexityFunction.TicksEntry\x1a\x39\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x1a\n\x05value\x18\x02 \x01(\x0b\x32\x0b.Complexity:\x02\x38\x01\"I\n\x10\x43omplexityCommit\x12\x0c\n\x04hash\x18\x01 \x01(\t\x12\x0b\n\x03\x64\x61y\x18\x02 \x01(\x05\x12\x1a\n\x05\x64\x65lta\x18\x03 \x01(\x0b\x32\x0b.Complexity\"\x8e\x02\n\x19\x43omplexityAnalysisResults\x12\x10\n\x08sampling\x18\x01 \x01(\x05\x12\x1c\n\x07project\x18\x02 \x03(\x0b\x32\x0b.Complexity\x12\x34\n\x05\x66iles\x18\x03 \x03(\x0b\x32%.ComplexityAnalysisResults.FilesEntry\x12&\n\tfunctions\x18\x04 \x03(\x0b\x32\x13.ComplexityFunction\x12\"\n\x07\x63ommits\x18\x05 \x03(\x0b\x32\x11.ComplexityCommit\x1a?\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12 \n\x05value\x18\x02 \x01(\x0b\x32\x11.ComplexitySeries:\x02\x38\x01\"2\n\x10\x46unctionChurnDay\x12\r\n\x05\x61\x64\x64\x65\x64\x18\x01 \x01(\x05\x12\x0f\n\x07removed\x18\x02 \x01(\x05\"\xbb\x01\n\rFunctionChurn\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x0c\n\x04\x66ile\x18\x02 \x01(\t\x12\x15\n\rinternal_role\x18\x03 \x01(\t\x12\x0f\n\x07\x63ommits\x18\x04 \x01(\x05\x12&\n\x04\x64\x61ys\x18\x05 \x03(\x0b\x32\x18.FunctionChurn.DaysEntry\x1a>\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12 \n\x05value\x18\x02 \x01(\x0b\x32\x11.FunctionChurnDay:\x02\x38\x01\"A\n\x1c\x46unctionChurnAnalysisResults\x12!\n\tfunctions\x18\x01 \x03(\x0b\x32\x0e.FunctionChurn\"\x85\x02\n\x0eHistoryRewrite\x12\x0b\n\x03ref\x18\x01 \x01(\t\x12\x0c\n\x04time\x18\x02 \x01(\x03\x12\r\n\x05\x61\x63tor\x18\x03 \x01(\x05\x12\x0b\n\x03old\x18\x04 \x01(\t\x12\x0b\n\x03new\x18\x05 \x01(\t\x12\x0f\n\x07message\x18\x06 \x01(\t\x12\x0f\n\x07\x63ommits\x18\x07 \x01(\x05\x12\r\n\x05\x61\x64\x64\x65\x64\x18\x08 \x01(\x05\x12\x0f\n\x07removed\x18\t \x01(\x05\x12-\n\x07\x61uthors\x18\n \x03(\x0b\x32\x1c.HistoryRewrite.AuthorsEntry\x12\x0e\n\x06pruned\x18\x0b \x01(\x08\x1a.\n\x0c\x41uthorsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"V\n\x1eHistoryRewritesAnalysisResults\x12!\n\x08rewrites\x18\x01 \x03(\x0b\x32\x0f.HistoryRewrite\x12\x11\n\tdev_index\x18\x02 \x03(\t\"x\n\x10\x43hangeEntropyDay\x12\x0f\n\x07\x63hanges\x18\x01 \x01(\x05\x12\r\n\x05\x66iles\x18\x02 \x01(\x05\x12\x13\n\x0b\x64irectories\x18\x03 \x01(\x05\x12\x14\n\x0c\x66ile_entropy\x18\x04 \x01(\x01\x12\x19\n\x11\x64irectory_entropy\x18\x05 \x01(\x01\"\x85\x01\n\x13\x43hangeEntropyCommit\x12\x0c\n\x04hash\x18\x01 \x01(\t\x12\x0b\n\x03\x64\x61y\x18\x02 \x01(\x05\x12\r\n\x05\x66iles\x18\x03 \x01(\x05\x12\x13\n\x0b\x64irectories\x18\x04 \x01(\x05\x12\x14\n\x0c\x66ile_entropy\x18\x05 \x01(\x01\x12\x19\n\x11\x64irectory_entropy\x18\x06 \x01(\x01\"\xf0\x01\n\x1c\x43hangeEntropyAnalysisResults\x12\x35\n\x04\x64\x61ys\x18\x01 \x03(\x0b\x32\'.ChangeEntropyAnalysisResults.DaysEntry\x12 \n\x05ticks\x18\x02 \x03(\x0b\x32\x11.ChangeEntropyDay\x12%\n\x07\x63ommits\x18\x03 \x03(\x0b\x32\x14.ChangeEntropyCommit\x12\x10\n\x08sampling\x18\x04 \x01(\x05\x1a>\n\tDaysEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12 \n\x05value\x18\x02 \x01(\x0b\x32\x11.ChangeEntropyDay:\x02\x38\x01\"!\n\x0f\x45xpertiseVector\x12\x0e\n\x06shares\x18\x01 \x03(\x01\"\xda\x01\n\x18\x45xpertiseAnalysisResults\x12\x11\n\thalf_life\x18\x01 \x01(\x05\x12\x11\n\tlanguages\x18\x02 \x03(\t\x12\x13\n\x0b\x64irectories\x18\x03 \x03(\t\x12*\n\x10people_languages\x18\x04 \x03(\x0b\x32\x10.ExpertiseVector\x12,\n\x12people_directories\x18\x05 \x03(\x0b\x32\x10.ExpertiseVector\x12\x16\n\x0epeople_weights\x18\x06 \x03(\x01\x12\x11\n\tdev_index\x18\x07 \x03(\t\"\xb8\x01\n\x18OwnershipAnalysisResults\x12\x13\n\x0b\x64irectories\x18\x01 \x03(\t\x12\x34\n\x10\x64irectory_owners\x18\x02 \x01(\x0b\x32\x1a.CompressedSparseRowMatrix\x12\r\n\x05\x66iles\x18\x03 \x03(\t\x12/\n\x0b\x66ile_owners\x18\x04 \x01(\x0b\x32\x1a.CompressedSparseRowMatrix\x12\x11\n\tdev_index\x18\x05 \x03(\t\"\x8f\x01\n\x0f\x41nalysisResults\x12\x19\n\x06header\x18\x01 \x01(\x0b\x32\t.Metadata\x12\x30\n\x08\x63ontents\x18\x02 \x03(\x0b\x32\x1e.AnalysisResults.ContentsEntry\x1a/\n\rContentsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01\x62\x06proto3')
 )
 
 
@@ -58,8 +58,82 @@ _METADATA_RUNTIMEPERITEMENTRY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=217,
-  serialized_end=270,
+  serialized_start=378,
+  serialized_end=431,
+)
+
+_METADATA_PROFILEPERITEMENTRY = _descriptor.Descriptor(
+  name='ProfilePerItemEntry',
+  full_name='Metadata.ProfilePerItemEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='Metadata.ProfilePerItemEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='Metadata.ProfilePerItemEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=433,
+  serialized_end=500,
+)
+
+_METADATA_UNPARSEDFILESENTRY = _descriptor.Descriptor(
+  name='UnparsedFilesEntry',
+  full_name='Metadata.UnparsedFilesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='Metadata.UnparsedFilesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='Metadata.UnparsedFilesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=502,
+  serialized_end=569,
 )
 
 _METADATA = _descriptor.Descriptor(
@@ -125,10 +199,38 @@ _METADATA = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='profile_per_item', full_name='Metadata.profile_per_item', index=8,
+      number=9, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='annotations', full_name='Metadata.annotations', index=9,
+      number=10, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people', full_name='Metadata.people', index=10,
+      number=11, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='unparsed_files', full_name='Metadata.unparsed_files', index=11,
+      number=12, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
-  nested_types=[_METADATA_RUNTIMEPERITEMENTRY, ],
+  nested_types=[_METADATA_RUNTIMEPERITEMENTRY, _METADATA_PROFILEPERITEMENTRY, _METADATA_UNPARSEDFILESENTRY, ],
   enum_types=[
   ],
   options=None,
@@ -138,21 +240,28 @@ _METADATA = _descriptor.Descriptor(
   oneofs=[
   ],
   serialized_start=13,
-  serialized_end=270,
+  serialized_end=569,
 )
 
 
-_BURNDOWNSPARSEMATRIXROW = _descriptor.Descriptor(
-  name='BurndownSparseMatrixRow',
-  full_name='BurndownSparseMatrixRow',
+_UNPARSEDFILE = _descriptor.Descriptor(
+  name='UnparsedFile',
+  full_name='UnparsedFile',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='columns', full_name='BurndownSparseMatrixRow.columns', index=0,
-      number=1, type=13, cpp_type=3, label=3,
-      has_default_value=False, default_value=[],
+      name='language', full_name='UnparsedFile.language', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='reason', full_name='UnparsedFile.reason', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
@@ -168,43 +277,29 @@ _BURNDOWNSPARSEMATRIXROW = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=272,
-  serialized_end=314,
+  serialized_start=571,
+  serialized_end=619,
 )
 
 
-_BURNDOWNSPARSEMATRIX = _descriptor.Descriptor(
-  name='BurndownSparseMatrix',
-  full_name='BurndownSparseMatrix',
+_ANNOTATION = _descriptor.Descriptor(
+  name='Annotation',
+  full_name='Annotation',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='name', full_name='BurndownSparseMatrix.name', index=0,
-      number=1, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=_b("").decode('utf-8'),
-      message_type=None, enum_type=None, containing_type=None,
-      is_extension=False, extension_scope=None,
-      options=None),
-    _descriptor.FieldDescriptor(
-      name='number_of_rows', full_name='BurndownSparseMatrix.number_of_rows', index=1,
-      number=2, type=5, cpp_type=1, label=1,
-      has_default_value=False, default_value=0,
-      message_type=None, enum_type=None, containing_type=None,
-      is_extension=False, extension_scope=None,
-      options=None),
-    _descriptor.FieldDescriptor(
-      name='number_of_columns', full_name='BurndownSparseMatrix.number_of_columns', index=2,
-      number=3, type=5, cpp_type=1, label=1,
+      name='unix_time', full_name='Annotation.unix_time', index=0,
+      number=1, type=3, cpp_type=2, label=1,
       has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='rows', full_name='BurndownSparseMatrix.rows', index=3,
-      number=4, type=11, cpp_type=10, label=3,
-      has_default_value=False, default_value=[],
+      name='label', full_name='Annotation.label', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
@@ -220,57 +315,81 @@ _BURNDOWNSPARSEMATRIX = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=316,
-  serialized_end=443,
+  serialized_start=621,
+  serialized_end=667,
 )
 
 
-_BURNDOWNANALYSISRESULTS = _descriptor.Descriptor(
-  name='BurndownAnalysisResults',
-  full_name='BurndownAnalysisResults',
+_ITEMPROFILE = _descriptor.Descriptor(
+  name='ItemProfile',
+  full_name='ItemProfile',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='granularity', full_name='BurndownAnalysisResults.granularity', index=0,
-      number=1, type=5, cpp_type=1, label=1,
-      has_default_value=False, default_value=0,
+      name='wall_time', full_name='ItemProfile.wall_time', index=0,
+      number=1, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='sampling', full_name='BurndownAnalysisResults.sampling', index=1,
-      number=2, type=5, cpp_type=1, label=1,
-      has_default_value=False, default_value=0,
+      name='cpu_time', full_name='ItemProfile.cpu_time', index=1,
+      number=2, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='project', full_name='BurndownAnalysisResults.project', index=2,
-      number=3, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='allocations', full_name='ItemProfile.allocations', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='files', full_name='BurndownAnalysisResults.files', index=3,
-      number=4, type=11, cpp_type=10, label=3,
-      has_default_value=False, default_value=[],
+      name='allocated_bytes', full_name='ItemProfile.allocated_bytes', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='people', full_name='BurndownAnalysisResults.people', index=4,
-      number=5, type=11, cpp_type=10, label=3,
-      has_default_value=False, default_value=[],
+      name='calls', full_name='ItemProfile.calls', index=4,
+      number=5, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=669,
+  serialized_end=780,
+)
+
+
+_BURNDOWNSPARSEMATRIXROW = _descriptor.Descriptor(
+  name='BurndownSparseMatrixRow',
+  full_name='BurndownSparseMatrixRow',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
     _descriptor.FieldDescriptor(
-      name='people_interaction', full_name='BurndownAnalysisResults.people_interaction', index=5,
-      number=6, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='columns', full_name='BurndownSparseMatrixRow.columns', index=0,
+      number=1, type=13, cpp_type=3, label=3,
+      has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
@@ -286,48 +405,48 @@ _BURNDOWNANALYSISRESULTS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=446,
-  serialized_end=683,
+  serialized_start=782,
+  serialized_end=824,
 )
 
 
-_COMPRESSEDSPARSEROWMATRIX = _descriptor.Descriptor(
-  name='CompressedSparseRowMatrix',
-  full_name='CompressedSparseRowMatrix',
+_BURNDOWNSPARSEMATRIX = _descriptor.Descriptor(
+  name='BurndownSparseMatrix',
+  full_name='BurndownSparseMatrix',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='number_of_rows', full_name='CompressedSparseRowMatrix.number_of_rows', index=0,
-      number=1, type=5, cpp_type=1, label=1,
-      has_default_value=False, default_value=0,
+      name='name', full_name='BurndownSparseMatrix.name', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='number_of_columns', full_name='CompressedSparseRowMatrix.number_of_columns', index=1,
+      name='number_of_rows', full_name='BurndownSparseMatrix.number_of_rows', index=1,
       number=2, type=5, cpp_type=1, label=1,
       has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='data', full_name='CompressedSparseRowMatrix.data', index=2,
-      number=3, type=3, cpp_type=2, label=3,
-      has_default_value=False, default_value=[],
+      name='number_of_columns', full_name='BurndownSparseMatrix.number_of_columns', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='indices', full_name='CompressedSparseRowMatrix.indices', index=3,
-      number=4, type=5, cpp_type=1, label=3,
+      name='rows', full_name='BurndownSparseMatrix.rows', index=3,
+      number=4, type=11, cpp_type=10, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='indptr', full_name='CompressedSparseRowMatrix.indptr', index=4,
+      name='pre_history', full_name='BurndownSparseMatrix.pre_history', index=4,
       number=5, type=3, cpp_type=2, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
@@ -345,29 +464,36 @@ _COMPRESSEDSPARSEROWMATRIX = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=685,
-  serialized_end=810,
+  serialized_start=827,
+  serialized_end=975,
 )
 
 
-_COUPLES = _descriptor.Descriptor(
-  name='Couples',
-  full_name='Couples',
+_BURNDOWNCOHORT = _descriptor.Descriptor(
+  name='BurndownCohort',
+  full_name='BurndownCohort',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='index', full_name='Couples.index', index=0,
-      number=1, type=9, cpp_type=9, label=3,
+      name='lines', full_name='BurndownCohort.lines', index=0,
+      number=1, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='curve', full_name='BurndownCohort.curve', index=1,
+      number=2, type=2, cpp_type=6, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='matrix', full_name='Couples.matrix', index=1,
-      number=2, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='half_life', full_name='BurndownCohort.half_life', index=2,
+      number=3, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
@@ -383,21 +509,35 @@ _COUPLES = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=812,
-  serialized_end=880,
+  serialized_start=977,
+  serialized_end=1042,
 )
 
 
-_TOUCHEDFILES = _descriptor.Descriptor(
-  name='TouchedFiles',
-  full_name='TouchedFiles',
+_BURNDOWNSURVIVAL = _descriptor.Descriptor(
+  name='BurndownSurvival',
+  full_name='BurndownSurvival',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='files', full_name='TouchedFiles.files', index=0,
-      number=1, type=5, cpp_type=1, label=3,
+      name='curve', full_name='BurndownSurvival.curve', index=0,
+      number=1, type=2, cpp_type=6, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='half_life', full_name='BurndownSurvival.half_life', index=1,
+      number=2, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='cohorts', full_name='BurndownSurvival.cohorts', index=2,
+      number=3, type=11, cpp_type=10, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
@@ -414,95 +554,85 @@ _TOUCHEDFILES = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=882,
-  serialized_end=911,
+  serialized_start=1044,
+  serialized_end=1130,
 )
 
 
-_COUPLESANALYSISRESULTS = _descriptor.Descriptor(
-  name='CouplesAnalysisResults',
-  full_name='CouplesAnalysisResults',
+_BURNDOWNANALYSISRESULTS = _descriptor.Descriptor(
+  name='BurndownAnalysisResults',
+  full_name='BurndownAnalysisResults',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='file_couples', full_name='CouplesAnalysisResults.file_couples', index=0,
-      number=6, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='granularity', full_name='BurndownAnalysisResults.granularity', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='people_couples', full_name='CouplesAnalysisResults.people_couples', index=1,
-      number=7, type=11, cpp_type=10, label=1,
+      name='sampling', full_name='BurndownAnalysisResults.sampling', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='project', full_name='BurndownAnalysisResults.project', index=2,
+      number=3, type=11, cpp_type=10, label=1,
       has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='people_files', full_name='CouplesAnalysisResults.people_files', index=2,
-      number=8, type=11, cpp_type=10, label=3,
+      name='files', full_name='BurndownAnalysisResults.files', index=3,
+      number=4, type=11, cpp_type=10, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
-  ],
-  extensions=[
-  ],
-  nested_types=[],
-  enum_types=[
-  ],
-  options=None,
-  is_extendable=False,
-  syntax='proto3',
-  extension_ranges=[],
-  oneofs=[
-  ],
-  serialized_start=913,
-  serialized_end=1040,
-)
-
-
-_UASTCHANGE = _descriptor.Descriptor(
-  name='UASTChange',
-  full_name='UASTChange',
-  filename=None,
-  file=DESCRIPTOR,
-  containing_type=None,
-  fields=[
     _descriptor.FieldDescriptor(
-      name='file_name', full_name='UASTChange.file_name', index=0,
-      number=1, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=_b("").decode('utf-8'),
+      name='people', full_name='BurndownAnalysisResults.people', index=4,
+      number=5, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='src_before', full_name='UASTChange.src_before', index=1,
-      number=2, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=_b("").decode('utf-8'),
+      name='people_interaction', full_name='BurndownAnalysisResults.people_interaction', index=5,
+      number=6, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='src_after', full_name='UASTChange.src_after', index=2,
-      number=3, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=_b("").decode('utf-8'),
+      name='snapshots', full_name='BurndownAnalysisResults.snapshots', index=6,
+      number=7, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='uast_before', full_name='UASTChange.uast_before', index=3,
-      number=4, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=_b("").decode('utf-8'),
+      name='directories', full_name='BurndownAnalysisResults.directories', index=7,
+      number=8, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='uast_after', full_name='UASTChange.uast_after', index=4,
-      number=5, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=_b("").decode('utf-8'),
+      name='languages', full_name='BurndownAnalysisResults.languages', index=8,
+      number=9, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='survival', full_name='BurndownAnalysisResults.survival', index=9,
+      number=10, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
@@ -518,22 +648,29 @@ _UASTCHANGE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1042,
-  serialized_end=1153,
+  serialized_start=1133,
+  serialized_end=1527,
 )
 
 
-_UASTCHANGESSAVERRESULTS = _descriptor.Descriptor(
-  name='UASTChangesSaverResults',
-  full_name='UASTChangesSaverResults',
+_FILESNAPSHOT_AGESENTRY = _descriptor.Descriptor(
+  name='AgesEntry',
+  full_name='FileSnapshot.AgesEntry',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='changes', full_name='UASTChangesSaverResults.changes', index=0,
-      number=1, type=11, cpp_type=10, label=3,
-      has_default_value=False, default_value=[],
+      name='key', full_name='FileSnapshot.AgesEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='FileSnapshot.AgesEntry.value', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
@@ -543,34 +680,33 @@ _UASTCHANGESSAVERRESULTS = _descriptor.Descriptor(
   nested_types=[],
   enum_types=[
   ],
-  options=None,
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
   is_extendable=False,
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1155,
-  serialized_end=1210,
+  serialized_start=1642,
+  serialized_end=1685,
 )
 
-
-_SHOTNESSRECORD_COUNTERSENTRY = _descriptor.Descriptor(
-  name='CountersEntry',
-  full_name='ShotnessRecord.CountersEntry',
+_FILESNAPSHOT_OWNERSENTRY = _descriptor.Descriptor(
+  name='OwnersEntry',
+  full_name='FileSnapshot.OwnersEntry',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='key', full_name='ShotnessRecord.CountersEntry.key', index=0,
+      name='key', full_name='FileSnapshot.OwnersEntry.key', index=0,
       number=1, type=5, cpp_type=1, label=1,
       has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='value', full_name='ShotnessRecord.CountersEntry.value', index=1,
-      number=2, type=5, cpp_type=1, label=1,
+      name='value', full_name='FileSnapshot.OwnersEntry.value', index=1,
+      number=2, type=3, cpp_type=2, label=1,
       has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
@@ -587,48 +723,34 @@ _SHOTNESSRECORD_COUNTERSENTRY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1346,
-  serialized_end=1393,
+  serialized_start=1687,
+  serialized_end=1732,
 )
 
-_SHOTNESSRECORD = _descriptor.Descriptor(
-  name='ShotnessRecord',
-  full_name='ShotnessRecord',
+_FILESNAPSHOT = _descriptor.Descriptor(
+  name='FileSnapshot',
+  full_name='FileSnapshot',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='internal_role', full_name='ShotnessRecord.internal_role', index=0,
+      name='name', full_name='FileSnapshot.name', index=0,
       number=1, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=_b("").decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='roles', full_name='ShotnessRecord.roles', index=1,
-      number=2, type=5, cpp_type=1, label=3,
+      name='ages', full_name='FileSnapshot.ages', index=1,
+      number=2, type=11, cpp_type=10, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='name', full_name='ShotnessRecord.name', index=2,
-      number=3, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=_b("").decode('utf-8'),
-      message_type=None, enum_type=None, containing_type=None,
-      is_extension=False, extension_scope=None,
-      options=None),
-    _descriptor.FieldDescriptor(
-      name='file', full_name='ShotnessRecord.file', index=3,
-      number=4, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=_b("").decode('utf-8'),
-      message_type=None, enum_type=None, containing_type=None,
-      is_extension=False, extension_scope=None,
-      options=None),
-    _descriptor.FieldDescriptor(
-      name='counters', full_name='ShotnessRecord.counters', index=4,
-      number=5, type=11, cpp_type=10, label=3,
+      name='owners', full_name='FileSnapshot.owners', index=2,
+      number=3, type=11, cpp_type=10, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
@@ -636,7 +758,7 @@ _SHOTNESSRECORD = _descriptor.Descriptor(
   ],
   extensions=[
   ],
-  nested_types=[_SHOTNESSRECORD_COUNTERSENTRY, ],
+  nested_types=[_FILESNAPSHOT_AGESENTRY, _FILESNAPSHOT_OWNERSENTRY, ],
   enum_types=[
   ],
   options=None,
@@ -645,52 +767,49 @@ _SHOTNESSRECORD = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1213,
-  serialized_end=1393,
+  serialized_start=1530,
+  serialized_end=1732,
 )
 
 
-_SHOTNESSANALYSISRESULTS = _descriptor.Descriptor(
-  name='ShotnessAnalysisResults',
-  full_name='ShotnessAnalysisResults',
+_COMPRESSEDSPARSEROWMATRIX = _descriptor.Descriptor(
+  name='CompressedSparseRowMatrix',
+  full_name='CompressedSparseRowMatrix',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='records', full_name='ShotnessAnalysisResults.records', index=0,
-      number=1, type=11, cpp_type=10, label=3,
+      name='number_of_rows', full_name='CompressedSparseRowMatrix.number_of_rows', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='number_of_columns', full_name='CompressedSparseRowMatrix.number_of_columns', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='data', full_name='CompressedSparseRowMatrix.data', index=2,
+      number=3, type=3, cpp_type=2, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
-  ],
-  extensions=[
-  ],
-  nested_types=[],
-  enum_types=[
-  ],
-  options=None,
-  is_extendable=False,
-  syntax='proto3',
-  extension_ranges=[],
-  oneofs=[
-  ],
-  serialized_start=1395,
-  serialized_end=1454,
-)
-
-
-_FILEHISTORY = _descriptor.Descriptor(
-  name='FileHistory',
-  full_name='FileHistory',
-  filename=None,
-  file=DESCRIPTOR,
-  containing_type=None,
-  fields=[
     _descriptor.FieldDescriptor(
-      name='commits', full_name='FileHistory.commits', index=0,
-      number=1, type=9, cpp_type=9, label=3,
+      name='indices', full_name='CompressedSparseRowMatrix.indices', index=3,
+      number=4, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='indptr', full_name='CompressedSparseRowMatrix.indptr', index=4,
+      number=5, type=3, cpp_type=2, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
@@ -707,27 +826,27 @@ _FILEHISTORY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1456,
-  serialized_end=1486,
+  serialized_start=1734,
+  serialized_end=1859,
 )
 
 
-_FILEHISTORYRESULTMESSAGE_FILESENTRY = _descriptor.Descriptor(
-  name='FilesEntry',
-  full_name='FileHistoryResultMessage.FilesEntry',
+_COUPLES = _descriptor.Descriptor(
+  name='Couples',
+  full_name='Couples',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='key', full_name='FileHistoryResultMessage.FilesEntry.key', index=0,
-      number=1, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=_b("").decode('utf-8'),
+      name='index', full_name='Couples.index', index=0,
+      number=1, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='value', full_name='FileHistoryResultMessage.FilesEntry.value', index=1,
+      name='matrix', full_name='Couples.matrix', index=1,
       number=2, type=11, cpp_type=10, label=1,
       has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
@@ -739,26 +858,27 @@ _FILEHISTORYRESULTMESSAGE_FILESENTRY = _descriptor.Descriptor(
   nested_types=[],
   enum_types=[
   ],
-  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  options=None,
   is_extendable=False,
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1570,
-  serialized_end=1628,
+  serialized_start=1861,
+  serialized_end=1929,
 )
 
-_FILEHISTORYRESULTMESSAGE = _descriptor.Descriptor(
-  name='FileHistoryResultMessage',
-  full_name='FileHistoryResultMessage',
+
+_TOUCHEDFILES = _descriptor.Descriptor(
+  name='TouchedFiles',
+  full_name='TouchedFiles',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='files', full_name='FileHistoryResultMessage.files', index=0,
-      number=1, type=11, cpp_type=10, label=3,
+      name='files', full_name='TouchedFiles.files', index=0,
+      number=1, type=5, cpp_type=1, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
@@ -766,7 +886,7 @@ _FILEHISTORYRESULTMESSAGE = _descriptor.Descriptor(
   ],
   extensions=[
   ],
-  nested_types=[_FILEHISTORYRESULTMESSAGE_FILESENTRY, ],
+  nested_types=[],
   enum_types=[
   ],
   options=None,
@@ -775,35 +895,49 @@ _FILEHISTORYRESULTMESSAGE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1489,
-  serialized_end=1628,
+  serialized_start=1931,
+  serialized_end=1960,
 )
 
 
-_SENTIMENT = _descriptor.Descriptor(
-  name='Sentiment',
-  full_name='Sentiment',
+_COUPLESSIGNIFICANCE = _descriptor.Descriptor(
+  name='CouplesSignificance',
+  full_name='CouplesSignificance',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='value', full_name='Sentiment.value', index=0,
-      number=1, type=2, cpp_type=6, label=1,
-      has_default_value=False, default_value=float(0),
+      name='commits', full_name='CouplesSignificance.commits', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='comments', full_name='Sentiment.comments', index=1,
-      number=2, type=9, cpp_type=9, label=3,
+      name='lift', full_name='CouplesSignificance.lift', index=1,
+      number=2, type=2, cpp_type=6, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='commits', full_name='Sentiment.commits', index=2,
-      number=3, type=9, cpp_type=9, label=3,
+      name='chi_square', full_name='CouplesSignificance.chi_square', index=2,
+      number=3, type=2, cpp_type=6, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='jaccard', full_name='CouplesSignificance.jaccard', index=3,
+      number=4, type=2, cpp_type=6, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='confidence', full_name='CouplesSignificance.confidence', index=4,
+      number=5, type=2, cpp_type=6, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
@@ -820,58 +954,49 @@ _SENTIMENT = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1630,
-  serialized_end=1691,
+  serialized_start=1962,
+  serialized_end=2071,
 )
 
 
-_COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY = _descriptor.Descriptor(
-  name='SentimentByDayEntry',
-  full_name='CommentSentimentResults.SentimentByDayEntry',
+_COUPLESDECAY = _descriptor.Descriptor(
+  name='CouplesDecay',
+  full_name='CouplesDecay',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='key', full_name='CommentSentimentResults.SentimentByDayEntry.key', index=0,
+      name='half_life', full_name='CouplesDecay.half_life', index=0,
       number=1, type=5, cpp_type=1, label=1,
       has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='value', full_name='CommentSentimentResults.SentimentByDayEntry.value', index=1,
-      number=2, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='window', full_name='CouplesDecay.window', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
-  ],
-  extensions=[
-  ],
-  nested_types=[],
-  enum_types=[
-  ],
-  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
-  is_extendable=False,
-  syntax='proto3',
-  extension_ranges=[],
-  oneofs=[
-  ],
-  serialized_start=1793,
-  serialized_end=1858,
-)
-
-_COMMENTSENTIMENTRESULTS = _descriptor.Descriptor(
-  name='CommentSentimentResults',
-  full_name='CommentSentimentResults',
-  filename=None,
-  file=DESCRIPTOR,
-  containing_type=None,
-  fields=[
     _descriptor.FieldDescriptor(
-      name='sentiment_by_day', full_name='CommentSentimentResults.sentiment_by_day', index=0,
-      number=1, type=11, cpp_type=10, label=3,
+      name='indptr', full_name='CouplesDecay.indptr', index=2,
+      number=3, type=3, cpp_type=2, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='indices', full_name='CouplesDecay.indices', index=3,
+      number=4, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='data', full_name='CouplesDecay.data', index=4,
+      number=5, type=2, cpp_type=6, label=3,
       has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
@@ -879,7 +1004,7 @@ _COMMENTSENTIMENTRESULTS = _descriptor.Descriptor(
   ],
   extensions=[
   ],
-  nested_types=[_COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY, ],
+  nested_types=[],
   enum_types=[
   ],
   options=None,
@@ -888,29 +1013,185 @@ _COMMENTSENTIMENTRESULTS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1694,
-  serialized_end=1858,
+  serialized_start=2073,
+  serialized_end=2169,
 )
 
 
-_ANALYSISRESULTS_CONTENTSENTRY = _descriptor.Descriptor(
-  name='ContentsEntry',
-  full_name='AnalysisResults.ContentsEntry',
+_COUPLESANALYSISRESULTS = _descriptor.Descriptor(
+  name='CouplesAnalysisResults',
+  full_name='CouplesAnalysisResults',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='key', full_name='AnalysisResults.ContentsEntry.key', index=0,
+      name='file_couples', full_name='CouplesAnalysisResults.file_couples', index=0,
+      number=6, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people_couples', full_name='CouplesAnalysisResults.people_couples', index=1,
+      number=7, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people_files', full_name='CouplesAnalysisResults.people_files', index=2,
+      number=8, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='file_significance', full_name='CouplesAnalysisResults.file_significance', index=3,
+      number=9, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='file_decay', full_name='CouplesAnalysisResults.file_decay', index=4,
+      number=10, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='directory_couples', full_name='CouplesAnalysisResults.directory_couples', index=5,
+      number=11, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2172,
+  serialized_end=2420,
+)
+
+
+_UASTCHANGE = _descriptor.Descriptor(
+  name='UASTChange',
+  full_name='UASTChange',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='file_name', full_name='UASTChange.file_name', index=0,
       number=1, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=_b("").decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='value', full_name='AnalysisResults.ContentsEntry.value', index=1,
-      number=2, type=12, cpp_type=9, label=1,
-      has_default_value=False, default_value=_b(""),
+      name='src_before', full_name='UASTChange.src_before', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='src_after', full_name='UASTChange.src_after', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='uast_before', full_name='UASTChange.uast_before', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='uast_after', full_name='UASTChange.uast_after', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2422,
+  serialized_end=2533,
+)
+
+
+_UASTCHANGESSAVERRESULTS = _descriptor.Descriptor(
+  name='UASTChangesSaverResults',
+  full_name='UASTChangesSaverResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='changes', full_name='UASTChangesSaverResults.changes', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2535,
+  serialized_end=2590,
+)
+
+
+_SHOTNESSRECORD_COUNTERSENTRY = _descriptor.Descriptor(
+  name='CountersEntry',
+  full_name='ShotnessRecord.CountersEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ShotnessRecord.CountersEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ShotnessRecord.CountersEntry.value', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
@@ -926,233 +1207,8742 @@ _ANALYSISRESULTS_CONTENTSENTRY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1957,
-  serialized_end=2004,
+  serialized_start=2726,
+  serialized_end=2773,
+)
+
+_SHOTNESSRECORD = _descriptor.Descriptor(
+  name='ShotnessRecord',
+  full_name='ShotnessRecord',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='internal_role', full_name='ShotnessRecord.internal_role', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='roles', full_name='ShotnessRecord.roles', index=1,
+      number=2, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='name', full_name='ShotnessRecord.name', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='file', full_name='ShotnessRecord.file', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='counters', full_name='ShotnessRecord.counters', index=4,
+      number=5, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_SHOTNESSRECORD_COUNTERSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2593,
+  serialized_end=2773,
+)
+
+
+_SHOTNESSANALYSISRESULTS = _descriptor.Descriptor(
+  name='ShotnessAnalysisResults',
+  full_name='ShotnessAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='records', full_name='ShotnessAnalysisResults.records', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2775,
+  serialized_end=2834,
 )
 
-_ANALYSISRESULTS = _descriptor.Descriptor(
-  name='AnalysisResults',
-  full_name='AnalysisResults',
-  filename=None,
-  file=DESCRIPTOR,
-  containing_type=None,
-  fields=[
-    _descriptor.FieldDescriptor(
-      name='header', full_name='AnalysisResults.header', index=0,
-      number=1, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
-      message_type=None, enum_type=None, containing_type=None,
-      is_extension=False, extension_scope=None,
-      options=None),
-    _descriptor.FieldDescriptor(
-      name='contents', full_name='AnalysisResults.contents', index=1,
-      number=2, type=11, cpp_type=10, label=3,
-      has_default_value=False, default_value=[],
-      message_type=None, enum_type=None, containing_type=None,
-      is_extension=False, extension_scope=None,
-      options=None),
-  ],
-  extensions=[
-  ],
-  nested_types=[_ANALYSISRESULTS_CONTENTSENTRY, ],
-  enum_types=[
-  ],
-  options=None,
-  is_extendable=False,
-  syntax='proto3',
-  extension_ranges=[],
-  oneofs=[
-  ],
-  serialized_start=1861,
-  serialized_end=2004,
-)
 
-_METADATA_RUNTIMEPERITEMENTRY.containing_type = _METADATA
-_METADATA.fields_by_name['run_time_per_item'].message_type = _METADATA_RUNTIMEPERITEMENTRY
-_BURNDOWNSPARSEMATRIX.fields_by_name['rows'].message_type = _BURNDOWNSPARSEMATRIXROW
-_BURNDOWNANALYSISRESULTS.fields_by_name['project'].message_type = _BURNDOWNSPARSEMATRIX
-_BURNDOWNANALYSISRESULTS.fields_by_name['files'].message_type = _BURNDOWNSPARSEMATRIX
-_BURNDOWNANALYSISRESULTS.fields_by_name['people'].message_type = _BURNDOWNSPARSEMATRIX
-_BURNDOWNANALYSISRESULTS.fields_by_name['people_interaction'].message_type = _COMPRESSEDSPARSEROWMATRIX
-_COUPLES.fields_by_name['matrix'].message_type = _COMPRESSEDSPARSEROWMATRIX
-_COUPLESANALYSISRESULTS.fields_by_name['file_couples'].message_type = _COUPLES
-_COUPLESANALYSISRESULTS.fields_by_name['people_couples'].message_type = _COUPLES
-_COUPLESANALYSISRESULTS.fields_by_name['people_files'].message_type = _TOUCHEDFILES
-_UASTCHANGESSAVERRESULTS.fields_by_name['changes'].message_type = _UASTCHANGE
-_SHOTNESSRECORD_COUNTERSENTRY.containing_type = _SHOTNESSRECORD
-_SHOTNESSRECORD.fields_by_name['counters'].message_type = _SHOTNESSRECORD_COUNTERSENTRY
-_SHOTNESSANALYSISRESULTS.fields_by_name['records'].message_type = _SHOTNESSRECORD
-_FILEHISTORYRESULTMESSAGE_FILESENTRY.fields_by_name['value'].message_type = _FILEHISTORY
-_FILEHISTORYRESULTMESSAGE_FILESENTRY.containing_type = _FILEHISTORYRESULTMESSAGE
-_FILEHISTORYRESULTMESSAGE.fields_by_name['files'].message_type = _FILEHISTORYRESULTMESSAGE_FILESENTRY
-_COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY.fields_by_name['value'].message_type = _SENTIMENT
-_COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY.containing_type = _COMMENTSENTIMENTRESULTS
-_COMMENTSENTIMENTRESULTS.fields_by_name['sentiment_by_day'].message_type = _COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY
-_ANALYSISRESULTS_CONTENTSENTRY.containing_type = _ANALYSISRESULTS
-_ANALYSISRESULTS.fields_by_name['header'].message_type = _METADATA
-_ANALYSISRESULTS.fields_by_name['contents'].message_type = _ANALYSISRESULTS_CONTENTSENTRY
-DESCRIPTOR.message_types_by_name['Metadata'] = _METADATA
-DESCRIPTOR.message_types_by_name['BurndownSparseMatrixRow'] = _BURNDOWNSPARSEMATRIXROW
-DESCRIPTOR.message_types_by_name['BurndownSparseMatrix'] = _BURNDOWNSPARSEMATRIX
-DESCRIPTOR.message_types_by_name['BurndownAnalysisResults'] = _BURNDOWNANALYSISRESULTS
-DESCRIPTOR.message_types_by_name['CompressedSparseRowMatrix'] = _COMPRESSEDSPARSEROWMATRIX
-DESCRIPTOR.message_types_by_name['Couples'] = _COUPLES
-DESCRIPTOR.message_types_by_name['TouchedFiles'] = _TOUCHEDFILES
-DESCRIPTOR.message_types_by_name['CouplesAnalysisResults'] = _COUPLESANALYSISRESULTS
-DESCRIPTOR.message_types_by_name['UASTChange'] = _UASTCHANGE
-DESCRIPTOR.message_types_by_name['UASTChangesSaverResults'] = _UASTCHANGESSAVERRESULTS
-DESCRIPTOR.message_types_by_name['ShotnessRecord'] = _SHOTNESSRECORD
-DESCRIPTOR.message_types_by_name['ShotnessAnalysisResults'] = _SHOTNESSANALYSISRESULTS
-DESCRIPTOR.message_types_by_name['FileHistory'] = _FILEHISTORY
-DESCRIPTOR.message_types_by_name['FileHistoryResultMessage'] = _FILEHISTORYRESULTMESSAGE
-DESCRIPTOR.message_types_by_name['Sentiment'] = _SENTIMENT
-DESCRIPTOR.message_types_by_name['CommentSentimentResults'] = _COMMENTSENTIMENTRESULTS
-DESCRIPTOR.message_types_by_name['AnalysisResults'] = _ANALYSISRESULTS
-_sym_db.RegisterFileDescriptor(DESCRIPTOR)
+_FILEHISTORY = _descriptor.Descriptor(
+  name='FileHistory',
+  full_name='FileHistory',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='FileHistory.commits', index=0,
+      number=1, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2836,
+  serialized_end=2866,
+)
+
+
+_FILEHISTORYRESULTMESSAGE_FILESENTRY = _descriptor.Descriptor(
+  name='FilesEntry',
+  full_name='FileHistoryResultMessage.FilesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='FileHistoryResultMessage.FilesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='FileHistoryResultMessage.FilesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2950,
+  serialized_end=3008,
+)
+
+_FILEHISTORYRESULTMESSAGE = _descriptor.Descriptor(
+  name='FileHistoryResultMessage',
+  full_name='FileHistoryResultMessage',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='files', full_name='FileHistoryResultMessage.files', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_FILEHISTORYRESULTMESSAGE_FILESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2869,
+  serialized_end=3008,
+)
+
+
+_SENTIMENT = _descriptor.Descriptor(
+  name='Sentiment',
+  full_name='Sentiment',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='value', full_name='Sentiment.value', index=0,
+      number=1, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='comments', full_name='Sentiment.comments', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='Sentiment.commits', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3010,
+  serialized_end=3071,
+)
+
+
+_COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY = _descriptor.Descriptor(
+  name='SentimentByDayEntry',
+  full_name='CommentSentimentResults.SentimentByDayEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='CommentSentimentResults.SentimentByDayEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='CommentSentimentResults.SentimentByDayEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3173,
+  serialized_end=3238,
+)
+
+_COMMENTSENTIMENTRESULTS = _descriptor.Descriptor(
+  name='CommentSentimentResults',
+  full_name='CommentSentimentResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='sentiment_by_day', full_name='CommentSentimentResults.sentiment_by_day', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3074,
+  serialized_end=3238,
+)
+
+
+_RECORDEDCOLUMN = _descriptor.Descriptor(
+  name='RecordedColumn',
+  full_name='RecordedColumn',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='name', full_name='RecordedColumn.name', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='values', full_name='RecordedColumn.values', index=1,
+      number=2, type=3, cpp_type=2, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dictionary', full_name='RecordedColumn.dictionary', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3240,
+  serialized_end=3306,
+)
+
+
+_RECORDEDSTREAM = _descriptor.Descriptor(
+  name='RecordedStream',
+  full_name='RecordedStream',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='name', full_name='RecordedStream.name', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='columns', full_name='RecordedStream.columns', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3308,
+  serialized_end=3372,
+)
+
+
+_RECORDERRESULTS = _descriptor.Descriptor(
+  name='RecorderResults',
+  full_name='RecorderResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='RecorderResults.commits', index=0,
+      number=1, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='streams', full_name='RecorderResults.streams', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3374,
+  serialized_end=3442,
+)
+
+
+_ACTIVITYDAY_COMMITSENTRY = _descriptor.Descriptor(
+  name='CommitsEntry',
+  full_name='ActivityDay.CommitsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ActivityDay.CommitsEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ActivityDay.CommitsEntry.value', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3503,
+  serialized_end=3549,
+)
+
+_ACTIVITYDAY = _descriptor.Descriptor(
+  name='ActivityDay',
+  full_name='ActivityDay',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='ActivityDay.commits', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_ACTIVITYDAY_COMMITSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3444,
+  serialized_end=3549,
+)
+
+
+_ACTIVEDEVELOPERS = _descriptor.Descriptor(
+  name='ActiveDevelopers',
+  full_name='ActiveDevelopers',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='developers', full_name='ActiveDevelopers.developers', index=0,
+      number=1, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3551,
+  serialized_end=3589,
+)
+
+
+_ACTIVITYANALYSISRESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='ActivityAnalysisResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ActivityAnalysisResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ActivityAnalysisResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3811,
+  serialized_end=3868,
+)
+
+_ACTIVITYANALYSISRESULTS_ACTIVEENTRY = _descriptor.Descriptor(
+  name='ActiveEntry',
+  full_name='ActivityAnalysisResults.ActiveEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ActivityAnalysisResults.ActiveEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ActivityAnalysisResults.ActiveEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3870,
+  serialized_end=3934,
+)
+
+_ACTIVITYANALYSISRESULTS = _descriptor.Descriptor(
+  name='ActivityAnalysisResults',
+  full_name='ActivityAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='days', full_name='ActivityAnalysisResults.days', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people_commits', full_name='ActivityAnalysisResults.people_commits', index=1,
+      number=2, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people_files', full_name='ActivityAnalysisResults.people_files', index=2,
+      number=3, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='ActivityAnalysisResults.dev_index', index=3,
+      number=4, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='active_window', full_name='ActivityAnalysisResults.active_window', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='active', full_name='ActivityAnalysisResults.active', index=5,
+      number=6, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_ACTIVITYANALYSISRESULTS_DAYSENTRY, _ACTIVITYANALYSISRESULTS_ACTIVEENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3592,
+  serialized_end=3934,
+)
+
+
+_LANGUAGECOUNTS_LANGUAGESENTRY = _descriptor.Descriptor(
+  name='LanguagesEntry',
+  full_name='LanguageCounts.LanguagesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='LanguageCounts.LanguagesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='LanguageCounts.LanguagesEntry.value', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4005,
+  serialized_end=4053,
+)
+
+_LANGUAGECOUNTS = _descriptor.Descriptor(
+  name='LanguageCounts',
+  full_name='LanguageCounts',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='languages', full_name='LanguageCounts.languages', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_LANGUAGECOUNTS_LANGUAGESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3936,
+  serialized_end=4053,
+)
+
+
+_COMMITLANGUAGESANALYSISRESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='CommitLanguagesAnalysisResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='CommitLanguagesAnalysisResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='CommitLanguagesAnalysisResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4199,
+  serialized_end=4259,
+)
+
+_COMMITLANGUAGESANALYSISRESULTS = _descriptor.Descriptor(
+  name='CommitLanguagesAnalysisResults',
+  full_name='CommitLanguagesAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='days', full_name='CommitLanguagesAnalysisResults.days', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people', full_name='CommitLanguagesAnalysisResults.people', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='CommitLanguagesAnalysisResults.dev_index', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_COMMITLANGUAGESANALYSISRESULTS_DAYSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4056,
+  serialized_end=4259,
+)
+
+
+_IMPACTCHURNDAY = _descriptor.Descriptor(
+  name='ImpactChurnDay',
+  full_name='ImpactChurnDay',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='added', full_name='ImpactChurnDay.added', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='removed', full_name='ImpactChurnDay.removed', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='weighted', full_name='ImpactChurnDay.weighted', index=2,
+      number=3, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4261,
+  serialized_end=4327,
+)
+
+
+_IMPACTCHURNFILE = _descriptor.Descriptor(
+  name='ImpactChurnFile',
+  full_name='ImpactChurnFile',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='ImpactChurnFile.lines', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='fan_in', full_name='ImpactChurnFile.fan_in', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='weighted', full_name='ImpactChurnFile.weighted', index=2,
+      number=3, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4329,
+  serialized_end=4395,
+)
+
+
+_IMPACTCHURNANALYSISRESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='ImpactChurnAnalysisResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ImpactChurnAnalysisResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ImpactChurnAnalysisResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4557,
+  serialized_end=4617,
+)
+
+_IMPACTCHURNANALYSISRESULTS_FILESENTRY = _descriptor.Descriptor(
+  name='FilesEntry',
+  full_name='ImpactChurnAnalysisResults.FilesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ImpactChurnAnalysisResults.FilesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ImpactChurnAnalysisResults.FilesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4619,
+  serialized_end=4681,
+)
+
+_IMPACTCHURNANALYSISRESULTS = _descriptor.Descriptor(
+  name='ImpactChurnAnalysisResults',
+  full_name='ImpactChurnAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='days', full_name='ImpactChurnAnalysisResults.days', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='files', full_name='ImpactChurnAnalysisResults.files', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='fan_in_mode', full_name='ImpactChurnAnalysisResults.fan_in_mode', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_IMPACTCHURNANALYSISRESULTS_DAYSENTRY, _IMPACTCHURNANALYSISRESULTS_FILESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4398,
+  serialized_end=4681,
+)
+
+
+_DAGSHAPETICK = _descriptor.Descriptor(
+  name='DAGShapeTick',
+  full_name='DAGShapeTick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='DAGShapeTick.commits', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='merges', full_name='DAGShapeTick.merges', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='parents', full_name='DAGShapeTick.parents', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='max_branches', full_name='DAGShapeTick.max_branches', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='longest_chain', full_name='DAGShapeTick.longest_chain', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4683,
+  serialized_end=4792,
+)
+
+
+_DAGSHAPEANALYSISRESULTS = _descriptor.Descriptor(
+  name='DAGShapeAnalysisResults',
+  full_name='DAGShapeAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='DAGShapeAnalysisResults.ticks', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='DAGShapeAnalysisResults.sampling', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4794,
+  serialized_end=4867,
+)
+
+
+_RENAMECHAIN = _descriptor.Descriptor(
+  name='RenameChain',
+  full_name='RenameChain',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='names', full_name='RenameChain.names', index=0,
+      number=1, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='days', full_name='RenameChain.days', index=1,
+      number=2, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='deleted', full_name='RenameChain.deleted', index=2,
+      number=3, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4869,
+  serialized_end=4928,
+)
+
+
+_RENAMEFREQUENCYANALYSISRESULTS_DIRECTORIESENTRY = _descriptor.Descriptor(
+  name='DirectoriesEntry',
+  full_name='RenameFrequencyAnalysisResults.DirectoriesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='RenameFrequencyAnalysisResults.DirectoriesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='RenameFrequencyAnalysisResults.DirectoriesEntry.value', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5124,
+  serialized_end=5174,
+)
+
+_RENAMEFREQUENCYANALYSISRESULTS = _descriptor.Descriptor(
+  name='RenameFrequencyAnalysisResults',
+  full_name='RenameFrequencyAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='RenameFrequencyAnalysisResults.ticks', index=0,
+      number=1, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='chains', full_name='RenameFrequencyAnalysisResults.chains', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='RenameFrequencyAnalysisResults.directories', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='RenameFrequencyAnalysisResults.sampling', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='directory_depth', full_name='RenameFrequencyAnalysisResults.directory_depth', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_RENAMEFREQUENCYANALYSISRESULTS_DIRECTORIESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4931,
+  serialized_end=5174,
+)
+
+
+_REWRITEDEPTHFILE = _descriptor.Descriptor(
+  name='RewriteDepthFile',
+  full_name='RewriteDepthFile',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='RewriteDepthFile.lines', index=0,
+      number=1, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5176,
+  serialized_end=5209,
+)
+
+
+_REWRITEHOTSPOT = _descriptor.Descriptor(
+  name='RewriteHotspot',
+  full_name='RewriteHotspot',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='file', full_name='RewriteHotspot.file', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='line', full_name='RewriteHotspot.line', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='length', full_name='RewriteHotspot.length', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='depth', full_name='RewriteHotspot.depth', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5211,
+  serialized_end=5286,
+)
+
+
+_REWRITEDEPTHANALYSISRESULTS_FILESENTRY = _descriptor.Descriptor(
+  name='FilesEntry',
+  full_name='RewriteDepthAnalysisResults.FilesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='RewriteDepthAnalysisResults.FilesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='RewriteDepthAnalysisResults.FilesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5456,
+  serialized_end=5519,
+)
+
+_REWRITEDEPTHANALYSISRESULTS = _descriptor.Descriptor(
+  name='RewriteDepthAnalysisResults',
+  full_name='RewriteDepthAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='files', full_name='RewriteDepthAnalysisResults.files', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='hotspots', full_name='RewriteDepthAnalysisResults.hotspots', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='hotspot_depth', full_name='RewriteDepthAnalysisResults.hotspot_depth', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='max_hotspots', full_name='RewriteDepthAnalysisResults.max_hotspots', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_REWRITEDEPTHANALYSISRESULTS_FILESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5289,
+  serialized_end=5519,
+)
+
+
+_SENSITIVEPATHCHANGE = _descriptor.Descriptor(
+  name='SensitivePathChange',
+  full_name='SensitivePathChange',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commit', full_name='SensitivePathChange.commit', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='author', full_name='SensitivePathChange.author', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='tick', full_name='SensitivePathChange.tick', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='file', full_name='SensitivePathChange.file', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='churn', full_name='SensitivePathChange.churn', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5521,
+  serialized_end=5617,
+)
+
+
+_SENSITIVEPATHSANALYSISRESULTS = _descriptor.Descriptor(
+  name='SensitivePathsAnalysisResults',
+  full_name='SensitivePathsAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='changes', full_name='SensitivePathsAnalysisResults.changes', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='SensitivePathsAnalysisResults.sampling', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='patterns', full_name='SensitivePathsAnalysisResults.patterns', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='SensitivePathsAnalysisResults.dev_index', index=3,
+      number=4, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5619,
+  serialized_end=5744,
+)
+
+
+_CONTRIBUTORSTICK = _descriptor.Descriptor(
+  name='ContributorsTick',
+  full_name='ContributorsTick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='core', full_name='ContributorsTick.core', index=0,
+      number=1, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='regular', full_name='ContributorsTick.regular', index=1,
+      number=2, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='drive_by', full_name='ContributorsTick.drive_by', index=2,
+      number=3, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5746,
+  serialized_end=5813,
+)
+
+
+_CONTRIBUTORSANALYSISRESULTS = _descriptor.Descriptor(
+  name='ContributorsAnalysisResults',
+  full_name='ContributorsAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='ContributorsAnalysisResults.ticks', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='ContributorsAnalysisResults.sampling', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='core_commits', full_name='ContributorsAnalysisResults.core_commits', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='core_span', full_name='ContributorsAnalysisResults.core_span', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='drive_by_commits', full_name='ContributorsAnalysisResults.drive_by_commits', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='drive_by_span', full_name='ContributorsAnalysisResults.drive_by_span', index=5,
+      number=6, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='ContributorsAnalysisResults.dev_index', index=6,
+      number=7, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5816,
+  serialized_end=6006,
+)
+
+
+_STEWARDSHIPCOUNTS = _descriptor.Descriptor(
+  name='StewardshipCounts',
+  full_name='StewardshipCounts',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='self', full_name='StewardshipCounts.self', index=0,
+      number=1, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='others', full_name='StewardshipCounts.others', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6008,
+  serialized_end=6057,
+)
+
+
+_STEWARDSHIPTICK_PEOPLEENTRY = _descriptor.Descriptor(
+  name='PeopleEntry',
+  full_name='StewardshipTick.PeopleEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='StewardshipTick.PeopleEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='StewardshipTick.PeopleEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6125,
+  serialized_end=6190,
+)
+
+_STEWARDSHIPTICK = _descriptor.Descriptor(
+  name='StewardshipTick',
+  full_name='StewardshipTick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='people', full_name='StewardshipTick.people', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_STEWARDSHIPTICK_PEOPLEENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6060,
+  serialized_end=6190,
+)
+
+
+_STEWARDSHIPANALYSISRESULTS = _descriptor.Descriptor(
+  name='StewardshipAnalysisResults',
+  full_name='StewardshipAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='StewardshipAnalysisResults.ticks', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='StewardshipAnalysisResults.sampling', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='StewardshipAnalysisResults.dev_index', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6192,
+  serialized_end=6290,
+)
+
+
+_TEAMALIGNMENTDIRECTORY_EDITSENTRY = _descriptor.Descriptor(
+  name='EditsEntry',
+  full_name='TeamAlignmentDirectory.EditsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='TeamAlignmentDirectory.EditsEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='TeamAlignmentDirectory.EditsEntry.value', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6430,
+  serialized_end=6474,
+)
+
+_TEAMALIGNMENTDIRECTORY = _descriptor.Descriptor(
+  name='TeamAlignmentDirectory',
+  full_name='TeamAlignmentDirectory',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='directory', full_name='TeamAlignmentDirectory.directory', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='edits', full_name='TeamAlignmentDirectory.edits', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='owner', full_name='TeamAlignmentDirectory.owner', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='cross_team_edits', full_name='TeamAlignmentDirectory.cross_team_edits', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_TEAMALIGNMENTDIRECTORY_EDITSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6293,
+  serialized_end=6474,
+)
+
+
+_TEAMALIGNMENTTICK = _descriptor.Descriptor(
+  name='TeamAlignmentTick',
+  full_name='TeamAlignmentTick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='TeamAlignmentTick.directories', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6476,
+  serialized_end=6541,
+)
+
+
+_TEAMALIGNMENTANALYSISRESULTS = _descriptor.Descriptor(
+  name='TeamAlignmentAnalysisResults',
+  full_name='TeamAlignmentAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='TeamAlignmentAnalysisResults.ticks', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='TeamAlignmentAnalysisResults.sampling', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='depth', full_name='TeamAlignmentAnalysisResults.depth', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='TeamAlignmentAnalysisResults.dev_index', index=3,
+      number=4, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6543,
+  serialized_end=6660,
+)
+
+
+_DEFECTFEATURESANALYSISRESULTS = _descriptor.Descriptor(
+  name='DefectFeaturesAnalysisResults',
+  full_name='DefectFeaturesAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='files', full_name='DefectFeaturesAnalysisResults.files', index=0,
+      number=1, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='tick', full_name='DefectFeaturesAnalysisResults.tick', index=1,
+      number=2, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='file', full_name='DefectFeaturesAnalysisResults.file', index=2,
+      number=3, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='DefectFeaturesAnalysisResults.commits', index=3,
+      number=4, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='churn', full_name='DefectFeaturesAnalysisResults.churn', index=4,
+      number=5, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='authors', full_name='DefectFeaturesAnalysisResults.authors', index=5,
+      number=6, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='age', full_name='DefectFeaturesAnalysisResults.age', index=6,
+      number=7, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='DefectFeaturesAnalysisResults.lines', index=7,
+      number=8, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='complexity', full_name='DefectFeaturesAnalysisResults.complexity', index=8,
+      number=9, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='coupling', full_name='DefectFeaturesAnalysisResults.coupling', index=9,
+      number=10, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='past_fixes', full_name='DefectFeaturesAnalysisResults.past_fixes', index=10,
+      number=11, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='fixes', full_name='DefectFeaturesAnalysisResults.fixes', index=11,
+      number=12, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='DefectFeaturesAnalysisResults.sampling', index=12,
+      number=13, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='fix_patterns', full_name='DefectFeaturesAnalysisResults.fix_patterns', index=13,
+      number=14, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6663,
+  serialized_end=6927,
+)
+
+
+_COCOMOTICK = _descriptor.Descriptor(
+  name='CocomoTick',
+  full_name='CocomoTick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='CocomoTick.lines', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='added', full_name='CocomoTick.added', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='removed', full_name='CocomoTick.removed', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='effort', full_name='CocomoTick.effort', index=3,
+      number=4, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='churn_effort', full_name='CocomoTick.churn_effort', index=4,
+      number=5, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6929,
+  serialized_end=7026,
+)
+
+
+_COCOMOANALYSISRESULTS = _descriptor.Descriptor(
+  name='CocomoAnalysisResults',
+  full_name='CocomoAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='CocomoAnalysisResults.ticks', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='CocomoAnalysisResults.sampling', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='coefficient', full_name='CocomoAnalysisResults.coefficient', index=2,
+      number=3, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='exponent', full_name='CocomoAnalysisResults.exponent', index=3,
+      number=4, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='monthly_cost', full_name='CocomoAnalysisResults.monthly_cost', index=4,
+      number=5, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7029,
+  serialized_end=7159,
+)
+
+
+_REVIEWLATENCYSTATS = _descriptor.Descriptor(
+  name='ReviewLatencyStats',
+  full_name='ReviewLatencyStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='ReviewLatencyStats.commits', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='histogram', full_name='ReviewLatencyStats.histogram', index=1,
+      number=2, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='total', full_name='ReviewLatencyStats.total', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='max', full_name='ReviewLatencyStats.max', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7161,
+  serialized_end=7245,
+)
+
+
+_REVIEWLATENCYANALYSISRESULTS_PEOPLEENTRY = _descriptor.Descriptor(
+  name='PeopleEntry',
+  full_name='ReviewLatencyAnalysisResults.PeopleEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ReviewLatencyAnalysisResults.PeopleEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ReviewLatencyAnalysisResults.PeopleEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7429,
+  serialized_end=7495,
+)
+
+_REVIEWLATENCYANALYSISRESULTS = _descriptor.Descriptor(
+  name='ReviewLatencyAnalysisResults',
+  full_name='ReviewLatencyAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='ReviewLatencyAnalysisResults.ticks', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people', full_name='ReviewLatencyAnalysisResults.people', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='ReviewLatencyAnalysisResults.sampling', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='buckets', full_name='ReviewLatencyAnalysisResults.buckets', index=3,
+      number=4, type=3, cpp_type=2, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='ReviewLatencyAnalysisResults.dev_index', index=4,
+      number=5, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_REVIEWLATENCYANALYSISRESULTS_PEOPLEENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7248,
+  serialized_end=7495,
+)
+
+
+_ISSUETICKET = _descriptor.Descriptor(
+  name='IssueTicket',
+  full_name='IssueTicket',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='IssueTicket.commits', index=0,
+      number=1, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='churn', full_name='IssueTicket.churn', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='first_day', full_name='IssueTicket.first_day', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='last_day', full_name='IssueTicket.last_day', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7497,
+  serialized_end=7579,
+)
+
+
+_ISSUEREFERENCESANALYSISRESULTS_TICKETSENTRY = _descriptor.Descriptor(
+  name='TicketsEntry',
+  full_name='IssueReferencesAnalysisResults.TicketsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='IssueReferencesAnalysisResults.TicketsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='IssueReferencesAnalysisResults.TicketsEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7742,
+  serialized_end=7802,
+)
+
+_ISSUEREFERENCESANALYSISRESULTS = _descriptor.Descriptor(
+  name='IssueReferencesAnalysisResults',
+  full_name='IssueReferencesAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='tickets', full_name='IssueReferencesAnalysisResults.tickets', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='IssueReferencesAnalysisResults.commits', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='referenced_commits', full_name='IssueReferencesAnalysisResults.referenced_commits', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='patterns', full_name='IssueReferencesAnalysisResults.patterns', index=3,
+      number=4, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_ISSUEREFERENCESANALYSISRESULTS_TICKETSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7582,
+  serialized_end=7802,
+)
+
+
+_CONVENTIONALCOMMITSSTATS_TYPESENTRY = _descriptor.Descriptor(
+  name='TypesEntry',
+  full_name='ConventionalCommitsStats.TypesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ConventionalCommitsStats.TypesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ConventionalCommitsStats.TypesEntry.value', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7940,
+  serialized_end=7984,
+)
+
+_CONVENTIONALCOMMITSSTATS = _descriptor.Descriptor(
+  name='ConventionalCommitsStats',
+  full_name='ConventionalCommitsStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='ConventionalCommitsStats.commits', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='compliant', full_name='ConventionalCommitsStats.compliant', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='breaking', full_name='ConventionalCommitsStats.breaking', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='types', full_name='ConventionalCommitsStats.types', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_CONVENTIONALCOMMITSSTATS_TYPESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7805,
+  serialized_end=7984,
+)
+
+
+_CONVENTIONALCOMMITSANALYSISRESULTS_PEOPLEENTRY = _descriptor.Descriptor(
+  name='PeopleEntry',
+  full_name='ConventionalCommitsAnalysisResults.PeopleEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ConventionalCommitsAnalysisResults.PeopleEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ConventionalCommitsAnalysisResults.PeopleEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8169,
+  serialized_end=8241,
+)
+
+_CONVENTIONALCOMMITSANALYSISRESULTS = _descriptor.Descriptor(
+  name='ConventionalCommitsAnalysisResults',
+  full_name='ConventionalCommitsAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='ConventionalCommitsAnalysisResults.ticks', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people', full_name='ConventionalCommitsAnalysisResults.people', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='ConventionalCommitsAnalysisResults.sampling', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='ConventionalCommitsAnalysisResults.dev_index', index=3,
+      number=4, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_CONVENTIONALCOMMITSANALYSISRESULTS_PEOPLEENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7987,
+  serialized_end=8241,
+)
+
+
+_LANGUAGELINES = _descriptor.Descriptor(
+  name='LanguageLines',
+  full_name='LanguageLines',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='LanguageLines.ticks', index=0,
+      number=1, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8243,
+  serialized_end=8273,
+)
+
+
+_LANGUAGELINESANALYSISRESULTS_LANGUAGESENTRY = _descriptor.Descriptor(
+  name='LanguagesEntry',
+  full_name='LanguageLinesAnalysisResults.LanguagesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='LanguageLinesAnalysisResults.LanguagesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='LanguageLinesAnalysisResults.LanguagesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8391,
+  serialized_end=8455,
+)
+
+_LANGUAGELINESANALYSISRESULTS = _descriptor.Descriptor(
+  name='LanguageLinesAnalysisResults',
+  full_name='LanguageLinesAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='languages', full_name='LanguageLinesAnalysisResults.languages', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='LanguageLinesAnalysisResults.sampling', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_LANGUAGELINESANALYSISRESULTS_LANGUAGESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8276,
+  serialized_end=8455,
+)
+
+
+_REPOSITORYSIZETICK = _descriptor.Descriptor(
+  name='RepositorySizeTick',
+  full_name='RepositorySizeTick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='text_bytes', full_name='RepositorySizeTick.text_bytes', index=0,
+      number=1, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='binary_bytes', full_name='RepositorySizeTick.binary_bytes', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8457,
+  serialized_end=8519,
+)
+
+
+_REPOSITORYBLOB = _descriptor.Descriptor(
+  name='RepositoryBlob',
+  full_name='RepositoryBlob',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='file', full_name='RepositoryBlob.file', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='commit', full_name='RepositoryBlob.commit', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='day', full_name='RepositoryBlob.day', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='size', full_name='RepositoryBlob.size', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='binary', full_name='RepositoryBlob.binary', index=4,
+      number=5, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8521,
+  serialized_end=8610,
+)
+
+
+_REPOSITORYSIZEANALYSISRESULTS = _descriptor.Descriptor(
+  name='RepositorySizeAnalysisResults',
+  full_name='RepositorySizeAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='RepositorySizeAnalysisResults.ticks', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='largest', full_name='RepositorySizeAnalysisResults.largest', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='top', full_name='RepositorySizeAnalysisResults.top', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='RepositorySizeAnalysisResults.sampling', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8613,
+  serialized_end=8745,
+)
+
+
+_FILEAGE = _descriptor.Descriptor(
+  name='FileAge',
+  full_name='FileAge',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='created', full_name='FileAge.created', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='modified', full_name='FileAge.modified', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='last_active_author', full_name='FileAge.last_active_author', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8747,
+  serialized_end=8819,
+)
+
+
+_FILEAGEANALYSISRESULTS_FILESENTRY = _descriptor.Descriptor(
+  name='FilesEntry',
+  full_name='FileAgeAnalysisResults.FilesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='FileAgeAnalysisResults.FilesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='FileAgeAnalysisResults.FilesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8999,
+  serialized_end=9053,
+)
+
+_FILEAGEANALYSISRESULTS = _descriptor.Descriptor(
+  name='FileAgeAnalysisResults',
+  full_name='FileAgeAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='files', full_name='FileAgeAnalysisResults.files', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='orphaned', full_name='FileAgeAnalysisResults.orphaned', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='last_day', full_name='FileAgeAnalysisResults.last_day', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='inactive_days', full_name='FileAgeAnalysisResults.inactive_days', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='significance', full_name='FileAgeAnalysisResults.significance', index=4,
+      number=5, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='FileAgeAnalysisResults.dev_index', index=5,
+      number=6, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_FILEAGEANALYSISRESULTS_FILESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8822,
+  serialized_end=9053,
+)
+
+
+_REFACTORINGSTATS = _descriptor.Descriptor(
+  name='RefactoringStats',
+  full_name='RefactoringStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='RefactoringStats.commits', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='refactorings', full_name='RefactoringStats.refactorings', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='RefactoringStats.lines', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='refactoring_lines', full_name='RefactoringStats.refactoring_lines', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9055,
+  serialized_end=9154,
+)
+
+
+_REFACTORINGANALYSISRESULTS_PEOPLEENTRY = _descriptor.Descriptor(
+  name='PeopleEntry',
+  full_name='RefactoringAnalysisResults.PeopleEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='RefactoringAnalysisResults.PeopleEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='RefactoringAnalysisResults.PeopleEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9334,
+  serialized_end=9398,
+)
+
+_REFACTORINGANALYSISRESULTS = _descriptor.Descriptor(
+  name='RefactoringAnalysisResults',
+  full_name='RefactoringAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='RefactoringAnalysisResults.ticks', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people', full_name='RefactoringAnalysisResults.people', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='threshold', full_name='RefactoringAnalysisResults.threshold', index=2,
+      number=3, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='RefactoringAnalysisResults.sampling', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='RefactoringAnalysisResults.dev_index', index=4,
+      number=5, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_REFACTORINGANALYSISRESULTS_PEOPLEENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9157,
+  serialized_end=9398,
+)
+
+
+_REVERTSTICK = _descriptor.Descriptor(
+  name='RevertsTick',
+  full_name='RevertsTick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='RevertsTick.commits', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='reverts', full_name='RevertsTick.reverts', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9400,
+  serialized_end=9447,
+)
+
+
+_REVERTEDCOMMIT = _descriptor.Descriptor(
+  name='RevertedCommit',
+  full_name='RevertedCommit',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='hash', full_name='RevertedCommit.hash', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='revert', full_name='RevertedCommit.revert', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='day', full_name='RevertedCommit.day', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='hours', full_name='RevertedCommit.hours', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9449,
+  serialized_end=9523,
+)
+
+
+_REVERTSANALYSISRESULTS_FILESENTRY = _descriptor.Descriptor(
+  name='FilesEntry',
+  full_name='RevertsAnalysisResults.FilesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='RevertsAnalysisResults.FilesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='RevertsAnalysisResults.FilesEntry.value', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9685,
+  serialized_end=9729,
+)
+
+_REVERTSANALYSISRESULTS = _descriptor.Descriptor(
+  name='RevertsAnalysisResults',
+  full_name='RevertsAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='RevertsAnalysisResults.ticks', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='reverted', full_name='RevertsAnalysisResults.reverted', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='files', full_name='RevertsAnalysisResults.files', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='RevertsAnalysisResults.sampling', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_REVERTSANALYSISRESULTS_FILESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9526,
+  serialized_end=9729,
+)
+
+
+_BRANCHMERGE = _descriptor.Descriptor(
+  name='BranchMerge',
+  full_name='BranchMerge',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='day', full_name='BranchMerge.day', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='BranchMerge.commits', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='lifetime', full_name='BranchMerge.lifetime', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='latency', full_name='BranchMerge.latency', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9731,
+  serialized_end=9809,
+)
+
+
+_BRANCHLIFETIMEANALYSISRESULTS = _descriptor.Descriptor(
+  name='BranchLifetimeAnalysisResults',
+  full_name='BranchLifetimeAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='merges', full_name='BranchLifetimeAnalysisResults.merges', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='BranchLifetimeAnalysisResults.sampling', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9811,
+  serialized_end=9890,
+)
+
+
+_RELEASE = _descriptor.Descriptor(
+  name='Release',
+  full_name='Release',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='tag', full_name='Release.tag', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='hash', full_name='Release.hash', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='day', full_name='Release.day', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='days', full_name='Release.days', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='Release.commits', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='churn', full_name='Release.churn', index=5,
+      number=6, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9892,
+  serialized_end=9987,
+)
+
+
+_RELEASECADENCEANALYSISRESULTS = _descriptor.Descriptor(
+  name='ReleaseCadenceAnalysisResults',
+  full_name='ReleaseCadenceAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='releases', full_name='ReleaseCadenceAnalysisResults.releases', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='unreleased_commits', full_name='ReleaseCadenceAnalysisResults.unreleased_commits', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='unreleased_churn', full_name='ReleaseCadenceAnalysisResults.unreleased_churn', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='tag_pattern', full_name='ReleaseCadenceAnalysisResults.tag_pattern', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9990,
+  serialized_end=10124,
+)
+
+
+_GINITICK_COMMITSENTRY = _descriptor.Descriptor(
+  name='CommitsEntry',
+  full_name='GiniTick.CommitsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='GiniTick.CommitsEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='GiniTick.CommitsEntry.value', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10259,
+  serialized_end=10305,
+)
+
+_GINITICK_LINESENTRY = _descriptor.Descriptor(
+  name='LinesEntry',
+  full_name='GiniTick.LinesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='GiniTick.LinesEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='GiniTick.LinesEntry.value', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10307,
+  serialized_end=10351,
+)
+
+_GINITICK = _descriptor.Descriptor(
+  name='GiniTick',
+  full_name='GiniTick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='GiniTick.commits', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='GiniTick.lines', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='commits_gini', full_name='GiniTick.commits_gini', index=2,
+      number=3, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='lines_gini', full_name='GiniTick.lines_gini', index=3,
+      number=4, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_GINITICK_COMMITSENTRY, _GINITICK_LINESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10127,
+  serialized_end=10351,
+)
+
+
+_GINIANALYSISRESULTS = _descriptor.Descriptor(
+  name='GiniAnalysisResults',
+  full_name='GiniAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='GiniAnalysisResults.ticks', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='total', full_name='GiniAnalysisResults.total', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='GiniAnalysisResults.sampling', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='GiniAnalysisResults.dev_index', index=3,
+      number=4, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10353,
+  serialized_end=10463,
+)
+
+
+_ONBOARDINGDEVELOPER_DIRECTORIESENTRY = _descriptor.Descriptor(
+  name='DirectoriesEntry',
+  full_name='OnboardingDeveloper.DirectoriesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='OnboardingDeveloper.DirectoriesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='OnboardingDeveloper.DirectoriesEntry.value', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10570,
+  serialized_end=10620,
+)
+
+_ONBOARDINGDEVELOPER = _descriptor.Descriptor(
+  name='OnboardingDeveloper',
+  full_name='OnboardingDeveloper',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commit_days', full_name='OnboardingDeveloper.commit_days', index=0,
+      number=1, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='OnboardingDeveloper.directories', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_ONBOARDINGDEVELOPER_DIRECTORIESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10466,
+  serialized_end=10620,
+)
+
+
+_ONBOARDINGDIRECTORY = _descriptor.Descriptor(
+  name='OnboardingDirectory',
+  full_name='OnboardingDirectory',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='developers', full_name='OnboardingDirectory.developers', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='median_days', full_name='OnboardingDirectory.median_days', index=1,
+      number=2, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10622,
+  serialized_end=10684,
+)
+
+
+_ONBOARDINGCOHORT_DIRECTORIESENTRY = _descriptor.Descriptor(
+  name='DirectoriesEntry',
+  full_name='OnboardingCohort.DirectoriesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='OnboardingCohort.DirectoriesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='OnboardingCohort.DirectoriesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10822,
+  serialized_end=10894,
+)
+
+_ONBOARDINGCOHORT = _descriptor.Descriptor(
+  name='OnboardingCohort',
+  full_name='OnboardingCohort',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='developers', full_name='OnboardingCohort.developers', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='reached', full_name='OnboardingCohort.reached', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='median_days', full_name='OnboardingCohort.median_days', index=2,
+      number=3, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='OnboardingCohort.directories', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_ONBOARDINGCOHORT_DIRECTORIESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10687,
+  serialized_end=10894,
+)
+
+
+_ONBOARDINGANALYSISRESULTS = _descriptor.Descriptor(
+  name='OnboardingAnalysisResults',
+  full_name='OnboardingAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='OnboardingAnalysisResults.commits', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='cohort_days', full_name='OnboardingAnalysisResults.cohort_days', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='directory_depth', full_name='OnboardingAnalysisResults.directory_depth', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='cohorts', full_name='OnboardingAnalysisResults.cohorts', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people', full_name='OnboardingAnalysisResults.people', index=4,
+      number=5, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='OnboardingAnalysisResults.dev_index', index=5,
+      number=6, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10897,
+  serialized_end=11080,
+)
+
+
+_TENURETICK = _descriptor.Descriptor(
+  name='TenureTick',
+  full_name='TenureTick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='new', full_name='TenureTick.new', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='active', full_name='TenureTick.active', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='departed', full_name='TenureTick.departed', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=11082,
+  serialized_end=11141,
+)
+
+
+_TENURESPAN = _descriptor.Descriptor(
+  name='TenureSpan',
+  full_name='TenureSpan',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='begin', full_name='TenureSpan.begin', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='end', full_name='TenureSpan.end', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=11143,
+  serialized_end=11183,
+)
+
+
+_TENUREDEVELOPER = _descriptor.Descriptor(
+  name='TenureDeveloper',
+  full_name='TenureDeveloper',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='days', full_name='TenureDeveloper.days', index=0,
+      number=1, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='TenureDeveloper.commits', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='spans', full_name='TenureDeveloper.spans', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=11185,
+  serialized_end=11261,
+)
+
+
+_TENUREANALYSISRESULTS = _descriptor.Descriptor(
+  name='TenureAnalysisResults',
+  full_name='TenureAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='TenureAnalysisResults.ticks', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people', full_name='TenureAnalysisResults.people', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='TenureAnalysisResults.sampling', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='inactive_days', full_name='TenureAnalysisResults.inactive_days', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='last_day', full_name='TenureAnalysisResults.last_day', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='TenureAnalysisResults.dev_index', index=5,
+      number=6, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=11264,
+  serialized_end=11427,
+)
+
+
+_WORKPATTERN = _descriptor.Descriptor(
+  name='WorkPattern',
+  full_name='WorkPattern',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='hours', full_name='WorkPattern.hours', index=0,
+      number=1, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='weekdays', full_name='WorkPattern.weekdays', index=1,
+      number=2, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='off_hours', full_name='WorkPattern.off_hours', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='off_hours_days', full_name='WorkPattern.off_hours_days', index=3,
+      number=4, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=11429,
+  serialized_end=11518,
+)
+
+
+_WORKPATTERNSSTREAK = _descriptor.Descriptor(
+  name='WorkPatternsStreak',
+  full_name='WorkPatternsStreak',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='developer', full_name='WorkPatternsStreak.developer', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='begin_day', full_name='WorkPatternsStreak.begin_day', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='end_day', full_name='WorkPatternsStreak.end_day', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='weeks', full_name='WorkPatternsStreak.weeks', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=11520,
+  serialized_end=11610,
+)
+
+
+_WORKPATTERNSANALYSISRESULTS = _descriptor.Descriptor(
+  name='WorkPatternsAnalysisResults',
+  full_name='WorkPatternsAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='timezone', full_name='WorkPatternsAnalysisResults.timezone', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='work_day_start', full_name='WorkPatternsAnalysisResults.work_day_start', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='work_day_end', full_name='WorkPatternsAnalysisResults.work_day_end', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sustained_weeks', full_name='WorkPatternsAnalysisResults.sustained_weeks', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people', full_name='WorkPatternsAnalysisResults.people', index=4,
+      number=5, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='streaks', full_name='WorkPatternsAnalysisResults.streaks', index=5,
+      number=6, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='WorkPatternsAnalysisResults.dev_index', index=6,
+      number=7, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=11613,
+  serialized_end=11818,
+)
+
+
+_KNOWLEDGEMAPVECTOR = _descriptor.Descriptor(
+  name='KnowledgeMapVector',
+  full_name='KnowledgeMapVector',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='values', full_name='KnowledgeMapVector.values', index=0,
+      number=1, type=1, cpp_type=5, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=11820,
+  serialized_end=11856,
+)
+
+
+_KNOWLEDGEMAPANALYSISRESULTS = _descriptor.Descriptor(
+  name='KnowledgeMapAnalysisResults',
+  full_name='KnowledgeMapAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='half_life', full_name='KnowledgeMapAnalysisResults.half_life', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='directory_depth', full_name='KnowledgeMapAnalysisResults.directory_depth', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='KnowledgeMapAnalysisResults.directories', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people_lines', full_name='KnowledgeMapAnalysisResults.people_lines', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people_commits', full_name='KnowledgeMapAnalysisResults.people_commits', index=4,
+      number=5, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='KnowledgeMapAnalysisResults.dev_index', index=5,
+      number=6, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=11859,
+  serialized_end=12060,
+)
+
+
+_DEADCODETICK = _descriptor.Descriptor(
+  name='DeadCodeTick',
+  full_name='DeadCodeTick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='definitions', full_name='DeadCodeTick.definitions', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='unreferenced', full_name='DeadCodeTick.unreferenced', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='orphaned', full_name='DeadCodeTick.orphaned', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='resolved', full_name='DeadCodeTick.resolved', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12062,
+  serialized_end=12155,
+)
+
+
+_DEADCODEORPHAN = _descriptor.Descriptor(
+  name='DeadCodeOrphan',
+  full_name='DeadCodeOrphan',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='hash', full_name='DeadCodeOrphan.hash', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='day', full_name='DeadCodeOrphan.day', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='file', full_name='DeadCodeOrphan.file', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='symbol', full_name='DeadCodeOrphan.symbol', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12157,
+  serialized_end=12230,
+)
+
+
+_DEADCODEANALYSISRESULTS = _descriptor.Descriptor(
+  name='DeadCodeAnalysisResults',
+  full_name='DeadCodeAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='DeadCodeAnalysisResults.sampling', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='DeadCodeAnalysisResults.ticks', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='orphans', full_name='DeadCodeAnalysisResults.orphans', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12232,
+  serialized_end=12339,
+)
+
+
+_COMMITSENTIMENT = _descriptor.Descriptor(
+  name='CommitSentiment',
+  full_name='CommitSentiment',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='positive', full_name='CommitSentiment.positive', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='negative', full_name='CommitSentiment.negative', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='neutral', full_name='CommitSentiment.neutral', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sum', full_name='CommitSentiment.sum', index=3,
+      number=4, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12341,
+  serialized_end=12424,
+)
+
+
+_COMMITSENTIMENTANALYSISRESULTS = _descriptor.Descriptor(
+  name='CommitSentimentAnalysisResults',
+  full_name='CommitSentimentAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='CommitSentimentAnalysisResults.sampling', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='model', full_name='CommitSentimentAnalysisResults.model', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='CommitSentimentAnalysisResults.ticks', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people', full_name='CommitSentimentAnalysisResults.people', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='CommitSentimentAnalysisResults.dev_index', index=4,
+      number=5, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12427,
+  serialized_end=12578,
+)
+
+
+_HOTSPOT = _descriptor.Descriptor(
+  name='Hotspot',
+  full_name='Hotspot',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='file', full_name='Hotspot.file', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='Hotspot.commits', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='churn', full_name='Hotspot.churn', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='Hotspot.lines', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='complexity', full_name='Hotspot.complexity', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='score', full_name='Hotspot.score', index=5,
+      number=6, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12580,
+  serialized_end=12685,
+)
+
+
+_HOTSPOTSANALYSISRESULTS = _descriptor.Descriptor(
+  name='HotspotsAnalysisResults',
+  full_name='HotspotsAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='hotspots', full_name='HotspotsAnalysisResults.hotspots', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12687,
+  serialized_end=12740,
+)
+
+
+_TESTRATIOTICK = _descriptor.Descriptor(
+  name='TestRatioTick',
+  full_name='TestRatioTick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='test_lines', full_name='TestRatioTick.test_lines', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='production_lines', full_name='TestRatioTick.production_lines', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='test_churn', full_name='TestRatioTick.test_churn', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='production_churn', full_name='TestRatioTick.production_churn', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12742,
+  serialized_end=12849,
+)
+
+
+_TESTRATIOANALYSISRESULTS = _descriptor.Descriptor(
+  name='TestRatioAnalysisResults',
+  full_name='TestRatioAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='TestRatioAnalysisResults.sampling', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='TestRatioAnalysisResults.ticks', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12851,
+  serialized_end=12926,
+)
+
+
+_VOCABULARYTICK = _descriptor.Descriptor(
+  name='VocabularyTick',
+  full_name='VocabularyTick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='terms', full_name='VocabularyTick.terms', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='introduced', full_name='VocabularyTick.introduced', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='retired', full_name='VocabularyTick.retired', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12928,
+  serialized_end=12996,
+)
+
+
+_VOCABULARYTERMS_TERMSENTRY = _descriptor.Descriptor(
+  name='TermsEntry',
+  full_name='VocabularyTerms.TermsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='VocabularyTerms.TermsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='VocabularyTerms.TermsEntry.value', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13061,
+  serialized_end=13105,
+)
+
+_VOCABULARYTERMS = _descriptor.Descriptor(
+  name='VocabularyTerms',
+  full_name='VocabularyTerms',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='terms', full_name='VocabularyTerms.terms', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_VOCABULARYTERMS_TERMSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=12998,
+  serialized_end=13105,
+)
+
+
+_VOCABULARYANALYSISRESULTS_TERMSENTRY = _descriptor.Descriptor(
+  name='TermsEntry',
+  full_name='VocabularyAnalysisResults.TermsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='VocabularyAnalysisResults.TermsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='VocabularyAnalysisResults.TermsEntry.value', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13294,
+  serialized_end=13338,
+)
+
+_VOCABULARYANALYSISRESULTS = _descriptor.Descriptor(
+  name='VocabularyAnalysisResults',
+  full_name='VocabularyAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='VocabularyAnalysisResults.sampling', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='VocabularyAnalysisResults.ticks', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='terms', full_name='VocabularyAnalysisResults.terms', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people', full_name='VocabularyAnalysisResults.people', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='VocabularyAnalysisResults.dev_index', index=4,
+      number=5, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_VOCABULARYANALYSISRESULTS_TERMSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13108,
+  serialized_end=13338,
+)
+
+
+_IMPORTGRAPHTICK = _descriptor.Descriptor(
+  name='ImportGraphTick',
+  full_name='ImportGraphTick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='nodes', full_name='ImportGraphTick.nodes', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='edges', full_name='ImportGraphTick.edges', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='cycles', full_name='ImportGraphTick.cycles', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='cyclic_nodes', full_name='ImportGraphTick.cyclic_nodes', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13340,
+  serialized_end=13425,
+)
+
+
+_IMPORTGRAPHEDGE = _descriptor.Descriptor(
+  name='ImportGraphEdge',
+  full_name='ImportGraphEdge',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='from', full_name='ImportGraphEdge.from', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='to', full_name='ImportGraphEdge.to', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='imports', full_name='ImportGraphEdge.imports', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13427,
+  serialized_end=13487,
+)
+
+
+_IMPORTGRAPHANALYSISRESULTS = _descriptor.Descriptor(
+  name='ImportGraphAnalysisResults',
+  full_name='ImportGraphAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='ImportGraphAnalysisResults.sampling', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='ImportGraphAnalysisResults.ticks', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='edges', full_name='ImportGraphAnalysisResults.edges', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13489,
+  serialized_end=13601,
+)
+
+
+_APISURFACETICK = _descriptor.Descriptor(
+  name='APISurfaceTick',
+  full_name='APISurfaceTick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='symbols', full_name='APISurfaceTick.symbols', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='added', full_name='APISurfaceTick.added', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='removed', full_name='APISurfaceTick.removed', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='changed', full_name='APISurfaceTick.changed', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13603,
+  serialized_end=13685,
+)
+
+
+_APIBREAKINGCHANGE = _descriptor.Descriptor(
+  name='APIBreakingChange',
+  full_name='APIBreakingChange',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='hash', full_name='APIBreakingChange.hash', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='day', full_name='APIBreakingChange.day', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='file', full_name='APIBreakingChange.file', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='symbol', full_name='APIBreakingChange.symbol', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='removed', full_name='APIBreakingChange.removed', index=4,
+      number=5, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13687,
+  serialized_end=13780,
+)
+
+
+_APISURFACEANALYSISRESULTS = _descriptor.Descriptor(
+  name='APISurfaceAnalysisResults',
+  full_name='APISurfaceAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='APISurfaceAnalysisResults.sampling', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='APISurfaceAnalysisResults.ticks', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='breaking_changes', full_name='APISurfaceAnalysisResults.breaking_changes', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13782,
+  serialized_end=13905,
+)
+
+
+_CLONESTICK = _descriptor.Descriptor(
+  name='ClonesTick',
+  full_name='ClonesTick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='ClonesTick.lines', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='duplicated_lines', full_name='ClonesTick.duplicated_lines', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13907,
+  serialized_end=13960,
+)
+
+
+_CLONESCOMMIT = _descriptor.Descriptor(
+  name='ClonesCommit',
+  full_name='ClonesCommit',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='hash', full_name='ClonesCommit.hash', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='day', full_name='ClonesCommit.day', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='ClonesCommit.lines', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='files', full_name='ClonesCommit.files', index=3,
+      number=4, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=13962,
+  serialized_end=14033,
+)
+
+
+_CLONESANALYSISRESULTS = _descriptor.Descriptor(
+  name='ClonesAnalysisResults',
+  full_name='ClonesAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='ClonesAnalysisResults.sampling', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='ClonesAnalysisResults.ticks', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='ClonesAnalysisResults.commits', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14035,
+  serialized_end=14136,
+)
+
+
+_TECHDEBTTICK = _descriptor.Descriptor(
+  name='TechDebtTick',
+  full_name='TechDebtTick',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='open', full_name='TechDebtTick.open', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='added', full_name='TechDebtTick.added', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='resolved', full_name='TechDebtTick.resolved', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14138,
+  serialized_end=14199,
+)
+
+
+_TECHDEBTMARKER = _descriptor.Descriptor(
+  name='TechDebtMarker',
+  full_name='TechDebtMarker',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='kind', full_name='TechDebtMarker.kind', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='text', full_name='TechDebtMarker.text', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='file', full_name='TechDebtMarker.file', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='line', full_name='TechDebtMarker.line', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='author', full_name='TechDebtMarker.author', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='day', full_name='TechDebtMarker.day', index=5,
+      number=6, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14201,
+  serialized_end=14302,
+)
+
+
+_TECHDEBTANALYSISRESULTS_KINDSENTRY = _descriptor.Descriptor(
+  name='KindsEntry',
+  full_name='TechDebtAnalysisResults.KindsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='TechDebtAnalysisResults.KindsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='TechDebtAnalysisResults.KindsEntry.value', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14503,
+  serialized_end=14547,
+)
+
+_TECHDEBTANALYSISRESULTS = _descriptor.Descriptor(
+  name='TechDebtAnalysisResults',
+  full_name='TechDebtAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='TechDebtAnalysisResults.sampling', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='TechDebtAnalysisResults.ticks', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='kinds', full_name='TechDebtAnalysisResults.kinds', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='oldest', full_name='TechDebtAnalysisResults.oldest', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='lifetimes', full_name='TechDebtAnalysisResults.lifetimes', index=4,
+      number=5, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='TechDebtAnalysisResults.dev_index', index=5,
+      number=6, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_TECHDEBTANALYSISRESULTS_KINDSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14305,
+  serialized_end=14547,
+)
+
+
+_COMMENTDENSITY = _descriptor.Descriptor(
+  name='CommentDensity',
+  full_name='CommentDensity',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='lines', full_name='CommentDensity.lines', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='comment_lines', full_name='CommentDensity.comment_lines', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='comments', full_name='CommentDensity.comments', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14549,
+  serialized_end=14621,
+)
+
+
+_COMMENTDENSITYSERIES = _descriptor.Descriptor(
+  name='CommentDensitySeries',
+  full_name='CommentDensitySeries',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='CommentDensitySeries.ticks', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14623,
+  serialized_end=14677,
+)
+
+
+_COMMENTDENSITYANALYSISRESULTS_DIRECTORIESENTRY = _descriptor.Descriptor(
+  name='DirectoriesEntry',
+  full_name='CommentDensityAnalysisResults.DirectoriesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='CommentDensityAnalysisResults.DirectoriesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='CommentDensityAnalysisResults.DirectoriesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14835,
+  serialized_end=14908,
+)
+
+_COMMENTDENSITYANALYSISRESULTS = _descriptor.Descriptor(
+  name='CommentDensityAnalysisResults',
+  full_name='CommentDensityAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='CommentDensityAnalysisResults.sampling', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='project', full_name='CommentDensityAnalysisResults.project', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='CommentDensityAnalysisResults.directories', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_COMMENTDENSITYANALYSISRESULTS_DIRECTORIESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14680,
+  serialized_end=14908,
+)
+
+
+_COMPLEXITY = _descriptor.Descriptor(
+  name='Complexity',
+  full_name='Complexity',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='functions', full_name='Complexity.functions', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='cyclomatic', full_name='Complexity.cyclomatic', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='cognitive', full_name='Complexity.cognitive', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14910,
+  serialized_end=14980,
+)
+
+
+_COMPLEXITYSERIES_TICKSENTRY = _descriptor.Descriptor(
+  name='TicksEntry',
+  full_name='ComplexitySeries.TicksEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ComplexitySeries.TicksEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ComplexitySeries.TicksEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=15047,
+  serialized_end=15104,
+)
+
+_COMPLEXITYSERIES = _descriptor.Descriptor(
+  name='ComplexitySeries',
+  full_name='ComplexitySeries',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='ComplexitySeries.ticks', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_COMPLEXITYSERIES_TICKSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=14982,
+  serialized_end=15104,
+)
+
+
+_COMPLEXITYFUNCTION_TICKSENTRY = _descriptor.Descriptor(
+  name='TicksEntry',
+  full_name='ComplexityFunction.TicksEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ComplexityFunction.TicksEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ComplexityFunction.TicksEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=15204,
+  serialized_end=15261,
+)
+
+_COMPLEXITYFUNCTION = _descriptor.Descriptor(
+  name='ComplexityFunction',
+  full_name='ComplexityFunction',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='file', full_name='ComplexityFunction.file', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='name', full_name='ComplexityFunction.name', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='ComplexityFunction.ticks', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_COMPLEXITYFUNCTION_TICKSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=15107,
+  serialized_end=15261,
+)
+
+
+_COMPLEXITYCOMMIT = _descriptor.Descriptor(
+  name='ComplexityCommit',
+  full_name='ComplexityCommit',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='hash', full_name='ComplexityCommit.hash', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='day', full_name='ComplexityCommit.day', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='delta', full_name='ComplexityCommit.delta', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=15263,
+  serialized_end=15336,
+)
+
+
+_COMPLEXITYANALYSISRESULTS_FILESENTRY = _descriptor.Descriptor(
+  name='FilesEntry',
+  full_name='ComplexityAnalysisResults.FilesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ComplexityAnalysisResults.FilesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ComplexityAnalysisResults.FilesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=15546,
+  serialized_end=15609,
+)
+
+_COMPLEXITYANALYSISRESULTS = _descriptor.Descriptor(
+  name='ComplexityAnalysisResults',
+  full_name='ComplexityAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='ComplexityAnalysisResults.sampling', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='project', full_name='ComplexityAnalysisResults.project', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='files', full_name='ComplexityAnalysisResults.files', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='functions', full_name='ComplexityAnalysisResults.functions', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='ComplexityAnalysisResults.commits', index=4,
+      number=5, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_COMPLEXITYANALYSISRESULTS_FILESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=15339,
+  serialized_end=15609,
+)
+
+
+_FUNCTIONCHURNDAY = _descriptor.Descriptor(
+  name='FunctionChurnDay',
+  full_name='FunctionChurnDay',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='added', full_name='FunctionChurnDay.added', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='removed', full_name='FunctionChurnDay.removed', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=15611,
+  serialized_end=15661,
+)
+
+
+_FUNCTIONCHURN_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='FunctionChurn.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='FunctionChurn.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='FunctionChurn.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=15789,
+  serialized_end=15851,
+)
+
+_FUNCTIONCHURN = _descriptor.Descriptor(
+  name='FunctionChurn',
+  full_name='FunctionChurn',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='name', full_name='FunctionChurn.name', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='file', full_name='FunctionChurn.file', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='internal_role', full_name='FunctionChurn.internal_role', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='FunctionChurn.commits', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='days', full_name='FunctionChurn.days', index=4,
+      number=5, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_FUNCTIONCHURN_DAYSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=15664,
+  serialized_end=15851,
+)
+
+
+_FUNCTIONCHURNANALYSISRESULTS = _descriptor.Descriptor(
+  name='FunctionChurnAnalysisResults',
+  full_name='FunctionChurnAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='functions', full_name='FunctionChurnAnalysisResults.functions', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=15853,
+  serialized_end=15918,
+)
+
+
+_HISTORYREWRITE_AUTHORSENTRY = _descriptor.Descriptor(
+  name='AuthorsEntry',
+  full_name='HistoryRewrite.AuthorsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='HistoryRewrite.AuthorsEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='HistoryRewrite.AuthorsEntry.value', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=16136,
+  serialized_end=16182,
+)
+
+_HISTORYREWRITE = _descriptor.Descriptor(
+  name='HistoryRewrite',
+  full_name='HistoryRewrite',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ref', full_name='HistoryRewrite.ref', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='time', full_name='HistoryRewrite.time', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='actor', full_name='HistoryRewrite.actor', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='old', full_name='HistoryRewrite.old', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='new', full_name='HistoryRewrite.new', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='message', full_name='HistoryRewrite.message', index=5,
+      number=6, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='HistoryRewrite.commits', index=6,
+      number=7, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='added', full_name='HistoryRewrite.added', index=7,
+      number=8, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='removed', full_name='HistoryRewrite.removed', index=8,
+      number=9, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='authors', full_name='HistoryRewrite.authors', index=9,
+      number=10, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='pruned', full_name='HistoryRewrite.pruned', index=10,
+      number=11, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_HISTORYREWRITE_AUTHORSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=15921,
+  serialized_end=16182,
+)
+
+
+_HISTORYREWRITESANALYSISRESULTS = _descriptor.Descriptor(
+  name='HistoryRewritesAnalysisResults',
+  full_name='HistoryRewritesAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='rewrites', full_name='HistoryRewritesAnalysisResults.rewrites', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='HistoryRewritesAnalysisResults.dev_index', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=16184,
+  serialized_end=16270,
+)
+
+
+_CHANGEENTROPYDAY = _descriptor.Descriptor(
+  name='ChangeEntropyDay',
+  full_name='ChangeEntropyDay',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='changes', full_name='ChangeEntropyDay.changes', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='files', full_name='ChangeEntropyDay.files', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='ChangeEntropyDay.directories', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='file_entropy', full_name='ChangeEntropyDay.file_entropy', index=3,
+      number=4, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='directory_entropy', full_name='ChangeEntropyDay.directory_entropy', index=4,
+      number=5, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=16272,
+  serialized_end=16392,
+)
+
+
+_CHANGEENTROPYCOMMIT = _descriptor.Descriptor(
+  name='ChangeEntropyCommit',
+  full_name='ChangeEntropyCommit',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='hash', full_name='ChangeEntropyCommit.hash', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='day', full_name='ChangeEntropyCommit.day', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='files', full_name='ChangeEntropyCommit.files', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='ChangeEntropyCommit.directories', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='file_entropy', full_name='ChangeEntropyCommit.file_entropy', index=4,
+      number=5, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='directory_entropy', full_name='ChangeEntropyCommit.directory_entropy', index=5,
+      number=6, type=1, cpp_type=5, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=16395,
+  serialized_end=16528,
+)
+
+
+_CHANGEENTROPYANALYSISRESULTS_DAYSENTRY = _descriptor.Descriptor(
+  name='DaysEntry',
+  full_name='ChangeEntropyAnalysisResults.DaysEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='ChangeEntropyAnalysisResults.DaysEntry.key', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='ChangeEntropyAnalysisResults.DaysEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=16709,
+  serialized_end=16771,
+)
+
+_CHANGEENTROPYANALYSISRESULTS = _descriptor.Descriptor(
+  name='ChangeEntropyAnalysisResults',
+  full_name='ChangeEntropyAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='days', full_name='ChangeEntropyAnalysisResults.days', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='ticks', full_name='ChangeEntropyAnalysisResults.ticks', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='commits', full_name='ChangeEntropyAnalysisResults.commits', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sampling', full_name='ChangeEntropyAnalysisResults.sampling', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_CHANGEENTROPYANALYSISRESULTS_DAYSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=16531,
+  serialized_end=16771,
+)
+
+
+_EXPERTISEVECTOR = _descriptor.Descriptor(
+  name='ExpertiseVector',
+  full_name='ExpertiseVector',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='shares', full_name='ExpertiseVector.shares', index=0,
+      number=1, type=1, cpp_type=5, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=16773,
+  serialized_end=16806,
+)
+
+
+_EXPERTISEANALYSISRESULTS = _descriptor.Descriptor(
+  name='ExpertiseAnalysisResults',
+  full_name='ExpertiseAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='half_life', full_name='ExpertiseAnalysisResults.half_life', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='languages', full_name='ExpertiseAnalysisResults.languages', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='ExpertiseAnalysisResults.directories', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people_languages', full_name='ExpertiseAnalysisResults.people_languages', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people_directories', full_name='ExpertiseAnalysisResults.people_directories', index=4,
+      number=5, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='people_weights', full_name='ExpertiseAnalysisResults.people_weights', index=5,
+      number=6, type=1, cpp_type=5, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='ExpertiseAnalysisResults.dev_index', index=6,
+      number=7, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=16809,
+  serialized_end=17027,
+)
+
+
+_OWNERSHIPANALYSISRESULTS = _descriptor.Descriptor(
+  name='OwnershipAnalysisResults',
+  full_name='OwnershipAnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='directories', full_name='OwnershipAnalysisResults.directories', index=0,
+      number=1, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='directory_owners', full_name='OwnershipAnalysisResults.directory_owners', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='files', full_name='OwnershipAnalysisResults.files', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='file_owners', full_name='OwnershipAnalysisResults.file_owners', index=3,
+      number=4, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='dev_index', full_name='OwnershipAnalysisResults.dev_index', index=4,
+      number=5, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=17030,
+  serialized_end=17214,
+)
+
+
+_ANALYSISRESULTS_CONTENTSENTRY = _descriptor.Descriptor(
+  name='ContentsEntry',
+  full_name='AnalysisResults.ContentsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='AnalysisResults.ContentsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='AnalysisResults.ContentsEntry.value', index=1,
+      number=2, type=12, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b(""),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=17313,
+  serialized_end=17360,
+)
+
+_ANALYSISRESULTS = _descriptor.Descriptor(
+  name='AnalysisResults',
+  full_name='AnalysisResults',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='header', full_name='AnalysisResults.header', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='contents', full_name='AnalysisResults.contents', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_ANALYSISRESULTS_CONTENTSENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=17217,
+  serialized_end=17360,
+)
+
+_METADATA_RUNTIMEPERITEMENTRY.containing_type = _METADATA
+_METADATA_PROFILEPERITEMENTRY.fields_by_name['value'].message_type = _ITEMPROFILE
+_METADATA_PROFILEPERITEMENTRY.containing_type = _METADATA
+_METADATA_UNPARSEDFILESENTRY.fields_by_name['value'].message_type = _UNPARSEDFILE
+_METADATA_UNPARSEDFILESENTRY.containing_type = _METADATA
+_METADATA.fields_by_name['run_time_per_item'].message_type = _METADATA_RUNTIMEPERITEMENTRY
+_METADATA.fields_by_name['profile_per_item'].message_type = _METADATA_PROFILEPERITEMENTRY
+_METADATA.fields_by_name['annotations'].message_type = _ANNOTATION
+_METADATA.fields_by_name['unparsed_files'].message_type = _METADATA_UNPARSEDFILESENTRY
+_BURNDOWNSPARSEMATRIX.fields_by_name['rows'].message_type = _BURNDOWNSPARSEMATRIXROW
+_BURNDOWNSURVIVAL.fields_by_name['cohorts'].message_type = _BURNDOWNCOHORT
+_BURNDOWNANALYSISRESULTS.fields_by_name['project'].message_type = _BURNDOWNSPARSEMATRIX
+_BURNDOWNANALYSISRESULTS.fields_by_name['files'].message_type = _BURNDOWNSPARSEMATRIX
+_BURNDOWNANALYSISRESULTS.fields_by_name['people'].message_type = _BURNDOWNSPARSEMATRIX
+_BURNDOWNANALYSISRESULTS.fields_by_name['people_interaction'].message_type = _COMPRESSEDSPARSEROWMATRIX
+_BURNDOWNANALYSISRESULTS.fields_by_name['snapshots'].message_type = _FILESNAPSHOT
+_BURNDOWNANALYSISRESULTS.fields_by_name['directories'].message_type = _BURNDOWNSPARSEMATRIX
+_BURNDOWNANALYSISRESULTS.fields_by_name['languages'].message_type = _BURNDOWNSPARSEMATRIX
+_BURNDOWNANALYSISRESULTS.fields_by_name['survival'].message_type = _BURNDOWNSURVIVAL
+_FILESNAPSHOT_AGESENTRY.containing_type = _FILESNAPSHOT
+_FILESNAPSHOT_OWNERSENTRY.containing_type = _FILESNAPSHOT
+_FILESNAPSHOT.fields_by_name['ages'].message_type = _FILESNAPSHOT_AGESENTRY
+_FILESNAPSHOT.fields_by_name['owners'].message_type = _FILESNAPSHOT_OWNERSENTRY
+_COUPLES.fields_by_name['matrix'].message_type = _COMPRESSEDSPARSEROWMATRIX
+_COUPLESANALYSISRESULTS.fields_by_name['file_couples'].message_type = _COUPLES
+_COUPLESANALYSISRESULTS.fields_by_name['people_couples'].message_type = _COUPLES
+_COUPLESANALYSISRESULTS.fields_by_name['people_files'].message_type = _TOUCHEDFILES
+_COUPLESANALYSISRESULTS.fields_by_name['file_significance'].message_type = _COUPLESSIGNIFICANCE
+_COUPLESANALYSISRESULTS.fields_by_name['file_decay'].message_type = _COUPLESDECAY
+_COUPLESANALYSISRESULTS.fields_by_name['directory_couples'].message_type = _COUPLES
+_UASTCHANGESSAVERRESULTS.fields_by_name['changes'].message_type = _UASTCHANGE
+_SHOTNESSRECORD_COUNTERSENTRY.containing_type = _SHOTNESSRECORD
+_SHOTNESSRECORD.fields_by_name['counters'].message_type = _SHOTNESSRECORD_COUNTERSENTRY
+_SHOTNESSANALYSISRESULTS.fields_by_name['records'].message_type = _SHOTNESSRECORD
+_FILEHISTORYRESULTMESSAGE_FILESENTRY.fields_by_name['value'].message_type = _FILEHISTORY
+_FILEHISTORYRESULTMESSAGE_FILESENTRY.containing_type = _FILEHISTORYRESULTMESSAGE
+_FILEHISTORYRESULTMESSAGE.fields_by_name['files'].message_type = _FILEHISTORYRESULTMESSAGE_FILESENTRY
+_COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY.fields_by_name['value'].message_type = _SENTIMENT
+_COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY.containing_type = _COMMENTSENTIMENTRESULTS
+_COMMENTSENTIMENTRESULTS.fields_by_name['sentiment_by_day'].message_type = _COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY
+_RECORDEDSTREAM.fields_by_name['columns'].message_type = _RECORDEDCOLUMN
+_RECORDERRESULTS.fields_by_name['streams'].message_type = _RECORDEDSTREAM
+_ACTIVITYDAY_COMMITSENTRY.containing_type = _ACTIVITYDAY
+_ACTIVITYDAY.fields_by_name['commits'].message_type = _ACTIVITYDAY_COMMITSENTRY
+_ACTIVITYANALYSISRESULTS_DAYSENTRY.fields_by_name['value'].message_type = _ACTIVITYDAY
+_ACTIVITYANALYSISRESULTS_DAYSENTRY.containing_type = _ACTIVITYANALYSISRESULTS
+_ACTIVITYANALYSISRESULTS_ACTIVEENTRY.fields_by_name['value'].message_type = _ACTIVEDEVELOPERS
+_ACTIVITYANALYSISRESULTS_ACTIVEENTRY.containing_type = _ACTIVITYANALYSISRESULTS
+_ACTIVITYANALYSISRESULTS.fields_by_name['days'].message_type = _ACTIVITYANALYSISRESULTS_DAYSENTRY
+_ACTIVITYANALYSISRESULTS.fields_by_name['active'].message_type = _ACTIVITYANALYSISRESULTS_ACTIVEENTRY
+_LANGUAGECOUNTS_LANGUAGESENTRY.containing_type = _LANGUAGECOUNTS
+_LANGUAGECOUNTS.fields_by_name['languages'].message_type = _LANGUAGECOUNTS_LANGUAGESENTRY
+_COMMITLANGUAGESANALYSISRESULTS_DAYSENTRY.fields_by_name['value'].message_type = _LANGUAGECOUNTS
+_COMMITLANGUAGESANALYSISRESULTS_DAYSENTRY.containing_type = _COMMITLANGUAGESANALYSISRESULTS
+_COMMITLANGUAGESANALYSISRESULTS.fields_by_name['days'].message_type = _COMMITLANGUAGESANALYSISRESULTS_DAYSENTRY
+_COMMITLANGUAGESANALYSISRESULTS.fields_by_name['people'].message_type = _LANGUAGECOUNTS
+_IMPACTCHURNANALYSISRESULTS_DAYSENTRY.fields_by_name['value'].message_type = _IMPACTCHURNDAY
+_IMPACTCHURNANALYSISRESULTS_DAYSENTRY.containing_type = _IMPACTCHURNANALYSISRESULTS
+_IMPACTCHURNANALYSISRESULTS_FILESENTRY.fields_by_name['value'].message_type = _IMPACTCHURNFILE
+_IMPACTCHURNANALYSISRESULTS_FILESENTRY.containing_type = _IMPACTCHURNANALYSISRESULTS
+_IMPACTCHURNANALYSISRESULTS.fields_by_name['days'].message_type = _IMPACTCHURNANALYSISRESULTS_DAYSENTRY
+_IMPACTCHURNANALYSISRESULTS.fields_by_name['files'].message_type = _IMPACTCHURNANALYSISRESULTS_FILESENTRY
+_DAGSHAPEANALYSISRESULTS.fields_by_name['ticks'].message_type = _DAGSHAPETICK
+_RENAMEFREQUENCYANALYSISRESULTS_DIRECTORIESENTRY.containing_type = _RENAMEFREQUENCYANALYSISRESULTS
+_RENAMEFREQUENCYANALYSISRESULTS.fields_by_name['chains'].message_type = _RENAMECHAIN
+_RENAMEFREQUENCYANALYSISRESULTS.fields_by_name['directories'].message_type = _RENAMEFREQUENCYANALYSISRESULTS_DIRECTORIESENTRY
+_REWRITEDEPTHANALYSISRESULTS_FILESENTRY.fields_by_name['value'].message_type = _REWRITEDEPTHFILE
+_REWRITEDEPTHANALYSISRESULTS_FILESENTRY.containing_type = _REWRITEDEPTHANALYSISRESULTS
+_REWRITEDEPTHANALYSISRESULTS.fields_by_name['files'].message_type = _REWRITEDEPTHANALYSISRESULTS_FILESENTRY
+_REWRITEDEPTHANALYSISRESULTS.fields_by_name['hotspots'].message_type = _REWRITEHOTSPOT
+_SENSITIVEPATHSANALYSISRESULTS.fields_by_name['changes'].message_type = _SENSITIVEPATHCHANGE
+_CONTRIBUTORSANALYSISRESULTS.fields_by_name['ticks'].message_type = _CONTRIBUTORSTICK
+_STEWARDSHIPTICK_PEOPLEENTRY.fields_by_name['value'].message_type = _STEWARDSHIPCOUNTS
+_STEWARDSHIPTICK_PEOPLEENTRY.containing_type = _STEWARDSHIPTICK
+_STEWARDSHIPTICK.fields_by_name['people'].message_type = _STEWARDSHIPTICK_PEOPLEENTRY
+_STEWARDSHIPANALYSISRESULTS.fields_by_name['ticks'].message_type = _STEWARDSHIPTICK
+_TEAMALIGNMENTDIRECTORY_EDITSENTRY.containing_type = _TEAMALIGNMENTDIRECTORY
+_TEAMALIGNMENTDIRECTORY.fields_by_name['edits'].message_type = _TEAMALIGNMENTDIRECTORY_EDITSENTRY
+_TEAMALIGNMENTTICK.fields_by_name['directories'].message_type = _TEAMALIGNMENTDIRECTORY
+_TEAMALIGNMENTANALYSISRESULTS.fields_by_name['ticks'].message_type = _TEAMALIGNMENTTICK
+_COCOMOANALYSISRESULTS.fields_by_name['ticks'].message_type = _COCOMOTICK
+_REVIEWLATENCYANALYSISRESULTS_PEOPLEENTRY.fields_by_name['value'].message_type = _REVIEWLATENCYSTATS
+_REVIEWLATENCYANALYSISRESULTS_PEOPLEENTRY.containing_type = _REVIEWLATENCYANALYSISRESULTS
+_REVIEWLATENCYANALYSISRESULTS.fields_by_name['ticks'].message_type = _REVIEWLATENCYSTATS
+_REVIEWLATENCYANALYSISRESULTS.fields_by_name['people'].message_type = _REVIEWLATENCYANALYSISRESULTS_PEOPLEENTRY
+_ISSUEREFERENCESANALYSISRESULTS_TICKETSENTRY.fields_by_name['value'].message_type = _ISSUETICKET
+_ISSUEREFERENCESANALYSISRESULTS_TICKETSENTRY.containing_type = _ISSUEREFERENCESANALYSISRESULTS
+_ISSUEREFERENCESANALYSISRESULTS.fields_by_name['tickets'].message_type = _ISSUEREFERENCESANALYSISRESULTS_TICKETSENTRY
+_CONVENTIONALCOMMITSSTATS_TYPESENTRY.containing_type = _CONVENTIONALCOMMITSSTATS
+_CONVENTIONALCOMMITSSTATS.fields_by_name['types'].message_type = _CONVENTIONALCOMMITSSTATS_TYPESENTRY
+_CONVENTIONALCOMMITSANALYSISRESULTS_PEOPLEENTRY.fields_by_name['value'].message_type = _CONVENTIONALCOMMITSSTATS
+_CONVENTIONALCOMMITSANALYSISRESULTS_PEOPLEENTRY.containing_type = _CONVENTIONALCOMMITSANALYSISRESULTS
+_CONVENTIONALCOMMITSANALYSISRESULTS.fields_by_name['ticks'].message_type = _CONVENTIONALCOMMITSSTATS
+_CONVENTIONALCOMMITSANALYSISRESULTS.fields_by_name['people'].message_type = _CONVENTIONALCOMMITSANALYSISRESULTS_PEOPLEENTRY
+_LANGUAGELINESANALYSISRESULTS_LANGUAGESENTRY.fields_by_name['value'].message_type = _LANGUAGELINES
+_LANGUAGELINESANALYSISRESULTS_LANGUAGESENTRY.containing_type = _LANGUAGELINESANALYSISRESULTS
+_LANGUAGELINESANALYSISRESULTS.fields_by_name['languages'].message_type = _LANGUAGELINESANALYSISRESULTS_LANGUAGESENTRY
+_REPOSITORYSIZEANALYSISRESULTS.fields_by_name['ticks'].message_type = _REPOSITORYSIZETICK
+_REPOSITORYSIZEANALYSISRESULTS.fields_by_name['largest'].message_type = _REPOSITORYBLOB
+_FILEAGEANALYSISRESULTS_FILESENTRY.fields_by_name['value'].message_type = _FILEAGE
+_FILEAGEANALYSISRESULTS_FILESENTRY.containing_type = _FILEAGEANALYSISRESULTS
+_FILEAGEANALYSISRESULTS.fields_by_name['files'].message_type = _FILEAGEANALYSISRESULTS_FILESENTRY
+_REFACTORINGANALYSISRESULTS_PEOPLEENTRY.fields_by_name['value'].message_type = _REFACTORINGSTATS
+_REFACTORINGANALYSISRESULTS_PEOPLEENTRY.containing_type = _REFACTORINGANALYSISRESULTS
+_REFACTORINGANALYSISRESULTS.fields_by_name['ticks'].message_type = _REFACTORINGSTATS
+_REFACTORINGANALYSISRESULTS.fields_by_name['people'].message_type = _REFACTORINGANALYSISRESULTS_PEOPLEENTRY
+_REVERTSANALYSISRESULTS_FILESENTRY.containing_type = _REVERTSANALYSISRESULTS
+_REVERTSANALYSISRESULTS.fields_by_name['ticks'].message_type = _REVERTSTICK
+_REVERTSANALYSISRESULTS.fields_by_name['reverted'].message_type = _REVERTEDCOMMIT
+_REVERTSANALYSISRESULTS.fields_by_name['files'].message_type = _REVERTSANALYSISRESULTS_FILESENTRY
+_BRANCHLIFETIMEANALYSISRESULTS.fields_by_name['merges'].message_type = _BRANCHMERGE
+_RELEASECADENCEANALYSISRESULTS.fields_by_name['releases'].message_type = _RELEASE
+_GINITICK_COMMITSENTRY.containing_type = _GINITICK
+_GINITICK_LINESENTRY.containing_type = _GINITICK
+_GINITICK.fields_by_name['commits'].message_type = _GINITICK_COMMITSENTRY
+_GINITICK.fields_by_name['lines'].message_type = _GINITICK_LINESENTRY
+_GINIANALYSISRESULTS.fields_by_name['ticks'].message_type = _GINITICK
+_GINIANALYSISRESULTS.fields_by_name['total'].message_type = _GINITICK
+_ONBOARDINGDEVELOPER_DIRECTORIESENTRY.containing_type = _ONBOARDINGDEVELOPER
+_ONBOARDINGDEVELOPER.fields_by_name['directories'].message_type = _ONBOARDINGDEVELOPER_DIRECTORIESENTRY
+_ONBOARDINGCOHORT_DIRECTORIESENTRY.fields_by_name['value'].message_type = _ONBOARDINGDIRECTORY
+_ONBOARDINGCOHORT_DIRECTORIESENTRY.containing_type = _ONBOARDINGCOHORT
+_ONBOARDINGCOHORT.fields_by_name['directories'].message_type = _ONBOARDINGCOHORT_DIRECTORIESENTRY
+_ONBOARDINGANALYSISRESULTS.fields_by_name['cohorts'].message_type = _ONBOARDINGCOHORT
+_ONBOARDINGANALYSISRESULTS.fields_by_name['people'].message_type = _ONBOARDINGDEVELOPER
+_TENUREDEVELOPER.fields_by_name['spans'].message_type = _TENURESPAN
+_TENUREANALYSISRESULTS.fields_by_name['ticks'].message_type = _TENURETICK
+_TENUREANALYSISRESULTS.fields_by_name['people'].message_type = _TENUREDEVELOPER
+_WORKPATTERNSANALYSISRESULTS.fields_by_name['people'].message_type = _WORKPATTERN
+_WORKPATTERNSANALYSISRESULTS.fields_by_name['streaks'].message_type = _WORKPATTERNSSTREAK
+_KNOWLEDGEMAPANALYSISRESULTS.fields_by_name['people_lines'].message_type = _KNOWLEDGEMAPVECTOR
+_KNOWLEDGEMAPANALYSISRESULTS.fields_by_name['people_commits'].message_type = _KNOWLEDGEMAPVECTOR
+_DEADCODEANALYSISRESULTS.fields_by_name['ticks'].message_type = _DEADCODETICK
+_DEADCODEANALYSISRESULTS.fields_by_name['orphans'].message_type = _DEADCODEORPHAN
+_COMMITSENTIMENTANALYSISRESULTS.fields_by_name['ticks'].message_type = _COMMITSENTIMENT
+_COMMITSENTIMENTANALYSISRESULTS.fields_by_name['people'].message_type = _COMMITSENTIMENT
+_HOTSPOTSANALYSISRESULTS.fields_by_name['hotspots'].message_type = _HOTSPOT
+_TESTRATIOANALYSISRESULTS.fields_by_name['ticks'].message_type = _TESTRATIOTICK
+_VOCABULARYTERMS_TERMSENTRY.containing_type = _VOCABULARYTERMS
+_VOCABULARYTERMS.fields_by_name['terms'].message_type = _VOCABULARYTERMS_TERMSENTRY
+_VOCABULARYANALYSISRESULTS_TERMSENTRY.containing_type = _VOCABULARYANALYSISRESULTS
+_VOCABULARYANALYSISRESULTS.fields_by_name['ticks'].message_type = _VOCABULARYTICK
+_VOCABULARYANALYSISRESULTS.fields_by_name['terms'].message_type = _VOCABULARYANALYSISRESULTS_TERMSENTRY
+_VOCABULARYANALYSISRESULTS.fields_by_name['people'].message_type = _VOCABULARYTERMS
+_IMPORTGRAPHANALYSISRESULTS.fields_by_name['ticks'].message_type = _IMPORTGRAPHTICK
+_IMPORTGRAPHANALYSISRESULTS.fields_by_name['edges'].message_type = _IMPORTGRAPHEDGE
+_APISURFACEANALYSISRESULTS.fields_by_name['ticks'].message_type = _APISURFACETICK
+_APISURFACEANALYSISRESULTS.fields_by_name['breaking_changes'].message_type = _APIBREAKINGCHANGE
+_CLONESANALYSISRESULTS.fields_by_name['ticks'].message_type = _CLONESTICK
+_CLONESANALYSISRESULTS.fields_by_name['commits'].message_type = _CLONESCOMMIT
+_TECHDEBTANALYSISRESULTS_KINDSENTRY.containing_type = _TECHDEBTANALYSISRESULTS
+_TECHDEBTANALYSISRESULTS.fields_by_name['ticks'].message_type = _TECHDEBTTICK
+_TECHDEBTANALYSISRESULTS.fields_by_name['kinds'].message_type = _TECHDEBTANALYSISRESULTS_KINDSENTRY
+_TECHDEBTANALYSISRESULTS.fields_by_name['oldest'].message_type = _TECHDEBTMARKER
+_COMMENTDENSITYSERIES.fields_by_name['ticks'].message_type = _COMMENTDENSITY
+_COMMENTDENSITYANALYSISRESULTS_DIRECTORIESENTRY.fields_by_name['value'].message_type = _COMMENTDENSITYSERIES
+_COMMENTDENSITYANALYSISRESULTS_DIRECTORIESENTRY.containing_type = _COMMENTDENSITYANALYSISRESULTS
+_COMMENTDENSITYANALYSISRESULTS.fields_by_name['project'].message_type = _COMMENTDENSITY
+_COMMENTDENSITYANALYSISRESULTS.fields_by_name['directories'].message_type = _COMMENTDENSITYANALYSISRESULTS_DIRECTORIESENTRY
+_COMPLEXITYSERIES_TICKSENTRY.fields_by_name['value'].message_type = _COMPLEXITY
+_COMPLEXITYSERIES_TICKSENTRY.containing_type = _COMPLEXITYSERIES
+_COMPLEXITYSERIES.fields_by_name['ticks'].message_type = _COMPLEXITYSERIES_TICKSENTRY
+_COMPLEXITYFUNCTION_TICKSENTRY.fields_by_name['value'].message_type = _COMPLEXITY
+_COMPLEXITYFUNCTION_TICKSENTRY.containing_type = _COMPLEXITYFUNCTION
+_COMPLEXITYFUNCTION.fields_by_name['ticks'].message_type = _COMPLEXITYFUNCTION_TICKSENTRY
+_COMPLEXITYCOMMIT.fields_by_name['delta'].message_type = _COMPLEXITY
+_COMPLEXITYANALYSISRESULTS_FILESENTRY.fields_by_name['value'].message_type = _COMPLEXITYSERIES
+_COMPLEXITYANALYSISRESULTS_FILESENTRY.containing_type = _COMPLEXITYANALYSISRESULTS
+_COMPLEXITYANALYSISRESULTS.fields_by_name['project'].message_type = _COMPLEXITY
+_COMPLEXITYANALYSISRESULTS.fields_by_name['files'].message_type = _COMPLEXITYANALYSISRESULTS_FILESENTRY
+_COMPLEXITYANALYSISRESULTS.fields_by_name['functions'].message_type = _COMPLEXITYFUNCTION
+_COMPLEXITYANALYSISRESULTS.fields_by_name['commits'].message_type = _COMPLEXITYCOMMIT
+_FUNCTIONCHURN_DAYSENTRY.fields_by_name['value'].message_type = _FUNCTIONCHURNDAY
+_FUNCTIONCHURN_DAYSENTRY.containing_type = _FUNCTIONCHURN
+_FUNCTIONCHURN.fields_by_name['days'].message_type = _FUNCTIONCHURN_DAYSENTRY
+_FUNCTIONCHURNANALYSISRESULTS.fields_by_name['functions'].message_type = _FUNCTIONCHURN
+_HISTORYREWRITE_AUTHORSENTRY.containing_type = _HISTORYREWRITE
+_HISTORYREWRITE.fields_by_name['authors'].message_type = _HISTORYREWRITE_AUTHORSENTRY
+_HISTORYREWRITESANALYSISRESULTS.fields_by_name['rewrites'].message_type = _HISTORYREWRITE
+_CHANGEENTROPYANALYSISRESULTS_DAYSENTRY.fields_by_name['value'].message_type = _CHANGEENTROPYDAY
+_CHANGEENTROPYANALYSISRESULTS_DAYSENTRY.containing_type = _CHANGEENTROPYANALYSISRESULTS
+_CHANGEENTROPYANALYSISRESULTS.fields_by_name['days'].message_type = _CHANGEENTROPYANALYSISRESULTS_DAYSENTRY
+_CHANGEENTROPYANALYSISRESULTS.fields_by_name['ticks'].message_type = _CHANGEENTROPYDAY
+_CHANGEENTROPYANALYSISRESULTS.fields_by_name['commits'].message_type = _CHANGEENTROPYCOMMIT
+_EXPERTISEANALYSISRESULTS.fields_by_name['people_languages'].message_type = _EXPERTISEVECTOR
+_EXPERTISEANALYSISRESULTS.fields_by_name['people_directories'].message_type = _EXPERTISEVECTOR
+_OWNERSHIPANALYSISRESULTS.fields_by_name['directory_owners'].message_type = _COMPRESSEDSPARSEROWMATRIX
+_OWNERSHIPANALYSISRESULTS.fields_by_name['file_owners'].message_type = _COMPRESSEDSPARSEROWMATRIX
+_ANALYSISRESULTS_CONTENTSENTRY.containing_type = _ANALYSISRESULTS
+_ANALYSISRESULTS.fields_by_name['header'].message_type = _METADATA
+_ANALYSISRESULTS.fields_by_name['contents'].message_type = _ANALYSISRESULTS_CONTENTSENTRY
+DESCRIPTOR.message_types_by_name['Metadata'] = _METADATA
+DESCRIPTOR.message_types_by_name['UnparsedFile'] = _UNPARSEDFILE
+DESCRIPTOR.message_types_by_name['Annotation'] = _ANNOTATION
+DESCRIPTOR.message_types_by_name['ItemProfile'] = _ITEMPROFILE
+DESCRIPTOR.message_types_by_name['BurndownSparseMatrixRow'] = _BURNDOWNSPARSEMATRIXROW
+DESCRIPTOR.message_types_by_name['BurndownSparseMatrix'] = _BURNDOWNSPARSEMATRIX
+DESCRIPTOR.message_types_by_name['BurndownCohort'] = _BURNDOWNCOHORT
+DESCRIPTOR.message_types_by_name['BurndownSurvival'] = _BURNDOWNSURVIVAL
+DESCRIPTOR.message_types_by_name['BurndownAnalysisResults'] = _BURNDOWNANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['FileSnapshot'] = _FILESNAPSHOT
+DESCRIPTOR.message_types_by_name['CompressedSparseRowMatrix'] = _COMPRESSEDSPARSEROWMATRIX
+DESCRIPTOR.message_types_by_name['Couples'] = _COUPLES
+DESCRIPTOR.message_types_by_name['TouchedFiles'] = _TOUCHEDFILES
+DESCRIPTOR.message_types_by_name['CouplesSignificance'] = _COUPLESSIGNIFICANCE
+DESCRIPTOR.message_types_by_name['CouplesDecay'] = _COUPLESDECAY
+DESCRIPTOR.message_types_by_name['CouplesAnalysisResults'] = _COUPLESANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['UASTChange'] = _UASTCHANGE
+DESCRIPTOR.message_types_by_name['UASTChangesSaverResults'] = _UASTCHANGESSAVERRESULTS
+DESCRIPTOR.message_types_by_name['ShotnessRecord'] = _SHOTNESSRECORD
+DESCRIPTOR.message_types_by_name['ShotnessAnalysisResults'] = _SHOTNESSANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['FileHistory'] = _FILEHISTORY
+DESCRIPTOR.message_types_by_name['FileHistoryResultMessage'] = _FILEHISTORYRESULTMESSAGE
+DESCRIPTOR.message_types_by_name['Sentiment'] = _SENTIMENT
+DESCRIPTOR.message_types_by_name['CommentSentimentResults'] = _COMMENTSENTIMENTRESULTS
+DESCRIPTOR.message_types_by_name['RecordedColumn'] = _RECORDEDCOLUMN
+DESCRIPTOR.message_types_by_name['RecordedStream'] = _RECORDEDSTREAM
+DESCRIPTOR.message_types_by_name['RecorderResults'] = _RECORDERRESULTS
+DESCRIPTOR.message_types_by_name['ActivityDay'] = _ACTIVITYDAY
+DESCRIPTOR.message_types_by_name['ActiveDevelopers'] = _ACTIVEDEVELOPERS
+DESCRIPTOR.message_types_by_name['ActivityAnalysisResults'] = _ACTIVITYANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['LanguageCounts'] = _LANGUAGECOUNTS
+DESCRIPTOR.message_types_by_name['CommitLanguagesAnalysisResults'] = _COMMITLANGUAGESANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['ImpactChurnDay'] = _IMPACTCHURNDAY
+DESCRIPTOR.message_types_by_name['ImpactChurnFile'] = _IMPACTCHURNFILE
+DESCRIPTOR.message_types_by_name['ImpactChurnAnalysisResults'] = _IMPACTCHURNANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['DAGShapeTick'] = _DAGSHAPETICK
+DESCRIPTOR.message_types_by_name['DAGShapeAnalysisResults'] = _DAGSHAPEANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['RenameChain'] = _RENAMECHAIN
+DESCRIPTOR.message_types_by_name['RenameFrequencyAnalysisResults'] = _RENAMEFREQUENCYANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['RewriteDepthFile'] = _REWRITEDEPTHFILE
+DESCRIPTOR.message_types_by_name['RewriteHotspot'] = _REWRITEHOTSPOT
+DESCRIPTOR.message_types_by_name['RewriteDepthAnalysisResults'] = _REWRITEDEPTHANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['SensitivePathChange'] = _SENSITIVEPATHCHANGE
+DESCRIPTOR.message_types_by_name['SensitivePathsAnalysisResults'] = _SENSITIVEPATHSANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['ContributorsTick'] = _CONTRIBUTORSTICK
+DESCRIPTOR.message_types_by_name['ContributorsAnalysisResults'] = _CONTRIBUTORSANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['StewardshipCounts'] = _STEWARDSHIPCOUNTS
+DESCRIPTOR.message_types_by_name['StewardshipTick'] = _STEWARDSHIPTICK
+DESCRIPTOR.message_types_by_name['StewardshipAnalysisResults'] = _STEWARDSHIPANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['TeamAlignmentDirectory'] = _TEAMALIGNMENTDIRECTORY
+DESCRIPTOR.message_types_by_name['TeamAlignmentTick'] = _TEAMALIGNMENTTICK
+DESCRIPTOR.message_types_by_name['TeamAlignmentAnalysisResults'] = _TEAMALIGNMENTANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['DefectFeaturesAnalysisResults'] = _DEFECTFEATURESANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['CocomoTick'] = _COCOMOTICK
+DESCRIPTOR.message_types_by_name['CocomoAnalysisResults'] = _COCOMOANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['ReviewLatencyStats'] = _REVIEWLATENCYSTATS
+DESCRIPTOR.message_types_by_name['ReviewLatencyAnalysisResults'] = _REVIEWLATENCYANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['IssueTicket'] = _ISSUETICKET
+DESCRIPTOR.message_types_by_name['IssueReferencesAnalysisResults'] = _ISSUEREFERENCESANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['ConventionalCommitsStats'] = _CONVENTIONALCOMMITSSTATS
+DESCRIPTOR.message_types_by_name['ConventionalCommitsAnalysisResults'] = _CONVENTIONALCOMMITSANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['LanguageLines'] = _LANGUAGELINES
+DESCRIPTOR.message_types_by_name['LanguageLinesAnalysisResults'] = _LANGUAGELINESANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['RepositorySizeTick'] = _REPOSITORYSIZETICK
+DESCRIPTOR.message_types_by_name['RepositoryBlob'] = _REPOSITORYBLOB
+DESCRIPTOR.message_types_by_name['RepositorySizeAnalysisResults'] = _REPOSITORYSIZEANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['FileAge'] = _FILEAGE
+DESCRIPTOR.message_types_by_name['FileAgeAnalysisResults'] = _FILEAGEANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['RefactoringStats'] = _REFACTORINGSTATS
+DESCRIPTOR.message_types_by_name['RefactoringAnalysisResults'] = _REFACTORINGANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['RevertsTick'] = _REVERTSTICK
+DESCRIPTOR.message_types_by_name['RevertedCommit'] = _REVERTEDCOMMIT
+DESCRIPTOR.message_types_by_name['RevertsAnalysisResults'] = _REVERTSANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['BranchMerge'] = _BRANCHMERGE
+DESCRIPTOR.message_types_by_name['BranchLifetimeAnalysisResults'] = _BRANCHLIFETIMEANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['Release'] = _RELEASE
+DESCRIPTOR.message_types_by_name['ReleaseCadenceAnalysisResults'] = _RELEASECADENCEANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['GiniTick'] = _GINITICK
+DESCRIPTOR.message_types_by_name['GiniAnalysisResults'] = _GINIANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['OnboardingDeveloper'] = _ONBOARDINGDEVELOPER
+DESCRIPTOR.message_types_by_name['OnboardingDirectory'] = _ONBOARDINGDIRECTORY
+DESCRIPTOR.message_types_by_name['OnboardingCohort'] = _ONBOARDINGCOHORT
+DESCRIPTOR.message_types_by_name['OnboardingAnalysisResults'] = _ONBOARDINGANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['TenureTick'] = _TENURETICK
+DESCRIPTOR.message_types_by_name['TenureSpan'] = _TENURESPAN
+DESCRIPTOR.message_types_by_name['TenureDeveloper'] = _TENUREDEVELOPER
+DESCRIPTOR.message_types_by_name['TenureAnalysisResults'] = _TENUREANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['WorkPattern'] = _WORKPATTERN
+DESCRIPTOR.message_types_by_name['WorkPatternsStreak'] = _WORKPATTERNSSTREAK
+DESCRIPTOR.message_types_by_name['WorkPatternsAnalysisResults'] = _WORKPATTERNSANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['KnowledgeMapVector'] = _KNOWLEDGEMAPVECTOR
+DESCRIPTOR.message_types_by_name['KnowledgeMapAnalysisResults'] = _KNOWLEDGEMAPANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['DeadCodeTick'] = _DEADCODETICK
+DESCRIPTOR.message_types_by_name['DeadCodeOrphan'] = _DEADCODEORPHAN
+DESCRIPTOR.message_types_by_name['DeadCodeAnalysisResults'] = _DEADCODEANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['CommitSentiment'] = _COMMITSENTIMENT
+DESCRIPTOR.message_types_by_name['CommitSentimentAnalysisResults'] = _COMMITSENTIMENTANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['Hotspot'] = _HOTSPOT
+DESCRIPTOR.message_types_by_name['HotspotsAnalysisResults'] = _HOTSPOTSANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['TestRatioTick'] = _TESTRATIOTICK
+DESCRIPTOR.message_types_by_name['TestRatioAnalysisResults'] = _TESTRATIOANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['VocabularyTick'] = _VOCABULARYTICK
+DESCRIPTOR.message_types_by_name['VocabularyTerms'] = _VOCABULARYTERMS
+DESCRIPTOR.message_types_by_name['VocabularyAnalysisResults'] = _VOCABULARYANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['ImportGraphTick'] = _IMPORTGRAPHTICK
+DESCRIPTOR.message_types_by_name['ImportGraphEdge'] = _IMPORTGRAPHEDGE
+DESCRIPTOR.message_types_by_name['ImportGraphAnalysisResults'] = _IMPORTGRAPHANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['APISurfaceTick'] = _APISURFACETICK
+DESCRIPTOR.message_types_by_name['APIBreakingChange'] = _APIBREAKINGCHANGE
+DESCRIPTOR.message_types_by_name['APISurfaceAnalysisResults'] = _APISURFACEANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['ClonesTick'] = _CLONESTICK
+DESCRIPTOR.message_types_by_name['ClonesCommit'] = _CLONESCOMMIT
+DESCRIPTOR.message_types_by_name['ClonesAnalysisResults'] = _CLONESANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['TechDebtTick'] = _TECHDEBTTICK
+DESCRIPTOR.message_types_by_name['TechDebtMarker'] = _TECHDEBTMARKER
+DESCRIPTOR.message_types_by_name['TechDebtAnalysisResults'] = _TECHDEBTANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['CommentDensity'] = _COMMENTDENSITY
+DESCRIPTOR.message_types_by_name['CommentDensitySeries'] = _COMMENTDENSITYSERIES
+DESCRIPTOR.message_types_by_name['CommentDensityAnalysisResults'] = _COMMENTDENSITYANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['Complexity'] = _COMPLEXITY
+DESCRIPTOR.message_types_by_name['ComplexitySeries'] = _COMPLEXITYSERIES
+DESCRIPTOR.message_types_by_name['ComplexityFunction'] = _COMPLEXITYFUNCTION
+DESCRIPTOR.message_types_by_name['ComplexityCommit'] = _COMPLEXITYCOMMIT
+DESCRIPTOR.message_types_by_name['ComplexityAnalysisResults'] = _COMPLEXITYANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['FunctionChurnDay'] = _FUNCTIONCHURNDAY
+DESCRIPTOR.message_types_by_name['FunctionChurn'] = _FUNCTIONCHURN
+DESCRIPTOR.message_types_by_name['FunctionChurnAnalysisResults'] = _FUNCTIONCHURNANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['HistoryRewrite'] = _HISTORYREWRITE
+DESCRIPTOR.message_types_by_name['HistoryRewritesAnalysisResults'] = _HISTORYREWRITESANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['ChangeEntropyDay'] = _CHANGEENTROPYDAY
+DESCRIPTOR.message_types_by_name['ChangeEntropyCommit'] = _CHANGEENTROPYCOMMIT
+DESCRIPTOR.message_types_by_name['ChangeEntropyAnalysisResults'] = _CHANGEENTROPYANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['ExpertiseVector'] = _EXPERTISEVECTOR
+DESCRIPTOR.message_types_by_name['ExpertiseAnalysisResults'] = _EXPERTISEANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['OwnershipAnalysisResults'] = _OWNERSHIPANALYSISRESULTS
+DESCRIPTOR.message_types_by_name['AnalysisResults'] = _ANALYSISRESULTS
+_sym_db.RegisterFileDescriptor(DESCRIPTOR)
+
+Metadata = _reflection.GeneratedProtocolMessageType('Metadata', (_message.Message,), dict(
+
+  RunTimePerItemEntry = _reflection.GeneratedProtocolMessageType('RunTimePerItemEntry', (_message.Message,), dict(
+    DESCRIPTOR = _METADATA_RUNTIMEPERITEMENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:Metadata.RunTimePerItemEntry)
+    ))
+  ,
+
+  ProfilePerItemEntry = _reflection.GeneratedProtocolMessageType('ProfilePerItemEntry', (_message.Message,), dict(
+    DESCRIPTOR = _METADATA_PROFILEPERITEMENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:Metadata.ProfilePerItemEntry)
+    ))
+  ,
+
+  UnparsedFilesEntry = _reflection.GeneratedProtocolMessageType('UnparsedFilesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _METADATA_UNPARSEDFILESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:Metadata.UnparsedFilesEntry)
+    ))
+  ,
+  DESCRIPTOR = _METADATA,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:Metadata)
+  ))
+_sym_db.RegisterMessage(Metadata)
+_sym_db.RegisterMessage(Metadata.RunTimePerItemEntry)
+_sym_db.RegisterMessage(Metadata.ProfilePerItemEntry)
+_sym_db.RegisterMessage(Metadata.UnparsedFilesEntry)
+
+UnparsedFile = _reflection.GeneratedProtocolMessageType('UnparsedFile', (_message.Message,), dict(
+  DESCRIPTOR = _UNPARSEDFILE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:UnparsedFile)
+  ))
+_sym_db.RegisterMessage(UnparsedFile)
+
+Annotation = _reflection.GeneratedProtocolMessageType('Annotation', (_message.Message,), dict(
+  DESCRIPTOR = _ANNOTATION,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:Annotation)
+  ))
+_sym_db.RegisterMessage(Annotation)
+
+ItemProfile = _reflection.GeneratedProtocolMessageType('ItemProfile', (_message.Message,), dict(
+  DESCRIPTOR = _ITEMPROFILE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ItemProfile)
+  ))
+_sym_db.RegisterMessage(ItemProfile)
+
+BurndownSparseMatrixRow = _reflection.GeneratedProtocolMessageType('BurndownSparseMatrixRow', (_message.Message,), dict(
+  DESCRIPTOR = _BURNDOWNSPARSEMATRIXROW,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:BurndownSparseMatrixRow)
+  ))
+_sym_db.RegisterMessage(BurndownSparseMatrixRow)
+
+BurndownSparseMatrix = _reflection.GeneratedProtocolMessageType('BurndownSparseMatrix', (_message.Message,), dict(
+  DESCRIPTOR = _BURNDOWNSPARSEMATRIX,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:BurndownSparseMatrix)
+  ))
+_sym_db.RegisterMessage(BurndownSparseMatrix)
+
+BurndownCohort = _reflection.GeneratedProtocolMessageType('BurndownCohort', (_message.Message,), dict(
+  DESCRIPTOR = _BURNDOWNCOHORT,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:BurndownCohort)
+  ))
+_sym_db.RegisterMessage(BurndownCohort)
+
+BurndownSurvival = _reflection.GeneratedProtocolMessageType('BurndownSurvival', (_message.Message,), dict(
+  DESCRIPTOR = _BURNDOWNSURVIVAL,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:BurndownSurvival)
+  ))
+_sym_db.RegisterMessage(BurndownSurvival)
+
+BurndownAnalysisResults = _reflection.GeneratedProtocolMessageType('BurndownAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _BURNDOWNANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:BurndownAnalysisResults)
+  ))
+_sym_db.RegisterMessage(BurndownAnalysisResults)
+
+FileSnapshot = _reflection.GeneratedProtocolMessageType('FileSnapshot', (_message.Message,), dict(
+
+  AgesEntry = _reflection.GeneratedProtocolMessageType('AgesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _FILESNAPSHOT_AGESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:FileSnapshot.AgesEntry)
+    ))
+  ,
+
+  OwnersEntry = _reflection.GeneratedProtocolMessageType('OwnersEntry', (_message.Message,), dict(
+    DESCRIPTOR = _FILESNAPSHOT_OWNERSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:FileSnapshot.OwnersEntry)
+    ))
+  ,
+  DESCRIPTOR = _FILESNAPSHOT,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:FileSnapshot)
+  ))
+_sym_db.RegisterMessage(FileSnapshot)
+_sym_db.RegisterMessage(FileSnapshot.AgesEntry)
+_sym_db.RegisterMessage(FileSnapshot.OwnersEntry)
+
+CompressedSparseRowMatrix = _reflection.GeneratedProtocolMessageType('CompressedSparseRowMatrix', (_message.Message,), dict(
+  DESCRIPTOR = _COMPRESSEDSPARSEROWMATRIX,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CompressedSparseRowMatrix)
+  ))
+_sym_db.RegisterMessage(CompressedSparseRowMatrix)
+
+Couples = _reflection.GeneratedProtocolMessageType('Couples', (_message.Message,), dict(
+  DESCRIPTOR = _COUPLES,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:Couples)
+  ))
+_sym_db.RegisterMessage(Couples)
+
+TouchedFiles = _reflection.GeneratedProtocolMessageType('TouchedFiles', (_message.Message,), dict(
+  DESCRIPTOR = _TOUCHEDFILES,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TouchedFiles)
+  ))
+_sym_db.RegisterMessage(TouchedFiles)
+
+CouplesSignificance = _reflection.GeneratedProtocolMessageType('CouplesSignificance', (_message.Message,), dict(
+  DESCRIPTOR = _COUPLESSIGNIFICANCE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CouplesSignificance)
+  ))
+_sym_db.RegisterMessage(CouplesSignificance)
+
+CouplesDecay = _reflection.GeneratedProtocolMessageType('CouplesDecay', (_message.Message,), dict(
+  DESCRIPTOR = _COUPLESDECAY,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CouplesDecay)
+  ))
+_sym_db.RegisterMessage(CouplesDecay)
+
+CouplesAnalysisResults = _reflection.GeneratedProtocolMessageType('CouplesAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _COUPLESANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CouplesAnalysisResults)
+  ))
+_sym_db.RegisterMessage(CouplesAnalysisResults)
+
+UASTChange = _reflection.GeneratedProtocolMessageType('UASTChange', (_message.Message,), dict(
+  DESCRIPTOR = _UASTCHANGE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:UASTChange)
+  ))
+_sym_db.RegisterMessage(UASTChange)
+
+UASTChangesSaverResults = _reflection.GeneratedProtocolMessageType('UASTChangesSaverResults', (_message.Message,), dict(
+  DESCRIPTOR = _UASTCHANGESSAVERRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:UASTChangesSaverResults)
+  ))
+_sym_db.RegisterMessage(UASTChangesSaverResults)
+
+ShotnessRecord = _reflection.GeneratedProtocolMessageType('ShotnessRecord', (_message.Message,), dict(
+
+  CountersEntry = _reflection.GeneratedProtocolMessageType('CountersEntry', (_message.Message,), dict(
+    DESCRIPTOR = _SHOTNESSRECORD_COUNTERSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:ShotnessRecord.CountersEntry)
+    ))
+  ,
+  DESCRIPTOR = _SHOTNESSRECORD,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ShotnessRecord)
+  ))
+_sym_db.RegisterMessage(ShotnessRecord)
+_sym_db.RegisterMessage(ShotnessRecord.CountersEntry)
+
+ShotnessAnalysisResults = _reflection.GeneratedProtocolMessageType('ShotnessAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _SHOTNESSANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ShotnessAnalysisResults)
+  ))
+_sym_db.RegisterMessage(ShotnessAnalysisResults)
+
+FileHistory = _reflection.GeneratedProtocolMessageType('FileHistory', (_message.Message,), dict(
+  DESCRIPTOR = _FILEHISTORY,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:FileHistory)
+  ))
+_sym_db.RegisterMessage(FileHistory)
+
+FileHistoryResultMessage = _reflection.GeneratedProtocolMessageType('FileHistoryResultMessage', (_message.Message,), dict(
+
+  FilesEntry = _reflection.GeneratedProtocolMessageType('FilesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _FILEHISTORYRESULTMESSAGE_FILESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:FileHistoryResultMessage.FilesEntry)
+    ))
+  ,
+  DESCRIPTOR = _FILEHISTORYRESULTMESSAGE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:FileHistoryResultMessage)
+  ))
+_sym_db.RegisterMessage(FileHistoryResultMessage)
+_sym_db.RegisterMessage(FileHistoryResultMessage.FilesEntry)
+
+Sentiment = _reflection.GeneratedProtocolMessageType('Sentiment', (_message.Message,), dict(
+  DESCRIPTOR = _SENTIMENT,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:Sentiment)
+  ))
+_sym_db.RegisterMessage(Sentiment)
+
+CommentSentimentResults = _reflection.GeneratedProtocolMessageType('CommentSentimentResults', (_message.Message,), dict(
+
+  SentimentByDayEntry = _reflection.GeneratedProtocolMessageType('SentimentByDayEntry', (_message.Message,), dict(
+    DESCRIPTOR = _COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:CommentSentimentResults.SentimentByDayEntry)
+    ))
+  ,
+  DESCRIPTOR = _COMMENTSENTIMENTRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommentSentimentResults)
+  ))
+_sym_db.RegisterMessage(CommentSentimentResults)
+_sym_db.RegisterMessage(CommentSentimentResults.SentimentByDayEntry)
+
+RecordedColumn = _reflection.GeneratedProtocolMessageType('RecordedColumn', (_message.Message,), dict(
+  DESCRIPTOR = _RECORDEDCOLUMN,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RecordedColumn)
+  ))
+_sym_db.RegisterMessage(RecordedColumn)
+
+RecordedStream = _reflection.GeneratedProtocolMessageType('RecordedStream', (_message.Message,), dict(
+  DESCRIPTOR = _RECORDEDSTREAM,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RecordedStream)
+  ))
+_sym_db.RegisterMessage(RecordedStream)
+
+RecorderResults = _reflection.GeneratedProtocolMessageType('RecorderResults', (_message.Message,), dict(
+  DESCRIPTOR = _RECORDERRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RecorderResults)
+  ))
+_sym_db.RegisterMessage(RecorderResults)
+
+ActivityDay = _reflection.GeneratedProtocolMessageType('ActivityDay', (_message.Message,), dict(
+
+  CommitsEntry = _reflection.GeneratedProtocolMessageType('CommitsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _ACTIVITYDAY_COMMITSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:ActivityDay.CommitsEntry)
+    ))
+  ,
+  DESCRIPTOR = _ACTIVITYDAY,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ActivityDay)
+  ))
+_sym_db.RegisterMessage(ActivityDay)
+_sym_db.RegisterMessage(ActivityDay.CommitsEntry)
+
+ActiveDevelopers = _reflection.GeneratedProtocolMessageType('ActiveDevelopers', (_message.Message,), dict(
+  DESCRIPTOR = _ACTIVEDEVELOPERS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ActiveDevelopers)
+  ))
+_sym_db.RegisterMessage(ActiveDevelopers)
+
+ActivityAnalysisResults = _reflection.GeneratedProtocolMessageType('ActivityAnalysisResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _ACTIVITYANALYSISRESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:ActivityAnalysisResults.DaysEntry)
+    ))
+  ,
+
+  ActiveEntry = _reflection.GeneratedProtocolMessageType('ActiveEntry', (_message.Message,), dict(
+    DESCRIPTOR = _ACTIVITYANALYSISRESULTS_ACTIVEENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:ActivityAnalysisResults.ActiveEntry)
+    ))
+  ,
+  DESCRIPTOR = _ACTIVITYANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ActivityAnalysisResults)
+  ))
+_sym_db.RegisterMessage(ActivityAnalysisResults)
+_sym_db.RegisterMessage(ActivityAnalysisResults.DaysEntry)
+_sym_db.RegisterMessage(ActivityAnalysisResults.ActiveEntry)
+
+LanguageCounts = _reflection.GeneratedProtocolMessageType('LanguageCounts', (_message.Message,), dict(
+
+  LanguagesEntry = _reflection.GeneratedProtocolMessageType('LanguagesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _LANGUAGECOUNTS_LANGUAGESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:LanguageCounts.LanguagesEntry)
+    ))
+  ,
+  DESCRIPTOR = _LANGUAGECOUNTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:LanguageCounts)
+  ))
+_sym_db.RegisterMessage(LanguageCounts)
+_sym_db.RegisterMessage(LanguageCounts.LanguagesEntry)
+
+CommitLanguagesAnalysisResults = _reflection.GeneratedProtocolMessageType('CommitLanguagesAnalysisResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _COMMITLANGUAGESANALYSISRESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:CommitLanguagesAnalysisResults.DaysEntry)
+    ))
+  ,
+  DESCRIPTOR = _COMMITLANGUAGESANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommitLanguagesAnalysisResults)
+  ))
+_sym_db.RegisterMessage(CommitLanguagesAnalysisResults)
+_sym_db.RegisterMessage(CommitLanguagesAnalysisResults.DaysEntry)
+
+ImpactChurnDay = _reflection.GeneratedProtocolMessageType('ImpactChurnDay', (_message.Message,), dict(
+  DESCRIPTOR = _IMPACTCHURNDAY,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ImpactChurnDay)
+  ))
+_sym_db.RegisterMessage(ImpactChurnDay)
+
+ImpactChurnFile = _reflection.GeneratedProtocolMessageType('ImpactChurnFile', (_message.Message,), dict(
+  DESCRIPTOR = _IMPACTCHURNFILE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ImpactChurnFile)
+  ))
+_sym_db.RegisterMessage(ImpactChurnFile)
+
+ImpactChurnAnalysisResults = _reflection.GeneratedProtocolMessageType('ImpactChurnAnalysisResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _IMPACTCHURNANALYSISRESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:ImpactChurnAnalysisResults.DaysEntry)
+    ))
+  ,
+
+  FilesEntry = _reflection.GeneratedProtocolMessageType('FilesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _IMPACTCHURNANALYSISRESULTS_FILESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:ImpactChurnAnalysisResults.FilesEntry)
+    ))
+  ,
+  DESCRIPTOR = _IMPACTCHURNANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ImpactChurnAnalysisResults)
+  ))
+_sym_db.RegisterMessage(ImpactChurnAnalysisResults)
+_sym_db.RegisterMessage(ImpactChurnAnalysisResults.DaysEntry)
+_sym_db.RegisterMessage(ImpactChurnAnalysisResults.FilesEntry)
+
+DAGShapeTick = _reflection.GeneratedProtocolMessageType('DAGShapeTick', (_message.Message,), dict(
+  DESCRIPTOR = _DAGSHAPETICK,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DAGShapeTick)
+  ))
+_sym_db.RegisterMessage(DAGShapeTick)
+
+DAGShapeAnalysisResults = _reflection.GeneratedProtocolMessageType('DAGShapeAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _DAGSHAPEANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DAGShapeAnalysisResults)
+  ))
+_sym_db.RegisterMessage(DAGShapeAnalysisResults)
+
+RenameChain = _reflection.GeneratedProtocolMessageType('RenameChain', (_message.Message,), dict(
+  DESCRIPTOR = _RENAMECHAIN,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RenameChain)
+  ))
+_sym_db.RegisterMessage(RenameChain)
+
+RenameFrequencyAnalysisResults = _reflection.GeneratedProtocolMessageType('RenameFrequencyAnalysisResults', (_message.Message,), dict(
+
+  DirectoriesEntry = _reflection.GeneratedProtocolMessageType('DirectoriesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _RENAMEFREQUENCYANALYSISRESULTS_DIRECTORIESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:RenameFrequencyAnalysisResults.DirectoriesEntry)
+    ))
+  ,
+  DESCRIPTOR = _RENAMEFREQUENCYANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RenameFrequencyAnalysisResults)
+  ))
+_sym_db.RegisterMessage(RenameFrequencyAnalysisResults)
+_sym_db.RegisterMessage(RenameFrequencyAnalysisResults.DirectoriesEntry)
+
+RewriteDepthFile = _reflection.GeneratedProtocolMessageType('RewriteDepthFile', (_message.Message,), dict(
+  DESCRIPTOR = _REWRITEDEPTHFILE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RewriteDepthFile)
+  ))
+_sym_db.RegisterMessage(RewriteDepthFile)
+
+RewriteHotspot = _reflection.GeneratedProtocolMessageType('RewriteHotspot', (_message.Message,), dict(
+  DESCRIPTOR = _REWRITEHOTSPOT,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RewriteHotspot)
+  ))
+_sym_db.RegisterMessage(RewriteHotspot)
+
+RewriteDepthAnalysisResults = _reflection.GeneratedProtocolMessageType('RewriteDepthAnalysisResults', (_message.Message,), dict(
+
+  FilesEntry = _reflection.GeneratedProtocolMessageType('FilesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _REWRITEDEPTHANALYSISRESULTS_FILESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:RewriteDepthAnalysisResults.FilesEntry)
+    ))
+  ,
+  DESCRIPTOR = _REWRITEDEPTHANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RewriteDepthAnalysisResults)
+  ))
+_sym_db.RegisterMessage(RewriteDepthAnalysisResults)
+_sym_db.RegisterMessage(RewriteDepthAnalysisResults.FilesEntry)
+
+SensitivePathChange = _reflection.GeneratedProtocolMessageType('SensitivePathChange', (_message.Message,), dict(
+  DESCRIPTOR = _SENSITIVEPATHCHANGE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:SensitivePathChange)
+  ))
+_sym_db.RegisterMessage(SensitivePathChange)
+
+SensitivePathsAnalysisResults = _reflection.GeneratedProtocolMessageType('SensitivePathsAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _SENSITIVEPATHSANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:SensitivePathsAnalysisResults)
+  ))
+_sym_db.RegisterMessage(SensitivePathsAnalysisResults)
+
+ContributorsTick = _reflection.GeneratedProtocolMessageType('ContributorsTick', (_message.Message,), dict(
+  DESCRIPTOR = _CONTRIBUTORSTICK,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ContributorsTick)
+  ))
+_sym_db.RegisterMessage(ContributorsTick)
+
+ContributorsAnalysisResults = _reflection.GeneratedProtocolMessageType('ContributorsAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _CONTRIBUTORSANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ContributorsAnalysisResults)
+  ))
+_sym_db.RegisterMessage(ContributorsAnalysisResults)
+
+StewardshipCounts = _reflection.GeneratedProtocolMessageType('StewardshipCounts', (_message.Message,), dict(
+  DESCRIPTOR = _STEWARDSHIPCOUNTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:StewardshipCounts)
+  ))
+_sym_db.RegisterMessage(StewardshipCounts)
+
+StewardshipTick = _reflection.GeneratedProtocolMessageType('StewardshipTick', (_message.Message,), dict(
+
+  PeopleEntry = _reflection.GeneratedProtocolMessageType('PeopleEntry', (_message.Message,), dict(
+    DESCRIPTOR = _STEWARDSHIPTICK_PEOPLEENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:StewardshipTick.PeopleEntry)
+    ))
+  ,
+  DESCRIPTOR = _STEWARDSHIPTICK,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:StewardshipTick)
+  ))
+_sym_db.RegisterMessage(StewardshipTick)
+_sym_db.RegisterMessage(StewardshipTick.PeopleEntry)
+
+StewardshipAnalysisResults = _reflection.GeneratedProtocolMessageType('StewardshipAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _STEWARDSHIPANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:StewardshipAnalysisResults)
+  ))
+_sym_db.RegisterMessage(StewardshipAnalysisResults)
+
+TeamAlignmentDirectory = _reflection.GeneratedProtocolMessageType('TeamAlignmentDirectory', (_message.Message,), dict(
+
+  EditsEntry = _reflection.GeneratedProtocolMessageType('EditsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _TEAMALIGNMENTDIRECTORY_EDITSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:TeamAlignmentDirectory.EditsEntry)
+    ))
+  ,
+  DESCRIPTOR = _TEAMALIGNMENTDIRECTORY,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TeamAlignmentDirectory)
+  ))
+_sym_db.RegisterMessage(TeamAlignmentDirectory)
+_sym_db.RegisterMessage(TeamAlignmentDirectory.EditsEntry)
+
+TeamAlignmentTick = _reflection.GeneratedProtocolMessageType('TeamAlignmentTick', (_message.Message,), dict(
+  DESCRIPTOR = _TEAMALIGNMENTTICK,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TeamAlignmentTick)
+  ))
+_sym_db.RegisterMessage(TeamAlignmentTick)
+
+TeamAlignmentAnalysisResults = _reflection.GeneratedProtocolMessageType('TeamAlignmentAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _TEAMALIGNMENTANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TeamAlignmentAnalysisResults)
+  ))
+_sym_db.RegisterMessage(TeamAlignmentAnalysisResults)
+
+DefectFeaturesAnalysisResults = _reflection.GeneratedProtocolMessageType('DefectFeaturesAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _DEFECTFEATURESANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DefectFeaturesAnalysisResults)
+  ))
+_sym_db.RegisterMessage(DefectFeaturesAnalysisResults)
+
+CocomoTick = _reflection.GeneratedProtocolMessageType('CocomoTick', (_message.Message,), dict(
+  DESCRIPTOR = _COCOMOTICK,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CocomoTick)
+  ))
+_sym_db.RegisterMessage(CocomoTick)
+
+CocomoAnalysisResults = _reflection.GeneratedProtocolMessageType('CocomoAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _COCOMOANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CocomoAnalysisResults)
+  ))
+_sym_db.RegisterMessage(CocomoAnalysisResults)
+
+ReviewLatencyStats = _reflection.GeneratedProtocolMessageType('ReviewLatencyStats', (_message.Message,), dict(
+  DESCRIPTOR = _REVIEWLATENCYSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ReviewLatencyStats)
+  ))
+_sym_db.RegisterMessage(ReviewLatencyStats)
+
+ReviewLatencyAnalysisResults = _reflection.GeneratedProtocolMessageType('ReviewLatencyAnalysisResults', (_message.Message,), dict(
+
+  PeopleEntry = _reflection.GeneratedProtocolMessageType('PeopleEntry', (_message.Message,), dict(
+    DESCRIPTOR = _REVIEWLATENCYANALYSISRESULTS_PEOPLEENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:ReviewLatencyAnalysisResults.PeopleEntry)
+    ))
+  ,
+  DESCRIPTOR = _REVIEWLATENCYANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ReviewLatencyAnalysisResults)
+  ))
+_sym_db.RegisterMessage(ReviewLatencyAnalysisResults)
+_sym_db.RegisterMessage(ReviewLatencyAnalysisResults.PeopleEntry)
+
+IssueTicket = _reflection.GeneratedProtocolMessageType('IssueTicket', (_message.Message,), dict(
+  DESCRIPTOR = _ISSUETICKET,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:IssueTicket)
+  ))
+_sym_db.RegisterMessage(IssueTicket)
+
+IssueReferencesAnalysisResults = _reflection.GeneratedProtocolMessageType('IssueReferencesAnalysisResults', (_message.Message,), dict(
+
+  TicketsEntry = _reflection.GeneratedProtocolMessageType('TicketsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _ISSUEREFERENCESANALYSISRESULTS_TICKETSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:IssueReferencesAnalysisResults.TicketsEntry)
+    ))
+  ,
+  DESCRIPTOR = _ISSUEREFERENCESANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:IssueReferencesAnalysisResults)
+  ))
+_sym_db.RegisterMessage(IssueReferencesAnalysisResults)
+_sym_db.RegisterMessage(IssueReferencesAnalysisResults.TicketsEntry)
+
+ConventionalCommitsStats = _reflection.GeneratedProtocolMessageType('ConventionalCommitsStats', (_message.Message,), dict(
+
+  TypesEntry = _reflection.GeneratedProtocolMessageType('TypesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _CONVENTIONALCOMMITSSTATS_TYPESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:ConventionalCommitsStats.TypesEntry)
+    ))
+  ,
+  DESCRIPTOR = _CONVENTIONALCOMMITSSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ConventionalCommitsStats)
+  ))
+_sym_db.RegisterMessage(ConventionalCommitsStats)
+_sym_db.RegisterMessage(ConventionalCommitsStats.TypesEntry)
+
+ConventionalCommitsAnalysisResults = _reflection.GeneratedProtocolMessageType('ConventionalCommitsAnalysisResults', (_message.Message,), dict(
+
+  PeopleEntry = _reflection.GeneratedProtocolMessageType('PeopleEntry', (_message.Message,), dict(
+    DESCRIPTOR = _CONVENTIONALCOMMITSANALYSISRESULTS_PEOPLEENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:ConventionalCommitsAnalysisResults.PeopleEntry)
+    ))
+  ,
+  DESCRIPTOR = _CONVENTIONALCOMMITSANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ConventionalCommitsAnalysisResults)
+  ))
+_sym_db.RegisterMessage(ConventionalCommitsAnalysisResults)
+_sym_db.RegisterMessage(ConventionalCommitsAnalysisResults.PeopleEntry)
+
+LanguageLines = _reflection.GeneratedProtocolMessageType('LanguageLines', (_message.Message,), dict(
+  DESCRIPTOR = _LANGUAGELINES,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:LanguageLines)
+  ))
+_sym_db.RegisterMessage(LanguageLines)
+
+LanguageLinesAnalysisResults = _reflection.GeneratedProtocolMessageType('LanguageLinesAnalysisResults', (_message.Message,), dict(
+
+  LanguagesEntry = _reflection.GeneratedProtocolMessageType('LanguagesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _LANGUAGELINESANALYSISRESULTS_LANGUAGESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:LanguageLinesAnalysisResults.LanguagesEntry)
+    ))
+  ,
+  DESCRIPTOR = _LANGUAGELINESANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:LanguageLinesAnalysisResults)
+  ))
+_sym_db.RegisterMessage(LanguageLinesAnalysisResults)
+_sym_db.RegisterMessage(LanguageLinesAnalysisResults.LanguagesEntry)
+
+RepositorySizeTick = _reflection.GeneratedProtocolMessageType('RepositorySizeTick', (_message.Message,), dict(
+  DESCRIPTOR = _REPOSITORYSIZETICK,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RepositorySizeTick)
+  ))
+_sym_db.RegisterMessage(RepositorySizeTick)
+
+RepositoryBlob = _reflection.GeneratedProtocolMessageType('RepositoryBlob', (_message.Message,), dict(
+  DESCRIPTOR = _REPOSITORYBLOB,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RepositoryBlob)
+  ))
+_sym_db.RegisterMessage(RepositoryBlob)
+
+RepositorySizeAnalysisResults = _reflection.GeneratedProtocolMessageType('RepositorySizeAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _REPOSITORYSIZEANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RepositorySizeAnalysisResults)
+  ))
+_sym_db.RegisterMessage(RepositorySizeAnalysisResults)
+
+FileAge = _reflection.GeneratedProtocolMessageType('FileAge', (_message.Message,), dict(
+  DESCRIPTOR = _FILEAGE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:FileAge)
+  ))
+_sym_db.RegisterMessage(FileAge)
+
+FileAgeAnalysisResults = _reflection.GeneratedProtocolMessageType('FileAgeAnalysisResults', (_message.Message,), dict(
+
+  FilesEntry = _reflection.GeneratedProtocolMessageType('FilesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _FILEAGEANALYSISRESULTS_FILESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:FileAgeAnalysisResults.FilesEntry)
+    ))
+  ,
+  DESCRIPTOR = _FILEAGEANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:FileAgeAnalysisResults)
+  ))
+_sym_db.RegisterMessage(FileAgeAnalysisResults)
+_sym_db.RegisterMessage(FileAgeAnalysisResults.FilesEntry)
+
+RefactoringStats = _reflection.GeneratedProtocolMessageType('RefactoringStats', (_message.Message,), dict(
+  DESCRIPTOR = _REFACTORINGSTATS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RefactoringStats)
+  ))
+_sym_db.RegisterMessage(RefactoringStats)
+
+RefactoringAnalysisResults = _reflection.GeneratedProtocolMessageType('RefactoringAnalysisResults', (_message.Message,), dict(
+
+  PeopleEntry = _reflection.GeneratedProtocolMessageType('PeopleEntry', (_message.Message,), dict(
+    DESCRIPTOR = _REFACTORINGANALYSISRESULTS_PEOPLEENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:RefactoringAnalysisResults.PeopleEntry)
+    ))
+  ,
+  DESCRIPTOR = _REFACTORINGANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RefactoringAnalysisResults)
+  ))
+_sym_db.RegisterMessage(RefactoringAnalysisResults)
+_sym_db.RegisterMessage(RefactoringAnalysisResults.PeopleEntry)
+
+RevertsTick = _reflection.GeneratedProtocolMessageType('RevertsTick', (_message.Message,), dict(
+  DESCRIPTOR = _REVERTSTICK,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RevertsTick)
+  ))
+_sym_db.RegisterMessage(RevertsTick)
+
+RevertedCommit = _reflection.GeneratedProtocolMessageType('RevertedCommit', (_message.Message,), dict(
+  DESCRIPTOR = _REVERTEDCOMMIT,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RevertedCommit)
+  ))
+_sym_db.RegisterMessage(RevertedCommit)
+
+RevertsAnalysisResults = _reflection.GeneratedProtocolMessageType('RevertsAnalysisResults', (_message.Message,), dict(
+
+  FilesEntry = _reflection.GeneratedProtocolMessageType('FilesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _REVERTSANALYSISRESULTS_FILESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:RevertsAnalysisResults.FilesEntry)
+    ))
+  ,
+  DESCRIPTOR = _REVERTSANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:RevertsAnalysisResults)
+  ))
+_sym_db.RegisterMessage(RevertsAnalysisResults)
+_sym_db.RegisterMessage(RevertsAnalysisResults.FilesEntry)
+
+BranchMerge = _reflection.GeneratedProtocolMessageType('BranchMerge', (_message.Message,), dict(
+  DESCRIPTOR = _BRANCHMERGE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:BranchMerge)
+  ))
+_sym_db.RegisterMessage(BranchMerge)
+
+BranchLifetimeAnalysisResults = _reflection.GeneratedProtocolMessageType('BranchLifetimeAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _BRANCHLIFETIMEANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:BranchLifetimeAnalysisResults)
+  ))
+_sym_db.RegisterMessage(BranchLifetimeAnalysisResults)
+
+Release = _reflection.GeneratedProtocolMessageType('Release', (_message.Message,), dict(
+  DESCRIPTOR = _RELEASE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:Release)
+  ))
+_sym_db.RegisterMessage(Release)
+
+ReleaseCadenceAnalysisResults = _reflection.GeneratedProtocolMessageType('ReleaseCadenceAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _RELEASECADENCEANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ReleaseCadenceAnalysisResults)
+  ))
+_sym_db.RegisterMessage(ReleaseCadenceAnalysisResults)
+
+GiniTick = _reflection.GeneratedProtocolMessageType('GiniTick', (_message.Message,), dict(
+
+  CommitsEntry = _reflection.GeneratedProtocolMessageType('CommitsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _GINITICK_COMMITSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:GiniTick.CommitsEntry)
+    ))
+  ,
+
+  LinesEntry = _reflection.GeneratedProtocolMessageType('LinesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _GINITICK_LINESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:GiniTick.LinesEntry)
+    ))
+  ,
+  DESCRIPTOR = _GINITICK,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:GiniTick)
+  ))
+_sym_db.RegisterMessage(GiniTick)
+_sym_db.RegisterMessage(GiniTick.CommitsEntry)
+_sym_db.RegisterMessage(GiniTick.LinesEntry)
+
+GiniAnalysisResults = _reflection.GeneratedProtocolMessageType('GiniAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _GINIANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:GiniAnalysisResults)
+  ))
+_sym_db.RegisterMessage(GiniAnalysisResults)
+
+OnboardingDeveloper = _reflection.GeneratedProtocolMessageType('OnboardingDeveloper', (_message.Message,), dict(
+
+  DirectoriesEntry = _reflection.GeneratedProtocolMessageType('DirectoriesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _ONBOARDINGDEVELOPER_DIRECTORIESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:OnboardingDeveloper.DirectoriesEntry)
+    ))
+  ,
+  DESCRIPTOR = _ONBOARDINGDEVELOPER,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:OnboardingDeveloper)
+  ))
+_sym_db.RegisterMessage(OnboardingDeveloper)
+_sym_db.RegisterMessage(OnboardingDeveloper.DirectoriesEntry)
+
+OnboardingDirectory = _reflection.GeneratedProtocolMessageType('OnboardingDirectory', (_message.Message,), dict(
+  DESCRIPTOR = _ONBOARDINGDIRECTORY,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:OnboardingDirectory)
+  ))
+_sym_db.RegisterMessage(OnboardingDirectory)
+
+OnboardingCohort = _reflection.GeneratedProtocolMessageType('OnboardingCohort', (_message.Message,), dict(
+
+  DirectoriesEntry = _reflection.GeneratedProtocolMessageType('DirectoriesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _ONBOARDINGCOHORT_DIRECTORIESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:OnboardingCohort.DirectoriesEntry)
+    ))
+  ,
+  DESCRIPTOR = _ONBOARDINGCOHORT,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:OnboardingCohort)
+  ))
+_sym_db.RegisterMessage(OnboardingCohort)
+_sym_db.RegisterMessage(OnboardingCohort.DirectoriesEntry)
+
+OnboardingAnalysisResults = _reflection.GeneratedProtocolMessageType('OnboardingAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _ONBOARDINGANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:OnboardingAnalysisResults)
+  ))
+_sym_db.RegisterMessage(OnboardingAnalysisResults)
+
+TenureTick = _reflection.GeneratedProtocolMessageType('TenureTick', (_message.Message,), dict(
+  DESCRIPTOR = _TENURETICK,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TenureTick)
+  ))
+_sym_db.RegisterMessage(TenureTick)
+
+TenureSpan = _reflection.GeneratedProtocolMessageType('TenureSpan', (_message.Message,), dict(
+  DESCRIPTOR = _TENURESPAN,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TenureSpan)
+  ))
+_sym_db.RegisterMessage(TenureSpan)
+
+TenureDeveloper = _reflection.GeneratedProtocolMessageType('TenureDeveloper', (_message.Message,), dict(
+  DESCRIPTOR = _TENUREDEVELOPER,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TenureDeveloper)
+  ))
+_sym_db.RegisterMessage(TenureDeveloper)
+
+TenureAnalysisResults = _reflection.GeneratedProtocolMessageType('TenureAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _TENUREANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TenureAnalysisResults)
+  ))
+_sym_db.RegisterMessage(TenureAnalysisResults)
+
+WorkPattern = _reflection.GeneratedProtocolMessageType('WorkPattern', (_message.Message,), dict(
+  DESCRIPTOR = _WORKPATTERN,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:WorkPattern)
+  ))
+_sym_db.RegisterMessage(WorkPattern)
+
+WorkPatternsStreak = _reflection.GeneratedProtocolMessageType('WorkPatternsStreak', (_message.Message,), dict(
+  DESCRIPTOR = _WORKPATTERNSSTREAK,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:WorkPatternsStreak)
+  ))
+_sym_db.RegisterMessage(WorkPatternsStreak)
+
+WorkPatternsAnalysisResults = _reflection.GeneratedProtocolMessageType('WorkPatternsAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _WORKPATTERNSANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:WorkPatternsAnalysisResults)
+  ))
+_sym_db.RegisterMessage(WorkPatternsAnalysisResults)
+
+KnowledgeMapVector = _reflection.GeneratedProtocolMessageType('KnowledgeMapVector', (_message.Message,), dict(
+  DESCRIPTOR = _KNOWLEDGEMAPVECTOR,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:KnowledgeMapVector)
+  ))
+_sym_db.RegisterMessage(KnowledgeMapVector)
+
+KnowledgeMapAnalysisResults = _reflection.GeneratedProtocolMessageType('KnowledgeMapAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _KNOWLEDGEMAPANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:KnowledgeMapAnalysisResults)
+  ))
+_sym_db.RegisterMessage(KnowledgeMapAnalysisResults)
+
+DeadCodeTick = _reflection.GeneratedProtocolMessageType('DeadCodeTick', (_message.Message,), dict(
+  DESCRIPTOR = _DEADCODETICK,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DeadCodeTick)
+  ))
+_sym_db.RegisterMessage(DeadCodeTick)
+
+DeadCodeOrphan = _reflection.GeneratedProtocolMessageType('DeadCodeOrphan', (_message.Message,), dict(
+  DESCRIPTOR = _DEADCODEORPHAN,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DeadCodeOrphan)
+  ))
+_sym_db.RegisterMessage(DeadCodeOrphan)
+
+DeadCodeAnalysisResults = _reflection.GeneratedProtocolMessageType('DeadCodeAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _DEADCODEANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:DeadCodeAnalysisResults)
+  ))
+_sym_db.RegisterMessage(DeadCodeAnalysisResults)
 
-Metadata = _reflection.GeneratedProtocolMessageType('Metadata', (_message.Message,), dict(
+CommitSentiment = _reflection.GeneratedProtocolMessageType('CommitSentiment', (_message.Message,), dict(
+  DESCRIPTOR = _COMMITSENTIMENT,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommitSentiment)
+  ))
+_sym_db.RegisterMessage(CommitSentiment)
 
-  RunTimePerItemEntry = _reflection.GeneratedProtocolMessageType('RunTimePerItemEntry', (_message.Message,), dict(
-    DESCRIPTOR = _METADATA_RUNTIMEPERITEMENTRY,
+CommitSentimentAnalysisResults = _reflection.GeneratedProtocolMessageType('CommitSentimentAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _COMMITSENTIMENTANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommitSentimentAnalysisResults)
+  ))
+_sym_db.RegisterMessage(CommitSentimentAnalysisResults)
+
+Hotspot = _reflection.GeneratedProtocolMessageType('Hotspot', (_message.Message,), dict(
+  DESCRIPTOR = _HOTSPOT,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:Hotspot)
+  ))
+_sym_db.RegisterMessage(Hotspot)
+
+HotspotsAnalysisResults = _reflection.GeneratedProtocolMessageType('HotspotsAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _HOTSPOTSANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:HotspotsAnalysisResults)
+  ))
+_sym_db.RegisterMessage(HotspotsAnalysisResults)
+
+TestRatioTick = _reflection.GeneratedProtocolMessageType('TestRatioTick', (_message.Message,), dict(
+  DESCRIPTOR = _TESTRATIOTICK,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TestRatioTick)
+  ))
+_sym_db.RegisterMessage(TestRatioTick)
+
+TestRatioAnalysisResults = _reflection.GeneratedProtocolMessageType('TestRatioAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _TESTRATIOANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TestRatioAnalysisResults)
+  ))
+_sym_db.RegisterMessage(TestRatioAnalysisResults)
+
+VocabularyTick = _reflection.GeneratedProtocolMessageType('VocabularyTick', (_message.Message,), dict(
+  DESCRIPTOR = _VOCABULARYTICK,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:VocabularyTick)
+  ))
+_sym_db.RegisterMessage(VocabularyTick)
+
+VocabularyTerms = _reflection.GeneratedProtocolMessageType('VocabularyTerms', (_message.Message,), dict(
+
+  TermsEntry = _reflection.GeneratedProtocolMessageType('TermsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _VOCABULARYTERMS_TERMSENTRY,
     __module__ = 'pb_pb2'
-    # @@protoc_insertion_point(class_scope:Metadata.RunTimePerItemEntry)
+    # @@protoc_insertion_point(class_scope:VocabularyTerms.TermsEntry)
     ))
   ,
-  DESCRIPTOR = _METADATA,
+  DESCRIPTOR = _VOCABULARYTERMS,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:Metadata)
+  # @@protoc_insertion_point(class_scope:VocabularyTerms)
   ))
-_sym_db.RegisterMessage(Metadata)
-_sym_db.RegisterMessage(Metadata.RunTimePerItemEntry)
+_sym_db.RegisterMessage(VocabularyTerms)
+_sym_db.RegisterMessage(VocabularyTerms.TermsEntry)
 
-BurndownSparseMatrixRow = _reflection.GeneratedProtocolMessageType('BurndownSparseMatrixRow', (_message.Message,), dict(
-  DESCRIPTOR = _BURNDOWNSPARSEMATRIXROW,
+VocabularyAnalysisResults = _reflection.GeneratedProtocolMessageType('VocabularyAnalysisResults', (_message.Message,), dict(
+
+  TermsEntry = _reflection.GeneratedProtocolMessageType('TermsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _VOCABULARYANALYSISRESULTS_TERMSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:VocabularyAnalysisResults.TermsEntry)
+    ))
+  ,
+  DESCRIPTOR = _VOCABULARYANALYSISRESULTS,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:BurndownSparseMatrixRow)
+  # @@protoc_insertion_point(class_scope:VocabularyAnalysisResults)
   ))
-_sym_db.RegisterMessage(BurndownSparseMatrixRow)
+_sym_db.RegisterMessage(VocabularyAnalysisResults)
+_sym_db.RegisterMessage(VocabularyAnalysisResults.TermsEntry)
 
-BurndownSparseMatrix = _reflection.GeneratedProtocolMessageType('BurndownSparseMatrix', (_message.Message,), dict(
-  DESCRIPTOR = _BURNDOWNSPARSEMATRIX,
+ImportGraphTick = _reflection.GeneratedProtocolMessageType('ImportGraphTick', (_message.Message,), dict(
+  DESCRIPTOR = _IMPORTGRAPHTICK,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:BurndownSparseMatrix)
+  # @@protoc_insertion_point(class_scope:ImportGraphTick)
   ))
-_sym_db.RegisterMessage(BurndownSparseMatrix)
+_sym_db.RegisterMessage(ImportGraphTick)
 
-BurndownAnalysisResults = _reflection.GeneratedProtocolMessageType('BurndownAnalysisResults', (_message.Message,), dict(
-  DESCRIPTOR = _BURNDOWNANALYSISRESULTS,
+ImportGraphEdge = _reflection.GeneratedProtocolMessageType('ImportGraphEdge', (_message.Message,), dict(
+  DESCRIPTOR = _IMPORTGRAPHEDGE,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:BurndownAnalysisResults)
+  # @@protoc_insertion_point(class_scope:ImportGraphEdge)
   ))
-_sym_db.RegisterMessage(BurndownAnalysisResults)
+_sym_db.RegisterMessage(ImportGraphEdge)
 
-CompressedSparseRowMatrix = _reflection.GeneratedProtocolMessageType('CompressedSparseRowMatrix', (_message.Message,), dict(
-  DESCRIPTOR = _COMPRESSEDSPARSEROWMATRIX,
+ImportGraphAnalysisResults = _reflection.GeneratedProtocolMessageType('ImportGraphAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _IMPORTGRAPHANALYSISRESULTS,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:CompressedSparseRowMatrix)
+  # @@protoc_insertion_point(class_scope:ImportGraphAnalysisResults)
   ))
-_sym_db.RegisterMessage(CompressedSparseRowMatrix)
+_sym_db.RegisterMessage(ImportGraphAnalysisResults)
 
-Couples = _reflection.GeneratedProtocolMessageType('Couples', (_message.Message,), dict(
-  DESCRIPTOR = _COUPLES,
+APISurfaceTick = _reflection.GeneratedProtocolMessageType('APISurfaceTick', (_message.Message,), dict(
+  DESCRIPTOR = _APISURFACETICK,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:Couples)
+  # @@protoc_insertion_point(class_scope:APISurfaceTick)
   ))
-_sym_db.RegisterMessage(Couples)
+_sym_db.RegisterMessage(APISurfaceTick)
 
-TouchedFiles = _reflection.GeneratedProtocolMessageType('TouchedFiles', (_message.Message,), dict(
-  DESCRIPTOR = _TOUCHEDFILES,
+APIBreakingChange = _reflection.GeneratedProtocolMessageType('APIBreakingChange', (_message.Message,), dict(
+  DESCRIPTOR = _APIBREAKINGCHANGE,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:TouchedFiles)
+  # @@protoc_insertion_point(class_scope:APIBreakingChange)
   ))
-_sym_db.RegisterMessage(TouchedFiles)
+_sym_db.RegisterMessage(APIBreakingChange)
 
-CouplesAnalysisResults = _reflection.GeneratedProtocolMessageType('CouplesAnalysisResults', (_message.Message,), dict(
-  DESCRIPTOR = _COUPLESANALYSISRESULTS,
+APISurfaceAnalysisResults = _reflection.GeneratedProtocolMessageType('APISurfaceAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _APISURFACEANALYSISRESULTS,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:CouplesAnalysisResults)
+  # @@protoc_insertion_point(class_scope:APISurfaceAnalysisResults)
   ))
-_sym_db.RegisterMessage(CouplesAnalysisResults)
+_sym_db.RegisterMessage(APISurfaceAnalysisResults)
 
-UASTChange = _reflection.GeneratedProtocolMessageType('UASTChange', (_message.Message,), dict(
-  DESCRIPTOR = _UASTCHANGE,
+ClonesTick = _reflection.GeneratedProtocolMessageType('ClonesTick', (_message.Message,), dict(
+  DESCRIPTOR = _CLONESTICK,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:UASTChange)
+  # @@protoc_insertion_point(class_scope:ClonesTick)
   ))
-_sym_db.RegisterMessage(UASTChange)
+_sym_db.RegisterMessage(ClonesTick)
 
-UASTChangesSaverResults = _reflection.GeneratedProtocolMessageType('UASTChangesSaverResults', (_message.Message,), dict(
-  DESCRIPTOR = _UASTCHANGESSAVERRESULTS,
+ClonesCommit = _reflection.GeneratedProtocolMessageType('ClonesCommit', (_message.Message,), dict(
+  DESCRIPTOR = _CLONESCOMMIT,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:UASTChangesSaverResults)
+  # @@protoc_insertion_point(class_scope:ClonesCommit)
   ))
-_sym_db.RegisterMessage(UASTChangesSaverResults)
+_sym_db.RegisterMessage(ClonesCommit)
 
-ShotnessRecord = _reflection.GeneratedProtocolMessageType('ShotnessRecord', (_message.Message,), dict(
+ClonesAnalysisResults = _reflection.GeneratedProtocolMessageType('ClonesAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _CLONESANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ClonesAnalysisResults)
+  ))
+_sym_db.RegisterMessage(ClonesAnalysisResults)
 
-  CountersEntry = _reflection.GeneratedProtocolMessageType('CountersEntry', (_message.Message,), dict(
-    DESCRIPTOR = _SHOTNESSRECORD_COUNTERSENTRY,
+TechDebtTick = _reflection.GeneratedProtocolMessageType('TechDebtTick', (_message.Message,), dict(
+  DESCRIPTOR = _TECHDEBTTICK,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TechDebtTick)
+  ))
+_sym_db.RegisterMessage(TechDebtTick)
+
+TechDebtMarker = _reflection.GeneratedProtocolMessageType('TechDebtMarker', (_message.Message,), dict(
+  DESCRIPTOR = _TECHDEBTMARKER,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:TechDebtMarker)
+  ))
+_sym_db.RegisterMessage(TechDebtMarker)
+
+TechDebtAnalysisResults = _reflection.GeneratedProtocolMessageType('TechDebtAnalysisResults', (_message.Message,), dict(
+
+  KindsEntry = _reflection.GeneratedProtocolMessageType('KindsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _TECHDEBTANALYSISRESULTS_KINDSENTRY,
     __module__ = 'pb_pb2'
-    # @@protoc_insertion_point(class_scope:ShotnessRecord.CountersEntry)
+    # @@protoc_insertion_point(class_scope:TechDebtAnalysisResults.KindsEntry)
     ))
   ,
-  DESCRIPTOR = _SHOTNESSRECORD,
+  DESCRIPTOR = _TECHDEBTANALYSISRESULTS,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:ShotnessRecord)
+  # @@protoc_insertion_point(class_scope:TechDebtAnalysisResults)
   ))
-_sym_db.RegisterMessage(ShotnessRecord)
-_sym_db.RegisterMessage(ShotnessRecord.CountersEntry)
+_sym_db.RegisterMessage(TechDebtAnalysisResults)
+_sym_db.RegisterMessage(TechDebtAnalysisResults.KindsEntry)
 
-ShotnessAnalysisResults = _reflection.GeneratedProtocolMessageType('ShotnessAnalysisResults', (_message.Message,), dict(
-  DESCRIPTOR = _SHOTNESSANALYSISRESULTS,
+CommentDensity = _reflection.GeneratedProtocolMessageType('CommentDensity', (_message.Message,), dict(
+  DESCRIPTOR = _COMMENTDENSITY,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:ShotnessAnalysisResults)
+  # @@protoc_insertion_point(class_scope:CommentDensity)
   ))
-_sym_db.RegisterMessage(ShotnessAnalysisResults)
+_sym_db.RegisterMessage(CommentDensity)
 
-FileHistory = _reflection.GeneratedProtocolMessageType('FileHistory', (_message.Message,), dict(
-  DESCRIPTOR = _FILEHISTORY,
+CommentDensitySeries = _reflection.GeneratedProtocolMessageType('CommentDensitySeries', (_message.Message,), dict(
+  DESCRIPTOR = _COMMENTDENSITYSERIES,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:FileHistory)
+  # @@protoc_insertion_point(class_scope:CommentDensitySeries)
   ))
-_sym_db.RegisterMessage(FileHistory)
+_sym_db.RegisterMessage(CommentDensitySeries)
 
-FileHistoryResultMessage = _reflection.GeneratedProtocolMessageType('FileHistoryResultMessage', (_message.Message,), dict(
+CommentDensityAnalysisResults = _reflection.GeneratedProtocolMessageType('CommentDensityAnalysisResults', (_message.Message,), dict(
+
+  DirectoriesEntry = _reflection.GeneratedProtocolMessageType('DirectoriesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _COMMENTDENSITYANALYSISRESULTS_DIRECTORIESENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:CommentDensityAnalysisResults.DirectoriesEntry)
+    ))
+  ,
+  DESCRIPTOR = _COMMENTDENSITYANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:CommentDensityAnalysisResults)
+  ))
+_sym_db.RegisterMessage(CommentDensityAnalysisResults)
+_sym_db.RegisterMessage(CommentDensityAnalysisResults.DirectoriesEntry)
+
+Complexity = _reflection.GeneratedProtocolMessageType('Complexity', (_message.Message,), dict(
+  DESCRIPTOR = _COMPLEXITY,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:Complexity)
+  ))
+_sym_db.RegisterMessage(Complexity)
+
+ComplexitySeries = _reflection.GeneratedProtocolMessageType('ComplexitySeries', (_message.Message,), dict(
+
+  TicksEntry = _reflection.GeneratedProtocolMessageType('TicksEntry', (_message.Message,), dict(
+    DESCRIPTOR = _COMPLEXITYSERIES_TICKSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:ComplexitySeries.TicksEntry)
+    ))
+  ,
+  DESCRIPTOR = _COMPLEXITYSERIES,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ComplexitySeries)
+  ))
+_sym_db.RegisterMessage(ComplexitySeries)
+_sym_db.RegisterMessage(ComplexitySeries.TicksEntry)
+
+ComplexityFunction = _reflection.GeneratedProtocolMessageType('ComplexityFunction', (_message.Message,), dict(
+
+  TicksEntry = _reflection.GeneratedProtocolMessageType('TicksEntry', (_message.Message,), dict(
+    DESCRIPTOR = _COMPLEXITYFUNCTION_TICKSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:ComplexityFunction.TicksEntry)
+    ))
+  ,
+  DESCRIPTOR = _COMPLEXITYFUNCTION,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ComplexityFunction)
+  ))
+_sym_db.RegisterMessage(ComplexityFunction)
+_sym_db.RegisterMessage(ComplexityFunction.TicksEntry)
+
+ComplexityCommit = _reflection.GeneratedProtocolMessageType('ComplexityCommit', (_message.Message,), dict(
+  DESCRIPTOR = _COMPLEXITYCOMMIT,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ComplexityCommit)
+  ))
+_sym_db.RegisterMessage(ComplexityCommit)
+
+ComplexityAnalysisResults = _reflection.GeneratedProtocolMessageType('ComplexityAnalysisResults', (_message.Message,), dict(
 
   FilesEntry = _reflection.GeneratedProtocolMessageType('FilesEntry', (_message.Message,), dict(
-    DESCRIPTOR = _FILEHISTORYRESULTMESSAGE_FILESENTRY,
+    DESCRIPTOR = _COMPLEXITYANALYSISRESULTS_FILESENTRY,
     __module__ = 'pb_pb2'
-    # @@protoc_insertion_point(class_scope:FileHistoryResultMessage.FilesEntry)
+    # @@protoc_insertion_point(class_scope:ComplexityAnalysisResults.FilesEntry)
     ))
   ,
-  DESCRIPTOR = _FILEHISTORYRESULTMESSAGE,
+  DESCRIPTOR = _COMPLEXITYANALYSISRESULTS,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:FileHistoryResultMessage)
+  # @@protoc_insertion_point(class_scope:ComplexityAnalysisResults)
   ))
-_sym_db.RegisterMessage(FileHistoryResultMessage)
-_sym_db.RegisterMessage(FileHistoryResultMessage.FilesEntry)
+_sym_db.RegisterMessage(ComplexityAnalysisResults)
+_sym_db.RegisterMessage(ComplexityAnalysisResults.FilesEntry)
 
-Sentiment = _reflection.GeneratedProtocolMessageType('Sentiment', (_message.Message,), dict(
-  DESCRIPTOR = _SENTIMENT,
+FunctionChurnDay = _reflection.GeneratedProtocolMessageType('FunctionChurnDay', (_message.Message,), dict(
+  DESCRIPTOR = _FUNCTIONCHURNDAY,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:Sentiment)
+  # @@protoc_insertion_point(class_scope:FunctionChurnDay)
   ))
-_sym_db.RegisterMessage(Sentiment)
+_sym_db.RegisterMessage(FunctionChurnDay)
 
-CommentSentimentResults = _reflection.GeneratedProtocolMessageType('CommentSentimentResults', (_message.Message,), dict(
+FunctionChurn = _reflection.GeneratedProtocolMessageType('FunctionChurn', (_message.Message,), dict(
 
-  SentimentByDayEntry = _reflection.GeneratedProtocolMessageType('SentimentByDayEntry', (_message.Message,), dict(
-    DESCRIPTOR = _COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY,
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _FUNCTIONCHURN_DAYSENTRY,
     __module__ = 'pb_pb2'
-    # @@protoc_insertion_point(class_scope:CommentSentimentResults.SentimentByDayEntry)
+    # @@protoc_insertion_point(class_scope:FunctionChurn.DaysEntry)
     ))
   ,
-  DESCRIPTOR = _COMMENTSENTIMENTRESULTS,
+  DESCRIPTOR = _FUNCTIONCHURN,
   __module__ = 'pb_pb2'
-  # @@protoc_insertion_point(class_scope:CommentSentimentResults)
+  # @@protoc_insertion_point(class_scope:FunctionChurn)
   ))
-_sym_db.RegisterMessage(CommentSentimentResults)
-_sym_db.RegisterMessage(CommentSentimentResults.SentimentByDayEntry)
+_sym_db.RegisterMessage(FunctionChurn)
+_sym_db.RegisterMessage(FunctionChurn.DaysEntry)
+
+FunctionChurnAnalysisResults = _reflection.GeneratedProtocolMessageType('FunctionChurnAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _FUNCTIONCHURNANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:FunctionChurnAnalysisResults)
+  ))
+_sym_db.RegisterMessage(FunctionChurnAnalysisResults)
+
+HistoryRewrite = _reflection.GeneratedProtocolMessageType('HistoryRewrite', (_message.Message,), dict(
+
+  AuthorsEntry = _reflection.GeneratedProtocolMessageType('AuthorsEntry', (_message.Message,), dict(
+    DESCRIPTOR = _HISTORYREWRITE_AUTHORSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:HistoryRewrite.AuthorsEntry)
+    ))
+  ,
+  DESCRIPTOR = _HISTORYREWRITE,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:HistoryRewrite)
+  ))
+_sym_db.RegisterMessage(HistoryRewrite)
+_sym_db.RegisterMessage(HistoryRewrite.AuthorsEntry)
+
+HistoryRewritesAnalysisResults = _reflection.GeneratedProtocolMessageType('HistoryRewritesAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _HISTORYREWRITESANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:HistoryRewritesAnalysisResults)
+  ))
+_sym_db.RegisterMessage(HistoryRewritesAnalysisResults)
+
+ChangeEntropyDay = _reflection.GeneratedProtocolMessageType('ChangeEntropyDay', (_message.Message,), dict(
+  DESCRIPTOR = _CHANGEENTROPYDAY,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ChangeEntropyDay)
+  ))
+_sym_db.RegisterMessage(ChangeEntropyDay)
+
+ChangeEntropyCommit = _reflection.GeneratedProtocolMessageType('ChangeEntropyCommit', (_message.Message,), dict(
+  DESCRIPTOR = _CHANGEENTROPYCOMMIT,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ChangeEntropyCommit)
+  ))
+_sym_db.RegisterMessage(ChangeEntropyCommit)
+
+ChangeEntropyAnalysisResults = _reflection.GeneratedProtocolMessageType('ChangeEntropyAnalysisResults', (_message.Message,), dict(
+
+  DaysEntry = _reflection.GeneratedProtocolMessageType('DaysEntry', (_message.Message,), dict(
+    DESCRIPTOR = _CHANGEENTROPYANALYSISRESULTS_DAYSENTRY,
+    __module__ = 'pb_pb2'
+    # @@protoc_insertion_point(class_scope:ChangeEntropyAnalysisResults.DaysEntry)
+    ))
+  ,
+  DESCRIPTOR = _CHANGEENTROPYANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ChangeEntropyAnalysisResults)
+  ))
+_sym_db.RegisterMessage(ChangeEntropyAnalysisResults)
+_sym_db.RegisterMessage(ChangeEntropyAnalysisResults.DaysEntry)
+
+ExpertiseVector = _reflection.GeneratedProtocolMessageType('ExpertiseVector', (_message.Message,), dict(
+  DESCRIPTOR = _EXPERTISEVECTOR,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ExpertiseVector)
+  ))
+_sym_db.RegisterMessage(ExpertiseVector)
+
+ExpertiseAnalysisResults = _reflection.GeneratedProtocolMessageType('ExpertiseAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _EXPERTISEANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:ExpertiseAnalysisResults)
+  ))
+_sym_db.RegisterMessage(ExpertiseAnalysisResults)
+
+OwnershipAnalysisResults = _reflection.GeneratedProtocolMessageType('OwnershipAnalysisResults', (_message.Message,), dict(
+  DESCRIPTOR = _OWNERSHIPANALYSISRESULTS,
+  __module__ = 'pb_pb2'
+  # @@protoc_insertion_point(class_scope:OwnershipAnalysisResults)
+  ))
+_sym_db.RegisterMessage(OwnershipAnalysisResults)
 
 AnalysisResults = _reflection.GeneratedProtocolMessageType('AnalysisResults', (_message.Message,), dict(
 
@@ -1172,12 +9962,86 @@ _sym_db.RegisterMessage(AnalysisResults.ContentsEntry)
 
 _METADATA_RUNTIMEPERITEMENTRY.has_options = True
 _METADATA_RUNTIMEPERITEMENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_METADATA_PROFILEPERITEMENTRY.has_options = True
+_METADATA_PROFILEPERITEMENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_METADATA_UNPARSEDFILESENTRY.has_options = True
+_METADATA_UNPARSEDFILESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_FILESNAPSHOT_AGESENTRY.has_options = True
+_FILESNAPSHOT_AGESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_FILESNAPSHOT_OWNERSENTRY.has_options = True
+_FILESNAPSHOT_OWNERSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
 _SHOTNESSRECORD_COUNTERSENTRY.has_options = True
 _SHOTNESSRECORD_COUNTERSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
 _FILEHISTORYRESULTMESSAGE_FILESENTRY.has_options = True
 _FILEHISTORYRESULTMESSAGE_FILESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
 _COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY.has_options = True
 _COMMENTSENTIMENTRESULTS_SENTIMENTBYDAYENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_ACTIVITYDAY_COMMITSENTRY.has_options = True
+_ACTIVITYDAY_COMMITSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_ACTIVITYANALYSISRESULTS_DAYSENTRY.has_options = True
+_ACTIVITYANALYSISRESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_ACTIVITYANALYSISRESULTS_ACTIVEENTRY.has_options = True
+_ACTIVITYANALYSISRESULTS_ACTIVEENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_LANGUAGECOUNTS_LANGUAGESENTRY.has_options = True
+_LANGUAGECOUNTS_LANGUAGESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_COMMITLANGUAGESANALYSISRESULTS_DAYSENTRY.has_options = True
+_COMMITLANGUAGESANALYSISRESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_IMPACTCHURNANALYSISRESULTS_DAYSENTRY.has_options = True
+_IMPACTCHURNANALYSISRESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_IMPACTCHURNANALYSISRESULTS_FILESENTRY.has_options = True
+_IMPACTCHURNANALYSISRESULTS_FILESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_RENAMEFREQUENCYANALYSISRESULTS_DIRECTORIESENTRY.has_options = True
+_RENAMEFREQUENCYANALYSISRESULTS_DIRECTORIESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_REWRITEDEPTHANALYSISRESULTS_FILESENTRY.has_options = True
+_REWRITEDEPTHANALYSISRESULTS_FILESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_STEWARDSHIPTICK_PEOPLEENTRY.has_options = True
+_STEWARDSHIPTICK_PEOPLEENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_TEAMALIGNMENTDIRECTORY_EDITSENTRY.has_options = True
+_TEAMALIGNMENTDIRECTORY_EDITSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_REVIEWLATENCYANALYSISRESULTS_PEOPLEENTRY.has_options = True
+_REVIEWLATENCYANALYSISRESULTS_PEOPLEENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_ISSUEREFERENCESANALYSISRESULTS_TICKETSENTRY.has_options = True
+_ISSUEREFERENCESANALYSISRESULTS_TICKETSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_CONVENTIONALCOMMITSSTATS_TYPESENTRY.has_options = True
+_CONVENTIONALCOMMITSSTATS_TYPESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_CONVENTIONALCOMMITSANALYSISRESULTS_PEOPLEENTRY.has_options = True
+_CONVENTIONALCOMMITSANALYSISRESULTS_PEOPLEENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_LANGUAGELINESANALYSISRESULTS_LANGUAGESENTRY.has_options = True
+_LANGUAGELINESANALYSISRESULTS_LANGUAGESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_FILEAGEANALYSISRESULTS_FILESENTRY.has_options = True
+_FILEAGEANALYSISRESULTS_FILESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_REFACTORINGANALYSISRESULTS_PEOPLEENTRY.has_options = True
+_REFACTORINGANALYSISRESULTS_PEOPLEENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_REVERTSANALYSISRESULTS_FILESENTRY.has_options = True
+_REVERTSANALYSISRESULTS_FILESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_GINITICK_COMMITSENTRY.has_options = True
+_GINITICK_COMMITSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_GINITICK_LINESENTRY.has_options = True
+_GINITICK_LINESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_ONBOARDINGDEVELOPER_DIRECTORIESENTRY.has_options = True
+_ONBOARDINGDEVELOPER_DIRECTORIESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_ONBOARDINGCOHORT_DIRECTORIESENTRY.has_options = True
+_ONBOARDINGCOHORT_DIRECTORIESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_VOCABULARYTERMS_TERMSENTRY.has_options = True
+_VOCABULARYTERMS_TERMSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_VOCABULARYANALYSISRESULTS_TERMSENTRY.has_options = True
+_VOCABULARYANALYSISRESULTS_TERMSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_TECHDEBTANALYSISRESULTS_KINDSENTRY.has_options = True
+_TECHDEBTANALYSISRESULTS_KINDSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_COMMENTDENSITYANALYSISRESULTS_DIRECTORIESENTRY.has_options = True
+_COMMENTDENSITYANALYSISRESULTS_DIRECTORIESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_COMPLEXITYSERIES_TICKSENTRY.has_options = True
+_COMPLEXITYSERIES_TICKSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_COMPLEXITYFUNCTION_TICKSENTRY.has_options = True
+_COMPLEXITYFUNCTION_TICKSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_COMPLEXITYANALYSISRESULTS_FILESENTRY.has_options = True
+_COMPLEXITYANALYSISRESULTS_FILESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_FUNCTIONCHURN_DAYSENTRY.has_options = True
+_FUNCTIONCHURN_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_HISTORYREWRITE_AUTHORSENTRY.has_options = True
+_HISTORYREWRITE_AUTHORSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
+_CHANGEENTROPYANALYSISRESULTS_DAYSENTRY.has_options = True
+_CHANGEENTROPYANALYSISRESULTS_DAYSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
 _ANALYSISRESULTS_CONTENTSENTRY.has_options = True
 _ANALYSISRESULTS_CONTENTSENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
 # @@protoc_insertion_point(module_scope)
@@ -1,9 +1,14 @@
 package pb
 
-import "sort"
+import (
+	"log"
+	"math"
+	"sort"
+)
 
 // ToBurndownSparseMatrix converts a rectangular integer matrix to the corresponding Protobuf object.
-// It is specific to hercules.BurndownAnalysis.
+// It is specific to hercules.BurndownAnalysis. The values which do not fit into uint32 are clamped
+// to math.MaxUint32.
 func ToBurndownSparseMatrix(matrix [][]int64, name string) *BurndownSparseMatrix {
 	if len(matrix) == 0 {
 		panic("matrix may not be nil or empty")
@@ -14,6 +19,7 @@ func ToBurndownSparseMatrix(matrix [][]int64, name string) *BurndownSparseMatrix
 		NumberOfColumns: int32(len(matrix[len(matrix)-1])),
 		Rows:            make([]*BurndownSparseMatrixRow, len(matrix)),
 	}
+	overflows := 0
 	for i, status := range matrix {
 		nnz := make([]uint32, 0, len(status))
 		changed := false
@@ -21,6 +27,9 @@ func ToBurndownSparseMatrix(matrix [][]int64, name string) *BurndownSparseMatrix
 			v := status[len(status)-1-j]
 			if v < 0 {
 				v = 0
+			} else if v > math.MaxUint32 {
+				v = math.MaxUint32
+				overflows++
 			}
 			if !changed {
 				changed = v != 0
@@ -36,6 +45,10 @@ func ToBurndownSparseMatrix(matrix [][]int64, name string) *BurndownSparseMatrix
 			r.Rows[i].Columns[j] = nnz[len(nnz)-1-j]
 		}
 	}
+	if overflows > 0 {
+		log.Printf("%s: clamped %d burndown values which exceed %d\n",
+			name, overflows, uint32(math.MaxUint32))
+	}
 	return &r
 }
 
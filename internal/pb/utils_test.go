@@ -0,0 +1,25 @@
+package pb
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToBurndownSparseMatrix(t *testing.T) {
+	matrix := ToBurndownSparseMatrix([][]int64{{1, 0, 0}, {2, 3, 0}, {-1, 4, 5}}, "test")
+	assert.Equal(t, matrix.Name, "test")
+	assert.Equal(t, matrix.NumberOfRows, int32(3))
+	assert.Equal(t, matrix.NumberOfColumns, int32(3))
+	assert.Equal(t, matrix.Rows[0].Columns, []uint32{1})
+	assert.Equal(t, matrix.Rows[1].Columns, []uint32{2, 3})
+	assert.Equal(t, matrix.Rows[2].Columns, []uint32{0, 4, 5})
+	assert.Panics(t, func() { ToBurndownSparseMatrix(nil, "test") })
+}
+
+func TestToBurndownSparseMatrixOverflow(t *testing.T) {
+	matrix := ToBurndownSparseMatrix(
+		[][]int64{{math.MaxUint32 + 1, math.MaxUint32, 7, 0}}, "test")
+	assert.Equal(t, matrix.Rows[0].Columns, []uint32{math.MaxUint32, math.MaxUint32, 7})
+}
@@ -1,7 +1,9 @@
 package plumbing
 
 import (
+	"io/ioutil"
 	"log"
+	"sort"
 
 	"gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/config"
@@ -22,15 +24,36 @@ type BlobCache struct {
 	// without the blob. If true, we look inside .gitmodules and if we don't find it,
 	// raise an error. If false, we do not look inside .gitmodules and always succeed.
 	FailOnMissingSubmodules bool
+	// PrefetchDepth is the number of the upcoming commits which blobs are loaded in the background
+	// while the current commit is being processed. 0 disables prefetching.
+	PrefetchDepth int
+	// MemoryLimit is the maximum total size in bytes of the blobs which are kept in memory
+	// between the commits. The biggest blobs above the limit are moved to the persistent cache.
+	// 0 disables the limit. It has no effect without the persistent cache.
+	MemoryLimit int
 
 	repository *git.Repository
 	cache      map[plumbing.Hash]*object.Blob
+	// spilled are the blobs of the previous commit which were moved to storage.
+	spilled    map[plumbing.Hash]bool
+	storage    core.Storage
+	workers    *core.WorkerPool
+	commits    []*object.Commit
+	prefetcher *blobPrefetcher
 }
 
 const (
 	// ConfigBlobCacheFailOnMissingSubmodules is the name of the configuration option for
 	// BlobCache.Configure() to check if the referenced submodules are registered in .gitignore.
 	ConfigBlobCacheFailOnMissingSubmodules = "BlobCache.FailOnMissingSubmodules"
+	// ConfigBlobCachePrefetchDepth is the name of the configuration option for
+	// BlobCache.Configure() to set the number of commits to load the blobs ahead.
+	ConfigBlobCachePrefetchDepth = "BlobCache.PrefetchDepth"
+	// DefaultBlobCachePrefetchDepth is the default number of commits to load the blobs ahead.
+	DefaultBlobCachePrefetchDepth = 1
+	// ConfigBlobCacheMemoryLimit is the name of the configuration option for
+	// BlobCache.Configure() to set the maximum size of the blobs kept in memory.
+	ConfigBlobCacheMemoryLimit = "BlobCache.MemoryLimit"
 	// DependencyBlobCache identifies the dependency provided by BlobCache.
 	DependencyBlobCache = "blob_cache"
 )
@@ -65,7 +88,20 @@ func (blobCache *BlobCache) ListConfigurationOptions() []core.ConfigurationOptio
 			"Override this if you want to ensure that your repository is integral. ",
 		Flag:    "fail-on-missing-submodules",
 		Type:    core.BoolConfigurationOption,
-		Default: false}}
+		Default: false}, {
+		Name: ConfigBlobCachePrefetchDepth,
+		Description: "Number of the upcoming commits which blobs are loaded in the background " +
+			"while the current commit is being analysed. 0 disables prefetching.",
+		Flag:    "blob-prefetch-depth",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultBlobCachePrefetchDepth}, {
+		Name: ConfigBlobCacheMemoryLimit,
+		Description: "Maximum total size in bytes of the blobs which are kept in memory " +
+			"between the commits. The rest goes to the persistent cache (--persistent-cache). " +
+			"0 disables the limit.",
+		Flag:    "blob-cache-memory-limit",
+		Type:    core.IntConfigurationOption,
+		Default: 0}}
 	return options[:]
 }
 
@@ -74,6 +110,21 @@ func (blobCache *BlobCache) Configure(facts map[string]interface{}) {
 	if val, exists := facts[ConfigBlobCacheFailOnMissingSubmodules].(bool); exists {
 		blobCache.FailOnMissingSubmodules = val
 	}
+	if val, exists := facts[ConfigBlobCachePrefetchDepth].(int); exists {
+		blobCache.PrefetchDepth = val
+	}
+	if val, exists := facts[ConfigBlobCacheMemoryLimit].(int); exists {
+		blobCache.MemoryLimit = val
+	}
+	if val, exists := facts[core.FactPersistentCache].(core.Storage); exists {
+		blobCache.storage = val
+	}
+	if val, exists := facts[core.FactWorkerPool].(*core.WorkerPool); exists {
+		blobCache.workers = val
+	}
+	if val, exists := facts[core.ConfigPipelineCommits].([]*object.Commit); exists {
+		blobCache.commits = val
+	}
 }
 
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
@@ -81,6 +132,12 @@ func (blobCache *BlobCache) Configure(facts map[string]interface{}) {
 func (blobCache *BlobCache) Initialize(repository *git.Repository) {
 	blobCache.repository = repository
 	blobCache.cache = map[plumbing.Hash]*object.Blob{}
+	blobCache.spilled = map[plumbing.Hash]bool{}
+	blobCache.prefetcher = nil
+	if blobCache.PrefetchDepth > 0 && len(blobCache.commits) > 0 {
+		blobCache.prefetcher = newBlobPrefetcher(
+			repository, blobCache.workers, blobCache.commits, blobCache.PrefetchDepth)
+	}
 }
 
 // Consume runs this PipelineItem on the next commit data.
@@ -94,6 +151,20 @@ func (blobCache *BlobCache) Consume(deps map[string]interface{}) (map[string]int
 	changes := deps[DependencyTreeChanges].(object.Changes)
 	cache := map[plumbing.Hash]*object.Blob{}
 	newCache := map[plumbing.Hash]*object.Blob{}
+	prefetched := blobCache.prefetcher.Take(commit.Hash)
+	blobCache.prefetcher.Schedule(commit.Hash)
+	loaded, err := blobCache.prefetch(changes, prefetched, commit.File)
+	if err != nil {
+		return nil, err
+	}
+	load := func(entry *object.ChangeEntry) (*object.Blob, error) {
+		result, exists := loaded[entry.TreeEntry.Hash]
+		if !exists {
+			// the spilled blob could not be restored
+			return blobCache.getBlob(entry, commit.File)
+		}
+		return result.Blob, result.Error
+	}
 	for _, change := range changes {
 		action, err := change.Action()
 		if err != nil {
@@ -104,7 +175,7 @@ func (blobCache *BlobCache) Consume(deps map[string]interface{}) (map[string]int
 		var blob *object.Blob
 		switch action {
 		case merkletrie.Insert:
-			blob, err = blobCache.getBlob(&change.To, commit.File)
+			blob, err = load(&change.To)
 			if err != nil {
 				log.Printf("file to %s %s\n",
 					change.To.Name, change.To.TreeEntry.Hash)
@@ -114,10 +185,10 @@ func (blobCache *BlobCache) Consume(deps map[string]interface{}) (map[string]int
 			}
 		case merkletrie.Delete:
 			cache[change.From.TreeEntry.Hash], exists =
-				blobCache.cache[change.From.TreeEntry.Hash]
+				blobCache.lookup(change.From.TreeEntry.Hash)
 			if !exists {
 				cache[change.From.TreeEntry.Hash], err =
-					blobCache.getBlob(&change.From, commit.File)
+					load(&change.From)
 				if err != nil {
 					if err.Error() != plumbing.ErrObjectNotFound.Error() {
 						log.Printf("file from %s %s\n", change.From.Name,
@@ -129,7 +200,7 @@ func (blobCache *BlobCache) Consume(deps map[string]interface{}) (map[string]int
 				}
 			}
 		case merkletrie.Modify:
-			blob, err = blobCache.getBlob(&change.To, commit.File)
+			blob, err = load(&change.To)
 			if err != nil {
 				log.Printf("file to %s\n", change.To.Name)
 			} else {
@@ -137,10 +208,10 @@ func (blobCache *BlobCache) Consume(deps map[string]interface{}) (map[string]int
 				newCache[change.To.TreeEntry.Hash] = blob
 			}
 			cache[change.From.TreeEntry.Hash], exists =
-				blobCache.cache[change.From.TreeEntry.Hash]
+				blobCache.lookup(change.From.TreeEntry.Hash)
 			if !exists {
 				cache[change.From.TreeEntry.Hash], err =
-					blobCache.getBlob(&change.From, commit.File)
+					load(&change.From)
 				if err != nil {
 					log.Printf("file from %s\n", change.From.Name)
 				}
@@ -151,9 +222,16 @@ func (blobCache *BlobCache) Consume(deps map[string]interface{}) (map[string]int
 		}
 	}
 	blobCache.cache = newCache
+	blobCache.spill()
 	return map[string]interface{}{DependencyBlobCache: cache}, nil
 }
 
+// ContentOptional returns false because BlobCache is the source of the file contents.
+// It is a part of ContentPipelineItem.
+func (blobCache *BlobCache) ContentOptional() bool {
+	return false
+}
+
 // Fork clones this PipelineItem.
 func (blobCache *BlobCache) Fork(n int) []core.PipelineItem {
 	caches := make([]core.PipelineItem, n)
@@ -162,15 +240,173 @@ func (blobCache *BlobCache) Fork(n int) []core.PipelineItem {
 		for k, v := range blobCache.cache {
 			cache[k] = v
 		}
+		spilled := map[plumbing.Hash]bool{}
+		for k := range blobCache.spilled {
+			spilled[k] = true
+		}
 		caches[i] = &BlobCache{
 			FailOnMissingSubmodules: blobCache.FailOnMissingSubmodules,
-			repository: blobCache.repository,
-			cache: cache,
+			PrefetchDepth:           blobCache.PrefetchDepth,
+			MemoryLimit:             blobCache.MemoryLimit,
+			repository:              blobCache.repository,
+			cache:                   cache,
+			spilled:                 spilled,
+			storage:                 blobCache.storage,
+			workers:                 blobCache.workers,
+			commits:                 blobCache.commits,
+			prefetcher:              blobCache.prefetcher,
 		}
 	}
 	return caches
 }
 
+// spilledBlobKey returns the key of the spilled blob in the persistent cache.
+func spilledBlobKey(hash plumbing.Hash) string {
+	return "BlobCache/" + hash.String()
+}
+
+// spill moves the biggest blobs of the previous commit to the persistent cache until
+// the rest fit into MemoryLimit. The blobs which fail to be stored stay in memory.
+func (blobCache *BlobCache) spill() {
+	blobCache.spilled = map[plumbing.Hash]bool{}
+	if blobCache.MemoryLimit <= 0 || blobCache.storage == nil {
+		return
+	}
+	var size int64
+	hashes := make([]plumbing.Hash, 0, len(blobCache.cache))
+	for hash, blob := range blobCache.cache {
+		size += blob.Size
+		hashes = append(hashes, hash)
+	}
+	if size <= int64(blobCache.MemoryLimit) {
+		return
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		si, sj := blobCache.cache[hashes[i]].Size, blobCache.cache[hashes[j]].Size
+		if si != sj {
+			return si > sj
+		}
+		return hashes[i].String() < hashes[j].String()
+	})
+	for _, hash := range hashes {
+		if size <= int64(blobCache.MemoryLimit) {
+			break
+		}
+		blob := blobCache.cache[hash]
+		if err := storeBlob(blobCache.storage, blob); err != nil {
+			log.Printf("failed to spill blob %s: %v", hash, err)
+			continue
+		}
+		size -= blob.Size
+		delete(blobCache.cache, hash)
+		blobCache.spilled[hash] = true
+	}
+}
+
+// lookup returns the blob of the previous commit either from memory or from the persistent cache.
+func (blobCache *BlobCache) lookup(hash plumbing.Hash) (*object.Blob, bool) {
+	if blob, exists := blobCache.cache[hash]; exists {
+		return blob, true
+	}
+	if !blobCache.spilled[hash] {
+		return nil, false
+	}
+	blob, err := loadBlob(blobCache.storage, hash)
+	if err != nil {
+		log.Printf("failed to restore spilled blob %s: %v", hash, err)
+		return nil, false
+	}
+	return blob, blob != nil
+}
+
+func storeBlob(storage core.Storage, blob *object.Blob) error {
+	reader, err := blob.Reader()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	return storage.Set(spilledBlobKey(blob.Hash), data)
+}
+
+// loadBlob reads the blob from storage. It returns nil if the blob does not exist there
+// or was corrupted.
+func loadBlob(storage core.Storage, hash plumbing.Hash) (*object.Blob, error) {
+	data, err := storage.Get(spilledBlobKey(hash))
+	if err != nil || data == nil {
+		return nil, err
+	}
+	obj := &plumbing.MemoryObject{}
+	obj.SetType(plumbing.BlobObject)
+	obj.Write(data)
+	if obj.Hash() != hash {
+		log.Printf("ignored the corrupted spilled blob %s", hash)
+		return nil, nil
+	}
+	return object.DecodeBlob(obj)
+}
+
+type blobLoadResult struct {
+	Blob  *object.Blob
+	Error error
+}
+
+// prefetch loads all the blobs which are going to be requested by Consume() at once.
+// The blobs which already exist in the cache from the previous commit or were loaded
+// in the background by blobPrefetcher are not loaded.
+func (blobCache *BlobCache) prefetch(changes object.Changes,
+	prefetched map[plumbing.Hash]*object.Blob, fileGetter FileGetter) (
+	map[plumbing.Hash]blobLoadResult, error) {
+	var entries []*object.ChangeEntry
+	loaded := map[plumbing.Hash]blobLoadResult{}
+	scheduled := map[plumbing.Hash]bool{}
+	schedule := func(entry *object.ChangeEntry, lookupCache bool) {
+		if scheduled[entry.TreeEntry.Hash] {
+			return
+		}
+		if blob, exists := prefetched[entry.TreeEntry.Hash]; exists {
+			scheduled[entry.TreeEntry.Hash] = true
+			loaded[entry.TreeEntry.Hash] = blobLoadResult{Blob: blob}
+			return
+		}
+		if lookupCache {
+			if _, exists := blobCache.cache[entry.TreeEntry.Hash]; exists {
+				return
+			}
+			if blobCache.spilled[entry.TreeEntry.Hash] {
+				return
+			}
+		}
+		scheduled[entry.TreeEntry.Hash] = true
+		entries = append(entries, entry)
+	}
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			log.Printf("no action in %s\n", change.To.TreeEntry.Hash)
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			schedule(&change.To, false)
+		case merkletrie.Delete:
+			schedule(&change.From, true)
+		case merkletrie.Modify:
+			schedule(&change.To, false)
+			schedule(&change.From, true)
+		}
+	}
+	// go-git's storers are not safe for concurrent reads, so the blobs are read one by one
+	for _, entry := range entries {
+		blob, err := blobCache.getBlob(entry, fileGetter)
+		loaded[entry.TreeEntry.Hash] = blobLoadResult{Blob: blob, Error: err}
+	}
+	return loaded, nil
+}
+
 // FileGetter defines a function which loads the Git file by
 // the specified path. The state can be arbitrary though here it always
 // corresponds to the currently processed commit.
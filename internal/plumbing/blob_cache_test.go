@@ -1,10 +1,12 @@
 package plumbing
 
 import (
+	"io/ioutil"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
 	"gopkg.in/src-d/hercules.v4/internal"
 	"gopkg.in/src-d/hercules.v4/internal/core"
@@ -28,6 +30,14 @@ func TestBlobCacheConfigureInitialize(t *testing.T) {
 	facts = map[string]interface{}{}
 	cache.Configure(facts)
 	assert.True(t, cache.FailOnMissingSubmodules)
+	assert.Nil(t, cache.prefetcher)
+	facts[ConfigBlobCachePrefetchDepth] = 2
+	facts[core.ConfigPipelineCommits] = []*object.Commit{{}}
+	cache.Configure(facts)
+	cache.Initialize(test.Repository)
+	assert.Equal(t, cache.PrefetchDepth, 2)
+	assert.NotNil(t, cache.prefetcher)
+	assert.Equal(t, cache.prefetcher.depth, 2)
 }
 
 func TestBlobCacheMetadata(t *testing.T) {
@@ -39,8 +49,10 @@ func TestBlobCacheMetadata(t *testing.T) {
 	changes := &TreeDiff{}
 	assert.Equal(t, cache.Requires()[0], changes.Provides()[0])
 	opts := cache.ListConfigurationOptions()
-	assert.Len(t, opts, 1)
+	assert.Len(t, opts, 3)
 	assert.Equal(t, opts[0].Name, ConfigBlobCacheFailOnMissingSubmodules)
+	assert.Equal(t, opts[1].Name, ConfigBlobCachePrefetchDepth)
+	assert.Equal(t, opts[2].Name, ConfigBlobCacheMemoryLimit)
 }
 
 func TestBlobCacheRegistration(t *testing.T) {
@@ -437,3 +449,77 @@ func TestBlobCacheFork(t *testing.T) {
 	// just for the sake of it
 	cache1.Merge([]core.PipelineItem{cache2})
 }
+
+func TestBlobCacheSpill(t *testing.T) {
+	storage, cleanup := fixturePersistentCache(t)
+	defer cleanup()
+	repository, commits, blobs := fixturePrefetchRepository(t)
+	cache := &BlobCache{}
+	cache.Configure(map[string]interface{}{
+		ConfigBlobCacheMemoryLimit: 1,
+		core.FactPersistentCache:   storage,
+	})
+	assert.Equal(t, cache.MemoryLimit, 1)
+	cache.Initialize(repository)
+	treeDiff := &TreeDiff{}
+	treeDiff.Initialize(repository)
+	consume := func(commit *object.Commit) map[plumbing.Hash]*object.Blob {
+		deps := map[string]interface{}{core.DependencyCommit: commit}
+		result, err := treeDiff.Consume(deps)
+		assert.Nil(t, err)
+		deps[DependencyTreeChanges] = result[DependencyTreeChanges]
+		result, err = cache.Consume(deps)
+		assert.Nil(t, err)
+		return result[DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	}
+	consume(commits[0])
+	assert.Len(t, cache.cache, 0)
+	assert.Equal(t, cache.spilled, map[plumbing.Hash]bool{blobs[0]: true})
+	assert.Equal(t, cacheValue(storage, spilledBlobKey(blobs[0])), []byte("one\n"))
+	forks := cache.Fork(1)
+	assert.Equal(t, forks[0].(*BlobCache).spilled, cache.spilled)
+	loaded := consume(commits[1])
+	assert.Len(t, loaded, 2)
+	reader, err := loaded[blobs[0]].Reader()
+	assert.Nil(t, err)
+	data, _ := ioutil.ReadAll(reader)
+	assert.Equal(t, string(data), "one\n")
+	assert.Equal(t, cache.spilled, map[plumbing.Hash]bool{blobs[1]: true})
+	// the corrupted blob is loaded from the repository
+	assert.Nil(t, storage.Set(spilledBlobKey(blobs[1]), []byte("xxx")))
+	blob, exists := cache.lookup(blobs[1])
+	assert.Nil(t, blob)
+	assert.False(t, exists)
+	forks = cache.Fork(1)
+	consume(commits[2])
+	fork := forks[0].(*BlobCache)
+	deps := map[string]interface{}{
+		core.DependencyCommit: commits[2],
+		DependencyTreeChanges: object.Changes{&object.Change{
+			From: object.ChangeEntry{Name: "a", TreeEntry: object.TreeEntry{
+				Name: "a", Mode: filemode.Regular, Hash: blobs[1]}}}},
+	}
+	result, err := fork.Consume(deps)
+	assert.Nil(t, err)
+	loaded = result[DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	assert.Equal(t, loaded[blobs[1]].Hash, blobs[1])
+	assert.Equal(t, loaded[blobs[1]].Size, int64(4))
+}
+
+func TestBlobCacheNoSpill(t *testing.T) {
+	repository, commits, _ := fixturePrefetchRepository(t)
+	cache := &BlobCache{}
+	cache.Configure(map[string]interface{}{ConfigBlobCacheMemoryLimit: 1})
+	cache.Initialize(repository)
+	treeDiff := &TreeDiff{}
+	treeDiff.Initialize(repository)
+	deps := map[string]interface{}{core.DependencyCommit: commits[0]}
+	result, err := treeDiff.Consume(deps)
+	assert.Nil(t, err)
+	deps[DependencyTreeChanges] = result[DependencyTreeChanges]
+	_, err = cache.Consume(deps)
+	assert.Nil(t, err)
+	// there is no persistent cache
+	assert.Len(t, cache.cache, 1)
+	assert.Len(t, cache.spilled, 0)
+}
@@ -0,0 +1,222 @@
+package plumbing
+
+import (
+	"errors"
+	"io/ioutil"
+	"log"
+	"sync"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/filesystem"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+// blobPrefetcher loads the blobs changed in the upcoming commits in the background while
+// the pipeline is busy with the current commit. It is shared by all the forks of BlobCache.
+// go-git's storers cannot be read from several goroutines at once, so the prefetcher never
+// touches the pipeline's storer: it reads a dedicated one and copies the blobs to memory
+// before handing them over to the pipeline.
+type blobPrefetcher struct {
+	// repository is opened over the same objects as the analysed repository but does not share
+	// the storer with it.
+	repository *git.Repository
+	// storerLock serialises the reads from `repository` between the workers.
+	storerLock sync.Locker
+	workers    *core.WorkerPool
+	commits    []*object.Commit
+	// index maps the commit hashes to their positions in `commits`
+	index map[plumbing.Hash]int
+	// depth is the number of the commits to look ahead
+	depth int
+
+	lock sync.Mutex
+	jobs map[plumbing.Hash]*prefetchJob
+}
+
+// prefetchJob is the background loading of the blobs of a single commit.
+type prefetchJob struct {
+	done  chan struct{}
+	blobs map[plumbing.Hash]*object.Blob
+}
+
+// noopLocker is the sync.Locker for the storers which are safe to read concurrently.
+type noopLocker struct{}
+
+func (noopLocker) Lock()   {}
+func (noopLocker) Unlock() {}
+
+// openDedicatedRepository returns the repository which reads the same objects as `repository`
+// without sharing any state with it, and the lock which must be held while reading it.
+// The in-memory storage is never written during the analysis and can be shared as is.
+// The on-disk storage is opened once again. Other storages are not supported.
+func openDedicatedRepository(repository *git.Repository) (*git.Repository, sync.Locker, error) {
+	switch storage := repository.Storer.(type) {
+	case *memory.Storage:
+		return repository, noopLocker{}, nil
+	case *filesystem.Storage:
+		root, isRooted := storage.Filesystem().(interface {
+			Root() string
+		})
+		if !isRooted {
+			return nil, nil, errors.New("the repository is not stored on disk")
+		}
+		dedicated, err := git.PlainOpen(root.Root())
+		if err != nil {
+			return nil, nil, err
+		}
+		return dedicated, &sync.Mutex{}, nil
+	}
+	return nil, nil, errors.New("the repository storage does not support concurrent reads")
+}
+
+// newBlobPrefetcher returns nil if the objects of the repository cannot be read
+// in the background.
+func newBlobPrefetcher(repository *git.Repository, workers *core.WorkerPool,
+	commits []*object.Commit, depth int) *blobPrefetcher {
+	dedicated, storerLock, err := openDedicatedRepository(repository)
+	if err != nil {
+		log.Printf("blob prefetching is disabled: %v\n", err)
+		return nil
+	}
+	index := map[plumbing.Hash]int{}
+	for i, commit := range commits {
+		if _, exists := index[commit.Hash]; !exists {
+			index[commit.Hash] = i
+		}
+	}
+	return &blobPrefetcher{
+		repository: dedicated, storerLock: storerLock, workers: workers,
+		commits: commits, index: index, depth: depth, jobs: map[plumbing.Hash]*prefetchJob{},
+	}
+}
+
+// Schedule starts loading the blobs of the `depth` commits which follow `hash`.
+// nil *blobPrefetcher does nothing.
+func (prefetcher *blobPrefetcher) Schedule(hash plumbing.Hash) {
+	if prefetcher == nil {
+		return
+	}
+	pos, exists := prefetcher.index[hash]
+	if !exists {
+		return
+	}
+	prefetcher.lock.Lock()
+	defer prefetcher.lock.Unlock()
+	for i := pos + 1; i <= pos+prefetcher.depth && i < len(prefetcher.commits); i++ {
+		commit := prefetcher.commits[i]
+		if _, exists := prefetcher.jobs[commit.Hash]; exists {
+			continue
+		}
+		job := &prefetchJob{done: make(chan struct{})}
+		prefetcher.jobs[commit.Hash] = job
+		go prefetcher.run(commit.Hash, job)
+	}
+}
+
+// Take waits for the blobs of the specified commit and forgets about them.
+// Returns nil if the commit was not scheduled. nil *blobPrefetcher always returns nil.
+func (prefetcher *blobPrefetcher) Take(hash plumbing.Hash) map[plumbing.Hash]*object.Blob {
+	if prefetcher == nil {
+		return nil
+	}
+	prefetcher.lock.Lock()
+	job, exists := prefetcher.jobs[hash]
+	delete(prefetcher.jobs, hash)
+	prefetcher.lock.Unlock()
+	if !exists {
+		return nil
+	}
+	<-job.done
+	return job.blobs
+}
+
+// run compares the tree of the commit with the tree of its first parent and loads the changed
+// blobs on the worker pool. The errors are ignored: BlobCache.Consume() loads the missing blobs
+// itself and reports.
+func (prefetcher *blobPrefetcher) run(hash plumbing.Hash, job *prefetchJob) {
+	defer close(job.done)
+	hashes := prefetcher.changedBlobs(hash)
+	blobs := make([]*object.Blob, len(hashes))
+	prefetcher.workers.ForEach(len(hashes), func(index int) {
+		blobs[index] = prefetcher.loadBlob(hashes[index])
+	})
+	job.blobs = map[plumbing.Hash]*object.Blob{}
+	for i, blob := range blobs {
+		if blob != nil {
+			job.blobs[hashes[i]] = blob
+		}
+	}
+}
+
+// changedBlobs lists the blobs which differ between the commit and its first parent.
+// The commit is read from the dedicated repository because the tree objects are loaded lazily
+// from the storer of the commit.
+func (prefetcher *blobPrefetcher) changedBlobs(hash plumbing.Hash) []plumbing.Hash {
+	prefetcher.storerLock.Lock()
+	defer prefetcher.storerLock.Unlock()
+	commit, err := prefetcher.repository.CommitObject(hash)
+	if err != nil {
+		return nil
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil
+	}
+	var parentTree *object.Tree
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return nil
+		}
+	}
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil
+	}
+	var hashes []plumbing.Hash
+	for _, change := range changes {
+		for _, entry := range [...]object.ChangeEntry{change.From, change.To} {
+			if entry.TreeEntry.Hash != plumbing.ZeroHash && entry.TreeEntry.Mode != 0160000 {
+				hashes = append(hashes, entry.TreeEntry.Hash)
+			}
+		}
+	}
+	return hashes
+}
+
+// loadBlob reads the blob from the dedicated repository into memory so that the pipeline
+// can read it without touching the dedicated storer. Returns nil on failure.
+func (prefetcher *blobPrefetcher) loadBlob(hash plumbing.Hash) *object.Blob {
+	prefetcher.storerLock.Lock()
+	defer prefetcher.storerLock.Unlock()
+	blob, err := prefetcher.repository.BlobObject(hash)
+	if err != nil {
+		return nil
+	}
+	if _, isShared := prefetcher.storerLock.(noopLocker); isShared {
+		// the in-memory objects are read without the storer
+		return blob
+	}
+	reader, err := blob.Reader()
+	if err != nil {
+		return nil
+	}
+	defer reader.Close()
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil
+	}
+	obj := &plumbing.MemoryObject{}
+	obj.SetType(plumbing.BlobObject)
+	obj.Write(data)
+	if blob, err = object.DecodeBlob(obj); err != nil {
+		return nil
+	}
+	return blob
+}
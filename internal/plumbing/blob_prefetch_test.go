@@ -0,0 +1,170 @@
+package plumbing
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+func storePrefetchObject(t *testing.T, storage storer.EncodedObjectStorer,
+	encoder interface {
+		Encode(plumbing.EncodedObject) error
+	}) plumbing.Hash {
+	obj := storage.NewEncodedObject()
+	assert.Nil(t, encoder.Encode(obj))
+	hash, err := storage.SetEncodedObject(obj)
+	assert.Nil(t, err)
+	return hash
+}
+
+func storePrefetchBlob(t *testing.T, storage storer.EncodedObjectStorer, data string) plumbing.Hash {
+	obj := storage.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+	writer, _ := obj.Writer()
+	writer.Write([]byte(data))
+	writer.Close()
+	hash, err := storage.SetEncodedObject(obj)
+	assert.Nil(t, err)
+	return hash
+}
+
+// fixturePrefetchRepository creates three commits: the first adds "a", the second modifies it
+// and the third adds "b".
+func fixturePrefetchRepository(t *testing.T) (
+	*git.Repository, []*object.Commit, []plumbing.Hash) {
+	storage := memory.NewStorage()
+	repository, err := git.Init(storage, nil)
+	assert.Nil(t, err)
+	blobs := []plumbing.Hash{
+		storePrefetchBlob(t, storage, "one\n"),
+		storePrefetchBlob(t, storage, "two\n"),
+		storePrefetchBlob(t, storage, "three\n"),
+	}
+	trees := [][]object.TreeEntry{
+		{{Name: "a", Mode: filemode.Regular, Hash: blobs[0]}},
+		{{Name: "a", Mode: filemode.Regular, Hash: blobs[1]}},
+		{{Name: "a", Mode: filemode.Regular, Hash: blobs[1]},
+			{Name: "b", Mode: filemode.Regular, Hash: blobs[2]}},
+	}
+	var commits []*object.Commit
+	var parents []plumbing.Hash
+	for i, entries := range trees {
+		treeHash := storePrefetchObject(t, storage, &object.Tree{Entries: entries})
+		signature := object.Signature{Name: "test", Email: "test@test.com",
+			When: time.Unix(int64(i), 0)}
+		hash := storePrefetchObject(t, storage, &object.Commit{
+			Author: signature, Committer: signature, TreeHash: treeHash, ParentHashes: parents})
+		commit, err := repository.CommitObject(hash)
+		assert.Nil(t, err)
+		commits = append(commits, commit)
+		parents = []plumbing.Hash{hash}
+	}
+	return repository, commits, blobs
+}
+
+func TestBlobPrefetcher(t *testing.T) {
+	repository, commits, blobs := fixturePrefetchRepository(t)
+	prefetcher := newBlobPrefetcher(repository, core.NewWorkerPool(2), commits, 2)
+	assert.Nil(t, prefetcher.Take(commits[0].Hash))
+	prefetcher.Schedule(commits[0].Hash)
+	assert.Len(t, prefetcher.jobs, 2)
+	loaded := prefetcher.Take(commits[1].Hash)
+	assert.Len(t, loaded, 2)
+	assert.Equal(t, loaded[blobs[0]].Hash, blobs[0])
+	assert.Equal(t, loaded[blobs[1]].Hash, blobs[1])
+	assert.Nil(t, prefetcher.Take(commits[1].Hash))
+	prefetcher.Schedule(commits[1].Hash)
+	assert.Len(t, prefetcher.jobs, 1)
+	loaded = prefetcher.Take(commits[2].Hash)
+	assert.Len(t, loaded, 1)
+	assert.Equal(t, loaded[blobs[2]].Hash, blobs[2])
+	prefetcher.Schedule(commits[2].Hash)
+	prefetcher.Schedule(plumbing.NewHash("ffffffffffffffffffffffffffffffffffffffff"))
+	assert.Len(t, prefetcher.jobs, 0)
+	var nilPrefetcher *blobPrefetcher
+	nilPrefetcher.Schedule(commits[0].Hash)
+	assert.Nil(t, nilPrefetcher.Take(commits[1].Hash))
+}
+
+func TestBlobPrefetcherFilesystem(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpdir)
+	origin, err := git.PlainInit(tmpdir, false)
+	assert.Nil(t, err)
+	worktree, err := origin.Worktree()
+	assert.Nil(t, err)
+	var commits []*object.Commit
+	for _, content := range []string{"one\n", "two\n"} {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpdir, "a"), []byte(content), 0666))
+		_, err := worktree.Add("a")
+		assert.Nil(t, err)
+		hash, err := worktree.Commit(content, &git.CommitOptions{Author: &object.Signature{
+			Name: "test", Email: "test@test.com", When: time.Now()}})
+		assert.Nil(t, err)
+		commit, err := origin.CommitObject(hash)
+		assert.Nil(t, err)
+		commits = append(commits, commit)
+	}
+	repository, err := git.PlainOpen(tmpdir)
+	assert.Nil(t, err)
+	prefetcher := newBlobPrefetcher(repository, core.NewWorkerPool(2), commits, 1)
+	assert.NotNil(t, prefetcher)
+	assert.True(t, prefetcher.repository != repository)
+	prefetcher.Schedule(commits[0].Hash)
+	loaded := prefetcher.Take(commits[1].Hash)
+	assert.Len(t, loaded, 2)
+	for hash, blob := range loaded {
+		assert.Equal(t, blob.Hash, hash)
+		contents, err := BlobToString(blob)
+		assert.Nil(t, err)
+		assert.Contains(t, []string{"one\n", "two\n"}, contents)
+	}
+}
+
+func TestBlobPrefetcherUnsupportedStorage(t *testing.T) {
+	repository, commits, _ := fixturePrefetchRepository(t)
+	prefetcher := newBlobPrefetcher(repository, nil, commits, 1)
+	assert.NotNil(t, prefetcher)
+	assert.True(t, prefetcher.repository == repository)
+	wrapped, err := git.Init(struct{ *memory.Storage }{memory.NewStorage()}, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, newBlobPrefetcher(wrapped, nil, commits, 1))
+}
+
+func TestBlobCacheConsumePrefetched(t *testing.T) {
+	repository, commits, blobs := fixturePrefetchRepository(t)
+	cache := &BlobCache{}
+	cache.Configure(map[string]interface{}{
+		ConfigBlobCachePrefetchDepth: 1,
+		core.ConfigPipelineCommits:   commits,
+	})
+	cache.Initialize(repository)
+	treeDiff := &TreeDiff{}
+	treeDiff.Initialize(repository)
+	for i, commit := range commits {
+		deps := map[string]interface{}{core.DependencyCommit: commit}
+		result, err := treeDiff.Consume(deps)
+		assert.Nil(t, err)
+		deps[DependencyTreeChanges] = result[DependencyTreeChanges]
+		result, err = cache.Consume(deps)
+		assert.Nil(t, err)
+		loaded := result[DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+		assert.Contains(t, loaded, blobs[i])
+		if i < len(commits)-1 {
+			assert.Contains(t, cache.prefetcher.jobs, commits[i+1].Hash)
+		}
+	}
+	assert.Len(t, cache.prefetcher.jobs, 0)
+}
@@ -0,0 +1,96 @@
+package plumbing
+
+import (
+	"bytes"
+	"encoding/gob"
+	"log"
+
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+// cachedChangeEntry is the serializable part of object.ChangeEntry.
+// Empty Name means that the entry does not exist, e.g. the "From" side of an insertion.
+type cachedChangeEntry struct {
+	Name      string
+	EntryName string
+	Mode      uint32
+	Hash      plumbing.Hash
+}
+
+// cachedChange is the serializable part of object.Change.
+type cachedChange struct {
+	From cachedChangeEntry
+	To   cachedChangeEntry
+}
+
+func newCachedChangeEntry(entry *object.ChangeEntry) cachedChangeEntry {
+	if entry.Name == "" {
+		return cachedChangeEntry{}
+	}
+	return cachedChangeEntry{
+		Name: entry.Name, EntryName: entry.TreeEntry.Name, Mode: uint32(entry.TreeEntry.Mode),
+		Hash: entry.TreeEntry.Hash,
+	}
+}
+
+// restore converts the entry back to object.ChangeEntry which belongs to the specified tree.
+// Unlike object.DiffTree(), ChangeEntry.Tree is the root tree instead of the parent directory,
+// which does not matter for the pipeline: it only checks whether the tree is nil.
+func (entry cachedChangeEntry) restore(tree *object.Tree) object.ChangeEntry {
+	if entry.Name == "" {
+		return object.ChangeEntry{}
+	}
+	return object.ChangeEntry{Name: entry.Name, Tree: tree, TreeEntry: object.TreeEntry{
+		Name: entry.EntryName, Mode: filemode.FileMode(entry.Mode), Hash: entry.Hash}}
+}
+
+// storeChanges serializes the changes and writes them to the persistent cache.
+// The errors are logged and otherwise ignored, the cache is an optimization.
+func storeChanges(cache core.Storage, key string, changes object.Changes) {
+	if cache == nil {
+		return
+	}
+	cached := make([]cachedChange, len(changes))
+	for i, change := range changes {
+		cached[i] = cachedChange{
+			From: newCachedChangeEntry(&change.From), To: newCachedChangeEntry(&change.To)}
+	}
+	buffer := &bytes.Buffer{}
+	err := gob.NewEncoder(buffer).Encode(cached)
+	if err == nil {
+		err = cache.Set(key, buffer.Bytes())
+	}
+	if err != nil {
+		log.Printf("failed to cache %s: %v", key, err)
+	}
+}
+
+// loadChanges reads the changes from the persistent cache. `restore` converts each side
+// of each change to object.ChangeEntry. Returns nil if there is nothing cached.
+func loadChanges(cache core.Storage, key string,
+	restore func(entry cachedChangeEntry, to bool) object.ChangeEntry) object.Changes {
+	if cache == nil {
+		return nil
+	}
+	data, err := cache.Get(key)
+	if err != nil {
+		log.Printf("failed to read the cache of %s: %v", key, err)
+		return nil
+	}
+	if data == nil {
+		return nil
+	}
+	var cached []cachedChange
+	if err = gob.NewDecoder(bytes.NewReader(data)).Decode(&cached); err != nil {
+		log.Printf("ignored the corrupted cache of %s: %v", key, err)
+		return nil
+	}
+	changes := make(object.Changes, len(cached))
+	for i, change := range cached {
+		changes[i] = &object.Change{From: restore(change.From, false), To: restore(change.To, true)}
+	}
+	return changes
+}
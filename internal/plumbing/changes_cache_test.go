@@ -0,0 +1,102 @@
+package plumbing
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+func fixturePersistentCache(t *testing.T) (core.Storage, func()) {
+	tmpdir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	cache, err := core.NewDiskCache(tmpdir)
+	assert.Nil(t, err)
+	return cache, func() { os.RemoveAll(tmpdir) }
+}
+
+func cacheValue(cache core.Storage, key string) []byte {
+	value, _ := cache.Get(key)
+	return value
+}
+
+func TestTreeDiffPersistentCache(t *testing.T) {
+	cache, cleanup := fixturePersistentCache(t)
+	defer cleanup()
+	repository, commits, _ := fixturePrefetchRepository(t)
+	consume := func() []object.Changes {
+		treeDiff := &TreeDiff{}
+		treeDiff.Configure(map[string]interface{}{core.FactPersistentCache: cache})
+		treeDiff.Initialize(repository)
+		var result []object.Changes
+		for _, commit := range commits {
+			changes, err := treeDiff.Consume(map[string]interface{}{core.DependencyCommit: commit})
+			assert.Nil(t, err)
+			result = append(result, changes[DependencyTreeChanges].(object.Changes))
+		}
+		return result
+	}
+	computed := consume()
+	key := "TreeDiff/" + commits[1].Hash.String() + "/" + commits[2].Hash.String()
+	assert.NotNil(t, cacheValue(cache, key))
+	cached := consume()
+	assert.Len(t, cached, len(computed))
+	for i := range computed {
+		assert.Len(t, cached[i], len(computed[i]))
+		for j, change := range computed[i] {
+			assert.Equal(t, cached[i][j].From.Name, change.From.Name)
+			assert.Equal(t, cached[i][j].From.TreeEntry, change.From.TreeEntry)
+			assert.Equal(t, cached[i][j].To.Name, change.To.Name)
+			assert.Equal(t, cached[i][j].To.TreeEntry, change.To.TreeEntry)
+			action1, _ := change.Action()
+			action2, _ := cached[i][j].Action()
+			assert.Equal(t, action1, action2)
+		}
+	}
+	assert.Nil(t, cache.Set(key, []byte("garbage")))
+	assert.Len(t, consume()[2], 1)
+}
+
+func TestRenameAnalysisPersistentCache(t *testing.T) {
+	cache, cleanup := fixturePersistentCache(t)
+	defer cleanup()
+	repository, commits, blobs := fixturePrefetchRepository(t)
+	tree, err := commits[0].Tree()
+	assert.Nil(t, err)
+	entry := func(name string, hash plumbing.Hash) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, Tree: tree, TreeEntry: object.TreeEntry{
+			Name: name, Mode: filemode.Regular, Hash: hash}}
+	}
+	changes := object.Changes{
+		{From: entry("a", blobs[0])},
+		{To: entry("c", blobs[0])},
+		{From: entry("b", blobs[1]), To: entry("b", blobs[2])},
+	}
+	blobCache := map[plumbing.Hash]*object.Blob{}
+	for _, hash := range blobs {
+		blobCache[hash], err = repository.BlobObject(hash)
+		assert.Nil(t, err)
+	}
+	deps := map[string]interface{}{
+		DependencyTreeChanges: changes, DependencyBlobCache: blobCache}
+	ra := &RenameAnalysis{SimilarityThreshold: 80}
+	ra.Configure(map[string]interface{}{core.FactPersistentCache: cache})
+	ra.Initialize(repository)
+	result, err := ra.Consume(deps)
+	assert.Nil(t, err)
+	computed := result[DependencyTreeChanges].(object.Changes)
+	assert.Len(t, computed, 2)
+	assert.NotNil(t, cacheValue(cache, ra.cacheKey(changes)))
+	// the blobs are not needed anymore
+	deps[DependencyBlobCache] = map[plumbing.Hash]*object.Blob{}
+	result, err = ra.Consume(deps)
+	assert.Nil(t, err)
+	assert.Equal(t, result[DependencyTreeChanges], computed)
+	ra.SimilarityThreshold = 90
+	assert.Nil(t, cacheValue(cache, ra.cacheKey(changes)))
+}
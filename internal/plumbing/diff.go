@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"sync"
 	"unicode/utf8"
 
 	"github.com/sergi/go-diff/diffmatchpatch"
@@ -20,6 +21,12 @@ import (
 type FileDiff struct {
 	core.NoopMerger
 	CleanupDisabled bool
+
+	workers *core.WorkerPool
+	// blobLock serialises reading the blobs in the workers: the blobs which were not prefetched
+	// are read lazily from the repository's storer, which is not safe for concurrent use.
+	// Only the diff calculation runs in parallel.
+	blobLock sync.Mutex
 }
 
 const (
@@ -77,6 +84,9 @@ func (diff *FileDiff) Configure(facts map[string]interface{}) {
 	if val, exists := facts[ConfigFileDiffDisableCleanup].(bool); exists {
 		diff.CleanupDisabled = val
 	}
+	if val, exists := facts[core.FactWorkerPool].(*core.WorkerPool); exists {
+		diff.workers = val
+	}
 }
 
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
@@ -92,43 +102,62 @@ func (diff *FileDiff) Consume(deps map[string]interface{}) (map[string]interface
 	result := map[string]FileDiffData{}
 	cache := deps[DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
 	treeDiff := deps[DependencyTreeChanges].(object.Changes)
+	var modified []*object.Change
 	for _, change := range treeDiff {
 		action, err := change.Action()
 		if err != nil {
 			return nil, err
 		}
-		switch action {
-		case merkletrie.Modify:
-			blobFrom := cache[change.From.TreeEntry.Hash]
-			blobTo := cache[change.To.TreeEntry.Hash]
-			// we are not validating UTF-8 here because for example
-			// git/git 4f7770c87ce3c302e1639a7737a6d2531fe4b160 fetch-pack.c is invalid UTF-8
-			strFrom, err := BlobToString(blobFrom)
-			if err != nil {
-				return nil, err
-			}
-			strTo, err := BlobToString(blobTo)
-			if err != nil {
-				return nil, err
-			}
-			dmp := diffmatchpatch.New()
-			src, dst, _ := dmp.DiffLinesToRunes(strFrom, strTo)
-			diffs := dmp.DiffMainRunes(src, dst, false)
-			if !diff.CleanupDisabled {
-				diffs = dmp.DiffCleanupMerge(dmp.DiffCleanupSemanticLossless(diffs))
-			}
-			result[change.To.Name] = FileDiffData{
-				OldLinesOfCode: len(src),
-				NewLinesOfCode: len(dst),
-				Diffs:          diffs,
-			}
-		default:
-			continue
+		if action == merkletrie.Modify {
+			modified = append(modified, change)
+		}
+	}
+	diffs := make([]FileDiffData, len(modified))
+	errs := make([]error, len(modified))
+	diff.workers.ForEach(len(modified), func(index int) {
+		diffs[index], errs[index] = diff.calculate(modified[index], cache)
+	})
+	for i, change := range modified {
+		if errs[i] != nil {
+			return nil, errs[i]
 		}
+		result[change.To.Name] = diffs[i]
 	}
 	return map[string]interface{}{DependencyFileDiff: result}, nil
 }
 
+// calculate finds the line-wise difference between the old and the new versions
+// of a modified file.
+func (diff *FileDiff) calculate(
+	change *object.Change, cache map[plumbing.Hash]*object.Blob) (FileDiffData, error) {
+	blobFrom := cache[change.From.TreeEntry.Hash]
+	blobTo := cache[change.To.TreeEntry.Hash]
+	// we are not validating UTF-8 here because for example
+	// git/git 4f7770c87ce3c302e1639a7737a6d2531fe4b160 fetch-pack.c is invalid UTF-8
+	diff.blobLock.Lock()
+	strFrom, err := BlobToString(blobFrom)
+	if err != nil {
+		diff.blobLock.Unlock()
+		return FileDiffData{}, err
+	}
+	strTo, err := BlobToString(blobTo)
+	diff.blobLock.Unlock()
+	if err != nil {
+		return FileDiffData{}, err
+	}
+	dmp := diffmatchpatch.New()
+	src, dst, _ := dmp.DiffLinesToRunes(strFrom, strTo)
+	diffs := dmp.DiffMainRunes(src, dst, false)
+	if !diff.CleanupDisabled {
+		diffs = dmp.DiffCleanupMerge(dmp.DiffCleanupSemanticLossless(diffs))
+	}
+	return FileDiffData{
+		OldLinesOfCode: len(src),
+		NewLinesOfCode: len(dst),
+		Diffs:          diffs,
+	}, nil
+}
+
 // Fork clones this PipelineItem.
 func (diff *FileDiff) Fork(n int) []core.PipelineItem {
 	return core.ForkSamePipelineItem(diff, n)
@@ -0,0 +1,398 @@
+package plumbing
+
+import (
+	"log"
+	"path"
+	"regexp"
+	"strings"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+// FileFanIn measures the importance of the changed files. The fan-in of a file is either
+// the number of distinct files which were ever changed in the same commit with it
+// ("coupling" mode) or the number of files which import it at the moment ("imports" mode).
+// The imports are extracted with regular expressions from Go, Python, JavaScript/TypeScript
+// and C/C++ sources and are resolved by the path suffixes, so the result is an estimation.
+// FileFanIn is a PipelineItem. The forks share the same state.
+type FileFanIn struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Mode is either FanInModeCoupling or FanInModeImports.
+	Mode string
+
+	// neighbours maps each file to the set of files which were changed together with it.
+	neighbours map[string]map[string]bool
+	// imports maps each file to the keys which it imports.
+	imports map[string][]string
+	// importers maps each import key to the set of files which import it.
+	importers map[string]map[string]bool
+}
+
+const (
+	// FanInModeCoupling counts the files which were changed in the same commits.
+	FanInModeCoupling = "coupling"
+	// FanInModeImports counts the files which import the file.
+	FanInModeImports = "imports"
+
+	// ConfigFileFanInMode is the name of the configuration option (FileFanIn.Configure())
+	// which selects the way to calculate the fan-in.
+	ConfigFileFanInMode = "FileFanIn.Mode"
+
+	// DependencyFileFanIn is the name of the dependency provided by FileFanIn.
+	// It maps the names of the changed files to their fan-ins. The deleted files are named
+	// as before the deletion.
+	DependencyFileFanIn = "file_fan_in"
+)
+
+var (
+	goImportBlockRegexp = regexp.MustCompile(`(?s)\bimport\s*\((.*?)\)`)
+	goImportRegexp      = regexp.MustCompile(`\bimport\s+(?:[\w.]+\s+)?"([^"]+)"`)
+	quotedRegexp        = regexp.MustCompile(`"([^"]+)"`)
+	pyFromRegexp        = regexp.MustCompile(`(?m)^\s*from\s+([\w.]+)\s+import\s+\(?([\w., ]+)`)
+	pyImportRegexp      = regexp.MustCompile(`(?m)^\s*import\s+([\w., ]+)`)
+	jsImportRegexp      = regexp.MustCompile(
+		`(?:\bfrom|\bimport|\brequire\s*\()\s*['"](\.{1,2}/[^'"]+)['"]`)
+	cIncludeRegexp = regexp.MustCompile(`(?m)^\s*#\s*include\s*"([^"]+)"`)
+)
+
+// fanInLanguages maps the file extensions to the import key prefixes.
+var fanInLanguages = map[string]string{
+	".go": "go:", ".py": "py:",
+	".js": "js:", ".jsx": "js:", ".mjs": "js:", ".ts": "js:", ".tsx": "js:",
+	".c": "c:", ".h": "c:", ".cc": "c:", ".cpp": "c:", ".cxx": "c:", ".hh": "c:", ".hpp": "c:",
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (fanIn *FileFanIn) Name() string {
+	return "FileFanIn"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (fanIn *FileFanIn) Provides() []string {
+	arr := [...]string{DependencyFileFanIn}
+	return arr[:]
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (fanIn *FileFanIn) Requires() []string {
+	arr := [...]string{DependencyTreeChanges, DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (fanIn *FileFanIn) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigFileFanInMode,
+		Description: "How to measure the importance of a file: \"coupling\" - the number of " +
+			"files changed together with it, \"imports\" - the number of files which import it.",
+		Flag:    "fan-in",
+		Type:    core.StringConfigurationOption,
+		Default: FanInModeCoupling},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (fanIn *FileFanIn) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigFileFanInMode].(string); exists {
+		fanIn.Mode = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (fanIn *FileFanIn) Initialize(repository *git.Repository) {
+	if fanIn.Mode != FanInModeCoupling && fanIn.Mode != FanInModeImports {
+		if fanIn.Mode != "" {
+			log.Printf("Warning: unknown fan-in mode \"%s\", using \"%s\"\n",
+				fanIn.Mode, FanInModeCoupling)
+		}
+		fanIn.Mode = FanInModeCoupling
+	}
+	fanIn.neighbours = map[string]map[string]bool{}
+	fanIn.imports = map[string][]string{}
+	fanIn.importers = map[string]map[string]bool{}
+	fanIn.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (fanIn *FileFanIn) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	treeDiff := deps[DependencyTreeChanges].(object.Changes)
+	cache := deps[DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	result := map[string]int{}
+	// the deleted files are measured before they disappear
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		if action == merkletrie.Delete {
+			result[change.From.Name] = fanIn.calculate(change.From.Name)
+		}
+	}
+	if fanIn.ShouldConsumeCommit(deps) {
+		var err error
+		if fanIn.Mode == FanInModeImports {
+			err = fanIn.updateImports(treeDiff, cache)
+		} else if !deps[core.DependencyIsMerge].(bool) {
+			err = fanIn.updateNeighbours(treeDiff)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, change := range treeDiff {
+		if change.To.Name != "" {
+			result[change.To.Name] = fanIn.calculate(change.To.Name)
+		}
+	}
+	return map[string]interface{}{DependencyFileFanIn: result}, nil
+}
+
+// Fork clones this PipelineItem.
+func (fanIn *FileFanIn) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(fanIn, n)
+}
+
+// calculate returns the current fan-in of the file.
+func (fanIn *FileFanIn) calculate(name string) int {
+	if fanIn.Mode == FanInModeCoupling {
+		return len(fanIn.neighbours[name])
+	}
+	files := map[string]bool{}
+	for _, key := range importTargetKeys(name) {
+		for file := range fanIn.importers[key] {
+			if file != name {
+				files[file] = true
+			}
+		}
+	}
+	return len(files)
+}
+
+func (fanIn *FileFanIn) updateNeighbours(treeDiff object.Changes) error {
+	context := make([]string, 0, len(treeDiff))
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return err
+		}
+		switch action {
+		case merkletrie.Insert:
+			context = append(context, change.To.Name)
+		case merkletrie.Delete:
+			for other := range fanIn.neighbours[change.From.Name] {
+				delete(fanIn.neighbours[other], change.From.Name)
+			}
+			delete(fanIn.neighbours, change.From.Name)
+		case merkletrie.Modify:
+			if change.From.Name != change.To.Name {
+				set := fanIn.neighbours[change.From.Name]
+				delete(fanIn.neighbours, change.From.Name)
+				for other := range set {
+					delete(fanIn.neighbours[other], change.From.Name)
+					fanIn.neighbours[other][change.To.Name] = true
+				}
+				if set != nil {
+					fanIn.neighbours[change.To.Name] = set
+				}
+			}
+			context = append(context, change.To.Name)
+		}
+	}
+	for _, file := range context {
+		set := fanIn.neighbours[file]
+		if set == nil {
+			set = map[string]bool{}
+			fanIn.neighbours[file] = set
+		}
+		for _, other := range context {
+			if other != file {
+				set[other] = true
+			}
+		}
+	}
+	return nil
+}
+
+func (fanIn *FileFanIn) updateImports(
+	treeDiff object.Changes, cache map[plumbing.Hash]*object.Blob) error {
+	for _, change := range treeDiff {
+		if change.From.Name != "" {
+			fanIn.removeImports(change.From.Name)
+		}
+		if change.To.Name == "" || fanInLanguages[path.Ext(change.To.Name)] == "" {
+			continue
+		}
+		contents, err := BlobToString(cache[change.To.TreeEntry.Hash])
+		if err != nil {
+			return err
+		}
+		keys := extractImportKeys(change.To.Name, contents)
+		fanIn.imports[change.To.Name] = keys
+		for _, key := range keys {
+			set := fanIn.importers[key]
+			if set == nil {
+				set = map[string]bool{}
+				fanIn.importers[key] = set
+			}
+			set[change.To.Name] = true
+		}
+	}
+	return nil
+}
+
+func (fanIn *FileFanIn) removeImports(name string) {
+	for _, key := range fanIn.imports[name] {
+		delete(fanIn.importers[key], name)
+		if len(fanIn.importers[key]) == 0 {
+			delete(fanIn.importers, key)
+		}
+	}
+	delete(fanIn.imports, name)
+}
+
+// pathSuffixes returns all the trailing parts of the slash-separated path, the longest first.
+func pathSuffixes(name string) []string {
+	var suffixes []string
+	for {
+		suffixes = append(suffixes, name)
+		slash := strings.IndexByte(name, '/')
+		if slash < 0 {
+			return suffixes
+		}
+		name = name[slash+1:]
+	}
+}
+
+// trimExt removes the file extension.
+func trimExt(name string) string {
+	return strings.TrimSuffix(name, path.Ext(name))
+}
+
+// extractImportKeys parses the imports in the file contents and returns the keys which
+// are matched against importTargetKeys().
+func extractImportKeys(name, contents string) []string {
+	prefix := fanInLanguages[path.Ext(name)]
+	dir := path.Dir(name)
+	keys := map[string]bool{}
+	switch prefix {
+	case "go:":
+		var paths []string
+		for _, block := range goImportBlockRegexp.FindAllStringSubmatch(contents, -1) {
+			for _, match := range quotedRegexp.FindAllStringSubmatch(block[1], -1) {
+				paths = append(paths, match[1])
+			}
+		}
+		for _, match := range goImportRegexp.FindAllStringSubmatch(contents, -1) {
+			paths = append(paths, match[1])
+		}
+		// a package directory matches if the import path ends with it
+		for _, importPath := range paths {
+			for _, suffix := range pathSuffixes(importPath) {
+				keys[prefix+suffix] = true
+			}
+		}
+	case "py:":
+		module := func(spec string) string {
+			dots := len(spec) - len(strings.TrimLeft(spec, "."))
+			spec = strings.Replace(spec[dots:], ".", "/", -1)
+			if dots == 0 {
+				return spec
+			}
+			base := dir
+			for i := 1; i < dots; i++ {
+				base = path.Dir(base)
+			}
+			return path.Join(base, spec)
+		}
+		for _, match := range pyFromRegexp.FindAllStringSubmatch(contents, -1) {
+			parent := module(match[1])
+			if parent != "" && parent != "." {
+				keys[prefix+parent] = true
+			}
+			for _, child := range strings.Split(match[2], ",") {
+				fields := strings.Fields(child)
+				if len(fields) > 0 && fields[0] != "*" {
+					keys[prefix+path.Join(parent, fields[0])] = true
+				}
+			}
+		}
+		for _, match := range pyImportRegexp.FindAllStringSubmatch(contents, -1) {
+			for _, spec := range strings.Split(match[1], ",") {
+				fields := strings.Fields(spec)
+				if len(fields) > 0 {
+					keys[prefix+module(fields[0])] = true
+				}
+			}
+		}
+	case "js:":
+		for _, match := range jsImportRegexp.FindAllStringSubmatch(contents, -1) {
+			target := path.Join(dir, match[1])
+			if fanInLanguages[path.Ext(target)] == prefix {
+				target = trimExt(target)
+			}
+			keys[prefix+target] = true
+		}
+	case "c:":
+		for _, match := range cIncludeRegexp.FindAllStringSubmatch(contents, -1) {
+			keys[prefix+path.Join(dir, match[1])] = true
+			keys[prefix+path.Clean(match[1])] = true
+		}
+	}
+	result := make([]string, 0, len(keys))
+	for key := range keys {
+		result = append(result, key)
+	}
+	return result
+}
+
+// importTargetKeys returns the keys by which the file can be imported.
+func importTargetKeys(name string) []string {
+	prefix := fanInLanguages[path.Ext(name)]
+	var keys []string
+	switch prefix {
+	case "go:":
+		if dir := path.Dir(name); dir != "." {
+			keys = append(keys, prefix+dir)
+		}
+	case "py:":
+		module := trimExt(name)
+		if path.Base(module) == "__init__" {
+			module = path.Dir(module)
+			if module == "." {
+				return nil
+			}
+		}
+		for _, suffix := range pathSuffixes(module) {
+			keys = append(keys, prefix+suffix)
+		}
+	case "js:":
+		keys = append(keys, prefix+trimExt(name))
+		if trimExt(path.Base(name)) == "index" {
+			keys = append(keys, prefix+path.Dir(name))
+		}
+	case "c:":
+		for _, suffix := range pathSuffixes(name) {
+			keys = append(keys, prefix+suffix)
+		}
+	}
+	return keys
+}
+
+func init() {
+	core.Registry.Register(&FileFanIn{})
+}
@@ -0,0 +1,147 @@
+package plumbing
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+func fixtureFileFanIn(mode string) *FileFanIn {
+	fanIn := FileFanIn{}
+	fanIn.Configure(map[string]interface{}{ConfigFileFanInMode: mode})
+	fanIn.Initialize(nil)
+	return &fanIn
+}
+
+func TestFileFanInMeta(t *testing.T) {
+	fanIn := fixtureFileFanIn(FanInModeImports)
+	assert.Equal(t, fanIn.Name(), "FileFanIn")
+	assert.Equal(t, fanIn.Provides(), []string{DependencyFileFanIn})
+	assert.Equal(t, fanIn.Requires(), []string{DependencyTreeChanges, DependencyBlobCache})
+	opts := fanIn.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigFileFanInMode)
+	assert.Equal(t, opts[0].Default, FanInModeCoupling)
+	assert.Equal(t, fanIn.Mode, FanInModeImports)
+	fanIn = fixtureFileFanIn("xxx")
+	assert.Equal(t, fanIn.Mode, FanInModeCoupling)
+	summoned := core.Registry.Summon(DependencyFileFanIn)
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "FileFanIn")
+}
+
+type fanInChange struct {
+	From, To string
+	Contents string
+}
+
+func consumeFileFanIn(t *testing.T, fanIn *FileFanIn, merge bool, changes ...fanInChange) map[string]int {
+	storage := memory.NewStorage()
+	cache := map[plumbing.Hash]*object.Blob{}
+	treeDiff := object.Changes{}
+	for _, change := range changes {
+		encoded := storage.NewEncodedObject()
+		encoded.SetType(plumbing.BlobObject)
+		writer, _ := encoded.Writer()
+		writer.Write([]byte(change.Contents))
+		writer.Close()
+		hash, err := storage.SetEncodedObject(encoded)
+		assert.Nil(t, err)
+		cache[hash], err = object.GetBlob(storage, hash)
+		assert.Nil(t, err)
+		objChange := &object.Change{}
+		if change.From != "" {
+			objChange.From = object.ChangeEntry{Name: change.From, TreeEntry: object.TreeEntry{
+				Name: change.From, Hash: plumbing.NewHash("ffffffffffffffffffffffffffffffffffffffff")}}
+		}
+		if change.To != "" {
+			objChange.To = object.ChangeEntry{Name: change.To, TreeEntry: object.TreeEntry{
+				Name: change.To, Hash: hash}}
+		}
+		treeDiff = append(treeDiff, objChange)
+	}
+	commit := &object.Commit{}
+	if merge {
+		commit.Hash = plumbing.NewHash("0123456789012345678901234567890123456789")
+		commit.ParentHashes = make([]plumbing.Hash, 2)
+	}
+	result, err := fanIn.Consume(map[string]interface{}{
+		DependencyTreeChanges:  treeDiff,
+		DependencyBlobCache:    cache,
+		core.DependencyCommit:  commit,
+		core.DependencyIsMerge: merge,
+	})
+	assert.Nil(t, err)
+	return result[DependencyFileFanIn].(map[string]int)
+}
+
+func TestFileFanInCoupling(t *testing.T) {
+	fanIn := fixtureFileFanIn(FanInModeCoupling)
+	result := consumeFileFanIn(t, fanIn, false,
+		fanInChange{To: "core.go"}, fanInChange{To: "a.go"}, fanInChange{To: "b.go"})
+	assert.Equal(t, result, map[string]int{"core.go": 2, "a.go": 2, "b.go": 2})
+	result = consumeFileFanIn(t, fanIn, false,
+		fanInChange{From: "core.go", To: "core.go"}, fanInChange{To: "c.go"})
+	assert.Equal(t, result, map[string]int{"core.go": 3, "c.go": 1})
+	result = consumeFileFanIn(t, fanIn, false,
+		fanInChange{From: "a.go", To: "utils.go"}, fanInChange{From: "b.go"})
+	assert.Equal(t, result, map[string]int{"utils.go": 1, "b.go": 2})
+	assert.Equal(t, fanIn.neighbours["core.go"], map[string]bool{"utils.go": true, "c.go": true})
+	// merges do not change the coupling
+	result = consumeFileFanIn(t, fanIn, true,
+		fanInChange{From: "c.go", To: "c.go"}, fanInChange{From: "utils.go", To: "utils.go"})
+	assert.Equal(t, result, map[string]int{"c.go": 1, "utils.go": 1})
+}
+
+func TestFileFanInImports(t *testing.T) {
+	fanIn := fixtureFileFanIn(FanInModeImports)
+	result := consumeFileFanIn(t, fanIn, false,
+		fanInChange{To: "cmd/main.go", Contents: "package main\n\nimport (\n\t\"fmt\"\n\t" +
+			"lib \"github.com/user/repo/internal/lib\"\n)\n"},
+		fanInChange{To: "internal/lib/lib.go", Contents: "package lib\n"},
+		fanInChange{To: "internal/lib/util.go", Contents: "package lib\nimport \"os\"\n"},
+		fanInChange{To: "tool/run.py", Contents: "import os, pkg.core\nfrom . import helpers\n"},
+		fanInChange{To: "tool/helpers.py", Contents: "from pkg.core import (parse,\n"},
+		fanInChange{To: "pkg/core.py", Contents: ""},
+		fanInChange{To: "web/app.ts", Contents: "import {x} from './util/index';\n" +
+			"const y = require(\"../shared/y.js\");\n"},
+		fanInChange{To: "web/util/index.ts", Contents: ""},
+		fanInChange{To: "shared/y.js", Contents: ""},
+		fanInChange{To: "src/main.c", Contents: "#include <stdio.h>\n#include \"util/str.h\"\n"},
+		fanInChange{To: "include/util/str.h", Contents: "#pragma once\n"},
+		fanInChange{To: "README.md", Contents: "import \"internal/lib\"\n"},
+	)
+	assert.Equal(t, result, map[string]int{
+		"cmd/main.go": 0, "internal/lib/lib.go": 1, "internal/lib/util.go": 1,
+		"tool/run.py": 0, "tool/helpers.py": 1, "pkg/core.py": 2,
+		"web/app.ts": 0, "web/util/index.ts": 1, "shared/y.js": 1,
+		"src/main.c": 0, "include/util/str.h": 1, "README.md": 0,
+	})
+	keys := fanIn.imports["tool/helpers.py"]
+	sort.Strings(keys)
+	assert.Equal(t, keys, []string{"py:pkg/core", "py:pkg/core/parse"})
+	// the imports disappear with the deleted and the modified files
+	result = consumeFileFanIn(t, fanIn, false,
+		fanInChange{From: "cmd/main.go"},
+		fanInChange{From: "tool/run.py", To: "tool/run.py", Contents: "import json\n"})
+	assert.Equal(t, result, map[string]int{"cmd/main.go": 0, "tool/run.py": 0})
+	result = consumeFileFanIn(t, fanIn, false,
+		fanInChange{From: "internal/lib/lib.go", To: "internal/lib/lib.go", Contents: "package lib\n"},
+		fanInChange{From: "pkg/core.py", To: "pkg/core.py", Contents: "\n"})
+	assert.Equal(t, result, map[string]int{"internal/lib/lib.go": 0, "pkg/core.py": 1})
+}
+
+func TestImportTargetKeys(t *testing.T) {
+	assert.Equal(t, importTargetKeys("a/b/c.go"), []string{"go:a/b"})
+	assert.Nil(t, importTargetKeys("main.go"))
+	assert.Equal(t, importTargetKeys("src/pkg/__init__.py"), []string{"py:src/pkg", "py:pkg"})
+	assert.Nil(t, importTargetKeys("__init__.py"))
+	assert.Equal(t, importTargetKeys("web/index.js"), []string{"js:web/index", "js:web"})
+	assert.Equal(t, importTargetKeys("x/y.h"), []string{"c:x/y.h", "c:y.h"})
+	assert.Nil(t, importTargetKeys("README.md"))
+}
@@ -0,0 +1,165 @@
+package plumbing
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+// FormattingDetector finds the diff hunks which change only the whitespace, e.g. the indentation,
+// the trailing spaces, the empty lines or the line wrapping. It is a PipelineItem.
+// The line-tracking analyses can use its results to keep the age of the reformatted lines,
+// so that a repository-wide gofmt run does not make every line brand new.
+type FormattingDetector struct {
+	core.NoopMerger
+}
+
+const (
+	// DependencyFormattingHunks is the name of the dependency provided by FormattingDetector.
+	// It is a map[string][]bool from the names of the modified files to the flags of the hunks
+	// in the corresponding FileDiffData. A hunk is a maximal sequence of the adjacent non-equal
+	// diffs and the flags follow the order of appearance; true means that the hunk is
+	// formatting-only.
+	DependencyFormattingHunks = "formatting_hunks"
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (detector *FormattingDetector) Name() string {
+	return "FormattingDetector"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (detector *FormattingDetector) Provides() []string {
+	arr := [...]string{DependencyFormattingHunks}
+	return arr[:]
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (detector *FormattingDetector) Requires() []string {
+	arr := [...]string{DependencyTreeChanges, DependencyBlobCache, DependencyFileDiff}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (detector *FormattingDetector) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (detector *FormattingDetector) Configure(facts map[string]interface{}) {}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (detector *FormattingDetector) Initialize(repository *git.Repository) {}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (detector *FormattingDetector) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	cache := deps[DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	treeDiff := deps[DependencyTreeChanges].(object.Changes)
+	fileDiffs := deps[DependencyFileDiff].(map[string]FileDiffData)
+	result := map[string][]bool{}
+	for _, change := range treeDiff {
+		diff, exists := fileDiffs[change.To.Name]
+		if !exists || change.From.Name == "" {
+			continue
+		}
+		strFrom, err := BlobToString(cache[change.From.TreeEntry.Hash])
+		if err != nil {
+			return nil, err
+		}
+		strTo, err := BlobToString(cache[change.To.TreeEntry.Hash])
+		if err != nil {
+			return nil, err
+		}
+		result[change.To.Name] = FormattingHunks(splitLines(strFrom), splitLines(strTo), diff)
+	}
+	return map[string]interface{}{DependencyFormattingHunks: result}, nil
+}
+
+// Fork clones this PipelineItem.
+func (detector *FormattingDetector) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(detector, n)
+}
+
+// FormattingHunks returns the formatting-only flags of the hunks in `diff` which transforms
+// `oldLines` into `newLines`. A hunk is formatting-only if the deleted and the inserted lines
+// are the same after removing all the whitespace; for example, a hunk which only inserts
+// empty lines is formatting-only. All the hunks are reported as not formatting-only
+// if the line counts do not match the diff.
+func FormattingHunks(oldLines, newLines []string, diff FileDiffData) []bool {
+	var hunks []bool
+	mismatch := len(oldLines) != diff.OldLinesOfCode || len(newLines) != diff.NewLinesOfCode
+	oldPos, newPos := 0, 0
+	var deleted, inserted []string
+	inHunk := false
+	flush := func() {
+		if inHunk {
+			hunks = append(hunks, !mismatch && stripWhitespace(deleted) == stripWhitespace(inserted))
+		}
+		deleted, inserted, inHunk = nil, nil, false
+	}
+	slice := func(lines []string, pos, length int) []string {
+		if mismatch || pos+length > len(lines) {
+			mismatch = true
+			return nil
+		}
+		return lines[pos : pos+length]
+	}
+	for _, edit := range diff.Diffs {
+		length := utf8.RuneCountInString(edit.Text)
+		switch edit.Type {
+		case diffmatchpatch.DiffEqual:
+			flush()
+			oldPos += length
+			newPos += length
+		case diffmatchpatch.DiffDelete:
+			inHunk = true
+			deleted = append(deleted, slice(oldLines, oldPos, length)...)
+			oldPos += length
+		case diffmatchpatch.DiffInsert:
+			inHunk = true
+			inserted = append(inserted, slice(newLines, newPos, length)...)
+			newPos += length
+		}
+	}
+	flush()
+	return hunks
+}
+
+// splitLines divides the text into lines the same way as diffmatchpatch.DiffLinesToRunes():
+// the line endings are preserved and the trailing line may lack one.
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// stripWhitespace joins the lines and removes all the whitespace.
+func stripWhitespace(lines []string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, strings.Join(lines, ""))
+}
+
+func init() {
+	core.Registry.Register(&FormattingDetector{})
+}
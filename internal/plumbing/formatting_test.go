@@ -0,0 +1,101 @@
+package plumbing_test
+
+import (
+	"testing"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func TestFormattingDetectorMeta(t *testing.T) {
+	detector := &items.FormattingDetector{}
+	detector.Initialize(nil)
+	assert.Equal(t, detector.Name(), "FormattingDetector")
+	assert.Equal(t, detector.Provides(), []string{items.DependencyFormattingHunks})
+	assert.Equal(t, detector.Requires(), []string{
+		items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyFileDiff})
+	assert.Len(t, detector.ListConfigurationOptions(), 0)
+	detector.Configure(nil)
+	summoned := core.Registry.Summon(detector.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "FormattingDetector")
+}
+
+func TestFormattingHunks(t *testing.T) {
+	oldLines := []string{"func f() {\n", "\treturn  a+b\n", "}\n", "x := []int{1,\n", "2}\n"}
+	newLines := []string{"func f() {\n", "\n", "\treturn a + b\n", "}\n", "x := []int{1, 2}\n",
+		"// end"}
+	diff := items.FileDiffData{OldLinesOfCode: 5, NewLinesOfCode: 6, Diffs: []diffmatchpatch.Diff{
+		{Type: diffmatchpatch.DiffEqual, Text: "a"},
+		{Type: diffmatchpatch.DiffDelete, Text: "b"},
+		{Type: diffmatchpatch.DiffInsert, Text: "cd"},
+		{Type: diffmatchpatch.DiffEqual, Text: "e"},
+		{Type: diffmatchpatch.DiffDelete, Text: "fg"},
+		{Type: diffmatchpatch.DiffInsert, Text: "h"},
+		{Type: diffmatchpatch.DiffInsert, Text: "i"},
+	}}
+	assert.Equal(t, items.FormattingHunks(oldLines, newLines, diff), []bool{true, false})
+	diff.Diffs = diff.Diffs[:6]
+	diff.NewLinesOfCode = 5
+	assert.Equal(t, items.FormattingHunks(oldLines, newLines[:5], diff), []bool{true, true})
+	// the line counts do not match
+	assert.Equal(t, items.FormattingHunks(oldLines[:4], newLines[:5], diff), []bool{false, false})
+	assert.Len(t, items.FormattingHunks(oldLines, oldLines, items.FileDiffData{
+		OldLinesOfCode: 5, NewLinesOfCode: 5, Diffs: []diffmatchpatch.Diff{
+			{Type: diffmatchpatch.DiffEqual, Text: "abcde"}}}), 0)
+}
+
+func TestFormattingDetectorConsume(t *testing.T) {
+	storage := memory.NewStorage()
+	store := func(text string) plumbing.Hash {
+		encoded := storage.NewEncodedObject()
+		encoded.SetType(plumbing.BlobObject)
+		writer, _ := encoded.Writer()
+		writer.Write([]byte(text))
+		writer.Close()
+		hash, err := storage.SetEncodedObject(encoded)
+		assert.Nil(t, err)
+		return hash
+	}
+	cache := map[plumbing.Hash]*object.Blob{}
+	hashBefore := store("one\ntwo\n")
+	hashAfter := store("one\n  two\n")
+	hashOther := store("three\n")
+	for _, hash := range []plumbing.Hash{hashBefore, hashAfter, hashOther} {
+		blob, err := object.GetBlob(storage, hash)
+		assert.Nil(t, err)
+		cache[hash] = blob
+	}
+	entry := func(name string, hash plumbing.Hash) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	changes := object.Changes{
+		{From: entry("a.go", hashBefore), To: entry("b.go", hashAfter)},
+		{From: entry("c.go", hashBefore), To: entry("c.go", hashOther)},
+		{To: entry("d.go", hashOther)},
+	}
+	fileDiffs := map[string]items.FileDiffData{
+		"b.go": {OldLinesOfCode: 2, NewLinesOfCode: 2, Diffs: []diffmatchpatch.Diff{
+			{Type: diffmatchpatch.DiffEqual, Text: "a"},
+			{Type: diffmatchpatch.DiffDelete, Text: "b"},
+			{Type: diffmatchpatch.DiffInsert, Text: "c"}}},
+		"c.go": {OldLinesOfCode: 2, NewLinesOfCode: 1, Diffs: []diffmatchpatch.Diff{
+			{Type: diffmatchpatch.DiffDelete, Text: "ab"},
+			{Type: diffmatchpatch.DiffInsert, Text: "c"}}},
+	}
+	detector := &items.FormattingDetector{}
+	detector.Initialize(nil)
+	result, err := detector.Consume(map[string]interface{}{
+		items.DependencyBlobCache:   cache,
+		items.DependencyTreeChanges: changes,
+		items.DependencyFileDiff:    fileDiffs,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, result[items.DependencyFormattingHunks], map[string][]bool{
+		"b.go": {true}, "c.go": {false}})
+}
@@ -0,0 +1,165 @@
+package identity
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+const (
+	// DefaultActiveMinCommits is the default value of ActivityPolicy.MinCommits.
+	DefaultActiveMinCommits = 1
+	// DefaultActiveWindow is the default value of ActivityPolicy.Window.
+	DefaultActiveWindow = 30
+)
+
+// ActivityPolicy defines who is an active developer. A developer is active in a time window
+// if they made at least MinCommits commits and changed at least MinLines lines in it.
+// The analyses share the same policy through FactIdentityDetectorActiveDevelopers so that
+// all the reports agree on who counts as active.
+type ActivityPolicy struct {
+	// MinCommits is the minimum number of commits in a window.
+	MinCommits int
+	// MinLines is the minimum number of added and removed lines in a window.
+	// Merge commits do not change lines. 0 disables the check and avoids reading the diffs.
+	MinLines int
+	// Window is the length of the time window in days.
+	Window int
+}
+
+// normalize replaces the invalid values with the defaults.
+func (policy ActivityPolicy) normalize() ActivityPolicy {
+	if policy.MinCommits < 1 {
+		if policy.MinCommits < 0 {
+			log.Printf("Warning: adjusted the minimum number of commits of an active developer "+
+				"%d -> %d\n", policy.MinCommits, DefaultActiveMinCommits)
+		}
+		policy.MinCommits = DefaultActiveMinCommits
+	}
+	if policy.MinLines < 0 {
+		log.Printf("Warning: adjusted the minimum number of lines of an active developer "+
+			"%d -> 0\n", policy.MinLines)
+		policy.MinLines = 0
+	}
+	if policy.Window <= 0 {
+		if policy.Window < 0 {
+			log.Printf("Warning: adjusted the active developer window %d -> %d\n",
+				policy.Window, DefaultActiveWindow)
+		}
+		policy.Window = DefaultActiveWindow
+	}
+	return policy
+}
+
+// ActiveDevelopers tells which developers are active in each time window according to
+// ActivityPolicy. The windows split the days since the beginning of the history, which are
+// counted the same way as plumbing.DaysSinceStart does, so the analyses can pass their day
+// indices directly.
+type ActiveDevelopers struct {
+	// Policy is the effective activity policy.
+	Policy ActivityPolicy
+
+	// windows maps the window index to the set of the active developers.
+	windows map[int]map[int]bool
+}
+
+// Window returns the index of the time window which contains the specified day.
+func (active *ActiveDevelopers) Window(day int) int {
+	if active == nil {
+		return day / DefaultActiveWindow
+	}
+	return day / active.Policy.Window
+}
+
+// IsActive returns true if the developer is active in the window which contains
+// the specified day.
+func (active *ActiveDevelopers) IsActive(author, day int) bool {
+	if active == nil {
+		return false
+	}
+	return active.windows[active.Window(day)][author]
+}
+
+// Active returns the sorted indices of the developers who are active in the specified window.
+func (active *ActiveDevelopers) Active(window int) []int {
+	if active == nil {
+		return nil
+	}
+	devs := make([]int, 0, len(active.windows[window]))
+	for dev := range active.windows[window] {
+		devs = append(devs, dev)
+	}
+	sort.Ints(devs)
+	return devs
+}
+
+// Windows maps the indices of the windows which have any active developers to the sorted
+// indices of those developers.
+func (active *ActiveDevelopers) Windows() map[int][]int {
+	result := map[int][]int{}
+	if active == nil {
+		return result
+	}
+	for window := range active.windows {
+		result[window] = active.Active(window)
+	}
+	return result
+}
+
+// calculateActiveDevelopers applies ActivityPolicy to the specified commits.
+// The unmatched identities are never active.
+func (detector *Detector) calculateActiveDevelopers(commits []*object.Commit) *ActiveDevelopers {
+	active := &ActiveDevelopers{
+		Policy:  detector.ActivityPolicy.normalize(),
+		windows: map[int]map[int]bool{},
+	}
+	if len(commits) == 0 {
+		return active
+	}
+	type slot struct {
+		window int
+		author int
+	}
+	slotCommits := map[slot]int{}
+	slotLines := map[slot]int{}
+	day0 := commits[0].Committer.When.Truncate(24 * time.Hour)
+	previousDay := 0
+	for _, commit := range commits {
+		day := int(commit.Committer.When.Sub(day0).Hours() / 24)
+		if day < previousDay {
+			day = previousDay
+		}
+		previousDay = day
+		author := detector.resolve(AttributedSignature(commit, detector.Attribution))
+		if author == AuthorMissing {
+			continue
+		}
+		key := slot{active.Window(day), author}
+		slotCommits[key]++
+		if active.Policy.MinLines == 0 || commit.NumParents() > 1 {
+			continue
+		}
+		stats, err := commit.Stats()
+		if err != nil {
+			log.Printf("Warning: failed to count the changed lines in %s: %v\n", commit.Hash, err)
+			continue
+		}
+		for _, stat := range stats {
+			slotLines[key] += stat.Addition + stat.Deletion
+		}
+	}
+	for key, count := range slotCommits {
+		if count < active.Policy.MinCommits || slotLines[key] < active.Policy.MinLines {
+			continue
+		}
+		devs := active.windows[key.window]
+		if devs == nil {
+			devs = map[int]bool{}
+			active.windows[key.window] = devs
+		}
+		devs[key.author] = true
+	}
+	return active
+}
@@ -0,0 +1,89 @@
+package identity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+func fixtureActivityCommits() []*object.Commit {
+	start := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	commit := func(name string, days int) *object.Commit {
+		return &object.Commit{Author: object.Signature{
+			Name: name, Email: name + "@corp.com", When: start.Add(time.Duration(days) * day)}}
+	}
+	return storeSplitCommits([]*object.Commit{
+		commit("alice", 0),
+		commit("bob", 1),
+		commit("alice", 2),
+		commit("bob", 12),
+		commit("alice", 5), // rebased, counted on day 12
+		commit("bob", 25),
+	})
+}
+
+func TestActivityPolicyNormalize(t *testing.T) {
+	assert.Equal(t, ActivityPolicy{}.normalize(), ActivityPolicy{
+		MinCommits: DefaultActiveMinCommits, Window: DefaultActiveWindow})
+	assert.Equal(t, ActivityPolicy{MinCommits: -1, MinLines: -1, Window: -1}.normalize(),
+		ActivityPolicy{MinCommits: DefaultActiveMinCommits, Window: DefaultActiveWindow})
+	assert.Equal(t, ActivityPolicy{MinCommits: 3, MinLines: 10, Window: 7}.normalize(),
+		ActivityPolicy{MinCommits: 3, MinLines: 10, Window: 7})
+}
+
+func TestIdentityDetectorActiveDevelopers(t *testing.T) {
+	commits := fixtureActivityCommits()
+	id := Detector{}
+	facts := map[string]interface{}{
+		ConfigIdentityDetectorActiveMinCommits: 2,
+		ConfigIdentityDetectorActiveWindow:     10,
+		core.ConfigPipelineCommits:             commits,
+	}
+	id.Configure(facts)
+	assert.Equal(t, id.ActivityPolicy, ActivityPolicy{MinCommits: 2, Window: 10})
+	active := facts[FactIdentityDetectorActiveDevelopers].(*ActiveDevelopers)
+	assert.True(t, active == id.ActiveDevelopers)
+	assert.Equal(t, active.Policy, ActivityPolicy{MinCommits: 2, Window: 10})
+	alice := id.PeopleDict["alice@corp.com"]
+	bob := id.PeopleDict["bob@corp.com"]
+	assert.Equal(t, active.Windows(), map[int][]int{0: {alice}})
+	assert.True(t, active.IsActive(alice, 9))
+	assert.False(t, active.IsActive(bob, 9))
+	assert.False(t, active.IsActive(alice, 12))
+	assert.Equal(t, active.Window(25), 2)
+	assert.Len(t, active.Active(2), 0)
+
+	id = Detector{}
+	facts = map[string]interface{}{
+		ConfigIdentityDetectorActiveWindow: 10,
+		core.ConfigPipelineCommits:         commits,
+	}
+	id.Configure(facts)
+	active = facts[FactIdentityDetectorActiveDevelopers].(*ActiveDevelopers)
+	assert.Equal(t, active.Windows(), map[int][]int{0: {0, 1}, 1: {0, 1}, 2: {bob}})
+	assert.Equal(t, active.Active(1), []int{0, 1})
+
+	id = Detector{}
+	facts = map[string]interface{}{
+		ConfigIdentityDetectorActiveMinLines: 1,
+		core.ConfigPipelineCommits:           commits,
+	}
+	id.Configure(facts)
+	// the commits do not change any lines
+	assert.Len(t, id.ActiveDevelopers.Windows(), 0)
+}
+
+func TestActiveDevelopersNil(t *testing.T) {
+	var active *ActiveDevelopers
+	assert.False(t, active.IsActive(0, 0))
+	assert.Nil(t, active.Active(0))
+	assert.Len(t, active.Windows(), 0)
+	assert.Equal(t, active.Window(DefaultActiveWindow), 1)
+	active = (&Detector{}).calculateActiveDevelopers(nil)
+	assert.Len(t, active.Windows(), 0)
+	assert.Equal(t, active.Policy.Window, DefaultActiveWindow)
+}
@@ -0,0 +1,55 @@
+package identity
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"strings"
+)
+
+// AnonymizedPrefix starts every anonymized identity.
+const AnonymizedPrefix = "dev-"
+
+// AnonymizeIdentity replaces the identity in the ReversedPeopleDict format with a stable salted
+// hash of its main key, the part before the first "|". The same developer is always mapped to
+// the same string given the same salt, and the original name is not recoverable without it.
+func AnonymizeIdentity(identity, salt string) string {
+	key := identity
+	if pos := strings.Index(key, "|"); pos >= 0 {
+		key = key[:pos]
+	}
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(strings.ToLower(key)))
+	return AnonymizedPrefix + hex.EncodeToString(mac.Sum(nil))[:16]
+}
+
+// anonymize replaces the developers in ReversedPeopleDict with AnonymizeIdentity().
+// AuthorMissingName and the teams are preserved. If AnonymizeSalt is empty, it is set
+// to a random value, so the hashes are stable only within one run.
+func (detector *Detector) anonymize() {
+	if !detector.Anonymize {
+		return
+	}
+	if detector.AnonymizeSalt == "" {
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			log.Panicf("failed to generate the anonymization salt: %v", err)
+		}
+		detector.AnonymizeSalt = hex.EncodeToString(salt)
+	}
+	if detector.TeamsFile != "" {
+		// the team names are not personal
+		return
+	}
+	people := make([]string, len(detector.ReversedPeopleDict))
+	for i, person := range detector.ReversedPeopleDict {
+		if person == AuthorMissingName {
+			people[i] = person
+			continue
+		}
+		people[i] = AnonymizeIdentity(person, detector.AnonymizeSalt)
+	}
+	detector.ReversedPeopleDict = people
+}
@@ -0,0 +1,81 @@
+package identity
+
+import (
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+func TestAnonymizeIdentity(t *testing.T) {
+	hash := AnonymizeIdentity("bob|bob@corp.com", "salt")
+	assert.True(t, strings.HasPrefix(hash, AnonymizedPrefix))
+	assert.Len(t, hash, len(AnonymizedPrefix)+16)
+	assert.Equal(t, AnonymizeIdentity("Bob|bob@home.com", "salt"), hash)
+	assert.Equal(t, AnonymizeIdentity("bob", "salt"), hash)
+	assert.NotEqual(t, AnonymizeIdentity("bob", "pepper"), hash)
+	assert.NotEqual(t, AnonymizeIdentity("alice", "salt"), hash)
+}
+
+func TestIdentityDetectorAnonymize(t *testing.T) {
+	commits := storeSplitCommits([]*object.Commit{
+		{Author: object.Signature{Name: "Bob", Email: "bob@corp.com"}},
+		{Author: object.Signature{Name: "Alice", Email: "alice@corp.com"}},
+	})
+	id := Detector{}
+	facts := map[string]interface{}{
+		ConfigIdentityDetectorAnonymize:     true,
+		ConfigIdentityDetectorAnonymizeSalt: "salt",
+		core.ConfigPipelineCommits:          commits,
+	}
+	id.Configure(facts)
+	assert.True(t, id.Anonymize)
+	assert.Equal(t, id.AnonymizeSalt, "salt")
+	assert.Len(t, id.ReversedPeopleDict, 2)
+	for _, person := range id.ReversedPeopleDict {
+		assert.True(t, strings.HasPrefix(person, AnonymizedPrefix))
+	}
+	assert.Equal(t, facts[FactIdentityDetectorReversedPeopleDict], id.ReversedPeopleDict)
+	bob := id.PeopleDict["bob@corp.com"]
+	assert.Equal(t, id.ReversedPeopleDict[bob], AnonymizeIdentity("bob", "salt"))
+	result, err := id.Consume(map[string]interface{}{core.DependencyCommit: commits[0]})
+	assert.Nil(t, err)
+	assert.Equal(t, result[DependencyAuthor], bob)
+
+	id = Detector{}
+	facts = map[string]interface{}{
+		ConfigIdentityDetectorAnonymize: true,
+		core.ConfigPipelineCommits:      commits,
+	}
+	id.Configure(facts)
+	assert.Len(t, id.AnonymizeSalt, 32)
+	assert.NotEqual(t, id.ReversedPeopleDict[id.PeopleDict["bob@corp.com"]],
+		AnonymizeIdentity("bob", "salt"))
+}
+
+func TestIdentityDetectorAnonymizePeopleDict(t *testing.T) {
+	id := Detector{}
+	facts := map[string]interface{}{
+		ConfigIdentityDetectorAnonymize:      true,
+		ConfigIdentityDetectorAnonymizeSalt:  "salt",
+		ConfigIdentityDetectorPeopleDictPath: path.Join("..", "..", "test_data", "identities"),
+	}
+	id.Configure(facts)
+	assert.Equal(t, id.ReversedPeopleDict, []string{
+		AnonymizeIdentity("linus torvalds", "salt"),
+		AnonymizeIdentity("vadim markovtsev", "salt"),
+		AnonymizeIdentity("Máximo Cuadros", "salt"),
+		AuthorMissingName})
+	assert.Equal(t, id.PeopleDict["vadim@sourced.tech"], 1)
+}
+
+func TestIdentityDetectorAnonymizeTeams(t *testing.T) {
+	id := Detector{ReversedPeopleDict: []string{"backend", TeamUnassigned},
+		Anonymize: true, TeamsFile: "teams.yml"}
+	id.anonymize()
+	assert.Equal(t, id.ReversedPeopleDict, []string{"backend", TeamUnassigned})
+	assert.NotEmpty(t, id.AnonymizeSalt)
+}
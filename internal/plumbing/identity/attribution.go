@@ -0,0 +1,61 @@
+package identity
+
+import (
+	"log"
+	"strings"
+
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+const (
+	// AttributionAuthor attributes the commits to their authors. This is the default.
+	AttributionAuthor = "author"
+	// AttributionCommitter attributes the commits to their committers, e.g. to the maintainers
+	// who applied the patches or to the developers who rebased the branches.
+	AttributionCommitter = "committer"
+	// AttributionBoth attributes the commits to their authors but also recognizes
+	// the committers as developers, so that DependencyCommitter is resolved for all the commits.
+	AttributionBoth = "both"
+)
+
+// AttributedSignature returns the signature of the commit which is credited with the changes
+// according to the attribution mode, see Detector.Attribution.
+func AttributedSignature(commit *object.Commit, attribution string) object.Signature {
+	if attribution == AttributionCommitter {
+		return commit.Committer
+	}
+	return commit.Author
+}
+
+// normalizeAttribution replaces the unknown attribution mode with AttributionAuthor.
+func normalizeAttribution(attribution string) string {
+	switch attribution {
+	case AttributionAuthor, AttributionCommitter, AttributionBoth:
+		return attribution
+	case "":
+		return AttributionAuthor
+	}
+	log.Printf("Warning: unknown attribution mode %s, using %s\n", attribution, AttributionAuthor)
+	return AttributionAuthor
+}
+
+// signatures returns the signatures of the commit which define the identities
+// in GeneratePeopleDict(), including the co-authors if Detector.CoAuthors is enabled.
+func (detector *Detector) signatures(commit *object.Commit) []object.Signature {
+	return append(detector.attributedSignatures(commit), detector.coAuthorSignatures(commit)...)
+}
+
+// attributedSignatures returns the signatures of the commit according to Detector.Attribution.
+func (detector *Detector) attributedSignatures(commit *object.Commit) []object.Signature {
+	switch detector.Attribution {
+	case AttributionCommitter:
+		return []object.Signature{commit.Committer}
+	case AttributionBoth:
+		if strings.EqualFold(commit.Author.Email, commit.Committer.Email) &&
+			strings.EqualFold(commit.Author.Name, commit.Committer.Name) {
+			return []object.Signature{commit.Author}
+		}
+		return []object.Signature{commit.Author, commit.Committer}
+	}
+	return []object.Signature{commit.Author}
+}
@@ -0,0 +1,102 @@
+package identity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+func fixtureAttributionCommits() []*object.Commit {
+	start := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+	signature := func(name string, days int) object.Signature {
+		return object.Signature{
+			Name: name, Email: name + "@corp.com", When: start.Add(time.Duration(days) * 24 * time.Hour)}
+	}
+	return storeSplitCommits([]*object.Commit{
+		{Author: signature("alice", 0)},
+		// the maintainer applies the patches of the contributors
+		{Author: signature("bob", 1), Committer: signature("carol", 5)},
+		{Author: signature("alice", 2), Committer: signature("carol", 40)},
+	})
+}
+
+func TestAttributedSignature(t *testing.T) {
+	commit := fixtureAttributionCommits()[1]
+	assert.Equal(t, AttributedSignature(commit, AttributionAuthor).Name, "bob")
+	assert.Equal(t, AttributedSignature(commit, AttributionBoth).Name, "bob")
+	assert.Equal(t, AttributedSignature(commit, AttributionCommitter).Name, "carol")
+	assert.Equal(t, normalizeAttribution(""), AttributionAuthor)
+	assert.Equal(t, normalizeAttribution(AttributionBoth), AttributionBoth)
+	assert.Equal(t, normalizeAttribution("reviewer"), AttributionAuthor)
+}
+
+func TestIdentityDetectorAttribution(t *testing.T) {
+	commits := fixtureAttributionCommits()
+	consume := func(id *Detector) ([]int, []int) {
+		var authors, committers []int
+		for _, commit := range commits {
+			result, err := id.Consume(map[string]interface{}{core.DependencyCommit: commit})
+			assert.Nil(t, err)
+			authors = append(authors, result[DependencyAuthor].(int))
+			committers = append(committers, result[DependencyCommitter].(int))
+		}
+		return authors, committers
+	}
+
+	id := &Detector{}
+	facts := map[string]interface{}{core.ConfigPipelineCommits: commits}
+	id.Configure(facts)
+	assert.Equal(t, id.Attribution, AttributionAuthor)
+	assert.Equal(t, facts[FactIdentityDetectorAttribution], AttributionAuthor)
+	assert.Equal(t, id.ReversedPeopleDict, []string{"alice|alice@corp.com", "bob|bob@corp.com"})
+	authors, committers := consume(id)
+	assert.Equal(t, authors, []int{0, 1, 0})
+	assert.Equal(t, committers, []int{0, AuthorMissing, AuthorMissing})
+
+	id = &Detector{}
+	facts = map[string]interface{}{
+		ConfigIdentityDetectorAttribution:  AttributionCommitter,
+		ConfigIdentityDetectorActiveWindow: 30,
+		core.ConfigPipelineCommits:         commits,
+	}
+	id.Configure(facts)
+	assert.Equal(t, id.Attribution, AttributionCommitter)
+	assert.Equal(t, facts[FactIdentityDetectorAttribution], AttributionCommitter)
+	assert.Equal(t, id.ReversedPeopleDict, []string{"alice|alice@corp.com", "carol|carol@corp.com"})
+	authors, committers = consume(id)
+	assert.Equal(t, authors, []int{0, 1, 1})
+	assert.Equal(t, committers, []int{0, 1, 1})
+	// the days are counted by the committer time, the last commit is on day 40
+	assert.Equal(t, id.ActiveDevelopers.Windows(), map[int][]int{0: {0, 1}, 1: {1}})
+
+	id = &Detector{}
+	facts = map[string]interface{}{
+		ConfigIdentityDetectorAttribution: AttributionBoth,
+		core.ConfigPipelineCommits:        commits,
+	}
+	id.Configure(facts)
+	assert.Equal(t, id.ReversedPeopleDict, []string{
+		"alice|alice@corp.com", "bob|bob@corp.com", "carol|carol@corp.com"})
+	assert.Equal(t, facts[FactIdentityDetectorPeopleCount], 3)
+	authors, committers = consume(id)
+	assert.Equal(t, authors, []int{0, 1, 0})
+	assert.Equal(t, committers, []int{0, 2, 2})
+
+	proposals := id.Proposals(commits)
+	assert.Len(t, proposals, 3)
+	assert.Equal(t, proposals[2].Signatures, []Signature{
+		{Name: "carol", Email: "carol@corp.com", Commits: 2}})
+
+	// the fact does not overwrite the option
+	id = &Detector{}
+	facts = map[string]interface{}{
+		ConfigIdentityDetectorAttribution: "xxx",
+		core.ConfigPipelineCommits:        commits,
+	}
+	id.Configure(facts)
+	assert.Equal(t, facts[ConfigIdentityDetectorAttribution], "xxx")
+	assert.Equal(t, facts[FactIdentityDetectorAttribution], AttributionAuthor)
+}
@@ -0,0 +1,85 @@
+package identity
+
+import (
+	"log"
+	"regexp"
+	"strings"
+
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+const (
+	// CoAuthorsNone ignores the commit message trailers. This is the default.
+	CoAuthorsNone = "none"
+	// CoAuthorsDuplicate credits every co-author with the whole commit as if they authored it.
+	CoAuthorsDuplicate = "duplicate"
+	// CoAuthorsSplit divides the credit for the commit evenly among the author and the co-authors.
+	CoAuthorsSplit = "split"
+)
+
+// DefaultCoAuthorTrailers is the default value of Detector.CoAuthorTrailers.
+var DefaultCoAuthorTrailers = []string{"Co-authored-by"}
+
+// trailerRegexp matches the "Key: Name <email>" lines in the commit messages.
+var trailerRegexp = regexp.MustCompile(`(?m)^\s*([A-Za-z][A-Za-z-]*)\s*:\s*(.*?)\s*<([^<>\s]+)>\s*$`)
+
+// ParseTrailers returns the signatures from the commit message trailers with the specified keys,
+// e.g. "Co-authored-by: Alice <alice@corp.com>". The keys are case insensitive. The signatures
+// which repeat the same email are reported once.
+func ParseTrailers(message string, keys []string) []object.Signature {
+	var result []object.Signature
+	seen := map[string]bool{}
+	for _, match := range trailerRegexp.FindAllStringSubmatch(message, -1) {
+		accepted := false
+		for _, key := range keys {
+			if strings.EqualFold(match[1], strings.TrimSpace(key)) {
+				accepted = true
+				break
+			}
+		}
+		email := strings.ToLower(match[3])
+		if !accepted || seen[email] {
+			continue
+		}
+		seen[email] = true
+		result = append(result, object.Signature{Name: match[2], Email: match[3]})
+	}
+	return result
+}
+
+// normalizeCoAuthors replaces the unknown co-authorship mode with CoAuthorsNone.
+func normalizeCoAuthors(mode string) string {
+	switch mode {
+	case CoAuthorsNone, CoAuthorsDuplicate, CoAuthorsSplit:
+		return mode
+	case "":
+		return CoAuthorsNone
+	}
+	log.Printf("Warning: unknown co-authorship mode %s, using %s\n", mode, CoAuthorsNone)
+	return CoAuthorsNone
+}
+
+// coAuthorSignatures returns the signatures of the co-authors of the commit according to
+// Detector.CoAuthors and Detector.CoAuthorTrailers.
+func (detector *Detector) coAuthorSignatures(commit *object.Commit) []object.Signature {
+	if detector.CoAuthors == CoAuthorsNone || detector.CoAuthors == "" {
+		return nil
+	}
+	return ParseTrailers(commit.Message, detector.CoAuthorTrailers)
+}
+
+// resolveCoAuthors returns the unique indices of the co-authors of the commit except `author`.
+// The unmatched co-authors are skipped.
+func (detector *Detector) resolveCoAuthors(commit *object.Commit, author int) []int {
+	var result []int
+	seen := map[int]bool{author: true}
+	for _, signature := range detector.coAuthorSignatures(commit) {
+		id := detector.resolve(signature)
+		if id == AuthorMissing || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+package identity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+func TestParseTrailers(t *testing.T) {
+	message := `Fix the crash
+
+Co-authored-by: Bob Smith <bob@corp.com>
+co-authored-by:Carol <Carol@corp.com>
+Signed-off-by: Alice <alice@corp.com>
+Co-authored-by: Bob <BOB@corp.com>
+Co-authored-by: nobody
+Reviewed-by: Dave <dave@corp.com>`
+	assert.Equal(t, ParseTrailers(message, DefaultCoAuthorTrailers), []object.Signature{
+		{Name: "Bob Smith", Email: "bob@corp.com"},
+		{Name: "Carol", Email: "Carol@corp.com"},
+	})
+	assert.Equal(t, ParseTrailers(message, []string{"signed-off-by", " Reviewed-by "}),
+		[]object.Signature{
+			{Name: "Alice", Email: "alice@corp.com"},
+			{Name: "Dave", Email: "dave@corp.com"},
+		})
+	assert.Len(t, ParseTrailers("Co-authored-by: Bob <bob@corp.com>", nil), 0)
+	assert.Len(t, ParseTrailers("No trailers", DefaultCoAuthorTrailers), 0)
+}
+
+func TestNormalizeCoAuthors(t *testing.T) {
+	assert.Equal(t, normalizeCoAuthors(""), CoAuthorsNone)
+	assert.Equal(t, normalizeCoAuthors(CoAuthorsSplit), CoAuthorsSplit)
+	assert.Equal(t, normalizeCoAuthors(CoAuthorsDuplicate), CoAuthorsDuplicate)
+	assert.Equal(t, normalizeCoAuthors("share"), CoAuthorsNone)
+}
+
+func fixtureCoAuthorsCommits() []*object.Commit {
+	when := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+	signature := func(name string) object.Signature {
+		return object.Signature{Name: name, Email: name + "@corp.com", When: when}
+	}
+	return storeSplitCommits([]*object.Commit{
+		{Author: signature("alice"), Message: "Pair on the parser\n\n" +
+			"Co-authored-by: bob <bob@corp.com>\nCo-authored-by: alice <alice@corp.com>\n"},
+		{Author: signature("bob"), Message: "Fix\n\nSigned-off-by: carol <carol@corp.com>\n"},
+	})
+}
+
+func TestIdentityDetectorCoAuthors(t *testing.T) {
+	commits := fixtureCoAuthorsCommits()
+	consume := func(id *Detector) [][]int {
+		var coAuthors [][]int
+		for _, commit := range commits {
+			result, err := id.Consume(map[string]interface{}{core.DependencyCommit: commit})
+			assert.Nil(t, err)
+			coAuthors = append(coAuthors, result[DependencyCoAuthors].([]int))
+		}
+		return coAuthors
+	}
+
+	id := &Detector{}
+	facts := map[string]interface{}{core.ConfigPipelineCommits: commits}
+	id.Configure(facts)
+	assert.Equal(t, id.CoAuthors, CoAuthorsNone)
+	assert.Equal(t, id.CoAuthorTrailers, DefaultCoAuthorTrailers)
+	assert.Equal(t, facts[FactIdentityDetectorCoAuthors], CoAuthorsNone)
+	assert.Equal(t, id.ReversedPeopleDict, []string{"alice|alice@corp.com", "bob|bob@corp.com"})
+	assert.Equal(t, consume(id), [][]int{nil, nil})
+
+	id = &Detector{}
+	facts = map[string]interface{}{
+		ConfigIdentityDetectorCoAuthors:        CoAuthorsSplit,
+		ConfigIdentityDetectorCoAuthorTrailers: []string{"Co-authored-by", "Signed-off-by"},
+		core.ConfigPipelineCommits:             commits,
+	}
+	id.Configure(facts)
+	assert.Equal(t, facts[FactIdentityDetectorCoAuthors], CoAuthorsSplit)
+	assert.Equal(t, id.ReversedPeopleDict, []string{
+		"alice|alice@corp.com", "bob|bob@corp.com", "carol|carol@corp.com"})
+	assert.Equal(t, consume(id), [][]int{{1}, {2}})
+
+	id = &Detector{}
+	facts = map[string]interface{}{
+		ConfigIdentityDetectorCoAuthors: CoAuthorsDuplicate,
+		core.ConfigPipelineCommits:      commits,
+	}
+	id.Configure(facts)
+	assert.Equal(t, id.ReversedPeopleDict, []string{"alice|alice@corp.com", "bob|bob@corp.com"})
+	assert.Equal(t, consume(id), [][]int{{1}, nil})
+}
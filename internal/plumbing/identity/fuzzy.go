@@ -0,0 +1,339 @@
+package identity
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+const (
+	// MergeReasonTransliteration means that the names are the same after the transliteration
+	// to ASCII, e.g. "José" and "Jose".
+	MergeReasonTransliteration = "transliteration"
+	// MergeReasonEmailLocalPart means that the emails have the same part before "@".
+	MergeReasonEmailLocalPart = "email-local-part"
+	// MergeReasonNameDistance means that the Levenshtein distance between the names
+	// does not exceed Detector.NameDistance.
+	MergeReasonNameDistance = "name-distance"
+
+	// fuzzyNameMinLength is the minimum number of letters in a name to be compared
+	// by the Levenshtein distance. Short names are too often similar by chance.
+	fuzzyNameMinLength = 6
+	// emailLocalPartMinLength is the minimum length of the matched email local parts.
+	emailLocalPartMinLength = 3
+)
+
+// IdentityMerge describes two identities which were merged by the fuzzy matching.
+type IdentityMerge struct {
+	// Identity is the index of the resulting identity.
+	Identity int
+	// Merged is the identity which was absorbed, in the people dictionary format.
+	Merged string
+	// Reason is one of MergeReason* constants.
+	Reason string
+	// Evidence is the matched pair of names or emails.
+	Evidence string
+}
+
+// genericEmailLocalParts are not personal and thus never matched.
+var genericEmailLocalParts = map[string]bool{
+	"admin": true, "bot": true, "build": true, "ci": true, "contact": true, "dev": true,
+	"developer": true, "git": true, "github": true, "hello": true, "info": true, "mail": true,
+	"me": true, "no-reply": true, "noreply": true, "release": true, "root": true,
+	"support": true, "team": true, "test": true, "user": true,
+}
+
+// transliterations map the non-ASCII letters to their Latin equivalents.
+var transliterations = func() map[rune]string {
+	table := map[rune]string{}
+	groups := [...][2]string{
+		{"àáâãäåāăą", "a"}, {"çćĉċč", "c"}, {"ďđ", "d"}, {"èéêëēĕėęě", "e"}, {"ĝğġģ", "g"},
+		{"ĥħ", "h"}, {"ìíîïĩīĭįı", "i"}, {"ĵ", "j"}, {"ķ", "k"}, {"ĺļľŀł", "l"},
+		{"ñńņňŉ", "n"}, {"òóôõöøōŏő", "o"}, {"ŕŗř", "r"}, {"śŝşšș", "s"}, {"ţťŧț", "t"},
+		{"ùúûüũūŭůűų", "u"}, {"ŵ", "w"}, {"ýÿŷ", "y"}, {"źżž", "z"},
+		{"ß", "ss"}, {"æ", "ae"}, {"œ", "oe"}, {"þ", "th"}, {"ð", "d"},
+		// Cyrillic
+		{"а", "a"}, {"б", "b"}, {"в", "v"}, {"гґ", "g"}, {"д", "d"}, {"её", "e"}, {"є", "ye"},
+		{"ж", "zh"}, {"з", "z"}, {"иіы", "i"}, {"ї", "yi"}, {"й", "y"}, {"к", "k"}, {"л", "l"},
+		{"м", "m"}, {"н", "n"}, {"о", "o"}, {"п", "p"}, {"р", "r"}, {"с", "s"}, {"т", "t"},
+		{"уў", "u"}, {"ф", "f"}, {"х", "kh"}, {"ц", "ts"}, {"ч", "ch"}, {"ш", "sh"},
+		{"щ", "shch"}, {"ъь", ""}, {"э", "e"}, {"ю", "yu"}, {"я", "ya"},
+		// Greek
+		{"αά", "a"}, {"β", "v"}, {"γ", "g"}, {"δ", "d"}, {"εέ", "e"}, {"ζ", "z"}, {"ηή", "i"},
+		{"θ", "th"}, {"ιίϊΐ", "i"}, {"κ", "k"}, {"λ", "l"}, {"μ", "m"}, {"ν", "n"}, {"ξ", "x"},
+		{"οό", "o"}, {"π", "p"}, {"ρ", "r"}, {"σς", "s"}, {"τ", "t"}, {"υύϋΰ", "y"}, {"φ", "f"},
+		{"χ", "ch"}, {"ψ", "ps"}, {"ωώ", "o"},
+	}
+	for _, group := range groups {
+		for _, r := range group[0] {
+			table[r] = group[1]
+		}
+	}
+	return table
+}()
+
+// Transliterate converts the lower case name to ASCII: the diacritics are removed and Cyrillic
+// and Greek letters are replaced with the Latin ones. Punctuation is removed and the spaces
+// are collapsed.
+func Transliterate(name string) string {
+	var builder strings.Builder
+	for _, r := range name {
+		if latin, exists := transliterations[r]; exists {
+			builder.WriteString(latin)
+		} else if unicode.IsLetter(r) || unicode.IsDigit(r) || r == ' ' {
+			builder.WriteRune(r)
+		}
+	}
+	return strings.Join(strings.Fields(builder.String()), " ")
+}
+
+// levenshtein calculates the edit distance between two strings in runes.
+func levenshtein(s1, s2 string) int {
+	r1, r2 := []rune(s1), []rune(s2)
+	prev := make([]int, len(r2)+1)
+	curr := make([]int, len(r2)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(r1); i++ {
+		curr[0] = i
+		for j := 1; j <= len(r2); j++ {
+			cost := 1
+			if r1[i-1] == r2[j-1] {
+				cost = 0
+			}
+			curr[j] = curr[j-1] + 1
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if prev[j-1]+cost < curr[j] {
+				curr[j] = prev[j-1] + cost
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(r2)]
+}
+
+// emailLocalPart returns the part of the email before "@" without "+tag" or an empty string
+// if it is too short or not personal.
+func emailLocalPart(email string) string {
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return ""
+	}
+	local := email[:at]
+	if plus := strings.IndexByte(local, '+'); plus >= 0 {
+		local = local[:plus]
+	}
+	if len(local) < emailLocalPartMinLength || genericEmailLocalParts[local] {
+		return ""
+	}
+	return local
+}
+
+// identityMerger joins the identities with the disjoint-set forest.
+type identityMerger struct {
+	parent    []int
+	names     map[int][]string
+	emails    map[int][]string
+	canonical map[int]string
+	merges    []IdentityMerge
+}
+
+func (merger *identityMerger) find(id int) int {
+	for merger.parent[id] != id {
+		merger.parent[id] = merger.parent[merger.parent[id]]
+		id = merger.parent[id]
+	}
+	return id
+}
+
+// union merges the identities of `a` and `b` unless they are already the same or both were
+// listed in PeopleDictFile.
+func (merger *identityMerger) union(a, b int, reason, evidence string) {
+	ra, rb := merger.find(a), merger.find(b)
+	if ra == rb {
+		return
+	}
+	if _, exists := merger.canonical[ra]; exists {
+		if _, exists := merger.canonical[rb]; exists {
+			return
+		}
+	}
+	if rb < ra {
+		ra, rb = rb, ra
+	}
+	merger.merges = append(merger.merges, IdentityMerge{
+		Identity: ra,
+		Merged:   strings.Join(append(append([]string{}, merger.names[rb]...), merger.emails[rb]...), "|"),
+		Reason:   reason,
+		Evidence: evidence,
+	})
+	merger.parent[rb] = ra
+	merger.names[ra] = append(merger.names[ra], merger.names[rb]...)
+	merger.emails[ra] = append(merger.emails[ra], merger.emails[rb]...)
+	sort.Strings(merger.names[ra])
+	sort.Strings(merger.emails[ra])
+	delete(merger.names, rb)
+	delete(merger.emails, rb)
+	if person, exists := merger.canonical[rb]; exists {
+		merger.canonical[ra] = person
+		delete(merger.canonical, rb)
+	}
+}
+
+// mergeFuzzyIdentities joins the identities with similar names or emails according to
+// ResolveGitHubNoreply, Transliterate, MatchEmailLocalPart and NameDistance. `dict`, `names`, `emails` and `canonical`
+// are the intermediate state of GeneratePeopleDict() and are updated in place.
+// Returns the new number of identities.
+func (detector *Detector) mergeFuzzyIdentities(
+	dict map[string]int, names, emails map[int][]string, canonical map[int]string, size int) int {
+	detector.Merges = nil
+	if !detector.ResolveGitHubNoreply && !detector.Transliterate &&
+		!detector.MatchEmailLocalPart && detector.NameDistance <= 0 {
+		return size
+	}
+	merger := &identityMerger{
+		parent: make([]int, size), names: map[int][]string{}, emails: map[int][]string{},
+		canonical: map[int]string{},
+	}
+	for id := range merger.parent {
+		merger.parent[id] = id
+		merger.names[id] = append([]string{}, names[id]...)
+		merger.emails[id] = append([]string{}, emails[id]...)
+		sort.Strings(merger.names[id])
+		sort.Strings(merger.emails[id])
+	}
+	for id, person := range canonical {
+		merger.canonical[id] = person
+	}
+	normalize := func(name string) string {
+		if detector.Transliterate {
+			return Transliterate(name)
+		}
+		return strings.Join(strings.Fields(name), " ")
+	}
+	type signature struct {
+		key, original string
+		id            int
+	}
+	collect := func(source map[int][]string, keyer func(string) string) []signature {
+		var result []signature
+		for id := 0; id < size; id++ {
+			vals := append([]string{}, source[id]...)
+			sort.Strings(vals)
+			for _, val := range vals {
+				if key := keyer(val); key != "" {
+					result = append(result, signature{key: key, original: val, id: id})
+				}
+			}
+		}
+		return result
+	}
+	matchExact := func(sigs []signature, reason string) {
+		first := map[string]signature{}
+		for _, sig := range sigs {
+			if prev, exists := first[sig.key]; exists {
+				if prev.id != sig.id {
+					merger.union(prev.id, sig.id, reason, prev.original+" = "+sig.original)
+				}
+				continue
+			}
+			first[sig.key] = sig
+		}
+	}
+	if detector.ResolveGitHubNoreply {
+		detector.mergeGitHubNoreply(merger, dict, emails, size)
+	}
+	if detector.Transliterate {
+		matchExact(collect(names, Transliterate), MergeReasonTransliteration)
+	}
+	if detector.MatchEmailLocalPart {
+		matchExact(collect(emails, emailLocalPart), MergeReasonEmailLocalPart)
+	}
+	if detector.NameDistance > 0 {
+		sigs := collect(names, func(name string) string {
+			name = normalize(name)
+			if utf8.RuneCountInString(name) < fuzzyNameMinLength {
+				return ""
+			}
+			return name
+		})
+		length := func(i int) int { return utf8.RuneCountInString(sigs[i].key) }
+		sort.SliceStable(sigs, func(i, j int) bool { return length(i) < length(j) })
+		for i := range sigs {
+			for j := i + 1; j < len(sigs) && length(j)-length(i) <= detector.NameDistance; j++ {
+				if merger.find(sigs[i].id) == merger.find(sigs[j].id) {
+					continue
+				}
+				if distance := levenshtein(sigs[i].key, sigs[j].key); distance <= detector.NameDistance {
+					merger.union(sigs[i].id, sigs[j].id, MergeReasonNameDistance,
+						fmt.Sprintf("%s ~ %s (%d)", sigs[i].original, sigs[j].original, distance))
+				}
+			}
+		}
+	}
+	if len(merger.merges) == 0 {
+		return size
+	}
+	// renumber the identities densely keeping the order
+	newIDs := make([]int, size)
+	newSize := 0
+	for id := 0; id < size; id++ {
+		if merger.find(id) == id {
+			newIDs[id] = newSize
+			newSize++
+		}
+	}
+	for key, id := range dict {
+		dict[key] = newIDs[merger.find(id)]
+	}
+	for id := 0; id < size; id++ {
+		delete(names, id)
+		delete(emails, id)
+		delete(canonical, id)
+	}
+	for id, val := range merger.names {
+		names[newIDs[id]] = val
+	}
+	for id, val := range merger.emails {
+		emails[newIDs[id]] = val
+	}
+	for id, person := range merger.canonical {
+		canonical[newIDs[id]] = person
+	}
+	for i := range merger.merges {
+		merger.merges[i].Identity = newIDs[merger.find(merger.merges[i].Identity)]
+	}
+	detector.Merges = merger.merges
+	return newSize
+}
+
+// WriteMergesReport writes the audit report about the identities merged by the fuzzy matching
+// in GeneratePeopleDict() in YAML format.
+func (detector *Detector) WriteMergesReport(writer io.Writer) {
+	fmt.Fprintln(writer, "merges:")
+	for _, merge := range detector.Merges {
+		fmt.Fprintf(writer, "  - identity: %s\n",
+			yaml.SafeString(detector.ReversedPeopleDict[merge.Identity]))
+		fmt.Fprintf(writer, "    merged: %s\n", yaml.SafeString(merge.Merged))
+		fmt.Fprintf(writer, "    reason: %s\n", merge.Reason)
+		fmt.Fprintf(writer, "    evidence: %s\n", yaml.SafeString(merge.Evidence))
+	}
+}
+
+func (detector *Detector) saveMergesReport(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	detector.WriteMergesReport(file)
+	return file.Close()
+}
@@ -0,0 +1,132 @@
+package identity
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+func TestTransliterate(t *testing.T) {
+	assert.Equal(t, Transliterate("josé garcía"), "jose garcia")
+	assert.Equal(t, Transliterate("иван  петров"), "ivan petrov")
+	assert.Equal(t, Transliterate("γιώργος"), "giorgos")
+	assert.Equal(t, Transliterate("jürgen o'neill-straße"), "jurgen oneillstrasse")
+	assert.Equal(t, Transliterate("王小明"), "王小明")
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, levenshtein("", ""), 0)
+	assert.Equal(t, levenshtein("abc", ""), 3)
+	assert.Equal(t, levenshtein("jon smith", "john smith"), 1)
+	assert.Equal(t, levenshtein("kitten", "sitting"), 3)
+	assert.Equal(t, levenshtein("josé", "jose"), 1)
+}
+
+func TestEmailLocalPart(t *testing.T) {
+	assert.Equal(t, emailLocalPart("john.smith+github@gmail.com"), "john.smith")
+	assert.Equal(t, emailLocalPart("bob@corp.com"), "bob")
+	assert.Equal(t, emailLocalPart("me@corp.com"), "")
+	assert.Equal(t, emailLocalPart("noreply@github.com"), "")
+	assert.Equal(t, emailLocalPart("nobody"), "")
+}
+
+func fixtureFuzzyCommits() []*object.Commit {
+	signatures := [...][2]string{
+		{"José García", "jose@corp.com"},
+		{"Jose Garcia", "jgarcia@home.net"},
+		{"Jon Smith", "jsmith@corp.com"},
+		{"John Smith", "john.smith@gmail.com"},
+		{"Иван Петров", "ivan@corp.com"},
+		{"Ivan Petrov", "ivan@gmail.com"},
+		{"Bob", "bob@corp.com"},
+		{"Robert", "bob@home.net"},
+		{"Admin", "admin@corp.com"},
+		{"Root", "admin@home.net"},
+		{"Max Mustermann", "max@corp.com"},
+		{"Max Musterman", "mm@x.org"},
+	}
+	commits := make([]*object.Commit, len(signatures))
+	for i, sig := range signatures {
+		commits[i] = &object.Commit{Author: object.Signature{Name: sig[0], Email: sig[1]}}
+	}
+	return storeSplitCommits(commits)
+}
+
+func TestIdentityDetectorFuzzyMerge(t *testing.T) {
+	commits := fixtureFuzzyCommits()
+	id := Detector{}
+	id.GeneratePeopleDict(commits)
+	assert.Len(t, id.ReversedPeopleDict, 12)
+	assert.Nil(t, id.Merges)
+
+	dir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	reportPath := filepath.Join(dir, "merges.yml")
+	id = Detector{}
+	id.Configure(map[string]interface{}{
+		ConfigIdentityDetectorNameDistance:        1,
+		ConfigIdentityDetectorMatchEmailLocalPart: true,
+		ConfigIdentityDetectorTransliterate:       true,
+		ConfigIdentityDetectorMergeReport:         reportPath,
+		core.ConfigPipelineCommits:                commits,
+	})
+	assert.Equal(t, id.NameDistance, 1)
+	assert.True(t, id.MatchEmailLocalPart)
+	assert.True(t, id.Transliterate)
+	assert.Equal(t, id.ReversedPeopleDict, []string{
+		"jose garcia|josé garcía|jgarcia@home.net|jose@corp.com",
+		"john smith|jon smith|john.smith@gmail.com|jsmith@corp.com",
+		"ivan petrov|иван петров|ivan@corp.com|ivan@gmail.com",
+		"bob|robert|bob@corp.com|bob@home.net",
+		"admin|admin@corp.com",
+		"root|admin@home.net",
+		"max musterman|max mustermann|max@corp.com|mm@x.org",
+	})
+	assert.Equal(t, id.Merges, []IdentityMerge{
+		{0, "jose garcia|jgarcia@home.net", MergeReasonTransliteration, "josé garcía = jose garcia"},
+		{2, "ivan petrov|ivan@gmail.com", MergeReasonTransliteration, "иван петров = ivan petrov"},
+		{3, "robert|bob@home.net", MergeReasonEmailLocalPart, "bob@corp.com = bob@home.net"},
+		{1, "john smith|john.smith@gmail.com", MergeReasonNameDistance, "jon smith ~ john smith (1)"},
+		{6, "max musterman|mm@x.org", MergeReasonNameDistance, "max musterman ~ max mustermann (1)"},
+	})
+	for i, author := range []int{0, 0, 1, 1, 2, 2, 3, 3, 4, 5, 6, 6} {
+		result, err := id.Consume(map[string]interface{}{core.DependencyCommit: commits[i]})
+		assert.Nil(t, err)
+		assert.Equal(t, result[DependencyAuthor], author)
+	}
+	report, err := ioutil.ReadFile(reportPath)
+	assert.Nil(t, err)
+	buffer := &bytes.Buffer{}
+	id.WriteMergesReport(buffer)
+	assert.Equal(t, string(report), buffer.String())
+	assert.Contains(t, buffer.String(), `merges:
+  - identity: "jose garcia|josé garcía|jgarcia@home.net|jose@corp.com"
+    merged: "jose garcia|jgarcia@home.net"
+    reason: transliteration
+    evidence: "josé garcía = jose garcia"
+`)
+}
+
+func TestIdentityDetectorFuzzyMergeKeepsPeopleDictFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "people.yml")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(
+		"Jon Smith:\n  - jsmith@corp.com\nJohn Smith:\n  - john.smith@gmail.com\n"), 0666))
+	id := Detector{PeopleDictFile: path, NameDistance: 1}
+	id.GeneratePeopleDict(fixtureFuzzyCommits())
+	assert.Equal(t, id.ReversedPeopleDict[0], "John Smith|john.smith@gmail.com")
+	assert.Equal(t, id.ReversedPeopleDict[1], "Jon Smith|jsmith@corp.com")
+	// the two listed developers are never merged
+	assert.Equal(t, id.Merges, []IdentityMerge{
+		{10, "max musterman|mm@x.org", MergeReasonNameDistance, "max musterman ~ max mustermann (1)"},
+	})
+}
@@ -0,0 +1,128 @@
+package identity
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MergeReasonGitHubNoreply means that the GitHub user behind the noreply email has the same
+// public email or name as the other identity.
+const MergeReasonGitHubNoreply = "github-noreply"
+
+// githubAPIURL is the root of the GitHub REST API. It is changed in the tests.
+var githubAPIURL = "https://api.github.com"
+
+// githubNoreplyRegexp matches "login@users.noreply.github.com" and
+// "12345+login@users.noreply.github.com".
+var githubNoreplyRegexp = regexp.MustCompile(
+	`^(?:\d+\+)?([a-z0-9](?:[a-z0-9-]*[a-z0-9])?)@users\.noreply\.github\.com$`)
+
+// githubUser is the subset of the GitHub user profile which is used to resolve
+// the noreply emails.
+type githubUser struct {
+	Login string `json:"login"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// GitHubNoreplyLogin returns the GitHub login encoded in the noreply email or an empty string
+// if the email is not a GitHub noreply address.
+func GitHubNoreplyLogin(email string) string {
+	match := githubNoreplyRegexp.FindStringSubmatch(strings.ToLower(email))
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// fetchGitHubUser requests the public profile of the GitHub user. It returns nil without
+// an error if the user does not exist.
+func (detector *Detector) fetchGitHubUser(client *http.Client, login string) (*githubUser, error) {
+	request, err := http.NewRequest("GET", githubAPIURL+"/users/"+url.PathEscape(login), nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/vnd.github.v3+json")
+	if detector.GitHubToken != "" {
+		request.Header.Set("Authorization", "token "+detector.GitHubToken)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", request.URL, response.Status)
+	}
+	user := &githubUser{}
+	if err = json.NewDecoder(response.Body).Decode(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// mergeGitHubNoreply joins the identities with the GitHub noreply emails and the identities
+// with the same public email or name of the corresponding GitHub users. The noreply emails
+// with the same login are joined without any requests. The resolution stops at the first
+// failed request, e.g. when the API rate limit is exceeded.
+func (detector *Detector) mergeGitHubNoreply(
+	merger *identityMerger, dict map[string]int, emails map[int][]string, size int) {
+	type noreply struct {
+		email string
+		id    int
+	}
+	logins := map[string][]noreply{}
+	for id := 0; id < size; id++ {
+		for _, email := range emails[id] {
+			if login := GitHubNoreplyLogin(email); login != "" {
+				logins[login] = append(logins[login], noreply{email, id})
+			}
+		}
+	}
+	sortedLogins := make([]string, 0, len(logins))
+	for login, addresses := range logins {
+		sortedLogins = append(sortedLogins, login)
+		sort.Slice(addresses, func(i, j int) bool { return addresses[i].email < addresses[j].email })
+	}
+	sort.Strings(sortedLogins)
+	client := &http.Client{Timeout: 30 * time.Second}
+	online := true
+	for _, login := range sortedLogins {
+		addresses := logins[login]
+		for _, address := range addresses[1:] {
+			merger.union(addresses[0].id, address.id, MergeReasonGitHubNoreply,
+				addresses[0].email+" = "+address.email)
+		}
+		if !online {
+			continue
+		}
+		user, err := detector.fetchGitHubUser(client, login)
+		if err != nil {
+			log.Printf("Warning: failed to resolve the GitHub noreply emails: %v\n", err)
+			online = false
+			continue
+		}
+		if user == nil {
+			continue
+		}
+		for _, key := range [...]string{strings.ToLower(user.Email), strings.ToLower(user.Name)} {
+			if key == "" {
+				continue
+			}
+			if id, exists := dict[key]; exists {
+				merger.union(addresses[0].id, id, MergeReasonGitHubNoreply,
+					fmt.Sprintf("%s is github.com/%s with %s", addresses[0].email, user.Login, key))
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package identity
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+func TestGitHubNoreplyLogin(t *testing.T) {
+	assert.Equal(t, GitHubNoreplyLogin("jdoe@users.noreply.github.com"), "jdoe")
+	assert.Equal(t, GitHubNoreplyLogin("12345+J-Doe@users.noreply.github.com"), "j-doe")
+	assert.Equal(t, GitHubNoreplyLogin("noreply@github.com"), "")
+	assert.Equal(t, GitHubNoreplyLogin("jdoe@gmail.com"), "")
+	assert.Equal(t, GitHubNoreplyLogin("-x@users.noreply.github.com"), "")
+}
+
+func fakeGitHubAPI(t *testing.T, requests *[]string) *httptest.Server {
+	users := map[string]string{
+		"jdoe":  `{"login": "jdoe", "name": "Johnny", "email": "john@corp.com"}`,
+		"alice": `{"login": "alice", "name": "Alice Liddell", "email": null}`,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Header.Get("Authorization"), "token secret")
+		login := strings.TrimPrefix(r.URL.Path, "/users/")
+		*requests = append(*requests, login)
+		if user, exists := users[login]; exists {
+			fmt.Fprint(w, user)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+}
+
+func TestIdentityDetectorGitHubNoreply(t *testing.T) {
+	var requests []string
+	server := fakeGitHubAPI(t, &requests)
+	defer server.Close()
+	defer func(url string) { githubAPIURL = url }(githubAPIURL)
+	githubAPIURL = server.URL
+
+	signatures := [...][2]string{
+		{"John Doe", "john@corp.com"},
+		{"jdoe", "12345+jdoe@users.noreply.github.com"},
+		{"J. Doe", "jdoe@users.noreply.github.com"},
+		{"Alice Liddell", "alice@corp.com"},
+		{"alice", "alice@users.noreply.github.com"},
+		{"Ghost", "ghost@users.noreply.github.com"},
+	}
+	commits := make([]*object.Commit, len(signatures))
+	for i, sig := range signatures {
+		commits[i] = &object.Commit{Author: object.Signature{Name: sig[0], Email: sig[1]}}
+	}
+	commits = storeSplitCommits(commits)
+	id := Detector{}
+	id.Configure(map[string]interface{}{
+		ConfigIdentityDetectorGitHubNoreply: true,
+		ConfigIdentityDetectorGitHubToken:   "secret",
+		core.ConfigPipelineCommits:          commits,
+	})
+	assert.True(t, id.ResolveGitHubNoreply)
+	assert.Equal(t, id.GitHubToken, "secret")
+	assert.Equal(t, requests, []string{"alice", "ghost", "jdoe"})
+	assert.Equal(t, id.ReversedPeopleDict, []string{
+		"j. doe|jdoe|john doe|12345+jdoe@users.noreply.github.com|" +
+			"jdoe@users.noreply.github.com|john@corp.com",
+		"alice|alice liddell|alice@corp.com|alice@users.noreply.github.com",
+		"ghost|ghost@users.noreply.github.com",
+	})
+	assert.Equal(t, id.Merges, []IdentityMerge{
+		{1, "alice|alice@users.noreply.github.com", MergeReasonGitHubNoreply,
+			"alice@users.noreply.github.com is github.com/alice with alice liddell"},
+		{0, "j. doe|jdoe@users.noreply.github.com", MergeReasonGitHubNoreply,
+			"12345+jdoe@users.noreply.github.com = jdoe@users.noreply.github.com"},
+		{0, "j. doe|jdoe|12345+jdoe@users.noreply.github.com|jdoe@users.noreply.github.com",
+			MergeReasonGitHubNoreply,
+			"12345+jdoe@users.noreply.github.com is github.com/jdoe with john@corp.com"},
+	})
+}
+
+func TestIdentityDetectorGitHubNoreplyOffline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "API rate limit exceeded", http.StatusForbidden)
+	}))
+	defer server.Close()
+	defer func(url string) { githubAPIURL = url }(githubAPIURL)
+	githubAPIURL = server.URL
+
+	commits := storeSplitCommits([]*object.Commit{
+		{Author: object.Signature{Name: "John Doe", Email: "john@corp.com"}},
+		{Author: object.Signature{Name: "jdoe", Email: "12345+jdoe@users.noreply.github.com"}},
+		{Author: object.Signature{Name: "jdoe", Email: "jdoe@users.noreply.github.com"}},
+	})
+	id := Detector{ResolveGitHubNoreply: true}
+	id.GeneratePeopleDict(commits)
+	// the noreply emails of the same login are still merged
+	assert.Equal(t, id.ReversedPeopleDict, []string{
+		"john doe|john@corp.com",
+		"jdoe|12345+jdoe@users.noreply.github.com|jdoe@users.noreply.github.com",
+	})
+}
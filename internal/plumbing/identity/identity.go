@@ -2,13 +2,18 @@ package identity
 
 import (
 	"bufio"
+	"fmt"
+	"io/ioutil"
+	"log"
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
 	"gopkg.in/src-d/hercules.v4/internal/core"
+	goyaml "gopkg.in/yaml.v2"
 	)
 
 // Detector determines the author of a commit. Same person can commit under different
@@ -20,6 +25,75 @@ type Detector struct {
 	PeopleDict map[string]int
 	// ReversedPeopleDict maps developer id -> description
 	ReversedPeopleDict []string
+	// SplitGap is the minimum inactivity period of an email after which the commits with
+	// new author names are attributed to a different identity. 0 disables the splitting.
+	SplitGap time.Duration
+	// Splits is the audit report of the identities split by GeneratePeopleDict().
+	Splits []IdentitySplit
+	// MailmapPath is the path to the additional .mailmap file which GeneratePeopleDict() applies
+	// after the .mailmap in the repository, like mailmap.file in Git.
+	MailmapPath string
+	// PeopleDictFile is the path to the YAML or JSON file which maps the canonical names
+	// of the developers to the lists of their emails and names. GeneratePeopleDict() applies it
+	// before the heuristics.
+	PeopleDictFile string
+	// NameDistance is the maximum Levenshtein distance between the names of two identities
+	// which are merged. 0 disables the fuzzy name matching.
+	NameDistance int
+	// MatchEmailLocalPart merges the identities whose emails have the same part before "@".
+	MatchEmailLocalPart bool
+	// Transliterate converts the names to ASCII before the matching, e.g. "Йосип" to "yosip".
+	Transliterate bool
+	// ResolveGitHubNoreply merges the identities with the GitHub noreply emails
+	// (login@users.noreply.github.com) with the other emails and names of the same GitHub users.
+	// It requests the public user profiles through the GitHub API.
+	ResolveGitHubNoreply bool
+	// GitHubToken is the GitHub API token for ResolveGitHubNoreply. It is optional but the
+	// anonymous requests are severely rate limited.
+	GitHubToken string
+	// TeamsFile is the path to the YAML or JSON file which maps the team names to the lists of
+	// the names and emails of their members, in the same format as PeopleDictFile. If it is set,
+	// the identities become the teams, so that all the per-developer analyses aggregate
+	// by team. The developers who are not listed form TeamUnassigned.
+	TeamsFile string
+	// ActivityPolicy defines which developers are active, see ActiveDevelopers.
+	ActivityPolicy ActivityPolicy
+	// Resolver is the active identity resolver. If it is nil, Configure() creates the one named
+	// by ConfigIdentityDetectorResolver.
+	Resolver Resolver
+	// ActiveDevelopers is the activity of the developers according to ActivityPolicy.
+	// Configure() calculates it and publishes as FactIdentityDetectorActiveDevelopers.
+	ActiveDevelopers *ActiveDevelopers
+	// Anonymize replaces the developers in ReversedPeopleDict with the salted hashes,
+	// see AnonymizeIdentity(). The audit reports are not anonymized.
+	Anonymize bool
+	// AnonymizeSalt is the salt of the anonymized identities. Configure() generates a random
+	// salt if it is empty; setting the same salt makes the identities comparable across runs.
+	AnonymizeSalt string
+	// Merges is the audit report of the identities merged by the fuzzy matching
+	// in GeneratePeopleDict().
+	Merges []IdentityMerge
+	// Attribution selects who is credited with the commits: AttributionAuthor,
+	// AttributionCommitter or AttributionBoth. The rebases and the patch-based workflows
+	// make the authors misleading for some analyses.
+	Attribution string
+	// CoAuthors selects how the co-authors from the commit message trailers are credited:
+	// CoAuthorsNone, CoAuthorsDuplicate or CoAuthorsSplit. The analyses which support
+	// the co-authors read it from FactIdentityDetectorCoAuthors.
+	CoAuthors string
+	// CoAuthorTrailers are the commit message trailers which list the co-authors,
+	// e.g. "Co-authored-by", "Signed-off-by" or "Reviewed-by".
+	CoAuthorTrailers []string
+
+	// sharedEmails are the emails which are resolved by the author names because they were
+	// used by several people.
+	sharedEmails map[string]bool
+	// mailmap maps the signatures to the canonical ones before the matching.
+	mailmap *Mailmap
+	// mapped are the emails from PeopleDictFile, they are never split.
+	mapped map[string]bool
+	// teams maps the developer indices returned by Resolver to the team indices.
+	teams []int
 }
 
 const (
@@ -44,9 +118,92 @@ const (
 	// Detector.Configure(). It is equal to the overall number of unique authors
 	// (the length of ReversedPeopleDict).
 	FactIdentityDetectorPeopleCount = "IdentityDetector.PeopleCount"
+	// ConfigIdentityDetectorSplitGap is the name of the configuration option
+	// (Detector.Configure()) which sets Detector.SplitGap in days.
+	ConfigIdentityDetectorSplitGap = "IdentityDetector.SplitGap"
+	// ConfigIdentityDetectorSplitReport is the name of the configuration option
+	// (Detector.Configure()) which sets the path to the audit report about the split identities.
+	ConfigIdentityDetectorSplitReport = "IdentityDetector.SplitReport"
+	// ConfigIdentityDetectorMailmapPath is the name of the configuration option
+	// (Detector.Configure()) which sets Detector.MailmapPath.
+	ConfigIdentityDetectorMailmapPath = "IdentityDetector.MailmapPath"
+	// ConfigIdentityDetectorPeopleDictFile is the name of the configuration option
+	// (Detector.Configure()) which sets Detector.PeopleDictFile.
+	ConfigIdentityDetectorPeopleDictFile = "IdentityDetector.PeopleDictFile"
+	// ConfigIdentityDetectorNameDistance is the name of the configuration option
+	// (Detector.Configure()) which sets Detector.NameDistance.
+	ConfigIdentityDetectorNameDistance = "IdentityDetector.NameDistance"
+	// ConfigIdentityDetectorMatchEmailLocalPart is the name of the configuration option
+	// (Detector.Configure()) which sets Detector.MatchEmailLocalPart.
+	ConfigIdentityDetectorMatchEmailLocalPart = "IdentityDetector.MatchEmailLocalPart"
+	// ConfigIdentityDetectorTransliterate is the name of the configuration option
+	// (Detector.Configure()) which sets Detector.Transliterate.
+	ConfigIdentityDetectorTransliterate = "IdentityDetector.Transliterate"
+	// ConfigIdentityDetectorMergeReport is the name of the configuration option
+	// (Detector.Configure()) which sets the path to the audit report about the merged identities.
+	ConfigIdentityDetectorMergeReport = "IdentityDetector.MergeReport"
+	// ConfigIdentityDetectorGitHubNoreply is the name of the configuration option
+	// (Detector.Configure()) which sets Detector.ResolveGitHubNoreply.
+	ConfigIdentityDetectorGitHubNoreply = "IdentityDetector.GitHubNoreply"
+	// ConfigIdentityDetectorGitHubToken is the name of the configuration option
+	// (Detector.Configure()) which sets Detector.GitHubToken.
+	ConfigIdentityDetectorGitHubToken = "IdentityDetector.GitHubToken"
+	// ConfigIdentityDetectorTeamsFile is the name of the configuration option
+	// (Detector.Configure()) which sets Detector.TeamsFile.
+	ConfigIdentityDetectorTeamsFile = "IdentityDetector.TeamsFile"
+	// ConfigIdentityDetectorActiveMinCommits is the name of the configuration option
+	// (Detector.Configure()) which sets Detector.ActivityPolicy.MinCommits.
+	ConfigIdentityDetectorActiveMinCommits = "IdentityDetector.ActiveMinCommits"
+	// ConfigIdentityDetectorActiveMinLines is the name of the configuration option
+	// (Detector.Configure()) which sets Detector.ActivityPolicy.MinLines.
+	ConfigIdentityDetectorActiveMinLines = "IdentityDetector.ActiveMinLines"
+	// ConfigIdentityDetectorActiveWindow is the name of the configuration option
+	// (Detector.Configure()) which sets Detector.ActivityPolicy.Window in days.
+	ConfigIdentityDetectorActiveWindow = "IdentityDetector.ActiveWindow"
+	// ConfigIdentityDetectorAnonymize is the name of the configuration option
+	// (Detector.Configure()) which sets Detector.Anonymize.
+	ConfigIdentityDetectorAnonymize = "IdentityDetector.Anonymize"
+	// ConfigIdentityDetectorAnonymizeSalt is the name of the configuration option
+	// (Detector.Configure()) which sets Detector.AnonymizeSalt.
+	ConfigIdentityDetectorAnonymizeSalt = "IdentityDetector.AnonymizeSalt"
+	// ConfigIdentityDetectorResolver is the name of the configuration option
+	// (Detector.Configure()) which selects the Resolver by name.
+	ConfigIdentityDetectorResolver = "IdentityDetector.Resolver"
+	// ConfigIdentityDetectorAttribution is the name of the configuration option
+	// (Detector.Configure()) which selects who is credited with the commits: the authors,
+	// the committers or both, see Detector.Attribution.
+	ConfigIdentityDetectorAttribution = "IdentityDetector.Attribution"
+	// FactIdentityDetectorAttribution is the name of the fact which is inserted in
+	// Detector.Configure(). It corresponds to Detector.Attribution, the analyses pass it
+	// to AttributedSignature() to take the time of the attributed signature.
+	FactIdentityDetectorAttribution = "IdentityDetector.AttributionMode"
+	// FactIdentityDetectorActiveDevelopers is the name of the fact which is inserted in
+	// Detector.Configure(). It corresponds to Detector.ActiveDevelopers - the shared
+	// definition of who is active in each time window.
+	FactIdentityDetectorActiveDevelopers = "IdentityDetector.ActiveDevelopers"
+	// ConfigIdentityDetectorCoAuthors is the name of the configuration option
+	// (Detector.Configure()) which sets Detector.CoAuthors.
+	ConfigIdentityDetectorCoAuthors = "IdentityDetector.CoAuthors"
+	// FactIdentityDetectorCoAuthors is the name of the fact which is inserted in
+	// Detector.Configure(). It corresponds to Detector.CoAuthors.
+	FactIdentityDetectorCoAuthors = "IdentityDetector.CoAuthorsMode"
+	// ConfigIdentityDetectorCoAuthorTrailers is the name of the configuration option
+	// (Detector.Configure()) which sets Detector.CoAuthorTrailers.
+	ConfigIdentityDetectorCoAuthorTrailers = "IdentityDetector.CoAuthorTrailers"
 
 	// DependencyAuthor is the name of the dependency provided by Detector.
+	// It is the index of the developer who is credited with the commit according
+	// to Detector.Attribution.
 	DependencyAuthor = "author"
+	// DependencyCommitter is the name of the dependency provided by Detector.
+	// It is the index of the committer. Unless Detector.Attribution is AttributionCommitter
+	// or AttributionBoth, the committers who never authored are AuthorMissing.
+	DependencyCommitter = "committer"
+	// DependencyCoAuthors is the name of the dependency provided by Detector.
+	// It is the []int with the unique indices of the co-authors of the commit, excluding
+	// DependencyAuthor and the unmatched identities. It is empty unless Detector.CoAuthors
+	// is CoAuthorsDuplicate or CoAuthorsSplit.
+	DependencyCoAuthors = "co_authors"
 )
 
 // Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
@@ -58,7 +215,7 @@ func (detector *Detector) Name() string {
 // Each produced entity will be inserted into `deps` of dependent Consume()-s according
 // to this list. Also used by core.Registry to build the global map of providers.
 func (detector *Detector) Provides() []string {
-	arr := [...]string{DependencyAuthor}
+	arr := [...]string{DependencyAuthor, DependencyCommitter, DependencyCoAuthors}
 	return arr[:]
 }
 
@@ -76,13 +233,190 @@ func (detector *Detector) ListConfigurationOptions() []core.ConfigurationOption
 		Description: "Path to the developers' email associations.",
 		Flag:        "people-dict",
 		Type:        core.StringConfigurationOption,
-		Default:     ""},
+		Default:     ""}, {
+		Name: ConfigIdentityDetectorSplitGap,
+		Description: "Split the email which was inactive for at least this number of days and " +
+			"then used by a person with a different name, e.g. a handed over role account. " +
+			"0 disables.",
+		Flag:    "identity-split-gap",
+		Type:    core.IntConfigurationOption,
+		Default: 0}, {
+		Name:        ConfigIdentityDetectorSplitReport,
+		Description: "Write the report about the split identities to this YAML file.",
+		Flag:        "identity-split-report",
+		Type:        core.StringConfigurationOption,
+		Default:     ""}, {
+		Name: ConfigIdentityDetectorMailmapPath,
+		Description: "Path to the .mailmap file which is applied after the one in the repository, " +
+			"the same as mailmap.file in Git.",
+		Flag:    "mailmap",
+		Type:    core.StringConfigurationOption,
+		Default: ""}, {
+		Name: ConfigIdentityDetectorPeopleDictFile,
+		Description: "Path to the YAML or JSON file which maps the canonical developer names " +
+			"to the lists of their emails and names. Unlike --people-dict, the heuristics still " +
+			"merge the signatures which are not listed.",
+		Flag:    "people-dict-file",
+		Type:    core.StringConfigurationOption,
+		Default: ""}, {
+		Name: ConfigIdentityDetectorNameDistance,
+		Description: "Merge the developers whose names differ by at most this number of " +
+			"characters (Levenshtein distance). 0 disables.",
+		Flag:    "identity-name-distance",
+		Type:    core.IntConfigurationOption,
+		Default: 0}, {
+		Name: ConfigIdentityDetectorMatchEmailLocalPart,
+		Description: "Merge the developers whose emails have the same part before \"@\", " +
+			"e.g. john@company.com and john@gmail.com.",
+		Flag:    "identity-email-local-part",
+		Type:    core.BoolConfigurationOption,
+		Default: false}, {
+		Name: ConfigIdentityDetectorTransliterate,
+		Description: "Convert the names to ASCII before the matching: remove the diacritics and " +
+			"transliterate Cyrillic and Greek.",
+		Flag:    "identity-transliterate",
+		Type:    core.BoolConfigurationOption,
+		Default: false}, {
+		Name:        ConfigIdentityDetectorMergeReport,
+		Description: "Write the report about the fuzzy merged identities to this YAML file.",
+		Flag:        "identity-merge-report",
+		Type:        core.StringConfigurationOption,
+		Default:     ""}, {
+		Name: ConfigIdentityDetectorGitHubNoreply,
+		Description: "Resolve the GitHub noreply emails through the GitHub API and merge them " +
+			"with the public emails and names of the users.",
+		Flag:    "identity-github-noreply",
+		Type:    core.BoolConfigurationOption,
+		Default: false}, {
+		Name:        ConfigIdentityDetectorGitHubToken,
+		Description: "GitHub API token for --identity-github-noreply.",
+		Flag:        "github-token",
+		Type:        core.StringConfigurationOption,
+		Default:     ""}, {
+		Name: ConfigIdentityDetectorTeamsFile,
+		Description: "Path to the YAML or JSON file which maps the team names to the lists of " +
+			"their members' names and emails. All the per-developer stats are aggregated by team.",
+		Flag:    "teams",
+		Type:    core.StringConfigurationOption,
+		Default: ""}, {
+		Name: ConfigIdentityDetectorActiveMinCommits,
+		Description: "Minimum number of commits in a time window (--active-window) which makes " +
+			"a developer active. Shared by all the analyses.",
+		Flag:    "active-min-commits",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultActiveMinCommits}, {
+		Name: ConfigIdentityDetectorActiveMinLines,
+		Description: "Minimum number of changed lines in a time window (--active-window) which " +
+			"makes a developer active. Requires reading the diffs of all the commits.",
+		Flag:    "active-min-lines",
+		Type:    core.IntConfigurationOption,
+		Default: 0}, {
+		Name:        ConfigIdentityDetectorActiveWindow,
+		Description: "Length of the time window in days which defines the active developers.",
+		Flag:        "active-window",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultActiveWindow}, {
+		Name: ConfigIdentityDetectorAnonymize,
+		Description: "Replace the developer names and emails in all the results with " +
+			"stable salted hashes.",
+		Flag:    "anonymize",
+		Type:    core.BoolConfigurationOption,
+		Default: false}, {
+		Name: ConfigIdentityDetectorAnonymizeSalt,
+		Description: "Salt of --anonymize. The same salt produces the same hashes in different " +
+			"runs; a random salt is used if it is empty.",
+		Flag:    "anonymize-salt",
+		Type:    core.StringConfigurationOption,
+		Default: ""}, {
+		Name: ConfigIdentityDetectorAttribution,
+		Description: "Who is credited with the commits: \"author\", \"committer\" or \"both\" - " +
+			"the authors together with the committers as separate developers.",
+		Flag:    "attribution",
+		Type:    core.StringConfigurationOption,
+		Default: AttributionAuthor}, {
+		Name: ConfigIdentityDetectorCoAuthors,
+		Description: "How to credit the co-authors from the commit message trailers: " +
+			"\"none\", \"duplicate\" - each gets the whole commit, or \"split\" - they share it.",
+		Flag:    "co-authors",
+		Type:    core.StringConfigurationOption,
+		Default: CoAuthorsNone}, {
+		Name:        ConfigIdentityDetectorCoAuthorTrailers,
+		Description: "The commit message trailers which list the co-authors, see --co-authors.",
+		Flag:        "co-author-trailers",
+		Type:        core.StringsConfigurationOption,
+		Default:     DefaultCoAuthorTrailers}, {
+		Name: ConfigIdentityDetectorResolver,
+		Description: "Identity resolver which maps the commit signatures to the developers: " +
+			strings.Join(Resolvers(), ", ") + ".",
+		Flag:    "identity-resolver",
+		Type:    core.StringConfigurationOption,
+		Default: DefaultResolver},
+	}
+	result := options[:]
+	for _, name := range Resolvers()[1:] {
+		result = append(result, resolverFactories[name]().ListConfigurationOptions()...)
 	}
-	return options[:]
+	return result
 }
 
 // Configure sets the properties previously published by ListConfigurationOptions().
 func (detector *Detector) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigIdentityDetectorSplitGap].(int); exists {
+		detector.SplitGap = time.Duration(val) * 24 * time.Hour
+	}
+	if val, exists := facts[ConfigIdentityDetectorMailmapPath].(string); exists {
+		detector.MailmapPath = val
+	}
+	if val, exists := facts[ConfigIdentityDetectorPeopleDictFile].(string); exists {
+		detector.PeopleDictFile = val
+	}
+	if val, exists := facts[ConfigIdentityDetectorNameDistance].(int); exists {
+		detector.NameDistance = val
+	}
+	if val, exists := facts[ConfigIdentityDetectorMatchEmailLocalPart].(bool); exists {
+		detector.MatchEmailLocalPart = val
+	}
+	if val, exists := facts[ConfigIdentityDetectorTransliterate].(bool); exists {
+		detector.Transliterate = val
+	}
+	if val, exists := facts[ConfigIdentityDetectorGitHubNoreply].(bool); exists {
+		detector.ResolveGitHubNoreply = val
+	}
+	if val, exists := facts[ConfigIdentityDetectorGitHubToken].(string); exists {
+		detector.GitHubToken = val
+	}
+	if val, exists := facts[ConfigIdentityDetectorTeamsFile].(string); exists {
+		detector.TeamsFile = val
+	}
+	if val, exists := facts[ConfigIdentityDetectorActiveMinCommits].(int); exists {
+		detector.ActivityPolicy.MinCommits = val
+	}
+	if val, exists := facts[ConfigIdentityDetectorActiveMinLines].(int); exists {
+		detector.ActivityPolicy.MinLines = val
+	}
+	if val, exists := facts[ConfigIdentityDetectorActiveWindow].(int); exists {
+		detector.ActivityPolicy.Window = val
+	}
+	if val, exists := facts[ConfigIdentityDetectorAnonymize].(bool); exists {
+		detector.Anonymize = val
+	}
+	if val, exists := facts[ConfigIdentityDetectorAnonymizeSalt].(string); exists {
+		detector.AnonymizeSalt = val
+	}
+	if val, exists := facts[ConfigIdentityDetectorAttribution].(string); exists {
+		detector.Attribution = val
+	}
+	detector.Attribution = normalizeAttribution(detector.Attribution)
+	if val, exists := facts[ConfigIdentityDetectorCoAuthors].(string); exists {
+		detector.CoAuthors = val
+	}
+	detector.CoAuthors = normalizeCoAuthors(detector.CoAuthors)
+	if val, exists := facts[ConfigIdentityDetectorCoAuthorTrailers].([]string); exists {
+		detector.CoAuthorTrailers = val
+	}
+	if len(detector.CoAuthorTrailers) == 0 {
+		detector.CoAuthorTrailers = DefaultCoAuthorTrailers
+	}
 	if val, exists := facts[FactIdentityDetectorPeopleDict].(map[string]int); exists {
 		detector.PeopleDict = val
 	}
@@ -90,22 +424,37 @@ func (detector *Detector) Configure(facts map[string]interface{}) {
 		detector.ReversedPeopleDict = val
 	}
 	if detector.PeopleDict == nil || detector.ReversedPeopleDict == nil {
-		peopleDictPath, _ := facts[ConfigIdentityDetectorPeopleDictPath].(string)
-		if peopleDictPath != "" {
-			detector.LoadPeopleDict(peopleDictPath)
+		if detector.Resolver == nil {
+			name, _ := facts[ConfigIdentityDetectorResolver].(string)
+			detector.Resolver = detector.newResolver(name)
+		}
+		detector.Resolver.Configure(facts)
+		if heuristic, ok := detector.Resolver.(*heuristicResolver); ok {
+			detector.Splits = heuristic.detector.Splits
+			detector.Merges = heuristic.detector.Merges
+		}
+		detector.PeopleDict = detector.Resolver.PeopleDict()
+		detector.ReversedPeopleDict = detector.Resolver.ReversedPeopleDict()
+		size := len(detector.ReversedPeopleDict)
+		missing := size > 0 && detector.ReversedPeopleDict[size-1] == AuthorMissingName
+		// the reports refer to the developers, so the teams are applied afterwards
+		detector.applyTeams(missing)
+		detector.anonymize()
+		if missing {
 			facts[FactIdentityDetectorPeopleCount] = len(detector.ReversedPeopleDict) - 1
 		} else {
-			if _, exists := facts[core.ConfigPipelineCommits]; !exists {
-				panic("IdentityDetector needs a list of commits to initialize.")
-			}
-			detector.GeneratePeopleDict(facts[core.ConfigPipelineCommits].([]*object.Commit))
 			facts[FactIdentityDetectorPeopleCount] = len(detector.ReversedPeopleDict)
 		}
 	} else {
 		facts[FactIdentityDetectorPeopleCount] = len(detector.ReversedPeopleDict)
 	}
+	commits, _ := facts[core.ConfigPipelineCommits].([]*object.Commit)
+	detector.ActiveDevelopers = detector.calculateActiveDevelopers(commits)
 	facts[FactIdentityDetectorPeopleDict] = detector.PeopleDict
 	facts[FactIdentityDetectorReversedPeopleDict] = detector.ReversedPeopleDict
+	facts[FactIdentityDetectorActiveDevelopers] = detector.ActiveDevelopers
+	facts[FactIdentityDetectorAttribution] = detector.Attribution
+	facts[FactIdentityDetectorCoAuthors] = detector.CoAuthors
 }
 
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
@@ -120,15 +469,50 @@ func (detector *Detector) Initialize(repository *git.Repository) {
 // in Provides(). If there was an error, nil is returned.
 func (detector *Detector) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
 	commit := deps[core.DependencyCommit].(*object.Commit)
-	signature := commit.Author
-	authorID, exists := detector.PeopleDict[strings.ToLower(signature.Email)]
+	author := detector.resolve(AttributedSignature(commit, detector.Attribution))
+	return map[string]interface{}{
+		DependencyAuthor:    author,
+		DependencyCommitter: detector.resolve(commit.Committer),
+		DependencyCoAuthors: detector.resolveCoAuthors(commit, author),
+	}, nil
+}
+
+// resolve returns the index of the developer with the specified signature. It applies
+// the active Resolver and then the teams.
+func (detector *Detector) resolve(signature object.Signature) int {
+	if detector.Resolver == nil {
+		// the people dictionaries were supplied in the facts
+		return detector.resolveSignature(signature)
+	}
+	author := detector.Resolver.Resolve(signature)
+	if author >= 0 && author < len(detector.teams) {
+		author = detector.teams[author]
+	}
+	return author
+}
+
+// resolveSignature returns the identity index of the commit author or AuthorMissing.
+func (detector *Detector) resolveSignature(signature object.Signature) int {
+	name, email := detector.canonicalSignature(signature)
+	authorID, exists := detector.PeopleDict[email]
+	if detector.sharedEmails[email] {
+		if id, found := detector.PeopleDict[name]; found {
+			authorID = id
+		}
+	}
 	if !exists {
-		authorID, exists = detector.PeopleDict[strings.ToLower(signature.Name)]
+		authorID, exists = detector.PeopleDict[name]
 		if !exists {
 			authorID = AuthorMissing
 		}
 	}
-	return map[string]interface{}{DependencyAuthor: authorID}, nil
+	return authorID
+}
+
+// canonicalSignature returns the lower-cased name and email of the signature mapped by .mailmap.
+func (detector *Detector) canonicalSignature(signature object.Signature) (string, string) {
+	name, email := detector.mailmap.Map(signature.Name, signature.Email)
+	return strings.ToLower(name), strings.ToLower(email)
 }
 
 // Fork clones this PipelineItem.
@@ -163,6 +547,39 @@ func (detector *Detector) LoadPeopleDict(path string) error {
 	return nil
 }
 
+// LoadPeopleDictFile reads the YAML or JSON file which maps the canonical names of the developers
+// to the lists of their emails and names:
+//
+//   Vadim Markovtsev:
+//     - vadim@sourced.tech
+//     - gmarkhor@gmail.com
+//
+// Each email or name may belong to only one developer.
+func LoadPeopleDictFile(path string) (map[string][]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	mapping := map[string][]string{}
+	if err = goyaml.Unmarshal(data, &mapping); err != nil {
+		return nil, err
+	}
+	owners := map[string]string{}
+	for person, aliases := range mapping {
+		for _, alias := range append([]string{person}, aliases...) {
+			alias = strings.ToLower(alias)
+			if owner, exists := owners[alias]; exists && owner != person {
+				if owner > person {
+					owner, person = person, owner
+				}
+				return nil, fmt.Errorf("%s belongs to both %s and %s", alias, owner, person)
+			}
+			owners[alias] = person
+		}
+	}
+	return mapping, nil
+}
+
 // GeneratePeopleDict loads author signatures from the specified list of Git commits.
 func (detector *Detector) GeneratePeopleDict(commits []*object.Commit) {
 	dict := map[string]int{}
@@ -170,95 +587,110 @@ func (detector *Detector) GeneratePeopleDict(commits []*object.Commit) {
 	names := map[int][]string{}
 	size := 0
 
-	mailmapFile, err := commits[len(commits)-1].File(".mailmap")
-	if err == nil {
-		mailMapContents, err := mailmapFile.Contents()
-		if err == nil {
-			mailmap := ParseMailmap(mailMapContents)
-			for key, val := range mailmap {
+	detector.mailmap = detector.loadMailmap(commits[len(commits)-1])
+	detector.mapped = map[string]bool{}
+	canonical := map[int]string{}
+	if detector.PeopleDictFile != "" {
+		mapping, err := LoadPeopleDictFile(detector.PeopleDictFile)
+		if err != nil {
+			log.Panicf("failed to load %s: %v", detector.PeopleDictFile, err)
+		}
+		people := make([]string, 0, len(mapping))
+		for person := range mapping {
+			people = append(people, person)
+		}
+		sort.Strings(people)
+		for _, person := range people {
+			id := size
+			size++
+			canonical[id] = person
+			for _, key := range append([]string{person}, mapping[person]...) {
 				key = strings.ToLower(key)
-				toEmail := strings.ToLower(val.Email)
-				toName := strings.ToLower(val.Name)
-				id, exists := dict[toEmail]
-				if !exists {
-					id, exists = dict[toName]
-				}
-				if exists {
-					dict[key] = id
-				} else {
-					id = size
-					size++
-					if toEmail != "" {
-						dict[toEmail] = id
-						emails[id] = append(emails[id], toEmail)
-					}
-					if toName != "" {
-						dict[toName] = id
-						names[id] = append(names[id], toName)
-					}
-					dict[key] = id
+				if _, exists := dict[key]; exists {
+					continue
 				}
+				dict[key] = id
 				if strings.Contains(key, "@") {
-					exists := false
-					for _, val := range emails[id] {
-						if key == val {
-							exists = true
-							break
-						}
-					}
-					if !exists {
-						emails[id] = append(emails[id], key)
-					}
+					emails[id] = append(emails[id], key)
+					detector.mapped[key] = true
 				} else {
-					exists := false
-					for _, val := range names[id] {
-						if key == val {
-							exists = true
-							break
-						}
-					}
-					if !exists {
-						names[id] = append(names[id], key)
-					}
+					names[id] = append(names[id], key)
 				}
 			}
 		}
 	}
-
 	for _, commit := range commits {
-		email := strings.ToLower(commit.Author.Email)
-		name := strings.ToLower(commit.Author.Name)
-		id, exists := dict[email]
-		if exists {
-			_, exists := dict[name]
+		for _, signature := range detector.signatures(commit) {
+			name, email := detector.canonicalSignature(signature)
+			// the signature parts before .mailmap become the aliases
+			keys := [...]string{email, name,
+				strings.ToLower(signature.Email), strings.ToLower(signature.Name)}
+			id, exists := -1, false
+			for _, key := range keys {
+				if id, exists = dict[key]; exists {
+					break
+				}
+			}
 			if !exists {
-				dict[name] = id
-				names[id] = append(names[id], name)
+				id = size
+				size++
+			}
+			for i, key := range keys {
+				if _, exists := dict[key]; exists {
+					continue
+				}
+				dict[key] = id
+				if i%2 == 0 {
+					emails[id] = append(emails[id], key)
+				} else {
+					names[id] = append(names[id], key)
+				}
 			}
-			continue
-		}
-		id, exists = dict[name]
-		if exists {
-			dict[email] = id
-			emails[id] = append(emails[id], email)
-			continue
 		}
-		dict[email] = size
-		dict[name] = size
-		emails[size] = append(emails[size], email)
-		names[size] = append(names[size], name)
-		size++
+	}
+	size = detector.mergeFuzzyIdentities(dict, names, emails, canonical, size)
+	if detector.SplitGap > 0 {
+		size = detector.splitSharedIdentities(commits, dict, names, emails, size)
 	}
 	reverseDict := make([]string, size)
 	for _, val := range dict {
 		sort.Strings(names[val])
 		sort.Strings(emails[val])
 		reverseDict[val] = strings.Join(names[val], "|") + "|" + strings.Join(emails[val], "|")
+		if person, exists := canonical[val]; exists {
+			// the canonical name goes first as is
+			aliases := []string{person}
+			for _, name := range names[val] {
+				if name != strings.ToLower(person) {
+					aliases = append(aliases, name)
+				}
+			}
+			reverseDict[val] = strings.Join(append(aliases, emails[val]...), "|")
+		}
 	}
 	detector.PeopleDict = dict
 	detector.ReversedPeopleDict = reverseDict
 }
 
+// loadMailmap reads .mailmap from the specified commit and then Detector.MailmapPath.
+func (detector *Detector) loadMailmap(commit *object.Commit) *Mailmap {
+	mailmap := &Mailmap{}
+	if file, err := commit.File(".mailmap"); err == nil {
+		if contents, err := file.Contents(); err == nil {
+			mailmap.Parse(contents)
+		}
+	}
+	if detector.MailmapPath != "" {
+		contents, err := ioutil.ReadFile(detector.MailmapPath)
+		if err != nil {
+			log.Printf("Failed to read %s: %v\n", detector.MailmapPath, err)
+		} else {
+			mailmap.Parse(string(contents))
+		}
+	}
+	return mailmap
+}
+
 // MergeReversedDicts joins two identity lists together, excluding duplicates, in-order.
 func (detector Detector) MergeReversedDicts(rd1, rd2 []string) (map[string][3]int, []string) {
 	people := map[string][3]int{}
@@ -36,11 +36,33 @@ func TestIdentityDetectorMeta(t *testing.T) {
 	id := fixtureIdentityDetector()
 	assert.Equal(t, id.Name(), "IdentityDetector")
 	assert.Equal(t, len(id.Requires()), 0)
-	assert.Equal(t, len(id.Provides()), 1)
+	assert.Equal(t, len(id.Provides()), 3)
 	assert.Equal(t, id.Provides()[0], DependencyAuthor)
+	assert.Equal(t, id.Provides()[1], DependencyCommitter)
+	assert.Equal(t, id.Provides()[2], DependencyCoAuthors)
 	opts := id.ListConfigurationOptions()
-	assert.Len(t, opts, 1)
+	assert.Len(t, opts, 21)
 	assert.Equal(t, opts[0].Name, ConfigIdentityDetectorPeopleDictPath)
+	assert.Equal(t, opts[1].Name, ConfigIdentityDetectorSplitGap)
+	assert.Equal(t, opts[2].Name, ConfigIdentityDetectorSplitReport)
+	assert.Equal(t, opts[3].Name, ConfigIdentityDetectorMailmapPath)
+	assert.Equal(t, opts[4].Name, ConfigIdentityDetectorPeopleDictFile)
+	assert.Equal(t, opts[5].Name, ConfigIdentityDetectorNameDistance)
+	assert.Equal(t, opts[6].Name, ConfigIdentityDetectorMatchEmailLocalPart)
+	assert.Equal(t, opts[7].Name, ConfigIdentityDetectorTransliterate)
+	assert.Equal(t, opts[8].Name, ConfigIdentityDetectorMergeReport)
+	assert.Equal(t, opts[9].Name, ConfigIdentityDetectorGitHubNoreply)
+	assert.Equal(t, opts[10].Name, ConfigIdentityDetectorGitHubToken)
+	assert.Equal(t, opts[11].Name, ConfigIdentityDetectorTeamsFile)
+	assert.Equal(t, opts[12].Name, ConfigIdentityDetectorActiveMinCommits)
+	assert.Equal(t, opts[13].Name, ConfigIdentityDetectorActiveMinLines)
+	assert.Equal(t, opts[14].Name, ConfigIdentityDetectorActiveWindow)
+	assert.Equal(t, opts[15].Name, ConfigIdentityDetectorAnonymize)
+	assert.Equal(t, opts[16].Name, ConfigIdentityDetectorAnonymizeSalt)
+	assert.Equal(t, opts[17].Name, ConfigIdentityDetectorAttribution)
+	assert.Equal(t, opts[18].Name, ConfigIdentityDetectorCoAuthors)
+	assert.Equal(t, opts[19].Name, ConfigIdentityDetectorCoAuthorTrailers)
+	assert.Equal(t, opts[20].Name, ConfigIdentityDetectorResolver)
 }
 
 func TestIdentityDetectorConfigure(t *testing.T) {
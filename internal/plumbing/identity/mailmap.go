@@ -1,51 +1,105 @@
 package identity
 
-import (
-	"strings"
+import "strings"
 
-	"gopkg.in/src-d/go-git.v4/plumbing/object"
-)
+// Mailmap maps the signatures to the canonical ones in the same way as `git log --use-mailmap`:
+// the entries are matched by the commit email and optionally by the commit name, both
+// case-insensitively. The nil *Mailmap does not change anything.
+type Mailmap struct {
+	// entries map the lower-cased commit emails to the replacements
+	entries map[string]*mailmapEntry
+}
+
+type mailmapTarget struct {
+	name  string
+	email string
+}
+
+type mailmapEntry struct {
+	// mailmapTarget is applied if none of the names match
+	mailmapTarget
+	// names map the lower-cased commit names to the replacements
+	names map[string]*mailmapTarget
+}
 
-// ParseMailmap parses the contents of .mailmap and returns the mapping
-// between signature parts. It does *not* follow the full signature
-// matching convention, that is, developers are identified by email
-// and by name independently.
-func ParseMailmap(contents string) map[string]object.Signature {
-	mm := map[string]object.Signature{}
-	lines := strings.Split(contents, "\n")
-	for _, line := range lines {
+// Parse adds the entries from the contents of a .mailmap file. The entries which are parsed
+// later override the previous ones, so the order is the same as in Git: the .mailmap file
+// in the repository first and then mailmap.file.
+func (mm *Mailmap) Parse(contents string) {
+	if mm.entries == nil {
+		mm.entries = map[string]*mailmapEntry{}
+	}
+	for _, line := range strings.Split(contents, "\n") {
 		line = strings.TrimSpace(line)
-		if line == "" {
+		if line == "" || strings.HasPrefix(line, "#") {
 			continue
 		}
-		if strings.HasPrefix(line, "#") {
+		newName, newEmail, rest, ok := parseMailmapSignature(line)
+		if !ok {
 			continue
 		}
-		if strings.LastIndex(line, ">") != len(line)-1 {
-			continue
+		oldName, oldEmail, _, ok := parseMailmapSignature(rest)
+		if !ok {
+			// "Proper Name <commit@email>" changes only the name
+			oldName, oldEmail = "", newEmail
+			newEmail = ""
 		}
-		ltp := strings.LastIndex(line, "<")
-		fromEmail := line[ltp+1 : len(line)-1]
-		line = strings.TrimSpace(line[:ltp])
-		gtp := strings.LastIndex(line, ">")
-		fromName := ""
-		if gtp != len(line)-1 {
-			fromName = strings.TrimSpace(line[gtp+1:])
+		key := strings.ToLower(oldEmail)
+		entry := mm.entries[key]
+		if entry == nil {
+			entry = &mailmapEntry{names: map[string]*mailmapTarget{}}
+			mm.entries[key] = entry
 		}
-		toEmail := ""
-		if gtp > 0 {
-			line = line[:gtp]
-			ltp = strings.LastIndex(line, "<")
-			toEmail = line[ltp+1:]
-			line = strings.TrimSpace(line[:ltp])
+		target := &entry.mailmapTarget
+		if oldName != "" {
+			key = strings.ToLower(oldName)
+			if target = entry.names[key]; target == nil {
+				target = &mailmapTarget{}
+				entry.names[key] = target
+			}
 		}
-		toName := line
-		if fromEmail != "" {
-			mm[fromEmail] = object.Signature{Name: toName, Email: toEmail}
+		if newName != "" {
+			target.name = newName
 		}
-		if fromName != "" {
-			mm[fromName] = object.Signature{Name: toName, Email: toEmail}
+		if newEmail != "" {
+			target.email = newEmail
 		}
 	}
-	return mm
+}
+
+// Map returns the canonical name and email of the signature.
+func (mm *Mailmap) Map(name, email string) (string, string) {
+	if mm == nil {
+		return name, email
+	}
+	entry := mm.entries[strings.ToLower(email)]
+	if entry == nil {
+		return name, email
+	}
+	target := &entry.mailmapTarget
+	if named := entry.names[strings.ToLower(name)]; named != nil {
+		target = named
+	}
+	if target.name != "" {
+		name = target.name
+	}
+	if target.email != "" {
+		email = target.email
+	}
+	return name, email
+}
+
+// parseMailmapSignature extracts "Name <email>" from the beginning of the line and returns
+// the rest after ">". The name may be empty.
+func parseMailmapSignature(line string) (name, email, rest string, ok bool) {
+	lt := strings.IndexByte(line, '<')
+	if lt < 0 {
+		return "", "", "", false
+	}
+	gt := strings.IndexByte(line[lt:], '>')
+	if gt < 0 {
+		return "", "", "", false
+	}
+	gt += lt
+	return strings.TrimSpace(line[:lt]), line[lt+1 : gt], line[gt+1:], true
 }
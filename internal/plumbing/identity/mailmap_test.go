@@ -6,7 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestParseMailmap(t *testing.T) {
+func TestMailmapGitContents(t *testing.T) {
 	contents := `#
 # This list is used by git-shortlog to fix a few botched name translations
 # in the git archive, either because the author's full name was messed up
@@ -270,34 +270,71 @@ YOSHIFUJI Hideaki <yoshfuji@linux-ipv6.org>
 anonymous <linux@horizon.com>
 anonymous <linux@horizon.net>
 İsmail Dönmez <ismail@pardus.org.tr>`
-	mm := ParseMailmap(contents)
-	assert.Equal(t, mm["ismail@pardus.org.tr"].Name, "İsmail Dönmez")
-	assert.Equal(t, mm["ismail@pardus.org.tr"].Email, "")
-
-	assert.Equal(t, mm["linux@horizon.com"].Name, "anonymous")
-	assert.Equal(t, mm["linux@horizon.com"].Email, "")
-
-	assert.Equal(t, mm["y0netan1@dragonflybsd.org"].Name, "YONETANI Tomokazu")
-	assert.Equal(t, mm["y0netan1@dragonflybsd.org"].Email, "y0n3t4n1@gmail.com")
-
-	assert.Equal(t, mm["qhwt+git@les.ath.cx"].Name, "YONETANI Tomokazu")
-	assert.Equal(t, mm["qhwt+git@les.ath.cx"].Email, "y0n3t4n1@gmail.com")
-
-	assert.Equal(t, mm["vnwildman@gmail.com"].Name, "Trần Ngọc Quân")
-	assert.Equal(t, mm["vnwildman@gmail.com"].Email, "vnwildman@gmail.com")
-
-	assert.Equal(t, mm["Tran Ngoc Quan"].Name, "Trần Ngọc Quân")
-	assert.Equal(t, mm["Tran Ngoc Quan"].Email, "vnwildman@gmail.com")
+	mm := &Mailmap{}
+	mm.Parse(contents)
+	resolve := func(name, email string) []string {
+		name, email = mm.Map(name, email)
+		return []string{name, email}
+	}
+	assert.Equal(t, resolve("ismail", "ismail@pardus.org.tr"),
+		[]string{"İsmail Dönmez", "ismail@pardus.org.tr"})
+	assert.Equal(t, resolve("anon", "linux@horizon.com"), []string{"anonymous", "linux@horizon.com"})
+	assert.Equal(t, resolve("y", "y0netan1@dragonflybsd.org"),
+		[]string{"YONETANI Tomokazu", "y0n3t4n1@gmail.com"})
+	assert.Equal(t, resolve("y", "qhwt+git@les.ath.cx"),
+		[]string{"YONETANI Tomokazu", "y0n3t4n1@gmail.com"})
+	assert.Equal(t, resolve("Tran Ngoc Quan", "vnwildman@gmail.com"),
+		[]string{"Trần Ngọc Quân", "vnwildman@gmail.com"})
+	assert.Equal(t, resolve("Other", "vnwildman@gmail.com"), []string{"Other", "vnwildman@gmail.com"})
+	assert.Equal(t, resolve("Nico", "nico@cam.org"), []string{"Nico", "nico@fluxnic.net"})
+	assert.Equal(t, resolve("a", "ASEDENO@mit.edu"), []string{"Alejandro R. Sedeño", "asedeno@MIT.EDU"})
+}
 
-	assert.Equal(t, mm["nico@cam.org"].Name, "")
-	assert.Equal(t, mm["nico@cam.org"].Email, "nico@fluxnic.net")
+func TestMailmapBadFormat(t *testing.T) {
+	mm := &Mailmap{}
+	// Git takes everything between the second "<" and the next ">" as the commit email
+	mm.Parse(`Denis Engemann <denis-alexander.engemann@inria.fr> <dengemann <denis.engemann@gmail.com>`)
+	name, email := mm.Map("Denis", "denis.engemann@gmail.com")
+	assert.Equal(t, name, "Denis")
+	assert.Equal(t, email, "denis.engemann@gmail.com")
+	name, email = mm.Map("Denis", "dengemann <denis.engemann@gmail.com")
+	assert.Equal(t, name, "Denis Engemann")
+	assert.Equal(t, email, "denis-alexander.engemann@inria.fr")
 }
 
-func TestParseMailmapBadFormat(t *testing.T) {
-	contents := `Denis Engemann <denis-alexander.engemann@inria.fr> <dengemann <denis.engemann@gmail.com>`
-	mm := ParseMailmap(contents)
-	assert.Equal(t, mm["denis.engemann@gmail.com"].Name, "Denis Engemann")
-	assert.Equal(t, mm["denis.engemann@gmail.com"].Email, "denis-alexander.engemann@inria.fr")
-	assert.Equal(t, mm["<dengemann"].Name, "Denis Engemann")
-	assert.Equal(t, mm["<dengemann"].Email, "denis-alexander.engemann@inria.fr")
+func TestMailmap(t *testing.T) {
+	mm := &Mailmap{}
+	mm.Parse(`# comment
+Alice <alice@corp.com>
+Alice Smith <alice@corp.com> <alice@home.net>
+Robert <bob@corp.com> Bob <bob@home.net>
+<bob@corp.com> <robert@home.net>
+garbage
+Carol <carol@corp.com>`)
+	name, email := mm.Map("alice", "ALICE@corp.com")
+	assert.Equal(t, name, "Alice")
+	assert.Equal(t, email, "ALICE@corp.com")
+	name, email = mm.Map("Alice", "alice@home.net")
+	assert.Equal(t, name, "Alice Smith")
+	assert.Equal(t, email, "alice@corp.com")
+	name, email = mm.Map("bob", "bob@home.net")
+	assert.Equal(t, name, "Robert")
+	assert.Equal(t, email, "bob@corp.com")
+	// only the matching name is replaced
+	name, email = mm.Map("Other", "bob@home.net")
+	assert.Equal(t, name, "Other")
+	assert.Equal(t, email, "bob@home.net")
+	name, email = mm.Map("Robert", "robert@home.net")
+	assert.Equal(t, name, "Robert")
+	assert.Equal(t, email, "bob@corp.com")
+	name, email = mm.Map("Dave", "dave@corp.com")
+	assert.Equal(t, name, "Dave")
+	assert.Equal(t, email, "dave@corp.com")
+	// the later entries override the previous ones
+	mm.Parse("Carol Jones <carol@corp.com>")
+	name, _ = mm.Map("carol", "carol@corp.com")
+	assert.Equal(t, name, "Carol Jones")
+	name, email = (*Mailmap)(nil).Map("Dave", "dave@corp.com")
+	assert.Equal(t, name, "Dave")
+	assert.Equal(t, email, "dave@corp.com")
 }
@@ -0,0 +1,140 @@
+package identity
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+// DefaultResolver is the name of the built-in Resolver which applies the heuristics of Detector.
+const DefaultResolver = "heuristic"
+
+// Resolver maps the commit signatures to the developers. Detector delegates the identity
+// resolution to the active Resolver, so plugins can replace the built-in heuristics, e.g.
+// with a lookup in an LDAP or HR system. The teams, the anonymization and the active developers
+// are applied by Detector on top of any Resolver.
+type Resolver interface {
+	// Name identifies the resolver in ConfigIdentityDetectorResolver.
+	Name() string
+	// ListConfigurationOptions returns the list of the resolver's own options. Detector
+	// publishes them together with its options.
+	ListConfigurationOptions() []core.ConfigurationOption
+	// Configure builds the people dictionaries. `facts` contain the values of the options
+	// and core.ConfigPipelineCommits - the analysed commits.
+	Configure(facts map[string]interface{})
+	// PeopleDict maps the lower-cased emails and names to the developer indices.
+	PeopleDict() map[string]int
+	// ReversedPeopleDict maps the developer indices to their descriptions, the first key
+	// separated by "|" is the main one. If the last element is AuthorMissingName, it is
+	// reserved for the unmatched identities.
+	ReversedPeopleDict() []string
+	// Resolve returns the index of the developer with the specified signature or AuthorMissing.
+	Resolve(signature object.Signature) int
+}
+
+// resolverFactories are the registered Resolver-s, see RegisterResolver().
+var resolverFactories = map[string]func() Resolver{}
+
+// RegisterResolver adds another Resolver which can be activated with
+// ConfigIdentityDetectorResolver. Plugins call it in init().
+func RegisterResolver(factory func() Resolver) {
+	resolverFactories[factory().Name()] = factory
+}
+
+// Resolvers returns the sorted names of the available Resolver-s, including DefaultResolver.
+func Resolvers() []string {
+	names := []string{DefaultResolver}
+	for name := range resolverFactories {
+		if name != DefaultResolver {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// newResolver creates the Resolver by name. DefaultResolver inherits the options of `detector`.
+func (detector *Detector) newResolver(name string) Resolver {
+	if name == "" || name == DefaultResolver {
+		return &heuristicResolver{detector: Detector{
+			SplitGap:             detector.SplitGap,
+			MailmapPath:          detector.MailmapPath,
+			PeopleDictFile:       detector.PeopleDictFile,
+			NameDistance:         detector.NameDistance,
+			MatchEmailLocalPart:  detector.MatchEmailLocalPart,
+			Transliterate:        detector.Transliterate,
+			ResolveGitHubNoreply: detector.ResolveGitHubNoreply,
+			GitHubToken:          detector.GitHubToken,
+			Attribution:          detector.Attribution,
+			CoAuthors:            detector.CoAuthors,
+			CoAuthorTrailers:     detector.CoAuthorTrailers,
+		}}
+	}
+	factory, exists := resolverFactories[name]
+	if !exists {
+		log.Panicf("unknown identity resolver %s, the available are: %s",
+			name, strings.Join(Resolvers(), ", "))
+	}
+	return factory()
+}
+
+// heuristicResolver is the default Resolver. It loads the people dictionary from
+// ConfigIdentityDetectorPeopleDictPath or generates it with the heuristics of Detector:
+// .mailmap, PeopleDictFile, the shared emails, the fuzzy matching, etc.
+type heuristicResolver struct {
+	// detector does the actual work, it holds the copy of the outer Detector's options.
+	detector Detector
+}
+
+// Name identifies the resolver in ConfigIdentityDetectorResolver.
+func (resolver *heuristicResolver) Name() string {
+	return DefaultResolver
+}
+
+// ListConfigurationOptions returns nothing because the heuristics are configured by the options
+// of Detector.
+func (resolver *heuristicResolver) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Configure builds the people dictionaries and writes the audit reports.
+func (resolver *heuristicResolver) Configure(facts map[string]interface{}) {
+	detector := &resolver.detector
+	peopleDictPath, _ := facts[ConfigIdentityDetectorPeopleDictPath].(string)
+	if peopleDictPath != "" {
+		detector.LoadPeopleDict(peopleDictPath)
+		return
+	}
+	if _, exists := facts[core.ConfigPipelineCommits]; !exists {
+		panic("IdentityDetector needs a list of commits to initialize.")
+	}
+	detector.GeneratePeopleDict(facts[core.ConfigPipelineCommits].([]*object.Commit))
+	if reportPath, _ := facts[ConfigIdentityDetectorSplitReport].(string); reportPath != "" {
+		if err := detector.saveSplitsReport(reportPath); err != nil {
+			log.Printf("Failed to write the identity splits report to %s: %v\n", reportPath, err)
+		}
+	}
+	if reportPath, _ := facts[ConfigIdentityDetectorMergeReport].(string); reportPath != "" {
+		if err := detector.saveMergesReport(reportPath); err != nil {
+			log.Printf("Failed to write the identity merges report to %s: %v\n", reportPath, err)
+		}
+	}
+}
+
+// PeopleDict maps the lower-cased emails and names to the developer indices.
+func (resolver *heuristicResolver) PeopleDict() map[string]int {
+	return resolver.detector.PeopleDict
+}
+
+// ReversedPeopleDict maps the developer indices to their descriptions.
+func (resolver *heuristicResolver) ReversedPeopleDict() []string {
+	return resolver.detector.ReversedPeopleDict
+}
+
+// Resolve returns the index of the developer with the specified signature or AuthorMissing.
+func (resolver *heuristicResolver) Resolve(signature object.Signature) int {
+	return resolver.detector.resolveSignature(signature)
+}
@@ -0,0 +1,130 @@
+package identity
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+// directoryResolver looks up the developers by email in a fixed directory.
+type directoryResolver struct {
+	people []string
+	dict   map[string]int
+}
+
+func (resolver *directoryResolver) Name() string {
+	return "directory"
+}
+
+func (resolver *directoryResolver) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{{
+		Name:        "Directory.Server",
+		Description: "LDAP server.",
+		Flag:        "directory-server",
+		Type:        core.StringConfigurationOption,
+		Default:     ""}}
+}
+
+func (resolver *directoryResolver) Configure(facts map[string]interface{}) {
+	resolver.people = []string{"Alice|alice@corp.com", "Bob|bob@corp.com", AuthorMissingName}
+	resolver.dict = map[string]int{"alice@corp.com": 0, "bob@corp.com": 1}
+	if server, _ := facts["Directory.Server"].(string); server != "" {
+		resolver.people[0] = "Alice@" + server + "|alice@corp.com"
+	}
+}
+
+func (resolver *directoryResolver) PeopleDict() map[string]int {
+	return resolver.dict
+}
+
+func (resolver *directoryResolver) ReversedPeopleDict() []string {
+	return resolver.people
+}
+
+func (resolver *directoryResolver) Resolve(signature object.Signature) int {
+	if id, exists := resolver.dict[strings.ToLower(signature.Email)]; exists {
+		return id
+	}
+	return AuthorMissing
+}
+
+func registerDirectoryResolver() func() {
+	RegisterResolver(func() Resolver { return &directoryResolver{} })
+	return func() { delete(resolverFactories, "directory") }
+}
+
+func TestResolvers(t *testing.T) {
+	assert.Equal(t, Resolvers(), []string{DefaultResolver})
+	defer registerDirectoryResolver()()
+	assert.Equal(t, Resolvers(), []string{DefaultResolver, "directory"})
+	opts := (&Detector{}).ListConfigurationOptions()
+	assert.Equal(t, opts[len(opts)-2].Name, ConfigIdentityDetectorResolver)
+	assert.Contains(t, opts[len(opts)-2].Description, "heuristic, directory")
+	assert.Equal(t, opts[len(opts)-1].Name, "Directory.Server")
+}
+
+func TestIdentityDetectorCustomResolver(t *testing.T) {
+	defer registerDirectoryResolver()()
+	commits := storeSplitCommits([]*object.Commit{
+		{Author: object.Signature{Name: "Bob", Email: "BOB@corp.com"}},
+		{Author: object.Signature{Name: "Carol", Email: "carol@corp.com"}},
+		{Author: object.Signature{Name: "Alice", Email: "alice@corp.com"}},
+	})
+	id := Detector{}
+	facts := map[string]interface{}{
+		ConfigIdentityDetectorResolver: "directory",
+		"Directory.Server":             "ldap",
+		core.ConfigPipelineCommits:     commits,
+	}
+	id.Configure(facts)
+	assert.Equal(t, id.Resolver.Name(), "directory")
+	assert.Equal(t, facts[FactIdentityDetectorReversedPeopleDict], []string{
+		"Alice@ldap|alice@corp.com", "Bob|bob@corp.com", AuthorMissingName})
+	assert.Equal(t, facts[FactIdentityDetectorPeopleCount], 2)
+	for i, author := range []int{1, AuthorMissing, 0} {
+		result, err := id.Consume(map[string]interface{}{core.DependencyCommit: commits[i]})
+		assert.Nil(t, err)
+		assert.Equal(t, result[DependencyAuthor], author)
+	}
+	assert.Equal(t, id.ActiveDevelopers.Active(0), []int{0, 1})
+}
+
+func TestIdentityDetectorCustomResolverTeams(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	commits := storeSplitCommits([]*object.Commit{
+		{Author: object.Signature{Name: "Bob", Email: "bob@corp.com"}},
+		{Author: object.Signature{Name: "Alice", Email: "alice@corp.com"}},
+	})
+	id := Detector{Resolver: &directoryResolver{}}
+	facts := map[string]interface{}{
+		ConfigIdentityDetectorTeamsFile: writeTeamsFile(t, dir),
+		core.ConfigPipelineCommits:      commits,
+	}
+	id.Configure(facts)
+	// the teams match only the resolver's keys, thus "alice" does not match
+	assert.Equal(t, id.ReversedPeopleDict, []string{
+		"backend", "frontend", "qa", TeamUnassigned, AuthorMissingName})
+	assert.Equal(t, facts[FactIdentityDetectorPeopleCount], 4)
+	// the resolver's own dictionary is intact
+	assert.Equal(t, id.Resolver.PeopleDict(), map[string]int{"alice@corp.com": 0, "bob@corp.com": 1})
+	assert.Equal(t, id.PeopleDict, map[string]int{"alice@corp.com": 3, "bob@corp.com": 0})
+	for i, team := range []int{0, 3} {
+		result, err := id.Consume(map[string]interface{}{core.DependencyCommit: commits[i]})
+		assert.Nil(t, err)
+		assert.Equal(t, result[DependencyAuthor], team)
+	}
+}
+
+func TestIdentityDetectorUnknownResolver(t *testing.T) {
+	id := Detector{}
+	assert.Panics(t, func() {
+		id.Configure(map[string]interface{}{ConfigIdentityDetectorResolver: "unknown"})
+	})
+}
@@ -0,0 +1,99 @@
+package identity
+
+import (
+	"sort"
+	"strings"
+
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// Signature is a distinct author name and email pair found in the commits.
+type Signature struct {
+	// Name is the author name in lower case.
+	Name string
+	// Email is the author email in lower case.
+	Email string
+	// Commits is the number of commits made with this signature.
+	Commits int
+}
+
+// IdentityProposal is an identity which was generated by GeneratePeopleDict() together with
+// the signatures which were merged into it, so that a human can review the merge.
+type IdentityProposal struct {
+	// Identity is the line in the people dictionary format: names and emails separated by "|".
+	Identity string
+	// Signatures are sorted by the number of commits in descending order.
+	Signatures []Signature
+}
+
+// Merged indicates whether the identity consists of several signatures.
+func (proposal IdentityProposal) Merged() bool {
+	return len(proposal.Signatures) > 1
+}
+
+// Separate returns the people dictionary lines which undo the merge: every email becomes
+// a separate identity together with the names used with it. A name which was used with several
+// emails goes to the email with the most commits.
+func (proposal IdentityProposal) Separate() []string {
+	var order []string
+	names := map[string][]string{}
+	seen := map[string]bool{}
+	for _, sig := range proposal.Signatures {
+		if _, exists := names[sig.Email]; !exists {
+			order = append(order, sig.Email)
+			names[sig.Email] = nil
+		}
+		if !seen[sig.Name] {
+			seen[sig.Name] = true
+			names[sig.Email] = append(names[sig.Email], sig.Name)
+		}
+	}
+	result := make([]string, 0, len(order))
+	for _, email := range order {
+		sort.Strings(names[email])
+		result = append(result, strings.Join(append(names[email], email), "|"))
+	}
+	return result
+}
+
+// Proposals groups the signatures of the commits by the identities in ReversedPeopleDict.
+// GeneratePeopleDict() must be called first.
+func (detector *Detector) Proposals(commits []*object.Commit) []IdentityProposal {
+	result := make([]IdentityProposal, len(detector.ReversedPeopleDict))
+	index := map[Signature]int{}
+	for i, identity := range detector.ReversedPeopleDict {
+		result[i].Identity = identity
+	}
+	for _, commit := range commits {
+		for _, signature := range detector.signatures(commit) {
+			id := detector.resolveSignature(signature)
+			if id == AuthorMissing || id >= len(result) {
+				continue
+			}
+			key := Signature{
+				Name:  strings.ToLower(signature.Name),
+				Email: strings.ToLower(signature.Email),
+			}
+			if pos, exists := index[key]; exists {
+				result[id].Signatures[pos].Commits++
+				continue
+			}
+			index[key] = len(result[id].Signatures)
+			key.Commits = 1
+			result[id].Signatures = append(result[id].Signatures, key)
+		}
+	}
+	for _, proposal := range result {
+		sigs := proposal.Signatures
+		sort.SliceStable(sigs, func(i, j int) bool {
+			if sigs[i].Commits != sigs[j].Commits {
+				return sigs[i].Commits > sigs[j].Commits
+			}
+			if sigs[i].Email != sigs[j].Email {
+				return sigs[i].Email < sigs[j].Email
+			}
+			return sigs[i].Name < sigs[j].Name
+		})
+	}
+	return result
+}
@@ -0,0 +1,29 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityDetectorProposals(t *testing.T) {
+	commits := fixtureSplitCommits()
+	id := Detector{}
+	id.GeneratePeopleDict(commits)
+	proposals := id.Proposals(commits)
+	assert.Len(t, proposals, 2)
+	assert.Equal(t, proposals[0].Identity, id.ReversedPeopleDict[0])
+	assert.True(t, proposals[0].Merged())
+	assert.Equal(t, proposals[0].Signatures, []Signature{
+		{Name: "alice", Email: "release@corp.com", Commits: 3},
+		{Name: "carol", Email: "release@corp.com", Commits: 2},
+		{Name: "alice", Email: "alice@corp.com", Commits: 1},
+		{Name: "bob", Email: "release@corp.com", Commits: 1},
+	})
+	assert.Equal(t, proposals[0].Separate(), []string{
+		"alice|bob|carol|release@corp.com", "alice@corp.com"})
+	assert.False(t, proposals[1].Merged())
+	assert.Equal(t, proposals[1].Signatures, []Signature{
+		{Name: "bob", Email: "bob@corp.com", Commits: 2}})
+	assert.Equal(t, proposals[1].Separate(), []string{"bob|bob@corp.com"})
+}
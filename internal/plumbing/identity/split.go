@@ -0,0 +1,137 @@
+package identity
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// IdentitySplit describes the moment when a shared email, e.g. a role account, was handed over
+// to a different person.
+type IdentitySplit struct {
+	// Email is the shared email in lower case.
+	Email string
+	// Since is the time of the first commit attributed to the new identity.
+	Since time.Time
+	// Gap is the inactivity period of Email before Since.
+	Gap time.Duration
+	// From is the index of the identity which used Email before Since.
+	From int
+	// To is the index of the identity which used Email since Since.
+	To int
+}
+
+// signatureActivity is a single commit made with some email.
+type signatureActivity struct {
+	when time.Time
+	name string
+}
+
+// splitSharedIdentities detects the emails which were used by several people in turn:
+// the activity stops for at least SplitGap and then resumes with a name which was never
+// used with that email before. The commits with such emails are attributed by the author
+// names instead of the emails, and the names which appeared after the first handover get
+// their own identities unless they already belong to somebody else.
+// `dict`, `names` and `emails` are the intermediate state of GeneratePeopleDict().
+// Returns the new number of identities.
+func (detector *Detector) splitSharedIdentities(
+	commits []*object.Commit, dict map[string]int, names, emails map[int][]string, size int) int {
+	activities := map[string][]signatureActivity{}
+	for _, commit := range commits {
+		for _, signature := range detector.signatures(commit) {
+			name, email := detector.canonicalSignature(signature)
+			activities[email] = append(activities[email], signatureActivity{
+				when: signature.When, name: name})
+		}
+	}
+	sortedEmails := make([]string, 0, len(activities))
+	for email := range activities {
+		sortedEmails = append(sortedEmails, email)
+	}
+	sort.Strings(sortedEmails)
+	detector.Splits = nil
+	detector.sharedEmails = map[string]bool{}
+	for _, email := range sortedEmails {
+		if detector.mapped[email] {
+			continue
+		}
+		acts := activities[email]
+		sort.SliceStable(acts, func(i, j int) bool { return acts[i].when.Before(acts[j].when) })
+		original := dict[email]
+		seen := map[string]bool{}
+		for i, act := range acts {
+			if i == 0 || seen[act.name] || act.when.Sub(acts[i-1].when) < detector.SplitGap {
+				seen[act.name] = true
+				continue
+			}
+			if !detector.sharedEmails[email] {
+				detector.sharedEmails[email] = true
+				// the names which joined after the first handover are different people
+				for _, next := range acts[i:] {
+					if seen[next.name] || dict[next.name] != original {
+						continue
+					}
+					dict[next.name] = size
+					names[original] = removeString(names[original], next.name)
+					names[size] = []string{next.name}
+					emails[size] = []string{email}
+					size++
+				}
+			}
+			to := dict[act.name]
+			emails[to] = appendUniqueString(emails[to], email)
+			detector.Splits = append(detector.Splits, IdentitySplit{
+				Email: email, Since: act.when, Gap: act.when.Sub(acts[i-1].when),
+				From: dict[acts[i-1].name], To: to,
+			})
+			seen[act.name] = true
+		}
+	}
+	return size
+}
+
+// WriteSplitsReport writes the audit report about the identities split by GeneratePeopleDict()
+// in YAML format.
+func (detector *Detector) WriteSplitsReport(writer io.Writer) {
+	fmt.Fprintln(writer, "splits:")
+	for _, split := range detector.Splits {
+		fmt.Fprintf(writer, "  - email: %s\n", yaml.SafeString(split.Email))
+		fmt.Fprintf(writer, "    since: %d\n", split.Since.Unix())
+		fmt.Fprintf(writer, "    gap_days: %d\n", int(split.Gap.Hours()/24))
+		fmt.Fprintf(writer, "    from: %s\n", yaml.SafeString(detector.ReversedPeopleDict[split.From]))
+		fmt.Fprintf(writer, "    to: %s\n", yaml.SafeString(detector.ReversedPeopleDict[split.To]))
+	}
+}
+
+func removeString(list []string, item string) []string {
+	result := list[:0]
+	for _, val := range list {
+		if val != item {
+			result = append(result, val)
+		}
+	}
+	return result
+}
+
+func appendUniqueString(list []string, item string) []string {
+	for _, val := range list {
+		if val == item {
+			return list
+		}
+	}
+	return append(list, item)
+}
+
+func (detector *Detector) saveSplitsReport(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	detector.WriteSplitsReport(file)
+	return file.Close()
+}
@@ -0,0 +1,189 @@
+package identity
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+// storeSplitCommits saves the commits with an empty tree to the memory storage so that
+// GeneratePeopleDict() is able to look for .mailmap.
+func storeSplitCommits(commits []*object.Commit) []*object.Commit {
+	storage := memory.NewStorage()
+	tree := storage.NewEncodedObject()
+	(&object.Tree{}).Encode(tree)
+	treeHash, _ := storage.SetEncodedObject(tree)
+	result := make([]*object.Commit, len(commits))
+	for i, commit := range commits {
+		commit.TreeHash = treeHash
+		if commit.Committer.Email == "" {
+			commit.Committer = commit.Author
+		}
+		encoded := storage.NewEncodedObject()
+		commit.Encode(encoded)
+		hash, _ := storage.SetEncodedObject(encoded)
+		result[i], _ = object.GetCommit(storage, hash)
+	}
+	return result
+}
+
+func fixtureSplitCommits() []*object.Commit {
+	start := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	commit := func(name, email string, days int) *object.Commit {
+		return &object.Commit{Author: object.Signature{
+			Name: name, Email: email, When: start.Add(time.Duration(days) * day)}}
+	}
+	return storeSplitCommits([]*object.Commit{
+		commit("Alice", "release@corp.com", 0),
+		commit("Alice", "alice@corp.com", 1),
+		commit("Alice", "release@corp.com", 10),
+		commit("Bob", "bob@corp.com", 20),
+		// the role account was handed over to Carol after a long pause
+		commit("Carol", "release@corp.com", 300),
+		commit("Carol", "release@corp.com", 310),
+		// Bob uses the role account for a while
+		commit("Bob", "release@corp.com", 700),
+		// Alice returns, this is not a handover
+		commit("Alice", "release@corp.com", 1200),
+		// a pause without a name change
+		commit("Bob", "bob@corp.com", 1000),
+	})
+}
+
+func TestIdentityDetectorSplitSharedIdentities(t *testing.T) {
+	commits := fixtureSplitCommits()
+	id := Detector{}
+	id.GeneratePeopleDict(commits)
+	assert.Len(t, id.ReversedPeopleDict, 2)
+	assert.Len(t, id.Splits, 0)
+	id = Detector{SplitGap: 180 * 24 * time.Hour}
+	id.GeneratePeopleDict(commits)
+	assert.Equal(t, id.ReversedPeopleDict, []string{
+		"alice|alice@corp.com|release@corp.com",
+		"bob|bob@corp.com|release@corp.com",
+		"carol|release@corp.com",
+	})
+	assert.Equal(t, id.PeopleDict["carol"], 2)
+	assert.Len(t, id.Splits, 2)
+	split := id.Splits[0]
+	assert.Equal(t, split.Email, "release@corp.com")
+	assert.Equal(t, split.From, 0)
+	assert.Equal(t, split.To, 2)
+	assert.Equal(t, split.Gap, 290*24*time.Hour)
+	assert.Equal(t, split.Since, commits[4].Author.When)
+	assert.Equal(t, id.Splits[1].From, 2)
+	assert.Equal(t, id.Splits[1].To, 1)
+
+	authors := make([]int, len(commits))
+	for i, commit := range commits {
+		result, err := id.Consume(map[string]interface{}{core.DependencyCommit: commit})
+		assert.Nil(t, err)
+		authors[i] = result[DependencyAuthor].(int)
+	}
+	assert.Equal(t, authors, []int{0, 0, 0, 1, 2, 2, 1, 0, 1})
+
+	buffer := &bytes.Buffer{}
+	id.WriteSplitsReport(buffer)
+	assert.Equal(t, buffer.String(), `splits:
+  - email: "release@corp.com"
+    since: 1509148800
+    gap_days: 290
+    from: "alice|alice@corp.com|release@corp.com"
+    to: "carol|release@corp.com"
+  - email: "release@corp.com"
+    since: 1543708800
+    gap_days: 390
+    from: "carol|release@corp.com"
+    to: "bob|bob@corp.com|release@corp.com"
+`)
+}
+
+func TestIdentityDetectorSplitToExistingIdentity(t *testing.T) {
+	commits := fixtureSplitCommits()[:4]
+	commits = append(commits, storeSplitCommits([]*object.Commit{{Author: object.Signature{
+		Name: "Bob", Email: "release@corp.com", When: commits[3].Author.When.Add(time.Hour)}}})...)
+	id := Detector{SplitGap: 5 * 24 * time.Hour}
+	id.GeneratePeopleDict(commits)
+	assert.Equal(t, id.ReversedPeopleDict, []string{
+		"alice|alice@corp.com|release@corp.com",
+		"bob|bob@corp.com|release@corp.com",
+	})
+	assert.Len(t, id.Splits, 1)
+	assert.Equal(t, id.Splits[0].From, 0)
+	assert.Equal(t, id.Splits[0].To, 1)
+	result, err := id.Consume(map[string]interface{}{core.DependencyCommit: commits[4]})
+	assert.Nil(t, err)
+	assert.Equal(t, result[DependencyAuthor], 1)
+	result, err = id.Consume(map[string]interface{}{core.DependencyCommit: commits[2]})
+	assert.Nil(t, err)
+	assert.Equal(t, result[DependencyAuthor], 0)
+}
+
+func TestIdentityDetectorMailmapPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mailmap")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(
+		"Alice <alice@corp.com> <release@corp.com>\nBob <bob@corp.com> <bob@home.net>\n"), 0666))
+	commits := storeSplitCommits([]*object.Commit{
+		{Author: object.Signature{Name: "Alice", Email: "alice@corp.com"}},
+		{Author: object.Signature{Name: "Release", Email: "release@corp.com"}},
+		{Author: object.Signature{Name: "Robert", Email: "bob@home.net"}},
+		{Author: object.Signature{Name: "Bob", Email: "bob@corp.com"}},
+	})
+	id := Detector{}
+	id.Configure(map[string]interface{}{
+		ConfigIdentityDetectorMailmapPath: path, core.ConfigPipelineCommits: commits})
+	assert.Equal(t, id.MailmapPath, path)
+	assert.Equal(t, id.ReversedPeopleDict, []string{
+		"alice|release|alice@corp.com|release@corp.com",
+		"bob|robert|bob@corp.com|bob@home.net",
+	})
+	for i, author := range []int{0, 0, 1, 1} {
+		result, err := id.Consume(map[string]interface{}{core.DependencyCommit: commits[i]})
+		assert.Nil(t, err)
+		assert.Equal(t, result[DependencyAuthor], author)
+	}
+}
+
+func TestIdentityDetectorPeopleDictFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "people.yml")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(
+		"Alice Smith:\n  - alice@corp.com\n  - release@corp.com\n  - Alice\n"), 0666))
+	id := Detector{SplitGap: 180 * 24 * time.Hour}
+	id.Configure(map[string]interface{}{
+		ConfigIdentityDetectorPeopleDictFile: path, core.ConfigPipelineCommits: fixtureSplitCommits()})
+	assert.Equal(t, id.PeopleDictFile, path)
+	// release@corp.com is never split because it belongs to Alice explicitly
+	assert.Equal(t, id.ReversedPeopleDict, []string{
+		"Alice Smith|alice|carol|alice@corp.com|release@corp.com",
+		"bob|bob@corp.com",
+	})
+	assert.Len(t, id.Splits, 0)
+	assert.Equal(t, id.PeopleDict["alice smith"], 0)
+	assert.Equal(t, id.PeopleDict["carol"], 0)
+
+	path = filepath.Join(dir, "people.json")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`{"Bob": ["bob@corp.com"], "Robert": ["Bob"]}`), 0666))
+	_, err = LoadPeopleDictFile(path)
+	assert.EqualError(t, err, "bob belongs to both Bob and Robert")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`{"Bob": ["bob@corp.com", "BOB@home.net"]}`), 0666))
+	mapping, err := LoadPeopleDictFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, mapping, map[string][]string{"Bob": {"bob@corp.com", "BOB@home.net"}})
+	_, err = LoadPeopleDictFile(filepath.Join(dir, "missing"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,79 @@
+package identity
+
+import (
+	"log"
+	"sort"
+	"strings"
+)
+
+// TeamUnassigned is the name of the team of the developers who are not listed in TeamsFile.
+const TeamUnassigned = "<unassigned>"
+
+// applyTeams loads TeamsFile, if it is set, and groups the developers by their teams.
+func (detector *Detector) applyTeams(missing bool) {
+	if detector.TeamsFile == "" {
+		return
+	}
+	teams, err := LoadPeopleDictFile(detector.TeamsFile)
+	if err != nil {
+		log.Panicf("failed to load %s: %v", detector.TeamsFile, err)
+	}
+	detector.groupTeams(teams, missing)
+}
+
+// groupTeams replaces the developers in PeopleDict and ReversedPeopleDict with their teams.
+// `teams` maps the team names to the names and emails of the members in the same format as
+// PeopleDictFile. A developer belongs to the team which lists any of their names or emails;
+// if there are several such teams, the first in the alphabetical order wins. The teams go
+// in alphabetical order, followed by TeamUnassigned if there are unlisted developers.
+// `missing` indicates that the last element of ReversedPeopleDict is AuthorMissingName which
+// is kept.
+func (detector *Detector) groupTeams(teams map[string][]string, missing bool) {
+	names := make([]string, 0, len(teams))
+	for team := range teams {
+		names = append(names, team)
+	}
+	sort.Strings(names)
+	members := map[string]int{}
+	for i, team := range names {
+		for _, member := range teams[team] {
+			members[strings.ToLower(member)] = i
+		}
+	}
+	people := detector.ReversedPeopleDict
+	if missing {
+		people = people[:len(people)-1]
+	}
+	mapping := make([]int, len(people))
+	for id := range mapping {
+		mapping[id] = -1
+	}
+	for key, id := range detector.PeopleDict {
+		if team, exists := members[key]; exists && id < len(mapping) {
+			if mapping[id] < 0 || team < mapping[id] {
+				mapping[id] = team
+			}
+		}
+	}
+	unassigned := -1
+	for id := range people {
+		if mapping[id] < 0 {
+			if unassigned < 0 {
+				unassigned = len(names)
+				names = append(names, TeamUnassigned)
+			}
+			mapping[id] = unassigned
+		}
+	}
+	if missing {
+		mapping = append(mapping, len(names))
+		names = append(names, AuthorMissingName)
+	}
+	peopleDict := make(map[string]int, len(detector.PeopleDict))
+	for key, id := range detector.PeopleDict {
+		peopleDict[key] = mapping[id]
+	}
+	detector.PeopleDict = peopleDict
+	detector.ReversedPeopleDict = names
+	detector.teams = mapping
+}
@@ -0,0 +1,71 @@
+package identity
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+func writeTeamsFile(t *testing.T, dir string) string {
+	name := filepath.Join(dir, "teams.yml")
+	assert.Nil(t, ioutil.WriteFile(name, []byte(`backend:
+  - bob@corp.com
+  - Vadim Markovtsev
+frontend:
+  - alice
+qa: []
+`), 0666))
+	return name
+}
+
+func TestIdentityDetectorGroupTeams(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	commits := storeSplitCommits([]*object.Commit{
+		{Author: object.Signature{Name: "Bob", Email: "bob@corp.com"}},
+		{Author: object.Signature{Name: "Alice", Email: "alice@corp.com"}},
+		{Author: object.Signature{Name: "Carol", Email: "carol@corp.com"}},
+		{Author: object.Signature{Name: "Robert", Email: "bob@corp.com"}},
+	})
+	id := Detector{}
+	facts := map[string]interface{}{
+		ConfigIdentityDetectorTeamsFile: writeTeamsFile(t, dir),
+		core.ConfigPipelineCommits:      commits,
+	}
+	id.Configure(facts)
+	assert.Equal(t, id.TeamsFile, filepath.Join(dir, "teams.yml"))
+	assert.Equal(t, id.ReversedPeopleDict, []string{"backend", "frontend", "qa", TeamUnassigned})
+	assert.Equal(t, facts[FactIdentityDetectorPeopleCount], 4)
+	assert.Equal(t, facts[FactIdentityDetectorReversedPeopleDict], id.ReversedPeopleDict)
+	for i, team := range []int{0, 1, 3, 0} {
+		result, err := id.Consume(map[string]interface{}{core.DependencyCommit: commits[i]})
+		assert.Nil(t, err)
+		assert.Equal(t, result[DependencyAuthor], team)
+	}
+}
+
+func TestIdentityDetectorGroupTeamsPeopleDict(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	id := Detector{}
+	facts := map[string]interface{}{
+		ConfigIdentityDetectorTeamsFile:      writeTeamsFile(t, dir),
+		ConfigIdentityDetectorPeopleDictPath: path.Join("..", "..", "test_data", "identities"),
+	}
+	id.Configure(facts)
+	assert.Equal(t, id.ReversedPeopleDict, []string{
+		"backend", "frontend", "qa", TeamUnassigned, AuthorMissingName})
+	assert.Equal(t, facts[FactIdentityDetectorPeopleCount], 4)
+	assert.Equal(t, id.PeopleDict["vadim@sourced.tech"], 0)
+	assert.Equal(t, id.PeopleDict["another@one.com"], 0)
+	assert.Equal(t, id.PeopleDict["torvalds@linux-foundation.org"], 3)
+	assert.Equal(t, id.PeopleDict["maximo@sourced.tech"], 3)
+}
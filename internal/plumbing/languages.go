@@ -0,0 +1,111 @@
+package plumbing
+
+import (
+	"io"
+	"path"
+
+	"gopkg.in/src-d/enry.v1"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+// LanguagesDetection detects the programming languages of the changed files with enry,
+// so that the analyses do not have to guess them on their own.
+// It is a PipelineItem.
+type LanguagesDetection struct {
+	core.NoopMerger
+}
+
+const (
+	// DependencyLanguages is the name of the dependency provided by LanguagesDetection.
+	// It is a map[string]string from the file names to the languages. The deleted files
+	// have their old names, the rest have the new names. The language is an empty string
+	// if enry does not recognize the file.
+	DependencyLanguages = "languages"
+	// languageSampleSize is the number of bytes which are read from the blobs
+	// to detect the language.
+	languageSampleSize = 1024
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (langs *LanguagesDetection) Name() string {
+	return "LanguagesDetection"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (langs *LanguagesDetection) Provides() []string {
+	arr := [...]string{DependencyLanguages}
+	return arr[:]
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (langs *LanguagesDetection) Requires() []string {
+	arr := [...]string{DependencyTreeChanges, DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (langs *LanguagesDetection) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (langs *LanguagesDetection) Configure(facts map[string]interface{}) {}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (langs *LanguagesDetection) Initialize(repository *git.Repository) {}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (langs *LanguagesDetection) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	cache := deps[DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	changes := deps[DependencyTreeChanges].(object.Changes)
+	result := make(map[string]string, len(changes))
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		entry := &change.To
+		if action == merkletrie.Delete {
+			entry = &change.From
+		}
+		result[entry.Name] = DetectLanguage(entry.Name, cache[entry.TreeEntry.Hash])
+	}
+	return map[string]interface{}{DependencyLanguages: result}, nil
+}
+
+// Fork clones this PipelineItem.
+func (langs *LanguagesDetection) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(langs, n)
+}
+
+// DetectLanguage returns the programming language of the file by its name and the beginning
+// of its contents or an empty string if it is not recognized. `blob` may be nil.
+func DetectLanguage(name string, blob *object.Blob) string {
+	var sample []byte
+	if blob != nil {
+		if reader, err := blob.Reader(); err == nil {
+			sample = make([]byte, languageSampleSize)
+			size, _ := io.ReadFull(reader, sample)
+			sample = sample[:size]
+			reader.Close()
+		}
+	}
+	return enry.GetLanguage(path.Base(name), sample)
+}
+
+func init() {
+	core.Registry.Register(&LanguagesDetection{})
+}
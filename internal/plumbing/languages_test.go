@@ -0,0 +1,57 @@
+package plumbing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func TestLanguagesDetectionMeta(t *testing.T) {
+	langs := &items.LanguagesDetection{}
+	assert.Equal(t, langs.Name(), "LanguagesDetection")
+	assert.Equal(t, langs.Provides(), []string{items.DependencyLanguages})
+	assert.Equal(t, langs.Requires(), []string{
+		items.DependencyTreeChanges, items.DependencyBlobCache})
+	assert.Len(t, langs.ListConfigurationOptions(), 0)
+	langs.Configure(nil)
+	summoned := core.Registry.Summon(items.DependencyLanguages)
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "LanguagesDetection")
+}
+
+func TestLanguagesDetectionConsume(t *testing.T) {
+	storage := memory.NewStorage()
+	encoded := storage.NewEncodedObject()
+	encoded.SetType(plumbing.BlobObject)
+	writer, _ := encoded.Writer()
+	writer.Write([]byte("package main\n\nfunc main() {}\n"))
+	writer.Close()
+	hash, err := storage.SetEncodedObject(encoded)
+	assert.Nil(t, err)
+	blob, err := object.GetBlob(storage, hash)
+	assert.Nil(t, err)
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	langs := &items.LanguagesDetection{}
+	langs.Initialize(nil)
+	result, err := langs.Consume(map[string]interface{}{
+		items.DependencyBlobCache: map[plumbing.Hash]*object.Blob{hash: blob},
+		items.DependencyTreeChanges: object.Changes{
+			{To: entry("cmd/main.go")},
+			{From: entry("setup.py")},
+			{From: entry("lib/a.c"), To: entry("lib/a.rs")},
+			{To: entry("unknown.xyz123")},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, result[items.DependencyLanguages], map[string]string{
+		"cmd/main.go": "Go", "setup.py": "Python", "lib/a.rs": "Rust", "unknown.xyz123": ""})
+	assert.Equal(t, items.DetectLanguage("main.go", nil), "Go")
+	assert.Equal(t, items.DetectLanguage("unknown.xyz123", nil), "")
+}
@@ -1,6 +1,9 @@
 package plumbing
 
 import (
+	"bytes"
+	"crypto/sha1"
+	"fmt"
 	"log"
 	"sort"
 	"unicode/utf8"
@@ -23,18 +26,48 @@ type RenameAnalysis struct {
 	// It has the same units as cgit's -X rename-threshold or -M. Better to
 	// set it to the default value of 90 (90%).
 	SimilarityThreshold int
+	// SizeThreshold is the maximum difference of the sizes of a deleted and an added file in
+	// percents of the smaller one to compare their contents. The files which were renamed and
+	// substantially extended or truncated in the same commit need a higher value. 0 means
+	// 100 - SimilarityThreshold.
+	SizeThreshold int
+	// MaxCandidates limits the number of the pairs of the deleted and the added files of similar
+	// sizes whose contents are compared in a single commit. If there are more, only the files
+	// with identical contents are matched. 0 means no limit.
+	MaxCandidates int
+	// ExactOnly disables the comparison of the contents: only the files with identical
+	// contents are matched.
+	ExactOnly bool
 
 	repository *git.Repository
+	cache      core.Storage
+	workers    *core.WorkerPool
 }
 
 const (
 	// RenameAnalysisDefaultThreshold specifies the default percentage of common lines in a pair
-	// of files to consider them linked. The exact code of the decision is sizesAreClose().
+	// of files to consider them linked. The exact code of the decision is textSimilarity().
 	RenameAnalysisDefaultThreshold = 90
 
 	// ConfigRenameAnalysisSimilarityThreshold is the name of the configuration option
 	// (RenameAnalysis.Configure()) which sets the similarity threshold.
 	ConfigRenameAnalysisSimilarityThreshold = "RenameAnalysis.SimilarityThreshold"
+
+	// ConfigRenameAnalysisSizeThreshold is the name of the configuration option
+	// (RenameAnalysis.Configure()) which sets the maximum relative difference of the sizes.
+	ConfigRenameAnalysisSizeThreshold = "RenameAnalysis.SizeThreshold"
+
+	// RenameAnalysisDefaultMaxCandidates is the default limit on the number of the compared
+	// pairs of files in a commit.
+	RenameAnalysisDefaultMaxCandidates = 100000
+
+	// ConfigRenameAnalysisMaxCandidates is the name of the configuration option
+	// (RenameAnalysis.Configure()) which sets the limit on the number of the compared pairs.
+	ConfigRenameAnalysisMaxCandidates = "RenameAnalysis.MaxCandidates"
+
+	// ConfigRenameAnalysisExactOnly is the name of the configuration option
+	// (RenameAnalysis.Configure()) which disables the comparison of the contents.
+	ConfigRenameAnalysisExactOnly = "RenameAnalysis.ExactOnly"
 )
 
 // Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
@@ -65,7 +98,24 @@ func (ra *RenameAnalysis) ListConfigurationOptions() []core.ConfigurationOption
 		Description: "The threshold on the similarity index used to detect renames.",
 		Flag:        "M",
 		Type:        core.IntConfigurationOption,
-		Default:     RenameAnalysisDefaultThreshold},
+		Default:     RenameAnalysisDefaultThreshold}, {
+		Name: ConfigRenameAnalysisSizeThreshold,
+		Description: "The maximum difference of the file sizes in percents to check the renames " +
+			"with edits; 0 means 100 minus the similarity threshold.",
+		Flag:    "rename-size-threshold",
+		Type:    core.IntConfigurationOption,
+		Default: 0}, {
+		Name: ConfigRenameAnalysisMaxCandidates,
+		Description: "The maximum number of the pairs of files which are compared to detect " +
+			"the renames with edits in a commit; 0 means no limit.",
+		Flag:    "rename-max-candidates",
+		Type:    core.IntConfigurationOption,
+		Default: RenameAnalysisDefaultMaxCandidates}, {
+		Name:        ConfigRenameAnalysisExactOnly,
+		Description: "Detect only the renames without edits, which is much faster.",
+		Flag:        "rename-exact-only",
+		Type:        core.BoolConfigurationOption,
+		Default:     false},
 	}
 	return options[:]
 }
@@ -75,6 +125,21 @@ func (ra *RenameAnalysis) Configure(facts map[string]interface{}) {
 	if val, exists := facts[ConfigRenameAnalysisSimilarityThreshold].(int); exists {
 		ra.SimilarityThreshold = val
 	}
+	if val, exists := facts[ConfigRenameAnalysisSizeThreshold].(int); exists {
+		ra.SizeThreshold = val
+	}
+	if val, exists := facts[ConfigRenameAnalysisMaxCandidates].(int); exists {
+		ra.MaxCandidates = val
+	}
+	if val, exists := facts[ConfigRenameAnalysisExactOnly].(bool); exists {
+		ra.ExactOnly = val
+	}
+	if val, exists := facts[core.FactPersistentCache].(core.Storage); exists {
+		ra.cache = val
+	}
+	if val, exists := facts[core.FactWorkerPool].(*core.WorkerPool); exists {
+		ra.workers = val
+	}
 }
 
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
@@ -85,6 +150,14 @@ func (ra *RenameAnalysis) Initialize(repository *git.Repository) {
 			RenameAnalysisDefaultThreshold)
 		ra.SimilarityThreshold = RenameAnalysisDefaultThreshold
 	}
+	if ra.SizeThreshold < 0 {
+		log.Printf("Warning: adjusted the size threshold to 0\n")
+		ra.SizeThreshold = 0
+	}
+	if ra.MaxCandidates < 0 {
+		log.Printf("Warning: adjusted the maximum number of rename candidates to 0\n")
+		ra.MaxCandidates = 0
+	}
 	ra.repository = repository
 }
 
@@ -96,7 +169,65 @@ func (ra *RenameAnalysis) Initialize(repository *git.Repository) {
 func (ra *RenameAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
 	changes := deps[DependencyTreeChanges].(object.Changes)
 	cache := deps[DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	var key string
+	if ra.cache != nil {
+		key = ra.cacheKey(changes)
+		if reducedChanges := ra.loadRenames(key, changes); reducedChanges != nil {
+			return map[string]interface{}{DependencyTreeChanges: reducedChanges}, nil
+		}
+	}
+	reducedChanges, err := ra.detectRenames(changes, cache)
+	if err != nil {
+		return nil, err
+	}
+	storeChanges(ra.cache, key, reducedChanges)
+	return map[string]interface{}{DependencyTreeChanges: reducedChanges}, nil
+}
+
+// cacheKey identifies the result of detectRenames() in the persistent cache. The result depends
+// only on the options and on the changes since the blobs are addressed by their contents.
+func (ra *RenameAnalysis) cacheKey(changes object.Changes) string {
+	hash := sha1.New()
+	for _, change := range changes {
+		for _, entry := range [...]object.ChangeEntry{change.From, change.To} {
+			fmt.Fprintf(hash, "%s\x00%s\x00", entry.Name, entry.TreeEntry.Hash.String())
+		}
+	}
+	return fmt.Sprintf("RenameAnalysis/%d/%d/%d/%t/%x",
+		ra.SimilarityThreshold, ra.SizeThreshold, ra.MaxCandidates, ra.ExactOnly, hash.Sum(nil))
+}
 
+// loadRenames reads the result of detectRenames() from the persistent cache and links it
+// to the original changes. Returns nil if there is nothing cached.
+func (ra *RenameAnalysis) loadRenames(key string, changes object.Changes) object.Changes {
+	type entryKey struct {
+		name string
+		hash plumbing.Hash
+	}
+	entries := map[entryKey]object.ChangeEntry{}
+	for _, change := range changes {
+		for _, entry := range [...]object.ChangeEntry{change.From, change.To} {
+			entries[entryKey{entry.Name, entry.TreeEntry.Hash}] = entry
+		}
+	}
+	consistent := true
+	reducedChanges := loadChanges(ra.cache, key, func(entry cachedChangeEntry, to bool) object.ChangeEntry {
+		if entry.Name == "" {
+			return object.ChangeEntry{}
+		}
+		original, exists := entries[entryKey{entry.Name, entry.Hash}]
+		consistent = consistent && exists
+		return original
+	})
+	if !consistent {
+		return nil
+	}
+	return reducedChanges
+}
+
+// detectRenames joins the deleted and the added files which are likely to be the same file.
+func (ra *RenameAnalysis) detectRenames(
+	changes object.Changes, cache map[plumbing.Hash]*object.Blob) (object.Changes, error) {
 	reducedChanges := make(object.Changes, 0, changes.Len())
 
 	// Stage 1 - find renames by matching the hashes
@@ -149,7 +280,11 @@ func (ra *RenameAnalysis) Consume(deps map[string]interface{}) (map[string]inter
 
 	// Stage 2 - apply the similarity threshold
 	// n^2 but actually linear
-	// We sort the blobs by size and do the single linear scan.
+	// We sort the blobs by size and find the window of the deleted blobs of close sizes for
+	// each added blob with the single linear scan. The similarities of all the candidate pairs
+	// are calculated in parallel. Then each added blob is paired with the most similar deleted
+	// blob which is still free so that several files which were renamed and edited in the same
+	// commit do not steal each other's histories.
 	addedBlobs := make(sortableBlobs, 0, stillAdded.Len())
 	deletedBlobs := make(sortableBlobs, 0, stillDeleted.Len())
 	for _, change := range stillAdded {
@@ -164,44 +299,119 @@ func (ra *RenameAnalysis) Consume(deps map[string]interface{}) (map[string]inter
 	}
 	sort.Sort(addedBlobs)
 	sort.Sort(deletedBlobs)
-	dStart := 0
-	for a = 0; a < addedBlobs.Len(); a++ {
-		myBlob := cache[addedBlobs[a].change.To.TreeEntry.Hash]
-		mySize := addedBlobs[a].size
-		for d = dStart; d < deletedBlobs.Len() && !ra.sizesAreClose(mySize, deletedBlobs[d].size); d++ {
+	windows := ra.candidateWindows(addedBlobs, deletedBlobs)
+	candidates := 0
+	for _, window := range windows {
+		candidates += window[1] - window[0]
+	}
+	if ra.MaxCandidates > 0 && candidates > ra.MaxCandidates {
+		log.Printf("Warning: skipped the inexact rename detection of %d files: %d candidate "+
+			"pairs exceed the limit %d\n", addedBlobs.Len()+deletedBlobs.Len(), candidates,
+			ra.MaxCandidates)
+	} else if !ra.ExactOnly && candidates > 0 {
+		similarities, err := ra.scoreCandidates(addedBlobs, deletedBlobs, windows, cache)
+		if err != nil {
+			return nil, err
 		}
-		dStart = d
-		foundMatch := false
-		for d = dStart; d < deletedBlobs.Len() && ra.sizesAreClose(mySize, deletedBlobs[d].size); d++ {
-			blobsAreClose, err := ra.blobsAreClose(
-				myBlob, cache[deletedBlobs[d].change.From.TreeEntry.Hash])
-			if err != nil {
-				return nil, err
+		matched := make([]bool, deletedBlobs.Len())
+		for a := range addedBlobs {
+			bestMatch := -1
+			bestSimilarity := -1
+			for i, similarity := range similarities[a] {
+				d := windows[a][0] + i
+				if !matched[d] && similarity >= ra.SimilarityThreshold && similarity > bestSimilarity {
+					bestMatch = d
+					bestSimilarity = similarity
+				}
 			}
-			if blobsAreClose {
-				foundMatch = true
+			if bestMatch >= 0 {
+				matched[bestMatch] = true
 				reducedChanges = append(
 					reducedChanges,
-					&object.Change{From: deletedBlobs[d].change.From,
+					&object.Change{From: deletedBlobs[bestMatch].change.From,
 						To: addedBlobs[a].change.To})
-				break
+				addedBlobs[a].change = nil
 			}
 		}
-		if foundMatch {
-			addedBlobs = append(addedBlobs[:a], addedBlobs[a+1:]...)
-			a--
-			deletedBlobs = append(deletedBlobs[:d], deletedBlobs[d+1:]...)
+		for d, isMatched := range matched {
+			if isMatched {
+				deletedBlobs[d].change = nil
+			}
 		}
 	}
 
 	// Stage 3 - we give up, everything left are independent additions and deletions
 	for _, blob := range addedBlobs {
-		reducedChanges = append(reducedChanges, blob.change)
+		if blob.change != nil {
+			reducedChanges = append(reducedChanges, blob.change)
+		}
 	}
 	for _, blob := range deletedBlobs {
-		reducedChanges = append(reducedChanges, blob.change)
+		if blob.change != nil {
+			reducedChanges = append(reducedChanges, blob.change)
+		}
 	}
-	return map[string]interface{}{DependencyTreeChanges: reducedChanges}, nil
+	return reducedChanges, nil
+}
+
+// candidateWindows returns the ranges [begin, end) of the deleted blobs whose sizes are close
+// to the size of each added blob. Both slices must be sorted by size.
+func (ra *RenameAnalysis) candidateWindows(addedBlobs, deletedBlobs sortableBlobs) [][2]int {
+	windows := make([][2]int, addedBlobs.Len())
+	begin, end := 0, 0
+	for a, blob := range addedBlobs {
+		for begin < deletedBlobs.Len() && !ra.sizesAreClose(blob.size, deletedBlobs[begin].size) &&
+			deletedBlobs[begin].size < blob.size {
+			begin++
+		}
+		if end < begin {
+			end = begin
+		}
+		for end < deletedBlobs.Len() && ra.sizesAreClose(blob.size, deletedBlobs[end].size) {
+			end++
+		}
+		windows[a] = [2]int{begin, end}
+	}
+	return windows
+}
+
+// scoreCandidates calculates the similarities of the added blobs to the deleted blobs
+// in the corresponding windows. Every blob is read only once.
+func (ra *RenameAnalysis) scoreCandidates(
+	addedBlobs, deletedBlobs sortableBlobs, windows [][2]int,
+	cache map[plumbing.Hash]*object.Blob) ([][]int, error) {
+	var hashes []plumbing.Hash
+	for _, blob := range addedBlobs {
+		hashes = append(hashes, blob.change.To.TreeEntry.Hash)
+	}
+	for _, blob := range deletedBlobs {
+		hashes = append(hashes, blob.change.From.TreeEntry.Hash)
+	}
+	// the blobs are read sequentially because the repository's storer is not safe
+	// for concurrent use; only the similarities are calculated in parallel
+	contents := make([]string, len(hashes))
+	for i, hash := range hashes {
+		var err error
+		if contents[i], err = BlobToString(cache[hash]); err != nil {
+			return nil, err
+		}
+	}
+	similarities := make([][]int, addedBlobs.Len())
+	ra.workers.ForEach(addedBlobs.Len(), func(a int) {
+		window := windows[a]
+		similarities[a] = make([]int, window[1]-window[0])
+		for d := window[0]; d < window[1]; d++ {
+			similarities[a][d-window[0]] = textSimilarity(
+				contents[a], contents[addedBlobs.Len()+d])
+		}
+	})
+	return similarities, nil
+}
+
+// ContentOptional returns true because the dependent items can use the changes from TreeDiff
+// without the detected renames. It is a part of ContentPipelineItem.
+func (ra *RenameAnalysis) ContentOptional() bool {
+	return true
 }
 
 // Fork clones this PipelineItem.
@@ -210,20 +420,16 @@ func (ra *RenameAnalysis) Fork(n int) []core.PipelineItem {
 }
 
 func (ra *RenameAnalysis) sizesAreClose(size1 int64, size2 int64) bool {
+	threshold := ra.SizeThreshold
+	if threshold == 0 {
+		threshold = 100 - ra.SimilarityThreshold
+	}
 	return internal.Abs64(size1-size2)*100/internal.Max64(1, internal.Min64(size1, size2)) <=
-		int64(100-ra.SimilarityThreshold)
+		int64(threshold)
 }
 
-func (ra *RenameAnalysis) blobsAreClose(
-	blob1 *object.Blob, blob2 *object.Blob) (bool, error) {
-	strFrom, err := BlobToString(blob1)
-	if err != nil {
-		return false, err
-	}
-	strTo, err := BlobToString(blob2)
-	if err != nil {
-		return false, err
-	}
+// textSimilarity returns the percentage of the common lines in the smaller text.
+func textSimilarity(strFrom, strTo string) int {
 	dmp := diffmatchpatch.New()
 	src, dst, _ := dmp.DiffLinesToRunes(strFrom, strTo)
 	diffs := dmp.DiffMainRunes(src, dst, false)
@@ -233,8 +439,7 @@ func (ra *RenameAnalysis) blobsAreClose(
 			common += utf8.RuneCountInString(edit.Text)
 		}
 	}
-	similarity := common * 100 / internal.Max(1, internal.Min(len(src), len(dst)))
-	return similarity >= ra.SimilarityThreshold, nil
+	return common * 100 / internal.Max(1, internal.Min(len(src), len(dst)))
 }
 
 type sortableChange struct {
@@ -245,12 +450,7 @@ type sortableChange struct {
 type sortableChanges []sortableChange
 
 func (change *sortableChange) Less(other *sortableChange) bool {
-	for x := 0; x < 20; x++ {
-		if change.hash[x] < other.hash[x] {
-			return true
-		}
-	}
-	return false
+	return bytes.Compare(change.hash[:], other.hash[:]) < 0
 }
 
 func (slice sortableChanges) Len() int {
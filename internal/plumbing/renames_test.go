@@ -1,11 +1,13 @@
 package plumbing
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/src-d/go-git.v4/plumbing"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
 	"gopkg.in/src-d/hercules.v4/internal/core"
 	"gopkg.in/src-d/hercules.v4/internal/test"
 )
@@ -25,16 +27,34 @@ func TestRenameAnalysisMeta(t *testing.T) {
 	assert.Equal(t, ra.Requires()[0], DependencyBlobCache)
 	assert.Equal(t, ra.Requires()[1], DependencyTreeChanges)
 	opts := ra.ListConfigurationOptions()
-	assert.Len(t, opts, 1)
+	assert.Len(t, opts, 4)
 	assert.Equal(t, opts[0].Name, ConfigRenameAnalysisSimilarityThreshold)
+	assert.Equal(t, opts[1].Name, ConfigRenameAnalysisSizeThreshold)
+	assert.Equal(t, opts[2].Name, ConfigRenameAnalysisMaxCandidates)
+	assert.Equal(t, opts[3].Name, ConfigRenameAnalysisExactOnly)
 	ra.SimilarityThreshold = 0
 	facts := map[string]interface{}{}
 	facts[ConfigRenameAnalysisSimilarityThreshold] = 70
+	facts[ConfigRenameAnalysisSizeThreshold] = 50
+	facts[ConfigRenameAnalysisMaxCandidates] = 1000
+	facts[ConfigRenameAnalysisExactOnly] = true
+	pool := core.NewWorkerPool(2)
+	facts[core.FactWorkerPool] = pool
 	ra.Configure(facts)
 	assert.Equal(t, ra.SimilarityThreshold, 70)
+	assert.Equal(t, ra.SizeThreshold, 50)
+	assert.Equal(t, ra.MaxCandidates, 1000)
+	assert.True(t, ra.ExactOnly)
+	assert.Equal(t, ra.workers, pool)
 	delete(facts, ConfigRenameAnalysisSimilarityThreshold)
+	delete(facts, ConfigRenameAnalysisSizeThreshold)
+	delete(facts, ConfigRenameAnalysisMaxCandidates)
+	delete(facts, ConfigRenameAnalysisExactOnly)
 	ra.Configure(facts)
 	assert.Equal(t, ra.SimilarityThreshold, 70)
+	assert.Equal(t, ra.SizeThreshold, 50)
+	assert.Equal(t, ra.MaxCandidates, 1000)
+	assert.True(t, ra.ExactOnly)
 }
 
 func TestRenameAnalysisRegistration(t *testing.T) {
@@ -61,6 +81,12 @@ func TestRenameAnalysisInitializeInvalidThreshold(t *testing.T) {
 	ra.Initialize(test.Repository)
 	ra = RenameAnalysis{SimilarityThreshold: 100}
 	ra.Initialize(test.Repository)
+	ra = RenameAnalysis{SimilarityThreshold: 90, SizeThreshold: -1}
+	ra.Initialize(test.Repository)
+	assert.Equal(t, ra.SizeThreshold, 0)
+	ra = RenameAnalysis{SimilarityThreshold: 90, MaxCandidates: -1}
+	ra.Initialize(test.Repository)
+	assert.Equal(t, ra.MaxCandidates, 0)
 }
 
 func TestRenameAnalysisConsume(t *testing.T) {
@@ -133,6 +159,153 @@ func TestRenameAnalysisConsume(t *testing.T) {
 	assert.Equal(t, len(renamed), 3)
 }
 
+// fixtureRenameBlobs stores the contents in memory and returns the deletions and the additions
+// of the files named by the keys together with the blob cache.
+func fixtureRenameBlobs(t *testing.T, deleted, added map[string]string) (
+	object.Changes, map[plumbing.Hash]*object.Blob) {
+	storage := memory.NewStorage()
+	cache := map[plumbing.Hash]*object.Blob{}
+	entry := func(name, contents string) object.ChangeEntry {
+		encoded := storage.NewEncodedObject()
+		encoded.SetType(plumbing.BlobObject)
+		writer, _ := encoded.Writer()
+		writer.Write([]byte(contents))
+		writer.Close()
+		hash, err := storage.SetEncodedObject(encoded)
+		assert.Nil(t, err)
+		cache[hash], err = object.GetBlob(storage, hash)
+		assert.Nil(t, err)
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{
+			Name: name, Mode: 0100644, Hash: hash}}
+	}
+	var changes object.Changes
+	for name, contents := range deleted {
+		changes = append(changes, &object.Change{From: entry(name, contents)})
+	}
+	for name, contents := range added {
+		changes = append(changes, &object.Change{To: entry(name, contents)})
+	}
+	return changes, cache
+}
+
+func TestRenameAnalysisSizeThreshold(t *testing.T) {
+	lines := ""
+	for i := 0; i < 10; i++ {
+		lines += fmt.Sprintf("line %d\n", i)
+	}
+	// renamed and doubled
+	changes, cache := fixtureRenameBlobs(t,
+		map[string]string{"old.go": lines}, map[string]string{"new.go": lines + lines})
+	ra := fixtureRenameAnalysis()
+	ra.SimilarityThreshold = 90
+	reduced, err := ra.detectRenames(changes, cache)
+	assert.Nil(t, err)
+	assert.Len(t, reduced, 2)
+	ra.SizeThreshold = 100
+	reduced, err = ra.detectRenames(changes, cache)
+	assert.Nil(t, err)
+	assert.Len(t, reduced, 1)
+	assert.Equal(t, reduced[0].From.Name, "old.go")
+	assert.Equal(t, reduced[0].To.Name, "new.go")
+	assert.NotEqual(t, ra.cacheKey(changes), (&RenameAnalysis{SimilarityThreshold: 90}).cacheKey(changes))
+}
+
+func TestRenameAnalysisBestMatch(t *testing.T) {
+	lines := ""
+	for i := 1; i <= 8; i++ {
+		lines += fmt.Sprintf("line %d\n", i)
+	}
+	// the smaller blob is checked first but the bigger one is more similar
+	changes, cache := fixtureRenameBlobs(t, map[string]string{
+		"x.go": lines + "x9\nx10\n",
+		"y.go": lines + "line 9\nyyyyyyy10\n",
+	}, map[string]string{"z.go": lines + "line 9\nadded 10\n"})
+	ra := fixtureRenameAnalysis()
+	ra.SimilarityThreshold = 70
+	reduced, err := ra.detectRenames(changes, cache)
+	assert.Nil(t, err)
+	assert.Len(t, reduced, 2)
+	for _, change := range reduced {
+		if change.To.Name != "" {
+			assert.Equal(t, change.From.Name, "y.go")
+			assert.Equal(t, change.To.Name, "z.go")
+		} else {
+			assert.Equal(t, change.From.Name, "x.go")
+		}
+	}
+}
+
+func TestRenameAnalysisLimits(t *testing.T) {
+	lines := ""
+	other := ""
+	for i := 0; i < 10; i++ {
+		lines += fmt.Sprintf("line %d\n", i)
+		other += fmt.Sprintf("other %d\n", i)
+	}
+	changes, cache := fixtureRenameBlobs(t, map[string]string{
+		"a.go": lines + "a\n", "b.go": other + "b\n", "e.go": "same\n",
+	}, map[string]string{
+		"c.go": lines + "c\n", "d.go": other + "d\n", "f.go": "same\n",
+	})
+	ra := fixtureRenameAnalysis()
+	ra.SimilarityThreshold = 90
+	reduced, err := ra.detectRenames(changes, cache)
+	assert.Nil(t, err)
+	assert.Len(t, reduced, 3)
+	// two candidate pairs exceed the limit, the exact match is still found
+	ra.MaxCandidates = 1
+	reduced, err = ra.detectRenames(changes, cache)
+	assert.Nil(t, err)
+	assert.Len(t, reduced, 5)
+	assert.Equal(t, reduced[0].From.Name, "e.go")
+	assert.Equal(t, reduced[0].To.Name, "f.go")
+	ra.MaxCandidates = 2
+	reduced, err = ra.detectRenames(changes, cache)
+	assert.Nil(t, err)
+	assert.Len(t, reduced, 3)
+	ra.ExactOnly = true
+	reduced, err = ra.detectRenames(changes, cache)
+	assert.Nil(t, err)
+	assert.Len(t, reduced, 5)
+	assert.NotEqual(t, ra.cacheKey(changes), (&RenameAnalysis{SimilarityThreshold: 90}).cacheKey(changes))
+}
+
+func TestRenameAnalysisParallel(t *testing.T) {
+	deleted := map[string]string{}
+	added := map[string]string{}
+	for i := 0; i < 20; i++ {
+		lines := ""
+		for j := 0; j < 10; j++ {
+			lines += fmt.Sprintf("file %d line %d\n", i, j)
+		}
+		deleted[fmt.Sprintf("old%d.go", i)] = lines
+		added[fmt.Sprintf("new%d.go", i)] = lines + "edit\n"
+	}
+	changes, cache := fixtureRenameBlobs(t, deleted, added)
+	ra := fixtureRenameAnalysis()
+	ra.SimilarityThreshold = 90
+	ra.workers = core.NewWorkerPool(4)
+	reduced, err := ra.detectRenames(changes, cache)
+	assert.Nil(t, err)
+	assert.Len(t, reduced, 20)
+	for _, change := range reduced {
+		assert.Equal(t, change.From.Name[3:], change.To.Name[3:])
+	}
+}
+
+func TestRenameAnalysisCandidateWindows(t *testing.T) {
+	ra := RenameAnalysis{SimilarityThreshold: 90}
+	blobs := func(sizes ...int64) sortableBlobs {
+		result := make(sortableBlobs, len(sizes))
+		for i, size := range sizes {
+			result[i].size = size
+		}
+		return result
+	}
+	windows := ra.candidateWindows(blobs(5, 100, 105, 1000), blobs(96, 100, 110, 111, 2000))
+	assert.Equal(t, windows, [][2]int{{0, 0}, {0, 3}, {0, 4}, {4, 4}})
+}
+
 func TestSortableChanges(t *testing.T) {
 	changes := sortableChanges{
 		sortableChange{
@@ -144,6 +317,16 @@ func TestSortableChanges(t *testing.T) {
 	assert.True(t, changes.Less(0, 1))
 	assert.False(t, changes.Less(1, 0))
 	assert.False(t, changes.Less(0, 0))
+	// the hashes are compared lexicographically
+	other := sortableChanges{
+		sortableChange{
+			nil, plumbing.NewHash("1000000000000000000000000000000000000000"),
+		}, sortableChange{
+			nil, plumbing.NewHash("0f00000000000000000000000000000000000000"),
+		},
+	}
+	assert.False(t, other.Less(0, 1))
+	assert.True(t, other.Less(1, 0))
 	changes.Swap(0, 1)
 	assert.Equal(t, changes[0].hash.String(), "ffffffffffffffffffffffffffffffffffffffff")
 	assert.Equal(t, changes[1].hash.String(), "0000000000000000000000000000000000000000")
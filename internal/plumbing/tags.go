@@ -0,0 +1,106 @@
+package plumbing
+
+import (
+	"log"
+	"sort"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+// TagsDetector reports the tags which point to each analysed commit. Both the lightweight
+// and the annotated tags are supported. It is a PipelineItem.
+type TagsDetector struct {
+	core.NoopMerger
+	// tags maps the commit hashes to the sorted names of the tags.
+	tags map[plumbing.Hash][]string
+}
+
+const (
+	// DependencyTags is the name of the dependency provided by TagsDetector.
+	// It is a []string with the sorted names of the tags which point to the current commit,
+	// nil if there are none.
+	DependencyTags = "tags"
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (detector *TagsDetector) Name() string {
+	return "TagsDetector"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (detector *TagsDetector) Provides() []string {
+	arr := [...]string{DependencyTags}
+	return arr[:]
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (detector *TagsDetector) Requires() []string {
+	return []string{}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (detector *TagsDetector) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (detector *TagsDetector) Configure(facts map[string]interface{}) {}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+// The tags are read from the repository once here.
+func (detector *TagsDetector) Initialize(repository *git.Repository) {
+	detector.tags = map[plumbing.Hash][]string{}
+	if repository == nil {
+		return
+	}
+	refs, err := repository.Tags()
+	if err != nil {
+		log.Printf("Warning: failed to list the tags: %v\n", err)
+		return
+	}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		hash := ref.Hash()
+		if tag, err := repository.TagObject(hash); err == nil {
+			// annotated tag
+			if tag.TargetType != plumbing.CommitObject {
+				return nil
+			}
+			hash = tag.Target
+		}
+		detector.tags[hash] = append(detector.tags[hash], ref.Name().Short())
+		return nil
+	})
+	if err != nil {
+		log.Printf("Warning: failed to read the tags: %v\n", err)
+	}
+	for _, names := range detector.tags {
+		sort.Strings(names)
+	}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (detector *TagsDetector) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	return map[string]interface{}{DependencyTags: detector.tags[commit.Hash]}, nil
+}
+
+// Fork clones this PipelineItem.
+func (detector *TagsDetector) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(detector, n)
+}
+
+func init() {
+	core.Registry.Register(&TagsDetector{})
+}
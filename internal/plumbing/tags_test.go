@@ -0,0 +1,76 @@
+package plumbing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-billy.v4/memfs"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func TestTagsDetectorMeta(t *testing.T) {
+	detector := &items.TagsDetector{}
+	assert.Equal(t, detector.Name(), "TagsDetector")
+	assert.Equal(t, detector.Provides(), []string{items.DependencyTags})
+	assert.Len(t, detector.Requires(), 0)
+	assert.Len(t, detector.ListConfigurationOptions(), 0)
+	detector.Configure(nil)
+	summoned := core.Registry.Summon(items.DependencyTags)
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "TagsDetector")
+}
+
+func TestTagsDetectorConsume(t *testing.T) {
+	storage := memory.NewStorage()
+	repository, err := git.Init(storage, memfs.New())
+	assert.Nil(t, err)
+	worktree, err := repository.Worktree()
+	assert.Nil(t, err)
+	signature := &object.Signature{Name: "Alice", Email: "alice@example.com", When: time.Unix(0, 0)}
+	commit := func(name string) plumbing.Hash {
+		file, err := worktree.Filesystem.Create(name)
+		assert.Nil(t, err)
+		file.Write([]byte(name))
+		file.Close()
+		_, err = worktree.Add(name)
+		assert.Nil(t, err)
+		hash, err := worktree.Commit(name, &git.CommitOptions{Author: signature})
+		assert.Nil(t, err)
+		return hash
+	}
+	first := commit("a")
+	second := commit("b")
+	third := commit("c")
+	// lightweight tags
+	assert.Nil(t, storage.SetReference(plumbing.NewHashReference("refs/tags/v1.0.0", first)))
+	assert.Nil(t, storage.SetReference(plumbing.NewHashReference("refs/tags/latest", third)))
+	// annotated tag
+	tag := &object.Tag{Name: "v2.0.0", Tagger: *signature, Message: "Release\n",
+		Target: third, TargetType: plumbing.CommitObject}
+	encoded := storage.NewEncodedObject()
+	assert.Nil(t, tag.Encode(encoded))
+	tagHash, err := storage.SetEncodedObject(encoded)
+	assert.Nil(t, err)
+	assert.Nil(t, storage.SetReference(plumbing.NewHashReference("refs/tags/v2.0.0", tagHash)))
+
+	detector := &items.TagsDetector{}
+	detector.Initialize(repository)
+	consume := func(hash plumbing.Hash) []string {
+		result, err := detector.Consume(map[string]interface{}{
+			core.DependencyCommit: &object.Commit{Hash: hash}})
+		assert.Nil(t, err)
+		return result[items.DependencyTags].([]string)
+	}
+	assert.Equal(t, consume(first), []string{"v1.0.0"})
+	assert.Nil(t, consume(second))
+	assert.Equal(t, consume(third), []string{"latest", "v2.0.0"})
+
+	detector.Initialize(nil)
+	assert.Nil(t, consume(first))
+}
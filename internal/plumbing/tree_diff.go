@@ -21,10 +21,17 @@ type TreeDiff struct {
 	core.NoopMerger
 	SkipDirs     []string
 	Languages    map[string]bool
+	// SkipVendored excludes the vendored and the generated files, see ConfigTreeDiffSkipVendored.
+	SkipVendored bool
 
 	previousTree *object.Tree
 	previousCommit plumbing.Hash
 	repository *git.Repository
+	cache core.Storage
+	// previousAttributes are the linguist overrides in .gitattributes of previousTree.
+	previousAttributes linguistAttributes
+	// attributesHash is the hash of .gitattributes which previousAttributes were read from.
+	attributesHash plumbing.Hash
 }
 
 const (
@@ -42,6 +49,11 @@ const (
 	// https://doc.bblf.sh/languages.html Names are joined with a comma ",".
 	// "all" is the special name which disables this filter.
 	ConfigTreeDiffLanguages = "TreeDiff.Languages"
+	// ConfigTreeDiffSkipVendored is the name of the configuration option (TreeDiff.Configure())
+	// which excludes the vendored and the generated files detected in the linguist style:
+	// vendor/, node_modules/, *.pb.go, minified JavaScript, "DO NOT EDIT" markers, etc.
+	// linguist-vendored and linguist-generated in the root .gitattributes override the detection.
+	ConfigTreeDiffSkipVendored = "TreeDiff.SkipVendored"
 	// allLanguages denotes passing all files in.
 	allLanguages = "all"
 )
@@ -96,7 +108,13 @@ func (treediff *TreeDiff) ListConfigurationOptions() []core.ConfigurationOption
 			"which disables this filter and lets all the files through.", allLanguages),
 		Flag:        "languages",
 		Type:        core.StringsConfigurationOption,
-		Default:     []string{allLanguages}},
+		Default:     []string{allLanguages}}, {
+		Name:        ConfigTreeDiffSkipVendored,
+		Description: "Skip the vendored and the generated files, e.g. vendor/ or *.pb.go. " +
+			"linguist-vendored and linguist-generated in .gitattributes are respected.",
+		Flag:        "skip-vendored",
+		Type:        core.BoolConfigurationOption,
+		Default:     false},
 	}
 	return options[:]
 }
@@ -115,12 +133,20 @@ func (treediff *TreeDiff) Configure(facts map[string]interface{}) {
 		treediff.Languages = map[string]bool{}
 		treediff.Languages[allLanguages] = true
 	}
+	if val, exists := facts[ConfigTreeDiffSkipVendored].(bool); exists {
+		treediff.SkipVendored = val
+	}
+	if val, exists := facts[core.FactPersistentCache].(core.Storage); exists {
+		treediff.cache = val
+	}
 }
 
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (treediff *TreeDiff) Initialize(repository *git.Repository) {
 	treediff.previousTree = nil
+	treediff.previousAttributes = nil
+	treediff.attributesHash = plumbing.ZeroHash
 	treediff.repository = repository
 	if treediff.Languages == nil {
 		treediff.Languages = map[string]bool{}
@@ -150,7 +176,7 @@ func (treediff *TreeDiff) Consume(deps map[string]interface{}) (map[string]inter
 	}
 	var diff object.Changes
 	if treediff.previousTree != nil {
-		diff, err = object.DiffTree(treediff.previousTree, tree)
+		diff, err = treediff.diffTrees(treediff.previousCommit, commit.Hash, tree)
 		if err != nil {
 			return nil, err
 		}
@@ -184,6 +210,11 @@ func (treediff *TreeDiff) Consume(deps map[string]interface{}) (map[string]inter
 			return nil, err
 		}
 	}
+	if treediff.SkipVendored {
+		attributes := treediff.loadLinguistAttributes(tree)
+		diff = treediff.filterVendored(diff, treediff.previousAttributes, attributes)
+		treediff.previousAttributes = attributes
+	}
 	treediff.previousTree = tree
 	treediff.previousCommit = commit.Hash
 
@@ -218,6 +249,28 @@ func (treediff *TreeDiff) Fork(n int) []core.PipelineItem {
 	return core.ForkCopyPipelineItem(treediff, n)
 }
 
+// diffTrees compares the previous tree with the specified one. The result is taken from
+// the persistent cache if it exists.
+func (treediff *TreeDiff) diffTrees(from, to plumbing.Hash, tree *object.Tree) (
+	object.Changes, error) {
+	key := "TreeDiff/" + from.String() + "/" + to.String()
+	diff := loadChanges(treediff.cache, key, func(entry cachedChangeEntry, isTo bool) object.ChangeEntry {
+		if isTo {
+			return entry.restore(tree)
+		}
+		return entry.restore(treediff.previousTree)
+	})
+	if diff != nil {
+		return diff, nil
+	}
+	diff, err := object.DiffTree(treediff.previousTree, tree)
+	if err != nil {
+		return nil, err
+	}
+	storeChanges(treediff.cache, key, diff)
+	return diff, nil
+}
+
 // checkLanguage returns whether the blob corresponds to the list of required languages.
 func (treediff *TreeDiff) checkLanguage(name string, blobHash plumbing.Hash) (bool, error) {
 	if treediff.Languages[allLanguages] {
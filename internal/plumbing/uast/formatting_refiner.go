@@ -0,0 +1,88 @@
+package uast
+
+import (
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+// FormattingRefiner uses the structural diff to find more formatting-only hunks than
+// plumbing.FormattingDetector does. It is a PipelineItem.
+// If the UASTs before and after the change are structurally equal, every hunk of the file
+// is formatting-only even if it changed more than the whitespace, e.g. the parentheses
+// or the trailing commas. The comments count as the formatting unless
+// StructuralDiff.WithComments is set.
+type FormattingRefiner struct {
+	core.NoopMerger
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (ref *FormattingRefiner) Name() string {
+	return "FormattingRefiner"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (ref *FormattingRefiner) Provides() []string {
+	arr := [...]string{plumbing.DependencyFormattingHunks}
+	return arr[:]
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (ref *FormattingRefiner) Requires() []string {
+	arr := [...]string{plumbing.DependencyFormattingHunks, DependencyStructuralDiff}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (ref *FormattingRefiner) Features() []string {
+	arr := [...]string{FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (ref *FormattingRefiner) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (ref *FormattingRefiner) Configure(facts map[string]interface{}) {}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (ref *FormattingRefiner) Initialize(repository *git.Repository) {}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (ref *FormattingRefiner) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	hunks := deps[plumbing.DependencyFormattingHunks].(map[string][]bool)
+	diffs := deps[DependencyStructuralDiff].(map[string]StructuralDiffData)
+	result := make(map[string][]bool, len(hunks))
+	for fileName, flags := range hunks {
+		if diff, exists := diffs[fileName]; !exists || !diff.FormattingOnly() {
+			result[fileName] = flags
+			continue
+		}
+		refined := make([]bool, len(flags))
+		for i := range refined {
+			refined[i] = true
+		}
+		result[fileName] = refined
+	}
+	return map[string]interface{}{plumbing.DependencyFormattingHunks: result}, nil
+}
+
+// Fork clones this PipelineItem.
+func (ref *FormattingRefiner) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(ref, n)
+}
+
+func init() {
+	core.Registry.Register(&FormattingRefiner{})
+}
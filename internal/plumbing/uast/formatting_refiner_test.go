@@ -0,0 +1,52 @@
+package uast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func TestFormattingRefinerMeta(t *testing.T) {
+	ref := &FormattingRefiner{}
+	ref.Initialize(nil)
+	assert.Equal(t, ref.Name(), "FormattingRefiner")
+	assert.Equal(t, ref.Provides(), []string{plumbing.DependencyFormattingHunks})
+	assert.Equal(t, ref.Requires(), []string{
+		plumbing.DependencyFormattingHunks, DependencyStructuralDiff})
+	assert.Equal(t, ref.Features(), []string{FeatureUast})
+	assert.Len(t, ref.ListConfigurationOptions(), 0)
+	ref.Configure(nil)
+	summoned := core.Registry.Summon(ref.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "FormattingRefiner")
+	summoned = core.Registry.Summon(plumbing.DependencyFormattingHunks)
+	assert.Len(t, summoned, 2)
+}
+
+func TestFormattingRefinerConsume(t *testing.T) {
+	ref := &FormattingRefiner{}
+	ref.Initialize(nil)
+	hunks := map[string][]bool{
+		"a.go": {false, true},
+		"b.go": {false},
+		"c.go": {false},
+	}
+	diffs := map[string]StructuralDiffData{
+		"a.go": {},
+		"b.go": {Changes: []StructuralChange{{After: &uast.Node{InternalType: "Call"}}}},
+	}
+	result, err := ref.Consume(map[string]interface{}{
+		plumbing.DependencyFormattingHunks: hunks,
+		DependencyStructuralDiff:           diffs,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, result[plumbing.DependencyFormattingHunks], map[string][]bool{
+		"a.go": {true, true},
+		"b.go": {false},
+		"c.go": {false},
+	})
+	assert.Equal(t, hunks["a.go"], []bool{false, true})
+}
@@ -0,0 +1,123 @@
+package uast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/bblfsh/client-go.v2"
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// Parser converts the contents of a file to UAST. Extractor creates a separate Parser
+// for each of its goroutines so the implementations do not have to be thread-safe.
+type Parser interface {
+	// Parse returns the UAST of the file. It returns nil without an error if the language
+	// is not supported.
+	Parse(ctx context.Context, name string, contents []byte) (*uast.Node, error)
+	// Version identifies the parser together with its grammars. The UASTs which were cached
+	// by a different version are not reused. Empty string disables the caching.
+	Version() string
+}
+
+// ParserFactory creates a new Parser configured by the specified Extractor.
+type ParserFactory func(exr *Extractor) (Parser, error)
+
+// DefaultParser is the name of the Parser which Extractor uses by default.
+const DefaultParser = "bblfsh"
+
+var parsers = struct {
+	sync.RWMutex
+	factories map[string]ParserFactory
+}{factories: map[string]ParserFactory{}}
+
+// RegisterParser makes the Parser available to Extractor under the specified name,
+// see ConfigUASTParser. It is intended to be called from init().
+func RegisterParser(name string, factory ParserFactory) {
+	parsers.Lock()
+	defer parsers.Unlock()
+	parsers.factories[name] = factory
+}
+
+// Parsers returns the sorted names of the registered parsers.
+func Parsers() []string {
+	parsers.RLock()
+	defer parsers.RUnlock()
+	names := make([]string, 0, len(parsers.factories))
+	for name := range parsers.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parserFactory returns the registered ParserFactory with the specified name.
+func parserFactory(name string) (ParserFactory, error) {
+	parsers.RLock()
+	factory, exists := parsers.factories[name]
+	parsers.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("unknown UAST parser: %s (registered: %s)",
+			name, strings.Join(Parsers(), ", "))
+	}
+	return factory, nil
+}
+
+// newParser creates the registered Parser with the specified name.
+func newParser(name string, exr *Extractor) (Parser, error) {
+	factory, err := parserFactory(name)
+	if err != nil {
+		return nil, err
+	}
+	return factory(exr)
+}
+
+// babelfishParser sends the files to a Babelfish server.
+type babelfishParser struct {
+	client *bblfsh.Client
+}
+
+func newBabelfishParser(exr *Extractor) (Parser, error) {
+	client, err := bblfsh.NewClient(exr.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return babelfishParser{client: client}, nil
+}
+
+// Parse queries the Babelfish server.
+func (parser babelfishParser) Parse(
+	ctx context.Context, name string, contents []byte) (*uast.Node, error) {
+	request := parser.client.NewParseRequest()
+	request.Content(string(contents))
+	request.Filename(name)
+	response, err := request.DoWithContext(ctx)
+	if err != nil {
+		if strings.Contains(err.Error(), "missing driver") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if response.Status != protocol.Ok {
+		return nil, errors.New(strings.Join(response.Errors, "\n"))
+	}
+	return response.UAST, nil
+}
+
+// Version returns the version of the Babelfish server. The versions of the drivers are not
+// taken into account, so the cache should be cleared after they are updated.
+func (parser babelfishParser) Version() string {
+	response, err := parser.client.NewVersionRequest().Do()
+	if err != nil || response.Status != protocol.Ok {
+		return ""
+	}
+	return "bblfsh/" + response.Version
+}
+
+func init() {
+	RegisterParser(DefaultParser, newBabelfishParser)
+}
@@ -0,0 +1,245 @@
+package uast
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+type fakeParser struct {
+	calls *int
+}
+
+func (parser fakeParser) Parse(
+	ctx context.Context, name string, contents []byte) (*uast.Node, error) {
+	*parser.calls++
+	switch name {
+	case "broken.go":
+		return nil, errors.New("syntax error")
+	case "README":
+		return nil, nil
+	}
+	return &uast.Node{InternalType: name, Token: string(contents)}, nil
+}
+
+func (parser fakeParser) Version() string {
+	return "fake/1"
+}
+
+func fixtureParsedChanges(t *testing.T) (object.Changes, map[plumbing.Hash]*object.Blob) {
+	storage := memory.NewStorage()
+	cache := map[plumbing.Hash]*object.Blob{}
+	var changes object.Changes
+	for _, name := range []string{"main.go", "broken.go", "README"} {
+		encoded := storage.NewEncodedObject()
+		encoded.SetType(plumbing.BlobObject)
+		writer, _ := encoded.Writer()
+		writer.Write([]byte("contents of " + name))
+		writer.Close()
+		hash, err := storage.SetEncodedObject(encoded)
+		assert.Nil(t, err)
+		cache[hash], err = object.GetBlob(storage, hash)
+		assert.Nil(t, err)
+		changes = append(changes, &object.Change{To: object.ChangeEntry{
+			Name: name, TreeEntry: object.TreeEntry{Name: name, Mode: 0100644, Hash: hash}}})
+	}
+	return changes, cache
+}
+
+func TestParsersRegistry(t *testing.T) {
+	assert.Contains(t, Parsers(), DefaultParser)
+	calls := 0
+	RegisterParser("fake", func(exr *Extractor) (Parser, error) {
+		return fakeParser{calls: &calls}, nil
+	})
+	assert.Contains(t, Parsers(), "fake")
+	parser, err := newParser("fake", &Extractor{})
+	assert.Nil(t, err)
+	node, err := parser.Parse(context.Background(), "main.go", []byte("package main"))
+	assert.Nil(t, err)
+	assert.Equal(t, node.Token, "package main")
+	assert.Equal(t, calls, 1)
+	parser, err = newParser("xxx", &Extractor{})
+	assert.Nil(t, parser)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "xxx")
+}
+
+func TestUASTExtractorCustomParser(t *testing.T) {
+	calls := 0
+	RegisterParser("fake", func(exr *Extractor) (Parser, error) {
+		return fakeParser{calls: &calls}, nil
+	})
+	exr := Extractor{Parser: "fake", PoolSize: 2}
+	exr.Initialize(nil)
+	changes, cache := fixtureParsedChanges(t)
+	deps := map[string]interface{}{
+		items.DependencyBlobCache:   cache,
+		items.DependencyTreeChanges: changes,
+	}
+	result, err := exr.Consume(deps)
+	assert.Nil(t, err)
+	assert.Equal(t, calls, 3)
+	uasts := result[DependencyUasts].(map[plumbing.Hash]*uast.Node)
+	assert.Len(t, uasts, 1)
+	assert.Equal(t, uasts[changes[0].To.TreeEntry.Hash].Token, "contents of main.go")
+	exr.FailOnErrors = true
+	result, err = exr.Consume(deps)
+	assert.Nil(t, result)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "syntax error")
+}
+
+func TestUASTExtractorPersistentCache(t *testing.T) {
+	calls := 0
+	RegisterParser("fake", func(exr *Extractor) (Parser, error) {
+		return fakeParser{calls: &calls}, nil
+	})
+	tmpdir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpdir)
+	storage, err := core.NewDiskCache(tmpdir)
+	assert.Nil(t, err)
+	changes, cache := fixtureParsedChanges(t)
+	deps := map[string]interface{}{
+		items.DependencyBlobCache:   cache,
+		items.DependencyTreeChanges: changes,
+	}
+	consume := func() map[plumbing.Hash]*uast.Node {
+		exr := Extractor{Parser: "fake"}
+		exr.Configure(map[string]interface{}{core.FactPersistentCache: storage})
+		exr.Initialize(nil)
+		result, err := exr.Consume(deps)
+		assert.Nil(t, err)
+		return result[DependencyUasts].(map[plumbing.Hash]*uast.Node)
+	}
+	uasts := consume()
+	assert.Equal(t, calls, 3)
+	assert.Len(t, uasts, 1)
+	calls = 0
+	cached := consume()
+	// the parsing errors are not cached
+	assert.Equal(t, calls, 1)
+	assert.Len(t, cached, 1)
+	hash := changes[0].To.TreeEntry.Hash
+	assert.Equal(t, cached[hash].Token, uasts[hash].Token)
+	assert.Equal(t, cached[hash].InternalType, "main.go")
+	exr := Extractor{cache: storage, cacheVersion: "fake/1"}
+	assert.Equal(t, exr.cacheKey(&object.File{Name: "a/b/main.GO", Blob: object.Blob{Hash: hash}}),
+		"UAST/fake/1/.go/"+hash.String())
+	assert.Equal(t, exr.cacheKey(&object.File{Name: "a/Makefile", Blob: object.Blob{Hash: hash}}),
+		"UAST/fake/1/Makefile/"+hash.String())
+	exr.cacheVersion = ""
+	assert.Equal(t, exr.cacheKey(&object.File{Name: "main.go"}), "")
+}
+
+func TestUASTExtractorLimits(t *testing.T) {
+	calls := 0
+	RegisterParser("fake", func(exr *Extractor) (Parser, error) {
+		return fakeParser{calls: &calls}, nil
+	})
+	changes, cache := fixtureParsedChanges(t)
+	deps := map[string]interface{}{
+		items.DependencyBlobCache:   cache,
+		items.DependencyTreeChanges: changes,
+	}
+	exr := Extractor{Parser: "fake", FailOnErrors: true}
+	exr.Configure(map[string]interface{}{ConfigUASTMaxFileSize: 20})
+	exr.Initialize(nil)
+	// "contents of broken.go" is 21 bytes long
+	result, err := exr.Consume(deps)
+	assert.Nil(t, err)
+	assert.Equal(t, calls, 2)
+	assert.Len(t, result[DependencyUasts], 1)
+	assert.Equal(t, exr.ProcessedFiles, map[string]int{"main.go": 1, "README": 1})
+	calls = 0
+	exr = Extractor{Parser: "fake", MaxFileSize: -1}
+	exr.Configure(map[string]interface{}{ConfigUASTSkipLanguages: []string{"GO"}})
+	exr.Initialize(nil)
+	assert.Equal(t, exr.MaxFileSize, 0)
+	result, err = exr.Consume(deps)
+	assert.Nil(t, err)
+	assert.Equal(t, calls, 1)
+	assert.Len(t, result[DependencyUasts], 0)
+	assert.Equal(t, exr.ProcessedFiles, map[string]int{"README": 1})
+}
+
+func TestUASTExtractorTimeout(t *testing.T) {
+	exr := Extractor{}
+	exr.Configure(map[string]interface{}{ConfigUASTTimeout: 0})
+	ctx, cancel := exr.Context()
+	assert.Nil(t, cancel)
+	_, exists := ctx.Deadline()
+	assert.False(t, exists)
+	exr.Configure(map[string]interface{}{ConfigUASTTimeout: 5})
+	ctx, cancel = exr.Context()
+	assert.NotNil(t, cancel)
+	cancel()
+	_, exists = ctx.Deadline()
+	assert.True(t, exists)
+}
+
+func TestUASTExtractorUnparsedFiles(t *testing.T) {
+	calls := 0
+	RegisterParser("fake", func(exr *Extractor) (Parser, error) {
+		return fakeParser{calls: &calls}, nil
+	})
+	changes, cache := fixtureParsedChanges(t)
+	deps := map[string]interface{}{
+		items.DependencyBlobCache:   cache,
+		items.DependencyTreeChanges: changes,
+	}
+	facts := map[string]interface{}{}
+	exr := Extractor{Parser: "fake"}
+	exr.Configure(facts)
+	exr.Initialize(nil)
+	unparsed := facts[FactUASTUnparsedFiles].(*UnparsedFiles)
+	assert.Nil(t, unparsed.Files())
+	_, err := exr.Consume(deps)
+	assert.Nil(t, err)
+	assert.Equal(t, unparsed.Files(), map[string]core.UnparsedFile{
+		"broken.go": {Language: "Go", Reason: "syntax error"},
+		"README":    {Language: "", Reason: UnparsedReasonUnsupported},
+	})
+	// the forks share the same collector
+	other := Extractor{Parser: "fake"}
+	other.Configure(facts)
+	assert.True(t, other.unparsed == unparsed)
+}
+
+func TestUASTExtractorUnavailable(t *testing.T) {
+	RegisterParser("down", func(exr *Extractor) (Parser, error) {
+		return nil, errors.New("connection refused")
+	})
+	changes, cache := fixtureParsedChanges(t)
+	deps := map[string]interface{}{
+		items.DependencyBlobCache:   cache,
+		items.DependencyTreeChanges: changes,
+	}
+	facts := map[string]interface{}{}
+	exr := Extractor{Parser: "down"}
+	exr.Configure(facts)
+	exr.Initialize(nil)
+	assert.NotNil(t, exr.unavailable)
+	result, err := exr.Consume(deps)
+	assert.Nil(t, err)
+	assert.Len(t, result[DependencyUasts], 0)
+	files := facts[FactUASTUnparsedFiles].(*UnparsedFiles).Files()
+	assert.Len(t, files, 3)
+	assert.Equal(t, files["main.go"], core.UnparsedFile{
+		Language: "Go", Reason: UnparsedReasonUnavailable})
+	exr.FailOnErrors = true
+	assert.Panics(t, func() { exr.Initialize(nil) })
+	exr = Extractor{Parser: "xxx"}
+	assert.Panics(t, func() { exr.Initialize(nil) })
+}
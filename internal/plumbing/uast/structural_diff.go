@@ -0,0 +1,293 @@
+package uast
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/minio/highwayhash"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+// StructuralDiff compares the UASTs before and after each change and finds the nodes which
+// were inserted, deleted or updated. Unlike the line diff, it ignores the formatting: the node
+// positions, the whitespace and, unless WithComments is set, the comments. It is a PipelineItem.
+// The identical subtrees are matched by their hashes, the children which are not identical
+// are aligned by the longest common subsequence and the remaining children of the same
+// internal type are compared recursively in the order of appearance.
+type StructuralDiff struct {
+	core.NoopMerger
+	// WithComments makes the comment nodes count as the structural changes.
+	WithComments bool
+}
+
+// StructuralChange is a node-level difference between two UASTs.
+type StructuralChange struct {
+	// Before is nil if the node was inserted.
+	Before *uast.Node
+	// After is nil if the node was deleted. If both Before and After are set,
+	// the node was updated in place: its token, type, roles or properties changed.
+	After *uast.Node
+}
+
+// StructuralDiffData is the structural difference of a file.
+type StructuralDiffData struct {
+	// Changes are the topmost changed nodes in the depth-first order; the inserted and the deleted
+	// nodes include their subtrees.
+	Changes []StructuralChange
+}
+
+// FormattingOnly returns true if the file changed but its structure did not.
+func (data StructuralDiffData) FormattingOnly() bool {
+	return len(data.Changes) == 0
+}
+
+const (
+	// ConfigStructuralDiffWithComments is the name of the configuration option
+	// (StructuralDiff.Configure()) which makes the comment changes structural.
+	ConfigStructuralDiffWithComments = "StructuralDiff.WithComments"
+
+	// DependencyStructuralDiff is the name of the dependency provided by StructuralDiff.
+	DependencyStructuralDiff = "structural_diff"
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (diff *StructuralDiff) Name() string {
+	return "StructuralDiff"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (diff *StructuralDiff) Provides() []string {
+	arr := [...]string{DependencyStructuralDiff}
+	return arr[:]
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (diff *StructuralDiff) Requires() []string {
+	arr := [...]string{DependencyUastChanges}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (diff *StructuralDiff) Features() []string {
+	arr := [...]string{FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (diff *StructuralDiff) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigStructuralDiffWithComments,
+		Description: "Treat the changed comments as the structural changes.",
+		Flag:        "structural-diff-comments",
+		Type:        core.BoolConfigurationOption,
+		Default:     false},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (diff *StructuralDiff) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigStructuralDiffWithComments].(bool); exists {
+		diff.WithComments = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (diff *StructuralDiff) Initialize(repository *git.Repository) {}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+// The result maps the file names to StructuralDiffData; the deleted files are mapped by their
+// previous names. The files which could not be parsed before or after the change are absent.
+func (diff *StructuralDiff) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	changes := deps[DependencyUastChanges].([]Change)
+	result := map[string]StructuralDiffData{}
+	for _, change := range changes {
+		switch {
+		case change.Change.From.Name == "":
+			if change.After != nil {
+				result[change.Change.To.Name] = StructuralDiffData{
+					Changes: []StructuralChange{{After: change.After}}}
+			}
+		case change.Change.To.Name == "":
+			if change.Before != nil {
+				result[change.Change.From.Name] = StructuralDiffData{
+					Changes: []StructuralChange{{Before: change.Before}}}
+			}
+		case change.Before != nil && change.After != nil:
+			result[change.Change.To.Name] = StructuralDiffData{
+				Changes: diff.Compare(change.Before, change.After)}
+		}
+	}
+	return map[string]interface{}{DependencyStructuralDiff: result}, nil
+}
+
+// Fork clones this PipelineItem.
+func (diff *StructuralDiff) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(diff, n)
+}
+
+// Compare returns the structural changes between two UASTs.
+func (diff *StructuralDiff) Compare(before, after *uast.Node) []StructuralChange {
+	differ := structuralDiffer{
+		withComments: diff.WithComments,
+		hashes:       map[*uast.Node]uint64{},
+		labels:       map[*uast.Node]uint64{},
+		changes:      []StructuralChange{},
+	}
+	differ.hash(before)
+	differ.hash(after)
+	differ.match(before, after)
+	return differ.changes
+}
+
+// structuralDiffer holds the state of a single StructuralDiff.Compare().
+type structuralDiffer struct {
+	withComments bool
+	// hashes of the subtrees
+	hashes map[*uast.Node]uint64
+	// hashes of the nodes themselves, without the children
+	labels  map[*uast.Node]uint64
+	changes []StructuralChange
+}
+
+// significant returns false for the nodes which do not affect the structure.
+func (differ *structuralDiffer) significant(node *uast.Node) bool {
+	for _, role := range node.Roles {
+		if role == uast.Whitespace || (role == uast.Comment && !differ.withComments) {
+			return false
+		}
+	}
+	return true
+}
+
+// children returns the significant children of the node.
+func (differ *structuralDiffer) children(node *uast.Node) []*uast.Node {
+	result := make([]*uast.Node, 0, len(node.Children))
+	for _, child := range node.Children {
+		if differ.significant(child) {
+			result = append(result, child)
+		}
+	}
+	return result
+}
+
+// hash calculates the hashes of the subtree, ignoring the positions.
+func (differ *structuralDiffer) hash(node *uast.Node) uint64 {
+	buffer := []byte(node.InternalType + "|" + node.Token + "|")
+	roles := make([]int, len(node.Roles))
+	for i, role := range node.Roles {
+		roles[i] = int(role)
+	}
+	sort.Ints(roles)
+	for _, role := range roles {
+		buffer = append(buffer, byte(role), byte(role>>8))
+	}
+	keys := make([]string, 0, len(node.Properties))
+	for key := range node.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		buffer = append(buffer, "|"+key+"="+node.Properties[key]...)
+	}
+	label := highwayhash.Sum64(buffer, hashKey)
+	differ.labels[node] = label
+	subtree := make([]byte, 8, 8*(len(node.Children)+1))
+	binary.LittleEndian.PutUint64(subtree, label)
+	for _, child := range differ.children(node) {
+		var childHash [8]byte
+		binary.LittleEndian.PutUint64(childHash[:], differ.hash(child))
+		subtree = append(subtree, childHash[:]...)
+	}
+	hash := highwayhash.Sum64(subtree, hashKey)
+	differ.hashes[node] = hash
+	return hash
+}
+
+// match compares two nodes which occupy the same place in the trees.
+func (differ *structuralDiffer) match(before, after *uast.Node) {
+	if differ.hashes[before] == differ.hashes[after] {
+		return
+	}
+	if differ.labels[before] != differ.labels[after] {
+		differ.changes = append(differ.changes, StructuralChange{Before: before, After: after})
+	}
+	oldChildren, newChildren := differ.children(before), differ.children(after)
+	pairs := differ.align(oldChildren, newChildren)
+	i, j := 0, 0
+	for _, pair := range append(pairs, [2]int{len(oldChildren), len(newChildren)}) {
+		differ.matchGap(oldChildren[i:pair[0]], newChildren[j:pair[1]])
+		i, j = pair[0]+1, pair[1]+1
+	}
+}
+
+// align returns the indexes of the identical children which form the longest common
+// subsequence.
+func (differ *structuralDiffer) align(oldChildren, newChildren []*uast.Node) [][2]int {
+	lengths := make([][]int, len(oldChildren)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(newChildren)+1)
+	}
+	for i := len(oldChildren) - 1; i >= 0; i-- {
+		for j := len(newChildren) - 1; j >= 0; j-- {
+			if differ.hashes[oldChildren[i]] == differ.hashes[newChildren[j]] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+	var pairs [][2]int
+	for i, j := 0, 0; i < len(oldChildren) && j < len(newChildren); {
+		if differ.hashes[oldChildren[i]] == differ.hashes[newChildren[j]] {
+			pairs = append(pairs, [2]int{i, j})
+			i++
+			j++
+		} else if lengths[i+1][j] >= lengths[i][j+1] {
+			i++
+		} else {
+			j++
+		}
+	}
+	return pairs
+}
+
+// matchGap compares the children between two identical ones: the nodes of the same type
+// are matched in the order of appearance, the rest are deleted or inserted.
+func (differ *structuralDiffer) matchGap(oldChildren, newChildren []*uast.Node) {
+	j := 0
+	for _, old := range oldChildren {
+		k := j
+		for ; k < len(newChildren) && newChildren[k].InternalType != old.InternalType; k++ {
+		}
+		if k == len(newChildren) {
+			differ.changes = append(differ.changes, StructuralChange{Before: old})
+			continue
+		}
+		for ; j < k; j++ {
+			differ.changes = append(differ.changes, StructuralChange{After: newChildren[j]})
+		}
+		differ.match(old, newChildren[k])
+		j = k + 1
+	}
+	for ; j < len(newChildren); j++ {
+		differ.changes = append(differ.changes, StructuralChange{After: newChildren[j]})
+	}
+}
+
+func init() {
+	core.Registry.Register(&StructuralDiff{})
+}
@@ -0,0 +1,120 @@
+package uast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+func fixtureStructuralDiff() *StructuralDiff {
+	diff := &StructuralDiff{}
+	diff.Initialize(nil)
+	return diff
+}
+
+// fixtureStructuralTree builds a small UAST; `line` shifts the positions.
+func fixtureStructuralTree(line uint32, name, comment string, calls ...string) *uast.Node {
+	position := func(offset uint32) *uast.Position {
+		return &uast.Position{Line: line + offset}
+	}
+	body := &uast.Node{InternalType: "Block", Roles: []uast.Role{uast.Body}}
+	for i, call := range calls {
+		body.Children = append(body.Children, &uast.Node{
+			InternalType: "Call", Token: call, StartPosition: position(uint32(i + 1))})
+	}
+	return &uast.Node{InternalType: "File", Children: []*uast.Node{
+		{InternalType: "Func", Roles: []uast.Role{uast.Function, uast.Declaration},
+			StartPosition: position(0), Children: []*uast.Node{
+				{InternalType: "Ident", Token: name, Roles: []uast.Role{uast.Identifier},
+					Properties: map[string]string{"internalRole": "name"}},
+				body,
+			}},
+		{InternalType: "Comment", Token: comment, Roles: []uast.Role{uast.Comment},
+			StartPosition: position(10)},
+		{InternalType: "Space", Roles: []uast.Role{uast.Whitespace}},
+		{InternalType: "Func", Roles: []uast.Role{uast.Function, uast.Declaration},
+			StartPosition: position(11), Children: []*uast.Node{
+				{InternalType: "Ident", Token: "bar", Roles: []uast.Role{uast.Identifier}}}},
+	}}
+}
+
+func TestStructuralDiffMeta(t *testing.T) {
+	diff := fixtureStructuralDiff()
+	assert.Equal(t, diff.Name(), "StructuralDiff")
+	assert.Equal(t, diff.Provides(), []string{DependencyStructuralDiff})
+	assert.Equal(t, diff.Requires(), []string{DependencyUastChanges})
+	assert.Equal(t, diff.Features(), []string{FeatureUast})
+	opts := diff.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Flag, "structural-diff-comments")
+	assert.False(t, diff.WithComments)
+	diff.Configure(map[string]interface{}{ConfigStructuralDiffWithComments: true})
+	assert.True(t, diff.WithComments)
+	summoned := core.Registry.Summon(diff.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "StructuralDiff")
+	summoned = core.Registry.Summon(DependencyStructuralDiff)
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "StructuralDiff")
+}
+
+func TestStructuralDiffCompare(t *testing.T) {
+	diff := fixtureStructuralDiff()
+	before := fixtureStructuralTree(1, "foo", "// x", "a", "b")
+	// moved down, the comment changed
+	after := fixtureStructuralTree(5, "foo", "// y", "a", "b")
+	assert.Len(t, diff.Compare(before, after), 0)
+	after.Children = append(after.Children[:2], after.Children[3:]...)
+	assert.Len(t, diff.Compare(before, after), 0)
+
+	after = fixtureStructuralTree(1, "baz", "// x", "a", "c", "b")
+	changes := diff.Compare(before, after)
+	assert.Equal(t, changes, []StructuralChange{
+		{Before: before.Children[0].Children[0], After: after.Children[0].Children[0]},
+		{After: after.Children[0].Children[1].Children[1]},
+	})
+
+	after = fixtureStructuralTree(1, "foo", "// x", "d")
+	after.Children = after.Children[:3]
+	changes = diff.Compare(before, after)
+	body := before.Children[0].Children[1]
+	assert.Equal(t, changes, []StructuralChange{
+		{Before: body.Children[0], After: after.Children[0].Children[1].Children[0]},
+		{Before: body.Children[1]},
+		{Before: before.Children[3]},
+	})
+
+	after = fixtureStructuralTree(1, "foo", "// y", "a", "b")
+	diff.WithComments = true
+	assert.Equal(t, diff.Compare(before, after), []StructuralChange{
+		{Before: before.Children[1], After: after.Children[1]}})
+	after.Children[0].Children[0].Properties = map[string]string{"internalRole": "other"}
+	assert.Len(t, diff.Compare(before, after), 2)
+}
+
+func TestStructuralDiffConsume(t *testing.T) {
+	diff := fixtureStructuralDiff()
+	before := fixtureStructuralTree(1, "foo", "// x", "a")
+	after := fixtureStructuralTree(2, "foo", "// x", "a")
+	change := func(from, to string, before, after *uast.Node) Change {
+		return Change{Before: before, After: after, Change: &object.Change{
+			From: object.ChangeEntry{Name: from}, To: object.ChangeEntry{Name: to}}}
+	}
+	result, err := diff.Consume(map[string]interface{}{DependencyUastChanges: []Change{
+		change("a.go", "a.go", before, after),
+		change("", "b.go", nil, after),
+		change("c.go", "", before, nil),
+		change("d.go", "e.go", nil, after),
+		change("", "f.go", nil, nil),
+	}})
+	assert.Nil(t, err)
+	diffs := result[DependencyStructuralDiff].(map[string]StructuralDiffData)
+	assert.Len(t, diffs, 3)
+	assert.True(t, diffs["a.go"].FormattingOnly())
+	assert.Equal(t, diffs["b.go"].Changes, []StructuralChange{{After: after}})
+	assert.Equal(t, diffs["c.go"].Changes, []StructuralChange{{Before: before}})
+	assert.False(t, diffs["c.go"].FormattingOnly())
+}
@@ -0,0 +1,226 @@
+//go:build treesitter
+// +build treesitter
+
+package uast
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/bash"
+	"github.com/smacker/go-tree-sitter/c"
+	"github.com/smacker/go-tree-sitter/cpp"
+	"github.com/smacker/go-tree-sitter/csharp"
+	"github.com/smacker/go-tree-sitter/golang"
+	"github.com/smacker/go-tree-sitter/java"
+	"github.com/smacker/go-tree-sitter/javascript"
+	"github.com/smacker/go-tree-sitter/kotlin"
+	"github.com/smacker/go-tree-sitter/php"
+	"github.com/smacker/go-tree-sitter/python"
+	"github.com/smacker/go-tree-sitter/ruby"
+	"github.com/smacker/go-tree-sitter/rust"
+	"github.com/smacker/go-tree-sitter/scala"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// treeSitterLanguage describes how to convert the syntax tree of a tree-sitter grammar to UAST.
+type treeSitterLanguage struct {
+	grammar func() *sitter.Language
+	// functions are the node types of the function and method declarations. They receive
+	// uast.Function and uast.Declaration roles so that the default Shotness XPath-s work.
+	functions map[string]bool
+}
+
+func treeSitterFunctions(types ...string) map[string]bool {
+	result := map[string]bool{}
+	for _, t := range types {
+		result[t] = true
+	}
+	return result
+}
+
+var (
+	treeSitterC = &treeSitterLanguage{
+		grammar: c.GetLanguage, functions: treeSitterFunctions("function_definition")}
+	treeSitterCPP = &treeSitterLanguage{
+		grammar: cpp.GetLanguage, functions: treeSitterFunctions("function_definition")}
+	treeSitterJavaScript = &treeSitterLanguage{
+		grammar: javascript.GetLanguage, functions: treeSitterFunctions(
+			"function_declaration", "generator_function_declaration", "method_definition")}
+	treeSitterTypeScript = &treeSitterLanguage{
+		grammar: typescript.GetLanguage, functions: treeSitterFunctions(
+			"function_declaration", "generator_function_declaration", "method_definition")}
+
+	// treeSitterLanguages map the lowercase file extensions to the grammars.
+	treeSitterLanguages = map[string]*treeSitterLanguage{
+		".sh": {grammar: bash.GetLanguage, functions: treeSitterFunctions("function_definition")},
+		".c":  treeSitterC,
+		".h":  treeSitterC,
+		".cc": treeSitterCPP, ".cpp": treeSitterCPP, ".cxx": treeSitterCPP,
+		".hh": treeSitterCPP, ".hpp": treeSitterCPP, ".hxx": treeSitterCPP,
+		".cs": {grammar: csharp.GetLanguage, functions: treeSitterFunctions(
+			"method_declaration", "constructor_declaration", "local_function_statement")},
+		".go": {grammar: golang.GetLanguage, functions: treeSitterFunctions(
+			"function_declaration", "method_declaration")},
+		".java": {grammar: java.GetLanguage, functions: treeSitterFunctions(
+			"method_declaration", "constructor_declaration")},
+		".js":  treeSitterJavaScript,
+		".jsx": treeSitterJavaScript,
+		".mjs": treeSitterJavaScript,
+		".kt": {grammar: kotlin.GetLanguage, functions: treeSitterFunctions(
+			"function_declaration")},
+		".php": {grammar: php.GetLanguage, functions: treeSitterFunctions(
+			"function_definition", "method_declaration")},
+		".py": {grammar: python.GetLanguage, functions: treeSitterFunctions(
+			"function_definition")},
+		".rb": {grammar: ruby.GetLanguage, functions: treeSitterFunctions(
+			"method", "singleton_method")},
+		".rs": {grammar: rust.GetLanguage, functions: treeSitterFunctions("function_item")},
+		".scala": {grammar: scala.GetLanguage, functions: treeSitterFunctions(
+			"function_definition")},
+		".ts": treeSitterTypeScript,
+	}
+)
+
+// treeSitterParserVersion must be incremented whenever the grammars or the conversion
+// to UAST change so that the cached UASTs are invalidated.
+const treeSitterParserVersion = "tree-sitter/1"
+
+// treeSitterParser parses the files in-process with the tree-sitter grammars which are
+// linked into the binary. It does not need a Babelfish server.
+type treeSitterParser struct {
+	parser *sitter.Parser
+}
+
+func newTreeSitterParser(exr *Extractor) (Parser, error) {
+	return &treeSitterParser{parser: sitter.NewParser()}, nil
+}
+
+// Parse converts the tree-sitter syntax tree to UAST. The languages are detected by
+// the file extensions.
+func (parser *treeSitterParser) Parse(
+	ctx context.Context, name string, contents []byte) (*uast.Node, error) {
+	language := treeSitterLanguages[strings.ToLower(path.Ext(name))]
+	if language == nil {
+		return nil, nil
+	}
+	parser.parser.SetLanguage(language.grammar())
+	tree, err := parser.parser.ParseCtx(ctx, nil, contents)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+	root := tree.RootNode()
+	if root.HasError() {
+		return nil, treeSitterSyntaxError(root)
+	}
+	node := language.convert(root, contents)
+	node.Roles = append(node.Roles, uast.File)
+	return node, nil
+}
+
+// Version identifies the linked grammars, see treeSitterParserVersion.
+func (parser *treeSitterParser) Version() string {
+	return treeSitterParserVersion
+}
+
+// treeSitterSyntaxError reports the position of the first erroneous node.
+func treeSitterSyntaxError(node *sitter.Node) error {
+	for node.Type() != "ERROR" && !node.IsMissing() {
+		var next *sitter.Node
+		for i := 0; i < int(node.ChildCount()); i++ {
+			if child := node.Child(i); child.HasError() {
+				next = child
+				break
+			}
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	point := node.StartPoint()
+	return fmt.Errorf("syntax error at line %d, column %d", point.Row+1, point.Column+1)
+}
+
+// convert recursively builds the UAST of the named tree-sitter nodes. The anonymous nodes,
+// e.g. the punctuation, are skipped.
+func (language *treeSitterLanguage) convert(node *sitter.Node, contents []byte) *uast.Node {
+	start, end := node.StartPoint(), node.EndPoint()
+	result := &uast.Node{
+		InternalType: node.Type(),
+		StartPosition: &uast.Position{
+			Offset: node.StartByte(), Line: start.Row + 1, Col: start.Column + 1},
+		EndPosition: &uast.Position{
+			Offset: node.EndByte(), Line: end.Row + 1, Col: end.Column + 1},
+	}
+	if node.NamedChildCount() == 0 {
+		result.Token = node.Content(contents)
+	}
+	switch {
+	case language.functions[result.InternalType]:
+		result.Roles = []uast.Role{uast.Function, uast.Declaration}
+	case strings.HasSuffix(result.InternalType, "comment"):
+		result.Roles = []uast.Role{uast.Comment}
+	case strings.HasSuffix(result.InternalType, "identifier"):
+		result.Roles = []uast.Role{uast.Identifier}
+	}
+	for i := 0; i < int(node.ChildCount()); i++ {
+		child := node.Child(i)
+		if !child.IsNamed() {
+			continue
+		}
+		converted := language.convert(child, contents)
+		if language.functions[result.InternalType] {
+			field := node.FieldNameForChild(i)
+			if field == "name" {
+				markFunctionName(converted)
+			} else if field == "declarator" {
+				// C and C++ nest the name in the declarators
+				markFunctionName(findDeclaratorName(child, converted))
+			}
+		}
+		result.Children = append(result.Children, converted)
+	}
+	return result
+}
+
+// findDeclaratorName follows the "declarator" fields of C and C++ down to the identifier.
+func findDeclaratorName(node *sitter.Node, converted *uast.Node) *uast.Node {
+	for {
+		next := -1
+		named := 0
+		for i := 0; i < int(node.ChildCount()); i++ {
+			child := node.Child(i)
+			if !child.IsNamed() {
+				continue
+			}
+			if node.FieldNameForChild(i) == "declarator" {
+				next = named
+				node = child
+				break
+			}
+			named++
+		}
+		if next < 0 {
+			return converted
+		}
+		converted = converted.Children[next]
+	}
+}
+
+// markFunctionName assigns the roles which the default Shotness name XPath expects.
+func markFunctionName(node *uast.Node) {
+	if !strings.HasSuffix(node.InternalType, "identifier") && node.InternalType != "name" {
+		return
+	}
+	node.Roles = []uast.Role{uast.Function, uast.Identifier, uast.Name}
+}
+
+func init() {
+	RegisterParser("tree-sitter", newTreeSitterParser)
+}
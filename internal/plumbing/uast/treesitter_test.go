@@ -0,0 +1,76 @@
+//go:build treesitter
+// +build treesitter
+
+package uast
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+func findTreeSitterNodes(node *uast.Node, roles ...uast.Role) []*uast.Node {
+	var result []*uast.Node
+	matches := len(node.Roles) >= len(roles)
+	for i := 0; matches && i < len(roles); i++ {
+		matches = node.Roles[i] == roles[i]
+	}
+	if matches {
+		result = append(result, node)
+	}
+	for _, child := range node.Children {
+		result = append(result, findTreeSitterNodes(child, roles...)...)
+	}
+	return result
+}
+
+func TestTreeSitterParserGo(t *testing.T) {
+	parser, err := newParser("tree-sitter", &Extractor{})
+	assert.Nil(t, err)
+	node, err := parser.Parse(context.Background(), "main.go", []byte(`package main
+
+// Foo is a function.
+func Foo() int {
+	return 1
+}
+
+func (s *S) Bar() {}
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, node.InternalType, "source_file")
+	assert.Contains(t, node.Roles, uast.File)
+	functions := findTreeSitterNodes(node, uast.Function, uast.Declaration)
+	assert.Len(t, functions, 2)
+	assert.Equal(t, functions[0].StartPosition.Line, uint32(4))
+	assert.Equal(t, functions[0].EndPosition.Line, uint32(6))
+	names := findTreeSitterNodes(node, uast.Function, uast.Identifier, uast.Name)
+	assert.Len(t, names, 2)
+	assert.Equal(t, names[0].Token, "Foo")
+	assert.Equal(t, names[1].Token, "Bar")
+	comments := findTreeSitterNodes(node, uast.Comment)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, comments[0].Token, "// Foo is a function.")
+}
+
+func TestTreeSitterParserC(t *testing.T) {
+	parser, _ := newParser("tree-sitter", &Extractor{})
+	node, err := parser.Parse(context.Background(), "main.c", []byte(
+		"static int *foo(int x) {\n  return 0;\n}\n"))
+	assert.Nil(t, err)
+	names := findTreeSitterNodes(node, uast.Function, uast.Identifier, uast.Name)
+	assert.Len(t, names, 1)
+	assert.Equal(t, names[0].Token, "foo")
+}
+
+func TestTreeSitterParserErrors(t *testing.T) {
+	parser, _ := newParser("tree-sitter", &Extractor{})
+	node, err := parser.Parse(context.Background(), "README.md", []byte("# hello"))
+	assert.Nil(t, node)
+	assert.Nil(t, err)
+	node, err = parser.Parse(context.Background(), "main.py", []byte("def foo(:\n  pass\n"))
+	assert.Nil(t, node)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "line 1")
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	goioutil "io/ioutil"
+	"log"
 	"os"
 	"path"
 	"runtime"
@@ -16,9 +17,8 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/jeffail/tunny"
-	"gopkg.in/bblfsh/client-go.v2"
-	"gopkg.in/bblfsh/sdk.v1/protocol"
 	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/enry.v1"
 	"gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
@@ -29,7 +29,8 @@ import (
 	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
 )
 
-// Extractor retrieves UASTs from Babelfish server which correspond to changed files in a commit.
+// Extractor retrieves UASTs which correspond to changed files in a commit. The files are parsed
+// by Babelfish server by default, other parsers are chosen with ConfigUASTParser.
 // It is a PipelineItem.
 type Extractor struct {
 	core.NoopMerger
@@ -37,10 +38,22 @@ type Extractor struct {
 	Context        func() (context.Context, context.CancelFunc)
 	PoolSize       int
 	FailOnErrors   bool
+	Parser         string
+	MaxFileSize    int
+	SkipLanguages  map[string]bool
 	ProcessedFiles map[string]int
 
-	clients []*bblfsh.Client
+	parsers []Parser
 	pool    *tunny.Pool
+	workers *core.WorkerPool
+	cache   core.Storage
+	// unparsed is shared with the caller in FactUASTUnparsedFiles.
+	unparsed *UnparsedFiles
+	// unavailable is the error which happened while creating the parsers, if any.
+	// All the files are reported as unparsed then.
+	unavailable error
+	// cacheVersion is the Parser.Version() which is included in the cache keys.
+	cacheVersion string
 }
 
 const (
@@ -50,14 +63,28 @@ const (
 	// which sets the Babelfish server address.
 	ConfigUASTEndpoint = "ConfigUASTEndpoint"
 	// ConfigUASTTimeout is the name of the configuration option (Extractor.Configure())
-	// which sets the maximum amount of time to wait for a Babelfish server response.
+	// which sets the maximum amount of time to parse a single file, e.g. to wait for
+	// a Babelfish server response. Zero disables the limit.
 	ConfigUASTTimeout = "ConfigUASTTimeout"
 	// ConfigUASTPoolSize is the name of the configuration option (Extractor.Configure())
-	// which sets the number of goroutines to run for UAST parse queries.
+	// which sets the number of goroutines to run for UAST parse queries. Zero means
+	// the size of the shared core.WorkerPool.
 	ConfigUASTPoolSize = "ConfigUASTPoolSize"
 	// ConfigUASTFailOnErrors is the name of the configuration option (Extractor.Configure())
 	// which enables early exit in case of any Babelfish UAST parsing errors.
 	ConfigUASTFailOnErrors = "ConfigUASTFailOnErrors"
+	// ConfigUASTParser is the name of the configuration option (Extractor.Configure())
+	// which chooses the registered Parser, e.g. "bblfsh" or "tree-sitter".
+	ConfigUASTParser = "ConfigUASTParser"
+	// ConfigUASTMaxFileSize is the name of the configuration option (Extractor.Configure())
+	// which sets the maximum size of the parsed files in bytes. The bigger files are skipped.
+	ConfigUASTMaxFileSize = "ConfigUASTMaxFileSize"
+	// ConfigUASTSkipLanguages is the name of the configuration option (Extractor.Configure())
+	// which sets the languages of the files which are not parsed.
+	ConfigUASTSkipLanguages = "ConfigUASTSkipLanguages"
+	// DefaultUASTMaxFileSize is the default value of ConfigUASTMaxFileSize. The parsers
+	// are very slow on the huge generated files while they are rarely interesting.
+	DefaultUASTMaxFileSize = 1 << 20
 	// FeatureUast is the name of the Pipeline feature which activates all the items related to UAST.
 	FeatureUast = "uast"
 	// DependencyUasts is the name of the dependency provided by Extractor.
@@ -65,20 +92,21 @@ const (
 )
 
 type uastTask struct {
-	Lock   *sync.RWMutex
-	Dest   map[plumbing.Hash]*uast.Node
-	File   *object.File
-	Errors *[]error
+	Lock     *sync.RWMutex
+	Dest     map[plumbing.Hash]*uast.Node
+	File     *object.File
+	Contents []byte
+	Errors   *[]error
 }
 
 type worker struct {
-	Client    *bblfsh.Client
+	Parser    Parser
 	Extractor *Extractor
 }
 
 // Process will synchronously perform a job and return the result.
 func (w worker) Process(data interface{}) interface{} {
-	return w.Extractor.extractTask(w.Client, data)
+	return w.Extractor.extractTask(w.Parser, data)
 }
 func (w worker) BlockUntilReady() {}
 func (w worker) Interrupt()       {}
@@ -115,25 +143,42 @@ func (exr *Extractor) Features() []string {
 func (exr *Extractor) ListConfigurationOptions() []core.ConfigurationOption {
 	options := [...]core.ConfigurationOption{{
 		Name:        ConfigUASTEndpoint,
-		Description: "How many days there are in a single band.",
+		Description: "Babelfish server's endpoint.",
 		Flag:        "bblfsh",
 		Type:        core.StringConfigurationOption,
 		Default:     "0.0.0.0:9432"}, {
 		Name:        ConfigUASTTimeout,
-		Description: "Babelfish's server timeout in seconds.",
+		Description: "Maximum time to parse a single file in seconds. 0 disables the limit.",
 		Flag:        "bblfsh-timeout",
 		Type:        core.IntConfigurationOption,
 		Default:     20}, {
 		Name:        ConfigUASTPoolSize,
-		Description: "Number of goroutines to extract UASTs.",
+		Description: "Number of parallel UAST parsers. 0 means the value of --workers.",
 		Flag:        "bblfsh-pool-size",
 		Type:        core.IntConfigurationOption,
-		Default:     runtime.NumCPU() * 2}, {
+		Default:     0}, {
 		Name:        ConfigUASTFailOnErrors,
 		Description: "Panic if there is a UAST extraction error.",
 		Flag:        "bblfsh-fail-on-error",
 		Type:        core.BoolConfigurationOption,
-		Default:     false},
+		Default:     false}, {
+		Name: ConfigUASTParser,
+		Description: fmt.Sprintf("UAST parser to use: %s. tree-sitter does not need a Babelfish "+
+			"server but requires hercules to be built with -tags treesitter.",
+			strings.Join(Parsers(), ", ")),
+		Flag:    "uast-parser",
+		Type:    core.StringConfigurationOption,
+		Default: DefaultParser}, {
+		Name:        ConfigUASTMaxFileSize,
+		Description: "Do not parse the files which are bigger than this number of bytes. 0 disables the limit.",
+		Flag:        "uast-max-file-size",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultUASTMaxFileSize}, {
+		Name:        ConfigUASTSkipLanguages,
+		Description: "Do not parse the files in these languages, e.g. \"JavaScript,SQL\".",
+		Flag:        "uast-skip-languages",
+		Type:        core.StringsConfigurationOption,
+		Default:     []string{}},
 	}
 	return options[:]
 }
@@ -145,6 +190,9 @@ func (exr *Extractor) Configure(facts map[string]interface{}) {
 	}
 	if val, exists := facts[ConfigUASTTimeout].(int); exists {
 		exr.Context = func() (context.Context, context.CancelFunc) {
+			if val <= 0 {
+				return context.Background(), nil
+			}
 			return context.WithTimeout(context.Background(),
 				time.Duration(val)*time.Second)
 		}
@@ -155,6 +203,30 @@ func (exr *Extractor) Configure(facts map[string]interface{}) {
 	if val, exists := facts[ConfigUASTFailOnErrors].(bool); exists {
 		exr.FailOnErrors = val
 	}
+	if val, exists := facts[ConfigUASTParser].(string); exists {
+		exr.Parser = val
+	}
+	if val, exists := facts[ConfigUASTMaxFileSize].(int); exists {
+		exr.MaxFileSize = val
+	}
+	if val, exists := facts[ConfigUASTSkipLanguages].([]string); exists {
+		exr.SkipLanguages = map[string]bool{}
+		for _, lang := range val {
+			exr.SkipLanguages[strings.ToLower(strings.TrimSpace(lang))] = true
+		}
+	}
+	if val, exists := facts[core.FactWorkerPool].(*core.WorkerPool); exists {
+		exr.workers = val
+	}
+	if val, exists := facts[core.FactPersistentCache].(core.Storage); exists {
+		exr.cache = val
+	}
+	if val, exists := facts[FactUASTUnparsedFiles].(*UnparsedFiles); exists {
+		exr.unparsed = val
+	} else {
+		exr.unparsed = &UnparsedFiles{}
+		facts[FactUASTUnparsedFiles] = exr.unparsed
+	}
 }
 
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
@@ -165,25 +237,67 @@ func (exr *Extractor) Initialize(repository *git.Repository) {
 			return context.Background(), nil
 		}
 	}
+	if exr.MaxFileSize < 0 {
+		log.Printf("Warning: adjusted the UAST max file size to 0 (no limit)\n")
+		exr.MaxFileSize = 0
+	}
 	poolSize := exr.PoolSize
 	if poolSize == 0 {
-		poolSize = runtime.NumCPU()
+		if exr.workers != nil {
+			poolSize = exr.workers.Size()
+		} else {
+			poolSize = runtime.NumCPU()
+		}
+	}
+	if exr.workers == nil {
+		exr.workers = core.NewWorkerPool(poolSize)
 	}
-	exr.clients = make([]*bblfsh.Client, poolSize)
+	if exr.Parser == "" {
+		exr.Parser = DefaultParser
+	}
+	if exr.unparsed == nil {
+		exr.unparsed = &UnparsedFiles{}
+	}
+	factory, err := parserFactory(exr.Parser)
+	if err != nil {
+		panic(err)
+	}
+	exr.unavailable = nil
+	exr.parsers = make([]Parser, poolSize)
 	for i := 0; i < poolSize; i++ {
-		client, err := bblfsh.NewClient(exr.Endpoint)
+		parser, err := factory(exr)
 		if err != nil {
-			panic(err)
+			if exr.FailOnErrors {
+				panic(err)
+			}
+			log.Printf("Warning: UAST parser %s is unavailable, the structural analyses "+
+				"will degrade: %v\n", exr.Parser, err)
+			exr.unavailable = err
+			exr.parsers = nil
+			break
+		}
+		exr.parsers[i] = parser
+	}
+	exr.cacheVersion = ""
+	if exr.cache != nil && exr.unavailable == nil {
+		exr.cacheVersion = exr.parsers[0].Version()
+		if exr.cacheVersion == "" {
+			log.Printf("Warning: the version of the UAST parser %s is unknown, the UASTs "+
+				"will not be cached\n", exr.Parser)
 		}
-		exr.clients[i] = client
 	}
 	if exr.pool != nil {
 		exr.pool.Close()
+		exr.pool = nil
+	}
+	if exr.unavailable != nil {
+		exr.ProcessedFiles = map[string]int{}
+		return
 	}
 	{
 		i := 0
 		exr.pool = tunny.New(poolSize, func() tunny.Worker {
-			w := worker{Client: exr.clients[i], Extractor: exr}
+			w := worker{Parser: exr.parsers[i], Extractor: exr}
 			i++
 			return w
 		})
@@ -205,31 +319,36 @@ func (exr *Extractor) Consume(deps map[string]interface{}) (map[string]interface
 	uasts := map[plumbing.Hash]*uast.Node{}
 	lock := sync.RWMutex{}
 	errs := make([]error, 0)
-	wg := sync.WaitGroup{}
+	var tasks []uastTask
 	submit := func(change *object.Change) {
-		{
-			reader, err := cache[change.To.TreeEntry.Hash].Reader()
-			if err != nil {
-				errs = append(errs, err)
-				return
-			}
-			defer ioutil.CheckClose(reader, &err)
-			buf := new(bytes.Buffer)
-			if _, err := buf.ReadFrom(reader); err != nil {
-				errs = append(errs, err)
+		blob := cache[change.To.TreeEntry.Hash]
+		if exr.MaxFileSize > 0 && blob.Size > int64(exr.MaxFileSize) {
+			return
+		}
+		reader, err := blob.Reader()
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		defer ioutil.CheckClose(reader, &err)
+		buf := new(bytes.Buffer)
+		if _, err := buf.ReadFrom(reader); err != nil {
+			errs = append(errs, err)
+			return
+		}
+		if len(exr.SkipLanguages) > 0 {
+			lang := enry.GetLanguage(path.Base(change.To.Name), buf.Bytes())
+			if exr.SkipLanguages[strings.ToLower(lang)] {
 				return
 			}
-			exr.ProcessedFiles[change.To.Name]++
 		}
-		wg.Add(1)
-		go func(task interface{}) {
-			exr.pool.Process(task)
-			wg.Done()
-		}(uastTask{
-			Lock:   &lock,
-			Dest:   uasts,
-			File:   &object.File{Name: change.To.Name, Blob: *cache[change.To.TreeEntry.Hash]},
-			Errors: &errs,
+		exr.ProcessedFiles[change.To.Name]++
+		tasks = append(tasks, uastTask{
+			Lock:     &lock,
+			Dest:     uasts,
+			File:     &object.File{Name: change.To.Name, Blob: *blob},
+			Contents: buf.Bytes(),
+			Errors:   &errs,
 		})
 	}
 	for _, change := range treeDiffs {
@@ -246,7 +365,15 @@ func (exr *Extractor) Consume(deps map[string]interface{}) (map[string]interface
 			submit(change)
 		}
 	}
-	wg.Wait()
+	if exr.unavailable != nil {
+		for _, task := range tasks {
+			exr.unparsed.add(task.File.Name, task.Contents, UnparsedReasonUnavailable)
+		}
+		tasks = nil
+	}
+	exr.workers.ForEach(len(tasks), func(index int) {
+		exr.pool.Process(tasks[index])
+	})
 	if len(errs) > 0 {
 		msgs := make([]string, len(errs))
 		for i, err := range errs {
@@ -267,37 +394,96 @@ func (exr *Extractor) Fork(n int) []core.PipelineItem {
 }
 
 func (exr *Extractor) extractUAST(
-	client *bblfsh.Client, file *object.File) (*uast.Node, error) {
-	request := client.NewParseRequest()
-	contents, err := file.Contents()
-	if err != nil {
-		return nil, err
+	parser Parser, file *object.File, contents []byte) (*uast.Node, error) {
+	key := exr.cacheKey(file)
+	if node, exists := exr.loadUAST(key); exists {
+		return node, nil
 	}
-	request.Content(contents)
-	request.Filename(file.Name)
 	ctx, cancel := exr.Context()
 	if cancel != nil {
 		defer cancel()
 	}
-	response, err := request.DoWithContext(ctx)
-	if err != nil {
-		if strings.Contains("missing driver", err.Error()) {
-			return nil, nil
-		}
-		return nil, err
+	node, err := parser.Parse(ctx, file.Name, contents)
+	if err == nil {
+		exr.storeUAST(key, node)
 	}
-	if response.Status != protocol.Ok {
-		return nil, errors.New(strings.Join(response.Errors, "\n"))
+	return node, err
+}
+
+// cacheKey identifies the UAST of the file in the persistent cache. The UAST depends on
+// the contents and on the language which is detected by the file extension, so the identical
+// files in different directories share it. Returns "" if the UASTs are not cached.
+func (exr *Extractor) cacheKey(file *object.File) string {
+	if exr.cache == nil || exr.cacheVersion == "" {
+		return ""
+	}
+	language := strings.ToLower(path.Ext(file.Name))
+	if language == "" {
+		language = path.Base(file.Name)
+	}
+	return fmt.Sprintf("UAST/%s/%s/%s", exr.cacheVersion, language, file.Hash.String())
+}
+
+// Cached values start with one of these bytes; the files which the parser does not support
+// are cached, too.
+const (
+	cachedNoUAST byte = iota
+	cachedUAST
+)
+
+// loadUAST reads the UAST from the persistent cache. The second returned value is false
+// if there is nothing cached.
+func (exr *Extractor) loadUAST(key string) (*uast.Node, bool) {
+	if key == "" {
+		return nil, false
 	}
+	data, err := exr.cache.Get(key)
 	if err != nil {
-		return nil, err
+		log.Printf("failed to read %s from the cache: %v", key, err)
+		return nil, false
+	}
+	if len(data) == 0 {
+		return nil, false
+	}
+	if data[0] == cachedNoUAST {
+		return nil, true
+	}
+	node := &uast.Node{}
+	if err = node.Unmarshal(data[1:]); err != nil {
+		log.Printf("failed to read %s from the cache: %v", key, err)
+		return nil, false
 	}
-	return response.UAST, nil
+	return node, true
 }
 
-func (exr *Extractor) extractTask(client *bblfsh.Client, data interface{}) interface{} {
+// storeUAST writes the UAST to the persistent cache. The parsing errors are not cached
+// because they can be temporary, e.g. timeouts.
+func (exr *Extractor) storeUAST(key string, node *uast.Node) {
+	if key == "" {
+		return
+	}
+	data := []byte{cachedNoUAST}
+	if node != nil {
+		serialized, err := node.Marshal()
+		if err != nil {
+			log.Printf("failed to cache %s: %v", key, err)
+			return
+		}
+		data = append([]byte{cachedUAST}, serialized...)
+	}
+	if err := exr.cache.Set(key, data); err != nil {
+		log.Printf("failed to cache %s: %v", key, err)
+	}
+}
+
+func (exr *Extractor) extractTask(parser Parser, data interface{}) interface{} {
 	task := data.(uastTask)
-	node, err := exr.extractUAST(client, task.File)
+	node, err := exr.extractUAST(parser, task.File, task.Contents)
+	if err != nil {
+		exr.unparsed.add(task.File.Name, task.Contents, strings.SplitN(err.Error(), "\n", 2)[0])
+	} else if node == nil {
+		exr.unparsed.add(task.File.Name, task.Contents, UnparsedReasonUnsupported)
+	}
 	task.Lock.Lock()
 	defer task.Lock.Unlock()
 	if err != nil {
@@ -539,6 +725,16 @@ func (saver *ChangesSaver) Serialize(result interface{}, binary bool, writer io.
 	return nil
 }
 
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (saver *ChangesSaver) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(saver, result, &pb.UASTChangesSaverResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (saver *ChangesSaver) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(saver, result, &pb.UASTChangesSaverResults{})
+}
+
 func (saver *ChangesSaver) dumpFiles(result [][]Change) []*pb.UASTChange {
 	fileNames := []*pb.UASTChange{}
 	for i, changes := range result {
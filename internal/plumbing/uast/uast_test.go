@@ -37,11 +37,15 @@ func TestUASTExtractorMeta(t *testing.T) {
 	assert.Equal(t, exr.Requires()[0], items.DependencyTreeChanges)
 	assert.Equal(t, exr.Requires()[1], items.DependencyBlobCache)
 	opts := exr.ListConfigurationOptions()
-	assert.Len(t, opts, 4)
+	assert.Len(t, opts, 7)
 	assert.Equal(t, opts[0].Name, ConfigUASTEndpoint)
 	assert.Equal(t, opts[1].Name, ConfigUASTTimeout)
 	assert.Equal(t, opts[2].Name, ConfigUASTPoolSize)
 	assert.Equal(t, opts[3].Name, ConfigUASTFailOnErrors)
+	assert.Equal(t, opts[4].Name, ConfigUASTParser)
+	assert.Equal(t, opts[4].Default, DefaultParser)
+	assert.Equal(t, opts[5].Name, ConfigUASTMaxFileSize)
+	assert.Equal(t, opts[6].Name, ConfigUASTSkipLanguages)
 	feats := exr.Features()
 	assert.Len(t, feats, 1)
 	assert.Equal(t, feats[0], FeatureUast)
@@ -55,11 +59,17 @@ func TestUASTExtractorConfiguration(t *testing.T) {
 	facts[ConfigUASTTimeout] = 15
 	facts[ConfigUASTPoolSize] = 7
 	facts[ConfigUASTFailOnErrors] = true
+	facts[ConfigUASTParser] = "tree-sitter"
+	facts[ConfigUASTMaxFileSize] = 1000
+	facts[ConfigUASTSkipLanguages] = []string{"JavaScript", " sql"}
 	exr.Configure(facts)
 	assert.Equal(t, exr.Endpoint, facts[ConfigUASTEndpoint])
 	assert.NotNil(t, exr.Context)
 	assert.Equal(t, exr.PoolSize, facts[ConfigUASTPoolSize])
 	assert.Equal(t, exr.FailOnErrors, true)
+	assert.Equal(t, exr.Parser, "tree-sitter")
+	assert.Equal(t, exr.MaxFileSize, 1000)
+	assert.Equal(t, exr.SkipLanguages, map[string]bool{"javascript": true, "sql": true})
 }
 
 func TestUASTExtractorRegistration(t *testing.T) {
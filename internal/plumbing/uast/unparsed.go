@@ -0,0 +1,55 @@
+package uast
+
+import (
+	"path"
+	"sync"
+
+	"gopkg.in/src-d/enry.v1"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+const (
+	// FactUASTUnparsedFiles is the name of the fact which is inserted in Extractor.Configure().
+	// It is *UnparsedFiles which is filled during Pipeline.Run() and should be copied to
+	// CommonAnalysisResult.UnparsedFiles afterwards.
+	FactUASTUnparsedFiles = "UAST.UnparsedFiles"
+
+	// UnparsedReasonUnsupported means that the parser does not support the language.
+	UnparsedReasonUnsupported = "unsupported"
+	// UnparsedReasonUnavailable means that the parser could not be created, e.g. Babelfish
+	// server is down.
+	UnparsedReasonUnavailable = "unavailable"
+)
+
+// UnparsedFiles collects the files which could not be converted to UASTs. It is safe for
+// concurrent use.
+type UnparsedFiles struct {
+	lock  sync.Mutex
+	files map[string]core.UnparsedFile
+}
+
+// add records the file. The files are never removed even if they are parsed in the later
+// commits; the latest reason wins.
+func (unparsed *UnparsedFiles) add(name string, contents []byte, reason string) {
+	language := enry.GetLanguage(path.Base(name), contents)
+	unparsed.lock.Lock()
+	defer unparsed.lock.Unlock()
+	if unparsed.files == nil {
+		unparsed.files = map[string]core.UnparsedFile{}
+	}
+	unparsed.files[name] = core.UnparsedFile{Language: language, Reason: reason}
+}
+
+// Files returns the copy of the collected files. The keys are the file names.
+func (unparsed *UnparsedFiles) Files() map[string]core.UnparsedFile {
+	unparsed.lock.Lock()
+	defer unparsed.lock.Unlock()
+	if len(unparsed.files) == 0 {
+		return nil
+	}
+	result := make(map[string]core.UnparsedFile, len(unparsed.files))
+	for key, val := range unparsed.files {
+		result[key] = val
+	}
+	return result
+}
@@ -0,0 +1,202 @@
+package plumbing
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"path"
+	"strings"
+
+	"gopkg.in/src-d/enry.v1"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/format/gitignore"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// linguistAttribute is the line in .gitattributes which overrides the linguist detection,
+// e.g. "*.pb.go linguist-generated" or "third_party/** -linguist-vendored".
+type linguistAttribute struct {
+	pattern gitignore.Pattern
+	// vendored and generated are nil if the line does not mention them.
+	vendored  *bool
+	generated *bool
+}
+
+// linguistAttributes are the linguist overrides in the order of appearance.
+// The last matching line wins, like in Git.
+type linguistAttributes []linguistAttribute
+
+const (
+	// gitAttributesFileName is the name of the file with the linguist overrides in the root
+	// of the tree. The nested .gitattributes are not supported.
+	gitAttributesFileName = ".gitattributes"
+	// generatedSampleSize is the number of bytes which are read from the blobs
+	// to detect the generated code.
+	generatedSampleSize = 4096
+	// minifiedLineLength is the average line length which indicates minified JavaScript or CSS.
+	minifiedLineLength = 110
+)
+
+// generatedSuffixes are the file name endings of the well-known generated files.
+var generatedSuffixes = []string{
+	".pb.go", ".pb.gw.go", "_pb2.py", "_pb2_grpc.py", ".pb.cc", ".pb.h", "_pb.js",
+	"_generated.go", "bindata.go", ".min.js", "-min.js", ".min.css", ".js.map", ".css.map",
+	"package-lock.json", "yarn.lock", "Gopkg.lock", "Cargo.lock", "composer.lock", "go.sum",
+}
+
+// generatedMarkers are the comments which the code generators put in the beginning of the files.
+var generatedMarkers = [][]byte{
+	[]byte("DO NOT EDIT"),
+	[]byte("@generated"),
+}
+
+// parseLinguistAttributes extracts linguist-vendored and linguist-generated from .gitattributes.
+func parseLinguistAttributes(reader io.Reader) linguistAttributes {
+	var result linguistAttributes
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		attr := linguistAttribute{}
+		for _, field := range fields[1:] {
+			name, value := field, true
+			if strings.HasPrefix(name, "-") {
+				name, value = name[1:], false
+			} else if pos := strings.IndexByte(name, '='); pos >= 0 {
+				name, value = name[:pos], name[pos+1:] != "false"
+			}
+			switch name {
+			case "linguist-vendored":
+				attr.vendored = &value
+			case "linguist-generated":
+				attr.generated = &value
+			}
+		}
+		if attr.vendored == nil && attr.generated == nil {
+			continue
+		}
+		attr.pattern = gitignore.ParsePattern(fields[0], nil)
+		result = append(result, attr)
+	}
+	return result
+}
+
+// lookup returns the vendored and the generated overrides for the file, nil if they are not set.
+func (attributes linguistAttributes) lookup(name string) (vendored, generated *bool) {
+	parts := strings.Split(name, "/")
+	for _, attr := range attributes {
+		if attr.pattern.Match(parts, false) != gitignore.Exclude {
+			continue
+		}
+		if attr.vendored != nil {
+			vendored = attr.vendored
+		}
+		if attr.generated != nil {
+			generated = attr.generated
+		}
+	}
+	return vendored, generated
+}
+
+// isGeneratedName returns whether the file name belongs to a well-known generated file.
+func isGeneratedName(name string) bool {
+	for _, suffix := range generatedSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isGeneratedContent returns whether the beginning of the file contains a code generator's
+// marker or looks like minified JavaScript or CSS.
+func isGeneratedContent(name string, sample []byte) bool {
+	for _, marker := range generatedMarkers {
+		if bytes.Contains(sample, marker) {
+			return true
+		}
+	}
+	switch path.Ext(name) {
+	case ".js", ".css":
+		lines := bytes.Count(sample, []byte{'\n'}) + 1
+		return len(sample)/lines > minifiedLineLength
+	}
+	return false
+}
+
+// loadLinguistAttributes reads the linguist overrides from the root .gitattributes in the tree.
+// The previous result is reused if the file has not changed.
+func (treediff *TreeDiff) loadLinguistAttributes(tree *object.Tree) linguistAttributes {
+	file, err := tree.File(gitAttributesFileName)
+	if err != nil {
+		treediff.attributesHash = plumbing.ZeroHash
+		return nil
+	}
+	if file.Hash == treediff.attributesHash {
+		return treediff.previousAttributes
+	}
+	reader, err := file.Reader()
+	if err != nil {
+		return nil
+	}
+	defer reader.Close()
+	treediff.attributesHash = file.Hash
+	return parseLinguistAttributes(reader)
+}
+
+// isVendored returns whether the change entry is third party or generated code according to
+// the linguist rules and the .gitattributes overrides. The empty entry is never vendored.
+func (treediff *TreeDiff) isVendored(entry object.ChangeEntry, attributes linguistAttributes) bool {
+	if entry.Name == "" {
+		return false
+	}
+	vendored, generated := attributes.lookup(entry.Name)
+	if vendored != nil && *vendored || vendored == nil && enry.IsVendor(entry.Name) {
+		return true
+	}
+	if generated != nil {
+		return *generated
+	}
+	if isGeneratedName(entry.Name) {
+		return true
+	}
+	blob, err := treediff.repository.BlobObject(entry.TreeEntry.Hash)
+	if err != nil {
+		return false
+	}
+	reader, err := blob.Reader()
+	if err != nil {
+		return false
+	}
+	defer reader.Close()
+	sample := make([]byte, generatedSampleSize)
+	size, _ := io.ReadFull(reader, sample)
+	return isGeneratedContent(entry.Name, sample[:size])
+}
+
+// filterVendored removes the vendored and generated files from the changes. The old and the new
+// sides are checked against their own trees, so that a file which becomes vendored is deleted
+// and a file which stops being vendored is inserted.
+func (treediff *TreeDiff) filterVendored(
+	changes object.Changes, previous, current linguistAttributes) object.Changes {
+	filtered := make(object.Changes, 0, len(changes))
+	for _, change := range changes {
+		from, to := change.From, change.To
+		if treediff.isVendored(from, previous) {
+			from = object.ChangeEntry{}
+		}
+		if treediff.isVendored(to, current) {
+			to = object.ChangeEntry{}
+		}
+		if from.Name == "" && to.Name == "" {
+			continue
+		}
+		if from != change.From || to != change.To {
+			change = &object.Change{From: from, To: to}
+		}
+		filtered = append(filtered, change)
+	}
+	return filtered
+}
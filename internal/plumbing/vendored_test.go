@@ -0,0 +1,99 @@
+package plumbing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+)
+
+func TestParseLinguistAttributes(t *testing.T) {
+	attributes := parseLinguistAttributes(strings.NewReader(`# comment
+*.pb.go linguist-generated=true -diff
+third_party/** linguist-vendored
+vendor/** -linguist-vendored
+*.txt text
+api/*.pb.go linguist-generated=false
+`))
+	assert.Len(t, attributes, 4)
+	vendored, generated := attributes.lookup("core/pipeline.pb.go")
+	assert.Nil(t, vendored)
+	assert.True(t, *generated)
+	vendored, generated = attributes.lookup("api/service.pb.go")
+	assert.Nil(t, vendored)
+	assert.False(t, *generated)
+	vendored, generated = attributes.lookup("third_party/lib/x.c")
+	assert.True(t, *vendored)
+	assert.Nil(t, generated)
+	vendored, _ = attributes.lookup("vendor/github.com/x/y.go")
+	assert.False(t, *vendored)
+	vendored, generated = attributes.lookup("README.txt")
+	assert.Nil(t, vendored)
+	assert.Nil(t, generated)
+}
+
+func TestIsGenerated(t *testing.T) {
+	assert.True(t, isGeneratedName("internal/pb/pb.pb.go"))
+	assert.True(t, isGeneratedName("labours/pb_pb2.py"))
+	assert.True(t, isGeneratedName("static/app.min.js"))
+	assert.True(t, isGeneratedName("package-lock.json"))
+	assert.False(t, isGeneratedName("internal/pb/pb.go"))
+	assert.True(t, isGeneratedContent("x.go", []byte(
+		"// Code generated by protoc-gen-gogo. DO NOT EDIT.\npackage pb\n")))
+	assert.True(t, isGeneratedContent("x.py", []byte("# @generated\n")))
+	assert.False(t, isGeneratedContent("x.go", []byte("package main\n\nfunc main() {}\n")))
+	assert.True(t, isGeneratedContent("app.js", []byte(strings.Repeat("var a=1;", 100))))
+	assert.False(t, isGeneratedContent("app.js", []byte(strings.Repeat("var a = 1;\n", 100))))
+	assert.False(t, isGeneratedContent("app.txt", []byte(strings.Repeat("var a=1;", 100))))
+}
+
+func TestTreeDiffFilterVendored(t *testing.T) {
+	repository, err := git.Init(memory.NewStorage(), nil)
+	assert.Nil(t, err)
+	storeBlob := func(text string) plumbing.Hash {
+		encoded := repository.Storer.NewEncodedObject()
+		encoded.SetType(plumbing.BlobObject)
+		writer, _ := encoded.Writer()
+		writer.Write([]byte(text))
+		writer.Close()
+		hash, err := repository.Storer.SetEncodedObject(encoded)
+		assert.Nil(t, err)
+		return hash
+	}
+	code := storeBlob("package main\n")
+	generated := storeBlob("// Code generated by go-bindata. DO NOT EDIT.\npackage main\n")
+	entry := func(name string, hash plumbing.Hash) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+
+	td := TreeDiff{}
+	td.Configure(map[string]interface{}{ConfigTreeDiffSkipVendored: true})
+	assert.True(t, td.SkipVendored)
+	td.Initialize(repository)
+	previous := parseLinguistAttributes(strings.NewReader("legacy/** linguist-vendored\n"))
+	current := parseLinguistAttributes(strings.NewReader("lib/** linguist-vendored\n"))
+	changes := td.filterVendored(object.Changes{
+		{To: entry("main.go", code)},
+		{To: entry("assets.go", generated)},
+		{To: entry("api/api.pb.go", code)},
+		{To: entry("vendor/github.com/x/y.go", code)},
+		{From: entry("node_modules/x/index.js", code)},
+		// stops being vendored: inserted
+		{From: entry("legacy/a.go", code), To: entry("legacy/a.go", code)},
+		// becomes vendored: deleted
+		{From: entry("lib/b.go", code), To: entry("lib/b.go", code)},
+		{From: entry("c.go", code), To: entry("c.go", generated)},
+	}, previous, current)
+	assert.Len(t, changes, 4)
+	assert.Equal(t, changes[0].To.Name, "main.go")
+	assert.Equal(t, changes[1].From, object.ChangeEntry{})
+	assert.Equal(t, changes[1].To.Name, "legacy/a.go")
+	assert.Equal(t, changes[2].From.Name, "lib/b.go")
+	assert.Equal(t, changes[2].To, object.ChangeEntry{})
+	assert.Equal(t, changes[3].From.Name, "c.go")
+	assert.Equal(t, changes[3].To, object.ChangeEntry{})
+}
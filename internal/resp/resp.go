@@ -0,0 +1,93 @@
+// Package resp implements the subset of the Redis serialization protocol (RESP) which is needed
+// to execute simple commands: the requests are arrays of bulk strings and the replies are
+// simple strings, errors, integers or bulk strings.
+package resp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Error is the error reply of the server. The connection stays valid after it.
+type Error string
+
+func (err Error) Error() string {
+	return "redis: " + string(err)
+}
+
+// Conn executes the commands over the underlying stream, one at a time.
+type Conn struct {
+	writer *bufio.Writer
+	reader *bufio.Reader
+}
+
+// NewConn wraps the stream, usually a net.Conn.
+func NewConn(stream io.ReadWriter) *Conn {
+	return &Conn{writer: bufio.NewWriter(stream), reader: bufio.NewReader(stream)}
+}
+
+// Do writes the command and reads the reply. The reply is nil if the server returned
+// the null bulk string. The error is of type Error if the server returned the error reply;
+// any other error means that the connection is broken.
+func (conn *Conn) Do(args ...string) ([]byte, error) {
+	if err := WriteCommand(conn.writer, args...); err != nil {
+		return nil, err
+	}
+	if err := conn.writer.Flush(); err != nil {
+		return nil, err
+	}
+	return ReadReply(conn.reader)
+}
+
+// WriteCommand encodes the command as the array of bulk strings.
+func WriteCommand(writer io.Writer, args ...string) error {
+	if _, err := fmt.Fprintf(writer, "*%d\r\n", len(args)); err != nil {
+		return err
+	}
+	for _, arg := range args {
+		if _, err := fmt.Fprintf(writer, "$%d\r\n%s\r\n", len(arg), arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadReply decodes a single reply. Simple strings and integers are returned as they are,
+// the null bulk string becomes nil.
+func ReadReply(reader *bufio.Reader) ([]byte, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("redis: empty reply")
+	}
+	switch line[0] {
+	case '+', ':':
+		return []byte(line[1:]), nil
+	case '-':
+		return nil, Error(line[1:])
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid reply %q", line)
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		data := make([]byte, size+2)
+		if _, err = io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		if data[size] != '\r' || data[size+1] != '\n' {
+			return nil, fmt.Errorf("redis: bulk string of size %d is not terminated", size)
+		}
+		return data[:size], nil
+	}
+	return nil, fmt.Errorf("redis: unsupported reply %q", line)
+}
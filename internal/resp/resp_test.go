@@ -0,0 +1,94 @@
+package resp
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteCommand(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, WriteCommand(buffer, "SET", "key", "multi\r\nline", ""))
+	assert.Equal(t, buffer.String(),
+		"*4\r\n$3\r\nSET\r\n$3\r\nkey\r\n$11\r\nmulti\r\nline\r\n$0\r\n\r\n")
+	buffer.Reset()
+	assert.Nil(t, WriteCommand(buffer))
+	assert.Equal(t, buffer.String(), "*0\r\n")
+}
+
+func readReply(data string) ([]byte, error) {
+	return ReadReply(bufio.NewReader(strings.NewReader(data)))
+}
+
+func TestReadReply(t *testing.T) {
+	reply, err := readReply("+OK\r\n")
+	assert.Nil(t, err)
+	assert.Equal(t, reply, []byte("OK"))
+	reply, err = readReply(":42\r\n")
+	assert.Nil(t, err)
+	assert.Equal(t, reply, []byte("42"))
+	reply, err = readReply("$11\r\nmulti\r\nline\r\n")
+	assert.Nil(t, err)
+	assert.Equal(t, reply, []byte("multi\r\nline"))
+	reply, err = readReply("$0\r\n\r\n")
+	assert.Nil(t, err)
+	assert.Equal(t, reply, []byte{})
+	reply, err = readReply("$-1\r\n")
+	assert.Nil(t, err)
+	assert.Nil(t, reply)
+	reply, err = readReply("-ERR invalid password\r\n")
+	assert.Nil(t, reply)
+	assert.Equal(t, err, Error("ERR invalid password"))
+	assert.EqualError(t, err, "redis: ERR invalid password")
+}
+
+func TestReadReplyErrors(t *testing.T) {
+	for _, data := range []string{
+		"", "\r\n", "$x\r\n", "$5\r\nabc\r\n", "$3\r\nabcde", "*1\r\n$1\r\na\r\n", "+OK",
+	} {
+		reply, err := readReply(data)
+		assert.Nil(t, reply, data)
+		assert.NotNil(t, err, data)
+		_, isReplyError := err.(Error)
+		assert.False(t, isReplyError, data)
+	}
+	_, err := readReply("")
+	assert.Equal(t, err, io.EOF)
+}
+
+func TestConnDo(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	go func() {
+		defer server.Close()
+		reader := bufio.NewReader(server)
+		// *2 $3 GET $3 key
+		for i := 0; i < 5; i++ {
+			if _, err := reader.ReadString('\n'); err != nil {
+				return
+			}
+		}
+		server.Write([]byte("$5\r\nvalue\r\n"))
+		for i := 0; i < 3; i++ {
+			if _, err := reader.ReadString('\n'); err != nil {
+				return
+			}
+		}
+		server.Write([]byte("-ERR unknown command\r\n"))
+	}()
+	conn := NewConn(client)
+	reply, err := conn.Do("GET", "key")
+	assert.Nil(t, err)
+	assert.Equal(t, reply, []byte("value"))
+	reply, err = conn.Do("X")
+	assert.Nil(t, reply)
+	assert.Equal(t, err, Error("ERR unknown command"))
+	// the server closed the connection
+	_, err = conn.Do("GET", "key")
+	assert.NotNil(t, err)
+}
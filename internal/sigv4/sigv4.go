@@ -0,0 +1,160 @@
+// Package sigv4 signs HTTP requests with AWS Signature Version 4 as described in
+// https://docs.aws.amazon.com/general/latest/gr/sigv4_signing.html
+// The payload is always hashed, so the signed requests are suitable for S3.
+package sigv4
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// Algorithm is the name of the signing algorithm in the Authorization header.
+	Algorithm = "AWS4-HMAC-SHA256"
+	// timeFormat is the format of X-Amz-Date.
+	timeFormat = "20060102T150405Z"
+	// dateFormat is the format of the date in the credential scope.
+	dateFormat = "20060102"
+)
+
+// Credentials are the AWS access keys.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	// SessionToken is set for the temporary credentials only.
+	SessionToken string
+}
+
+// Signer adds the Authorization header to the requests to the specified service in the region.
+type Signer struct {
+	Credentials
+	Region  string
+	Service string
+}
+
+// Sign sets X-Amz-Date, X-Amz-Content-Sha256, X-Amz-Security-Token if there is the session
+// token, and Authorization. All the headers which are already set are signed, as well as Host.
+// `body` must be the same bytes which are sent in the request.
+func (signer Signer) Sign(request *http.Request, body []byte, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format(timeFormat)
+	payloadHash := sha256.Sum256(body)
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+	if signer.SessionToken != "" {
+		request.Header.Set("X-Amz-Security-Token", signer.SessionToken)
+	}
+	canonicalHeaders, signedHeaders := CanonicalHeaders(request)
+	canonicalRequest := strings.Join([]string{
+		request.Method, CanonicalURI(request.URL.Path), CanonicalQuery(request.URL.Query()),
+		canonicalHeaders, signedHeaders, hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+	scope := signer.Scope(now)
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := Algorithm + "\n" + amzDate + "\n" + scope + "\n" +
+		hex.EncodeToString(requestHash[:])
+	signature := hmacSHA256(signer.SigningKey(now), stringToSign)
+	request.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		Algorithm, signer.AccessKeyID, scope, signedHeaders, hex.EncodeToString(signature)))
+}
+
+// Scope returns the credential scope: date/region/service/aws4_request.
+func (signer Signer) Scope(now time.Time) string {
+	return now.UTC().Format(dateFormat) + "/" + signer.Region + "/" + signer.Service +
+		"/aws4_request"
+}
+
+// SigningKey derives the key which signs the requests on the specified day.
+func (signer Signer) SigningKey(now time.Time) []byte {
+	key := []byte("AWS4" + signer.SecretAccessKey)
+	for _, part := range []string{
+		now.UTC().Format(dateFormat), signer.Region, signer.Service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	return key
+}
+
+// CanonicalURI encodes every byte of the path except the unreserved characters and slashes.
+// S3 requires the path to be encoded exactly once.
+func CanonicalURI(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return escape(path, false)
+}
+
+// CanonicalQuery sorts the parameters by name and then by value and encodes them the same way
+// as CanonicalURI does, including the slashes.
+func CanonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var pairs []string
+	for _, name := range names {
+		values := append([]string{}, query[name]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, escape(name, true)+"="+escape(value, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// escape percent-encodes the string as required by RFC 3986 with upper case hexadecimal digits.
+func escape(str string, encodeSlash bool) string {
+	buffer := &bytes.Buffer{}
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' && !encodeSlash {
+			buffer.WriteByte(c)
+		} else {
+			fmt.Fprintf(buffer, "%%%02X", c)
+		}
+	}
+	return buffer.String()
+}
+
+// CanonicalHeaders returns the lower-cased and sorted "name:value\n" lines of the request's
+// headers including Host and the list of their names joined with semicolons.
+func CanonicalHeaders(request *http.Request) (string, string) {
+	host := request.Host
+	if host == "" {
+		host = request.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range request.Header {
+		trimmed := make([]string, len(values))
+		for i, value := range values {
+			trimmed[i] = strings.Join(strings.Fields(value), " ")
+		}
+		headers[strings.ToLower(name)] = strings.Join(trimmed, ",")
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	buffer := &bytes.Buffer{}
+	for _, name := range names {
+		fmt.Fprintf(buffer, "%s:%s\n", name, headers[name])
+	}
+	return buffer.String(), strings.Join(names, ";")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,103 @@
+// Package tone scores the sentiment of short English texts such as commit messages.
+// It does not depend on any models: the words are looked up in a lexicon of weights
+// and the sum is squashed into [0, 1]. The negations flip the signs of the following words.
+package tone
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Neutral is the score of the texts without any sentiment.
+const Neutral = 0.5
+
+// negationWindow is the number of words after a negation which have their signs flipped.
+const negationWindow = 3
+
+// normalization controls how fast the sum of the weights approaches the score bounds.
+const normalization = 15
+
+var wordRegexp = regexp.MustCompile(`\pL+(?:'\pL+)?`)
+
+// Lexicon maps the lower case words to their sentiment weights. The positive weights
+// mean positive sentiment, the negative weights mean negative sentiment.
+type Lexicon map[string]float64
+
+// DefaultLexicon is tuned for the commit messages: the words which are neutral in the
+// software development such as "fix", "bug" or "error" are not included.
+var DefaultLexicon = Lexicon{
+	"awesome": 3, "beautiful": 3, "excellent": 3, "great": 3, "love": 3, "perfect": 3,
+	"amazing": 3, "yay": 3, "hooray": 3, "thanks": 2, "thank": 2, "nice": 2, "good": 2,
+	"happy": 2, "elegant": 2, "cool": 2, "glad": 2, "neat": 2, "finally": 1, "better": 1,
+	"improve": 1, "improved": 1, "improves": 1, "improvement": 1, "cleaner": 1,
+	"simpler": 1, "simplify": 1, "simplified": 1, "faster": 1, "robust": 1, "easier": 1,
+	"success": 1, "successfully": 1, "properly": 1, "correctly": 1, "welcome": 1,
+	"ugly": -2, "hack": -1, "hacky": -2, "horrible": -3, "terrible": -3, "awful": -3,
+	"stupid": -3, "dumb": -2, "silly": -1, "idiotic": -3, "crap": -3, "crappy": -3,
+	"damn": -3, "wtf": -3, "shit": -4, "fuck": -4, "fucking": -4, "hate": -3, "sucks": -3,
+	"annoying": -2, "mess": -2, "messy": -2, "broken": -2, "worse": -2, "worst": -3,
+	"bad": -2, "wrong": -1, "oops": -1, "sorry": -1, "weird": -1, "confusing": -1,
+	"painful": -2, "nasty": -2, "insane": -2, "ridiculous": -2, "garbage": -3,
+	"useless": -2, "dirty": -1, "kludge": -2, "workaround": -1, "unfortunately": -1,
+	"embarrassing": -2, "frustrating": -2,
+}
+
+// negations flip the signs of the words which follow them.
+var negations = map[string]bool{
+	"not": true, "no": true, "never": true, "without": true, "nothing": true,
+	"don't": true, "doesn't": true, "didn't": true, "isn't": true, "wasn't": true,
+	"aren't": true, "can't": true, "cannot": true, "won't": true, "shouldn't": true,
+}
+
+// LoadLexicon reads a lexicon from the text with one "word weight" pair per line.
+// The empty lines and the lines which start with "#" are ignored.
+func LoadLexicon(reader io.Reader) (Lexicon, error) {
+	lexicon := Lexicon{}
+	scanner := bufio.NewScanner(reader)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("lexicon line %d: expected \"word weight\", got %q", line, text)
+		}
+		weight, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("lexicon line %d: %v", line, err)
+		}
+		lexicon[strings.ToLower(fields[0])] = weight
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lexicon, nil
+}
+
+// Score returns the sentiment of the text between 0 (the most negative) and 1 (the most
+// positive). The texts without any known words are Neutral.
+func (lexicon Lexicon) Score(text string) float32 {
+	sum := 0.0
+	negated := 0
+	for _, word := range wordRegexp.FindAllString(strings.ToLower(text), -1) {
+		if negations[word] {
+			negated = negationWindow
+			continue
+		}
+		weight := lexicon[word]
+		if negated > 0 {
+			negated--
+			weight = -weight
+		}
+		sum += weight
+	}
+	return float32((sum/math.Sqrt(sum*sum+normalization) + 1) / 2)
+}
@@ -0,0 +1,37 @@
+package tone
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScore(t *testing.T) {
+	assert.Equal(t, DefaultLexicon.Score("Refactor the parser"), float32(Neutral))
+	assert.Equal(t, DefaultLexicon.Score(""), float32(Neutral))
+	assert.Equal(t, DefaultLexicon.Score("Fix the bug in the error handling"), float32(Neutral))
+	positive := DefaultLexicon.Score("Great cleanup, thanks!")
+	assert.True(t, positive > 0.8)
+	assert.True(t, positive < 1)
+	negative := DefaultLexicon.Score("WTF, this ugly hack is BROKEN again")
+	assert.True(t, negative < 0.1)
+	assert.True(t, negative > 0)
+	assert.InDelta(t, DefaultLexicon.Score("Nice"), 1-DefaultLexicon.Score("Bad"), 0.0001)
+	assert.True(t, DefaultLexicon.Score("The tests are not broken anymore") > Neutral)
+	assert.True(t, DefaultLexicon.Score("This doesn't look good") < Neutral)
+	assert.Equal(t, DefaultLexicon.Score("not, well, refactor anything good"),
+		DefaultLexicon.Score("good"))
+}
+
+func TestLoadLexicon(t *testing.T) {
+	lexicon, err := LoadLexicon(strings.NewReader("# custom\nLGTM 2\n\nmeh\t-0.5\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, lexicon, Lexicon{"lgtm": 2, "meh": -0.5})
+	assert.True(t, lexicon.Score("lgtm") > Neutral)
+	assert.Equal(t, lexicon.Score("great"), float32(Neutral))
+	_, err = LoadLexicon(strings.NewReader("good\n"))
+	assert.NotNil(t, err)
+	_, err = LoadLexicon(strings.NewReader("good very\n"))
+	assert.NotNil(t, err)
+}
@@ -28,9 +28,52 @@ if sys.version_info[0] < 3:
 
 
 PB_MESSAGES = {
+    "APISurface": "internal.pb.pb_pb2.APISurfaceAnalysisResults",
+    "Activity": "internal.pb.pb_pb2.ActivityAnalysisResults",
+    "CommitLanguages": "internal.pb.pb_pb2.CommitLanguagesAnalysisResults",
+    "CommitSentiment": "internal.pb.pb_pb2.CommitSentimentAnalysisResults",
+    "Complexity": "internal.pb.pb_pb2.ComplexityAnalysisResults",
+    "BranchLifetime": "internal.pb.pb_pb2.BranchLifetimeAnalysisResults",
     "Burndown": "internal.pb.pb_pb2.BurndownAnalysisResults",
+    "Clones": "internal.pb.pb_pb2.ClonesAnalysisResults",
+    "Cocomo": "internal.pb.pb_pb2.CocomoAnalysisResults",
+    "CommentDensity": "internal.pb.pb_pb2.CommentDensityAnalysisResults",
+    "ChangeEntropy": "internal.pb.pb_pb2.ChangeEntropyAnalysisResults",
+    "ConventionalCommits": "internal.pb.pb_pb2.ConventionalCommitsAnalysisResults",
+    "Contributors": "internal.pb.pb_pb2.ContributorsAnalysisResults",
     "Couples": "internal.pb.pb_pb2.CouplesAnalysisResults",
+    "DAGShape": "internal.pb.pb_pb2.DAGShapeAnalysisResults",
+    "DeadCode": "internal.pb.pb_pb2.DeadCodeAnalysisResults",
+    "DefectFeatures": "internal.pb.pb_pb2.DefectFeaturesAnalysisResults",
+    "Expertise": "internal.pb.pb_pb2.ExpertiseAnalysisResults",
+    "FileAge": "internal.pb.pb_pb2.FileAgeAnalysisResults",
+    "FunctionChurn": "internal.pb.pb_pb2.FunctionChurnAnalysisResults",
+    "Gini": "internal.pb.pb_pb2.GiniAnalysisResults",
+    "HistoryRewrites": "internal.pb.pb_pb2.HistoryRewritesAnalysisResults",
+    "Hotspots": "internal.pb.pb_pb2.HotspotsAnalysisResults",
+    "ImpactChurn": "internal.pb.pb_pb2.ImpactChurnAnalysisResults",
+    "ImportGraph": "internal.pb.pb_pb2.ImportGraphAnalysisResults",
+    "IssueReferences": "internal.pb.pb_pb2.IssueReferencesAnalysisResults",
+    "KnowledgeMap": "internal.pb.pb_pb2.KnowledgeMapAnalysisResults",
+    "LanguageLines": "internal.pb.pb_pb2.LanguageLinesAnalysisResults",
+    "Onboarding": "internal.pb.pb_pb2.OnboardingAnalysisResults",
+    "Ownership": "internal.pb.pb_pb2.OwnershipAnalysisResults",
+    "Refactoring": "internal.pb.pb_pb2.RefactoringAnalysisResults",
+    "ReleaseCadence": "internal.pb.pb_pb2.ReleaseCadenceAnalysisResults",
+    "RepositorySize": "internal.pb.pb_pb2.RepositorySizeAnalysisResults",
+    "ReviewLatency": "internal.pb.pb_pb2.ReviewLatencyAnalysisResults",
+    "RenameFrequency": "internal.pb.pb_pb2.RenameFrequencyAnalysisResults",
+    "Reverts": "internal.pb.pb_pb2.RevertsAnalysisResults",
+    "RewriteDepth": "internal.pb.pb_pb2.RewriteDepthAnalysisResults",
+    "SensitivePaths": "internal.pb.pb_pb2.SensitivePathsAnalysisResults",
     "Shotness": "internal.pb.pb_pb2.ShotnessAnalysisResults",
+    "Stewardship": "internal.pb.pb_pb2.StewardshipAnalysisResults",
+    "TeamAlignment": "internal.pb.pb_pb2.TeamAlignmentAnalysisResults",
+    "TechDebt": "internal.pb.pb_pb2.TechDebtAnalysisResults",
+    "Tenure": "internal.pb.pb_pb2.TenureAnalysisResults",
+    "TestRatio": "internal.pb.pb_pb2.TestRatioAnalysisResults",
+    "Vocabulary": "internal.pb.pb_pb2.VocabularyAnalysisResults",
+    "WorkPatterns": "internal.pb.pb_pb2.WorkPatternsAnalysisResults",
 }
 
 
@@ -53,7 +96,8 @@ def parse_args():
                         help="Occupy 100%% height for every measurement.")
     parser.add_argument("--couples-tmp-dir", help="Temporary directory to work with couples.")
     parser.add_argument("-m", "--mode",
-                        choices=["project", "file", "person", "churn_matrix", "ownership",
+                        choices=["project", "file", "directory", "language", "person",
+                                 "churn_matrix", "ownership",
                                  "couples", "shotness", "sentiment", "all", "run_times"],
                         help="What to plot.")
     parser.add_argument(
@@ -80,6 +124,9 @@ class Reader(object):
     def get_header(self):
         raise NotImplementedError
 
+    def get_annotations(self):
+        raise NotImplementedError
+
     def get_burndown_parameters(self):
         raise NotImplementedError
 
@@ -89,6 +136,12 @@ class Reader(object):
     def get_files_burndown(self):
         raise NotImplementedError
 
+    def get_directories_burndown(self):
+        raise NotImplementedError
+
+    def get_languages_burndown(self):
+        raise NotImplementedError
+
     def get_people_burndown(self):
         raise NotImplementedError
 
@@ -134,6 +187,9 @@ class YamlReader(Reader):
     def get_run_times(self):
         return {}
 
+    def get_profile(self):
+        return self.data["hercules"].get("profile", {})
+
     def get_name(self):
         return self.data["hercules"]["repository"]
 
@@ -141,6 +197,10 @@ class YamlReader(Reader):
         header = self.data["hercules"]
         return header["begin_unix_time"], header["end_unix_time"]
 
+    def get_annotations(self):
+        return [(datetime.fromtimestamp(a["unix_time"]), a["label"])
+                for a in self.data["hercules"].get("annotations", [])]
+
     def get_burndown_parameters(self):
         header = self.data["Burndown"]
         return header["sampling"], header["granularity"]
@@ -153,6 +213,14 @@ class YamlReader(Reader):
         return [(p[0], self._parse_burndown_matrix(p[1]).T)
                 for p in self.data["Burndown"]["files"].items()]
 
+    def get_directories_burndown(self):
+        return [(p[0], self._parse_burndown_matrix(p[1]).T)
+                for p in self.data["Burndown"]["directories"].items()]
+
+    def get_languages_burndown(self):
+        return [(p[0], self._parse_burndown_matrix(p[1]).T)
+                for p in self.data["Burndown"]["languages"].items()]
+
     def get_people_burndown(self):
         return [(p[0], self._parse_burndown_matrix(p[1]).T)
                 for p in self.data["Burndown"]["people"].items()]
@@ -255,6 +323,12 @@ class ProtobufReader(Reader):
     def get_run_times(self):
         return {key: val for key, val in self.data.header.run_time_per_item.items()}
 
+    def get_profile(self):
+        return {key: {"wall_time": val.wall_time, "cpu_time": val.cpu_time,
+                      "allocations": val.allocations, "allocated_bytes": val.allocated_bytes,
+                      "calls": val.calls}
+                for key, val in self.data.header.profile_per_item.items()}
+
     def get_name(self):
         return self.data.header.repository
 
@@ -262,6 +336,10 @@ class ProtobufReader(Reader):
         header = self.data.header
         return header.begin_unix_time, header.end_unix_time
 
+    def get_annotations(self):
+        return [(datetime.fromtimestamp(a.unix_time), a.label)
+                for a in self.data.header.annotations]
+
     def get_burndown_parameters(self):
         burndown = self.contents["Burndown"]
         return burndown.sampling, burndown.granularity
@@ -272,6 +350,18 @@ class ProtobufReader(Reader):
     def get_files_burndown(self):
         return [self._parse_burndown_matrix(i) for i in self.contents["Burndown"].files]
 
+    def get_directories_burndown(self):
+        directories = self.contents["Burndown"].directories
+        if not directories:
+            raise KeyError
+        return [self._parse_burndown_matrix(i) for i in directories]
+
+    def get_languages_burndown(self):
+        languages = self.contents["Burndown"].languages
+        if not languages:
+            raise KeyError
+        return [self._parse_burndown_matrix(i) for i in languages]
+
     def get_people_burndown(self):
         return [self._parse_burndown_matrix(i) for i in self.contents["Burndown"].people]
 
@@ -657,6 +747,24 @@ def deploy_plot(title, output, style):
     pyplot.clf()
 
 
+def plot_annotations(pyplot, annotations, style):
+    """
+    Draws the events supplied with `hercules --annotations` as labelled vertical lines.
+    """
+    if not annotations:
+        return
+    xlim = pyplot.xlim()
+    ymax = pyplot.ylim()[1]
+    import matplotlib.dates
+    for date, label in annotations:
+        x = matplotlib.dates.date2num(date)
+        if x < xlim[0] or x > xlim[1]:
+            continue
+        pyplot.axvline(date, color=style, linestyle="--", linewidth=0.8, alpha=0.6)
+        pyplot.text(date, ymax, " " + label, rotation=90, va="top", ha="right",
+                    color=style, fontsize="small")
+
+
 def default_json(x):
     if hasattr(x, "tolist"):
         return x.tolist()
@@ -671,6 +779,7 @@ def plot_burndown(args, target, name, matrix, date_range_sampling, labels, granu
         data = locals().copy()
         del data["args"]
         data["type"] = "burndown"
+        data["annotations"] = getattr(args, "annotations", [])
         if args.mode == "project" and target == "project":
             output = args.output
         else:
@@ -699,6 +808,7 @@ def plot_burndown(args, target, name, matrix, date_range_sampling, labels, granu
     pyplot.xlabel("Time")
     apply_plot_style(pyplot.gcf(), pyplot.gca(), legend, args.style, args.text_size, args.size)
     pyplot.xlim(date_range_sampling[0], date_range_sampling[-1])
+    plot_annotations(pyplot, getattr(args, "annotations", []), args.style)
     locator = pyplot.gca().xaxis.get_major_locator()
     # set the optimal xticks locator
     if "M" not in resample:
@@ -811,6 +921,7 @@ def plot_ownership(args, repo, names, people, date_range, last):
         data = locals().copy()
         del data["args"]
         data["type"] = "ownership"
+        data["annotations"] = getattr(args, "annotations", [])
         if args.mode == "all":
             output = get_plot_path(args.output, "people")
         else:
@@ -835,6 +946,7 @@ def plot_ownership(args, repo, names, people, date_range, last):
         legend_loc = 2
     legend = pyplot.legend(loc=legend_loc, fontsize=args.text_size)
     apply_plot_style(pyplot.gcf(), pyplot.gca(), legend, args.style, args.text_size, args.size)
+    plot_annotations(pyplot, getattr(args, "annotations", []), args.style)
     if args.mode == "all":
         output = get_plot_path(args.output, "people")
     else:
@@ -1136,11 +1248,18 @@ def main():
     reader = read_input(args)
     header = reader.get_header()
     name = reader.get_name()
+    args.annotations = reader.get_annotations()
 
     burndown_warning = "Burndown stats were not collected. Re-run hercules with --burndown."
     burndown_files_warning = \
         "Burndown stats for files were not collected. Re-run hercules with " \
         "--burndown --burndown-files."
+    burndown_directories_warning = \
+        "Burndown stats for directories were not collected. Re-run hercules with " \
+        "--burndown --burndown-dirs."
+    burndown_languages_warning = \
+        "Burndown stats for languages were not collected. Re-run hercules with " \
+        "--burndown --burndown-languages."
     burndown_people_warning = \
         "Burndown stats for people were not collected. Re-run hercules with " \
         "--burndown --burndown-people."
@@ -1153,6 +1272,10 @@ def main():
         rt = reader.get_run_times()
         import pandas
         print(pandas.to_timedelta(pandas.Series(rt).sort_values(ascending=False), unit="s"))
+        profile = reader.get_profile()
+        if profile:
+            print()
+            print(pandas.DataFrame(profile).T.sort_values("wall_time", ascending=False))
 
     def project_burndown():
         try:
@@ -1175,6 +1298,28 @@ def main():
         except KeyError:
             print("files: " + burndown_files_warning)
 
+    def directories_burndown():
+        try:
+            full_header = header + reader.get_burndown_parameters()
+        except KeyError:
+            print(burndown_warning)
+            return
+        try:
+            plot_many_burndown(args, "directory", full_header, reader.get_directories_burndown())
+        except KeyError:
+            print("directories: " + burndown_directories_warning)
+
+    def languages_burndown():
+        try:
+            full_header = header + reader.get_burndown_parameters()
+        except KeyError:
+            print(burndown_warning)
+            return
+        try:
+            plot_many_burndown(args, "language", full_header, reader.get_languages_burndown())
+        except KeyError:
+            print("languages: " + burndown_languages_warning)
+
     def people_burndown():
         try:
             full_header = header + reader.get_burndown_parameters()
@@ -1244,6 +1389,10 @@ def main():
         project_burndown()
     elif args.mode == "file":
         files_burndown()
+    elif args.mode == "directory":
+        directories_burndown()
+    elif args.mode == "language":
+        languages_burndown()
     elif args.mode == "person":
         people_burndown()
     elif args.mode == "churn_matrix":
@@ -1259,6 +1408,8 @@ def main():
     elif args.mode == "all":
         project_burndown()
         files_burndown()
+        directories_burndown()
+        languages_burndown()
         people_burndown()
         churn_matrix()
         ownership_burndown()
@@ -0,0 +1,422 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// ActivityAnalysis counts the commits and the touched files of each developer and the commits
+// made on each day. It also reports the active developers in each time window as defined by
+// identity.ActiveDevelopers. It needs only the commit metadata and the changed file names, so it is
+// suitable for the fast mode (core.ConfigPipelineFast).
+type ActivityAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// PeopleNumber is the number of developers.
+	PeopleNumber int
+
+	// days maps the day index to the number of commits of each developer.
+	days map[int]map[int]int
+	// peopleCommits is the number of commits of each developer.
+	peopleCommits []int
+	// peopleFiles is the number of file changes made by each developer.
+	peopleFiles []int
+	// activeDevelopers references IdentityDetector.ActiveDevelopers
+	activeDevelopers *identity.ActiveDevelopers
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+	// coAuthors references IdentityDetector.CoAuthors: the co-authors are credited with
+	// the commit and either with all the file changes or with an even share of them.
+	coAuthors string
+}
+
+// ActivityResult is returned by ActivityAnalysis.Finalize().
+type ActivityResult struct {
+	// Days maps the day index to the number of commits of each developer.
+	Days map[int]map[int]int
+	// PeopleCommits is the number of commits of each developer. The last element
+	// corresponds to the unmatched identities.
+	PeopleCommits []int
+	// PeopleFiles is the number of file changes made by each developer. It has the same
+	// layout as PeopleCommits.
+	PeopleFiles []int
+	// ActiveWindow is the length of the time window in days which defines the active developers.
+	ActiveWindow int
+	// Active maps the window index to the active developers. The windows without any
+	// are omitted.
+	Active map[int][]int
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (activity *ActivityAnalysis) Name() string {
+	return "Activity"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (activity *ActivityAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (activity *ActivityAnalysis) Requires() []string {
+	arr := [...]string{
+		identity.DependencyAuthor, identity.DependencyCoAuthors, items.DependencyDay,
+		items.DependencyTreeChanges}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (activity *ActivityAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (activity *ActivityAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		activity.PeopleNumber = val
+		activity.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+	}
+	if val, exists := facts[identity.FactIdentityDetectorActiveDevelopers]; exists {
+		activity.activeDevelopers = val.(*identity.ActiveDevelopers)
+	}
+	if val, exists := facts[identity.FactIdentityDetectorCoAuthors].(string); exists {
+		activity.coAuthors = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (activity *ActivityAnalysis) Flag() string {
+	return "activity"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (activity *ActivityAnalysis) Description() string {
+	return "Counts the commits and the touched files of each developer, the commits " +
+		"made on each day and the active developers. Does not read the file contents."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (activity *ActivityAnalysis) Initialize(repository *git.Repository) {
+	activity.days = map[int]map[int]int{}
+	activity.peopleCommits = make([]int, activity.PeopleNumber+1)
+	activity.peopleFiles = make([]int, activity.PeopleNumber+1)
+	activity.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (activity *ActivityAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !activity.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing {
+		author = activity.PeopleNumber
+	}
+	day := deps[items.DependencyDay].(int)
+	dayActivity := activity.days[day]
+	if dayActivity == nil {
+		dayActivity = map[int]int{}
+		activity.days[day] = dayActivity
+	}
+	people := []int{author}
+	if coAuthors, _ := deps[identity.DependencyCoAuthors].([]int); len(coAuthors) > 0 {
+		for _, coAuthor := range coAuthors {
+			if coAuthor >= 0 && coAuthor < activity.PeopleNumber {
+				people = append(people, coAuthor)
+			}
+		}
+	}
+	var files []int
+	if !deps[core.DependencyIsMerge].(bool) {
+		changes := len(deps[items.DependencyTreeChanges].(object.Changes))
+		if activity.coAuthors == identity.CoAuthorsSplit {
+			files = splitCredit(changes, len(people))
+		} else {
+			files = make([]int, len(people))
+			for i := range files {
+				files[i] = changes
+			}
+		}
+	}
+	for i, person := range people {
+		dayActivity[person]++
+		activity.peopleCommits[person]++
+		if files != nil {
+			activity.peopleFiles[person] += files[i]
+		}
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (activity *ActivityAnalysis) Finalize() interface{} {
+	window := identity.DefaultActiveWindow
+	if activity.activeDevelopers != nil {
+		window = activity.activeDevelopers.Policy.Window
+	}
+	return ActivityResult{
+		Days:               activity.days,
+		PeopleCommits:      activity.peopleCommits,
+		PeopleFiles:        activity.peopleFiles,
+		ActiveWindow:       window,
+		Active:             activity.activeDevelopers.Windows(),
+		reversedPeopleDict: activity.reversedPeopleDict,
+	}
+}
+
+// Fork clones this pipeline item.
+func (activity *ActivityAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(activity, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (activity *ActivityAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	activityResult := result.(ActivityResult)
+	if binary {
+		return activity.serializeBinary(&activityResult, writer)
+	}
+	activity.serializeText(&activityResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (activity *ActivityAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(activity, result, &pb.ActivityAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (activity *ActivityAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(activity, result, &pb.ActivityAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to ActivityResult.
+func (activity *ActivityAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.ActivityAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := ActivityResult{
+		Days:               map[int]map[int]int{},
+		PeopleCommits:      make([]int, len(message.PeopleCommits)),
+		PeopleFiles:        make([]int, len(message.PeopleFiles)),
+		ActiveWindow:       int(message.ActiveWindow),
+		Active:             map[int][]int{},
+		reversedPeopleDict: message.DevIndex,
+	}
+	for day, dayActivity := range message.Days {
+		commits := map[int]int{}
+		for dev, val := range dayActivity.Commits {
+			commits[int(dev)] = int(val)
+		}
+		result.Days[int(day)] = commits
+	}
+	for i, val := range message.PeopleCommits {
+		result.PeopleCommits[i] = int(val)
+	}
+	for i, val := range message.PeopleFiles {
+		result.PeopleFiles[i] = int(val)
+	}
+	for window, active := range message.Active {
+		devs := make([]int, len(active.Developers))
+		for i, dev := range active.Developers {
+			devs[i] = int(dev)
+		}
+		result.Active[int(window)] = devs
+	}
+	return result, nil
+}
+
+// MergeResults combines two ActivityResult-s together. The active developers are
+// regrouped by the time window of the first result: a developer is active in the merged window
+// if they were active in any of the original windows which start in it.
+func (activity *ActivityAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	ar1 := r1.(ActivityResult)
+	ar2 := r2.(ActivityResult)
+	merged := ActivityResult{
+		Days:         map[int]map[int]int{},
+		ActiveWindow: ar1.ActiveWindow,
+		Active:       map[int][]int{},
+	}
+	if merged.ActiveWindow <= 0 {
+		merged.ActiveWindow = ar2.ActiveWindow
+	}
+	if merged.ActiveWindow <= 0 {
+		merged.ActiveWindow = identity.DefaultActiveWindow
+	}
+	active := map[int]map[int]bool{}
+	var people map[string][3]int
+	people, merged.reversedPeopleDict = identity.Detector{}.MergeReversedDicts(
+		ar1.reversedPeopleDict, ar2.reversedPeopleDict)
+	merged.PeopleCommits = make([]int, len(merged.reversedPeopleDict)+1)
+	merged.PeopleFiles = make([]int, len(merged.reversedPeopleDict)+1)
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	add := func(result *ActivityResult, c *core.CommonAnalysisResult) {
+		index := func(dev int) int {
+			if dev < len(result.reversedPeopleDict) {
+				return people[result.reversedPeopleDict[dev]][0]
+			}
+			return len(merged.reversedPeopleDict)
+		}
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for day, commits := range result.Days {
+			dayActivity := merged.Days[day+offset]
+			if dayActivity == nil {
+				dayActivity = map[int]int{}
+				merged.Days[day+offset] = dayActivity
+			}
+			for dev, val := range commits {
+				dayActivity[index(dev)] += val
+			}
+		}
+		for dev, val := range result.PeopleCommits {
+			merged.PeopleCommits[index(dev)] += val
+		}
+		for dev, val := range result.PeopleFiles {
+			merged.PeopleFiles[index(dev)] += val
+		}
+		for window, devs := range result.Active {
+			window = (window*result.ActiveWindow + offset) / merged.ActiveWindow
+			if active[window] == nil {
+				active[window] = map[int]bool{}
+			}
+			for _, dev := range devs {
+				active[window][index(dev)] = true
+			}
+		}
+	}
+	add(&ar1, c1)
+	add(&ar2, c2)
+	for window, devs := range active {
+		list := make([]int, 0, len(devs))
+		for dev := range devs {
+			list = append(list, dev)
+		}
+		sort.Ints(list)
+		merged.Active[window] = list
+	}
+	return merged
+}
+
+func (activity *ActivityAnalysis) serializeText(result *ActivityResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  days:")
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	for _, day := range days {
+		devs := make([]int, 0, len(result.Days[day]))
+		for dev := range result.Days[day] {
+			devs = append(devs, dev)
+		}
+		sort.Ints(devs)
+		fmt.Fprintf(writer, "    %d: {", day)
+		for i, dev := range devs {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprintf(writer, "%d: %d", dev, result.Days[day][dev])
+		}
+		fmt.Fprintln(writer, "}")
+	}
+	fmt.Fprint(writer, "  people_commits: [")
+	writeIntList(writer, result.PeopleCommits)
+	fmt.Fprintln(writer, "]")
+	fmt.Fprint(writer, "  people_files: [")
+	writeIntList(writer, result.PeopleFiles)
+	fmt.Fprintln(writer, "]")
+	fmt.Fprintln(writer, "  active_window:", result.ActiveWindow)
+	fmt.Fprintln(writer, "  active:")
+	windows := make([]int, 0, len(result.Active))
+	for window := range result.Active {
+		windows = append(windows, window)
+	}
+	sort.Ints(windows)
+	for _, window := range windows {
+		fmt.Fprintf(writer, "    %d: [", window)
+		writeIntList(writer, result.Active[window])
+		fmt.Fprintln(writer, "]")
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func writeIntList(writer io.Writer, list []int) {
+	for i, val := range list {
+		if i > 0 {
+			fmt.Fprint(writer, ", ")
+		}
+		fmt.Fprint(writer, val)
+	}
+}
+
+func (activity *ActivityAnalysis) serializeBinary(result *ActivityResult, writer io.Writer) error {
+	message := pb.ActivityAnalysisResults{
+		Days:          map[int32]*pb.ActivityDay{},
+		PeopleCommits: make([]int32, len(result.PeopleCommits)),
+		PeopleFiles:   make([]int32, len(result.PeopleFiles)),
+		DevIndex:      result.reversedPeopleDict,
+		ActiveWindow:  int32(result.ActiveWindow),
+		Active:        map[int32]*pb.ActiveDevelopers{},
+	}
+	for day, commits := range result.Days {
+		dayActivity := &pb.ActivityDay{Commits: map[int32]int32{}}
+		for dev, val := range commits {
+			dayActivity.Commits[int32(dev)] = int32(val)
+		}
+		message.Days[int32(day)] = dayActivity
+	}
+	for i, val := range result.PeopleCommits {
+		message.PeopleCommits[i] = int32(val)
+	}
+	for i, val := range result.PeopleFiles {
+		message.PeopleFiles[i] = int32(val)
+	}
+	for window, devs := range result.Active {
+		active := &pb.ActiveDevelopers{Developers: make([]int32, len(devs))}
+		for i, dev := range devs {
+			active.Developers[i] = int32(dev)
+		}
+		message.Active[int32(window)] = active
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&ActivityAnalysis{})
+}
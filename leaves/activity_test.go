@@ -0,0 +1,214 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureActivity() *ActivityAnalysis {
+	activity := ActivityAnalysis{}
+	activity.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorPeopleCount:        2,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"alice", "bob"},
+	})
+	activity.Initialize(nil)
+	return &activity
+}
+
+func TestActivityMeta(t *testing.T) {
+	activity := fixtureActivity()
+	assert.Equal(t, activity.Name(), "Activity")
+	assert.Len(t, activity.Provides(), 0)
+	assert.Equal(t, activity.Requires(), []string{
+		identity.DependencyAuthor, identity.DependencyCoAuthors, items.DependencyDay,
+		items.DependencyTreeChanges})
+	assert.Len(t, activity.ListConfigurationOptions(), 0)
+	assert.Equal(t, activity.Flag(), "activity")
+	assert.NotEmpty(t, activity.Description())
+	assert.Equal(t, activity.PeopleNumber, 2)
+	assert.Equal(t, activity.reversedPeopleDict, []string{"alice", "bob"})
+}
+
+func TestActivityRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&ActivityAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Activity")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&ActivityAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func consumeActivity(t *testing.T, activity *ActivityAnalysis, author, day, changes int,
+	commit *object.Commit, merge bool) {
+	deps := map[string]interface{}{
+		identity.DependencyAuthor:   author,
+		items.DependencyDay:         day,
+		items.DependencyTreeChanges: make(object.Changes, changes),
+		core.DependencyCommit:       commit,
+		core.DependencyIsMerge:      merge,
+	}
+	result, err := activity.Consume(deps)
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func TestActivityConsumeFinalize(t *testing.T) {
+	activity := fixtureActivity()
+	commit := &object.Commit{}
+	consumeActivity(t, activity, 0, 0, 2, commit, false)
+	consumeActivity(t, activity, 1, 0, 1, commit, false)
+	consumeActivity(t, activity, 0, 3, 4, commit, false)
+	consumeActivity(t, activity, identity.AuthorMissing, 3, 1, commit, false)
+	merge := &object.Commit{Hash: plumbing.NewHash("0123456789012345678901234567890123456789"),
+		ParentHashes: make([]plumbing.Hash, 2)}
+	consumeActivity(t, activity, 1, 5, 7, merge, true)
+	consumeActivity(t, activity, 1, 5, 7, merge, true)
+	result := activity.Finalize().(ActivityResult)
+	assert.Equal(t, result.Days, map[int]map[int]int{0: {0: 1, 1: 1}, 3: {0: 1, 2: 1}, 5: {1: 1}})
+	assert.Equal(t, result.PeopleCommits, []int{2, 2, 1})
+	assert.Equal(t, result.PeopleFiles, []int{6, 1, 1})
+	assert.Equal(t, result.reversedPeopleDict, []string{"alice", "bob"})
+	assert.Equal(t, result.ActiveWindow, identity.DefaultActiveWindow)
+	assert.Len(t, result.Active, 0)
+}
+
+func TestActivityCoAuthors(t *testing.T) {
+	for _, mode := range []string{identity.CoAuthorsDuplicate, identity.CoAuthorsSplit} {
+		activity := ActivityAnalysis{}
+		activity.Configure(map[string]interface{}{
+			identity.FactIdentityDetectorPeopleCount:        3,
+			identity.FactIdentityDetectorReversedPeopleDict: []string{"alice", "bob", "carol"},
+			identity.FactIdentityDetectorCoAuthors:          mode,
+		})
+		activity.Initialize(nil)
+		consume := func(author, changes int, coAuthors []int) {
+			result, err := activity.Consume(map[string]interface{}{
+				identity.DependencyAuthor:    author,
+				identity.DependencyCoAuthors: coAuthors,
+				items.DependencyDay:          0,
+				items.DependencyTreeChanges:  make(object.Changes, changes),
+				core.DependencyCommit:        &object.Commit{},
+				core.DependencyIsMerge:       false,
+			})
+			assert.Nil(t, err)
+			assert.Nil(t, result)
+		}
+		consume(0, 5, []int{1, 2})
+		consume(identity.AuthorMissing, 2, []int{2})
+		consume(1, 1, nil)
+		result := activity.Finalize().(ActivityResult)
+		assert.Equal(t, result.Days, map[int]map[int]int{0: {0: 1, 1: 2, 2: 2, 3: 1}}, mode)
+		assert.Equal(t, result.PeopleCommits, []int{1, 2, 2, 1}, mode)
+		if mode == identity.CoAuthorsDuplicate {
+			assert.Equal(t, result.PeopleFiles, []int{5, 6, 7, 2})
+		} else {
+			assert.Equal(t, result.PeopleFiles, []int{2, 3, 2, 1})
+		}
+	}
+}
+
+func TestActivityActiveDevelopers(t *testing.T) {
+	commits := make([]*object.Commit, 3)
+	for i, name := range []string{"alice", "bob", "alice"} {
+		commits[i] = &object.Commit{
+			Author: object.Signature{Name: name, Email: name + "@x.com",
+				When: time.Date(2017, 1, 1+i*10, 0, 0, 0, 0, time.UTC)}}
+		commits[i].Committer = commits[i].Author
+	}
+	facts := map[string]interface{}{
+		identity.ConfigIdentityDetectorActiveWindow:     10,
+		identity.FactIdentityDetectorPeopleDict:         map[string]int{"alice@x.com": 0, "bob@x.com": 1},
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"alice", "bob"},
+		core.ConfigPipelineCommits:                      commits,
+	}
+	(&identity.Detector{}).Configure(facts)
+	activity := ActivityAnalysis{}
+	activity.Configure(facts)
+	activity.Initialize(nil)
+	result := activity.Finalize().(ActivityResult)
+	assert.Equal(t, result.ActiveWindow, 10)
+	assert.Equal(t, result.Active, map[int][]int{0: {0}, 1: {1}, 2: {0}})
+}
+
+func fixtureActivityResult() ActivityResult {
+	return ActivityResult{
+		Days:               map[int]map[int]int{0: {0: 1, 1: 1}, 3: {0: 1, 2: 1}},
+		PeopleCommits:      []int{2, 1, 1},
+		PeopleFiles:        []int{6, 1, 1},
+		ActiveWindow:       2,
+		Active:             map[int][]int{0: {0, 1}, 1: {0}},
+		reversedPeopleDict: []string{"alice", "bob"},
+	}
+}
+
+func TestActivitySerialize(t *testing.T) {
+	activity := fixtureActivity()
+	result := fixtureActivityResult()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, activity.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  days:
+    0: {0: 1, 1: 1}
+    3: {0: 1, 2: 1}
+  people_commits: [2, 1, 1]
+  people_files: [6, 1, 1]
+  active_window: 2
+  active:
+    0: [0, 1]
+    1: [0]
+  people:
+  - "alice"
+  - "bob"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, activity.Serialize(result, true, buffer))
+	msg := pb.ActivityAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.PeopleCommits, []int32{2, 1, 1})
+	assert.Equal(t, msg.Days[3].Commits, map[int32]int32{0: 1, 2: 1})
+	assert.Equal(t, msg.ActiveWindow, int32(2))
+	assert.Equal(t, msg.Active[0].Developers, []int32{0, 1})
+	deserialized, err := activity.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+	_, err = activity.Deserialize([]byte("garbage"))
+	assert.NotNil(t, err)
+}
+
+func TestActivityMergeResults(t *testing.T) {
+	activity := fixtureActivity()
+	r1 := fixtureActivityResult()
+	r2 := ActivityResult{
+		Days:               map[int]map[int]int{0: {0: 3, 1: 1}},
+		PeopleCommits:      []int{3, 1, 0},
+		PeopleFiles:        []int{3, 2, 0},
+		ActiveWindow:       1,
+		Active:             map[int][]int{0: {0, 1}, 1: {1}},
+		reversedPeopleDict: []string{"bob", "carol"},
+	}
+	c1 := &core.CommonAnalysisResult{BeginTime: 1500000000}
+	c2 := &core.CommonAnalysisResult{BeginTime: 1500000000 + 3*24*3600}
+	merged := activity.MergeResults(r1, r2, c1, c2).(ActivityResult)
+	assert.Equal(t, merged.reversedPeopleDict, []string{"alice", "bob", "carol"})
+	assert.Equal(t, merged.Days, map[int]map[int]int{
+		0: {0: 1, 1: 1}, 3: {0: 1, 1: 3, 2: 1, 3: 1}})
+	assert.Equal(t, merged.PeopleCommits, []int{2, 4, 1, 1})
+	assert.Equal(t, merged.PeopleFiles, []int{6, 4, 2, 1})
+	assert.Equal(t, merged.ActiveWindow, 2)
+	assert.Equal(t, merged.Active, map[int][]int{0: {0, 1}, 1: {0, 1, 2}, 2: {2}})
+}
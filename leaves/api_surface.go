@@ -0,0 +1,498 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// APISurfaceAnalysis follows the exported symbols of the Go, Java and Python files through
+// the commit history: the functions and the types which are visible outside of their package.
+// Go symbols are exported if they start with a capital letter, Java symbols if they are public
+// and Python symbols if they do not start with an underscore. The members of the exported
+// types are qualified with the type name. A removed symbol or a changed number of function
+// arguments is a breaking change. The files which cannot be parsed keep the previous symbols.
+// The merge commits are skipped.
+type APISurfaceAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the size of a tick in days.
+	Sampling int
+
+	// files map the file names to the exported symbols and their numbers of arguments.
+	files map[string]map[string]int
+	// total is the current number of the exported symbols.
+	total int
+	ticks []APISurfaceTick
+	// breakingChanges in the order of the commits.
+	breakingChanges []APIBreakingChange
+}
+
+// APISurfaceTick is the evolution of the exported symbols during a tick.
+type APISurfaceTick struct {
+	// Symbols is the number of the exported symbols at the end of the tick.
+	Symbols int
+	// Added is the number of the new exported symbols.
+	Added int
+	// Removed is the number of the deleted exported symbols.
+	Removed int
+	// Changed is the number of the exported functions with a different number of arguments.
+	Changed int
+}
+
+// APIBreakingChange is the removal or the signature change of an exported symbol.
+type APIBreakingChange struct {
+	Hash string
+	// Day is the number of days since the beginning of the history.
+	Day  int
+	File string
+	// Symbol is the name of the symbol, qualified with the names of the enclosing types.
+	Symbol string
+	// Removed is true if the symbol was removed and false if the number of the arguments changed.
+	Removed bool
+}
+
+// APISurfaceResult is returned by APISurfaceAnalysis.Finalize().
+type APISurfaceResult struct {
+	Ticks []APISurfaceTick
+	// BreakingChanges are sorted by day.
+	BreakingChanges []APIBreakingChange
+	// Sampling is the size of a tick in days.
+	Sampling int
+}
+
+const (
+	// ConfigAPISurfaceSampling is the name of the option to set APISurfaceAnalysis.Sampling.
+	ConfigAPISurfaceSampling = "APISurface.Sampling"
+	// DefaultAPISurfaceSampling is the default value of APISurfaceAnalysis.Sampling.
+	DefaultAPISurfaceSampling = 30
+	// apiSurfaceType is the number of arguments which marks the types.
+	apiSurfaceType = -1
+)
+
+// apiSurfaceLanguages map the file extensions to the supported languages.
+var apiSurfaceLanguages = map[string]string{
+	".go":   "go",
+	".java": "java",
+	".py":   "python",
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (analyser *APISurfaceAnalysis) Name() string {
+	return "APISurface"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (analyser *APISurfaceAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (analyser *APISurfaceAnalysis) Requires() []string {
+	arr := [...]string{uast_items.DependencyUastChanges, items.DependencyDay}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (analyser *APISurfaceAnalysis) Features() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (analyser *APISurfaceAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigAPISurfaceSampling,
+		Description: "How frequently to record the API changes in days.",
+		Flag:        "api-surface-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultAPISurfaceSampling},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (analyser *APISurfaceAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigAPISurfaceSampling].(int); exists {
+		analyser.Sampling = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (analyser *APISurfaceAnalysis) Flag() string {
+	return "api-surface"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (analyser *APISurfaceAnalysis) Description() string {
+	return "Tracks the exported functions and types in Go, Java and Python over time " +
+		"and reports the additions, the removals and the breaking changes."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (analyser *APISurfaceAnalysis) Initialize(repository *git.Repository) {
+	if analyser.Sampling <= 0 {
+		log.Printf("Warning: adjusted the API surface sampling to %d days\n",
+			DefaultAPISurfaceSampling)
+		analyser.Sampling = DefaultAPISurfaceSampling
+	}
+	analyser.files = map[string]map[string]int{}
+	analyser.total = 0
+	analyser.ticks = []APISurfaceTick{}
+	analyser.breakingChanges = []APIBreakingChange{}
+	analyser.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (analyser *APISurfaceAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !analyser.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	day := deps[items.DependencyDay].(int)
+	tick := day / analyser.Sampling
+	for len(analyser.ticks) <= tick {
+		analyser.ticks = append(analyser.ticks, APISurfaceTick{Symbols: analyser.total})
+	}
+	stats := &analyser.ticks[tick]
+	breaking := func(file, symbol string, removed bool) {
+		analyser.breakingChanges = append(analyser.breakingChanges, APIBreakingChange{
+			Hash: commit.Hash.String(), Day: day, File: file, Symbol: symbol, Removed: removed})
+	}
+	for _, change := range changes {
+		fromName, toName := change.Change.From.Name, change.Change.To.Name
+		old := analyser.files[fromName]
+		delete(analyser.files, fromName)
+		language := apiSurfaceLanguages[strings.ToLower(path.Ext(toName))]
+		symbols := old
+		if change.After != nil && language != "" {
+			symbols = exportedSymbols(change.After, language)
+		} else if toName == "" || language == "" {
+			symbols = nil
+		}
+		for _, symbol := range sortedAPISymbols(old) {
+			if arguments, exists := symbols[symbol]; !exists {
+				stats.Removed++
+				breaking(fromName, symbol, true)
+			} else if arguments != old[symbol] {
+				stats.Changed++
+				breaking(toName, symbol, false)
+			}
+		}
+		for symbol := range symbols {
+			if _, exists := old[symbol]; !exists {
+				stats.Added++
+			}
+		}
+		analyser.total += len(symbols) - len(old)
+		if len(symbols) > 0 {
+			analyser.files[toName] = symbols
+		}
+	}
+	stats.Symbols = analyser.total
+	return nil, nil
+}
+
+// sortedAPISymbols returns the symbol names in the alphabetical order.
+func sortedAPISymbols(symbols map[string]int) []string {
+	keys := make([]string, 0, len(symbols))
+	for symbol := range symbols {
+		keys = append(keys, symbol)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// exportedSymbols returns the exported functions and types declared in the UAST
+// mapped to their numbers of arguments; the types map to apiSurfaceType.
+func exportedSymbols(root *uast.Node, language string) map[string]int {
+	result := map[string]int{}
+	var visit func(node *uast.Node, scope string)
+	visit = func(node *uast.Node, scope string) {
+		isFunction := hasRoles(node, uast.Function, uast.Declaration)
+		isType := !isFunction && hasRoles(node, uast.Type, uast.Declaration)
+		if isFunction || isType {
+			var name string
+			if isFunction {
+				name = complexityFunctionName(node)
+			} else {
+				name = apiTypeName(node)
+			}
+			if name == "" || !isExportedSymbol(node, name, language) {
+				// neither the symbol nor its members are visible
+				return
+			}
+			if scope != "" {
+				name = scope + "." + name
+			}
+			if isFunction {
+				if _, exists := result[name]; !exists {
+					result[name] = countArguments(node)
+				}
+				// the nested functions are not exported
+				return
+			}
+			result[name] = apiSurfaceType
+			scope = name
+		}
+		for _, child := range node.Children {
+			visit(child, scope)
+		}
+	}
+	visit(root, "")
+	return result
+}
+
+// apiTypeName returns the name of the type declared by the node. The name is looked up
+// in the children and in the grandchildren.
+func apiTypeName(node *uast.Node) string {
+	for _, child := range node.Children {
+		if hasRoles(child, uast.Identifier) && child.Token != "" {
+			return child.Token
+		}
+	}
+	for _, child := range node.Children {
+		for _, grandchild := range child.Children {
+			if hasRoles(grandchild, uast.Identifier) && grandchild.Token != "" {
+				return grandchild.Token
+			}
+		}
+	}
+	return ""
+}
+
+// isExportedSymbol applies the visibility rules of the language.
+func isExportedSymbol(node *uast.Node, name, language string) bool {
+	switch language {
+	case "go":
+		first, _ := utf8.DecodeRuneInString(name)
+		return unicode.IsUpper(first)
+	case "python":
+		return !strings.HasPrefix(name, "_") ||
+			(len(name) > 4 && strings.HasPrefix(name, "__") && strings.HasSuffix(name, "__"))
+	case "java":
+		// the modifiers are either the children or the grandchildren
+		for _, child := range node.Children {
+			if hasRoles(child, uast.Visibility, uast.World) {
+				return true
+			}
+			if hasRoles(child, uast.Declaration) {
+				// the modifiers of the members
+				continue
+			}
+			for _, grandchild := range child.Children {
+				if hasRoles(grandchild, uast.Visibility, uast.World) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// countArguments returns the number of the arguments of the function. The body and
+// the nested functions are not inspected.
+func countArguments(function *uast.Node) int {
+	count := 0
+	var visit func(node *uast.Node)
+	visit = func(node *uast.Node) {
+		for _, child := range node.Children {
+			if hasRoles(child, uast.Argument) {
+				count++
+			} else if !hasRoles(child, uast.Body) && !hasRoles(child, uast.Function, uast.Declaration) {
+				visit(child)
+			}
+		}
+	}
+	visit(function)
+	return count
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (analyser *APISurfaceAnalysis) Finalize() interface{} {
+	return APISurfaceResult{
+		Ticks:           analyser.ticks,
+		BreakingChanges: analyser.breakingChanges,
+		Sampling:        analyser.Sampling,
+	}
+}
+
+// Fork clones this pipeline item.
+func (analyser *APISurfaceAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(analyser, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (analyser *APISurfaceAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	apiResult := result.(APISurfaceResult)
+	if binary {
+		return analyser.serializeBinary(&apiResult, writer)
+	}
+	analyser.serializeText(&apiResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (analyser *APISurfaceAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(analyser, result, &pb.APISurfaceAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (analyser *APISurfaceAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(analyser, result, &pb.APISurfaceAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to APISurfaceResult.
+func (analyser *APISurfaceAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.APISurfaceAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := APISurfaceResult{
+		Ticks:           make([]APISurfaceTick, len(message.Ticks)),
+		BreakingChanges: make([]APIBreakingChange, len(message.BreakingChanges)),
+		Sampling:        int(message.Sampling),
+	}
+	for i, tick := range message.Ticks {
+		result.Ticks[i] = APISurfaceTick{
+			Symbols: int(tick.Symbols), Added: int(tick.Added), Removed: int(tick.Removed),
+			Changed: int(tick.Changed)}
+	}
+	for i, change := range message.BreakingChanges {
+		result.BreakingChanges[i] = APIBreakingChange{
+			Hash: change.Hash, Day: int(change.Day), File: change.File, Symbol: change.Symbol,
+			Removed: change.Removed}
+	}
+	return result, nil
+}
+
+// MergeResults combines two APISurfaceResult-s together. The ticks are resampled to
+// the bigger sampling of the two.
+func (analyser *APISurfaceAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	ar1 := r1.(APISurfaceResult)
+	ar2 := r2.(APISurfaceResult)
+	merged := APISurfaceResult{BreakingChanges: []APIBreakingChange{}, Sampling: ar1.Sampling}
+	if ar2.Sampling > merged.Sampling {
+		merged.Sampling = ar2.Sampling
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	offsets := [2]int{
+		int((c1.BeginTime - beginTime) / (24 * 3600)),
+		int((c2.BeginTime - beginTime) / (24 * 3600)),
+	}
+	results := [2]*APISurfaceResult{&ar1, &ar2}
+	days := 0
+	for i, result := range results {
+		if end := offsets[i] + len(result.Ticks)*result.Sampling; end > days {
+			days = end
+		}
+	}
+	merged.Ticks = make([]APISurfaceTick, (days+merged.Sampling-1)/merged.Sampling)
+	for i, result := range results {
+		for j, tick := range result.Ticks {
+			// the merged tick which contains the first day of the tick
+			k := (j*result.Sampling + offsets[i]) / merged.Sampling
+			merged.Ticks[k].Added += tick.Added
+			merged.Ticks[k].Removed += tick.Removed
+			merged.Ticks[k].Changed += tick.Changed
+		}
+		// the number of the symbols at the end of each merged tick
+		for k := range merged.Ticks {
+			day := (k+1)*merged.Sampling - 1 - offsets[i]
+			if day < 0 || len(result.Ticks) == 0 {
+				continue
+			}
+			index := day / result.Sampling
+			if index >= len(result.Ticks) {
+				index = len(result.Ticks) - 1
+			}
+			merged.Ticks[k].Symbols += result.Ticks[index].Symbols
+		}
+		for _, change := range result.BreakingChanges {
+			change.Day += offsets[i]
+			merged.BreakingChanges = append(merged.BreakingChanges, change)
+		}
+	}
+	sort.SliceStable(merged.BreakingChanges, func(i, j int) bool {
+		return merged.BreakingChanges[i].Day < merged.BreakingChanges[j].Day
+	})
+	return merged
+}
+
+func (analyser *APISurfaceAnalysis) serializeText(result *APISurfaceResult, writer io.Writer) {
+	ticks := make([]string, len(result.Ticks))
+	for i, tick := range result.Ticks {
+		ticks[i] = fmt.Sprintf("[%d, %d, %d, %d]", tick.Symbols, tick.Added, tick.Removed,
+			tick.Changed)
+	}
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  # [symbols, added, removed, changed]")
+	fmt.Fprintf(writer, "  ticks: [%s]\n", strings.Join(ticks, ", "))
+	fmt.Fprintln(writer, "  breaking_changes:")
+	for _, change := range result.BreakingChanges {
+		fmt.Fprintf(writer, "    - hash: %s\n", change.Hash)
+		fmt.Fprintf(writer, "      day: %d\n", change.Day)
+		fmt.Fprintf(writer, "      file: %s\n", yaml.SafeString(change.File))
+		fmt.Fprintf(writer, "      symbol: %s\n", yaml.SafeString(change.Symbol))
+		fmt.Fprintf(writer, "      removed: %t\n", change.Removed)
+	}
+}
+
+func (analyser *APISurfaceAnalysis) serializeBinary(result *APISurfaceResult, writer io.Writer) error {
+	message := pb.APISurfaceAnalysisResults{
+		Sampling:        int32(result.Sampling),
+		Ticks:           make([]*pb.APISurfaceTick, len(result.Ticks)),
+		BreakingChanges: make([]*pb.APIBreakingChange, len(result.BreakingChanges)),
+	}
+	for i, tick := range result.Ticks {
+		message.Ticks[i] = &pb.APISurfaceTick{
+			Symbols: int32(tick.Symbols), Added: int32(tick.Added), Removed: int32(tick.Removed),
+			Changed: int32(tick.Changed)}
+	}
+	for i, change := range result.BreakingChanges {
+		message.BreakingChanges[i] = &pb.APIBreakingChange{
+			Hash: change.Hash, Day: int32(change.Day), File: change.File, Symbol: change.Symbol,
+			Removed: change.Removed}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&APISurfaceAnalysis{})
+}
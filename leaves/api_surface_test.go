@@ -0,0 +1,222 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+)
+
+func fixtureAPISurface() *APISurfaceAnalysis {
+	analyser := APISurfaceAnalysis{}
+	analyser.Configure(map[string]interface{}{ConfigAPISurfaceSampling: 10})
+	analyser.Initialize(nil)
+	return &analyser
+}
+
+func apiFunctionNode(name string, arguments int, children ...*uast.Node) *uast.Node {
+	node := &uast.Node{Roles: []uast.Role{uast.Function, uast.Declaration}, Children: []*uast.Node{
+		{Roles: []uast.Role{uast.Function, uast.Identifier, uast.Name}, Token: name}}}
+	params := &uast.Node{}
+	for i := 0; i < arguments; i++ {
+		params.Children = append(params.Children, &uast.Node{
+			Roles: []uast.Role{uast.Function, uast.Declaration, uast.Argument}})
+	}
+	node.Children = append(node.Children, params)
+	node.Children = append(node.Children, children...)
+	return node
+}
+
+func apiTypeNode(name string, children ...*uast.Node) *uast.Node {
+	return &uast.Node{Roles: []uast.Role{uast.Type, uast.Declaration}, Children: append(
+		[]*uast.Node{{Roles: []uast.Role{uast.Identifier}, Token: name}}, children...)}
+}
+
+func apiPublicNode() *uast.Node {
+	return &uast.Node{Roles: []uast.Role{uast.Visibility, uast.World}, Token: "public"}
+}
+
+func TestAPISurfaceMeta(t *testing.T) {
+	analyser := fixtureAPISurface()
+	assert.Equal(t, analyser.Name(), "APISurface")
+	assert.Len(t, analyser.Provides(), 0)
+	assert.Equal(t, analyser.Requires(), []string{
+		uast_items.DependencyUastChanges, items.DependencyDay})
+	assert.Equal(t, analyser.Features(), []string{uast_items.FeatureUast})
+	assert.Equal(t, analyser.Flag(), "api-surface")
+	assert.NotEmpty(t, analyser.Description())
+	opts := analyser.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Flag, "api-surface-sampling")
+	assert.Equal(t, analyser.Sampling, 10)
+	analyser = &APISurfaceAnalysis{}
+	analyser.Initialize(nil)
+	assert.Equal(t, analyser.Sampling, DefaultAPISurfaceSampling)
+	summoned := core.Registry.Summon(analyser.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "APISurface")
+}
+
+func TestAPISurfaceExportedSymbols(t *testing.T) {
+	body := &uast.Node{Roles: []uast.Role{uast.Function, uast.Body}, Children: []*uast.Node{
+		apiFunctionNode("Nested", 1)}}
+	root := &uast.Node{Children: []*uast.Node{
+		apiFunctionNode("Exported", 2, body),
+		apiFunctionNode("private", 0),
+		apiTypeNode("Type"),
+		apiTypeNode("hidden"),
+		{Roles: []uast.Role{uast.Function, uast.Declaration}},
+	}}
+	assert.Equal(t, exportedSymbols(root, "go"), map[string]int{"Exported": 2, "Type": -1})
+	root = &uast.Node{Children: []*uast.Node{
+		apiTypeNode("Public",
+			apiPublicNode(),
+			apiFunctionNode("method", 1, apiPublicNode()),
+			apiFunctionNode("helper", 0),
+			apiTypeNode("Inner", &uast.Node{Children: []*uast.Node{apiPublicNode()}})),
+		apiTypeNode("Package", apiFunctionNode("method", 0, apiPublicNode())),
+	}}
+	assert.Equal(t, exportedSymbols(root, "java"), map[string]int{
+		"Public": -1, "Public.method": 1, "Public.Inner": -1})
+	root = &uast.Node{Children: []*uast.Node{
+		apiTypeNode("Class", apiFunctionNode("__init__", 2), apiFunctionNode("_private", 1),
+			apiFunctionNode("run", 0)),
+		apiTypeNode("_Hidden", apiFunctionNode("run", 0)),
+		apiFunctionNode("main", 0),
+		apiFunctionNode("__", 0),
+	}}
+	assert.Equal(t, exportedSymbols(root, "python"), map[string]int{
+		"Class": -1, "Class.__init__": 2, "Class.run": 0, "main": 0})
+	assert.Len(t, exportedSymbols(root, "cobol"), 0)
+}
+
+func fixtureAPISurfaceResult(t *testing.T) APISurfaceResult {
+	analyser := fixtureAPISurface()
+	consume := func(hash string, day int, changes ...uast_items.Change) {
+		result, err := analyser.Consume(map[string]interface{}{
+			core.DependencyCommit:            &object.Commit{Hash: plumbing.NewHash(hash)},
+			core.DependencyIsMerge:           false,
+			uast_items.DependencyUastChanges: changes,
+			items.DependencyDay:              day,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	change := func(from, to string, symbols ...*uast.Node) uast_items.Change {
+		var after *uast.Node
+		if symbols != nil {
+			after = &uast.Node{Children: symbols}
+		}
+		return uast_items.Change{After: after, Change: &object.Change{
+			From: object.ChangeEntry{Name: from}, To: object.ChangeEntry{Name: to}}}
+	}
+	consume("1111111111111111111111111111111111111111", 0,
+		change("", "api.go", apiFunctionNode("Get", 1), apiFunctionNode("Put", 2),
+			apiFunctionNode("internal", 0)),
+		change("", "README.md", apiFunctionNode("Ignored", 0)))
+	consume("2222222222222222222222222222222222222222", 3,
+		change("", "lib.py", apiFunctionNode("load", 1)))
+	// nothing in tick 1
+	consume("3333333333333333333333333333333333333333", 25,
+		change("api.go", "api.go", apiFunctionNode("Get", 2), apiFunctionNode("List", 0)),
+		// could not be parsed
+		change("lib.py", "pkg/lib.py"))
+	consume("4444444444444444444444444444444444444444", 27, change("pkg/lib.py", ""))
+	return analyser.Finalize().(APISurfaceResult)
+}
+
+func TestAPISurfaceConsumeFinalize(t *testing.T) {
+	result := fixtureAPISurfaceResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Ticks, []APISurfaceTick{
+		{Symbols: 3, Added: 3}, {Symbols: 3}, {Symbols: 2, Added: 1, Removed: 2, Changed: 1}})
+	assert.Equal(t, result.BreakingChanges, []APIBreakingChange{
+		{Hash: "3333333333333333333333333333333333333333", Day: 25, File: "api.go", Symbol: "Get"},
+		{Hash: "3333333333333333333333333333333333333333", Day: 25, File: "api.go", Symbol: "Put",
+			Removed: true},
+		{Hash: "4444444444444444444444444444444444444444", Day: 27, File: "pkg/lib.py",
+			Symbol: "load", Removed: true},
+	})
+}
+
+func TestAPISurfaceConsumeMerge(t *testing.T) {
+	analyser := fixtureAPISurface()
+	result, err := analyser.Consume(map[string]interface{}{
+		core.DependencyCommit: &object.Commit{
+			ParentHashes: make([]plumbing.Hash, 2)},
+		core.DependencyIsMerge: true,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+	assert.Len(t, analyser.Finalize().(APISurfaceResult).Ticks, 0)
+}
+
+func TestAPISurfaceSerialize(t *testing.T) {
+	result := fixtureAPISurfaceResult(t)
+	analyser := fixtureAPISurface()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  # [symbols, added, removed, changed]
+  ticks: [[3, 3, 0, 0], [3, 0, 0, 0], [2, 1, 2, 1]]
+  breaking_changes:
+    - hash: 3333333333333333333333333333333333333333
+      day: 25
+      file: "api.go"
+      symbol: "Get"
+      removed: false
+    - hash: 3333333333333333333333333333333333333333
+      day: 25
+      file: "api.go"
+      symbol: "Put"
+      removed: true
+    - hash: 4444444444444444444444444444444444444444
+      day: 27
+      file: "pkg/lib.py"
+      symbol: "load"
+      removed: true
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, true, buffer))
+	msg := pb.APISurfaceAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Len(t, msg.Ticks, 3)
+	assert.Len(t, msg.BreakingChanges, 3)
+	deserialized, err := analyser.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestAPISurfaceMergeResults(t *testing.T) {
+	r1 := APISurfaceResult{
+		Ticks: []APISurfaceTick{{Symbols: 2, Added: 2}, {Symbols: 1, Removed: 1}},
+		BreakingChanges: []APIBreakingChange{
+			{Hash: "a", Day: 15, File: "a.go", Symbol: "A", Removed: true}},
+		Sampling: 10,
+	}
+	r2 := APISurfaceResult{
+		Ticks: []APISurfaceTick{{Symbols: 5, Added: 6, Changed: 1}},
+		BreakingChanges: []APIBreakingChange{
+			{Hash: "b", Day: 1, File: "b.go", Symbol: "B"}},
+		Sampling: 20,
+	}
+	analyser := fixtureAPISurface()
+	merged := analyser.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 5 * 24 * 3600}).(APISurfaceResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.Ticks, []APISurfaceTick{
+		{Symbols: 6, Added: 8, Removed: 1, Changed: 1}, {Symbols: 6}})
+	assert.Equal(t, merged.BreakingChanges, []APIBreakingChange{
+		{Hash: "b", Day: 6, File: "b.go", Symbol: "B"},
+		{Hash: "a", Day: 15, File: "a.go", Symbol: "A", Removed: true}})
+}
@@ -0,0 +1,390 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+// BranchLifetimeAnalysis measures the side branches at each merge commit: how many commits
+// they accumulated, how long they lived before the merge and how long the last commit waited
+// for the merge. The side branch of a merge is the commits which are reachable from
+// the merged parents but not from the first parent, like in `git rev-list P2 ^P1`.
+type BranchLifetimeAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the number of days in a tick.
+	Sampling int
+
+	// repository is the analysed repository.
+	repository *git.Repository
+	// merges are the side branches found so far.
+	merges []BranchMerge
+}
+
+// BranchMerge is a single merged side branch.
+type BranchMerge struct {
+	// Day is the day of the merge commit.
+	Day int
+	// Commits is the number of commits in the side branch.
+	Commits int
+	// Lifetime is the number of hours between the earliest authored commit of the side branch
+	// and the merge.
+	Lifetime int
+	// Latency is the number of hours between the last committed commit of the side branch
+	// and the merge.
+	Latency int
+}
+
+// BranchLifetimeTick is the distributions of the merged side branches in a tick.
+type BranchLifetimeTick struct {
+	// Commits are the numbers of commits in the side branches.
+	Commits []int
+	// Lifetimes are the lifetimes of the side branches in hours.
+	Lifetimes []int
+	// Latencies are the merge latencies of the side branches in hours.
+	Latencies []int
+}
+
+// BranchLifetimeResult is returned by BranchLifetimeAnalysis.Finalize().
+type BranchLifetimeResult struct {
+	// Merges are sorted by Day.
+	Merges []BranchMerge
+	// Sampling is the effective BranchLifetimeAnalysis.Sampling.
+	Sampling int
+}
+
+const (
+	// ConfigBranchLifetimeSampling is the name of the option to set
+	// BranchLifetimeAnalysis.Sampling.
+	ConfigBranchLifetimeSampling = "BranchLifetime.Sampling"
+	// DefaultBranchLifetimeSampling is the default value of BranchLifetimeAnalysis.Sampling.
+	DefaultBranchLifetimeSampling = 30
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (branches *BranchLifetimeAnalysis) Name() string {
+	return "BranchLifetime"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (branches *BranchLifetimeAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (branches *BranchLifetimeAnalysis) Requires() []string {
+	arr := [...]string{items.DependencyDay}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (branches *BranchLifetimeAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigBranchLifetimeSampling,
+		Description: "How frequently to record the distributions of the merged branches, in days.",
+		Flag:        "branch-lifetime-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultBranchLifetimeSampling},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (branches *BranchLifetimeAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigBranchLifetimeSampling].(int); exists {
+		branches.Sampling = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (branches *BranchLifetimeAnalysis) Flag() string {
+	return "branch-lifetime"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (branches *BranchLifetimeAnalysis) Description() string {
+	return "Measures how long the side branches live before they are merged, " +
+		"how long the merges wait and how many commits the branches accumulate."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (branches *BranchLifetimeAnalysis) Initialize(repository *git.Repository) {
+	if branches.Sampling <= 0 {
+		log.Printf("Warning: adjusted the branch lifetime sampling to %d days\n",
+			DefaultBranchLifetimeSampling)
+		branches.Sampling = DefaultBranchLifetimeSampling
+	}
+	branches.repository = repository
+	branches.merges = nil
+	branches.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (branches *BranchLifetimeAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !branches.ShouldConsumeCommit(deps) || !deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	side := branches.sideCommits(commit)
+	if len(side) == 0 {
+		return nil, nil
+	}
+	mergeTime := commit.Committer.When
+	first := side[0].Author.When
+	last := side[0].Committer.When
+	for _, c := range side[1:] {
+		if c.Author.When.Before(first) {
+			first = c.Author.When
+		}
+		if c.Committer.When.After(last) {
+			last = c.Committer.When
+		}
+	}
+	hours := func(begin int64) int {
+		if begin >= mergeTime.Unix() {
+			return 0
+		}
+		return int((mergeTime.Unix() - begin) / 3600)
+	}
+	branches.merges = append(branches.merges, BranchMerge{
+		Day:      deps[items.DependencyDay].(int),
+		Commits:  len(side),
+		Lifetime: hours(first.Unix()),
+		Latency:  hours(last.Unix()),
+	})
+	return nil, nil
+}
+
+// sideCommits returns the commits which are reachable from the second and the following parents
+// of the merge but not from the first parent. The commits are walked from the newest to the oldest
+// by the commit time and the walk stops when only the ancestors of the first parent are left.
+// The commits with skewed clocks may be misattributed, like in git. The missing objects are skipped.
+func (branches *BranchLifetimeAnalysis) sideCommits(merge *object.Commit) []*object.Commit {
+	const (
+		sideFlag = 1 << iota
+		mainFlag
+	)
+	if branches.repository == nil {
+		return nil
+	}
+	flags := map[plumbing.Hash]int{}
+	queued := map[plumbing.Hash]bool{}
+	var queue []*object.Commit
+	push := func(hash plumbing.Hash, flag int) {
+		if flags[hash]|flag == flags[hash] {
+			return
+		}
+		flags[hash] |= flag
+		if queued[hash] {
+			return
+		}
+		commit, err := branches.repository.CommitObject(hash)
+		if err != nil {
+			return
+		}
+		queued[hash] = true
+		// keep the queue sorted by the commit time in the ascending order
+		pos := sort.Search(len(queue), func(i int) bool {
+			return queue[i].Committer.When.After(commit.Committer.When)
+		})
+		queue = append(queue, nil)
+		copy(queue[pos+1:], queue[pos:])
+		queue[pos] = commit
+	}
+	for i, hash := range merge.ParentHashes {
+		if i == 0 {
+			push(hash, mainFlag)
+		} else {
+			push(hash, sideFlag)
+		}
+	}
+	var visited []*object.Commit
+	for {
+		interesting := false
+		for _, commit := range queue {
+			if flags[commit.Hash]&mainFlag == 0 {
+				interesting = true
+				break
+			}
+		}
+		if !interesting {
+			break
+		}
+		commit := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		delete(queued, commit.Hash)
+		if flags[commit.Hash] == sideFlag {
+			visited = append(visited, commit)
+		}
+		for _, parent := range commit.ParentHashes {
+			push(parent, flags[commit.Hash])
+		}
+	}
+	// the main flag could reach some visited commits later
+	var side []*object.Commit
+	for _, commit := range visited {
+		if flags[commit.Hash] == sideFlag {
+			side = append(side, commit)
+		}
+	}
+	return side
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (branches *BranchLifetimeAnalysis) Finalize() interface{} {
+	return BranchLifetimeResult{Merges: branches.merges, Sampling: branches.Sampling}
+}
+
+// Ticks groups the merged side branches by the ticks of Sampling days.
+func (result BranchLifetimeResult) Ticks() []BranchLifetimeTick {
+	var ticks []BranchLifetimeTick
+	for _, merge := range result.Merges {
+		index := merge.Day / result.Sampling
+		for len(ticks) <= index {
+			ticks = append(ticks, BranchLifetimeTick{})
+		}
+		tick := &ticks[index]
+		tick.Commits = append(tick.Commits, merge.Commits)
+		tick.Lifetimes = append(tick.Lifetimes, merge.Lifetime)
+		tick.Latencies = append(tick.Latencies, merge.Latency)
+	}
+	return ticks
+}
+
+// Fork clones this pipeline item.
+func (branches *BranchLifetimeAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(branches, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (branches *BranchLifetimeAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	branchesResult := result.(BranchLifetimeResult)
+	if binary {
+		return branches.serializeBinary(&branchesResult, writer)
+	}
+	branches.serializeText(&branchesResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (branches *BranchLifetimeAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(branches, result, &pb.BranchLifetimeAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (branches *BranchLifetimeAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(branches, result, &pb.BranchLifetimeAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to BranchLifetimeResult.
+func (branches *BranchLifetimeAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.BranchLifetimeAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := BranchLifetimeResult{Sampling: int(message.Sampling)}
+	if len(message.Merges) > 0 {
+		result.Merges = make([]BranchMerge, len(message.Merges))
+		for i, merge := range message.Merges {
+			result.Merges[i] = BranchMerge{
+				Day:      int(merge.Day),
+				Commits:  int(merge.Commits),
+				Lifetime: int(merge.Lifetime),
+				Latency:  int(merge.Latency),
+			}
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two BranchLifetimeResult-s together. The merges are joined
+// and the larger sampling is used.
+func (branches *BranchLifetimeAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	br1 := r1.(BranchLifetimeResult)
+	br2 := r2.(BranchLifetimeResult)
+	merged := BranchLifetimeResult{Sampling: br1.Sampling}
+	if br2.Sampling > merged.Sampling {
+		merged.Sampling = br2.Sampling
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	add := func(result *BranchLifetimeResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for _, merge := range result.Merges {
+			merge.Day += offset
+			merged.Merges = append(merged.Merges, merge)
+		}
+	}
+	add(&br1, c1)
+	add(&br2, c2)
+	sort.SliceStable(merged.Merges, func(i, j int) bool {
+		return merged.Merges[i].Day < merged.Merges[j].Day
+	})
+	return merged
+}
+
+func (branches *BranchLifetimeAnalysis) serializeText(result *BranchLifetimeResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  ticks:")
+	for _, tick := range result.Ticks() {
+		fmt.Fprintf(writer, "    - merges: %d\n", len(tick.Commits))
+		fmt.Fprint(writer, "      commits: [")
+		writeIntList(writer, tick.Commits)
+		fmt.Fprintln(writer, "]")
+		fmt.Fprint(writer, "      lifetimes: [")
+		writeIntList(writer, tick.Lifetimes)
+		fmt.Fprintln(writer, "]")
+		fmt.Fprint(writer, "      latencies: [")
+		writeIntList(writer, tick.Latencies)
+		fmt.Fprintln(writer, "]")
+	}
+}
+
+func (branches *BranchLifetimeAnalysis) serializeBinary(result *BranchLifetimeResult, writer io.Writer) error {
+	message := pb.BranchLifetimeAnalysisResults{
+		Merges:   make([]*pb.BranchMerge, len(result.Merges)),
+		Sampling: int32(result.Sampling),
+	}
+	for i, merge := range result.Merges {
+		message.Merges[i] = &pb.BranchMerge{
+			Day:      int32(merge.Day),
+			Commits:  int32(merge.Commits),
+			Lifetime: int32(merge.Lifetime),
+			Latency:  int32(merge.Latency),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&BranchLifetimeAnalysis{})
+}
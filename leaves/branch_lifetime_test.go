@@ -0,0 +1,191 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func fixtureBranchLifetime(repository *git.Repository) *BranchLifetimeAnalysis {
+	branches := BranchLifetimeAnalysis{}
+	branches.Configure(map[string]interface{}{ConfigBranchLifetimeSampling: 10})
+	branches.Initialize(repository)
+	return &branches
+}
+
+func TestBranchLifetimeMeta(t *testing.T) {
+	branches := fixtureBranchLifetime(nil)
+	assert.Equal(t, branches.Name(), "BranchLifetime")
+	assert.Len(t, branches.Provides(), 0)
+	assert.Equal(t, branches.Requires(), []string{items.DependencyDay})
+	assert.Equal(t, branches.Flag(), "branch-lifetime")
+	assert.NotEmpty(t, branches.Description())
+	opts := branches.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Flag, "branch-lifetime-sampling")
+	assert.Equal(t, branches.Sampling, 10)
+	branches = &BranchLifetimeAnalysis{}
+	branches.Initialize(nil)
+	assert.Equal(t, branches.Sampling, DefaultBranchLifetimeSampling)
+	summoned := core.Registry.Summon(branches.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "BranchLifetime")
+}
+
+// storeBranchLifetimeCommit writes a commit which was authored and committed at the specified
+// hours to the storage.
+func storeBranchLifetimeCommit(t *testing.T, storage *memory.Storage, message string,
+	authored, committed int, parents ...plumbing.Hash) *object.Commit {
+	when := func(hours int) object.Signature {
+		return object.Signature{
+			Name: "Alice", Email: "alice@example.com",
+			When: time.Unix(int64(hours)*3600, 0).UTC()}
+	}
+	commit := &object.Commit{
+		Author: when(authored), Committer: when(committed), Message: message,
+		ParentHashes: parents,
+	}
+	encoded := storage.NewEncodedObject()
+	assert.Nil(t, commit.Encode(encoded))
+	hash, err := storage.SetEncodedObject(encoded)
+	assert.Nil(t, err)
+	commit.Hash = hash
+	return commit
+}
+
+func fixtureBranchLifetimeResult(t *testing.T) BranchLifetimeResult {
+	storage := memory.NewStorage()
+	repository, err := git.Init(storage, nil)
+	assert.Nil(t, err)
+	store := func(message string, authored, committed int, parents ...*object.Commit) *object.Commit {
+		hashes := make([]plumbing.Hash, len(parents))
+		for i, parent := range parents {
+			hashes[i] = parent.Hash
+		}
+		return storeBranchLifetimeCommit(t, storage, message, authored, committed, hashes...)
+	}
+	root := store("root", 0, 0)
+	main1 := store("main1", 1, 1, root)
+	side1 := store("side1", 2, 2, root)
+	side2 := store("side2", 3, 5, side1)
+	merge1 := store("merge1", 10, 10, main1, side2)
+	main2 := store("main2", 20, 20, merge1)
+	// branched off before merge1
+	side3 := store("side3", 21, 21, main1)
+	merge2 := store("merge2", 30, 30, main2, side3)
+	// nothing to merge
+	merge3 := store("merge3", 40, 40, merge2, main2)
+
+	branches := fixtureBranchLifetime(repository)
+	consume := func(commit *object.Commit, day int) {
+		result, err := branches.Consume(map[string]interface{}{
+			core.DependencyCommit:  commit,
+			core.DependencyIsMerge: len(commit.ParentHashes) > 1,
+			items.DependencyDay:    day,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	for _, commit := range []*object.Commit{root, main1, side1, side2} {
+		consume(commit, 0)
+	}
+	consume(merge1, 0)
+	consume(main2, 1)
+	consume(side3, 1)
+	consume(merge2, 25)
+	consume(merge3, 26)
+	return branches.Finalize().(BranchLifetimeResult)
+}
+
+func TestBranchLifetimeConsumeFinalize(t *testing.T) {
+	result := fixtureBranchLifetimeResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Merges, []BranchMerge{
+		{Day: 0, Commits: 2, Lifetime: 8, Latency: 5},
+		{Day: 25, Commits: 1, Lifetime: 9, Latency: 9},
+	})
+	assert.Equal(t, result.Ticks(), []BranchLifetimeTick{
+		{Commits: []int{2}, Lifetimes: []int{8}, Latencies: []int{5}},
+		{},
+		{Commits: []int{1}, Lifetimes: []int{9}, Latencies: []int{9}},
+	})
+	// no repository
+	branches := fixtureBranchLifetime(nil)
+	result2, err := branches.Consume(map[string]interface{}{
+		core.DependencyCommit: &object.Commit{
+			Hash:         plumbing.NewHash("0123456789012345678901234567890123456789"),
+			ParentHashes: make([]plumbing.Hash, 2)},
+		core.DependencyIsMerge: true,
+		items.DependencyDay:    0,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, result2)
+	assert.Len(t, branches.Finalize().(BranchLifetimeResult).Merges, 0)
+}
+
+func TestBranchLifetimeSerialize(t *testing.T) {
+	result := fixtureBranchLifetimeResult(t)
+	branches := fixtureBranchLifetime(nil)
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, branches.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  ticks:
+    - merges: 1
+      commits: [2]
+      lifetimes: [8]
+      latencies: [5]
+    - merges: 0
+      commits: []
+      lifetimes: []
+      latencies: []
+    - merges: 1
+      commits: [1]
+      lifetimes: [9]
+      latencies: [9]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, branches.Serialize(result, true, buffer))
+	msg := pb.BranchLifetimeAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Len(t, msg.Merges, 2)
+	assert.Equal(t, msg.Merges[0].Lifetime, int32(8))
+	deserialized, err := branches.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestBranchLifetimeMergeResults(t *testing.T) {
+	r1 := BranchLifetimeResult{
+		Merges: []BranchMerge{
+			{Day: 5, Commits: 1, Lifetime: 2, Latency: 1},
+			{Day: 30, Commits: 3, Lifetime: 40, Latency: 4}},
+		Sampling: 10,
+	}
+	r2 := BranchLifetimeResult{
+		Merges:   []BranchMerge{{Day: 5, Commits: 2, Lifetime: 7, Latency: 0}},
+		Sampling: 20,
+	}
+	branches := fixtureBranchLifetime(nil)
+	merged := branches.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 10 * 24 * 3600}).(BranchLifetimeResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.Merges, []BranchMerge{
+		{Day: 5, Commits: 1, Lifetime: 2, Latency: 1},
+		{Day: 15, Commits: 2, Lifetime: 7, Latency: 0},
+		{Day: 30, Commits: 3, Lifetime: 40, Latency: 4}})
+	assert.Equal(t, merged.Ticks(), []BranchLifetimeTick{
+		{Commits: []int{1, 2}, Lifetimes: []int{2, 7}, Latencies: []int{1, 0}},
+		{Commits: []int{3}, Lifetimes: []int{40}, Latencies: []int{4}}})
+}
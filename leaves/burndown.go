@@ -6,6 +6,7 @@ import (
 	"io"
 	"log"
 	"sort"
+	"strings"
 	"sync"
 	"unicode/utf8"
 
@@ -35,43 +36,105 @@ type BurndownAnalysis struct {
 	// days between consecutive measurements. It may not be greater than Granularity. Try 15 or 30.
 	Sampling int
 
+	// MaxSamples limits the number of samples in the results. If the history is longer,
+	// the adjacent samples are merged so that the effective sampling becomes a multiple
+	// of Sampling; the bands are merged as well if the sampling exceeds Granularity.
+	// 0 disables the limit.
+	MaxSamples int
+
+	// CompactBands bounds the memory for very long histories: the bands which are older than
+	// this number of bands are merged into exponentially wider cohorts during the accumulation,
+	// and the lines of each cohort are spread uniformly over its bands in the results.
+	// 0 disables the compaction.
+	CompactBands int
+
 	// TrackFiles enables or disables the fine-grained per-file burndown analysis.
 	// It does not change the project level burndown results.
 	TrackFiles bool
 
+	// TrackTree enables recording the age and the owners of the lines of each file
+	// in the last analysed commit, see BurndownResult.FileSnapshots.
+	TrackTree bool
+
+	// DirectoryDepth enables the per-directory burndown analysis: the lines are grouped by
+	// the first DirectoryDepth components of the paths of their files. 0 disables it.
+	// It does not change the project level burndown results.
+	DirectoryDepth int
+
+	// TrackLanguages enables the per-language burndown analysis. The language of each file is
+	// detected by LanguagesDetection from its name and contents and re-evaluated on every change.
+	// It does not change the project level burndown results.
+	TrackLanguages bool
+
+	// TrackSurvival enables the survival statistics of the lines: the fraction of the lines
+	// added in each band which are still alive after some time and the median line lifetime.
+	// See BurndownResult.Survival.
+	TrackSurvival bool
+
 	// The number of developers for which to collect the burndown stats. 0 disables it.
 	PeopleNumber int
 
+	// HistoryBoundary defines how the lines which existed before the first analysed commit
+	// are attributed when the analysis starts in the middle of the history, e.g. in a shallow
+	// clone. See BurndownBoundaryCommit, BurndownBoundaryPreHistory and BurndownBoundaryBlame.
+	HistoryBoundary string
+
+	// IgnoreFormatting keeps the age and the owner of the lines which are changed by
+	// the formatting-only hunks as detected by FormattingDetector, so that reformatting
+	// the code does not make the lines new. If such a hunk changes the number of lines,
+	// only the surplus is inserted or deleted as usual.
+	IgnoreFormatting bool
+
 	// Debug activates the debugging mode. Analyse() runs slower in this mode
 	// but it accurately checks all the intermediate states for invariant
 	// violations.
 	Debug bool
 
+	// linesOnly disables all the histories so that only the lines of the files and their
+	// owners are maintained. It is used by OwnershipAnalysis.
+	linesOnly bool
+	// lineUpdater is the only updater of the files in the linesOnly mode, if set.
+	// It is used by StewardshipAnalysis.
+	lineUpdater burndown.Updater
+	// lineTime, if set, adjusts the time of the lines which replace the others outside of
+	// the merges; `deleted` are the times of the replaced line intervals.
+	// It is used by RewriteDepthAnalysis.
+	lineTime func(time int, deleted []int) int
+
 	// Repository points to the analysed Git repository struct from go-git.
 	repository *git.Repository
-	// globalHistory is the daily deltas of daily line counts.
-	// E.g. day 0: day 0 +50 lines
-	//      day 10: day 0 -10 lines; day 10 +20 lines
-	//      day 12: day 0 -5 lines; day 10 -3 lines; day 12 +10 lines
-	// map [0] [0] = 50
-	// map[10] [0] = -10
-	// map[10][10] = 20
-	// map[12] [0] = -5
-	// map[12][10] = -3
-	// map[12][12] = 10
-	globalHistory sparseHistory
-	// fileHistories is the daily deltas of each file's daily line counts.
-	fileHistories map[string]sparseHistory
-	// peopleHistories is the daily deltas of each person's daily line counts.
-	peopleHistories []sparseHistory
+	// globalHistory is the deltas of the line counts in each sample and band.
+	globalHistory *sparseHistory
+	// fileHistories is the deltas of each file's line counts.
+	fileHistories map[string]*sparseHistory
+	// directoryHistories is the deltas of each directory's line counts.
+	directoryHistories map[string]*sparseHistory
+	// languageHistories is the deltas of each language's line counts.
+	languageHistories map[string]*sparseHistory
+	// fileLanguages is the mapping <file path> -> language, only if TrackLanguages is set.
+	fileLanguages map[string]string
+	// formattingHunks are the formatting-only flags of the diff hunks of the files changed in
+	// the current commit, only if IgnoreFormatting is set.
+	formattingHunks map[string][]bool
+	// languages are the languages of the files changed in the current commit as detected by
+	// LanguagesDetection, only if TrackLanguages is set.
+	languages map[string]string
+	// peopleHistories is the deltas of each person's line counts.
+	peopleHistories []*sparseHistory
 	// files is the mapping <file path> -> *File.
 	files map[string]*burndown.File
 	// mergedFiles is used during merges to record the real file hashes
 	mergedFiles map[string]bool
 	// mergedAuthor of the processed merge commit
 	mergedAuthor int
+	// coAuthors of the processed commit share its inserted lines with the author,
+	// see identity.DependencyCoAuthors.
+	coAuthors []int
 	// renames is a quick and dirty solution for the "future branch renames" problem.
 	renames map[string]string
+	// cohorts is the layout of the compacted bands shared by all the histories,
+	// nil unless CompactBands is set.
+	cohorts *bandCohorts
 	// matrix is the mutual deletions and self insertions.
 	matrix []map[int]int64
 	// day is the most recent day index processed.
@@ -81,6 +144,12 @@ type BurndownAnalysis struct {
 	previousDay int
 	// references IdentityDetector.ReversedPeopleDict
 	reversedPeopleDict []string
+	// references IdentityDetector.PeopleDict
+	peopleDict map[string]int
+	// started indicates whether the first commit was consumed.
+	started bool
+	// boundary is the first analysed commit if it is not a root and its files are being inserted.
+	boundary *object.Commit
 }
 
 // BurndownResult carries the result of running BurndownAnalysis - it is returned by
@@ -93,14 +162,33 @@ type BurndownResult struct {
 	// The key is the path inside the Git repository. The value's dimensions are the same as
 	// in GlobalHistory.
 	FileHistories map[string]DenseHistory
+	// The key is the directory path which consists of at most BurndownAnalysis.DirectoryDepth
+	// components, "." for the files in the root. The value's dimensions are the same as
+	// in GlobalHistory.
+	DirectoryHistories map[string]DenseHistory
+	// The key is the programming language detected by enry, "Other" for the unrecognized files.
+	// The value's dimensions are the same as in GlobalHistory.
+	LanguageHistories map[string]DenseHistory
+	// Survival is calculated from GlobalHistory. nil unless BurndownAnalysis.TrackSurvival is set.
+	Survival *BurndownSurvival
 	// [number of people][number of samples][number of bands]
 	PeopleHistories []DenseHistory
+	// [number of samples] The number of lines which existed before the first analysed commit
+	// if BurndownAnalysis.HistoryBoundary is not BurndownBoundaryCommit. They are not included
+	// in GlobalHistory. nil if there were no such lines.
+	PreHistory []int64
+	// [number of people][number of samples] The same as PreHistory for the lines of each
+	// person. The elements are nil for the people without such lines.
+	PeoplePreHistories [][]int64
 	// [number of people][number of people + 2]
 	// The first element is the total number of lines added by the author.
 	// The second element is the number of removals by unidentified authors (outside reversedPeopleDict).
 	// The rest of the elements are equal the number of line removals by the corresponding
 	// authors in reversedPeopleDict: 2 -> 0, 3 -> 1, etc.
 	PeopleMatrix DenseHistory
+	// The key is the path inside the Git repository. Only the files which exist in the last
+	// analysed commit are included. Empty unless BurndownAnalysis.TrackTree is set.
+	FileSnapshots map[string]BurndownFileSnapshot
 
 	// The following members are private.
 
@@ -119,21 +207,58 @@ const (
 	ConfigBurndownGranularity = "Burndown.Granularity"
 	// ConfigBurndownSampling is the name of the option to set BurndownAnalysis.Sampling.
 	ConfigBurndownSampling = "Burndown.Sampling"
+	// ConfigBurndownMaxSamples is the name of the option to set BurndownAnalysis.MaxSamples.
+	ConfigBurndownMaxSamples = "Burndown.MaxSamples"
+	// ConfigBurndownCompactBands is the name of the option to set BurndownAnalysis.CompactBands.
+	ConfigBurndownCompactBands = "Burndown.CompactBands"
 	// ConfigBurndownTrackFiles enables burndown collection for files.
 	ConfigBurndownTrackFiles = "Burndown.TrackFiles"
 	// ConfigBurndownTrackPeople enables burndown collection for authors.
 	ConfigBurndownTrackPeople = "Burndown.TrackPeople"
+	// ConfigBurndownTrackTree enables recording the line ages and owners of each file.
+	ConfigBurndownTrackTree = "Burndown.TrackTree"
+	// ConfigBurndownDirectoryDepth enables burndown collection for directories
+	// and sets BurndownAnalysis.DirectoryDepth.
+	ConfigBurndownDirectoryDepth = "Burndown.DirectoryDepth"
+	// ConfigBurndownTrackLanguages enables burndown collection for programming languages.
+	ConfigBurndownTrackLanguages = "Burndown.TrackLanguages"
+	// ConfigBurndownTrackSurvival enables the survival statistics of the lines.
+	ConfigBurndownTrackSurvival = "Burndown.TrackSurvival"
 	// ConfigBurndownDebug enables some extra debug assertions.
 	ConfigBurndownDebug = "Burndown.Debug"
+	// ConfigBurndownHistoryBoundary sets BurndownAnalysis.HistoryBoundary.
+	ConfigBurndownHistoryBoundary = "Burndown.HistoryBoundary"
+	// ConfigBurndownIgnoreFormatting sets BurndownAnalysis.IgnoreFormatting.
+	ConfigBurndownIgnoreFormatting = "Burndown.IgnoreFormatting"
+	// BurndownBoundaryCommit attributes the lines which existed before the first analysed commit
+	// to the author of that commit.
+	BurndownBoundaryCommit = "commit"
+	// BurndownBoundaryPreHistory attributes the lines which existed before the first analysed
+	// commit to the synthetic "pre-history" author which is excluded from the people stats.
+	BurndownBoundaryPreHistory = "pre-history"
+	// BurndownBoundaryBlame attributes the lines which existed before the first analysed commit
+	// to their actual authors according to `git blame`.
+	BurndownBoundaryBlame = "blame"
 	// DefaultBurndownGranularity is the default number of days for BurndownAnalysis.Granularity
 	// and BurndownAnalysis.Sampling.
 	DefaultBurndownGranularity = 30
 	// authorSelf is the internal author index which is used in BurndownAnalysis.Finalize() to
 	// format the author overwrites matrix.
 	authorSelf = (1 << (32 - burndown.TreeMaxBinPower)) - 2
+	// preHistoryDay is the internal day of the lines which existed before the first analysed
+	// commit, see BurndownAnalysis.HistoryBoundary. They are counted apart from the bands.
+	preHistoryDay = burndown.TreeMergeMark - 1
 )
 
-type sparseHistory = map[int]map[int]int64
+// BurndownFileSnapshot is the state of the lines of a file in the last analysed commit.
+type BurndownFileSnapshot struct {
+	// Ages maps the age of the lines in days to the number of lines.
+	Ages map[int]int64
+	// Owners maps the developer index to the number of lines; -1 means an unidentified
+	// developer. It is empty unless the people are tracked.
+	Owners map[int]int64
+}
+
 
 // DenseHistory is the matrix [number of samples][number of bands] -> number of lines.
 type DenseHistory = [][]int64
@@ -154,10 +279,16 @@ func (analyser *BurndownAnalysis) Provides() []string {
 // Each requested entity will be inserted into `deps` of Consume(). In turn, those
 // entities are Provides() upstream.
 func (analyser *BurndownAnalysis) Requires() []string {
-	arr := [...]string{
+	arr := []string{
 		items.DependencyFileDiff, items.DependencyTreeChanges, items.DependencyBlobCache,
-		items.DependencyDay, identity.DependencyAuthor}
-	return arr[:]
+		items.DependencyDay, identity.DependencyAuthor, identity.DependencyCoAuthors}
+	if analyser.TrackLanguages {
+		arr = append(arr, items.DependencyLanguages)
+	}
+	if analyser.IgnoreFormatting {
+		arr = append(arr, items.DependencyFormattingHunks)
+	}
+	return arr
 }
 
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
@@ -173,6 +304,18 @@ func (analyser *BurndownAnalysis) ListConfigurationOptions() []core.Configuratio
 		Flag:        "sampling",
 		Type:        core.IntConfigurationOption,
 		Default:     DefaultBurndownGranularity}, {
+		Name: ConfigBurndownMaxSamples,
+		Description: "Merge the adjacent samples if there are more than this number of them. " +
+			"0 means no limit.",
+		Flag:    "burndown-max-samples",
+		Type:    core.IntConfigurationOption,
+		Default: 0}, {
+		Name: ConfigBurndownCompactBands,
+		Description: "Merge the bands older than this number of bands into exponentially wider " +
+			"cohorts to bound the memory on long histories. 0 disables the compaction.",
+		Flag:    "burndown-compact-bands",
+		Type:    core.IntConfigurationOption,
+		Default: 0}, {
 		Name:        ConfigBurndownTrackFiles,
 		Description: "Record detailed statistics per each file.",
 		Flag:        "burndown-files",
@@ -183,30 +326,86 @@ func (analyser *BurndownAnalysis) ListConfigurationOptions() []core.Configuratio
 		Flag:        "burndown-people",
 		Type:        core.BoolConfigurationOption,
 		Default:     false}, {
+		Name: ConfigBurndownTrackTree,
+		Description: "Record the age and the owners of the lines of each file in the last commit. " +
+			"\"hercules tree-snapshot\" converts them to a directory tree.",
+		Flag:    "burndown-tree",
+		Type:    core.BoolConfigurationOption,
+		Default: false}, {
+		Name: ConfigBurndownDirectoryDepth,
+		Description: "Record detailed statistics per each directory with at most this number " +
+			"of path components; --burndown-dirs without a value means the top-level directories.",
+		Flag:    "burndown-dirs",
+		Type:    core.IntConfigurationOption,
+		Default: 0,
+		NoValue: "1"}, {
+		Name:        ConfigBurndownTrackLanguages,
+		Description: "Record detailed statistics per each programming language.",
+		Flag:        "burndown-languages",
+		Type:        core.BoolConfigurationOption,
+		Default:     false}, {
+		Name: ConfigBurndownTrackSurvival,
+		Description: "Calculate the survival curves of the lines added in each band " +
+			"and the median line lifetime.",
+		Flag:    "burndown-survival",
+		Type:    core.BoolConfigurationOption,
+		Default: false}, {
 		Name:        ConfigBurndownDebug,
 		Description: "Validate the trees on each step.",
 		Flag:        "burndown-debug",
 		Type:        core.BoolConfigurationOption,
-		Default:     false},
+		Default:     false}, {
+		Name: ConfigBurndownHistoryBoundary,
+		Description: "How to attribute the lines which existed before the first analysed commit " +
+			"if it is not the root, e.g. in a shallow clone: \"" + BurndownBoundaryCommit +
+			"\" - to its author, \"" + BurndownBoundaryPreHistory + "\" - to nobody, \"" +
+			BurndownBoundaryBlame + "\" - to the actual authors using git blame.",
+		Flag:    "burndown-boundary",
+		Type:    core.StringConfigurationOption,
+		Default: BurndownBoundaryCommit}, {
+		Name: ConfigBurndownIgnoreFormatting,
+		Description: "Keep the age and the owner of the lines which are changed only in " +
+			"the whitespace or, if the UAST feature is enabled, only in the formatting. " +
+			"Also applies to --ownership and --stewardship.",
+		Flag:    "burndown-ignore-formatting",
+		Type:    core.BoolConfigurationOption,
+		Default: false},
 	}
 	return options[:]
 }
 
 // Configure sets the properties previously published by ListConfigurationOptions().
 func (analyser *BurndownAnalysis) Configure(facts map[string]interface{}) {
+	analyser.ConfigureRequirements(facts)
 	if val, exists := facts[ConfigBurndownGranularity].(int); exists {
 		analyser.Granularity = val
 	}
 	if val, exists := facts[ConfigBurndownSampling].(int); exists {
 		analyser.Sampling = val
 	}
+	if val, exists := facts[ConfigBurndownMaxSamples].(int); exists {
+		analyser.MaxSamples = val
+	}
+	if val, exists := facts[ConfigBurndownCompactBands].(int); exists {
+		analyser.CompactBands = val
+	}
 	if val, exists := facts[ConfigBurndownTrackFiles].(bool); exists {
 		analyser.TrackFiles = val
 	}
+	if val, exists := facts[ConfigBurndownTrackTree].(bool); exists {
+		analyser.TrackTree = val
+	}
+	if val, exists := facts[ConfigBurndownDirectoryDepth].(int); exists {
+		analyser.DirectoryDepth = val
+	}
+	if val, exists := facts[ConfigBurndownTrackSurvival].(bool); exists {
+		analyser.TrackSurvival = val
+	}
 	if people, exists := facts[ConfigBurndownTrackPeople].(bool); people {
 		if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
 			analyser.PeopleNumber = val
 			analyser.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+			analyser.peopleDict, _ = facts[identity.FactIdentityDetectorPeopleDict].(map[string]int)
 		}
 	} else if exists {
 		analyser.PeopleNumber = 0
@@ -214,6 +413,21 @@ func (analyser *BurndownAnalysis) Configure(facts map[string]interface{}) {
 	if val, exists := facts[ConfigBurndownDebug].(bool); exists {
 		analyser.Debug = val
 	}
+	if val, exists := facts[ConfigBurndownHistoryBoundary].(string); exists {
+		analyser.HistoryBoundary = val
+	}
+}
+
+// ConfigureRequirements sets TrackLanguages and IgnoreFormatting which change the result of
+// Requires(): LanguagesDetection and FormattingDetector read every changed blob, so they are
+// deployed only if needed. It is a part of core.DynamicPipelineItem.
+func (analyser *BurndownAnalysis) ConfigureRequirements(facts map[string]interface{}) {
+	if val, exists := facts[ConfigBurndownTrackLanguages].(bool); exists {
+		analyser.TrackLanguages = val
+	}
+	if val, exists := facts[ConfigBurndownIgnoreFormatting].(bool); exists {
+		analyser.IgnoreFormatting = val
+	}
 }
 
 // Flag for the command line switch which enables this analysis.
@@ -245,10 +459,38 @@ func (analyser *BurndownAnalysis) Initialize(repository *git.Repository) {
 			analyser.Granularity)
 		analyser.Sampling = analyser.Granularity
 	}
+	if analyser.MaxSamples < 0 {
+		log.Printf("Warning: adjusted the maximum number of samples to 0 (no limit)\n")
+		analyser.MaxSamples = 0
+	}
+	if analyser.DirectoryDepth < 0 {
+		log.Printf("Warning: adjusted the directory depth to 0 (disabled)\n")
+		analyser.DirectoryDepth = 0
+	}
+	if analyser.CompactBands < 0 {
+		log.Printf("Warning: adjusted the band compaction to 0 (disabled)\n")
+		analyser.CompactBands = 0
+	}
+	switch analyser.HistoryBoundary {
+	case BurndownBoundaryCommit, BurndownBoundaryPreHistory, BurndownBoundaryBlame:
+	default:
+		if analyser.HistoryBoundary != "" {
+			log.Printf("Warning: unknown history boundary \"%s\", adjusted to \"%s\"\n",
+				analyser.HistoryBoundary, BurndownBoundaryCommit)
+		}
+		analyser.HistoryBoundary = BurndownBoundaryCommit
+	}
 	analyser.repository = repository
-	analyser.globalHistory = sparseHistory{}
-	analyser.fileHistories = map[string]sparseHistory{}
-	analyser.peopleHistories = make([]sparseHistory, analyser.PeopleNumber)
+	analyser.cohorts = nil
+	if analyser.CompactBands > 0 {
+		analyser.cohorts = newBandCohorts(analyser.CompactBands)
+	}
+	analyser.globalHistory = analyser.newHistory()
+	analyser.fileHistories = map[string]*sparseHistory{}
+	analyser.directoryHistories = map[string]*sparseHistory{}
+	analyser.languageHistories = map[string]*sparseHistory{}
+	analyser.fileLanguages = map[string]string{}
+	analyser.peopleHistories = make([]*sparseHistory, analyser.PeopleNumber)
 	analyser.files = map[string]*burndown.File{}
 	analyser.mergedFiles = map[string]bool{}
 	analyser.mergedAuthor = identity.AuthorMissing
@@ -256,6 +498,8 @@ func (analyser *BurndownAnalysis) Initialize(repository *git.Repository) {
 	analyser.matrix = make([]map[int]int64, analyser.PeopleNumber)
 	analyser.day = 0
 	analyser.previousDay = 0
+	analyser.started = false
+	analyser.boundary = nil
 }
 
 // Consume runs this PipelineItem on the next commit's data.
@@ -266,9 +510,13 @@ func (analyser *BurndownAnalysis) Initialize(repository *git.Repository) {
 func (analyser *BurndownAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
 	author := deps[identity.DependencyAuthor].(int)
 	day := deps[items.DependencyDay].(int)
+	analyser.coAuthors = nil
 	if !deps[core.DependencyIsMerge].(bool) {
 		analyser.day = day
 		analyser.onNewDay()
+		if analyser.PeopleNumber > 0 {
+			analyser.coAuthors, _ = deps[identity.DependencyCoAuthors].([]int)
+		}
 	} else {
 		// effectively disables the status updates if the commit is a merge
 		// we will analyse the conflicts resolution in Merge()
@@ -276,9 +524,25 @@ func (analyser *BurndownAnalysis) Consume(deps map[string]interface{}) (map[stri
 		analyser.mergedFiles = map[string]bool{}
 		analyser.mergedAuthor = author
 	}
+	if !analyser.started {
+		analyser.started = true
+		commit := deps[core.DependencyCommit].(*object.Commit)
+		if commit.NumParents() > 0 && analyser.HistoryBoundary != BurndownBoundaryCommit &&
+			!deps[core.DependencyIsMerge].(bool) {
+			// the history is cut, all the files are inserted in this commit
+			analyser.boundary = commit
+		}
+	}
 	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
 	treeDiffs := deps[items.DependencyTreeChanges].(object.Changes)
 	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	if analyser.TrackLanguages {
+		analyser.languages = deps[items.DependencyLanguages].(map[string]string)
+	}
+	if analyser.IgnoreFormatting {
+		analyser.formattingHunks, _ = deps[items.DependencyFormattingHunks].(map[string][]bool)
+	}
+	defer func() { analyser.boundary = nil }()
 	for _, change := range treeDiffs {
 		action, _ := change.Action()
 		var err error
@@ -309,6 +573,10 @@ func (analyser *BurndownAnalysis) Fork(n int) []core.PipelineItem {
 		for key, file := range analyser.files {
 			clone.files[key] = file.Clone(false)
 		}
+		clone.fileLanguages = map[string]string{}
+		for key, lang := range analyser.fileLanguages {
+			clone.fileLanguages[key] = lang
+		}
 		result[i] = &clone
 	}
 	return result
@@ -335,14 +603,20 @@ func (analyser *BurndownAnalysis) Merge(branches []core.PipelineItem) {
 		if !val {
 			for _, burn := range all {
 				delete(burn.files, key)
+				delete(burn.fileLanguages, key)
 			}
 			continue
 		}
 		files := make([]*burndown.File, 0, len(all))
+		var language string
 		for _, burn := range all {
 			file := burn.files[key]
 			if file != nil {
 				// file can be nil if it is considered binary in this branch
+				if len(files) == 0 {
+					// the updaters of the first file are inherited by the rest
+					language = burn.fileLanguages[key]
+				}
 				files = append(files, file)
 			}
 		}
@@ -358,6 +632,9 @@ func (analyser *BurndownAnalysis) Merge(branches []core.PipelineItem) {
 			if burn.files[key] != files[0] {
 				burn.files[key] = files[0].Clone(false)
 			}
+			if analyser.TrackLanguages {
+				burn.fileLanguages[key] = language
+			}
 		}
 	}
 	analyser.onNewDay()
@@ -365,16 +642,42 @@ func (analyser *BurndownAnalysis) Merge(branches []core.PipelineItem) {
 
 // Finalize returns the result of the analysis. Further Consume() calls are not expected.
 func (analyser *BurndownAnalysis) Finalize() interface{} {
-	globalHistory, lastDay := analyser.groupSparseHistory(analyser.globalHistory, -1)
+	globalHistory, lastDay := analyser.globalHistory.dense(-1)
 	fileHistories := map[string]DenseHistory{}
 	for key, history := range analyser.fileHistories {
-		fileHistories[key], _ = analyser.groupSparseHistory(history, lastDay)
+		fileHistories[key], _ = history.dense(lastDay)
+	}
+	var directoryHistories map[string]DenseHistory
+	if analyser.DirectoryDepth > 0 {
+		directoryHistories = map[string]DenseHistory{}
+		for key, history := range analyser.directoryHistories {
+			if !history.empty() {
+				directoryHistories[key], _ = history.dense(lastDay)
+			}
+		}
 	}
+	var languageHistories map[string]DenseHistory
+	if analyser.TrackLanguages {
+		languageHistories = map[string]DenseHistory{}
+		for key, history := range analyser.languageHistories {
+			if !history.empty() {
+				languageHistories[key], _ = history.dense(lastDay)
+			}
+		}
+	}
+	preHistory := analyser.globalHistory.densePreHistory(len(globalHistory))
+	var peoplePreHistories [][]int64
 	peopleHistories := make([]DenseHistory, analyser.PeopleNumber)
 	for i, history := range analyser.peopleHistories {
-		if len(history) > 0 {
+		if history != nil && !history.empty() {
 			// there can be people with only trivial merge commits and without own lines
-			peopleHistories[i], _ = analyser.groupSparseHistory(history, lastDay)
+			peopleHistories[i], _ = history.dense(lastDay)
+			if personPreHistory := history.densePreHistory(len(globalHistory)); personPreHistory != nil {
+				if peoplePreHistories == nil {
+					peoplePreHistories = make([][]int64, analyser.PeopleNumber)
+				}
+				peoplePreHistories[i] = personPreHistory
+			}
 		} else {
 			peopleHistories[i] = make(DenseHistory, len(globalHistory))
 			for j, gh := range globalHistory {
@@ -395,17 +698,119 @@ func (analyser *BurndownAnalysis) Finalize() interface{} {
 			mrow[key+2] = val
 		}
 	}
+	var fileSnapshots map[string]BurndownFileSnapshot
+	if analyser.TrackTree {
+		fileSnapshots = analyser.snapshotFiles()
+	}
+	sampling, granularity := analyser.Sampling, analyser.Granularity
+	if analyser.MaxSamples > 0 && len(globalHistory) > analyser.MaxSamples {
+		samplesFactor := (len(globalHistory) + analyser.MaxSamples - 1) / analyser.MaxSamples
+		sampling *= samplesFactor
+		bandsFactor := 1
+		if sampling > granularity {
+			// granularity may not be less than sampling
+			bandsFactor = (sampling + granularity - 1) / granularity
+			granularity *= bandsFactor
+		}
+		globalHistory = downsampleHistory(globalHistory, samplesFactor, bandsFactor)
+		for key, history := range fileHistories {
+			fileHistories[key] = downsampleHistory(history, samplesFactor, bandsFactor)
+		}
+		for key, history := range directoryHistories {
+			directoryHistories[key] = downsampleHistory(history, samplesFactor, bandsFactor)
+		}
+		for key, history := range languageHistories {
+			languageHistories[key] = downsampleHistory(history, samplesFactor, bandsFactor)
+		}
+		for i, history := range peopleHistories {
+			peopleHistories[i] = downsampleHistory(history, samplesFactor, bandsFactor)
+		}
+		preHistory = downsamplePreHistory(preHistory, samplesFactor)
+		for i, history := range peoplePreHistories {
+			peoplePreHistories[i] = downsamplePreHistory(history, samplesFactor)
+		}
+	}
+	var survival *BurndownSurvival
+	if analyser.TrackSurvival {
+		survival = newBurndownSurvival(globalHistory, sampling, granularity)
+	}
 	return BurndownResult{
 		GlobalHistory:      globalHistory,
 		FileHistories:      fileHistories,
+		DirectoryHistories: directoryHistories,
+		LanguageHistories:  languageHistories,
+		Survival:           survival,
 		PeopleHistories:    peopleHistories,
+		PreHistory:         preHistory,
+		PeoplePreHistories: peoplePreHistories,
 		PeopleMatrix:       peopleMatrix,
+		FileSnapshots:      fileSnapshots,
 		reversedPeopleDict: analyser.reversedPeopleDict,
-		sampling:           analyser.Sampling,
-		granularity:        analyser.Granularity,
+		sampling:           sampling,
+		granularity:        granularity,
 	}
 }
 
+// downsampleHistory merges each `samples` adjacent rows of the burndown matrix into one and
+// each `bands` adjacent columns into one. Since every row is the snapshot at the end of its
+// sampling period, the merged row is the last row of the group; the merged columns are summed.
+func downsampleHistory(history DenseHistory, samples, bands int) DenseHistory {
+	result := make(DenseHistory, (len(history)+samples-1)/samples)
+	for i := range result {
+		last := (i+1)*samples - 1
+		if last >= len(history) {
+			last = len(history) - 1
+		}
+		row := history[last]
+		merged := make([]int64, (len(row)+bands-1)/bands)
+		for j, value := range row {
+			merged[j/bands] += value
+		}
+		result[i] = merged
+	}
+	return result
+}
+
+// downsamplePreHistory takes the last element of each `samples` adjacent elements.
+func downsamplePreHistory(history []int64, samples int) []int64 {
+	if history == nil {
+		return nil
+	}
+	result := make([]int64, (len(history)+samples-1)/samples)
+	for i := range result {
+		last := (i+1)*samples - 1
+		if last >= len(history) {
+			last = len(history) - 1
+		}
+		result[i] = history[last]
+	}
+	return result
+}
+
+// snapshotFiles collects the line ages relative to the last day and the line owners of each file.
+func (analyser *BurndownAnalysis) snapshotFiles() map[string]BurndownFileSnapshot {
+	snapshots := map[string]BurndownFileSnapshot{}
+	for name, file := range analyser.files {
+		snapshot := BurndownFileSnapshot{Ages: map[int]int64{}, Owners: map[int]int64{}}
+		file.ForEach(func(line, length, value int) {
+			author, day := analyser.unpackPersonWithDay(value)
+			if day == preHistoryDay {
+				// the lines are at least as old as the first analysed commit
+				day = 0
+			}
+			snapshot.Ages[analyser.day-day] += int64(length)
+			if analyser.PeopleNumber > 0 {
+				if author == identity.AuthorMissing {
+					author = -1
+				}
+				snapshot.Owners[author] += int64(length)
+			}
+		})
+		snapshots[name] = snapshot
+	}
+	return snapshots
+}
+
 // Serialize converts the analysis result as returned by Finalize() to text or bytes.
 // The text format is YAML and the bytes format is Protocol Buffers.
 func (analyser *BurndownAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
@@ -417,6 +822,16 @@ func (analyser *BurndownAnalysis) Serialize(result interface{}, binary bool, wri
 	return nil
 }
 
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (analyser *BurndownAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(analyser, result, &pb.BurndownAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (analyser *BurndownAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(analyser, result, &pb.BurndownAnalysisResults{})
+}
+
 // Deserialize converts the specified protobuf bytes to BurndownResult.
 func (analyser *BurndownAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
 	msg := pb.BurndownAnalysisResults{}
@@ -436,15 +851,39 @@ func (analyser *BurndownAnalysis) Deserialize(pbmessage []byte) (interface{}, er
 		return res
 	}
 	result.GlobalHistory = convertCSR(msg.Project)
+	if len(msg.Project.PreHistory) > 0 {
+		result.PreHistory = msg.Project.PreHistory
+	}
 	result.FileHistories = map[string]DenseHistory{}
 	for _, mat := range msg.Files {
 		result.FileHistories[mat.Name] = convertCSR(mat)
 	}
+	if len(msg.Directories) > 0 {
+		result.DirectoryHistories = map[string]DenseHistory{}
+	}
+	for _, mat := range msg.Directories {
+		result.DirectoryHistories[mat.Name] = convertCSR(mat)
+	}
+	if len(msg.Languages) > 0 {
+		result.LanguageHistories = map[string]DenseHistory{}
+	}
+	for _, mat := range msg.Languages {
+		result.LanguageHistories[mat.Name] = convertCSR(mat)
+	}
+	if msg.Survival != nil {
+		result.Survival = burndownSurvivalFromPB(msg.Survival)
+	}
 	result.reversedPeopleDict = make([]string, len(msg.People))
 	result.PeopleHistories = make([]DenseHistory, len(msg.People))
 	for i, mat := range msg.People {
 		result.PeopleHistories[i] = convertCSR(mat)
 		result.reversedPeopleDict[i] = mat.Name
+		if len(mat.PreHistory) > 0 {
+			if result.PeoplePreHistories == nil {
+				result.PeoplePreHistories = make([][]int64, len(msg.People))
+			}
+			result.PeoplePreHistories[i] = mat.PreHistory
+		}
 	}
 	if msg.PeopleInteraction != nil {
 		result.PeopleMatrix = make(DenseHistory, msg.PeopleInteraction.NumberOfRows)
@@ -455,6 +894,19 @@ func (analyser *BurndownAnalysis) Deserialize(pbmessage []byte) (interface{}, er
 			result.PeopleMatrix[i][msg.PeopleInteraction.Indices[j]] = msg.PeopleInteraction.Data[j]
 		}
 	}
+	if len(msg.Snapshots) > 0 {
+		result.FileSnapshots = map[string]BurndownFileSnapshot{}
+	}
+	for _, snapshot := range msg.Snapshots {
+		fs := BurndownFileSnapshot{Ages: map[int]int64{}, Owners: map[int]int64{}}
+		for age, lines := range snapshot.Ages {
+			fs.Ages[int(age)] = lines
+		}
+		for dev, lines := range snapshot.Owners {
+			fs.Owners[int(dev)] = lines
+		}
+		result.FileSnapshots[snapshot.Name] = fs
+	}
 	result.sampling = int(msg.Sampling)
 	result.granularity = int(msg.Granularity)
 	return result, nil
@@ -491,32 +943,65 @@ func (analyser *BurndownAnalysis) MergeResults(
 				c1, c2)
 		}()
 	}
-	if len(bar1.FileHistories) > 0 || len(bar2.FileHistories) > 0 {
-		merged.FileHistories = map[string]DenseHistory{}
+	if len(bar1.PreHistory) > 0 || len(bar2.PreHistory) > 0 {
+		merged.PreHistory = mergePreHistories(
+			bar1.PreHistory, bar2.PreHistory, bar1.sampling, bar2.sampling, c1, c2)
+	}
+	// mergeHistories merges the matrices with the same keys, e.g. the same files
+	mergeHistories := func(histories1, histories2 map[string]DenseHistory) map[string]DenseHistory {
+		if len(histories1) == 0 && len(histories2) == 0 {
+			return nil
+		}
+		result := map[string]DenseHistory{}
 		historyMutex := sync.Mutex{}
-		for key, fh1 := range bar1.FileHistories {
-			if fh2, exists := bar2.FileHistories[key]; exists {
+		for key, fh1 := range histories1 {
+			if fh2, exists := histories2[key]; exists {
 				wg.Add(1)
 				go func(fh1, fh2 DenseHistory, key string) {
 					defer wg.Done()
 					historyMutex.Lock()
 					defer historyMutex.Unlock()
-					merged.FileHistories[key] = mergeMatrices(
+					result[key] = mergeMatrices(
 						fh1, fh2, bar1.granularity, bar1.sampling, bar2.granularity, bar2.sampling, c1, c2)
 				}(fh1, fh2, key)
 			} else {
 				historyMutex.Lock()
-				merged.FileHistories[key] = fh1
+				result[key] = fh1
 				historyMutex.Unlock()
 			}
 		}
-		for key, fh2 := range bar2.FileHistories {
-			if _, exists := bar1.FileHistories[key]; !exists {
+		for key, fh2 := range histories2 {
+			if _, exists := histories1[key]; !exists {
 				historyMutex.Lock()
-				merged.FileHistories[key] = fh2
+				result[key] = fh2
 				historyMutex.Unlock()
 			}
 		}
+		return result
+	}
+	merged.FileHistories = mergeHistories(bar1.FileHistories, bar2.FileHistories)
+	merged.DirectoryHistories = mergeHistories(bar1.DirectoryHistories, bar2.DirectoryHistories)
+	merged.LanguageHistories = mergeHistories(bar1.LanguageHistories, bar2.LanguageHistories)
+	if len(bar1.FileSnapshots) > 0 || len(bar2.FileSnapshots) > 0 {
+		merged.FileSnapshots = mergeFileSnapshots(
+			bar1, bar2, c1, c2, people, merged.reversedPeopleDict)
+	}
+	if len(bar1.PeoplePreHistories) > 0 || len(bar2.PeoplePreHistories) > 0 {
+		merged.PeoplePreHistories = make([][]int64, len(merged.reversedPeopleDict))
+		for i, key := range merged.reversedPeopleDict {
+			ptrs := people[key]
+			var h1, h2 []int64
+			if ptrs[1] >= 0 && len(bar1.PeoplePreHistories) > 0 {
+				h1 = bar1.PeoplePreHistories[ptrs[1]]
+			}
+			if ptrs[2] >= 0 && len(bar2.PeoplePreHistories) > 0 {
+				h2 = bar2.PeoplePreHistories[ptrs[2]]
+			}
+			if len(h1) > 0 || len(h2) > 0 {
+				merged.PeoplePreHistories[i] = mergePreHistories(
+					h1, h2, bar1.sampling, bar2.sampling, c1, c2)
+			}
+		}
 	}
 	if len(merged.reversedPeopleDict) > 0 {
 		merged.PeopleHistories = make([]DenseHistory, len(merged.reversedPeopleDict))
@@ -589,6 +1074,45 @@ func (analyser *BurndownAnalysis) MergeResults(
 		}()
 	}
 	wg.Wait()
+	if bar1.Survival != nil || bar2.Survival != nil {
+		merged.Survival = newBurndownSurvival(
+			merged.GlobalHistory, merged.sampling, merged.granularity)
+	}
+	return merged
+}
+
+// mergeFileSnapshots sums the line ages and owners of the same files. The ages are shifted
+// to be relative to the latest end time and the developers are mapped to the merged identities.
+func mergeFileSnapshots(bar1, bar2 BurndownResult, c1, c2 *core.CommonAnalysisResult,
+	people map[string][3]int, reversedPeopleDict []string) map[string]BurndownFileSnapshot {
+	merged := map[string]BurndownFileSnapshot{}
+	endTime := c1.EndTime
+	if c2.EndTime > endTime {
+		endTime = c2.EndTime
+	}
+	add := func(result BurndownResult, c *core.CommonAnalysisResult) {
+		offset := int((endTime - c.EndTime) / (24 * 3600))
+		for name, snapshot := range result.FileSnapshots {
+			ms, exists := merged[name]
+			if !exists {
+				ms = BurndownFileSnapshot{Ages: map[int]int64{}, Owners: map[int]int64{}}
+				merged[name] = ms
+			}
+			for age, lines := range snapshot.Ages {
+				ms.Ages[age+offset] += lines
+			}
+			for dev, lines := range snapshot.Owners {
+				if dev >= 0 && dev < len(result.reversedPeopleDict) {
+					dev = people[result.reversedPeopleDict[dev]][0]
+				} else {
+					dev = -1
+				}
+				ms.Owners[dev] += lines
+			}
+		}
+	}
+	add(bar1, c1)
+	add(bar2, c2)
 	return merged
 }
 
@@ -645,6 +1169,38 @@ func mergeMatrices(m1, m2 DenseHistory, granularity1, sampling1, granularity2, s
 	return result
 }
 
+// mergePreHistories takes two [number of samples] vectors of the lines which existed before
+// the first analysed commits, resamples them to days, sums and resamples back to the least of
+// (sampling1, sampling2) similar to mergeMatrices().
+func mergePreHistories(h1, h2 []int64, sampling1, sampling2 int,
+	c1, c2 *core.CommonAnalysisResult) []int64 {
+	commonMerged := *c1
+	commonMerged.Merge(c2)
+	sampling := sampling1
+	if sampling2 < sampling {
+		sampling = sampling2
+	}
+	size := int((commonMerged.EndTime - commonMerged.BeginTime) / (3600 * 24))
+	daily := make([]int64, size+sampling)
+	add := func(history []int64, sampling int, c *core.CommonAnalysisResult) {
+		offset := int(c.BeginTime-commonMerged.BeginTime) / (3600 * 24)
+		for i := 0; i < len(history)*sampling && offset+i < len(daily); i++ {
+			daily[offset+i] += history[i/sampling]
+		}
+	}
+	add(h1, sampling1, c1)
+	add(h2, sampling2, c2)
+	result := make([]int64, (size+sampling-1)/sampling)
+	for i := range result {
+		sampledIndex := i * sampling
+		if i == len(result)-1 {
+			sampledIndex = size - 1
+		}
+		result[i] = daily[sampledIndex]
+	}
+	return result
+}
+
 // Explode `matrix` so that it is daily sampled and has daily bands, shift by `offset` days
 // and add to the accumulator. `daily` size is square and is guaranteed to fit `matrix` by
 // the caller.
@@ -810,6 +1366,11 @@ func (analyser *BurndownAnalysis) serializeText(result *BurndownResult, writer i
 	fmt.Fprintln(writer, "  granularity:", result.granularity)
 	fmt.Fprintln(writer, "  sampling:", result.sampling)
 	yaml.PrintMatrix(writer, result.GlobalHistory, 2, "project", true)
+	if len(result.PreHistory) > 0 {
+		fmt.Fprint(writer, "  pre_history: ")
+		writeInt64List(writer, result.PreHistory)
+		fmt.Fprintln(writer)
+	}
 	if len(result.FileHistories) > 0 {
 		fmt.Fprintln(writer, "  files:")
 		keys := sortedKeys(result.FileHistories)
@@ -817,6 +1378,21 @@ func (analyser *BurndownAnalysis) serializeText(result *BurndownResult, writer i
 			yaml.PrintMatrix(writer, result.FileHistories[key], 4, key, true)
 		}
 	}
+	if len(result.DirectoryHistories) > 0 {
+		fmt.Fprintln(writer, "  directories:")
+		for _, key := range sortedKeys(result.DirectoryHistories) {
+			yaml.PrintMatrix(writer, result.DirectoryHistories[key], 4, key, true)
+		}
+	}
+	if len(result.LanguageHistories) > 0 {
+		fmt.Fprintln(writer, "  languages:")
+		for _, key := range sortedKeys(result.LanguageHistories) {
+			yaml.PrintMatrix(writer, result.LanguageHistories[key], 4, key, true)
+		}
+	}
+	if result.Survival != nil {
+		result.Survival.serializeText(writer)
+	}
 
 	if len(result.PeopleHistories) > 0 {
 		fmt.Fprintln(writer, "  people_sequence:")
@@ -827,9 +1403,58 @@ func (analyser *BurndownAnalysis) serializeText(result *BurndownResult, writer i
 		for key, val := range result.PeopleHistories {
 			yaml.PrintMatrix(writer, val, 4, result.reversedPeopleDict[key], true)
 		}
+		if len(result.PeoplePreHistories) > 0 {
+			fmt.Fprintln(writer, "  people_pre_history:")
+			for _, val := range result.PeoplePreHistories {
+				fmt.Fprint(writer, "    - ")
+				writeInt64List(writer, val)
+				fmt.Fprintln(writer)
+			}
+		}
 		fmt.Fprintln(writer, "  people_interaction: |-")
 		yaml.PrintMatrix(writer, result.PeopleMatrix, 4, "", false)
 	}
+	if len(result.FileSnapshots) > 0 {
+		fmt.Fprintln(writer, "  snapshots:")
+		for _, key := range sortedSnapshotKeys(result.FileSnapshots) {
+			snapshot := result.FileSnapshots[key]
+			fmt.Fprintf(writer, "    %s:\n", yaml.SafeString(key))
+			fmt.Fprint(writer, "      ages: ")
+			writeIntMap(writer, snapshot.Ages)
+			fmt.Fprint(writer, "\n      owners: ")
+			writeIntMap(writer, snapshot.Owners)
+			fmt.Fprintln(writer)
+		}
+	}
+}
+
+// writeInt64List prints the numbers in the YAML flow style.
+func writeInt64List(writer io.Writer, values []int64) {
+	fmt.Fprint(writer, "[")
+	for i, val := range values {
+		if i > 0 {
+			fmt.Fprint(writer, ", ")
+		}
+		fmt.Fprint(writer, val)
+	}
+	fmt.Fprint(writer, "]")
+}
+
+// writeIntMap prints the map in the YAML flow style with the keys sorted.
+func writeIntMap(writer io.Writer, m map[int]int64) {
+	keys := make([]int, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	fmt.Fprint(writer, "{")
+	for i, key := range keys {
+		if i > 0 {
+			fmt.Fprint(writer, ", ")
+		}
+		fmt.Fprintf(writer, "%d: %d", key, m[key])
+	}
+	fmt.Fprint(writer, "}")
 }
 
 func (analyser *BurndownAnalysis) serializeBinary(result *BurndownResult, writer io.Writer) error {
@@ -839,6 +1464,7 @@ func (analyser *BurndownAnalysis) serializeBinary(result *BurndownResult, writer
 	}
 	if len(result.GlobalHistory) > 0 {
 		message.Project = pb.ToBurndownSparseMatrix(result.GlobalHistory, "project")
+		message.Project.PreHistory = result.PreHistory
 	}
 	if len(result.FileHistories) > 0 {
 		message.Files = make([]*pb.BurndownSparseMatrix, len(result.FileHistories))
@@ -850,6 +1476,23 @@ func (analyser *BurndownAnalysis) serializeBinary(result *BurndownResult, writer
 			i++
 		}
 	}
+	if len(result.DirectoryHistories) > 0 {
+		message.Directories = make([]*pb.BurndownSparseMatrix, 0, len(result.DirectoryHistories))
+		for _, key := range sortedKeys(result.DirectoryHistories) {
+			message.Directories = append(message.Directories,
+				pb.ToBurndownSparseMatrix(result.DirectoryHistories[key], key))
+		}
+	}
+	if len(result.LanguageHistories) > 0 {
+		message.Languages = make([]*pb.BurndownSparseMatrix, 0, len(result.LanguageHistories))
+		for _, key := range sortedKeys(result.LanguageHistories) {
+			message.Languages = append(message.Languages,
+				pb.ToBurndownSparseMatrix(result.LanguageHistories[key], key))
+		}
+	}
+	if result.Survival != nil {
+		message.Survival = result.Survival.toPB()
+	}
 
 	if len(result.PeopleHistories) > 0 {
 		message.People = make(
@@ -857,10 +1500,27 @@ func (analyser *BurndownAnalysis) serializeBinary(result *BurndownResult, writer
 		for key, val := range result.PeopleHistories {
 			if len(val) > 0 {
 				message.People[key] = pb.ToBurndownSparseMatrix(val, result.reversedPeopleDict[key])
+				if len(result.PeoplePreHistories) > 0 {
+					message.People[key].PreHistory = result.PeoplePreHistories[key]
+				}
 			}
 		}
 		message.PeopleInteraction = pb.DenseToCompressedSparseRowMatrix(result.PeopleMatrix)
 	}
+	if len(result.FileSnapshots) > 0 {
+		message.Snapshots = make([]*pb.FileSnapshot, 0, len(result.FileSnapshots))
+		for _, key := range sortedSnapshotKeys(result.FileSnapshots) {
+			snapshot := result.FileSnapshots[key]
+			ms := &pb.FileSnapshot{Name: key, Ages: map[int32]int64{}, Owners: map[int32]int64{}}
+			for age, lines := range snapshot.Ages {
+				ms.Ages[int32(age)] = lines
+			}
+			for dev, lines := range snapshot.Owners {
+				ms.Owners[int32(dev)] = lines
+			}
+			message.Snapshots = append(message.Snapshots, ms)
+		}
+	}
 	serialized, err := proto.Marshal(&message)
 	if err != nil {
 		return err
@@ -878,6 +1538,15 @@ func sortedKeys(m map[string]DenseHistory) []string {
 	return keys
 }
 
+func sortedSnapshotKeys(m map[string]BurndownFileSnapshot) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func checkClose(c io.Closer) {
 	if err := c.Close(); err != nil {
 		panic(err)
@@ -894,8 +1563,9 @@ func (analyser *BurndownAnalysis) packPersonWithDay(person int, day int) int {
 	}
 	result := day & burndown.TreeMergeMark
 	result |= person << burndown.TreeMaxBinPower
-	// This effectively means max (16383 - 1) days (>44 years) and (131072 - 2) devs.
-	// One day less because burndown.TreeMergeMark = ((1 << 14) - 1) is a special day.
+	// This effectively means max (16383 - 2) days (>44 years) and (131072 - 2) devs.
+	// Two days less because burndown.TreeMergeMark = ((1 << 14) - 1) and preHistoryDay
+	// are special days.
 	return result
 }
 
@@ -913,30 +1583,28 @@ func (analyser *BurndownAnalysis) onNewDay() {
 	analyser.mergedAuthor = identity.AuthorMissing
 }
 
+// currentDay unpacks the day of the change. The lines which existed before the first analysed
+// commit are inserted on the current day.
+func (analyser *BurndownAnalysis) currentDay(currentTime int) int {
+	_, day := analyser.unpackPersonWithDay(currentTime)
+	if day == preHistoryDay {
+		return analyser.day
+	}
+	return day
+}
+
 func (analyser *BurndownAnalysis) updateGlobal(currentTime, previousTime, delta int) {
-	_, currentDay := analyser.unpackPersonWithDay(currentTime)
+	currentDay := analyser.currentDay(currentTime)
 	_, previousDay := analyser.unpackPersonWithDay(previousTime)
-	currentHistory := analyser.globalHistory[currentDay]
-	if currentHistory == nil {
-		currentHistory = map[int]int64{}
-		analyser.globalHistory[currentDay] = currentHistory
-	}
-	currentHistory[previousDay] += int64(delta)
+	analyser.globalHistory.add(currentDay, previousDay, int64(delta))
 }
 
 // updateFile is bound to the specific `history` in the closure.
 func (analyser *BurndownAnalysis) updateFile(
-	history sparseHistory, currentTime, previousTime, delta int) {
-		
-	_, currentDay := analyser.unpackPersonWithDay(currentTime)
+	history *sparseHistory, currentTime, previousTime, delta int) {
+	currentDay := analyser.currentDay(currentTime)
 	_, previousDay := analyser.unpackPersonWithDay(previousTime)
-
-	currentHistory := history[currentDay]
-	if currentHistory == nil {
-		currentHistory = map[int]int64{}
-		history[currentDay] = currentHistory
-	}
-	currentHistory[previousDay] += int64(delta)
+	history.add(currentDay, previousDay, int64(delta))
 }
 
 func (analyser *BurndownAnalysis) updateAuthor(currentTime, previousTime, delta int) {
@@ -944,18 +1612,13 @@ func (analyser *BurndownAnalysis) updateAuthor(currentTime, previousTime, delta
 	if previousAuthor == identity.AuthorMissing {
 		return
 	}
-	_, currentDay := analyser.unpackPersonWithDay(currentTime)
+	currentDay := analyser.currentDay(currentTime)
 	history := analyser.peopleHistories[previousAuthor]
 	if history == nil {
-		history = sparseHistory{}
+		history = analyser.newHistory()
 		analyser.peopleHistories[previousAuthor] = history
 	}
-	currentHistory := history[currentDay]
-	if currentHistory == nil {
-		currentHistory = map[int]int64{}
-		history[currentDay] = currentHistory
-	}
-	currentHistory[previousDay] += int64(delta)
+	history.add(currentDay, previousDay, int64(delta))
 }
 
 func (analyser *BurndownAnalysis) updateMatrix(currentTime, previousTime, delta int) {
@@ -983,25 +1646,192 @@ func (analyser *BurndownAnalysis) updateMatrix(currentTime, previousTime, delta
 
 func (analyser *BurndownAnalysis) newFile(
 	hash plumbing.Hash, name string, author int, day int, size int) (*burndown.File, error) {
+	if analyser.PeopleNumber > 0 {
+		day = analyser.packPersonWithDay(author, day)
+	}
+	return burndown.NewFile(day, size, analyser.newUpdaters(name)...), nil
+}
+
+// newUpdaters returns the updaters of the file with the specified name.
+func (analyser *BurndownAnalysis) newUpdaters(name string) []burndown.Updater {
+	if analyser.linesOnly {
+		if analyser.lineUpdater != nil {
+			return []burndown.Updater{analyser.lineUpdater}
+		}
+		return nil
+	}
 	updaters := make([]burndown.Updater, 1)
 	updaters[0] = analyser.updateGlobal
 	if analyser.TrackFiles {
 		history := analyser.fileHistories[name]
 		if history == nil {
 			// can be not nil if the file was created in a future branch
-			history = sparseHistory{}
+			history = analyser.newHistory()
 		}
 		analyser.fileHistories[name] = history
 		updaters = append(updaters, func(currentTime, previousTime, delta int) {
 			analyser.updateFile(history, currentTime, previousTime, delta)
 		})
 	}
+	if analyser.DirectoryDepth > 0 {
+		history := analyser.directoryHistory(analyser.directoryOf(name))
+		updaters = append(updaters, func(currentTime, previousTime, delta int) {
+			analyser.updateFile(history, currentTime, previousTime, delta)
+		})
+	}
+	if analyser.TrackLanguages {
+		history := analyser.languageHistory(analyser.fileLanguages[name])
+		updaters = append(updaters, func(currentTime, previousTime, delta int) {
+			analyser.updateFile(history, currentTime, previousTime, delta)
+		})
+	}
 	if analyser.PeopleNumber > 0 {
 		updaters = append(updaters, analyser.updateAuthor)
 		updaters = append(updaters, analyser.updateMatrix)
-		day = analyser.packPersonWithDay(author, day)
 	}
-	return burndown.NewFile(day, size, updaters...), nil
+	return updaters
+}
+
+// directoryOf returns the directory of the file truncated to DirectoryDepth components.
+func (analyser *BurndownAnalysis) directoryOf(name string) string {
+	return truncateDirectory(name, analyser.DirectoryDepth)
+}
+
+// truncateDirectory returns the directory of the file truncated to `depth` components,
+// "." for the files in the root.
+func truncateDirectory(name string, depth int) string {
+	parts := strings.Split(name, "/")
+	parts = parts[:len(parts)-1]
+	if len(parts) == 0 {
+		return "."
+	}
+	if len(parts) > depth {
+		parts = parts[:depth]
+	}
+	return strings.Join(parts, "/")
+}
+
+// newHistory creates an empty history with the analyser's sampling, granularity and cohorts.
+func (analyser *BurndownAnalysis) newHistory() *sparseHistory {
+	history := newSparseHistory(analyser.Sampling, analyser.Granularity)
+	history.cohorts = analyser.cohorts
+	return history
+}
+
+// directoryHistory returns the history of the directory, creating it if necessary.
+func (analyser *BurndownAnalysis) directoryHistory(dir string) *sparseHistory {
+	history := analyser.directoryHistories[dir]
+	if history == nil {
+		history = analyser.newHistory()
+		analyser.directoryHistories[dir] = history
+	}
+	return history
+}
+
+// languageHistory returns the history of the language, creating it if necessary.
+func (analyser *BurndownAnalysis) languageHistory(lang string) *sparseHistory {
+	history := analyser.languageHistories[lang]
+	if history == nil {
+		history = analyser.newHistory()
+		analyser.languageHistories[lang] = history
+	}
+	return history
+}
+
+// moveLines transfers the lines of the file from one history to another on the current day,
+// e.g. when the file moves to another directory. The lines keep their ages.
+func (analyser *BurndownAnalysis) moveLines(file *burndown.File, from, to *sparseHistory) {
+	currentTime := analyser.packPersonWithDay(identity.AuthorMissing, analyser.day)
+	file.ForEach(func(line, length, value int) {
+		analyser.updateFile(from, currentTime, value, -length)
+		analyser.updateFile(to, currentTime, value, length)
+	})
+}
+
+// language returns the language of the file changed in the current commit.
+func (analyser *BurndownAnalysis) language(name string) string {
+	if lang := analyser.languages[name]; lang != "" {
+		return lang
+	}
+	return otherLanguage
+}
+
+// updateLanguage takes the language of the file after the change and transfers its lines
+// to the new language if it is different. Returns the file with the rebound updaters.
+func (analyser *BurndownAnalysis) updateLanguage(name string, file *burndown.File) *burndown.File {
+	lang := analyser.language(name)
+	previous := analyser.fileLanguages[name]
+	if lang == previous {
+		return file
+	}
+	if analyser.day != burndown.TreeMergeMark {
+		// in a merge, the lines have already been moved in a branch
+		analyser.moveLines(file, analyser.languageHistory(previous), analyser.languageHistory(lang))
+	}
+	analyser.fileLanguages[name] = lang
+	file = file.Clone(true, analyser.newUpdaters(name)...)
+	analyser.files[name] = file
+	return file
+}
+
+// newBoundaryFile creates the file which existed before the first analysed commit according
+// to HistoryBoundary. Its lines belong to the pre-history instead of the current band.
+func (analyser *BurndownAnalysis) newBoundaryFile(
+	hash plumbing.Hash, name string, author int, size int) (*burndown.File, error) {
+	if analyser.HistoryBoundary == BurndownBoundaryPreHistory {
+		return analyser.newFile(hash, name, identity.AuthorMissing, preHistoryDay, size)
+	}
+	authors := analyser.blameAuthors(name, size)
+	if len(authors) == 0 {
+		return analyser.newFile(hash, name, author, preHistoryDay, size)
+	}
+	// group the consecutive lines with the same author
+	start := 0
+	var file *burndown.File
+	for i := 1; i <= len(authors); i++ {
+		if i < len(authors) && authors[i] == authors[start] {
+			continue
+		}
+		if file == nil {
+			var err error
+			file, err = analyser.newFile(hash, name, authors[start], preHistoryDay, i-start)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			file.Update(analyser.packPersonWithDay(authors[start], preHistoryDay), start, i-start, 0)
+		}
+		start = i
+	}
+	return file, nil
+}
+
+// blameAuthors returns the author of each line of the file in the boundary commit.
+// It returns nil if the people are not tracked or `git blame` failed, e.g. due to the
+// missing objects in a shallow clone.
+func (analyser *BurndownAnalysis) blameAuthors(name string, size int) []int {
+	if analyser.PeopleNumber == 0 {
+		return nil
+	}
+	blame, err := git.Blame(analyser.boundary, name)
+	if err != nil {
+		log.Printf("Warning: failed to blame %s: %v\n", name, err)
+		return nil
+	}
+	if len(blame.Lines) != size {
+		log.Printf("Warning: blame of %s has %d lines instead of %d\n",
+			name, len(blame.Lines), size)
+		return nil
+	}
+	authors := make([]int, size)
+	for i, line := range blame.Lines {
+		author, exists := analyser.peopleDict[strings.ToLower(line.Author)]
+		if !exists {
+			author = identity.AuthorMissing
+		}
+		authors[i] = author
+	}
+	return authors
 }
 
 func (analyser *BurndownAnalysis) handleInsertion(
@@ -1023,7 +1853,20 @@ func (analyser *BurndownAnalysis) handleInsertion(
 	if analyser.day != burndown.TreeMergeMark {
 		hash = blob.Hash
 	}
-	file, err = analyser.newFile(hash, name, author, analyser.day, lines)
+	if analyser.TrackLanguages {
+		analyser.fileLanguages[name] = analyser.language(name)
+	}
+	if analyser.boundary != nil {
+		file, err = analyser.newBoundaryFile(hash, name, author, lines)
+	} else if len(analyser.coAuthors) > 0 {
+		shares := splitCredit(lines, len(analyser.coAuthors)+1)
+		file, err = analyser.newFile(hash, name, author, analyser.day, shares[0])
+		if err == nil {
+			analyser.insertLines(file, shares[0], 0, analyser.coAuthors, shares[1:])
+		}
+	} else {
+		file, err = analyser.newFile(hash, name, author, analyser.day, lines)
+	}
 	analyser.files[name] = file
 	if analyser.day == burndown.TreeMergeMark {
 		analyser.mergedFiles[name] = true
@@ -1047,6 +1890,7 @@ func (analyser *BurndownAnalysis) handleDeletion(
 	file.Update(analyser.packPersonWithDay(author, analyser.day), 0, 0, lines)
 	delete(analyser.files, name)
 	delete(analyser.fileHistories, name)
+	delete(analyser.fileLanguages, name)
 	analyser.renames[name] = ""
 	if analyser.day == burndown.TreeMergeMark {
 		analyser.mergedFiles[name] = false
@@ -1073,6 +1917,12 @@ func (analyser *BurndownAnalysis) handleModification(
 		if err != nil {
 			return err
 		}
+		// the updaters may have changed
+		file = analyser.files[change.To.Name]
+	}
+	if analyser.TrackLanguages {
+		// the language depends on both the name and the contents
+		file = analyser.updateLanguage(change.To.Name, file)
 	}
 
 	thisDiffs := diffs[change.To.Name]
@@ -1083,6 +1933,16 @@ func (analyser *BurndownAnalysis) handleModification(
 			change.From.TreeEntry.Hash.String(), change.To.TreeEntry.Hash.String())
 	}
 
+	// the merges are resolved in Merge() so we do not bother with the formatting there
+	var formatting []bool
+	if analyser.IgnoreFormatting && analyser.day != burndown.TreeMergeMark {
+		formatting = analyser.formattingHunks[change.To.Name]
+	}
+	// hunk is the index of the current sequence of the adjacent non-equal diffs,
+	// the same as in FormattingDetector
+	hunk := -1
+	previousEqual := true
+
 	// we do not call RunesToDiffLines so the number of lines equals
 	// to the rune count
 	position := 0
@@ -1091,7 +1951,7 @@ func (analyser *BurndownAnalysis) handleModification(
 	apply := func(edit diffmatchpatch.Diff) {
 		length := utf8.RuneCountInString(edit.Text)
 		if edit.Type == diffmatchpatch.DiffInsert {
-			file.Update(analyser.packPersonWithDay(author, analyser.day), position, length, 0)
+			analyser.updateLines(file, author, position, length, 0)
 			position += length
 		} else {
 			file.Update(analyser.packPersonWithDay(author, analyser.day), position, 0, length)
@@ -1107,6 +1967,10 @@ func (analyser *BurndownAnalysis) handleModification(
 			dumpBefore = file.Dump()
 		}
 		length := utf8.RuneCountInString(edit.Text)
+		if edit.Type != diffmatchpatch.DiffEqual && previousEqual {
+			hunk++
+		}
+		previousEqual = edit.Type == diffmatchpatch.DiffEqual
 		debugError := func() {
 			log.Printf("%s: internal diff error\n", change.To.Name)
 			log.Printf("Update(%d, %d, %d (0), %d (0))\n", analyser.day, position,
@@ -1129,8 +1993,18 @@ func (analyser *BurndownAnalysis) handleModification(
 					debugError()
 					return errors.New("DiffInsert may not appear after DiffInsert")
 				}
-				file.Update(analyser.packPersonWithDay(author, analyser.day), position, length,
-					utf8.RuneCountInString(pending.Text))
+				deleted := utf8.RuneCountInString(pending.Text)
+				if hunk < len(formatting) && formatting[hunk] {
+					// the reformatted lines stay as they are, only the surplus changes
+					kept := length
+					if deleted < kept {
+						kept = deleted
+					}
+					position += kept
+					length -= kept
+					deleted -= kept
+				}
+				analyser.updateLines(file, author, position, length, deleted)
 				if analyser.Debug {
 					file.Validate()
 				}
@@ -1161,6 +2035,51 @@ func (analyser *BurndownAnalysis) handleModification(
 	return nil
 }
 
+// updateLines inserts `length` lines at `position` after deleting `deleted` lines there.
+// The inserted lines are split between the author and the co-authors of the commit.
+func (analyser *BurndownAnalysis) updateLines(
+	file *burndown.File, author, position, length, deleted int) {
+	time := analyser.packPersonWithDay(author, analyser.day)
+	if analyser.lineTime != nil && length > 0 && deleted > 0 &&
+		analyser.day != burndown.TreeMergeMark {
+		time = analyser.lineTime(time, file.Times(position, deleted))
+	}
+	if len(analyser.coAuthors) == 0 || length == 0 {
+		file.Update(time, position, length, deleted)
+		return
+	}
+	shares := splitCredit(length, len(analyser.coAuthors)+1)
+	file.Update(time, position, shares[0], deleted)
+	analyser.insertLines(file, position+shares[0], 0, analyser.coAuthors, shares[1:])
+}
+
+// insertLines inserts the lines at `position` which belong to `authors` in consecutive
+// blocks of the corresponding `shares`. `deleted` lines are removed first.
+func (analyser *BurndownAnalysis) insertLines(
+	file *burndown.File, position, deleted int, authors, shares []int) {
+	for i, share := range shares {
+		if share == 0 && deleted == 0 {
+			continue
+		}
+		file.Update(analyser.packPersonWithDay(authors[i], analyser.day), position, share, deleted)
+		position += share
+		deleted = 0
+	}
+}
+
+// splitCredit divides `total` into `parts` integer shares which differ by at most one,
+// the first shares are larger.
+func splitCredit(total, parts int) []int {
+	shares := make([]int, parts)
+	for i := range shares {
+		shares[i] = total / parts
+		if i < total%parts {
+			shares[i]++
+		}
+	}
+	return shares
+}
+
 func (analyser *BurndownAnalysis) handleRename(from, to string) error {
 	if from == to {
 		return nil
@@ -1171,6 +2090,10 @@ func (analyser *BurndownAnalysis) handleRename(from, to string) error {
 	}
 	analyser.files[to] = file
 	delete(analyser.files, from)
+	if lang, exists := analyser.fileLanguages[from]; exists {
+		analyser.fileLanguages[to] = lang
+		delete(analyser.fileLanguages, from)
+	}
 	if analyser.day == burndown.TreeMergeMark {
 		analyser.mergedFiles[from] = false
 	}
@@ -1182,7 +2105,7 @@ func (analyser *BurndownAnalysis) handleRename(from, to string) error {
 			futureRename, exists := analyser.renames[from]
 			if futureRename == "" && exists {
 				// the file will be deleted in the future, whatever
-				history = sparseHistory{}
+				history = analyser.newHistory()
 			} else {
 				history = analyser.fileHistories[futureRename]
 				if history == nil {
@@ -1193,55 +2116,20 @@ func (analyser *BurndownAnalysis) handleRename(from, to string) error {
 		analyser.fileHistories[to] = history
 		delete(analyser.fileHistories, from)
 	}
-	analyser.renames[from] = to
-	return nil
-}
-
-func (analyser *BurndownAnalysis) groupSparseHistory(
-	history sparseHistory, lastDay int) (DenseHistory, int) {
-
-	if len(history) == 0 {
-		panic("empty history")
-	}
-	var days []int
-	for day := range history {
-		days = append(days, day)
-	}
-	sort.Ints(days)
-	if lastDay >= 0 {
-		if days[len(days)-1] < lastDay {
-			days = append(days, lastDay)
-		} else if days[len(days)-1] > lastDay {
-			panic("days corruption")
-		}
-	} else {
-		lastDay = days[len(days)-1]
-	}
-	// [y][x]
-	// y - sampling
-	// x - granularity
-	samples := lastDay / analyser.Sampling + 1
-	bands := lastDay / analyser.Granularity + 1
-	result := make(DenseHistory, samples)
-	for i := 0; i < bands; i++ {
-		result[i] = make([]int64, bands)
-	}
-	prevsi := 0
-	for _, day := range days {
-		si := day / analyser.Sampling
-		if si > prevsi {
-			state := result[prevsi]
-			for i := prevsi + 1; i <= si; i++ {
-				copy(result[i], state)
+	if analyser.DirectoryDepth > 0 {
+		fromDir, toDir := analyser.directoryOf(from), analyser.directoryOf(to)
+		if fromDir != toDir {
+			if analyser.day != burndown.TreeMergeMark {
+				// in a merge, the lines have already been moved in a branch
+				analyser.moveLines(file,
+					analyser.directoryHistory(fromDir), analyser.directoryHistory(toDir))
 			}
-			prevsi = si
-		}
-		sample := result[si]
-		for bday, value := range history[day] {
-			sample[bday / analyser.Granularity] += value
+			// rebind the updaters to the new directory
+			analyser.files[to] = file.Clone(true, analyser.newUpdaters(to)...)
 		}
 	}
-	return result, lastDay
+	analyser.renames[from] = to
+	return nil
 }
 
 func init() {
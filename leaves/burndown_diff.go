@@ -0,0 +1,156 @@
+package leaves
+
+import (
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+// BurndownDiff is the difference between the final states of two burndown results, e.g.
+// the results of two runs over the same repository at different releases. Both results are
+// aligned to the same calendar bands which start at BeginTime.
+type BurndownDiff struct {
+	// BeginTime is the UNIX timestamp of the beginning of the first band: the earliest
+	// of the beginnings of the compared results.
+	BeginTime int64
+	// Granularity is the size of each band in days.
+	Granularity int
+	// Global is the difference of the whole project.
+	Global BurndownBandsDiff
+	// Directories is the difference of each directory which exists in either result.
+	// Empty unless both results contain DirectoryHistories.
+	Directories map[string]BurndownBandsDiff
+	// Languages is the difference of each programming language which exists in either result.
+	// Empty unless both results contain LanguageHistories.
+	Languages map[string]BurndownBandsDiff
+}
+
+// BurndownBandsDiff contains the numbers of alive lines in each band in the old and in the new
+// result. Old and New have the same length.
+type BurndownBandsDiff struct {
+	Old []int64
+	New []int64
+	// Retired is the number of the lines which disappeared from the bands.
+	Retired int64
+	// Added is the number of the lines which appeared in the bands.
+	Added int64
+}
+
+// Delta returns New - Old for each band.
+func (diff BurndownBandsDiff) Delta() []int64 {
+	delta := make([]int64, len(diff.Old))
+	for i := range delta {
+		delta[i] = diff.New[i] - diff.Old[i]
+	}
+	return delta
+}
+
+// burndownBands describes the layout of the bands of a BurndownResult on the common calendar.
+type burndownBands struct {
+	// offset is the number of days between the common beginning and the result's beginning.
+	offset      int
+	granularity int
+}
+
+// DiffBurndown aligns the last samples of two burndown results and calculates the difference
+// in each band. The results may be obtained with different granularities and at different
+// dates. granularity sets the size of the common bands; it defaults to the largest of the two
+// granularities if it is not positive. The lines of a band are spread uniformly over its days.
+func DiffBurndown(old, new BurndownResult, oldCommon, newCommon *core.CommonAnalysisResult,
+	granularity int) BurndownDiff {
+	diff := BurndownDiff{BeginTime: oldCommon.BeginTime}
+	if newCommon.BeginTime < diff.BeginTime {
+		diff.BeginTime = newCommon.BeginTime
+	}
+	if granularity <= 0 {
+		granularity = old.granularity
+		if new.granularity > granularity {
+			granularity = new.granularity
+		}
+	}
+	diff.Granularity = granularity
+	oldBands := burndownBands{
+		offset: int((oldCommon.BeginTime - diff.BeginTime) / 86400), granularity: old.granularity}
+	newBands := burndownBands{
+		offset: int((newCommon.BeginTime - diff.BeginTime) / 86400), granularity: new.granularity}
+	// all the matrices share the same number of bands so that they are easy to compare
+	days := 0
+	for _, side := range []struct {
+		bands     burndownBands
+		histories []map[string]DenseHistory
+	}{
+		{oldBands, []map[string]DenseHistory{
+			{"": old.GlobalHistory}, old.DirectoryHistories, old.LanguageHistories}},
+		{newBands, []map[string]DenseHistory{
+			{"": new.GlobalHistory}, new.DirectoryHistories, new.LanguageHistories}},
+	} {
+		for _, histories := range side.histories {
+			for _, history := range histories {
+				if span := side.bands.days(history); span > days {
+					days = span
+				}
+			}
+		}
+	}
+	size := (days + granularity - 1) / granularity
+	diffHistories := func(h1, h2 DenseHistory) BurndownBandsDiff {
+		result := BurndownBandsDiff{
+			Old: oldBands.align(h1, granularity, size),
+			New: newBands.align(h2, granularity, size),
+		}
+		for i, val := range result.Old {
+			if delta := result.New[i] - val; delta > 0 {
+				result.Added += delta
+			} else {
+				result.Retired -= delta
+			}
+		}
+		return result
+	}
+	diff.Global = diffHistories(old.GlobalHistory, new.GlobalHistory)
+	diffMaps := func(m1, m2 map[string]DenseHistory) map[string]BurndownBandsDiff {
+		if len(m1) == 0 || len(m2) == 0 {
+			return nil
+		}
+		result := map[string]BurndownBandsDiff{}
+		for key, h1 := range m1 {
+			result[key] = diffHistories(h1, m2[key])
+		}
+		for key, h2 := range m2 {
+			if _, exists := m1[key]; !exists {
+				result[key] = diffHistories(nil, h2)
+			}
+		}
+		return result
+	}
+	diff.Directories = diffMaps(old.DirectoryHistories, new.DirectoryHistories)
+	diff.Languages = diffMaps(old.LanguageHistories, new.LanguageHistories)
+	return diff
+}
+
+// days returns the number of days since the common beginning covered by the last sample.
+func (bands burndownBands) days(history DenseHistory) int {
+	if len(history) == 0 {
+		return 0
+	}
+	return bands.offset + len(history[len(history)-1])*bands.granularity
+}
+
+// align regroups the last sample of the matrix into size bands of the specified granularity
+// on the common calendar. The sum of the values is preserved.
+func (bands burndownBands) align(history DenseHistory, granularity, size int) []int64 {
+	result := make([]int64, size)
+	if len(history) == 0 {
+		return result
+	}
+	for x, val := range history[len(history)-1] {
+		// spread the value over the days of the band so that the parts sum to val exactly
+		for i := 0; i < bands.granularity; i++ {
+			part := val*int64(i+1)/int64(bands.granularity) - val*int64(i)/int64(bands.granularity)
+			if part == 0 {
+				continue
+			}
+			day := bands.offset + x*bands.granularity + i
+			result[day/granularity] += part
+		}
+	}
+	return result
+}
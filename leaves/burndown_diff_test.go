@@ -0,0 +1,57 @@
+package leaves
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+)
+
+func TestBurndownBandsAlign(t *testing.T) {
+	bands := burndownBands{offset: 5, granularity: 10}
+	assert.Equal(t, bands.days(DenseHistory{{1}, {1, 2}}), 25)
+	assert.Equal(t, bands.days(nil), 0)
+	assert.Equal(t, bands.align(DenseHistory{{7}, {10, 20}}, 15, 2), []int64{10, 20})
+	assert.Equal(t, bands.align(DenseHistory{{7}, {3, 0}}, 5, 5), []int64{0, 1, 2, 0, 0})
+	assert.Equal(t, bands.align(nil, 15, 2), []int64{0, 0})
+}
+
+func TestDiffBurndown(t *testing.T) {
+	old := BurndownResult{
+		GlobalHistory: DenseHistory{{100, 0}, {80, 60}},
+		DirectoryHistories: map[string]DenseHistory{
+			"core": {{100, 0}, {80, 20}},
+			"lib":  {{0, 0}, {0, 40}},
+		},
+		granularity: 30, sampling: 30,
+	}
+	new := BurndownResult{
+		GlobalHistory: DenseHistory{{50, 40, 30}},
+		DirectoryHistories: map[string]DenseHistory{
+			"core": {{50, 10, 0}},
+			"src":  {{0, 30, 30}},
+		},
+		granularity: 15, sampling: 15,
+	}
+	c1 := &core.CommonAnalysisResult{BeginTime: 1500000000 - 30*86400}
+	c2 := &core.CommonAnalysisResult{BeginTime: 1500000000}
+	diff := DiffBurndown(old, new, c1, c2, 0)
+	assert.Equal(t, diff.BeginTime, c1.BeginTime)
+	assert.Equal(t, diff.Granularity, 30)
+	assert.Equal(t, diff.Global, BurndownBandsDiff{
+		Old: []int64{80, 60, 0}, New: []int64{0, 90, 30}, Retired: 80, Added: 60})
+	assert.Equal(t, diff.Global.Delta(), []int64{-80, 30, 30})
+	assert.Len(t, diff.Directories, 3)
+	assert.Equal(t, diff.Directories["core"], BurndownBandsDiff{
+		Old: []int64{80, 20, 0}, New: []int64{0, 60, 0}, Retired: 80, Added: 40})
+	assert.Equal(t, diff.Directories["lib"], BurndownBandsDiff{
+		Old: []int64{0, 40, 0}, New: []int64{0, 0, 0}, Retired: 40})
+	assert.Equal(t, diff.Directories["src"], BurndownBandsDiff{
+		Old: []int64{0, 0, 0}, New: []int64{0, 30, 30}, Added: 60})
+	assert.Nil(t, diff.Languages)
+
+	diff = DiffBurndown(old, new, c1, c2, 15)
+	assert.Equal(t, diff.Granularity, 15)
+	assert.Equal(t, diff.Global.Old, []int64{40, 40, 30, 30, 0})
+	assert.Equal(t, diff.Global.New, []int64{0, 0, 50, 40, 30})
+}
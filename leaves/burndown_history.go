@@ -0,0 +1,271 @@
+package leaves
+
+import "sort"
+
+// sparseHistory accumulates the deltas of the line counts directly at the resolution of
+// the resulting matrix: the rows are the samples and the columns are the bands.
+// E.g. with Sampling = Granularity = 10
+//
+//	day 0: day 0 +50 lines
+//	day 10: day 0 -10 lines; day 10 +20 lines
+//	day 12: day 0 -5 lines; day 10 -3 lines; day 12 +10 lines
+//
+// rows[0] = {first: 0, values: [50]}
+// rows[1] = {first: 0, values: [-15, 27]}
+// The samples without changes are not allocated and each row stores only the contiguous
+// range of the affected bands, so the memory does not grow quadratically with the age of
+// the repository.
+type sparseHistory struct {
+	sampling    int
+	granularity int
+	rows        []historyRow
+	// lastDay is the most recent day with a change, -1 if there were none.
+	lastDay int
+	// cohorts is the shared layout of the compacted bands; the columns are the cohorts
+	// instead of the bands if it is not nil.
+	cohorts *bandCohorts
+	// compacted is the number of the merges in cohorts which were applied to the rows.
+	compacted int
+	// preHistory is the deltas of the lines which existed before the first analysed commit
+	// in each sample. They do not belong to any band.
+	preHistory []int64
+}
+
+// bandCohorts is the layout of the columns of sparseHistory when the old bands are compacted.
+// The most recent bands keep their resolution and the older ones are merged into cohorts
+// with exponentially growing widths: there are at most two cohorts of the same width and
+// the older cohorts are never narrower than the newer ones. Thus the number of columns
+// is logarithmic in the number of bands.
+type bandCohorts struct {
+	// recent is the number of the most recent bands which are never merged.
+	recent int
+	// starts are the first bands of the cohorts from the oldest to the newest.
+	starts []int
+	// merges is the log of the merged cohorts: cohort i absorbed cohort i+1 and the following
+	// cohorts shifted by one. The histories replay it lazily.
+	merges []int
+}
+
+// newBandCohorts creates the layout which keeps `recent` (at least 1) bands intact.
+func newBandCohorts(recent int) *bandCohorts {
+	if recent < 1 {
+		recent = 1
+	}
+	return &bandCohorts{recent: recent, starts: []int{0}}
+}
+
+// advance adds the cohorts for the bands up to `band` inclusive and merges the old ones.
+func (cohorts *bandCohorts) advance(band int) {
+	head := cohorts.starts[len(cohorts.starts)-1]
+	if band <= head {
+		return
+	}
+	for next := head + 1; next <= band; next++ {
+		cohorts.starts = append(cohorts.starts, next)
+		cohorts.compact(next)
+	}
+}
+
+// compact merges the two oldest of any three consecutive old cohorts of the same width.
+func (cohorts *bandCohorts) compact(head int) {
+	width := func(i int) int {
+		return cohorts.starts[i+1] - cohorts.starts[i]
+	}
+	for merged := true; merged; {
+		merged = false
+		// the cohorts which start before this band are old
+		old := sort.SearchInts(cohorts.starts, head-cohorts.recent+1)
+		for i := 0; i+2 < old; i++ {
+			if width(i) == width(i+1) && width(i+1) == width(i+2) {
+				cohorts.starts = append(cohorts.starts[:i+1], cohorts.starts[i+2:]...)
+				cohorts.merges = append(cohorts.merges, i)
+				merged = true
+				break
+			}
+		}
+	}
+}
+
+// index returns the cohort which contains the band.
+func (cohorts *bandCohorts) index(band int) int {
+	return sort.Search(len(cohorts.starts), func(i int) bool {
+		return cohorts.starts[i] > band
+	}) - 1
+}
+
+// historyRow is the band of non-zero columns in a row of sparseHistory.
+type historyRow struct {
+	// first is the index of the band which corresponds to values[0].
+	first  int
+	values []int64
+}
+
+// newSparseHistory creates an empty history with the specified sampling and granularity in days.
+func newSparseHistory(sampling, granularity int) *sparseHistory {
+	return &sparseHistory{sampling: sampling, granularity: granularity, lastDay: -1}
+}
+
+// empty returns whether there were no changes in the history.
+func (history *sparseHistory) empty() bool {
+	return history.lastDay < 0
+}
+
+// add records `delta` lines which were written on `previousDay` and changed on `currentDay`.
+func (history *sparseHistory) add(currentDay, previousDay int, delta int64) {
+	if currentDay > history.lastDay {
+		history.lastDay = currentDay
+	}
+	sample := currentDay / history.sampling
+	if previousDay == preHistoryDay {
+		if sample >= len(history.preHistory) {
+			history.preHistory = append(
+				history.preHistory, make([]int64, sample+1-len(history.preHistory))...)
+		}
+		history.preHistory[sample] += delta
+		return
+	}
+	band := previousDay / history.granularity
+	if history.cohorts != nil {
+		history.cohorts.advance(currentDay / history.granularity)
+		history.compact()
+		band = history.cohorts.index(band)
+	}
+	if sample >= len(history.rows) {
+		history.rows = append(history.rows, make([]historyRow, sample+1-len(history.rows))...)
+	}
+	row := &history.rows[sample]
+	switch {
+	case row.values == nil:
+		row.first = band
+		row.values = make([]int64, 1)
+	case band < row.first:
+		row.values = append(make([]int64, row.first-band), row.values...)
+		row.first = band
+	case band >= row.first+len(row.values):
+		row.values = append(row.values, make([]int64, band+1-row.first-len(row.values))...)
+	}
+	row.values[band-row.first] += delta
+}
+
+// compact replays the merges of the cohorts which have not been applied to the rows yet.
+func (history *sparseHistory) compact() {
+	for _, merged := range history.cohorts.merges[history.compacted:] {
+		for i := range history.rows {
+			history.rows[i].merge(merged)
+		}
+	}
+	history.compacted = len(history.cohorts.merges)
+}
+
+// merge adds column i+1 to column i and shifts the following columns by one.
+func (row *historyRow) merge(i int) {
+	switch {
+	case row.values == nil || i >= row.first+len(row.values)-1:
+	case i+1 <= row.first:
+		row.first--
+	default:
+		pos := i - row.first
+		row.values[pos] += row.values[pos+1]
+		row.values = append(row.values[:pos+1], row.values[pos+2:]...)
+	}
+}
+
+// get returns the accumulated delta in the specified sample and column: the band or the cohort.
+func (history *sparseHistory) get(sample, band int) int64 {
+	if sample >= len(history.rows) {
+		return 0
+	}
+	row := history.rows[sample]
+	if band < row.first || band >= row.first+len(row.values) {
+		return 0
+	}
+	return row.values[band-row.first]
+}
+
+// dense integrates the deltas into the matrix [number of samples][number of bands].
+// lastDay sets the size of the matrix; it is taken from the history itself if negative.
+// Returns the matrix and the used lastDay.
+func (history *sparseHistory) dense(lastDay int) (DenseHistory, int) {
+	if history.empty() {
+		panic("empty history")
+	}
+	if lastDay < 0 {
+		lastDay = history.lastDay
+	} else if history.lastDay > lastDay {
+		panic("days corruption")
+	}
+	samples := lastDay/history.sampling + 1
+	bands := lastDay/history.granularity + 1
+	if history.cohorts != nil {
+		history.compact()
+		return history.expand(samples, bands), lastDay
+	}
+	result := make(DenseHistory, samples)
+	for i := range result {
+		result[i] = make([]int64, bands)
+		if i > 0 {
+			copy(result[i], result[i-1])
+		}
+		if i < len(history.rows) {
+			row := history.rows[i]
+			for j, value := range row.values {
+				result[i][row.first+j] += value
+			}
+		}
+	}
+	return result, lastDay
+}
+
+// densePreHistory integrates the deltas of the lines which existed before the first analysed
+// commit into the vector [number of samples]. Returns nil if there were no such lines.
+func (history *sparseHistory) densePreHistory(samples int) []int64 {
+	if len(history.preHistory) == 0 {
+		return nil
+	}
+	result := make([]int64, samples)
+	for i := range result {
+		if i > 0 {
+			result[i] = result[i-1]
+		}
+		if i < len(history.preHistory) {
+			result[i] += history.preHistory[i]
+		}
+	}
+	return result
+}
+
+// expand integrates the deltas of the compacted history and spreads the lines of each cohort
+// uniformly over its bands which existed at the time of each sample.
+func (history *sparseHistory) expand(samples, bands int) DenseHistory {
+	starts := history.cohorts.starts
+	state := make([]int64, len(starts))
+	result := make(DenseHistory, samples)
+	for i := range result {
+		result[i] = make([]int64, bands)
+		if i < len(history.rows) {
+			row := history.rows[i]
+			for j, value := range row.values {
+				state[row.first+j] += value
+			}
+		}
+		// the last band which exists at the end of the sample
+		last := ((i+1)*history.sampling - 1) / history.granularity
+		if last >= bands {
+			last = bands - 1
+		}
+		for c, value := range state {
+			if value == 0 || starts[c] > last {
+				continue
+			}
+			end := last + 1
+			if c+1 < len(starts) && starts[c+1] < end {
+				end = starts[c+1]
+			}
+			width := int64(end - starts[c])
+			for k := int64(0); k < width; k++ {
+				result[i][starts[c]+int(k)] += value*(k+1)/width - value*k/width
+			}
+		}
+	}
+	return result
+}
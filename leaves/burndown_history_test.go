@@ -0,0 +1,159 @@
+package leaves
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSparseHistoryAdd(t *testing.T) {
+	history := newSparseHistory(10, 10)
+	assert.True(t, history.empty())
+	history.add(0, 0, 50)
+	history.add(10, 0, -10)
+	history.add(10, 10, 20)
+	history.add(12, 0, -5)
+	history.add(12, 10, -3)
+	history.add(12, 12, 10)
+	assert.False(t, history.empty())
+	assert.Equal(t, history.lastDay, 12)
+	assert.Equal(t, history.rows, []historyRow{
+		{first: 0, values: []int64{50}}, {first: 0, values: []int64{-15, 27}}})
+	// the samples without changes are not allocated
+	history.add(45, 30, 7)
+	history.add(45, 41, 1)
+	history.add(47, 12, -2)
+	assert.Len(t, history.rows, 5)
+	assert.Nil(t, history.rows[2].values)
+	assert.Nil(t, history.rows[3].values)
+	assert.Equal(t, history.rows[4], historyRow{first: 1, values: []int64{-2, 0, 7, 1}})
+	assert.Equal(t, history.get(4, 3), int64(7))
+	assert.Equal(t, history.get(4, 0), int64(0))
+	assert.Equal(t, history.get(2, 0), int64(0))
+	assert.Equal(t, history.get(10, 0), int64(0))
+	// no overflow on huge files
+	history.add(0, 0, 1<<40)
+	assert.Equal(t, history.get(0, 0), int64(1<<40+50))
+}
+
+func TestSparseHistoryDense(t *testing.T) {
+	history := newSparseHistory(10, 20)
+	assert.Panics(t, func() { history.dense(-1) })
+	history.add(0, 0, 10)
+	history.add(25, 0, -2)
+	history.add(25, 25, 5)
+	matrix, lastDay := history.dense(-1)
+	assert.Equal(t, lastDay, 25)
+	assert.Equal(t, matrix, DenseHistory{{10, 0}, {10, 0}, {8, 5}})
+	matrix, lastDay = history.dense(40)
+	assert.Equal(t, lastDay, 40)
+	assert.Equal(t, matrix, DenseHistory{{10, 0, 0}, {10, 0, 0}, {8, 5, 0}, {8, 5, 0}, {8, 5, 0}})
+	assert.Panics(t, func() { history.dense(20) })
+}
+
+func TestBandCohorts(t *testing.T) {
+	cohorts := newBandCohorts(0)
+	assert.Equal(t, cohorts.recent, 1)
+	cohorts = newBandCohorts(2)
+	cohorts.advance(3)
+	assert.Equal(t, cohorts.starts, []int{0, 1, 2, 3})
+	assert.Len(t, cohorts.merges, 0)
+	cohorts.advance(4)
+	// 0 1 2 are old and have the same width
+	assert.Equal(t, cohorts.starts, []int{0, 2, 3, 4})
+	assert.Equal(t, cohorts.merges, []int{0})
+	cohorts.advance(2)
+	assert.Equal(t, cohorts.starts, []int{0, 2, 3, 4})
+	cohorts.advance(100)
+	widths := map[int]int{}
+	for i := 0; i+1 < len(cohorts.starts); i++ {
+		width := cohorts.starts[i+1] - cohorts.starts[i]
+		widths[width]++
+		if i > 0 {
+			assert.True(t, width <= cohorts.starts[i]-cohorts.starts[i-1])
+		}
+	}
+	for _, count := range widths {
+		assert.True(t, count <= 2)
+	}
+	assert.True(t, len(cohorts.starts) < 16)
+	assert.Equal(t, cohorts.starts[len(cohorts.starts)-2:], []int{99, 100})
+	assert.Equal(t, cohorts.index(0), 0)
+	assert.Equal(t, cohorts.index(100), len(cohorts.starts)-1)
+	assert.Equal(t, cohorts.index(99), len(cohorts.starts)-2)
+}
+
+func TestHistoryRowMerge(t *testing.T) {
+	row := historyRow{first: 2, values: []int64{1, 2, 3}}
+	row.merge(5)
+	assert.Equal(t, row, historyRow{first: 2, values: []int64{1, 2, 3}})
+	row.merge(4)
+	assert.Equal(t, row, historyRow{first: 2, values: []int64{1, 2, 3}})
+	row.merge(2)
+	assert.Equal(t, row, historyRow{first: 2, values: []int64{3, 3}})
+	row.merge(1)
+	assert.Equal(t, row, historyRow{first: 1, values: []int64{3, 3}})
+	row.merge(0)
+	assert.Equal(t, row, historyRow{first: 0, values: []int64{3, 3}})
+	row = historyRow{}
+	row.merge(0)
+	assert.Equal(t, row, historyRow{})
+}
+
+func TestSparseHistoryCompacted(t *testing.T) {
+	fill := func(history *sparseHistory) {
+		for day := 0; day < 200; day += 3 {
+			history.add(day, day, 10)
+			if day >= 30 {
+				history.add(day, day-30, -3)
+				history.add(day, day/2, -2)
+			}
+		}
+	}
+	plain := newSparseHistory(10, 10)
+	fill(plain)
+	expected, _ := plain.dense(-1)
+
+	history := newSparseHistory(10, 10)
+	history.cohorts = newBandCohorts(100)
+	fill(history)
+	matrix, _ := history.dense(-1)
+	assert.Equal(t, matrix, expected)
+
+	history = newSparseHistory(10, 10)
+	history.cohorts = newBandCohorts(3)
+	fill(history)
+	assert.True(t, len(history.cohorts.starts) < 10)
+	for _, row := range history.rows {
+		assert.True(t, row.first+len(row.values) <= len(history.cohorts.starts))
+	}
+	matrix, _ = history.dense(-1)
+	assert.Len(t, matrix, len(expected))
+	for i, row := range matrix {
+		var sum, expectedSum int64
+		for j, value := range row {
+			sum += value
+			expectedSum += expected[i][j]
+			if j > i {
+				// no lines from the future
+				assert.Equal(t, value, int64(0))
+			}
+		}
+		assert.Equal(t, sum, expectedSum)
+	}
+	// the recent bands keep their resolution
+	last := len(matrix) - 1
+	assert.Equal(t, matrix[last][last-2:], expected[last][last-2:])
+
+	// another history catches up with the merges which happened before it was created
+	late := newSparseHistory(10, 10)
+	late.cohorts = history.cohorts
+	late.add(199, 5, 7)
+	assert.Equal(t, late.compacted, len(history.cohorts.merges))
+	matrix, _ = late.dense(199)
+	var sum int64
+	for _, value := range matrix[len(matrix)-1] {
+		sum += value
+	}
+	assert.Equal(t, sum, int64(7))
+}
@@ -0,0 +1,152 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+)
+
+// BurndownSurvival contains the survival statistics of the lines derived from
+// BurndownResult.GlobalHistory. The lines added in the same band form a cohort; the age
+// of a cohort is counted in samples since the end of its band.
+type BurndownSurvival struct {
+	// Curve is the fraction of the lines which are still alive at each age. It is the product
+	// of the ratios of the lines which survived each sample among all the cohorts observed
+	// at that age, similar to the Kaplan-Meier estimator.
+	Curve []float32
+	// HalfLife is the median line lifetime in days: the age at which Curve drops to 1/2.
+	// It is negative if more than half of the lines are still alive.
+	HalfLife float32
+	// Cohorts are indexed by band.
+	Cohorts []BurndownCohort
+}
+
+// BurndownCohort contains the survival statistics of the lines added in a band.
+type BurndownCohort struct {
+	// Lines is the number of the lines at the end of the band.
+	Lines int64
+	// Curve is the fraction of Lines which are still alive at each age.
+	Curve []float32
+	// HalfLife is the median lifetime of the cohort's lines in days, negative if
+	// more than half of them are still alive.
+	HalfLife float32
+}
+
+// newBurndownSurvival calculates the survival statistics of the burndown matrix.
+// The last band is excluded since it has not ended yet; so are the empty bands.
+func newBurndownSurvival(history DenseHistory, sampling, granularity int) *BurndownSurvival {
+	survival := &BurndownSurvival{HalfLife: -1}
+	if len(history) == 0 {
+		return survival
+	}
+	// alive and before are the sums of the lines of the cohorts observed at each age
+	// in the current and in the previous sample
+	var alive, before []int64
+	for band := range history[len(history)-1] {
+		cohort := BurndownCohort{HalfLife: -1}
+		// the sample which contains the last day of the band
+		start := ((band+1)*granularity - 1) / sampling
+		if start < len(history) && history[start][band] > 0 {
+			cohort.Lines = history[start][band]
+			cohort.Curve = make([]float32, len(history)-start)
+			for age := range cohort.Curve {
+				lines := history[start+age][band]
+				cohort.Curve[age] = float32(lines) / float32(cohort.Lines)
+				if age >= len(alive) {
+					alive = append(alive, 0)
+					before = append(before, 0)
+				}
+				alive[age] += lines
+				if age > 0 {
+					before[age] += history[start+age-1][band]
+				} else {
+					before[age] += lines
+				}
+			}
+			cohort.HalfLife = survivalHalfLife(cohort.Curve, sampling)
+		}
+		survival.Cohorts = append(survival.Cohorts, cohort)
+	}
+	survival.Curve = make([]float32, len(alive))
+	fraction := float32(1)
+	for age := range alive {
+		if before[age] > 0 {
+			fraction *= float32(alive[age]) / float32(before[age])
+		}
+		survival.Curve[age] = fraction
+	}
+	survival.HalfLife = survivalHalfLife(survival.Curve, sampling)
+	return survival
+}
+
+// survivalHalfLife finds the age in days at which the survival curve drops to 1/2.
+// The curve is interpolated linearly between the samples. Returns -1 if it never drops.
+func survivalHalfLife(curve []float32, sampling int) float32 {
+	for age, value := range curve {
+		if value > 0.5 {
+			continue
+		}
+		if age == 0 {
+			return 0
+		}
+		previous := curve[age-1]
+		return float32(sampling) * (float32(age-1) + (previous-0.5)/(previous-value))
+	}
+	return -1
+}
+
+// serializeText prints the survival statistics in YAML.
+func (survival *BurndownSurvival) serializeText(writer io.Writer) {
+	fmt.Fprintln(writer, "  survival:")
+	fmt.Fprintf(writer, "    half_life: %.4f\n", survival.HalfLife)
+	fmt.Fprint(writer, "    curve: ")
+	writeFloatList(writer, survival.Curve)
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "    cohorts:")
+	for _, cohort := range survival.Cohorts {
+		fmt.Fprintf(writer, "    - {lines: %d, half_life: %.4f, curve: ", cohort.Lines, cohort.HalfLife)
+		writeFloatList(writer, cohort.Curve)
+		fmt.Fprintln(writer, "}")
+	}
+}
+
+// writeFloatList prints the numbers in the YAML flow style.
+func writeFloatList(writer io.Writer, values []float32) {
+	fmt.Fprint(writer, "[")
+	for i, val := range values {
+		if i > 0 {
+			fmt.Fprint(writer, ", ")
+		}
+		fmt.Fprintf(writer, "%.4f", val)
+	}
+	fmt.Fprint(writer, "]")
+}
+
+// toPB converts the survival statistics to the Protocol Buffers message.
+func (survival *BurndownSurvival) toPB() *pb.BurndownSurvival {
+	message := &pb.BurndownSurvival{
+		Curve:    survival.Curve,
+		HalfLife: survival.HalfLife,
+		Cohorts:  make([]*pb.BurndownCohort, len(survival.Cohorts)),
+	}
+	for i, cohort := range survival.Cohorts {
+		message.Cohorts[i] = &pb.BurndownCohort{
+			Lines: cohort.Lines, Curve: cohort.Curve, HalfLife: cohort.HalfLife}
+	}
+	return message
+}
+
+// burndownSurvivalFromPB is the inverse of BurndownSurvival.toPB().
+func burndownSurvivalFromPB(message *pb.BurndownSurvival) *BurndownSurvival {
+	survival := &BurndownSurvival{
+		Curve:    message.Curve,
+		HalfLife: message.HalfLife,
+		Cohorts:  make([]BurndownCohort, len(message.Cohorts)),
+	}
+	for i, cohort := range message.Cohorts {
+		survival.Cohorts[i] = BurndownCohort{
+			Lines: cohort.Lines, Curve: cohort.Curve, HalfLife: cohort.HalfLife}
+	}
+	return survival
+}
@@ -0,0 +1,100 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+)
+
+func fixtureSurvivalHistory() DenseHistory {
+	return DenseHistory{
+		{100, 0, 0},
+		{80, 50, 0},
+		{40, 50, 20},
+		{20, 30, 20},
+	}
+}
+
+func TestBurndownSurvival(t *testing.T) {
+	survival := newBurndownSurvival(fixtureSurvivalHistory(), 10, 10)
+	assert.Len(t, survival.Cohorts, 3)
+	assert.Equal(t, survival.Cohorts[0].Lines, int64(100))
+	assert.Equal(t, survival.Cohorts[0].Curve, []float32{1, 0.8, 0.4, 0.2})
+	// 0.8 -> 0.4 crosses 0.5 at 3/4 of the second sample
+	assert.InDelta(t, survival.Cohorts[0].HalfLife, 17.5, 1e-5)
+	assert.Equal(t, survival.Cohorts[1].Lines, int64(50))
+	assert.Equal(t, survival.Cohorts[1].Curve, []float32{1, 1, 0.6})
+	assert.Equal(t, survival.Cohorts[1].HalfLife, float32(-1))
+	assert.Equal(t, survival.Cohorts[2].Curve, []float32{1, 1})
+	assert.Len(t, survival.Curve, 4)
+	assert.InDeltaSlice(t, survival.Curve, []float32{
+		1, 150.0 / 170, 150.0 / 170 * 70 / 130, 150.0 / 170 * 70 / 130 * 20 / 40}, 1e-5)
+	assert.True(t, survival.HalfLife > 10 && survival.HalfLife < 20)
+
+	// the band which has not ended yet is excluded
+	survival = newBurndownSurvival(DenseHistory{{10, 0}, {8, 5}}, 10, 20)
+	assert.Equal(t, survival.Cohorts[0], BurndownCohort{Lines: 8, Curve: []float32{1}, HalfLife: -1})
+	assert.Equal(t, survival.Cohorts[1], BurndownCohort{HalfLife: -1})
+	assert.Equal(t, survival.Curve, []float32{1})
+
+	survival = newBurndownSurvival(nil, 10, 10)
+	assert.Equal(t, survival, &BurndownSurvival{HalfLife: -1})
+}
+
+func TestSurvivalHalfLife(t *testing.T) {
+	assert.Equal(t, survivalHalfLife([]float32{1, 0.75, 0.25}, 30), float32(45))
+	assert.Equal(t, survivalHalfLife([]float32{0.4}, 30), float32(0))
+	assert.Equal(t, survivalHalfLife([]float32{1, 0.5}, 30), float32(30))
+	assert.Equal(t, survivalHalfLife([]float32{1, 0.6}, 30), float32(-1))
+	assert.Equal(t, survivalHalfLife(nil, 30), float32(-1))
+}
+
+func TestBurndownSurvivalFinalizeSerialize(t *testing.T) {
+	burndown := BurndownAnalysis{Granularity: 10, Sampling: 10, TrackSurvival: true}
+	burndown.Initialize(nil)
+	burndown.globalHistory.add(0, 0, 100)
+	burndown.globalHistory.add(10, 0, -20)
+	burndown.globalHistory.add(10, 10, 50)
+	burndown.globalHistory.add(25, 0, -40)
+	result := burndown.Finalize().(BurndownResult)
+	assert.NotNil(t, result.Survival)
+	assert.Equal(t, result.Survival.Cohorts[0].Curve, []float32{1, 0.8, 0.4})
+
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, burndown.Serialize(result, false, buffer))
+	assert.Contains(t, buffer.String(), `  survival:
+    half_life: 18.4615
+    curve: [1.0000, 0.8667, 0.4333]
+    cohorts:
+    - {lines: 100, half_life: 17.5000, curve: [1.0000, 0.8000, 0.4000]}
+    - {lines: 50, half_life: -1.0000, curve: [1.0000, 1.0000]}
+    - {lines: 0, half_life: -1.0000, curve: []}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, burndown.Serialize(result, true, buffer))
+	msg := pb.BurndownAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Survival.Cohorts, 3)
+	deserialized, err := burndown.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized.(BurndownResult).Survival, result.Survival)
+
+	merged := burndown.MergeResults(result, result,
+		&core.CommonAnalysisResult{BeginTime: 1500000000, EndTime: 1500000000 + 25*86400},
+		&core.CommonAnalysisResult{BeginTime: 1500000000, EndTime: 1500000000 + 25*86400},
+	).(BurndownResult)
+	assert.NotNil(t, merged.Survival)
+	assert.Len(t, merged.Survival.Cohorts, len(merged.GlobalHistory[len(merged.GlobalHistory)-1]))
+
+	burndown.TrackSurvival = false
+	result = burndown.Finalize().(BurndownResult)
+	assert.Nil(t, result.Survival)
+	assert.Nil(t, burndown.MergeResults(result, result,
+		&core.CommonAnalysisResult{BeginTime: 1500000000, EndTime: 1500000000 + 25*86400},
+		&core.CommonAnalysisResult{BeginTime: 1500000000, EndTime: 1500000000 + 25*86400},
+	).(BurndownResult).Survival)
+}
@@ -6,14 +6,18 @@ import (
 	"io/ioutil"
 	"path"
 	"testing"
+	"time"
 
 	"gopkg.in/src-d/hercules.v4/internal/core"
 	"gopkg.in/src-d/hercules.v4/internal/test/fixtures"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
 	"gopkg.in/src-d/hercules.v4/internal/pb"
 	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
 	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
@@ -24,18 +28,29 @@ func TestBurndownMeta(t *testing.T) {
 	burndown := BurndownAnalysis{}
 	assert.Equal(t, burndown.Name(), "Burndown")
 	assert.Len(t, burndown.Provides(), 0)
-	required := [...]string{
+	assert.Equal(t, burndown.Requires(), []string{
 		items.DependencyFileDiff, items.DependencyTreeChanges, items.DependencyBlobCache,
-		items.DependencyDay, identity.DependencyAuthor}
-	for _, name := range required {
-		assert.Contains(t, burndown.Requires(), name)
-	}
+		items.DependencyDay, identity.DependencyAuthor, identity.DependencyCoAuthors})
+	var dynamic core.DynamicPipelineItem = &burndown
+	dynamic.ConfigureRequirements(map[string]interface{}{
+		ConfigBurndownTrackLanguages:   true,
+		ConfigBurndownIgnoreFormatting: true,
+		ConfigBurndownGranularity:      100,
+	})
+	assert.Contains(t, burndown.Requires(), items.DependencyLanguages)
+	assert.Contains(t, burndown.Requires(), items.DependencyFormattingHunks)
+	assert.Equal(t, burndown.Granularity, 0)
+	burndown.TrackLanguages = false
+	burndown.IgnoreFormatting = false
 	opts := burndown.ListConfigurationOptions()
 	matches := 0
 	for _, opt := range opts {
 		switch opt.Name {
 		case ConfigBurndownGranularity, ConfigBurndownSampling, ConfigBurndownTrackFiles,
-			ConfigBurndownTrackPeople, ConfigBurndownDebug:
+			ConfigBurndownTrackPeople, ConfigBurndownDebug, ConfigBurndownHistoryBoundary,
+			ConfigBurndownTrackTree, ConfigBurndownMaxSamples, ConfigBurndownDirectoryDepth,
+			ConfigBurndownTrackLanguages, ConfigBurndownTrackSurvival, ConfigBurndownCompactBands,
+			ConfigBurndownIgnoreFormatting:
 			matches++
 		}
 	}
@@ -48,18 +63,37 @@ func TestBurndownConfigure(t *testing.T) {
 	facts := map[string]interface{}{}
 	facts[ConfigBurndownGranularity] = 100
 	facts[ConfigBurndownSampling] = 200
+	facts[ConfigBurndownMaxSamples] = 300
+	facts[ConfigBurndownCompactBands] = 12
 	facts[ConfigBurndownTrackFiles] = true
+	facts[ConfigBurndownDirectoryDepth] = 2
+	facts[ConfigBurndownTrackLanguages] = true
+	facts[ConfigBurndownTrackSurvival] = true
 	facts[ConfigBurndownTrackPeople] = true
 	facts[ConfigBurndownDebug] = true
+	facts[ConfigBurndownHistoryBoundary] = BurndownBoundaryBlame
+	facts[ConfigBurndownIgnoreFormatting] = true
 	facts[identity.FactIdentityDetectorPeopleCount] = 5
-	facts[identity.FactIdentityDetectorReversedPeopleDict] = burndown.Requires()
+	people := []string{"one@srcd", "two@srcd"}
+	facts[identity.FactIdentityDetectorReversedPeopleDict] = people
+	facts[identity.FactIdentityDetectorPeopleDict] = map[string]int{"one@srcd": 0}
 	burndown.Configure(facts)
 	assert.Equal(t, burndown.Granularity, 100)
 	assert.Equal(t, burndown.Sampling, 200)
+	assert.Equal(t, burndown.MaxSamples, 300)
+	assert.Equal(t, burndown.CompactBands, 12)
 	assert.Equal(t, burndown.TrackFiles, true)
+	assert.Equal(t, burndown.DirectoryDepth, 2)
+	assert.Equal(t, burndown.TrackLanguages, true)
+	assert.Equal(t, burndown.TrackSurvival, true)
 	assert.Equal(t, burndown.PeopleNumber, 5)
 	assert.Equal(t, burndown.Debug, true)
-	assert.Equal(t, burndown.reversedPeopleDict, burndown.Requires())
+	assert.Equal(t, burndown.HistoryBoundary, BurndownBoundaryBlame)
+	assert.Equal(t, burndown.IgnoreFormatting, true)
+	assert.Equal(t, burndown.reversedPeopleDict, people)
+	assert.Contains(t, burndown.Requires(), items.DependencyLanguages)
+	assert.Contains(t, burndown.Requires(), items.DependencyFormattingHunks)
+	assert.Equal(t, burndown.peopleDict, map[string]int{"one@srcd": 0})
 	facts[ConfigBurndownTrackPeople] = false
 	facts[identity.FactIdentityDetectorPeopleCount] = 50
 	burndown.Configure(facts)
@@ -71,7 +105,7 @@ func TestBurndownConfigure(t *testing.T) {
 	assert.Equal(t, burndown.TrackFiles, true)
 	assert.Equal(t, burndown.PeopleNumber, 0)
 	assert.Equal(t, burndown.Debug, true)
-	assert.Equal(t, burndown.reversedPeopleDict, burndown.Requires())
+	assert.Equal(t, burndown.reversedPeopleDict, people)
 }
 
 func TestBurndownRegistration(t *testing.T) {
@@ -106,6 +140,336 @@ func TestBurndownInitialize(t *testing.T) {
 	burndown.Initialize(test.Repository)
 	assert.Equal(t, burndown.Sampling, DefaultBurndownGranularity-1)
 	assert.Equal(t, burndown.Granularity, DefaultBurndownGranularity)
+	assert.Equal(t, burndown.HistoryBoundary, BurndownBoundaryCommit)
+	burndown.HistoryBoundary = "xxx"
+	burndown.Initialize(test.Repository)
+	assert.Equal(t, burndown.HistoryBoundary, BurndownBoundaryCommit)
+	burndown.HistoryBoundary = BurndownBoundaryPreHistory
+	burndown.Initialize(test.Repository)
+	assert.Equal(t, burndown.HistoryBoundary, BurndownBoundaryPreHistory)
+	burndown.MaxSamples = -5
+	burndown.Initialize(test.Repository)
+	assert.Equal(t, burndown.MaxSamples, 0)
+	burndown.DirectoryDepth = -1
+	burndown.Initialize(test.Repository)
+	assert.Equal(t, burndown.DirectoryDepth, 0)
+}
+
+func TestBurndownDirectories(t *testing.T) {
+	storage := memory.NewStorage()
+	encoded := storage.NewEncodedObject()
+	encoded.SetType(plumbing.BlobObject)
+	writer, _ := encoded.Writer()
+	writer.Write([]byte("one\ntwo\nthree\n"))
+	writer.Close()
+	hash, err := storage.SetEncodedObject(encoded)
+	assert.Nil(t, err)
+	blob, err := object.GetBlob(storage, hash)
+	assert.Nil(t, err)
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	consume := func(burndown *BurndownAnalysis, day int, changes object.Changes,
+		fileDiffs map[string]items.FileDiffData) {
+		_, err := burndown.Consume(map[string]interface{}{
+			identity.DependencyAuthor:   0,
+			items.DependencyDay:         day,
+			core.DependencyIsMerge:      false,
+			items.DependencyBlobCache:   map[plumbing.Hash]*object.Blob{hash: blob},
+			items.DependencyFileDiff:    fileDiffs,
+			items.DependencyTreeChanges: changes,
+			core.DependencyCommit:       &object.Commit{},
+		})
+		assert.Nil(t, err)
+	}
+	burndown := BurndownAnalysis{Granularity: 30, Sampling: 30, DirectoryDepth: 1}
+	burndown.Initialize(nil)
+	assert.Equal(t, burndown.directoryOf("src/core/a.go"), "src")
+	assert.Equal(t, burndown.directoryOf("README"), ".")
+	consume(&burndown, 0, object.Changes{
+		{To: entry("src/core/a.go")}, {To: entry("README")}}, map[string]items.FileDiffData{})
+	// the file moves to another directory together with its old lines
+	consume(&burndown, 35, object.Changes{{From: entry("src/core/a.go"), To: entry("lib/a.go")}},
+		map[string]items.FileDiffData{"lib/a.go": {
+			OldLinesOfCode: 3, NewLinesOfCode: 4, Diffs: []diffmatchpatch.Diff{
+				{Type: diffmatchpatch.DiffEqual, Text: "abc"},
+				{Type: diffmatchpatch.DiffInsert, Text: "d"},
+			}}})
+	result := burndown.Finalize().(BurndownResult)
+	assert.Equal(t, result.GlobalHistory, DenseHistory{{6, 0}, {6, 1}})
+	assert.Nil(t, result.FileHistories["lib/a.go"])
+	assert.Equal(t, result.DirectoryHistories, map[string]DenseHistory{
+		".":   {{3, 0}, {3, 0}},
+		"lib": {{0, 0}, {3, 1}},
+		"src": {{3, 0}, {0, 0}},
+	})
+
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, burndown.Serialize(result, false, buffer))
+	assert.Contains(t, buffer.String(), "  directories:\n    \".\": |-\n")
+	buffer.Reset()
+	assert.Nil(t, burndown.Serialize(result, true, buffer))
+	deserialized, err := burndown.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized.(BurndownResult).DirectoryHistories, result.DirectoryHistories)
+
+	burndown = BurndownAnalysis{Granularity: 30, Sampling: 30, DirectoryDepth: 2}
+	burndown.Initialize(nil)
+	assert.Equal(t, burndown.directoryOf("src/core/util/a.go"), "src/core")
+	assert.Equal(t, burndown.directoryOf("src/a.go"), "src")
+}
+
+func TestBurndownLanguages(t *testing.T) {
+	storage := memory.NewStorage()
+	encoded := storage.NewEncodedObject()
+	encoded.SetType(plumbing.BlobObject)
+	writer, _ := encoded.Writer()
+	writer.Write([]byte("one\ntwo\nthree\n"))
+	writer.Close()
+	hash, err := storage.SetEncodedObject(encoded)
+	assert.Nil(t, err)
+	blob, err := object.GetBlob(storage, hash)
+	assert.Nil(t, err)
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	consume := func(burndown *BurndownAnalysis, day int, changes object.Changes,
+		fileDiffs map[string]items.FileDiffData) {
+		deps := map[string]interface{}{
+			identity.DependencyAuthor:   0,
+			items.DependencyDay:         day,
+			core.DependencyIsMerge:      false,
+			items.DependencyBlobCache:   map[plumbing.Hash]*object.Blob{hash: blob},
+			items.DependencyFileDiff:    fileDiffs,
+			items.DependencyTreeChanges: changes,
+			core.DependencyCommit:       &object.Commit{},
+		}
+		languages, err := (&items.LanguagesDetection{}).Consume(deps)
+		assert.Nil(t, err)
+		deps[items.DependencyLanguages] = languages[items.DependencyLanguages]
+		_, err = burndown.Consume(deps)
+		assert.Nil(t, err)
+	}
+	burndown := BurndownAnalysis{Granularity: 30, Sampling: 30, TrackLanguages: true}
+	burndown.Initialize(nil)
+	consume(&burndown, 0, object.Changes{
+		{To: entry("src/main.c")}, {To: entry("setup.py")}}, map[string]items.FileDiffData{})
+	assert.Equal(t, burndown.fileLanguages, map[string]string{"src/main.c": "C", "setup.py": "Python"})
+	// the file is rewritten in another language, its old lines keep their age
+	consume(&burndown, 35, object.Changes{{From: entry("src/main.c"), To: entry("src/main.go")}},
+		map[string]items.FileDiffData{"src/main.go": {
+			OldLinesOfCode: 3, NewLinesOfCode: 4, Diffs: []diffmatchpatch.Diff{
+				{Type: diffmatchpatch.DiffEqual, Text: "abc"},
+				{Type: diffmatchpatch.DiffInsert, Text: "d"},
+			}}})
+	assert.Equal(t, burndown.fileLanguages, map[string]string{"src/main.go": "Go", "setup.py": "Python"})
+	consume(&burndown, 40, object.Changes{{From: entry("setup.py")}}, map[string]items.FileDiffData{})
+	assert.Equal(t, burndown.fileLanguages, map[string]string{"src/main.go": "Go"})
+	result := burndown.Finalize().(BurndownResult)
+	assert.Equal(t, result.GlobalHistory, DenseHistory{{6, 0}, {3, 1}})
+	assert.Nil(t, result.DirectoryHistories)
+	assert.Equal(t, result.LanguageHistories, map[string]DenseHistory{
+		"C":      {{3, 0}, {0, 0}},
+		"Go":     {{0, 0}, {3, 1}},
+		"Python": {{3, 0}, {0, 0}},
+	})
+
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, burndown.Serialize(result, false, buffer))
+	assert.Contains(t, buffer.String(), "  languages:\n    \"C\": |-\n")
+	buffer.Reset()
+	assert.Nil(t, burndown.Serialize(result, true, buffer))
+	deserialized, err := burndown.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized.(BurndownResult).LanguageHistories, result.LanguageHistories)
+	merged := burndown.MergeResults(result, BurndownResult{
+		GlobalHistory:     DenseHistory{{2}},
+		LanguageHistories: map[string]DenseHistory{"Rust": {{2}}},
+		sampling:          30, granularity: 30,
+	}, &core.CommonAnalysisResult{BeginTime: 1500000000, EndTime: 1500000000 + 40*24*3600},
+		&core.CommonAnalysisResult{BeginTime: 1500000000, EndTime: 1500000000 + 24*3600},
+	).(BurndownResult)
+	assert.Len(t, merged.LanguageHistories, 4)
+	assert.Contains(t, merged.LanguageHistories, "Rust")
+}
+
+func TestBurndownIgnoreFormatting(t *testing.T) {
+	storage := memory.NewStorage()
+	encoded := storage.NewEncodedObject()
+	encoded.SetType(plumbing.BlobObject)
+	writer, _ := encoded.Writer()
+	writer.Write([]byte("one\ntwo\nthree\n"))
+	writer.Close()
+	hash, err := storage.SetEncodedObject(encoded)
+	assert.Nil(t, err)
+	blob, err := object.GetBlob(storage, hash)
+	assert.Nil(t, err)
+	entry := object.ChangeEntry{Name: "a.go", TreeEntry: object.TreeEntry{Name: "a.go", Hash: hash}}
+	consume := func(burndown *BurndownAnalysis, day int, changes object.Changes,
+		fileDiffs map[string]items.FileDiffData, formatting map[string][]bool) {
+		_, err := burndown.Consume(map[string]interface{}{
+			identity.DependencyAuthor:       0,
+			items.DependencyDay:             day,
+			core.DependencyIsMerge:          false,
+			items.DependencyBlobCache:       map[plumbing.Hash]*object.Blob{hash: blob},
+			items.DependencyFileDiff:        fileDiffs,
+			items.DependencyTreeChanges:     changes,
+			items.DependencyFormattingHunks: formatting,
+			core.DependencyCommit:           &object.Commit{},
+		})
+		assert.Nil(t, err)
+	}
+	run := func(ignore bool) DenseHistory {
+		burndown := BurndownAnalysis{Granularity: 30, Sampling: 30}
+		burndown.Configure(map[string]interface{}{ConfigBurndownIgnoreFormatting: ignore})
+		burndown.Initialize(nil)
+		consume(&burndown, 0, object.Changes{{To: entry}}, map[string]items.FileDiffData{}, nil)
+		// the first hunk is reformatted and splits a line in two
+		consume(&burndown, 35, object.Changes{{From: entry, To: entry}},
+			map[string]items.FileDiffData{"a.go": {
+				OldLinesOfCode: 3, NewLinesOfCode: 5, Diffs: []diffmatchpatch.Diff{
+					{Type: diffmatchpatch.DiffDelete, Text: "a"},
+					{Type: diffmatchpatch.DiffInsert, Text: "dg"},
+					{Type: diffmatchpatch.DiffEqual, Text: "b"},
+					{Type: diffmatchpatch.DiffDelete, Text: "c"},
+					{Type: diffmatchpatch.DiffInsert, Text: "ef"},
+				}}},
+			map[string][]bool{"a.go": {true, false}})
+		return burndown.Finalize().(BurndownResult).GlobalHistory
+	}
+	assert.Equal(t, run(true), DenseHistory{{3, 0}, {2, 3}})
+	assert.Equal(t, run(false), DenseHistory{{3, 0}, {1, 4}})
+}
+
+// fixtureBurndownBoundary stores the root commit by one@srcd and its child by two@srcd
+// which both have "three.txt" with 3 lines. Returns the child commit and the blob.
+func fixtureBurndownBoundary(t *testing.T) (*object.Commit, *object.Blob) {
+	storage := memory.NewStorage()
+	store := func(object interface {
+		Encode(plumbing.EncodedObject) error
+	}) plumbing.Hash {
+		encoded := storage.NewEncodedObject()
+		assert.Nil(t, object.Encode(encoded))
+		hash, err := storage.SetEncodedObject(encoded)
+		assert.Nil(t, err)
+		return hash
+	}
+	encoded := storage.NewEncodedObject()
+	encoded.SetType(plumbing.BlobObject)
+	writer, _ := encoded.Writer()
+	writer.Write([]byte("one\ntwo\nthree\n"))
+	writer.Close()
+	hash, err := storage.SetEncodedObject(encoded)
+	assert.Nil(t, err)
+	blob, err := object.GetBlob(storage, hash)
+	assert.Nil(t, err)
+	treeHash := store(&object.Tree{Entries: []object.TreeEntry{
+		{Name: "three.txt", Mode: filemode.Regular, Hash: hash}}})
+	signature := func(name string, days int) object.Signature {
+		return object.Signature{Name: name, Email: name + "@srcd",
+			When: time.Unix(1500000000+int64(days)*24*3600, 0).UTC()}
+	}
+	rootHash := store(&object.Commit{
+		Author: signature("one", 0), Committer: signature("one", 0),
+		Message: "root", TreeHash: treeHash})
+	boundaryHash := store(&object.Commit{
+		Author: signature("two", 1), Committer: signature("two", 1),
+		Message: "boundary", TreeHash: treeHash, ParentHashes: []plumbing.Hash{rootHash}})
+	boundary, err := object.GetCommit(storage, boundaryHash)
+	assert.Nil(t, err)
+	return boundary, blob
+}
+
+func TestBurndownHistoryBoundary(t *testing.T) {
+	commit, blob := fixtureBurndownBoundary(t)
+	deps := map[string]interface{}{
+		identity.DependencyAuthor: 1,
+		items.DependencyDay:       0,
+		core.DependencyIsMerge:    false,
+		items.DependencyBlobCache: map[plumbing.Hash]*object.Blob{blob.Hash: blob},
+		items.DependencyFileDiff:  map[string]items.FileDiffData{},
+		items.DependencyTreeChanges: object.Changes{&object.Change{To: object.ChangeEntry{
+			Name: "three.txt", TreeEntry: object.TreeEntry{Name: "three.txt", Hash: blob.Hash}}}},
+		core.DependencyCommit: commit,
+	}
+	for _, boundary := range []string{
+		BurndownBoundaryCommit, BurndownBoundaryPreHistory, BurndownBoundaryBlame} {
+		burndown := BurndownAnalysis{
+			Granularity: 30, Sampling: 30, PeopleNumber: 2, HistoryBoundary: boundary}
+		burndown.Initialize(test.Repository)
+		burndown.peopleDict = map[string]int{"one@srcd": 0, "two@srcd": 1}
+		_, err := burndown.Consume(deps)
+		assert.Nil(t, err)
+		assert.Nil(t, burndown.boundary)
+		assert.Equal(t, burndown.files["three.txt"].Len(), 3)
+		result := burndown.Finalize().(BurndownResult)
+		switch boundary {
+		case BurndownBoundaryCommit:
+			assert.Equal(t, result.GlobalHistory, DenseHistory{{3}})
+			assert.Nil(t, result.PreHistory)
+			assert.Equal(t, result.PeopleHistories[1], DenseHistory{{3}})
+			assert.Nil(t, result.PeoplePreHistories)
+		case BurndownBoundaryPreHistory:
+			// the lines are not in the band of the first analysed commit
+			assert.Equal(t, result.GlobalHistory, DenseHistory{{0}})
+			assert.Equal(t, result.PreHistory, []int64{3})
+			assert.Nil(t, burndown.peopleHistories[0])
+			assert.Nil(t, burndown.peopleHistories[1])
+			assert.Nil(t, result.PeoplePreHistories)
+		case BurndownBoundaryBlame:
+			// the lines belong to the author of the root commit
+			assert.Equal(t, result.GlobalHistory, DenseHistory{{0}})
+			assert.Equal(t, result.PreHistory, []int64{3})
+			assert.Equal(t, result.PeopleHistories[0], DenseHistory{{0}})
+			assert.Equal(t, result.PeoplePreHistories, [][]int64{{3}, nil})
+			assert.Equal(t, result.PeopleMatrix, DenseHistory{{3, 0, 0, 0}, {0, 0, 0, 0}})
+		}
+	}
+	// git blame is not possible without people
+	burndown := BurndownAnalysis{HistoryBoundary: BurndownBoundaryBlame}
+	burndown.Initialize(test.Repository)
+	assert.Nil(t, burndown.blameAuthors("three.txt", 3))
+	_, err := burndown.Consume(deps)
+	assert.Nil(t, err)
+	assert.Equal(t, burndown.globalHistory.get(0, 0), int64(0))
+	assert.Equal(t, burndown.globalHistory.preHistory, []int64{3})
+}
+
+func TestBurndownPreHistoryDecay(t *testing.T) {
+	burndown := BurndownAnalysis{
+		Granularity: 10, Sampling: 10, HistoryBoundary: BurndownBoundaryPreHistory}
+	burndown.Initialize(test.Repository)
+	burndown.day = 0
+	file, err := burndown.newBoundaryFile(plumbing.ZeroHash, "test.go", 0, 10)
+	assert.Nil(t, err)
+	burndown.day = 12
+	// replace 4 old lines with 2 new ones
+	file.Update(burndown.packPersonWithDay(0, 12), 3, 2, 4)
+	result := burndown.Finalize().(BurndownResult)
+	assert.Equal(t, result.GlobalHistory, DenseHistory{{0, 0}, {0, 2}})
+	assert.Equal(t, result.PreHistory, []int64{10, 6})
+	snapshots := burndown.snapshotFiles()
+	assert.Len(t, snapshots, 0)
+	burndown.files["test.go"] = file
+	assert.Equal(t, burndown.snapshotFiles()["test.go"].Ages, map[int]int64{0: 2, 12: 6})
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, burndown.Serialize(result, false, buffer))
+	assert.Contains(t, buffer.String(), "\n  pre_history: [10, 6]\n")
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, burndown.Serialize(result, true, buffer))
+	deserialized, err := burndown.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized.(BurndownResult).PreHistory, []int64{10, 6})
+	assert.Nil(t, deserialized.(BurndownResult).PeoplePreHistories)
+}
+
+func TestBurndownMergePreHistories(t *testing.T) {
+	c1 := &core.CommonAnalysisResult{BeginTime: 0, EndTime: 20 * 24 * 3600}
+	c2 := &core.CommonAnalysisResult{BeginTime: 10 * 24 * 3600, EndTime: 30 * 24 * 3600}
+	merged := mergePreHistories([]int64{10, 6}, []int64{4}, 10, 20, c1, c2)
+	assert.Equal(t, merged, []int64{10, 10, 4})
+	merged = mergePreHistories(nil, []int64{4}, 10, 20, c1, c2)
+	assert.Equal(t, merged, []int64{0, 4, 4})
 }
 
 func TestBurndownConsumeFinalize(t *testing.T) {
@@ -190,9 +554,9 @@ func TestBurndownConsumeFinalize(t *testing.T) {
 	assert.Equal(t, burndown.files["analyser.go"].Len(), 926)
 	assert.Equal(t, burndown.files[".travis.yml"].Len(), 12)
 	assert.Len(t, burndown.peopleHistories, 2)
-	assert.Equal(t, burndown.peopleHistories[0][0][0], int64(12+207+926))
-	assert.Len(t, burndown.globalHistory, 1)
-	assert.Equal(t, burndown.globalHistory[0][0], int64(12+207+926))
+	assert.Equal(t, burndown.peopleHistories[0].get(0, 0), int64(12+207+926))
+	assert.Len(t, burndown.globalHistory.rows, 1)
+	assert.Equal(t, burndown.globalHistory.get(0, 0), int64(12+207+926))
 	assert.Len(t, burndown.fileHistories, 3)
 	burndown2 := BurndownAnalysis{
 		Granularity: 30,
@@ -296,10 +660,10 @@ func TestBurndownConsumeFinalize(t *testing.T) {
 	assert.Equal(t, burndown.files["cmd/hercules/main.go"].Len(), 290)
 	assert.Equal(t, burndown.files["burndown.go"].Len(), 543)
 	assert.Len(t, burndown.peopleHistories, 2)
-	assert.Len(t, burndown.globalHistory, 2)
-	assert.Equal(t, burndown.globalHistory[0][0], int64(1145))
-	assert.Equal(t, burndown.globalHistory[30][0], int64(-681))
-	assert.Equal(t, burndown.globalHistory[30][30], int64(369))
+	assert.Len(t, burndown.globalHistory.rows, 2)
+	assert.Equal(t, burndown.globalHistory.get(0, 0), int64(1145))
+	assert.Equal(t, burndown.globalHistory.get(1, 0), int64(-681))
+	assert.Equal(t, burndown.globalHistory.get(1, 1), int64(369))
 	assert.Len(t, burndown.fileHistories, 2)
 	out := burndown.Finalize().(BurndownResult)
 	/*
@@ -572,6 +936,46 @@ func (c panickingCloser) Close() error {
 	return io.EOF
 }
 
+func TestBurndownDownsample(t *testing.T) {
+	burndown := BurndownAnalysis{Granularity: 30, Sampling: 30, PeopleNumber: 1, TrackFiles: true,
+		reversedPeopleDict: []string{"one@srcd"}}
+	burndown.Initialize(nil)
+	burndown.globalHistory.add(0, 0, 10)
+	burndown.globalHistory.add(35, 0, -2)
+	burndown.globalHistory.add(35, 35, 5)
+	burndown.globalHistory.add(70, 70, 3)
+	burndown.globalHistory.add(95, 0, -1)
+	burndown.fileHistories["file"] = burndown.newHistory()
+	burndown.fileHistories["file"].add(0, 0, 4)
+	burndown.fileHistories["file"].add(70, 70, 3)
+	result := burndown.Finalize().(BurndownResult)
+	assert.Equal(t, result.GlobalHistory, DenseHistory{
+		{10, 0, 0, 0}, {8, 5, 0, 0}, {8, 5, 3, 0}, {7, 5, 3, 0}})
+	assert.Equal(t, result.sampling, 30)
+	assert.Equal(t, result.granularity, 30)
+	burndown.MaxSamples = 4
+	result = burndown.Finalize().(BurndownResult)
+	assert.Len(t, result.GlobalHistory, 4)
+	burndown.MaxSamples = 2
+	result = burndown.Finalize().(BurndownResult)
+	assert.Equal(t, result.GlobalHistory, DenseHistory{{13, 0}, {12, 3}})
+	assert.Equal(t, result.FileHistories["file"], DenseHistory{{4, 0}, {4, 3}})
+	assert.Equal(t, result.PeopleHistories[0], DenseHistory{{0, 0}, {0, 0}})
+	assert.Equal(t, result.sampling, 60)
+	assert.Equal(t, result.granularity, 60)
+	buffer := &bytes.Buffer{}
+	burndown.Serialize(result, false, buffer)
+	assert.Contains(t, buffer.String(), "  granularity: 60\n  sampling: 60\n")
+}
+
+func TestDownsampleHistory(t *testing.T) {
+	history := DenseHistory{{1}, {2, 3}, {4, 5, 6}}
+	assert.Equal(t, downsampleHistory(history, 1, 1), history)
+	assert.Equal(t, downsampleHistory(history, 2, 1), DenseHistory{{2, 3}, {4, 5, 6}})
+	assert.Equal(t, downsampleHistory(history, 3, 2), DenseHistory{{9, 6}})
+	assert.Equal(t, downsampleHistory(history, 5, 5), DenseHistory{{15}})
+}
+
 func TestCheckClose(t *testing.T) {
 	closer := panickingCloser{}
 	assert.Panics(t, func() { checkClose(closer) })
@@ -915,6 +1319,8 @@ func TestBurndownMergeGlobalHistory(t *testing.T) {
 	}
 	res2.FileHistories["file2"] = res2.GlobalHistory
 	res2.FileHistories["file3"] = res2.GlobalHistory
+	res1.DirectoryHistories = map[string]DenseHistory{"src": res1.GlobalHistory}
+	res2.DirectoryHistories = map[string]DenseHistory{"src": res2.GlobalHistory}
 	res2.PeopleHistories = append(res2.PeopleHistories, res2.GlobalHistory)
 	res2.PeopleHistories = append(res2.PeopleHistories, res2.GlobalHistory)
 	res2.PeopleMatrix = append(res2.PeopleMatrix, make([]int64, 4))
@@ -938,6 +1344,7 @@ func TestBurndownMergeGlobalHistory(t *testing.T) {
 	assert.Equal(t, merged.FileHistories["file1"], res1.GlobalHistory)
 	assert.Equal(t, merged.FileHistories["file2"], merged.GlobalHistory)
 	assert.Equal(t, merged.FileHistories["file3"], res2.GlobalHistory)
+	assert.Equal(t, merged.DirectoryHistories, map[string]DenseHistory{"src": merged.GlobalHistory})
 	assert.Len(t, merged.reversedPeopleDict, 3)
 	assert.Equal(t, merged.PeopleHistories[0], res1.GlobalHistory)
 	assert.Equal(t, merged.PeopleHistories[1], merged.GlobalHistory)
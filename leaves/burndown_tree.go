@@ -0,0 +1,131 @@
+package leaves
+
+import (
+	"sort"
+	"strings"
+)
+
+// BurndownTreeNode is a directory or a file in the tree built by BuildBurndownTree().
+// It is designed to be marshalled to JSON and fed into treemap or sunburst visualizations.
+type BurndownTreeNode struct {
+	// Name is the last path component; it is empty for the root.
+	Name string `json:"name"`
+	// Lines is the number of lines in the file or in all the files in the directory.
+	Lines int64 `json:"lines"`
+	// Age is the distribution of the line ages in days.
+	Age BurndownTreeAge `json:"age"`
+	// Owners are the developers who own the most lines, sorted by the number of lines.
+	Owners []BurndownTreeOwner `json:"owners,omitempty"`
+	// Children are the nested files and directories sorted by name. Files do not have them.
+	Children []*BurndownTreeNode `json:"children,omitempty"`
+
+	ages     map[int]int64
+	owners   map[int]int64
+	children map[string]*BurndownTreeNode
+}
+
+// BurndownTreeAge contains the percentiles of the line ages in days.
+type BurndownTreeAge struct {
+	P10 int `json:"p10"`
+	P50 int `json:"p50"`
+	P90 int `json:"p90"`
+}
+
+// BurndownTreeOwner is the number of lines owned by a developer.
+type BurndownTreeOwner struct {
+	// Name is the developer's identity; it is empty for the unidentified developers.
+	Name  string `json:"name"`
+	Lines int64  `json:"lines"`
+}
+
+// BuildBurndownTree aggregates BurndownResult.FileSnapshots into the directory tree.
+// topOwners is the maximum number of owners in each node.
+func BuildBurndownTree(result BurndownResult, topOwners int) *BurndownTreeNode {
+	root := newBurndownTreeNode("")
+	for path, snapshot := range result.FileSnapshots {
+		nodes := []*BurndownTreeNode{root}
+		node := root
+		for _, name := range strings.Split(path, "/") {
+			child := node.child(name)
+			nodes = append(nodes, child)
+			node = child
+		}
+		for _, node := range nodes {
+			for age, lines := range snapshot.Ages {
+				node.Lines += lines
+				node.ages[age] += lines
+			}
+			for dev, lines := range snapshot.Owners {
+				node.owners[dev] += lines
+			}
+		}
+	}
+	root.finalize(result.reversedPeopleDict, topOwners)
+	return root
+}
+
+func newBurndownTreeNode(name string) *BurndownTreeNode {
+	return &BurndownTreeNode{Name: name, ages: map[int]int64{}, owners: map[int]int64{},
+		children: map[string]*BurndownTreeNode{}}
+}
+
+// child returns the existing child node with the specified name or creates a new one.
+func (node *BurndownTreeNode) child(name string) *BurndownTreeNode {
+	child := node.children[name]
+	if child == nil {
+		child = newBurndownTreeNode(name)
+		node.children[name] = child
+		node.Children = append(node.Children, child)
+	}
+	return child
+}
+
+// finalize calculates the percentiles and the top owners recursively.
+func (node *BurndownTreeNode) finalize(reversedPeopleDict []string, topOwners int) {
+	ages := make([]int, 0, len(node.ages))
+	for age := range node.ages {
+		ages = append(ages, age)
+	}
+	sort.Ints(ages)
+	percentile := func(p int64) int {
+		var sum int64
+		for _, age := range ages {
+			sum += node.ages[age]
+			if sum*100 >= node.Lines*p {
+				return age
+			}
+		}
+		return 0
+	}
+	node.Age = BurndownTreeAge{P10: percentile(10), P50: percentile(50), P90: percentile(90)}
+	devs := make([]int, 0, len(node.owners))
+	for dev := range node.owners {
+		devs = append(devs, dev)
+	}
+	sort.Slice(devs, func(i, j int) bool {
+		li, lj := node.owners[devs[i]], node.owners[devs[j]]
+		if li != lj {
+			return li > lj
+		}
+		return devs[i] < devs[j]
+	})
+	if len(devs) > topOwners {
+		devs = devs[:topOwners]
+	}
+	for _, dev := range devs {
+		owner := BurndownTreeOwner{Lines: node.owners[dev]}
+		if dev >= 0 && dev < len(reversedPeopleDict) {
+			owner.Name = reversedPeopleDict[dev]
+		}
+		node.Owners = append(node.Owners, owner)
+	}
+	sort.Slice(node.Children, func(i, j int) bool {
+		return node.Children[i].Name < node.Children[j].Name
+	})
+	for _, child := range node.Children {
+		child.finalize(reversedPeopleDict, topOwners)
+	}
+	node.ages = nil
+	node.owners = nil
+	node.children = nil
+}
@@ -0,0 +1,127 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/hercules.v4/internal/burndown"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureBurndownSnapshots() BurndownResult {
+	return BurndownResult{
+		FileSnapshots: map[string]BurndownFileSnapshot{
+			"README.md": {Ages: map[int]int64{0: 10}, Owners: map[int]int64{1: 10}},
+			"src/a.go": {
+				Ages: map[int]int64{0: 5, 10: 40, 100: 5}, Owners: map[int]int64{0: 45, -1: 5}},
+			"src/lib/b.go": {Ages: map[int]int64{200: 50}, Owners: map[int]int64{1: 50}},
+		},
+		reversedPeopleDict: []string{"alice", "bob"},
+	}
+}
+
+func TestBurndownSnapshotFiles(t *testing.T) {
+	bd := BurndownAnalysis{PeopleNumber: 2, TrackTree: true}
+	bd.files = map[string]*burndown.File{
+		"a": burndown.NewFileFromTree([]int{0, 10, 15, 20},
+			[]int{bd.packPersonWithDay(0, 3), bd.packPersonWithDay(1, 8),
+				bd.packPersonWithDay(identity.AuthorMissing, 3), burndown.TreeEnd}),
+		"empty": burndown.NewFile(0, 0),
+	}
+	bd.day = 10
+	assert.Equal(t, bd.snapshotFiles(), map[string]BurndownFileSnapshot{
+		"a":     {Ages: map[int]int64{7: 15, 2: 5}, Owners: map[int]int64{0: 10, 1: 5, -1: 5}},
+		"empty": {Ages: map[int]int64{}, Owners: map[int]int64{}},
+	})
+	bd.PeopleNumber = 0
+	bd.files = map[string]*burndown.File{
+		"a": burndown.NewFileFromTree([]int{0, 10, 20}, []int{3, 8, burndown.TreeEnd})}
+	assert.Equal(t, bd.snapshotFiles(), map[string]BurndownFileSnapshot{
+		"a": {Ages: map[int]int64{7: 10, 2: 10}, Owners: map[int]int64{}},
+	})
+}
+
+func TestBurndownSnapshotsSerialize(t *testing.T) {
+	bd := BurndownAnalysis{}
+	result := fixtureBurndownSnapshots()
+	result.GlobalHistory = DenseHistory{{100}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, bd.Serialize(result, false, buffer))
+	assert.Contains(t, buffer.String(), `  snapshots:
+    "README.md":
+      ages: {0: 10}
+      owners: {1: 10}
+    "src/a.go":
+      ages: {0: 5, 10: 40, 100: 5}
+      owners: {-1: 5, 0: 45}
+    "src/lib/b.go":
+      ages: {200: 50}
+      owners: {1: 50}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, bd.Serialize(result, true, buffer))
+	msg := pb.BurndownAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Snapshots, 3)
+	assert.Equal(t, msg.Snapshots[1].Name, "src/a.go")
+	assert.Equal(t, msg.Snapshots[1].Owners, map[int32]int64{0: 45, -1: 5})
+	decoded, err := bd.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, decoded.(BurndownResult).FileSnapshots, result.FileSnapshots)
+}
+
+func TestBurndownMergeFileSnapshots(t *testing.T) {
+	r1 := fixtureBurndownSnapshots()
+	r2 := BurndownResult{
+		FileSnapshots: map[string]BurndownFileSnapshot{
+			"src/a.go": {Ages: map[int]int64{0: 3}, Owners: map[int]int64{0: 3}},
+			"c.go":     {Ages: map[int]int64{1: 2}, Owners: map[int]int64{0: 2}},
+		},
+		reversedPeopleDict: []string{"carol"},
+	}
+	people, merged := identity.Detector{}.MergeReversedDicts(r1.reversedPeopleDict, r2.reversedPeopleDict)
+	snapshots := mergeFileSnapshots(r1, r2,
+		&core.CommonAnalysisResult{EndTime: 100 * 24 * 3600},
+		&core.CommonAnalysisResult{EndTime: 102 * 24 * 3600}, people, merged)
+	assert.Len(t, snapshots, 4)
+	assert.Equal(t, snapshots["src/a.go"], BurndownFileSnapshot{
+		Ages:   map[int]int64{2: 5, 12: 40, 102: 5, 0: 3},
+		Owners: map[int]int64{0: 45, -1: 5, 2: 3}})
+	assert.Equal(t, snapshots["c.go"], BurndownFileSnapshot{
+		Ages: map[int]int64{1: 2}, Owners: map[int]int64{2: 2}})
+}
+
+func TestBuildBurndownTree(t *testing.T) {
+	tree := BuildBurndownTree(fixtureBurndownSnapshots(), 2)
+	assert.Equal(t, tree.Name, "")
+	assert.Equal(t, tree.Lines, int64(110))
+	assert.Equal(t, tree.Age, BurndownTreeAge{P10: 0, P50: 10, P90: 200})
+	assert.Equal(t, tree.Owners, []BurndownTreeOwner{{"bob", 60}, {"alice", 45}})
+	assert.Len(t, tree.Children, 2)
+	readme := tree.Children[0]
+	assert.Equal(t, readme.Name, "README.md")
+	assert.Equal(t, readme.Lines, int64(10))
+	assert.Nil(t, readme.Children)
+	src := tree.Children[1]
+	assert.Equal(t, src.Name, "src")
+	assert.Equal(t, src.Lines, int64(100))
+	assert.Equal(t, src.Age, BurndownTreeAge{P10: 10, P50: 100, P90: 200})
+	assert.Len(t, src.Children, 2)
+	a := src.Children[0]
+	assert.Equal(t, a.Name, "a.go")
+	assert.Equal(t, a.Age, BurndownTreeAge{P10: 0, P50: 10, P90: 10})
+	assert.Equal(t, a.Owners, []BurndownTreeOwner{{"alice", 45}, {"", 5}})
+	lib := src.Children[1]
+	assert.Equal(t, lib.Name, "lib")
+	assert.Equal(t, lib.Children[0].Name, "b.go")
+	assert.Equal(t, lib.Children[0].Age, BurndownTreeAge{P10: 200, P50: 200, P90: 200})
+
+	tree = BuildBurndownTree(BurndownResult{FileSnapshots: map[string]BurndownFileSnapshot{
+		"a": {Ages: map[int]int64{5: 1}, Owners: map[int]int64{}}}}, 3)
+	assert.Nil(t, tree.Owners)
+	assert.Equal(t, tree.Children[0].Age, BurndownTreeAge{P10: 5, P50: 5, P90: 5})
+}
@@ -0,0 +1,453 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"path"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+// ChangeEntropyAnalysis calculates the Shannon entropy of the changes across the files and
+// the directories in each commit, on each day and in each tick of Sampling days, that is,
+// how scattered or focused the work was. The commits which touch many files across the tree
+// at once (shotgun surgery) have high entropy. Every file change in a commit counts once.
+// The merge commits are skipped. It needs only the tree changes, so it is suitable
+// for the fast mode (core.ConfigPipelineFast).
+type ChangeEntropyAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the number of days in a tick.
+	Sampling int
+
+	// days maps the day index to the number of changes of each file.
+	days map[int]map[string]int
+	// ticks are the numbers of changes of each file in each tick.
+	ticks []map[string]int
+	// commits are the scatter of the changes in each commit.
+	commits []ChangeEntropyCommit
+}
+
+// ChangeEntropyDay is the scatter of the changes on a single day.
+type ChangeEntropyDay struct {
+	// Changes is the number of file changes.
+	Changes int
+	// Files is the number of distinct changed files.
+	Files int
+	// Directories is the number of distinct directories of the changed files.
+	Directories int
+	// FileEntropy is the Shannon entropy in bits of the distribution of the changes
+	// across the files. It is 0 if a single file was changed and log2(Files) if all the files
+	// were changed equally often.
+	FileEntropy float64
+	// DirectoryEntropy is the same as FileEntropy across the directories.
+	DirectoryEntropy float64
+}
+
+// ChangeEntropyCommit is the scatter of the changes in a single commit. Each file is changed
+// once, so FileEntropy is always log2(Files).
+type ChangeEntropyCommit struct {
+	Hash string
+	Day  int
+	// Files is the number of changed files.
+	Files int
+	// Directories is the number of distinct directories of the changed files.
+	Directories int
+	// FileEntropy is the Shannon entropy in bits of the changes across the files.
+	FileEntropy float64
+	// DirectoryEntropy is the Shannon entropy in bits of the changes across the directories.
+	DirectoryEntropy float64
+}
+
+// ChangeEntropyResult is returned by ChangeEntropyAnalysis.Finalize().
+type ChangeEntropyResult struct {
+	// Days maps the day index to the scatter of the changes on that day.
+	Days map[int]ChangeEntropyDay
+	// Ticks are the scatter of the changes in each tick of Sampling days.
+	Ticks []ChangeEntropyDay
+	// Commits are the scatter of the changes in each commit, sorted by Day.
+	Commits []ChangeEntropyCommit
+	// Sampling is the effective ChangeEntropyAnalysis.Sampling.
+	Sampling int
+}
+
+const (
+	// ConfigChangeEntropySampling is the name of the option to set ChangeEntropyAnalysis.Sampling.
+	ConfigChangeEntropySampling = "ChangeEntropy.Sampling"
+	// DefaultChangeEntropySampling is the default value of ChangeEntropyAnalysis.Sampling.
+	DefaultChangeEntropySampling = 30
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (entropy *ChangeEntropyAnalysis) Name() string {
+	return "ChangeEntropy"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (entropy *ChangeEntropyAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (entropy *ChangeEntropyAnalysis) Requires() []string {
+	arr := [...]string{items.DependencyDay, items.DependencyTreeChanges}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (entropy *ChangeEntropyAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigChangeEntropySampling,
+		Description: "How frequently to record the entropy of the changes, in days.",
+		Flag:        "change-entropy-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultChangeEntropySampling},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (entropy *ChangeEntropyAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigChangeEntropySampling].(int); exists {
+		entropy.Sampling = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (entropy *ChangeEntropyAnalysis) Flag() string {
+	return "change-entropy"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (entropy *ChangeEntropyAnalysis) Description() string {
+	return "Calculates the entropy of the changes across the files and the directories " +
+		"in each commit, on each day and in each tick: how scattered or focused the work was. " +
+		"Does not read the file contents."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (entropy *ChangeEntropyAnalysis) Initialize(repository *git.Repository) {
+	if entropy.Sampling <= 0 {
+		log.Printf("Warning: adjusted the change entropy sampling to %d days\n",
+			DefaultChangeEntropySampling)
+		entropy.Sampling = DefaultChangeEntropySampling
+	}
+	entropy.days = map[int]map[string]int{}
+	entropy.ticks = nil
+	entropy.commits = nil
+	entropy.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (entropy *ChangeEntropyAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !entropy.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	day := deps[items.DependencyDay].(int)
+	files := entropy.days[day]
+	if files == nil {
+		files = map[string]int{}
+		entropy.days[day] = files
+	}
+	tick := day / entropy.Sampling
+	for len(entropy.ticks) <= tick {
+		entropy.ticks = append(entropy.ticks, map[string]int{})
+	}
+	commitFiles := map[string]int{}
+	for _, change := range changes {
+		name := change.To.Name
+		if name == "" {
+			name = change.From.Name
+		}
+		files[name]++
+		entropy.ticks[tick][name]++
+		commitFiles[name] = 1
+	}
+	scatter := newChangeEntropyDay(commitFiles)
+	entropy.commits = append(entropy.commits, ChangeEntropyCommit{
+		Hash:             deps[core.DependencyCommit].(*object.Commit).Hash.String(),
+		Day:              day,
+		Files:            scatter.Files,
+		Directories:      scatter.Directories,
+		FileEntropy:      scatter.FileEntropy,
+		DirectoryEntropy: scatter.DirectoryEntropy,
+	})
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (entropy *ChangeEntropyAnalysis) Finalize() interface{} {
+	result := ChangeEntropyResult{
+		Days:     map[int]ChangeEntropyDay{},
+		Ticks:    make([]ChangeEntropyDay, len(entropy.ticks)),
+		Commits:  entropy.commits,
+		Sampling: entropy.Sampling,
+	}
+	for day, files := range entropy.days {
+		result.Days[day] = newChangeEntropyDay(files)
+	}
+	for i, files := range entropy.ticks {
+		result.Ticks[i] = newChangeEntropyDay(files)
+	}
+	return result
+}
+
+// newChangeEntropyDay calculates the scatter of the changes given the number of changes
+// of each file.
+func newChangeEntropyDay(files map[string]int) ChangeEntropyDay {
+	dirs := map[string]int{}
+	changes := 0
+	for name, count := range files {
+		dirs[path.Dir(name)] += count
+		changes += count
+	}
+	return ChangeEntropyDay{
+		Changes:          changes,
+		Files:            len(files),
+		Directories:      len(dirs),
+		FileEntropy:      shannonEntropy(files, changes),
+		DirectoryEntropy: shannonEntropy(dirs, changes),
+	}
+}
+
+// shannonEntropy returns the entropy in bits of the distribution given by the counts
+// which sum to `total`.
+func shannonEntropy(counts map[string]int, total int) float64 {
+	entropy := 0.0
+	for _, count := range counts {
+		if count == 0 {
+			continue
+		}
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// Fork clones this pipeline item.
+func (entropy *ChangeEntropyAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(entropy, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (entropy *ChangeEntropyAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	entropyResult := result.(ChangeEntropyResult)
+	if binary {
+		return entropy.serializeBinary(&entropyResult, writer)
+	}
+	entropy.serializeText(&entropyResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (entropy *ChangeEntropyAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(entropy, result, &pb.ChangeEntropyAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (entropy *ChangeEntropyAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(entropy, result, &pb.ChangeEntropyAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to ChangeEntropyResult.
+func (entropy *ChangeEntropyAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.ChangeEntropyAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	convert := func(dayEntropy *pb.ChangeEntropyDay) ChangeEntropyDay {
+		return ChangeEntropyDay{
+			Changes:          int(dayEntropy.Changes),
+			Files:            int(dayEntropy.Files),
+			Directories:      int(dayEntropy.Directories),
+			FileEntropy:      dayEntropy.FileEntropy,
+			DirectoryEntropy: dayEntropy.DirectoryEntropy,
+		}
+	}
+	result := ChangeEntropyResult{
+		Days:     map[int]ChangeEntropyDay{},
+		Ticks:    make([]ChangeEntropyDay, len(message.Ticks)),
+		Sampling: int(message.Sampling),
+	}
+	for day, dayEntropy := range message.Days {
+		result.Days[int(day)] = convert(dayEntropy)
+	}
+	for i, tick := range message.Ticks {
+		result.Ticks[i] = convert(tick)
+	}
+	if len(message.Commits) > 0 {
+		result.Commits = make([]ChangeEntropyCommit, len(message.Commits))
+		for i, commit := range message.Commits {
+			result.Commits[i] = ChangeEntropyCommit{
+				Hash:             commit.Hash,
+				Day:              int(commit.Day),
+				Files:            int(commit.Files),
+				Directories:      int(commit.Directories),
+				FileEntropy:      commit.FileEntropy,
+				DirectoryEntropy: commit.DirectoryEntropy,
+			}
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two ChangeEntropyResult-s together. The repositories are assumed
+// to have no common files, then the entropy of the joint distribution is exact:
+// H = w1*H1 + w2*H2 + H(w1, w2) where w1 and w2 are the shares of the changes.
+// The ticks are regrouped by the larger sampling; the ticks of the same repository which fall
+// into the same merged tick are joined in the same way, which overestimates the entropy
+// if they changed the same files.
+func (entropy *ChangeEntropyAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	er1 := r1.(ChangeEntropyResult)
+	er2 := r2.(ChangeEntropyResult)
+	merged := ChangeEntropyResult{Days: map[int]ChangeEntropyDay{}, Sampling: er1.Sampling}
+	if er2.Sampling > merged.Sampling {
+		merged.Sampling = er2.Sampling
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	add := func(result *ChangeEntropyResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for day, dayEntropy := range result.Days {
+			merged.Days[day+offset] = mergeChangeEntropyDays(merged.Days[day+offset], dayEntropy)
+		}
+		for i, tick := range result.Ticks {
+			index := (i*result.Sampling + offset) / merged.Sampling
+			for len(merged.Ticks) <= index {
+				merged.Ticks = append(merged.Ticks, ChangeEntropyDay{})
+			}
+			merged.Ticks[index] = mergeChangeEntropyDays(merged.Ticks[index], tick)
+		}
+		for _, commit := range result.Commits {
+			commit.Day += offset
+			merged.Commits = append(merged.Commits, commit)
+		}
+	}
+	add(&er1, c1)
+	add(&er2, c2)
+	sort.SliceStable(merged.Commits, func(i, j int) bool {
+		return merged.Commits[i].Day < merged.Commits[j].Day
+	})
+	return merged
+}
+
+// mergeChangeEntropyDays joins the scatter of the changes in two disjoint sets of files.
+func mergeChangeEntropyDays(day1, day2 ChangeEntropyDay) ChangeEntropyDay {
+	if day1.Changes == 0 {
+		return day2
+	}
+	if day2.Changes == 0 {
+		return day1
+	}
+	changes := day1.Changes + day2.Changes
+	w1 := float64(day1.Changes) / float64(changes)
+	w2 := float64(day2.Changes) / float64(changes)
+	mixture := -w1*math.Log2(w1) - w2*math.Log2(w2)
+	return ChangeEntropyDay{
+		Changes:          changes,
+		Files:            day1.Files + day2.Files,
+		Directories:      day1.Directories + day2.Directories,
+		FileEntropy:      w1*day1.FileEntropy + w2*day2.FileEntropy + mixture,
+		DirectoryEntropy: w1*day1.DirectoryEntropy + w2*day2.DirectoryEntropy + mixture,
+	}
+}
+
+func (entropy *ChangeEntropyAnalysis) serializeText(result *ChangeEntropyResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  days:")
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	for _, day := range days {
+		dayEntropy := result.Days[day]
+		fmt.Fprintf(writer, "    %d: {changes: %d, files: %d, directories: %d, "+
+			"file_entropy: %.4f, directory_entropy: %.4f}\n",
+			day, dayEntropy.Changes, dayEntropy.Files, dayEntropy.Directories,
+			dayEntropy.FileEntropy, dayEntropy.DirectoryEntropy)
+	}
+	fmt.Fprintln(writer, "  ticks:")
+	for _, tick := range result.Ticks {
+		fmt.Fprintf(writer, "    - {changes: %d, files: %d, directories: %d, "+
+			"file_entropy: %.4f, directory_entropy: %.4f}\n",
+			tick.Changes, tick.Files, tick.Directories, tick.FileEntropy, tick.DirectoryEntropy)
+	}
+	fmt.Fprintln(writer, "  commits:")
+	for _, commit := range result.Commits {
+		fmt.Fprintf(writer, "    - {hash: %s, day: %d, files: %d, directories: %d, "+
+			"file_entropy: %.4f, directory_entropy: %.4f}\n",
+			commit.Hash, commit.Day, commit.Files, commit.Directories,
+			commit.FileEntropy, commit.DirectoryEntropy)
+	}
+}
+
+func (entropy *ChangeEntropyAnalysis) serializeBinary(result *ChangeEntropyResult, writer io.Writer) error {
+	convert := func(dayEntropy ChangeEntropyDay) *pb.ChangeEntropyDay {
+		return &pb.ChangeEntropyDay{
+			Changes:          int32(dayEntropy.Changes),
+			Files:            int32(dayEntropy.Files),
+			Directories:      int32(dayEntropy.Directories),
+			FileEntropy:      dayEntropy.FileEntropy,
+			DirectoryEntropy: dayEntropy.DirectoryEntropy,
+		}
+	}
+	message := pb.ChangeEntropyAnalysisResults{
+		Days:     map[int32]*pb.ChangeEntropyDay{},
+		Ticks:    make([]*pb.ChangeEntropyDay, len(result.Ticks)),
+		Commits:  make([]*pb.ChangeEntropyCommit, len(result.Commits)),
+		Sampling: int32(result.Sampling),
+	}
+	for day, dayEntropy := range result.Days {
+		message.Days[int32(day)] = convert(dayEntropy)
+	}
+	for i, tick := range result.Ticks {
+		message.Ticks[i] = convert(tick)
+	}
+	for i, commit := range result.Commits {
+		message.Commits[i] = &pb.ChangeEntropyCommit{
+			Hash:             commit.Hash,
+			Day:              int32(commit.Day),
+			Files:            int32(commit.Files),
+			Directories:      int32(commit.Directories),
+			FileEntropy:      commit.FileEntropy,
+			DirectoryEntropy: commit.DirectoryEntropy,
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&ChangeEntropyAnalysis{})
+}
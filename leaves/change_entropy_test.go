@@ -0,0 +1,202 @@
+package leaves
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func fixtureChangeEntropy() *ChangeEntropyAnalysis {
+	entropy := ChangeEntropyAnalysis{}
+	entropy.Configure(map[string]interface{}{ConfigChangeEntropySampling: 2})
+	entropy.Initialize(nil)
+	return &entropy
+}
+
+func TestChangeEntropyMeta(t *testing.T) {
+	entropy := fixtureChangeEntropy()
+	assert.Equal(t, entropy.Name(), "ChangeEntropy")
+	assert.Len(t, entropy.Provides(), 0)
+	assert.Equal(t, entropy.Requires(), []string{items.DependencyDay, items.DependencyTreeChanges})
+	opts := entropy.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Flag, "change-entropy-sampling")
+	assert.Equal(t, entropy.Sampling, 2)
+	assert.Equal(t, entropy.Flag(), "change-entropy")
+	assert.NotEmpty(t, entropy.Description())
+	entropy = &ChangeEntropyAnalysis{}
+	entropy.Initialize(nil)
+	assert.Equal(t, entropy.Sampling, DefaultChangeEntropySampling)
+	summoned := core.Registry.Summon(entropy.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), entropy.Name())
+	matched := false
+	for _, tp := range core.Registry.GetLeaves() {
+		if tp.Flag() == entropy.Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func consumeChangeEntropy(t *testing.T, entropy *ChangeEntropyAnalysis, day int,
+	commit *object.Commit, merge bool, names ...string) {
+	changes := make(object.Changes, len(names))
+	for i, name := range names {
+		changes[i] = &object.Change{To: object.ChangeEntry{Name: name}}
+	}
+	if len(names) > 0 && names[0] == "deleted.go" {
+		changes[0] = &object.Change{From: object.ChangeEntry{Name: names[0]}}
+	}
+	result, err := entropy.Consume(map[string]interface{}{
+		items.DependencyDay:         day,
+		items.DependencyTreeChanges: changes,
+		core.DependencyCommit:       commit,
+		core.DependencyIsMerge:      merge,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func TestChangeEntropyConsumeFinalize(t *testing.T) {
+	entropy := fixtureChangeEntropy()
+	commit := &object.Commit{Hash: plumbing.NewHash("1111111111111111111111111111111111111111")}
+	consumeChangeEntropy(t, entropy, 0, commit, false, "a/x.go")
+	consumeChangeEntropy(t, entropy, 0, commit, false, "a/x.go")
+	consumeChangeEntropy(t, entropy, 1, commit, false, "a/x.go", "a/y.go", "b/z.go", "README.md")
+	consumeChangeEntropy(t, entropy, 2, commit, false, "deleted.go", "b/z.go")
+	consumeChangeEntropy(t, entropy, 3, commit, false)
+	merge := &object.Commit{Hash: plumbing.NewHash("0123456789012345678901234567890123456789"),
+		ParentHashes: make([]plumbing.Hash, 2)}
+	consumeChangeEntropy(t, entropy, 2, merge, true, "a/x.go", "c/w.go")
+	result := entropy.Finalize().(ChangeEntropyResult)
+	assert.Len(t, result.Days, 3)
+	assert.Equal(t, result.Days[0], ChangeEntropyDay{Changes: 2, Files: 1, Directories: 1})
+	day1 := result.Days[1]
+	assert.Equal(t, day1.Changes, 4)
+	assert.Equal(t, day1.Files, 4)
+	assert.Equal(t, day1.Directories, 3)
+	assert.InDelta(t, day1.FileEntropy, 2, 1e-9)
+	assert.InDelta(t, day1.DirectoryEntropy, 1.5, 1e-9)
+	day2 := result.Days[2]
+	assert.Equal(t, day2.Changes, 2)
+	assert.Equal(t, day2.Directories, 2)
+	assert.InDelta(t, day2.FileEntropy, 1, 1e-9)
+	assert.InDelta(t, day2.DirectoryEntropy, 1, 1e-9)
+	assert.Equal(t, result.Sampling, 2)
+	assert.Len(t, result.Ticks, 2)
+	tick0 := result.Ticks[0]
+	assert.Equal(t, tick0.Changes, 6)
+	assert.Equal(t, tick0.Files, 4)
+	assert.Equal(t, tick0.Directories, 3)
+	// the files are changed 3, 1, 1, 1 times and the directories 4, 1, 1 times
+	assert.InDelta(t, tick0.FileEntropy, -0.5*math.Log2(0.5)-3.0/6*math.Log2(1.0/6), 1e-9)
+	assert.InDelta(t, tick0.DirectoryEntropy, -4.0/6*math.Log2(4.0/6)-2.0/6*math.Log2(1.0/6), 1e-9)
+	assert.Equal(t, result.Ticks[1], result.Days[2])
+	assert.Len(t, result.Commits, 4)
+	assert.Equal(t, result.Commits[0], ChangeEntropyCommit{
+		Hash: "1111111111111111111111111111111111111111", Day: 0, Files: 1, Directories: 1})
+	commit2 := result.Commits[2]
+	assert.Equal(t, commit2.Day, 1)
+	assert.Equal(t, commit2.Files, 4)
+	assert.Equal(t, commit2.Directories, 3)
+	assert.InDelta(t, commit2.FileEntropy, 2, 1e-9)
+	assert.InDelta(t, commit2.DirectoryEntropy, 1.5, 1e-9)
+	assert.Equal(t, result.Commits[3].Day, 2)
+}
+
+func fixtureChangeEntropyResult() ChangeEntropyResult {
+	return ChangeEntropyResult{
+		Days: map[int]ChangeEntropyDay{
+			0: {Changes: 2, Files: 1, Directories: 1},
+			3: {Changes: 4, Files: 4, Directories: 3, FileEntropy: 2, DirectoryEntropy: 1.5},
+		},
+		Ticks: []ChangeEntropyDay{
+			{Changes: 2, Files: 1, Directories: 1},
+			{Changes: 4, Files: 4, Directories: 3, FileEntropy: 2, DirectoryEntropy: 1.5},
+		},
+		Commits: []ChangeEntropyCommit{
+			{Hash: "1111111111111111111111111111111111111111", Day: 0, Files: 1, Directories: 1},
+			{Hash: "2222222222222222222222222222222222222222", Day: 3, Files: 4, Directories: 3,
+				FileEntropy: 2, DirectoryEntropy: 1.5},
+		},
+		Sampling: 2,
+	}
+}
+
+func TestChangeEntropySerialize(t *testing.T) {
+	entropy := fixtureChangeEntropy()
+	result := fixtureChangeEntropyResult()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, entropy.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 2
+  days:
+    0: {changes: 2, files: 1, directories: 1, file_entropy: 0.0000, directory_entropy: 0.0000}
+    3: {changes: 4, files: 4, directories: 3, file_entropy: 2.0000, directory_entropy: 1.5000}
+  ticks:
+    - {changes: 2, files: 1, directories: 1, file_entropy: 0.0000, directory_entropy: 0.0000}
+    - {changes: 4, files: 4, directories: 3, file_entropy: 2.0000, directory_entropy: 1.5000}
+  commits:
+    - {hash: 1111111111111111111111111111111111111111, day: 0, files: 1, directories: 1, file_entropy: 0.0000, directory_entropy: 0.0000}
+    - {hash: 2222222222222222222222222222222222222222, day: 3, files: 4, directories: 3, file_entropy: 2.0000, directory_entropy: 1.5000}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, entropy.Serialize(result, true, buffer))
+	msg := pb.ChangeEntropyAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Days[3].DirectoryEntropy, 1.5)
+	assert.Len(t, msg.Ticks, 2)
+	assert.Equal(t, msg.Commits[1].Hash, "2222222222222222222222222222222222222222")
+	assert.Equal(t, msg.Sampling, int32(2))
+	deserialized, err := entropy.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+	_, err = entropy.Deserialize([]byte("garbage"))
+	assert.NotNil(t, err)
+}
+
+func TestChangeEntropyMergeResults(t *testing.T) {
+	entropy := fixtureChangeEntropy()
+	r1 := fixtureChangeEntropyResult()
+	r2 := ChangeEntropyResult{
+		Days: map[int]ChangeEntropyDay{
+			0: {Changes: 2, Files: 2, Directories: 1, FileEntropy: 1},
+		},
+		Ticks: []ChangeEntropyDay{{Changes: 2, Files: 2, Directories: 1, FileEntropy: 1}},
+		Commits: []ChangeEntropyCommit{
+			{Hash: "3333333333333333333333333333333333333333", Day: 0, Files: 2, Directories: 1,
+				FileEntropy: 1}},
+		Sampling: 4,
+	}
+	c1 := &core.CommonAnalysisResult{BeginTime: 1500000000}
+	c2 := &core.CommonAnalysisResult{BeginTime: 1500000000 + 3*24*3600}
+	merged := entropy.MergeResults(r1, r2, c1, c2).(ChangeEntropyResult)
+	assert.Len(t, merged.Days, 2)
+	assert.Equal(t, merged.Days[0], r1.Days[0])
+	day := merged.Days[3]
+	assert.Equal(t, day.Changes, 6)
+	assert.Equal(t, day.Files, 6)
+	assert.Equal(t, day.Directories, 4)
+	// the files are changed 1, 1, 1, 1 and 1, 1 times
+	assert.InDelta(t, day.FileEntropy, math.Log2(6), 1e-9)
+	// the directories are changed 2, 1, 1 and 2 times
+	assert.InDelta(t, day.DirectoryEntropy, 2*(-1.0/3*math.Log2(1.0/3))+2*(-1.0/6*math.Log2(1.0/6)), 1e-9)
+	assert.Equal(t, merged.Sampling, 4)
+	assert.Len(t, merged.Ticks, 1)
+	assert.Equal(t, merged.Ticks[0].Changes, 8)
+	assert.Equal(t, merged.Ticks[0].Files, 7)
+	assert.Len(t, merged.Commits, 3)
+	assert.Equal(t, merged.Commits[1].Day, 3)
+	assert.Equal(t, merged.Commits[2].Day, 3)
+	assert.Equal(t, merged.Commits[1].Hash, "2222222222222222222222222222222222222222")
+	assert.Equal(t, merged.Commits[2].Hash, "3333333333333333333333333333333333333333")
+}
@@ -0,0 +1,545 @@
+package leaves
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// ClonesAnalysis detects the duplicated code. Each file is split into the overlapping shingles
+// of MinLines consecutive meaningful lines; the lines which belong to a shingle met in
+// more than one place are duplicated. The whitespace is normalized and the lines without letters
+// or digits, e.g. the closing braces, are ignored. The number of the duplicated lines is sampled
+// every Sampling days, and the commits which inserted the most duplicated lines are reported.
+// The binary files and the merge commits are skipped.
+type ClonesAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the size of a tick in days.
+	Sampling int
+	// MinLines is the minimum number of meaningful lines in a duplicated block.
+	MinLines int
+	// TopCommits is the number of the commits with the biggest copy-pastes to report.
+	TopCommits int
+
+	// files map the file names to their shingles.
+	files map[string]*clonesFile
+	// shingles map the shingle hashes to the number of their occurrences.
+	shingles map[uint64]int
+	// ticks are the measurements at the end of each finished tick.
+	ticks []ClonesTick
+	// commits which inserted duplicated lines.
+	commits []ClonesCommit
+}
+
+// clonesFile is the state of a file in ClonesAnalysis.
+type clonesFile struct {
+	// lines are the 0-based numbers of the meaningful lines.
+	lines []int
+	// shingles are the hashes of each MinLines consecutive meaningful lines.
+	shingles []uint64
+}
+
+// ClonesTick is the amount of duplication at the end of a tick.
+type ClonesTick struct {
+	// Lines is the total number of meaningful lines.
+	Lines int
+	// DuplicatedLines is the number of meaningful lines which belong to the duplicated blocks.
+	DuplicatedLines int
+}
+
+// ClonesCommit is the commit which inserted duplicated lines.
+type ClonesCommit struct {
+	Hash string
+	// Day is the number of days since the beginning of the history.
+	Day int
+	// Lines is the number of inserted lines which belong to the duplicated blocks.
+	Lines int
+	// Files are the sorted names of the files with such lines.
+	Files []string
+}
+
+// ClonesResult is returned by ClonesAnalysis.Finalize().
+type ClonesResult struct {
+	// Ticks is the amount of duplication at the end of each tick.
+	Ticks []ClonesTick
+	// Commits are sorted by the number of the inserted duplicated lines in descending order.
+	Commits []ClonesCommit
+	// Sampling is the size of a tick in days.
+	Sampling int
+}
+
+const (
+	// ConfigClonesSampling is the name of the option to set ClonesAnalysis.Sampling.
+	ConfigClonesSampling = "Clones.Sampling"
+	// ConfigClonesMinLines is the name of the option to set ClonesAnalysis.MinLines.
+	ConfigClonesMinLines = "Clones.MinLines"
+	// ConfigClonesTopCommits is the name of the option to set ClonesAnalysis.TopCommits.
+	ConfigClonesTopCommits = "Clones.TopCommits"
+	// DefaultClonesSampling is the default value of ClonesAnalysis.Sampling.
+	DefaultClonesSampling = 30
+	// DefaultClonesMinLines is the default value of ClonesAnalysis.MinLines.
+	DefaultClonesMinLines = 6
+	// DefaultClonesTopCommits is the default value of ClonesAnalysis.TopCommits.
+	DefaultClonesTopCommits = 20
+)
+
+// Ratio returns the share of the duplicated lines.
+func (tick ClonesTick) Ratio() float64 {
+	if tick.Lines == 0 {
+		return 0
+	}
+	return float64(tick.DuplicatedLines) / float64(tick.Lines)
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (analyser *ClonesAnalysis) Name() string {
+	return "Clones"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (analyser *ClonesAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (analyser *ClonesAnalysis) Requires() []string {
+	arr := [...]string{
+		items.DependencyTreeChanges, items.DependencyFileDiff, items.DependencyBlobCache,
+		items.DependencyDay}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (analyser *ClonesAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigClonesSampling,
+		Description: "How frequently to record the amount of duplication in days.",
+		Flag:        "clones-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultClonesSampling}, {
+		Name:        ConfigClonesMinLines,
+		Description: "Minimum number of meaningful lines in a duplicated block.",
+		Flag:        "clones-min-lines",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultClonesMinLines}, {
+		Name:        ConfigClonesTopCommits,
+		Description: "Number of the commits with the biggest copy-pastes to report.",
+		Flag:        "clones-top-commits",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultClonesTopCommits},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (analyser *ClonesAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigClonesSampling].(int); exists {
+		analyser.Sampling = val
+	}
+	if val, exists := facts[ConfigClonesMinLines].(int); exists {
+		analyser.MinLines = val
+	}
+	if val, exists := facts[ConfigClonesTopCommits].(int); exists {
+		analyser.TopCommits = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (analyser *ClonesAnalysis) Flag() string {
+	return "clones"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (analyser *ClonesAnalysis) Description() string {
+	return "Detects the duplicated blocks of code, measures the share of the duplicated lines " +
+		"over time and finds the commits which introduced the biggest copy-pastes."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (analyser *ClonesAnalysis) Initialize(repository *git.Repository) {
+	if analyser.Sampling <= 0 {
+		log.Printf("Warning: adjusted the clones sampling to %d days\n", DefaultClonesSampling)
+		analyser.Sampling = DefaultClonesSampling
+	}
+	if analyser.MinLines <= 0 {
+		log.Printf("Warning: adjusted the minimum duplicated block size to %d lines\n",
+			DefaultClonesMinLines)
+		analyser.MinLines = DefaultClonesMinLines
+	}
+	if analyser.TopCommits < 0 {
+		log.Printf("Warning: adjusted the number of the top copy-paste commits to %d\n",
+			DefaultClonesTopCommits)
+		analyser.TopCommits = DefaultClonesTopCommits
+	}
+	analyser.files = map[string]*clonesFile{}
+	analyser.shingles = map[uint64]int{}
+	analyser.ticks = []ClonesTick{}
+	analyser.commits = []ClonesCommit{}
+	analyser.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (analyser *ClonesAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !analyser.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	day := deps[items.DependencyDay].(int)
+	if tick := day / analyser.Sampling; tick > len(analyser.ticks) {
+		// the state does not change between the commits
+		measurement := analyser.measure()
+		for len(analyser.ticks) < tick {
+			analyser.ticks = append(analyser.ticks, measurement)
+		}
+	}
+	// file name -> inserted 0-based line numbers, nil means all the lines
+	inserted := map[string]map[int]bool{}
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		if action != merkletrie.Insert {
+			analyser.update(change.From.Name, nil)
+		}
+		if action == merkletrie.Delete {
+			continue
+		}
+		contents, err := items.BlobToString(cache[change.To.TreeEntry.Hash])
+		if err != nil {
+			return nil, err
+		}
+		if strings.IndexByte(contents, 0) >= 0 {
+			// binary
+			continue
+		}
+		analyser.update(change.To.Name, strings.Split(contents, "\n"))
+		if action == merkletrie.Insert {
+			inserted[change.To.Name] = nil
+		} else if diff, exists := fileDiffs[change.To.Name]; exists {
+			inserted[change.To.Name] = insertedLines(diff.Diffs)
+		}
+	}
+	record := ClonesCommit{Hash: commit.Hash.String(), Day: day, Files: []string{}}
+	for name, lines := range inserted {
+		file := analyser.files[name]
+		if file == nil {
+			continue
+		}
+		copied := 0
+		for _, index := range analyser.duplicated(file) {
+			if lines == nil || lines[file.lines[index]] {
+				copied++
+			}
+		}
+		if copied > 0 {
+			record.Lines += copied
+			record.Files = append(record.Files, name)
+		}
+	}
+	if record.Lines > 0 {
+		sort.Strings(record.Files)
+		analyser.commits = append(analyser.commits, record)
+	}
+	return nil, nil
+}
+
+// update replaces the shingles of the file. nil `lines` means that the file was deleted.
+func (analyser *ClonesAnalysis) update(name string, lines []string) {
+	if old := analyser.files[name]; old != nil {
+		for _, shingle := range old.shingles {
+			if analyser.shingles[shingle] <= 1 {
+				delete(analyser.shingles, shingle)
+			} else {
+				analyser.shingles[shingle]--
+			}
+		}
+		delete(analyser.files, name)
+	}
+	if lines == nil {
+		return
+	}
+	file := &clonesFile{}
+	var normalized []string
+	for i, line := range lines {
+		if strings.IndexFunc(line, func(r rune) bool {
+			return unicode.IsLetter(r) || unicode.IsDigit(r)
+		}) < 0 {
+			continue
+		}
+		file.lines = append(file.lines, i)
+		normalized = append(normalized, strings.Join(strings.Fields(line), " "))
+	}
+	for i := 0; i+analyser.MinLines <= len(normalized); i++ {
+		hasher := fnv.New64a()
+		hasher.Write([]byte(strings.Join(normalized[i:i+analyser.MinLines], "\n")))
+		shingle := hasher.Sum64()
+		file.shingles = append(file.shingles, shingle)
+		analyser.shingles[shingle]++
+	}
+	analyser.files[name] = file
+}
+
+// duplicated returns the indexes in `file.lines` of the duplicated lines.
+func (analyser *ClonesAnalysis) duplicated(file *clonesFile) []int {
+	var result []int
+	end := 0
+	for i, shingle := range file.shingles {
+		if analyser.shingles[shingle] <= 1 {
+			continue
+		}
+		if end < i {
+			end = i
+		}
+		for ; end < i+analyser.MinLines; end++ {
+			result = append(result, end)
+		}
+	}
+	return result
+}
+
+// measure returns the current amount of duplication.
+func (analyser *ClonesAnalysis) measure() ClonesTick {
+	var tick ClonesTick
+	for _, file := range analyser.files {
+		tick.Lines += len(file.lines)
+		tick.DuplicatedLines += len(analyser.duplicated(file))
+	}
+	return tick
+}
+
+// insertedLines returns the 0-based numbers of the new lines which were inserted by the diff.
+func insertedLines(diffs []diffmatchpatch.Diff) map[int]bool {
+	result := map[int]bool{}
+	line := 0
+	for _, edit := range diffs {
+		// FileDiff encodes each line as a single rune
+		size := utf8.RuneCountInString(edit.Text)
+		switch edit.Type {
+		case diffmatchpatch.DiffEqual:
+			line += size
+		case diffmatchpatch.DiffInsert:
+			for i := 0; i < size; i++ {
+				result[line+i] = true
+			}
+			line += size
+		}
+	}
+	return result
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (analyser *ClonesAnalysis) Finalize() interface{} {
+	ticks := analyser.ticks
+	if len(analyser.files) > 0 || len(ticks) > 0 {
+		ticks = append(ticks, analyser.measure())
+	}
+	commits := make([]ClonesCommit, len(analyser.commits))
+	copy(commits, analyser.commits)
+	return ClonesResult{
+		Ticks:    ticks,
+		Commits:  topClonesCommits(commits, analyser.TopCommits),
+		Sampling: analyser.Sampling,
+	}
+}
+
+// topClonesCommits sorts the commits by the number of the duplicated lines and keeps
+// the first `size`.
+func topClonesCommits(commits []ClonesCommit, size int) []ClonesCommit {
+	sort.SliceStable(commits, func(i, j int) bool {
+		if commits[i].Lines != commits[j].Lines {
+			return commits[i].Lines > commits[j].Lines
+		}
+		return commits[i].Day < commits[j].Day
+	})
+	if len(commits) > size {
+		commits = commits[:size]
+	}
+	return commits
+}
+
+// Fork clones this pipeline item.
+func (analyser *ClonesAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(analyser, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (analyser *ClonesAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	clonesResult := result.(ClonesResult)
+	if binary {
+		return analyser.serializeBinary(&clonesResult, writer)
+	}
+	analyser.serializeText(&clonesResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (analyser *ClonesAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(analyser, result, &pb.ClonesAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (analyser *ClonesAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(analyser, result, &pb.ClonesAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to ClonesResult.
+func (analyser *ClonesAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.ClonesAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := ClonesResult{
+		Ticks:    make([]ClonesTick, len(message.Ticks)),
+		Commits:  make([]ClonesCommit, len(message.Commits)),
+		Sampling: int(message.Sampling),
+	}
+	for i, tick := range message.Ticks {
+		result.Ticks[i] = ClonesTick{
+			Lines: int(tick.Lines), DuplicatedLines: int(tick.DuplicatedLines)}
+	}
+	for i, commit := range message.Commits {
+		result.Commits[i] = ClonesCommit{
+			Hash: commit.Hash, Day: int(commit.Day), Lines: int(commit.Lines), Files: commit.Files}
+		if result.Commits[i].Files == nil {
+			result.Commits[i].Files = []string{}
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two ClonesResult-s together. The ticks are resampled to
+// the bigger sampling of the two.
+func (analyser *ClonesAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	cr1 := r1.(ClonesResult)
+	cr2 := r2.(ClonesResult)
+	sampling := cr1.Sampling
+	if cr2.Sampling > sampling {
+		sampling = cr2.Sampling
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	offsets := [2]int{
+		int((c1.BeginTime - beginTime) / (24 * 3600)),
+		int((c2.BeginTime - beginTime) / (24 * 3600)),
+	}
+	results := [2]*ClonesResult{&cr1, &cr2}
+	days := 0
+	for i, result := range results {
+		if end := offsets[i] + len(result.Ticks)*result.Sampling; end > days {
+			days = end
+		}
+	}
+	merged := ClonesResult{
+		Ticks:    make([]ClonesTick, (days+sampling-1)/sampling),
+		Commits:  []ClonesCommit{},
+		Sampling: sampling,
+	}
+	for i, result := range results {
+		// take the value of the series at the end of each merged tick
+		for tick := range merged.Ticks {
+			day := (tick+1)*sampling - 1
+			if day < offsets[i] || len(result.Ticks) == 0 {
+				continue
+			}
+			index := (day - offsets[i]) / result.Sampling
+			if index >= len(result.Ticks) {
+				index = len(result.Ticks) - 1
+			}
+			merged.Ticks[tick].Lines += result.Ticks[index].Lines
+			merged.Ticks[tick].DuplicatedLines += result.Ticks[index].DuplicatedLines
+		}
+		for _, commit := range result.Commits {
+			commit.Day += offsets[i]
+			merged.Commits = append(merged.Commits, commit)
+		}
+	}
+	size := len(cr1.Commits)
+	if len(cr2.Commits) > size {
+		size = len(cr2.Commits)
+	}
+	merged.Commits = topClonesCommits(merged.Commits, size)
+	return merged
+}
+
+func (analyser *ClonesAnalysis) serializeText(result *ClonesResult, writer io.Writer) {
+	ticks := make([]string, len(result.Ticks))
+	for i, tick := range result.Ticks {
+		ticks[i] = fmt.Sprintf("[%d, %d]", tick.Lines, tick.DuplicatedLines)
+	}
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  # [lines, duplicated lines]")
+	fmt.Fprintf(writer, "  ticks: [%s]\n", strings.Join(ticks, ", "))
+	fmt.Fprintln(writer, "  commits:")
+	for _, commit := range result.Commits {
+		files := make([]string, len(commit.Files))
+		for i, file := range commit.Files {
+			files[i] = yaml.SafeString(file)
+		}
+		fmt.Fprintf(writer, "    - hash: %s\n", commit.Hash)
+		fmt.Fprintf(writer, "      day: %d\n", commit.Day)
+		fmt.Fprintf(writer, "      lines: %d\n", commit.Lines)
+		fmt.Fprintf(writer, "      files: [%s]\n", strings.Join(files, ", "))
+	}
+}
+
+func (analyser *ClonesAnalysis) serializeBinary(result *ClonesResult, writer io.Writer) error {
+	message := pb.ClonesAnalysisResults{
+		Sampling: int32(result.Sampling),
+		Ticks:    make([]*pb.ClonesTick, len(result.Ticks)),
+		Commits:  make([]*pb.ClonesCommit, len(result.Commits)),
+	}
+	for i, tick := range result.Ticks {
+		message.Ticks[i] = &pb.ClonesTick{
+			Lines: int32(tick.Lines), DuplicatedLines: int32(tick.DuplicatedLines)}
+	}
+	for i, commit := range result.Commits {
+		message.Commits[i] = &pb.ClonesCommit{
+			Hash: commit.Hash, Day: int32(commit.Day), Lines: int32(commit.Lines),
+			Files: commit.Files}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&ClonesAnalysis{})
+}
@@ -0,0 +1,209 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func fixtureClones() *ClonesAnalysis {
+	analyser := ClonesAnalysis{}
+	analyser.Configure(map[string]interface{}{
+		ConfigClonesSampling:   10,
+		ConfigClonesMinLines:   3,
+		ConfigClonesTopCommits: 5,
+	})
+	analyser.Initialize(nil)
+	return &analyser
+}
+
+func TestClonesMeta(t *testing.T) {
+	analyser := fixtureClones()
+	assert.Equal(t, analyser.Name(), "Clones")
+	assert.Len(t, analyser.Provides(), 0)
+	assert.Equal(t, analyser.Requires(), []string{
+		items.DependencyTreeChanges, items.DependencyFileDiff, items.DependencyBlobCache,
+		items.DependencyDay})
+	assert.Equal(t, analyser.Flag(), "clones")
+	assert.NotEmpty(t, analyser.Description())
+	opts := analyser.ListConfigurationOptions()
+	assert.Len(t, opts, 3)
+	assert.Equal(t, opts[0].Flag, "clones-sampling")
+	assert.Equal(t, opts[1].Flag, "clones-min-lines")
+	assert.Equal(t, opts[2].Flag, "clones-top-commits")
+	assert.Equal(t, analyser.Sampling, 10)
+	assert.Equal(t, analyser.MinLines, 3)
+	assert.Equal(t, analyser.TopCommits, 5)
+	analyser = &ClonesAnalysis{TopCommits: -1}
+	analyser.Initialize(nil)
+	assert.Equal(t, analyser.Sampling, DefaultClonesSampling)
+	assert.Equal(t, analyser.MinLines, DefaultClonesMinLines)
+	assert.Equal(t, analyser.TopCommits, DefaultClonesTopCommits)
+	summoned := core.Registry.Summon(analyser.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Clones")
+}
+
+func TestClonesUpdate(t *testing.T) {
+	analyser := fixtureClones()
+	analyser.update("a", []string{"a := 1", "}", "b := 2", "  c  :=\t3", "a := 1", "b := 2",
+		"c := 3", ""})
+	file := analyser.files["a"]
+	assert.Equal(t, file.lines, []int{0, 2, 3, 4, 5, 6})
+	assert.Len(t, file.shingles, 4)
+	assert.Equal(t, analyser.duplicated(file), []int{0, 1, 2, 3, 4, 5})
+	assert.Equal(t, analyser.measure(), ClonesTick{Lines: 6, DuplicatedLines: 6})
+	assert.Len(t, analyser.shingles, 3)
+	analyser.update("a", nil)
+	assert.Len(t, analyser.files, 0)
+	assert.Len(t, analyser.shingles, 0)
+	assert.Equal(t, analyser.measure(), ClonesTick{})
+	assert.Equal(t, ClonesTick{}.Ratio(), float64(0))
+	assert.Equal(t, ClonesTick{Lines: 4, DuplicatedLines: 1}.Ratio(), 0.25)
+}
+
+func fixtureClonesResult(t *testing.T) ClonesResult {
+	storage := memory.NewStorage()
+	cache := map[plumbing.Hash]*object.Blob{}
+	entry := func(name, contents string) object.ChangeEntry {
+		encoded := storage.NewEncodedObject()
+		encoded.SetType(plumbing.BlobObject)
+		writer, _ := encoded.Writer()
+		writer.Write([]byte(contents))
+		writer.Close()
+		hash, err := storage.SetEncodedObject(encoded)
+		assert.Nil(t, err)
+		cache[hash], err = object.GetBlob(storage, hash)
+		assert.Nil(t, err)
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	diff := func(before, after string) items.FileDiffData {
+		dmp := diffmatchpatch.New()
+		src, dst, _ := dmp.DiffLinesToRunes(before, after)
+		return items.FileDiffData{
+			OldLinesOfCode: len(src), NewLinesOfCode: len(dst),
+			Diffs: dmp.DiffMainRunes(src, dst, false)}
+	}
+	analyser := fixtureClones()
+	consume := func(hash string, day int, changes object.Changes,
+		diffs map[string]items.FileDiffData) {
+		result, err := analyser.Consume(map[string]interface{}{
+			core.DependencyCommit:       &object.Commit{Hash: plumbing.NewHash(hash)},
+			core.DependencyIsMerge:      false,
+			items.DependencyTreeChanges: changes,
+			items.DependencyFileDiff:    diffs,
+			items.DependencyBlobCache:   cache,
+			items.DependencyDay:         day,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	block := "alpha()\nbeta()\ngamma()\n"
+	a1 := "func a() {\n" + block + "}\n"
+	a2 := a1 + "func c() {\n" + block + "}\n"
+	b := "func b() {\n  alpha()\n\tbeta()\ngamma()\n}\n"
+	consume("1111111111111111111111111111111111111111", 0,
+		object.Changes{{To: entry("a.go", a1)}}, nil)
+	consume("2222222222222222222222222222222222222222", 3,
+		object.Changes{{To: entry("b.go", b)}, {To: entry("image.png", "\x00"+block)}}, nil)
+	consume("3333333333333333333333333333333333333333", 15, object.Changes{
+		{From: entry("a.go", a1), To: entry("a.go", a2)},
+		{To: entry("d.go", block)},
+	}, map[string]items.FileDiffData{"a.go": diff(a1, a2)})
+	consume("4444444444444444444444444444444444444444", 31, object.Changes{
+		{From: entry("a.go", a2), To: entry("e.go", a2)},
+		{From: entry("b.go", b)},
+		{From: entry("d.go", block)},
+	}, map[string]items.FileDiffData{"e.go": diff(a2, a2)})
+	return analyser.Finalize().(ClonesResult)
+}
+
+func TestClonesConsumeFinalize(t *testing.T) {
+	result := fixtureClonesResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Ticks, []ClonesTick{
+		{Lines: 8, DuplicatedLines: 6}, {Lines: 15, DuplicatedLines: 12},
+		{Lines: 15, DuplicatedLines: 12}, {Lines: 8, DuplicatedLines: 6}})
+	assert.Equal(t, result.Commits, []ClonesCommit{
+		{Hash: "3333333333333333333333333333333333333333", Day: 15, Lines: 6,
+			Files: []string{"a.go", "d.go"}},
+		{Hash: "2222222222222222222222222222222222222222", Day: 3, Lines: 3,
+			Files: []string{"b.go"}},
+	})
+}
+
+func TestClonesConsumeMerge(t *testing.T) {
+	analyser := fixtureClones()
+	result, err := analyser.Consume(map[string]interface{}{
+		core.DependencyCommit: &object.Commit{
+			ParentHashes: make([]plumbing.Hash, 2)},
+		core.DependencyIsMerge: true,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+	assert.Len(t, analyser.Finalize().(ClonesResult).Ticks, 0)
+}
+
+func TestClonesSerialize(t *testing.T) {
+	result := fixtureClonesResult(t)
+	analyser := fixtureClones()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  # [lines, duplicated lines]
+  ticks: [[8, 6], [15, 12], [15, 12], [8, 6]]
+  commits:
+    - hash: 3333333333333333333333333333333333333333
+      day: 15
+      lines: 6
+      files: ["a.go", "d.go"]
+    - hash: 2222222222222222222222222222222222222222
+      day: 3
+      lines: 3
+      files: ["b.go"]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, true, buffer))
+	msg := pb.ClonesAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Len(t, msg.Ticks, 4)
+	assert.Equal(t, msg.Commits[0].Files, []string{"a.go", "d.go"})
+	deserialized, err := analyser.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestClonesMergeResults(t *testing.T) {
+	r1 := ClonesResult{
+		Ticks: []ClonesTick{{Lines: 10, DuplicatedLines: 1}, {Lines: 20, DuplicatedLines: 2}},
+		Commits: []ClonesCommit{
+			{Hash: "a", Day: 5, Lines: 2, Files: []string{"x"}},
+			{Hash: "b", Day: 15, Lines: 1, Files: []string{"y"}}},
+		Sampling: 10,
+	}
+	r2 := ClonesResult{
+		Ticks:    []ClonesTick{{Lines: 5, DuplicatedLines: 5}},
+		Commits:  []ClonesCommit{{Hash: "c", Day: 1, Lines: 3, Files: []string{"z"}}},
+		Sampling: 20,
+	}
+	analyser := fixtureClones()
+	merged := analyser.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 20 * 24 * 3600}).(ClonesResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.Ticks, []ClonesTick{
+		{Lines: 20, DuplicatedLines: 2}, {Lines: 25, DuplicatedLines: 7}})
+	assert.Equal(t, merged.Commits, []ClonesCommit{
+		{Hash: "c", Day: 21, Lines: 3, Files: []string{"z"}},
+		{Hash: "a", Day: 5, Lines: 2, Files: []string{"x"}}})
+}
@@ -0,0 +1,428 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+// CocomoAnalysis estimates the development effort over time with the basic COCOMO model:
+// effort = Coefficient * KLOC ^ Exponent person-months. It applies the model both to the size
+// of the project at the end of each tick of Sampling days, which estimates the effort to write
+// the whole code base from scratch, and to the lines added and removed during the tick, which
+// estimates the effort spent on the changes. The costs are the efforts multiplied
+// by MonthlyCost. The binary files are skipped and the merge commits are ignored.
+type CocomoAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the number of days in a tick.
+	Sampling int
+	// Coefficient is the multiplier of the model, 2.4 for the "organic" projects.
+	Coefficient float64
+	// Exponent is the power of KLOC in the model, 1.05 for the "organic" projects.
+	Exponent float64
+	// MonthlyCost is the cost of a person-month.
+	MonthlyCost float64
+
+	// files map the file names to their numbers of lines.
+	files map[string]int
+	// lines is the current number of lines.
+	lines int
+	// ticks are the sizes and the changes in each tick.
+	ticks []CocomoTick
+}
+
+// CocomoTick is the size of the project and the changes in a tick.
+type CocomoTick struct {
+	// Lines is the number of lines at the end of the tick.
+	Lines int
+	// Added is the number of lines added during the tick.
+	Added int
+	// Removed is the number of lines removed during the tick.
+	Removed int
+}
+
+// CocomoResult is returned by CocomoAnalysis.Finalize().
+type CocomoResult struct {
+	// Ticks are the sizes and the changes in each tick of Sampling days.
+	Ticks []CocomoTick
+	// Sampling is the effective CocomoAnalysis.Sampling.
+	Sampling int
+	// Coefficient is the effective CocomoAnalysis.Coefficient.
+	Coefficient float64
+	// Exponent is the effective CocomoAnalysis.Exponent.
+	Exponent float64
+	// MonthlyCost is the effective CocomoAnalysis.MonthlyCost.
+	MonthlyCost float64
+}
+
+const (
+	// ConfigCocomoSampling is the name of the option to set CocomoAnalysis.Sampling.
+	ConfigCocomoSampling = "Cocomo.Sampling"
+	// ConfigCocomoCoefficient is the name of the option to set CocomoAnalysis.Coefficient.
+	ConfigCocomoCoefficient = "Cocomo.Coefficient"
+	// ConfigCocomoExponent is the name of the option to set CocomoAnalysis.Exponent.
+	ConfigCocomoExponent = "Cocomo.Exponent"
+	// ConfigCocomoMonthlyCost is the name of the option to set CocomoAnalysis.MonthlyCost.
+	ConfigCocomoMonthlyCost = "Cocomo.MonthlyCost"
+	// DefaultCocomoSampling is the default value of CocomoAnalysis.Sampling.
+	DefaultCocomoSampling = 30
+	// DefaultCocomoCoefficient is the default value of CocomoAnalysis.Coefficient:
+	// the basic COCOMO "organic" projects.
+	DefaultCocomoCoefficient = 2.4
+	// DefaultCocomoExponent is the default value of CocomoAnalysis.Exponent:
+	// the basic COCOMO "organic" projects.
+	DefaultCocomoExponent = 1.05
+	// DefaultCocomoMonthlyCost is the default value of CocomoAnalysis.MonthlyCost.
+	DefaultCocomoMonthlyCost = 10000.0
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (cocomo *CocomoAnalysis) Name() string {
+	return "Cocomo"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (cocomo *CocomoAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (cocomo *CocomoAnalysis) Requires() []string {
+	arr := [...]string{
+		items.DependencyDay, items.DependencyTreeChanges, items.DependencyFileDiff,
+		items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (cocomo *CocomoAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigCocomoSampling,
+		Description: "How frequently to record the effort estimates, in days.",
+		Flag:        "cocomo-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultCocomoSampling}, {
+		Name:        ConfigCocomoCoefficient,
+		Description: "The multiplier in the COCOMO model: effort = coefficient * KLOC ^ exponent.",
+		Flag:        "cocomo-coefficient",
+		Type:        core.FloatConfigurationOption,
+		Default:     float32(DefaultCocomoCoefficient)}, {
+		Name:        ConfigCocomoExponent,
+		Description: "The power of KLOC in the COCOMO model: effort = coefficient * KLOC ^ exponent.",
+		Flag:        "cocomo-exponent",
+		Type:        core.FloatConfigurationOption,
+		Default:     float32(DefaultCocomoExponent)}, {
+		Name:        ConfigCocomoMonthlyCost,
+		Description: "The cost of a person-month to convert the effort estimates to the costs.",
+		Flag:        "cocomo-monthly-cost",
+		Type:        core.FloatConfigurationOption,
+		Default:     float32(DefaultCocomoMonthlyCost)},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (cocomo *CocomoAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigCocomoSampling].(int); exists {
+		cocomo.Sampling = val
+	}
+	if val, exists := facts[ConfigCocomoCoefficient].(float32); exists {
+		cocomo.Coefficient = float64(val)
+	}
+	if val, exists := facts[ConfigCocomoExponent].(float32); exists {
+		cocomo.Exponent = float64(val)
+	}
+	if val, exists := facts[ConfigCocomoMonthlyCost].(float32); exists {
+		cocomo.MonthlyCost = float64(val)
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (cocomo *CocomoAnalysis) Flag() string {
+	return "cocomo"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (cocomo *CocomoAnalysis) Description() string {
+	return "Estimates the development effort and cost over time with the COCOMO model " +
+		"from the lines of code and the changed lines."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (cocomo *CocomoAnalysis) Initialize(repository *git.Repository) {
+	if cocomo.Sampling <= 0 {
+		log.Printf("Warning: adjusted the COCOMO sampling to %d days\n", DefaultCocomoSampling)
+		cocomo.Sampling = DefaultCocomoSampling
+	}
+	if cocomo.Coefficient <= 0 {
+		log.Printf("Warning: adjusted the COCOMO coefficient to %.2f\n", DefaultCocomoCoefficient)
+		cocomo.Coefficient = DefaultCocomoCoefficient
+	}
+	if cocomo.Exponent <= 0 {
+		log.Printf("Warning: adjusted the COCOMO exponent to %.2f\n", DefaultCocomoExponent)
+		cocomo.Exponent = DefaultCocomoExponent
+	}
+	if cocomo.MonthlyCost < 0 {
+		log.Printf("Warning: adjusted the COCOMO monthly cost to %.0f\n", DefaultCocomoMonthlyCost)
+		cocomo.MonthlyCost = DefaultCocomoMonthlyCost
+	}
+	cocomo.files = map[string]int{}
+	cocomo.lines = 0
+	cocomo.ticks = nil
+	cocomo.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (cocomo *CocomoAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !cocomo.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	tick := deps[items.DependencyDay].(int) / cocomo.Sampling
+	for len(cocomo.ticks) <= tick {
+		cocomo.ticks = append(cocomo.ticks, CocomoTick{Lines: cocomo.lines})
+	}
+	current := &cocomo.ticks[tick]
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		oldLines, exists := cocomo.files[change.From.Name]
+		if exists {
+			cocomo.lines -= oldLines
+			delete(cocomo.files, change.From.Name)
+		}
+		newLines := 0
+		if change.To.Name != "" {
+			newLines, err = items.CountLines(cache[change.To.TreeEntry.Hash])
+			if err != nil {
+				if err.Error() != "binary" {
+					return nil, err
+				}
+				newLines = 0
+			} else {
+				cocomo.files[change.To.Name] = newLines
+				cocomo.lines += newLines
+			}
+		}
+		switch action {
+		case merkletrie.Insert:
+			current.Added += newLines
+		case merkletrie.Delete:
+			current.Removed += oldLines
+		case merkletrie.Modify:
+			for _, edit := range fileDiffs[change.To.Name].Diffs {
+				// FileDiff encodes each line as a single rune
+				switch edit.Type {
+				case diffmatchpatch.DiffInsert:
+					current.Added += utf8.RuneCountInString(edit.Text)
+				case diffmatchpatch.DiffDelete:
+					current.Removed += utf8.RuneCountInString(edit.Text)
+				}
+			}
+		}
+	}
+	current.Lines = cocomo.lines
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (cocomo *CocomoAnalysis) Finalize() interface{} {
+	return CocomoResult{
+		Ticks:       cocomo.ticks,
+		Sampling:    cocomo.Sampling,
+		Coefficient: cocomo.Coefficient,
+		Exponent:    cocomo.Exponent,
+		MonthlyCost: cocomo.MonthlyCost,
+	}
+}
+
+// Effort returns the COCOMO estimate of the effort to write the specified number of lines
+// in person-months.
+func (result CocomoResult) Effort(lines int) float64 {
+	if lines <= 0 {
+		return 0
+	}
+	return result.Coefficient * math.Pow(float64(lines)/1000, result.Exponent)
+}
+
+// TickEffort returns the estimated effort to write the code base as of the end of the tick
+// and the estimated effort spent on the changes during the tick, in person-months.
+func (result CocomoResult) TickEffort(tick CocomoTick) (total float64, churn float64) {
+	return result.Effort(tick.Lines), result.Effort(tick.Added + tick.Removed)
+}
+
+// Fork clones this pipeline item.
+func (cocomo *CocomoAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(cocomo, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (cocomo *CocomoAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	cocomoResult := result.(CocomoResult)
+	if binary {
+		return cocomo.serializeBinary(&cocomoResult, writer)
+	}
+	cocomo.serializeText(&cocomoResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (cocomo *CocomoAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(cocomo, result, &pb.CocomoAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (cocomo *CocomoAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(cocomo, result, &pb.CocomoAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to CocomoResult.
+func (cocomo *CocomoAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.CocomoAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := CocomoResult{
+		Sampling:    int(message.Sampling),
+		Coefficient: message.Coefficient,
+		Exponent:    message.Exponent,
+		MonthlyCost: message.MonthlyCost,
+	}
+	if len(message.Ticks) > 0 {
+		result.Ticks = make([]CocomoTick, len(message.Ticks))
+		for i, tick := range message.Ticks {
+			result.Ticks[i] = CocomoTick{
+				Lines: int(tick.Lines), Added: int(tick.Added), Removed: int(tick.Removed)}
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two CocomoResult-s together. The ticks are regrouped by the larger
+// sampling, the changes are summed and so are the sizes: each repository keeps its last
+// known size after its history ends. The model parameters of the first result are used.
+func (cocomo *CocomoAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	cr1 := r1.(CocomoResult)
+	cr2 := r2.(CocomoResult)
+	merged := CocomoResult{
+		Sampling:    cr1.Sampling,
+		Coefficient: cr1.Coefficient,
+		Exponent:    cr1.Exponent,
+		MonthlyCost: cr1.MonthlyCost,
+	}
+	if cr2.Sampling > merged.Sampling {
+		merged.Sampling = cr2.Sampling
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	regroup := func(result *CocomoResult, c *core.CommonAnalysisResult) []CocomoTick {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		var ticks []CocomoTick
+		for i, tick := range result.Ticks {
+			index := (i*result.Sampling + offset) / merged.Sampling
+			for len(ticks) <= index {
+				ticks = append(ticks, CocomoTick{})
+			}
+			ticks[index].Lines = tick.Lines
+			ticks[index].Added += tick.Added
+			ticks[index].Removed += tick.Removed
+		}
+		return ticks
+	}
+	regrouped := [...][]CocomoTick{regroup(&cr1, c1), regroup(&cr2, c2)}
+	size := len(regrouped[0])
+	if len(regrouped[1]) > size {
+		size = len(regrouped[1])
+	}
+	for i := 0; i < size; i++ {
+		tick := CocomoTick{}
+		for _, ticks := range regrouped {
+			if i < len(ticks) {
+				tick.Lines += ticks[i].Lines
+				tick.Added += ticks[i].Added
+				tick.Removed += ticks[i].Removed
+			} else if len(ticks) > 0 {
+				tick.Lines += ticks[len(ticks)-1].Lines
+			}
+		}
+		merged.Ticks = append(merged.Ticks, tick)
+	}
+	return merged
+}
+
+func (cocomo *CocomoAnalysis) serializeText(result *CocomoResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintf(writer, "  coefficient: %.4f\n", result.Coefficient)
+	fmt.Fprintf(writer, "  exponent: %.4f\n", result.Exponent)
+	fmt.Fprintf(writer, "  monthly_cost: %.2f\n", result.MonthlyCost)
+	fmt.Fprintln(writer, "  # the efforts are in person-months")
+	fmt.Fprintln(writer, "  ticks:")
+	for _, tick := range result.Ticks {
+		effort, churnEffort := result.TickEffort(tick)
+		fmt.Fprintf(writer, "    - {lines: %d, added: %d, removed: %d, effort: %.2f, "+
+			"churn_effort: %.2f, cost: %.0f, churn_cost: %.0f}\n",
+			tick.Lines, tick.Added, tick.Removed, effort, churnEffort,
+			effort*result.MonthlyCost, churnEffort*result.MonthlyCost)
+	}
+}
+
+func (cocomo *CocomoAnalysis) serializeBinary(result *CocomoResult, writer io.Writer) error {
+	message := pb.CocomoAnalysisResults{
+		Ticks:       make([]*pb.CocomoTick, len(result.Ticks)),
+		Sampling:    int32(result.Sampling),
+		Coefficient: result.Coefficient,
+		Exponent:    result.Exponent,
+		MonthlyCost: result.MonthlyCost,
+	}
+	for i, tick := range result.Ticks {
+		effort, churnEffort := result.TickEffort(tick)
+		message.Ticks[i] = &pb.CocomoTick{
+			Lines:       int32(tick.Lines),
+			Added:       int32(tick.Added),
+			Removed:     int32(tick.Removed),
+			Effort:      effort,
+			ChurnEffort: churnEffort,
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&CocomoAnalysis{})
+}
@@ -0,0 +1,182 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func fixtureCocomo() *CocomoAnalysis {
+	cocomo := CocomoAnalysis{}
+	cocomo.Configure(map[string]interface{}{
+		ConfigCocomoSampling:    10,
+		ConfigCocomoCoefficient: float32(3.0),
+		ConfigCocomoExponent:    float32(1.0),
+		ConfigCocomoMonthlyCost: float32(100.0),
+	})
+	cocomo.Initialize(nil)
+	return &cocomo
+}
+
+func TestCocomoMeta(t *testing.T) {
+	cocomo := fixtureCocomo()
+	assert.Equal(t, cocomo.Name(), "Cocomo")
+	assert.Len(t, cocomo.Provides(), 0)
+	assert.Equal(t, cocomo.Requires(), []string{
+		items.DependencyDay, items.DependencyTreeChanges, items.DependencyFileDiff,
+		items.DependencyBlobCache})
+	assert.Equal(t, cocomo.Flag(), "cocomo")
+	assert.NotEmpty(t, cocomo.Description())
+	opts := cocomo.ListConfigurationOptions()
+	assert.Len(t, opts, 4)
+	assert.Equal(t, opts[0].Flag, "cocomo-sampling")
+	assert.Equal(t, opts[1].Flag, "cocomo-coefficient")
+	assert.Equal(t, opts[2].Flag, "cocomo-exponent")
+	assert.Equal(t, opts[3].Flag, "cocomo-monthly-cost")
+	assert.Equal(t, cocomo.Sampling, 10)
+	assert.Equal(t, cocomo.Coefficient, 3.0)
+	assert.Equal(t, cocomo.Exponent, 1.0)
+	assert.Equal(t, cocomo.MonthlyCost, 100.0)
+	cocomo = &CocomoAnalysis{MonthlyCost: -1}
+	cocomo.Initialize(nil)
+	assert.Equal(t, cocomo.Sampling, DefaultCocomoSampling)
+	assert.Equal(t, cocomo.Coefficient, DefaultCocomoCoefficient)
+	assert.Equal(t, cocomo.Exponent, DefaultCocomoExponent)
+	assert.Equal(t, cocomo.MonthlyCost, DefaultCocomoMonthlyCost)
+	summoned := core.Registry.Summon(cocomo.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Cocomo")
+}
+
+func TestCocomoEffort(t *testing.T) {
+	result := CocomoResult{Coefficient: DefaultCocomoCoefficient, Exponent: DefaultCocomoExponent}
+	assert.Equal(t, result.Effort(0), float64(0))
+	assert.InDelta(t, result.Effort(1000), 2.4, 1e-9)
+	assert.InDelta(t, result.Effort(10000), 26.93, 0.01)
+	effort, churn := result.TickEffort(CocomoTick{Lines: 1000, Added: 500, Removed: 500})
+	assert.InDelta(t, effort, 2.4, 1e-9)
+	assert.InDelta(t, churn, 2.4, 1e-9)
+}
+
+func fixtureCocomoResult(t *testing.T) CocomoResult {
+	hash, blob := storeExpertiseBlob(t, "one\ntwo\nthree\nfour\n")
+	binaryHash, binaryBlob := storeExpertiseBlob(t, "\x00\x01\x02")
+	cache := map[plumbing.Hash]*object.Blob{hash: blob, binaryHash: binaryBlob}
+	entry := func(name string, hash plumbing.Hash) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	cocomo := fixtureCocomo()
+	consume := func(day int, merge bool, changes object.Changes,
+		fileDiffs map[string]items.FileDiffData) {
+		commit := &object.Commit{}
+		if merge {
+			commit.Hash = plumbing.NewHash("0123456789012345678901234567890123456789")
+			commit.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		result, err := cocomo.Consume(map[string]interface{}{
+			core.DependencyCommit:       commit,
+			core.DependencyIsMerge:      merge,
+			items.DependencyDay:         day,
+			items.DependencyTreeChanges: changes,
+			items.DependencyFileDiff:    fileDiffs,
+			items.DependencyBlobCache:   cache,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	consume(0, false, object.Changes{
+		{To: entry("a.go", hash)}, {To: entry("b.go", hash)}, {To: entry("c.png", binaryHash)}}, nil)
+	// one line is replaced with two
+	consume(5, false, object.Changes{{From: entry("a.go", hash), To: entry("a.go", hash)}},
+		map[string]items.FileDiffData{"a.go": {
+			OldLinesOfCode: 4, NewLinesOfCode: 5, Diffs: []diffmatchpatch.Diff{
+				{Type: diffmatchpatch.DiffEqual, Text: "abc"},
+				{Type: diffmatchpatch.DiffDelete, Text: "d"},
+				{Type: diffmatchpatch.DiffInsert, Text: "ef"},
+			}}})
+	consume(25, false, object.Changes{{From: entry("b.go", hash)}}, nil)
+	consume(26, true, object.Changes{{To: entry("d.go", hash)}}, nil)
+	return cocomo.Finalize().(CocomoResult)
+}
+
+func TestCocomoConsumeFinalize(t *testing.T) {
+	result := fixtureCocomoResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Coefficient, 3.0)
+	assert.Equal(t, result.Exponent, 1.0)
+	assert.Equal(t, result.MonthlyCost, 100.0)
+	// the modified file has the same blob in this fixture, so the size does not change
+	assert.Equal(t, result.Ticks, []CocomoTick{
+		{Lines: 8, Added: 10, Removed: 1},
+		{Lines: 8},
+		{Lines: 4, Removed: 4},
+	})
+}
+
+func TestCocomoSerialize(t *testing.T) {
+	result := fixtureCocomoResult(t)
+	cocomo := fixtureCocomo()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, cocomo.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  coefficient: 3.0000
+  exponent: 1.0000
+  monthly_cost: 100.00
+  # the efforts are in person-months
+  ticks:
+    - {lines: 8, added: 10, removed: 1, effort: 0.02, churn_effort: 0.03, cost: 2, churn_cost: 3}
+    - {lines: 8, added: 0, removed: 0, effort: 0.02, churn_effort: 0.00, cost: 2, churn_cost: 0}
+    - {lines: 4, added: 0, removed: 4, effort: 0.01, churn_effort: 0.01, cost: 1, churn_cost: 1}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, cocomo.Serialize(result, true, buffer))
+	msg := pb.CocomoAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Equal(t, msg.Coefficient, 3.0)
+	assert.Len(t, msg.Ticks, 3)
+	assert.Equal(t, msg.Ticks[0].Added, int32(10))
+	assert.InDelta(t, msg.Ticks[0].Effort, 0.024, 1e-9)
+	assert.InDelta(t, msg.Ticks[0].ChurnEffort, 0.033, 1e-9)
+	deserialized, err := cocomo.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestCocomoMergeResults(t *testing.T) {
+	r1 := CocomoResult{
+		Ticks:       []CocomoTick{{Lines: 10, Added: 10}, {Lines: 15, Added: 6, Removed: 1}},
+		Sampling:    10,
+		Coefficient: 3,
+		Exponent:    1,
+		MonthlyCost: 100,
+	}
+	r2 := CocomoResult{
+		Ticks:       []CocomoTick{{Lines: 100, Added: 100}, {Lines: 90, Removed: 10}},
+		Sampling:    20,
+		Coefficient: 2,
+		Exponent:    2,
+		MonthlyCost: 50,
+	}
+	cocomo := fixtureCocomo()
+	merged := cocomo.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 20 * 24 * 3600}).(CocomoResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.Coefficient, 3.0)
+	assert.Equal(t, merged.Exponent, 1.0)
+	assert.Equal(t, merged.MonthlyCost, 100.0)
+	assert.Equal(t, merged.Ticks, []CocomoTick{
+		{Lines: 15, Added: 16, Removed: 1},
+		{Lines: 115, Added: 100},
+		{Lines: 105, Removed: 10},
+	})
+}
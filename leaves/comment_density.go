@@ -0,0 +1,480 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// CommentDensityAnalysis measures how much of the code is commented: it counts the lines
+// which belong to the UAST nodes with the Comment role in every parsed file and samples
+// the totals of the project and of each directory every Sampling days. The files which cannot
+// be parsed are not counted. The merge commits are skipped.
+type CommentDensityAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the size of a tick in days.
+	Sampling int
+	// DirectoryDepth is the number of path components which define the directories.
+	DirectoryDepth int
+
+	// files map the file names to their current comment density.
+	files map[string]CommentDensity
+	// directories map the directory names to their current comment density.
+	directories map[string]CommentDensity
+	// total is the current comment density of the whole project.
+	total CommentDensity
+	// project is the comment density of the project at the end of each tick.
+	project []CommentDensity
+	// directoryTicks is the comment density of each directory at the end of each tick.
+	directoryTicks map[string][]CommentDensity
+}
+
+// CommentDensity is the number of the commented lines in a file or in several files.
+type CommentDensity struct {
+	// Lines is the total number of lines.
+	Lines int
+	// CommentLines is the number of lines which contain comments.
+	CommentLines int
+	// Comments is the number of the comment nodes.
+	Comments int
+}
+
+// CommentDensityResult is returned by CommentDensityAnalysis.Finalize().
+type CommentDensityResult struct {
+	// Project is the comment density of the project at the end of each tick.
+	Project []CommentDensity
+	// Directories is the comment density of each directory at the end of each tick.
+	Directories map[string][]CommentDensity
+	// Sampling is the size of a tick in days.
+	Sampling int
+}
+
+const (
+	// ConfigCommentDensitySampling is the name of the option to set
+	// CommentDensityAnalysis.Sampling.
+	ConfigCommentDensitySampling = "CommentDensity.Sampling"
+	// ConfigCommentDensityDirectoryDepth is the name of the option to set
+	// CommentDensityAnalysis.DirectoryDepth.
+	ConfigCommentDensityDirectoryDepth = "CommentDensity.DirectoryDepth"
+	// DefaultCommentDensitySampling is the default value of CommentDensityAnalysis.Sampling.
+	DefaultCommentDensitySampling = 30
+	// DefaultCommentDensityDirectoryDepth is the default value of
+	// CommentDensityAnalysis.DirectoryDepth: the top-level directories.
+	DefaultCommentDensityDirectoryDepth = 1
+)
+
+// Density returns the ratio of the commented lines to all the lines.
+func (density CommentDensity) Density() float64 {
+	if density.Lines == 0 {
+		return 0
+	}
+	return float64(density.CommentLines) / float64(density.Lines)
+}
+
+func (density *CommentDensity) add(other CommentDensity) {
+	density.Lines += other.Lines
+	density.CommentLines += other.CommentLines
+	density.Comments += other.Comments
+}
+
+func (density *CommentDensity) sub(other CommentDensity) {
+	density.Lines -= other.Lines
+	density.CommentLines -= other.CommentLines
+	density.Comments -= other.Comments
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (analyser *CommentDensityAnalysis) Name() string {
+	return "CommentDensity"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (analyser *CommentDensityAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (analyser *CommentDensityAnalysis) Requires() []string {
+	arr := [...]string{
+		uast_items.DependencyUastChanges, items.DependencyBlobCache, items.DependencyDay}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (analyser *CommentDensityAnalysis) Features() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (analyser *CommentDensityAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigCommentDensitySampling,
+		Description: "How frequently to record the comment density in days.",
+		Flag:        "comment-density-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultCommentDensitySampling}, {
+		Name:        ConfigCommentDensityDirectoryDepth,
+		Description: "Group the files by this number of the leading path components.",
+		Flag:        "comment-density-dirs",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultCommentDensityDirectoryDepth},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (analyser *CommentDensityAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigCommentDensitySampling].(int); exists {
+		analyser.Sampling = val
+	}
+	if val, exists := facts[ConfigCommentDensityDirectoryDepth].(int); exists {
+		analyser.DirectoryDepth = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (analyser *CommentDensityAnalysis) Flag() string {
+	return "comment-density"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (analyser *CommentDensityAnalysis) Description() string {
+	return "Measures the ratio of the commented lines to all the lines over time " +
+		"in the whole project and in each directory."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (analyser *CommentDensityAnalysis) Initialize(repository *git.Repository) {
+	if analyser.Sampling <= 0 {
+		log.Printf("Warning: adjusted the comment density sampling to %d days\n",
+			DefaultCommentDensitySampling)
+		analyser.Sampling = DefaultCommentDensitySampling
+	}
+	if analyser.DirectoryDepth <= 0 {
+		log.Printf("Warning: adjusted the comment density directory depth to %d\n",
+			DefaultCommentDensityDirectoryDepth)
+		analyser.DirectoryDepth = DefaultCommentDensityDirectoryDepth
+	}
+	analyser.files = map[string]CommentDensity{}
+	analyser.directories = map[string]CommentDensity{}
+	analyser.total = CommentDensity{}
+	analyser.project = []CommentDensity{}
+	analyser.directoryTicks = map[string][]CommentDensity{}
+	analyser.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (analyser *CommentDensityAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !analyser.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	day := deps[items.DependencyDay].(int)
+	tick := day / analyser.Sampling
+	for len(analyser.project) <= tick {
+		analyser.project = append(analyser.project, analyser.total)
+	}
+	touched := map[string]bool{}
+	for _, change := range changes {
+		fromName, toName := change.Change.From.Name, change.Change.To.Name
+		old, exists := analyser.files[fromName]
+		if exists {
+			analyser.update(fromName, old, true, touched)
+		}
+		if toName == "" {
+			continue
+		}
+		if change.After == nil {
+			// could not be parsed, keep the previous state
+			if exists {
+				analyser.update(toName, old, false, touched)
+			}
+			continue
+		}
+		lines, err := items.CountLines(cache[change.Change.To.TreeEntry.Hash])
+		if err != nil {
+			if err.Error() == "binary" {
+				continue
+			}
+			return nil, err
+		}
+		density := measureComments(change.After)
+		density.Lines = lines
+		analyser.update(toName, density, false, touched)
+	}
+	analyser.project[tick] = analyser.total
+	for dir := range touched {
+		ticks := analyser.directoryTicks[dir]
+		for len(ticks) <= tick {
+			var last CommentDensity
+			if len(ticks) > 0 {
+				last = ticks[len(ticks)-1]
+			}
+			ticks = append(ticks, last)
+		}
+		ticks[tick] = analyser.directories[dir]
+		analyser.directoryTicks[dir] = ticks
+		if analyser.directories[dir] == (CommentDensity{}) {
+			delete(analyser.directories, dir)
+		}
+	}
+	return nil, nil
+}
+
+// update adds the file to the totals or removes it from them.
+func (analyser *CommentDensityAnalysis) update(
+	name string, density CommentDensity, remove bool, touched map[string]bool) {
+	dir := truncateDirectory(name, analyser.DirectoryDepth)
+	dirDensity := analyser.directories[dir]
+	if remove {
+		delete(analyser.files, name)
+		dirDensity.sub(density)
+		analyser.total.sub(density)
+	} else {
+		analyser.files[name] = density
+		dirDensity.add(density)
+		analyser.total.add(density)
+	}
+	analyser.directories[dir] = dirDensity
+	touched[dir] = true
+}
+
+// measureComments counts the comment nodes and the lines which they span.
+func measureComments(root *uast.Node) CommentDensity {
+	var density CommentDensity
+	lines := map[uint32]bool{}
+	var visit func(node *uast.Node)
+	visit = func(node *uast.Node) {
+		if hasRoles(node, uast.Comment) {
+			density.Comments++
+			if node.StartPosition != nil {
+				start := node.StartPosition.Line
+				end := start + uint32(strings.Count(strings.TrimRight(node.Token, "\n"), "\n"))
+				if node.EndPosition != nil && node.EndPosition.Line > start {
+					end = node.EndPosition.Line
+				}
+				for line := start; line <= end; line++ {
+					lines[line] = true
+				}
+			}
+			// the nested comment nodes, e.g. in the docstrings, are not counted twice
+			return
+		}
+		for _, child := range node.Children {
+			visit(child)
+		}
+	}
+	visit(root)
+	density.CommentLines = len(lines)
+	return density
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (analyser *CommentDensityAnalysis) Finalize() interface{} {
+	directories := map[string][]CommentDensity{}
+	for dir, ticks := range analyser.directoryTicks {
+		// carry the last value forward to the end
+		padded := make([]CommentDensity, len(analyser.project))
+		copy(padded, ticks)
+		for i := len(ticks); i < len(padded); i++ {
+			padded[i] = ticks[len(ticks)-1]
+		}
+		directories[dir] = padded
+	}
+	return CommentDensityResult{
+		Project:     analyser.project,
+		Directories: directories,
+		Sampling:    analyser.Sampling,
+	}
+}
+
+// Fork clones this pipeline item.
+func (analyser *CommentDensityAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(analyser, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (analyser *CommentDensityAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	densityResult := result.(CommentDensityResult)
+	if binary {
+		return analyser.serializeBinary(&densityResult, writer)
+	}
+	analyser.serializeText(&densityResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (analyser *CommentDensityAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(analyser, result, &pb.CommentDensityAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (analyser *CommentDensityAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(analyser, result, &pb.CommentDensityAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to CommentDensityResult.
+func (analyser *CommentDensityAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.CommentDensityAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	convert := func(series []*pb.CommentDensity) []CommentDensity {
+		result := make([]CommentDensity, len(series))
+		for i, density := range series {
+			result[i] = CommentDensity{
+				Lines:        int(density.Lines),
+				CommentLines: int(density.CommentLines),
+				Comments:     int(density.Comments),
+			}
+		}
+		return result
+	}
+	result := CommentDensityResult{
+		Project:     convert(message.Project),
+		Directories: map[string][]CommentDensity{},
+		Sampling:    int(message.Sampling),
+	}
+	for dir, series := range message.Directories {
+		result.Directories[dir] = convert(series.Ticks)
+	}
+	return result, nil
+}
+
+// MergeResults combines two CommentDensityResult-s together. The ticks are resampled to
+// the bigger sampling of the two.
+func (analyser *CommentDensityAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	cr1 := r1.(CommentDensityResult)
+	cr2 := r2.(CommentDensityResult)
+	sampling := cr1.Sampling
+	if cr2.Sampling > sampling {
+		sampling = cr2.Sampling
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	offsets := [2]int{
+		int((c1.BeginTime - beginTime) / (24 * 3600)),
+		int((c2.BeginTime - beginTime) / (24 * 3600)),
+	}
+	results := [2]*CommentDensityResult{&cr1, &cr2}
+	days := 0
+	for i, result := range results {
+		if end := offsets[i] + len(result.Project)*result.Sampling; end > days {
+			days = end
+		}
+	}
+	ticks := (days + sampling - 1) / sampling
+	// resample returns the value of the series at the end of each merged tick
+	resample := func(dst, series []CommentDensity, seriesSampling, offset int) {
+		for tick := range dst {
+			day := (tick+1)*sampling - 1
+			if day < offset || len(series) == 0 {
+				continue
+			}
+			index := (day - offset) / seriesSampling
+			if index >= len(series) {
+				index = len(series) - 1
+			}
+			dst[tick].add(series[index])
+		}
+	}
+	merged := CommentDensityResult{
+		Project:     make([]CommentDensity, ticks),
+		Directories: map[string][]CommentDensity{},
+		Sampling:    sampling,
+	}
+	for i, result := range results {
+		resample(merged.Project, result.Project, result.Sampling, offsets[i])
+		for dir, series := range result.Directories {
+			if merged.Directories[dir] == nil {
+				merged.Directories[dir] = make([]CommentDensity, ticks)
+			}
+			resample(merged.Directories[dir], series, result.Sampling, offsets[i])
+		}
+	}
+	return merged
+}
+
+func (analyser *CommentDensityAnalysis) serializeText(result *CommentDensityResult, writer io.Writer) {
+	formatSeries := func(series []CommentDensity) string {
+		parts := make([]string, len(series))
+		for i, density := range series {
+			parts[i] = fmt.Sprintf("[%d, %d, %d]", density.Lines, density.CommentLines, density.Comments)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	}
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  # [lines, comment lines, comments]")
+	fmt.Fprintln(writer, "  project:", formatSeries(result.Project))
+	dirs := make([]string, 0, len(result.Directories))
+	for dir := range result.Directories {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	fmt.Fprintln(writer, "  directories:")
+	for _, dir := range dirs {
+		fmt.Fprintf(writer, "    %s: %s\n", yaml.SafeString(dir), formatSeries(result.Directories[dir]))
+	}
+}
+
+func (analyser *CommentDensityAnalysis) serializeBinary(result *CommentDensityResult, writer io.Writer) error {
+	convert := func(series []CommentDensity) []*pb.CommentDensity {
+		result := make([]*pb.CommentDensity, len(series))
+		for i, density := range series {
+			result[i] = &pb.CommentDensity{
+				Lines:        int32(density.Lines),
+				CommentLines: int32(density.CommentLines),
+				Comments:     int32(density.Comments),
+			}
+		}
+		return result
+	}
+	message := pb.CommentDensityAnalysisResults{
+		Sampling:    int32(result.Sampling),
+		Project:     convert(result.Project),
+		Directories: map[string]*pb.CommentDensitySeries{},
+	}
+	for dir, series := range result.Directories {
+		message.Directories[dir] = &pb.CommentDensitySeries{Ticks: convert(series)}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&CommentDensityAnalysis{})
+}
@@ -0,0 +1,206 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+)
+
+func fixtureCommentDensity() *CommentDensityAnalysis {
+	analyser := CommentDensityAnalysis{}
+	analyser.Configure(map[string]interface{}{
+		ConfigCommentDensitySampling:       10,
+		ConfigCommentDensityDirectoryDepth: 1,
+	})
+	analyser.Initialize(nil)
+	return &analyser
+}
+
+func commentNode(line uint32, token string) *uast.Node {
+	return &uast.Node{
+		Roles: []uast.Role{uast.Comment}, Token: token,
+		StartPosition: &uast.Position{Line: line}}
+}
+
+func TestCommentDensityMeta(t *testing.T) {
+	analyser := fixtureCommentDensity()
+	assert.Equal(t, analyser.Name(), "CommentDensity")
+	assert.Len(t, analyser.Provides(), 0)
+	assert.Equal(t, analyser.Requires(), []string{
+		uast_items.DependencyUastChanges, items.DependencyBlobCache, items.DependencyDay})
+	assert.Equal(t, analyser.Features(), []string{uast_items.FeatureUast})
+	assert.Equal(t, analyser.Flag(), "comment-density")
+	assert.NotEmpty(t, analyser.Description())
+	opts := analyser.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Flag, "comment-density-sampling")
+	assert.Equal(t, opts[1].Flag, "comment-density-dirs")
+	assert.Equal(t, analyser.Sampling, 10)
+	analyser = &CommentDensityAnalysis{}
+	analyser.Initialize(nil)
+	assert.Equal(t, analyser.Sampling, DefaultCommentDensitySampling)
+	assert.Equal(t, analyser.DirectoryDepth, DefaultCommentDensityDirectoryDepth)
+	summoned := core.Registry.Summon(analyser.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "CommentDensity")
+}
+
+func TestCommentDensityMeasure(t *testing.T) {
+	root := &uast.Node{Children: []*uast.Node{
+		commentNode(1, "// one"),
+		{Children: []*uast.Node{commentNode(3, "/* two\n three\n*/\n")}},
+		// the same line
+		commentNode(3, "/* four */"),
+		{Roles: []uast.Role{uast.Comment}, StartPosition: &uast.Position{Line: 7},
+			EndPosition: &uast.Position{Line: 8},
+			Children:    []*uast.Node{commentNode(7, "nested")}},
+		{Roles: []uast.Role{uast.Comment}},
+	}}
+	assert.Equal(t, measureComments(root), CommentDensity{CommentLines: 6, Comments: 5})
+	assert.Equal(t, measureComments(&uast.Node{}), CommentDensity{})
+	assert.Equal(t, CommentDensity{}.Density(), float64(0))
+	assert.Equal(t, CommentDensity{Lines: 4, CommentLines: 1}.Density(), 0.25)
+}
+
+func fixtureCommentDensityResult(t *testing.T) CommentDensityResult {
+	storage := memory.NewStorage()
+	cache := map[plumbing.Hash]*object.Blob{}
+	blob := func(lines int) plumbing.Hash {
+		encoded := storage.NewEncodedObject()
+		encoded.SetType(plumbing.BlobObject)
+		writer, _ := encoded.Writer()
+		for i := 0; i < lines; i++ {
+			writer.Write([]byte("line\n"))
+		}
+		writer.Close()
+		hash, err := storage.SetEncodedObject(encoded)
+		assert.Nil(t, err)
+		cache[hash], err = object.GetBlob(storage, hash)
+		assert.Nil(t, err)
+		return hash
+	}
+	analyser := fixtureCommentDensity()
+	consume := func(day int, changes ...uast_items.Change) {
+		result, err := analyser.Consume(map[string]interface{}{
+			core.DependencyCommit:            &object.Commit{},
+			core.DependencyIsMerge:           false,
+			uast_items.DependencyUastChanges: changes,
+			items.DependencyBlobCache:        cache,
+			items.DependencyDay:              day,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	change := func(from, to string, lines int, comments ...*uast.Node) uast_items.Change {
+		var after *uast.Node
+		if comments != nil {
+			after = &uast.Node{Children: comments}
+		}
+		result := uast_items.Change{After: after, Change: &object.Change{
+			From: object.ChangeEntry{Name: from}, To: object.ChangeEntry{Name: to}}}
+		if to != "" {
+			result.Change.To.TreeEntry.Hash = blob(lines)
+		}
+		return result
+	}
+	consume(0,
+		change("", "main.go", 10, commentNode(1, "// a"), commentNode(2, "// b")),
+		change("", "lib/a.go", 4, commentNode(1, "// c")))
+	// nothing in tick 1
+	consume(25, change("main.go", "main.go", 20, commentNode(1, "// a")))
+	// could not be parsed
+	consume(26, change("lib/a.go", "pkg/a.go", 5))
+	consume(27, change("main.go", "", 0))
+	return analyser.Finalize().(CommentDensityResult)
+}
+
+func TestCommentDensityConsumeFinalize(t *testing.T) {
+	result := fixtureCommentDensityResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Project, []CommentDensity{
+		{Lines: 14, CommentLines: 3, Comments: 3},
+		{Lines: 14, CommentLines: 3, Comments: 3},
+		{Lines: 4, CommentLines: 1, Comments: 1},
+	})
+	assert.Equal(t, result.Directories, map[string][]CommentDensity{
+		".": {{Lines: 10, CommentLines: 2, Comments: 2}, {Lines: 10, CommentLines: 2, Comments: 2},
+			{}},
+		"lib": {{Lines: 4, CommentLines: 1, Comments: 1}, {Lines: 4, CommentLines: 1, Comments: 1},
+			{}},
+		"pkg": {{}, {}, {Lines: 4, CommentLines: 1, Comments: 1}},
+	})
+}
+
+func TestCommentDensityConsumeMerge(t *testing.T) {
+	analyser := fixtureCommentDensity()
+	result, err := analyser.Consume(map[string]interface{}{
+		core.DependencyCommit: &object.Commit{
+			ParentHashes: make([]plumbing.Hash, 2)},
+		core.DependencyIsMerge: true,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+	assert.Len(t, analyser.Finalize().(CommentDensityResult).Project, 0)
+}
+
+func TestCommentDensitySerialize(t *testing.T) {
+	result := fixtureCommentDensityResult(t)
+	analyser := fixtureCommentDensity()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  # [lines, comment lines, comments]
+  project: [[14, 3, 3], [14, 3, 3], [4, 1, 1]]
+  directories:
+    ".": [[10, 2, 2], [10, 2, 2], [0, 0, 0]]
+    "lib": [[4, 1, 1], [4, 1, 1], [0, 0, 0]]
+    "pkg": [[0, 0, 0], [0, 0, 0], [4, 1, 1]]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, true, buffer))
+	msg := pb.CommentDensityAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Len(t, msg.Project, 3)
+	assert.Len(t, msg.Directories, 3)
+	deserialized, err := analyser.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestCommentDensityMergeResults(t *testing.T) {
+	r1 := CommentDensityResult{
+		Project: []CommentDensity{{Lines: 10, CommentLines: 1}, {Lines: 20, CommentLines: 2}},
+		Directories: map[string][]CommentDensity{
+			".": {{Lines: 10, CommentLines: 1}, {Lines: 20, CommentLines: 2}}},
+		Sampling: 10,
+	}
+	r2 := CommentDensityResult{
+		Project: []CommentDensity{{Lines: 5, CommentLines: 5}},
+		Directories: map[string][]CommentDensity{
+			".":   {{Lines: 1, CommentLines: 1}},
+			"src": {{Lines: 4, CommentLines: 4}}},
+		Sampling: 20,
+	}
+	analyser := fixtureCommentDensity()
+	merged := analyser.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 20 * 24 * 3600}).(CommentDensityResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.Project, []CommentDensity{
+		{Lines: 20, CommentLines: 2}, {Lines: 25, CommentLines: 7}})
+	assert.Equal(t, merged.Directories, map[string][]CommentDensity{
+		".":   {{Lines: 20, CommentLines: 2}, {Lines: 21, CommentLines: 3}},
+		"src": {{}, {Lines: 4, CommentLines: 4}},
+	})
+}
@@ -254,6 +254,16 @@ func (sent *CommentSentimentAnalysis) Serialize(result interface{}, binary bool,
 	return nil
 }
 
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (sent *CommentSentimentAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(sent, result, &pb.CommentSentimentResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (sent *CommentSentimentAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(sent, result, &pb.CommentSentimentResults{})
+}
+
 func (sent *CommentSentimentAnalysis) serializeText(result *CommentSentimentResult, writer io.Writer) {
 	days := make([]int, 0, len(result.EmotionsByDay))
 	for day := range result.EmotionsByDay {
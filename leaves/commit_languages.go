@@ -0,0 +1,310 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/langdetect"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// CommitLanguagesAnalysis detects the natural language of each commit message and counts
+// the languages on each day and of each developer. The merge commits are skipped because
+// their messages are usually generated.
+type CommitLanguagesAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// PeopleNumber is the number of developers.
+	PeopleNumber int
+
+	// days maps the day index to the number of commits in each language.
+	days map[int]map[string]int
+	// people is the number of commits in each language of each developer.
+	people []map[string]int
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// CommitLanguagesResult is returned by CommitLanguagesAnalysis.Finalize().
+// The languages are ISO 639-1 codes or langdetect.Undetermined.
+type CommitLanguagesResult struct {
+	// Days maps the day index to the number of commits in each language.
+	Days map[int]map[string]int
+	// People is the number of commits in each language of each developer. The last element
+	// corresponds to the unmatched identities.
+	People []map[string]int
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (langs *CommitLanguagesAnalysis) Name() string {
+	return "CommitLanguages"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (langs *CommitLanguagesAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (langs *CommitLanguagesAnalysis) Requires() []string {
+	arr := [...]string{identity.DependencyAuthor, items.DependencyDay}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (langs *CommitLanguagesAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (langs *CommitLanguagesAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		langs.PeopleNumber = val
+		langs.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (langs *CommitLanguagesAnalysis) Flag() string {
+	return "commit-languages"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (langs *CommitLanguagesAnalysis) Description() string {
+	return "Detects the natural language of the commit messages and counts the languages " +
+		"on each day and of each developer."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (langs *CommitLanguagesAnalysis) Initialize(repository *git.Repository) {
+	langs.days = map[int]map[string]int{}
+	langs.people = make([]map[string]int, langs.PeopleNumber+1)
+	for i := range langs.people {
+		langs.people[i] = map[string]int{}
+	}
+	langs.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (langs *CommitLanguagesAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !langs.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing || author > langs.PeopleNumber {
+		author = langs.PeopleNumber
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	lang := langdetect.Detect(langdetect.Clean(commit.Message))
+	day := deps[items.DependencyDay].(int)
+	dayLangs := langs.days[day]
+	if dayLangs == nil {
+		dayLangs = map[string]int{}
+		langs.days[day] = dayLangs
+	}
+	dayLangs[lang]++
+	langs.people[author][lang]++
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (langs *CommitLanguagesAnalysis) Finalize() interface{} {
+	return CommitLanguagesResult{
+		Days:               langs.days,
+		People:             langs.people,
+		reversedPeopleDict: langs.reversedPeopleDict,
+	}
+}
+
+// Fork clones this pipeline item.
+func (langs *CommitLanguagesAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(langs, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (langs *CommitLanguagesAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	langsResult := result.(CommitLanguagesResult)
+	if binary {
+		return langs.serializeBinary(&langsResult, writer)
+	}
+	langs.serializeText(&langsResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (langs *CommitLanguagesAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(langs, result, &pb.CommitLanguagesAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (langs *CommitLanguagesAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(langs, result, &pb.CommitLanguagesAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to CommitLanguagesResult.
+func (langs *CommitLanguagesAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.CommitLanguagesAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	convert := func(counts *pb.LanguageCounts) map[string]int {
+		result := map[string]int{}
+		if counts == nil {
+			return result
+		}
+		for lang, val := range counts.Languages {
+			result[lang] = int(val)
+		}
+		return result
+	}
+	result := CommitLanguagesResult{
+		Days:               map[int]map[string]int{},
+		People:             make([]map[string]int, len(message.People)),
+		reversedPeopleDict: message.DevIndex,
+	}
+	for day, counts := range message.Days {
+		result.Days[int(day)] = convert(counts)
+	}
+	for i, counts := range message.People {
+		result.People[i] = convert(counts)
+	}
+	return result, nil
+}
+
+// MergeResults combines two CommitLanguagesResult-s together.
+func (langs *CommitLanguagesAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	lr1 := r1.(CommitLanguagesResult)
+	lr2 := r2.(CommitLanguagesResult)
+	merged := CommitLanguagesResult{Days: map[int]map[string]int{}}
+	var people map[string][3]int
+	people, merged.reversedPeopleDict = identity.Detector{}.MergeReversedDicts(
+		lr1.reversedPeopleDict, lr2.reversedPeopleDict)
+	merged.People = make([]map[string]int, len(merged.reversedPeopleDict)+1)
+	for i := range merged.People {
+		merged.People[i] = map[string]int{}
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	add := func(result *CommitLanguagesResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for day, counts := range result.Days {
+			dayLangs := merged.Days[day+offset]
+			if dayLangs == nil {
+				dayLangs = map[string]int{}
+				merged.Days[day+offset] = dayLangs
+			}
+			for lang, val := range counts {
+				dayLangs[lang] += val
+			}
+		}
+		for dev, counts := range result.People {
+			index := len(merged.reversedPeopleDict)
+			if dev < len(result.reversedPeopleDict) {
+				index = people[result.reversedPeopleDict[dev]][0]
+			}
+			for lang, val := range counts {
+				merged.People[index][lang] += val
+			}
+		}
+	}
+	add(&lr1, c1)
+	add(&lr2, c2)
+	return merged
+}
+
+func (langs *CommitLanguagesAnalysis) serializeText(result *CommitLanguagesResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  days:")
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	for _, day := range days {
+		fmt.Fprintf(writer, "    %d: ", day)
+		writeLanguageCounts(writer, result.Days[day])
+		fmt.Fprintln(writer)
+	}
+	fmt.Fprintln(writer, "  people_languages:")
+	for _, counts := range result.People {
+		fmt.Fprint(writer, "  - ")
+		writeLanguageCounts(writer, counts)
+		fmt.Fprintln(writer)
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+// writeLanguageCounts prints the languages in the YAML flow style in alphabetical order.
+func writeLanguageCounts(writer io.Writer, counts map[string]int) {
+	keys := make([]string, 0, len(counts))
+	for lang := range counts {
+		keys = append(keys, lang)
+	}
+	sort.Strings(keys)
+	fmt.Fprint(writer, "{")
+	for i, lang := range keys {
+		if i > 0 {
+			fmt.Fprint(writer, ", ")
+		}
+		fmt.Fprintf(writer, "%s: %d", lang, counts[lang])
+	}
+	fmt.Fprint(writer, "}")
+}
+
+func (langs *CommitLanguagesAnalysis) serializeBinary(result *CommitLanguagesResult, writer io.Writer) error {
+	convert := func(counts map[string]int) *pb.LanguageCounts {
+		message := &pb.LanguageCounts{Languages: map[string]int32{}}
+		for lang, val := range counts {
+			message.Languages[lang] = int32(val)
+		}
+		return message
+	}
+	message := pb.CommitLanguagesAnalysisResults{
+		Days:     map[int32]*pb.LanguageCounts{},
+		People:   make([]*pb.LanguageCounts, len(result.People)),
+		DevIndex: result.reversedPeopleDict,
+	}
+	for day, counts := range result.Days {
+		message.Days[int32(day)] = convert(counts)
+	}
+	for i, counts := range result.People {
+		message.People[i] = convert(counts)
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&CommitLanguagesAnalysis{})
+}
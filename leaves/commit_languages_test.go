@@ -0,0 +1,125 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureCommitLanguages() *CommitLanguagesAnalysis {
+	langs := CommitLanguagesAnalysis{}
+	langs.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorPeopleCount:        2,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"alice", "bob"},
+	})
+	langs.Initialize(nil)
+	return &langs
+}
+
+func TestCommitLanguagesMeta(t *testing.T) {
+	langs := fixtureCommitLanguages()
+	assert.Equal(t, langs.Name(), "CommitLanguages")
+	assert.Len(t, langs.Provides(), 0)
+	assert.Equal(t, langs.Requires(), []string{identity.DependencyAuthor, items.DependencyDay})
+	assert.Len(t, langs.ListConfigurationOptions(), 0)
+	assert.Equal(t, langs.Flag(), "commit-languages")
+	assert.NotEmpty(t, langs.Description())
+	assert.Equal(t, langs.PeopleNumber, 2)
+	summoned := core.Registry.Summon(langs.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "CommitLanguages")
+}
+
+func consumeCommitLanguages(t *testing.T, langs *CommitLanguagesAnalysis, author, day int,
+	message string, merge bool) {
+	commit := &object.Commit{Message: message}
+	if merge {
+		commit.Hash = plumbing.NewHash("0123456789012345678901234567890123456789")
+		commit.ParentHashes = make([]plumbing.Hash, 2)
+	}
+	result, err := langs.Consume(map[string]interface{}{
+		identity.DependencyAuthor: author,
+		items.DependencyDay:       day,
+		core.DependencyCommit:     commit,
+		core.DependencyIsMerge:    merge,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func fixtureCommitLanguagesResult(t *testing.T) CommitLanguagesResult {
+	langs := fixtureCommitLanguages()
+	consumeCommitLanguages(t, langs, 0, 0, "Fix the crash in the parser", false)
+	consumeCommitLanguages(t, langs, 1, 0, "Исправлена ошибка в парсере", false)
+	consumeCommitLanguages(t, langs, 1, 2, "Add the tests for the parser", false)
+	consumeCommitLanguages(t, langs, identity.AuthorMissing, 2, "wip", false)
+	consumeCommitLanguages(t, langs, 0, 3, "Merge branch 'master' into feature", true)
+	return langs.Finalize().(CommitLanguagesResult)
+}
+
+func TestCommitLanguagesConsumeFinalize(t *testing.T) {
+	result := fixtureCommitLanguagesResult(t)
+	assert.Equal(t, result.Days, map[int]map[string]int{
+		0: {"en": 1, "ru": 1},
+		2: {"en": 1, "und": 1},
+	})
+	assert.Equal(t, result.People, []map[string]int{
+		{"en": 1}, {"en": 1, "ru": 1}, {"und": 1}})
+	assert.Equal(t, result.reversedPeopleDict, []string{"alice", "bob"})
+}
+
+func TestCommitLanguagesSerialize(t *testing.T) {
+	langs := fixtureCommitLanguages()
+	result := fixtureCommitLanguagesResult(t)
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, langs.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  days:
+    0: {en: 1, ru: 1}
+    2: {en: 1, und: 1}
+  people_languages:
+  - {en: 1}
+  - {en: 1, ru: 1}
+  - {und: 1}
+  people:
+  - "alice"
+  - "bob"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, langs.Serialize(result, true, buffer))
+	msg := pb.CommitLanguagesAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Days[2].Languages, map[string]int32{"en": 1, "und": 1})
+	assert.Len(t, msg.People, 3)
+	assert.Equal(t, msg.DevIndex, []string{"alice", "bob"})
+	decoded, err := langs.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, decoded, result)
+}
+
+func TestCommitLanguagesMergeResults(t *testing.T) {
+	langs := fixtureCommitLanguages()
+	r1 := fixtureCommitLanguagesResult(t)
+	r2 := CommitLanguagesResult{
+		Days:               map[int]map[string]int{0: {"de": 2}},
+		People:             []map[string]int{{"de": 2}, {}},
+		reversedPeopleDict: []string{"carol"},
+	}
+	merged := langs.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0}, &core.CommonAnalysisResult{BeginTime: 2 * 24 * 3600},
+	).(CommitLanguagesResult)
+	assert.Equal(t, merged.reversedPeopleDict, []string{"alice", "bob", "carol"})
+	assert.Equal(t, merged.Days, map[int]map[string]int{
+		0: {"en": 1, "ru": 1},
+		2: {"en": 1, "und": 1, "de": 2},
+	})
+	assert.Equal(t, merged.People, []map[string]int{
+		{"en": 1}, {"en": 1, "ru": 1}, {"de": 2}, {"und": 1}})
+}
@@ -0,0 +1,486 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/langdetect"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/tone"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// CommitSentimentAnalysis evaluates the sentiment of the commit messages and aggregates it
+// per tick and per developer. The trailers, the URLs and the inline code are removed from
+// the messages beforehand. The model is pluggable, see RegisterCommitSentimentModel();
+// the default one is an offline lexicon which does not require anything to be installed.
+// The merge commits are skipped because their messages are generated.
+type CommitSentimentAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the size of a tick in days.
+	Sampling int
+	// Gap is the width of the neutral band around 0.5: the messages with the sentiment between
+	// 0.5 - Gap/2 and 0.5 + Gap/2 are counted as neutral.
+	Gap float32
+	// Model is the name of the registered CommitSentimentModel.
+	Model string
+	// LexiconPath is the file with the custom "word weight" lexicon for the "lexicon" model.
+	// The built-in lexicon is used if it is empty.
+	LexiconPath string
+	// PeopleNumber is the number of developers for which to collect the sentiment.
+	PeopleNumber int
+
+	model    CommitSentimentModel
+	messages []string
+	// commits are the ticks and the authors of the messages.
+	commits []commitSentimentRecord
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+type commitSentimentRecord struct {
+	Tick   int
+	Author int
+}
+
+// CommitSentimentModel evaluates the sentiment of the commit messages. It returns one value
+// per message between 0 (the most negative) and 1 (the most positive).
+type CommitSentimentModel interface {
+	Evaluate(messages []string) ([]float32, error)
+}
+
+// CommitSentiment aggregates the sentiment of several commit messages.
+type CommitSentiment struct {
+	Positive int
+	Negative int
+	Neutral  int
+	// Sum is the sum of the sentiment values of the positive and the negative messages.
+	Sum float64
+}
+
+// Value returns the average sentiment of the positive and the negative messages or 0.5
+// if there are none.
+func (stats CommitSentiment) Value() float64 {
+	if stats.Positive+stats.Negative == 0 {
+		return 0.5
+	}
+	return stats.Sum / float64(stats.Positive+stats.Negative)
+}
+
+func (stats *CommitSentiment) add(other CommitSentiment) {
+	stats.Positive += other.Positive
+	stats.Negative += other.Negative
+	stats.Neutral += other.Neutral
+	stats.Sum += other.Sum
+}
+
+// CommitSentimentResult is returned by CommitSentimentAnalysis.Finalize().
+type CommitSentimentResult struct {
+	Ticks []CommitSentiment
+	// People is the sentiment of each developer. The last element corresponds to
+	// the unmatched identities.
+	People []CommitSentiment
+	// Sampling is the size of a tick in days.
+	Sampling int
+	// Model is the name of the model which evaluated the messages.
+	Model string
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigCommitSentimentSampling is the name of the option to set CommitSentimentAnalysis.Sampling.
+	ConfigCommitSentimentSampling = "CommitSentiment.Sampling"
+	// ConfigCommitSentimentGap is the name of the option to set CommitSentimentAnalysis.Gap.
+	ConfigCommitSentimentGap = "CommitSentiment.Gap"
+	// ConfigCommitSentimentModel is the name of the option to set CommitSentimentAnalysis.Model.
+	ConfigCommitSentimentModel = "CommitSentiment.Model"
+	// ConfigCommitSentimentLexicon is the name of the option to set CommitSentimentAnalysis.LexiconPath.
+	ConfigCommitSentimentLexicon = "CommitSentiment.Lexicon"
+	// DefaultCommitSentimentSampling is the default value of CommitSentimentAnalysis.Sampling.
+	DefaultCommitSentimentSampling = 30
+	// DefaultCommitSentimentGap is the default value of CommitSentimentAnalysis.Gap.
+	DefaultCommitSentimentGap = float32(0.2)
+	// DefaultCommitSentimentModel is the default value of CommitSentimentAnalysis.Model.
+	DefaultCommitSentimentModel = "lexicon"
+)
+
+// lexiconSentimentModel evaluates the commit messages with a tone.Lexicon.
+type lexiconSentimentModel struct {
+	lexicon tone.Lexicon
+}
+
+func (model lexiconSentimentModel) Evaluate(messages []string) ([]float32, error) {
+	weights := make([]float32, len(messages))
+	for i, message := range messages {
+		weights[i] = model.lexicon.Score(message)
+	}
+	return weights, nil
+}
+
+// commitSentimentModels map the names to the registered models.
+var commitSentimentModels = map[string]CommitSentimentModel{
+	DefaultCommitSentimentModel: lexiconSentimentModel{tone.DefaultLexicon},
+}
+
+// RegisterCommitSentimentModel makes the model available to CommitSentimentAnalysis under
+// the specified name. It is intended to be called from init().
+func RegisterCommitSentimentModel(name string, model CommitSentimentModel) {
+	commitSentimentModels[name] = model
+}
+
+// commitSentimentModelNames returns the sorted names of the registered models.
+func commitSentimentModelNames() []string {
+	names := make([]string, 0, len(commitSentimentModels))
+	for name := range commitSentimentModels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (analyser *CommitSentimentAnalysis) Name() string {
+	return "CommitSentiment"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (analyser *CommitSentimentAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (analyser *CommitSentimentAnalysis) Requires() []string {
+	arr := [...]string{identity.DependencyAuthor, items.DependencyDay}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (analyser *CommitSentimentAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigCommitSentimentSampling,
+		Description: "How frequently to aggregate the commit message sentiment in days.",
+		Flag:        "commit-sentiment-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultCommitSentimentSampling}, {
+		Name: ConfigCommitSentimentGap,
+		Description: "Sentiment value threshold, values between 0.5 - X/2 and 0.5 + X/2 are " +
+			"considered neutral. Must be >= 0 and < 1.",
+		Flag:    "commit-sentiment-gap",
+		Type:    core.FloatConfigurationOption,
+		Default: DefaultCommitSentimentGap}, {
+		Name: ConfigCommitSentimentModel,
+		Description: "The model which evaluates the commit messages: " +
+			strings.Join(commitSentimentModelNames(), ", ") + ".",
+		Flag:    "commit-sentiment-model",
+		Type:    core.StringConfigurationOption,
+		Default: DefaultCommitSentimentModel}, {
+		Name: ConfigCommitSentimentLexicon,
+		Description: "Path to the file with \"word weight\" lines which replaces the built-in " +
+			"lexicon of the \"lexicon\" model.",
+		Flag:    "commit-sentiment-lexicon",
+		Type:    core.StringConfigurationOption,
+		Default: ""},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (analyser *CommitSentimentAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigCommitSentimentSampling].(int); exists {
+		analyser.Sampling = val
+	}
+	if val, exists := facts[ConfigCommitSentimentGap].(float32); exists {
+		analyser.Gap = val
+	}
+	if val, exists := facts[ConfigCommitSentimentModel].(string); exists {
+		analyser.Model = val
+	}
+	if val, exists := facts[ConfigCommitSentimentLexicon].(string); exists {
+		analyser.LexiconPath = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		analyser.PeopleNumber = val
+		analyser.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (analyser *CommitSentimentAnalysis) Flag() string {
+	return "commit-sentiment"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (analyser *CommitSentimentAnalysis) Description() string {
+	return "Classifies the commit messages as positive, negative or neutral and aggregates " +
+		"the sentiment over time and for each developer."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (analyser *CommitSentimentAnalysis) Initialize(repository *git.Repository) {
+	if analyser.Sampling <= 0 {
+		log.Printf("Warning: adjusted the commit sentiment sampling to %d days\n",
+			DefaultCommitSentimentSampling)
+		analyser.Sampling = DefaultCommitSentimentSampling
+	}
+	if analyser.Gap < 0 || analyser.Gap >= 1 {
+		log.Printf("Warning: adjusted the commit sentiment gap %f to %f\n",
+			analyser.Gap, DefaultCommitSentimentGap)
+		analyser.Gap = DefaultCommitSentimentGap
+	}
+	if analyser.Model == "" {
+		analyser.Model = DefaultCommitSentimentModel
+	}
+	model, exists := commitSentimentModels[analyser.Model]
+	if !exists {
+		log.Printf("Warning: unknown commit sentiment model %s, using %s\n",
+			analyser.Model, DefaultCommitSentimentModel)
+		analyser.Model = DefaultCommitSentimentModel
+		model = commitSentimentModels[analyser.Model]
+	}
+	if analyser.LexiconPath != "" && analyser.Model == DefaultCommitSentimentModel {
+		file, err := os.Open(analyser.LexiconPath)
+		if err != nil {
+			log.Panicf("failed to open %s: %v", analyser.LexiconPath, err)
+		}
+		lexicon, err := tone.LoadLexicon(file)
+		file.Close()
+		if err != nil {
+			log.Panicf("failed to load %s: %v", analyser.LexiconPath, err)
+		}
+		model = lexiconSentimentModel{lexicon}
+	}
+	analyser.model = model
+	analyser.messages = []string{}
+	analyser.commits = []commitSentimentRecord{}
+	analyser.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (analyser *CommitSentimentAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !analyser.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	message := strings.TrimSpace(langdetect.Clean(commit.Message))
+	if message == "" {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing || author > analyser.PeopleNumber {
+		author = analyser.PeopleNumber
+	}
+	analyser.messages = append(analyser.messages, message)
+	analyser.commits = append(analyser.commits, commitSentimentRecord{
+		Tick: deps[items.DependencyDay].(int) / analyser.Sampling, Author: author})
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (analyser *CommitSentimentAnalysis) Finalize() interface{} {
+	result := CommitSentimentResult{
+		Ticks:              []CommitSentiment{},
+		People:             make([]CommitSentiment, analyser.PeopleNumber+1),
+		Sampling:           analyser.Sampling,
+		Model:              analyser.Model,
+		reversedPeopleDict: analyser.reversedPeopleDict,
+	}
+	if len(analyser.messages) == 0 {
+		return result
+	}
+	weights, err := analyser.model.Evaluate(analyser.messages)
+	if err != nil {
+		log.Panicf("failed to evaluate the commit messages with %s: %v", analyser.Model, err)
+	}
+	for i, record := range analyser.commits {
+		for len(result.Ticks) <= record.Tick {
+			result.Ticks = append(result.Ticks, CommitSentiment{})
+		}
+		stats := CommitSentiment{}
+		weight := weights[i]
+		switch {
+		case weight < 0.5*(1-analyser.Gap):
+			stats.Negative = 1
+			stats.Sum = float64(weight)
+		case weight > 0.5*(1+analyser.Gap):
+			stats.Positive = 1
+			stats.Sum = float64(weight)
+		default:
+			stats.Neutral = 1
+		}
+		result.Ticks[record.Tick].add(stats)
+		result.People[record.Author].add(stats)
+	}
+	return result
+}
+
+// Fork clones this pipeline item.
+func (analyser *CommitSentimentAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(analyser, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (analyser *CommitSentimentAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	sentimentResult := result.(CommitSentimentResult)
+	if binary {
+		return analyser.serializeBinary(&sentimentResult, writer)
+	}
+	analyser.serializeText(&sentimentResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (analyser *CommitSentimentAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(analyser, result, &pb.CommitSentimentAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (analyser *CommitSentimentAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(analyser, result, &pb.CommitSentimentAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to CommitSentimentResult.
+func (analyser *CommitSentimentAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.CommitSentimentAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	convert := func(stats []*pb.CommitSentiment) []CommitSentiment {
+		result := make([]CommitSentiment, len(stats))
+		for i, s := range stats {
+			result[i] = CommitSentiment{
+				Positive: int(s.Positive), Negative: int(s.Negative), Neutral: int(s.Neutral),
+				Sum: s.Sum}
+		}
+		return result
+	}
+	result := CommitSentimentResult{
+		Ticks:              convert(message.Ticks),
+		People:             convert(message.People),
+		Sampling:           int(message.Sampling),
+		Model:              message.Model,
+		reversedPeopleDict: message.DevIndex,
+	}
+	return result, nil
+}
+
+// MergeResults combines two CommitSentimentResult-s together. The ticks are resampled to
+// the bigger sampling of the two.
+func (analyser *CommitSentimentAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	sr1 := r1.(CommitSentimentResult)
+	sr2 := r2.(CommitSentimentResult)
+	merged := CommitSentimentResult{Sampling: sr1.Sampling, Model: sr1.Model}
+	if sr2.Sampling > merged.Sampling {
+		merged.Sampling = sr2.Sampling
+	}
+	if sr1.Model != sr2.Model {
+		log.Printf("Warning: merging the commit sentiment evaluated by %s and %s\n",
+			sr1.Model, sr2.Model)
+	}
+	var people map[string][3]int
+	people, merged.reversedPeopleDict = identity.Detector{}.MergeReversedDicts(
+		sr1.reversedPeopleDict, sr2.reversedPeopleDict)
+	merged.People = make([]CommitSentiment, len(merged.reversedPeopleDict)+1)
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	offsets := [2]int{
+		int((c1.BeginTime - beginTime) / (24 * 3600)),
+		int((c2.BeginTime - beginTime) / (24 * 3600)),
+	}
+	results := [2]*CommitSentimentResult{&sr1, &sr2}
+	days := 0
+	for i, result := range results {
+		if end := offsets[i] + len(result.Ticks)*result.Sampling; end > days {
+			days = end
+		}
+	}
+	merged.Ticks = make([]CommitSentiment, (days+merged.Sampling-1)/merged.Sampling)
+	for i, result := range results {
+		for j, tick := range result.Ticks {
+			// the merged tick which contains the first day of the tick
+			merged.Ticks[(j*result.Sampling+offsets[i])/merged.Sampling].add(tick)
+		}
+		for dev, stats := range result.People {
+			index := len(merged.reversedPeopleDict)
+			if dev < len(result.reversedPeopleDict) {
+				index = people[result.reversedPeopleDict[dev]][0]
+			}
+			merged.People[index].add(stats)
+		}
+	}
+	return merged
+}
+
+func (analyser *CommitSentimentAnalysis) serializeText(result *CommitSentimentResult, writer io.Writer) {
+	format := func(stats []CommitSentiment) string {
+		parts := make([]string, len(stats))
+		for i, s := range stats {
+			parts[i] = fmt.Sprintf("[%d, %d, %d, %.4f]", s.Positive, s.Negative, s.Neutral, s.Value())
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	}
+	fmt.Fprintln(writer, "  model:", result.Model)
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  # [positive, negative, neutral, sentiment]")
+	fmt.Fprintln(writer, "  ticks:", format(result.Ticks))
+	fmt.Fprintln(writer, "  people_sentiment:", format(result.People))
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (analyser *CommitSentimentAnalysis) serializeBinary(result *CommitSentimentResult, writer io.Writer) error {
+	convert := func(stats []CommitSentiment) []*pb.CommitSentiment {
+		result := make([]*pb.CommitSentiment, len(stats))
+		for i, s := range stats {
+			result[i] = &pb.CommitSentiment{
+				Positive: int32(s.Positive), Negative: int32(s.Negative),
+				Neutral: int32(s.Neutral), Sum: s.Sum}
+		}
+		return result
+	}
+	message := pb.CommitSentimentAnalysisResults{
+		Sampling: int32(result.Sampling),
+		Model:    result.Model,
+		Ticks:    convert(result.Ticks),
+		People:   convert(result.People),
+		DevIndex: result.reversedPeopleDict,
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&CommitSentimentAnalysis{})
+}
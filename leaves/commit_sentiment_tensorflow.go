@@ -0,0 +1,25 @@
+//go:build tensorflow
+// +build tensorflow
+
+package leaves
+
+import (
+	"gopkg.in/vmarkovtsev/BiDiSentiment.v1"
+)
+
+// bidiSentimentModel evaluates the commit messages with the BiDiSentiment neural network,
+// the same as CommentSentimentAnalysis.
+type bidiSentimentModel struct{}
+
+func (model bidiSentimentModel) Evaluate(messages []string) ([]float32, error) {
+	session, err := sentiment.OpenSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+	return sentiment.EvaluateWithProgress(messages, session, func(int, int) {})
+}
+
+func init() {
+	RegisterCommitSentimentModel("bidisentiment", bidiSentimentModel{})
+}
@@ -0,0 +1,233 @@
+package leaves
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+// numericSentimentModel parses the messages as the sentiment values.
+type numericSentimentModel struct{}
+
+func (model numericSentimentModel) Evaluate(messages []string) ([]float32, error) {
+	weights := make([]float32, len(messages))
+	for i, message := range messages {
+		weight, err := strconv.ParseFloat(message, 32)
+		if err != nil {
+			return nil, errors.New("not a number: " + message)
+		}
+		weights[i] = float32(weight)
+	}
+	return weights, nil
+}
+
+func init() {
+	RegisterCommitSentimentModel("numeric", numericSentimentModel{})
+}
+
+func fixtureCommitSentiment() *CommitSentimentAnalysis {
+	analyser := CommitSentimentAnalysis{}
+	analyser.Configure(map[string]interface{}{
+		ConfigCommitSentimentSampling:                   10,
+		ConfigCommitSentimentGap:                        float32(0.5),
+		ConfigCommitSentimentModel:                      "numeric",
+		identity.FactIdentityDetectorPeopleCount:        2,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"one", "two"},
+	})
+	analyser.Initialize(nil)
+	return &analyser
+}
+
+func consumeCommitSentiment(
+	t *testing.T, analyser *CommitSentimentAnalysis, day, author int, message string) {
+	result, err := analyser.Consume(map[string]interface{}{
+		core.DependencyCommit:     &object.Commit{Message: message},
+		core.DependencyIsMerge:    false,
+		identity.DependencyAuthor: author,
+		items.DependencyDay:       day,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func TestCommitSentimentMeta(t *testing.T) {
+	analyser := fixtureCommitSentiment()
+	assert.Equal(t, analyser.Name(), "CommitSentiment")
+	assert.Len(t, analyser.Provides(), 0)
+	assert.Equal(t, analyser.Requires(), []string{identity.DependencyAuthor, items.DependencyDay})
+	assert.Equal(t, analyser.Flag(), "commit-sentiment")
+	assert.NotEmpty(t, analyser.Description())
+	opts := analyser.ListConfigurationOptions()
+	assert.Len(t, opts, 4)
+	assert.Equal(t, opts[0].Flag, "commit-sentiment-sampling")
+	assert.Equal(t, opts[1].Flag, "commit-sentiment-gap")
+	assert.Equal(t, opts[2].Flag, "commit-sentiment-model")
+	assert.Contains(t, opts[2].Description, "lexicon, numeric")
+	assert.Equal(t, opts[3].Flag, "commit-sentiment-lexicon")
+	assert.Equal(t, analyser.Sampling, 10)
+	assert.Equal(t, analyser.Gap, float32(0.5))
+	assert.Equal(t, analyser.Model, "numeric")
+	assert.Equal(t, analyser.PeopleNumber, 2)
+	assert.Equal(t, analyser.reversedPeopleDict, []string{"one", "two"})
+	analyser = &CommitSentimentAnalysis{Gap: 1, Model: "unknown"}
+	analyser.Initialize(nil)
+	assert.Equal(t, analyser.Sampling, DefaultCommitSentimentSampling)
+	assert.Equal(t, analyser.Gap, DefaultCommitSentimentGap)
+	assert.Equal(t, analyser.Model, DefaultCommitSentimentModel)
+	summoned := core.Registry.Summon(analyser.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "CommitSentiment")
+}
+
+func fixtureCommitSentimentResult(t *testing.T) CommitSentimentResult {
+	analyser := fixtureCommitSentiment()
+	consumeCommitSentiment(t, analyser, 0, 0, "0.875")
+	consumeCommitSentiment(t, analyser, 3, identity.AuthorMissing,
+		"0.5\n\nSigned-off-by: Jane Doe <jane@example.com>")
+	consumeCommitSentiment(t, analyser, 5, 1, "0.125")
+	consumeCommitSentiment(t, analyser, 14, 0, "Signed-off-by: Jane Doe <jane@example.com>")
+	consumeCommitSentiment(t, analyser, 15, 1, "0.75")
+	consumeCommitSentiment(t, analyser, 35, 0, "0.125")
+	return analyser.Finalize().(CommitSentimentResult)
+}
+
+func TestCommitSentimentConsumeFinalize(t *testing.T) {
+	result := fixtureCommitSentimentResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Model, "numeric")
+	assert.Equal(t, result.Ticks, []CommitSentiment{
+		{Positive: 1, Negative: 1, Neutral: 1, Sum: 1},
+		{Neutral: 1},
+		{},
+		{Negative: 1, Sum: 0.125},
+	})
+	assert.Equal(t, result.People, []CommitSentiment{
+		{Positive: 1, Negative: 1, Sum: 1},
+		{Negative: 1, Neutral: 1, Sum: 0.125},
+		{Neutral: 1},
+	})
+	assert.Equal(t, result.Ticks[0].Value(), 0.5)
+	assert.Equal(t, result.Ticks[1].Value(), 0.5)
+	assert.Equal(t, result.People[0].Value(), 0.5)
+	assert.Equal(t, result.Ticks[3].Value(), 0.125)
+	assert.Equal(t, result.reversedPeopleDict, []string{"one", "two"})
+}
+
+func TestCommitSentimentConsumeMerge(t *testing.T) {
+	analyser := fixtureCommitSentiment()
+	result, err := analyser.Consume(map[string]interface{}{
+		core.DependencyCommit: &object.Commit{
+			ParentHashes: make([]plumbing.Hash, 2), Message: "0.9"},
+		core.DependencyIsMerge: true,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+	assert.Len(t, analyser.Finalize().(CommitSentimentResult).Ticks, 0)
+}
+
+func TestCommitSentimentFinalizeError(t *testing.T) {
+	analyser := fixtureCommitSentiment()
+	consumeCommitSentiment(t, analyser, 0, 0, "nan-sense")
+	assert.Panics(t, func() { analyser.Finalize() })
+}
+
+func TestCommitSentimentLexicon(t *testing.T) {
+	analyser := &CommitSentimentAnalysis{PeopleNumber: 1}
+	analyser.Initialize(nil)
+	assert.Equal(t, analyser.Model, "lexicon")
+	consumeCommitSentiment(t, analyser, 0, 0, "Great cleanup, thanks!")
+	consumeCommitSentiment(t, analyser, 0, 0, "Remove this ugly `wtf()` hack")
+	consumeCommitSentiment(t, analyser, 0, 0, "Update the changelog")
+	result := analyser.Finalize().(CommitSentimentResult)
+	assert.Len(t, result.Ticks, 1)
+	assert.Equal(t, result.Ticks[0].Positive, 1)
+	assert.Equal(t, result.Ticks[0].Negative, 1)
+	assert.Equal(t, result.Ticks[0].Neutral, 1)
+
+	tmp, err := ioutil.TempFile("", "hercules-lexicon-")
+	assert.Nil(t, err)
+	defer os.Remove(tmp.Name())
+	tmp.WriteString("changelog 3\n")
+	tmp.Close()
+	analyser = &CommitSentimentAnalysis{LexiconPath: tmp.Name()}
+	analyser.Initialize(nil)
+	consumeCommitSentiment(t, analyser, 0, 0, "Great cleanup, thanks!")
+	consumeCommitSentiment(t, analyser, 0, 0, "Update the changelog")
+	result = analyser.Finalize().(CommitSentimentResult)
+	assert.Equal(t, result.Ticks[0].Positive, 1)
+	assert.Equal(t, result.Ticks[0].Neutral, 1)
+	assert.Equal(t, result.People[0].Positive, 1)
+	assert.Equal(t, result.People[0].Neutral, 1)
+
+	analyser = &CommitSentimentAnalysis{LexiconPath: tmp.Name() + ".missing"}
+	assert.Panics(t, func() { analyser.Initialize(nil) })
+}
+
+func TestCommitSentimentSerialize(t *testing.T) {
+	result := fixtureCommitSentimentResult(t)
+	analyser := fixtureCommitSentiment()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  model: numeric
+  sampling: 10
+  # [positive, negative, neutral, sentiment]
+  ticks: [[1, 1, 1, 0.5000], [0, 0, 1, 0.5000], [0, 0, 0, 0.5000], [0, 1, 0, 0.1250]]
+  people_sentiment: [[1, 1, 0, 0.5000], [0, 1, 1, 0.1250], [0, 0, 1, 0.5000]]
+  people:
+  - "one"
+  - "two"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, true, buffer))
+	msg := pb.CommitSentimentAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Equal(t, msg.Model, "numeric")
+	assert.Len(t, msg.Ticks, 4)
+	assert.Len(t, msg.People, 3)
+	assert.Equal(t, msg.People[0].Sum, float64(1))
+	deserialized, err := analyser.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestCommitSentimentMergeResults(t *testing.T) {
+	r1 := CommitSentimentResult{
+		Ticks: []CommitSentiment{
+			{Positive: 1, Sum: 0.75}, {Negative: 2, Neutral: 1, Sum: 0.5}},
+		People:             []CommitSentiment{{Positive: 1, Sum: 0.75}, {Negative: 2, Sum: 0.5}, {Neutral: 1}},
+		Sampling:           10,
+		Model:              "lexicon",
+		reversedPeopleDict: []string{"one", "two"},
+	}
+	r2 := CommitSentimentResult{
+		Ticks:              []CommitSentiment{{Positive: 2, Neutral: 3, Sum: 1.5}},
+		People:             []CommitSentiment{{Positive: 2, Neutral: 2, Sum: 1.5}, {Neutral: 1}},
+		Sampling:           20,
+		Model:              "lexicon",
+		reversedPeopleDict: []string{"two"},
+	}
+	analyser := fixtureCommitSentiment()
+	merged := analyser.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 20 * 24 * 3600}).(CommitSentimentResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.Model, "lexicon")
+	assert.Equal(t, merged.Ticks, []CommitSentiment{
+		{Positive: 1, Negative: 2, Neutral: 1, Sum: 1.25}, {Positive: 2, Neutral: 3, Sum: 1.5}})
+	assert.Equal(t, merged.reversedPeopleDict, []string{"one", "two"})
+	assert.Equal(t, merged.People, []CommitSentiment{
+		{Positive: 1, Sum: 0.75}, {Positive: 2, Negative: 2, Neutral: 2, Sum: 2}, {Neutral: 2}})
+}
@@ -0,0 +1,748 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// ComplexityAnalysis measures the cyclomatic and the cognitive complexity of every function
+// in the UASTs and records how they evolve. The state is sampled every Sampling days, so that
+// the trend can be plotted, and the commits which added the most complexity are reported.
+// The functions are the named UAST nodes with the Function and Declaration roles.
+// The merge commits are skipped.
+type ComplexityAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the size of a tick in days.
+	Sampling int
+	// TopCommits is the number of the commits with the biggest complexity increase to report.
+	TopCommits int
+
+	// files map the file names to the complexity of their functions.
+	files map[string]map[string]Complexity
+	// total is the current complexity of the whole project.
+	total Complexity
+	// project is the total complexity at the end of each tick.
+	project []Complexity
+	// fileTicks map the file names to the complexity at the end of the ticks when it changed.
+	fileTicks map[string]map[int]Complexity
+	// functionTicks is the same as fileTicks for the functions.
+	functionTicks map[ComplexityFunction]map[int]Complexity
+	// commits are the complexity deltas of the analysed commits.
+	commits []ComplexityCommit
+}
+
+// Complexity is the complexity of a function or the sum over several functions.
+type Complexity struct {
+	// Functions is the number of the functions.
+	Functions int
+	// Cyclomatic is McCabe's number of the linearly independent paths.
+	Cyclomatic int
+	// Cognitive approximates G. Ann Campbell's cognitive complexity: the control flow
+	// structures weigh more when they are nested.
+	Cognitive int
+}
+
+// ComplexityFunction identifies a function in ComplexityResult.
+type ComplexityFunction struct {
+	File string
+	Name string
+}
+
+// ComplexityCommit is the change of the project complexity in a single commit.
+type ComplexityCommit struct {
+	Hash  string
+	Day   int
+	Delta Complexity
+}
+
+// ComplexityResult is returned by ComplexityAnalysis.Finalize().
+type ComplexityResult struct {
+	// Project is the total complexity at the end of each tick.
+	Project []Complexity
+	// Files map the file names to the complexity at the end of the ticks when they changed.
+	// The deleted files are zero.
+	Files map[string]map[int]Complexity
+	// Functions is the same as Files for the functions.
+	Functions map[ComplexityFunction]map[int]Complexity
+	// Commits are sorted by the cyclomatic complexity increase in descending order.
+	Commits []ComplexityCommit
+	// Sampling is the size of a tick in days.
+	Sampling int
+}
+
+const (
+	// ConfigComplexitySampling is the name of the option to set ComplexityAnalysis.Sampling.
+	ConfigComplexitySampling = "Complexity.Sampling"
+	// ConfigComplexityTopCommits is the name of the option to set ComplexityAnalysis.TopCommits.
+	ConfigComplexityTopCommits = "Complexity.TopCommits"
+	// DefaultComplexitySampling is the default value of ComplexityAnalysis.Sampling.
+	DefaultComplexitySampling = 30
+	// DefaultComplexityTopCommits is the default value of ComplexityAnalysis.TopCommits.
+	DefaultComplexityTopCommits = 20
+)
+
+func (c *Complexity) add(other Complexity) {
+	c.Functions += other.Functions
+	c.Cyclomatic += other.Cyclomatic
+	c.Cognitive += other.Cognitive
+}
+
+func (c *Complexity) sub(other Complexity) {
+	c.Functions -= other.Functions
+	c.Cyclomatic -= other.Cyclomatic
+	c.Cognitive -= other.Cognitive
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (analyser *ComplexityAnalysis) Name() string {
+	return "Complexity"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (analyser *ComplexityAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (analyser *ComplexityAnalysis) Requires() []string {
+	arr := [...]string{uast_items.DependencyUastChanges, items.DependencyDay}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (analyser *ComplexityAnalysis) Features() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (analyser *ComplexityAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigComplexitySampling,
+		Description: "How frequently to record the complexity in days.",
+		Flag:        "complexity-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultComplexitySampling}, {
+		Name:        ConfigComplexityTopCommits,
+		Description: "Number of the commits which increased the complexity the most to report.",
+		Flag:        "complexity-top-commits",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultComplexityTopCommits},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (analyser *ComplexityAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigComplexitySampling].(int); exists {
+		analyser.Sampling = val
+	}
+	if val, exists := facts[ConfigComplexityTopCommits].(int); exists {
+		analyser.TopCommits = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (analyser *ComplexityAnalysis) Flag() string {
+	return "complexity"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (analyser *ComplexityAnalysis) Description() string {
+	return "Measures the cyclomatic and the cognitive complexity of the functions over time " +
+		"and finds the commits which increased it the most."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (analyser *ComplexityAnalysis) Initialize(repository *git.Repository) {
+	if analyser.Sampling <= 0 {
+		log.Printf("Warning: adjusted the complexity sampling to %d days\n",
+			DefaultComplexitySampling)
+		analyser.Sampling = DefaultComplexitySampling
+	}
+	if analyser.TopCommits < 0 {
+		log.Printf("Warning: adjusted the number of the top complexity commits to %d\n",
+			DefaultComplexityTopCommits)
+		analyser.TopCommits = DefaultComplexityTopCommits
+	}
+	analyser.files = map[string]map[string]Complexity{}
+	analyser.total = Complexity{}
+	analyser.project = []Complexity{}
+	analyser.fileTicks = map[string]map[int]Complexity{}
+	analyser.functionTicks = map[ComplexityFunction]map[int]Complexity{}
+	analyser.commits = []ComplexityCommit{}
+	analyser.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (analyser *ComplexityAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !analyser.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	day := deps[items.DependencyDay].(int)
+	tick := day / analyser.Sampling
+	for len(analyser.project) <= tick {
+		analyser.project = append(analyser.project, analyser.total)
+	}
+	before := analyser.total
+	for _, change := range changes {
+		fromName, toName := change.Change.From.Name, change.Change.To.Name
+		if fromName != "" && toName != "" && fromName != toName {
+			analyser.rename(fromName, toName)
+		}
+		if toName == "" {
+			analyser.update(tick, fromName, nil)
+			continue
+		}
+		if change.After == nil {
+			// the file could not be parsed, keep the previous state
+			continue
+		}
+		analyser.update(tick, toName, measureComplexity(change.After))
+	}
+	analyser.project[tick] = analyser.total
+	delta := analyser.total
+	delta.sub(before)
+	if delta != (Complexity{}) {
+		analyser.commits = append(analyser.commits, ComplexityCommit{
+			Hash: commit.Hash.String(), Day: day, Delta: delta})
+	}
+	return nil, nil
+}
+
+// update replaces the functions of the file. nil `functions` means that the file was deleted.
+func (analyser *ComplexityAnalysis) update(tick int, file string, functions map[string]Complexity) {
+	old := analyser.files[file]
+	var oldTotal, newTotal Complexity
+	for name, complexity := range old {
+		oldTotal.add(complexity)
+		if _, exists := functions[name]; !exists {
+			analyser.recordFunction(tick, ComplexityFunction{File: file, Name: name}, Complexity{})
+		}
+	}
+	for name, complexity := range functions {
+		newTotal.add(complexity)
+		if prev, exists := old[name]; !exists || prev != complexity {
+			analyser.recordFunction(tick, ComplexityFunction{File: file, Name: name}, complexity)
+		}
+	}
+	if functions == nil {
+		delete(analyser.files, file)
+	} else {
+		analyser.files[file] = functions
+	}
+	if old == nil && functions == nil {
+		return
+	}
+	analyser.total.sub(oldTotal)
+	analyser.total.add(newTotal)
+	ticks := analyser.fileTicks[file]
+	if ticks == nil {
+		ticks = map[int]Complexity{}
+		analyser.fileTicks[file] = ticks
+	}
+	ticks[tick] = newTotal
+}
+
+func (analyser *ComplexityAnalysis) recordFunction(
+	tick int, function ComplexityFunction, complexity Complexity) {
+	ticks := analyser.functionTicks[function]
+	if ticks == nil {
+		ticks = map[int]Complexity{}
+		analyser.functionTicks[function] = ticks
+	}
+	ticks[tick] = complexity
+}
+
+// rename moves the state and the history of the renamed file.
+func (analyser *ComplexityAnalysis) rename(from, to string) {
+	if functions, exists := analyser.files[from]; exists {
+		delete(analyser.files, from)
+		analyser.files[to] = functions
+	}
+	if ticks, exists := analyser.fileTicks[from]; exists {
+		delete(analyser.fileTicks, from)
+		analyser.fileTicks[to] = ticks
+	}
+	for function, ticks := range analyser.functionTicks {
+		if function.File == from {
+			delete(analyser.functionTicks, function)
+			analyser.functionTicks[ComplexityFunction{File: to, Name: function.Name}] = ticks
+		}
+	}
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (analyser *ComplexityAnalysis) Finalize() interface{} {
+	commits := make([]ComplexityCommit, 0, len(analyser.commits))
+	for _, commit := range analyser.commits {
+		if commit.Delta.Cyclomatic > 0 || commit.Delta.Cognitive > 0 {
+			commits = append(commits, commit)
+		}
+	}
+	return ComplexityResult{
+		Project:   analyser.project,
+		Files:     analyser.fileTicks,
+		Functions: analyser.functionTicks,
+		Commits:   topComplexityCommits(commits, analyser.TopCommits),
+		Sampling:  analyser.Sampling,
+	}
+}
+
+// topComplexityCommits sorts the commits by the complexity increase and keeps the first `size`.
+func topComplexityCommits(commits []ComplexityCommit, size int) []ComplexityCommit {
+	sort.SliceStable(commits, func(i, j int) bool {
+		di, dj := commits[i].Delta, commits[j].Delta
+		if di.Cyclomatic != dj.Cyclomatic {
+			return di.Cyclomatic > dj.Cyclomatic
+		}
+		if di.Cognitive != dj.Cognitive {
+			return di.Cognitive > dj.Cognitive
+		}
+		return commits[i].Day < commits[j].Day
+	})
+	if len(commits) > size {
+		commits = commits[:size]
+	}
+	return commits
+}
+
+// Fork clones this pipeline item.
+func (analyser *ComplexityAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(analyser, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (analyser *ComplexityAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	complexityResult := result.(ComplexityResult)
+	if binary {
+		return analyser.serializeBinary(&complexityResult, writer)
+	}
+	analyser.serializeText(&complexityResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (analyser *ComplexityAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(analyser, result, &pb.ComplexityAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (analyser *ComplexityAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(analyser, result, &pb.ComplexityAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to ComplexityResult.
+func (analyser *ComplexityAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.ComplexityAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	convert := func(c *pb.Complexity) Complexity {
+		if c == nil {
+			return Complexity{}
+		}
+		return Complexity{
+			Functions: int(c.Functions), Cyclomatic: int(c.Cyclomatic), Cognitive: int(c.Cognitive)}
+	}
+	convertTicks := func(ticks map[int32]*pb.Complexity) map[int]Complexity {
+		result := map[int]Complexity{}
+		for tick, c := range ticks {
+			result[int(tick)] = convert(c)
+		}
+		return result
+	}
+	result := ComplexityResult{
+		Project:   make([]Complexity, len(message.Project)),
+		Files:     map[string]map[int]Complexity{},
+		Functions: map[ComplexityFunction]map[int]Complexity{},
+		Commits:   make([]ComplexityCommit, len(message.Commits)),
+		Sampling:  int(message.Sampling),
+	}
+	for i, c := range message.Project {
+		result.Project[i] = convert(c)
+	}
+	for file, series := range message.Files {
+		result.Files[file] = convertTicks(series.Ticks)
+	}
+	for _, function := range message.Functions {
+		result.Functions[ComplexityFunction{File: function.File, Name: function.Name}] =
+			convertTicks(function.Ticks)
+	}
+	for i, commit := range message.Commits {
+		result.Commits[i] = ComplexityCommit{
+			Hash: commit.Hash, Day: int(commit.Day), Delta: convert(commit.Delta)}
+	}
+	return result, nil
+}
+
+// MergeResults combines two ComplexityResult-s together. The ticks are resampled to the bigger
+// sampling of the two.
+func (analyser *ComplexityAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	cr1 := r1.(ComplexityResult)
+	cr2 := r2.(ComplexityResult)
+	sampling := cr1.Sampling
+	if cr2.Sampling > sampling {
+		sampling = cr2.Sampling
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	merged := ComplexityResult{
+		Files:     map[string]map[int]Complexity{},
+		Functions: map[ComplexityFunction]map[int]Complexity{},
+		Sampling:  sampling,
+	}
+	offsets := [2]int{
+		int((c1.BeginTime - beginTime) / (24 * 3600)),
+		int((c2.BeginTime - beginTime) / (24 * 3600)),
+	}
+	results := [2]*ComplexityResult{&cr1, &cr2}
+	days := 0
+	for i, result := range results {
+		if end := offsets[i] + len(result.Project)*result.Sampling; end > days {
+			days = end
+		}
+	}
+	merged.Project = make([]Complexity, (days+sampling-1)/sampling)
+	for tick := range merged.Project {
+		// the value at the end of the tick
+		day := (tick+1)*sampling - 1
+		for i, result := range results {
+			if day < offsets[i] || len(result.Project) == 0 {
+				continue
+			}
+			index := (day - offsets[i]) / result.Sampling
+			if index >= len(result.Project) {
+				index = len(result.Project) - 1
+			}
+			merged.Project[tick].add(result.Project[index])
+		}
+	}
+	// resample converts the sparse ticks, the later ticks overwrite the earlier ones
+	resample := func(ticks map[int]Complexity, result *ComplexityResult, offset int) map[int]Complexity {
+		keys := make([]int, 0, len(ticks))
+		for tick := range ticks {
+			keys = append(keys, tick)
+		}
+		sort.Ints(keys)
+		resampled := map[int]Complexity{}
+		for _, tick := range keys {
+			resampled[((tick+1)*result.Sampling-1+offset)/sampling] = ticks[tick]
+		}
+		return resampled
+	}
+	mergeTicks := func(dst, src map[int]Complexity) {
+		for tick, c := range src {
+			value := dst[tick]
+			value.add(c)
+			dst[tick] = value
+		}
+	}
+	for i, result := range results {
+		for file, ticks := range result.Files {
+			if merged.Files[file] == nil {
+				merged.Files[file] = map[int]Complexity{}
+			}
+			mergeTicks(merged.Files[file], resample(ticks, result, offsets[i]))
+		}
+		for function, ticks := range result.Functions {
+			if merged.Functions[function] == nil {
+				merged.Functions[function] = map[int]Complexity{}
+			}
+			mergeTicks(merged.Functions[function], resample(ticks, result, offsets[i]))
+		}
+		for _, commit := range result.Commits {
+			commit.Day += offsets[i]
+			merged.Commits = append(merged.Commits, commit)
+		}
+	}
+	size := len(cr1.Commits)
+	if len(cr2.Commits) > size {
+		size = len(cr2.Commits)
+	}
+	merged.Commits = topComplexityCommits(merged.Commits, size)
+	return merged
+}
+
+func (analyser *ComplexityAnalysis) serializeText(result *ComplexityResult, writer io.Writer) {
+	formatTicks := func(ticks map[int]Complexity) string {
+		keys := make([]int, 0, len(ticks))
+		for tick := range ticks {
+			keys = append(keys, tick)
+		}
+		sort.Ints(keys)
+		text := "{"
+		for i, tick := range keys {
+			if i > 0 {
+				text += ", "
+			}
+			c := ticks[tick]
+			text += fmt.Sprintf("%d: [%d, %d, %d]", tick, c.Functions, c.Cyclomatic, c.Cognitive)
+		}
+		return text + "}"
+	}
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  # [functions, cyclomatic, cognitive]")
+	fmt.Fprintln(writer, "  project:")
+	for _, c := range result.Project {
+		fmt.Fprintf(writer, "    - [%d, %d, %d]\n", c.Functions, c.Cyclomatic, c.Cognitive)
+	}
+	files := make([]string, 0, len(result.Files))
+	for file := range result.Files {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	fmt.Fprintln(writer, "  files:")
+	for _, file := range files {
+		fmt.Fprintf(writer, "    %s: %s\n", yaml.SafeString(file), formatTicks(result.Files[file]))
+	}
+	functions := make([]ComplexityFunction, 0, len(result.Functions))
+	for function := range result.Functions {
+		functions = append(functions, function)
+	}
+	sort.Slice(functions, func(i, j int) bool {
+		if functions[i].File != functions[j].File {
+			return functions[i].File < functions[j].File
+		}
+		return functions[i].Name < functions[j].Name
+	})
+	fmt.Fprintln(writer, "  functions:")
+	for _, function := range functions {
+		fmt.Fprintf(writer, "    - file: %s\n", yaml.SafeString(function.File))
+		fmt.Fprintf(writer, "      name: %s\n", yaml.SafeString(function.Name))
+		fmt.Fprintf(writer, "      ticks: %s\n", formatTicks(result.Functions[function]))
+	}
+	fmt.Fprintln(writer, "  commits:")
+	for _, commit := range result.Commits {
+		fmt.Fprintf(writer, "    - hash: %s\n", commit.Hash)
+		fmt.Fprintf(writer, "      day: %d\n", commit.Day)
+		fmt.Fprintf(writer, "      delta: [%d, %d, %d]\n",
+			commit.Delta.Functions, commit.Delta.Cyclomatic, commit.Delta.Cognitive)
+	}
+}
+
+func (analyser *ComplexityAnalysis) serializeBinary(result *ComplexityResult, writer io.Writer) error {
+	convert := func(c Complexity) *pb.Complexity {
+		return &pb.Complexity{
+			Functions:  int32(c.Functions),
+			Cyclomatic: int32(c.Cyclomatic),
+			Cognitive:  int32(c.Cognitive),
+		}
+	}
+	convertTicks := func(ticks map[int]Complexity) map[int32]*pb.Complexity {
+		result := map[int32]*pb.Complexity{}
+		for tick, c := range ticks {
+			result[int32(tick)] = convert(c)
+		}
+		return result
+	}
+	message := pb.ComplexityAnalysisResults{
+		Sampling:  int32(result.Sampling),
+		Project:   make([]*pb.Complexity, len(result.Project)),
+		Files:     map[string]*pb.ComplexitySeries{},
+		Functions: make([]*pb.ComplexityFunction, 0, len(result.Functions)),
+		Commits:   make([]*pb.ComplexityCommit, len(result.Commits)),
+	}
+	for i, c := range result.Project {
+		message.Project[i] = convert(c)
+	}
+	for file, ticks := range result.Files {
+		message.Files[file] = &pb.ComplexitySeries{Ticks: convertTicks(ticks)}
+	}
+	for function, ticks := range result.Functions {
+		message.Functions = append(message.Functions, &pb.ComplexityFunction{
+			File: function.File, Name: function.Name, Ticks: convertTicks(ticks)})
+	}
+	sort.Slice(message.Functions, func(i, j int) bool {
+		if message.Functions[i].File != message.Functions[j].File {
+			return message.Functions[i].File < message.Functions[j].File
+		}
+		return message.Functions[i].Name < message.Functions[j].Name
+	})
+	for i, commit := range result.Commits {
+		message.Commits[i] = &pb.ComplexityCommit{
+			Hash: commit.Hash, Day: int32(commit.Day), Delta: convert(commit.Delta)}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+// measureComplexity returns the complexity of each named function in the UAST.
+// The functions with the same names, e.g. the methods of different classes, are summed.
+func measureComplexity(root *uast.Node) map[string]Complexity {
+	result := map[string]Complexity{}
+	var findFunctions func(node *uast.Node)
+	measure := func(function *uast.Node, name string) {
+		meter := complexityMeter{nested: findFunctions}
+		meter.complexity = Complexity{Functions: 1, Cyclomatic: 1}
+		for _, child := range function.Children {
+			meter.visit(child, function, 0)
+		}
+		c := result[name]
+		c.add(meter.complexity)
+		result[name] = c
+	}
+	findFunctions = func(node *uast.Node) {
+		if name := complexityFunctionName(node); name != "" {
+			measure(node, name)
+			return
+		}
+		for _, child := range node.Children {
+			findFunctions(child)
+		}
+	}
+	findFunctions(root)
+	return result
+}
+
+// complexityFunctionName returns the name of the function declared by the node or an empty
+// string if the node is not a named function. The name is looked up in the children and
+// in the grandchildren, same as with DefaultShotnessXpathName.
+func complexityFunctionName(node *uast.Node) string {
+	if !hasRoles(node, uast.Function, uast.Declaration) {
+		return ""
+	}
+	for _, child := range node.Children {
+		if hasRoles(child, uast.Function, uast.Identifier, uast.Name) {
+			return child.Token
+		}
+	}
+	for _, child := range node.Children {
+		for _, grandchild := range child.Children {
+			if hasRoles(grandchild, uast.Function, uast.Identifier, uast.Name) {
+				return grandchild.Token
+			}
+		}
+	}
+	return ""
+}
+
+func hasRoles(node *uast.Node, roles ...uast.Role) bool {
+	for _, role := range roles {
+		found := false
+		for _, nodeRole := range node.Roles {
+			if nodeRole == role {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// complexityMeter calculates the complexity of a single function.
+type complexityMeter struct {
+	complexity Complexity
+	// nested is called for the nested named functions which are measured separately.
+	nested func(node *uast.Node)
+	// lastOperator is the previous boolean operator in the current expression; a sequence
+	// of the same operators increments the cognitive complexity only once.
+	lastOperator uast.Role
+}
+
+func (meter *complexityMeter) visit(node, parent *uast.Node, nesting int) {
+	if complexityFunctionName(node) != "" {
+		meter.nested(node)
+		return
+	}
+	nest := false
+	switch {
+	case isComplexityStructure(node, uast.If):
+		meter.complexity.Cyclomatic++
+		if hasRoles(parent, uast.If, uast.Else) {
+			// "else if" does not increase the nesting
+			meter.complexity.Cognitive++
+		} else {
+			meter.complexity.Cognitive += 1 + nesting
+			nest = true
+		}
+	case isComplexityStructure(node, uast.Switch):
+		meter.complexity.Cognitive += 1 + nesting
+		nest = true
+	case hasRoles(node, uast.Case) && !hasRoles(node, uast.Default):
+		meter.complexity.Cyclomatic++
+	case isComplexityStructure(node, uast.For), isComplexityStructure(node, uast.While),
+		isComplexityStructure(node, uast.DoWhile), hasRoles(node, uast.Catch):
+		meter.complexity.Cyclomatic++
+		meter.complexity.Cognitive += 1 + nesting
+		nest = true
+	case hasRoles(node, uast.Operator, uast.Boolean, uast.And),
+		hasRoles(node, uast.Operator, uast.Boolean, uast.Or):
+		operator := uast.And
+		if hasRoles(node, uast.Or) {
+			operator = uast.Or
+		}
+		meter.complexity.Cyclomatic++
+		if meter.lastOperator != operator {
+			meter.complexity.Cognitive++
+		}
+		meter.lastOperator = operator
+	case hasRoles(node, uast.Goto):
+		meter.complexity.Cognitive++
+	}
+	if hasRoles(node, uast.Statement) || nest {
+		meter.lastOperator = 0
+	}
+	if nest {
+		nesting++
+	}
+	for _, child := range node.Children {
+		meter.visit(child, node, nesting)
+	}
+}
+
+// isComplexityStructure returns true if the node is the control flow statement itself and not
+// its part: UAST marks the parts with the same role, e.g. the condition of "if" has both
+// If and Condition roles.
+func isComplexityStructure(node *uast.Node, role uast.Role) bool {
+	if !hasRoles(node, role) {
+		return false
+	}
+	for _, part := range [...]uast.Role{uast.Condition, uast.Then, uast.Else, uast.Body,
+		uast.Initialization, uast.Update, uast.Iterator, uast.Case, uast.Default} {
+		if hasRoles(node, part) {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	core.Registry.Register(&ComplexityAnalysis{})
+}
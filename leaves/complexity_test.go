@@ -0,0 +1,252 @@
+package leaves
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+)
+
+func fixtureComplexity() *ComplexityAnalysis {
+	analyser := ComplexityAnalysis{}
+	analyser.Configure(map[string]interface{}{
+		ConfigComplexitySampling:   10,
+		ConfigComplexityTopCommits: 2,
+	})
+	analyser.Initialize(nil)
+	return &analyser
+}
+
+func complexityNode(roles []uast.Role, children ...*uast.Node) *uast.Node {
+	return &uast.Node{Roles: roles, Children: children}
+}
+
+func complexityFunction(name string, body ...*uast.Node) *uast.Node {
+	return complexityNode([]uast.Role{uast.Function, uast.Declaration},
+		&uast.Node{Roles: []uast.Role{uast.Function, uast.Identifier, uast.Name}, Token: name},
+		complexityNode([]uast.Role{uast.Function, uast.Body}, body...))
+}
+
+func complexityIf(children ...*uast.Node) *uast.Node {
+	return complexityNode([]uast.Role{uast.If, uast.Statement}, children...)
+}
+
+func complexityOperator(role uast.Role) *uast.Node {
+	return complexityNode([]uast.Role{uast.Operator, uast.Boolean, role})
+}
+
+// fixtureComplexityUAST has foo with cyclomatic 8 and cognitive 7 and bar with cyclomatic 2
+// and cognitive 2.
+func fixtureComplexityUAST() *uast.Node {
+	return complexityNode([]uast.Role{uast.File},
+		complexityFunction("foo",
+			complexityIf(
+				// a && b && c || d
+				complexityNode([]uast.Role{uast.If, uast.Condition},
+					complexityOperator(uast.And), complexityOperator(uast.And),
+					complexityOperator(uast.Or)),
+				complexityNode([]uast.Role{uast.If, uast.Then},
+					complexityNode([]uast.Role{uast.For, uast.Statement})),
+				complexityNode([]uast.Role{uast.If, uast.Else}, complexityIf())),
+			complexityNode([]uast.Role{uast.Switch, uast.Statement},
+				complexityNode([]uast.Role{uast.Switch, uast.Case}),
+				complexityNode([]uast.Role{uast.Switch, uast.Case, uast.Default})),
+			complexityFunction("bar",
+				complexityNode([]uast.Role{uast.While, uast.Statement},
+					complexityNode([]uast.Role{uast.Goto, uast.Statement})))),
+		// not a function
+		complexityNode([]uast.Role{uast.Function, uast.Declaration},
+			complexityNode([]uast.Role{uast.For, uast.Statement})))
+}
+
+func TestComplexityMeta(t *testing.T) {
+	analyser := fixtureComplexity()
+	assert.Equal(t, analyser.Name(), "Complexity")
+	assert.Len(t, analyser.Provides(), 0)
+	assert.Equal(t, analyser.Requires(), []string{
+		uast_items.DependencyUastChanges, items.DependencyDay})
+	assert.Equal(t, analyser.Features(), []string{uast_items.FeatureUast})
+	assert.Equal(t, analyser.Flag(), "complexity")
+	assert.NotEmpty(t, analyser.Description())
+	opts := analyser.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Flag, "complexity-sampling")
+	assert.Equal(t, opts[1].Flag, "complexity-top-commits")
+	assert.Equal(t, analyser.Sampling, 10)
+	assert.Equal(t, analyser.TopCommits, 2)
+	analyser = &ComplexityAnalysis{TopCommits: -1}
+	analyser.Initialize(nil)
+	assert.Equal(t, analyser.Sampling, DefaultComplexitySampling)
+	assert.Equal(t, analyser.TopCommits, DefaultComplexityTopCommits)
+	summoned := core.Registry.Summon(analyser.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Complexity")
+}
+
+func TestComplexityMeasure(t *testing.T) {
+	functions := measureComplexity(fixtureComplexityUAST())
+	assert.Equal(t, functions, map[string]Complexity{
+		"foo": {Functions: 1, Cyclomatic: 8, Cognitive: 7},
+		"bar": {Functions: 1, Cyclomatic: 2, Cognitive: 2},
+	})
+	assert.Len(t, measureComplexity(complexityNode(nil)), 0)
+}
+
+func fixtureComplexityResult(t *testing.T) ComplexityResult {
+	analyser := fixtureComplexity()
+	hash := func(i int) plumbing.Hash {
+		return plumbing.NewHash(strings.Repeat(string(rune('0'+i)), 40))
+	}
+	consume := func(commit int, day int, changes ...uast_items.Change) {
+		result, err := analyser.Consume(map[string]interface{}{
+			core.DependencyCommit:            &object.Commit{Hash: hash(commit)},
+			core.DependencyIsMerge:           false,
+			uast_items.DependencyUastChanges: changes,
+			items.DependencyDay:              day,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	change := func(from, to string, after *uast.Node) uast_items.Change {
+		return uast_items.Change{After: after, Change: &object.Change{
+			From: object.ChangeEntry{Name: from}, To: object.ChangeEntry{Name: to}}}
+	}
+	consume(1, 0, change("", "a.go", complexityFunction("one")))
+	consume(2, 5, change("", "b.go", fixtureComplexityUAST()))
+	// no changes in ticks 1 and 2
+	consume(3, 31, change("b.go", "c.go", complexityFunction("bar")))
+	// could not be parsed
+	consume(4, 32, change("c.go", "c.go", nil))
+	consume(5, 33, change("a.go", "", nil))
+	return analyser.Finalize().(ComplexityResult)
+}
+
+func TestComplexityConsumeFinalize(t *testing.T) {
+	result := fixtureComplexityResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Project, []Complexity{
+		{Functions: 3, Cyclomatic: 11, Cognitive: 9},
+		{Functions: 3, Cyclomatic: 11, Cognitive: 9},
+		{Functions: 3, Cyclomatic: 11, Cognitive: 9},
+		{Functions: 1, Cyclomatic: 1},
+	})
+	assert.Equal(t, result.Files, map[string]map[int]Complexity{
+		"a.go": {0: {Functions: 1, Cyclomatic: 1}, 3: {}},
+		"c.go": {0: {Functions: 2, Cyclomatic: 10, Cognitive: 9}, 3: {Functions: 1, Cyclomatic: 1}},
+	})
+	assert.Equal(t, result.Functions, map[ComplexityFunction]map[int]Complexity{
+		{File: "a.go", Name: "one"}: {0: {Functions: 1, Cyclomatic: 1}, 3: {}},
+		{File: "c.go", Name: "foo"}: {0: {Functions: 1, Cyclomatic: 8, Cognitive: 7}, 3: {}},
+		{File: "c.go", Name: "bar"}: {
+			0: {Functions: 1, Cyclomatic: 2, Cognitive: 2}, 3: {Functions: 1, Cyclomatic: 1}},
+	})
+	assert.Equal(t, result.Commits, []ComplexityCommit{
+		{Hash: strings.Repeat("2", 40), Day: 5,
+			Delta: Complexity{Functions: 2, Cyclomatic: 10, Cognitive: 9}},
+		{Hash: strings.Repeat("1", 40), Day: 0,
+			Delta: Complexity{Functions: 1, Cyclomatic: 1}},
+	})
+}
+
+func TestComplexityConsumeMerge(t *testing.T) {
+	analyser := fixtureComplexity()
+	result, err := analyser.Consume(map[string]interface{}{
+		core.DependencyCommit: &object.Commit{
+			ParentHashes: make([]plumbing.Hash, 2)},
+		core.DependencyIsMerge: true,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+	assert.Len(t, analyser.Finalize().(ComplexityResult).Project, 0)
+}
+
+func TestComplexitySerialize(t *testing.T) {
+	result := fixtureComplexityResult(t)
+	analyser := fixtureComplexity()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  # [functions, cyclomatic, cognitive]
+  project:
+    - [3, 11, 9]
+    - [3, 11, 9]
+    - [3, 11, 9]
+    - [1, 1, 0]
+  files:
+    "a.go": {0: [1, 1, 0], 3: [0, 0, 0]}
+    "c.go": {0: [2, 10, 9], 3: [1, 1, 0]}
+  functions:
+    - file: "a.go"
+      name: "one"
+      ticks: {0: [1, 1, 0], 3: [0, 0, 0]}
+    - file: "c.go"
+      name: "bar"
+      ticks: {0: [1, 2, 2], 3: [1, 1, 0]}
+    - file: "c.go"
+      name: "foo"
+      ticks: {0: [1, 8, 7], 3: [0, 0, 0]}
+  commits:
+    - hash: 2222222222222222222222222222222222222222
+      day: 5
+      delta: [2, 10, 9]
+    - hash: 1111111111111111111111111111111111111111
+      day: 0
+      delta: [1, 1, 0]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, true, buffer))
+	msg := pb.ComplexityAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Len(t, msg.Project, 4)
+	assert.Len(t, msg.Functions, 3)
+	assert.Equal(t, msg.Functions[0].Name, "one")
+	deserialized, err := analyser.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestComplexityMergeResults(t *testing.T) {
+	r1 := ComplexityResult{
+		Project: []Complexity{{Functions: 1, Cyclomatic: 2}, {Functions: 2, Cyclomatic: 4}},
+		Files: map[string]map[int]Complexity{
+			"a.go": {0: {Functions: 1, Cyclomatic: 2}, 1: {Functions: 2, Cyclomatic: 4}}},
+		Functions: map[ComplexityFunction]map[int]Complexity{
+			{File: "a.go", Name: "f"}: {0: {Functions: 1, Cyclomatic: 2}}},
+		Commits:  []ComplexityCommit{{Hash: "1", Day: 3, Delta: Complexity{Cyclomatic: 2}}},
+		Sampling: 10,
+	}
+	r2 := ComplexityResult{
+		Project: []Complexity{{Functions: 1, Cyclomatic: 1}},
+		Files: map[string]map[int]Complexity{
+			"a.go": {0: {Functions: 1, Cyclomatic: 1}}},
+		Functions: map[ComplexityFunction]map[int]Complexity{
+			{File: "a.go", Name: "g"}: {0: {Functions: 1, Cyclomatic: 1}}},
+		Commits:  []ComplexityCommit{{Hash: "2", Day: 0, Delta: Complexity{Cyclomatic: 3}}},
+		Sampling: 20,
+	}
+	analyser := fixtureComplexity()
+	merged := analyser.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 5 * 24 * 3600}).(ComplexityResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.Project, []Complexity{
+		{Functions: 3, Cyclomatic: 5}, {Functions: 3, Cyclomatic: 5}})
+	assert.Equal(t, merged.Files, map[string]map[int]Complexity{
+		"a.go": {0: {Functions: 2, Cyclomatic: 4}, 1: {Functions: 1, Cyclomatic: 1}}})
+	assert.Equal(t, merged.Functions, map[ComplexityFunction]map[int]Complexity{
+		{File: "a.go", Name: "f"}: {0: {Functions: 1, Cyclomatic: 2}},
+		{File: "a.go", Name: "g"}: {1: {Functions: 1, Cyclomatic: 1}},
+	})
+	assert.Equal(t, merged.Commits, []ComplexityCommit{
+		{Hash: "2", Day: 5, Delta: Complexity{Cyclomatic: 3}}})
+}
@@ -0,0 +1,488 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// ContributorsAnalysis classifies the developers who committed in each tick of Sampling days
+// into the core, the regular and the drive-by contributors. The span of a developer is
+// the number of days between their first commit and their last commit in the tick.
+// A developer is core if they made at least CoreCommits commits in the tick and their span
+// is at least CoreSpan days. A developer is drive-by if they made at most DriveByCommits
+// commits so far and their span is at most DriveBySpan days. The rest are regular.
+// Each tick is classified with what is known at its end, so a drive-by contributor can
+// later become regular or core. The merge commits and the unmatched identities are ignored.
+// It needs only the commit metadata, so it is suitable for the fast mode.
+type ContributorsAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the number of days in a tick.
+	Sampling int
+	// CoreCommits is the minimum number of commits in a tick of a core developer.
+	CoreCommits int
+	// CoreSpan is the minimum span of a core developer in days.
+	CoreSpan int
+	// DriveByCommits is the maximum total number of commits of a drive-by developer.
+	DriveByCommits int
+	// DriveBySpan is the maximum span of a drive-by developer in days.
+	DriveBySpan int
+	// PeopleNumber is the number of developers.
+	PeopleNumber int
+
+	// firstDays are the days of the first commits of each developer, -1 if there are none.
+	firstDays []int
+	// activity is the activity of each developer in each tick.
+	activity []map[int]contributorsActivity
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// contributorsActivity is the activity of a developer in a tick.
+type contributorsActivity struct {
+	commits int
+	lastDay int
+}
+
+// ContributorsTick lists the developers in each bucket in a tick.
+type ContributorsTick struct {
+	// Core are the sorted indices of the core developers.
+	Core []int
+	// Regular are the sorted indices of the regular developers.
+	Regular []int
+	// DriveBy are the sorted indices of the drive-by developers.
+	DriveBy []int
+}
+
+// ContributorsResult is returned by ContributorsAnalysis.Finalize().
+type ContributorsResult struct {
+	// Ticks are the buckets in each tick of Sampling days.
+	Ticks []ContributorsTick
+	// Sampling is the effective ContributorsAnalysis.Sampling.
+	Sampling int
+	// CoreCommits is the effective ContributorsAnalysis.CoreCommits.
+	CoreCommits int
+	// CoreSpan is the effective ContributorsAnalysis.CoreSpan.
+	CoreSpan int
+	// DriveByCommits is the effective ContributorsAnalysis.DriveByCommits.
+	DriveByCommits int
+	// DriveBySpan is the effective ContributorsAnalysis.DriveBySpan.
+	DriveBySpan int
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigContributorsSampling is the name of the option to set ContributorsAnalysis.Sampling.
+	ConfigContributorsSampling = "Contributors.Sampling"
+	// ConfigContributorsCoreCommits is the name of the option to set
+	// ContributorsAnalysis.CoreCommits.
+	ConfigContributorsCoreCommits = "Contributors.CoreCommits"
+	// ConfigContributorsCoreSpan is the name of the option to set ContributorsAnalysis.CoreSpan.
+	ConfigContributorsCoreSpan = "Contributors.CoreSpan"
+	// ConfigContributorsDriveByCommits is the name of the option to set
+	// ContributorsAnalysis.DriveByCommits.
+	ConfigContributorsDriveByCommits = "Contributors.DriveByCommits"
+	// ConfigContributorsDriveBySpan is the name of the option to set
+	// ContributorsAnalysis.DriveBySpan.
+	ConfigContributorsDriveBySpan = "Contributors.DriveBySpan"
+	// DefaultContributorsSampling is the default value of ContributorsAnalysis.Sampling.
+	DefaultContributorsSampling = 30
+	// DefaultContributorsCoreCommits is the default value of ContributorsAnalysis.CoreCommits.
+	DefaultContributorsCoreCommits = 10
+	// DefaultContributorsCoreSpan is the default value of ContributorsAnalysis.CoreSpan.
+	DefaultContributorsCoreSpan = 90
+	// DefaultContributorsDriveByCommits is the default value of
+	// ContributorsAnalysis.DriveByCommits.
+	DefaultContributorsDriveByCommits = 2
+	// DefaultContributorsDriveBySpan is the default value of ContributorsAnalysis.DriveBySpan.
+	DefaultContributorsDriveBySpan = 7
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (contributors *ContributorsAnalysis) Name() string {
+	return "Contributors"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (contributors *ContributorsAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (contributors *ContributorsAnalysis) Requires() []string {
+	arr := [...]string{identity.DependencyAuthor, items.DependencyDay}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (contributors *ContributorsAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigContributorsSampling,
+		Description: "How frequently to classify the contributors, in days.",
+		Flag:        "contributors-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultContributorsSampling}, {
+		Name:        ConfigContributorsCoreCommits,
+		Description: "Minimum number of commits in a tick of a core contributor.",
+		Flag:        "contributors-core-commits",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultContributorsCoreCommits}, {
+		Name: ConfigContributorsCoreSpan,
+		Description: "Minimum number of days between the first and the latest commit of " +
+			"a core contributor.",
+		Flag:    "contributors-core-span",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultContributorsCoreSpan}, {
+		Name:        ConfigContributorsDriveByCommits,
+		Description: "Maximum total number of commits of a drive-by contributor.",
+		Flag:        "contributors-drive-by-commits",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultContributorsDriveByCommits}, {
+		Name: ConfigContributorsDriveBySpan,
+		Description: "Maximum number of days between the first and the latest commit of " +
+			"a drive-by contributor.",
+		Flag:    "contributors-drive-by-span",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultContributorsDriveBySpan},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (contributors *ContributorsAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigContributorsSampling].(int); exists {
+		contributors.Sampling = val
+	}
+	if val, exists := facts[ConfigContributorsCoreCommits].(int); exists {
+		contributors.CoreCommits = val
+	}
+	if val, exists := facts[ConfigContributorsCoreSpan].(int); exists {
+		contributors.CoreSpan = val
+	}
+	if val, exists := facts[ConfigContributorsDriveByCommits].(int); exists {
+		contributors.DriveByCommits = val
+	}
+	if val, exists := facts[ConfigContributorsDriveBySpan].(int); exists {
+		contributors.DriveBySpan = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		contributors.PeopleNumber = val
+		contributors.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (contributors *ContributorsAnalysis) Flag() string {
+	return "contributors"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (contributors *ContributorsAnalysis) Description() string {
+	return "Classifies the contributors into the core, the regular and the drive-by ones " +
+		"by their commit counts and spans, over time."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (contributors *ContributorsAnalysis) Initialize(repository *git.Repository) {
+	if contributors.Sampling <= 0 {
+		log.Printf("Warning: adjusted the contributors sampling to %d days\n",
+			DefaultContributorsSampling)
+		contributors.Sampling = DefaultContributorsSampling
+	}
+	if contributors.CoreCommits <= 0 {
+		log.Printf("Warning: adjusted the core contributor commits to %d\n",
+			DefaultContributorsCoreCommits)
+		contributors.CoreCommits = DefaultContributorsCoreCommits
+	}
+	if contributors.CoreSpan < 0 {
+		log.Printf("Warning: adjusted the core contributor span to %d days\n",
+			DefaultContributorsCoreSpan)
+		contributors.CoreSpan = DefaultContributorsCoreSpan
+	}
+	if contributors.DriveByCommits <= 0 {
+		log.Printf("Warning: adjusted the drive-by contributor commits to %d\n",
+			DefaultContributorsDriveByCommits)
+		contributors.DriveByCommits = DefaultContributorsDriveByCommits
+	}
+	if contributors.DriveBySpan < 0 {
+		log.Printf("Warning: adjusted the drive-by contributor span to %d days\n",
+			DefaultContributorsDriveBySpan)
+		contributors.DriveBySpan = DefaultContributorsDriveBySpan
+	}
+	contributors.firstDays = make([]int, contributors.PeopleNumber)
+	for i := range contributors.firstDays {
+		contributors.firstDays[i] = -1
+	}
+	contributors.activity = make([]map[int]contributorsActivity, contributors.PeopleNumber)
+	contributors.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (contributors *ContributorsAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !contributors.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing || author >= contributors.PeopleNumber {
+		return nil, nil
+	}
+	day := deps[items.DependencyDay].(int)
+	if first := contributors.firstDays[author]; first < 0 || day < first {
+		contributors.firstDays[author] = day
+	}
+	ticks := contributors.activity[author]
+	if ticks == nil {
+		ticks = map[int]contributorsActivity{}
+		contributors.activity[author] = ticks
+	}
+	tick := day / contributors.Sampling
+	activity := ticks[tick]
+	activity.commits++
+	if day > activity.lastDay {
+		activity.lastDay = day
+	}
+	ticks[tick] = activity
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (contributors *ContributorsAnalysis) Finalize() interface{} {
+	result := ContributorsResult{
+		Sampling:           contributors.Sampling,
+		CoreCommits:        contributors.CoreCommits,
+		CoreSpan:           contributors.CoreSpan,
+		DriveByCommits:     contributors.DriveByCommits,
+		DriveBySpan:        contributors.DriveBySpan,
+		reversedPeopleDict: contributors.reversedPeopleDict,
+	}
+	for dev, ticks := range contributors.activity {
+		indices := make([]int, 0, len(ticks))
+		for tick := range ticks {
+			indices = append(indices, tick)
+		}
+		sort.Ints(indices)
+		total := 0
+		for _, tick := range indices {
+			activity := ticks[tick]
+			total += activity.commits
+			span := activity.lastDay - contributors.firstDays[dev]
+			for len(result.Ticks) <= tick {
+				result.Ticks = append(result.Ticks, ContributorsTick{})
+			}
+			bucket := &result.Ticks[tick]
+			switch {
+			case activity.commits >= contributors.CoreCommits && span >= contributors.CoreSpan:
+				bucket.Core = append(bucket.Core, dev)
+			case total <= contributors.DriveByCommits && span <= contributors.DriveBySpan:
+				bucket.DriveBy = append(bucket.DriveBy, dev)
+			default:
+				bucket.Regular = append(bucket.Regular, dev)
+			}
+		}
+	}
+	return result
+}
+
+// Fork clones this pipeline item.
+func (contributors *ContributorsAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(contributors, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (contributors *ContributorsAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	contributorsResult := result.(ContributorsResult)
+	if binary {
+		return contributors.serializeBinary(&contributorsResult, writer)
+	}
+	contributors.serializeText(&contributorsResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (contributors *ContributorsAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(contributors, result, &pb.ContributorsAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (contributors *ContributorsAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(contributors, result, &pb.ContributorsAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to ContributorsResult.
+func (contributors *ContributorsAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.ContributorsAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	convert := func(devs []int32) []int {
+		if len(devs) == 0 {
+			return nil
+		}
+		result := make([]int, len(devs))
+		for i, dev := range devs {
+			result[i] = int(dev)
+		}
+		return result
+	}
+	result := ContributorsResult{
+		Sampling:           int(message.Sampling),
+		CoreCommits:        int(message.CoreCommits),
+		CoreSpan:           int(message.CoreSpan),
+		DriveByCommits:     int(message.DriveByCommits),
+		DriveBySpan:        int(message.DriveBySpan),
+		reversedPeopleDict: message.DevIndex,
+	}
+	if len(message.Ticks) > 0 {
+		result.Ticks = make([]ContributorsTick, len(message.Ticks))
+		for i, tick := range message.Ticks {
+			result.Ticks[i] = ContributorsTick{
+				Core:    convert(tick.Core),
+				Regular: convert(tick.Regular),
+				DriveBy: convert(tick.DriveBy),
+			}
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two ContributorsResult-s together. The ticks are regrouped by
+// the larger sampling and the developers are matched by name. A developer who falls into
+// several buckets in the same merged tick stays in the highest one: core, regular, drive-by.
+// The thresholds are taken from the first result.
+func (contributors *ContributorsAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	cr1 := r1.(ContributorsResult)
+	cr2 := r2.(ContributorsResult)
+	merged := ContributorsResult{
+		Sampling:       cr1.Sampling,
+		CoreCommits:    cr1.CoreCommits,
+		CoreSpan:       cr1.CoreSpan,
+		DriveByCommits: cr1.DriveByCommits,
+		DriveBySpan:    cr1.DriveBySpan,
+	}
+	if cr2.Sampling > merged.Sampling {
+		merged.Sampling = cr2.Sampling
+	}
+	var people map[string][3]int
+	people, merged.reversedPeopleDict = identity.Detector{}.MergeReversedDicts(
+		cr1.reversedPeopleDict, cr2.reversedPeopleDict)
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	// the buckets are ranked 2 (core), 1 (regular) and 0 (drive-by)
+	var ranks []map[int]int
+	add := func(result *ContributorsResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for i, tick := range result.Ticks {
+			index := (i*result.Sampling + offset) / merged.Sampling
+			for len(ranks) <= index {
+				ranks = append(ranks, map[int]int{})
+			}
+			for rank, devs := range [][]int{tick.DriveBy, tick.Regular, tick.Core} {
+				for _, dev := range devs {
+					newDev := people[result.reversedPeopleDict[dev]][0]
+					if prev, exists := ranks[index][newDev]; !exists || rank > prev {
+						ranks[index][newDev] = rank
+					}
+				}
+			}
+		}
+	}
+	add(&cr1, c1)
+	add(&cr2, c2)
+	merged.Ticks = make([]ContributorsTick, len(ranks))
+	for i, devs := range ranks {
+		tick := &merged.Ticks[i]
+		buckets := [...]*[]int{&tick.DriveBy, &tick.Regular, &tick.Core}
+		for dev, rank := range devs {
+			*buckets[rank] = append(*buckets[rank], dev)
+		}
+		for _, bucket := range buckets {
+			sort.Ints(*bucket)
+		}
+	}
+	return merged
+}
+
+func (contributors *ContributorsAnalysis) serializeText(result *ContributorsResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  core_commits:", result.CoreCommits)
+	fmt.Fprintln(writer, "  core_span:", result.CoreSpan)
+	fmt.Fprintln(writer, "  drive_by_commits:", result.DriveByCommits)
+	fmt.Fprintln(writer, "  drive_by_span:", result.DriveBySpan)
+	fmt.Fprintln(writer, "  # the developer indices in each bucket")
+	fmt.Fprintln(writer, "  ticks:")
+	for _, tick := range result.Ticks {
+		fmt.Fprint(writer, "    - {core: [")
+		writeIntList(writer, tick.Core)
+		fmt.Fprint(writer, "], regular: [")
+		writeIntList(writer, tick.Regular)
+		fmt.Fprint(writer, "], drive_by: [")
+		writeIntList(writer, tick.DriveBy)
+		fmt.Fprintln(writer, "]}")
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (contributors *ContributorsAnalysis) serializeBinary(result *ContributorsResult, writer io.Writer) error {
+	convert := func(devs []int) []int32 {
+		converted := make([]int32, len(devs))
+		for i, dev := range devs {
+			converted[i] = int32(dev)
+		}
+		return converted
+	}
+	message := pb.ContributorsAnalysisResults{
+		Ticks:          make([]*pb.ContributorsTick, len(result.Ticks)),
+		Sampling:       int32(result.Sampling),
+		CoreCommits:    int32(result.CoreCommits),
+		CoreSpan:       int32(result.CoreSpan),
+		DriveByCommits: int32(result.DriveByCommits),
+		DriveBySpan:    int32(result.DriveBySpan),
+		DevIndex:       result.reversedPeopleDict,
+	}
+	for i, tick := range result.Ticks {
+		message.Ticks[i] = &pb.ContributorsTick{
+			Core:    convert(tick.Core),
+			Regular: convert(tick.Regular),
+			DriveBy: convert(tick.DriveBy),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&ContributorsAnalysis{})
+}
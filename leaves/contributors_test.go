@@ -0,0 +1,167 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureContributors() *ContributorsAnalysis {
+	contributors := ContributorsAnalysis{}
+	contributors.Configure(map[string]interface{}{
+		ConfigContributorsSampling:                      10,
+		ConfigContributorsCoreCommits:                   3,
+		ConfigContributorsCoreSpan:                      10,
+		ConfigContributorsDriveByCommits:                1,
+		ConfigContributorsDriveBySpan:                   0,
+		identity.FactIdentityDetectorPeopleCount:        3,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"alice", "bob", "carol"},
+	})
+	contributors.Initialize(nil)
+	return &contributors
+}
+
+func TestContributorsMeta(t *testing.T) {
+	contributors := fixtureContributors()
+	assert.Equal(t, contributors.Name(), "Contributors")
+	assert.Len(t, contributors.Provides(), 0)
+	assert.Equal(t, contributors.Requires(), []string{identity.DependencyAuthor, items.DependencyDay})
+	assert.Equal(t, contributors.Flag(), "contributors")
+	assert.NotEmpty(t, contributors.Description())
+	opts := contributors.ListConfigurationOptions()
+	assert.Len(t, opts, 5)
+	assert.Equal(t, opts[0].Flag, "contributors-sampling")
+	assert.Equal(t, opts[1].Flag, "contributors-core-commits")
+	assert.Equal(t, opts[2].Flag, "contributors-core-span")
+	assert.Equal(t, opts[3].Flag, "contributors-drive-by-commits")
+	assert.Equal(t, opts[4].Flag, "contributors-drive-by-span")
+	assert.Equal(t, contributors.Sampling, 10)
+	assert.Equal(t, contributors.CoreCommits, 3)
+	assert.Equal(t, contributors.CoreSpan, 10)
+	assert.Equal(t, contributors.DriveByCommits, 1)
+	assert.Equal(t, contributors.DriveBySpan, 0)
+	assert.Equal(t, contributors.PeopleNumber, 3)
+	contributors = &ContributorsAnalysis{CoreSpan: -1, DriveBySpan: -1}
+	contributors.Initialize(nil)
+	assert.Equal(t, contributors.Sampling, DefaultContributorsSampling)
+	assert.Equal(t, contributors.CoreCommits, DefaultContributorsCoreCommits)
+	assert.Equal(t, contributors.CoreSpan, DefaultContributorsCoreSpan)
+	assert.Equal(t, contributors.DriveByCommits, DefaultContributorsDriveByCommits)
+	assert.Equal(t, contributors.DriveBySpan, DefaultContributorsDriveBySpan)
+	summoned := core.Registry.Summon(contributors.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Contributors")
+}
+
+func fixtureContributorsResult(t *testing.T) ContributorsResult {
+	contributors := fixtureContributors()
+	consume := func(author, day int, merge bool) {
+		commit := &object.Commit{}
+		if merge {
+			commit.Hash = plumbing.NewHash("0123456789012345678901234567890123456789")
+			commit.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		result, err := contributors.Consume(map[string]interface{}{
+			core.DependencyCommit:     commit,
+			core.DependencyIsMerge:    merge,
+			identity.DependencyAuthor: author,
+			items.DependencyDay:       day,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	for _, day := range []int{0, 1, 2, 20, 21, 22} {
+		consume(0, day, false)
+	}
+	consume(1, 5, false)
+	consume(identity.AuthorMissing, 3, false)
+	consume(2, 15, true)
+	consume(2, 16, false)
+	consume(1, 25, false)
+	return contributors.Finalize().(ContributorsResult)
+}
+
+func TestContributorsConsumeFinalize(t *testing.T) {
+	result := fixtureContributorsResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.CoreCommits, 3)
+	assert.Equal(t, result.reversedPeopleDict, []string{"alice", "bob", "carol"})
+	// alice is not core in the first tick because the span is too short
+	assert.Equal(t, result.Ticks, []ContributorsTick{
+		{Regular: []int{0}, DriveBy: []int{1}},
+		{DriveBy: []int{2}},
+		{Core: []int{0}, Regular: []int{1}},
+	})
+}
+
+func TestContributorsSerialize(t *testing.T) {
+	result := fixtureContributorsResult(t)
+	contributors := fixtureContributors()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, contributors.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  core_commits: 3
+  core_span: 10
+  drive_by_commits: 1
+  drive_by_span: 0
+  # the developer indices in each bucket
+  ticks:
+    - {core: [], regular: [0], drive_by: [1]}
+    - {core: [], regular: [], drive_by: [2]}
+    - {core: [0], regular: [1], drive_by: []}
+  people:
+  - "alice"
+  - "bob"
+  - "carol"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, contributors.Serialize(result, true, buffer))
+	msg := pb.ContributorsAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Equal(t, msg.CoreSpan, int32(10))
+	assert.Equal(t, msg.DevIndex, []string{"alice", "bob", "carol"})
+	assert.Len(t, msg.Ticks, 3)
+	assert.Equal(t, msg.Ticks[2].Core, []int32{0})
+	deserialized, err := contributors.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestContributorsMergeResults(t *testing.T) {
+	r1 := ContributorsResult{
+		Ticks:              []ContributorsTick{{Regular: []int{0}}, {Core: []int{0}}},
+		Sampling:           10,
+		CoreCommits:        3,
+		CoreSpan:           10,
+		DriveByCommits:     1,
+		DriveBySpan:        0,
+		reversedPeopleDict: []string{"alice"},
+	}
+	r2 := ContributorsResult{
+		Ticks:              []ContributorsTick{{Regular: []int{1}, DriveBy: []int{0}}},
+		Sampling:           20,
+		CoreCommits:        5,
+		reversedPeopleDict: []string{"bob", "alice"},
+	}
+	contributors := fixtureContributors()
+	merged := contributors.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 20 * 24 * 3600}).(ContributorsResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.CoreCommits, 3)
+	assert.Equal(t, merged.CoreSpan, 10)
+	assert.Equal(t, merged.reversedPeopleDict, []string{"alice", "bob"})
+	assert.Equal(t, merged.Ticks, []ContributorsTick{
+		{Core: []int{0}},
+		{Regular: []int{0}, DriveBy: []int{1}},
+	})
+}
@@ -0,0 +1,415 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// ConventionalCommitsAnalysis checks the commit messages against the Conventional Commits
+// specification (https://www.conventionalcommits.org): the subject must look like
+// "type(optional scope)!: description". It reports the compliance rate and the breakdown of
+// the change types in each tick of Sampling days and of each developer. The merge commits
+// are skipped because their messages are usually generated.
+type ConventionalCommitsAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the number of days in a tick.
+	Sampling int
+
+	// ticks are the statistics of each tick.
+	ticks []ConventionalCommitsStats
+	// people are the statistics of each developer; -1 means an unmatched identity.
+	people map[int]ConventionalCommitsStats
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// ConventionalCommitsStats is the compliance of a group of commits.
+type ConventionalCommitsStats struct {
+	// Commits is the number of analysed commits.
+	Commits int
+	// Compliant is the number of commits among Commits which follow the specification.
+	Compliant int
+	// Breaking is the number of commits among Compliant which announce a breaking change.
+	Breaking int
+	// Types map the lower case change types ("feat", "fix", "chore", ...) to the numbers
+	// of Compliant commits.
+	Types map[string]int
+}
+
+// ConventionalCommitsResult is returned by ConventionalCommitsAnalysis.Finalize().
+type ConventionalCommitsResult struct {
+	// Ticks are the statistics of each tick of Sampling days.
+	Ticks []ConventionalCommitsStats
+	// People are the statistics of each developer; -1 means an unmatched identity.
+	People map[int]ConventionalCommitsStats
+	// Sampling is the effective ConventionalCommitsAnalysis.Sampling.
+	Sampling int
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigConventionalCommitsSampling is the name of the option to set
+	// ConventionalCommitsAnalysis.Sampling.
+	ConfigConventionalCommitsSampling = "ConventionalCommits.Sampling"
+	// DefaultConventionalCommitsSampling is the default value of ConventionalCommitsAnalysis.Sampling.
+	DefaultConventionalCommitsSampling = 30
+)
+
+var (
+	conventionalSubjectRegexp  = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_-]*)(\([^()\r\n]+\))?(!)?: \S`)
+	conventionalBreakingRegexp = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: `)
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (analyser *ConventionalCommitsAnalysis) Name() string {
+	return "ConventionalCommits"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (analyser *ConventionalCommitsAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (analyser *ConventionalCommitsAnalysis) Requires() []string {
+	arr := [...]string{identity.DependencyAuthor, items.DependencyDay}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (analyser *ConventionalCommitsAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigConventionalCommitsSampling,
+		Description: "How frequently to record the Conventional Commits compliance, in days.",
+		Flag:        "conventional-commits-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultConventionalCommitsSampling},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (analyser *ConventionalCommitsAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigConventionalCommitsSampling].(int); exists {
+		analyser.Sampling = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		analyser.reversedPeopleDict = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (analyser *ConventionalCommitsAnalysis) Flag() string {
+	return "conventional-commits"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (analyser *ConventionalCommitsAnalysis) Description() string {
+	return "Checks the commit messages against the Conventional Commits specification and " +
+		"reports the compliance rate and the change types over time and per developer."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (analyser *ConventionalCommitsAnalysis) Initialize(repository *git.Repository) {
+	if analyser.Sampling <= 0 {
+		log.Printf("Warning: adjusted the Conventional Commits sampling to %d days\n",
+			DefaultConventionalCommitsSampling)
+		analyser.Sampling = DefaultConventionalCommitsSampling
+	}
+	analyser.ticks = nil
+	analyser.people = map[int]ConventionalCommitsStats{}
+	analyser.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (analyser *ConventionalCommitsAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !analyser.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	commitType, breaking, compliant := parseConventionalCommit(commit.Message)
+	update := func(stats *ConventionalCommitsStats) {
+		stats.Commits++
+		if !compliant {
+			return
+		}
+		stats.Compliant++
+		if breaking {
+			stats.Breaking++
+		}
+		if stats.Types == nil {
+			stats.Types = map[string]int{}
+		}
+		stats.Types[commitType]++
+	}
+	tick := deps[items.DependencyDay].(int) / analyser.Sampling
+	for len(analyser.ticks) <= tick {
+		analyser.ticks = append(analyser.ticks, ConventionalCommitsStats{})
+	}
+	update(&analyser.ticks[tick])
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing {
+		author = -1
+	}
+	stats := analyser.people[author]
+	update(&stats)
+	analyser.people[author] = stats
+	return nil, nil
+}
+
+// parseConventionalCommit returns the lower case change type of the commit message and
+// whether it announces a breaking change. `compliant` is false if the message does not
+// follow the specification.
+func parseConventionalCommit(message string) (commitType string, breaking bool, compliant bool) {
+	subject := message
+	if newline := strings.IndexByte(subject, '\n'); newline >= 0 {
+		subject = subject[:newline]
+	}
+	match := conventionalSubjectRegexp.FindStringSubmatch(subject)
+	if match == nil {
+		return "", false, false
+	}
+	breaking = match[3] != "" || conventionalBreakingRegexp.MatchString(message)
+	return strings.ToLower(match[1]), breaking, true
+}
+
+// Rate returns the share of the compliant commits.
+func (stats ConventionalCommitsStats) Rate() float64 {
+	if stats.Commits == 0 {
+		return 0
+	}
+	return float64(stats.Compliant) / float64(stats.Commits)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (analyser *ConventionalCommitsAnalysis) Finalize() interface{} {
+	return ConventionalCommitsResult{
+		Ticks:              analyser.ticks,
+		People:             analyser.people,
+		Sampling:           analyser.Sampling,
+		reversedPeopleDict: analyser.reversedPeopleDict,
+	}
+}
+
+// Fork clones this pipeline item.
+func (analyser *ConventionalCommitsAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(analyser, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (analyser *ConventionalCommitsAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	conventionalResult := result.(ConventionalCommitsResult)
+	if binary {
+		return analyser.serializeBinary(&conventionalResult, writer)
+	}
+	analyser.serializeText(&conventionalResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (analyser *ConventionalCommitsAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(analyser, result, &pb.ConventionalCommitsAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (analyser *ConventionalCommitsAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(analyser, result, &pb.ConventionalCommitsAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to ConventionalCommitsResult.
+func (analyser *ConventionalCommitsAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.ConventionalCommitsAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	convert := func(stats *pb.ConventionalCommitsStats) ConventionalCommitsStats {
+		result := ConventionalCommitsStats{
+			Commits:   int(stats.Commits),
+			Compliant: int(stats.Compliant),
+			Breaking:  int(stats.Breaking),
+		}
+		if len(stats.Types) > 0 {
+			result.Types = map[string]int{}
+			for commitType, val := range stats.Types {
+				result.Types[commitType] = int(val)
+			}
+		}
+		return result
+	}
+	result := ConventionalCommitsResult{
+		People:             map[int]ConventionalCommitsStats{},
+		Sampling:           int(message.Sampling),
+		reversedPeopleDict: message.DevIndex,
+	}
+	if len(message.Ticks) > 0 {
+		result.Ticks = make([]ConventionalCommitsStats, len(message.Ticks))
+		for i, tick := range message.Ticks {
+			result.Ticks[i] = convert(tick)
+		}
+	}
+	for dev, stats := range message.People {
+		result.People[int(dev)] = convert(stats)
+	}
+	return result, nil
+}
+
+// MergeResults combines two ConventionalCommitsResult-s together. The ticks are regrouped
+// by the larger sampling.
+func (analyser *ConventionalCommitsAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	cr1 := r1.(ConventionalCommitsResult)
+	cr2 := r2.(ConventionalCommitsResult)
+	merged := ConventionalCommitsResult{
+		People:   map[int]ConventionalCommitsStats{},
+		Sampling: cr1.Sampling,
+	}
+	if cr2.Sampling > merged.Sampling {
+		merged.Sampling = cr2.Sampling
+	}
+	var people map[string][3]int
+	people, merged.reversedPeopleDict = identity.Detector{}.MergeReversedDicts(
+		cr1.reversedPeopleDict, cr2.reversedPeopleDict)
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	sum := func(target *ConventionalCommitsStats, stats ConventionalCommitsStats) {
+		target.Commits += stats.Commits
+		target.Compliant += stats.Compliant
+		target.Breaking += stats.Breaking
+		for commitType, val := range stats.Types {
+			if target.Types == nil {
+				target.Types = map[string]int{}
+			}
+			target.Types[commitType] += val
+		}
+	}
+	add := func(result *ConventionalCommitsResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for i, tick := range result.Ticks {
+			index := (i*result.Sampling + offset) / merged.Sampling
+			for len(merged.Ticks) <= index {
+				merged.Ticks = append(merged.Ticks, ConventionalCommitsStats{})
+			}
+			sum(&merged.Ticks[index], tick)
+		}
+		for dev, stats := range result.People {
+			index := -1
+			if dev >= 0 && dev < len(result.reversedPeopleDict) {
+				index = people[result.reversedPeopleDict[dev]][0]
+			}
+			target := merged.People[index]
+			sum(&target, stats)
+			merged.People[index] = target
+		}
+	}
+	add(&cr1, c1)
+	add(&cr2, c2)
+	return merged
+}
+
+func (analyser *ConventionalCommitsAnalysis) serializeText(
+	result *ConventionalCommitsResult, writer io.Writer) {
+	writeStats := func(stats ConventionalCommitsStats) {
+		types := make([]string, 0, len(stats.Types))
+		for commitType := range stats.Types {
+			types = append(types, commitType)
+		}
+		sort.Strings(types)
+		fmt.Fprintf(writer, "{commits: %d, compliant: %d, breaking: %d, rate: %.4f, types: {",
+			stats.Commits, stats.Compliant, stats.Breaking, stats.Rate())
+		for i, commitType := range types {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprintf(writer, "%s: %d", yaml.SafeString(commitType), stats.Types[commitType])
+		}
+		fmt.Fprintln(writer, "}}")
+	}
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  ticks:")
+	for _, tick := range result.Ticks {
+		fmt.Fprint(writer, "    - ")
+		writeStats(tick)
+	}
+	devs := make([]int, 0, len(result.People))
+	for dev := range result.People {
+		devs = append(devs, dev)
+	}
+	sort.Ints(devs)
+	fmt.Fprintln(writer, "  # -1 means an unmatched identity")
+	fmt.Fprintln(writer, "  developers:")
+	for _, dev := range devs {
+		fmt.Fprintf(writer, "    %d: ", dev)
+		writeStats(result.People[dev])
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (analyser *ConventionalCommitsAnalysis) serializeBinary(
+	result *ConventionalCommitsResult, writer io.Writer) error {
+	convert := func(stats ConventionalCommitsStats) *pb.ConventionalCommitsStats {
+		converted := &pb.ConventionalCommitsStats{
+			Commits:   int32(stats.Commits),
+			Compliant: int32(stats.Compliant),
+			Breaking:  int32(stats.Breaking),
+			Types:     map[string]int32{},
+		}
+		for commitType, val := range stats.Types {
+			converted.Types[commitType] = int32(val)
+		}
+		return converted
+	}
+	message := pb.ConventionalCommitsAnalysisResults{
+		Ticks:    make([]*pb.ConventionalCommitsStats, len(result.Ticks)),
+		People:   map[int32]*pb.ConventionalCommitsStats{},
+		Sampling: int32(result.Sampling),
+		DevIndex: result.reversedPeopleDict,
+	}
+	for i, tick := range result.Ticks {
+		message.Ticks[i] = convert(tick)
+	}
+	for dev, stats := range result.People {
+		message.People[int32(dev)] = convert(stats)
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&ConventionalCommitsAnalysis{})
+}
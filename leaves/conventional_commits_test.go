@@ -0,0 +1,173 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureConventionalCommits() *ConventionalCommitsAnalysis {
+	analyser := ConventionalCommitsAnalysis{}
+	analyser.Configure(map[string]interface{}{
+		ConfigConventionalCommitsSampling:               10,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"alice", "bob"},
+	})
+	analyser.Initialize(nil)
+	return &analyser
+}
+
+func TestConventionalCommitsMeta(t *testing.T) {
+	analyser := fixtureConventionalCommits()
+	assert.Equal(t, analyser.Name(), "ConventionalCommits")
+	assert.Len(t, analyser.Provides(), 0)
+	assert.Equal(t, analyser.Requires(), []string{identity.DependencyAuthor, items.DependencyDay})
+	assert.Equal(t, analyser.Flag(), "conventional-commits")
+	assert.NotEmpty(t, analyser.Description())
+	opts := analyser.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Flag, "conventional-commits-sampling")
+	assert.Equal(t, analyser.Sampling, 10)
+	assert.Equal(t, analyser.reversedPeopleDict, []string{"alice", "bob"})
+	analyser = &ConventionalCommitsAnalysis{}
+	analyser.Initialize(nil)
+	assert.Equal(t, analyser.Sampling, DefaultConventionalCommitsSampling)
+	summoned := core.Registry.Summon(analyser.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "ConventionalCommits")
+}
+
+func TestParseConventionalCommit(t *testing.T) {
+	check := func(message, commitType string, breaking, compliant bool) {
+		parsedType, parsedBreaking, parsedCompliant := parseConventionalCommit(message)
+		assert.Equal(t, parsedType, commitType, message)
+		assert.Equal(t, parsedBreaking, breaking, message)
+		assert.Equal(t, parsedCompliant, compliant, message)
+	}
+	check("feat: add the button", "feat", false, true)
+	check("Fix(parser): handle the empty input\n\nDetails.", "fix", false, true)
+	check("refactor(api)!: drop the old endpoints", "refactor", true, true)
+	check("chore: bump the deps\n\nBREAKING CHANGE: requires Go 1.10", "chore", true, true)
+	check("feat:no space", "", false, false)
+	check("feat(): empty scope", "", false, false)
+	check("Add the button", "", false, false)
+	check("", "", false, false)
+	// the footer does not make a non-compliant message compliant
+	check("Add the button\n\nBREAKING CHANGE: everything", "", false, false)
+}
+
+func fixtureConventionalCommitsResult(t *testing.T) ConventionalCommitsResult {
+	analyser := fixtureConventionalCommits()
+	consume := func(author, day int, merge bool, message string) {
+		commit := &object.Commit{Message: message}
+		if merge {
+			commit.Hash = plumbing.NewHash("0123456789012345678901234567890123456789")
+			commit.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		result, err := analyser.Consume(map[string]interface{}{
+			core.DependencyCommit:     commit,
+			core.DependencyIsMerge:    merge,
+			identity.DependencyAuthor: author,
+			items.DependencyDay:       day,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	consume(0, 0, false, "feat: one")
+	consume(0, 2, false, "fix(core)!: two")
+	consume(1, 5, false, "Three")
+	consume(identity.AuthorMissing, 21, false, "feat: four")
+	consume(1, 22, true, "Merge branch 'master'")
+	return analyser.Finalize().(ConventionalCommitsResult)
+}
+
+func TestConventionalCommitsConsumeFinalize(t *testing.T) {
+	result := fixtureConventionalCommitsResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Ticks, []ConventionalCommitsStats{
+		{Commits: 3, Compliant: 2, Breaking: 1, Types: map[string]int{"feat": 1, "fix": 1}},
+		{},
+		{Commits: 1, Compliant: 1, Types: map[string]int{"feat": 1}},
+	})
+	assert.Equal(t, result.People, map[int]ConventionalCommitsStats{
+		0:  {Commits: 2, Compliant: 2, Breaking: 1, Types: map[string]int{"feat": 1, "fix": 1}},
+		1:  {Commits: 1},
+		-1: {Commits: 1, Compliant: 1, Types: map[string]int{"feat": 1}},
+	})
+	assert.Equal(t, result.Ticks[0].Rate(), 2.0/3)
+	assert.Equal(t, ConventionalCommitsStats{}.Rate(), float64(0))
+}
+
+func TestConventionalCommitsSerialize(t *testing.T) {
+	result := fixtureConventionalCommitsResult(t)
+	analyser := fixtureConventionalCommits()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  ticks:
+    - {commits: 3, compliant: 2, breaking: 1, rate: 0.6667, types: {"feat": 1, "fix": 1}}
+    - {commits: 0, compliant: 0, breaking: 0, rate: 0.0000, types: {}}
+    - {commits: 1, compliant: 1, breaking: 0, rate: 1.0000, types: {"feat": 1}}
+  # -1 means an unmatched identity
+  developers:
+    -1: {commits: 1, compliant: 1, breaking: 0, rate: 1.0000, types: {"feat": 1}}
+    0: {commits: 2, compliant: 2, breaking: 1, rate: 1.0000, types: {"feat": 1, "fix": 1}}
+    1: {commits: 1, compliant: 0, breaking: 0, rate: 0.0000, types: {}}
+  people:
+  - "alice"
+  - "bob"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, true, buffer))
+	msg := pb.ConventionalCommitsAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Len(t, msg.Ticks, 3)
+	assert.Equal(t, msg.Ticks[0].Types, map[string]int32{"feat": 1, "fix": 1})
+	assert.Len(t, msg.People, 3)
+	assert.Equal(t, msg.DevIndex, []string{"alice", "bob"})
+	deserialized, err := analyser.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestConventionalCommitsMergeResults(t *testing.T) {
+	r1 := ConventionalCommitsResult{
+		Ticks: []ConventionalCommitsStats{
+			{Commits: 2, Compliant: 1, Types: map[string]int{"feat": 1}}},
+		People: map[int]ConventionalCommitsStats{
+			0: {Commits: 2, Compliant: 1, Types: map[string]int{"feat": 1}}},
+		Sampling:           10,
+		reversedPeopleDict: []string{"alice"},
+	}
+	r2 := ConventionalCommitsResult{
+		Ticks: []ConventionalCommitsStats{
+			{Commits: 3, Compliant: 3, Breaking: 1, Types: map[string]int{"feat": 2, "docs": 1}}},
+		People: map[int]ConventionalCommitsStats{
+			0:  {Commits: 1, Compliant: 1, Types: map[string]int{"docs": 1}},
+			1:  {Commits: 2, Compliant: 2, Breaking: 1, Types: map[string]int{"feat": 2}},
+			-1: {}},
+		Sampling:           20,
+		reversedPeopleDict: []string{"bob", "alice"},
+	}
+	analyser := fixtureConventionalCommits()
+	merged := analyser.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 30 * 24 * 3600}).(ConventionalCommitsResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.reversedPeopleDict, []string{"alice", "bob"})
+	assert.Equal(t, merged.Ticks, []ConventionalCommitsStats{
+		{Commits: 2, Compliant: 1, Types: map[string]int{"feat": 1}},
+		{Commits: 3, Compliant: 3, Breaking: 1, Types: map[string]int{"feat": 2, "docs": 1}}})
+	assert.Equal(t, merged.People, map[int]ConventionalCommitsStats{
+		0:  {Commits: 4, Compliant: 3, Breaking: 1, Types: map[string]int{"feat": 3}},
+		1:  {Commits: 1, Compliant: 1, Types: map[string]int{"docs": 1}},
+		-1: {}})
+}
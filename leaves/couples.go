@@ -3,6 +3,8 @@ package leaves
 import (
 	"fmt"
 	"io"
+	"log"
+	"math"
 		"sort"
 
 	"github.com/gogo/protobuf/proto"
@@ -24,6 +26,20 @@ type CouplesAnalysis struct {
 	core.OneShotMergeProcessor
 	// PeopleNumber is the number of developers for which to build the matrix. 0 disables this analysis.
 	PeopleNumber int
+	// SignificantOnly removes the file couples which are not statistically significant.
+	SignificantOnly bool
+	// SignificanceLevel is the p-value threshold of the chi-square test which decides whether
+	// a pair of files changes together more often than by chance.
+	SignificanceLevel float32
+	// DecayHalfLife is the number of days after which the weight of a common commit halves
+	// in CouplesResult.FilesDecayed. 0 disables the decay.
+	DecayHalfLife int
+	// Window is the number of the most recent days whose commits are counted
+	// in CouplesResult.FilesDecayed. 0 means the whole history.
+	Window int
+	// DirectoryDepth enables the directory couples: the files are grouped by the first
+	// DirectoryDepth components of their paths. 0 disables them.
+	DirectoryDepth int
 
 	// people store how many times every developer committed to every file.
 	people []map[string]int
@@ -31,10 +47,16 @@ type CouplesAnalysis struct {
 	peopleCommits []int
 	// files store every file occurred in the same commit with every other file.
 	files map[string]map[string]int
+	// directories store every directory occurred in the same commit with every other directory.
+	directories map[string]map[string]int
 	// renames point from new file name to old file name.
 	renames *[]rename
 	// lastCommit is the last commit which was consumed.
 	lastCommit *object.Commit
+	// commits is the number of consumed non-merge commits.
+	commits int
+	// decayed accumulates the common commits weighted by recency, nil if disabled.
+	decayed *decayedCouples
 	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
 	reversedPeopleDict []string
 }
@@ -46,11 +68,63 @@ type CouplesResult struct {
 	PeopleFiles  [][]int
 	FilesMatrix  []map[int]int64
 	Files        []string
+	// FilesLift is the ratio of the observed number of common commits of each pair of files
+	// to the number expected by chance. It has the same layout as FilesMatrix.
+	FilesLift []map[int]float32
+	// FilesChiSquare is Pearson's chi-square statistic of each pair of files.
+	// It has the same layout as FilesMatrix.
+	FilesChiSquare []map[int]float32
+	// FilesJaccard is the number of common commits of each pair of files divided by
+	// the number of commits which changed either of them. It has the same layout as FilesMatrix.
+	FilesJaccard []map[int]float32
+	// FilesConfidence is the fraction of the commits which changed the file in the row
+	// and also changed the file in the column. It is not symmetric. It has the same layout
+	// as FilesMatrix.
+	FilesConfidence []map[int]float32
+	// CommitsNumber is the number of non-merge commits which were counted in FilesMatrix.
+	CommitsNumber int
+	// FilesDecayed is the number of common commits of each pair of files weighted by recency:
+	// a commit which happened `age` days before the last one weighs 2^(-age/DecayHalfLife)
+	// and the commits older than Window days are ignored. The diagonal contains the weighted
+	// number of commits of each file. The rows and the columns correspond to Files.
+	// It is nil unless CouplesAnalysis.DecayHalfLife or CouplesAnalysis.Window is set.
+	FilesDecayed []map[int]float32
+	// DecayHalfLife is the half-life of FilesDecayed in days.
+	DecayHalfLife int
+	// Window is the window of FilesDecayed in days.
+	Window int
+	// DirectoriesMatrix is the number of commits which changed the files in each pair of
+	// directories. The rows and the columns correspond to Directories. It is nil unless
+	// CouplesAnalysis.DirectoryDepth is set.
+	DirectoriesMatrix []map[int]int64
+	// Directories are the directories of the files which exist in the last commit truncated
+	// to CouplesAnalysis.DirectoryDepth components; "." is the root.
+	Directories []string
 
 	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
 	reversedPeopleDict []string
 }
 
+const (
+	// ConfigCouplesSignificantOnly is the name of the option to keep only the statistically
+	// significant file couples.
+	ConfigCouplesSignificantOnly = "Couples.SignificantOnly"
+	// ConfigCouplesSignificanceLevel is the name of the option to set the p-value threshold
+	// of the chi-square test.
+	ConfigCouplesSignificanceLevel = "Couples.SignificanceLevel"
+	// DefaultCouplesSignificanceLevel is the default p-value threshold of the chi-square test.
+	DefaultCouplesSignificanceLevel = float32(0.05)
+	// ConfigCouplesDecayHalfLife is the name of the option to set the half-life in days
+	// of the recency-weighted common commits.
+	ConfigCouplesDecayHalfLife = "Couples.DecayHalfLife"
+	// ConfigCouplesWindow is the name of the option to count only the common commits
+	// within the specified number of the most recent days.
+	ConfigCouplesWindow = "Couples.Window"
+	// ConfigCouplesDirectoryDepth is the name of the option to calculate the directory couples
+	// with the specified depth of the paths.
+	ConfigCouplesDirectoryDepth = "Couples.DirectoryDepth"
+)
+
 type rename struct {
 	FromName string
 	ToName string
@@ -72,13 +146,44 @@ func (couples *CouplesAnalysis) Provides() []string {
 // Each requested entity will be inserted into `deps` of Consume(). In turn, those
 // entities are Provides() upstream.
 func (couples *CouplesAnalysis) Requires() []string {
-	arr := [...]string{identity.DependencyAuthor, items.DependencyTreeChanges}
+	arr := [...]string{identity.DependencyAuthor, items.DependencyTreeChanges, items.DependencyDay}
 	return arr[:]
 }
 
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
 func (couples *CouplesAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
-	return []core.ConfigurationOption{}
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigCouplesSignificantOnly,
+		Description: "Keep only the file couples which change together more often than by chance " +
+			"according to the chi-square test.",
+		Flag:    "couples-significant-only",
+		Type:    core.BoolConfigurationOption,
+		Default: false}, {
+		Name:        ConfigCouplesSignificanceLevel,
+		Description: "The p-value threshold of the chi-square test for --couples-significant-only.",
+		Flag:        "couples-significance",
+		Type:        core.FloatConfigurationOption,
+		Default:     DefaultCouplesSignificanceLevel}, {
+		Name: ConfigCouplesDecayHalfLife,
+		Description: "Additionally weigh the common commits of the files by recency: " +
+			"the weight halves every specified number of days. 0 disables the decay.",
+		Flag:    "couples-half-life",
+		Type:    core.IntConfigurationOption,
+		Default: 0}, {
+		Name: ConfigCouplesWindow,
+		Description: "Additionally count the common commits of the files only within " +
+			"the specified number of the most recent days. 0 means the whole history.",
+		Flag:    "couples-window",
+		Type:    core.IntConfigurationOption,
+		Default: 0}, {
+		Name: ConfigCouplesDirectoryDepth,
+		Description: "Additionally couple the directories which consist of the specified " +
+			"number of the first path components. 0 disables the directory couples.",
+		Flag:    "couples-directory-depth",
+		Type:    core.IntConfigurationOption,
+		Default: 0},
+	}
+	return options[:]
 }
 
 // Configure sets the properties previously published by ListConfigurationOptions().
@@ -87,6 +192,21 @@ func (couples *CouplesAnalysis) Configure(facts map[string]interface{}) {
 		couples.PeopleNumber = val
 		couples.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
 	}
+	if val, exists := facts[ConfigCouplesSignificantOnly].(bool); exists {
+		couples.SignificantOnly = val
+	}
+	if val, exists := facts[ConfigCouplesSignificanceLevel].(float32); exists {
+		couples.SignificanceLevel = val
+	}
+	if val, exists := facts[ConfigCouplesDecayHalfLife].(int); exists {
+		couples.DecayHalfLife = val
+	}
+	if val, exists := facts[ConfigCouplesWindow].(int); exists {
+		couples.Window = val
+	}
+	if val, exists := facts[ConfigCouplesDirectoryDepth].(int); exists {
+		couples.DirectoryDepth = val
+	}
 }
 
 // Flag for the command line switch which enables this analysis.
@@ -111,6 +231,27 @@ func (couples *CouplesAnalysis) Initialize(repository *git.Repository) {
 	couples.peopleCommits = make([]int, couples.PeopleNumber+1)
 	couples.files = map[string]map[string]int{}
 	couples.renames = &[]rename{}
+	couples.commits = 0
+	if couples.SignificanceLevel <= 0 || couples.SignificanceLevel >= 1 {
+		couples.SignificanceLevel = DefaultCouplesSignificanceLevel
+	}
+	if couples.DecayHalfLife < 0 {
+		log.Printf("Warning: adjusted the couples half-life to 0\n")
+		couples.DecayHalfLife = 0
+	}
+	if couples.Window < 0 {
+		log.Printf("Warning: adjusted the couples window to 0\n")
+		couples.Window = 0
+	}
+	if couples.DirectoryDepth < 0 {
+		log.Printf("Warning: adjusted the couples directory depth to 0\n")
+		couples.DirectoryDepth = 0
+	}
+	couples.directories = map[string]map[string]int{}
+	couples.decayed = nil
+	if couples.DecayHalfLife > 0 || couples.Window > 0 {
+		couples.decayed = newDecayedCouples(couples.DecayHalfLife, couples.Window)
+	}
 	couples.OneShotMergeProcessor.Initialize()
 }
 
@@ -130,6 +271,9 @@ func (couples *CouplesAnalysis) Consume(deps map[string]interface{}) (map[string
 	if firstMerge {
 		couples.peopleCommits[author]++
 	}
+	if !mergeMode {
+		couples.commits++
+	}
 	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
 	context := make([]string, 0, len(treeDiff))
 	for _, change := range treeDiff {
@@ -158,6 +302,9 @@ func (couples *CouplesAnalysis) Consume(deps map[string]interface{}) (map[string
 				// renamed
 				*couples.renames = append(
 					*couples.renames, rename{ToName: toName, FromName: fromName})
+				if couples.decayed != nil {
+					couples.decayed.rename(fromName, toName)
+				}
 			}
 			if !mergeMode {
 				context = append(context, toName)
@@ -175,6 +322,25 @@ func (couples *CouplesAnalysis) Consume(deps map[string]interface{}) (map[string
 			lane[otherFile]++
 		}
 	}
+	if couples.DirectoryDepth > 0 {
+		dirs := map[string]bool{}
+		for _, file := range context {
+			dirs[truncateDirectory(file, couples.DirectoryDepth)] = true
+		}
+		for dir := range dirs {
+			lane, exists := couples.directories[dir]
+			if !exists {
+				lane = map[string]int{}
+				couples.directories[dir] = lane
+			}
+			for otherDir := range dirs {
+				lane[otherDir]++
+			}
+		}
+	}
+	if couples.decayed != nil && !mergeMode && len(context) > 0 {
+		couples.decayed.add(deps[items.DependencyDay].(int), context)
+	}
 	return nil, nil
 }
 
@@ -222,15 +388,163 @@ func (couples *CouplesAnalysis) Finalize() interface{} {
 			filesMatrix[i][filesIndex[otherFile]] = int64(cooccs)
 		}
 	}
+	filesLift, filesChiSquare := calculateCouplesSignificance(filesMatrix, couples.commits)
+	filesJaccard, filesConfidence := calculateCouplesSimilarity(filesMatrix)
+	if couples.SignificantOnly {
+		filterSignificantCouples(filesMatrix, filesLift, filesChiSquare, couples.SignificanceLevel,
+			filesJaccard, filesConfidence)
+	}
+	var filesDecayed []map[int]float32
+	if couples.decayed != nil {
+		filesDecayed = couples.decayed.matrix(filesIndex)
+	}
+	var directoriesSequence []string
+	var directoriesMatrix []map[int]int64
+	if couples.DirectoryDepth > 0 {
+		directoriesSequence, directoriesMatrix = couples.directoriesMatrix(files)
+	}
 	return CouplesResult{
 		PeopleMatrix:       peopleMatrix,
 		PeopleFiles:        peopleFiles,
 		Files:              filesSequence,
 		FilesMatrix:        filesMatrix,
+		FilesLift:          filesLift,
+		FilesChiSquare:     filesChiSquare,
+		FilesJaccard:       filesJaccard,
+		FilesConfidence:    filesConfidence,
+		CommitsNumber:      couples.commits,
+		FilesDecayed:       filesDecayed,
+		DecayHalfLife:      couples.DecayHalfLife,
+		Window:             couples.Window,
+		Directories:        directoriesSequence,
+		DirectoriesMatrix:  directoriesMatrix,
 		reversedPeopleDict: couples.reversedPeopleDict,
 	}
 }
 
+// directoriesMatrix returns the sorted directories of the files and their co-occurrences.
+func (couples *CouplesAnalysis) directoriesMatrix(files map[string]map[string]int) (
+	[]string, []map[int]int64) {
+	index := map[string]int{}
+	for file := range files {
+		index[truncateDirectory(file, couples.DirectoryDepth)] = 0
+	}
+	sequence := make([]string, 0, len(index))
+	for dir := range index {
+		sequence = append(sequence, dir)
+	}
+	sort.Strings(sequence)
+	for i, dir := range sequence {
+		index[dir] = i
+	}
+	matrix := make([]map[int]int64, len(sequence))
+	for i, dir := range sequence {
+		matrix[i] = map[int]int64{}
+		for otherDir, cooccs := range couples.directories[dir] {
+			if j, exists := index[otherDir]; exists {
+				matrix[i][j] = int64(cooccs)
+			}
+		}
+	}
+	return sequence, matrix
+}
+
+// calculateCouplesSignificance compares the number of common commits of each pair of files
+// with the number expected by chance given how often each of them changed. The diagonal of
+// `matrix` must contain the number of commits which changed the corresponding file.
+// It returns the lift and Pearson's chi-square statistic of the 2x2 contingency table.
+func calculateCouplesSignificance(matrix []map[int]int64, commits int) (
+	lift []map[int]float32, chiSquare []map[int]float32) {
+	lift = make([]map[int]float32, len(matrix))
+	chiSquare = make([]map[int]float32, len(matrix))
+	n := float64(commits)
+	for i, row := range matrix {
+		lift[i] = map[int]float32{}
+		chiSquare[i] = map[int]float32{}
+		ci := float64(matrix[i][i])
+		for j, val := range row {
+			cj := float64(matrix[j][j])
+			cij := float64(val)
+			if ci == 0 || cj == 0 || n == 0 {
+				lift[i][j] = 0
+				chiSquare[i][j] = 0
+				continue
+			}
+			lift[i][j] = float32(n * cij / (ci * cj))
+			// contingency table: both changed, only i, only j, neither
+			a, b, c := cij, ci-cij, cj-cij
+			d := n - ci - cj + cij
+			denominator := (a + b) * (c + d) * (a + c) * (b + d)
+			if denominator <= 0 {
+				chiSquare[i][j] = 0
+				continue
+			}
+			delta := a*d - b*c
+			chiSquare[i][j] = float32(n * delta * delta / denominator)
+		}
+	}
+	return lift, chiSquare
+}
+
+// calculateCouplesSimilarity returns the Jaccard index and the confidence of each pair of files.
+// The diagonal of `matrix` must contain the number of commits which changed the corresponding file.
+func calculateCouplesSimilarity(matrix []map[int]int64) (
+	jaccard []map[int]float32, confidence []map[int]float32) {
+	jaccard = make([]map[int]float32, len(matrix))
+	confidence = make([]map[int]float32, len(matrix))
+	for i, row := range matrix {
+		jaccard[i] = map[int]float32{}
+		confidence[i] = map[int]float32{}
+		ci := matrix[i][i]
+		for j, cij := range row {
+			cj := matrix[j][j]
+			if union := ci + cj - cij; union > 0 {
+				jaccard[i][j] = float32(cij) / float32(union)
+			} else {
+				jaccard[i][j] = 0
+			}
+			if ci > 0 {
+				confidence[i][j] = float32(cij) / float32(ci)
+			} else {
+				confidence[i][j] = 0
+			}
+		}
+	}
+	return jaccard, confidence
+}
+
+// chiSquareCriticalValue returns the critical value of the chi-square distribution with
+// one degree of freedom for the specified significance level.
+func chiSquareCriticalValue(level float32) float64 {
+	z := math.Erfinv(1 - float64(level))
+	return 2 * z * z
+}
+
+// filterSignificantCouples removes the pairs of files which do not change together
+// significantly more often than by chance. The diagonal is always preserved.
+// `metrics` are the other matrices with the same layout which are filtered as well.
+func filterSignificantCouples(
+	matrix []map[int]int64, lift, chiSquare []map[int]float32, level float32,
+	metrics ...[]map[int]float32) {
+	critical := chiSquareCriticalValue(level)
+	for i, row := range matrix {
+		for j := range row {
+			if i == j {
+				continue
+			}
+			if lift[i][j] > 1 && float64(chiSquare[i][j]) >= critical {
+				continue
+			}
+			delete(row, j)
+			delete(lift[i], j)
+			delete(chiSquare[i], j)
+			for _, metric := range metrics {
+				delete(metric[i], j)
+			}
+		}
+	}
+}
+
 // Fork clones this pipeline item.
 func (couples *CouplesAnalysis) Fork(n int) []core.PipelineItem {
 	return core.ForkCopyPipelineItem(couples, n)
@@ -247,6 +561,16 @@ func (couples *CouplesAnalysis) Serialize(result interface{}, binary bool, write
 	return nil
 }
 
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (couples *CouplesAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(couples, result, &pb.CouplesAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (couples *CouplesAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(couples, result, &pb.CouplesAnalysisResults{})
+}
+
 // Deserialize converts the specified protobuf bytes to CouplesResult.
 func (couples *CouplesAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
 	message := pb.CouplesAnalysisResults{}
@@ -280,6 +604,48 @@ func (couples *CouplesAnalysis) Deserialize(pbmessage []byte) (interface{}, erro
 	}
 	convertCSR(result.FilesMatrix, message.FileCouples.Matrix)
 	convertCSR(result.PeopleMatrix, message.PeopleCouples.Matrix)
+	if message.FileSignificance != nil {
+		src := message.FileCouples.Matrix
+		result.CommitsNumber = int(message.FileSignificance.Commits)
+		result.FilesLift = make([]map[int]float32, len(result.FilesMatrix))
+		result.FilesChiSquare = make([]map[int]float32, len(result.FilesMatrix))
+		similarity := len(message.FileSignificance.Jaccard) > 0 || len(src.Indices) == 0
+		if similarity {
+			result.FilesJaccard = make([]map[int]float32, len(result.FilesMatrix))
+			result.FilesConfidence = make([]map[int]float32, len(result.FilesMatrix))
+		}
+		for indptr := 1; indptr < len(src.Indptr); indptr++ {
+			lift := map[int]float32{}
+			chiSquare := map[int]float32{}
+			jaccard := map[int]float32{}
+			confidence := map[int]float32{}
+			for j := src.Indptr[indptr-1]; j < src.Indptr[indptr]; j++ {
+				lift[int(src.Indices[j])] = message.FileSignificance.Lift[j]
+				chiSquare[int(src.Indices[j])] = message.FileSignificance.ChiSquare[j]
+				if similarity {
+					jaccard[int(src.Indices[j])] = message.FileSignificance.Jaccard[j]
+					confidence[int(src.Indices[j])] = message.FileSignificance.Confidence[j]
+				}
+			}
+			result.FilesLift[indptr-1] = lift
+			result.FilesChiSquare[indptr-1] = chiSquare
+			if similarity {
+				result.FilesJaccard[indptr-1] = jaccard
+				result.FilesConfidence[indptr-1] = confidence
+			}
+		}
+	}
+	if message.DirectoryCouples != nil {
+		result.Directories = message.DirectoryCouples.Index
+		result.DirectoriesMatrix = make(
+			[]map[int]int64, message.DirectoryCouples.Matrix.NumberOfRows)
+		convertCSR(result.DirectoriesMatrix, message.DirectoryCouples.Matrix)
+	}
+	if message.FileDecay != nil {
+		result.FilesDecayed = decayedMatrixFromPB(message.FileDecay)
+		result.DecayHalfLife = int(message.FileDecay.HalfLife)
+		result.Window = int(message.FileDecay.Window)
+	}
 	return result, nil
 }
 
@@ -357,6 +723,62 @@ func (couples *CouplesAnalysis) MergeResults(r1, r2 interface{}, c1, c2 *core.Co
 	}
 	addFiles(cr1.FilesMatrix, cr1.Files)
 	addFiles(cr2.FilesMatrix, cr2.Files)
+	merged.CommitsNumber = cr1.CommitsNumber + cr2.CommitsNumber
+	merged.FilesLift, merged.FilesChiSquare = calculateCouplesSignificance(
+		merged.FilesMatrix, merged.CommitsNumber)
+	merged.FilesJaccard, merged.FilesConfidence = calculateCouplesSimilarity(merged.FilesMatrix)
+	if couples.SignificantOnly {
+		// the pairs which were significant in each result separately may be not in the sum
+		level := couples.SignificanceLevel
+		if level <= 0 || level >= 1 {
+			level = DefaultCouplesSignificanceLevel
+		}
+		filterSignificantCouples(merged.FilesMatrix, merged.FilesLift, merged.FilesChiSquare,
+			level, merged.FilesJaccard, merged.FilesConfidence)
+	}
+	if cr1.DirectoriesMatrix != nil || cr2.DirectoriesMatrix != nil {
+		var dirs map[string][3]int
+		dirs, merged.Directories = identity.Detector{}.MergeReversedDicts(
+			cr1.Directories, cr2.Directories)
+		merged.DirectoriesMatrix = make([]map[int]int64, len(merged.Directories))
+		for i := range merged.DirectoriesMatrix {
+			merged.DirectoriesMatrix[i] = map[int]int64{}
+		}
+		addDirectories := func(matrix []map[int]int64, reversedDict []string) {
+			for di, dc := range matrix {
+				m := merged.DirectoriesMatrix[dirs[reversedDict[di]][0]]
+				for dir, val := range dc {
+					m[dirs[reversedDict[dir]][0]] += val
+				}
+			}
+		}
+		addDirectories(cr1.DirectoriesMatrix, cr1.Directories)
+		addDirectories(cr2.DirectoriesMatrix, cr2.Directories)
+	}
+	if cr1.FilesDecayed != nil && cr2.FilesDecayed != nil {
+		if cr1.DecayHalfLife != cr2.DecayHalfLife || cr1.Window != cr2.Window {
+			log.Printf("Warning: dropped the decayed couples with different parameters: "+
+				"half-life %d and %d, window %d and %d\n", cr1.DecayHalfLife, cr2.DecayHalfLife,
+				cr1.Window, cr2.Window)
+		} else {
+			// both results are assumed to end at the same time
+			merged.DecayHalfLife, merged.Window = cr1.DecayHalfLife, cr1.Window
+			merged.FilesDecayed = make([]map[int]float32, len(merged.Files))
+			for i := range merged.FilesDecayed {
+				merged.FilesDecayed[i] = map[int]float32{}
+			}
+			addDecayed := func(filesDecayed []map[int]float32, reversedFilesDict []string) {
+				for fi, fc := range filesDecayed {
+					m := merged.FilesDecayed[files[reversedFilesDict[fi]][0]]
+					for file, val := range fc {
+						m[files[reversedFilesDict[file]][0]] += val
+					}
+				}
+			}
+			addDecayed(cr1.FilesDecayed, cr1.Files)
+			addDecayed(cr2.FilesDecayed, cr2.Files)
+		}
+	}
 	return merged
 }
 
@@ -384,6 +806,63 @@ func (couples *CouplesAnalysis) serializeText(result *CouplesResult, writer io.W
 		fmt.Fprintln(writer, "}")
 	}
 
+	serializeFloatMatrix := func(name string, matrix []map[int]float32) {
+		fmt.Fprintf(writer, "    %s:\n", name)
+		for _, files := range matrix {
+			fmt.Fprint(writer, "      - {")
+			var indices []int
+			for file := range files {
+				indices = append(indices, file)
+			}
+			sort.Ints(indices)
+			for i, file := range indices {
+				fmt.Fprintf(writer, "%d: %.4f", file, files[file])
+				if i < len(indices)-1 {
+					fmt.Fprint(writer, ", ")
+				}
+			}
+			fmt.Fprintln(writer, "}")
+		}
+	}
+	if result.FilesLift != nil {
+		fmt.Fprintf(writer, "    commits: %d\n", result.CommitsNumber)
+		serializeFloatMatrix("lift", result.FilesLift)
+		serializeFloatMatrix("chi_square", result.FilesChiSquare)
+	}
+	if result.FilesJaccard != nil {
+		serializeFloatMatrix("jaccard", result.FilesJaccard)
+		serializeFloatMatrix("confidence", result.FilesConfidence)
+	}
+	if result.FilesDecayed != nil {
+		fmt.Fprintf(writer, "    decay_half_life: %d\n", result.DecayHalfLife)
+		fmt.Fprintf(writer, "    decay_window: %d\n", result.Window)
+		serializeFloatMatrix("decayed", result.FilesDecayed)
+	}
+
+	if result.DirectoriesMatrix != nil {
+		fmt.Fprintln(writer, "  dirs_coocc:")
+		fmt.Fprintln(writer, "    index:")
+		for _, dir := range result.Directories {
+			fmt.Fprintf(writer, "      - %s\n", yaml.SafeString(dir))
+		}
+		fmt.Fprintln(writer, "    matrix:")
+		for _, dirs := range result.DirectoriesMatrix {
+			fmt.Fprint(writer, "      - {")
+			var indices []int
+			for dir := range dirs {
+				indices = append(indices, dir)
+			}
+			sort.Ints(indices)
+			for i, dir := range indices {
+				fmt.Fprintf(writer, "%d: %d", dir, dirs[dir])
+				if i < len(indices)-1 {
+					fmt.Fprint(writer, ", ")
+				}
+			}
+			fmt.Fprintln(writer, "}")
+		}
+	}
+
 	fmt.Fprintln(writer, "  people_coocc:")
 	fmt.Fprintln(writer, "    index:")
 	for _, person := range result.reversedPeopleDict {
@@ -458,6 +937,36 @@ func (couples *CouplesAnalysis) serializeBinary(result *CouplesResult, writer io
 		Index:  result.Files,
 		Matrix: pb.MapToCompressedSparseRowMatrix(result.FilesMatrix),
 	}
+	if result.FilesLift != nil {
+		significance := &pb.CouplesSignificance{Commits: int32(result.CommitsNumber)}
+		for i, files := range result.FilesMatrix {
+			order := make([]int, 0, len(files))
+			for file := range files {
+				order = append(order, file)
+			}
+			sort.Ints(order)
+			for _, file := range order {
+				significance.Lift = append(significance.Lift, result.FilesLift[i][file])
+				significance.ChiSquare = append(significance.ChiSquare, result.FilesChiSquare[i][file])
+				if result.FilesJaccard != nil {
+					significance.Jaccard = append(significance.Jaccard, result.FilesJaccard[i][file])
+					significance.Confidence = append(
+						significance.Confidence, result.FilesConfidence[i][file])
+				}
+			}
+		}
+		message.FileSignificance = significance
+	}
+	if result.DirectoriesMatrix != nil {
+		message.DirectoryCouples = &pb.Couples{
+			Index:  result.Directories,
+			Matrix: pb.MapToCompressedSparseRowMatrix(result.DirectoriesMatrix),
+		}
+	}
+	if result.FilesDecayed != nil {
+		message.FileDecay = decayedMatrixToPB(
+			result.FilesDecayed, result.DecayHalfLife, result.Window)
+	}
 	message.PeopleCouples = &pb.Couples{
 		Index:  result.reversedPeopleDict,
 		Matrix: pb.MapToCompressedSparseRowMatrix(result.PeopleMatrix),
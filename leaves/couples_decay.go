@@ -0,0 +1,220 @@
+package leaves
+
+import (
+	"math"
+	"sort"
+
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+)
+
+// decayedCouples accumulates the number of common commits of the pairs of files weighted
+// by recency. A commit which happened `age` days before the last one weighs 2^(-age/halfLife);
+// the commits which are older than the window are ignored.
+// The state is shared by the forks of CouplesAnalysis the same way as the counts.
+type decayedCouples struct {
+	// halfLife is in days, 0 disables the decay.
+	halfLife int
+	// window is in days, 0 means the whole history.
+	window int
+	// lastDay is the most recent day of the consumed commits.
+	lastDay int
+	// pairs are the decayed counts which are updated lazily. They are used without the window.
+	pairs map[string]map[string]*decayedCount
+	// commits are the changed files of the commits in the window.
+	commits []decayedCommit
+}
+
+// decayedCount is the weight of the common commits at the specified day.
+type decayedCount struct {
+	value float64
+	day   int
+}
+
+// decayedCommit is the set of the files changed in a commit.
+type decayedCommit struct {
+	day   int
+	files []string
+}
+
+func newDecayedCouples(halfLife, window int) *decayedCouples {
+	return &decayedCouples{
+		halfLife: halfLife, window: window, pairs: map[string]map[string]*decayedCount{}}
+}
+
+// weight returns the decay factor of the commit which happened `age` days ago.
+func (dc *decayedCouples) weight(age int) float64 {
+	if dc.halfLife <= 0 || age <= 0 {
+		return 1
+	}
+	return math.Exp2(-float64(age) / float64(dc.halfLife))
+}
+
+// decayTo moves the count to the specified day if it is later.
+func (dc *decayedCouples) decayTo(count *decayedCount, day int) {
+	if day > count.day {
+		count.value *= dc.weight(day - count.day)
+		count.day = day
+	}
+}
+
+// add records the files which were changed together on the specified day.
+func (dc *decayedCouples) add(day int, files []string) {
+	if day > dc.lastDay {
+		dc.lastDay = day
+	}
+	if dc.window > 0 {
+		dc.commits = append(dc.commits, decayedCommit{day: day, files: files})
+		i := 0
+		for ; i < len(dc.commits) && dc.lastDay-dc.commits[i].day >= dc.window; i++ {
+		}
+		dc.commits = dc.commits[i:]
+		return
+	}
+	for _, file := range files {
+		lane := dc.pairs[file]
+		if lane == nil {
+			lane = map[string]*decayedCount{}
+			dc.pairs[file] = lane
+		}
+		for _, other := range files {
+			count := lane[other]
+			if count == nil {
+				count = &decayedCount{day: day}
+				lane[other] = count
+			}
+			if day >= count.day {
+				dc.decayTo(count, day)
+				count.value++
+			} else {
+				// the commits of the branches are not ordered by time
+				count.value += dc.weight(count.day - day)
+			}
+		}
+	}
+}
+
+// rename moves the accumulated weights of `from` to `to`.
+func (dc *decayedCouples) rename(from, to string) {
+	if dc.window > 0 {
+		for _, commit := range dc.commits {
+			for i, file := range commit.files {
+				if file == from {
+					commit.files[i] = to
+				}
+			}
+		}
+		return
+	}
+	lane, exists := dc.pairs[from]
+	if !exists {
+		return
+	}
+	delete(dc.pairs, from)
+	merge := func(lane map[string]*decayedCount, key string, count *decayedCount) {
+		if existing := lane[key]; existing != nil {
+			day := existing.day
+			if count.day > day {
+				day = count.day
+			}
+			dc.decayTo(existing, day)
+			dc.decayTo(count, day)
+			existing.value += count.value
+		} else {
+			lane[key] = count
+		}
+	}
+	toLane := dc.pairs[to]
+	if toLane == nil {
+		toLane = map[string]*decayedCount{}
+		dc.pairs[to] = toLane
+	}
+	for other, count := range lane {
+		switch other {
+		case from:
+			other = to
+		case to:
+			delete(toLane, from)
+		default:
+			otherLane := dc.pairs[other]
+			delete(otherLane, from)
+			merge(otherLane, to, &decayedCount{value: count.value, day: count.day})
+		}
+		merge(toLane, other, count)
+	}
+}
+
+// matrix returns the weights of the pairs of the indexed files at the last day.
+func (dc *decayedCouples) matrix(index map[string]int) []map[int]float32 {
+	result := make([]map[int]float32, len(index))
+	for i := range result {
+		result[i] = map[int]float32{}
+	}
+	if dc.window > 0 {
+		for _, commit := range dc.commits {
+			if dc.lastDay-commit.day >= dc.window {
+				continue
+			}
+			weight := float32(dc.weight(dc.lastDay - commit.day))
+			for _, file := range commit.files {
+				i, exists := index[file]
+				if !exists {
+					continue
+				}
+				for _, other := range commit.files {
+					if j, exists := index[other]; exists {
+						result[i][j] += weight
+					}
+				}
+			}
+		}
+		return result
+	}
+	for file, lane := range dc.pairs {
+		i, exists := index[file]
+		if !exists {
+			continue
+		}
+		for other, count := range lane {
+			if j, exists := index[other]; exists {
+				dc.decayTo(count, dc.lastDay)
+				result[i][j] = float32(count.value)
+			}
+		}
+	}
+	return result
+}
+
+// decayedMatrixToPB converts the decayed weights to the Protocol Buffers message.
+func decayedMatrixToPB(matrix []map[int]float32, halfLife, window int) *pb.CouplesDecay {
+	message := &pb.CouplesDecay{
+		HalfLife: int32(halfLife),
+		Window:   int32(window),
+		Indptr:   make([]int64, 1, len(matrix)+1),
+	}
+	for _, row := range matrix {
+		order := make([]int, 0, len(row))
+		for j := range row {
+			order = append(order, j)
+		}
+		sort.Ints(order)
+		for _, j := range order {
+			message.Indices = append(message.Indices, int32(j))
+			message.Data = append(message.Data, row[j])
+		}
+		message.Indptr = append(message.Indptr, int64(len(message.Indices)))
+	}
+	return message
+}
+
+// decayedMatrixFromPB is the inverse of decayedMatrixToPB().
+func decayedMatrixFromPB(message *pb.CouplesDecay) []map[int]float32 {
+	matrix := make([]map[int]float32, 0, len(message.Indptr))
+	for i := 1; i < len(message.Indptr); i++ {
+		row := map[int]float32{}
+		for k := message.Indptr[i-1]; k < message.Indptr[i]; k++ {
+			row[int(message.Indices[k])] = message.Data[k]
+		}
+		matrix = append(matrix, row)
+	}
+	return matrix
+}
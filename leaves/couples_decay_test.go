@@ -0,0 +1,73 @@
+package leaves
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecayedCouplesHalfLife(t *testing.T) {
+	dc := newDecayedCouples(10, 0)
+	dc.add(0, []string{"a", "b"})
+	dc.add(10, []string{"a", "c"})
+	// out of order, e.g. from another branch
+	dc.add(5, []string{"b"})
+	dc.add(20, []string{"c"})
+	matrix := dc.matrix(map[string]int{"a": 0, "b": 1, "c": 2})
+	assert.InDelta(t, matrix[0][0], 0.75, 1e-6)
+	assert.InDelta(t, matrix[0][1], 0.25, 1e-6)
+	assert.InDelta(t, matrix[1][0], 0.25, 1e-6)
+	assert.InDelta(t, matrix[1][1], 0.25+0.3536, 1e-4)
+	assert.InDelta(t, matrix[0][2], 0.5, 1e-6)
+	assert.InDelta(t, matrix[2][2], 1.5, 1e-6)
+	assert.Len(t, matrix[1], 2)
+}
+
+func TestDecayedCouplesWindow(t *testing.T) {
+	dc := newDecayedCouples(0, 10)
+	dc.add(0, []string{"a", "b"})
+	dc.add(5, []string{"a", "c"})
+	dc.add(14, []string{"a"})
+	assert.Len(t, dc.commits, 2)
+	matrix := dc.matrix(map[string]int{"a": 0, "b": 1, "c": 2})
+	assert.Equal(t, matrix[0], map[int]float32{0: 2, 2: 1})
+	assert.Equal(t, matrix[1], map[int]float32{})
+	assert.Equal(t, matrix[2], map[int]float32{0: 1, 2: 1})
+	dc.rename("c", "d")
+	matrix = dc.matrix(map[string]int{"a": 0, "d": 1})
+	assert.Equal(t, matrix[1], map[int]float32{0: 1, 1: 1})
+	dc = newDecayedCouples(5, 10)
+	dc.add(0, []string{"a"})
+	dc.add(5, []string{"a"})
+	dc.add(10, []string{"a"})
+	matrix = dc.matrix(map[string]int{"a": 0})
+	assert.Equal(t, matrix[0], map[int]float32{0: 1.5})
+}
+
+func TestDecayedCouplesRename(t *testing.T) {
+	dc := newDecayedCouples(10, 0)
+	dc.add(0, []string{"a", "b"})
+	dc.add(10, []string{"c", "b"})
+	dc.rename("a", "c")
+	assert.NotContains(t, dc.pairs, "a")
+	assert.NotContains(t, dc.pairs["b"], "a")
+	matrix := dc.matrix(map[string]int{"b": 0, "c": 1})
+	assert.InDelta(t, matrix[0][1], 1.5, 1e-6)
+	assert.InDelta(t, matrix[1][0], 1.5, 1e-6)
+	assert.InDelta(t, matrix[1][1], 1.5, 1e-6)
+	assert.InDelta(t, matrix[0][0], 1.5, 1e-6)
+	// nothing to rename
+	dc.rename("x", "y")
+	assert.NotContains(t, dc.pairs, "y")
+}
+
+func TestDecayedMatrixPB(t *testing.T) {
+	matrix := []map[int]float32{{0: 1, 2: 0.5}, {}, {0: 0.5, 2: 2}}
+	message := decayedMatrixToPB(matrix, 30, 0)
+	assert.Equal(t, message.HalfLife, int32(30))
+	assert.Equal(t, message.Window, int32(0))
+	assert.Equal(t, message.Indptr, []int64{0, 2, 2, 4})
+	assert.Equal(t, message.Indices, []int32{0, 2, 0, 2})
+	assert.Equal(t, message.Data, []float32{1, 0.5, 0.5, 2})
+	assert.Equal(t, decayedMatrixFromPB(message), matrix)
+}
@@ -28,11 +28,46 @@ func TestCouplesMeta(t *testing.T) {
 	c := fixtureCouples()
 	assert.Equal(t, c.Name(), "Couples")
 	assert.Equal(t, len(c.Provides()), 0)
-	assert.Equal(t, len(c.Requires()), 2)
+	assert.Equal(t, len(c.Requires()), 3)
 	assert.Equal(t, c.Requires()[0], identity.DependencyAuthor)
 	assert.Equal(t, c.Requires()[1], plumbing.DependencyTreeChanges)
+	assert.Equal(t, c.Requires()[2], plumbing.DependencyDay)
 	assert.Equal(t, c.Flag(), "couples")
-	assert.Len(t, c.ListConfigurationOptions(), 0)
+	opts := c.ListConfigurationOptions()
+	assert.Len(t, opts, 5)
+	assert.Equal(t, opts[0].Name, ConfigCouplesSignificantOnly)
+	assert.Equal(t, opts[1].Name, ConfigCouplesSignificanceLevel)
+	assert.Equal(t, opts[2].Name, ConfigCouplesDecayHalfLife)
+	assert.Equal(t, opts[3].Name, ConfigCouplesWindow)
+	assert.Equal(t, opts[4].Name, ConfigCouplesDirectoryDepth)
+}
+
+func TestCouplesConfigure(t *testing.T) {
+	c := fixtureCouples()
+	assert.Equal(t, c.SignificanceLevel, DefaultCouplesSignificanceLevel)
+	facts := map[string]interface{}{}
+	facts[ConfigCouplesSignificantOnly] = true
+	facts[ConfigCouplesSignificanceLevel] = float32(0.01)
+	facts[ConfigCouplesDecayHalfLife] = 90
+	facts[ConfigCouplesWindow] = 365
+	facts[ConfigCouplesDirectoryDepth] = 2
+	c.Configure(facts)
+	assert.True(t, c.SignificantOnly)
+	assert.Equal(t, c.SignificanceLevel, float32(0.01))
+	assert.Equal(t, c.DecayHalfLife, 90)
+	assert.Equal(t, c.Window, 365)
+	assert.Equal(t, c.DirectoryDepth, 2)
+	assert.Nil(t, c.decayed)
+	c.Initialize(test.Repository)
+	assert.NotNil(t, c.decayed)
+	c.DecayHalfLife = -1
+	c.Window = -1
+	c.DirectoryDepth = -1
+	c.Initialize(test.Repository)
+	assert.Equal(t, c.DecayHalfLife, 0)
+	assert.Equal(t, c.Window, 0)
+	assert.Equal(t, c.DirectoryDepth, 0)
+	assert.Nil(t, c.decayed)
 }
 
 func TestCouplesRegistration(t *testing.T) {
@@ -187,6 +222,11 @@ func TestCouplesConsumeFinalize(t *testing.T) {
 	assert.Equal(t, cr.FilesMatrix[2][0], int64(1))
 	assert.Equal(t, cr.FilesMatrix[2][1], int64(1))
 	assert.Equal(t, cr.FilesMatrix[2][2], int64(3))
+	assert.Equal(t, cr.CommitsNumber, 4)
+	assert.Len(t, cr.FilesLift, 3)
+	assert.Len(t, cr.FilesChiSquare, 3)
+	assert.Equal(t, cr.FilesLift[0][1], float32(2))
+	assert.Equal(t, cr.FilesChiSquare[0][1], float32(4))
 }
 
 func TestCouplesFork(t *testing.T) {
@@ -361,6 +401,31 @@ func TestCouplesMerge(t *testing.T) {
 	assert.Equal(t, merged.FilesMatrix[2], getCouplesMap(1, 200))
 }
 
+func TestCouplesMergeSignificantOnly(t *testing.T) {
+	r1, r2 := CouplesResult{}, CouplesResult{}
+	r1.Files = []string{"one", "two"}
+	r2.Files = []string{"two", "three"}
+	r1.reversedPeopleDict = r1.Files
+	r2.reversedPeopleDict = r2.Files
+	r1.FilesMatrix = []map[int]int64{{0: 100, 1: 100}, {0: 100, 1: 100}}
+	r2.FilesMatrix = []map[int]int64{{0: 200, 1: 200}, {0: 200, 1: 1300}}
+	r1.CommitsNumber = 1000
+	r2.CommitsNumber = 1000
+	couples := CouplesAnalysis{}
+	merged := couples.MergeResults(r1, r2, nil, nil).(CouplesResult)
+	assert.Equal(t, merged.FilesMatrix[1], getCouplesMap(0, 100, 1, 300, 2, 200))
+	couples.SignificantOnly = true
+	merged = couples.MergeResults(r1, r2, nil, nil).(CouplesResult)
+	assert.Equal(t, merged.Files, []string{"one", "two", "three"})
+	assert.Equal(t, merged.FilesMatrix, []map[int]int64{
+		getCouplesMap(0, 100, 1, 100), getCouplesMap(0, 100, 1, 300), getCouplesMap(2, 1300)})
+	assert.NotContains(t, merged.FilesLift[1], 2)
+	assert.NotContains(t, merged.FilesChiSquare[2], 1)
+	assert.NotContains(t, merged.FilesJaccard[1], 2)
+	assert.NotContains(t, merged.FilesConfidence[2], 1)
+	assert.Contains(t, merged.FilesLift[0], 1)
+}
+
 func TestCouplesCurrentFiles(t *testing.T) {
 	c := fixtureCouples()
 	c.lastCommit, _ = test.Repository.CommitObject(gitplumbing.NewHash(
@@ -421,3 +486,212 @@ func getCouplesMap(vals ...int) map[int]int64 {
 	}
 	return res
 }
+
+func TestCouplesSignificance(t *testing.T) {
+	// 0 and 1 always change together, 2 changes in every commit
+	matrix := []map[int]int64{
+		{0: 5, 1: 5, 2: 5}, {0: 5, 1: 5, 2: 5}, {0: 5, 1: 5, 2: 20, 3: 2}, {2: 2, 3: 2},
+	}
+	lift, chiSquare := calculateCouplesSignificance(matrix, 20)
+	assert.Len(t, lift, 4)
+	assert.Len(t, chiSquare, 4)
+	assert.Equal(t, lift[0][1], float32(4))
+	assert.Equal(t, chiSquare[0][1], float32(20))
+	assert.Equal(t, lift[0][2], float32(1))
+	assert.Equal(t, chiSquare[0][2], float32(0))
+	assert.Equal(t, lift[3][2], float32(1))
+	assert.InDelta(t, chiSquareCriticalValue(0.05), 3.841, 0.001)
+	assert.InDelta(t, chiSquareCriticalValue(0.01), 6.635, 0.001)
+	filterSignificantCouples(matrix, lift, chiSquare, 0.05)
+	assert.Equal(t, matrix[0], map[int]int64{0: 5, 1: 5})
+	assert.Equal(t, matrix[2], map[int]int64{2: 20})
+	assert.Equal(t, matrix[3], map[int]int64{3: 2})
+	assert.Equal(t, lift[0], map[int]float32{0: 4, 1: 4})
+	assert.Len(t, chiSquare[2], 1)
+	lift, chiSquare = calculateCouplesSignificance(matrix, 0)
+	assert.Equal(t, lift[0][1], float32(0))
+	assert.Equal(t, chiSquare[0][1], float32(0))
+}
+
+func TestCouplesSimilarity(t *testing.T) {
+	matrix := []map[int]int64{{0: 4, 1: 2}, {0: 2, 1: 3, 2: 1}, {1: 1, 2: 0}}
+	jaccard, confidence := calculateCouplesSimilarity(matrix)
+	assert.Equal(t, jaccard[0], map[int]float32{0: 1, 1: 0.4})
+	assert.Equal(t, jaccard[1][0], float32(0.4))
+	assert.Equal(t, jaccard[1][2], float32(0.5))
+	assert.Equal(t, jaccard[2][2], float32(0))
+	assert.Equal(t, confidence[0], map[int]float32{0: 1, 1: 0.5})
+	assert.Equal(t, confidence[1][0], float32(2)/3)
+	// the file never changed alone
+	assert.Equal(t, confidence[2][1], float32(0))
+	lift, chiSquare := calculateCouplesSignificance(matrix, 10)
+	filterSignificantCouples(matrix, lift, chiSquare, 0.05, jaccard, confidence)
+	assert.Equal(t, len(jaccard[0]), len(matrix[0]))
+	assert.Equal(t, len(confidence[1]), len(matrix[1]))
+}
+
+func TestCouplesSerializeSimilarity(t *testing.T) {
+	c := fixtureCouples()
+	result := CouplesResult{
+		PeopleMatrix: []map[int]int64{{}},
+		PeopleFiles:  [][]int{{}},
+		FilesMatrix:  []map[int]int64{{0: 2, 1: 2}, {0: 2, 1: 4}},
+		Files:        []string{"one", "two"},
+	}
+	result.FilesLift, result.FilesChiSquare = calculateCouplesSignificance(result.FilesMatrix, 4)
+	result.FilesJaccard, result.FilesConfidence = calculateCouplesSimilarity(result.FilesMatrix)
+	result.CommitsNumber = 4
+	buffer := &bytes.Buffer{}
+	c.Serialize(result, false, buffer)
+	assert.True(t, strings.Contains(buffer.String(), `    jaccard:
+      - {0: 1.0000, 1: 0.5000}
+      - {0: 0.5000, 1: 1.0000}
+    confidence:
+      - {0: 1.0000, 1: 1.0000}
+      - {0: 0.5000, 1: 1.0000}
+  people_coocc:
+`))
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, c.Serialize(result, true, buffer))
+	deserialized, err := c.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	dr := deserialized.(CouplesResult)
+	assert.Equal(t, dr.FilesJaccard, result.FilesJaccard)
+	assert.Equal(t, dr.FilesConfidence, result.FilesConfidence)
+}
+
+func TestCouplesSerializeSignificance(t *testing.T) {
+	c := fixtureCouples()
+	result := CouplesResult{
+		PeopleMatrix: []map[int]int64{{}},
+		PeopleFiles:  [][]int{{}},
+		FilesMatrix:  []map[int]int64{{0: 2, 1: 2}, {0: 2, 1: 3}},
+		Files:        []string{"one", "two"},
+	}
+	result.FilesLift, result.FilesChiSquare = calculateCouplesSignificance(result.FilesMatrix, 4)
+	result.CommitsNumber = 4
+	buffer := &bytes.Buffer{}
+	c.Serialize(result, false, buffer)
+	assert.True(t, strings.Contains(buffer.String(), `    commits: 4
+    lift:
+      - {0: 2.0000, 1: 1.3333}
+      - {0: 1.3333, 1: 1.3333}
+    chi_square:
+      - {0: 4.0000, 1: 1.3333}
+      - {0: 1.3333, 1: 4.0000}
+  people_coocc:
+`))
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, c.Serialize(result, true, buffer))
+	deserialized, err := c.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	dr := deserialized.(CouplesResult)
+	assert.Equal(t, dr.CommitsNumber, 4)
+	assert.Equal(t, dr.FilesLift, result.FilesLift)
+	assert.Equal(t, dr.FilesChiSquare, result.FilesChiSquare)
+}
+
+func TestCouplesSerializeDecayed(t *testing.T) {
+	c := fixtureCouples()
+	result := CouplesResult{
+		PeopleMatrix:       []map[int]int64{{}, {}},
+		PeopleFiles:        [][]int{{}},
+		FilesMatrix:        []map[int]int64{{0: 2, 1: 2}, {0: 2, 1: 3}},
+		Files:              []string{"one", "two"},
+		FilesDecayed:       []map[int]float32{{0: 1.5, 1: 1.5}, {0: 1.5, 1: 1.75}},
+		DecayHalfLife:      30,
+		Window:             90,
+		reversedPeopleDict: []string{"dev"},
+	}
+	buffer := &bytes.Buffer{}
+	c.Serialize(result, false, buffer)
+	assert.True(t, strings.Contains(buffer.String(), `    decay_half_life: 30
+    decay_window: 90
+    decayed:
+      - {0: 1.5000, 1: 1.5000}
+      - {0: 1.5000, 1: 1.7500}
+  people_coocc:
+`))
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, c.Serialize(result, true, buffer))
+	deserialized, err := c.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	dr := deserialized.(CouplesResult)
+	assert.Equal(t, dr.FilesDecayed, result.FilesDecayed)
+	assert.Equal(t, dr.DecayHalfLife, 30)
+	assert.Equal(t, dr.Window, 90)
+	merged := c.MergeResults(result, dr, &core.CommonAnalysisResult{},
+		&core.CommonAnalysisResult{}).(CouplesResult)
+	assert.Equal(t, merged.FilesDecayed, []map[int]float32{{0: 3, 1: 3}, {0: 3, 1: 3.5}})
+	dr.Window = 0
+	merged = c.MergeResults(result, dr, &core.CommonAnalysisResult{},
+		&core.CommonAnalysisResult{}).(CouplesResult)
+	assert.Nil(t, merged.FilesDecayed)
+}
+
+func TestCouplesDirectories(t *testing.T) {
+	c := CouplesAnalysis{PeopleNumber: 1, DirectoryDepth: 1}
+	c.Initialize(test.Repository)
+	deps := map[string]interface{}{}
+	deps[identity.DependencyAuthor] = 0
+	deps[core.DependencyCommit] = &object.Commit{}
+	deps[core.DependencyIsMerge] = false
+	deps[plumbing.DependencyTreeChanges] = generateChanges(
+		"+a/one.go", "+a/b/two.go", "+c/three.go", "+README.md")
+	_, err := c.Consume(deps)
+	assert.Nil(t, err)
+	deps[plumbing.DependencyTreeChanges] = generateChanges("=a/one.go", "-c/three.go")
+	_, err = c.Consume(deps)
+	assert.Nil(t, err)
+	// merges are not counted
+	deps[core.DependencyIsMerge] = true
+	deps[plumbing.DependencyTreeChanges] = generateChanges("=a/one.go", "=README.md")
+	_, err = c.Consume(deps)
+	assert.Nil(t, err)
+	assert.Equal(t, c.directories, map[string]map[string]int{
+		"a": {"a": 2, "c": 1, ".": 1},
+		"c": {"a": 1, "c": 1, ".": 1},
+		".": {"a": 1, "c": 1, ".": 1},
+	})
+	dirs, matrix := c.directoriesMatrix(map[string]map[string]int{
+		"a/one.go": {}, "a/b/two.go": {}, "README.md": {}})
+	assert.Equal(t, dirs, []string{".", "a"})
+	assert.Equal(t, matrix, []map[int]int64{{0: 1, 1: 1}, {0: 1, 1: 2}})
+}
+
+func TestCouplesSerializeDirectories(t *testing.T) {
+	c := fixtureCouples()
+	result := CouplesResult{
+		PeopleMatrix:       []map[int]int64{{}, {}},
+		PeopleFiles:        [][]int{{}},
+		FilesMatrix:        []map[int]int64{{0: 1}},
+		Files:              []string{"a/one.go"},
+		Directories:        []string{".", "a"},
+		DirectoriesMatrix:  []map[int]int64{{0: 1, 1: 1}, {0: 1, 1: 2}},
+		reversedPeopleDict: []string{"dev"},
+	}
+	buffer := &bytes.Buffer{}
+	c.Serialize(result, false, buffer)
+	assert.True(t, strings.Contains(buffer.String(), `  dirs_coocc:
+    index:
+      - "."
+      - "a"
+    matrix:
+      - {0: 1, 1: 1}
+      - {0: 1, 1: 2}
+  people_coocc:
+`))
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, c.Serialize(result, true, buffer))
+	deserialized, err := c.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	dr := deserialized.(CouplesResult)
+	assert.Equal(t, dr.Directories, result.Directories)
+	assert.Equal(t, dr.DirectoriesMatrix, result.DirectoriesMatrix)
+	dr.Directories = []string{"a", "b"}
+	dr.DirectoriesMatrix = []map[int]int64{{0: 3}, {1: 1}}
+	merged := c.MergeResults(result, dr, &core.CommonAnalysisResult{},
+		&core.CommonAnalysisResult{}).(CouplesResult)
+	assert.Equal(t, merged.Directories, []string{".", "a", "b"})
+	assert.Equal(t, merged.DirectoriesMatrix, []map[int]int64{{0: 1, 1: 1}, {0: 1, 1: 5}, {2: 1}})
+}
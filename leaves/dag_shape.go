@@ -0,0 +1,315 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+// DAGShapeAnalysis measures the shape of the commit graph in each tick of Sampling days:
+// the share of the merge commits, the average number of parents, the maximum number of
+// the concurrent branches and the longest linear chain of commits. Those characterize
+// the workflow, e.g. the switch to the trunk-based development is clearly visible.
+// The concurrent branches are the heads of the graph built from the commits seen so far,
+// that is, the commits which do not have children yet.
+type DAGShapeAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the number of days in a tick.
+	Sampling int
+
+	// ticks are the metrics of each tick.
+	ticks []DAGShapeTick
+	// heads are the commits without children.
+	heads map[plumbing.Hash]bool
+	// chains map the commits to the lengths of the linear chains which end at them.
+	chains map[plumbing.Hash]int
+}
+
+// DAGShapeTick is the shape of the commit graph in a tick.
+type DAGShapeTick struct {
+	// Commits is the number of commits, including the merges.
+	Commits int
+	// Merges is the number of merge commits.
+	Merges int
+	// Parents is the total number of parents of the commits.
+	Parents int
+	// MaxBranches is the maximum number of the concurrent branches.
+	MaxBranches int
+	// LongestChain is the length of the longest linear chain which ends in the tick.
+	// A linear chain is a sequence of commits which have exactly one parent except the first.
+	LongestChain int
+}
+
+// DAGShapeResult is returned by DAGShapeAnalysis.Finalize().
+type DAGShapeResult struct {
+	// Ticks are the metrics of each tick of Sampling days.
+	Ticks []DAGShapeTick
+	// Sampling is the effective DAGShapeAnalysis.Sampling.
+	Sampling int
+}
+
+const (
+	// ConfigDAGShapeSampling is the name of the option to set DAGShapeAnalysis.Sampling.
+	ConfigDAGShapeSampling = "DAGShape.Sampling"
+	// DefaultDAGShapeSampling is the default value of DAGShapeAnalysis.Sampling.
+	DefaultDAGShapeSampling = 30
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (shape *DAGShapeAnalysis) Name() string {
+	return "DAGShape"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (shape *DAGShapeAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (shape *DAGShapeAnalysis) Requires() []string {
+	arr := [...]string{items.DependencyDay}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (shape *DAGShapeAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigDAGShapeSampling,
+		Description: "How frequently to record the shape of the commit graph, in days.",
+		Flag:        "dag-shape-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultDAGShapeSampling},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (shape *DAGShapeAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigDAGShapeSampling].(int); exists {
+		shape.Sampling = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (shape *DAGShapeAnalysis) Flag() string {
+	return "dag-shape"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (shape *DAGShapeAnalysis) Description() string {
+	return "Measures the merge density, the concurrent branches, the average number of parents " +
+		"and the longest linear chain of the commit graph over time."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (shape *DAGShapeAnalysis) Initialize(repository *git.Repository) {
+	if shape.Sampling <= 0 {
+		log.Printf("Warning: adjusted the DAG shape sampling to %d days\n", DefaultDAGShapeSampling)
+		shape.Sampling = DefaultDAGShapeSampling
+	}
+	shape.ticks = nil
+	shape.heads = map[plumbing.Hash]bool{}
+	shape.chains = map[plumbing.Hash]int{}
+	shape.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (shape *DAGShapeAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !shape.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	day := deps[items.DependencyDay].(int)
+	for _, parent := range commit.ParentHashes {
+		delete(shape.heads, parent)
+	}
+	shape.heads[commit.Hash] = true
+	chain := 1
+	if len(commit.ParentHashes) == 1 {
+		// the parent is absent if the analysis started in the middle of the history
+		chain += shape.chains[commit.ParentHashes[0]]
+	}
+	shape.chains[commit.Hash] = chain
+	index := day / shape.Sampling
+	for len(shape.ticks) <= index {
+		shape.ticks = append(shape.ticks, DAGShapeTick{})
+	}
+	tick := &shape.ticks[index]
+	tick.Commits++
+	if len(commit.ParentHashes) > 1 {
+		tick.Merges++
+	}
+	tick.Parents += len(commit.ParentHashes)
+	if len(shape.heads) > tick.MaxBranches {
+		tick.MaxBranches = len(shape.heads)
+	}
+	if chain > tick.LongestChain {
+		tick.LongestChain = chain
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (shape *DAGShapeAnalysis) Finalize() interface{} {
+	ticks := make([]DAGShapeTick, len(shape.ticks))
+	copy(ticks, shape.ticks)
+	return DAGShapeResult{Ticks: ticks, Sampling: shape.Sampling}
+}
+
+// MergeDensity returns the share of the merge commits in the tick.
+func (tick DAGShapeTick) MergeDensity() float64 {
+	if tick.Commits == 0 {
+		return 0
+	}
+	return float64(tick.Merges) / float64(tick.Commits)
+}
+
+// MeanParents returns the average number of parents per commit in the tick.
+func (tick DAGShapeTick) MeanParents() float64 {
+	if tick.Commits == 0 {
+		return 0
+	}
+	return float64(tick.Parents) / float64(tick.Commits)
+}
+
+// Fork clones this pipeline item.
+func (shape *DAGShapeAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(shape, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (shape *DAGShapeAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	shapeResult := result.(DAGShapeResult)
+	if binary {
+		return shape.serializeBinary(&shapeResult, writer)
+	}
+	shape.serializeText(&shapeResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (shape *DAGShapeAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(shape, result, &pb.DAGShapeAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (shape *DAGShapeAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(shape, result, &pb.DAGShapeAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to DAGShapeResult.
+func (shape *DAGShapeAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.DAGShapeAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := DAGShapeResult{Sampling: int(message.Sampling)}
+	if len(message.Ticks) > 0 {
+		result.Ticks = make([]DAGShapeTick, len(message.Ticks))
+		for i, tick := range message.Ticks {
+			result.Ticks[i] = DAGShapeTick{
+				Commits:      int(tick.Commits),
+				Merges:       int(tick.Merges),
+				Parents:      int(tick.Parents),
+				MaxBranches:  int(tick.MaxBranches),
+				LongestChain: int(tick.LongestChain),
+			}
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two DAGShapeResult-s together. The ticks are regrouped by
+// the larger sampling; the counts are summed and the maximums are taken.
+func (shape *DAGShapeAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	sr1 := r1.(DAGShapeResult)
+	sr2 := r2.(DAGShapeResult)
+	merged := DAGShapeResult{Sampling: sr1.Sampling}
+	if sr2.Sampling > merged.Sampling {
+		merged.Sampling = sr2.Sampling
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	add := func(result *DAGShapeResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for i, tick := range result.Ticks {
+			index := (i*result.Sampling + offset) / merged.Sampling
+			for len(merged.Ticks) <= index {
+				merged.Ticks = append(merged.Ticks, DAGShapeTick{})
+			}
+			mtick := &merged.Ticks[index]
+			mtick.Commits += tick.Commits
+			mtick.Merges += tick.Merges
+			mtick.Parents += tick.Parents
+			if tick.MaxBranches > mtick.MaxBranches {
+				mtick.MaxBranches = tick.MaxBranches
+			}
+			if tick.LongestChain > mtick.LongestChain {
+				mtick.LongestChain = tick.LongestChain
+			}
+		}
+	}
+	add(&sr1, c1)
+	add(&sr2, c2)
+	return merged
+}
+
+func (shape *DAGShapeAnalysis) serializeText(result *DAGShapeResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  ticks:")
+	for _, tick := range result.Ticks {
+		fmt.Fprintf(writer, "    - {commits: %d, merges: %d, merge_density: %.3f, "+
+			"mean_parents: %.3f, max_branches: %d, longest_chain: %d}\n",
+			tick.Commits, tick.Merges, tick.MergeDensity(), tick.MeanParents(),
+			tick.MaxBranches, tick.LongestChain)
+	}
+}
+
+func (shape *DAGShapeAnalysis) serializeBinary(result *DAGShapeResult, writer io.Writer) error {
+	message := pb.DAGShapeAnalysisResults{
+		Ticks:    make([]*pb.DAGShapeTick, len(result.Ticks)),
+		Sampling: int32(result.Sampling),
+	}
+	for i, tick := range result.Ticks {
+		message.Ticks[i] = &pb.DAGShapeTick{
+			Commits:      int32(tick.Commits),
+			Merges:       int32(tick.Merges),
+			Parents:      int32(tick.Parents),
+			MaxBranches:  int32(tick.MaxBranches),
+			LongestChain: int32(tick.LongestChain),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&DAGShapeAnalysis{})
+}
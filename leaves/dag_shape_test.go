@@ -0,0 +1,153 @@
+package leaves
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func fixtureDAGShape() *DAGShapeAnalysis {
+	shape := DAGShapeAnalysis{}
+	shape.Configure(map[string]interface{}{ConfigDAGShapeSampling: 10})
+	shape.Initialize(nil)
+	return &shape
+}
+
+func TestDAGShapeMeta(t *testing.T) {
+	shape := fixtureDAGShape()
+	assert.Equal(t, shape.Name(), "DAGShape")
+	assert.Len(t, shape.Provides(), 0)
+	assert.Equal(t, shape.Requires(), []string{items.DependencyDay})
+	assert.Equal(t, shape.Flag(), "dag-shape")
+	assert.NotEmpty(t, shape.Description())
+	opts := shape.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Flag, "dag-shape-sampling")
+	assert.Equal(t, shape.Sampling, 10)
+	shape = &DAGShapeAnalysis{}
+	shape.Initialize(nil)
+	assert.Equal(t, shape.Sampling, DefaultDAGShapeSampling)
+	summoned := core.Registry.Summon(shape.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "DAGShape")
+}
+
+func fixtureDAGShapeResult(t *testing.T) DAGShapeResult {
+	shape := fixtureDAGShape()
+	consume := func(hash string, day int, parents ...string) {
+		commit := &object.Commit{Hash: plumbing.NewHash(strings.Repeat(hash, 40))}
+		for _, parent := range parents {
+			commit.ParentHashes = append(commit.ParentHashes,
+				plumbing.NewHash(strings.Repeat(parent, 40)))
+		}
+		result, err := shape.Consume(map[string]interface{}{
+			core.DependencyCommit:  commit,
+			core.DependencyIsMerge: len(parents) > 1,
+			items.DependencyDay:    day,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	consume("0", 0)
+	consume("a", 1, "0")
+	consume("b", 2, "0")
+	consume("c", 3, "a")
+	consume("d", 12, "c", "b")
+	// the merge is consumed in each branch
+	consume("d", 12, "c", "b")
+	consume("e", 13, "d")
+	consume("f", 25, "e")
+	return shape.Finalize().(DAGShapeResult)
+}
+
+func TestDAGShapeConsumeFinalize(t *testing.T) {
+	result := fixtureDAGShapeResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Ticks, []DAGShapeTick{
+		{Commits: 4, Merges: 0, Parents: 3, MaxBranches: 2, LongestChain: 3},
+		{Commits: 2, Merges: 1, Parents: 3, MaxBranches: 1, LongestChain: 2},
+		{Commits: 1, Merges: 0, Parents: 1, MaxBranches: 1, LongestChain: 3},
+	})
+	assert.InDelta(t, result.Ticks[1].MergeDensity(), 0.5, 0.001)
+	assert.InDelta(t, result.Ticks[1].MeanParents(), 1.5, 0.001)
+	assert.Equal(t, DAGShapeTick{}.MergeDensity(), 0.0)
+	assert.Equal(t, DAGShapeTick{}.MeanParents(), 0.0)
+}
+
+func TestDAGShapeSerialize(t *testing.T) {
+	result := fixtureDAGShapeResult(t)
+	shape := fixtureDAGShape()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, shape.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  ticks:
+    - {commits: 4, merges: 0, merge_density: 0.000, mean_parents: 0.750, max_branches: 2, longest_chain: 3}
+    - {commits: 2, merges: 1, merge_density: 0.500, mean_parents: 1.500, max_branches: 1, longest_chain: 2}
+    - {commits: 1, merges: 0, merge_density: 0.000, mean_parents: 1.000, max_branches: 1, longest_chain: 3}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, shape.Serialize(result, true, buffer))
+	msg := pb.DAGShapeAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Len(t, msg.Ticks, 3)
+	assert.Equal(t, msg.Ticks[1].Merges, int32(1))
+	assert.Equal(t, msg.Ticks[2].LongestChain, int32(3))
+	deserialized, err := shape.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestDAGShapeMergeResults(t *testing.T) {
+	r1 := DAGShapeResult{
+		Ticks: []DAGShapeTick{
+			{Commits: 4, Merges: 1, Parents: 4, MaxBranches: 2, LongestChain: 3},
+			{Commits: 2, Merges: 0, Parents: 2, MaxBranches: 1, LongestChain: 5}},
+		Sampling: 10,
+	}
+	r2 := DAGShapeResult{
+		Ticks: []DAGShapeTick{
+			{Commits: 3, Merges: 2, Parents: 5, MaxBranches: 3, LongestChain: 1}},
+		Sampling: 20,
+	}
+	shape := fixtureDAGShape()
+	merged := shape.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 20 * 24 * 3600}).(DAGShapeResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.Ticks, []DAGShapeTick{
+		{Commits: 6, Merges: 1, Parents: 6, MaxBranches: 2, LongestChain: 5},
+		{Commits: 3, Merges: 2, Parents: 5, MaxBranches: 3, LongestChain: 1},
+	})
+}
+
+func TestDAGShapeSerializeJSON(t *testing.T) {
+	result := fixtureDAGShapeResult(t)
+	shape := fixtureDAGShape()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, shape.SerializeJSON(result, buffer))
+	assert.Equal(t, buffer.String(), `{"ticks":[`+
+		`{"commits":4,"merges":0,"parents":3,"max_branches":2,"longest_chain":3},`+
+		`{"commits":2,"merges":1,"parents":3,"max_branches":1,"longest_chain":2},`+
+		`{"commits":1,"merges":0,"parents":1,"max_branches":1,"longest_chain":3}],"sampling":10}`)
+}
+
+func TestDAGShapeExportCSV(t *testing.T) {
+	result := fixtureDAGShapeResult(t)
+	shape := fixtureDAGShape()
+	tables, err := shape.ExportCSV(result)
+	assert.Nil(t, err)
+	assert.Len(t, tables, 2)
+	assert.Equal(t, tables[0].Rows, []core.CSVRow{{Tick: -1, Metric: "sampling", Value: "10"}})
+	assert.Equal(t, tables[1].Name, "ticks")
+	assert.Len(t, tables[1].Rows, 15)
+	assert.Equal(t, tables[1].Rows[7], core.CSVRow{Tick: 1, Metric: "parents", Value: "3"})
+}
@@ -0,0 +1,499 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// DeadCodeAnalysis approximates the unreferenced functions and types. A symbol is declared
+// by a UAST node with the Function and Declaration or the Type and Declaration roles and
+// referenced by any other identifier with the same name anywhere in the repository. The names
+// are not qualified and only the references inside the repository count, so this is a heuristic:
+// a method is alive if anything with the same name is called and an exported API which is only
+// used by other projects is dead. The entry points such as main(), the constructors and the tests
+// are never dead. Every Sampling days, it records the number of the unreferenced symbols and
+// remembers the commits after which the previously referenced symbols became unreferenced.
+// The files which cannot be parsed keep the previous symbols. The merge commits are skipped.
+type DeadCodeAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the size of a tick in days.
+	Sampling int
+
+	files map[string]deadCodeFile
+	// definitions map the declared names to the files and the numbers of the declarations.
+	definitions map[string]map[string]int
+	// references map the names to the number of their references in all the files.
+	references map[string]int
+	// unreferenced is the current number of the unreferenced names.
+	unreferenced int
+	ticks        []DeadCodeTick
+	orphans      []DeadCodeOrphan
+}
+
+// deadCodeFile is the declared and the referenced names of a file with their frequencies.
+type deadCodeFile struct {
+	Definitions map[string]int
+	References  map[string]int
+}
+
+// DeadCodeTick is the evolution of the unreferenced code during a tick.
+type DeadCodeTick struct {
+	// Definitions is the number of the distinct declared names at the end of the tick.
+	Definitions int
+	// Unreferenced is the number of the declared names without references at the end of the tick.
+	Unreferenced int
+	// Orphaned is the number of the names which lost their last reference.
+	Orphaned int
+	// Resolved is the number of the unreferenced names which were referenced again or deleted.
+	Resolved int
+}
+
+// DeadCodeOrphan is the symbol which became unreferenced after a commit.
+type DeadCodeOrphan struct {
+	Hash string
+	// Day is the number of days since the beginning of the history.
+	Day int
+	// File is where the symbol is declared, the first in the alphabetical order if there are
+	// several declarations.
+	File   string
+	Symbol string
+}
+
+// DeadCodeResult is returned by DeadCodeAnalysis.Finalize().
+type DeadCodeResult struct {
+	Ticks []DeadCodeTick
+	// Orphans are sorted by day.
+	Orphans []DeadCodeOrphan
+	// Sampling is the size of a tick in days.
+	Sampling int
+}
+
+const (
+	// ConfigDeadCodeSampling is the name of the option to set DeadCodeAnalysis.Sampling.
+	ConfigDeadCodeSampling = "DeadCode.Sampling"
+	// DefaultDeadCodeSampling is the default value of DeadCodeAnalysis.Sampling.
+	DefaultDeadCodeSampling = 30
+)
+
+// deadCodeEntryPoints are the names which are called by the runtimes and the frameworks.
+var deadCodeEntryPoints = map[string]bool{
+	"main": true, "init": true, "constructor": true, "setUp": true, "tearDown": true,
+	"setUpClass": true, "tearDownClass": true, "setup": true, "teardown": true,
+}
+
+// deadCodeEntryPrefixes are the prefixes of the test functions.
+var deadCodeEntryPrefixes = []string{"Test", "test", "Benchmark", "Example", "Fuzz"}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (analyser *DeadCodeAnalysis) Name() string {
+	return "DeadCode"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (analyser *DeadCodeAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (analyser *DeadCodeAnalysis) Requires() []string {
+	arr := [...]string{uast_items.DependencyUastChanges, items.DependencyDay}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (analyser *DeadCodeAnalysis) Features() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (analyser *DeadCodeAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigDeadCodeSampling,
+		Description: "How frequently to record the number of the unreferenced symbols in days.",
+		Flag:        "dead-code-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultDeadCodeSampling},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (analyser *DeadCodeAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigDeadCodeSampling].(int); exists {
+		analyser.Sampling = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (analyser *DeadCodeAnalysis) Flag() string {
+	return "dead-code"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (analyser *DeadCodeAnalysis) Description() string {
+	return "Approximates the functions and the types which are not referenced anywhere in " +
+		"the repository, tracks their number and the commits which orphaned them."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (analyser *DeadCodeAnalysis) Initialize(repository *git.Repository) {
+	if analyser.Sampling <= 0 {
+		log.Printf("Warning: adjusted the dead code sampling to %d days\n",
+			DefaultDeadCodeSampling)
+		analyser.Sampling = DefaultDeadCodeSampling
+	}
+	analyser.files = map[string]deadCodeFile{}
+	analyser.definitions = map[string]map[string]int{}
+	analyser.references = map[string]int{}
+	analyser.unreferenced = 0
+	analyser.ticks = []DeadCodeTick{}
+	analyser.orphans = []DeadCodeOrphan{}
+	analyser.OneShotMergeProcessor.Initialize()
+}
+
+// deadCodeState is the status of a name before the commit.
+type deadCodeState struct {
+	Defined      bool
+	Unreferenced bool
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (analyser *DeadCodeAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !analyser.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	day := deps[items.DependencyDay].(int)
+	tick := day / analyser.Sampling
+	for len(analyser.ticks) <= tick {
+		analyser.ticks = append(analyser.ticks, DeadCodeTick{
+			Definitions: len(analyser.definitions), Unreferenced: analyser.unreferenced})
+	}
+	stats := &analyser.ticks[tick]
+	before := map[string]deadCodeState{}
+	touch := func(name string) {
+		if _, exists := before[name]; !exists {
+			before[name] = deadCodeState{
+				Defined:      len(analyser.definitions[name]) > 0,
+				Unreferenced: analyser.isUnreferenced(name),
+			}
+		}
+	}
+	for _, change := range changes {
+		fromName, toName := change.Change.From.Name, change.Change.To.Name
+		old := analyser.files[fromName]
+		delete(analyser.files, fromName)
+		file := old
+		if change.After != nil {
+			file = extractDeadCodeSymbols(change.After)
+		} else if toName == "" {
+			file = deadCodeFile{}
+		}
+		for name, count := range old.Definitions {
+			touch(name)
+			files := analyser.definitions[name]
+			if files[fromName] -= count; files[fromName] <= 0 {
+				delete(files, fromName)
+			}
+			if len(files) == 0 {
+				delete(analyser.definitions, name)
+			}
+		}
+		for name, count := range old.References {
+			touch(name)
+			if analyser.references[name] -= count; analyser.references[name] <= 0 {
+				delete(analyser.references, name)
+			}
+		}
+		for name, count := range file.Definitions {
+			touch(name)
+			files := analyser.definitions[name]
+			if files == nil {
+				files = map[string]int{}
+				analyser.definitions[name] = files
+			}
+			files[toName] += count
+		}
+		for name, count := range file.References {
+			touch(name)
+			analyser.references[name] += count
+		}
+		if len(file.Definitions) > 0 || len(file.References) > 0 {
+			analyser.files[toName] = file
+		}
+	}
+	names := make([]string, 0, len(before))
+	for name := range before {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		state := before[name]
+		unreferenced := analyser.isUnreferenced(name)
+		if state.Unreferenced == unreferenced {
+			continue
+		}
+		if !unreferenced {
+			analyser.unreferenced--
+			stats.Resolved++
+			continue
+		}
+		analyser.unreferenced++
+		if state.Defined {
+			// the declaration existed and was referenced before
+			stats.Orphaned++
+			analyser.orphans = append(analyser.orphans, DeadCodeOrphan{
+				Hash: commit.Hash.String(), Day: day, File: analyser.firstDefinition(name),
+				Symbol: name})
+		}
+	}
+	stats.Definitions = len(analyser.definitions)
+	stats.Unreferenced = analyser.unreferenced
+	return nil, nil
+}
+
+// isUnreferenced returns whether the name is declared, is not an entry point and has
+// no references.
+func (analyser *DeadCodeAnalysis) isUnreferenced(name string) bool {
+	return len(analyser.definitions[name]) > 0 && analyser.references[name] == 0 &&
+		!isDeadCodeEntryPoint(name)
+}
+
+// firstDefinition returns the first file in the alphabetical order which declares the name.
+func (analyser *DeadCodeAnalysis) firstDefinition(name string) string {
+	first := ""
+	for file := range analyser.definitions[name] {
+		if first == "" || file < first {
+			first = file
+		}
+	}
+	return first
+}
+
+// isDeadCodeEntryPoint returns whether the name is called by the runtime or by a framework.
+func isDeadCodeEntryPoint(name string) bool {
+	if deadCodeEntryPoints[name] {
+		return true
+	}
+	if len(name) > 4 && strings.HasPrefix(name, "__") && strings.HasSuffix(name, "__") {
+		return true
+	}
+	for _, prefix := range deadCodeEntryPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// extractDeadCodeSymbols returns the declared function and type names and the other identifiers
+// in the UAST. The identifier which names a declaration is not a reference.
+func extractDeadCodeSymbols(root *uast.Node) deadCodeFile {
+	file := deadCodeFile{Definitions: map[string]int{}, References: map[string]int{}}
+	uast_items.VisitEachNode(root, func(node *uast.Node) {
+		var name string
+		if hasRoles(node, uast.Function, uast.Declaration) {
+			name = complexityFunctionName(node)
+		} else if hasRoles(node, uast.Type, uast.Declaration) {
+			name = apiTypeName(node)
+		}
+		if name != "" {
+			file.Definitions[name]++
+		}
+		if node.Token != "" && hasRoles(node, uast.Identifier) {
+			file.References[node.Token]++
+		}
+	})
+	for name, count := range file.Definitions {
+		if file.References[name] -= count; file.References[name] <= 0 {
+			delete(file.References, name)
+		}
+	}
+	return file
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (analyser *DeadCodeAnalysis) Finalize() interface{} {
+	return DeadCodeResult{
+		Ticks:    analyser.ticks,
+		Orphans:  analyser.orphans,
+		Sampling: analyser.Sampling,
+	}
+}
+
+// Fork clones this pipeline item.
+func (analyser *DeadCodeAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(analyser, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (analyser *DeadCodeAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	deadCodeResult := result.(DeadCodeResult)
+	if binary {
+		return analyser.serializeBinary(&deadCodeResult, writer)
+	}
+	analyser.serializeText(&deadCodeResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (analyser *DeadCodeAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(analyser, result, &pb.DeadCodeAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (analyser *DeadCodeAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(analyser, result, &pb.DeadCodeAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to DeadCodeResult.
+func (analyser *DeadCodeAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.DeadCodeAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := DeadCodeResult{
+		Ticks:    make([]DeadCodeTick, len(message.Ticks)),
+		Orphans:  make([]DeadCodeOrphan, len(message.Orphans)),
+		Sampling: int(message.Sampling),
+	}
+	for i, tick := range message.Ticks {
+		result.Ticks[i] = DeadCodeTick{
+			Definitions: int(tick.Definitions), Unreferenced: int(tick.Unreferenced),
+			Orphaned: int(tick.Orphaned), Resolved: int(tick.Resolved)}
+	}
+	for i, orphan := range message.Orphans {
+		result.Orphans[i] = DeadCodeOrphan{
+			Hash: orphan.Hash, Day: int(orphan.Day), File: orphan.File, Symbol: orphan.Symbol}
+	}
+	return result, nil
+}
+
+// MergeResults combines two DeadCodeResult-s together. The ticks are resampled to
+// the bigger sampling of the two.
+func (analyser *DeadCodeAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	dr1 := r1.(DeadCodeResult)
+	dr2 := r2.(DeadCodeResult)
+	merged := DeadCodeResult{Orphans: []DeadCodeOrphan{}, Sampling: dr1.Sampling}
+	if dr2.Sampling > merged.Sampling {
+		merged.Sampling = dr2.Sampling
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	offsets := [2]int{
+		int((c1.BeginTime - beginTime) / (24 * 3600)),
+		int((c2.BeginTime - beginTime) / (24 * 3600)),
+	}
+	results := [2]*DeadCodeResult{&dr1, &dr2}
+	days := 0
+	for i, result := range results {
+		if end := offsets[i] + len(result.Ticks)*result.Sampling; end > days {
+			days = end
+		}
+	}
+	merged.Ticks = make([]DeadCodeTick, (days+merged.Sampling-1)/merged.Sampling)
+	for i, result := range results {
+		for j, tick := range result.Ticks {
+			// the merged tick which contains the first day of the tick
+			k := (j*result.Sampling + offsets[i]) / merged.Sampling
+			merged.Ticks[k].Orphaned += tick.Orphaned
+			merged.Ticks[k].Resolved += tick.Resolved
+		}
+		// the numbers of the symbols at the end of each merged tick
+		for k := range merged.Ticks {
+			day := (k+1)*merged.Sampling - 1 - offsets[i]
+			if day < 0 || len(result.Ticks) == 0 {
+				continue
+			}
+			index := day / result.Sampling
+			if index >= len(result.Ticks) {
+				index = len(result.Ticks) - 1
+			}
+			merged.Ticks[k].Definitions += result.Ticks[index].Definitions
+			merged.Ticks[k].Unreferenced += result.Ticks[index].Unreferenced
+		}
+		for _, orphan := range result.Orphans {
+			orphan.Day += offsets[i]
+			merged.Orphans = append(merged.Orphans, orphan)
+		}
+	}
+	sort.SliceStable(merged.Orphans, func(i, j int) bool {
+		return merged.Orphans[i].Day < merged.Orphans[j].Day
+	})
+	return merged
+}
+
+func (analyser *DeadCodeAnalysis) serializeText(result *DeadCodeResult, writer io.Writer) {
+	ticks := make([]string, len(result.Ticks))
+	for i, tick := range result.Ticks {
+		ticks[i] = fmt.Sprintf("[%d, %d, %d, %d]", tick.Definitions, tick.Unreferenced,
+			tick.Orphaned, tick.Resolved)
+	}
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  # [definitions, unreferenced, orphaned, resolved]")
+	fmt.Fprintf(writer, "  ticks: [%s]\n", strings.Join(ticks, ", "))
+	fmt.Fprintln(writer, "  orphans:")
+	for _, orphan := range result.Orphans {
+		fmt.Fprintf(writer, "    - hash: %s\n", orphan.Hash)
+		fmt.Fprintf(writer, "      day: %d\n", orphan.Day)
+		fmt.Fprintf(writer, "      file: %s\n", yaml.SafeString(orphan.File))
+		fmt.Fprintf(writer, "      symbol: %s\n", yaml.SafeString(orphan.Symbol))
+	}
+}
+
+func (analyser *DeadCodeAnalysis) serializeBinary(result *DeadCodeResult, writer io.Writer) error {
+	message := pb.DeadCodeAnalysisResults{
+		Sampling: int32(result.Sampling),
+		Ticks:    make([]*pb.DeadCodeTick, len(result.Ticks)),
+		Orphans:  make([]*pb.DeadCodeOrphan, len(result.Orphans)),
+	}
+	for i, tick := range result.Ticks {
+		message.Ticks[i] = &pb.DeadCodeTick{
+			Definitions: int32(tick.Definitions), Unreferenced: int32(tick.Unreferenced),
+			Orphaned: int32(tick.Orphaned), Resolved: int32(tick.Resolved)}
+	}
+	for i, orphan := range result.Orphans {
+		message.Orphans[i] = &pb.DeadCodeOrphan{
+			Hash: orphan.Hash, Day: int32(orphan.Day), File: orphan.File, Symbol: orphan.Symbol}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&DeadCodeAnalysis{})
+}
@@ -0,0 +1,199 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+)
+
+func fixtureDeadCode() *DeadCodeAnalysis {
+	analyser := DeadCodeAnalysis{}
+	analyser.Configure(map[string]interface{}{ConfigDeadCodeSampling: 10})
+	analyser.Initialize(nil)
+	return &analyser
+}
+
+// deadCodeNode builds a file with the function declarations, the type declarations
+// and the references.
+func deadCodeNode(functions, types, references []string) *uast.Node {
+	root := &uast.Node{}
+	for _, name := range functions {
+		root.Children = append(root.Children, &uast.Node{
+			Roles: []uast.Role{uast.Function, uast.Declaration},
+			Children: []*uast.Node{{
+				Roles: []uast.Role{uast.Function, uast.Identifier, uast.Name}, Token: name}},
+		})
+	}
+	for _, name := range types {
+		root.Children = append(root.Children, &uast.Node{
+			Roles:    []uast.Role{uast.Type, uast.Declaration},
+			Children: []*uast.Node{{Roles: []uast.Role{uast.Identifier}, Token: name}},
+		})
+	}
+	for _, name := range references {
+		root.Children = append(root.Children, &uast.Node{
+			Roles: []uast.Role{uast.Call}, Children: []*uast.Node{{
+				Roles: []uast.Role{uast.Identifier}, Token: name}},
+		})
+	}
+	return root
+}
+
+func TestDeadCodeMeta(t *testing.T) {
+	analyser := fixtureDeadCode()
+	assert.Equal(t, analyser.Name(), "DeadCode")
+	assert.Len(t, analyser.Provides(), 0)
+	assert.Equal(t, analyser.Requires(), []string{
+		uast_items.DependencyUastChanges, items.DependencyDay})
+	assert.Equal(t, analyser.Features(), []string{uast_items.FeatureUast})
+	assert.Equal(t, analyser.Flag(), "dead-code")
+	assert.NotEmpty(t, analyser.Description())
+	opts := analyser.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Flag, "dead-code-sampling")
+	assert.Equal(t, analyser.Sampling, 10)
+	analyser = &DeadCodeAnalysis{}
+	analyser.Initialize(nil)
+	assert.Equal(t, analyser.Sampling, DefaultDeadCodeSampling)
+	summoned := core.Registry.Summon(analyser.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "DeadCode")
+}
+
+func TestDeadCodeExtractSymbols(t *testing.T) {
+	file := extractDeadCodeSymbols(deadCodeNode(
+		[]string{"foo", "bar", "foo"}, []string{"Config"}, []string{"foo", "foo", "foo", "bar", "x"}))
+	assert.Equal(t, file.Definitions, map[string]int{"foo": 2, "bar": 1, "Config": 1})
+	assert.Equal(t, file.References, map[string]int{"foo": 3, "bar": 1, "x": 1})
+	for _, name := range []string{"main", "init", "__init__", "TestFoo", "test_foo",
+		"BenchmarkFoo", "setUp"} {
+		assert.True(t, isDeadCodeEntryPoint(name), name)
+	}
+	for _, name := range []string{"foo", "_private", "__x", "Config"} {
+		assert.False(t, isDeadCodeEntryPoint(name), name)
+	}
+}
+
+func fixtureDeadCodeResult(t *testing.T) DeadCodeResult {
+	analyser := fixtureDeadCode()
+	consume := func(day int, hash string, changes ...uast_items.Change) {
+		result, err := analyser.Consume(map[string]interface{}{
+			core.DependencyCommit:            &object.Commit{Hash: plumbing.NewHash(hash)},
+			core.DependencyIsMerge:           false,
+			uast_items.DependencyUastChanges: changes,
+			items.DependencyDay:              day,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	change := func(from, to string, after *uast.Node) uast_items.Change {
+		return uast_items.Change{After: after, Change: &object.Change{
+			From: object.ChangeEntry{Name: from}, To: object.ChangeEntry{Name: to}}}
+	}
+	a := deadCodeNode(
+		[]string{"foo", "bar", "main", "TestFoo"}, []string{"Config"}, []string{"foo"})
+	consume(0, "1000000000000000000000000000000000000000", change("", "a.go", a))
+	consume(3, "2000000000000000000000000000000000000000",
+		change("", "b.go", deadCodeNode(nil, nil, []string{"bar", "Config"})))
+	consume(12, "3000000000000000000000000000000000000000",
+		change("b.go", "b.go", deadCodeNode(nil, nil, []string{"Config"})),
+		// could not be parsed
+		change("", "c.go", nil))
+	consume(14, "4000000000000000000000000000000000000000",
+		change("a.go", "d.go", nil), change("b.go", "", nil))
+	consume(16, "5000000000000000000000000000000000000000",
+		change("d.go", "d.go", deadCodeNode(
+			[]string{"foo", "main", "TestFoo"}, []string{"Config"}, []string{"foo"})))
+	return analyser.Finalize().(DeadCodeResult)
+}
+
+func TestDeadCodeConsumeFinalize(t *testing.T) {
+	result := fixtureDeadCodeResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Ticks, []DeadCodeTick{
+		{Definitions: 5, Unreferenced: 0, Orphaned: 0, Resolved: 2},
+		{Definitions: 4, Unreferenced: 1, Orphaned: 2, Resolved: 1},
+	})
+	assert.Equal(t, result.Orphans, []DeadCodeOrphan{
+		{Hash: "3000000000000000000000000000000000000000", Day: 12, File: "a.go", Symbol: "bar"},
+		{Hash: "4000000000000000000000000000000000000000", Day: 14, File: "d.go", Symbol: "Config"},
+	})
+}
+
+func TestDeadCodeConsumeMerge(t *testing.T) {
+	analyser := fixtureDeadCode()
+	result, err := analyser.Consume(map[string]interface{}{
+		core.DependencyCommit: &object.Commit{
+			ParentHashes: make([]plumbing.Hash, 2)},
+		core.DependencyIsMerge: true,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+	assert.Len(t, analyser.Finalize().(DeadCodeResult).Ticks, 0)
+}
+
+func TestDeadCodeSerialize(t *testing.T) {
+	result := fixtureDeadCodeResult(t)
+	analyser := fixtureDeadCode()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  # [definitions, unreferenced, orphaned, resolved]
+  ticks: [[5, 0, 0, 2], [4, 1, 2, 1]]
+  orphans:
+    - hash: 3000000000000000000000000000000000000000
+      day: 12
+      file: "a.go"
+      symbol: "bar"
+    - hash: 4000000000000000000000000000000000000000
+      day: 14
+      file: "d.go"
+      symbol: "Config"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, true, buffer))
+	msg := pb.DeadCodeAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Len(t, msg.Ticks, 2)
+	assert.Len(t, msg.Orphans, 2)
+	assert.Equal(t, msg.Orphans[1].Symbol, "Config")
+	deserialized, err := analyser.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestDeadCodeMergeResults(t *testing.T) {
+	r1 := DeadCodeResult{
+		Ticks: []DeadCodeTick{
+			{Definitions: 10, Unreferenced: 2, Orphaned: 1},
+			{Definitions: 12, Unreferenced: 1, Resolved: 1}},
+		Orphans:  []DeadCodeOrphan{{Hash: "a", Day: 5, File: "a.go", Symbol: "a"}},
+		Sampling: 10,
+	}
+	r2 := DeadCodeResult{
+		Ticks:    []DeadCodeTick{{Definitions: 3, Unreferenced: 3, Orphaned: 2}},
+		Orphans:  []DeadCodeOrphan{{Hash: "b", Day: 1, File: "b.go", Symbol: "b"}},
+		Sampling: 20,
+	}
+	analyser := fixtureDeadCode()
+	merged := analyser.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 20 * 24 * 3600}).(DeadCodeResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.Ticks, []DeadCodeTick{
+		{Definitions: 12, Unreferenced: 1, Orphaned: 1, Resolved: 1},
+		{Definitions: 15, Unreferenced: 4, Orphaned: 2}})
+	assert.Equal(t, merged.Orphans, []DeadCodeOrphan{
+		{Hash: "a", Day: 5, File: "a.go", Symbol: "a"},
+		{Hash: "b", Day: 21, File: "b.go", Symbol: "b"}})
+}
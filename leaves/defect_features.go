@@ -0,0 +1,600 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// DefectFeaturesAnalysis exports the features which are commonly used to train the defect
+// predictors. Each row of the feature table is a file which was changed in a tick of Sampling
+// days; the columns are the changes during the tick (commits, churn, fixes) and the history
+// of the file up to the end of the tick (authors, age, size, indentation complexity, coupling,
+// past fixes). The fixes are the commits whose messages match any of FixPatterns. The table
+// is columnar so that it loads directly into a data frame. The binary files and the merge
+// commits are skipped.
+type DefectFeaturesAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the number of days in a tick.
+	Sampling int
+	// FixPatterns are the regular expressions which match the messages of the fix commits.
+	FixPatterns []string
+
+	// regexps are the compiled FixPatterns.
+	regexps []*regexp.Regexp
+	// files map the current file names to their histories.
+	files map[string]*defectFeaturesFile
+	// tick is the index of the current tick.
+	tick int
+	// touched map the names of the files changed in the current tick to their histories.
+	touched map[string]*defectFeaturesFile
+	// rows are the feature rows of the finished ticks.
+	rows []defectFeaturesRow
+}
+
+// DefectFeatures is the columnar feature table. All the columns have the same length.
+type DefectFeatures struct {
+	// Tick is the index of the tick.
+	Tick []int
+	// File is the index of the file in DefectFeaturesResult.Files.
+	File []int
+	// Commits is the number of commits which changed the file during the tick.
+	Commits []int
+	// Churn is the number of lines added and removed during the tick.
+	Churn []int
+	// Authors is the number of distinct developers who changed the file up to the end of the tick.
+	Authors []int
+	// Age is the number of days between the creation of the file and its last change in the tick.
+	Age []int
+	// Lines is the number of lines at the end of the tick, 0 if the file was deleted.
+	Lines []int
+	// Complexity is the indentation complexity at the end of the tick, see HotspotsAnalysis.
+	Complexity []int
+	// Coupling is the number of distinct files which were changed together with the file
+	// up to the end of the tick.
+	Coupling []int
+	// PastFixes is the number of fix commits which changed the file before the tick.
+	PastFixes []int
+	// Fixes is the number of fix commits which changed the file during the tick.
+	Fixes []int
+}
+
+// DefectFeaturesResult is returned by DefectFeaturesAnalysis.Finalize().
+type DefectFeaturesResult struct {
+	// Files are the file names referenced by Features.File, sorted.
+	Files []string
+	// Features are sorted by Tick and then by the file name.
+	Features DefectFeatures
+	// Sampling is the effective DefectFeaturesAnalysis.Sampling.
+	Sampling int
+	// FixPatterns are the effective DefectFeaturesAnalysis.FixPatterns.
+	FixPatterns []string
+}
+
+// defectFeaturesFile is the history of a file.
+type defectFeaturesFile struct {
+	created    int
+	authors    map[int]bool
+	coupled    map[string]bool
+	fixes      int
+	lines      int
+	complexity int
+	// the changes in the current tick
+	commits   int
+	churn     int
+	tickFixes int
+	lastDay   int
+}
+
+// defectFeaturesRow is a row of DefectFeatures.
+type defectFeaturesRow struct {
+	tick                         int
+	file                         string
+	commits, churn, authors, age int
+	lines, complexity, coupling  int
+	pastFixes, fixes             int
+}
+
+const (
+	// ConfigDefectFeaturesSampling is the name of the option to set DefectFeaturesAnalysis.Sampling.
+	ConfigDefectFeaturesSampling = "DefectFeatures.Sampling"
+	// ConfigDefectFeaturesFixPatterns is the name of the option to set
+	// DefectFeaturesAnalysis.FixPatterns.
+	ConfigDefectFeaturesFixPatterns = "DefectFeatures.FixPatterns"
+	// DefaultDefectFeaturesSampling is the default value of DefectFeaturesAnalysis.Sampling.
+	DefaultDefectFeaturesSampling = 30
+)
+
+// DefaultDefectFeaturesFixPatterns is the default value of DefectFeaturesAnalysis.FixPatterns:
+// the keywords of the SZZ algorithm.
+var DefaultDefectFeaturesFixPatterns = []string{
+	`(?i)\b(fix(es|ed|ing)?|bugs?|defects?|faults?|crash(es|ed)?|regressions?)\b`,
+}
+
+// defectFeaturesColumns are the names of the columns in the order of DefectFeatures.columns().
+var defectFeaturesColumns = []string{
+	"tick", "file", "commits", "churn", "authors", "age", "lines", "complexity", "coupling",
+	"past_fixes", "fixes"}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (features *DefectFeaturesAnalysis) Name() string {
+	return "DefectFeatures"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (features *DefectFeaturesAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (features *DefectFeaturesAnalysis) Requires() []string {
+	arr := [...]string{
+		identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges,
+		items.DependencyFileDiff, items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (features *DefectFeaturesAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigDefectFeaturesSampling,
+		Description: "The length of a tick in the defect prediction features, in days.",
+		Flag:        "defect-features-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultDefectFeaturesSampling}, {
+		Name:        ConfigDefectFeaturesFixPatterns,
+		Description: "The regular expressions which match the messages of the fix commits.",
+		Flag:        "defect-features-fix-patterns",
+		Type:        core.StringsConfigurationOption,
+		Default:     DefaultDefectFeaturesFixPatterns},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (features *DefectFeaturesAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigDefectFeaturesSampling].(int); exists {
+		features.Sampling = val
+	}
+	if val, exists := facts[ConfigDefectFeaturesFixPatterns].([]string); exists {
+		features.FixPatterns = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (features *DefectFeaturesAnalysis) Flag() string {
+	return "defect-features"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (features *DefectFeaturesAnalysis) Description() string {
+	return "Exports the per-file, per-tick features for the defect prediction: churn, authors, " +
+		"age, complexity, coupling and fixes."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (features *DefectFeaturesAnalysis) Initialize(repository *git.Repository) {
+	if features.Sampling <= 0 {
+		log.Printf("Warning: adjusted the defect features sampling to %d days\n",
+			DefaultDefectFeaturesSampling)
+		features.Sampling = DefaultDefectFeaturesSampling
+	}
+	patterns := make([]string, 0, len(features.FixPatterns))
+	features.regexps = nil
+	for _, pattern := range features.FixPatterns {
+		if pattern = strings.TrimSpace(pattern); pattern == "" {
+			continue
+		}
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Printf("Warning: skipped the fix pattern %s: %v\n", pattern, err)
+			continue
+		}
+		patterns = append(patterns, pattern)
+		features.regexps = append(features.regexps, compiled)
+	}
+	if len(patterns) == 0 {
+		if features.FixPatterns != nil {
+			log.Printf("Warning: adjusted the fix patterns to %s\n",
+				strings.Join(DefaultDefectFeaturesFixPatterns, ","))
+		}
+		patterns = DefaultDefectFeaturesFixPatterns
+		for _, pattern := range patterns {
+			features.regexps = append(features.regexps, regexp.MustCompile(pattern))
+		}
+	}
+	features.FixPatterns = patterns
+	features.files = map[string]*defectFeaturesFile{}
+	features.tick = 0
+	features.touched = map[string]*defectFeaturesFile{}
+	features.rows = nil
+	features.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (features *DefectFeaturesAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !features.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	day := deps[items.DependencyDay].(int)
+	if tick := day / features.Sampling; tick != features.tick {
+		features.rows = append(features.rows, features.pendingRows()...)
+		for _, file := range features.touched {
+			file.fixes += file.tickFixes
+			file.commits, file.churn, file.tickFixes = 0, 0, 0
+		}
+		features.touched = map[string]*defectFeaturesFile{}
+		features.tick = tick
+	}
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	var names []string
+	var changed []*defectFeaturesFile
+	for _, change := range deps[items.DependencyTreeChanges].(object.Changes) {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		file := features.files[change.From.Name]
+		delete(features.files, change.From.Name)
+		if action == merkletrie.Delete {
+			if file == nil {
+				continue
+			}
+			file.churn += file.lines
+			file.lines, file.complexity = 0, 0
+			names = append(names, change.From.Name)
+			changed = append(changed, file)
+			continue
+		}
+		text, err := items.BlobToString(cache[change.To.TreeEntry.Hash])
+		if err != nil {
+			return nil, err
+		}
+		if strings.IndexByte(text, 0) >= 0 {
+			// binary
+			delete(features.touched, change.From.Name)
+			continue
+		}
+		if file == nil {
+			// a new file or used to be binary
+			file = &defectFeaturesFile{
+				created: day, authors: map[int]bool{}, coupled: map[string]bool{}}
+		}
+		file.lines, file.complexity = indentationComplexity(text)
+		if action == merkletrie.Insert {
+			file.churn += file.lines
+		} else {
+			for _, edit := range fileDiffs[change.To.Name].Diffs {
+				// FileDiff encodes each line as a single rune
+				if edit.Type != diffmatchpatch.DiffEqual {
+					file.churn += utf8.RuneCountInString(edit.Text)
+				}
+			}
+		}
+		if change.From.Name != change.To.Name {
+			delete(features.touched, change.From.Name)
+		}
+		features.files[change.To.Name] = file
+		names = append(names, change.To.Name)
+		changed = append(changed, file)
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	fix := features.isFix(deps[core.DependencyCommit].(*object.Commit).Message)
+	for i, file := range changed {
+		file.commits++
+		file.lastDay = day
+		if author != identity.AuthorMissing {
+			file.authors[author] = true
+		}
+		if fix {
+			file.tickFixes++
+		}
+		for j, other := range names {
+			if i != j {
+				file.coupled[other] = true
+			}
+		}
+		features.touched[names[i]] = file
+	}
+	return nil, nil
+}
+
+// isFix indicates whether the commit message matches any of the FixPatterns.
+func (features *DefectFeaturesAnalysis) isFix(message string) bool {
+	for _, re := range features.regexps {
+		if re.MatchString(message) {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingRows returns the feature rows of the files changed in the current tick.
+func (features *DefectFeaturesAnalysis) pendingRows() []defectFeaturesRow {
+	rows := make([]defectFeaturesRow, 0, len(features.touched))
+	for name, file := range features.touched {
+		rows = append(rows, defectFeaturesRow{
+			tick:       features.tick,
+			file:       name,
+			commits:    file.commits,
+			churn:      file.churn,
+			authors:    len(file.authors),
+			age:        file.lastDay - file.created,
+			lines:      file.lines,
+			complexity: file.complexity,
+			coupling:   len(file.coupled),
+			pastFixes:  file.fixes,
+			fixes:      file.tickFixes,
+		})
+	}
+	return rows
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (features *DefectFeaturesAnalysis) Finalize() interface{} {
+	rows := make([]defectFeaturesRow, 0, len(features.rows)+len(features.touched))
+	rows = append(rows, features.rows...)
+	rows = append(rows, features.pendingRows()...)
+	return newDefectFeaturesResult(rows, features.Sampling, features.FixPatterns)
+}
+
+// newDefectFeaturesResult sorts the rows and converts them to the columns.
+func newDefectFeaturesResult(rows []defectFeaturesRow, sampling int, patterns []string) DefectFeaturesResult {
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].tick != rows[j].tick {
+			return rows[i].tick < rows[j].tick
+		}
+		return rows[i].file < rows[j].file
+	})
+	result := DefectFeaturesResult{Sampling: sampling, FixPatterns: patterns}
+	index := map[string]int{}
+	for _, row := range rows {
+		if _, exists := index[row.file]; !exists {
+			index[row.file] = 0
+			result.Files = append(result.Files, row.file)
+		}
+	}
+	sort.Strings(result.Files)
+	for i, file := range result.Files {
+		index[file] = i
+	}
+	columns := result.Features.columns()
+	for _, row := range rows {
+		values := [...]int{row.tick, index[row.file], row.commits, row.churn, row.authors, row.age,
+			row.lines, row.complexity, row.coupling, row.pastFixes, row.fixes}
+		for i, column := range columns {
+			*column = append(*column, values[i])
+		}
+	}
+	return result
+}
+
+// columns returns the pointers to the columns in the order of defectFeaturesColumns.
+func (table *DefectFeatures) columns() []*[]int {
+	return []*[]int{
+		&table.Tick, &table.File, &table.Commits, &table.Churn, &table.Authors, &table.Age,
+		&table.Lines, &table.Complexity, &table.Coupling, &table.PastFixes, &table.Fixes}
+}
+
+// rows converts the columns back to the rows.
+func (result *DefectFeaturesResult) rows() []defectFeaturesRow {
+	table := result.Features
+	rows := make([]defectFeaturesRow, len(table.Tick))
+	for i := range rows {
+		rows[i] = defectFeaturesRow{
+			tick:       table.Tick[i],
+			file:       result.Files[table.File[i]],
+			commits:    table.Commits[i],
+			churn:      table.Churn[i],
+			authors:    table.Authors[i],
+			age:        table.Age[i],
+			lines:      table.Lines[i],
+			complexity: table.Complexity[i],
+			coupling:   table.Coupling[i],
+			pastFixes:  table.PastFixes[i],
+			fixes:      table.Fixes[i],
+		}
+	}
+	return rows
+}
+
+// Fork clones this pipeline item.
+func (features *DefectFeaturesAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(features, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (features *DefectFeaturesAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	featuresResult := result.(DefectFeaturesResult)
+	if binary {
+		return features.serializeBinary(&featuresResult, writer)
+	}
+	features.serializeText(&featuresResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (features *DefectFeaturesAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(features, result, &pb.DefectFeaturesAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (features *DefectFeaturesAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(features, result, &pb.DefectFeaturesAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to DefectFeaturesResult.
+func (features *DefectFeaturesAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.DefectFeaturesAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := DefectFeaturesResult{
+		Files:       message.Files,
+		Sampling:    int(message.Sampling),
+		FixPatterns: message.FixPatterns,
+	}
+	sources := defectFeaturesMessageColumns(&message)
+	for i, column := range result.Features.columns() {
+		values := *sources[i]
+		if len(values) == 0 {
+			continue
+		}
+		*column = make([]int, len(values))
+		for j, val := range values {
+			(*column)[j] = int(val)
+		}
+	}
+	return result, nil
+}
+
+// defectFeaturesMessageColumns returns the pointers to the columns of the message in the order
+// of defectFeaturesColumns.
+func defectFeaturesMessageColumns(message *pb.DefectFeaturesAnalysisResults) []*[]int32 {
+	return []*[]int32{
+		&message.Tick, &message.File, &message.Commits, &message.Churn, &message.Authors,
+		&message.Age, &message.Lines, &message.Complexity, &message.Coupling,
+		&message.PastFixes, &message.Fixes}
+}
+
+// MergeResults combines two DefectFeaturesResult-s together. The ticks are regrouped by
+// the larger sampling. The rows of the same file in the same tick are joined: the changes
+// are summed, the histories are the maximums, the past fixes are the minimum and the size
+// and the complexity are taken from the later row.
+func (features *DefectFeaturesAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	dr1 := r1.(DefectFeaturesResult)
+	dr2 := r2.(DefectFeaturesResult)
+	sampling := dr1.Sampling
+	if dr2.Sampling > sampling {
+		sampling = dr2.Sampling
+	}
+	var patterns []string
+	seen := map[string]bool{}
+	for _, pattern := range append(append([]string{}, dr1.FixPatterns...), dr2.FixPatterns...) {
+		if !seen[pattern] {
+			seen[pattern] = true
+			patterns = append(patterns, pattern)
+		}
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	type rowKey struct {
+		tick int
+		file string
+	}
+	merged := map[rowKey]*defectFeaturesRow{}
+	maxInt := func(a, b int) int {
+		if a > b {
+			return a
+		}
+		return b
+	}
+	add := func(result *DefectFeaturesResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for _, row := range result.rows() {
+			row.tick = (row.tick*result.Sampling + offset) / sampling
+			key := rowKey{row.tick, row.file}
+			target := merged[key]
+			if target == nil {
+				copied := row
+				merged[key] = &copied
+				continue
+			}
+			target.commits += row.commits
+			target.churn += row.churn
+			target.fixes += row.fixes
+			target.authors = maxInt(target.authors, row.authors)
+			target.age = maxInt(target.age, row.age)
+			target.coupling = maxInt(target.coupling, row.coupling)
+			if row.pastFixes < target.pastFixes {
+				target.pastFixes = row.pastFixes
+			}
+			target.lines = row.lines
+			target.complexity = row.complexity
+		}
+	}
+	add(&dr1, c1)
+	add(&dr2, c2)
+	rows := make([]defectFeaturesRow, 0, len(merged))
+	for _, row := range merged {
+		rows = append(rows, *row)
+	}
+	return newDefectFeaturesResult(rows, sampling, patterns)
+}
+
+func (features *DefectFeaturesAnalysis) serializeText(result *DefectFeaturesResult, writer io.Writer) {
+	quote := func(values []string) string {
+		quoted := make([]string, len(values))
+		for i, val := range values {
+			quoted[i] = yaml.SafeString(val)
+		}
+		return strings.Join(quoted, ", ")
+	}
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintf(writer, "  fix_patterns: [%s]\n", quote(result.FixPatterns))
+	fmt.Fprintf(writer, "  files: [%s]\n", quote(result.Files))
+	fmt.Fprintln(writer, "  columns:")
+	for i, column := range result.Features.columns() {
+		fmt.Fprintf(writer, "    %s: [", defectFeaturesColumns[i])
+		writeIntList(writer, *column)
+		fmt.Fprintln(writer, "]")
+	}
+}
+
+func (features *DefectFeaturesAnalysis) serializeBinary(result *DefectFeaturesResult, writer io.Writer) error {
+	message := pb.DefectFeaturesAnalysisResults{
+		Files:       result.Files,
+		Sampling:    int32(result.Sampling),
+		FixPatterns: result.FixPatterns,
+	}
+	targets := defectFeaturesMessageColumns(&message)
+	for i, column := range result.Features.columns() {
+		values := make([]int32, len(*column))
+		for j, val := range *column {
+			values[j] = int32(val)
+		}
+		*targets[i] = values
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&DefectFeaturesAnalysis{})
+}
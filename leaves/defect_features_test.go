@@ -0,0 +1,195 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureDefectFeatures() *DefectFeaturesAnalysis {
+	features := DefectFeaturesAnalysis{}
+	features.Configure(map[string]interface{}{ConfigDefectFeaturesSampling: 10})
+	features.Initialize(nil)
+	return &features
+}
+
+func TestDefectFeaturesMeta(t *testing.T) {
+	features := fixtureDefectFeatures()
+	assert.Equal(t, features.Name(), "DefectFeatures")
+	assert.Len(t, features.Provides(), 0)
+	assert.Equal(t, features.Requires(), []string{
+		identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges,
+		items.DependencyFileDiff, items.DependencyBlobCache})
+	assert.Equal(t, features.Flag(), "defect-features")
+	assert.NotEmpty(t, features.Description())
+	opts := features.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Flag, "defect-features-sampling")
+	assert.Equal(t, opts[1].Flag, "defect-features-fix-patterns")
+	assert.Equal(t, features.Sampling, 10)
+	assert.Equal(t, features.FixPatterns, DefaultDefectFeaturesFixPatterns)
+	features.Configure(map[string]interface{}{
+		ConfigDefectFeaturesFixPatterns: []string{"(", "hotfix"}})
+	features.Initialize(nil)
+	assert.Equal(t, features.FixPatterns, []string{"hotfix"})
+	features = &DefectFeaturesAnalysis{FixPatterns: []string{"("}}
+	features.Initialize(nil)
+	assert.Equal(t, features.Sampling, DefaultDefectFeaturesSampling)
+	assert.Equal(t, features.FixPatterns, DefaultDefectFeaturesFixPatterns)
+	summoned := core.Registry.Summon(features.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "DefectFeatures")
+}
+
+func TestDefectFeaturesIsFix(t *testing.T) {
+	features := fixtureDefectFeatures()
+	assert.True(t, features.isFix("Fixes #12"))
+	assert.True(t, features.isFix("Handle the crash\n\nBug 7"))
+	assert.True(t, features.isFix("Revert the regression"))
+	assert.False(t, features.isFix("Add the prefix option"))
+	assert.False(t, features.isFix("Debugging output"))
+}
+
+func fixtureDefectFeaturesResult(t *testing.T) DefectFeaturesResult {
+	hash, blob := storeExpertiseBlob(t, "func\n\tbody\n")
+	binaryHash, binaryBlob := storeExpertiseBlob(t, "\x00\x01\x02")
+	cache := map[plumbing.Hash]*object.Blob{hash: blob, binaryHash: binaryBlob}
+	entry := func(name string, hash plumbing.Hash) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	diff := func(edit diffmatchpatch.Operation) items.FileDiffData {
+		return items.FileDiffData{Diffs: []diffmatchpatch.Diff{
+			{Type: diffmatchpatch.DiffEqual, Text: "ab"}, {Type: edit, Text: "c"}}}
+	}
+	features := fixtureDefectFeatures()
+	consume := func(author, day int, merge bool, message string, changes object.Changes,
+		fileDiffs map[string]items.FileDiffData) {
+		commit := &object.Commit{Message: message}
+		if merge {
+			commit.Hash = plumbing.NewHash("0123456789012345678901234567890123456789")
+			commit.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		result, err := features.Consume(map[string]interface{}{
+			core.DependencyCommit:       commit,
+			core.DependencyIsMerge:      merge,
+			identity.DependencyAuthor:   author,
+			items.DependencyDay:         day,
+			items.DependencyTreeChanges: changes,
+			items.DependencyFileDiff:    fileDiffs,
+			items.DependencyBlobCache:   cache,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	consume(0, 0, false, "Initial", object.Changes{
+		{To: entry("a.go", hash)}, {To: entry("b.go", hash)}}, nil)
+	consume(1, 2, false, "Fix the crash",
+		object.Changes{{From: entry("a.go", hash), To: entry("a.go", hash)}},
+		map[string]items.FileDiffData{"a.go": diff(diffmatchpatch.DiffInsert)})
+	consume(0, 15, false, "Refactor", object.Changes{
+		{From: entry("a.go", hash), To: entry("a.go", hash)},
+		{From: entry("b.go", hash), To: entry("c.go", hash)},
+		{To: entry("d.png", binaryHash)}},
+		map[string]items.FileDiffData{
+			"a.go": diff(diffmatchpatch.DiffDelete), "c.go": diff(diffmatchpatch.DiffDelete)})
+	consume(1, 16, true, "Merge the bug fix", object.Changes{{To: entry("e.go", hash)}}, nil)
+	consume(identity.AuthorMissing, 17, false, "Remove the bug",
+		object.Changes{{From: entry("c.go", hash)}}, nil)
+	return features.Finalize().(DefectFeaturesResult)
+}
+
+func TestDefectFeaturesConsumeFinalize(t *testing.T) {
+	result := fixtureDefectFeaturesResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.FixPatterns, DefaultDefectFeaturesFixPatterns)
+	assert.Equal(t, result.Files, []string{"a.go", "b.go", "c.go"})
+	assert.Equal(t, result.Features, DefectFeatures{
+		Tick:       []int{0, 0, 1, 1},
+		File:       []int{0, 1, 0, 2},
+		Commits:    []int{2, 1, 1, 2},
+		Churn:      []int{3, 2, 1, 3},
+		Authors:    []int{2, 1, 2, 1},
+		Age:        []int{2, 0, 15, 17},
+		Lines:      []int{2, 2, 2, 0},
+		Complexity: []int{1, 1, 1, 0},
+		Coupling:   []int{1, 1, 2, 1},
+		PastFixes:  []int{0, 0, 1, 0},
+		Fixes:      []int{1, 0, 0, 1},
+	})
+}
+
+func TestDefectFeaturesSerialize(t *testing.T) {
+	result := fixtureDefectFeaturesResult(t)
+	features := fixtureDefectFeatures()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, features.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  fix_patterns: ["(?i)\\b(fix(es|ed|ing)?|bugs?|defects?|faults?|crash(es|ed)?|regressions?)\\b"]
+  files: ["a.go", "b.go", "c.go"]
+  columns:
+    tick: [0, 0, 1, 1]
+    file: [0, 1, 0, 2]
+    commits: [2, 1, 1, 2]
+    churn: [3, 2, 1, 3]
+    authors: [2, 1, 2, 1]
+    age: [2, 0, 15, 17]
+    lines: [2, 2, 2, 0]
+    complexity: [1, 1, 1, 0]
+    coupling: [1, 1, 2, 1]
+    past_fixes: [0, 0, 1, 0]
+    fixes: [1, 0, 0, 1]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, features.Serialize(result, true, buffer))
+	msg := pb.DefectFeaturesAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Files, []string{"a.go", "b.go", "c.go"})
+	assert.Equal(t, msg.Churn, []int32{3, 2, 1, 3})
+	assert.Equal(t, msg.PastFixes, []int32{0, 0, 1, 0})
+	assert.Equal(t, msg.Sampling, int32(10))
+	deserialized, err := features.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestDefectFeaturesMergeResults(t *testing.T) {
+	r1 := DefectFeaturesResult{
+		Files: []string{"a.go"},
+		Features: DefectFeatures{
+			Tick: []int{0, 1}, File: []int{0, 0}, Commits: []int{2, 1}, Churn: []int{10, 5},
+			Authors: []int{1, 2}, Age: []int{3, 12}, Lines: []int{10, 12},
+			Complexity: []int{4, 5}, Coupling: []int{0, 1}, PastFixes: []int{0, 1},
+			Fixes: []int{1, 0}},
+		Sampling:    10,
+		FixPatterns: []string{"fix"},
+	}
+	r2 := DefectFeaturesResult{
+		Files: []string{"b.go"},
+		Features: DefectFeatures{
+			Tick: []int{0}, File: []int{0}, Commits: []int{1}, Churn: []int{7},
+			Authors: []int{1}, Age: []int{0}, Lines: []int{7}, Complexity: []int{2},
+			Coupling: []int{0}, PastFixes: []int{0}, Fixes: []int{0}},
+		Sampling:    20,
+		FixPatterns: []string{"bug", "fix"},
+	}
+	features := fixtureDefectFeatures()
+	merged := features.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 20 * 24 * 3600}).(DefectFeaturesResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.FixPatterns, []string{"fix", "bug"})
+	assert.Equal(t, merged.Files, []string{"a.go", "b.go"})
+	assert.Equal(t, merged.Features, DefectFeatures{
+		Tick: []int{0, 1}, File: []int{0, 1}, Commits: []int{3, 1}, Churn: []int{15, 7},
+		Authors: []int{2, 1}, Age: []int{12, 0}, Lines: []int{12, 7}, Complexity: []int{5, 2},
+		Coupling: []int{1, 0}, PastFixes: []int{0, 0}, Fixes: []int{1, 0}})
+}
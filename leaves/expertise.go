@@ -0,0 +1,496 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// ExpertiseAnalysis calculates the expertise vector of each developer: the shares of their
+// changed lines in each programming language and in each top-level directory. The older
+// changes weigh less: the weight halves every HalfLife days before the last analysed day.
+// The merge commits are skipped.
+type ExpertiseAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// HalfLife is the number of days after which the weight of a change halves.
+	// 0 disables the recency weighting.
+	HalfLife int
+	// PeopleNumber is the number of developers.
+	PeopleNumber int
+
+	// languages is the decayed number of changed lines of each developer in each language.
+	languages []map[string]*decayedLines
+	// directories is the same as languages for the top-level directories.
+	directories []map[string]*decayedLines
+	// lastDay is the latest day index seen so far.
+	lastDay int
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// decayedLines is the number of lines decayed to the specified day.
+type decayedLines struct {
+	Value float64
+	Day   int
+}
+
+// ExpertiseResult is returned by ExpertiseAnalysis.Finalize().
+type ExpertiseResult struct {
+	// HalfLife is the effective ExpertiseAnalysis.HalfLife.
+	HalfLife int
+	// Languages are the sorted programming languages, the columns of PeopleLanguages.
+	Languages []string
+	// Directories are the sorted top-level directories, the columns of PeopleDirectories.
+	// "." stands for the files in the root.
+	Directories []string
+	// PeopleLanguages is the expertise matrix: the share of the weighted changed lines
+	// of each developer in each language. Each row sums to 1 unless the developer changed
+	// nothing. The last row corresponds to the unmatched identities.
+	PeopleLanguages [][]float64
+	// PeopleDirectories is the same as PeopleLanguages for Directories.
+	PeopleDirectories [][]float64
+	// PeopleWeights is the weighted number of changed lines of each developer. It has the same
+	// layout as the rows of PeopleLanguages.
+	PeopleWeights []float64
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigExpertiseHalfLife is the name of the option to set ExpertiseAnalysis.HalfLife.
+	ConfigExpertiseHalfLife = "Expertise.HalfLife"
+	// DefaultExpertiseHalfLife is the default value of ExpertiseAnalysis.HalfLife.
+	DefaultExpertiseHalfLife = 365
+	// otherLanguage denotes the files which are not recognized by enry.
+	otherLanguage = "Other"
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (expertise *ExpertiseAnalysis) Name() string {
+	return "Expertise"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (expertise *ExpertiseAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (expertise *ExpertiseAnalysis) Requires() []string {
+	arr := [...]string{
+		identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges,
+		items.DependencyFileDiff, items.DependencyBlobCache, items.DependencyLanguages}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (expertise *ExpertiseAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigExpertiseHalfLife,
+		Description: "Number of days after which the weight of a change in the expertise " +
+			"vectors halves. 0 weighs all the changes equally.",
+		Flag:    "expertise-half-life",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultExpertiseHalfLife},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (expertise *ExpertiseAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigExpertiseHalfLife].(int); exists {
+		expertise.HalfLife = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		expertise.PeopleNumber = val
+		expertise.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (expertise *ExpertiseAnalysis) Flag() string {
+	return "expertise"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (expertise *ExpertiseAnalysis) Description() string {
+	return "Calculates the expertise vectors of the developers: the recency-weighted shares " +
+		"of their changed lines in each language and each top-level directory."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (expertise *ExpertiseAnalysis) Initialize(repository *git.Repository) {
+	if expertise.HalfLife < 0 {
+		log.Printf("Warning: adjusted the expertise half-life to %d days\n",
+			DefaultExpertiseHalfLife)
+		expertise.HalfLife = DefaultExpertiseHalfLife
+	}
+	expertise.languages = make([]map[string]*decayedLines, expertise.PeopleNumber+1)
+	expertise.directories = make([]map[string]*decayedLines, expertise.PeopleNumber+1)
+	for i := range expertise.languages {
+		expertise.languages[i] = map[string]*decayedLines{}
+		expertise.directories[i] = map[string]*decayedLines{}
+	}
+	expertise.lastDay = 0
+	expertise.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (expertise *ExpertiseAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !expertise.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing || author > expertise.PeopleNumber {
+		author = expertise.PeopleNumber
+	}
+	day := deps[items.DependencyDay].(int)
+	if day > expertise.lastDay {
+		expertise.lastDay = day
+	}
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	languages := deps[items.DependencyLanguages].(map[string]string)
+	for _, change := range deps[items.DependencyTreeChanges].(object.Changes) {
+		name, lines, err := countChangedLines(change, cache, fileDiffs)
+		if err != nil {
+			return nil, err
+		}
+		if lines == 0 {
+			continue
+		}
+		language := languages[name]
+		if language == "" {
+			language = otherLanguage
+		}
+		expertise.add(expertise.languages[author], language, lines, day)
+		expertise.add(expertise.directories[author], expertiseDirectory(name), lines, day)
+	}
+	return nil, nil
+}
+
+// add accumulates the changed lines made on the specified day.
+func (expertise *ExpertiseAnalysis) add(counts map[string]*decayedLines, key string, lines, day int) {
+	addDecayedLines(counts, key, float64(lines), day, expertise.HalfLife)
+}
+
+// addDecayedLines accumulates the value on the specified day under the key, decaying
+// with the given half-life.
+func addDecayedLines(counts map[string]*decayedLines, key string, value float64, day, halfLife int) {
+	counter := counts[key]
+	if counter == nil {
+		counts[key] = &decayedLines{Value: value, Day: day}
+		return
+	}
+	if day >= counter.Day {
+		counter.Value = counter.Value*expertiseDecay(float64(day-counter.Day), halfLife) + value
+		counter.Day = day
+	} else {
+		counter.Value += value * expertiseDecay(float64(counter.Day-day), halfLife)
+	}
+}
+
+// countChangedLines returns the name of the changed file and the number of inserted
+// and deleted lines. The binary files have zero lines.
+func countChangedLines(change *object.Change, cache map[plumbing.Hash]*object.Blob,
+	fileDiffs map[string]items.FileDiffData) (string, int, error) {
+	action, err := change.Action()
+	if err != nil {
+		return "", 0, err
+	}
+	var name string
+	lines := 0
+	switch action {
+	case merkletrie.Insert:
+		name = change.To.Name
+		lines, err = items.CountLines(cache[change.To.TreeEntry.Hash])
+	case merkletrie.Delete:
+		name = change.From.Name
+		lines, err = items.CountLines(cache[change.From.TreeEntry.Hash])
+	case merkletrie.Modify:
+		name = change.To.Name
+		for _, edit := range fileDiffs[name].Diffs {
+			// FileDiff encodes each line as a single rune
+			if edit.Type != diffmatchpatch.DiffEqual {
+				lines += utf8.RuneCountInString(edit.Text)
+			}
+		}
+	}
+	if err != nil {
+		if err.Error() == "binary" {
+			return name, 0, nil
+		}
+		return "", 0, err
+	}
+	return name, lines, nil
+}
+
+// decay returns the weight of a change made `days` ago.
+func (expertise *ExpertiseAnalysis) decay(days int) float64 {
+	return expertiseDecay(float64(days), expertise.HalfLife)
+}
+
+func expertiseDecay(days float64, halfLife int) float64 {
+	if halfLife <= 0 || days <= 0 {
+		return 1
+	}
+	return math.Exp2(-days / float64(halfLife))
+}
+
+// expertiseDirectory returns the top-level directory of the file or "." for the root.
+func expertiseDirectory(name string) string {
+	if pos := strings.IndexByte(name, '/'); pos >= 0 {
+		return name[:pos]
+	}
+	return "."
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (expertise *ExpertiseAnalysis) Finalize() interface{} {
+	collect := func(people []map[string]*decayedLines) []map[string]float64 {
+		result := make([]map[string]float64, len(people))
+		for i, counts := range people {
+			result[i] = map[string]float64{}
+			for key, counter := range counts {
+				result[i][key] = counter.Value * expertise.decay(expertise.lastDay-counter.Day)
+			}
+		}
+		return result
+	}
+	return newExpertiseResult(expertise.HalfLife, collect(expertise.languages),
+		collect(expertise.directories), expertise.reversedPeopleDict)
+}
+
+// newExpertiseResult normalizes the weighted changed lines of each developer.
+func newExpertiseResult(halfLife int, languages, directories []map[string]float64,
+	reversedPeopleDict []string) ExpertiseResult {
+	result := ExpertiseResult{
+		HalfLife:           halfLife,
+		PeopleWeights:      make([]float64, len(languages)),
+		reversedPeopleDict: reversedPeopleDict,
+	}
+	for i, counts := range languages {
+		for _, val := range counts {
+			result.PeopleWeights[i] += val
+		}
+	}
+	matrix := func(people []map[string]float64) ([]string, [][]float64) {
+		keySet := map[string]bool{}
+		for _, counts := range people {
+			for key := range counts {
+				keySet[key] = true
+			}
+		}
+		keys := make([]string, 0, len(keySet))
+		for key := range keySet {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		rows := make([][]float64, len(people))
+		for i, counts := range people {
+			rows[i] = make([]float64, len(keys))
+			if result.PeopleWeights[i] == 0 {
+				continue
+			}
+			for j, key := range keys {
+				rows[i][j] = counts[key] / result.PeopleWeights[i]
+			}
+		}
+		return keys, rows
+	}
+	result.Languages, result.PeopleLanguages = matrix(languages)
+	result.Directories, result.PeopleDirectories = matrix(directories)
+	return result
+}
+
+// Fork clones this pipeline item.
+func (expertise *ExpertiseAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(expertise, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (expertise *ExpertiseAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	expertiseResult := result.(ExpertiseResult)
+	if binary {
+		return expertise.serializeBinary(&expertiseResult, writer)
+	}
+	expertise.serializeText(&expertiseResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (expertise *ExpertiseAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(expertise, result, &pb.ExpertiseAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (expertise *ExpertiseAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(expertise, result, &pb.ExpertiseAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to ExpertiseResult.
+func (expertise *ExpertiseAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.ExpertiseAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	convert := func(vectors []*pb.ExpertiseVector) [][]float64 {
+		rows := make([][]float64, len(vectors))
+		for i, vector := range vectors {
+			rows[i] = make([]float64, len(vector.Shares))
+			copy(rows[i], vector.Shares)
+		}
+		return rows
+	}
+	result := ExpertiseResult{
+		HalfLife:           int(message.HalfLife),
+		Languages:          message.Languages,
+		Directories:        message.Directories,
+		PeopleLanguages:    convert(message.PeopleLanguages),
+		PeopleDirectories:  convert(message.PeopleDirectories),
+		PeopleWeights:      message.PeopleWeights,
+		reversedPeopleDict: message.DevIndex,
+	}
+	return result, nil
+}
+
+// MergeResults combines two ExpertiseResult-s together. The older result is decayed
+// by the time between the ends of the histories with the half-life of the first result.
+func (expertise *ExpertiseAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	er1 := r1.(ExpertiseResult)
+	er2 := r2.(ExpertiseResult)
+	people, reversedPeopleDict := identity.Detector{}.MergeReversedDicts(
+		er1.reversedPeopleDict, er2.reversedPeopleDict)
+	languages := make([]map[string]float64, len(reversedPeopleDict)+1)
+	directories := make([]map[string]float64, len(reversedPeopleDict)+1)
+	for i := range languages {
+		languages[i] = map[string]float64{}
+		directories[i] = map[string]float64{}
+	}
+	endTime := c1.EndTime
+	if c2.EndTime > endTime {
+		endTime = c2.EndTime
+	}
+	add := func(result *ExpertiseResult, c *core.CommonAnalysisResult) {
+		decay := expertiseDecay(float64(endTime-c.EndTime)/(24*3600), er1.HalfLife)
+		for dev, weight := range result.PeopleWeights {
+			index := len(reversedPeopleDict)
+			if dev < len(result.reversedPeopleDict) {
+				index = people[result.reversedPeopleDict[dev]][0]
+			}
+			for i, share := range result.PeopleLanguages[dev] {
+				languages[index][result.Languages[i]] += share * weight * decay
+			}
+			for i, share := range result.PeopleDirectories[dev] {
+				directories[index][result.Directories[i]] += share * weight * decay
+			}
+		}
+	}
+	add(&er1, c1)
+	add(&er2, c2)
+	return newExpertiseResult(er1.HalfLife, languages, directories, reversedPeopleDict)
+}
+
+func (expertise *ExpertiseAnalysis) serializeText(result *ExpertiseResult, writer io.Writer) {
+	writeStrings := func(name string, list []string) {
+		fmt.Fprintf(writer, "  %s: [", name)
+		for i, val := range list {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprint(writer, yaml.SafeString(val))
+		}
+		fmt.Fprintln(writer, "]")
+	}
+	writeFloats := func(list []float64) {
+		fmt.Fprint(writer, "[")
+		for i, val := range list {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprintf(writer, "%.4f", val)
+		}
+		fmt.Fprint(writer, "]")
+	}
+	fmt.Fprintln(writer, "  half_life:", result.HalfLife)
+	writeStrings("languages", result.Languages)
+	writeStrings("directories", result.Directories)
+	fmt.Fprintln(writer, "  people_languages:")
+	for _, row := range result.PeopleLanguages {
+		fmt.Fprint(writer, "  - ")
+		writeFloats(row)
+		fmt.Fprintln(writer)
+	}
+	fmt.Fprintln(writer, "  people_directories:")
+	for _, row := range result.PeopleDirectories {
+		fmt.Fprint(writer, "  - ")
+		writeFloats(row)
+		fmt.Fprintln(writer)
+	}
+	fmt.Fprint(writer, "  people_weights: ")
+	writeFloats(result.PeopleWeights)
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (expertise *ExpertiseAnalysis) serializeBinary(result *ExpertiseResult, writer io.Writer) error {
+	convert := func(rows [][]float64) []*pb.ExpertiseVector {
+		vectors := make([]*pb.ExpertiseVector, len(rows))
+		for i, row := range rows {
+			vectors[i] = &pb.ExpertiseVector{Shares: row}
+		}
+		return vectors
+	}
+	message := pb.ExpertiseAnalysisResults{
+		HalfLife:          int32(result.HalfLife),
+		Languages:         result.Languages,
+		Directories:       result.Directories,
+		PeopleLanguages:   convert(result.PeopleLanguages),
+		PeopleDirectories: convert(result.PeopleDirectories),
+		PeopleWeights:     result.PeopleWeights,
+		DevIndex:          result.reversedPeopleDict,
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&ExpertiseAnalysis{})
+}
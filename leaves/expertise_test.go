@@ -0,0 +1,215 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureExpertise(halfLife int) *ExpertiseAnalysis {
+	expertise := ExpertiseAnalysis{}
+	expertise.Configure(map[string]interface{}{
+		ConfigExpertiseHalfLife:                         halfLife,
+		identity.FactIdentityDetectorPeopleCount:        2,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"alice", "bob"},
+	})
+	expertise.Initialize(nil)
+	return &expertise
+}
+
+func TestExpertiseMeta(t *testing.T) {
+	expertise := fixtureExpertise(10)
+	assert.Equal(t, expertise.Name(), "Expertise")
+	assert.Len(t, expertise.Provides(), 0)
+	assert.Contains(t, expertise.Requires(), identity.DependencyAuthor)
+	assert.Contains(t, expertise.Requires(), items.DependencyFileDiff)
+	assert.Contains(t, expertise.Requires(), items.DependencyBlobCache)
+	assert.Contains(t, expertise.Requires(), items.DependencyLanguages)
+	opts := expertise.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Name, ConfigExpertiseHalfLife)
+	assert.Equal(t, opts[0].Default, DefaultExpertiseHalfLife)
+	assert.Equal(t, expertise.Flag(), "expertise")
+	assert.NotEmpty(t, expertise.Description())
+	assert.Equal(t, expertise.HalfLife, 10)
+	assert.Equal(t, expertise.PeopleNumber, 2)
+	assert.Equal(t, expertise.reversedPeopleDict, []string{"alice", "bob"})
+	expertise = &ExpertiseAnalysis{HalfLife: -1}
+	expertise.Initialize(nil)
+	assert.Equal(t, expertise.HalfLife, DefaultExpertiseHalfLife)
+	summoned := core.Registry.Summon(expertise.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Expertise")
+	matched := false
+	for _, tp := range core.Registry.GetLeaves() {
+		if tp.Flag() == expertise.Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestExpertiseHelpers(t *testing.T) {
+	assert.Equal(t, expertiseDirectory("core/pipeline.go"), "core")
+	assert.Equal(t, expertiseDirectory("a/b/c.py"), "a")
+	assert.Equal(t, expertiseDirectory("README.md"), ".")
+	assert.Equal(t, expertiseDecay(10, 10), 0.5)
+	assert.Equal(t, expertiseDecay(10, 0), 1.0)
+	assert.Equal(t, expertiseDecay(0, 10), 1.0)
+}
+
+func storeExpertiseBlob(t *testing.T, text string) (plumbing.Hash, *object.Blob) {
+	storage := memory.NewStorage()
+	encoded := storage.NewEncodedObject()
+	encoded.SetType(plumbing.BlobObject)
+	writer, _ := encoded.Writer()
+	writer.Write([]byte(text))
+	writer.Close()
+	hash, err := storage.SetEncodedObject(encoded)
+	assert.Nil(t, err)
+	blob, err := object.GetBlob(storage, hash)
+	assert.Nil(t, err)
+	return hash, blob
+}
+
+func TestExpertiseConsumeFinalize(t *testing.T) {
+	hash, blob := storeExpertiseBlob(t, "one\ntwo\nthree\nfour\n")
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	expertise := fixtureExpertise(10)
+	consume := func(author, day int, merge bool, changes object.Changes,
+		fileDiffs map[string]items.FileDiffData) {
+		commit := &object.Commit{}
+		if merge {
+			commit.Hash = plumbing.NewHash("0123456789012345678901234567890123456789")
+			commit.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		deps := map[string]interface{}{
+			identity.DependencyAuthor:   author,
+			items.DependencyDay:         day,
+			items.DependencyBlobCache:   map[plumbing.Hash]*object.Blob{hash: blob},
+			items.DependencyTreeChanges: changes,
+			items.DependencyFileDiff:    fileDiffs,
+			core.DependencyCommit:       commit,
+			core.DependencyIsMerge:      merge,
+		}
+		languages, err := (&items.LanguagesDetection{}).Consume(deps)
+		assert.Nil(t, err)
+		deps[items.DependencyLanguages] = languages[items.DependencyLanguages]
+		result, err := expertise.Consume(deps)
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	// alice adds 4 Go lines in core/ on day 0, they decay to 1 line by day 20
+	consume(0, 0, false, object.Changes{{To: entry("core/main.go")}}, nil)
+	// bob modifies 2 lines of a Python script in the root on day 10
+	consume(1, 10, false, object.Changes{{From: entry("setup.py"), To: entry("setup.py")}},
+		map[string]items.FileDiffData{"setup.py": {Diffs: []diffmatchpatch.Diff{
+			{Type: diffmatchpatch.DiffEqual, Text: "ab"},
+			{Type: diffmatchpatch.DiffDelete, Text: "c"},
+			{Type: diffmatchpatch.DiffInsert, Text: "d"},
+		}}})
+	// alice deletes 4 lines of a Python file in lib/ on day 20
+	consume(0, 20, false, object.Changes{{From: entry("lib/util.py")}}, nil)
+	// the merges are ignored
+	consume(identity.AuthorMissing, 20, true, object.Changes{{To: entry("lib/other.py")}}, nil)
+	result := expertise.Finalize().(ExpertiseResult)
+	assert.Equal(t, result.HalfLife, 10)
+	assert.Equal(t, result.Languages, []string{"Go", "Python"})
+	assert.Equal(t, result.Directories, []string{".", "core", "lib"})
+	assert.Equal(t, result.PeopleWeights, []float64{5, 1, 0})
+	assert.Equal(t, result.PeopleLanguages, [][]float64{{0.2, 0.8}, {0, 1}, {0, 0}})
+	assert.Equal(t, result.PeopleDirectories, [][]float64{{0, 0.2, 0.8}, {1, 0, 0}, {0, 0, 0}})
+	assert.Equal(t, result.reversedPeopleDict, []string{"alice", "bob"})
+}
+
+func TestExpertiseDecayOutOfOrder(t *testing.T) {
+	expertise := fixtureExpertise(10)
+	counts := map[string]*decayedLines{}
+	expertise.add(counts, "Go", 4, 10)
+	expertise.add(counts, "Go", 4, 0)
+	assert.Equal(t, *counts["Go"], decayedLines{Value: 6, Day: 10})
+	expertise.add(counts, "Go", 1, 20)
+	assert.Equal(t, *counts["Go"], decayedLines{Value: 4, Day: 20})
+}
+
+func fixtureExpertiseResult() ExpertiseResult {
+	return ExpertiseResult{
+		HalfLife:           10,
+		Languages:          []string{"Go", "Python"},
+		Directories:        []string{".", "core"},
+		PeopleLanguages:    [][]float64{{0.25, 0.75}, {1, 0}, {0, 0}},
+		PeopleDirectories:  [][]float64{{0.5, 0.5}, {0, 1}, {0, 0}},
+		PeopleWeights:      []float64{8, 2, 0},
+		reversedPeopleDict: []string{"alice", "bob"},
+	}
+}
+
+func TestExpertiseSerialize(t *testing.T) {
+	expertise := fixtureExpertise(10)
+	result := fixtureExpertiseResult()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, expertise.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  half_life: 10
+  languages: ["Go", "Python"]
+  directories: [".", "core"]
+  people_languages:
+  - [0.2500, 0.7500]
+  - [1.0000, 0.0000]
+  - [0.0000, 0.0000]
+  people_directories:
+  - [0.5000, 0.5000]
+  - [0.0000, 1.0000]
+  - [0.0000, 0.0000]
+  people_weights: [8.0000, 2.0000, 0.0000]
+  people:
+  - "alice"
+  - "bob"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, expertise.Serialize(result, true, buffer))
+	msg := pb.ExpertiseAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.PeopleLanguages[0].Shares, []float64{0.25, 0.75})
+	deserialized, err := expertise.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+	_, err = expertise.Deserialize([]byte("garbage"))
+	assert.NotNil(t, err)
+}
+
+func TestExpertiseMergeResults(t *testing.T) {
+	expertise := fixtureExpertise(10)
+	r1 := fixtureExpertiseResult()
+	r2 := ExpertiseResult{
+		HalfLife:           10,
+		Languages:          []string{"Rust"},
+		Directories:        []string{"src"},
+		PeopleLanguages:    [][]float64{{1}, {0}},
+		PeopleDirectories:  [][]float64{{1}, {0}},
+		PeopleWeights:      []float64{4, 0},
+		reversedPeopleDict: []string{"bob"},
+	}
+	c1 := &core.CommonAnalysisResult{EndTime: 1500000000}
+	c2 := &core.CommonAnalysisResult{EndTime: 1500000000 + 10*24*3600}
+	merged := expertise.MergeResults(r1, r2, c1, c2).(ExpertiseResult)
+	assert.Equal(t, merged.reversedPeopleDict, []string{"alice", "bob"})
+	assert.Equal(t, merged.Languages, []string{"Go", "Python", "Rust"})
+	assert.Equal(t, merged.Directories, []string{".", "core", "src"})
+	// the first result is decayed by half
+	assert.Equal(t, merged.PeopleWeights, []float64{4, 5, 0})
+	assert.Equal(t, merged.PeopleLanguages, [][]float64{{0.25, 0.75, 0}, {0.2, 0, 0.8}, {0, 0, 0}})
+	assert.Equal(t, merged.PeopleDirectories, [][]float64{{0.5, 0.5, 0}, {0, 0.2, 0.8}, {0, 0, 0}})
+}
@@ -0,0 +1,441 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// FileAgeAnalysis reports the files in the last analysed tree: when each was created and last
+// modified and who is the most recent of its authors which is still active. A developer is
+// active if they committed during the last InactiveDays of the history. A file is orphaned
+// if all its significant authors - those who changed at least Significance of its lines -
+// are not active. The files follow the renames. The merge commits and the unmatched
+// identities are skipped.
+type FileAgeAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// InactiveDays is the number of days without commits at the end of the history after which
+	// a developer is no longer active.
+	InactiveDays int
+	// Significance is the minimum share of the changed lines of a file which makes
+	// its author significant.
+	Significance float32
+
+	// files map the file names to their history.
+	files map[string]*fileAgeHistory
+	// people are the days of the last commits of each developer.
+	people map[int]int
+	// lastDay is the latest day index seen so far.
+	lastDay int
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// FileAge is the age of a single file.
+type FileAge struct {
+	// Created is the day when the file was created or renamed into the current name
+	// from a file which was not tracked.
+	Created int
+	// Modified is the day of the last change of the file.
+	Modified int
+	// LastActiveAuthor is the active developer who changed the file the most recently;
+	// -1 means none.
+	LastActiveAuthor int
+}
+
+// FileAgeResult is returned by FileAgeAnalysis.Finalize().
+type FileAgeResult struct {
+	// Files map the file names in the last tree to their ages.
+	Files map[string]FileAge
+	// Orphaned are the sorted names of the files whose significant authors are not active.
+	Orphaned []string
+	// LastDay is the last day of the analysed history.
+	LastDay int
+	// InactiveDays is the effective FileAgeAnalysis.InactiveDays.
+	InactiveDays int
+	// Significance is the effective FileAgeAnalysis.Significance.
+	Significance float32
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// fileAgeHistory is what FileAgeAnalysis tracks for each file.
+type fileAgeHistory struct {
+	created  int
+	modified int
+	// authors map the developers to the number of changed lines and the day of the last change.
+	authors map[int]*fileAgeAuthor
+}
+
+type fileAgeAuthor struct {
+	lines   int
+	lastDay int
+}
+
+const (
+	// ConfigFileAgeInactiveDays is the name of the option to set FileAgeAnalysis.InactiveDays.
+	ConfigFileAgeInactiveDays = "FileAge.InactiveDays"
+	// ConfigFileAgeSignificance is the name of the option to set FileAgeAnalysis.Significance.
+	ConfigFileAgeSignificance = "FileAge.Significance"
+	// DefaultFileAgeInactiveDays is the default value of FileAgeAnalysis.InactiveDays.
+	DefaultFileAgeInactiveDays = 90
+	// DefaultFileAgeSignificance is the default value of FileAgeAnalysis.Significance.
+	DefaultFileAgeSignificance = 0.1
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (age *FileAgeAnalysis) Name() string {
+	return "FileAge"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (age *FileAgeAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (age *FileAgeAnalysis) Requires() []string {
+	arr := [...]string{
+		identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges,
+		items.DependencyFileDiff, items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (age *FileAgeAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigFileAgeInactiveDays,
+		Description: "Number of days without commits at the end of the history after which a developer is not active.",
+		Flag:        "file-age-inactive",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultFileAgeInactiveDays}, {
+		Name:        ConfigFileAgeSignificance,
+		Description: "Minimum share of the changed lines of a file which makes its author significant.",
+		Flag:        "file-age-significance",
+		Type:        core.FloatConfigurationOption,
+		Default:     float32(DefaultFileAgeSignificance)},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (age *FileAgeAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigFileAgeInactiveDays].(int); exists {
+		age.InactiveDays = val
+	}
+	if val, exists := facts[ConfigFileAgeSignificance].(float32); exists {
+		age.Significance = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		age.reversedPeopleDict = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (age *FileAgeAnalysis) Flag() string {
+	return "file-age"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (age *FileAgeAnalysis) Description() string {
+	return "Reports when each file in the last tree was created and modified, its last active " +
+		"author and the orphaned files whose significant authors are no longer active."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (age *FileAgeAnalysis) Initialize(repository *git.Repository) {
+	if age.InactiveDays <= 0 {
+		log.Printf("Warning: adjusted the file age inactivity period to %d days\n",
+			DefaultFileAgeInactiveDays)
+		age.InactiveDays = DefaultFileAgeInactiveDays
+	}
+	if age.Significance <= 0 || age.Significance > 1 {
+		log.Printf("Warning: adjusted the file age author significance to %.2f\n",
+			DefaultFileAgeSignificance)
+		age.Significance = DefaultFileAgeSignificance
+	}
+	age.files = map[string]*fileAgeHistory{}
+	age.people = map[int]int{}
+	age.lastDay = 0
+	age.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (age *FileAgeAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !age.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	day := deps[items.DependencyDay].(int)
+	if day > age.lastDay {
+		age.lastDay = day
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author != identity.AuthorMissing {
+		age.people[author] = day
+	}
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	for _, change := range deps[items.DependencyTreeChanges].(object.Changes) {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		if action == merkletrie.Delete {
+			delete(age.files, change.From.Name)
+			continue
+		}
+		file := age.files[change.From.Name]
+		if action == merkletrie.Modify && change.From.Name != change.To.Name {
+			delete(age.files, change.From.Name)
+		}
+		if file == nil {
+			file = &fileAgeHistory{created: day, authors: map[int]*fileAgeAuthor{}}
+		}
+		age.files[change.To.Name] = file
+		file.modified = day
+		if author == identity.AuthorMissing {
+			continue
+		}
+		_, lines, err := countChangedLines(change, cache, fileDiffs)
+		if err != nil {
+			return nil, err
+		}
+		if lines == 0 {
+			// binary files and pure renames
+			lines = 1
+		}
+		stats := file.authors[author]
+		if stats == nil {
+			stats = &fileAgeAuthor{}
+			file.authors[author] = stats
+		}
+		stats.lines += lines
+		stats.lastDay = day
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (age *FileAgeAnalysis) Finalize() interface{} {
+	result := FileAgeResult{
+		Files:              map[string]FileAge{},
+		Orphaned:           []string{},
+		LastDay:            age.lastDay,
+		InactiveDays:       age.InactiveDays,
+		Significance:       age.Significance,
+		reversedPeopleDict: age.reversedPeopleDict,
+	}
+	active := func(dev int) bool {
+		return age.lastDay-age.people[dev] < age.InactiveDays
+	}
+	for name, file := range age.files {
+		info := FileAge{Created: file.created, Modified: file.modified, LastActiveAuthor: -1}
+		total := 0
+		for _, stats := range file.authors {
+			total += stats.lines
+		}
+		significant, orphaned := false, true
+		lastActiveDay := -1
+		for dev, stats := range file.authors {
+			isActive := active(dev)
+			if isActive && (stats.lastDay > lastActiveDay ||
+				stats.lastDay == lastActiveDay && dev < info.LastActiveAuthor) {
+				lastActiveDay = stats.lastDay
+				info.LastActiveAuthor = dev
+			}
+			if float64(stats.lines) >= float64(age.Significance)*float64(total) {
+				significant = true
+				if isActive {
+					orphaned = false
+				}
+			}
+		}
+		if significant && orphaned {
+			result.Orphaned = append(result.Orphaned, name)
+		}
+		result.Files[name] = info
+	}
+	sort.Strings(result.Orphaned)
+	return result
+}
+
+// Fork clones this pipeline item.
+func (age *FileAgeAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(age, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (age *FileAgeAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	ageResult := result.(FileAgeResult)
+	if binary {
+		return age.serializeBinary(&ageResult, writer)
+	}
+	age.serializeText(&ageResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (age *FileAgeAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(age, result, &pb.FileAgeAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (age *FileAgeAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(age, result, &pb.FileAgeAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to FileAgeResult.
+func (age *FileAgeAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.FileAgeAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := FileAgeResult{
+		Files:              map[string]FileAge{},
+		Orphaned:           message.Orphaned,
+		LastDay:            int(message.LastDay),
+		InactiveDays:       int(message.InactiveDays),
+		Significance:       message.Significance,
+		reversedPeopleDict: message.DevIndex,
+	}
+	if result.Orphaned == nil {
+		result.Orphaned = []string{}
+	}
+	for name, file := range message.Files {
+		result.Files[name] = FileAge{
+			Created:          int(file.Created),
+			Modified:         int(file.Modified),
+			LastActiveAuthor: int(file.LastActiveAuthor),
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two FileAgeResult-s together. The repositories are assumed to have
+// different files; if the same name appears in both, the second one wins. The activity
+// of the developers is not evaluated again.
+func (age *FileAgeAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	fr1 := r1.(FileAgeResult)
+	fr2 := r2.(FileAgeResult)
+	merged := FileAgeResult{
+		Files:        map[string]FileAge{},
+		InactiveDays: fr1.InactiveDays,
+		Significance: fr1.Significance,
+	}
+	var people map[string][3]int
+	people, merged.reversedPeopleDict = identity.Detector{}.MergeReversedDicts(
+		fr1.reversedPeopleDict, fr2.reversedPeopleDict)
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	orphaned := map[string]bool{}
+	add := func(result *FileAgeResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		if result.LastDay+offset > merged.LastDay {
+			merged.LastDay = result.LastDay + offset
+		}
+		for name, file := range result.Files {
+			file.Created += offset
+			file.Modified += offset
+			if file.LastActiveAuthor >= 0 && file.LastActiveAuthor < len(result.reversedPeopleDict) {
+				file.LastActiveAuthor = people[result.reversedPeopleDict[file.LastActiveAuthor]][0]
+			} else {
+				file.LastActiveAuthor = -1
+			}
+			merged.Files[name] = file
+			delete(orphaned, name)
+		}
+		for _, name := range result.Orphaned {
+			orphaned[name] = true
+		}
+	}
+	add(&fr1, c1)
+	add(&fr2, c2)
+	merged.Orphaned = make([]string, 0, len(orphaned))
+	for name := range orphaned {
+		merged.Orphaned = append(merged.Orphaned, name)
+	}
+	sort.Strings(merged.Orphaned)
+	return merged
+}
+
+func (age *FileAgeAnalysis) serializeText(result *FileAgeResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  last_day:", result.LastDay)
+	fmt.Fprintln(writer, "  inactive_days:", result.InactiveDays)
+	fmt.Fprintf(writer, "  significance: %.2f\n", result.Significance)
+	names := make([]string, 0, len(result.Files))
+	for name := range result.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(writer, "  # last_active_author -1 means none")
+	fmt.Fprintln(writer, "  files:")
+	for _, name := range names {
+		file := result.Files[name]
+		fmt.Fprintf(writer, "    %s: {created: %d, modified: %d, last_active_author: %d}\n",
+			yaml.SafeString(name), file.Created, file.Modified, file.LastActiveAuthor)
+	}
+	fmt.Fprintln(writer, "  orphaned:")
+	for _, name := range result.Orphaned {
+		fmt.Fprintf(writer, "    - %s\n", yaml.SafeString(name))
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (age *FileAgeAnalysis) serializeBinary(result *FileAgeResult, writer io.Writer) error {
+	message := pb.FileAgeAnalysisResults{
+		Files:        map[string]*pb.FileAge{},
+		Orphaned:     result.Orphaned,
+		LastDay:      int32(result.LastDay),
+		InactiveDays: int32(result.InactiveDays),
+		Significance: result.Significance,
+		DevIndex:     result.reversedPeopleDict,
+	}
+	for name, file := range result.Files {
+		message.Files[name] = &pb.FileAge{
+			Created:          int32(file.Created),
+			Modified:         int32(file.Modified),
+			LastActiveAuthor: int32(file.LastActiveAuthor),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&FileAgeAnalysis{})
+}
@@ -0,0 +1,196 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureFileAge() *FileAgeAnalysis {
+	age := FileAgeAnalysis{}
+	age.Configure(map[string]interface{}{
+		ConfigFileAgeInactiveDays:                       30,
+		ConfigFileAgeSignificance:                       float32(0.25),
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"alice", "bob", "carol"},
+	})
+	age.Initialize(nil)
+	return &age
+}
+
+func TestFileAgeMeta(t *testing.T) {
+	age := fixtureFileAge()
+	assert.Equal(t, age.Name(), "FileAge")
+	assert.Len(t, age.Provides(), 0)
+	assert.Equal(t, age.Requires(), []string{
+		identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges,
+		items.DependencyFileDiff, items.DependencyBlobCache})
+	assert.Equal(t, age.Flag(), "file-age")
+	assert.NotEmpty(t, age.Description())
+	opts := age.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Flag, "file-age-inactive")
+	assert.Equal(t, opts[1].Flag, "file-age-significance")
+	assert.Equal(t, age.InactiveDays, 30)
+	assert.Equal(t, age.Significance, float32(0.25))
+	assert.Equal(t, age.reversedPeopleDict, []string{"alice", "bob", "carol"})
+	age = &FileAgeAnalysis{Significance: 2}
+	age.Initialize(nil)
+	assert.Equal(t, age.InactiveDays, DefaultFileAgeInactiveDays)
+	assert.Equal(t, age.Significance, float32(DefaultFileAgeSignificance))
+	summoned := core.Registry.Summon(age.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "FileAge")
+}
+
+func fixtureFileAgeResult(t *testing.T) FileAgeResult {
+	hash, blob := storeExpertiseBlob(t, "one\ntwo\nthree\nfour\n")
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	insert := func(name string) *object.Change {
+		return &object.Change{To: entry(name)}
+	}
+	modify := func(from, to string) *object.Change {
+		return &object.Change{From: entry(from), To: entry(to)}
+	}
+	age := fixtureFileAge()
+	consume := func(author, day int, merge bool, changes object.Changes,
+		fileDiffs map[string]items.FileDiffData) {
+		commit := &object.Commit{}
+		if merge {
+			commit.Hash = plumbing.NewHash("0123456789012345678901234567890123456789")
+			commit.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		result, err := age.Consume(map[string]interface{}{
+			core.DependencyCommit:       commit,
+			core.DependencyIsMerge:      merge,
+			identity.DependencyAuthor:   author,
+			items.DependencyDay:         day,
+			items.DependencyTreeChanges: changes,
+			items.DependencyFileDiff:    fileDiffs,
+			items.DependencyBlobCache:   map[plumbing.Hash]*object.Blob{hash: blob},
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	diffs := func(name string, edits ...diffmatchpatch.Diff) map[string]items.FileDiffData {
+		return map[string]items.FileDiffData{name: {Diffs: edits}}
+	}
+	consume(0, 0, false, object.Changes{insert("a.go"), insert("b.go")}, nil)
+	consume(1, 1, false, object.Changes{insert("c.go"), insert("x.go")}, nil)
+	consume(1, 5, false, object.Changes{modify("a.go", "a.go")}, diffs("a.go",
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffDelete, Text: "a"},
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffInsert, Text: "b"},
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffEqual, Text: "cde"}))
+	consume(identity.AuthorMissing, 10, false, object.Changes{modify("b.go", "b.go")}, diffs("b.go",
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffInsert, Text: "a"},
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffEqual, Text: "bcd"}))
+	consume(0, 20, false, object.Changes{modify("c.go", "d.go"),
+		{From: entry("x.go")}}, diffs("d.go",
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffEqual, Text: "abcd"}))
+	consume(2, 40, false, object.Changes{insert("e.go")}, nil)
+	consume(2, 50, false, object.Changes{modify("d.go", "d.go")}, diffs("d.go",
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffEqual, Text: "abcd"},
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffInsert, Text: "e"}))
+	consume(2, 60, false, object.Changes{modify("e.go", "e.go")}, diffs("e.go",
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffInsert, Text: "a"}))
+	// the merges are ignored
+	consume(1, 70, true, object.Changes{insert("f.go")}, nil)
+	return age.Finalize().(FileAgeResult)
+}
+
+func TestFileAgeConsumeFinalize(t *testing.T) {
+	result := fixtureFileAgeResult(t)
+	assert.Equal(t, result.LastDay, 60)
+	assert.Equal(t, result.InactiveDays, 30)
+	assert.Equal(t, result.Significance, float32(0.25))
+	assert.Equal(t, result.Files, map[string]FileAge{
+		"a.go": {Created: 0, Modified: 5, LastActiveAuthor: -1},
+		"b.go": {Created: 0, Modified: 10, LastActiveAuthor: -1},
+		// carol changed 1 line of 6 and is not significant
+		"d.go": {Created: 1, Modified: 50, LastActiveAuthor: 2},
+		"e.go": {Created: 40, Modified: 60, LastActiveAuthor: 2},
+	})
+	assert.Equal(t, result.Orphaned, []string{"a.go", "b.go", "d.go"})
+	assert.Equal(t, result.reversedPeopleDict, []string{"alice", "bob", "carol"})
+}
+
+func TestFileAgeSerialize(t *testing.T) {
+	result := fixtureFileAgeResult(t)
+	age := fixtureFileAge()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, age.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  last_day: 60
+  inactive_days: 30
+  significance: 0.25
+  # last_active_author -1 means none
+  files:
+    "a.go": {created: 0, modified: 5, last_active_author: -1}
+    "b.go": {created: 0, modified: 10, last_active_author: -1}
+    "d.go": {created: 1, modified: 50, last_active_author: 2}
+    "e.go": {created: 40, modified: 60, last_active_author: 2}
+  orphaned:
+    - "a.go"
+    - "b.go"
+    - "d.go"
+  people:
+  - "alice"
+  - "bob"
+  - "carol"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, age.Serialize(result, true, buffer))
+	msg := pb.FileAgeAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Files, 4)
+	assert.Equal(t, msg.Files["d.go"].LastActiveAuthor, int32(2))
+	assert.Equal(t, msg.Orphaned, []string{"a.go", "b.go", "d.go"})
+	assert.Equal(t, msg.DevIndex, []string{"alice", "bob", "carol"})
+	deserialized, err := age.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestFileAgeMergeResults(t *testing.T) {
+	r1 := FileAgeResult{
+		Files: map[string]FileAge{
+			"a.go": {Created: 0, Modified: 5, LastActiveAuthor: 0},
+			"b.go": {Created: 1, Modified: 2, LastActiveAuthor: -1}},
+		Orphaned:           []string{"b.go"},
+		LastDay:            5,
+		InactiveDays:       30,
+		Significance:       0.25,
+		reversedPeopleDict: []string{"bob"},
+	}
+	r2 := FileAgeResult{
+		Files: map[string]FileAge{
+			"b.go": {Created: 0, Modified: 3, LastActiveAuthor: 1},
+			"c.go": {Created: 2, Modified: 2, LastActiveAuthor: -1}},
+		Orphaned:           []string{"c.go"},
+		LastDay:            3,
+		InactiveDays:       30,
+		Significance:       0.25,
+		reversedPeopleDict: []string{"carol", "alice"},
+	}
+	age := fixtureFileAge()
+	merged := age.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 10 * 24 * 3600}).(FileAgeResult)
+	assert.Equal(t, merged.reversedPeopleDict, []string{"bob", "carol", "alice"})
+	assert.Equal(t, merged.LastDay, 13)
+	assert.Equal(t, merged.Files, map[string]FileAge{
+		"a.go": {Created: 0, Modified: 5, LastActiveAuthor: 0},
+		"b.go": {Created: 10, Modified: 13, LastActiveAuthor: 2},
+		"c.go": {Created: 12, Modified: 12, LastActiveAuthor: -1}})
+	assert.Equal(t, merged.Orphaned, []string{"c.go"})
+	assert.Equal(t, merged.InactiveDays, 30)
+}
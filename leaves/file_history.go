@@ -128,6 +128,16 @@ func (history *FileHistory) Serialize(result interface{}, binary bool, writer io
 	return nil
 }
 
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (history *FileHistory) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(history, result, &pb.FileHistoryResultMessage{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (history *FileHistory) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(history, result, &pb.FileHistoryResultMessage{})
+}
+
 func (history *FileHistory) serializeText(result *FileHistoryResult, writer io.Writer) {
 	keys := make([]string, len(result.Files))
 	i := 0
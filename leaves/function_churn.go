@@ -0,0 +1,537 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// FunctionChurnAnalysis attributes the added and removed lines to the enclosing functions
+// and accumulates the daily churn of each function. The functions are found with the same
+// UAST XPath-s as in ShotnessAnalysis. The merge commits are skipped.
+type FunctionChurnAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// XpathStruct and XpathName are copied from ConfigShotnessXpathStruct and
+	// ConfigShotnessXpathName.
+	XpathStruct string
+	XpathName   string
+	// LanguageXpathStruct and LanguageXpathName are copied from
+	// ConfigShotnessLanguageXpathStruct and ConfigShotnessLanguageXpathName.
+	LanguageXpathStruct map[string]string
+	LanguageXpathName   map[string]string
+	// IdentityThreshold is copied from ConfigShotnessIdentityThreshold.
+	IdentityThreshold int
+
+	// functions map NodeSummary.String() to the churn of the function.
+	functions map[string]*FunctionChurn
+	// files map the file names to the keys in `functions`.
+	files map[string]map[string]bool
+}
+
+// FunctionChurn is the churn of a single function.
+type FunctionChurn struct {
+	NodeSummary
+	// Commits is the number of the commits which changed the function.
+	Commits int
+	// Days maps the day index to the churn on that day.
+	Days map[int]FunctionChurnDay
+}
+
+// FunctionChurnDay is the churn of a function on a single day.
+type FunctionChurnDay struct {
+	Added   int
+	Removed int
+}
+
+// FunctionChurnResult is returned by FunctionChurnAnalysis.Finalize().
+type FunctionChurnResult struct {
+	// Functions are sorted by the total number of the changed lines in descending order.
+	Functions []FunctionChurn
+}
+
+// Lines returns the total number of the added and the removed lines.
+func (churn FunctionChurn) Lines() (added int, removed int) {
+	for _, day := range churn.Days {
+		added += day.Added
+		removed += day.Removed
+	}
+	return
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (churn *FunctionChurnAnalysis) Name() string {
+	return "FunctionChurn"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (churn *FunctionChurnAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (churn *FunctionChurnAnalysis) Requires() []string {
+	arr := [...]string{
+		items.DependencyFileDiff, uast_items.DependencyUastChanges, items.DependencyDay}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (churn *FunctionChurnAnalysis) Features() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (churn *FunctionChurnAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (churn *FunctionChurnAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigShotnessXpathStruct].(string); exists {
+		churn.XpathStruct = val
+	}
+	if val, exists := facts[ConfigShotnessXpathName].(string); exists {
+		churn.XpathName = val
+	}
+	if val, exists := facts[ConfigShotnessLanguageXpathStruct].([]string); exists {
+		churn.LanguageXpathStruct = parseLanguageXpaths(val)
+	}
+	if val, exists := facts[ConfigShotnessLanguageXpathName].([]string); exists {
+		churn.LanguageXpathName = parseLanguageXpaths(val)
+	}
+	if val, exists := facts[ConfigShotnessIdentityThreshold].(int); exists {
+		churn.IdentityThreshold = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (churn *FunctionChurnAnalysis) Flag() string {
+	return "function-churn"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (churn *FunctionChurnAnalysis) Description() string {
+	return "Attributes the daily added and removed lines to the enclosing functions. " +
+		"The functions are selected with --shotness-xpath-struct and --shotness-xpath-name."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (churn *FunctionChurnAnalysis) Initialize(repository *git.Repository) {
+	if churn.XpathStruct == "" {
+		churn.XpathStruct = DefaultShotnessXpathStruct
+	}
+	if churn.XpathName == "" {
+		churn.XpathName = DefaultShotnessXpathName
+	}
+	if churn.IdentityThreshold < 0 || churn.IdentityThreshold > 100 {
+		log.Printf("Warning: adjusted the function churn identity threshold to %d\n",
+			DefaultShotnessIdentityThreshold)
+		churn.IdentityThreshold = DefaultShotnessIdentityThreshold
+	}
+	churn.functions = map[string]*FunctionChurn{}
+	churn.files = map[string]map[string]bool{}
+	churn.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (churn *FunctionChurnAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !churn.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	diffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	day := deps[items.DependencyDay].(int)
+	extractor := ShotnessAnalysis{
+		XpathStruct: churn.XpathStruct, XpathName: churn.XpathName,
+		LanguageXpathStruct: churn.LanguageXpathStruct, LanguageXpathName: churn.LanguageXpathName}
+	extract := func(node *uast.Node, name string) map[string]*uast.Node {
+		if node == nil {
+			return nil
+		}
+		nodes, err := extractor.extractNodes(node, name)
+		if err != nil {
+			log.Printf("FunctionChurn: commit %s file %s failed to filter UAST: %s\n",
+				commit.Hash.String(), name, err.Error())
+			return nil
+		}
+		return nodes
+	}
+	// the functions which disappeared and appeared in this commit, to track their identity
+	var vanished, appeared []nodeIdentity
+	touched := map[string]bool{}
+	for _, change := range changes {
+		fromName, toName := change.Change.From.Name, change.Change.To.Name
+		if fromName != "" && toName != "" && fromName != toName {
+			churn.rename(fromName, toName)
+		}
+		if toName == "" {
+			toName = fromName
+		}
+		nodesBefore := extract(change.Before, fromName)
+		nodesAfter := extract(change.After, toName)
+		var diff *items.FileDiffData
+		if change.Before != nil && change.After != nil {
+			fileDiff, exists := diffs[toName]
+			if !exists {
+				continue
+			}
+			diff = &fileDiff
+		}
+		for key := range churn.attribute(day, toName, nodesBefore, nodesAfter, diff) {
+			touched[key] = true
+		}
+		if churn.IdentityThreshold > 0 {
+			vanished = appendNodeIdentities(vanished, nodesBefore, nodesAfter, toName)
+			appeared = appendNodeIdentities(appeared, nodesAfter, nodesBefore, toName)
+		}
+	}
+	identities := matchNodeIdentities(vanished, appeared, churn.IdentityThreshold)
+	for _, oldKey := range sortedNodeIdentities(identities) {
+		churn.inherit(oldKey, identities[oldKey], touched)
+	}
+	return nil, nil
+}
+
+// attribute adds the changed lines of the file to the enclosing functions. If `diff` is nil,
+// the functions in `before` are entirely removed and those in `after` are entirely added.
+// Returns the keys of the changed functions.
+func (churn *FunctionChurnAnalysis) attribute(
+	day int, file string, before, after map[string]*uast.Node,
+	diff *items.FileDiffData) map[string]bool {
+	changed := map[string]FunctionChurnDay{}
+	count := func(names map[*uast.Node]string, lines [][]*uast.Node, line int, added bool) {
+		if line >= len(lines) {
+			return
+		}
+		for _, node := range lines[line] {
+			delta := changed[names[node]]
+			if added {
+				delta.Added++
+			} else {
+				delta.Removed++
+			}
+			changed[names[node]] = delta
+		}
+	}
+	if diff == nil {
+		for name, node := range before {
+			if node.StartPosition != nil {
+				start, end := nodeLines(node)
+				delta := changed[name]
+				delta.Removed += int(end - start + 1)
+				changed[name] = delta
+			}
+		}
+		for name, node := range after {
+			if node.StartPosition != nil {
+				start, end := nodeLines(node)
+				delta := changed[name]
+				delta.Added += int(end - start + 1)
+				changed[name] = delta
+			}
+		}
+	} else {
+		linesBefore := nodesByLine(before, diff.OldLinesOfCode)
+		linesAfter := nodesByLine(after, diff.NewLinesOfCode)
+		namesBefore, namesAfter := reverseNodeMap(before), reverseNodeMap(after)
+		var lineBefore, lineAfter int
+		for _, edit := range diff.Diffs {
+			// FileDiff encodes each line as a single rune
+			size := utf8.RuneCountInString(edit.Text)
+			switch edit.Type {
+			case diffmatchpatch.DiffDelete:
+				for l := lineBefore; l < lineBefore+size; l++ {
+					count(namesBefore, linesBefore, l, false)
+				}
+				lineBefore += size
+			case diffmatchpatch.DiffInsert:
+				for l := lineAfter; l < lineAfter+size; l++ {
+					count(namesAfter, linesAfter, l, true)
+				}
+				lineAfter += size
+			case diffmatchpatch.DiffEqual:
+				lineBefore += size
+				lineAfter += size
+			}
+		}
+	}
+	keys := map[string]bool{}
+	for name, delta := range changed {
+		node := after[name]
+		if node == nil {
+			node = before[name]
+		}
+		summary := newNodeSummary(name, node, file)
+		key := summary.String()
+		keys[key] = true
+		function := churn.functions[key]
+		if function == nil {
+			function = &FunctionChurn{NodeSummary: summary, Days: map[int]FunctionChurnDay{}}
+			churn.add(key, function)
+		}
+		function.Commits++
+		dayChurn := function.Days[day]
+		dayChurn.Added += delta.Added
+		dayChurn.Removed += delta.Removed
+		function.Days[day] = dayChurn
+	}
+	return keys
+}
+
+// rename moves the functions of the renamed file so that their history continues.
+func (churn *FunctionChurnAnalysis) rename(from, to string) {
+	keys := churn.files[from]
+	delete(churn.files, from)
+	for key := range keys {
+		function := churn.functions[key]
+		delete(churn.functions, key)
+		function.File = to
+		newKey := function.String()
+		if existing := churn.functions[newKey]; existing != nil {
+			existing.absorb(function)
+			continue
+		}
+		churn.add(newKey, function)
+	}
+}
+
+// inherit transfers the history of the function which was renamed or moved to its new summary.
+// `touched` are the functions changed in the current commit.
+func (churn *FunctionChurnAnalysis) inherit(oldKey string, summary NodeSummary, touched map[string]bool) {
+	function := churn.functions[oldKey]
+	newKey := summary.String()
+	if function == nil || oldKey == newKey {
+		return
+	}
+	delete(churn.functions, oldKey)
+	delete(churn.files[function.File], oldKey)
+	if existing := churn.functions[newKey]; existing != nil {
+		existing.absorb(function)
+		if touched[oldKey] && touched[newKey] {
+			// the same function cannot be changed twice in a commit
+			existing.Commits--
+		}
+		return
+	}
+	function.NodeSummary = summary
+	churn.add(newKey, function)
+}
+
+// add registers the function under the specified key.
+func (churn *FunctionChurnAnalysis) add(key string, function *FunctionChurn) {
+	churn.functions[key] = function
+	fileFunctions := churn.files[function.File]
+	if fileFunctions == nil {
+		fileFunctions = map[string]bool{}
+		churn.files[function.File] = fileFunctions
+	}
+	fileFunctions[key] = true
+}
+
+// absorb adds the commits and the churn of the other function.
+func (function *FunctionChurn) absorb(other *FunctionChurn) {
+	function.Commits += other.Commits
+	for day, dayChurn := range other.Days {
+		existingDay := function.Days[day]
+		existingDay.Added += dayChurn.Added
+		existingDay.Removed += dayChurn.Removed
+		function.Days[day] = existingDay
+	}
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (churn *FunctionChurnAnalysis) Finalize() interface{} {
+	functions := make([]FunctionChurn, 0, len(churn.functions))
+	for _, function := range churn.functions {
+		functions = append(functions, *function)
+	}
+	sortFunctionChurn(functions)
+	return FunctionChurnResult{Functions: functions}
+}
+
+// sortFunctionChurn orders the functions by the total churn in descending order.
+func sortFunctionChurn(functions []FunctionChurn) {
+	totals := make(map[string]int, len(functions))
+	for _, function := range functions {
+		added, removed := function.Lines()
+		totals[function.String()] = added + removed
+	}
+	sort.Slice(functions, func(i, j int) bool {
+		ti, tj := totals[functions[i].String()], totals[functions[j].String()]
+		if ti != tj {
+			return ti > tj
+		}
+		return functions[i].String() < functions[j].String()
+	})
+}
+
+// Fork clones this pipeline item.
+func (churn *FunctionChurnAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(churn, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (churn *FunctionChurnAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	churnResult := result.(FunctionChurnResult)
+	if binary {
+		return churn.serializeBinary(&churnResult, writer)
+	}
+	churn.serializeText(&churnResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (churn *FunctionChurnAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(churn, result, &pb.FunctionChurnAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (churn *FunctionChurnAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(churn, result, &pb.FunctionChurnAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to FunctionChurnResult.
+func (churn *FunctionChurnAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.FunctionChurnAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := FunctionChurnResult{Functions: make([]FunctionChurn, len(message.Functions))}
+	for i, function := range message.Functions {
+		result.Functions[i] = FunctionChurn{
+			NodeSummary: NodeSummary{
+				InternalRole: function.InternalRole,
+				Name:         function.Name,
+				File:         function.File,
+			},
+			Commits: int(function.Commits),
+			Days:    map[int]FunctionChurnDay{},
+		}
+		for day, dayChurn := range function.Days {
+			result.Functions[i].Days[int(day)] = FunctionChurnDay{
+				Added: int(dayChurn.Added), Removed: int(dayChurn.Removed)}
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two FunctionChurnResult-s together.
+func (churn *FunctionChurnAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	cr1 := r1.(FunctionChurnResult)
+	cr2 := r2.(FunctionChurnResult)
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	functions := map[string]*FunctionChurn{}
+	add := func(result *FunctionChurnResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for _, function := range result.Functions {
+			key := function.String()
+			merged := functions[key]
+			if merged == nil {
+				merged = &FunctionChurn{
+					NodeSummary: function.NodeSummary, Days: map[int]FunctionChurnDay{}}
+				functions[key] = merged
+			}
+			merged.Commits += function.Commits
+			for day, dayChurn := range function.Days {
+				mergedDay := merged.Days[day+offset]
+				mergedDay.Added += dayChurn.Added
+				mergedDay.Removed += dayChurn.Removed
+				merged.Days[day+offset] = mergedDay
+			}
+		}
+	}
+	add(&cr1, c1)
+	add(&cr2, c2)
+	result := FunctionChurnResult{Functions: make([]FunctionChurn, 0, len(functions))}
+	for _, function := range functions {
+		result.Functions = append(result.Functions, *function)
+	}
+	sortFunctionChurn(result.Functions)
+	return result
+}
+
+func (churn *FunctionChurnAnalysis) serializeText(result *FunctionChurnResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  functions:")
+	for _, function := range result.Functions {
+		added, removed := function.Lines()
+		fmt.Fprintf(writer, "    - name: %s\n", yaml.SafeString(function.Name))
+		fmt.Fprintf(writer, "      file: %s\n", yaml.SafeString(function.File))
+		fmt.Fprintf(writer, "      internal_role: %s\n", function.InternalRole)
+		fmt.Fprintf(writer, "      commits: %d\n", function.Commits)
+		fmt.Fprintf(writer, "      added: %d\n", added)
+		fmt.Fprintf(writer, "      removed: %d\n", removed)
+		days := make([]int, 0, len(function.Days))
+		for day := range function.Days {
+			days = append(days, day)
+		}
+		sort.Ints(days)
+		fmt.Fprint(writer, "      days: {")
+		for i, day := range days {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprintf(writer, "%d: [%d, %d]", day, function.Days[day].Added, function.Days[day].Removed)
+		}
+		fmt.Fprintln(writer, "}")
+	}
+}
+
+func (churn *FunctionChurnAnalysis) serializeBinary(result *FunctionChurnResult, writer io.Writer) error {
+	message := pb.FunctionChurnAnalysisResults{
+		Functions: make([]*pb.FunctionChurn, len(result.Functions)),
+	}
+	for i, function := range result.Functions {
+		message.Functions[i] = &pb.FunctionChurn{
+			Name:         function.Name,
+			File:         function.File,
+			InternalRole: function.InternalRole,
+			Commits:      int32(function.Commits),
+			Days:         map[int32]*pb.FunctionChurnDay{},
+		}
+		for day, dayChurn := range function.Days {
+			message.Functions[i].Days[int32(day)] = &pb.FunctionChurnDay{
+				Added: int32(dayChurn.Added), Removed: int32(dayChurn.Removed)}
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&FunctionChurnAnalysis{})
+}
@@ -0,0 +1,215 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+)
+
+func fixtureFunctionChurn() *FunctionChurnAnalysis {
+	churn := FunctionChurnAnalysis{}
+	churn.Initialize(nil)
+	return &churn
+}
+
+func fixtureFunctionChurnNode(start, end uint32) *uast.Node {
+	return &uast.Node{
+		InternalType:  "FunctionDef",
+		StartPosition: &uast.Position{Line: start},
+		EndPosition:   &uast.Position{Line: end},
+	}
+}
+
+func TestFunctionChurnMeta(t *testing.T) {
+	churn := fixtureFunctionChurn()
+	assert.Equal(t, churn.Name(), "FunctionChurn")
+	assert.Len(t, churn.Provides(), 0)
+	assert.Contains(t, churn.Requires(), items.DependencyFileDiff)
+	assert.Contains(t, churn.Requires(), uast_items.DependencyUastChanges)
+	assert.Contains(t, churn.Requires(), items.DependencyDay)
+	assert.Equal(t, churn.Features(), []string{uast_items.FeatureUast})
+	assert.Len(t, churn.ListConfigurationOptions(), 0)
+	assert.Equal(t, churn.Flag(), "function-churn")
+	assert.NotEmpty(t, churn.Description())
+	assert.Equal(t, churn.XpathStruct, DefaultShotnessXpathStruct)
+	assert.Equal(t, churn.XpathName, DefaultShotnessXpathName)
+	churn.Configure(map[string]interface{}{
+		ConfigShotnessXpathStruct:         "xpath!",
+		ConfigShotnessXpathName:           "another!",
+		ConfigShotnessLanguageXpathStruct: []string{"Java=classes"},
+		ConfigShotnessLanguageXpathName:   []string{"Java=names"},
+		ConfigShotnessIdentityThreshold:   60,
+	})
+	assert.Equal(t, churn.IdentityThreshold, 60)
+	assert.Equal(t, churn.XpathStruct, "xpath!")
+	assert.Equal(t, churn.XpathName, "another!")
+	assert.Equal(t, churn.LanguageXpathStruct, map[string]string{"java": "classes"})
+	assert.Equal(t, churn.LanguageXpathName, map[string]string{"java": "names"})
+	summoned := core.Registry.Summon(churn.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "FunctionChurn")
+}
+
+func fixtureFunctionChurnResult() FunctionChurnResult {
+	churn := fixtureFunctionChurn()
+	// foo spans lines 1-3 and bar spans lines 5-7
+	churn.attribute(0, "main.py", nil, map[string]*uast.Node{
+		"foo": fixtureFunctionChurnNode(1, 3),
+		"bar": fixtureFunctionChurnNode(5, 7),
+	}, nil)
+	// insert a line into foo and replace a line in bar; bar moves to lines 6-8
+	churn.attribute(2, "main.py", map[string]*uast.Node{
+		"foo": fixtureFunctionChurnNode(1, 3),
+		"bar": fixtureFunctionChurnNode(5, 7),
+	}, map[string]*uast.Node{
+		"foo": fixtureFunctionChurnNode(1, 4),
+		"bar": fixtureFunctionChurnNode(6, 8),
+	}, &items.FileDiffData{
+		OldLinesOfCode: 7,
+		NewLinesOfCode: 8,
+		Diffs: []diffmatchpatch.Diff{
+			{Type: diffmatchpatch.DiffEqual, Text: "ab"},
+			{Type: diffmatchpatch.DiffInsert, Text: "x"},
+			{Type: diffmatchpatch.DiffEqual, Text: "cde"},
+			{Type: diffmatchpatch.DiffDelete, Text: "f"},
+			{Type: diffmatchpatch.DiffInsert, Text: "y"},
+			{Type: diffmatchpatch.DiffEqual, Text: "g"},
+		},
+	})
+	churn.rename("main.py", "app.py")
+	// bar is deleted
+	churn.attribute(3, "app.py", map[string]*uast.Node{
+		"bar": fixtureFunctionChurnNode(6, 8),
+	}, nil, nil)
+	return churn.Finalize().(FunctionChurnResult)
+}
+
+func TestFunctionChurnAttribute(t *testing.T) {
+	result := fixtureFunctionChurnResult()
+	assert.Len(t, result.Functions, 2)
+	bar := result.Functions[0]
+	assert.Equal(t, bar.Name, "bar")
+	assert.Equal(t, bar.File, "app.py")
+	assert.Equal(t, bar.InternalRole, "FunctionDef")
+	assert.Equal(t, bar.Commits, 3)
+	assert.Equal(t, bar.Days, map[int]FunctionChurnDay{
+		0: {Added: 3}, 2: {Added: 1, Removed: 1}, 3: {Removed: 3}})
+	added, removed := bar.Lines()
+	assert.Equal(t, added, 4)
+	assert.Equal(t, removed, 4)
+	foo := result.Functions[1]
+	assert.Equal(t, foo.Name, "foo")
+	assert.Equal(t, foo.File, "app.py")
+	assert.Equal(t, foo.Commits, 2)
+	assert.Equal(t, foo.Days, map[int]FunctionChurnDay{0: {Added: 3}, 2: {Added: 1}})
+}
+
+func TestFunctionChurnInherit(t *testing.T) {
+	churn := fixtureFunctionChurn()
+	churn.attribute(0, "a.py", nil, map[string]*uast.Node{
+		"foo": fixtureFunctionChurnNode(1, 3),
+		"bar": fixtureFunctionChurnNode(5, 6),
+	}, nil)
+	// foo is moved to b.py and renamed to foo2
+	touched := churn.attribute(1, "a.py", map[string]*uast.Node{
+		"foo": fixtureFunctionChurnNode(1, 3),
+	}, nil, nil)
+	for key := range churn.attribute(1, "b.py", nil, map[string]*uast.Node{
+		"foo2": fixtureFunctionChurnNode(1, 3),
+	}, nil) {
+		touched[key] = true
+	}
+	assert.Equal(t, touched, map[string]bool{
+		"FunctionDef_foo_a.py": true, "FunctionDef_foo2_b.py": true})
+	foo2 := NodeSummary{InternalRole: "FunctionDef", Name: "foo2", File: "b.py"}
+	churn.inherit("FunctionDef_foo_a.py", foo2, touched)
+	// bar is renamed to bar2 without changes
+	bar2 := NodeSummary{InternalRole: "FunctionDef", Name: "bar2", File: "a.py"}
+	churn.inherit("FunctionDef_bar_a.py", bar2, touched)
+	churn.inherit("missing", bar2, touched)
+	assert.Equal(t, churn.files, map[string]map[string]bool{
+		"a.py": {"FunctionDef_bar2_a.py": true}, "b.py": {"FunctionDef_foo2_b.py": true}})
+	result := churn.Finalize().(FunctionChurnResult)
+	assert.Len(t, result.Functions, 2)
+	assert.Equal(t, result.Functions[0].NodeSummary, foo2)
+	assert.Equal(t, result.Functions[0].Commits, 2)
+	assert.Equal(t, result.Functions[0].Days, map[int]FunctionChurnDay{
+		0: {Added: 3}, 1: {Added: 3, Removed: 3}})
+	assert.Equal(t, result.Functions[1].NodeSummary, bar2)
+	assert.Equal(t, result.Functions[1].Commits, 1)
+}
+
+func TestFunctionChurnConsumeMerge(t *testing.T) {
+	churn := fixtureFunctionChurn()
+	result, err := churn.Consume(map[string]interface{}{
+		core.DependencyCommit: &object.Commit{
+			ParentHashes: make([]plumbing.Hash, 2)},
+		core.DependencyIsMerge: true,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+	assert.Len(t, churn.Finalize().(FunctionChurnResult).Functions, 0)
+}
+
+func TestFunctionChurnSerialize(t *testing.T) {
+	result := fixtureFunctionChurnResult()
+	churn := fixtureFunctionChurn()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, churn.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  functions:
+    - name: "bar"
+      file: "app.py"
+      internal_role: FunctionDef
+      commits: 3
+      added: 4
+      removed: 4
+      days: {0: [3, 0], 2: [1, 1], 3: [0, 3]}
+    - name: "foo"
+      file: "app.py"
+      internal_role: FunctionDef
+      commits: 2
+      added: 4
+      removed: 0
+      days: {0: [3, 0], 2: [1, 0]}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, churn.Serialize(result, true, buffer))
+	msg := pb.FunctionChurnAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Functions, 2)
+	assert.Equal(t, msg.Functions[0].Name, "bar")
+	assert.Equal(t, msg.Functions[0].Commits, int32(3))
+	assert.Equal(t, *msg.Functions[0].Days[2], pb.FunctionChurnDay{Added: 1, Removed: 1})
+	deserialized, err := churn.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestFunctionChurnMergeResults(t *testing.T) {
+	result := fixtureFunctionChurnResult()
+	churn := fixtureFunctionChurn()
+	c1 := core.CommonAnalysisResult{BeginTime: 3600 * 24}
+	c2 := core.CommonAnalysisResult{BeginTime: 0}
+	merged := churn.MergeResults(result, result, &c1, &c2).(FunctionChurnResult)
+	assert.Len(t, merged.Functions, 2)
+	bar := merged.Functions[0]
+	assert.Equal(t, bar.Name, "bar")
+	assert.Equal(t, bar.Commits, 6)
+	assert.Equal(t, bar.Days, map[int]FunctionChurnDay{
+		0: {Added: 3}, 1: {Added: 3}, 2: {Added: 1, Removed: 1}, 3: {Added: 1, Removed: 4},
+		4: {Removed: 3}})
+	foo := merged.Functions[1]
+	assert.Equal(t, foo.Commits, 4)
+	assert.Equal(t, foo.Days, map[int]FunctionChurnDay{
+		0: {Added: 3}, 1: {Added: 3}, 2: {Added: 1}, 3: {Added: 1}})
+}
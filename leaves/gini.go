@@ -0,0 +1,427 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+// GiniAnalysis measures how concentrated the development is: the Gini coefficients and
+// the Lorenz curves of the commits and the changed lines of the developers in each tick
+// of Sampling days and over the whole history. 0 means that everybody contributed equally
+// and values close to 1 mean that a single developer did almost everything.
+// The merge commits and the unmatched identities are ignored.
+type GiniAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the number of days in a tick.
+	Sampling int
+
+	// ticks are the contributions of the developers in each tick.
+	ticks []GiniTick
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// GiniTick is the contributions of the developers in a period.
+type GiniTick struct {
+	// Commits maps the developer indices to the numbers of commits.
+	Commits map[int]int
+	// Lines maps the developer indices to the numbers of changed lines.
+	Lines map[int]int
+	// CommitsGini is the Gini coefficient of Commits.
+	CommitsGini float64
+	// LinesGini is the Gini coefficient of Lines.
+	LinesGini float64
+}
+
+// GiniResult is returned by GiniAnalysis.Finalize().
+type GiniResult struct {
+	// Ticks are the contributions in each tick of Sampling days.
+	Ticks []GiniTick
+	// Total is the contributions over the whole history.
+	Total GiniTick
+	// Sampling is the effective GiniAnalysis.Sampling.
+	Sampling int
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigGiniSampling is the name of the option to set GiniAnalysis.Sampling.
+	ConfigGiniSampling = "Gini.Sampling"
+	// DefaultGiniSampling is the default value of GiniAnalysis.Sampling.
+	DefaultGiniSampling = 30
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (gini *GiniAnalysis) Name() string {
+	return "Gini"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (gini *GiniAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (gini *GiniAnalysis) Requires() []string {
+	arr := [...]string{
+		identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges,
+		items.DependencyFileDiff, items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (gini *GiniAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigGiniSampling,
+		Description: "How frequently to measure the contribution inequality, in days.",
+		Flag:        "gini-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultGiniSampling},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (gini *GiniAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigGiniSampling].(int); exists {
+		gini.Sampling = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		gini.reversedPeopleDict = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (gini *GiniAnalysis) Flag() string {
+	return "gini"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (gini *GiniAnalysis) Description() string {
+	return "Calculates the Gini coefficients and the Lorenz curves of the commits and " +
+		"the changed lines of the developers over time."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (gini *GiniAnalysis) Initialize(repository *git.Repository) {
+	if gini.Sampling <= 0 {
+		log.Printf("Warning: adjusted the Gini sampling to %d days\n", DefaultGiniSampling)
+		gini.Sampling = DefaultGiniSampling
+	}
+	gini.ticks = nil
+	gini.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (gini *GiniAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !gini.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing {
+		return nil, nil
+	}
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	lines := 0
+	for _, change := range deps[items.DependencyTreeChanges].(object.Changes) {
+		_, changed, err := countChangedLines(change, cache, fileDiffs)
+		if err != nil {
+			return nil, err
+		}
+		lines += changed
+	}
+	tick := gini.tick(deps[items.DependencyDay].(int) / gini.Sampling)
+	tick.Commits[author]++
+	tick.Lines[author] += lines
+	return nil, nil
+}
+
+// tick returns the contributions in the specified tick, appending the missing ticks.
+func (gini *GiniAnalysis) tick(index int) *GiniTick {
+	for len(gini.ticks) <= index {
+		gini.ticks = append(gini.ticks, GiniTick{Commits: map[int]int{}, Lines: map[int]int{}})
+	}
+	return &gini.ticks[index]
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (gini *GiniAnalysis) Finalize() interface{} {
+	return newGiniResult(gini.ticks, gini.Sampling, gini.reversedPeopleDict)
+}
+
+// newGiniResult sums the total contributions and calculates the Gini coefficients.
+func newGiniResult(ticks []GiniTick, sampling int, reversedPeopleDict []string) GiniResult {
+	result := GiniResult{
+		Ticks:              ticks,
+		Total:              GiniTick{Commits: map[int]int{}, Lines: map[int]int{}},
+		Sampling:           sampling,
+		reversedPeopleDict: reversedPeopleDict,
+	}
+	for i := range result.Ticks {
+		tick := &result.Ticks[i]
+		for dev, val := range tick.Commits {
+			result.Total.Commits[dev] += val
+		}
+		for dev, val := range tick.Lines {
+			result.Total.Lines[dev] += val
+		}
+		tick.updateCoefficients()
+	}
+	result.Total.updateCoefficients()
+	return result
+}
+
+// updateCoefficients calculates CommitsGini and LinesGini.
+func (tick *GiniTick) updateCoefficients() {
+	tick.CommitsGini = giniCoefficient(giniValues(tick.Commits))
+	tick.LinesGini = giniCoefficient(giniValues(tick.Lines))
+}
+
+// CommitsLorenz returns the Lorenz curve of Commits.
+func (tick GiniTick) CommitsLorenz() []float64 {
+	return lorenzCurve(giniValues(tick.Commits))
+}
+
+// LinesLorenz returns the Lorenz curve of Lines.
+func (tick GiniTick) LinesLorenz() []float64 {
+	return lorenzCurve(giniValues(tick.Lines))
+}
+
+// giniValues returns the contributions sorted in the ascending order.
+func giniValues(contributions map[int]int) []int {
+	values := make([]int, 0, len(contributions))
+	for _, val := range contributions {
+		values = append(values, val)
+	}
+	sort.Ints(values)
+	return values
+}
+
+// giniCoefficient calculates the Gini coefficient of the values sorted in the ascending order.
+// It is 0 if there are no values or they sum to 0.
+func giniCoefficient(values []int) float64 {
+	sum := 0
+	weighted := 0
+	for i, val := range values {
+		sum += val
+		weighted += (i + 1) * val
+	}
+	if sum == 0 {
+		return 0
+	}
+	n := float64(len(values))
+	return 2*float64(weighted)/(n*float64(sum)) - (n+1)/n
+}
+
+// lorenzCurve returns the cumulative shares of the values sorted in the ascending order:
+// the i-th element is the share of the i+1 smallest contributors.
+func lorenzCurve(values []int) []float64 {
+	sum := 0
+	for _, val := range values {
+		sum += val
+	}
+	curve := make([]float64, len(values))
+	if sum == 0 {
+		return curve
+	}
+	cumulative := 0
+	for i, val := range values {
+		cumulative += val
+		curve[i] = float64(cumulative) / float64(sum)
+	}
+	return curve
+}
+
+// Fork clones this pipeline item.
+func (gini *GiniAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(gini, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (gini *GiniAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	giniResult := result.(GiniResult)
+	if binary {
+		return gini.serializeBinary(&giniResult, writer)
+	}
+	gini.serializeText(&giniResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (gini *GiniAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(gini, result, &pb.GiniAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (gini *GiniAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(gini, result, &pb.GiniAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to GiniResult.
+func (gini *GiniAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.GiniAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	convert := func(tick *pb.GiniTick) GiniTick {
+		result := GiniTick{
+			Commits:     map[int]int{},
+			Lines:       map[int]int{},
+			CommitsGini: tick.CommitsGini,
+			LinesGini:   tick.LinesGini,
+		}
+		for dev, val := range tick.Commits {
+			result.Commits[int(dev)] = int(val)
+		}
+		for dev, val := range tick.Lines {
+			result.Lines[int(dev)] = int(val)
+		}
+		return result
+	}
+	result := GiniResult{
+		Sampling:           int(message.Sampling),
+		reversedPeopleDict: message.DevIndex,
+	}
+	if len(message.Ticks) > 0 {
+		result.Ticks = make([]GiniTick, len(message.Ticks))
+		for i, tick := range message.Ticks {
+			result.Ticks[i] = convert(tick)
+		}
+	}
+	if message.Total != nil {
+		result.Total = convert(message.Total)
+	} else {
+		result.Total = convert(&pb.GiniTick{})
+	}
+	return result, nil
+}
+
+// MergeResults combines two GiniResult-s together. The contributions are regrouped
+// by the larger sampling.
+func (gini *GiniAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	gr1 := r1.(GiniResult)
+	gr2 := r2.(GiniResult)
+	merged := GiniAnalysis{Sampling: gr1.Sampling}
+	if gr2.Sampling > merged.Sampling {
+		merged.Sampling = gr2.Sampling
+	}
+	people, reversedPeopleDict := identity.Detector{}.MergeReversedDicts(
+		gr1.reversedPeopleDict, gr2.reversedPeopleDict)
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	add := func(result *GiniResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		index := func(dev int) int {
+			if dev < len(result.reversedPeopleDict) {
+				return people[result.reversedPeopleDict[dev]][0]
+			}
+			return len(reversedPeopleDict)
+		}
+		for i, tick := range result.Ticks {
+			target := merged.tick((i*result.Sampling + offset) / merged.Sampling)
+			for dev, val := range tick.Commits {
+				target.Commits[index(dev)] += val
+			}
+			for dev, val := range tick.Lines {
+				target.Lines[index(dev)] += val
+			}
+		}
+	}
+	add(&gr1, c1)
+	add(&gr2, c2)
+	return newGiniResult(merged.ticks, merged.Sampling, reversedPeopleDict)
+}
+
+func (gini *GiniAnalysis) serializeText(result *GiniResult, writer io.Writer) {
+	writeFloats := func(name string, list []float64) {
+		fmt.Fprintf(writer, "%s: [", name)
+		for i, val := range list {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprintf(writer, "%.4f", val)
+		}
+		fmt.Fprintln(writer, "]")
+	}
+	writeTick := func(indent string, tick *GiniTick) {
+		fmt.Fprintf(writer, "developers: %d\n", len(tick.Commits))
+		fmt.Fprintf(writer, "%scommits_gini: %.4f\n", indent, tick.CommitsGini)
+		fmt.Fprintf(writer, "%slines_gini: %.4f\n", indent, tick.LinesGini)
+		writeFloats(indent+"commits_lorenz", tick.CommitsLorenz())
+		writeFloats(indent+"lines_lorenz", tick.LinesLorenz())
+	}
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprint(writer, "  total:\n    ")
+	writeTick("    ", &result.Total)
+	fmt.Fprintln(writer, "  ticks:")
+	for i := range result.Ticks {
+		fmt.Fprint(writer, "    - ")
+		writeTick("      ", &result.Ticks[i])
+	}
+}
+
+func (gini *GiniAnalysis) serializeBinary(result *GiniResult, writer io.Writer) error {
+	convert := func(tick *GiniTick) *pb.GiniTick {
+		message := &pb.GiniTick{
+			Commits:     map[int32]int32{},
+			Lines:       map[int32]int32{},
+			CommitsGini: tick.CommitsGini,
+			LinesGini:   tick.LinesGini,
+		}
+		for dev, val := range tick.Commits {
+			message.Commits[int32(dev)] = int32(val)
+		}
+		for dev, val := range tick.Lines {
+			message.Lines[int32(dev)] = int32(val)
+		}
+		return message
+	}
+	message := pb.GiniAnalysisResults{
+		Ticks:    make([]*pb.GiniTick, len(result.Ticks)),
+		Total:    convert(&result.Total),
+		Sampling: int32(result.Sampling),
+		DevIndex: result.reversedPeopleDict,
+	}
+	for i := range result.Ticks {
+		message.Ticks[i] = convert(&result.Ticks[i])
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&GiniAnalysis{})
+}
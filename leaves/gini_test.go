@@ -0,0 +1,188 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureGini() *GiniAnalysis {
+	gini := GiniAnalysis{}
+	gini.Configure(map[string]interface{}{
+		ConfigGiniSampling:                              10,
+		identity.FactIdentityDetectorPeopleCount:        3,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"alice", "bob", "carol"},
+	})
+	gini.Initialize(nil)
+	return &gini
+}
+
+func TestGiniMeta(t *testing.T) {
+	gini := fixtureGini()
+	assert.Equal(t, gini.Name(), "Gini")
+	assert.Len(t, gini.Provides(), 0)
+	assert.Equal(t, gini.Requires(), []string{
+		identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges,
+		items.DependencyFileDiff, items.DependencyBlobCache})
+	assert.Equal(t, gini.Flag(), "gini")
+	assert.NotEmpty(t, gini.Description())
+	opts := gini.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Flag, "gini-sampling")
+	assert.Equal(t, gini.Sampling, 10)
+	assert.Equal(t, gini.reversedPeopleDict, []string{"alice", "bob", "carol"})
+	gini = &GiniAnalysis{}
+	gini.Initialize(nil)
+	assert.Equal(t, gini.Sampling, DefaultGiniSampling)
+	summoned := core.Registry.Summon(gini.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Gini")
+}
+
+func TestGiniCoefficient(t *testing.T) {
+	assert.Equal(t, giniCoefficient(nil), float64(0))
+	assert.Equal(t, giniCoefficient([]int{0, 0}), float64(0))
+	assert.Equal(t, giniCoefficient([]int{5, 5, 5}), float64(0))
+	assert.Equal(t, giniCoefficient([]int{0, 0, 0, 10}), 0.75)
+	assert.InDelta(t, giniCoefficient([]int{1, 2}), 1.0/6, 1e-9)
+	assert.Equal(t, lorenzCurve([]int{0, 0}), []float64{0, 0})
+	assert.Equal(t, lorenzCurve([]int{1, 1, 2}), []float64{0.25, 0.5, 1})
+	assert.Equal(t, giniValues(map[int]int{0: 3, 1: 1, 5: 2}), []int{1, 2, 3})
+}
+
+func fixtureGiniResult(t *testing.T) GiniResult {
+	hash, blob := storeExpertiseBlob(t, "one\ntwo\nthree\nfour\n")
+	insert := func(name string) *object.Change {
+		return &object.Change{To: object.ChangeEntry{
+			Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}}
+	}
+	gini := fixtureGini()
+	consume := func(author, day int, merge bool, changes object.Changes,
+		fileDiffs map[string]items.FileDiffData) {
+		commit := &object.Commit{}
+		if merge {
+			commit.Hash = plumbing.NewHash("0123456789012345678901234567890123456789")
+			commit.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		result, err := gini.Consume(map[string]interface{}{
+			core.DependencyCommit:       commit,
+			core.DependencyIsMerge:      merge,
+			identity.DependencyAuthor:   author,
+			items.DependencyDay:         day,
+			items.DependencyTreeChanges: changes,
+			items.DependencyFileDiff:    fileDiffs,
+			items.DependencyBlobCache:   map[plumbing.Hash]*object.Blob{hash: blob},
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	consume(0, 0, false, object.Changes{insert("a.go")}, nil)
+	consume(0, 1, false, object.Changes{insert("b.go")}, nil)
+	modified := insert("a.go")
+	modified.From = modified.To
+	consume(1, 2, false, object.Changes{modified},
+		map[string]items.FileDiffData{"a.go": {Diffs: []diffmatchpatch.Diff{
+			{Type: diffmatchpatch.DiffEqual, Text: "ab"},
+			{Type: diffmatchpatch.DiffDelete, Text: "c"},
+			{Type: diffmatchpatch.DiffInsert, Text: "d"},
+		}}})
+	consume(2, 15, false, object.Changes{insert("c.go")}, nil)
+	consume(1, 16, false, object.Changes{insert("d.go")}, nil)
+	// the merges and the unmatched identities are ignored
+	consume(0, 17, true, object.Changes{insert("e.go")}, nil)
+	consume(identity.AuthorMissing, 18, false, object.Changes{insert("f.go")}, nil)
+	return gini.Finalize().(GiniResult)
+}
+
+func TestGiniConsumeFinalize(t *testing.T) {
+	result := fixtureGiniResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Len(t, result.Ticks, 2)
+	assert.Equal(t, result.Ticks[0].Commits, map[int]int{0: 2, 1: 1})
+	assert.Equal(t, result.Ticks[0].Lines, map[int]int{0: 8, 1: 2})
+	assert.InDelta(t, result.Ticks[0].CommitsGini, 1.0/6, 1e-9)
+	assert.InDelta(t, result.Ticks[0].LinesGini, 0.3, 1e-9)
+	assert.Equal(t, result.Ticks[1].Commits, map[int]int{1: 1, 2: 1})
+	assert.Equal(t, result.Ticks[1].Lines, map[int]int{1: 4, 2: 4})
+	assert.Equal(t, result.Ticks[1].CommitsGini, float64(0))
+	assert.Equal(t, result.Ticks[1].LinesGini, float64(0))
+	assert.Equal(t, result.Total.Commits, map[int]int{0: 2, 1: 2, 2: 1})
+	assert.Equal(t, result.Total.Lines, map[int]int{0: 8, 1: 6, 2: 4})
+	assert.InDelta(t, result.Total.CommitsGini, 2.0/15, 1e-9)
+	assert.InDelta(t, result.Total.LinesGini, 4.0/27, 1e-9)
+	assert.Equal(t, result.reversedPeopleDict, []string{"alice", "bob", "carol"})
+}
+
+func TestGiniSerialize(t *testing.T) {
+	result := fixtureGiniResult(t)
+	gini := fixtureGini()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, gini.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  total:
+    developers: 3
+    commits_gini: 0.1333
+    lines_gini: 0.1481
+    commits_lorenz: [0.2000, 0.6000, 1.0000]
+    lines_lorenz: [0.2222, 0.5556, 1.0000]
+  ticks:
+    - developers: 2
+      commits_gini: 0.1667
+      lines_gini: 0.3000
+      commits_lorenz: [0.3333, 1.0000]
+      lines_lorenz: [0.2000, 1.0000]
+    - developers: 2
+      commits_gini: 0.0000
+      lines_gini: 0.0000
+      commits_lorenz: [0.5000, 1.0000]
+      lines_lorenz: [0.5000, 1.0000]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, gini.Serialize(result, true, buffer))
+	msg := pb.GiniAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Len(t, msg.Ticks, 2)
+	assert.Equal(t, msg.Total.Lines, map[int32]int32{0: 8, 1: 6, 2: 4})
+	assert.Equal(t, msg.DevIndex, []string{"alice", "bob", "carol"})
+	deserialized, err := gini.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestGiniMergeResults(t *testing.T) {
+	r1 := GiniResult{
+		Ticks: []GiniTick{
+			{Commits: map[int]int{0: 1}, Lines: map[int]int{0: 10}},
+			{Commits: map[int]int{0: 1}, Lines: map[int]int{0: 5}}},
+		Sampling:           10,
+		reversedPeopleDict: []string{"alice"},
+	}
+	r2 := GiniResult{
+		Ticks: []GiniTick{
+			{Commits: map[int]int{0: 2, 1: 1}, Lines: map[int]int{0: 4, 1: 1}}},
+		Sampling:           20,
+		reversedPeopleDict: []string{"bob", "alice"},
+	}
+	gini := fixtureGini()
+	merged := gini.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 10 * 24 * 3600}).(GiniResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.reversedPeopleDict, []string{"alice", "bob"})
+	assert.Len(t, merged.Ticks, 1)
+	assert.Equal(t, merged.Ticks[0].Commits, map[int]int{0: 3, 1: 2})
+	assert.Equal(t, merged.Ticks[0].Lines, map[int]int{0: 16, 1: 4})
+	assert.InDelta(t, merged.Ticks[0].CommitsGini, 0.1, 1e-9)
+	assert.InDelta(t, merged.Ticks[0].LinesGini, 0.3, 1e-9)
+	assert.Equal(t, merged.Total.Commits, merged.Ticks[0].Commits)
+}
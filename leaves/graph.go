@@ -0,0 +1,177 @@
+package leaves
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Graph is the weighted undirected graph of the couples, e.g. the files which are changed
+// together. It can be written in the formats understood by Graphviz and Gephi.
+type Graph struct {
+	Nodes []GraphNode
+	Edges []GraphEdge
+}
+
+// GraphNode is a vertex of Graph.
+type GraphNode struct {
+	Label string
+	// Weight is usually the number of the changes of the node.
+	Weight float64
+}
+
+// GraphEdge connects two nodes of Graph.
+type GraphEdge struct {
+	// Source and Target are the indexes in Graph.Nodes; Source is always less than Target.
+	Source int
+	Target int
+	// Weight is usually the number of the common changes of the nodes.
+	Weight float64
+}
+
+// GraphFormats are the names of the supported formats for Graph.Write().
+var GraphFormats = []string{"dot", "gexf", "graphml"}
+
+// matrixGraph converts the symmetric co-occurrence matrix to Graph. The diagonal becomes
+// the weights of the nodes.
+func matrixGraph(labels []string, matrix []map[int]int64) Graph {
+	graph := Graph{Nodes: make([]GraphNode, len(labels))}
+	for i, label := range labels {
+		graph.Nodes[i].Label = label
+		if i >= len(matrix) {
+			continue
+		}
+		graph.Nodes[i].Weight = float64(matrix[i][i])
+		var targets []int
+		for j, val := range matrix[i] {
+			if j > i && j < len(labels) && val > 0 {
+				targets = append(targets, j)
+			}
+		}
+		sort.Ints(targets)
+		for _, j := range targets {
+			graph.Edges = append(graph.Edges, GraphEdge{
+				Source: i, Target: j, Weight: float64(matrix[i][j])})
+		}
+	}
+	return graph
+}
+
+// FilesGraph returns the graph of the files which are changed in the same commits.
+func (result CouplesResult) FilesGraph() Graph {
+	return matrixGraph(result.Files, result.FilesMatrix)
+}
+
+// DirectoriesGraph returns the graph of the directories which are changed in the same commits.
+func (result CouplesResult) DirectoriesGraph() Graph {
+	return matrixGraph(result.Directories, result.DirectoriesMatrix)
+}
+
+// PeopleGraph returns the graph of the developers who change the same files.
+func (result CouplesResult) PeopleGraph() Graph {
+	return matrixGraph(result.reversedPeopleDict, result.PeopleMatrix)
+}
+
+// Graph returns the graph of the structural units which are changed in the same commits.
+func (result ShotnessResult) Graph() Graph {
+	labels := make([]string, len(result.Nodes))
+	for i, node := range result.Nodes {
+		labels[i] = node.String()
+	}
+	matrix := make([]map[int]int64, len(result.Counters))
+	for i, counters := range result.Counters {
+		matrix[i] = map[int]int64{}
+		for j, val := range counters {
+			matrix[i][j] = int64(val)
+		}
+	}
+	return matrixGraph(labels, matrix)
+}
+
+// Write prints the graph in the specified format: "dot", "gexf" or "graphml".
+func (graph Graph) Write(writer io.Writer, format string) error {
+	switch format {
+	case "dot":
+		graph.writeDOT(writer)
+	case "gexf":
+		graph.writeGEXF(writer)
+	case "graphml":
+		graph.writeGraphML(writer)
+	default:
+		return fmt.Errorf("unsupported graph format: %s (supported: %s)",
+			format, strings.Join(GraphFormats, ", "))
+	}
+	return nil
+}
+
+func formatGraphWeight(weight float64) string {
+	return strconv.FormatFloat(weight, 'g', -1, 64)
+}
+
+// escapeXML returns the string which is safe to put in the XML attributes and text.
+func escapeXML(text string) string {
+	builder := &strings.Builder{}
+	xml.EscapeText(builder, []byte(text))
+	return builder.String()
+}
+
+func (graph Graph) writeDOT(writer io.Writer) {
+	fmt.Fprintln(writer, "graph couples {")
+	for i, node := range graph.Nodes {
+		fmt.Fprintf(writer, "  n%d [label=%s, weight=%s];\n",
+			i, strconv.Quote(node.Label), formatGraphWeight(node.Weight))
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(writer, "  n%d -- n%d [weight=%s];\n",
+			edge.Source, edge.Target, formatGraphWeight(edge.Weight))
+	}
+	fmt.Fprintln(writer, "}")
+}
+
+func (graph Graph) writeGraphML(writer io.Writer) {
+	fmt.Fprintln(writer, `<?xml version="1.0" encoding="UTF-8"?>`)
+	fmt.Fprintln(writer, `<graphml xmlns="http://graphml.graphdrawing.org/xmlns">`)
+	fmt.Fprintln(writer, `  <key id="label" for="node" attr.name="label" attr.type="string"/>`)
+	fmt.Fprintln(writer, `  <key id="weight" for="node" attr.name="weight" attr.type="double"/>`)
+	fmt.Fprintln(writer, `  <key id="edge_weight" for="edge" attr.name="weight" attr.type="double"/>`)
+	fmt.Fprintln(writer, `  <graph id="couples" edgedefault="undirected">`)
+	for i, node := range graph.Nodes {
+		fmt.Fprintf(writer, `    <node id="n%d"><data key="label">%s</data>`+
+			`<data key="weight">%s</data></node>`+"\n",
+			i, escapeXML(node.Label), formatGraphWeight(node.Weight))
+	}
+	for i, edge := range graph.Edges {
+		fmt.Fprintf(writer, `    <edge id="e%d" source="n%d" target="n%d">`+
+			`<data key="edge_weight">%s</data></edge>`+"\n",
+			i, edge.Source, edge.Target, formatGraphWeight(edge.Weight))
+	}
+	fmt.Fprintln(writer, `  </graph>`)
+	fmt.Fprintln(writer, `</graphml>`)
+}
+
+func (graph Graph) writeGEXF(writer io.Writer) {
+	fmt.Fprintln(writer, `<?xml version="1.0" encoding="UTF-8"?>`)
+	fmt.Fprintln(writer, `<gexf xmlns="http://www.gexf.net/1.2draft" version="1.2">`)
+	fmt.Fprintln(writer, `  <graph mode="static" defaultedgetype="undirected">`)
+	fmt.Fprintln(writer, `    <attributes class="node">`)
+	fmt.Fprintln(writer, `      <attribute id="weight" title="weight" type="double"/>`)
+	fmt.Fprintln(writer, `    </attributes>`)
+	fmt.Fprintln(writer, `    <nodes>`)
+	for i, node := range graph.Nodes {
+		fmt.Fprintf(writer, `      <node id="%d" label="%s"><attvalues>`+
+			`<attvalue for="weight" value="%s"/></attvalues></node>`+"\n",
+			i, escapeXML(node.Label), formatGraphWeight(node.Weight))
+	}
+	fmt.Fprintln(writer, `    </nodes>`)
+	fmt.Fprintln(writer, `    <edges>`)
+	for i, edge := range graph.Edges {
+		fmt.Fprintf(writer, `      <edge id="%d" source="%d" target="%d" weight="%s"/>`+"\n",
+			i, edge.Source, edge.Target, formatGraphWeight(edge.Weight))
+	}
+	fmt.Fprintln(writer, `    </edges>`)
+	fmt.Fprintln(writer, `  </graph>`)
+	fmt.Fprintln(writer, `</gexf>`)
+}
@@ -0,0 +1,81 @@
+package leaves
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fixtureGraph() Graph {
+	return CouplesResult{
+		Files:       []string{"a.go", "b \"quoted\" & <b>.go", "c.go"},
+		FilesMatrix: []map[int]int64{{0: 3, 1: 2}, {0: 2, 1: 4, 2: 1}, {1: 1, 2: 1}},
+	}.FilesGraph()
+}
+
+func TestCouplesGraphs(t *testing.T) {
+	graph := fixtureGraph()
+	assert.Equal(t, graph.Nodes, []GraphNode{
+		{Label: "a.go", Weight: 3}, {Label: "b \"quoted\" & <b>.go", Weight: 4}, {Label: "c.go", Weight: 1}})
+	assert.Equal(t, graph.Edges, []GraphEdge{
+		{Source: 0, Target: 1, Weight: 2}, {Source: 1, Target: 2, Weight: 1}})
+	result := CouplesResult{
+		PeopleMatrix:       []map[int]int64{{0: 5, 1: 2}, {0: 2, 1: 3}, {0: 1}},
+		Directories:        []string{"."},
+		DirectoriesMatrix:  []map[int]int64{{0: 7}},
+		reversedPeopleDict: []string{"one", "two"},
+	}
+	graph = result.PeopleGraph()
+	assert.Len(t, graph.Nodes, 2)
+	assert.Equal(t, graph.Edges, []GraphEdge{{Source: 0, Target: 1, Weight: 2}})
+	graph = result.DirectoriesGraph()
+	assert.Equal(t, graph.Nodes, []GraphNode{{Label: ".", Weight: 7}})
+	assert.Len(t, graph.Edges, 0)
+	graph = ShotnessResult{
+		Nodes:    []NodeSummary{{InternalRole: "Function", Name: "f", File: "a.go"}, {InternalRole: "Function", Name: "g", File: "a.go"}},
+		Counters: []map[int]int{{0: 2, 1: 1}, {0: 1, 1: 1}},
+	}.Graph()
+	assert.Equal(t, graph.Nodes, []GraphNode{
+		{Label: "Function_f_a.go", Weight: 2}, {Label: "Function_g_a.go", Weight: 1}})
+	assert.Equal(t, graph.Edges, []GraphEdge{{Source: 0, Target: 1, Weight: 1}})
+}
+
+func TestGraphWriteDOT(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, fixtureGraph().Write(buffer, "dot"))
+	assert.Equal(t, buffer.String(), `graph couples {
+  n0 [label="a.go", weight=3];
+  n1 [label="b \"quoted\" & <b>.go", weight=4];
+  n2 [label="c.go", weight=1];
+  n0 -- n1 [weight=2];
+  n1 -- n2 [weight=1];
+}
+`)
+}
+
+func TestGraphWriteXML(t *testing.T) {
+	for _, format := range []string{"graphml", "gexf"} {
+		buffer := &bytes.Buffer{}
+		assert.Nil(t, fixtureGraph().Write(buffer, format))
+		// well-formed
+		decoder := xml.NewDecoder(bytes.NewReader(buffer.Bytes()))
+		var err error
+		for err == nil {
+			_, err = decoder.Token()
+		}
+		assert.Equal(t, err.Error(), "EOF", format)
+		assert.Contains(t, buffer.String(), "b &#34;quoted&#34; &amp; &lt;b&gt;.go")
+	}
+	buffer := &bytes.Buffer{}
+	fixtureGraph().Write(buffer, "graphml")
+	assert.Contains(t, buffer.String(),
+		`<edge id="e1" source="n1" target="n2"><data key="edge_weight">1</data></edge>`)
+	buffer = &bytes.Buffer{}
+	fixtureGraph().Write(buffer, "gexf")
+	assert.Contains(t, buffer.String(),
+		`<node id="0" label="a.go"><attvalues><attvalue for="weight" value="3"/></attvalues></node>`)
+	assert.Contains(t, buffer.String(), `<edge id="0" source="0" target="1" weight="2"/>`)
+	assert.NotNil(t, fixtureGraph().Write(buffer, "svg"))
+}
@@ -0,0 +1,506 @@
+package leaves
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-billy.v4"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/storage/filesystem"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// HistoryRewritesAnalysis detects the history rewrites such as force pushes, rebases of
+// the published branches and amended commits. It reads the reflogs of the branches
+// (.git/logs/refs) and the backups of git filter-branch (refs/original), so the repository
+// must be a local clone or a mirror which keeps them. A reference update is a rewrite
+// if some commits which were reachable from the old value are reachable neither from the new
+// value nor from any live reference. Those commits are the rewritten work.
+type HistoryRewritesAnalysis struct {
+	core.NoopMerger
+
+	// repository is the analysed repository.
+	repository *git.Repository
+	// peopleDict references IdentityDetector.PeopleDict
+	peopleDict map[string]int
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// HistoryRewrite is a single reference update which dropped some commits.
+type HistoryRewrite struct {
+	// Ref is the rewritten reference, e.g. "refs/heads/master".
+	Ref string
+	// Time is the Unix time of the reflog entry; 0 for refs/original.
+	Time int64
+	// Actor is the developer who moved the reference; -1 means an unmatched identity.
+	Actor int
+	// Old and New are the hashes of the commits before and after the update.
+	Old, New string
+	// Message is the reflog message, e.g. "rebase finished" or "update by push".
+	Message string
+	// Commits is the number of the rewritten commits.
+	Commits int
+	// Added and Removed are the numbers of lines changed by the rewritten commits.
+	Added, Removed int
+	// Authors maps the developer index to the number of rewritten commits;
+	// -1 means an unmatched identity.
+	Authors map[int]int
+	// Pruned indicates that the old commit was garbage collected and the rewritten
+	// work is unknown.
+	Pruned bool
+}
+
+// HistoryRewritesResult is returned by HistoryRewritesAnalysis.Finalize().
+type HistoryRewritesResult struct {
+	// Rewrites are sorted by time.
+	Rewrites []HistoryRewrite
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// reflogEntry is a line of a reflog file.
+type reflogEntry struct {
+	old, new plumbing.Hash
+	name     string
+	email    string
+	time     int64
+	message  string
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (rewrites *HistoryRewritesAnalysis) Name() string {
+	return "HistoryRewrites"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (rewrites *HistoryRewritesAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream. The author is required so that the identities are resolved.
+func (rewrites *HistoryRewritesAnalysis) Requires() []string {
+	arr := [...]string{identity.DependencyAuthor}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (rewrites *HistoryRewritesAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (rewrites *HistoryRewritesAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[identity.FactIdentityDetectorPeopleDict].(map[string]int); exists {
+		rewrites.peopleDict = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		rewrites.reversedPeopleDict = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (rewrites *HistoryRewritesAnalysis) Flag() string {
+	return "history-rewrites"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (rewrites *HistoryRewritesAnalysis) Description() string {
+	return "Detects the force pushes, rebases and other history rewrites in the reflogs and " +
+		"refs/original and measures the rewritten work. Requires a local clone or a mirror."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (rewrites *HistoryRewritesAnalysis) Initialize(repository *git.Repository) {
+	rewrites.repository = repository
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (rewrites *HistoryRewritesAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	// the reflogs are scanned in Finalize()
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (rewrites *HistoryRewritesAnalysis) Finalize() interface{} {
+	result := HistoryRewritesResult{
+		Rewrites: []HistoryRewrite{}, reversedPeopleDict: rewrites.reversedPeopleDict}
+	if rewrites.repository == nil {
+		return result
+	}
+	live, backups := rewrites.scanReferences()
+	alive := rewrites.reachable(live, nil)
+	check := func(ref string, entry reflogEntry) {
+		if entry.old == plumbing.ZeroHash || entry.old == entry.new || alive[entry.old] {
+			return
+		}
+		rewrite := HistoryRewrite{
+			Ref: ref, Time: entry.time, Actor: rewrites.resolve(entry.name, entry.email),
+			Old: entry.old.String(), New: entry.new.String(), Message: entry.message,
+			Authors: map[int]int{},
+		}
+		if _, err := rewrites.repository.CommitObject(entry.old); err != nil {
+			rewrite.Pruned = true
+			result.Rewrites = append(result.Rewrites, rewrite)
+			return
+		}
+		kept := rewrites.reachable([]plumbing.Hash{entry.new}, alive)
+		lost := rewrites.reachable([]plumbing.Hash{entry.old}, alive)
+		for hash := range lost {
+			if kept[hash] {
+				continue
+			}
+			commit, err := rewrites.repository.CommitObject(hash)
+			if err != nil {
+				continue
+			}
+			rewrite.Commits++
+			rewrite.Authors[rewrites.resolve(commit.Author.Name, commit.Author.Email)]++
+			if len(commit.ParentHashes) > 1 {
+				continue
+			}
+			if stats, err := commit.Stats(); err == nil {
+				for _, file := range stats {
+					rewrite.Added += file.Addition
+					rewrite.Removed += file.Deletion
+				}
+			}
+		}
+		if rewrite.Commits > 0 {
+			result.Rewrites = append(result.Rewrites, rewrite)
+		}
+	}
+	for ref, entries := range rewrites.readReflogs() {
+		for _, entry := range entries {
+			check(ref, entry)
+		}
+	}
+	for ref, hash := range backups {
+		current, err := rewrites.repository.Reference(plumbing.ReferenceName(ref), true)
+		entry := reflogEntry{old: hash, message: "refs/original"}
+		if err == nil {
+			entry.new = current.Hash()
+		}
+		check(ref, entry)
+	}
+	sort.Slice(result.Rewrites, func(i, j int) bool {
+		ri, rj := result.Rewrites[i], result.Rewrites[j]
+		if ri.Time != rj.Time {
+			return ri.Time < rj.Time
+		}
+		if ri.Ref != rj.Ref {
+			return ri.Ref < rj.Ref
+		}
+		return ri.Old < rj.Old
+	})
+	return result
+}
+
+// Fork clones this pipeline item.
+func (rewrites *HistoryRewritesAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(rewrites, n)
+}
+
+// resolve returns the identity of the signature or -1.
+func (rewrites *HistoryRewritesAnalysis) resolve(name, email string) int {
+	if id, exists := rewrites.peopleDict[strings.ToLower(email)]; exists {
+		return id
+	}
+	if id, exists := rewrites.peopleDict[strings.ToLower(name)]; exists {
+		return id
+	}
+	return -1
+}
+
+// scanReferences returns the commits of the live references and the backups in refs/original
+// mapped to the names of the original references.
+func (rewrites *HistoryRewritesAnalysis) scanReferences() ([]plumbing.Hash, map[string]plumbing.Hash) {
+	var live []plumbing.Hash
+	backups := map[string]plumbing.Hash{}
+	iter, err := rewrites.repository.References()
+	if err != nil {
+		return live, backups
+	}
+	iter.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+		name := ref.Name().String()
+		hash := ref.Hash()
+		if tag, err := rewrites.repository.TagObject(hash); err == nil {
+			if commit, err := tag.Commit(); err == nil {
+				hash = commit.Hash
+			}
+		}
+		if strings.HasPrefix(name, "refs/original/") {
+			backups[strings.TrimPrefix(name, "refs/original/")] = hash
+		} else if name != "refs/stash" {
+			live = append(live, hash)
+		}
+		return nil
+	})
+	return live, backups
+}
+
+// reachable returns the commits which are reachable from `heads` except those in `stop`.
+// The missing objects are skipped.
+func (rewrites *HistoryRewritesAnalysis) reachable(
+	heads []plumbing.Hash, stop map[plumbing.Hash]bool) map[plumbing.Hash]bool {
+	visited := map[plumbing.Hash]bool{}
+	queue := append([]plumbing.Hash{}, heads...)
+	for len(queue) > 0 {
+		hash := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		if hash == plumbing.ZeroHash || visited[hash] || stop[hash] {
+			continue
+		}
+		commit, err := rewrites.repository.CommitObject(hash)
+		if err != nil {
+			continue
+		}
+		visited[hash] = true
+		queue = append(queue, commit.ParentHashes...)
+	}
+	return visited
+}
+
+// readReflogs parses the reflogs of the references except HEAD and refs/stash. The repository
+// must be stored in the file system, otherwise nothing is returned.
+func (rewrites *HistoryRewritesAnalysis) readReflogs() map[string][]reflogEntry {
+	result := map[string][]reflogEntry{}
+	storage, ok := rewrites.repository.Storer.(*filesystem.Storage)
+	if !ok {
+		return result
+	}
+	fs := storage.Filesystem()
+	var walk func(dir string)
+	walk = func(dir string) {
+		infos, err := fs.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		for _, info := range infos {
+			name := path.Join(dir, info.Name())
+			if info.IsDir() {
+				walk(name)
+				continue
+			}
+			ref := strings.TrimPrefix(name, "logs/")
+			if ref == "refs/stash" {
+				continue
+			}
+			if entries := readReflog(fs, name); len(entries) > 0 {
+				result[ref] = entries
+			}
+		}
+	}
+	walk("logs/refs")
+	return result
+}
+
+// readReflog parses a single reflog file. The malformed lines are skipped.
+func readReflog(fs billy.Filesystem, name string) []reflogEntry {
+	file, err := fs.Open(name)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+	var entries []reflogEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if entry, ok := parseReflogLine(scanner.Text()); ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// parseReflogLine parses "<old> <new> Name <email> <timestamp> <timezone>\t<message>".
+func parseReflogLine(line string) (reflogEntry, bool) {
+	entry := reflogEntry{}
+	if len(line) < 83 || line[40] != ' ' || line[81] != ' ' {
+		return entry, false
+	}
+	entry.old = plumbing.NewHash(line[:40])
+	entry.new = plumbing.NewHash(line[41:81])
+	signature := line[82:]
+	if tab := strings.IndexByte(signature, '\t'); tab >= 0 {
+		entry.message = signature[tab+1:]
+		signature = signature[:tab]
+	}
+	open := strings.IndexByte(signature, '<')
+	closing := strings.LastIndexByte(signature, '>')
+	if open < 0 || closing < open {
+		return entry, false
+	}
+	entry.name = strings.TrimSpace(signature[:open])
+	entry.email = signature[open+1 : closing]
+	fields := strings.Fields(signature[closing+1:])
+	if len(fields) > 0 {
+		entry.time, _ = strconv.ParseInt(fields[0], 10, 64)
+	}
+	return entry, true
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (rewrites *HistoryRewritesAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	rewritesResult := result.(HistoryRewritesResult)
+	if binary {
+		return rewrites.serializeBinary(&rewritesResult, writer)
+	}
+	rewrites.serializeText(&rewritesResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (rewrites *HistoryRewritesAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(rewrites, result, &pb.HistoryRewritesAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (rewrites *HistoryRewritesAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(rewrites, result, &pb.HistoryRewritesAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to HistoryRewritesResult.
+func (rewrites *HistoryRewritesAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.HistoryRewritesAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := HistoryRewritesResult{
+		Rewrites:           make([]HistoryRewrite, len(message.Rewrites)),
+		reversedPeopleDict: message.DevIndex,
+	}
+	for i, msg := range message.Rewrites {
+		rewrite := HistoryRewrite{
+			Ref: msg.Ref, Time: msg.Time, Actor: int(msg.Actor), Old: msg.Old, New: msg.New,
+			Message: msg.Message, Commits: int(msg.Commits), Added: int(msg.Added),
+			Removed: int(msg.Removed), Authors: map[int]int{}, Pruned: msg.Pruned,
+		}
+		for dev, val := range msg.Authors {
+			rewrite.Authors[int(dev)] = int(val)
+		}
+		result.Rewrites[i] = rewrite
+	}
+	return result, nil
+}
+
+// MergeResults combines two HistoryRewritesResult-s together.
+func (rewrites *HistoryRewritesAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	hr1 := r1.(HistoryRewritesResult)
+	hr2 := r2.(HistoryRewritesResult)
+	merged := HistoryRewritesResult{}
+	var people map[string][3]int
+	people, merged.reversedPeopleDict = identity.Detector{}.MergeReversedDicts(
+		hr1.reversedPeopleDict, hr2.reversedPeopleDict)
+	add := func(result *HistoryRewritesResult) {
+		remap := func(dev int) int {
+			if dev < 0 || dev >= len(result.reversedPeopleDict) {
+				return -1
+			}
+			return people[result.reversedPeopleDict[dev]][0]
+		}
+		for _, rewrite := range result.Rewrites {
+			authors := map[int]int{}
+			for dev, val := range rewrite.Authors {
+				authors[remap(dev)] += val
+			}
+			rewrite.Actor = remap(rewrite.Actor)
+			rewrite.Authors = authors
+			merged.Rewrites = append(merged.Rewrites, rewrite)
+		}
+	}
+	add(&hr1)
+	add(&hr2)
+	sort.SliceStable(merged.Rewrites, func(i, j int) bool {
+		return merged.Rewrites[i].Time < merged.Rewrites[j].Time
+	})
+	return merged
+}
+
+func (rewrites *HistoryRewritesAnalysis) serializeText(result *HistoryRewritesResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  rewrites:")
+	for _, rewrite := range result.Rewrites {
+		fmt.Fprintf(writer, "    - ref: %s\n", yaml.SafeString(rewrite.Ref))
+		fmt.Fprintf(writer, "      time: %d\n", rewrite.Time)
+		fmt.Fprintf(writer, "      actor: %d\n", rewrite.Actor)
+		fmt.Fprintf(writer, "      old: %s\n", rewrite.Old)
+		fmt.Fprintf(writer, "      new: %s\n", rewrite.New)
+		fmt.Fprintf(writer, "      message: %s\n", yaml.SafeString(rewrite.Message))
+		fmt.Fprintf(writer, "      commits: %d\n", rewrite.Commits)
+		fmt.Fprintf(writer, "      added: %d\n", rewrite.Added)
+		fmt.Fprintf(writer, "      removed: %d\n", rewrite.Removed)
+		authors := make([]int, 0, len(rewrite.Authors))
+		for dev := range rewrite.Authors {
+			authors = append(authors, dev)
+		}
+		sort.Ints(authors)
+		fmt.Fprint(writer, "      authors: {")
+		for i, dev := range authors {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprintf(writer, "%d: %d", dev, rewrite.Authors[dev])
+		}
+		fmt.Fprintln(writer, "}")
+		fmt.Fprintf(writer, "      pruned: %t\n", rewrite.Pruned)
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (rewrites *HistoryRewritesAnalysis) serializeBinary(result *HistoryRewritesResult, writer io.Writer) error {
+	message := pb.HistoryRewritesAnalysisResults{
+		Rewrites: make([]*pb.HistoryRewrite, len(result.Rewrites)),
+		DevIndex: result.reversedPeopleDict,
+	}
+	for i, rewrite := range result.Rewrites {
+		msg := &pb.HistoryRewrite{
+			Ref: rewrite.Ref, Time: rewrite.Time, Actor: int32(rewrite.Actor),
+			Old: rewrite.Old, New: rewrite.New, Message: rewrite.Message,
+			Commits: int32(rewrite.Commits), Added: int32(rewrite.Added),
+			Removed: int32(rewrite.Removed), Authors: map[int32]int32{}, Pruned: rewrite.Pruned,
+		}
+		for dev, val := range rewrite.Authors {
+			msg.Authors[int32(dev)] = int32(val)
+		}
+		message.Rewrites[i] = msg
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&HistoryRewritesAnalysis{})
+}
@@ -0,0 +1,230 @@
+package leaves
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func TestHistoryRewritesMeta(t *testing.T) {
+	rewrites := &HistoryRewritesAnalysis{}
+	assert.Equal(t, rewrites.Name(), "HistoryRewrites")
+	assert.Len(t, rewrites.Provides(), 0)
+	assert.Equal(t, rewrites.Requires(), []string{identity.DependencyAuthor})
+	assert.Len(t, rewrites.ListConfigurationOptions(), 0)
+	assert.Equal(t, rewrites.Flag(), "history-rewrites")
+	assert.NotEmpty(t, rewrites.Description())
+	rewrites.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorPeopleDict:         map[string]int{"bob": 0},
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"bob"},
+	})
+	assert.Equal(t, rewrites.peopleDict, map[string]int{"bob": 0})
+	assert.Equal(t, rewrites.reversedPeopleDict, []string{"bob"})
+	summoned := core.Registry.Summon((&HistoryRewritesAnalysis{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), rewrites.Name())
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == rewrites.Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestParseReflogLine(t *testing.T) {
+	entry, ok := parseReflogLine(
+		"0000000000000000000000000000000000000000 a3ee37f91f0d705ec9c5ae2fb1d6fa5d1d2f0000 " +
+			"Bob Smith <bob@x.com> 1500000000 +0200\tcommit (initial): first")
+	assert.True(t, ok)
+	assert.Equal(t, entry.old, plumbing.ZeroHash)
+	assert.Equal(t, entry.new, plumbing.NewHash("a3ee37f91f0d705ec9c5ae2fb1d6fa5d1d2f0000"))
+	assert.Equal(t, entry.name, "Bob Smith")
+	assert.Equal(t, entry.email, "bob@x.com")
+	assert.Equal(t, entry.time, int64(1500000000))
+	assert.Equal(t, entry.message, "commit (initial): first")
+	_, ok = parseReflogLine("garbage")
+	assert.False(t, ok)
+	_, ok = parseReflogLine(
+		"0000000000000000000000000000000000000000 a3ee37f91f0d705ec9c5ae2fb1d6fa5d1d2f0000 " +
+			"no email 1500000000 +0200")
+	assert.False(t, ok)
+}
+
+func commitHistoryRewritesFile(t *testing.T, repo *git.Repository, dir, file, text, author string) plumbing.Hash {
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, file), []byte(text), 0666))
+	worktree, err := repo.Worktree()
+	assert.Nil(t, err)
+	_, err = worktree.Add(file)
+	assert.Nil(t, err)
+	hash, err := worktree.Commit(file, &git.CommitOptions{Author: &object.Signature{
+		Name: author, Email: author + "@x.com", When: time.Unix(1500000000, 0)}})
+	assert.Nil(t, err)
+	return hash
+}
+
+func TestHistoryRewritesFinalize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	repo, err := git.PlainInit(dir, false)
+	assert.Nil(t, err)
+	c1 := commitHistoryRewritesFile(t, repo, dir, "a.txt", "1\n2\n", "bob")
+	c2 := commitHistoryRewritesFile(t, repo, dir, "a.txt", "1\n2\n3\n", "alice")
+	master := plumbing.NewBranchReferenceName("master")
+	// amend c2
+	assert.Nil(t, repo.Storer.SetReference(plumbing.NewHashReference(master, c1)))
+	c3 := commitHistoryRewritesFile(t, repo, dir, "a.txt", "1\n2\nthree\n", "alice")
+	worktree, _ := repo.Worktree()
+	feature := plumbing.NewBranchReferenceName("feature")
+	assert.Nil(t, worktree.Checkout(&git.CheckoutOptions{Branch: feature, Create: true}))
+	f1 := commitHistoryRewritesFile(t, repo, dir, "b.txt", "x\ny\n", "bob")
+	// force reset feature
+	assert.Nil(t, repo.Storer.SetReference(plumbing.NewHashReference(feature, c3)))
+	assert.Nil(t, repo.Storer.SetReference(plumbing.NewHashReference(
+		plumbing.ReferenceName("refs/original/refs/heads/master"), c2)))
+	pruned := plumbing.NewHash("1111111111111111111111111111111111111111")
+	writeLog := func(ref string, lines ...string) {
+		name := filepath.Join(dir, ".git", "logs", filepath.FromSlash(ref))
+		assert.Nil(t, os.MkdirAll(filepath.Dir(name), 0777))
+		text := ""
+		for _, line := range lines {
+			text += line + "\n"
+		}
+		assert.Nil(t, ioutil.WriteFile(name, []byte(text), 0666))
+	}
+	line := func(old, new plumbing.Hash, who string, when int, message string) string {
+		return fmt.Sprintf("%s %s %s <%s@x.com> %d +0000\t%s", old, new, who, who, when, message)
+	}
+	writeLog("refs/heads/master",
+		line(plumbing.ZeroHash, c1, "bob", 1500000000, "commit (initial): a.txt"),
+		line(c1, c2, "alice", 1500000100, "commit: a.txt"),
+		line(c2, c3, "alice", 1500000200, "commit (amend): a.txt"))
+	writeLog("refs/heads/feature",
+		line(c3, f1, "bob", 1500000250, "commit: b.txt"),
+		line(f1, c3, "bob", 1500000300, "reset: moving to HEAD~1"))
+	writeLog("refs/heads/gone", line(pruned, c3, "carol", 1500000400, "update by push"))
+	writeLog("refs/stash", line(f1, c1, "bob", 1500000500, "WIP"))
+	writeLog("HEAD", line(f1, c1, "bob", 1500000600, "checkout: moving from feature to master"))
+
+	rewrites := &HistoryRewritesAnalysis{}
+	rewrites.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorPeopleDict:         map[string]int{"bob@x.com": 0},
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"bob|bob@x.com"},
+	})
+	rewrites.Initialize(repo)
+	result := rewrites.Finalize().(HistoryRewritesResult)
+	assert.Equal(t, result.reversedPeopleDict, []string{"bob|bob@x.com"})
+	assert.Equal(t, result.Rewrites, []HistoryRewrite{{
+		Ref: "refs/heads/master", Time: 0, Actor: -1, Old: c2.String(), New: c3.String(),
+		Message: "refs/original", Commits: 1, Added: 1, Removed: 0, Authors: map[int]int{-1: 1},
+	}, {
+		Ref: "refs/heads/master", Time: 1500000200, Actor: -1, Old: c2.String(), New: c3.String(),
+		Message: "commit (amend): a.txt", Commits: 1, Added: 1, Removed: 0,
+		Authors: map[int]int{-1: 1},
+	}, {
+		Ref: "refs/heads/feature", Time: 1500000300, Actor: 0, Old: f1.String(), New: c3.String(),
+		Message: "reset: moving to HEAD~1", Commits: 1, Added: 2, Removed: 0,
+		Authors: map[int]int{0: 1},
+	}, {
+		Ref: "refs/heads/gone", Time: 1500000400, Actor: -1, Old: pruned.String(),
+		New: c3.String(), Message: "update by push", Authors: map[int]int{}, Pruned: true,
+	}})
+
+	memRepo, err := git.Init(memory.NewStorage(), nil)
+	assert.Nil(t, err)
+	rewrites.Initialize(memRepo)
+	result = rewrites.Finalize().(HistoryRewritesResult)
+	assert.Len(t, result.Rewrites, 0)
+}
+
+func fixtureHistoryRewritesResult() HistoryRewritesResult {
+	return HistoryRewritesResult{
+		Rewrites: []HistoryRewrite{{
+			Ref: "refs/heads/master", Time: 1500000200, Actor: 0,
+			Old: "1111111111111111111111111111111111111111",
+			New: "2222222222222222222222222222222222222222", Message: "update by push: forced",
+			Commits: 3, Added: 10, Removed: 4, Authors: map[int]int{0: 2, -1: 1},
+		}, {
+			Ref: "refs/heads/dev", Time: 1500000300, Actor: -1,
+			Old: "3333333333333333333333333333333333333333",
+			New: "4444444444444444444444444444444444444444", Message: "rebase",
+			Authors: map[int]int{}, Pruned: true,
+		}},
+		reversedPeopleDict: []string{"bob|bob@x.com"},
+	}
+}
+
+func TestHistoryRewritesSerialize(t *testing.T) {
+	rewrites := &HistoryRewritesAnalysis{}
+	result := fixtureHistoryRewritesResult()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, rewrites.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  rewrites:
+    - ref: "refs/heads/master"
+      time: 1500000200
+      actor: 0
+      old: 1111111111111111111111111111111111111111
+      new: 2222222222222222222222222222222222222222
+      message: "update by push: forced"
+      commits: 3
+      added: 10
+      removed: 4
+      authors: {-1: 1, 0: 2}
+      pruned: false
+    - ref: "refs/heads/dev"
+      time: 1500000300
+      actor: -1
+      old: 3333333333333333333333333333333333333333
+      new: 4444444444444444444444444444444444444444
+      message: "rebase"
+      commits: 0
+      added: 0
+      removed: 0
+      authors: {}
+      pruned: true
+  people:
+  - "bob|bob@x.com"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, rewrites.Serialize(result, true, buffer))
+	restored, err := rewrites.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, restored, result)
+}
+
+func TestHistoryRewritesMergeResults(t *testing.T) {
+	rewrites := &HistoryRewritesAnalysis{}
+	r1 := fixtureHistoryRewritesResult()
+	r2 := HistoryRewritesResult{
+		Rewrites: []HistoryRewrite{{
+			Ref: "refs/heads/main", Time: 1500000250, Actor: 0, Commits: 1,
+			Authors: map[int]int{0: 1, 1: 2}}},
+		reversedPeopleDict: []string{"alice|alice@x.com", "bob|bob@x.com"},
+	}
+	merged := rewrites.MergeResults(r1, r2, &core.CommonAnalysisResult{},
+		&core.CommonAnalysisResult{}).(HistoryRewritesResult)
+	assert.Equal(t, merged.reversedPeopleDict, []string{"bob|bob@x.com", "alice|alice@x.com"})
+	assert.Len(t, merged.Rewrites, 3)
+	assert.Equal(t, merged.Rewrites[0].Ref, "refs/heads/master")
+	assert.Equal(t, merged.Rewrites[0].Actor, 0)
+	assert.Equal(t, merged.Rewrites[0].Authors, map[int]int{0: 2, -1: 1})
+	assert.Equal(t, merged.Rewrites[1].Ref, "refs/heads/main")
+	assert.Equal(t, merged.Rewrites[1].Actor, 1)
+	assert.Equal(t, merged.Rewrites[1].Authors, map[int]int{0: 2, 1: 1})
+	assert.Equal(t, merged.Rewrites[2].Actor, -1)
+}
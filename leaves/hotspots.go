@@ -0,0 +1,372 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// HotspotsAnalysis finds the files which are both complex and frequently changed. Those are
+// the places where the maintenance effort concentrates and the defects are likely. Each file
+// collects the number of commits which changed it and the number of changed lines; its complexity
+// is measured on the latest version as the indentation complexity: the sum of the indentation
+// levels of the non-blank lines, a tab or four spaces being a level. It does not depend on
+// the language and correlates well with the cyclomatic complexity. The score is the product of
+// the commits and the complexity normalized by their maximums among the current files, so
+// it is between 0 and 1. The binary files and the merge commits are skipped.
+type HotspotsAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// TopFiles is the number of the hotspots to report.
+	TopFiles int
+
+	// files map the current file names to their statistics.
+	files map[string]*Hotspot
+}
+
+// Hotspot is the change frequency and the complexity of a file.
+type Hotspot struct {
+	File string
+	// Commits is the number of commits which changed the file, including the one which created it.
+	Commits int
+	// Churn is the number of added and removed lines.
+	Churn int
+	// Lines is the number of lines in the latest version.
+	Lines int
+	// Complexity is the indentation complexity of the latest version.
+	Complexity int
+	// Score is the product of the normalized Commits and Complexity.
+	Score float64
+}
+
+// HotspotsResult is returned by HotspotsAnalysis.Finalize().
+type HotspotsResult struct {
+	// Hotspots are sorted by Score in the descending order.
+	Hotspots []Hotspot
+}
+
+const (
+	// ConfigHotspotsTopFiles is the name of the option to set HotspotsAnalysis.TopFiles.
+	ConfigHotspotsTopFiles = "Hotspots.TopFiles"
+	// DefaultHotspotsTopFiles is the default value of HotspotsAnalysis.TopFiles.
+	DefaultHotspotsTopFiles = 50
+	// hotspotsIndentWidth is the number of spaces in an indentation level.
+	hotspotsIndentWidth = 4
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (hotspots *HotspotsAnalysis) Name() string {
+	return "Hotspots"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (hotspots *HotspotsAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (hotspots *HotspotsAnalysis) Requires() []string {
+	arr := [...]string{
+		items.DependencyTreeChanges, items.DependencyFileDiff, items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (hotspots *HotspotsAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigHotspotsTopFiles,
+		Description: "Number of the hotspots to report.",
+		Flag:        "hotspots-top",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultHotspotsTopFiles},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (hotspots *HotspotsAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigHotspotsTopFiles].(int); exists {
+		hotspots.TopFiles = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (hotspots *HotspotsAnalysis) Flag() string {
+	return "hotspots"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (hotspots *HotspotsAnalysis) Description() string {
+	return "Ranks the files by the product of their change frequency and their " +
+		"indentation complexity."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (hotspots *HotspotsAnalysis) Initialize(repository *git.Repository) {
+	if hotspots.TopFiles <= 0 {
+		log.Printf("Warning: adjusted the number of the hotspots to %d\n",
+			DefaultHotspotsTopFiles)
+		hotspots.TopFiles = DefaultHotspotsTopFiles
+	}
+	hotspots.files = map[string]*Hotspot{}
+	hotspots.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (hotspots *HotspotsAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !hotspots.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	for _, change := range deps[items.DependencyTreeChanges].(object.Changes) {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		var file *Hotspot
+		switch action {
+		case merkletrie.Delete:
+			delete(hotspots.files, change.From.Name)
+			continue
+		case merkletrie.Insert:
+			file = &Hotspot{File: change.To.Name}
+		case merkletrie.Modify:
+			file = hotspots.files[change.From.Name]
+			delete(hotspots.files, change.From.Name)
+			if file == nil {
+				// used to be binary
+				file = &Hotspot{}
+			}
+			file.File = change.To.Name
+		}
+		text, err := items.BlobToString(cache[change.To.TreeEntry.Hash])
+		if err != nil {
+			return nil, err
+		}
+		if strings.IndexByte(text, 0) >= 0 {
+			// binary
+			continue
+		}
+		file.Lines, file.Complexity = indentationComplexity(text)
+		file.Commits++
+		if action == merkletrie.Insert {
+			file.Churn += file.Lines
+		} else {
+			for _, edit := range fileDiffs[file.File].Diffs {
+				// FileDiff encodes each line as a single rune
+				if edit.Type != diffmatchpatch.DiffEqual {
+					file.Churn += utf8.RuneCountInString(edit.Text)
+				}
+			}
+		}
+		hotspots.files[file.File] = file
+	}
+	return nil, nil
+}
+
+// indentationComplexity returns the number of lines and the sum of the indentation levels
+// of the non-blank lines. Incomplete levels are rounded up.
+func indentationComplexity(text string) (lines int, complexity int) {
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if line == "" {
+			continue
+		}
+		lines++
+		width := 0
+		for _, char := range line {
+			if char == ' ' {
+				width++
+			} else if char == '\t' {
+				width += hotspotsIndentWidth
+			} else {
+				if char != '\n' && char != '\r' {
+					complexity += (width + hotspotsIndentWidth - 1) / hotspotsIndentWidth
+				}
+				break
+			}
+		}
+	}
+	return lines, complexity
+}
+
+// rankHotspots calculates the scores and returns the `size` files with the highest ones.
+func rankHotspots(files []Hotspot, size int) []Hotspot {
+	maxCommits, maxComplexity := 0, 0
+	for _, file := range files {
+		if file.Commits > maxCommits {
+			maxCommits = file.Commits
+		}
+		if file.Complexity > maxComplexity {
+			maxComplexity = file.Complexity
+		}
+	}
+	for i := range files {
+		files[i].Score = 0
+		if maxCommits > 0 && maxComplexity > 0 {
+			files[i].Score = float64(files[i].Commits) / float64(maxCommits) *
+				float64(files[i].Complexity) / float64(maxComplexity)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Score != files[j].Score {
+			return files[i].Score > files[j].Score
+		}
+		return files[i].File < files[j].File
+	})
+	if len(files) > size {
+		files = files[:size]
+	}
+	return files
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (hotspots *HotspotsAnalysis) Finalize() interface{} {
+	files := make([]Hotspot, 0, len(hotspots.files))
+	for _, file := range hotspots.files {
+		files = append(files, *file)
+	}
+	return HotspotsResult{Hotspots: rankHotspots(files, hotspots.TopFiles)}
+}
+
+// Fork clones this pipeline item.
+func (hotspots *HotspotsAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(hotspots, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (hotspots *HotspotsAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	hotspotsResult := result.(HotspotsResult)
+	if binary {
+		return hotspots.serializeBinary(&hotspotsResult, writer)
+	}
+	hotspots.serializeText(&hotspotsResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (hotspots *HotspotsAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(hotspots, result, &pb.HotspotsAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (hotspots *HotspotsAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(hotspots, result, &pb.HotspotsAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to HotspotsResult.
+func (hotspots *HotspotsAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.HotspotsAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := HotspotsResult{Hotspots: make([]Hotspot, len(message.Hotspots))}
+	for i, file := range message.Hotspots {
+		result.Hotspots[i] = Hotspot{
+			File:       file.File,
+			Commits:    int(file.Commits),
+			Churn:      int(file.Churn),
+			Lines:      int(file.Lines),
+			Complexity: int(file.Complexity),
+			Score:      file.Score,
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two HotspotsResult-s together. The commits and the churn of the same
+// files are summed, the lines and the complexity are taken from the result which ends later.
+// The scores are calculated again. Since only the top files are stored, the merged ranking
+// is approximate.
+func (hotspots *HotspotsAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	hr1 := r1.(HotspotsResult)
+	hr2 := r2.(HotspotsResult)
+	older, newer := hr1.Hotspots, hr2.Hotspots
+	if c1.EndTime > c2.EndTime {
+		older, newer = newer, older
+	}
+	merged := map[string]*Hotspot{}
+	for _, list := range [...][]Hotspot{older, newer} {
+		for _, file := range list {
+			mergedFile := merged[file.File]
+			if mergedFile == nil {
+				mergedFile = &Hotspot{File: file.File}
+				merged[file.File] = mergedFile
+			}
+			mergedFile.Commits += file.Commits
+			mergedFile.Churn += file.Churn
+			mergedFile.Lines = file.Lines
+			mergedFile.Complexity = file.Complexity
+		}
+	}
+	files := make([]Hotspot, 0, len(merged))
+	for _, file := range merged {
+		files = append(files, *file)
+	}
+	size := len(hr1.Hotspots)
+	if len(hr2.Hotspots) > size {
+		size = len(hr2.Hotspots)
+	}
+	return HotspotsResult{Hotspots: rankHotspots(files, size)}
+}
+
+func (hotspots *HotspotsAnalysis) serializeText(result *HotspotsResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  # [file, commits, churn, lines, complexity, score]")
+	fmt.Fprintln(writer, "  hotspots:")
+	for _, file := range result.Hotspots {
+		fmt.Fprintf(writer, "    - [%s, %d, %d, %d, %d, %.4f]\n", yaml.SafeString(file.File),
+			file.Commits, file.Churn, file.Lines, file.Complexity, file.Score)
+	}
+}
+
+func (hotspots *HotspotsAnalysis) serializeBinary(result *HotspotsResult, writer io.Writer) error {
+	message := pb.HotspotsAnalysisResults{
+		Hotspots: make([]*pb.Hotspot, len(result.Hotspots)),
+	}
+	for i, file := range result.Hotspots {
+		message.Hotspots[i] = &pb.Hotspot{
+			File:       file.File,
+			Commits:    int32(file.Commits),
+			Churn:      int32(file.Churn),
+			Lines:      int32(file.Lines),
+			Complexity: int32(file.Complexity),
+			Score:      file.Score,
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&HotspotsAnalysis{})
+}
@@ -0,0 +1,169 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func fixtureHotspots() *HotspotsAnalysis {
+	hotspots := HotspotsAnalysis{}
+	hotspots.Configure(map[string]interface{}{ConfigHotspotsTopFiles: 2})
+	hotspots.Initialize(nil)
+	return &hotspots
+}
+
+func TestHotspotsMeta(t *testing.T) {
+	hotspots := fixtureHotspots()
+	assert.Equal(t, hotspots.Name(), "Hotspots")
+	assert.Len(t, hotspots.Provides(), 0)
+	assert.Equal(t, hotspots.Requires(), []string{
+		items.DependencyTreeChanges, items.DependencyFileDiff, items.DependencyBlobCache})
+	assert.Equal(t, hotspots.Flag(), "hotspots")
+	assert.NotEmpty(t, hotspots.Description())
+	opts := hotspots.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Flag, "hotspots-top")
+	assert.Equal(t, hotspots.TopFiles, 2)
+	hotspots = &HotspotsAnalysis{}
+	hotspots.Initialize(nil)
+	assert.Equal(t, hotspots.TopFiles, DefaultHotspotsTopFiles)
+	summoned := core.Registry.Summon(hotspots.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Hotspots")
+}
+
+func TestHotspotsIndentationComplexity(t *testing.T) {
+	lines, complexity := indentationComplexity("\tfoo\n    bar\n  \n\t  baz\r\n")
+	assert.Equal(t, lines, 4)
+	assert.Equal(t, complexity, 4)
+	lines, complexity = indentationComplexity("a\n b")
+	assert.Equal(t, lines, 2)
+	assert.Equal(t, complexity, 1)
+	lines, complexity = indentationComplexity("")
+	assert.Equal(t, lines, 0)
+	assert.Equal(t, complexity, 0)
+}
+
+func fixtureHotspotsResult(t *testing.T) HotspotsResult {
+	storage := memory.NewStorage()
+	cache := map[plumbing.Hash]*object.Blob{}
+	entry := func(name, contents string) object.ChangeEntry {
+		encoded := storage.NewEncodedObject()
+		encoded.SetType(plumbing.BlobObject)
+		writer, _ := encoded.Writer()
+		writer.Write([]byte(contents))
+		writer.Close()
+		hash, err := storage.SetEncodedObject(encoded)
+		assert.Nil(t, err)
+		cache[hash], err = object.GetBlob(storage, hash)
+		assert.Nil(t, err)
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	diff := func(before, after string) items.FileDiffData {
+		dmp := diffmatchpatch.New()
+		src, dst, _ := dmp.DiffLinesToRunes(before, after)
+		return items.FileDiffData{
+			OldLinesOfCode: len(src), NewLinesOfCode: len(dst),
+			Diffs: dmp.DiffMainRunes(src, dst, false)}
+	}
+	hotspots := fixtureHotspots()
+	consume := func(changes object.Changes, diffs map[string]items.FileDiffData) {
+		result, err := hotspots.Consume(map[string]interface{}{
+			core.DependencyCommit:       &object.Commit{},
+			core.DependencyIsMerge:      false,
+			items.DependencyTreeChanges: changes,
+			items.DependencyFileDiff:    diffs,
+			items.DependencyBlobCache:   cache,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	a1 := "func a() {\n\tif x {\n\t\treturn\n\t}\n}\n"
+	a2 := "func a() {\n\tif x {\n\t\treturn\n\t}\n\treturn\n}\n"
+	b1 := "x = 1\n\ny = 2\n"
+	b2 := "x = 1\n  y = 2\n"
+	consume(object.Changes{
+		{To: entry("a.go", a1)},
+		{To: entry("b.py", b1)},
+		{To: entry("image.png", "\x00PNG")},
+		{To: entry("e.go", "package e\n\tvar x\n")},
+	}, nil)
+	consume(object.Changes{
+		{From: entry("a.go", a1), To: entry("a.go", a2)},
+		{From: entry("b.py", b1), To: entry("c.py", b2)},
+		{From: entry("image.png", "\x00PNG"), To: entry("image.png", "\x00GIF")},
+		{To: entry("d.go", "package d\n")},
+	}, map[string]items.FileDiffData{"a.go": diff(a1, a2), "c.py": diff(b1, b2)})
+	consume(object.Changes{{From: entry("e.go", "package e\n\tvar x\n")}}, nil)
+	return hotspots.Finalize().(HotspotsResult)
+}
+
+func TestHotspotsConsumeFinalize(t *testing.T) {
+	result := fixtureHotspotsResult(t)
+	assert.Equal(t, result.Hotspots, []Hotspot{
+		{File: "a.go", Commits: 2, Churn: 6, Lines: 6, Complexity: 5, Score: 1},
+		{File: "c.py", Commits: 2, Churn: 6, Lines: 2, Complexity: 1, Score: 0.2},
+	})
+}
+
+func TestHotspotsConsumeMerge(t *testing.T) {
+	hotspots := fixtureHotspots()
+	result, err := hotspots.Consume(map[string]interface{}{
+		core.DependencyCommit: &object.Commit{
+			ParentHashes: make([]plumbing.Hash, 2)},
+		core.DependencyIsMerge: true,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+	assert.Len(t, hotspots.Finalize().(HotspotsResult).Hotspots, 0)
+}
+
+func TestHotspotsSerialize(t *testing.T) {
+	result := fixtureHotspotsResult(t)
+	hotspots := fixtureHotspots()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, hotspots.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  # [file, commits, churn, lines, complexity, score]
+  hotspots:
+    - ["a.go", 2, 6, 6, 5, 1.0000]
+    - ["c.py", 2, 6, 2, 1, 0.2000]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, hotspots.Serialize(result, true, buffer))
+	msg := pb.HotspotsAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Hotspots, 2)
+	assert.Equal(t, msg.Hotspots[1].File, "c.py")
+	assert.Equal(t, msg.Hotspots[1].Score, 0.2)
+	deserialized, err := hotspots.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestHotspotsMergeResults(t *testing.T) {
+	r1 := HotspotsResult{Hotspots: []Hotspot{
+		{File: "a.go", Commits: 2, Churn: 6, Lines: 6, Complexity: 5, Score: 1},
+		{File: "b.go", Commits: 1, Churn: 1, Lines: 1, Complexity: 1, Score: 0.2},
+	}}
+	r2 := HotspotsResult{Hotspots: []Hotspot{
+		{File: "a.go", Commits: 1, Churn: 2, Lines: 10, Complexity: 10, Score: 1},
+	}}
+	hotspots := fixtureHotspots()
+	merged := hotspots.MergeResults(r2, r1,
+		&core.CommonAnalysisResult{EndTime: 200},
+		&core.CommonAnalysisResult{EndTime: 100}).(HotspotsResult)
+	assert.Equal(t, merged.Hotspots, []Hotspot{
+		{File: "a.go", Commits: 3, Churn: 8, Lines: 10, Complexity: 10, Score: 1},
+		{File: "b.go", Commits: 1, Churn: 1, Lines: 1, Complexity: 1, Score: 1.0 / 30},
+	})
+}
@@ -0,0 +1,341 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// ImpactChurnAnalysis counts the added and removed lines on each day together with the
+// impact-weighted churn: every changed line is multiplied by 1 + the fan-in of its file
+// (see items.FileFanIn). Thus edits to the core modules weigh more than edits to the leaf
+// utilities. The merge commits are skipped.
+type ImpactChurnAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// FanInMode is the way to calculate the fan-in, copied from items.ConfigFileFanInMode.
+	FanInMode string
+
+	// days maps the day index to the churn on that day.
+	days map[int]ImpactChurnDay
+	// files maps the file names to their churn.
+	files map[string]ImpactChurnFile
+}
+
+// ImpactChurnDay is the churn on a single day.
+type ImpactChurnDay struct {
+	Added   int
+	Removed int
+	// Weighted is the sum of the changed lines multiplied by 1 + fan-in of each file.
+	Weighted float64
+}
+
+// ImpactChurnFile is the churn of a single file.
+type ImpactChurnFile struct {
+	// Lines is the total number of added and removed lines.
+	Lines int
+	// FanIn is the fan-in at the time of the last change.
+	FanIn    int
+	Weighted float64
+}
+
+// ImpactChurnResult is returned by ImpactChurnAnalysis.Finalize().
+type ImpactChurnResult struct {
+	// Days maps the day index to the churn on that day.
+	Days map[int]ImpactChurnDay
+	// Files maps the file names to their churn. The files are named as they were at the time
+	// of the changes.
+	Files map[string]ImpactChurnFile
+	// FanInMode is either items.FanInModeCoupling or items.FanInModeImports.
+	FanInMode string
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (churn *ImpactChurnAnalysis) Name() string {
+	return "ImpactChurn"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (churn *ImpactChurnAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (churn *ImpactChurnAnalysis) Requires() []string {
+	arr := [...]string{
+		items.DependencyFileDiff, items.DependencyTreeChanges, items.DependencyBlobCache,
+		items.DependencyDay, items.DependencyFileFanIn}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (churn *ImpactChurnAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (churn *ImpactChurnAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[items.ConfigFileFanInMode].(string); exists {
+		churn.FanInMode = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (churn *ImpactChurnAnalysis) Flag() string {
+	return "impact-churn"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (churn *ImpactChurnAnalysis) Description() string {
+	return "Counts the daily added and removed lines and weights them by the fan-in " +
+		"of the changed files, see --fan-in."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (churn *ImpactChurnAnalysis) Initialize(repository *git.Repository) {
+	if churn.FanInMode != items.FanInModeImports {
+		churn.FanInMode = items.FanInModeCoupling
+	}
+	churn.days = map[int]ImpactChurnDay{}
+	churn.files = map[string]ImpactChurnFile{}
+	churn.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (churn *ImpactChurnAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !churn.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	fanIns := deps[items.DependencyFileFanIn].(map[string]int)
+	day := deps[items.DependencyDay].(int)
+	dayChurn := churn.days[day]
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		var name string
+		var added, removed int
+		switch action {
+		case merkletrie.Insert:
+			name = change.To.Name
+			added, err = items.CountLines(cache[change.To.TreeEntry.Hash])
+		case merkletrie.Delete:
+			name = change.From.Name
+			removed, err = items.CountLines(cache[change.From.TreeEntry.Hash])
+		case merkletrie.Modify:
+			name = change.To.Name
+			for _, edit := range fileDiffs[name].Diffs {
+				// FileDiff encodes each line as a single rune
+				switch edit.Type {
+				case diffmatchpatch.DiffInsert:
+					added += utf8.RuneCountInString(edit.Text)
+				case diffmatchpatch.DiffDelete:
+					removed += utf8.RuneCountInString(edit.Text)
+				}
+			}
+		}
+		if err != nil {
+			if err.Error() == "binary" {
+				continue
+			}
+			return nil, err
+		}
+		weight := float64(1 + fanIns[name])
+		dayChurn.Added += added
+		dayChurn.Removed += removed
+		dayChurn.Weighted += weight * float64(added+removed)
+		file := churn.files[name]
+		file.Lines += added + removed
+		file.FanIn = fanIns[name]
+		file.Weighted += weight * float64(added+removed)
+		churn.files[name] = file
+	}
+	churn.days[day] = dayChurn
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (churn *ImpactChurnAnalysis) Finalize() interface{} {
+	return ImpactChurnResult{
+		Days:      churn.days,
+		Files:     churn.files,
+		FanInMode: churn.FanInMode,
+	}
+}
+
+// Fork clones this pipeline item.
+func (churn *ImpactChurnAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(churn, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (churn *ImpactChurnAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	churnResult := result.(ImpactChurnResult)
+	if binary {
+		return churn.serializeBinary(&churnResult, writer)
+	}
+	churn.serializeText(&churnResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (churn *ImpactChurnAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(churn, result, &pb.ImpactChurnAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (churn *ImpactChurnAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(churn, result, &pb.ImpactChurnAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to ImpactChurnResult.
+func (churn *ImpactChurnAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.ImpactChurnAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := ImpactChurnResult{
+		Days:      map[int]ImpactChurnDay{},
+		Files:     map[string]ImpactChurnFile{},
+		FanInMode: message.FanInMode,
+	}
+	for day, dayChurn := range message.Days {
+		result.Days[int(day)] = ImpactChurnDay{
+			Added:    int(dayChurn.Added),
+			Removed:  int(dayChurn.Removed),
+			Weighted: dayChurn.Weighted,
+		}
+	}
+	for name, file := range message.Files {
+		result.Files[name] = ImpactChurnFile{
+			Lines:    int(file.Lines),
+			FanIn:    int(file.FanIn),
+			Weighted: file.Weighted,
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two ImpactChurnResult-s together. The fan-in of a file which exists
+// in both results is the maximum.
+func (churn *ImpactChurnAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	cr1 := r1.(ImpactChurnResult)
+	cr2 := r2.(ImpactChurnResult)
+	merged := ImpactChurnResult{
+		Days:      map[int]ImpactChurnDay{},
+		Files:     map[string]ImpactChurnFile{},
+		FanInMode: cr1.FanInMode,
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	add := func(result *ImpactChurnResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for day, dayChurn := range result.Days {
+			mergedDay := merged.Days[day+offset]
+			mergedDay.Added += dayChurn.Added
+			mergedDay.Removed += dayChurn.Removed
+			mergedDay.Weighted += dayChurn.Weighted
+			merged.Days[day+offset] = mergedDay
+		}
+		for name, file := range result.Files {
+			mergedFile := merged.Files[name]
+			mergedFile.Lines += file.Lines
+			mergedFile.Weighted += file.Weighted
+			if file.FanIn > mergedFile.FanIn {
+				mergedFile.FanIn = file.FanIn
+			}
+			merged.Files[name] = mergedFile
+		}
+	}
+	add(&cr1, c1)
+	add(&cr2, c2)
+	return merged
+}
+
+func (churn *ImpactChurnAnalysis) serializeText(result *ImpactChurnResult, writer io.Writer) {
+	fmt.Fprintf(writer, "  fan_in_mode: %s\n", result.FanInMode)
+	fmt.Fprintln(writer, "  days:")
+	days := make([]int, 0, len(result.Days))
+	for day := range result.Days {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	for _, day := range days {
+		dayChurn := result.Days[day]
+		fmt.Fprintf(writer, "    %d: {added: %d, removed: %d, weighted: %.4f}\n",
+			day, dayChurn.Added, dayChurn.Removed, dayChurn.Weighted)
+	}
+	fmt.Fprintln(writer, "  files:")
+	names := make([]string, 0, len(result.Files))
+	for name := range result.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		file := result.Files[name]
+		fmt.Fprintf(writer, "    %s: {lines: %d, fan_in: %d, weighted: %.4f}\n",
+			yaml.SafeString(name), file.Lines, file.FanIn, file.Weighted)
+	}
+}
+
+func (churn *ImpactChurnAnalysis) serializeBinary(result *ImpactChurnResult, writer io.Writer) error {
+	message := pb.ImpactChurnAnalysisResults{
+		Days:      map[int32]*pb.ImpactChurnDay{},
+		Files:     map[string]*pb.ImpactChurnFile{},
+		FanInMode: result.FanInMode,
+	}
+	for day, dayChurn := range result.Days {
+		message.Days[int32(day)] = &pb.ImpactChurnDay{
+			Added:    int32(dayChurn.Added),
+			Removed:  int32(dayChurn.Removed),
+			Weighted: dayChurn.Weighted,
+		}
+	}
+	for name, file := range result.Files {
+		message.Files[name] = &pb.ImpactChurnFile{
+			Lines:    int32(file.Lines),
+			FanIn:    int32(file.FanIn),
+			Weighted: file.Weighted,
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&ImpactChurnAnalysis{})
+}
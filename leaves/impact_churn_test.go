@@ -0,0 +1,147 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func fixtureImpactChurn() *ImpactChurnAnalysis {
+	churn := ImpactChurnAnalysis{}
+	churn.Configure(map[string]interface{}{items.ConfigFileFanInMode: items.FanInModeImports})
+	churn.Initialize(nil)
+	return &churn
+}
+
+func TestImpactChurnMeta(t *testing.T) {
+	churn := fixtureImpactChurn()
+	assert.Equal(t, churn.Name(), "ImpactChurn")
+	assert.Len(t, churn.Provides(), 0)
+	assert.Contains(t, churn.Requires(), items.DependencyFileFanIn)
+	assert.Contains(t, churn.Requires(), items.DependencyFileDiff)
+	assert.Len(t, churn.ListConfigurationOptions(), 0)
+	assert.Equal(t, churn.Flag(), "impact-churn")
+	assert.NotEmpty(t, churn.Description())
+	assert.Equal(t, churn.FanInMode, items.FanInModeImports)
+	churn = &ImpactChurnAnalysis{FanInMode: "xxx"}
+	churn.Initialize(nil)
+	assert.Equal(t, churn.FanInMode, items.FanInModeCoupling)
+	summoned := core.Registry.Summon(churn.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "ImpactChurn")
+}
+
+func fixtureImpactChurnResult(t *testing.T) ImpactChurnResult {
+	storage := memory.NewStorage()
+	encoded := storage.NewEncodedObject()
+	encoded.SetType(plumbing.BlobObject)
+	writer, _ := encoded.Writer()
+	writer.Write([]byte("one\ntwo\nthree\n"))
+	writer.Close()
+	hash, err := storage.SetEncodedObject(encoded)
+	assert.Nil(t, err)
+	blob, err := object.GetBlob(storage, hash)
+	assert.Nil(t, err)
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	churn := fixtureImpactChurn()
+	consume := func(day int, merge bool, changes object.Changes, fileDiffs map[string]items.FileDiffData,
+		fanIns map[string]int) {
+		commit := &object.Commit{}
+		if merge {
+			commit.Hash = plumbing.NewHash("0123456789012345678901234567890123456789")
+			commit.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		result, err := churn.Consume(map[string]interface{}{
+			items.DependencyBlobCache:   map[plumbing.Hash]*object.Blob{hash: blob},
+			items.DependencyTreeChanges: changes,
+			items.DependencyFileDiff:    fileDiffs,
+			items.DependencyFileFanIn:   fanIns,
+			items.DependencyDay:         day,
+			core.DependencyCommit:       commit,
+			core.DependencyIsMerge:      merge,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	consume(0, false, object.Changes{{To: entry("core.go")}, {To: entry("util.go")}},
+		map[string]items.FileDiffData{}, map[string]int{"core.go": 4, "util.go": 0})
+	consume(2, false, object.Changes{{From: entry("core.go"), To: entry("core.go")}},
+		map[string]items.FileDiffData{"core.go": {Diffs: []diffmatchpatch.Diff{
+			{Type: diffmatchpatch.DiffEqual, Text: "ab"},
+			{Type: diffmatchpatch.DiffDelete, Text: "c"},
+			{Type: diffmatchpatch.DiffInsert, Text: "de"},
+		}}}, map[string]int{"core.go": 5})
+	consume(2, false, object.Changes{{From: entry("util.go")}},
+		map[string]items.FileDiffData{}, map[string]int{"util.go": 1})
+	consume(3, true, object.Changes{{To: entry("other.go")}},
+		map[string]items.FileDiffData{}, map[string]int{"other.go": 0})
+	return churn.Finalize().(ImpactChurnResult)
+}
+
+func TestImpactChurnConsumeFinalize(t *testing.T) {
+	result := fixtureImpactChurnResult(t)
+	assert.Equal(t, result.FanInMode, items.FanInModeImports)
+	assert.Equal(t, result.Days, map[int]ImpactChurnDay{
+		0: {Added: 6, Weighted: 18},
+		2: {Added: 2, Removed: 4, Weighted: 24},
+	})
+	assert.Equal(t, result.Files, map[string]ImpactChurnFile{
+		"core.go": {Lines: 6, FanIn: 5, Weighted: 33},
+		"util.go": {Lines: 6, FanIn: 1, Weighted: 9},
+	})
+}
+
+func TestImpactChurnSerialize(t *testing.T) {
+	churn := fixtureImpactChurn()
+	result := fixtureImpactChurnResult(t)
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, churn.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  fan_in_mode: imports
+  days:
+    0: {added: 6, removed: 0, weighted: 18.0000}
+    2: {added: 2, removed: 4, weighted: 24.0000}
+  files:
+    "core.go": {lines: 6, fan_in: 5, weighted: 33.0000}
+    "util.go": {lines: 6, fan_in: 1, weighted: 9.0000}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, churn.Serialize(result, true, buffer))
+	msg := pb.ImpactChurnAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.FanInMode, items.FanInModeImports)
+	assert.Equal(t, msg.Days[2].Weighted, float64(24))
+	assert.Equal(t, msg.Files["core.go"].FanIn, int32(5))
+	decoded, err := churn.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, decoded, result)
+}
+
+func TestImpactChurnMergeResults(t *testing.T) {
+	churn := fixtureImpactChurn()
+	r1 := fixtureImpactChurnResult(t)
+	r2 := ImpactChurnResult{
+		Days:      map[int]ImpactChurnDay{0: {Added: 1, Weighted: 3}},
+		Files:     map[string]ImpactChurnFile{"core.go": {Lines: 1, FanIn: 2, Weighted: 3}},
+		FanInMode: items.FanInModeImports,
+	}
+	merged := churn.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0}, &core.CommonAnalysisResult{BeginTime: 2 * 24 * 3600},
+	).(ImpactChurnResult)
+	assert.Equal(t, merged.Days, map[int]ImpactChurnDay{
+		0: {Added: 6, Weighted: 18},
+		2: {Added: 3, Removed: 4, Weighted: 27},
+	})
+	assert.Equal(t, merged.Files["core.go"], ImpactChurnFile{Lines: 7, FanIn: 5, Weighted: 36})
+	assert.Equal(t, merged.Files["util.go"], r1.Files["util.go"])
+}
@@ -0,0 +1,552 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// ImportGraphAnalysis builds the dependency graph of the internal modules from the imports
+// in the UASTs. The modules are the directories of the parsed files, truncated to
+// DirectoryDepth components if it is positive. An import depends on the module whose path
+// matches the imported path or its parent; the imports which do not match any module are
+// external and ignored. The graph is measured every Sampling days: the number of nodes,
+// edges and import cycles. The files which cannot be parsed keep the previous imports.
+// The merge commits are skipped.
+type ImportGraphAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the size of a tick in days.
+	Sampling int
+	// DirectoryDepth is the number of path components which define the modules, 0 means all.
+	DirectoryDepth int
+
+	// files map the file names to the normalized imported paths.
+	files map[string][]string
+	// ticks are the measurements at the end of each finished tick.
+	ticks []ImportGraphTick
+}
+
+// ImportGraphTick is the state of the module dependency graph at the end of a tick.
+type ImportGraphTick struct {
+	// Nodes is the number of modules.
+	Nodes int
+	// Edges is the number of the dependent pairs of modules.
+	Edges int
+	// Cycles is the number of the strongly connected components with more than one module.
+	Cycles int
+	// CyclicNodes is the number of modules which belong to the cycles.
+	CyclicNodes int
+}
+
+// ImportGraphEdge is the dependency of one module on another.
+type ImportGraphEdge struct {
+	From string
+	To   string
+	// Imports is the number of the files in From which import To.
+	Imports int
+}
+
+// ImportGraphResult is returned by ImportGraphAnalysis.Finalize().
+type ImportGraphResult struct {
+	Ticks []ImportGraphTick
+	// Edges are the dependencies in the end of the history, sorted by From and To.
+	Edges []ImportGraphEdge
+	// Sampling is the size of a tick in days.
+	Sampling int
+}
+
+const (
+	// ConfigImportGraphSampling is the name of the option to set ImportGraphAnalysis.Sampling.
+	ConfigImportGraphSampling = "ImportGraph.Sampling"
+	// ConfigImportGraphDirectoryDepth is the name of the option to set
+	// ImportGraphAnalysis.DirectoryDepth.
+	ConfigImportGraphDirectoryDepth = "ImportGraph.DirectoryDepth"
+	// DefaultImportGraphSampling is the default value of ImportGraphAnalysis.Sampling.
+	DefaultImportGraphSampling = 30
+	// DefaultImportGraphDirectoryDepth is the default value of ImportGraphAnalysis.DirectoryDepth:
+	// every directory is a module.
+	DefaultImportGraphDirectoryDepth = 0
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (analyser *ImportGraphAnalysis) Name() string {
+	return "ImportGraph"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (analyser *ImportGraphAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (analyser *ImportGraphAnalysis) Requires() []string {
+	arr := [...]string{uast_items.DependencyUastChanges, items.DependencyDay}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (analyser *ImportGraphAnalysis) Features() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (analyser *ImportGraphAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigImportGraphSampling,
+		Description: "How frequently to measure the module dependency graph in days.",
+		Flag:        "import-graph-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultImportGraphSampling}, {
+		Name:        ConfigImportGraphDirectoryDepth,
+		Description: "Define the modules by this number of the leading path components, 0 means all.",
+		Flag:        "import-graph-dirs",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultImportGraphDirectoryDepth},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (analyser *ImportGraphAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigImportGraphSampling].(int); exists {
+		analyser.Sampling = val
+	}
+	if val, exists := facts[ConfigImportGraphDirectoryDepth].(int); exists {
+		analyser.DirectoryDepth = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (analyser *ImportGraphAnalysis) Flag() string {
+	return "import-graph"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (analyser *ImportGraphAnalysis) Description() string {
+	return "Builds the dependency graph of the internal modules from the imports and measures " +
+		"its nodes, edges and cycles over time."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (analyser *ImportGraphAnalysis) Initialize(repository *git.Repository) {
+	if analyser.Sampling <= 0 {
+		log.Printf("Warning: adjusted the import graph sampling to %d days\n",
+			DefaultImportGraphSampling)
+		analyser.Sampling = DefaultImportGraphSampling
+	}
+	if analyser.DirectoryDepth < 0 {
+		log.Printf("Warning: adjusted the import graph directory depth to %d\n",
+			DefaultImportGraphDirectoryDepth)
+		analyser.DirectoryDepth = DefaultImportGraphDirectoryDepth
+	}
+	analyser.files = map[string][]string{}
+	analyser.ticks = []ImportGraphTick{}
+	analyser.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (analyser *ImportGraphAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !analyser.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	day := deps[items.DependencyDay].(int)
+	if tick := day / analyser.Sampling; tick > len(analyser.ticks) {
+		// the graph does not change between the commits
+		measurement := measureImportGraph(analyser.edges())
+		measurement.Nodes = len(analyser.modules())
+		for len(analyser.ticks) < tick {
+			analyser.ticks = append(analyser.ticks, measurement)
+		}
+	}
+	for _, change := range changes {
+		fromName, toName := change.Change.From.Name, change.Change.To.Name
+		imports, exists := analyser.files[fromName]
+		delete(analyser.files, fromName)
+		if toName == "" {
+			continue
+		}
+		if change.After != nil {
+			imports = extractImports(change.After, toName)
+		} else if !exists {
+			// could not be parsed
+			continue
+		}
+		analyser.files[toName] = imports
+	}
+	return nil, nil
+}
+
+// module returns the module of the file.
+func (analyser *ImportGraphAnalysis) module(file string) string {
+	if analyser.DirectoryDepth > 0 {
+		return truncateDirectory(file, analyser.DirectoryDepth)
+	}
+	return path.Dir(file)
+}
+
+// modules returns the set of the current modules.
+func (analyser *ImportGraphAnalysis) modules() map[string]bool {
+	result := map[string]bool{}
+	for file := range analyser.files {
+		result[analyser.module(file)] = true
+	}
+	return result
+}
+
+// edges resolves the imports and returns the dependency graph.
+func (analyser *ImportGraphAnalysis) edges() map[string]map[string]int {
+	modules := analyser.modules()
+	// every path suffix of every module -> the longest module with that suffix
+	suffixes := map[string]string{}
+	for module := range modules {
+		parts := strings.Split(module, "/")
+		for i := range parts {
+			suffix := strings.Join(parts[i:], "/")
+			if existing, exists := suffixes[suffix]; !exists || len(module) > len(existing) ||
+				(len(module) == len(existing) && module < existing) {
+				suffixes[suffix] = module
+			}
+		}
+	}
+	resolve := func(imported string) string {
+		parts := strings.Split(imported, "/")
+		for end := len(parts); end > 0; end-- {
+			// the module ends with the imported path or its parent
+			if module, exists := suffixes[strings.Join(parts[:end], "/")]; exists {
+				return module
+			}
+			// the imported path ends with the module, e.g. the Go import prefix
+			for start := 1; start < end; start++ {
+				if candidate := strings.Join(parts[start:end], "/"); modules[candidate] {
+					return candidate
+				}
+			}
+		}
+		return ""
+	}
+	graph := map[string]map[string]int{}
+	for file, imports := range analyser.files {
+		from := analyser.module(file)
+		visited := map[string]bool{}
+		for _, imported := range imports {
+			to := resolve(imported)
+			if to == "" || to == from || visited[to] {
+				continue
+			}
+			visited[to] = true
+			if graph[from] == nil {
+				graph[from] = map[string]int{}
+			}
+			graph[from][to]++
+		}
+	}
+	return graph
+}
+
+// measureImportGraph counts the edges and the cycles of the graph with Tarjan's algorithm.
+func measureImportGraph(graph map[string]map[string]int) ImportGraphTick {
+	var tick ImportGraphTick
+	nodes := make([]string, 0, len(graph))
+	for node, edges := range graph {
+		nodes = append(nodes, node)
+		tick.Edges += len(edges)
+	}
+	sort.Strings(nodes)
+	index := map[string]int{}
+	lowlink := map[string]int{}
+	onStack := map[string]bool{}
+	var stack []string
+	var connect func(node string)
+	connect = func(node string) {
+		index[node] = len(index)
+		lowlink[node] = index[node]
+		stack = append(stack, node)
+		onStack[node] = true
+		for next := range graph[node] {
+			if _, visited := index[next]; !visited {
+				connect(next)
+				if lowlink[next] < lowlink[node] {
+					lowlink[node] = lowlink[next]
+				}
+			} else if onStack[next] && index[next] < lowlink[node] {
+				lowlink[node] = index[next]
+			}
+		}
+		if lowlink[node] != index[node] {
+			return
+		}
+		size := 0
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			size++
+			if top == node {
+				break
+			}
+		}
+		if size > 1 {
+			tick.Cycles++
+			tick.CyclicNodes += size
+		}
+	}
+	for _, node := range nodes {
+		if _, visited := index[node]; !visited {
+			connect(node)
+		}
+	}
+	return tick
+}
+
+// extractImports returns the normalized paths imported in the UAST of the file.
+// The relative imports are resolved against the directory of the file.
+func extractImports(root *uast.Node, file string) []string {
+	var result []string
+	dir := path.Dir(file)
+	var visit func(node *uast.Node)
+	visit = func(node *uast.Node) {
+		if hasRoles(node, uast.Import, uast.Pathname) && node.Token != "" {
+			if imported := normalizeImport(node.Token, dir, path.Ext(file) == ".go"); imported != "" {
+				result = append(result, imported)
+			}
+			return
+		}
+		for _, child := range node.Children {
+			visit(child)
+		}
+	}
+	visit(root)
+	return result
+}
+
+// normalizeImport converts the imported name to a slash-separated path.
+func normalizeImport(imported, dir string, golang bool) string {
+	imported = strings.Trim(imported, " \t\"'`<>;")
+	switch {
+	case strings.HasPrefix(imported, "./") || strings.HasPrefix(imported, "../"):
+		imported = path.Join(dir, imported)
+	case strings.HasPrefix(imported, "."):
+		// Python relative import: each dot after the first one goes one level up
+		dots := len(imported) - len(strings.TrimLeft(imported, "."))
+		base := dir
+		for i := 1; i < dots; i++ {
+			base = path.Dir(base)
+		}
+		imported = path.Join(base, strings.Replace(imported[dots:], ".", "/", -1))
+	case !golang && !strings.Contains(imported, "/"):
+		// Java and Python qualified names
+		imported = strings.Replace(imported, ".", "/", -1)
+	}
+	if imported == "." || strings.HasPrefix(imported, "../") {
+		return ""
+	}
+	return imported
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (analyser *ImportGraphAnalysis) Finalize() interface{} {
+	graph := analyser.edges()
+	ticks := analyser.ticks
+	if len(analyser.files) > 0 || len(ticks) > 0 {
+		measurement := measureImportGraph(graph)
+		measurement.Nodes = len(analyser.modules())
+		ticks = append(ticks, measurement)
+	}
+	return ImportGraphResult{
+		Ticks:    ticks,
+		Edges:    flattenImportGraph(graph),
+		Sampling: analyser.Sampling,
+	}
+}
+
+// flattenImportGraph returns the sorted list of the edges.
+func flattenImportGraph(graph map[string]map[string]int) []ImportGraphEdge {
+	edges := []ImportGraphEdge{}
+	for from, targets := range graph {
+		for to, imports := range targets {
+			edges = append(edges, ImportGraphEdge{From: from, To: to, Imports: imports})
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+	return edges
+}
+
+// Fork clones this pipeline item.
+func (analyser *ImportGraphAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(analyser, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (analyser *ImportGraphAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	graphResult := result.(ImportGraphResult)
+	if binary {
+		return analyser.serializeBinary(&graphResult, writer)
+	}
+	analyser.serializeText(&graphResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (analyser *ImportGraphAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(analyser, result, &pb.ImportGraphAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (analyser *ImportGraphAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(analyser, result, &pb.ImportGraphAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to ImportGraphResult.
+func (analyser *ImportGraphAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.ImportGraphAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := ImportGraphResult{
+		Ticks:    make([]ImportGraphTick, len(message.Ticks)),
+		Edges:    make([]ImportGraphEdge, len(message.Edges)),
+		Sampling: int(message.Sampling),
+	}
+	for i, tick := range message.Ticks {
+		result.Ticks[i] = ImportGraphTick{
+			Nodes: int(tick.Nodes), Edges: int(tick.Edges), Cycles: int(tick.Cycles),
+			CyclicNodes: int(tick.CyclicNodes)}
+	}
+	for i, edge := range message.Edges {
+		result.Edges[i] = ImportGraphEdge{From: edge.From, To: edge.To, Imports: int(edge.Imports)}
+	}
+	return result, nil
+}
+
+// MergeResults combines two ImportGraphResult-s together. The ticks are resampled to
+// the bigger sampling of the two and the graphs are united.
+func (analyser *ImportGraphAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	gr1 := r1.(ImportGraphResult)
+	gr2 := r2.(ImportGraphResult)
+	sampling := gr1.Sampling
+	if gr2.Sampling > sampling {
+		sampling = gr2.Sampling
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	offsets := [2]int{
+		int((c1.BeginTime - beginTime) / (24 * 3600)),
+		int((c2.BeginTime - beginTime) / (24 * 3600)),
+	}
+	results := [2]*ImportGraphResult{&gr1, &gr2}
+	days := 0
+	for i, result := range results {
+		if end := offsets[i] + len(result.Ticks)*result.Sampling; end > days {
+			days = end
+		}
+	}
+	merged := ImportGraphResult{
+		Ticks:    make([]ImportGraphTick, (days+sampling-1)/sampling),
+		Sampling: sampling,
+	}
+	graph := map[string]map[string]int{}
+	for i, result := range results {
+		// take the value of the series at the end of each merged tick
+		for tick := range merged.Ticks {
+			day := (tick+1)*sampling - 1
+			if day < offsets[i] || len(result.Ticks) == 0 {
+				continue
+			}
+			index := (day - offsets[i]) / result.Sampling
+			if index >= len(result.Ticks) {
+				index = len(result.Ticks) - 1
+			}
+			value := result.Ticks[index]
+			merged.Ticks[tick].Nodes += value.Nodes
+			merged.Ticks[tick].Edges += value.Edges
+			merged.Ticks[tick].Cycles += value.Cycles
+			merged.Ticks[tick].CyclicNodes += value.CyclicNodes
+		}
+		for _, edge := range result.Edges {
+			if graph[edge.From] == nil {
+				graph[edge.From] = map[string]int{}
+			}
+			graph[edge.From][edge.To] += edge.Imports
+		}
+	}
+	merged.Edges = flattenImportGraph(graph)
+	return merged
+}
+
+func (analyser *ImportGraphAnalysis) serializeText(result *ImportGraphResult, writer io.Writer) {
+	ticks := make([]string, len(result.Ticks))
+	for i, tick := range result.Ticks {
+		ticks[i] = fmt.Sprintf("[%d, %d, %d, %d]", tick.Nodes, tick.Edges, tick.Cycles,
+			tick.CyclicNodes)
+	}
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  # [nodes, edges, cycles, cyclic nodes]")
+	fmt.Fprintf(writer, "  ticks: [%s]\n", strings.Join(ticks, ", "))
+	fmt.Fprintln(writer, "  # [from, to, imports]")
+	fmt.Fprintln(writer, "  edges:")
+	for _, edge := range result.Edges {
+		fmt.Fprintf(writer, "    - [%s, %s, %d]\n", yaml.SafeString(edge.From),
+			yaml.SafeString(edge.To), edge.Imports)
+	}
+}
+
+func (analyser *ImportGraphAnalysis) serializeBinary(result *ImportGraphResult, writer io.Writer) error {
+	message := pb.ImportGraphAnalysisResults{
+		Sampling: int32(result.Sampling),
+		Ticks:    make([]*pb.ImportGraphTick, len(result.Ticks)),
+		Edges:    make([]*pb.ImportGraphEdge, len(result.Edges)),
+	}
+	for i, tick := range result.Ticks {
+		message.Ticks[i] = &pb.ImportGraphTick{
+			Nodes: int32(tick.Nodes), Edges: int32(tick.Edges), Cycles: int32(tick.Cycles),
+			CyclicNodes: int32(tick.CyclicNodes)}
+	}
+	for i, edge := range result.Edges {
+		message.Edges[i] = &pb.ImportGraphEdge{
+			From: edge.From, To: edge.To, Imports: int32(edge.Imports)}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&ImportGraphAnalysis{})
+}
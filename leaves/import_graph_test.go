@@ -0,0 +1,202 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+)
+
+func fixtureImportGraph() *ImportGraphAnalysis {
+	analyser := ImportGraphAnalysis{}
+	analyser.Configure(map[string]interface{}{ConfigImportGraphSampling: 10})
+	analyser.Initialize(nil)
+	return &analyser
+}
+
+func importNode(paths ...string) *uast.Node {
+	node := &uast.Node{Roles: []uast.Role{uast.Import, uast.Declaration}}
+	for _, p := range paths {
+		node.Children = append(node.Children, &uast.Node{
+			Roles: []uast.Role{uast.Import, uast.Pathname}, Token: p})
+	}
+	return node
+}
+
+func TestImportGraphMeta(t *testing.T) {
+	analyser := fixtureImportGraph()
+	assert.Equal(t, analyser.Name(), "ImportGraph")
+	assert.Len(t, analyser.Provides(), 0)
+	assert.Equal(t, analyser.Requires(), []string{
+		uast_items.DependencyUastChanges, items.DependencyDay})
+	assert.Equal(t, analyser.Features(), []string{uast_items.FeatureUast})
+	assert.Equal(t, analyser.Flag(), "import-graph")
+	assert.NotEmpty(t, analyser.Description())
+	opts := analyser.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Flag, "import-graph-sampling")
+	assert.Equal(t, opts[1].Flag, "import-graph-dirs")
+	assert.Equal(t, analyser.Sampling, 10)
+	analyser = &ImportGraphAnalysis{DirectoryDepth: -1}
+	analyser.Initialize(nil)
+	assert.Equal(t, analyser.Sampling, DefaultImportGraphSampling)
+	assert.Equal(t, analyser.DirectoryDepth, DefaultImportGraphDirectoryDepth)
+	summoned := core.Registry.Summon(analyser.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "ImportGraph")
+}
+
+func TestImportGraphNormalizeImport(t *testing.T) {
+	assert.Equal(t, normalizeImport("\"github.com/x/repo/core\"", "cmd", true),
+		"github.com/x/repo/core")
+	assert.Equal(t, normalizeImport("com.foo.bar.Baz", "src", false), "com/foo/bar/Baz")
+	assert.Equal(t, normalizeImport("'./utils'", "web/app", false), "web/app/utils")
+	assert.Equal(t, normalizeImport("../lib", "web/app", false), "web/lib")
+	assert.Equal(t, normalizeImport(".models", "pkg/api", false), "pkg/api/models")
+	assert.Equal(t, normalizeImport("..models.user", "pkg/api", false), "pkg/models/user")
+	assert.Equal(t, normalizeImport("../../x", "a", false), "")
+	assert.Equal(t, normalizeImport(".", "a", false), "a")
+	assert.Equal(t, normalizeImport(".", ".", false), "")
+}
+
+func TestImportGraphExtractImports(t *testing.T) {
+	root := &uast.Node{Children: []*uast.Node{
+		importNode("\"fmt\"", "\"github.com/x/repo/core\""),
+		{Children: []*uast.Node{importNode("")}},
+		{Roles: []uast.Role{uast.Pathname}, Token: "not.an.import"},
+	}}
+	assert.Equal(t, extractImports(root, "cmd/main.go"), []string{"fmt", "github.com/x/repo/core"})
+	assert.Equal(t, extractImports(importNode("os.path"), "app.py"), []string{"os/path"})
+}
+
+func TestImportGraphEdges(t *testing.T) {
+	analyser := fixtureImportGraph()
+	analyser.files = map[string][]string{
+		"src/main/java/com/foo/A.java":     {"com/foo/bar/B", "java/util/List"},
+		"src/main/java/com/foo/bar/B.java": {"com/foo/A", "com/foo/C"},
+		"src/main/java/com/foo/C.java":     {},
+		"A.java":                           {"com/foo/A"},
+	}
+	assert.Equal(t, analyser.edges(), map[string]map[string]int{
+		"src/main/java/com/foo":     {"src/main/java/com/foo/bar": 1},
+		"src/main/java/com/foo/bar": {"src/main/java/com/foo": 1},
+		".":                         {"src/main/java/com/foo": 1},
+	})
+	analyser.DirectoryDepth = 3
+	assert.Equal(t, analyser.edges(), map[string]map[string]int{})
+	assert.Equal(t, measureImportGraph(map[string]map[string]int{
+		"a": {"b": 1}, "b": {"c": 2, "a": 1}, "c": {"d": 1}, "d": {"c": 1}, "e": {"a": 1},
+	}), ImportGraphTick{Edges: 6, Cycles: 2, CyclicNodes: 4})
+}
+
+func fixtureImportGraphResult(t *testing.T) ImportGraphResult {
+	analyser := fixtureImportGraph()
+	consume := func(day int, changes ...uast_items.Change) {
+		result, err := analyser.Consume(map[string]interface{}{
+			core.DependencyCommit:            &object.Commit{},
+			core.DependencyIsMerge:           false,
+			uast_items.DependencyUastChanges: changes,
+			items.DependencyDay:              day,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	change := func(from, to string, nodes ...*uast.Node) uast_items.Change {
+		var after *uast.Node
+		if nodes != nil {
+			after = &uast.Node{Children: nodes}
+		}
+		return uast_items.Change{After: after, Change: &object.Change{
+			From: object.ChangeEntry{Name: from}, To: object.ChangeEntry{Name: to}}}
+	}
+	consume(0,
+		change("", "cmd/main.go", importNode("\"github.com/x/repo/core\"", "\"fmt\"")),
+		change("", "core/core.go", importNode("\"github.com/x/repo/util\"")),
+		change("", "util/util.go", importNode("\"github.com/x/repo/core\"")),
+		// could not be parsed
+		change("", "README.md"))
+	consume(12,
+		change("util/util.go", "util/util.go", importNode("\"fmt\"")),
+		change("core/core.go", "core/base.go"),
+		change("", "py/app.py", importNode(".helpers")),
+		change("", "py/helpers/x.py", &uast.Node{}))
+	consume(15, change("cmd/main.go", ""))
+	return analyser.Finalize().(ImportGraphResult)
+}
+
+func TestImportGraphConsumeFinalize(t *testing.T) {
+	result := fixtureImportGraphResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Ticks, []ImportGraphTick{
+		{Nodes: 3, Edges: 3, Cycles: 1, CyclicNodes: 2}, {Nodes: 4, Edges: 2}})
+	assert.Equal(t, result.Edges, []ImportGraphEdge{
+		{From: "core", To: "util", Imports: 1}, {From: "py", To: "py/helpers", Imports: 1}})
+}
+
+func TestImportGraphConsumeMerge(t *testing.T) {
+	analyser := fixtureImportGraph()
+	result, err := analyser.Consume(map[string]interface{}{
+		core.DependencyCommit: &object.Commit{
+			ParentHashes: make([]plumbing.Hash, 2)},
+		core.DependencyIsMerge: true,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+	assert.Len(t, analyser.Finalize().(ImportGraphResult).Ticks, 0)
+}
+
+func TestImportGraphSerialize(t *testing.T) {
+	result := fixtureImportGraphResult(t)
+	analyser := fixtureImportGraph()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  # [nodes, edges, cycles, cyclic nodes]
+  ticks: [[3, 3, 1, 2], [4, 2, 0, 0]]
+  # [from, to, imports]
+  edges:
+    - ["core", "util", 1]
+    - ["py", "py/helpers", 1]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, true, buffer))
+	msg := pb.ImportGraphAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Len(t, msg.Ticks, 2)
+	assert.Equal(t, msg.Edges[1].To, "py/helpers")
+	deserialized, err := analyser.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestImportGraphMergeResults(t *testing.T) {
+	r1 := ImportGraphResult{
+		Ticks:    []ImportGraphTick{{Nodes: 2, Edges: 1}, {Nodes: 3, Edges: 2, Cycles: 1, CyclicNodes: 2}},
+		Edges:    []ImportGraphEdge{{From: "a", To: "b", Imports: 2}},
+		Sampling: 10,
+	}
+	r2 := ImportGraphResult{
+		Ticks: []ImportGraphTick{{Nodes: 5, Edges: 4}},
+		Edges: []ImportGraphEdge{
+			{From: "a", To: "b", Imports: 1}, {From: "a", To: "a/c", Imports: 1}},
+		Sampling: 20,
+	}
+	analyser := fixtureImportGraph()
+	merged := analyser.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 20 * 24 * 3600}).(ImportGraphResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.Ticks, []ImportGraphTick{
+		{Nodes: 3, Edges: 2, Cycles: 1, CyclicNodes: 2}, {Nodes: 8, Edges: 6, Cycles: 1, CyclicNodes: 2}})
+	assert.Equal(t, merged.Edges, []ImportGraphEdge{
+		{From: "a", To: "a/c", Imports: 1}, {From: "a", To: "b", Imports: 3}})
+}
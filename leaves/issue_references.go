@@ -0,0 +1,388 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// IssueReferencesAnalysis extracts the issue tracker references from the commit messages,
+// e.g. JIRA keys like "PROJ-123" or GitHub numbers like "#123", and maps the tickets to the
+// commits and to the changed lines. The references are found with the regular expressions
+// in Patterns: if a pattern has a capturing group, the first group is the ticket, otherwise
+// the whole match is. The merge commits are skipped.
+type IssueReferencesAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Patterns are the regular expressions which match the tickets.
+	Patterns []string
+
+	// regexps are the compiled Patterns.
+	regexps []*regexp.Regexp
+	// tickets map the ticket keys to the referencing commits.
+	tickets map[string]*IssueTicket
+	// commits is the number of analysed commits.
+	commits int
+	// referencedCommits is the number of analysed commits which reference at least one ticket.
+	referencedCommits int
+}
+
+// IssueTicket is the part of the history which references the same ticket.
+type IssueTicket struct {
+	// Commits are the hashes of the commits which reference the ticket, in the analysis order.
+	Commits []string
+	// Churn is the number of changed lines in Commits.
+	Churn int
+	// FirstDay is the day of the first commit in Commits.
+	FirstDay int
+	// LastDay is the day of the last commit in Commits.
+	LastDay int
+}
+
+// IssueReferencesResult is returned by IssueReferencesAnalysis.Finalize().
+type IssueReferencesResult struct {
+	// Tickets map the ticket keys to the referencing commits.
+	Tickets map[string]IssueTicket
+	// Commits is the number of analysed commits.
+	Commits int
+	// ReferencedCommits is the number of commits among Commits which reference at least one ticket.
+	ReferencedCommits int
+	// Patterns are the effective IssueReferencesAnalysis.Patterns.
+	Patterns []string
+}
+
+const (
+	// ConfigIssueReferencesPatterns is the name of the option to set IssueReferencesAnalysis.Patterns.
+	ConfigIssueReferencesPatterns = "IssueReferences.Patterns"
+)
+
+// DefaultIssueReferencesPatterns is the default value of IssueReferencesAnalysis.Patterns:
+// JIRA-style keys and GitHub or GitLab numbers.
+var DefaultIssueReferencesPatterns = []string{
+	`\b([A-Z][A-Z0-9]+-[0-9]+)\b`,
+	`(?:^|[^\w/&])(#[0-9]+)\b`,
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (refs *IssueReferencesAnalysis) Name() string {
+	return "IssueReferences"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (refs *IssueReferencesAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (refs *IssueReferencesAnalysis) Requires() []string {
+	arr := [...]string{
+		items.DependencyDay, items.DependencyTreeChanges, items.DependencyFileDiff,
+		items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (refs *IssueReferencesAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigIssueReferencesPatterns,
+		Description: "The regular expressions which match the tickets in the commit messages. " +
+			"The first capturing group is the ticket if there is one.",
+		Flag:    "issue-references-patterns",
+		Type:    core.StringsConfigurationOption,
+		Default: DefaultIssueReferencesPatterns},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (refs *IssueReferencesAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigIssueReferencesPatterns].([]string); exists {
+		refs.Patterns = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (refs *IssueReferencesAnalysis) Flag() string {
+	return "issue-references"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (refs *IssueReferencesAnalysis) Description() string {
+	return "Extracts the issue tracker references from the commit messages and maps " +
+		"the tickets to the commits and to the changed lines."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (refs *IssueReferencesAnalysis) Initialize(repository *git.Repository) {
+	patterns := make([]string, 0, len(refs.Patterns))
+	refs.regexps = nil
+	for _, pattern := range refs.Patterns {
+		if pattern = strings.TrimSpace(pattern); pattern == "" {
+			continue
+		}
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Printf("Warning: skipped the issue reference pattern %s: %v\n", pattern, err)
+			continue
+		}
+		patterns = append(patterns, pattern)
+		refs.regexps = append(refs.regexps, compiled)
+	}
+	if len(patterns) == 0 {
+		if refs.Patterns != nil {
+			log.Printf("Warning: adjusted the issue reference patterns to %s\n",
+				strings.Join(DefaultIssueReferencesPatterns, ","))
+		}
+		patterns = DefaultIssueReferencesPatterns
+		for _, pattern := range patterns {
+			refs.regexps = append(refs.regexps, regexp.MustCompile(pattern))
+		}
+	}
+	refs.Patterns = patterns
+	refs.tickets = map[string]*IssueTicket{}
+	refs.commits = 0
+	refs.referencedCommits = 0
+	refs.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (refs *IssueReferencesAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !refs.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	refs.commits++
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	keys := refs.extractTickets(commit.Message)
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	refs.referencedCommits++
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	churn := 0
+	for _, change := range deps[items.DependencyTreeChanges].(object.Changes) {
+		_, lines, err := countChangedLines(change, cache, fileDiffs)
+		if err != nil {
+			return nil, err
+		}
+		churn += lines
+	}
+	day := deps[items.DependencyDay].(int)
+	for _, key := range keys {
+		ticket := refs.tickets[key]
+		if ticket == nil {
+			ticket = &IssueTicket{FirstDay: day}
+			refs.tickets[key] = ticket
+		}
+		ticket.Commits = append(ticket.Commits, commit.Hash.String())
+		ticket.Churn += churn
+		ticket.LastDay = day
+	}
+	return nil, nil
+}
+
+// extractTickets returns the unique tickets referenced in the commit message in the order
+// of Patterns and then of their appearance.
+func (refs *IssueReferencesAnalysis) extractTickets(message string) []string {
+	var keys []string
+	seen := map[string]bool{}
+	for _, re := range refs.regexps {
+		for _, match := range re.FindAllStringSubmatch(message, -1) {
+			key := match[0]
+			if len(match) > 1 {
+				key = match[1]
+			}
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (refs *IssueReferencesAnalysis) Finalize() interface{} {
+	result := IssueReferencesResult{
+		Tickets:           make(map[string]IssueTicket, len(refs.tickets)),
+		Commits:           refs.commits,
+		ReferencedCommits: refs.referencedCommits,
+		Patterns:          refs.Patterns,
+	}
+	for key, ticket := range refs.tickets {
+		result.Tickets[key] = *ticket
+	}
+	return result
+}
+
+// Fork clones this pipeline item.
+func (refs *IssueReferencesAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(refs, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (refs *IssueReferencesAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	refsResult := result.(IssueReferencesResult)
+	if binary {
+		return refs.serializeBinary(&refsResult, writer)
+	}
+	refs.serializeText(&refsResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (refs *IssueReferencesAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(refs, result, &pb.IssueReferencesAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (refs *IssueReferencesAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(refs, result, &pb.IssueReferencesAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to IssueReferencesResult.
+func (refs *IssueReferencesAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.IssueReferencesAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := IssueReferencesResult{
+		Tickets:           make(map[string]IssueTicket, len(message.Tickets)),
+		Commits:           int(message.Commits),
+		ReferencedCommits: int(message.ReferencedCommits),
+		Patterns:          message.Patterns,
+	}
+	for key, ticket := range message.Tickets {
+		result.Tickets[key] = IssueTicket{
+			Commits:  ticket.Commits,
+			Churn:    int(ticket.Churn),
+			FirstDay: int(ticket.FirstDay),
+			LastDay:  int(ticket.LastDay),
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two IssueReferencesResult-s together. The tickets with the same keys
+// are considered the same and their commits are concatenated.
+func (refs *IssueReferencesAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	rr1 := r1.(IssueReferencesResult)
+	rr2 := r2.(IssueReferencesResult)
+	merged := IssueReferencesResult{
+		Tickets:           map[string]IssueTicket{},
+		Commits:           rr1.Commits + rr2.Commits,
+		ReferencedCommits: rr1.ReferencedCommits + rr2.ReferencedCommits,
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	patterns := map[string]bool{}
+	add := func(result *IssueReferencesResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for key, ticket := range result.Tickets {
+			ticket.FirstDay += offset
+			ticket.LastDay += offset
+			target, exists := merged.Tickets[key]
+			if !exists {
+				ticket.Commits = append([]string{}, ticket.Commits...)
+				merged.Tickets[key] = ticket
+				continue
+			}
+			target.Commits = append(target.Commits, ticket.Commits...)
+			target.Churn += ticket.Churn
+			if ticket.FirstDay < target.FirstDay {
+				target.FirstDay = ticket.FirstDay
+			}
+			if ticket.LastDay > target.LastDay {
+				target.LastDay = ticket.LastDay
+			}
+			merged.Tickets[key] = target
+		}
+		for _, pattern := range result.Patterns {
+			if !patterns[pattern] {
+				patterns[pattern] = true
+				merged.Patterns = append(merged.Patterns, pattern)
+			}
+		}
+	}
+	add(&rr1, c1)
+	add(&rr2, c2)
+	return merged
+}
+
+func (refs *IssueReferencesAnalysis) serializeText(result *IssueReferencesResult, writer io.Writer) {
+	patterns := make([]string, len(result.Patterns))
+	for i, pattern := range result.Patterns {
+		patterns[i] = yaml.SafeString(pattern)
+	}
+	fmt.Fprintf(writer, "  patterns: [%s]\n", strings.Join(patterns, ", "))
+	fmt.Fprintln(writer, "  commits:", result.Commits)
+	fmt.Fprintln(writer, "  referenced_commits:", result.ReferencedCommits)
+	keys := make([]string, 0, len(result.Tickets))
+	for key := range result.Tickets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fmt.Fprintln(writer, "  tickets:")
+	for _, key := range keys {
+		ticket := result.Tickets[key]
+		fmt.Fprintf(writer, "    %s:\n", yaml.SafeString(key))
+		fmt.Fprintf(writer, "      first_day: %d\n", ticket.FirstDay)
+		fmt.Fprintf(writer, "      last_day: %d\n", ticket.LastDay)
+		fmt.Fprintf(writer, "      churn: %d\n", ticket.Churn)
+		fmt.Fprintf(writer, "      commits: [%s]\n", strings.Join(ticket.Commits, ", "))
+	}
+}
+
+func (refs *IssueReferencesAnalysis) serializeBinary(result *IssueReferencesResult, writer io.Writer) error {
+	message := pb.IssueReferencesAnalysisResults{
+		Tickets:           make(map[string]*pb.IssueTicket, len(result.Tickets)),
+		Commits:           int32(result.Commits),
+		ReferencedCommits: int32(result.ReferencedCommits),
+		Patterns:          result.Patterns,
+	}
+	for key, ticket := range result.Tickets {
+		message.Tickets[key] = &pb.IssueTicket{
+			Commits:  ticket.Commits,
+			Churn:    int32(ticket.Churn),
+			FirstDay: int32(ticket.FirstDay),
+			LastDay:  int32(ticket.LastDay),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&IssueReferencesAnalysis{})
+}
@@ -0,0 +1,164 @@
+package leaves
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func fixtureIssueReferences() *IssueReferencesAnalysis {
+	refs := IssueReferencesAnalysis{}
+	refs.Initialize(nil)
+	return &refs
+}
+
+func TestIssueReferencesMeta(t *testing.T) {
+	refs := fixtureIssueReferences()
+	assert.Equal(t, refs.Name(), "IssueReferences")
+	assert.Len(t, refs.Provides(), 0)
+	assert.Equal(t, refs.Requires(), []string{
+		items.DependencyDay, items.DependencyTreeChanges, items.DependencyFileDiff,
+		items.DependencyBlobCache})
+	assert.Equal(t, refs.Flag(), "issue-references")
+	assert.NotEmpty(t, refs.Description())
+	opts := refs.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Flag, "issue-references-patterns")
+	assert.Equal(t, refs.Patterns, DefaultIssueReferencesPatterns)
+	refs.Configure(map[string]interface{}{
+		ConfigIssueReferencesPatterns: []string{"bug ([0-9]+)", "(", " "}})
+	refs.Initialize(nil)
+	assert.Equal(t, refs.Patterns, []string{"bug ([0-9]+)"})
+	refs.Configure(map[string]interface{}{ConfigIssueReferencesPatterns: []string{"("}})
+	refs.Initialize(nil)
+	assert.Equal(t, refs.Patterns, DefaultIssueReferencesPatterns)
+	summoned := core.Registry.Summon(refs.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "IssueReferences")
+}
+
+func TestIssueReferencesExtractTickets(t *testing.T) {
+	refs := fixtureIssueReferences()
+	assert.Equal(t, refs.extractTickets("PROJ-12: fix the crash (#34)\n\nSee PROJ-12 and #5."),
+		[]string{"PROJ-12", "#34", "#5"})
+	assert.Len(t, refs.extractTickets("Update utf-8 handling in a/b#1 and C-3PO, &#39;"), 0)
+	assert.Equal(t, refs.extractTickets("#7 first"), []string{"#7"})
+	refs.Configure(map[string]interface{}{ConfigIssueReferencesPatterns: []string{"bug [0-9]+"}})
+	refs.Initialize(nil)
+	assert.Equal(t, refs.extractTickets("Fix bug 42"), []string{"bug 42"})
+}
+
+func fixtureIssueReferencesResult(t *testing.T) IssueReferencesResult {
+	hash, blob := storeExpertiseBlob(t, "one\ntwo\nthree\nfour\n")
+	refs := fixtureIssueReferences()
+	consume := func(hashDigit string, day int, merge bool, message string, files ...string) {
+		commit := &object.Commit{Hash: plumbing.NewHash(strings.Repeat(hashDigit, 40)), Message: message}
+		if merge {
+			commit.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		changes := make(object.Changes, len(files))
+		for i, file := range files {
+			changes[i] = &object.Change{To: object.ChangeEntry{
+				Name: file, TreeEntry: object.TreeEntry{Name: file, Hash: hash}}}
+		}
+		result, err := refs.Consume(map[string]interface{}{
+			core.DependencyCommit:       commit,
+			core.DependencyIsMerge:      merge,
+			items.DependencyDay:         day,
+			items.DependencyTreeChanges: changes,
+			items.DependencyFileDiff:    map[string]items.FileDiffData{},
+			items.DependencyBlobCache:   map[plumbing.Hash]*object.Blob{hash: blob},
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	consume("1", 0, false, "ABC-1: start", "a.go", "b.go")
+	consume("2", 3, false, "no reference", "c.go")
+	consume("3", 5, false, "ABC-1 continue, closes #9", "d.go")
+	consume("4", 6, true, "Merge pull request #10", "e.go")
+	return refs.Finalize().(IssueReferencesResult)
+}
+
+func TestIssueReferencesConsumeFinalize(t *testing.T) {
+	result := fixtureIssueReferencesResult(t)
+	assert.Equal(t, result.Commits, 3)
+	assert.Equal(t, result.ReferencedCommits, 2)
+	assert.Equal(t, result.Patterns, DefaultIssueReferencesPatterns)
+	assert.Equal(t, result.Tickets, map[string]IssueTicket{
+		"ABC-1": {Commits: []string{strings.Repeat("1", 40), strings.Repeat("3", 40)},
+			Churn: 12, FirstDay: 0, LastDay: 5},
+		"#9": {Commits: []string{strings.Repeat("3", 40)}, Churn: 4, FirstDay: 5, LastDay: 5},
+	})
+}
+
+func TestIssueReferencesSerialize(t *testing.T) {
+	result := fixtureIssueReferencesResult(t)
+	refs := fixtureIssueReferences()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, refs.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  patterns: ["\\b([A-Z][A-Z0-9]+-[0-9]+)\\b", "(?:^|[^\\w/&])(#[0-9]+)\\b"]
+  commits: 3
+  referenced_commits: 2
+  tickets:
+    "#9":
+      first_day: 5
+      last_day: 5
+      churn: 4
+      commits: [3333333333333333333333333333333333333333]
+    "ABC-1":
+      first_day: 0
+      last_day: 5
+      churn: 12
+      commits: [1111111111111111111111111111111111111111, 3333333333333333333333333333333333333333]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, refs.Serialize(result, true, buffer))
+	msg := pb.IssueReferencesAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Commits, int32(3))
+	assert.Equal(t, msg.ReferencedCommits, int32(2))
+	assert.Len(t, msg.Tickets, 2)
+	assert.Equal(t, msg.Tickets["ABC-1"].Churn, int32(12))
+	assert.Equal(t, msg.Patterns, DefaultIssueReferencesPatterns)
+	deserialized, err := refs.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestIssueReferencesMergeResults(t *testing.T) {
+	r1 := IssueReferencesResult{
+		Tickets: map[string]IssueTicket{
+			"ABC-1": {Commits: []string{"a"}, Churn: 10, FirstDay: 2, LastDay: 2}},
+		Commits:           3,
+		ReferencedCommits: 1,
+		Patterns:          []string{"x"},
+	}
+	r2 := IssueReferencesResult{
+		Tickets: map[string]IssueTicket{
+			"ABC-1": {Commits: []string{"b", "c"}, Churn: 5, FirstDay: 0, LastDay: 1},
+			"#2":    {Commits: []string{"d"}, Churn: 1, FirstDay: 4, LastDay: 4}},
+		Commits:           4,
+		ReferencedCommits: 3,
+		Patterns:          []string{"y", "x"},
+	}
+	refs := fixtureIssueReferences()
+	merged := refs.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 10 * 24 * 3600}).(IssueReferencesResult)
+	assert.Equal(t, merged.Commits, 7)
+	assert.Equal(t, merged.ReferencedCommits, 4)
+	assert.Equal(t, merged.Patterns, []string{"x", "y"})
+	assert.Equal(t, merged.Tickets, map[string]IssueTicket{
+		"ABC-1": {Commits: []string{"a", "b", "c"}, Churn: 15, FirstDay: 2, LastDay: 11},
+		"#2":    {Commits: []string{"d"}, Churn: 1, FirstDay: 14, LastDay: 14},
+	})
+	assert.Equal(t, r1.Tickets["ABC-1"].Commits, []string{"a"})
+}
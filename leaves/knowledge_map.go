@@ -0,0 +1,407 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// KnowledgeMapAnalysis builds the developer × directory matrices of the accumulated
+// changed lines and commits, so that it is possible to tell who knows each subsystem.
+// Unlike ExpertiseAnalysis, the values are absolute and comparable between the developers.
+// The older changes weigh less: the weight halves every HalfLife days before the last
+// analysed day. The merge commits are skipped.
+type KnowledgeMapAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// HalfLife is the number of days after which the weight of a change halves.
+	// 0 disables the recency weighting.
+	HalfLife int
+	// DirectoryDepth is the number of path components which define the directories.
+	DirectoryDepth int
+	// PeopleNumber is the number of developers.
+	PeopleNumber int
+
+	// lines is the decayed number of changed lines of each developer in each directory.
+	lines []map[string]*decayedLines
+	// commits is the decayed number of commits of each developer in each directory.
+	commits []map[string]*decayedLines
+	// lastDay is the latest day index seen so far.
+	lastDay int
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// KnowledgeMapResult is returned by KnowledgeMapAnalysis.Finalize().
+type KnowledgeMapResult struct {
+	// HalfLife is the effective KnowledgeMapAnalysis.HalfLife.
+	HalfLife int
+	// DirectoryDepth is the effective KnowledgeMapAnalysis.DirectoryDepth.
+	DirectoryDepth int
+	// Directories are the sorted directories, the columns of PeopleLines and PeopleCommits.
+	// "." stands for the files in the root.
+	Directories []string
+	// PeopleLines is the weighted number of changed lines of each developer in each directory.
+	// The last row corresponds to the unmatched identities.
+	PeopleLines [][]float64
+	// PeopleCommits is the weighted number of commits of each developer which changed
+	// each directory. It has the same layout as PeopleLines.
+	PeopleCommits [][]float64
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigKnowledgeMapHalfLife is the name of the option to set KnowledgeMapAnalysis.HalfLife.
+	ConfigKnowledgeMapHalfLife = "KnowledgeMap.HalfLife"
+	// DefaultKnowledgeMapHalfLife is the default value of KnowledgeMapAnalysis.HalfLife.
+	DefaultKnowledgeMapHalfLife = 365
+	// ConfigKnowledgeMapDirectoryDepth is the name of the option to set
+	// KnowledgeMapAnalysis.DirectoryDepth.
+	ConfigKnowledgeMapDirectoryDepth = "KnowledgeMap.DirectoryDepth"
+	// DefaultKnowledgeMapDirectoryDepth is the default value of KnowledgeMapAnalysis.DirectoryDepth:
+	// the top-level directories.
+	DefaultKnowledgeMapDirectoryDepth = 1
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (km *KnowledgeMapAnalysis) Name() string {
+	return "KnowledgeMap"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (km *KnowledgeMapAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (km *KnowledgeMapAnalysis) Requires() []string {
+	arr := [...]string{
+		identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges,
+		items.DependencyFileDiff, items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (km *KnowledgeMapAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigKnowledgeMapHalfLife,
+		Description: "Number of days after which the weight of a change in the knowledge map " +
+			"halves. 0 weighs all the changes equally.",
+		Flag:    "knowledge-map-half-life",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultKnowledgeMapHalfLife}, {
+		Name:        ConfigKnowledgeMapDirectoryDepth,
+		Description: "Number of path components which define the directories in the knowledge map.",
+		Flag:        "knowledge-map-depth",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultKnowledgeMapDirectoryDepth},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (km *KnowledgeMapAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigKnowledgeMapHalfLife].(int); exists {
+		km.HalfLife = val
+	}
+	if val, exists := facts[ConfigKnowledgeMapDirectoryDepth].(int); exists {
+		km.DirectoryDepth = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		km.PeopleNumber = val
+		km.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (km *KnowledgeMapAnalysis) Flag() string {
+	return "knowledge-map"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (km *KnowledgeMapAnalysis) Description() string {
+	return "Builds the developer × directory matrices of the recency-weighted changed lines " +
+		"and commits which show who knows each subsystem."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (km *KnowledgeMapAnalysis) Initialize(repository *git.Repository) {
+	if km.HalfLife < 0 {
+		log.Printf("Warning: adjusted the knowledge map half-life to %d days\n",
+			DefaultKnowledgeMapHalfLife)
+		km.HalfLife = DefaultKnowledgeMapHalfLife
+	}
+	if km.DirectoryDepth <= 0 {
+		log.Printf("Warning: adjusted the knowledge map directory depth to %d\n",
+			DefaultKnowledgeMapDirectoryDepth)
+		km.DirectoryDepth = DefaultKnowledgeMapDirectoryDepth
+	}
+	km.lines = make([]map[string]*decayedLines, km.PeopleNumber+1)
+	km.commits = make([]map[string]*decayedLines, km.PeopleNumber+1)
+	for i := range km.lines {
+		km.lines[i] = map[string]*decayedLines{}
+		km.commits[i] = map[string]*decayedLines{}
+	}
+	km.lastDay = 0
+	km.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (km *KnowledgeMapAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !km.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing || author > km.PeopleNumber {
+		author = km.PeopleNumber
+	}
+	day := deps[items.DependencyDay].(int)
+	if day > km.lastDay {
+		km.lastDay = day
+	}
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	touched := map[string]bool{}
+	for _, change := range deps[items.DependencyTreeChanges].(object.Changes) {
+		name, lines, err := countChangedLines(change, cache, fileDiffs)
+		if err != nil {
+			return nil, err
+		}
+		dir := truncateDirectory(name, km.DirectoryDepth)
+		touched[dir] = true
+		if lines > 0 {
+			addDecayedLines(km.lines[author], dir, float64(lines), day, km.HalfLife)
+		}
+	}
+	for dir := range touched {
+		addDecayedLines(km.commits[author], dir, 1, day, km.HalfLife)
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (km *KnowledgeMapAnalysis) Finalize() interface{} {
+	collect := func(people []map[string]*decayedLines) []map[string]float64 {
+		result := make([]map[string]float64, len(people))
+		for i, counts := range people {
+			result[i] = map[string]float64{}
+			for key, counter := range counts {
+				result[i][key] = counter.Value * expertiseDecay(
+					float64(km.lastDay-counter.Day), km.HalfLife)
+			}
+		}
+		return result
+	}
+	return newKnowledgeMapResult(km.HalfLife, km.DirectoryDepth, collect(km.lines),
+		collect(km.commits), km.reversedPeopleDict)
+}
+
+// newKnowledgeMapResult converts the per-developer directory weights to the matrices.
+func newKnowledgeMapResult(halfLife, depth int, lines, commits []map[string]float64,
+	reversedPeopleDict []string) KnowledgeMapResult {
+	dirSet := map[string]bool{}
+	for _, counts := range commits {
+		for dir := range counts {
+			dirSet[dir] = true
+		}
+	}
+	dirs := make([]string, 0, len(dirSet))
+	for dir := range dirSet {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	matrix := func(people []map[string]float64) [][]float64 {
+		rows := make([][]float64, len(people))
+		for i, counts := range people {
+			rows[i] = make([]float64, len(dirs))
+			for j, dir := range dirs {
+				rows[i][j] = counts[dir]
+			}
+		}
+		return rows
+	}
+	return KnowledgeMapResult{
+		HalfLife:           halfLife,
+		DirectoryDepth:     depth,
+		Directories:        dirs,
+		PeopleLines:        matrix(lines),
+		PeopleCommits:      matrix(commits),
+		reversedPeopleDict: reversedPeopleDict,
+	}
+}
+
+// Fork clones this pipeline item.
+func (km *KnowledgeMapAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(km, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (km *KnowledgeMapAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	kmResult := result.(KnowledgeMapResult)
+	if binary {
+		return km.serializeBinary(&kmResult, writer)
+	}
+	km.serializeText(&kmResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (km *KnowledgeMapAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(km, result, &pb.KnowledgeMapAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (km *KnowledgeMapAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(km, result, &pb.KnowledgeMapAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to KnowledgeMapResult.
+func (km *KnowledgeMapAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.KnowledgeMapAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	convert := func(vectors []*pb.KnowledgeMapVector) [][]float64 {
+		rows := make([][]float64, len(vectors))
+		for i, vector := range vectors {
+			rows[i] = make([]float64, len(vector.Values))
+			copy(rows[i], vector.Values)
+		}
+		return rows
+	}
+	result := KnowledgeMapResult{
+		HalfLife:           int(message.HalfLife),
+		DirectoryDepth:     int(message.DirectoryDepth),
+		Directories:        message.Directories,
+		PeopleLines:        convert(message.PeopleLines),
+		PeopleCommits:      convert(message.PeopleCommits),
+		reversedPeopleDict: message.DevIndex,
+	}
+	return result, nil
+}
+
+// MergeResults combines two KnowledgeMapResult-s together. The older result is decayed
+// by the time between the ends of the histories with the half-life of the first result.
+// The directories are taken as is, so both results should have the same depth.
+func (km *KnowledgeMapAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	kr1 := r1.(KnowledgeMapResult)
+	kr2 := r2.(KnowledgeMapResult)
+	people, reversedPeopleDict := identity.Detector{}.MergeReversedDicts(
+		kr1.reversedPeopleDict, kr2.reversedPeopleDict)
+	lines := make([]map[string]float64, len(reversedPeopleDict)+1)
+	commits := make([]map[string]float64, len(reversedPeopleDict)+1)
+	for i := range lines {
+		lines[i] = map[string]float64{}
+		commits[i] = map[string]float64{}
+	}
+	endTime := c1.EndTime
+	if c2.EndTime > endTime {
+		endTime = c2.EndTime
+	}
+	add := func(result *KnowledgeMapResult, c *core.CommonAnalysisResult) {
+		decay := expertiseDecay(float64(endTime-c.EndTime)/(24*3600), kr1.HalfLife)
+		for dev := range result.PeopleCommits {
+			index := len(reversedPeopleDict)
+			if dev < len(result.reversedPeopleDict) {
+				index = people[result.reversedPeopleDict[dev]][0]
+			}
+			for i, dir := range result.Directories {
+				if val := result.PeopleLines[dev][i]; val > 0 {
+					lines[index][dir] += val * decay
+				}
+				if val := result.PeopleCommits[dev][i]; val > 0 {
+					commits[index][dir] += val * decay
+				}
+			}
+		}
+	}
+	add(&kr1, c1)
+	add(&kr2, c2)
+	return newKnowledgeMapResult(kr1.HalfLife, kr1.DirectoryDepth, lines, commits,
+		reversedPeopleDict)
+}
+
+func (km *KnowledgeMapAnalysis) serializeText(result *KnowledgeMapResult, writer io.Writer) {
+	writeRows := func(name string, rows [][]float64) {
+		fmt.Fprintf(writer, "  %s:\n", name)
+		for _, row := range rows {
+			fmt.Fprint(writer, "  - [")
+			for i, val := range row {
+				if i > 0 {
+					fmt.Fprint(writer, ", ")
+				}
+				fmt.Fprintf(writer, "%.4f", val)
+			}
+			fmt.Fprintln(writer, "]")
+		}
+	}
+	fmt.Fprintln(writer, "  half_life:", result.HalfLife)
+	fmt.Fprintln(writer, "  depth:", result.DirectoryDepth)
+	fmt.Fprint(writer, "  directories: [")
+	for i, dir := range result.Directories {
+		if i > 0 {
+			fmt.Fprint(writer, ", ")
+		}
+		fmt.Fprint(writer, yaml.SafeString(dir))
+	}
+	fmt.Fprintln(writer, "]")
+	writeRows("people_lines", result.PeopleLines)
+	writeRows("people_commits", result.PeopleCommits)
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (km *KnowledgeMapAnalysis) serializeBinary(result *KnowledgeMapResult, writer io.Writer) error {
+	convert := func(rows [][]float64) []*pb.KnowledgeMapVector {
+		vectors := make([]*pb.KnowledgeMapVector, len(rows))
+		for i, row := range rows {
+			vectors[i] = &pb.KnowledgeMapVector{Values: row}
+		}
+		return vectors
+	}
+	message := pb.KnowledgeMapAnalysisResults{
+		HalfLife:       int32(result.HalfLife),
+		DirectoryDepth: int32(result.DirectoryDepth),
+		Directories:    result.Directories,
+		PeopleLines:    convert(result.PeopleLines),
+		PeopleCommits:  convert(result.PeopleCommits),
+		DevIndex:       result.reversedPeopleDict,
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&KnowledgeMapAnalysis{})
+}
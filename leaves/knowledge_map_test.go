@@ -0,0 +1,170 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureKnowledgeMap(halfLife, depth int) *KnowledgeMapAnalysis {
+	km := KnowledgeMapAnalysis{}
+	km.Configure(map[string]interface{}{
+		ConfigKnowledgeMapHalfLife:                      halfLife,
+		ConfigKnowledgeMapDirectoryDepth:                depth,
+		identity.FactIdentityDetectorPeopleCount:        2,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"alice", "bob"},
+	})
+	km.Initialize(nil)
+	return &km
+}
+
+func TestKnowledgeMapMeta(t *testing.T) {
+	km := fixtureKnowledgeMap(10, 2)
+	assert.Equal(t, km.Name(), "KnowledgeMap")
+	assert.Len(t, km.Provides(), 0)
+	assert.Equal(t, km.Requires(), []string{
+		identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges,
+		items.DependencyFileDiff, items.DependencyBlobCache})
+	opts := km.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Flag, "knowledge-map-half-life")
+	assert.Equal(t, opts[1].Flag, "knowledge-map-depth")
+	assert.Equal(t, km.Flag(), "knowledge-map")
+	assert.NotEmpty(t, km.Description())
+	assert.Equal(t, km.HalfLife, 10)
+	assert.Equal(t, km.DirectoryDepth, 2)
+	assert.Equal(t, km.PeopleNumber, 2)
+	assert.Equal(t, km.reversedPeopleDict, []string{"alice", "bob"})
+	km = &KnowledgeMapAnalysis{HalfLife: -1}
+	km.Initialize(nil)
+	assert.Equal(t, km.HalfLife, DefaultKnowledgeMapHalfLife)
+	assert.Equal(t, km.DirectoryDepth, DefaultKnowledgeMapDirectoryDepth)
+	summoned := core.Registry.Summon(km.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "KnowledgeMap")
+}
+
+func TestKnowledgeMapConsumeFinalize(t *testing.T) {
+	hash, blob := storeExpertiseBlob(t, "one\ntwo\nthree\nfour\n")
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	km := fixtureKnowledgeMap(10, 2)
+	consume := func(author, day int, merge bool, changes object.Changes,
+		fileDiffs map[string]items.FileDiffData) {
+		commit := &object.Commit{}
+		if merge {
+			commit.Hash = plumbing.NewHash("0123456789012345678901234567890123456789")
+			commit.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		result, err := km.Consume(map[string]interface{}{
+			identity.DependencyAuthor:   author,
+			items.DependencyDay:         day,
+			items.DependencyBlobCache:   map[plumbing.Hash]*object.Blob{hash: blob},
+			items.DependencyTreeChanges: changes,
+			items.DependencyFileDiff:    fileDiffs,
+			core.DependencyCommit:       commit,
+			core.DependencyIsMerge:      merge,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	// alice adds two files in core/a on day 0: 8 lines and 1 commit decay to 2 and 0.25 by day 20
+	consume(0, 0, false, object.Changes{
+		{To: entry("core/a/main.go")}, {To: entry("core/a/util.go")}}, nil)
+	// bob modifies 2 lines in the root on day 10
+	consume(1, 10, false, object.Changes{{From: entry("setup.py"), To: entry("setup.py")}},
+		map[string]items.FileDiffData{"setup.py": {Diffs: []diffmatchpatch.Diff{
+			{Type: diffmatchpatch.DiffEqual, Text: "ab"},
+			{Type: diffmatchpatch.DiffDelete, Text: "c"},
+			{Type: diffmatchpatch.DiffInsert, Text: "d"},
+		}}})
+	// an unknown developer deletes 4 lines in core/a and in core/b on day 20
+	consume(identity.AuthorMissing, 20, false, object.Changes{
+		{From: entry("core/a/main.go")}, {From: entry("core/b/x/y.go")}}, nil)
+	// the merges are ignored
+	consume(0, 20, true, object.Changes{{To: entry("lib/other.py")}}, nil)
+	result := km.Finalize().(KnowledgeMapResult)
+	assert.Equal(t, result.HalfLife, 10)
+	assert.Equal(t, result.DirectoryDepth, 2)
+	assert.Equal(t, result.Directories, []string{".", "core/a", "core/b"})
+	assert.Equal(t, result.PeopleLines, [][]float64{{0, 2, 0}, {1, 0, 0}, {0, 4, 4}})
+	assert.Equal(t, result.PeopleCommits, [][]float64{{0, 0.25, 0}, {0.5, 0, 0}, {0, 1, 1}})
+	assert.Equal(t, result.reversedPeopleDict, []string{"alice", "bob"})
+}
+
+func fixtureKnowledgeMapResult() KnowledgeMapResult {
+	return KnowledgeMapResult{
+		HalfLife:           10,
+		DirectoryDepth:     1,
+		Directories:        []string{".", "core"},
+		PeopleLines:        [][]float64{{2, 6}, {0, 3}, {0, 0}},
+		PeopleCommits:      [][]float64{{1, 2}, {0, 1}, {0, 0}},
+		reversedPeopleDict: []string{"alice", "bob"},
+	}
+}
+
+func TestKnowledgeMapSerialize(t *testing.T) {
+	km := fixtureKnowledgeMap(10, 1)
+	result := fixtureKnowledgeMapResult()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, km.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  half_life: 10
+  depth: 1
+  directories: [".", "core"]
+  people_lines:
+  - [2.0000, 6.0000]
+  - [0.0000, 3.0000]
+  - [0.0000, 0.0000]
+  people_commits:
+  - [1.0000, 2.0000]
+  - [0.0000, 1.0000]
+  - [0.0000, 0.0000]
+  people:
+  - "alice"
+  - "bob"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, km.Serialize(result, true, buffer))
+	msg := pb.KnowledgeMapAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.DirectoryDepth, int32(1))
+	assert.Equal(t, msg.PeopleLines[0].Values, []float64{2, 6})
+	deserialized, err := km.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+	_, err = km.Deserialize([]byte("garbage"))
+	assert.NotNil(t, err)
+}
+
+func TestKnowledgeMapMergeResults(t *testing.T) {
+	km := fixtureKnowledgeMap(10, 1)
+	r1 := fixtureKnowledgeMapResult()
+	r2 := KnowledgeMapResult{
+		HalfLife:           10,
+		DirectoryDepth:     1,
+		Directories:        []string{"core", "src"},
+		PeopleLines:        [][]float64{{4, 8}, {1, 0}},
+		PeopleCommits:      [][]float64{{2, 2}, {1, 0}},
+		reversedPeopleDict: []string{"bob"},
+	}
+	// r1 ends 10 days earlier, so it is decayed by half
+	merged := km.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{EndTime: 0},
+		&core.CommonAnalysisResult{EndTime: 10 * 24 * 3600}).(KnowledgeMapResult)
+	assert.Equal(t, merged.HalfLife, 10)
+	assert.Equal(t, merged.DirectoryDepth, 1)
+	assert.Equal(t, merged.reversedPeopleDict, []string{"alice", "bob"})
+	assert.Equal(t, merged.Directories, []string{".", "core", "src"})
+	assert.Equal(t, merged.PeopleLines, [][]float64{{1, 3, 0}, {0, 5.5, 8}, {0, 1, 0}})
+	assert.Equal(t, merged.PeopleCommits, [][]float64{{0.5, 1, 0}, {0, 2.5, 2}, {0, 1, 0}})
+}
@@ -0,0 +1,336 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// LanguageLinesAnalysis counts the lines of code in each programming language at the end of
+// each tick of Sampling days. This is the same time series as running cloc on the checkouts
+// of many commits, but in a single pass. The files which enry does not recognize are counted
+// as "Other" and the binary files are skipped. The merge commits are ignored.
+type LanguageLinesAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the number of days in a tick.
+	Sampling int
+
+	// files map the file names to their languages and numbers of lines.
+	files map[string]languageLinesFile
+	// lines map the languages to the current numbers of lines.
+	lines map[string]int
+	// ticks are the copies of lines at the end of each tick.
+	ticks []map[string]int
+}
+
+// LanguageLinesResult is returned by LanguageLinesAnalysis.Finalize().
+type LanguageLinesResult struct {
+	// Languages map the programming languages to the numbers of lines at the end of each tick.
+	// All the series have the same length.
+	Languages map[string][]int
+	// Sampling is the effective LanguageLinesAnalysis.Sampling.
+	Sampling int
+}
+
+// languageLinesFile is what LanguageLinesAnalysis remembers about each existing file.
+type languageLinesFile struct {
+	language string
+	lines    int
+}
+
+const (
+	// ConfigLanguageLinesSampling is the name of the option to set LanguageLinesAnalysis.Sampling.
+	ConfigLanguageLinesSampling = "LanguageLines.Sampling"
+	// DefaultLanguageLinesSampling is the default value of LanguageLinesAnalysis.Sampling.
+	DefaultLanguageLinesSampling = 30
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (analyser *LanguageLinesAnalysis) Name() string {
+	return "LanguageLines"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (analyser *LanguageLinesAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (analyser *LanguageLinesAnalysis) Requires() []string {
+	arr := [...]string{
+		items.DependencyDay, items.DependencyTreeChanges, items.DependencyBlobCache,
+		items.DependencyLanguages}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (analyser *LanguageLinesAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigLanguageLinesSampling,
+		Description: "How frequently to record the lines of code per language, in days.",
+		Flag:        "language-lines-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultLanguageLinesSampling},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (analyser *LanguageLinesAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigLanguageLinesSampling].(int); exists {
+		analyser.Sampling = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (analyser *LanguageLinesAnalysis) Flag() string {
+	return "language-lines"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (analyser *LanguageLinesAnalysis) Description() string {
+	return "Counts the lines of code in each programming language over time."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (analyser *LanguageLinesAnalysis) Initialize(repository *git.Repository) {
+	if analyser.Sampling <= 0 {
+		log.Printf("Warning: adjusted the language lines sampling to %d days\n",
+			DefaultLanguageLinesSampling)
+		analyser.Sampling = DefaultLanguageLinesSampling
+	}
+	analyser.files = map[string]languageLinesFile{}
+	analyser.lines = map[string]int{}
+	analyser.ticks = nil
+	analyser.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (analyser *LanguageLinesAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !analyser.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	languages := deps[items.DependencyLanguages].(map[string]string)
+	tick := deps[items.DependencyDay].(int) / analyser.Sampling
+	for len(analyser.ticks) < tick {
+		analyser.ticks = append(analyser.ticks, analyser.snapshot())
+	}
+	for _, change := range changes {
+		if file, exists := analyser.files[change.From.Name]; exists {
+			analyser.lines[file.language] -= file.lines
+			delete(analyser.files, change.From.Name)
+		}
+		if change.To.Name == "" {
+			continue
+		}
+		lines, err := items.CountLines(cache[change.To.TreeEntry.Hash])
+		if err != nil {
+			if err.Error() == "binary" {
+				continue
+			}
+			return nil, err
+		}
+		language := languages[change.To.Name]
+		if language == "" {
+			language = otherLanguage
+		}
+		analyser.files[change.To.Name] = languageLinesFile{language: language, lines: lines}
+		analyser.lines[language] += lines
+	}
+	if len(analyser.ticks) == tick {
+		analyser.ticks = append(analyser.ticks, nil)
+	}
+	analyser.ticks[tick] = analyser.snapshot()
+	return nil, nil
+}
+
+// snapshot copies the current numbers of lines.
+func (analyser *LanguageLinesAnalysis) snapshot() map[string]int {
+	lines := make(map[string]int, len(analyser.lines))
+	for language, val := range analyser.lines {
+		lines[language] = val
+	}
+	return lines
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (analyser *LanguageLinesAnalysis) Finalize() interface{} {
+	return LanguageLinesResult{
+		Languages: languageLinesSeries(analyser.ticks), Sampling: analyser.Sampling}
+}
+
+// languageLinesSeries transposes the per-tick line counts to the per-language series.
+func languageLinesSeries(ticks []map[string]int) map[string][]int {
+	series := map[string][]int{}
+	for i, tick := range ticks {
+		for language, lines := range tick {
+			if series[language] == nil {
+				series[language] = make([]int, len(ticks))
+			}
+			series[language][i] = lines
+		}
+	}
+	return series
+}
+
+// Fork clones this pipeline item.
+func (analyser *LanguageLinesAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(analyser, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (analyser *LanguageLinesAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	linesResult := result.(LanguageLinesResult)
+	if binary {
+		return analyser.serializeBinary(&linesResult, writer)
+	}
+	analyser.serializeText(&linesResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (analyser *LanguageLinesAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(analyser, result, &pb.LanguageLinesAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (analyser *LanguageLinesAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(analyser, result, &pb.LanguageLinesAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to LanguageLinesResult.
+func (analyser *LanguageLinesAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.LanguageLinesAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := LanguageLinesResult{
+		Languages: map[string][]int{}, Sampling: int(message.Sampling)}
+	for language, series := range message.Languages {
+		lines := make([]int, len(series.Ticks))
+		for i, val := range series.Ticks {
+			lines[i] = int(val)
+		}
+		result.Languages[language] = lines
+	}
+	return result, nil
+}
+
+// MergeResults combines two LanguageLinesResult-s together. The ticks are regrouped
+// by the larger sampling and the lines are summed: each repository keeps its last known
+// numbers of lines after its history ends.
+func (analyser *LanguageLinesAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	lr1 := r1.(LanguageLinesResult)
+	lr2 := r2.(LanguageLinesResult)
+	sampling := lr1.Sampling
+	if lr2.Sampling > sampling {
+		sampling = lr2.Sampling
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	size := 0
+	regroup := func(result *LanguageLinesResult, c *core.CommonAnalysisResult) map[string][]int {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		regrouped := map[string][]int{}
+		for language, lines := range result.Languages {
+			var series []int
+			for i, val := range lines {
+				index := (i*result.Sampling + offset) / sampling
+				for len(series) <= index {
+					series = append(series, 0)
+				}
+				series[index] = val
+			}
+			if len(series) > size {
+				size = len(series)
+			}
+			regrouped[language] = series
+		}
+		return regrouped
+	}
+	merged := LanguageLinesResult{Languages: map[string][]int{}, Sampling: sampling}
+	for _, regrouped := range [...]map[string][]int{regroup(&lr1, c1), regroup(&lr2, c2)} {
+		for language, series := range regrouped {
+			lines := merged.Languages[language]
+			if lines == nil {
+				lines = make([]int, size)
+				merged.Languages[language] = lines
+			}
+			for i := range lines {
+				if i < len(series) {
+					lines[i] += series[i]
+				} else if len(series) > 0 {
+					lines[i] += series[len(series)-1]
+				}
+			}
+		}
+	}
+	return merged
+}
+
+func (analyser *LanguageLinesAnalysis) serializeText(result *LanguageLinesResult, writer io.Writer) {
+	languages := make([]string, 0, len(result.Languages))
+	for language := range result.Languages {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  languages:")
+	for _, language := range languages {
+		fmt.Fprintf(writer, "    %s: [", yaml.SafeString(language))
+		writeIntList(writer, result.Languages[language])
+		fmt.Fprintln(writer, "]")
+	}
+}
+
+func (analyser *LanguageLinesAnalysis) serializeBinary(
+	result *LanguageLinesResult, writer io.Writer) error {
+	message := pb.LanguageLinesAnalysisResults{
+		Languages: map[string]*pb.LanguageLines{},
+		Sampling:  int32(result.Sampling),
+	}
+	for language, lines := range result.Languages {
+		series := &pb.LanguageLines{Ticks: make([]int32, len(lines))}
+		for i, val := range lines {
+			series.Ticks[i] = int32(val)
+		}
+		message.Languages[language] = series
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&LanguageLinesAnalysis{})
+}
@@ -0,0 +1,134 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func fixtureLanguageLines() *LanguageLinesAnalysis {
+	analyser := LanguageLinesAnalysis{}
+	analyser.Configure(map[string]interface{}{ConfigLanguageLinesSampling: 10})
+	analyser.Initialize(nil)
+	return &analyser
+}
+
+func TestLanguageLinesMeta(t *testing.T) {
+	analyser := fixtureLanguageLines()
+	assert.Equal(t, analyser.Name(), "LanguageLines")
+	assert.Len(t, analyser.Provides(), 0)
+	assert.Equal(t, analyser.Requires(), []string{
+		items.DependencyDay, items.DependencyTreeChanges, items.DependencyBlobCache,
+		items.DependencyLanguages})
+	assert.Equal(t, analyser.Flag(), "language-lines")
+	assert.NotEmpty(t, analyser.Description())
+	opts := analyser.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Flag, "language-lines-sampling")
+	assert.Equal(t, analyser.Sampling, 10)
+	analyser = &LanguageLinesAnalysis{}
+	analyser.Initialize(nil)
+	assert.Equal(t, analyser.Sampling, DefaultLanguageLinesSampling)
+	summoned := core.Registry.Summon(analyser.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "LanguageLines")
+}
+
+func fixtureLanguageLinesResult(t *testing.T) LanguageLinesResult {
+	hash3, blob3 := storeExpertiseBlob(t, "one\ntwo\nthree\n")
+	hash5, blob5 := storeExpertiseBlob(t, "one\ntwo\nthree\nfour\nfive\n")
+	hashBin, blobBin := storeExpertiseBlob(t, "\x00\x01\x02\n")
+	cache := map[plumbing.Hash]*object.Blob{hash3: blob3, hash5: blob5, hashBin: blobBin}
+	entry := func(name string, hash plumbing.Hash) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	analyser := fixtureLanguageLines()
+	consume := func(day int, merge bool, languages map[string]string, changes ...*object.Change) {
+		commit := &object.Commit{}
+		if merge {
+			commit.Hash = plumbing.NewHash("0123456789012345678901234567890123456789")
+			commit.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		result, err := analyser.Consume(map[string]interface{}{
+			core.DependencyCommit:       commit,
+			core.DependencyIsMerge:      merge,
+			items.DependencyDay:         day,
+			items.DependencyTreeChanges: object.Changes(changes),
+			items.DependencyBlobCache:   cache,
+			items.DependencyLanguages:   languages,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	consume(0, false, map[string]string{"a.go": "Go", "b.py": "Python", "c.png": "", "d": ""},
+		&object.Change{To: entry("a.go", hash3)}, &object.Change{To: entry("b.py", hash5)},
+		&object.Change{To: entry("c.png", hashBin)}, &object.Change{To: entry("d", hash3)})
+	// the tick without commits keeps the lines
+	consume(25, false, map[string]string{"a.go": "Go", "b.go": "Go", "d": ""},
+		&object.Change{From: entry("a.go", hash3), To: entry("a.go", hash5)},
+		&object.Change{From: entry("b.py", hash5), To: entry("b.go", hash5)},
+		&object.Change{From: entry("d", hash3)})
+	consume(26, true, map[string]string{"e.go": "Go"}, &object.Change{To: entry("e.go", hash5)})
+	return analyser.Finalize().(LanguageLinesResult)
+}
+
+func TestLanguageLinesConsumeFinalize(t *testing.T) {
+	result := fixtureLanguageLinesResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Languages, map[string][]int{
+		"Go":     {3, 3, 10},
+		"Python": {5, 5, 0},
+		"Other":  {3, 3, 0},
+	})
+}
+
+func TestLanguageLinesSerialize(t *testing.T) {
+	result := fixtureLanguageLinesResult(t)
+	analyser := fixtureLanguageLines()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  languages:
+    "Go": [3, 3, 10]
+    "Other": [3, 3, 0]
+    "Python": [5, 5, 0]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, true, buffer))
+	msg := pb.LanguageLinesAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Len(t, msg.Languages, 3)
+	assert.Equal(t, msg.Languages["Go"].Ticks, []int32{3, 3, 10})
+	deserialized, err := analyser.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestLanguageLinesMergeResults(t *testing.T) {
+	r1 := LanguageLinesResult{
+		Languages: map[string][]int{"Go": {10, 20, 30}, "Python": {5, 0, 0}},
+		Sampling:  10,
+	}
+	r2 := LanguageLinesResult{
+		Languages: map[string][]int{"Go": {100}, "Rust": {50}},
+		Sampling:  20,
+	}
+	analyser := fixtureLanguageLines()
+	merged := analyser.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 20 * 24 * 3600}).(LanguageLinesResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.Languages, map[string][]int{
+		"Go":     {20, 130},
+		"Python": {0, 0},
+		"Rust":   {0, 50},
+	})
+}
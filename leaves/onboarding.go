@@ -0,0 +1,523 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// OnboardingAnalysis measures how fast the developers get up to speed: the number of days
+// from the first commit to the Commits-th commit and to the first change in each directory.
+// The developers are grouped into the joining cohorts of CohortDays days by their first commit,
+// and the medians are reported for each cohort. The merge commits and the unmatched identities
+// are ignored.
+type OnboardingAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Commits is the number of commits which defines an onboarded developer.
+	Commits int
+	// CohortDays is the number of days in a joining cohort.
+	CohortDays int
+	// DirectoryDepth is the number of path components which define the directories.
+	DirectoryDepth int
+	// PeopleNumber is the number of developers.
+	PeopleNumber int
+
+	// people is the onboarding record of each developer.
+	people []OnboardingDeveloper
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// OnboardingDeveloper is the onboarding record of a developer.
+type OnboardingDeveloper struct {
+	// CommitDays are the sorted days of the first Commits commits.
+	CommitDays []int
+	// Directories map the directories to the days of the first changes in them.
+	Directories map[string]int
+}
+
+// OnboardingDirectory is the summary of the first changes in a directory made by a cohort.
+type OnboardingDirectory struct {
+	// Developers is the number of developers who changed the directory.
+	Developers int
+	// MedianDays is the median number of days from the first commit to the first change
+	// in the directory.
+	MedianDays float64
+}
+
+// OnboardingCohort is the summary of the developers who joined in the same period.
+type OnboardingCohort struct {
+	// Developers is the number of developers who made their first commit in the period.
+	Developers int
+	// Reached is the number of developers who made OnboardingAnalysis.Commits commits.
+	Reached int
+	// MedianDays is the median number of days from the first commit to the Commits-th commit
+	// among the developers who reached it, -1 if nobody did.
+	MedianDays float64
+	// Directories map the directories to the summaries of their first changes.
+	Directories map[string]OnboardingDirectory
+}
+
+// OnboardingResult is returned by OnboardingAnalysis.Finalize().
+type OnboardingResult struct {
+	// Cohorts are the joining cohorts in the chronological order.
+	Cohorts []OnboardingCohort
+	// People is the onboarding record of each developer.
+	People []OnboardingDeveloper
+	// Commits is the effective OnboardingAnalysis.Commits.
+	Commits int
+	// CohortDays is the effective OnboardingAnalysis.CohortDays.
+	CohortDays int
+	// DirectoryDepth is the effective OnboardingAnalysis.DirectoryDepth.
+	DirectoryDepth int
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigOnboardingCommits is the name of the option to set OnboardingAnalysis.Commits.
+	ConfigOnboardingCommits = "Onboarding.Commits"
+	// ConfigOnboardingCohortDays is the name of the option to set OnboardingAnalysis.CohortDays.
+	ConfigOnboardingCohortDays = "Onboarding.CohortDays"
+	// ConfigOnboardingDirectoryDepth is the name of the option to set
+	// OnboardingAnalysis.DirectoryDepth.
+	ConfigOnboardingDirectoryDepth = "Onboarding.DirectoryDepth"
+	// DefaultOnboardingCommits is the default value of OnboardingAnalysis.Commits.
+	DefaultOnboardingCommits = 10
+	// DefaultOnboardingCohortDays is the default value of OnboardingAnalysis.CohortDays.
+	DefaultOnboardingCohortDays = 90
+	// DefaultOnboardingDirectoryDepth is the default value of OnboardingAnalysis.DirectoryDepth:
+	// the top-level directories.
+	DefaultOnboardingDirectoryDepth = 1
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (onboarding *OnboardingAnalysis) Name() string {
+	return "Onboarding"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (onboarding *OnboardingAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (onboarding *OnboardingAnalysis) Requires() []string {
+	arr := [...]string{identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (onboarding *OnboardingAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigOnboardingCommits,
+		Description: "Number of commits after which a developer is considered onboarded.",
+		Flag:        "onboarding-commits",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultOnboardingCommits}, {
+		Name:        ConfigOnboardingCohortDays,
+		Description: "Length of the joining cohorts in days.",
+		Flag:        "onboarding-cohort",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultOnboardingCohortDays}, {
+		Name:        ConfigOnboardingDirectoryDepth,
+		Description: "Number of path components which define the directories.",
+		Flag:        "onboarding-depth",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultOnboardingDirectoryDepth},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (onboarding *OnboardingAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigOnboardingCommits].(int); exists {
+		onboarding.Commits = val
+	}
+	if val, exists := facts[ConfigOnboardingCohortDays].(int); exists {
+		onboarding.CohortDays = val
+	}
+	if val, exists := facts[ConfigOnboardingDirectoryDepth].(int); exists {
+		onboarding.DirectoryDepth = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		onboarding.PeopleNumber = val
+		onboarding.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (onboarding *OnboardingAnalysis) Flag() string {
+	return "onboarding"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (onboarding *OnboardingAnalysis) Description() string {
+	return "Measures the time from the first commit of each developer to the Nth commit and " +
+		"to the first change in each directory, aggregated by the joining cohorts."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (onboarding *OnboardingAnalysis) Initialize(repository *git.Repository) {
+	if onboarding.Commits <= 0 {
+		log.Printf("Warning: adjusted the onboarding commits to %d\n", DefaultOnboardingCommits)
+		onboarding.Commits = DefaultOnboardingCommits
+	}
+	if onboarding.CohortDays <= 0 {
+		log.Printf("Warning: adjusted the onboarding cohort length to %d days\n",
+			DefaultOnboardingCohortDays)
+		onboarding.CohortDays = DefaultOnboardingCohortDays
+	}
+	if onboarding.DirectoryDepth <= 0 {
+		log.Printf("Warning: adjusted the onboarding directory depth to %d\n",
+			DefaultOnboardingDirectoryDepth)
+		onboarding.DirectoryDepth = DefaultOnboardingDirectoryDepth
+	}
+	onboarding.people = make([]OnboardingDeveloper, onboarding.PeopleNumber)
+	for i := range onboarding.people {
+		onboarding.people[i].Directories = map[string]int{}
+	}
+	onboarding.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (onboarding *OnboardingAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !onboarding.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing || author >= onboarding.PeopleNumber {
+		return nil, nil
+	}
+	day := deps[items.DependencyDay].(int)
+	dev := &onboarding.people[author]
+	dev.CommitDays = insertOnboardingDay(dev.CommitDays, day, onboarding.Commits)
+	for _, change := range deps[items.DependencyTreeChanges].(object.Changes) {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		name := change.To.Name
+		if action == merkletrie.Delete {
+			name = change.From.Name
+		}
+		dir := truncateDirectory(name, onboarding.DirectoryDepth)
+		if first, exists := dev.Directories[dir]; !exists || day < first {
+			dev.Directories[dir] = day
+		}
+	}
+	return nil, nil
+}
+
+// insertOnboardingDay inserts the day into the sorted list and keeps at most `limit` first days.
+func insertOnboardingDay(days []int, day, limit int) []int {
+	pos := sort.SearchInts(days, day)
+	if pos >= limit {
+		return days
+	}
+	if len(days) < limit {
+		days = append(days, 0)
+	}
+	copy(days[pos+1:], days[pos:])
+	days[pos] = day
+	return days
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (onboarding *OnboardingAnalysis) Finalize() interface{} {
+	return newOnboardingResult(onboarding.people, onboarding.Commits, onboarding.CohortDays,
+		onboarding.DirectoryDepth, onboarding.reversedPeopleDict)
+}
+
+// newOnboardingResult groups the developers into the cohorts and calculates the medians.
+func newOnboardingResult(people []OnboardingDeveloper, commits, cohortDays, depth int,
+	reversedPeopleDict []string) OnboardingResult {
+	result := OnboardingResult{
+		People:             people,
+		Commits:            commits,
+		CohortDays:         cohortDays,
+		DirectoryDepth:     depth,
+		reversedPeopleDict: reversedPeopleDict,
+	}
+	type cohortSamples struct {
+		reached     []int
+		directories map[string][]int
+	}
+	var cohorts []cohortSamples
+	for _, dev := range people {
+		if len(dev.CommitDays) == 0 {
+			continue
+		}
+		first := dev.CommitDays[0]
+		index := first / result.CohortDays
+		for len(cohorts) <= index {
+			cohorts = append(cohorts, cohortSamples{directories: map[string][]int{}})
+			result.Cohorts = append(result.Cohorts, OnboardingCohort{
+				MedianDays: -1, Directories: map[string]OnboardingDirectory{}})
+		}
+		result.Cohorts[index].Developers++
+		if len(dev.CommitDays) >= commits {
+			cohorts[index].reached = append(cohorts[index].reached, dev.CommitDays[commits-1]-first)
+		}
+		for dir, day := range dev.Directories {
+			cohorts[index].directories[dir] = append(cohorts[index].directories[dir], day-first)
+		}
+	}
+	for i, cohort := range cohorts {
+		summary := &result.Cohorts[i]
+		summary.Reached = len(cohort.reached)
+		if summary.Reached > 0 {
+			summary.MedianDays = medianInts(cohort.reached)
+		}
+		for dir, days := range cohort.directories {
+			summary.Directories[dir] = OnboardingDirectory{
+				Developers: len(days), MedianDays: medianInts(days)}
+		}
+	}
+	return result
+}
+
+// medianInts returns the median of the non-empty list. The list is sorted in place.
+func medianInts(values []int) float64 {
+	sort.Ints(values)
+	middle := len(values) / 2
+	if len(values)%2 == 1 {
+		return float64(values[middle])
+	}
+	return float64(values[middle-1]+values[middle]) / 2
+}
+
+// Fork clones this pipeline item.
+func (onboarding *OnboardingAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(onboarding, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (onboarding *OnboardingAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	onboardingResult := result.(OnboardingResult)
+	if binary {
+		return onboarding.serializeBinary(&onboardingResult, writer)
+	}
+	onboarding.serializeText(&onboardingResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (onboarding *OnboardingAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(onboarding, result, &pb.OnboardingAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (onboarding *OnboardingAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(onboarding, result, &pb.OnboardingAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to OnboardingResult.
+func (onboarding *OnboardingAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.OnboardingAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := OnboardingResult{
+		People:             make([]OnboardingDeveloper, len(message.People)),
+		Commits:            int(message.Commits),
+		CohortDays:         int(message.CohortDays),
+		DirectoryDepth:     int(message.DirectoryDepth),
+		reversedPeopleDict: message.DevIndex,
+	}
+	if len(message.Cohorts) > 0 {
+		result.Cohorts = make([]OnboardingCohort, len(message.Cohorts))
+		for i, cohort := range message.Cohorts {
+			summary := OnboardingCohort{
+				Developers:  int(cohort.Developers),
+				Reached:     int(cohort.Reached),
+				MedianDays:  cohort.MedianDays,
+				Directories: map[string]OnboardingDirectory{},
+			}
+			for dir, val := range cohort.Directories {
+				summary.Directories[dir] = OnboardingDirectory{
+					Developers: int(val.Developers), MedianDays: val.MedianDays}
+			}
+			result.Cohorts[i] = summary
+		}
+	}
+	for i, person := range message.People {
+		dev := OnboardingDeveloper{Directories: map[string]int{}}
+		if len(person.CommitDays) > 0 {
+			dev.CommitDays = make([]int, len(person.CommitDays))
+			for j, day := range person.CommitDays {
+				dev.CommitDays[j] = int(day)
+			}
+		}
+		for dir, day := range person.Directories {
+			dev.Directories[dir] = int(day)
+		}
+		result.People[i] = dev
+	}
+	return result, nil
+}
+
+// MergeResults combines two OnboardingResult-s together. The records of the same developers
+// are joined and the cohorts are calculated again with the smaller Commits and the cohort
+// length and the depth of the first result.
+func (onboarding *OnboardingAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	or1 := r1.(OnboardingResult)
+	or2 := r2.(OnboardingResult)
+	commits := or1.Commits
+	if or2.Commits < commits {
+		commits = or2.Commits
+	}
+	people, reversedPeopleDict := identity.Detector{}.MergeReversedDicts(
+		or1.reversedPeopleDict, or2.reversedPeopleDict)
+	merged := make([]OnboardingDeveloper, len(reversedPeopleDict))
+	for i := range merged {
+		merged[i].Directories = map[string]int{}
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	add := func(result *OnboardingResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for i, dev := range result.People {
+			if i >= len(result.reversedPeopleDict) {
+				continue
+			}
+			target := &merged[people[result.reversedPeopleDict[i]][0]]
+			for _, day := range dev.CommitDays {
+				target.CommitDays = insertOnboardingDay(target.CommitDays, day+offset, commits)
+			}
+			for dir, day := range dev.Directories {
+				if first, exists := target.Directories[dir]; !exists || day+offset < first {
+					target.Directories[dir] = day + offset
+				}
+			}
+		}
+	}
+	add(&or1, c1)
+	add(&or2, c2)
+	return newOnboardingResult(merged, commits, or1.CohortDays, or1.DirectoryDepth,
+		reversedPeopleDict)
+}
+
+func (onboarding *OnboardingAnalysis) serializeText(result *OnboardingResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  commits:", result.Commits)
+	fmt.Fprintln(writer, "  cohort_days:", result.CohortDays)
+	fmt.Fprintln(writer, "  depth:", result.DirectoryDepth)
+	fmt.Fprintln(writer, "  cohorts:")
+	for _, cohort := range result.Cohorts {
+		fmt.Fprintf(writer, "    - developers: %d\n", cohort.Developers)
+		fmt.Fprintf(writer, "      reached: %d\n", cohort.Reached)
+		fmt.Fprintf(writer, "      median_days: %.1f\n", cohort.MedianDays)
+		dirs := make([]string, 0, len(cohort.Directories))
+		for dir := range cohort.Directories {
+			dirs = append(dirs, dir)
+		}
+		sort.Strings(dirs)
+		fmt.Fprintln(writer, "      # [developers, median days]")
+		fmt.Fprint(writer, "      directories: {")
+		for i, dir := range dirs {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			val := cohort.Directories[dir]
+			fmt.Fprintf(writer, "%s: [%d, %.1f]", yaml.SafeString(dir), val.Developers, val.MedianDays)
+		}
+		fmt.Fprintln(writer, "}")
+	}
+	fmt.Fprintln(writer, "  developers:")
+	for _, dev := range result.People {
+		fmt.Fprint(writer, "    - commit_days: [")
+		writeIntList(writer, dev.CommitDays)
+		fmt.Fprintln(writer, "]")
+		dirs := make([]string, 0, len(dev.Directories))
+		for dir := range dev.Directories {
+			dirs = append(dirs, dir)
+		}
+		sort.Strings(dirs)
+		fmt.Fprint(writer, "      directories: {")
+		for i, dir := range dirs {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprintf(writer, "%s: %d", yaml.SafeString(dir), dev.Directories[dir])
+		}
+		fmt.Fprintln(writer, "}")
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (onboarding *OnboardingAnalysis) serializeBinary(result *OnboardingResult, writer io.Writer) error {
+	message := pb.OnboardingAnalysisResults{
+		Commits:        int32(result.Commits),
+		CohortDays:     int32(result.CohortDays),
+		DirectoryDepth: int32(result.DirectoryDepth),
+		Cohorts:        make([]*pb.OnboardingCohort, len(result.Cohorts)),
+		People:         make([]*pb.OnboardingDeveloper, len(result.People)),
+		DevIndex:       result.reversedPeopleDict,
+	}
+	for i, cohort := range result.Cohorts {
+		summary := &pb.OnboardingCohort{
+			Developers:  int32(cohort.Developers),
+			Reached:     int32(cohort.Reached),
+			MedianDays:  cohort.MedianDays,
+			Directories: map[string]*pb.OnboardingDirectory{},
+		}
+		for dir, val := range cohort.Directories {
+			summary.Directories[dir] = &pb.OnboardingDirectory{
+				Developers: int32(val.Developers), MedianDays: val.MedianDays}
+		}
+		message.Cohorts[i] = summary
+	}
+	for i, dev := range result.People {
+		person := &pb.OnboardingDeveloper{
+			CommitDays:  make([]int32, len(dev.CommitDays)),
+			Directories: map[string]int32{},
+		}
+		for j, day := range dev.CommitDays {
+			person.CommitDays[j] = int32(day)
+		}
+		for dir, day := range dev.Directories {
+			person.Directories[dir] = int32(day)
+		}
+		message.People[i] = person
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&OnboardingAnalysis{})
+}
@@ -0,0 +1,211 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureOnboarding() *OnboardingAnalysis {
+	onboarding := OnboardingAnalysis{}
+	onboarding.Configure(map[string]interface{}{
+		ConfigOnboardingCommits:                         3,
+		ConfigOnboardingCohortDays:                      30,
+		ConfigOnboardingDirectoryDepth:                  1,
+		identity.FactIdentityDetectorPeopleCount:        3,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"alice", "bob", "carol"},
+	})
+	onboarding.Initialize(nil)
+	return &onboarding
+}
+
+func TestOnboardingMeta(t *testing.T) {
+	onboarding := fixtureOnboarding()
+	assert.Equal(t, onboarding.Name(), "Onboarding")
+	assert.Len(t, onboarding.Provides(), 0)
+	assert.Equal(t, onboarding.Requires(), []string{
+		identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges})
+	assert.Equal(t, onboarding.Flag(), "onboarding")
+	assert.NotEmpty(t, onboarding.Description())
+	opts := onboarding.ListConfigurationOptions()
+	assert.Len(t, opts, 3)
+	assert.Equal(t, opts[0].Flag, "onboarding-commits")
+	assert.Equal(t, opts[1].Flag, "onboarding-cohort")
+	assert.Equal(t, opts[2].Flag, "onboarding-depth")
+	assert.Equal(t, onboarding.Commits, 3)
+	assert.Equal(t, onboarding.CohortDays, 30)
+	assert.Equal(t, onboarding.DirectoryDepth, 1)
+	assert.Equal(t, onboarding.PeopleNumber, 3)
+	assert.Equal(t, onboarding.reversedPeopleDict, []string{"alice", "bob", "carol"})
+	onboarding = &OnboardingAnalysis{}
+	onboarding.Initialize(nil)
+	assert.Equal(t, onboarding.Commits, DefaultOnboardingCommits)
+	assert.Equal(t, onboarding.CohortDays, DefaultOnboardingCohortDays)
+	assert.Equal(t, onboarding.DirectoryDepth, DefaultOnboardingDirectoryDepth)
+	summoned := core.Registry.Summon(onboarding.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Onboarding")
+}
+
+func TestOnboardingHelpers(t *testing.T) {
+	var days []int
+	for _, day := range []int{5, 1, 5, 3, 0, 9} {
+		days = insertOnboardingDay(days, day, 4)
+	}
+	assert.Equal(t, days, []int{0, 1, 3, 5})
+	assert.Equal(t, medianInts([]int{3, 1, 2}), float64(2))
+	assert.Equal(t, medianInts([]int{4, 1, 2, 10}), 3.0)
+	assert.Equal(t, medianInts([]int{7}), float64(7))
+}
+
+func fixtureOnboardingResult(t *testing.T) OnboardingResult {
+	onboarding := fixtureOnboarding()
+	consume := func(author, day int, merge bool, changes object.Changes) {
+		commit := &object.Commit{}
+		if merge {
+			commit.Hash = plumbing.NewHash("0123456789012345678901234567890123456789")
+			commit.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		result, err := onboarding.Consume(map[string]interface{}{
+			core.DependencyCommit:       commit,
+			core.DependencyIsMerge:      merge,
+			identity.DependencyAuthor:   author,
+			items.DependencyDay:         day,
+			items.DependencyTreeChanges: changes,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	insert := func(name string) *object.Change {
+		return &object.Change{To: object.ChangeEntry{Name: name}}
+	}
+	consume(0, 0, false, object.Changes{insert("core/a.go")})
+	consume(0, 4, false, object.Changes{insert("docs/x.md")})
+	consume(0, 2, false, object.Changes{insert("core/b.go")})
+	consume(0, 10, false, object.Changes{insert("lib/c.go")})
+	consume(1, 5, false, object.Changes{insert("README.md")})
+	consume(1, 25, false, object.Changes{{From: object.ChangeEntry{Name: "core/d.go"}}})
+	consume(2, 40, false, object.Changes{insert("core/e.go")})
+	consume(2, 41, false, object.Changes{})
+	consume(2, 45, false, object.Changes{})
+	consume(2, 3, true, object.Changes{insert("lib/f.go")})
+	consume(identity.AuthorMissing, 1, false, object.Changes{insert("lib/g.go")})
+	return onboarding.Finalize().(OnboardingResult)
+}
+
+func TestOnboardingConsumeFinalize(t *testing.T) {
+	result := fixtureOnboardingResult(t)
+	assert.Equal(t, result.Commits, 3)
+	assert.Equal(t, result.CohortDays, 30)
+	assert.Equal(t, result.DirectoryDepth, 1)
+	assert.Equal(t, result.People, []OnboardingDeveloper{
+		{CommitDays: []int{0, 2, 4}, Directories: map[string]int{"core": 0, "docs": 4, "lib": 10}},
+		{CommitDays: []int{5, 25}, Directories: map[string]int{".": 5, "core": 25}},
+		{CommitDays: []int{40, 41, 45}, Directories: map[string]int{"core": 40}},
+	})
+	assert.Equal(t, result.Cohorts, []OnboardingCohort{
+		{Developers: 2, Reached: 1, MedianDays: 4, Directories: map[string]OnboardingDirectory{
+			".":    {Developers: 1, MedianDays: 0},
+			"core": {Developers: 2, MedianDays: 10},
+			"docs": {Developers: 1, MedianDays: 4},
+			"lib":  {Developers: 1, MedianDays: 10},
+		}},
+		{Developers: 1, Reached: 1, MedianDays: 5, Directories: map[string]OnboardingDirectory{
+			"core": {Developers: 1, MedianDays: 0},
+		}},
+	})
+	assert.Equal(t, result.reversedPeopleDict, []string{"alice", "bob", "carol"})
+	empty := fixtureOnboarding().Finalize().(OnboardingResult)
+	assert.Len(t, empty.Cohorts, 0)
+}
+
+func TestOnboardingSerialize(t *testing.T) {
+	result := fixtureOnboardingResult(t)
+	onboarding := fixtureOnboarding()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, onboarding.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  commits: 3
+  cohort_days: 30
+  depth: 1
+  cohorts:
+    - developers: 2
+      reached: 1
+      median_days: 4.0
+      # [developers, median days]
+      directories: {".": [1, 0.0], "core": [2, 10.0], "docs": [1, 4.0], "lib": [1, 10.0]}
+    - developers: 1
+      reached: 1
+      median_days: 5.0
+      # [developers, median days]
+      directories: {"core": [1, 0.0]}
+  developers:
+    - commit_days: [0, 2, 4]
+      directories: {"core": 0, "docs": 4, "lib": 10}
+    - commit_days: [5, 25]
+      directories: {".": 5, "core": 25}
+    - commit_days: [40, 41, 45]
+      directories: {"core": 40}
+  people:
+  - "alice"
+  - "bob"
+  - "carol"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, onboarding.Serialize(result, true, buffer))
+	msg := pb.OnboardingAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Commits, int32(3))
+	assert.Len(t, msg.Cohorts, 2)
+	assert.Equal(t, msg.Cohorts[0].Directories["core"].MedianDays, float64(10))
+	assert.Equal(t, msg.People[1].Directories, map[string]int32{".": 5, "core": 25})
+	deserialized, err := onboarding.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestOnboardingMergeResults(t *testing.T) {
+	r1 := OnboardingResult{
+		People: []OnboardingDeveloper{
+			{CommitDays: []int{0, 2}, Directories: map[string]int{"core": 0}}},
+		Commits:            3,
+		CohortDays:         30,
+		DirectoryDepth:     1,
+		reversedPeopleDict: []string{"alice"},
+	}
+	r2 := OnboardingResult{
+		People: []OnboardingDeveloper{
+			{CommitDays: []int{0, 1}, Directories: map[string]int{"lib": 1}},
+			{CommitDays: []int{3}, Directories: map[string]int{"core": 5, "docs": 3}}},
+		Commits:            2,
+		CohortDays:         10,
+		DirectoryDepth:     2,
+		reversedPeopleDict: []string{"bob", "alice"},
+	}
+	onboarding := fixtureOnboarding()
+	merged := onboarding.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 10 * 24 * 3600}).(OnboardingResult)
+	assert.Equal(t, merged.Commits, 2)
+	assert.Equal(t, merged.CohortDays, 30)
+	assert.Equal(t, merged.DirectoryDepth, 1)
+	assert.Equal(t, merged.reversedPeopleDict, []string{"alice", "bob"})
+	assert.Equal(t, merged.People, []OnboardingDeveloper{
+		{CommitDays: []int{0, 2}, Directories: map[string]int{"core": 0, "docs": 13}},
+		{CommitDays: []int{10, 11}, Directories: map[string]int{"lib": 11}},
+	})
+	assert.Equal(t, merged.Cohorts, []OnboardingCohort{
+		{Developers: 2, Reached: 2, MedianDays: 1.5, Directories: map[string]OnboardingDirectory{
+			"core": {Developers: 1, MedianDays: 0},
+			"docs": {Developers: 1, MedianDays: 13},
+			"lib":  {Developers: 1, MedianDays: 1},
+		}},
+	})
+}
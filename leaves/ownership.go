@@ -0,0 +1,399 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// OwnershipAnalysis reports how many lines each developer owns in the last analysed commit
+// in each directory and optionally in each file. A line belongs to the developer who changed
+// it last. The lines are tracked by the same machinery as in BurndownAnalysis, including
+// the merges and the history boundary.
+type OwnershipAnalysis struct {
+	// DirectoryDepth is the number of path components which define the directories.
+	DirectoryDepth int
+	// TrackFiles enables the ownership of each file.
+	TrackFiles bool
+	// PeopleNumber is the number of developers.
+	PeopleNumber int
+
+	// lines maintains the lines of the files and their owners; all its histories are disabled.
+	lines *BurndownAnalysis
+	// historyBoundary is copied from BurndownAnalysis.HistoryBoundary.
+	historyBoundary string
+	// ignoreFormatting is copied from BurndownAnalysis.IgnoreFormatting.
+	ignoreFormatting bool
+	// peopleDict references IdentityDetector.PeopleDict
+	peopleDict map[string]int
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// OwnershipResult is returned by OwnershipAnalysis.Finalize().
+type OwnershipResult struct {
+	// Directories are the sorted directories, the rows of DirectoryOwners. "." stands for
+	// the files in the root.
+	Directories []string
+	// DirectoryOwners is the ownership matrix: the number of lines each developer owns in
+	// each directory. The last column corresponds to the unmatched identities.
+	DirectoryOwners DenseHistory
+	// Files are the sorted files, the rows of FileOwners. Empty unless
+	// OwnershipAnalysis.TrackFiles is set.
+	Files []string
+	// FileOwners is the same as DirectoryOwners for Files.
+	FileOwners DenseHistory
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigOwnershipDirectoryDepth is the name of the option to set OwnershipAnalysis.DirectoryDepth.
+	ConfigOwnershipDirectoryDepth = "Ownership.DirectoryDepth"
+	// ConfigOwnershipTrackFiles is the name of the option to set OwnershipAnalysis.TrackFiles.
+	ConfigOwnershipTrackFiles = "Ownership.TrackFiles"
+	// DefaultOwnershipDirectoryDepth is the default value of OwnershipAnalysis.DirectoryDepth:
+	// the top-level directories.
+	DefaultOwnershipDirectoryDepth = 1
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (ownership *OwnershipAnalysis) Name() string {
+	return "Ownership"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (ownership *OwnershipAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (ownership *OwnershipAnalysis) Requires() []string {
+	arr := []string{
+		items.DependencyFileDiff, items.DependencyTreeChanges, items.DependencyBlobCache,
+		items.DependencyDay, identity.DependencyAuthor, identity.DependencyCoAuthors}
+	if ownership.ignoreFormatting {
+		arr = append(arr, items.DependencyFormattingHunks)
+	}
+	return arr
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (ownership *OwnershipAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigOwnershipDirectoryDepth,
+		Description: "Number of path components which define the directories in the ownership " +
+			"matrix, 1 means the top-level directories.",
+		Flag:    "ownership-depth",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultOwnershipDirectoryDepth}, {
+		Name:        ConfigOwnershipTrackFiles,
+		Description: "Report the line owners of each file in addition to the directories.",
+		Flag:        "ownership-files",
+		Type:        core.BoolConfigurationOption,
+		Default:     false},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (ownership *OwnershipAnalysis) Configure(facts map[string]interface{}) {
+	ownership.ConfigureRequirements(facts)
+	if val, exists := facts[ConfigOwnershipDirectoryDepth].(int); exists {
+		ownership.DirectoryDepth = val
+	}
+	if val, exists := facts[ConfigOwnershipTrackFiles].(bool); exists {
+		ownership.TrackFiles = val
+	}
+	if val, exists := facts[ConfigBurndownHistoryBoundary].(string); exists {
+		ownership.historyBoundary = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		ownership.PeopleNumber = val
+		ownership.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+		ownership.peopleDict, _ = facts[identity.FactIdentityDetectorPeopleDict].(map[string]int)
+	}
+}
+
+// ConfigureRequirements copies BurndownAnalysis.IgnoreFormatting which adds
+// DependencyFormattingHunks to Requires(). It is a part of core.DynamicPipelineItem.
+func (ownership *OwnershipAnalysis) ConfigureRequirements(facts map[string]interface{}) {
+	if val, exists := facts[ConfigBurndownIgnoreFormatting].(bool); exists {
+		ownership.ignoreFormatting = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (ownership *OwnershipAnalysis) Flag() string {
+	return "ownership"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (ownership *OwnershipAnalysis) Description() string {
+	return "Counts the lines which each developer owns in each directory in the last commit: " +
+		"a line belongs to the developer who changed it last."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (ownership *OwnershipAnalysis) Initialize(repository *git.Repository) {
+	if ownership.DirectoryDepth <= 0 {
+		log.Printf("Warning: adjusted the ownership directory depth to %d\n",
+			DefaultOwnershipDirectoryDepth)
+		ownership.DirectoryDepth = DefaultOwnershipDirectoryDepth
+	}
+	ownership.lines = &BurndownAnalysis{
+		Granularity:        DefaultBurndownGranularity,
+		Sampling:           DefaultBurndownGranularity,
+		PeopleNumber:       ownership.PeopleNumber,
+		HistoryBoundary:    ownership.historyBoundary,
+		IgnoreFormatting:   ownership.ignoreFormatting,
+		linesOnly:          true,
+		peopleDict:         ownership.peopleDict,
+		reversedPeopleDict: ownership.reversedPeopleDict,
+	}
+	ownership.lines.Initialize(repository)
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (ownership *OwnershipAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	return ownership.lines.Consume(deps)
+}
+
+// Fork clones this item. The files are copied by value.
+func (ownership *OwnershipAnalysis) Fork(n int) []core.PipelineItem {
+	lines := ownership.lines.Fork(n)
+	result := make([]core.PipelineItem, n)
+	for i := range result {
+		clone := *ownership
+		clone.lines = lines[i].(*BurndownAnalysis)
+		result[i] = &clone
+	}
+	return result
+}
+
+// Merge combines several items together, see BurndownAnalysis.Merge().
+func (ownership *OwnershipAnalysis) Merge(branches []core.PipelineItem) {
+	lines := make([]core.PipelineItem, len(branches))
+	for i, branch := range branches {
+		lines[i] = branch.(*OwnershipAnalysis).lines
+	}
+	ownership.lines.Merge(lines)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (ownership *OwnershipAnalysis) Finalize() interface{} {
+	directories := map[string]map[int]int64{}
+	var files map[string]map[int]int64
+	if ownership.TrackFiles {
+		files = map[string]map[int]int64{}
+	}
+	for name, file := range ownership.lines.files {
+		owners := map[int]int64{}
+		file.ForEach(func(line, length, value int) {
+			author, _ := ownership.lines.unpackPersonWithDay(value)
+			if author < 0 || author >= ownership.PeopleNumber {
+				author = ownership.PeopleNumber
+			}
+			owners[author] += int64(length)
+		})
+		dir := truncateDirectory(name, ownership.DirectoryDepth)
+		if directories[dir] == nil {
+			directories[dir] = map[int]int64{}
+		}
+		for author, lines := range owners {
+			directories[dir][author] += lines
+		}
+		if files != nil {
+			files[name] = owners
+		}
+	}
+	return newOwnershipResult(directories, files, ownership.reversedPeopleDict)
+}
+
+// newOwnershipResult converts the line counts of each developer (the unmatched identities
+// have the index len(reversedPeopleDict)) to the ownership matrices.
+func newOwnershipResult(directories, files map[string]map[int]int64,
+	reversedPeopleDict []string) OwnershipResult {
+	matrix := func(owners map[string]map[int]int64) ([]string, DenseHistory) {
+		if len(owners) == 0 {
+			return nil, nil
+		}
+		keys := make([]string, 0, len(owners))
+		for key := range owners {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		rows := make(DenseHistory, len(keys))
+		for i, key := range keys {
+			rows[i] = make([]int64, len(reversedPeopleDict)+1)
+			for author, lines := range owners[key] {
+				rows[i][author] = lines
+			}
+		}
+		return keys, rows
+	}
+	result := OwnershipResult{reversedPeopleDict: reversedPeopleDict}
+	result.Directories, result.DirectoryOwners = matrix(directories)
+	result.Files, result.FileOwners = matrix(files)
+	return result
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (ownership *OwnershipAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	ownershipResult := result.(OwnershipResult)
+	if binary {
+		return ownership.serializeBinary(&ownershipResult, writer)
+	}
+	ownership.serializeText(&ownershipResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (ownership *OwnershipAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(ownership, result, &pb.OwnershipAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (ownership *OwnershipAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(ownership, result, &pb.OwnershipAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to OwnershipResult.
+func (ownership *OwnershipAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.OwnershipAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	convert := func(csr *pb.CompressedSparseRowMatrix) DenseHistory {
+		if csr == nil {
+			return nil
+		}
+		rows := make(DenseHistory, csr.NumberOfRows)
+		for i := range rows {
+			rows[i] = make([]int64, csr.NumberOfColumns)
+			for j := csr.Indptr[i]; j < csr.Indptr[i+1]; j++ {
+				rows[i][csr.Indices[j]] = csr.Data[j]
+			}
+		}
+		return rows
+	}
+	result := OwnershipResult{
+		Directories:        message.Directories,
+		DirectoryOwners:    convert(message.DirectoryOwners),
+		Files:              message.Files,
+		FileOwners:         convert(message.FileOwners),
+		reversedPeopleDict: message.DevIndex,
+	}
+	return result, nil
+}
+
+// MergeResults combines two OwnershipResult-s together. The lines of the same directories
+// and files are summed.
+func (ownership *OwnershipAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	or1 := r1.(OwnershipResult)
+	or2 := r2.(OwnershipResult)
+	people, reversedPeopleDict := identity.Detector{}.MergeReversedDicts(
+		or1.reversedPeopleDict, or2.reversedPeopleDict)
+	directories := map[string]map[int]int64{}
+	var files map[string]map[int]int64
+	if len(or1.Files) > 0 || len(or2.Files) > 0 {
+		files = map[string]map[int]int64{}
+	}
+	add := func(merged map[string]map[int]int64, keys []string, rows DenseHistory,
+		devs []string) {
+		for i, key := range keys {
+			owners := merged[key]
+			if owners == nil {
+				owners = map[int]int64{}
+				merged[key] = owners
+			}
+			for dev, lines := range rows[i] {
+				if lines == 0 {
+					continue
+				}
+				index := len(reversedPeopleDict)
+				if dev < len(devs) {
+					index = people[devs[dev]][0]
+				}
+				owners[index] += lines
+			}
+		}
+	}
+	for _, result := range []*OwnershipResult{&or1, &or2} {
+		add(directories, result.Directories, result.DirectoryOwners, result.reversedPeopleDict)
+		if files != nil {
+			add(files, result.Files, result.FileOwners, result.reversedPeopleDict)
+		}
+	}
+	return newOwnershipResult(directories, files, reversedPeopleDict)
+}
+
+func (ownership *OwnershipAnalysis) serializeText(result *OwnershipResult, writer io.Writer) {
+	writeRows := func(name string, keys []string, rows DenseHistory) {
+		fmt.Fprintf(writer, "  %s:\n", name)
+		for i, key := range keys {
+			fmt.Fprintf(writer, "    %s: [", yaml.SafeString(key))
+			for j, val := range rows[i] {
+				if j > 0 {
+					fmt.Fprint(writer, ", ")
+				}
+				fmt.Fprint(writer, val)
+			}
+			fmt.Fprintln(writer, "]")
+		}
+	}
+	writeRows("directories", result.Directories, result.DirectoryOwners)
+	if len(result.Files) > 0 {
+		writeRows("files", result.Files, result.FileOwners)
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (ownership *OwnershipAnalysis) serializeBinary(result *OwnershipResult, writer io.Writer) error {
+	message := pb.OwnershipAnalysisResults{
+		Directories: result.Directories,
+		Files:       result.Files,
+		DevIndex:    result.reversedPeopleDict,
+	}
+	if len(result.DirectoryOwners) > 0 {
+		message.DirectoryOwners = pb.DenseToCompressedSparseRowMatrix(result.DirectoryOwners)
+	}
+	if len(result.FileOwners) > 0 {
+		message.FileOwners = pb.DenseToCompressedSparseRowMatrix(result.FileOwners)
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&OwnershipAnalysis{})
+}
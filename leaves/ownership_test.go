@@ -0,0 +1,216 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureOwnership(trackFiles bool) *OwnershipAnalysis {
+	ownership := OwnershipAnalysis{}
+	ownership.Configure(map[string]interface{}{
+		ConfigOwnershipDirectoryDepth:                   1,
+		ConfigOwnershipTrackFiles:                       trackFiles,
+		identity.FactIdentityDetectorPeopleCount:        2,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"alice", "bob"},
+	})
+	ownership.Initialize(nil)
+	return &ownership
+}
+
+func TestOwnershipMeta(t *testing.T) {
+	ownership := fixtureOwnership(true)
+	assert.Equal(t, ownership.Name(), "Ownership")
+	assert.Len(t, ownership.Provides(), 0)
+	for _, name := range []string{
+		items.DependencyFileDiff, items.DependencyTreeChanges, items.DependencyBlobCache,
+		items.DependencyDay, identity.DependencyAuthor} {
+		assert.Contains(t, ownership.Requires(), name)
+	}
+	assert.NotContains(t, ownership.Requires(), items.DependencyFormattingHunks)
+	var dynamic core.DynamicPipelineItem = ownership
+	dynamic.ConfigureRequirements(map[string]interface{}{ConfigBurndownIgnoreFormatting: true})
+	assert.Contains(t, ownership.Requires(), items.DependencyFormattingHunks)
+	ownership.ignoreFormatting = false
+	opts := ownership.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Name, ConfigOwnershipDirectoryDepth)
+	assert.Equal(t, opts[1].Name, ConfigOwnershipTrackFiles)
+	assert.Equal(t, ownership.Flag(), "ownership")
+	assert.NotEmpty(t, ownership.Description())
+	assert.Equal(t, ownership.DirectoryDepth, 1)
+	assert.True(t, ownership.TrackFiles)
+	assert.Equal(t, ownership.PeopleNumber, 2)
+	assert.Equal(t, ownership.reversedPeopleDict, []string{"alice", "bob"})
+	assert.True(t, ownership.lines.linesOnly)
+	ownership = &OwnershipAnalysis{}
+	ownership.Initialize(nil)
+	assert.Equal(t, ownership.DirectoryDepth, DefaultOwnershipDirectoryDepth)
+	assert.False(t, ownership.lines.IgnoreFormatting)
+	ownership.Configure(map[string]interface{}{ConfigBurndownIgnoreFormatting: true})
+	ownership.Initialize(nil)
+	assert.True(t, ownership.lines.IgnoreFormatting)
+	summoned := core.Registry.Summon(ownership.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Ownership")
+	matched := false
+	for _, tp := range core.Registry.GetLeaves() {
+		if tp.Flag() == ownership.Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestOwnershipConsumeFinalize(t *testing.T) {
+	hash, blob := storeExpertiseBlob(t, "one\ntwo\nthree\n")
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	ownership := fixtureOwnership(true)
+	consume := func(item *OwnershipAnalysis, author, day int, changes object.Changes,
+		fileDiffs map[string]items.FileDiffData) {
+		result, err := item.Consume(map[string]interface{}{
+			identity.DependencyAuthor:   author,
+			items.DependencyDay:         day,
+			items.DependencyBlobCache:   map[plumbing.Hash]*object.Blob{hash: blob},
+			items.DependencyTreeChanges: changes,
+			items.DependencyFileDiff:    fileDiffs,
+			core.DependencyCommit:       &object.Commit{},
+			core.DependencyIsMerge:      false,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	consume(ownership, 0, 0, object.Changes{
+		{To: entry("core/a.go")}, {To: entry("README")}, {To: entry("lib/b.go")}}, nil)
+	consume(ownership, identity.AuthorMissing, 1, object.Changes{{To: entry("lib/c.go")}}, nil)
+	// bob appends a line to alice's file
+	consume(ownership, 1, 5, object.Changes{{From: entry("core/a.go"), To: entry("core/a.go")}},
+		map[string]items.FileDiffData{"core/a.go": {
+			OldLinesOfCode: 3, NewLinesOfCode: 4, Diffs: []diffmatchpatch.Diff{
+				{Type: diffmatchpatch.DiffEqual, Text: "abc"},
+				{Type: diffmatchpatch.DiffInsert, Text: "d"},
+			}}})
+	// the fork does not affect the original
+	fork := ownership.Fork(1)[0].(*OwnershipAnalysis)
+	consume(fork, 1, 6, object.Changes{{From: entry("lib/b.go")}}, nil)
+	assert.Len(t, fork.lines.files, 3)
+	assert.Len(t, ownership.lines.files, 4)
+
+	result := ownership.Finalize().(OwnershipResult)
+	assert.Equal(t, result.Directories, []string{".", "core", "lib"})
+	assert.Equal(t, result.DirectoryOwners, DenseHistory{{3, 0, 0}, {3, 1, 0}, {3, 0, 3}})
+	assert.Equal(t, result.Files, []string{"README", "core/a.go", "lib/b.go", "lib/c.go"})
+	assert.Equal(t, result.FileOwners, DenseHistory{{3, 0, 0}, {3, 1, 0}, {3, 0, 0}, {0, 0, 3}})
+	assert.Equal(t, result.reversedPeopleDict, []string{"alice", "bob"})
+
+	ownership.TrackFiles = false
+	result = ownership.Finalize().(OwnershipResult)
+	assert.Len(t, result.Directories, 3)
+	assert.Nil(t, result.Files)
+	assert.Nil(t, result.FileOwners)
+}
+
+func TestOwnershipCoAuthors(t *testing.T) {
+	hash, blob := storeExpertiseBlob(t, "one\ntwo\nthree\n")
+	entry := object.ChangeEntry{Name: "a.go", TreeEntry: object.TreeEntry{Name: "a.go", Hash: hash}}
+	ownership := fixtureOwnership(true)
+	consume := func(author, day int, coAuthors []int, change *object.Change,
+		fileDiffs map[string]items.FileDiffData) {
+		result, err := ownership.Consume(map[string]interface{}{
+			identity.DependencyAuthor:    author,
+			identity.DependencyCoAuthors: coAuthors,
+			items.DependencyDay:          day,
+			items.DependencyBlobCache:    map[plumbing.Hash]*object.Blob{hash: blob},
+			items.DependencyTreeChanges:  object.Changes{change},
+			items.DependencyFileDiff:     fileDiffs,
+			core.DependencyCommit:        &object.Commit{},
+			core.DependencyIsMerge:       false,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	// alice and bob write the file together, alice gets the extra line
+	consume(0, 0, []int{1}, &object.Change{To: entry}, nil)
+	result := ownership.Finalize().(OwnershipResult)
+	assert.Equal(t, result.FileOwners, DenseHistory{{2, 1, 0}})
+	// bob replaces alice's second line with three lines together with alice
+	consume(1, 1, []int{0}, &object.Change{From: entry, To: entry},
+		map[string]items.FileDiffData{"a.go": {
+			OldLinesOfCode: 3, NewLinesOfCode: 5, Diffs: []diffmatchpatch.Diff{
+				{Type: diffmatchpatch.DiffEqual, Text: "a"},
+				{Type: diffmatchpatch.DiffDelete, Text: "b"},
+				{Type: diffmatchpatch.DiffInsert, Text: "xyz"},
+				{Type: diffmatchpatch.DiffEqual, Text: "c"},
+			}}})
+	result = ownership.Finalize().(OwnershipResult)
+	assert.Equal(t, result.FileOwners, DenseHistory{{2, 3, 0}})
+	assert.Equal(t, splitCredit(7, 3), []int{3, 2, 2})
+	assert.Equal(t, splitCredit(1, 3), []int{1, 0, 0})
+}
+
+func fixtureOwnershipResult() OwnershipResult {
+	return OwnershipResult{
+		Directories:        []string{".", "core"},
+		DirectoryOwners:    DenseHistory{{0, 3, 0}, {3, 1, 2}},
+		Files:              []string{"README", "core/a.go"},
+		FileOwners:         DenseHistory{{0, 3, 0}, {3, 1, 2}},
+		reversedPeopleDict: []string{"alice", "bob"},
+	}
+}
+
+func TestOwnershipSerialize(t *testing.T) {
+	ownership := fixtureOwnership(true)
+	result := fixtureOwnershipResult()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, ownership.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  directories:
+    ".": [0, 3, 0]
+    "core": [3, 1, 2]
+  files:
+    "README": [0, 3, 0]
+    "core/a.go": [3, 1, 2]
+  people:
+  - "alice"
+  - "bob"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, ownership.Serialize(result, true, buffer))
+	msg := pb.OwnershipAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Directories, []string{".", "core"})
+	deserialized, err := ownership.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+	_, err = ownership.Deserialize([]byte("garbage"))
+	assert.NotNil(t, err)
+}
+
+func TestOwnershipMergeResults(t *testing.T) {
+	ownership := fixtureOwnership(false)
+	r1 := fixtureOwnershipResult()
+	r2 := OwnershipResult{
+		Directories:        []string{"core", "src"},
+		DirectoryOwners:    DenseHistory{{5, 1}, {2, 0}},
+		reversedPeopleDict: []string{"bob"},
+	}
+	c1 := &core.CommonAnalysisResult{EndTime: 1500000000}
+	c2 := &core.CommonAnalysisResult{EndTime: 1500000000}
+	merged := ownership.MergeResults(r1, r2, c1, c2).(OwnershipResult)
+	assert.Equal(t, merged.reversedPeopleDict, []string{"alice", "bob"})
+	assert.Equal(t, merged.Directories, []string{".", "core", "src"})
+	assert.Equal(t, merged.DirectoryOwners, DenseHistory{{0, 3, 0}, {3, 6, 3}, {0, 2, 0}})
+	assert.Equal(t, merged.Files, []string{"README", "core/a.go"})
+	assert.Equal(t, merged.FileOwners, DenseHistory{{0, 3, 0}, {3, 1, 2}})
+}
@@ -0,0 +1,467 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// Recorder exports the chosen dependency streams, e.g. all the tree changes or all the file diffs,
+// as column-oriented tables, so that custom offline analyses can be built on top of
+// the plumbing without writing pipeline items. It is a LeafPipelineItem.
+type Recorder struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Streams is the list of the recorded dependencies. See RecordableStreams().
+	Streams []string
+	// OutputPath is the file path where the recorded dataset is additionally written
+	// in Protocol Buffers format. It is not written if empty.
+	OutputPath string
+
+	commits []string
+	tables  []*recordedTable
+}
+
+// RecorderResult is returned by Finalize() and represents the recorded dataset.
+type RecorderResult struct {
+	// Commits are the hashes of the recorded commits. "commit" columns index this list.
+	Commits []string
+	// Streams are the recorded tables, in the same order as Recorder.Streams.
+	Streams []RecordedStream
+}
+
+// RecordedStream is the column-oriented table with the values of a single dependency.
+type RecordedStream struct {
+	// Name is the name of the recorded dependency.
+	Name string
+	// Columns have the same length, the first one is always "commit".
+	Columns []RecordedColumn
+}
+
+// RecordedColumn is a column in RecordedStream. Integer columns store the values directly
+// and string columns store the indexes in Dictionary.
+type RecordedColumn struct {
+	Name       string
+	Values     []int64
+	Dictionary []string
+}
+
+const (
+	// ConfigRecorderStreams is the name of the option to set Recorder.Streams.
+	ConfigRecorderStreams = "Recorder.Streams"
+	// ConfigRecorderOutput is the name of the option to set Recorder.OutputPath.
+	ConfigRecorderOutput = "Recorder.Output"
+)
+
+// recordableStream defines how to flatten a dependency into table rows.
+type recordableStream struct {
+	// Columns except "commit". The values which are passed to emit() must be either
+	// int or string and their order must match Columns.
+	Columns []string
+	Flatten func(value interface{}, emit func(row ...interface{}))
+}
+
+var recordableStreams = map[string]recordableStream{
+	items.DependencyTreeChanges: {
+		Columns: []string{"action", "from_name", "to_name", "from_hash", "to_hash"},
+		Flatten: func(value interface{}, emit func(row ...interface{})) {
+			for _, change := range value.(object.Changes) {
+				action, err := change.Action()
+				if err != nil {
+					continue
+				}
+				var actionName string
+				switch action {
+				case merkletrie.Insert:
+					actionName = "insert"
+				case merkletrie.Delete:
+					actionName = "delete"
+				case merkletrie.Modify:
+					actionName = "modify"
+				}
+				emit(actionName, change.From.Name, change.To.Name,
+					change.From.TreeEntry.Hash.String(), change.To.TreeEntry.Hash.String())
+			}
+		},
+	},
+	items.DependencyFileDiff: {
+		Columns: []string{"file", "old_lines", "new_lines", "inserted", "deleted"},
+		Flatten: func(value interface{}, emit func(row ...interface{})) {
+			diffs := value.(map[string]items.FileDiffData)
+			files := make([]string, 0, len(diffs))
+			for file := range diffs {
+				files = append(files, file)
+			}
+			sort.Strings(files)
+			for _, file := range files {
+				diff := diffs[file]
+				inserted, deleted := 0, 0
+				for _, edit := range diff.Diffs {
+					// DiffLinesToRunes maps every line to a rune
+					length := len([]rune(edit.Text))
+					switch edit.Type {
+					case diffmatchpatch.DiffInsert:
+						inserted += length
+					case diffmatchpatch.DiffDelete:
+						deleted += length
+					}
+				}
+				emit(file, diff.OldLinesOfCode, diff.NewLinesOfCode, inserted, deleted)
+			}
+		},
+	},
+	items.DependencyBlobCache: {
+		Columns: []string{"hash", "size"},
+		Flatten: func(value interface{}, emit func(row ...interface{})) {
+			cache := value.(map[plumbing.Hash]*object.Blob)
+			hashes := make([]string, 0, len(cache))
+			for hash := range cache {
+				hashes = append(hashes, hash.String())
+			}
+			sort.Strings(hashes)
+			for _, hash := range hashes {
+				emit(hash, int(cache[plumbing.NewHash(hash)].Size))
+			}
+		},
+	},
+	identity.DependencyAuthor: {
+		Columns: []string{"author"},
+		Flatten: func(value interface{}, emit func(row ...interface{})) {
+			emit(value.(int))
+		},
+	},
+	items.DependencyDay: {
+		Columns: []string{"day"},
+		Flatten: func(value interface{}, emit func(row ...interface{})) {
+			emit(value.(int))
+		},
+	},
+}
+
+// RecordableStreams returns the sorted list of the dependencies which Recorder supports.
+func RecordableStreams() []string {
+	streams := make([]string, 0, len(recordableStreams))
+	for stream := range recordableStreams {
+		streams = append(streams, stream)
+	}
+	sort.Strings(streams)
+	return streams
+}
+
+type recordedColumn struct {
+	RecordedColumn
+	codes map[string]int64
+}
+
+func (column *recordedColumn) append(value interface{}) {
+	switch val := value.(type) {
+	case int:
+		column.Values = append(column.Values, int64(val))
+	case string:
+		code, exists := column.codes[val]
+		if !exists {
+			code = int64(len(column.Dictionary))
+			column.codes[val] = code
+			column.Dictionary = append(column.Dictionary, val)
+		}
+		column.Values = append(column.Values, code)
+	default:
+		log.Panicf("unsupported recorded value type %T in column %s", value, column.Name)
+	}
+}
+
+type recordedTable struct {
+	Name    string
+	Columns []*recordedColumn
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (recorder *Recorder) Name() string {
+	return "Recorder"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (recorder *Recorder) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (recorder *Recorder) Requires() []string {
+	if len(recorder.Streams) == 0 {
+		return []string{items.DependencyTreeChanges}
+	}
+	return recorder.Streams
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (recorder *Recorder) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigRecorderStreams,
+		Description: fmt.Sprintf("Dependencies to record. Supported values: %s.",
+			strings.Join(RecordableStreams(), ", ")),
+		Flag:    "record-streams",
+		Type:    core.StringsConfigurationOption,
+		Default: []string{items.DependencyTreeChanges}}, {
+		Name: ConfigRecorderOutput,
+		Description: "Additionally write the recorded dataset in Protocol Buffers format " +
+			"to this file.",
+		Flag:    "record-output",
+		Type:    core.StringConfigurationOption,
+		Default: ""},
+	}
+	return options[:]
+}
+
+// Flag for the command line switch which enables this analysis.
+func (recorder *Recorder) Flag() string {
+	return "record"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (recorder *Recorder) Description() string {
+	return "Exports the chosen dependencies (tree changes, file diffs, etc.) for every commit " +
+		"as column-oriented tables."
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (recorder *Recorder) Configure(facts map[string]interface{}) {
+	recorder.ConfigureRequirements(facts)
+	if val, exists := facts[ConfigRecorderOutput].(string); exists {
+		recorder.OutputPath = val
+	}
+}
+
+// ConfigureRequirements sets Streams which are returned from Requires().
+// It is a part of core.DynamicPipelineItem.
+func (recorder *Recorder) ConfigureRequirements(facts map[string]interface{}) {
+	if val, exists := facts[ConfigRecorderStreams].([]string); exists {
+		for _, stream := range val {
+			if _, supported := recordableStreams[stream]; !supported {
+				log.Panicf("Recorder does not support %s, the supported streams are: %s",
+					stream, strings.Join(RecordableStreams(), ", "))
+			}
+		}
+		recorder.Streams = val
+	}
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (recorder *Recorder) Initialize(repository *git.Repository) {
+	recorder.commits = []string{}
+	recorder.tables = nil
+	for _, stream := range recorder.Requires() {
+		table := &recordedTable{Name: stream}
+		for _, name := range append([]string{"commit"}, recordableStreams[stream].Columns...) {
+			table.Columns = append(table.Columns, &recordedColumn{
+				RecordedColumn: RecordedColumn{Name: name}, codes: map[string]int64{}})
+		}
+		recorder.tables = append(recorder.tables, table)
+	}
+	recorder.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (recorder *Recorder) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !recorder.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	commitIndex := len(recorder.commits)
+	recorder.commits = append(recorder.commits, commit.Hash.String())
+	for _, table := range recorder.tables {
+		recordableStreams[table.Name].Flatten(deps[table.Name], func(row ...interface{}) {
+			table.Columns[0].append(commitIndex)
+			for i, value := range row {
+				table.Columns[i+1].append(value)
+			}
+		})
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (recorder *Recorder) Finalize() interface{} {
+	result := RecorderResult{Commits: recorder.commits}
+	for _, table := range recorder.tables {
+		stream := RecordedStream{Name: table.Name}
+		for _, column := range table.Columns {
+			stream.Columns = append(stream.Columns, column.RecordedColumn)
+		}
+		result.Streams = append(result.Streams, stream)
+	}
+	if recorder.OutputPath != "" {
+		serialized, err := proto.Marshal(recorder.toProtobuf(&result))
+		if err != nil {
+			panic(err)
+		}
+		if err = ioutil.WriteFile(recorder.OutputPath, serialized, 0666); err != nil {
+			log.Panicf("failed to write the recorded dataset to %s: %v", recorder.OutputPath, err)
+		}
+	}
+	return result
+}
+
+// Fork clones this PipelineItem.
+func (recorder *Recorder) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(recorder, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (recorder *Recorder) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	recorderResult := result.(RecorderResult)
+	if binary {
+		return recorder.serializeBinary(&recorderResult, writer)
+	}
+	recorder.serializeText(&recorderResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (recorder *Recorder) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(recorder, result, &pb.RecorderResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (recorder *Recorder) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(recorder, result, &pb.RecorderResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to RecorderResult.
+func (recorder *Recorder) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.RecorderResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := RecorderResult{Commits: message.Commits}
+	for _, stream := range message.Streams {
+		recorded := RecordedStream{Name: stream.Name}
+		for _, column := range stream.Columns {
+			recorded.Columns = append(recorded.Columns, RecordedColumn{
+				Name:       column.Name,
+				Values:     column.Values,
+				Dictionary: column.Dictionary,
+			})
+		}
+		result.Streams = append(result.Streams, recorded)
+	}
+	return result, nil
+}
+
+// MergeResults concatenates two RecorderResult-s. The streams which are absent in either of
+// the results are discarded.
+func (recorder *Recorder) MergeResults(r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	result1 := r1.(RecorderResult)
+	result2 := r2.(RecorderResult)
+	merged := RecorderResult{}
+	merged.Commits = append(append([]string{}, result1.Commits...), result2.Commits...)
+	streams2 := map[string]RecordedStream{}
+	for _, stream := range result2.Streams {
+		streams2[stream.Name] = stream
+	}
+	for _, stream1 := range result1.Streams {
+		stream2, exists := streams2[stream1.Name]
+		if !exists || len(stream1.Columns) != len(stream2.Columns) {
+			continue
+		}
+		stream := RecordedStream{Name: stream1.Name}
+		for i, column1 := range stream1.Columns {
+			column := &recordedColumn{
+				RecordedColumn: RecordedColumn{Name: column1.Name}, codes: map[string]int64{}}
+			for _, source := range [...]RecordedColumn{column1, stream2.Columns[i]} {
+				for _, val := range source.Values {
+					if source.Dictionary != nil {
+						column.append(source.Dictionary[val])
+					} else {
+						column.append(int(val))
+					}
+				}
+			}
+			if i == 0 {
+				// shift the commit indexes of the second result
+				for j := len(column1.Values); j < len(column.Values); j++ {
+					column.Values[j] += int64(len(result1.Commits))
+				}
+			}
+			stream.Columns = append(stream.Columns, column.RecordedColumn)
+		}
+		merged.Streams = append(merged.Streams, stream)
+	}
+	return merged
+}
+
+func (recorder *Recorder) serializeText(result *RecorderResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  commits:")
+	for _, commit := range result.Commits {
+		fmt.Fprintf(writer, "    - \"%s\"\n", commit)
+	}
+	fmt.Fprintln(writer, "  streams:")
+	for _, stream := range result.Streams {
+		fmt.Fprintf(writer, "    %s:\n", stream.Name)
+		for _, column := range stream.Columns {
+			values := make([]string, len(column.Values))
+			for i, val := range column.Values {
+				if column.Dictionary != nil {
+					values[i] = yaml.SafeString(column.Dictionary[val])
+				} else {
+					values[i] = fmt.Sprint(val)
+				}
+			}
+			fmt.Fprintf(writer, "      %s: [%s]\n", column.Name, strings.Join(values, ", "))
+		}
+	}
+}
+
+func (recorder *Recorder) serializeBinary(result *RecorderResult, writer io.Writer) error {
+	serialized, err := proto.Marshal(recorder.toProtobuf(result))
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func (recorder *Recorder) toProtobuf(result *RecorderResult) *pb.RecorderResults {
+	message := &pb.RecorderResults{Commits: result.Commits}
+	for _, stream := range result.Streams {
+		recorded := &pb.RecordedStream{Name: stream.Name}
+		for _, column := range stream.Columns {
+			recorded.Columns = append(recorded.Columns, &pb.RecordedColumn{
+				Name:       column.Name,
+				Values:     column.Values,
+				Dictionary: column.Dictionary,
+			})
+		}
+		message.Streams = append(message.Streams, recorded)
+	}
+	return message
+}
+
+func init() {
+	core.Registry.Register(&Recorder{})
+}
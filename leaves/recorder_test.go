@@ -0,0 +1,209 @@
+package leaves
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/test"
+)
+
+func fixtureRecorder(streams ...string) *Recorder {
+	rec := Recorder{Streams: streams}
+	rec.Initialize(test.Repository)
+	return &rec
+}
+
+func fixtureRecorderDeps(commit string) map[string]interface{} {
+	changes := object.Changes{
+		&object.Change{To: object.ChangeEntry{
+			Name: "new.go",
+			TreeEntry: object.TreeEntry{
+				Name: "new.go",
+				Hash: plumbing.NewHash("dc248ba2b22048cc730c571a748e8ffcf7085ab9"),
+			},
+		}},
+		&object.Change{From: object.ChangeEntry{
+			Name: "old.go",
+			TreeEntry: object.TreeEntry{
+				Name: "old.go",
+				Hash: plumbing.NewHash("c29112dbd697ad9b401333b80c18a63951bc18d9"),
+			},
+		}},
+	}
+	diffs := map[string]items.FileDiffData{
+		"mod.go": {OldLinesOfCode: 10, NewLinesOfCode: 11, Diffs: []diffmatchpatch.Diff{
+			{Type: diffmatchpatch.DiffEqual, Text: "abcdefgh"},
+			{Type: diffmatchpatch.DiffDelete, Text: "i"},
+			{Type: diffmatchpatch.DiffInsert, Text: "jk"},
+			{Type: diffmatchpatch.DiffEqual, Text: "l"},
+		}},
+	}
+	return map[string]interface{}{
+		core.DependencyCommit:       &object.Commit{Hash: plumbing.NewHash(commit)},
+		core.DependencyIsMerge:      false,
+		items.DependencyTreeChanges: changes,
+		items.DependencyFileDiff:    diffs,
+		identity.DependencyAuthor:   3,
+		items.DependencyDay:         7,
+	}
+}
+
+func TestRecorderMeta(t *testing.T) {
+	rec := fixtureRecorder()
+	assert.Equal(t, rec.Name(), "Recorder")
+	assert.Equal(t, len(rec.Provides()), 0)
+	assert.Equal(t, rec.Requires(), []string{items.DependencyTreeChanges})
+	assert.Equal(t, rec.Flag(), "record")
+	opts := rec.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Name, ConfigRecorderStreams)
+	assert.Equal(t, opts[1].Name, ConfigRecorderOutput)
+	assert.Len(t, RecordableStreams(), 5)
+}
+
+func TestRecorderConfigure(t *testing.T) {
+	rec := fixtureRecorder()
+	facts := map[string]interface{}{}
+	facts[ConfigRecorderStreams] = []string{items.DependencyFileDiff, items.DependencyDay}
+	facts[ConfigRecorderOutput] = "/tmp/dataset.pb"
+	rec.Configure(facts)
+	assert.Equal(t, rec.Streams, []string{items.DependencyFileDiff, items.DependencyDay})
+	assert.Equal(t, rec.Requires(), rec.Streams)
+	assert.Equal(t, rec.OutputPath, "/tmp/dataset.pb")
+	facts[ConfigRecorderStreams] = []string{"xxx"}
+	assert.Panics(t, func() { rec.Configure(facts) })
+}
+
+func TestRecorderConfigureRequirements(t *testing.T) {
+	rec := &Recorder{}
+	var dynamic core.DynamicPipelineItem = rec
+	dynamic.ConfigureRequirements(map[string]interface{}{
+		ConfigRecorderStreams: []string{items.DependencyDay},
+		ConfigRecorderOutput:  "/tmp/dataset.pb",
+	})
+	assert.Equal(t, rec.Requires(), []string{items.DependencyDay})
+	assert.Equal(t, rec.OutputPath, "")
+}
+
+func TestRecorderRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&Recorder{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Recorder")
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&Recorder{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestRecorderConsumeFinalize(t *testing.T) {
+	rec := fixtureRecorder(items.DependencyTreeChanges, items.DependencyFileDiff,
+		identity.DependencyAuthor, items.DependencyDay)
+	rec.Consume(fixtureRecorderDeps("cce947b98a050c6d356bc6ba95030254914027b1"))
+	rec.Consume(fixtureRecorderDeps("a3ee37f91f0d705ec9c41ae88426f0ae44b2fbc3"))
+	result := rec.Finalize().(RecorderResult)
+	assert.Equal(t, result.Commits, []string{
+		"cce947b98a050c6d356bc6ba95030254914027b1", "a3ee37f91f0d705ec9c41ae88426f0ae44b2fbc3"})
+	assert.Len(t, result.Streams, 4)
+	changes := result.Streams[0]
+	assert.Equal(t, changes.Name, items.DependencyTreeChanges)
+	assert.Len(t, changes.Columns, 6)
+	assert.Equal(t, changes.Columns[0].Name, "commit")
+	assert.Equal(t, changes.Columns[0].Values, []int64{0, 0, 1, 1})
+	assert.Nil(t, changes.Columns[0].Dictionary)
+	assert.Equal(t, changes.Columns[1].Name, "action")
+	assert.Equal(t, changes.Columns[1].Values, []int64{0, 1, 0, 1})
+	assert.Equal(t, changes.Columns[1].Dictionary, []string{"insert", "delete"})
+	assert.Equal(t, changes.Columns[3].Dictionary, []string{"new.go", ""})
+	diffs := result.Streams[1]
+	assert.Equal(t, diffs.Columns[1].Dictionary, []string{"mod.go"})
+	assert.Equal(t, diffs.Columns[2].Values, []int64{10, 10})
+	assert.Equal(t, diffs.Columns[3].Values, []int64{11, 11})
+	assert.Equal(t, diffs.Columns[4].Values, []int64{2, 2})
+	assert.Equal(t, diffs.Columns[5].Values, []int64{1, 1})
+	assert.Equal(t, result.Streams[2].Columns[1].Values, []int64{3, 3})
+	assert.Equal(t, result.Streams[3].Columns[1].Values, []int64{7, 7})
+}
+
+func TestRecorderOutputPath(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "hercules-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpdir)
+	rec := fixtureRecorder(identity.DependencyAuthor)
+	rec.OutputPath = filepath.Join(tmpdir, "dataset.pb")
+	rec.Consume(fixtureRecorderDeps("cce947b98a050c6d356bc6ba95030254914027b1"))
+	rec.Finalize()
+	data, err := ioutil.ReadFile(rec.OutputPath)
+	assert.Nil(t, err)
+	msg := pb.RecorderResults{}
+	assert.Nil(t, proto.Unmarshal(data, &msg))
+	assert.Equal(t, msg.Commits, []string{"cce947b98a050c6d356bc6ba95030254914027b1"})
+	assert.Len(t, msg.Streams, 1)
+	assert.Equal(t, msg.Streams[0].Name, identity.DependencyAuthor)
+	assert.Equal(t, msg.Streams[0].Columns[1].Values, []int64{3})
+}
+
+func TestRecorderSerializeText(t *testing.T) {
+	rec := fixtureRecorder(items.DependencyTreeChanges, items.DependencyDay)
+	rec.Consume(fixtureRecorderDeps("cce947b98a050c6d356bc6ba95030254914027b1"))
+	result := rec.Finalize()
+	buffer := &bytes.Buffer{}
+	rec.Serialize(result, false, buffer)
+	assert.Equal(t, buffer.String(), `  commits:
+    - "cce947b98a050c6d356bc6ba95030254914027b1"
+  streams:
+    changes:
+      commit: [0, 0]
+      action: ["insert", "delete"]
+      from_name: ["", "old.go"]
+      to_name: ["new.go", ""]
+      from_hash: ["0000000000000000000000000000000000000000", "c29112dbd697ad9b401333b80c18a63951bc18d9"]
+      to_hash: ["dc248ba2b22048cc730c571a748e8ffcf7085ab9", "0000000000000000000000000000000000000000"]
+    day:
+      commit: [0]
+      day: [7]
+`)
+}
+
+func TestRecorderSerializeBinaryDeserialize(t *testing.T) {
+	rec := fixtureRecorder(items.DependencyTreeChanges, items.DependencyDay)
+	rec.Consume(fixtureRecorderDeps("cce947b98a050c6d356bc6ba95030254914027b1"))
+	result := rec.Finalize()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, rec.Serialize(result, true, buffer))
+	deserialized, err := rec.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestRecorderMergeResults(t *testing.T) {
+	rec1 := fixtureRecorder(items.DependencyTreeChanges)
+	rec1.Consume(fixtureRecorderDeps("cce947b98a050c6d356bc6ba95030254914027b1"))
+	rec2 := fixtureRecorder(items.DependencyTreeChanges, items.DependencyDay)
+	deps := fixtureRecorderDeps("a3ee37f91f0d705ec9c41ae88426f0ae44b2fbc3")
+	deps[items.DependencyTreeChanges] = deps[items.DependencyTreeChanges].(object.Changes)[1:]
+	rec2.Consume(deps)
+	merged := rec1.MergeResults(rec1.Finalize(), rec2.Finalize(), nil, nil).(RecorderResult)
+	assert.Equal(t, merged.Commits, []string{
+		"cce947b98a050c6d356bc6ba95030254914027b1", "a3ee37f91f0d705ec9c41ae88426f0ae44b2fbc3"})
+	assert.Len(t, merged.Streams, 1)
+	assert.Equal(t, merged.Streams[0].Columns[0].Values, []int64{0, 0, 1})
+	assert.Equal(t, merged.Streams[0].Columns[1].Values, []int64{0, 1, 1})
+	assert.Equal(t, merged.Streams[0].Columns[1].Dictionary, []string{"insert", "delete"})
+}
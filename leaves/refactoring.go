@@ -0,0 +1,503 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// RefactoringAnalysis classifies the commits as refactorings and reports the share of
+// the refactorings in the effort in each tick of Sampling days and of each developer.
+// The score of a commit is the largest of the share of the renamed files and the share of
+// the moved lines - the deleted lines which were inserted again, possibly in a different file
+// or with a different indentation - multiplied by the balance of the inserted and the deleted
+// lines. The commits with the score not less than Threshold are refactorings.
+// The merge commits are skipped.
+type RefactoringAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Threshold is the minimum score of a refactoring.
+	Threshold float32
+	// Sampling is the number of days in a tick.
+	Sampling int
+
+	// ticks are the statistics of each tick.
+	ticks []RefactoringStats
+	// people are the statistics of each developer; -1 means an unmatched identity.
+	people map[int]RefactoringStats
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// RefactoringStats is the effort spent on the refactorings.
+type RefactoringStats struct {
+	// Commits is the number of analysed commits.
+	Commits int
+	// Refactorings is the number of refactorings among Commits.
+	Refactorings int
+	// Lines is the number of changed lines in Commits.
+	Lines int
+	// RefactoringLines is the number of changed lines in Refactorings.
+	RefactoringLines int
+}
+
+// RefactoringResult is returned by RefactoringAnalysis.Finalize().
+type RefactoringResult struct {
+	// Ticks are the statistics of each tick of Sampling days.
+	Ticks []RefactoringStats
+	// People are the statistics of each developer; -1 means an unmatched identity.
+	People map[int]RefactoringStats
+	// Threshold is the effective RefactoringAnalysis.Threshold.
+	Threshold float32
+	// Sampling is the effective RefactoringAnalysis.Sampling.
+	Sampling int
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigRefactoringThreshold is the name of the option to set RefactoringAnalysis.Threshold.
+	ConfigRefactoringThreshold = "Refactoring.Threshold"
+	// ConfigRefactoringSampling is the name of the option to set RefactoringAnalysis.Sampling.
+	ConfigRefactoringSampling = "Refactoring.Sampling"
+	// DefaultRefactoringThreshold is the default value of RefactoringAnalysis.Threshold.
+	DefaultRefactoringThreshold = 0.5
+	// DefaultRefactoringSampling is the default value of RefactoringAnalysis.Sampling.
+	DefaultRefactoringSampling = 30
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (refactoring *RefactoringAnalysis) Name() string {
+	return "Refactoring"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (refactoring *RefactoringAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (refactoring *RefactoringAnalysis) Requires() []string {
+	arr := [...]string{
+		identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges,
+		items.DependencyFileDiff, items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (refactoring *RefactoringAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigRefactoringThreshold,
+		Description: "Minimum share of the renamed files or the moved lines in a refactoring commit.",
+		Flag:        "refactoring-threshold",
+		Type:        core.FloatConfigurationOption,
+		Default:     float32(DefaultRefactoringThreshold)}, {
+		Name:        ConfigRefactoringSampling,
+		Description: "How frequently to record the refactoring statistics, in days.",
+		Flag:        "refactoring-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultRefactoringSampling},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (refactoring *RefactoringAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigRefactoringThreshold].(float32); exists {
+		refactoring.Threshold = val
+	}
+	if val, exists := facts[ConfigRefactoringSampling].(int); exists {
+		refactoring.Sampling = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		refactoring.reversedPeopleDict = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (refactoring *RefactoringAnalysis) Flag() string {
+	return "refactoring"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (refactoring *RefactoringAnalysis) Description() string {
+	return "Classifies the commits which mostly rename files or move lines as refactorings " +
+		"and reports the share of the refactorings in the effort over time and per developer."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (refactoring *RefactoringAnalysis) Initialize(repository *git.Repository) {
+	if refactoring.Threshold <= 0 || refactoring.Threshold > 1 {
+		log.Printf("Warning: adjusted the refactoring threshold to %.2f\n",
+			DefaultRefactoringThreshold)
+		refactoring.Threshold = DefaultRefactoringThreshold
+	}
+	if refactoring.Sampling <= 0 {
+		log.Printf("Warning: adjusted the refactoring sampling to %d days\n",
+			DefaultRefactoringSampling)
+		refactoring.Sampling = DefaultRefactoringSampling
+	}
+	refactoring.ticks = nil
+	refactoring.people = map[int]RefactoringStats{}
+	refactoring.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (refactoring *RefactoringAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !refactoring.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	renamed := 0
+	var deleted, inserted []string
+	for _, change := range changes {
+		if change.From.Name != "" && change.To.Name != "" && change.From.Name != change.To.Name {
+			renamed++
+		}
+		fileDeleted, fileInserted, err := changedLineTexts(change, cache, fileDiffs)
+		if err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, fileDeleted...)
+		inserted = append(inserted, fileInserted...)
+	}
+	lines := len(deleted) + len(inserted)
+	isRefactoring := len(changes) > 0 &&
+		refactoringScore(len(changes), renamed, deleted, inserted) >= float64(refactoring.Threshold)
+	update := func(stats *RefactoringStats) {
+		stats.Commits++
+		stats.Lines += lines
+		if isRefactoring {
+			stats.Refactorings++
+			stats.RefactoringLines += lines
+		}
+	}
+	tick := deps[items.DependencyDay].(int) / refactoring.Sampling
+	for len(refactoring.ticks) <= tick {
+		refactoring.ticks = append(refactoring.ticks, RefactoringStats{})
+	}
+	update(&refactoring.ticks[tick])
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing {
+		author = -1
+	}
+	stats := refactoring.people[author]
+	update(&stats)
+	refactoring.people[author] = stats
+	return nil, nil
+}
+
+// refactoringScore calculates how much the commit looks like a refactoring, from 0 to 1.
+func refactoringScore(files, renamed int, deleted, inserted []string) float64 {
+	score := float64(renamed) / float64(files)
+	if len(deleted)+len(inserted) == 0 {
+		return score
+	}
+	pool := map[string]int{}
+	for _, line := range deleted {
+		pool[strings.TrimSpace(line)]++
+	}
+	moved := 0
+	for _, line := range inserted {
+		line = strings.TrimSpace(line)
+		if pool[line] > 0 {
+			pool[line]--
+			moved++
+		}
+	}
+	if share := float64(2*moved) / float64(len(deleted)+len(inserted)); share > score {
+		score = share
+	}
+	small, large := len(deleted), len(inserted)
+	if small > large {
+		small, large = large, small
+	}
+	return score * float64(small) / float64(large)
+}
+
+// changedLineTexts returns the deleted and the inserted lines of the change.
+// The binary files have no lines.
+func changedLineTexts(change *object.Change, cache map[plumbing.Hash]*object.Blob,
+	fileDiffs map[string]items.FileDiffData) ([]string, []string, error) {
+	action, err := change.Action()
+	if err != nil {
+		return nil, nil, err
+	}
+	split := func(hash plumbing.Hash) ([]string, error) {
+		contents, err := items.BlobToString(cache[hash])
+		if err != nil || contents == "" || strings.IndexByte(contents, 0) >= 0 {
+			return nil, err
+		}
+		return strings.Split(strings.TrimSuffix(contents, "\n"), "\n"), nil
+	}
+	switch action {
+	case merkletrie.Insert:
+		inserted, err := split(change.To.TreeEntry.Hash)
+		return nil, inserted, err
+	case merkletrie.Delete:
+		deleted, err := split(change.From.TreeEntry.Hash)
+		return deleted, nil, err
+	}
+	diff, exists := fileDiffs[change.To.Name]
+	if !exists {
+		return nil, nil, nil
+	}
+	oldLines, err := split(change.From.TreeEntry.Hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	newLines, err := split(change.To.TreeEntry.Hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	var deleted, inserted []string
+	oldLine, newLine := 0, 0
+	for _, edit := range diff.Diffs {
+		// FileDiff encodes each line as a single rune
+		size := utf8.RuneCountInString(edit.Text)
+		switch edit.Type {
+		case diffmatchpatch.DiffEqual:
+			oldLine += size
+			newLine += size
+		case diffmatchpatch.DiffDelete:
+			for line := oldLine; line < oldLine+size && line < len(oldLines); line++ {
+				deleted = append(deleted, oldLines[line])
+			}
+			oldLine += size
+		case diffmatchpatch.DiffInsert:
+			for line := newLine; line < newLine+size && line < len(newLines); line++ {
+				inserted = append(inserted, newLines[line])
+			}
+			newLine += size
+		}
+	}
+	return deleted, inserted, nil
+}
+
+// CommitShare returns the share of the refactorings among the commits.
+func (stats RefactoringStats) CommitShare() float64 {
+	if stats.Commits == 0 {
+		return 0
+	}
+	return float64(stats.Refactorings) / float64(stats.Commits)
+}
+
+// LineShare returns the share of the refactorings in the changed lines.
+func (stats RefactoringStats) LineShare() float64 {
+	if stats.Lines == 0 {
+		return 0
+	}
+	return float64(stats.RefactoringLines) / float64(stats.Lines)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (refactoring *RefactoringAnalysis) Finalize() interface{} {
+	return RefactoringResult{
+		Ticks:              refactoring.ticks,
+		People:             refactoring.people,
+		Threshold:          refactoring.Threshold,
+		Sampling:           refactoring.Sampling,
+		reversedPeopleDict: refactoring.reversedPeopleDict,
+	}
+}
+
+// Fork clones this pipeline item.
+func (refactoring *RefactoringAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(refactoring, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (refactoring *RefactoringAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	refactoringResult := result.(RefactoringResult)
+	if binary {
+		return refactoring.serializeBinary(&refactoringResult, writer)
+	}
+	refactoring.serializeText(&refactoringResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (refactoring *RefactoringAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(refactoring, result, &pb.RefactoringAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (refactoring *RefactoringAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(refactoring, result, &pb.RefactoringAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to RefactoringResult.
+func (refactoring *RefactoringAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.RefactoringAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	convert := func(stats *pb.RefactoringStats) RefactoringStats {
+		return RefactoringStats{
+			Commits:          int(stats.Commits),
+			Refactorings:     int(stats.Refactorings),
+			Lines:            int(stats.Lines),
+			RefactoringLines: int(stats.RefactoringLines),
+		}
+	}
+	result := RefactoringResult{
+		People:             map[int]RefactoringStats{},
+		Threshold:          message.Threshold,
+		Sampling:           int(message.Sampling),
+		reversedPeopleDict: message.DevIndex,
+	}
+	if len(message.Ticks) > 0 {
+		result.Ticks = make([]RefactoringStats, len(message.Ticks))
+		for i, tick := range message.Ticks {
+			result.Ticks[i] = convert(tick)
+		}
+	}
+	for dev, stats := range message.People {
+		result.People[int(dev)] = convert(stats)
+	}
+	return result, nil
+}
+
+// MergeResults combines two RefactoringResult-s together. The ticks are regrouped
+// by the larger sampling.
+func (refactoring *RefactoringAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	rr1 := r1.(RefactoringResult)
+	rr2 := r2.(RefactoringResult)
+	merged := RefactoringResult{
+		People:    map[int]RefactoringStats{},
+		Threshold: rr1.Threshold,
+		Sampling:  rr1.Sampling,
+	}
+	if rr2.Sampling > merged.Sampling {
+		merged.Sampling = rr2.Sampling
+	}
+	var people map[string][3]int
+	people, merged.reversedPeopleDict = identity.Detector{}.MergeReversedDicts(
+		rr1.reversedPeopleDict, rr2.reversedPeopleDict)
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	sum := func(target *RefactoringStats, stats RefactoringStats) {
+		target.Commits += stats.Commits
+		target.Refactorings += stats.Refactorings
+		target.Lines += stats.Lines
+		target.RefactoringLines += stats.RefactoringLines
+	}
+	add := func(result *RefactoringResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for i, tick := range result.Ticks {
+			index := (i*result.Sampling + offset) / merged.Sampling
+			for len(merged.Ticks) <= index {
+				merged.Ticks = append(merged.Ticks, RefactoringStats{})
+			}
+			sum(&merged.Ticks[index], tick)
+		}
+		for dev, stats := range result.People {
+			index := -1
+			if dev >= 0 && dev < len(result.reversedPeopleDict) {
+				index = people[result.reversedPeopleDict[dev]][0]
+			}
+			target := merged.People[index]
+			sum(&target, stats)
+			merged.People[index] = target
+		}
+	}
+	add(&rr1, c1)
+	add(&rr2, c2)
+	return merged
+}
+
+func (refactoring *RefactoringAnalysis) serializeText(result *RefactoringResult, writer io.Writer) {
+	writeStats := func(stats RefactoringStats) {
+		fmt.Fprintf(writer, "{commits: %d, refactorings: %d, lines: %d, refactoring_lines: %d, "+
+			"commit_share: %.4f, line_share: %.4f}\n", stats.Commits, stats.Refactorings,
+			stats.Lines, stats.RefactoringLines, stats.CommitShare(), stats.LineShare())
+	}
+	fmt.Fprintf(writer, "  threshold: %.2f\n", result.Threshold)
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  ticks:")
+	for _, tick := range result.Ticks {
+		fmt.Fprint(writer, "    - ")
+		writeStats(tick)
+	}
+	devs := make([]int, 0, len(result.People))
+	for dev := range result.People {
+		devs = append(devs, dev)
+	}
+	sort.Ints(devs)
+	fmt.Fprintln(writer, "  # -1 means an unmatched identity")
+	fmt.Fprintln(writer, "  developers:")
+	for _, dev := range devs {
+		fmt.Fprintf(writer, "    %d: ", dev)
+		writeStats(result.People[dev])
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (refactoring *RefactoringAnalysis) serializeBinary(result *RefactoringResult, writer io.Writer) error {
+	convert := func(stats RefactoringStats) *pb.RefactoringStats {
+		return &pb.RefactoringStats{
+			Commits:          int32(stats.Commits),
+			Refactorings:     int32(stats.Refactorings),
+			Lines:            int32(stats.Lines),
+			RefactoringLines: int32(stats.RefactoringLines),
+		}
+	}
+	message := pb.RefactoringAnalysisResults{
+		Ticks:     make([]*pb.RefactoringStats, len(result.Ticks)),
+		People:    map[int32]*pb.RefactoringStats{},
+		Threshold: result.Threshold,
+		Sampling:  int32(result.Sampling),
+		DevIndex:  result.reversedPeopleDict,
+	}
+	for i, tick := range result.Ticks {
+		message.Ticks[i] = convert(tick)
+	}
+	for dev, stats := range result.People {
+		message.People[int32(dev)] = convert(stats)
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&RefactoringAnalysis{})
+}
@@ -0,0 +1,195 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureRefactoring() *RefactoringAnalysis {
+	refactoring := RefactoringAnalysis{}
+	refactoring.Configure(map[string]interface{}{
+		ConfigRefactoringSampling:                       10,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"alice", "bob"},
+	})
+	refactoring.Initialize(nil)
+	return &refactoring
+}
+
+func TestRefactoringMeta(t *testing.T) {
+	refactoring := fixtureRefactoring()
+	assert.Equal(t, refactoring.Name(), "Refactoring")
+	assert.Len(t, refactoring.Provides(), 0)
+	assert.Equal(t, refactoring.Requires(), []string{
+		identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges,
+		items.DependencyFileDiff, items.DependencyBlobCache})
+	assert.Equal(t, refactoring.Flag(), "refactoring")
+	assert.NotEmpty(t, refactoring.Description())
+	opts := refactoring.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Flag, "refactoring-threshold")
+	assert.Equal(t, opts[1].Flag, "refactoring-sampling")
+	assert.Equal(t, refactoring.Sampling, 10)
+	assert.Equal(t, refactoring.Threshold, float32(DefaultRefactoringThreshold))
+	assert.Equal(t, refactoring.reversedPeopleDict, []string{"alice", "bob"})
+	refactoring.Configure(map[string]interface{}{ConfigRefactoringThreshold: float32(0.75)})
+	assert.Equal(t, refactoring.Threshold, float32(0.75))
+	refactoring = &RefactoringAnalysis{Threshold: 2}
+	refactoring.Initialize(nil)
+	assert.Equal(t, refactoring.Threshold, float32(DefaultRefactoringThreshold))
+	assert.Equal(t, refactoring.Sampling, DefaultRefactoringSampling)
+	summoned := core.Registry.Summon(refactoring.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Refactoring")
+}
+
+func TestRefactoringScore(t *testing.T) {
+	assert.Equal(t, refactoringScore(2, 1, nil, nil), 0.5)
+	assert.Equal(t, refactoringScore(1, 0, nil, []string{"a", "b"}), float64(0))
+	assert.Equal(t, refactoringScore(2, 0, []string{"a", "b"}, []string{"  b", "a"}), float64(1))
+	// half of the lines moved, 2 deleted versus 4 inserted
+	assert.Equal(t, refactoringScore(1, 0, []string{"a", "b"}, []string{"a", "c", "d", "e"}),
+		float64(2)/6*2/4)
+}
+
+func fixtureRefactoringResult(t *testing.T) RefactoringResult {
+	hashA, blobA := storeExpertiseBlob(t, "one\ntwo\nthree\n")
+	hashC, blobC := storeExpertiseBlob(t, "  one\n  two\n  three\n")
+	hashD, blobD := storeExpertiseBlob(t, "  one\n  TWO\n  three\n")
+	cache := map[plumbing.Hash]*object.Blob{hashA: blobA, hashC: blobC, hashD: blobD}
+	entry := func(name string, hash plumbing.Hash) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	refactoring := fixtureRefactoring()
+	consume := func(author, day int, merge bool, changes object.Changes,
+		fileDiffs map[string]items.FileDiffData) {
+		commit := &object.Commit{}
+		if merge {
+			commit.Hash = plumbing.NewHash("0123456789012345678901234567890123456789")
+			commit.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		result, err := refactoring.Consume(map[string]interface{}{
+			core.DependencyCommit:       commit,
+			core.DependencyIsMerge:      merge,
+			identity.DependencyAuthor:   author,
+			items.DependencyDay:         day,
+			items.DependencyTreeChanges: changes,
+			items.DependencyFileDiff:    fileDiffs,
+			items.DependencyBlobCache:   cache,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	consume(0, 0, false, object.Changes{{To: entry("a.go", hashA)}}, nil)
+	// rename
+	consume(1, 1, false, object.Changes{{From: entry("a.go", hashA), To: entry("b.go", hashA)}},
+		map[string]items.FileDiffData{"b.go": {Diffs: []diffmatchpatch.Diff{
+			{Type: diffmatchpatch.DiffEqual, Text: "abc"}}}})
+	// move with the indentation change
+	consume(0, 12, false, object.Changes{{From: entry("b.go", hashA)}, {To: entry("c.go", hashC)}}, nil)
+	// behavioral change
+	consume(identity.AuthorMissing, 13, false,
+		object.Changes{{From: entry("c.go", hashC), To: entry("c.go", hashD)}},
+		map[string]items.FileDiffData{"c.go": {Diffs: []diffmatchpatch.Diff{
+			{Type: diffmatchpatch.DiffEqual, Text: "a"},
+			{Type: diffmatchpatch.DiffDelete, Text: "b"},
+			{Type: diffmatchpatch.DiffInsert, Text: "x"},
+			{Type: diffmatchpatch.DiffEqual, Text: "c"},
+		}}})
+	consume(1, 14, true, object.Changes{{From: entry("c.go", hashC), To: entry("d.go", hashC)}}, nil)
+	return refactoring.Finalize().(RefactoringResult)
+}
+
+func TestRefactoringConsumeFinalize(t *testing.T) {
+	result := fixtureRefactoringResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Threshold, float32(DefaultRefactoringThreshold))
+	assert.Equal(t, result.Ticks, []RefactoringStats{
+		{Commits: 2, Refactorings: 1, Lines: 3, RefactoringLines: 0},
+		{Commits: 2, Refactorings: 1, Lines: 8, RefactoringLines: 6},
+	})
+	assert.Equal(t, result.People, map[int]RefactoringStats{
+		0:  {Commits: 2, Refactorings: 1, Lines: 9, RefactoringLines: 6},
+		1:  {Commits: 1, Refactorings: 1, Lines: 0, RefactoringLines: 0},
+		-1: {Commits: 1, Refactorings: 0, Lines: 2, RefactoringLines: 0},
+	})
+	assert.Equal(t, result.Ticks[1].CommitShare(), 0.5)
+	assert.Equal(t, result.Ticks[1].LineShare(), 0.75)
+	assert.Equal(t, RefactoringStats{}.CommitShare(), float64(0))
+	assert.Equal(t, RefactoringStats{}.LineShare(), float64(0))
+}
+
+func TestRefactoringSerialize(t *testing.T) {
+	result := fixtureRefactoringResult(t)
+	refactoring := fixtureRefactoring()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, refactoring.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  threshold: 0.50
+  sampling: 10
+  ticks:
+    - {commits: 2, refactorings: 1, lines: 3, refactoring_lines: 0, commit_share: 0.5000, line_share: 0.0000}
+    - {commits: 2, refactorings: 1, lines: 8, refactoring_lines: 6, commit_share: 0.5000, line_share: 0.7500}
+  # -1 means an unmatched identity
+  developers:
+    -1: {commits: 1, refactorings: 0, lines: 2, refactoring_lines: 0, commit_share: 0.0000, line_share: 0.0000}
+    0: {commits: 2, refactorings: 1, lines: 9, refactoring_lines: 6, commit_share: 0.5000, line_share: 0.6667}
+    1: {commits: 1, refactorings: 1, lines: 0, refactoring_lines: 0, commit_share: 1.0000, line_share: 0.0000}
+  people:
+  - "alice"
+  - "bob"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, refactoring.Serialize(result, true, buffer))
+	msg := pb.RefactoringAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Len(t, msg.Ticks, 2)
+	assert.Len(t, msg.People, 3)
+	assert.Equal(t, msg.People[0].RefactoringLines, int32(6))
+	assert.Equal(t, msg.DevIndex, []string{"alice", "bob"})
+	deserialized, err := refactoring.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestRefactoringMergeResults(t *testing.T) {
+	r1 := RefactoringResult{
+		Ticks:              []RefactoringStats{{Commits: 2, Refactorings: 1, Lines: 10, RefactoringLines: 4}},
+		People:             map[int]RefactoringStats{0: {Commits: 2, Refactorings: 1, Lines: 10, RefactoringLines: 4}},
+		Threshold:          0.5,
+		Sampling:           10,
+		reversedPeopleDict: []string{"alice"},
+	}
+	r2 := RefactoringResult{
+		Ticks: []RefactoringStats{{Commits: 3, Refactorings: 2, Lines: 6, RefactoringLines: 6}},
+		People: map[int]RefactoringStats{
+			0:  {Commits: 2, Refactorings: 2, Lines: 6, RefactoringLines: 6},
+			1:  {Commits: 1},
+			-1: {Commits: 0}},
+		Threshold:          0.5,
+		Sampling:           20,
+		reversedPeopleDict: []string{"bob", "alice"},
+	}
+	refactoring := fixtureRefactoring()
+	merged := refactoring.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 30 * 24 * 3600}).(RefactoringResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.reversedPeopleDict, []string{"alice", "bob"})
+	assert.Equal(t, merged.Ticks, []RefactoringStats{
+		{Commits: 2, Refactorings: 1, Lines: 10, RefactoringLines: 4},
+		{Commits: 3, Refactorings: 2, Lines: 6, RefactoringLines: 6}})
+	assert.Equal(t, merged.People, map[int]RefactoringStats{
+		0:  {Commits: 3, Refactorings: 1, Lines: 10, RefactoringLines: 4},
+		1:  {Commits: 2, Refactorings: 2, Lines: 6, RefactoringLines: 6},
+		-1: {}})
+}
@@ -0,0 +1,344 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// ReleaseCadenceAnalysis splits the history into releases by the tags which match TagPattern
+// and reports the number of days between the releases, the number of commits and the number
+// of changed lines in each. The commits are attributed to the next tagged commit in the analysis
+// order, so the side branches which are merged after a release fall into the following one.
+// The merge commits can be released but are not counted.
+type ReleaseCadenceAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// TagPattern is the regular expression which the release tags match.
+	TagPattern string
+
+	// pattern is the compiled TagPattern.
+	pattern *regexp.Regexp
+	// releases are the releases found so far.
+	releases []TaggedRelease
+	// commits is the number of commits since the last release.
+	commits int
+	// churn is the number of changed lines since the last release.
+	churn int
+}
+
+// TaggedRelease is a single release.
+type TaggedRelease struct {
+	// Tag is the first matching tag of the released commit.
+	Tag string
+	// Hash is the released commit.
+	Hash string
+	// Day is the day of the released commit.
+	Day int
+	// Days is the number of days since the previous release or since the beginning
+	// of the history for the first release.
+	Days int
+	// Commits is the number of commits since the previous release including the released one.
+	Commits int
+	// Churn is the number of changed lines in Commits.
+	Churn int
+}
+
+// ReleaseCadenceResult is returned by ReleaseCadenceAnalysis.Finalize().
+type ReleaseCadenceResult struct {
+	// Releases are sorted by Day.
+	Releases []TaggedRelease
+	// UnreleasedCommits is the number of commits after the last release.
+	UnreleasedCommits int
+	// UnreleasedChurn is the number of changed lines in UnreleasedCommits.
+	UnreleasedChurn int
+	// TagPattern is the effective ReleaseCadenceAnalysis.TagPattern.
+	TagPattern string
+}
+
+const (
+	// ConfigReleaseCadenceTagPattern is the name of the option to set
+	// ReleaseCadenceAnalysis.TagPattern.
+	ConfigReleaseCadenceTagPattern = "ReleaseCadence.TagPattern"
+	// DefaultReleaseCadenceTagPattern is the default value of ReleaseCadenceAnalysis.TagPattern:
+	// the version tags like "v1.2.3" or "1.2".
+	DefaultReleaseCadenceTagPattern = `^v?\d+\.\d+`
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (cadence *ReleaseCadenceAnalysis) Name() string {
+	return "ReleaseCadence"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (cadence *ReleaseCadenceAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (cadence *ReleaseCadenceAnalysis) Requires() []string {
+	arr := [...]string{
+		items.DependencyTags, items.DependencyDay, items.DependencyTreeChanges,
+		items.DependencyFileDiff, items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (cadence *ReleaseCadenceAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigReleaseCadenceTagPattern,
+		Description: "Regular expression which the release tags match.",
+		Flag:        "release-cadence-tags",
+		Type:        core.StringConfigurationOption,
+		Default:     DefaultReleaseCadenceTagPattern},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (cadence *ReleaseCadenceAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigReleaseCadenceTagPattern].(string); exists {
+		cadence.TagPattern = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (cadence *ReleaseCadenceAnalysis) Flag() string {
+	return "release-cadence"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (cadence *ReleaseCadenceAnalysis) Description() string {
+	return "Splits the history into releases by the tags and reports the time between " +
+		"the releases, the commits and the churn of each release."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (cadence *ReleaseCadenceAnalysis) Initialize(repository *git.Repository) {
+	if cadence.TagPattern == "" {
+		cadence.TagPattern = DefaultReleaseCadenceTagPattern
+	}
+	pattern, err := regexp.Compile(cadence.TagPattern)
+	if err != nil {
+		log.Printf("Warning: adjusted the release tag pattern to %s: %v\n",
+			DefaultReleaseCadenceTagPattern, err)
+		cadence.TagPattern = DefaultReleaseCadenceTagPattern
+		pattern = regexp.MustCompile(cadence.TagPattern)
+	}
+	cadence.pattern = pattern
+	cadence.releases = nil
+	cadence.commits = 0
+	cadence.churn = 0
+	cadence.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (cadence *ReleaseCadenceAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !cadence.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	if !deps[core.DependencyIsMerge].(bool) {
+		cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+		fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+		for _, change := range deps[items.DependencyTreeChanges].(object.Changes) {
+			_, lines, err := countChangedLines(change, cache, fileDiffs)
+			if err != nil {
+				return nil, err
+			}
+			cadence.churn += lines
+		}
+		cadence.commits++
+	}
+	for _, tag := range deps[items.DependencyTags].([]string) {
+		if !cadence.pattern.MatchString(tag) {
+			continue
+		}
+		day := deps[items.DependencyDay].(int)
+		release := TaggedRelease{
+			Tag:     tag,
+			Hash:    deps[core.DependencyCommit].(*object.Commit).Hash.String(),
+			Day:     day,
+			Days:    day,
+			Commits: cadence.commits,
+			Churn:   cadence.churn,
+		}
+		if len(cadence.releases) > 0 {
+			release.Days -= cadence.releases[len(cadence.releases)-1].Day
+		}
+		cadence.releases = append(cadence.releases, release)
+		cadence.commits = 0
+		cadence.churn = 0
+		break
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (cadence *ReleaseCadenceAnalysis) Finalize() interface{} {
+	return ReleaseCadenceResult{
+		Releases:          cadence.releases,
+		UnreleasedCommits: cadence.commits,
+		UnreleasedChurn:   cadence.churn,
+		TagPattern:        cadence.TagPattern,
+	}
+}
+
+// Fork clones this pipeline item.
+func (cadence *ReleaseCadenceAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(cadence, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (cadence *ReleaseCadenceAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	cadenceResult := result.(ReleaseCadenceResult)
+	if binary {
+		return cadence.serializeBinary(&cadenceResult, writer)
+	}
+	cadence.serializeText(&cadenceResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (cadence *ReleaseCadenceAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(cadence, result, &pb.ReleaseCadenceAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (cadence *ReleaseCadenceAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(cadence, result, &pb.ReleaseCadenceAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to ReleaseCadenceResult.
+func (cadence *ReleaseCadenceAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.ReleaseCadenceAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := ReleaseCadenceResult{
+		UnreleasedCommits: int(message.UnreleasedCommits),
+		UnreleasedChurn:   int(message.UnreleasedChurn),
+		TagPattern:        message.TagPattern,
+	}
+	if len(message.Releases) > 0 {
+		result.Releases = make([]TaggedRelease, len(message.Releases))
+		for i, release := range message.Releases {
+			result.Releases[i] = TaggedRelease{
+				Tag:     release.Tag,
+				Hash:    release.Hash,
+				Day:     int(release.Day),
+				Days:    int(release.Days),
+				Commits: int(release.Commits),
+				Churn:   int(release.Churn),
+			}
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two ReleaseCadenceResult-s together. The releases are joined,
+// the duplicate released commits are dropped and the days between the releases are
+// calculated again. The unreleased counters are summed.
+func (cadence *ReleaseCadenceAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	rr1 := r1.(ReleaseCadenceResult)
+	rr2 := r2.(ReleaseCadenceResult)
+	merged := ReleaseCadenceResult{
+		UnreleasedCommits: rr1.UnreleasedCommits + rr2.UnreleasedCommits,
+		UnreleasedChurn:   rr1.UnreleasedChurn + rr2.UnreleasedChurn,
+		TagPattern:        rr1.TagPattern,
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	released := map[string]bool{}
+	add := func(result *ReleaseCadenceResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for _, release := range result.Releases {
+			if released[release.Hash] {
+				continue
+			}
+			released[release.Hash] = true
+			release.Day += offset
+			merged.Releases = append(merged.Releases, release)
+		}
+	}
+	add(&rr1, c1)
+	add(&rr2, c2)
+	sort.SliceStable(merged.Releases, func(i, j int) bool {
+		return merged.Releases[i].Day < merged.Releases[j].Day
+	})
+	for i := range merged.Releases {
+		merged.Releases[i].Days = merged.Releases[i].Day
+		if i > 0 {
+			merged.Releases[i].Days -= merged.Releases[i-1].Day
+		}
+	}
+	return merged
+}
+
+func (cadence *ReleaseCadenceAnalysis) serializeText(result *ReleaseCadenceResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  tag_pattern:", yaml.SafeString(result.TagPattern))
+	fmt.Fprintln(writer, "  releases:")
+	for _, release := range result.Releases {
+		fmt.Fprintf(writer, "    - tag: %s\n", yaml.SafeString(release.Tag))
+		fmt.Fprintf(writer, "      hash: %s\n", release.Hash)
+		fmt.Fprintf(writer, "      day: %d\n", release.Day)
+		fmt.Fprintf(writer, "      days: %d\n", release.Days)
+		fmt.Fprintf(writer, "      commits: %d\n", release.Commits)
+		fmt.Fprintf(writer, "      churn: %d\n", release.Churn)
+	}
+	fmt.Fprintf(writer, "  unreleased: {commits: %d, churn: %d}\n",
+		result.UnreleasedCommits, result.UnreleasedChurn)
+}
+
+func (cadence *ReleaseCadenceAnalysis) serializeBinary(result *ReleaseCadenceResult, writer io.Writer) error {
+	message := pb.ReleaseCadenceAnalysisResults{
+		Releases:          make([]*pb.Release, len(result.Releases)),
+		UnreleasedCommits: int32(result.UnreleasedCommits),
+		UnreleasedChurn:   int32(result.UnreleasedChurn),
+		TagPattern:        result.TagPattern,
+	}
+	for i, release := range result.Releases {
+		message.Releases[i] = &pb.Release{
+			Tag:     release.Tag,
+			Hash:    release.Hash,
+			Day:     int32(release.Day),
+			Days:    int32(release.Days),
+			Commits: int32(release.Commits),
+			Churn:   int32(release.Churn),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&ReleaseCadenceAnalysis{})
+}
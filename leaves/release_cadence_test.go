@@ -0,0 +1,156 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func fixtureReleaseCadence() *ReleaseCadenceAnalysis {
+	cadence := ReleaseCadenceAnalysis{}
+	cadence.Initialize(nil)
+	return &cadence
+}
+
+func TestReleaseCadenceMeta(t *testing.T) {
+	cadence := fixtureReleaseCadence()
+	assert.Equal(t, cadence.Name(), "ReleaseCadence")
+	assert.Len(t, cadence.Provides(), 0)
+	assert.Equal(t, cadence.Requires(), []string{
+		items.DependencyTags, items.DependencyDay, items.DependencyTreeChanges,
+		items.DependencyFileDiff, items.DependencyBlobCache})
+	assert.Equal(t, cadence.Flag(), "release-cadence")
+	assert.NotEmpty(t, cadence.Description())
+	opts := cadence.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Flag, "release-cadence-tags")
+	assert.Equal(t, cadence.TagPattern, DefaultReleaseCadenceTagPattern)
+	cadence.Configure(map[string]interface{}{ConfigReleaseCadenceTagPattern: "^release-"})
+	assert.Equal(t, cadence.TagPattern, "^release-")
+	cadence.Configure(map[string]interface{}{ConfigReleaseCadenceTagPattern: "("})
+	cadence.Initialize(nil)
+	assert.Equal(t, cadence.TagPattern, DefaultReleaseCadenceTagPattern)
+	summoned := core.Registry.Summon(cadence.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "ReleaseCadence")
+}
+
+func fixtureReleaseCadenceResult(t *testing.T) ReleaseCadenceResult {
+	hash, blob := storeExpertiseBlob(t, "one\ntwo\nthree\n")
+	insert := func(name string) *object.Change {
+		return &object.Change{To: object.ChangeEntry{
+			Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}}
+	}
+	cadence := fixtureReleaseCadence()
+	index := 0
+	consume := func(day int, merge bool, tags []string, changes object.Changes) {
+		index++
+		commit := &object.Commit{Hash: plumbing.NewHash(
+			"000000000000000000000000000000000000000" + string('0'+rune(index)))}
+		if merge {
+			commit.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		result, err := cadence.Consume(map[string]interface{}{
+			core.DependencyCommit:       commit,
+			core.DependencyIsMerge:      merge,
+			items.DependencyTags:        tags,
+			items.DependencyDay:         day,
+			items.DependencyTreeChanges: changes,
+			items.DependencyFileDiff:    map[string]items.FileDiffData{},
+			items.DependencyBlobCache:   map[plumbing.Hash]*object.Blob{hash: blob},
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	consume(0, false, nil, object.Changes{insert("a.go")})
+	consume(2, false, []string{"latest", "v1.0"}, object.Changes{insert("b.go"), insert("c.go")})
+	consume(5, false, nil, object.Changes{insert("d.go")})
+	// the merges are released but not counted
+	consume(9, true, []string{"v1.1.0"}, object.Changes{insert("e.go")})
+	consume(10, false, []string{"nightly"}, object.Changes{insert("f.go")})
+	return cadence.Finalize().(ReleaseCadenceResult)
+}
+
+func TestReleaseCadenceConsumeFinalize(t *testing.T) {
+	result := fixtureReleaseCadenceResult(t)
+	assert.Equal(t, result.TagPattern, DefaultReleaseCadenceTagPattern)
+	assert.Len(t, result.Releases, 2)
+	assert.Equal(t, result.Releases[0], TaggedRelease{
+		Tag: "v1.0", Hash: "0000000000000000000000000000000000000002",
+		Day: 2, Days: 2, Commits: 2, Churn: 9})
+	assert.Equal(t, result.Releases[1], TaggedRelease{
+		Tag: "v1.1.0", Hash: "0000000000000000000000000000000000000004",
+		Day: 9, Days: 7, Commits: 1, Churn: 3})
+	assert.Equal(t, result.UnreleasedCommits, 1)
+	assert.Equal(t, result.UnreleasedChurn, 3)
+}
+
+func TestReleaseCadenceSerialize(t *testing.T) {
+	result := fixtureReleaseCadenceResult(t)
+	cadence := fixtureReleaseCadence()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, cadence.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  tag_pattern: "^v?\\d+\\.\\d+"
+  releases:
+    - tag: "v1.0"
+      hash: 0000000000000000000000000000000000000002
+      day: 2
+      days: 2
+      commits: 2
+      churn: 9
+    - tag: "v1.1.0"
+      hash: 0000000000000000000000000000000000000004
+      day: 9
+      days: 7
+      commits: 1
+      churn: 3
+  unreleased: {commits: 1, churn: 3}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, cadence.Serialize(result, true, buffer))
+	msg := pb.ReleaseCadenceAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Releases, 2)
+	assert.Equal(t, msg.Releases[1].Tag, "v1.1.0")
+	assert.Equal(t, msg.UnreleasedChurn, int32(3))
+	deserialized, err := cadence.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestReleaseCadenceMergeResults(t *testing.T) {
+	r1 := ReleaseCadenceResult{
+		Releases: []TaggedRelease{
+			{Tag: "v1.0", Hash: "a", Day: 3, Days: 3, Commits: 4, Churn: 40},
+			{Tag: "v2.0", Hash: "b", Day: 20, Days: 17, Commits: 2, Churn: 20}},
+		UnreleasedCommits: 1,
+		UnreleasedChurn:   5,
+		TagPattern:        DefaultReleaseCadenceTagPattern,
+	}
+	r2 := ReleaseCadenceResult{
+		Releases: []TaggedRelease{
+			{Tag: "v1.5", Hash: "c", Day: 0, Days: 0, Commits: 3, Churn: 30},
+			{Tag: "v2.0", Hash: "b", Day: 10, Days: 10, Commits: 2, Churn: 20}},
+		UnreleasedCommits: 2,
+		UnreleasedChurn:   7,
+		TagPattern:        DefaultReleaseCadenceTagPattern,
+	}
+	cadence := fixtureReleaseCadence()
+	merged := cadence.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 10 * 24 * 3600}).(ReleaseCadenceResult)
+	assert.Equal(t, merged.Releases, []TaggedRelease{
+		{Tag: "v1.0", Hash: "a", Day: 3, Days: 3, Commits: 4, Churn: 40},
+		{Tag: "v1.5", Hash: "c", Day: 10, Days: 7, Commits: 3, Churn: 30},
+		{Tag: "v2.0", Hash: "b", Day: 20, Days: 10, Commits: 2, Churn: 20}})
+	assert.Equal(t, merged.UnreleasedCommits, 3)
+	assert.Equal(t, merged.UnreleasedChurn, 12)
+	assert.Equal(t, merged.TagPattern, DefaultReleaseCadenceTagPattern)
+}
@@ -0,0 +1,438 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// RenameFrequencyAnalysis finds the files and the directories which are renamed or moved
+// the most often. It follows each renamed file through all its names, counts the renames in
+// each tick of Sampling days and the commits which move the files out of each directory.
+// The frequent moves often indicate an unstable architecture. The renames are detected by
+// RenameAnalysis and the merge commits are skipped.
+type RenameFrequencyAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the number of days in a tick.
+	Sampling int
+	// DirectoryDepth is the number of path components which define the directories.
+	DirectoryDepth int
+
+	// ticks are the numbers of renames in each tick.
+	ticks []int
+	// chains map the current names of the renamed files to their chains.
+	chains map[string]*RenameChain
+	// finished are the chains of the deleted files.
+	finished []RenameChain
+	// directories map the directories to the numbers of commits which moved the files out.
+	directories map[string]int
+}
+
+// RenameChain is the sequence of the names of a renamed file.
+type RenameChain struct {
+	// Names are the consecutive names of the file, the oldest first.
+	Names []string
+	// Days are the days of the renames, Names[i+1] appeared on Days[i].
+	Days []int
+	// Deleted indicates that the file does not exist in the last analysed commit.
+	Deleted bool
+}
+
+// RenameFrequencyResult is returned by RenameFrequencyAnalysis.Finalize().
+type RenameFrequencyResult struct {
+	// Ticks are the numbers of renames in each tick of Sampling days.
+	Ticks []int
+	// Chains are sorted by the number of renames, the most renamed files go first.
+	Chains []RenameChain
+	// Directories map the directories to the numbers of commits which moved the files
+	// out of them into different directories.
+	Directories map[string]int
+	// Sampling is the effective RenameFrequencyAnalysis.Sampling.
+	Sampling int
+	// DirectoryDepth is the effective RenameFrequencyAnalysis.DirectoryDepth.
+	DirectoryDepth int
+}
+
+const (
+	// ConfigRenameFrequencySampling is the name of the option to set
+	// RenameFrequencyAnalysis.Sampling.
+	ConfigRenameFrequencySampling = "RenameFrequency.Sampling"
+	// ConfigRenameFrequencyDirectoryDepth is the name of the option to set
+	// RenameFrequencyAnalysis.DirectoryDepth.
+	ConfigRenameFrequencyDirectoryDepth = "RenameFrequency.DirectoryDepth"
+	// DefaultRenameFrequencySampling is the default value of RenameFrequencyAnalysis.Sampling.
+	DefaultRenameFrequencySampling = 30
+	// DefaultRenameFrequencyDirectoryDepth is the default value of
+	// RenameFrequencyAnalysis.DirectoryDepth: the top-level directories.
+	DefaultRenameFrequencyDirectoryDepth = 1
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (renames *RenameFrequencyAnalysis) Name() string {
+	return "RenameFrequency"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (renames *RenameFrequencyAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (renames *RenameFrequencyAnalysis) Requires() []string {
+	arr := [...]string{items.DependencyDay, items.DependencyTreeChanges}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (renames *RenameFrequencyAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigRenameFrequencySampling,
+		Description: "How frequently to record the number of renames, in days.",
+		Flag:        "rename-frequency-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultRenameFrequencySampling}, {
+		Name: ConfigRenameFrequencyDirectoryDepth,
+		Description: "Number of path components which define the directories, " +
+			"1 means the top-level directories.",
+		Flag:    "rename-frequency-depth",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultRenameFrequencyDirectoryDepth},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (renames *RenameFrequencyAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigRenameFrequencySampling].(int); exists {
+		renames.Sampling = val
+	}
+	if val, exists := facts[ConfigRenameFrequencyDirectoryDepth].(int); exists {
+		renames.DirectoryDepth = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (renames *RenameFrequencyAnalysis) Flag() string {
+	return "rename-frequency"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (renames *RenameFrequencyAnalysis) Description() string {
+	return "Finds the files and the directories which are renamed or moved the most often " +
+		"and records the rename chains of the files."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (renames *RenameFrequencyAnalysis) Initialize(repository *git.Repository) {
+	if renames.Sampling <= 0 {
+		log.Printf("Warning: adjusted the rename frequency sampling to %d days\n",
+			DefaultRenameFrequencySampling)
+		renames.Sampling = DefaultRenameFrequencySampling
+	}
+	if renames.DirectoryDepth <= 0 {
+		log.Printf("Warning: adjusted the rename frequency directory depth to %d\n",
+			DefaultRenameFrequencyDirectoryDepth)
+		renames.DirectoryDepth = DefaultRenameFrequencyDirectoryDepth
+	}
+	renames.ticks = nil
+	renames.chains = map[string]*RenameChain{}
+	renames.finished = nil
+	renames.directories = map[string]int{}
+	renames.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (renames *RenameFrequencyAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !renames.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	day := deps[items.DependencyDay].(int)
+	// the files can swap their names in the same commit, so we detach all the renamed
+	// chains before attaching them to the new names
+	var renamed []*object.Change
+	var detached []*RenameChain
+	moved := map[string]bool{}
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case action == merkletrie.Delete:
+			renames.finish(change.From.Name)
+		case action == merkletrie.Modify && change.From.Name != change.To.Name:
+			chain := renames.chains[change.From.Name]
+			if chain == nil {
+				chain = &RenameChain{Names: []string{change.From.Name}}
+			}
+			delete(renames.chains, change.From.Name)
+			renamed = append(renamed, change)
+			detached = append(detached, chain)
+			from := truncateDirectory(change.From.Name, renames.DirectoryDepth)
+			if from != truncateDirectory(change.To.Name, renames.DirectoryDepth) {
+				moved[from] = true
+			}
+		}
+	}
+	if len(renamed) == 0 {
+		return nil, nil
+	}
+	for i, change := range renamed {
+		// the rename can replace an existing file
+		renames.finish(change.To.Name)
+		chain := detached[i]
+		chain.Names = append(chain.Names, change.To.Name)
+		chain.Days = append(chain.Days, day)
+		renames.chains[change.To.Name] = chain
+	}
+	for len(renames.ticks) <= day/renames.Sampling {
+		renames.ticks = append(renames.ticks, 0)
+	}
+	renames.ticks[day/renames.Sampling] += len(renamed)
+	for dir := range moved {
+		renames.directories[dir]++
+	}
+	return nil, nil
+}
+
+// finish marks the chain of the file as deleted, if any.
+func (renames *RenameFrequencyAnalysis) finish(name string) {
+	if chain := renames.chains[name]; chain != nil {
+		chain.Deleted = true
+		renames.finished = append(renames.finished, *chain)
+		delete(renames.chains, name)
+	}
+}
+
+// Renames returns the number of times the file was renamed.
+func (chain RenameChain) Renames() int {
+	return len(chain.Days)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (renames *RenameFrequencyAnalysis) Finalize() interface{} {
+	chains := make([]RenameChain, 0, len(renames.finished)+len(renames.chains))
+	chains = append(chains, renames.finished...)
+	for _, chain := range renames.chains {
+		chains = append(chains, *chain)
+	}
+	directories := make(map[string]int, len(renames.directories))
+	for dir, val := range renames.directories {
+		directories[dir] = val
+	}
+	return newRenameFrequencyResult(
+		renames.ticks, chains, directories, renames.Sampling, renames.DirectoryDepth)
+}
+
+func newRenameFrequencyResult(ticks []int, chains []RenameChain, directories map[string]int,
+	sampling, depth int) RenameFrequencyResult {
+	sort.Slice(chains, func(i, j int) bool {
+		if chains[i].Renames() != chains[j].Renames() {
+			return chains[i].Renames() > chains[j].Renames()
+		}
+		lastI, lastJ := chains[i].Names[len(chains[i].Names)-1], chains[j].Names[len(chains[j].Names)-1]
+		if lastI != lastJ {
+			return lastI < lastJ
+		}
+		return chains[i].Days[0] < chains[j].Days[0]
+	})
+	return RenameFrequencyResult{
+		Ticks: ticks, Chains: chains, Directories: directories,
+		Sampling: sampling, DirectoryDepth: depth,
+	}
+}
+
+// Fork clones this pipeline item.
+func (renames *RenameFrequencyAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(renames, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (renames *RenameFrequencyAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	renamesResult := result.(RenameFrequencyResult)
+	if binary {
+		return renames.serializeBinary(&renamesResult, writer)
+	}
+	renames.serializeText(&renamesResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (renames *RenameFrequencyAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(renames, result, &pb.RenameFrequencyAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (renames *RenameFrequencyAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(renames, result, &pb.RenameFrequencyAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to RenameFrequencyResult.
+func (renames *RenameFrequencyAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.RenameFrequencyAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := RenameFrequencyResult{
+		Directories:    map[string]int{},
+		Sampling:       int(message.Sampling),
+		DirectoryDepth: int(message.DirectoryDepth),
+	}
+	if len(message.Ticks) > 0 {
+		result.Ticks = make([]int, len(message.Ticks))
+		for i, val := range message.Ticks {
+			result.Ticks[i] = int(val)
+		}
+	}
+	if len(message.Chains) > 0 {
+		result.Chains = make([]RenameChain, len(message.Chains))
+		for i, chain := range message.Chains {
+			days := make([]int, len(chain.Days))
+			for j, day := range chain.Days {
+				days[j] = int(day)
+			}
+			result.Chains[i] = RenameChain{Names: chain.Names, Days: days, Deleted: chain.Deleted}
+		}
+	}
+	for dir, val := range message.Directories {
+		result.Directories[dir] = int(val)
+	}
+	return result, nil
+}
+
+// MergeResults combines two RenameFrequencyResult-s together. The ticks are regrouped
+// by the larger sampling and the directory depth is taken from the first result.
+func (renames *RenameFrequencyAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	rr1 := r1.(RenameFrequencyResult)
+	rr2 := r2.(RenameFrequencyResult)
+	sampling := rr1.Sampling
+	if rr2.Sampling > sampling {
+		sampling = rr2.Sampling
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	var ticks []int
+	var chains []RenameChain
+	directories := map[string]int{}
+	add := func(result *RenameFrequencyResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for i, val := range result.Ticks {
+			index := (i*result.Sampling + offset) / sampling
+			for len(ticks) <= index {
+				ticks = append(ticks, 0)
+			}
+			ticks[index] += val
+		}
+		for _, chain := range result.Chains {
+			days := make([]int, len(chain.Days))
+			for i, day := range chain.Days {
+				days[i] = day + offset
+			}
+			chain.Days = days
+			chains = append(chains, chain)
+		}
+		for dir, val := range result.Directories {
+			directories[dir] += val
+		}
+	}
+	add(&rr1, c1)
+	add(&rr2, c2)
+	return newRenameFrequencyResult(ticks, chains, directories, sampling, rr1.DirectoryDepth)
+}
+
+func (renames *RenameFrequencyAnalysis) serializeText(result *RenameFrequencyResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  directory_depth:", result.DirectoryDepth)
+	fmt.Fprint(writer, "  ticks: [")
+	writeIntList(writer, result.Ticks)
+	fmt.Fprintln(writer, "]")
+	fmt.Fprintln(writer, "  # the most renamed files first")
+	fmt.Fprintln(writer, "  chains:")
+	for _, chain := range result.Chains {
+		fmt.Fprintf(writer, "    - {renames: %d, deleted: %t, names: [",
+			chain.Renames(), chain.Deleted)
+		for i, name := range chain.Names {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprint(writer, yaml.SafeString(name))
+		}
+		fmt.Fprint(writer, "], days: [")
+		writeIntList(writer, chain.Days)
+		fmt.Fprintln(writer, "]}")
+	}
+	dirs := make([]string, 0, len(result.Directories))
+	for dir := range result.Directories {
+		dirs = append(dirs, dir)
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		if result.Directories[dirs[i]] != result.Directories[dirs[j]] {
+			return result.Directories[dirs[i]] > result.Directories[dirs[j]]
+		}
+		return dirs[i] < dirs[j]
+	})
+	fmt.Fprintln(writer, "  # the number of commits which moved the files out of each directory")
+	fmt.Fprintln(writer, "  directories:")
+	for _, dir := range dirs {
+		fmt.Fprintf(writer, "    %s: %d\n", yaml.SafeString(dir), result.Directories[dir])
+	}
+}
+
+func (renames *RenameFrequencyAnalysis) serializeBinary(result *RenameFrequencyResult, writer io.Writer) error {
+	message := pb.RenameFrequencyAnalysisResults{
+		Ticks:          make([]int32, len(result.Ticks)),
+		Chains:         make([]*pb.RenameChain, len(result.Chains)),
+		Directories:    map[string]int32{},
+		Sampling:       int32(result.Sampling),
+		DirectoryDepth: int32(result.DirectoryDepth),
+	}
+	for i, val := range result.Ticks {
+		message.Ticks[i] = int32(val)
+	}
+	for i, chain := range result.Chains {
+		days := make([]int32, len(chain.Days))
+		for j, day := range chain.Days {
+			days[j] = int32(day)
+		}
+		message.Chains[i] = &pb.RenameChain{Names: chain.Names, Days: days, Deleted: chain.Deleted}
+	}
+	for dir, val := range result.Directories {
+		message.Directories[dir] = int32(val)
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&RenameFrequencyAnalysis{})
+}
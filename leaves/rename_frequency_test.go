@@ -0,0 +1,163 @@
+package leaves
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func fixtureRenameFrequency() *RenameFrequencyAnalysis {
+	renames := RenameFrequencyAnalysis{}
+	renames.Configure(map[string]interface{}{
+		ConfigRenameFrequencySampling:       10,
+		ConfigRenameFrequencyDirectoryDepth: 1,
+	})
+	renames.Initialize(nil)
+	return &renames
+}
+
+func TestRenameFrequencyMeta(t *testing.T) {
+	renames := fixtureRenameFrequency()
+	assert.Equal(t, renames.Name(), "RenameFrequency")
+	assert.Len(t, renames.Provides(), 0)
+	assert.Equal(t, renames.Requires(), []string{items.DependencyDay, items.DependencyTreeChanges})
+	assert.Equal(t, renames.Flag(), "rename-frequency")
+	assert.NotEmpty(t, renames.Description())
+	opts := renames.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Flag, "rename-frequency-sampling")
+	assert.Equal(t, opts[1].Flag, "rename-frequency-depth")
+	assert.Equal(t, renames.Sampling, 10)
+	assert.Equal(t, renames.DirectoryDepth, 1)
+	renames = &RenameFrequencyAnalysis{}
+	renames.Initialize(nil)
+	assert.Equal(t, renames.Sampling, DefaultRenameFrequencySampling)
+	assert.Equal(t, renames.DirectoryDepth, DefaultRenameFrequencyDirectoryDepth)
+	summoned := core.Registry.Summon(renames.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "RenameFrequency")
+}
+
+func fixtureRenameFrequencyResult(t *testing.T) RenameFrequencyResult {
+	entry := func(name string) object.ChangeEntry {
+		hash := plumbing.NewHash("1111111111111111111111111111111111111111")
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	rename := func(from, to string) *object.Change {
+		return &object.Change{From: entry(from), To: entry(to)}
+	}
+	renames := fixtureRenameFrequency()
+	consume := func(commit string, day int, merge bool, changes object.Changes) {
+		obj := &object.Commit{Hash: plumbing.NewHash(commit)}
+		if merge {
+			obj.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		result, err := renames.Consume(map[string]interface{}{
+			core.DependencyCommit:       obj,
+			core.DependencyIsMerge:      merge,
+			items.DependencyDay:         day,
+			items.DependencyTreeChanges: changes,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	consume(strings.Repeat("1", 40), 0, false, object.Changes{
+		{To: entry("a.go")}, {To: entry("src/b.go")}, {To: entry("z.go")}})
+	consume(strings.Repeat("2", 40), 3, false, object.Changes{
+		rename("a.go", "lib/a.go"), rename("src/b.go", "src/c.go"),
+		{From: entry("z.go"), To: entry("z.go")}})
+	consume(strings.Repeat("3", 40), 5, true, object.Changes{rename("lib/a.go", "x.go")})
+	// swap the names
+	consume(strings.Repeat("4", 40), 12, false, object.Changes{
+		rename("lib/a.go", "src/c.go"), rename("src/c.go", "lib/a.go")})
+	consume(strings.Repeat("5", 40), 25, false, object.Changes{
+		{From: entry("lib/a.go")}, rename("z.go", "lib/z.go")})
+	return renames.Finalize().(RenameFrequencyResult)
+}
+
+func TestRenameFrequencyConsumeFinalize(t *testing.T) {
+	result := fixtureRenameFrequencyResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.DirectoryDepth, 1)
+	assert.Equal(t, result.Ticks, []int{2, 2, 1})
+	assert.Equal(t, result.Chains, []RenameChain{
+		{Names: []string{"src/b.go", "src/c.go", "lib/a.go"}, Days: []int{3, 12}, Deleted: true},
+		{Names: []string{"a.go", "lib/a.go", "src/c.go"}, Days: []int{3, 12}},
+		{Names: []string{"z.go", "lib/z.go"}, Days: []int{25}},
+	})
+	assert.Equal(t, result.Chains[0].Renames(), 2)
+	assert.Equal(t, result.Directories, map[string]int{".": 2, "lib": 1, "src": 1})
+}
+
+func TestRenameFrequencySerialize(t *testing.T) {
+	result := fixtureRenameFrequencyResult(t)
+	renames := fixtureRenameFrequency()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, renames.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  directory_depth: 1
+  ticks: [2, 2, 1]
+  # the most renamed files first
+  chains:
+    - {renames: 2, deleted: true, names: ["src/b.go", "src/c.go", "lib/a.go"], days: [3, 12]}
+    - {renames: 2, deleted: false, names: ["a.go", "lib/a.go", "src/c.go"], days: [3, 12]}
+    - {renames: 1, deleted: false, names: ["z.go", "lib/z.go"], days: [25]}
+  # the number of commits which moved the files out of each directory
+  directories:
+    ".": 2
+    "lib": 1
+    "src": 1
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, renames.Serialize(result, true, buffer))
+	msg := pb.RenameFrequencyAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Equal(t, msg.Ticks, []int32{2, 2, 1})
+	assert.Len(t, msg.Chains, 3)
+	assert.Equal(t, msg.Chains[2].Names, []string{"z.go", "lib/z.go"})
+	assert.Equal(t, msg.Directories["."], int32(2))
+	deserialized, err := renames.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestRenameFrequencyMergeResults(t *testing.T) {
+	r1 := RenameFrequencyResult{
+		Ticks: []int{1, 2},
+		Chains: []RenameChain{
+			{Names: []string{"a", "b"}, Days: []int{5}}},
+		Directories:    map[string]int{"src": 1},
+		Sampling:       10,
+		DirectoryDepth: 1,
+	}
+	r2 := RenameFrequencyResult{
+		Ticks: []int{3},
+		Chains: []RenameChain{
+			{Names: []string{"c", "d", "e"}, Days: []int{1, 2}, Deleted: true}},
+		Directories:    map[string]int{"src": 2, "lib": 1},
+		Sampling:       20,
+		DirectoryDepth: 2,
+	}
+	renames := fixtureRenameFrequency()
+	merged := renames.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 20 * 24 * 3600}).(RenameFrequencyResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.DirectoryDepth, 1)
+	assert.Equal(t, merged.Ticks, []int{3, 3})
+	assert.Equal(t, merged.Chains, []RenameChain{
+		{Names: []string{"c", "d", "e"}, Days: []int{21, 22}, Deleted: true},
+		{Names: []string{"a", "b"}, Days: []int{5}},
+	})
+	assert.Equal(t, merged.Directories, map[string]int{"src": 3, "lib": 1})
+	assert.Equal(t, r2.Chains[0].Days, []int{1, 2})
+}
@@ -0,0 +1,462 @@
+package leaves
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// RepositorySizeAnalysis tracks the total size of the files in the repository over time.
+// The files are split into text and binary the same way as Git does: a file is binary if
+// there is a zero byte among the first 8000. Besides, it records the Top largest blobs
+// introduced in each tick of Sampling days together with the commits which added them.
+// The merge commits are ignored.
+type RepositorySizeAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the number of days in a tick.
+	Sampling int
+	// Top is the number of the largest blobs to record in each tick.
+	Top int
+
+	// files map the file names to their current sizes.
+	files map[string]repositorySizeFile
+	// textBytes is the current total size of the text files.
+	textBytes int64
+	// binaryBytes is the current total size of the binary files.
+	binaryBytes int64
+	// ticks are the repository sizes at the end of each tick.
+	ticks []RepositorySizeTick
+	// largest are the largest blobs in each tick, sorted by size in the descending order.
+	largest [][]RepositoryBlob
+}
+
+// RepositorySizeTick is the size of the repository at the end of a period.
+type RepositorySizeTick struct {
+	// TextBytes is the total size of the text files.
+	TextBytes int64
+	// BinaryBytes is the total size of the binary files.
+	BinaryBytes int64
+}
+
+// RepositoryBlob is a file version which was introduced by a commit.
+type RepositoryBlob struct {
+	// File is the name of the file.
+	File string
+	// Commit is the hash of the commit which introduced the blob.
+	Commit string
+	// Day is the day of Commit.
+	Day int
+	// Size is the size of the blob in bytes.
+	Size int64
+	// Binary indicates whether the blob is binary.
+	Binary bool
+}
+
+// RepositorySizeResult is returned by RepositorySizeAnalysis.Finalize().
+type RepositorySizeResult struct {
+	// Ticks are the repository sizes at the end of each tick of Sampling days.
+	Ticks []RepositorySizeTick
+	// Largest are the Top largest blobs introduced in each tick. They are sorted by Day
+	// and then by Size in the descending order.
+	Largest []RepositoryBlob
+	// Top is the effective RepositorySizeAnalysis.Top.
+	Top int
+	// Sampling is the effective RepositorySizeAnalysis.Sampling.
+	Sampling int
+}
+
+// repositorySizeFile is what RepositorySizeAnalysis remembers about each existing file.
+type repositorySizeFile struct {
+	size   int64
+	binary bool
+}
+
+const (
+	// ConfigRepositorySizeSampling is the name of the option to set RepositorySizeAnalysis.Sampling.
+	ConfigRepositorySizeSampling = "RepositorySize.Sampling"
+	// DefaultRepositorySizeSampling is the default value of RepositorySizeAnalysis.Sampling.
+	DefaultRepositorySizeSampling = 30
+	// ConfigRepositorySizeTop is the name of the option to set RepositorySizeAnalysis.Top.
+	ConfigRepositorySizeTop = "RepositorySize.Top"
+	// DefaultRepositorySizeTop is the default value of RepositorySizeAnalysis.Top.
+	DefaultRepositorySizeTop = 5
+	// binaryDetectionLimit is the number of leading bytes which are checked for zeros,
+	// the same as in Git.
+	binaryDetectionLimit = 8000
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (analyser *RepositorySizeAnalysis) Name() string {
+	return "RepositorySize"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (analyser *RepositorySizeAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (analyser *RepositorySizeAnalysis) Requires() []string {
+	arr := [...]string{items.DependencyDay, items.DependencyTreeChanges, items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (analyser *RepositorySizeAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigRepositorySizeSampling,
+		Description: "How frequently to record the repository size, in days.",
+		Flag:        "repository-size-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultRepositorySizeSampling}, {
+		Name:        ConfigRepositorySizeTop,
+		Description: "The number of the largest blobs to record in each tick.",
+		Flag:        "repository-size-top",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultRepositorySizeTop},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (analyser *RepositorySizeAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigRepositorySizeSampling].(int); exists {
+		analyser.Sampling = val
+	}
+	if val, exists := facts[ConfigRepositorySizeTop].(int); exists {
+		analyser.Top = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (analyser *RepositorySizeAnalysis) Flag() string {
+	return "repository-size"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (analyser *RepositorySizeAnalysis) Description() string {
+	return "Tracks the total size of the text and the binary files over time and finds " +
+		"the largest blobs introduced in each period."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (analyser *RepositorySizeAnalysis) Initialize(repository *git.Repository) {
+	if analyser.Sampling <= 0 {
+		log.Printf("Warning: adjusted the repository size sampling to %d days\n",
+			DefaultRepositorySizeSampling)
+		analyser.Sampling = DefaultRepositorySizeSampling
+	}
+	if analyser.Top <= 0 {
+		log.Printf("Warning: adjusted the number of the largest blobs to %d\n",
+			DefaultRepositorySizeTop)
+		analyser.Top = DefaultRepositorySizeTop
+	}
+	analyser.files = map[string]repositorySizeFile{}
+	analyser.textBytes = 0
+	analyser.binaryBytes = 0
+	analyser.ticks = nil
+	analyser.largest = nil
+	analyser.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (analyser *RepositorySizeAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !analyser.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	day := deps[items.DependencyDay].(int)
+	tick := day / analyser.Sampling
+	for len(analyser.ticks) <= tick {
+		analyser.ticks = append(analyser.ticks, RepositorySizeTick{
+			TextBytes: analyser.textBytes, BinaryBytes: analyser.binaryBytes})
+		analyser.largest = append(analyser.largest, nil)
+	}
+	for _, change := range changes {
+		if change.From.Name != "" {
+			analyser.removeFile(change.From.Name)
+		}
+		if change.To.Name == "" {
+			continue
+		}
+		blob := cache[change.To.TreeEntry.Hash]
+		binary, err := isBinaryBlob(blob)
+		if err != nil {
+			return nil, err
+		}
+		analyser.files[change.To.Name] = repositorySizeFile{size: blob.Size, binary: binary}
+		if binary {
+			analyser.binaryBytes += blob.Size
+		} else {
+			analyser.textBytes += blob.Size
+		}
+		if change.From.TreeEntry.Hash != change.To.TreeEntry.Hash {
+			analyser.largest[tick] = insertLargestBlob(analyser.largest[tick], RepositoryBlob{
+				File: change.To.Name, Commit: commit.Hash.String(), Day: day,
+				Size: blob.Size, Binary: binary,
+			}, analyser.Top)
+		}
+	}
+	analyser.ticks[tick] = RepositorySizeTick{
+		TextBytes: analyser.textBytes, BinaryBytes: analyser.binaryBytes}
+	return nil, nil
+}
+
+// removeFile subtracts the size of the specified file from the totals.
+func (analyser *RepositorySizeAnalysis) removeFile(name string) {
+	file, exists := analyser.files[name]
+	if !exists {
+		return
+	}
+	if file.binary {
+		analyser.binaryBytes -= file.size
+	} else {
+		analyser.textBytes -= file.size
+	}
+	delete(analyser.files, name)
+}
+
+// isBinaryBlob returns true if there is a zero byte among the first binaryDetectionLimit bytes.
+func isBinaryBlob(blob *object.Blob) (bool, error) {
+	if blob == nil {
+		return false, errors.New("blob is nil: probably not cached")
+	}
+	reader, err := blob.Reader()
+	if err != nil {
+		return false, err
+	}
+	defer reader.Close()
+	buffer := make([]byte, binaryDetectionLimit)
+	n, err := io.ReadFull(reader, buffer)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return false, err
+	}
+	return bytes.IndexByte(buffer[:n], 0) >= 0, nil
+}
+
+// insertLargestBlob adds the blob to the list sorted by size in the descending order
+// and keeps at most `top` elements.
+func insertLargestBlob(largest []RepositoryBlob, blob RepositoryBlob, top int) []RepositoryBlob {
+	index := sort.Search(len(largest), func(i int) bool {
+		return largest[i].Size < blob.Size
+	})
+	if index >= top {
+		return largest
+	}
+	largest = append(largest, RepositoryBlob{})
+	copy(largest[index+1:], largest[index:])
+	largest[index] = blob
+	if len(largest) > top {
+		largest = largest[:top]
+	}
+	return largest
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (analyser *RepositorySizeAnalysis) Finalize() interface{} {
+	result := RepositorySizeResult{
+		Ticks: analyser.ticks, Largest: []RepositoryBlob{},
+		Top: analyser.Top, Sampling: analyser.Sampling,
+	}
+	for _, largest := range analyser.largest {
+		result.Largest = append(result.Largest, largest...)
+	}
+	return result
+}
+
+// Fork clones this pipeline item.
+func (analyser *RepositorySizeAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(analyser, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (analyser *RepositorySizeAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	sizeResult := result.(RepositorySizeResult)
+	if binary {
+		return analyser.serializeBinary(&sizeResult, writer)
+	}
+	analyser.serializeText(&sizeResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (analyser *RepositorySizeAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(analyser, result, &pb.RepositorySizeAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (analyser *RepositorySizeAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(analyser, result, &pb.RepositorySizeAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to RepositorySizeResult.
+func (analyser *RepositorySizeAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.RepositorySizeAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := RepositorySizeResult{
+		Largest:  make([]RepositoryBlob, len(message.Largest)),
+		Top:      int(message.Top),
+		Sampling: int(message.Sampling),
+	}
+	if len(message.Ticks) > 0 {
+		result.Ticks = make([]RepositorySizeTick, len(message.Ticks))
+		for i, tick := range message.Ticks {
+			result.Ticks[i] = RepositorySizeTick{TextBytes: tick.TextBytes, BinaryBytes: tick.BinaryBytes}
+		}
+	}
+	for i, blob := range message.Largest {
+		result.Largest[i] = RepositoryBlob{
+			File:   blob.File,
+			Commit: blob.Commit,
+			Day:    int(blob.Day),
+			Size:   blob.Size,
+			Binary: blob.Binary,
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two RepositorySizeResult-s together. The ticks are regrouped
+// by the larger sampling and the sizes are summed: each repository keeps its last known
+// size after its history ends.
+func (analyser *RepositorySizeAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	sr1 := r1.(RepositorySizeResult)
+	sr2 := r2.(RepositorySizeResult)
+	merged := RepositorySizeResult{Sampling: sr1.Sampling, Top: sr1.Top}
+	if sr2.Sampling > merged.Sampling {
+		merged.Sampling = sr2.Sampling
+	}
+	if sr2.Top > merged.Top {
+		merged.Top = sr2.Top
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	var largest [][]RepositoryBlob
+	regroup := func(result *RepositorySizeResult, c *core.CommonAnalysisResult) []RepositorySizeTick {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		var snapshots []RepositorySizeTick
+		for i, tick := range result.Ticks {
+			index := (i*result.Sampling + offset) / merged.Sampling
+			for len(snapshots) <= index {
+				snapshots = append(snapshots, RepositorySizeTick{})
+			}
+			snapshots[index] = tick
+		}
+		for _, blob := range result.Largest {
+			blob.Day += offset
+			index := blob.Day / merged.Sampling
+			for len(largest) <= index {
+				largest = append(largest, nil)
+			}
+			largest[index] = insertLargestBlob(largest[index], blob, merged.Top)
+		}
+		return snapshots
+	}
+	snapshots := [...][]RepositorySizeTick{regroup(&sr1, c1), regroup(&sr2, c2)}
+	size := len(snapshots[0])
+	if len(snapshots[1]) > size {
+		size = len(snapshots[1])
+	}
+	for i := 0; i < size; i++ {
+		tick := RepositorySizeTick{}
+		for _, ticks := range snapshots {
+			if len(ticks) == 0 {
+				continue
+			}
+			last := ticks[len(ticks)-1]
+			if i < len(ticks) {
+				last = ticks[i]
+			}
+			tick.TextBytes += last.TextBytes
+			tick.BinaryBytes += last.BinaryBytes
+		}
+		merged.Ticks = append(merged.Ticks, tick)
+	}
+	merged.Largest = []RepositoryBlob{}
+	for _, blobs := range largest {
+		merged.Largest = append(merged.Largest, blobs...)
+	}
+	return merged
+}
+
+func (analyser *RepositorySizeAnalysis) serializeText(result *RepositorySizeResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  top:", result.Top)
+	fmt.Fprintln(writer, "  ticks:")
+	for _, tick := range result.Ticks {
+		fmt.Fprintf(writer, "    - {text_bytes: %d, binary_bytes: %d}\n",
+			tick.TextBytes, tick.BinaryBytes)
+	}
+	fmt.Fprintln(writer, "  largest:")
+	for _, blob := range result.Largest {
+		fmt.Fprintf(writer, "    - file: %s\n", yaml.SafeString(blob.File))
+		fmt.Fprintf(writer, "      commit: %s\n", blob.Commit)
+		fmt.Fprintf(writer, "      day: %d\n", blob.Day)
+		fmt.Fprintf(writer, "      size: %d\n", blob.Size)
+		fmt.Fprintf(writer, "      binary: %t\n", blob.Binary)
+	}
+}
+
+func (analyser *RepositorySizeAnalysis) serializeBinary(
+	result *RepositorySizeResult, writer io.Writer) error {
+	message := pb.RepositorySizeAnalysisResults{
+		Ticks:    make([]*pb.RepositorySizeTick, len(result.Ticks)),
+		Largest:  make([]*pb.RepositoryBlob, len(result.Largest)),
+		Top:      int32(result.Top),
+		Sampling: int32(result.Sampling),
+	}
+	for i, tick := range result.Ticks {
+		message.Ticks[i] = &pb.RepositorySizeTick{
+			TextBytes: tick.TextBytes, BinaryBytes: tick.BinaryBytes}
+	}
+	for i, blob := range result.Largest {
+		message.Largest[i] = &pb.RepositoryBlob{
+			File:   blob.File,
+			Commit: blob.Commit,
+			Day:    int32(blob.Day),
+			Size:   blob.Size,
+			Binary: blob.Binary,
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&RepositorySizeAnalysis{})
+}
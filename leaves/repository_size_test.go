@@ -0,0 +1,203 @@
+package leaves
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func fixtureRepositorySize() *RepositorySizeAnalysis {
+	analyser := RepositorySizeAnalysis{}
+	analyser.Configure(map[string]interface{}{
+		ConfigRepositorySizeSampling: 10,
+		ConfigRepositorySizeTop:      2,
+	})
+	analyser.Initialize(nil)
+	return &analyser
+}
+
+func TestRepositorySizeMeta(t *testing.T) {
+	analyser := fixtureRepositorySize()
+	assert.Equal(t, analyser.Name(), "RepositorySize")
+	assert.Len(t, analyser.Provides(), 0)
+	assert.Equal(t, analyser.Requires(), []string{
+		items.DependencyDay, items.DependencyTreeChanges, items.DependencyBlobCache})
+	assert.Equal(t, analyser.Flag(), "repository-size")
+	assert.NotEmpty(t, analyser.Description())
+	opts := analyser.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Flag, "repository-size-sampling")
+	assert.Equal(t, opts[1].Flag, "repository-size-top")
+	assert.Equal(t, analyser.Sampling, 10)
+	assert.Equal(t, analyser.Top, 2)
+	analyser = &RepositorySizeAnalysis{}
+	analyser.Initialize(nil)
+	assert.Equal(t, analyser.Sampling, DefaultRepositorySizeSampling)
+	assert.Equal(t, analyser.Top, DefaultRepositorySizeTop)
+	summoned := core.Registry.Summon(analyser.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "RepositorySize")
+}
+
+func TestIsBinaryBlob(t *testing.T) {
+	_, blob := storeExpertiseBlob(t, "text\n")
+	binary, err := isBinaryBlob(blob)
+	assert.Nil(t, err)
+	assert.False(t, binary)
+	_, blob = storeExpertiseBlob(t, "PNG\x00\x01")
+	binary, err = isBinaryBlob(blob)
+	assert.Nil(t, err)
+	assert.True(t, binary)
+	// the zero is too far
+	_, blob = storeExpertiseBlob(t, strings.Repeat("a", binaryDetectionLimit)+"\x00")
+	binary, err = isBinaryBlob(blob)
+	assert.Nil(t, err)
+	assert.False(t, binary)
+	_, err = isBinaryBlob(nil)
+	assert.NotNil(t, err)
+}
+
+func TestInsertLargestBlob(t *testing.T) {
+	var largest []RepositoryBlob
+	largest = insertLargestBlob(largest, RepositoryBlob{File: "a", Size: 10}, 2)
+	largest = insertLargestBlob(largest, RepositoryBlob{File: "b", Size: 20}, 2)
+	largest = insertLargestBlob(largest, RepositoryBlob{File: "c", Size: 5}, 2)
+	largest = insertLargestBlob(largest, RepositoryBlob{File: "d", Size: 10}, 2)
+	assert.Equal(t, largest, []RepositoryBlob{{File: "b", Size: 20}, {File: "a", Size: 10}})
+}
+
+func fixtureRepositorySizeResult(t *testing.T) RepositorySizeResult {
+	hashText, blobText := storeExpertiseBlob(t, "0123456789")
+	hashSmall, blobSmall := storeExpertiseBlob(t, "012")
+	hashImage, blobImage := storeExpertiseBlob(t, "PNG\x00"+strings.Repeat("x", 96))
+	cache := map[plumbing.Hash]*object.Blob{
+		hashText: blobText, hashSmall: blobSmall, hashImage: blobImage}
+	entry := func(name string, hash plumbing.Hash) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	analyser := fixtureRepositorySize()
+	consume := func(hash string, day int, merge bool, changes ...*object.Change) {
+		commit := &object.Commit{Hash: plumbing.NewHash(strings.Repeat(hash, 40))}
+		if merge {
+			commit.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		result, err := analyser.Consume(map[string]interface{}{
+			core.DependencyCommit:       commit,
+			core.DependencyIsMerge:      merge,
+			items.DependencyDay:         day,
+			items.DependencyTreeChanges: object.Changes(changes),
+			items.DependencyBlobCache:   cache,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	consume("1", 0, false, &object.Change{To: entry("a.go", hashText)},
+		&object.Change{To: entry("b.go", hashSmall)})
+	consume("2", 3, false, &object.Change{To: entry("logo.png", hashImage)},
+		&object.Change{To: entry("c.go", hashSmall)})
+	// the tick without commits keeps the size
+	consume("3", 25, false, &object.Change{From: entry("logo.png", hashImage)},
+		&object.Change{From: entry("b.go", hashSmall), To: entry("d.go", hashSmall)},
+		&object.Change{From: entry("a.go", hashText), To: entry("a.go", hashSmall)})
+	consume("4", 26, true, &object.Change{To: entry("e.png", hashImage)})
+	return analyser.Finalize().(RepositorySizeResult)
+}
+
+func TestRepositorySizeConsumeFinalize(t *testing.T) {
+	result := fixtureRepositorySizeResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Top, 2)
+	assert.Equal(t, result.Ticks, []RepositorySizeTick{
+		{TextBytes: 16, BinaryBytes: 100},
+		{TextBytes: 16, BinaryBytes: 100},
+		{TextBytes: 9, BinaryBytes: 0},
+	})
+	assert.Equal(t, result.Largest, []RepositoryBlob{
+		{File: "logo.png", Commit: strings.Repeat("2", 40), Day: 3, Size: 100, Binary: true},
+		{File: "a.go", Commit: strings.Repeat("1", 40), Day: 0, Size: 10},
+		{File: "a.go", Commit: strings.Repeat("3", 40), Day: 25, Size: 3},
+	})
+}
+
+func TestRepositorySizeSerialize(t *testing.T) {
+	result := fixtureRepositorySizeResult(t)
+	analyser := fixtureRepositorySize()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  top: 2
+  ticks:
+    - {text_bytes: 16, binary_bytes: 100}
+    - {text_bytes: 16, binary_bytes: 100}
+    - {text_bytes: 9, binary_bytes: 0}
+  largest:
+    - file: "logo.png"
+      commit: 2222222222222222222222222222222222222222
+      day: 3
+      size: 100
+      binary: true
+    - file: "a.go"
+      commit: 1111111111111111111111111111111111111111
+      day: 0
+      size: 10
+      binary: false
+    - file: "a.go"
+      commit: 3333333333333333333333333333333333333333
+      day: 25
+      size: 3
+      binary: false
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, true, buffer))
+	msg := pb.RepositorySizeAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Equal(t, msg.Top, int32(2))
+	assert.Len(t, msg.Ticks, 3)
+	assert.Equal(t, msg.Ticks[0].BinaryBytes, int64(100))
+	assert.Len(t, msg.Largest, 3)
+	assert.True(t, msg.Largest[0].Binary)
+	deserialized, err := analyser.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestRepositorySizeMergeResults(t *testing.T) {
+	r1 := RepositorySizeResult{
+		Ticks: []RepositorySizeTick{{TextBytes: 10, BinaryBytes: 0}, {TextBytes: 20, BinaryBytes: 5}},
+		Largest: []RepositoryBlob{
+			{File: "a", Day: 1, Size: 10}, {File: "b", Day: 15, Size: 5, Binary: true}},
+		Top:      1,
+		Sampling: 10,
+	}
+	r2 := RepositorySizeResult{
+		Ticks:    []RepositorySizeTick{{TextBytes: 100, BinaryBytes: 50}, {TextBytes: 200, BinaryBytes: 50}},
+		Largest:  []RepositoryBlob{{File: "c", Day: 0, Size: 50}, {File: "d", Day: 25, Size: 1}},
+		Top:      2,
+		Sampling: 20,
+	}
+	analyser := fixtureRepositorySize()
+	merged := analyser.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 20 * 24 * 3600}).(RepositorySizeResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.Top, 2)
+	assert.Equal(t, merged.Ticks, []RepositorySizeTick{
+		{TextBytes: 20, BinaryBytes: 5},
+		{TextBytes: 120, BinaryBytes: 55},
+		{TextBytes: 220, BinaryBytes: 55},
+	})
+	assert.Equal(t, merged.Largest, []RepositoryBlob{
+		{File: "a", Day: 1, Size: 10}, {File: "b", Day: 15, Size: 5, Binary: true},
+		{File: "c", Day: 20, Size: 50},
+		{File: "d", Day: 45, Size: 1},
+	})
+}
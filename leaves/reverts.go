@@ -0,0 +1,439 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// RevertsAnalysis detects the reverted commits. A commit is a revert if its message follows
+// `git revert` ("Revert "..."" and "This reverts commit <hash>.") or if it exactly undoes
+// the changes of an earlier commit: the same files go back to the same blobs. It reports
+// the share of the reverts in each tick of Sampling days, how fast the commits are reverted
+// and which files the reverted commits touch. The merge commits are ignored.
+type RevertsAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the number of days in a tick.
+	Sampling int
+
+	// ticks are the numbers of commits and reverts in each tick.
+	ticks []RevertsTick
+	// commits are the analysed commits which can be reverted.
+	commits map[plumbing.Hash]*revertCandidate
+	// patches map the patch signatures to the commits which were the last to apply them.
+	patches map[string]plumbing.Hash
+	// reverted are the reverted commits found so far.
+	reverted []RevertedCommit
+	// files map the file names to the numbers of times they were reverted.
+	files map[string]int
+}
+
+// RevertsTick is the numbers of commits and reverts in a period.
+type RevertsTick struct {
+	// Commits is the number of analysed commits.
+	Commits int
+	// Reverts is the number of reverts among Commits.
+	Reverts int
+}
+
+// RevertedCommit is a commit which was reverted later.
+type RevertedCommit struct {
+	// Hash is the reverted commit.
+	Hash string
+	// Revert is the commit which reverted Hash.
+	Revert string
+	// Day is the day of the reverted commit.
+	Day int
+	// Hours is the number of hours between the reverted commit and the revert.
+	Hours int
+}
+
+// RevertsResult is returned by RevertsAnalysis.Finalize().
+type RevertsResult struct {
+	// Ticks are the numbers of commits and reverts in each tick of Sampling days.
+	Ticks []RevertsTick
+	// Reverted are sorted by Hours, the fastest reverts go first.
+	Reverted []RevertedCommit
+	// Files map the file names to the numbers of reverted commits which changed them.
+	Files map[string]int
+	// Sampling is the effective RevertsAnalysis.Sampling.
+	Sampling int
+}
+
+// revertCandidate is what RevertsAnalysis remembers about each analysed commit.
+type revertCandidate struct {
+	when      int64
+	day       int
+	files     []string
+	signature string
+}
+
+const (
+	// ConfigRevertsSampling is the name of the option to set RevertsAnalysis.Sampling.
+	ConfigRevertsSampling = "Reverts.Sampling"
+	// DefaultRevertsSampling is the default value of RevertsAnalysis.Sampling.
+	DefaultRevertsSampling = 30
+)
+
+var (
+	revertSubjectRegexp = regexp.MustCompile(`^Revert "`)
+	revertHashRegexp    = regexp.MustCompile(`This reverts commit ([0-9a-f]{7,40})`)
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (reverts *RevertsAnalysis) Name() string {
+	return "Reverts"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (reverts *RevertsAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (reverts *RevertsAnalysis) Requires() []string {
+	arr := [...]string{items.DependencyDay, items.DependencyTreeChanges}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (reverts *RevertsAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigRevertsSampling,
+		Description: "How frequently to record the revert rate, in days.",
+		Flag:        "reverts-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultRevertsSampling},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (reverts *RevertsAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigRevertsSampling].(int); exists {
+		reverts.Sampling = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (reverts *RevertsAnalysis) Flag() string {
+	return "reverts"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (reverts *RevertsAnalysis) Description() string {
+	return "Detects the reverts by the commit messages and by the inverse changes and reports " +
+		"the revert rate over time, the time to revert and the most revert-prone files."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (reverts *RevertsAnalysis) Initialize(repository *git.Repository) {
+	if reverts.Sampling <= 0 {
+		log.Printf("Warning: adjusted the reverts sampling to %d days\n", DefaultRevertsSampling)
+		reverts.Sampling = DefaultRevertsSampling
+	}
+	reverts.ticks = nil
+	reverts.commits = map[plumbing.Hash]*revertCandidate{}
+	reverts.patches = map[string]plumbing.Hash{}
+	reverts.reverted = nil
+	reverts.files = map[string]int{}
+	reverts.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (reverts *RevertsAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !reverts.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	day := deps[items.DependencyDay].(int)
+	for len(reverts.ticks) <= day/reverts.Sampling {
+		reverts.ticks = append(reverts.ticks, RevertsTick{})
+	}
+	tick := &reverts.ticks[day/reverts.Sampling]
+	tick.Commits++
+
+	isRevert := revertSubjectRegexp.MatchString(commit.Message)
+	target := plumbing.ZeroHash
+	if match := revertHashRegexp.FindStringSubmatch(commit.Message); match != nil {
+		isRevert = true
+		target = reverts.findCommit(match[1])
+	}
+	if target == plumbing.ZeroHash {
+		if hash, exists := reverts.patches[revertPatchSignature(changes, true)]; exists {
+			isRevert = true
+			target = hash
+		}
+	}
+	if isRevert {
+		tick.Reverts++
+	}
+	when := commit.Committer.When.Unix()
+	if candidate := reverts.commits[target]; candidate != nil {
+		hours := 0
+		if when > candidate.when {
+			hours = int((when - candidate.when) / 3600)
+		}
+		reverts.reverted = append(reverts.reverted, RevertedCommit{
+			Hash: target.String(), Revert: commit.Hash.String(), Day: candidate.day, Hours: hours,
+		})
+		for _, file := range candidate.files {
+			reverts.files[file]++
+		}
+		delete(reverts.commits, target)
+		if reverts.patches[candidate.signature] == target {
+			delete(reverts.patches, candidate.signature)
+		}
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	candidate := &revertCandidate{
+		when: when, day: day, files: make([]string, len(changes)),
+		signature: revertPatchSignature(changes, false),
+	}
+	for i, change := range changes {
+		candidate.files[i] = change.To.Name
+		if candidate.files[i] == "" {
+			candidate.files[i] = change.From.Name
+		}
+	}
+	reverts.commits[commit.Hash] = candidate
+	reverts.patches[candidate.signature] = commit.Hash
+	return nil, nil
+}
+
+// findCommit returns the analysed commit with the specified full or abbreviated hash
+// or plumbing.ZeroHash.
+func (reverts *RevertsAnalysis) findCommit(hash string) plumbing.Hash {
+	if len(hash) == 40 {
+		return plumbing.NewHash(hash)
+	}
+	for candidate := range reverts.commits {
+		if strings.HasPrefix(candidate.String(), hash) {
+			return candidate
+		}
+	}
+	return plumbing.ZeroHash
+}
+
+// revertPatchSignature identifies the changes by the blobs before and after each change.
+// `inverse` swaps the sides so that the signature of a revert matches the reverted commit.
+func revertPatchSignature(changes object.Changes, inverse bool) string {
+	lines := make([]string, len(changes))
+	for i, change := range changes {
+		from, to := change.From, change.To
+		if inverse {
+			from, to = to, from
+		}
+		lines[i] = fmt.Sprintf("%s %s %s %s",
+			from.Name, from.TreeEntry.Hash, to.Name, to.TreeEntry.Hash)
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+// Rate returns the share of the reverts among the commits.
+func (tick RevertsTick) Rate() float64 {
+	if tick.Commits == 0 {
+		return 0
+	}
+	return float64(tick.Reverts) / float64(tick.Commits)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (reverts *RevertsAnalysis) Finalize() interface{} {
+	return newRevertsResult(reverts.ticks, reverts.reverted, reverts.files, reverts.Sampling)
+}
+
+func newRevertsResult(ticks []RevertsTick, reverted []RevertedCommit, files map[string]int,
+	sampling int) RevertsResult {
+	sort.SliceStable(reverted, func(i, j int) bool {
+		if reverted[i].Hours != reverted[j].Hours {
+			return reverted[i].Hours < reverted[j].Hours
+		}
+		return reverted[i].Hash < reverted[j].Hash
+	})
+	return RevertsResult{Ticks: ticks, Reverted: reverted, Files: files, Sampling: sampling}
+}
+
+// Fork clones this pipeline item.
+func (reverts *RevertsAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(reverts, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (reverts *RevertsAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	revertsResult := result.(RevertsResult)
+	if binary {
+		return reverts.serializeBinary(&revertsResult, writer)
+	}
+	reverts.serializeText(&revertsResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (reverts *RevertsAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(reverts, result, &pb.RevertsAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (reverts *RevertsAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(reverts, result, &pb.RevertsAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to RevertsResult.
+func (reverts *RevertsAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.RevertsAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := RevertsResult{Files: map[string]int{}, Sampling: int(message.Sampling)}
+	if len(message.Ticks) > 0 {
+		result.Ticks = make([]RevertsTick, len(message.Ticks))
+		for i, tick := range message.Ticks {
+			result.Ticks[i] = RevertsTick{Commits: int(tick.Commits), Reverts: int(tick.Reverts)}
+		}
+	}
+	if len(message.Reverted) > 0 {
+		result.Reverted = make([]RevertedCommit, len(message.Reverted))
+		for i, reverted := range message.Reverted {
+			result.Reverted[i] = RevertedCommit{
+				Hash:   reverted.Hash,
+				Revert: reverted.Revert,
+				Day:    int(reverted.Day),
+				Hours:  int(reverted.Hours),
+			}
+		}
+	}
+	for file, val := range message.Files {
+		result.Files[file] = int(val)
+	}
+	return result, nil
+}
+
+// MergeResults combines two RevertsResult-s together. The ticks are regrouped
+// by the larger sampling.
+func (reverts *RevertsAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	rr1 := r1.(RevertsResult)
+	rr2 := r2.(RevertsResult)
+	sampling := rr1.Sampling
+	if rr2.Sampling > sampling {
+		sampling = rr2.Sampling
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	var ticks []RevertsTick
+	var reverted []RevertedCommit
+	files := map[string]int{}
+	add := func(result *RevertsResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for i, tick := range result.Ticks {
+			index := (i*result.Sampling + offset) / sampling
+			for len(ticks) <= index {
+				ticks = append(ticks, RevertsTick{})
+			}
+			ticks[index].Commits += tick.Commits
+			ticks[index].Reverts += tick.Reverts
+		}
+		for _, commit := range result.Reverted {
+			commit.Day += offset
+			reverted = append(reverted, commit)
+		}
+		for file, val := range result.Files {
+			files[file] += val
+		}
+	}
+	add(&rr1, c1)
+	add(&rr2, c2)
+	return newRevertsResult(ticks, reverted, files, sampling)
+}
+
+func (reverts *RevertsAnalysis) serializeText(result *RevertsResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  ticks:")
+	for _, tick := range result.Ticks {
+		fmt.Fprintf(writer, "    - {commits: %d, reverts: %d, rate: %.4f}\n",
+			tick.Commits, tick.Reverts, tick.Rate())
+	}
+	fmt.Fprintln(writer, "  reverted:")
+	for _, commit := range result.Reverted {
+		fmt.Fprintf(writer, "    - {hash: %s, revert: %s, day: %d, hours: %d}\n",
+			commit.Hash, commit.Revert, commit.Day, commit.Hours)
+	}
+	files := make([]string, 0, len(result.Files))
+	for file := range result.Files {
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if result.Files[files[i]] != result.Files[files[j]] {
+			return result.Files[files[i]] > result.Files[files[j]]
+		}
+		return files[i] < files[j]
+	})
+	fmt.Fprintln(writer, "  files:")
+	for _, file := range files {
+		fmt.Fprintf(writer, "    %s: %d\n", yaml.SafeString(file), result.Files[file])
+	}
+}
+
+func (reverts *RevertsAnalysis) serializeBinary(result *RevertsResult, writer io.Writer) error {
+	message := pb.RevertsAnalysisResults{
+		Ticks:    make([]*pb.RevertsTick, len(result.Ticks)),
+		Reverted: make([]*pb.RevertedCommit, len(result.Reverted)),
+		Files:    map[string]int32{},
+		Sampling: int32(result.Sampling),
+	}
+	for i, tick := range result.Ticks {
+		message.Ticks[i] = &pb.RevertsTick{Commits: int32(tick.Commits), Reverts: int32(tick.Reverts)}
+	}
+	for i, commit := range result.Reverted {
+		message.Reverted[i] = &pb.RevertedCommit{
+			Hash:   commit.Hash,
+			Revert: commit.Revert,
+			Day:    int32(commit.Day),
+			Hours:  int32(commit.Hours),
+		}
+	}
+	for file, val := range result.Files {
+		message.Files[file] = int32(val)
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&RevertsAnalysis{})
+}
@@ -0,0 +1,173 @@
+package leaves
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func fixtureReverts() *RevertsAnalysis {
+	reverts := RevertsAnalysis{}
+	reverts.Configure(map[string]interface{}{ConfigRevertsSampling: 10})
+	reverts.Initialize(nil)
+	return &reverts
+}
+
+func TestRevertsMeta(t *testing.T) {
+	reverts := fixtureReverts()
+	assert.Equal(t, reverts.Name(), "Reverts")
+	assert.Len(t, reverts.Provides(), 0)
+	assert.Equal(t, reverts.Requires(), []string{items.DependencyDay, items.DependencyTreeChanges})
+	assert.Equal(t, reverts.Flag(), "reverts")
+	assert.NotEmpty(t, reverts.Description())
+	opts := reverts.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Flag, "reverts-sampling")
+	assert.Equal(t, reverts.Sampling, 10)
+	reverts = &RevertsAnalysis{}
+	reverts.Initialize(nil)
+	assert.Equal(t, reverts.Sampling, DefaultRevertsSampling)
+	summoned := core.Registry.Summon(reverts.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Reverts")
+}
+
+func TestRevertPatchSignature(t *testing.T) {
+	h1 := plumbing.NewHash("1111111111111111111111111111111111111111")
+	h2 := plumbing.NewHash("2222222222222222222222222222222222222222")
+	forward := object.Changes{
+		{From: object.ChangeEntry{Name: "b", TreeEntry: object.TreeEntry{Hash: h1}},
+			To: object.ChangeEntry{Name: "b", TreeEntry: object.TreeEntry{Hash: h2}}},
+		{To: object.ChangeEntry{Name: "a", TreeEntry: object.TreeEntry{Hash: h1}}},
+	}
+	backward := object.Changes{
+		{From: object.ChangeEntry{Name: "a", TreeEntry: object.TreeEntry{Hash: h1}}},
+		{From: object.ChangeEntry{Name: "b", TreeEntry: object.TreeEntry{Hash: h2}},
+			To: object.ChangeEntry{Name: "b", TreeEntry: object.TreeEntry{Hash: h1}}},
+	}
+	assert.Equal(t, revertPatchSignature(backward, true), revertPatchSignature(forward, false))
+	assert.NotEqual(t, revertPatchSignature(backward, false), revertPatchSignature(forward, false))
+}
+
+func fixtureRevertsResult(t *testing.T) RevertsResult {
+	blob := func(digit string) plumbing.Hash {
+		return plumbing.NewHash(strings.Repeat(digit, 40))
+	}
+	change := func(name string, from, to plumbing.Hash) *object.Change {
+		result := &object.Change{}
+		if from != plumbing.ZeroHash {
+			result.From = object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: from}}
+		}
+		if to != plumbing.ZeroHash {
+			result.To = object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: to}}
+		}
+		return result
+	}
+	reverts := fixtureReverts()
+	consume := func(hash string, hours int, merge bool, message string, changes ...*object.Change) {
+		commit := &object.Commit{
+			Hash:      plumbing.NewHash(strings.Repeat(hash, 40)),
+			Committer: object.Signature{When: time.Unix(int64(hours)*3600, 0)},
+			Message:   message,
+		}
+		if merge {
+			commit.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		result, err := reverts.Consume(map[string]interface{}{
+			core.DependencyCommit:       commit,
+			core.DependencyIsMerge:      merge,
+			items.DependencyDay:         hours / 24,
+			items.DependencyTreeChanges: object.Changes(changes),
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	consume("1", 0, false, "add a", change("a.go", plumbing.ZeroHash, blob("a")))
+	consume("2", 24, false, "feature",
+		change("a.go", blob("a"), blob("b")), change("b.go", plumbing.ZeroHash, blob("c")))
+	// undoes "2" without the standard message
+	consume("3", 29, false, "undo",
+		change("b.go", blob("c"), plumbing.ZeroHash), change("a.go", blob("b"), blob("a")))
+	consume("4", 12*24, false, "Revert \"add a\"\n\nThis reverts commit 1111111111.\n",
+		change("a.go", blob("a"), plumbing.ZeroHash))
+	// the reverted commit is unknown
+	consume("5", 13*24, false, "Revert \"something\"\n", change("c.go", blob("d"), blob("e")))
+	consume("6", 13*24, true, "Revert \"merge\"\n", change("d.go", blob("d"), blob("e")))
+	return reverts.Finalize().(RevertsResult)
+}
+
+func TestRevertsConsumeFinalize(t *testing.T) {
+	result := fixtureRevertsResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Ticks, []RevertsTick{{Commits: 3, Reverts: 1}, {Commits: 2, Reverts: 2}})
+	assert.Equal(t, result.Ticks[0].Rate(), 1.0/3)
+	assert.Equal(t, RevertsTick{}.Rate(), float64(0))
+	assert.Equal(t, result.Reverted, []RevertedCommit{
+		{Hash: strings.Repeat("2", 40), Revert: strings.Repeat("3", 40), Day: 1, Hours: 5},
+		{Hash: strings.Repeat("1", 40), Revert: strings.Repeat("4", 40), Day: 0, Hours: 288},
+	})
+	assert.Equal(t, result.Files, map[string]int{"a.go": 2, "b.go": 1})
+}
+
+func TestRevertsSerialize(t *testing.T) {
+	result := fixtureRevertsResult(t)
+	reverts := fixtureReverts()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, reverts.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  ticks:
+    - {commits: 3, reverts: 1, rate: 0.3333}
+    - {commits: 2, reverts: 2, rate: 1.0000}
+  reverted:
+    - {hash: 2222222222222222222222222222222222222222, revert: 3333333333333333333333333333333333333333, day: 1, hours: 5}
+    - {hash: 1111111111111111111111111111111111111111, revert: 4444444444444444444444444444444444444444, day: 0, hours: 288}
+  files:
+    "a.go": 2
+    "b.go": 1
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, reverts.Serialize(result, true, buffer))
+	msg := pb.RevertsAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Len(t, msg.Ticks, 2)
+	assert.Len(t, msg.Reverted, 2)
+	assert.Equal(t, msg.Files, map[string]int32{"a.go": 2, "b.go": 1})
+	deserialized, err := reverts.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestRevertsMergeResults(t *testing.T) {
+	r1 := RevertsResult{
+		Ticks:    []RevertsTick{{Commits: 4, Reverts: 1}, {Commits: 2, Reverts: 0}},
+		Reverted: []RevertedCommit{{Hash: "a", Revert: "b", Day: 3, Hours: 10}},
+		Files:    map[string]int{"a.go": 1},
+		Sampling: 10,
+	}
+	r2 := RevertsResult{
+		Ticks:    []RevertsTick{{Commits: 5, Reverts: 2}},
+		Reverted: []RevertedCommit{{Hash: "c", Revert: "d", Day: 1, Hours: 2}},
+		Files:    map[string]int{"a.go": 2, "b.go": 1},
+		Sampling: 20,
+	}
+	reverts := fixtureReverts()
+	merged := reverts.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 10 * 24 * 3600}).(RevertsResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.Ticks, []RevertsTick{{Commits: 11, Reverts: 3}})
+	assert.Equal(t, merged.Reverted, []RevertedCommit{
+		{Hash: "c", Revert: "d", Day: 11, Hours: 2},
+		{Hash: "a", Revert: "b", Day: 3, Hours: 10}})
+	assert.Equal(t, merged.Files, map[string]int{"a.go": 3, "b.go": 1})
+}
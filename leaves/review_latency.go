@@ -0,0 +1,416 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// ReviewLatencyAnalysis measures the time between the author date and the committer date of
+// each commit. In the patch-based and the rebase workflows the commits are authored by
+// the contributors and committed when they are applied or rebased onto the main branch,
+// so the difference is a proxy for the review and integration latency. The distributions
+// are reported in each tick of Sampling days and for each developer. The merge commits
+// are skipped because they are authored and committed at the same time.
+type ReviewLatencyAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the number of days in a tick.
+	Sampling int
+
+	// ticks are the distributions of each tick.
+	ticks []ReviewLatencyStats
+	// people are the distributions of each developer; -1 means an unmatched identity.
+	people map[int]ReviewLatencyStats
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// ReviewLatencyStats is the distribution of the latencies of a group of commits.
+type ReviewLatencyStats struct {
+	// Commits is the number of analysed commits.
+	Commits int
+	// Histogram is the number of commits in each bucket of ReviewLatencyBuckets;
+	// the last element counts the latencies which exceed the largest bound.
+	Histogram []int
+	// Total is the sum of the latencies in seconds.
+	Total int64
+	// Max is the largest latency in seconds.
+	Max int64
+}
+
+// ReviewLatencyResult is returned by ReviewLatencyAnalysis.Finalize().
+type ReviewLatencyResult struct {
+	// Ticks are the distributions in each tick of Sampling days.
+	Ticks []ReviewLatencyStats
+	// People are the distributions of each developer; -1 means an unmatched identity.
+	People map[int]ReviewLatencyStats
+	// Sampling is the effective ReviewLatencyAnalysis.Sampling.
+	Sampling int
+	// Buckets are the upper bounds of the histogram buckets, see ReviewLatencyBuckets.
+	Buckets []int64
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigReviewLatencySampling is the name of the option to set ReviewLatencyAnalysis.Sampling.
+	ConfigReviewLatencySampling = "ReviewLatency.Sampling"
+	// DefaultReviewLatencySampling is the default value of ReviewLatencyAnalysis.Sampling.
+	DefaultReviewLatencySampling = 30
+)
+
+// ReviewLatencyBuckets are the exclusive upper bounds of the latency histogram buckets
+// in seconds: a minute, an hour, a day, a week and 30 days. The commits which were committed
+// directly by their authors fall into the first bucket.
+var ReviewLatencyBuckets = []int64{60, 3600, 24 * 3600, 7 * 24 * 3600, 30 * 24 * 3600}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (latency *ReviewLatencyAnalysis) Name() string {
+	return "ReviewLatency"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (latency *ReviewLatencyAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (latency *ReviewLatencyAnalysis) Requires() []string {
+	arr := [...]string{identity.DependencyAuthor, items.DependencyDay}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (latency *ReviewLatencyAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigReviewLatencySampling,
+		Description: "How frequently to record the review latency distributions, in days.",
+		Flag:        "review-latency-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultReviewLatencySampling},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (latency *ReviewLatencyAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigReviewLatencySampling].(int); exists {
+		latency.Sampling = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		latency.reversedPeopleDict = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (latency *ReviewLatencyAnalysis) Flag() string {
+	return "review-latency"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (latency *ReviewLatencyAnalysis) Description() string {
+	return "Measures the time between the author and the committer dates of the commits " +
+		"as a proxy for the review and integration latency, over time and per developer."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (latency *ReviewLatencyAnalysis) Initialize(repository *git.Repository) {
+	if latency.Sampling <= 0 {
+		log.Printf("Warning: adjusted the review latency sampling to %d days\n",
+			DefaultReviewLatencySampling)
+		latency.Sampling = DefaultReviewLatencySampling
+	}
+	latency.ticks = nil
+	latency.people = map[int]ReviewLatencyStats{}
+	latency.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (latency *ReviewLatencyAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !latency.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	seconds := commitLatency(commit)
+	tick := deps[items.DependencyDay].(int) / latency.Sampling
+	for len(latency.ticks) <= tick {
+		latency.ticks = append(latency.ticks, ReviewLatencyStats{})
+	}
+	latency.ticks[tick].add(seconds)
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing {
+		author = -1
+	}
+	stats := latency.people[author]
+	stats.add(seconds)
+	latency.people[author] = stats
+	return nil, nil
+}
+
+// commitLatency returns the number of seconds between the author and the committer dates.
+// The commits with the committer date before the author date, e.g. because of the skewed
+// clocks, have zero latency.
+func commitLatency(commit *object.Commit) int64 {
+	seconds := commit.Committer.When.Unix() - commit.Author.When.Unix()
+	if seconds < 0 {
+		return 0
+	}
+	return seconds
+}
+
+// add records a single latency in seconds.
+func (stats *ReviewLatencyStats) add(seconds int64) {
+	if stats.Histogram == nil {
+		stats.Histogram = make([]int, len(ReviewLatencyBuckets)+1)
+	}
+	bucket := sort.Search(len(ReviewLatencyBuckets), func(i int) bool {
+		return seconds < ReviewLatencyBuckets[i]
+	})
+	stats.Histogram[bucket]++
+	stats.Commits++
+	stats.Total += seconds
+	if seconds > stats.Max {
+		stats.Max = seconds
+	}
+}
+
+// merge adds the other distribution to this one.
+func (stats *ReviewLatencyStats) merge(other ReviewLatencyStats) {
+	for len(stats.Histogram) < len(other.Histogram) {
+		stats.Histogram = append(stats.Histogram, 0)
+	}
+	for i, val := range other.Histogram {
+		stats.Histogram[i] += val
+	}
+	stats.Commits += other.Commits
+	stats.Total += other.Total
+	if other.Max > stats.Max {
+		stats.Max = other.Max
+	}
+}
+
+// MeanHours returns the average latency in hours.
+func (stats ReviewLatencyStats) MeanHours() float64 {
+	if stats.Commits == 0 {
+		return 0
+	}
+	return float64(stats.Total) / float64(stats.Commits) / 3600
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (latency *ReviewLatencyAnalysis) Finalize() interface{} {
+	return ReviewLatencyResult{
+		Ticks:              latency.ticks,
+		People:             latency.people,
+		Sampling:           latency.Sampling,
+		Buckets:            ReviewLatencyBuckets,
+		reversedPeopleDict: latency.reversedPeopleDict,
+	}
+}
+
+// Fork clones this pipeline item.
+func (latency *ReviewLatencyAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(latency, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (latency *ReviewLatencyAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	latencyResult := result.(ReviewLatencyResult)
+	if binary {
+		return latency.serializeBinary(&latencyResult, writer)
+	}
+	latency.serializeText(&latencyResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (latency *ReviewLatencyAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(latency, result, &pb.ReviewLatencyAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (latency *ReviewLatencyAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(latency, result, &pb.ReviewLatencyAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to ReviewLatencyResult.
+func (latency *ReviewLatencyAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.ReviewLatencyAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	convert := func(stats *pb.ReviewLatencyStats) ReviewLatencyStats {
+		result := ReviewLatencyStats{
+			Commits: int(stats.Commits),
+			Total:   stats.Total,
+			Max:     stats.Max,
+		}
+		if len(stats.Histogram) > 0 {
+			result.Histogram = make([]int, len(stats.Histogram))
+			for i, val := range stats.Histogram {
+				result.Histogram[i] = int(val)
+			}
+		}
+		return result
+	}
+	result := ReviewLatencyResult{
+		People:             map[int]ReviewLatencyStats{},
+		Sampling:           int(message.Sampling),
+		Buckets:            message.Buckets,
+		reversedPeopleDict: message.DevIndex,
+	}
+	if len(message.Ticks) > 0 {
+		result.Ticks = make([]ReviewLatencyStats, len(message.Ticks))
+		for i, tick := range message.Ticks {
+			result.Ticks[i] = convert(tick)
+		}
+	}
+	for dev, stats := range message.People {
+		result.People[int(dev)] = convert(stats)
+	}
+	return result, nil
+}
+
+// MergeResults combines two ReviewLatencyResult-s together. The ticks are regrouped
+// by the larger sampling.
+func (latency *ReviewLatencyAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	lr1 := r1.(ReviewLatencyResult)
+	lr2 := r2.(ReviewLatencyResult)
+	merged := ReviewLatencyResult{
+		People:   map[int]ReviewLatencyStats{},
+		Sampling: lr1.Sampling,
+		Buckets:  lr1.Buckets,
+	}
+	if lr2.Sampling > merged.Sampling {
+		merged.Sampling = lr2.Sampling
+	}
+	var people map[string][3]int
+	people, merged.reversedPeopleDict = identity.Detector{}.MergeReversedDicts(
+		lr1.reversedPeopleDict, lr2.reversedPeopleDict)
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	add := func(result *ReviewLatencyResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for i, tick := range result.Ticks {
+			index := (i*result.Sampling + offset) / merged.Sampling
+			for len(merged.Ticks) <= index {
+				merged.Ticks = append(merged.Ticks, ReviewLatencyStats{})
+			}
+			merged.Ticks[index].merge(tick)
+		}
+		for dev, stats := range result.People {
+			index := -1
+			if dev >= 0 && dev < len(result.reversedPeopleDict) {
+				index = people[result.reversedPeopleDict[dev]][0]
+			}
+			target := merged.People[index]
+			target.merge(stats)
+			merged.People[index] = target
+		}
+	}
+	add(&lr1, c1)
+	add(&lr2, c2)
+	return merged
+}
+
+func (latency *ReviewLatencyAnalysis) serializeText(result *ReviewLatencyResult, writer io.Writer) {
+	writeStats := func(stats ReviewLatencyStats) {
+		fmt.Fprintf(writer, "{commits: %d, mean_hours: %.2f, max_hours: %.2f, histogram: [",
+			stats.Commits, stats.MeanHours(), float64(stats.Max)/3600)
+		writeIntList(writer, stats.Histogram)
+		fmt.Fprintln(writer, "]}")
+	}
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprint(writer, "  # the upper bounds of the histogram buckets in seconds\n  buckets: [")
+	for i, bound := range result.Buckets {
+		if i > 0 {
+			fmt.Fprint(writer, ", ")
+		}
+		fmt.Fprint(writer, bound)
+	}
+	fmt.Fprintln(writer, "]")
+	fmt.Fprintln(writer, "  ticks:")
+	for _, tick := range result.Ticks {
+		fmt.Fprint(writer, "    - ")
+		writeStats(tick)
+	}
+	devs := make([]int, 0, len(result.People))
+	for dev := range result.People {
+		devs = append(devs, dev)
+	}
+	sort.Ints(devs)
+	fmt.Fprintln(writer, "  # -1 means an unmatched identity")
+	fmt.Fprintln(writer, "  developers:")
+	for _, dev := range devs {
+		fmt.Fprintf(writer, "    %d: ", dev)
+		writeStats(result.People[dev])
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (latency *ReviewLatencyAnalysis) serializeBinary(result *ReviewLatencyResult, writer io.Writer) error {
+	convert := func(stats ReviewLatencyStats) *pb.ReviewLatencyStats {
+		converted := &pb.ReviewLatencyStats{
+			Commits:   int32(stats.Commits),
+			Histogram: make([]int32, len(stats.Histogram)),
+			Total:     stats.Total,
+			Max:       stats.Max,
+		}
+		for i, val := range stats.Histogram {
+			converted.Histogram[i] = int32(val)
+		}
+		return converted
+	}
+	message := pb.ReviewLatencyAnalysisResults{
+		Ticks:    make([]*pb.ReviewLatencyStats, len(result.Ticks)),
+		People:   map[int32]*pb.ReviewLatencyStats{},
+		Sampling: int32(result.Sampling),
+		Buckets:  result.Buckets,
+		DevIndex: result.reversedPeopleDict,
+	}
+	for i, tick := range result.Ticks {
+		message.Ticks[i] = convert(tick)
+	}
+	for dev, stats := range result.People {
+		message.People[int32(dev)] = convert(stats)
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&ReviewLatencyAnalysis{})
+}
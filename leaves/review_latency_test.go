@@ -0,0 +1,185 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureReviewLatency() *ReviewLatencyAnalysis {
+	latency := ReviewLatencyAnalysis{}
+	latency.Configure(map[string]interface{}{
+		ConfigReviewLatencySampling:                     10,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"alice", "bob"},
+	})
+	latency.Initialize(nil)
+	return &latency
+}
+
+func TestReviewLatencyMeta(t *testing.T) {
+	latency := fixtureReviewLatency()
+	assert.Equal(t, latency.Name(), "ReviewLatency")
+	assert.Len(t, latency.Provides(), 0)
+	assert.Equal(t, latency.Requires(), []string{identity.DependencyAuthor, items.DependencyDay})
+	assert.Equal(t, latency.Flag(), "review-latency")
+	assert.NotEmpty(t, latency.Description())
+	opts := latency.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Flag, "review-latency-sampling")
+	assert.Equal(t, latency.Sampling, 10)
+	assert.Equal(t, latency.reversedPeopleDict, []string{"alice", "bob"})
+	latency = &ReviewLatencyAnalysis{}
+	latency.Initialize(nil)
+	assert.Equal(t, latency.Sampling, DefaultReviewLatencySampling)
+	summoned := core.Registry.Summon(latency.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "ReviewLatency")
+}
+
+func TestReviewLatencyStatsAdd(t *testing.T) {
+	stats := ReviewLatencyStats{}
+	for _, seconds := range []int64{0, 59, 60, 3600, 2 * 24 * 3600, 100 * 24 * 3600} {
+		stats.add(seconds)
+	}
+	assert.Equal(t, stats.Histogram, []int{2, 1, 1, 1, 0, 1})
+	assert.Equal(t, stats.Commits, 6)
+	assert.Equal(t, stats.Max, int64(100*24*3600))
+	assert.Equal(t, stats.Total, int64(59+60+3600+102*24*3600))
+	assert.Equal(t, ReviewLatencyStats{}.MeanHours(), float64(0))
+	start := time.Unix(1000, 0)
+	commit := &object.Commit{
+		Author:    object.Signature{When: start},
+		Committer: object.Signature{When: start.Add(-time.Hour)},
+	}
+	assert.Equal(t, commitLatency(commit), int64(0))
+	commit.Committer.When = start.Add(90 * time.Minute)
+	assert.Equal(t, commitLatency(commit), int64(5400))
+}
+
+func fixtureReviewLatencyResult(t *testing.T) ReviewLatencyResult {
+	latency := fixtureReviewLatency()
+	start := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+	consume := func(author, day int, merge bool, delay time.Duration) {
+		commit := &object.Commit{
+			Author:    object.Signature{When: start},
+			Committer: object.Signature{When: start.Add(delay)},
+		}
+		if merge {
+			commit.Hash = plumbing.NewHash("0123456789012345678901234567890123456789")
+			commit.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		result, err := latency.Consume(map[string]interface{}{
+			core.DependencyCommit:     commit,
+			core.DependencyIsMerge:    merge,
+			identity.DependencyAuthor: author,
+			items.DependencyDay:       day,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	consume(0, 0, false, 0)
+	consume(1, 3, false, 2*time.Hour)
+	consume(identity.AuthorMissing, 25, false, 10*24*time.Hour)
+	consume(1, 26, true, 40*24*time.Hour)
+	return latency.Finalize().(ReviewLatencyResult)
+}
+
+func TestReviewLatencyConsumeFinalize(t *testing.T) {
+	result := fixtureReviewLatencyResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Buckets, ReviewLatencyBuckets)
+	assert.Equal(t, result.Ticks, []ReviewLatencyStats{
+		{Commits: 2, Histogram: []int{1, 0, 1, 0, 0, 0}, Total: 7200, Max: 7200},
+		{},
+		{Commits: 1, Histogram: []int{0, 0, 0, 0, 1, 0}, Total: 864000, Max: 864000},
+	})
+	assert.Equal(t, result.People, map[int]ReviewLatencyStats{
+		0:  {Commits: 1, Histogram: []int{1, 0, 0, 0, 0, 0}},
+		1:  {Commits: 1, Histogram: []int{0, 0, 1, 0, 0, 0}, Total: 7200, Max: 7200},
+		-1: {Commits: 1, Histogram: []int{0, 0, 0, 0, 1, 0}, Total: 864000, Max: 864000},
+	})
+	assert.Equal(t, result.Ticks[0].MeanHours(), float64(1))
+}
+
+func TestReviewLatencySerialize(t *testing.T) {
+	result := fixtureReviewLatencyResult(t)
+	latency := fixtureReviewLatency()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, latency.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  # the upper bounds of the histogram buckets in seconds
+  buckets: [60, 3600, 86400, 604800, 2592000]
+  ticks:
+    - {commits: 2, mean_hours: 1.00, max_hours: 2.00, histogram: [1, 0, 1, 0, 0, 0]}
+    - {commits: 0, mean_hours: 0.00, max_hours: 0.00, histogram: []}
+    - {commits: 1, mean_hours: 240.00, max_hours: 240.00, histogram: [0, 0, 0, 0, 1, 0]}
+  # -1 means an unmatched identity
+  developers:
+    -1: {commits: 1, mean_hours: 240.00, max_hours: 240.00, histogram: [0, 0, 0, 0, 1, 0]}
+    0: {commits: 1, mean_hours: 0.00, max_hours: 0.00, histogram: [1, 0, 0, 0, 0, 0]}
+    1: {commits: 1, mean_hours: 2.00, max_hours: 2.00, histogram: [0, 0, 1, 0, 0, 0]}
+  people:
+  - "alice"
+  - "bob"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, latency.Serialize(result, true, buffer))
+	msg := pb.ReviewLatencyAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Equal(t, msg.Buckets, ReviewLatencyBuckets)
+	assert.Len(t, msg.Ticks, 3)
+	assert.Equal(t, msg.Ticks[0].Histogram, []int32{1, 0, 1, 0, 0, 0})
+	assert.Len(t, msg.People, 3)
+	assert.Equal(t, msg.DevIndex, []string{"alice", "bob"})
+	deserialized, err := latency.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestReviewLatencyMergeResults(t *testing.T) {
+	r1 := ReviewLatencyResult{
+		Ticks: []ReviewLatencyStats{
+			{Commits: 2, Histogram: []int{1, 1, 0, 0, 0, 0}, Total: 100, Max: 70}},
+		People: map[int]ReviewLatencyStats{
+			0: {Commits: 2, Histogram: []int{1, 1, 0, 0, 0, 0}, Total: 100, Max: 70}},
+		Sampling:           10,
+		Buckets:            ReviewLatencyBuckets,
+		reversedPeopleDict: []string{"alice"},
+	}
+	r2 := ReviewLatencyResult{
+		Ticks: []ReviewLatencyStats{
+			{Commits: 2, Histogram: []int{0, 0, 1, 1, 0, 0}, Total: 100000, Max: 90000}},
+		People: map[int]ReviewLatencyStats{
+			0:  {Commits: 1, Histogram: []int{0, 0, 1, 0, 0, 0}, Total: 10000, Max: 10000},
+			1:  {Commits: 1, Histogram: []int{0, 0, 0, 1, 0, 0}, Total: 90000, Max: 90000},
+			-1: {}},
+		Sampling:           20,
+		Buckets:            ReviewLatencyBuckets,
+		reversedPeopleDict: []string{"bob", "alice"},
+	}
+	latency := fixtureReviewLatency()
+	merged := latency.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 30 * 24 * 3600}).(ReviewLatencyResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.Buckets, ReviewLatencyBuckets)
+	assert.Equal(t, merged.reversedPeopleDict, []string{"alice", "bob"})
+	assert.Equal(t, merged.Ticks, []ReviewLatencyStats{
+		{Commits: 2, Histogram: []int{1, 1, 0, 0, 0, 0}, Total: 100, Max: 70},
+		{Commits: 2, Histogram: []int{0, 0, 1, 1, 0, 0}, Total: 100000, Max: 90000}})
+	assert.Equal(t, merged.People, map[int]ReviewLatencyStats{
+		0:  {Commits: 3, Histogram: []int{1, 1, 0, 1, 0, 0}, Total: 90100, Max: 90000},
+		1:  {Commits: 1, Histogram: []int{0, 0, 1, 0, 0, 0}, Total: 10000, Max: 10000},
+		-1: {}})
+	assert.Equal(t, r1.People[0].Histogram, []int{1, 1, 0, 0, 0, 0})
+}
@@ -0,0 +1,413 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4/internal/burndown"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// RewriteDepthAnalysis finds how many times the location of each line in the last analysed
+// commit has been rewritten. The lines are tracked by the same machinery as in BurndownAnalysis.
+// An inserted line has the depth 0 and a line which replaces the others has the maximum depth
+// of the replaced lines plus one, so the depth grows each time the same place is rewritten.
+// The merge conflict resolutions start from 0. The deepest line ranges are the chronic
+// rewrite hotspots.
+type RewriteDepthAnalysis struct {
+	// HotspotDepth is the minimum depth of the reported hotspots.
+	HotspotDepth int
+	// MaxHotspots is the maximum number of the reported hotspots.
+	MaxHotspots int
+
+	// lines maintains the lines of the files and their depths; all its histories are disabled.
+	lines *BurndownAnalysis
+	// ignoreFormatting is copied from BurndownAnalysis.IgnoreFormatting.
+	ignoreFormatting bool
+}
+
+// RewriteHotspot is a range of the adjacent lines with the same rewrite depth.
+type RewriteHotspot struct {
+	File string
+	// Line is the number of the first line, starting from 1.
+	Line int
+	// Length is the number of lines.
+	Length int
+	Depth  int
+}
+
+// RewriteDepthResult is returned by RewriteDepthAnalysis.Finalize().
+type RewriteDepthResult struct {
+	// Files map the file names to the number of lines with each rewrite depth;
+	// the index is the depth.
+	Files map[string][]int
+	// Hotspots are the deepest line ranges, the deepest first.
+	Hotspots []RewriteHotspot
+	// HotspotDepth is the effective RewriteDepthAnalysis.HotspotDepth.
+	HotspotDepth int
+	// MaxHotspots is the effective RewriteDepthAnalysis.MaxHotspots.
+	MaxHotspots int
+}
+
+const (
+	// ConfigRewriteDepthHotspotDepth is the name of the option to set
+	// RewriteDepthAnalysis.HotspotDepth.
+	ConfigRewriteDepthHotspotDepth = "RewriteDepth.HotspotDepth"
+	// ConfigRewriteDepthMaxHotspots is the name of the option to set
+	// RewriteDepthAnalysis.MaxHotspots.
+	ConfigRewriteDepthMaxHotspots = "RewriteDepth.MaxHotspots"
+	// DefaultRewriteDepthHotspotDepth is the default value of RewriteDepthAnalysis.HotspotDepth.
+	DefaultRewriteDepthHotspotDepth = 3
+	// DefaultRewriteDepthMaxHotspots is the default value of RewriteDepthAnalysis.MaxHotspots.
+	DefaultRewriteDepthMaxHotspots = 50
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (rewrites *RewriteDepthAnalysis) Name() string {
+	return "RewriteDepth"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (rewrites *RewriteDepthAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (rewrites *RewriteDepthAnalysis) Requires() []string {
+	arr := [...]string{
+		items.DependencyFileDiff, items.DependencyTreeChanges, items.DependencyBlobCache,
+		items.DependencyDay, identity.DependencyAuthor, items.DependencyFormattingHunks}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (rewrites *RewriteDepthAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigRewriteDepthHotspotDepth,
+		Description: "Minimum rewrite depth of the reported line ranges.",
+		Flag:        "rewrite-depth-hotspot-depth",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultRewriteDepthHotspotDepth}, {
+		Name:        ConfigRewriteDepthMaxHotspots,
+		Description: "Maximum number of the reported line ranges.",
+		Flag:        "rewrite-depth-hotspots",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultRewriteDepthMaxHotspots},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (rewrites *RewriteDepthAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigRewriteDepthHotspotDepth].(int); exists {
+		rewrites.HotspotDepth = val
+	}
+	if val, exists := facts[ConfigRewriteDepthMaxHotspots].(int); exists {
+		rewrites.MaxHotspots = val
+	}
+	if val, exists := facts[ConfigBurndownIgnoreFormatting].(bool); exists {
+		rewrites.ignoreFormatting = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (rewrites *RewriteDepthAnalysis) Flag() string {
+	return "rewrite-depth"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (rewrites *RewriteDepthAnalysis) Description() string {
+	return "Counts how many times the location of each surviving line has been rewritten " +
+		"and reports the distribution per file together with the deepest line ranges."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (rewrites *RewriteDepthAnalysis) Initialize(repository *git.Repository) {
+	if rewrites.HotspotDepth <= 0 {
+		log.Printf("Warning: adjusted the rewrite depth of the hotspots to %d\n",
+			DefaultRewriteDepthHotspotDepth)
+		rewrites.HotspotDepth = DefaultRewriteDepthHotspotDepth
+	}
+	if rewrites.MaxHotspots <= 0 {
+		log.Printf("Warning: adjusted the maximum number of the rewrite hotspots to %d\n",
+			DefaultRewriteDepthMaxHotspots)
+		rewrites.MaxHotspots = DefaultRewriteDepthMaxHotspots
+	}
+	// the people are not tracked, so the time of a line is its day
+	// and we store the depth in the bits of the author
+	rewrites.lines = &BurndownAnalysis{
+		Granularity:      DefaultBurndownGranularity,
+		Sampling:         DefaultBurndownGranularity,
+		IgnoreFormatting: rewrites.ignoreFormatting,
+		linesOnly:        true,
+	}
+	rewrites.lines.lineTime = func(time int, deleted []int) int {
+		depth := 0
+		for _, value := range deleted {
+			if valueDepth := rewriteDepthOf(value); valueDepth > depth {
+				depth = valueDepth
+			}
+		}
+		return time | (depth+1)<<burndown.TreeMaxBinPower
+	}
+	rewrites.lines.Initialize(repository)
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (rewrites *RewriteDepthAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	return rewrites.lines.Consume(deps)
+}
+
+// Fork clones this item. The files are copied by value.
+func (rewrites *RewriteDepthAnalysis) Fork(n int) []core.PipelineItem {
+	lines := rewrites.lines.Fork(n)
+	result := make([]core.PipelineItem, n)
+	for i := range result {
+		clone := *rewrites
+		clone.lines = lines[i].(*BurndownAnalysis)
+		result[i] = &clone
+	}
+	return result
+}
+
+// Merge combines several items together, see BurndownAnalysis.Merge().
+func (rewrites *RewriteDepthAnalysis) Merge(branches []core.PipelineItem) {
+	lines := make([]core.PipelineItem, len(branches))
+	for i, branch := range branches {
+		lines[i] = branch.(*RewriteDepthAnalysis).lines
+	}
+	rewrites.lines.Merge(lines)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (rewrites *RewriteDepthAnalysis) Finalize() interface{} {
+	result := RewriteDepthResult{
+		Files:        map[string][]int{},
+		HotspotDepth: rewrites.HotspotDepth,
+		MaxHotspots:  rewrites.MaxHotspots,
+	}
+	for name, file := range rewrites.lines.files {
+		var histogram []int
+		// hotspot is the index of the hotspot which ends at the current line, -1 if none
+		hotspot := -1
+		file.ForEach(func(line, length, value int) {
+			depth := rewriteDepthOf(value)
+			for len(histogram) <= depth {
+				histogram = append(histogram, 0)
+			}
+			histogram[depth] += length
+			if depth < rewrites.HotspotDepth {
+				hotspot = -1
+				return
+			}
+			if hotspot >= 0 && result.Hotspots[hotspot].Depth == depth {
+				result.Hotspots[hotspot].Length += length
+				return
+			}
+			hotspot = len(result.Hotspots)
+			result.Hotspots = append(result.Hotspots, RewriteHotspot{
+				File: name, Line: line + 1, Length: length, Depth: depth})
+		})
+		if len(histogram) > 0 {
+			result.Files[name] = histogram
+		}
+	}
+	result.Hotspots = sortRewriteHotspots(result.Hotspots, result.MaxHotspots)
+	return result
+}
+
+// rewriteDepthOf extracts the rewrite depth from the time of a line.
+func rewriteDepthOf(value int) int {
+	return value >> burndown.TreeMaxBinPower
+}
+
+// sortRewriteHotspots orders the hotspots by their depth, the deepest first, and leaves
+// at most `limit` of them.
+func sortRewriteHotspots(hotspots []RewriteHotspot, limit int) []RewriteHotspot {
+	sort.Slice(hotspots, func(i, j int) bool {
+		if hotspots[i].Depth != hotspots[j].Depth {
+			return hotspots[i].Depth > hotspots[j].Depth
+		}
+		if hotspots[i].File != hotspots[j].File {
+			return hotspots[i].File < hotspots[j].File
+		}
+		return hotspots[i].Line < hotspots[j].Line
+	})
+	if len(hotspots) > limit {
+		hotspots = hotspots[:limit]
+	}
+	return hotspots
+}
+
+// MeanDepths returns the average rewrite depth of the lines of each file.
+func (result RewriteDepthResult) MeanDepths() map[string]float64 {
+	means := map[string]float64{}
+	for name, histogram := range result.Files {
+		lines, sum := 0, 0
+		for depth, count := range histogram {
+			lines += count
+			sum += depth * count
+		}
+		if lines > 0 {
+			means[name] = float64(sum) / float64(lines)
+		}
+	}
+	return means
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (rewrites *RewriteDepthAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	rewriteResult := result.(RewriteDepthResult)
+	if binary {
+		return rewrites.serializeBinary(&rewriteResult, writer)
+	}
+	rewrites.serializeText(&rewriteResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (rewrites *RewriteDepthAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(rewrites, result, &pb.RewriteDepthAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (rewrites *RewriteDepthAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(rewrites, result, &pb.RewriteDepthAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to RewriteDepthResult.
+func (rewrites *RewriteDepthAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.RewriteDepthAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := RewriteDepthResult{
+		Files:        map[string][]int{},
+		HotspotDepth: int(message.HotspotDepth),
+		MaxHotspots:  int(message.MaxHotspots),
+	}
+	for name, file := range message.Files {
+		histogram := make([]int, len(file.Lines))
+		for i, lines := range file.Lines {
+			histogram[i] = int(lines)
+		}
+		result.Files[name] = histogram
+	}
+	for _, hotspot := range message.Hotspots {
+		result.Hotspots = append(result.Hotspots, RewriteHotspot{
+			File:   hotspot.File,
+			Line:   int(hotspot.Line),
+			Length: int(hotspot.Length),
+			Depth:  int(hotspot.Depth),
+		})
+	}
+	return result, nil
+}
+
+// MergeResults combines two RewriteDepthResult-s together. The histograms of the files
+// with the same names are summed and the thresholds are taken from the first result.
+func (rewrites *RewriteDepthAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	rr1 := r1.(RewriteDepthResult)
+	rr2 := r2.(RewriteDepthResult)
+	merged := RewriteDepthResult{
+		Files:        map[string][]int{},
+		HotspotDepth: rr1.HotspotDepth,
+		MaxHotspots:  rr1.MaxHotspots,
+	}
+	for _, result := range []RewriteDepthResult{rr1, rr2} {
+		for name, histogram := range result.Files {
+			target := merged.Files[name]
+			for len(target) < len(histogram) {
+				target = append(target, 0)
+			}
+			for depth, lines := range histogram {
+				target[depth] += lines
+			}
+			merged.Files[name] = target
+		}
+		for _, hotspot := range result.Hotspots {
+			if hotspot.Depth >= merged.HotspotDepth {
+				merged.Hotspots = append(merged.Hotspots, hotspot)
+			}
+		}
+	}
+	merged.Hotspots = sortRewriteHotspots(merged.Hotspots, merged.MaxHotspots)
+	return merged
+}
+
+func (rewrites *RewriteDepthAnalysis) serializeText(result *RewriteDepthResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  hotspot_depth:", result.HotspotDepth)
+	fmt.Fprintln(writer, "  max_hotspots:", result.MaxHotspots)
+	names := make([]string, 0, len(result.Files))
+	for name := range result.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	means := result.MeanDepths()
+	fmt.Fprintln(writer, "  # the number of lines with each rewrite depth, starting from 0")
+	fmt.Fprintln(writer, "  files:")
+	for _, name := range names {
+		fmt.Fprintf(writer, "    %s: {mean: %.3f, lines: [", yaml.SafeString(name), means[name])
+		writeIntList(writer, result.Files[name])
+		fmt.Fprintln(writer, "]}")
+	}
+	fmt.Fprintln(writer, "  hotspots:")
+	for _, hotspot := range result.Hotspots {
+		fmt.Fprintf(writer, "    - {file: %s, line: %d, length: %d, depth: %d}\n",
+			yaml.SafeString(hotspot.File), hotspot.Line, hotspot.Length, hotspot.Depth)
+	}
+}
+
+func (rewrites *RewriteDepthAnalysis) serializeBinary(result *RewriteDepthResult, writer io.Writer) error {
+	message := pb.RewriteDepthAnalysisResults{
+		Files:        map[string]*pb.RewriteDepthFile{},
+		Hotspots:     make([]*pb.RewriteHotspot, len(result.Hotspots)),
+		HotspotDepth: int32(result.HotspotDepth),
+		MaxHotspots:  int32(result.MaxHotspots),
+	}
+	for name, histogram := range result.Files {
+		lines := make([]int32, len(histogram))
+		for i, count := range histogram {
+			lines[i] = int32(count)
+		}
+		message.Files[name] = &pb.RewriteDepthFile{Lines: lines}
+	}
+	for i, hotspot := range result.Hotspots {
+		message.Hotspots[i] = &pb.RewriteHotspot{
+			File:   hotspot.File,
+			Line:   int32(hotspot.Line),
+			Length: int32(hotspot.Length),
+			Depth:  int32(hotspot.Depth),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&RewriteDepthAnalysis{})
+}
@@ -0,0 +1,187 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureRewriteDepth() *RewriteDepthAnalysis {
+	rewrites := RewriteDepthAnalysis{}
+	rewrites.Configure(map[string]interface{}{
+		ConfigRewriteDepthHotspotDepth: 2,
+		ConfigRewriteDepthMaxHotspots:  10,
+	})
+	rewrites.Initialize(nil)
+	return &rewrites
+}
+
+func TestRewriteDepthMeta(t *testing.T) {
+	rewrites := fixtureRewriteDepth()
+	assert.Equal(t, rewrites.Name(), "RewriteDepth")
+	assert.Len(t, rewrites.Provides(), 0)
+	for _, name := range []string{
+		items.DependencyFileDiff, items.DependencyTreeChanges, items.DependencyBlobCache,
+		items.DependencyDay, identity.DependencyAuthor, items.DependencyFormattingHunks} {
+		assert.Contains(t, rewrites.Requires(), name)
+	}
+	opts := rewrites.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Flag, "rewrite-depth-hotspot-depth")
+	assert.Equal(t, opts[1].Flag, "rewrite-depth-hotspots")
+	assert.Equal(t, rewrites.Flag(), "rewrite-depth")
+	assert.NotEmpty(t, rewrites.Description())
+	assert.Equal(t, rewrites.HotspotDepth, 2)
+	assert.Equal(t, rewrites.MaxHotspots, 10)
+	assert.True(t, rewrites.lines.linesOnly)
+	assert.NotNil(t, rewrites.lines.lineTime)
+	assert.False(t, rewrites.lines.IgnoreFormatting)
+	rewrites = &RewriteDepthAnalysis{}
+	rewrites.Configure(map[string]interface{}{ConfigBurndownIgnoreFormatting: true})
+	rewrites.Initialize(nil)
+	assert.Equal(t, rewrites.HotspotDepth, DefaultRewriteDepthHotspotDepth)
+	assert.Equal(t, rewrites.MaxHotspots, DefaultRewriteDepthMaxHotspots)
+	assert.True(t, rewrites.lines.IgnoreFormatting)
+	summoned := core.Registry.Summon(rewrites.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "RewriteDepth")
+}
+
+func fixtureRewriteDepthResult(t *testing.T) RewriteDepthResult {
+	hash, blob := storeExpertiseBlob(t, "one\ntwo\nthree\n")
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	rewrites := fixtureRewriteDepth()
+	consume := func(item *RewriteDepthAnalysis, day int, changes object.Changes, diffs ...diffmatchpatch.Diff) {
+		fileDiffs := map[string]items.FileDiffData{}
+		if len(diffs) > 0 {
+			data := items.FileDiffData{Diffs: diffs}
+			for _, diff := range diffs {
+				length := len(diff.Text)
+				if diff.Type != diffmatchpatch.DiffInsert {
+					data.OldLinesOfCode += length
+				}
+				if diff.Type != diffmatchpatch.DiffDelete {
+					data.NewLinesOfCode += length
+				}
+			}
+			fileDiffs["a.go"] = data
+		}
+		result, err := item.Consume(map[string]interface{}{
+			identity.DependencyAuthor:   0,
+			items.DependencyDay:         day,
+			items.DependencyBlobCache:   map[plumbing.Hash]*object.Blob{hash: blob},
+			items.DependencyTreeChanges: changes,
+			items.DependencyFileDiff:    fileDiffs,
+			core.DependencyCommit:       &object.Commit{},
+			core.DependencyIsMerge:      false,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	modify := object.Changes{{From: entry("a.go"), To: entry("a.go")}}
+	consume(rewrites, 0, object.Changes{{To: entry("a.go")}, {To: entry("b.go")}})
+	// 0 1 0
+	consume(rewrites, 1, modify,
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffEqual, Text: "a"},
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffDelete, Text: "b"},
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffInsert, Text: "d"},
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffEqual, Text: "c"})
+	// 0 2 2
+	consume(rewrites, 2, modify,
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffEqual, Text: "a"},
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffDelete, Text: "dc"},
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffInsert, Text: "ef"})
+	// the forks keep their own lines
+	fork := rewrites.Fork(1)[0].(*RewriteDepthAnalysis)
+	consume(fork, 3, object.Changes{{From: entry("a.go")}})
+	assert.Len(t, fork.lines.files, 1)
+	// 0 2 3 3 0
+	consume(rewrites, 3, modify,
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffEqual, Text: "ae"},
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffDelete, Text: "f"},
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffInsert, Text: "gh"},
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffInsert, Text: "i"})
+	return rewrites.Finalize().(RewriteDepthResult)
+}
+
+func TestRewriteDepthConsumeFinalize(t *testing.T) {
+	result := fixtureRewriteDepthResult(t)
+	assert.Equal(t, result.HotspotDepth, 2)
+	assert.Equal(t, result.MaxHotspots, 10)
+	assert.Equal(t, result.Files, map[string][]int{"a.go": {2, 0, 1, 2}, "b.go": {3}})
+	assert.Equal(t, result.Hotspots, []RewriteHotspot{
+		{File: "a.go", Line: 3, Length: 2, Depth: 3},
+		{File: "a.go", Line: 2, Length: 1, Depth: 2},
+	})
+	assert.Equal(t, result.MeanDepths(), map[string]float64{"a.go": 1.6, "b.go": 0})
+}
+
+func TestRewriteDepthSerialize(t *testing.T) {
+	result := fixtureRewriteDepthResult(t)
+	rewrites := fixtureRewriteDepth()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, rewrites.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  hotspot_depth: 2
+  max_hotspots: 10
+  # the number of lines with each rewrite depth, starting from 0
+  files:
+    "a.go": {mean: 1.600, lines: [2, 0, 1, 2]}
+    "b.go": {mean: 0.000, lines: [3]}
+  hotspots:
+    - {file: "a.go", line: 3, length: 2, depth: 3}
+    - {file: "a.go", line: 2, length: 1, depth: 2}
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, rewrites.Serialize(result, true, buffer))
+	msg := pb.RewriteDepthAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.HotspotDepth, int32(2))
+	assert.Equal(t, msg.MaxHotspots, int32(10))
+	assert.Equal(t, msg.Files["a.go"].Lines, []int32{2, 0, 1, 2})
+	assert.Len(t, msg.Hotspots, 2)
+	assert.Equal(t, msg.Hotspots[0].Line, int32(3))
+	deserialized, err := rewrites.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestRewriteDepthMergeResults(t *testing.T) {
+	r1 := RewriteDepthResult{
+		Files: map[string][]int{"a.go": {1, 2}, "b.go": {4, 0, 0, 1}},
+		Hotspots: []RewriteHotspot{
+			{File: "b.go", Line: 5, Length: 1, Depth: 3}},
+		HotspotDepth: 3,
+		MaxHotspots:  2,
+	}
+	r2 := RewriteDepthResult{
+		Files: map[string][]int{"a.go": {0, 1, 0, 0, 2}},
+		Hotspots: []RewriteHotspot{
+			{File: "a.go", Line: 1, Length: 2, Depth: 4},
+			{File: "a.go", Line: 9, Length: 1, Depth: 3},
+			{File: "a.go", Line: 3, Length: 1, Depth: 2}},
+		HotspotDepth: 2,
+		MaxHotspots:  5,
+	}
+	rewrites := fixtureRewriteDepth()
+	merged := rewrites.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{}, &core.CommonAnalysisResult{}).(RewriteDepthResult)
+	assert.Equal(t, merged.HotspotDepth, 3)
+	assert.Equal(t, merged.MaxHotspots, 2)
+	assert.Equal(t, merged.Files, map[string][]int{"a.go": {1, 3, 0, 0, 2}, "b.go": {4, 0, 0, 1}})
+	assert.Equal(t, merged.Hotspots, []RewriteHotspot{
+		{File: "a.go", Line: 1, Length: 2, Depth: 4},
+		{File: "a.go", Line: 9, Length: 1, Depth: 3},
+	})
+	assert.Equal(t, r1.Files["a.go"], []int{1, 2})
+}
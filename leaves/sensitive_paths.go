@@ -0,0 +1,432 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/format/gitignore"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// SensitivePathsAnalysis records every change to the security-sensitive files, such as
+// the authentication and the cryptography code, the infrastructure definitions or
+// the container images, which yields a lightweight audit trail. The files are matched by
+// the .gitignore-like Patterns; a renamed file matches if either of its names does.
+// Each change carries the commit, the author, the tick of Sampling days and the churn.
+// The merge commits are skipped.
+type SensitivePathsAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the number of days in a tick.
+	Sampling int
+	// Patterns are the .gitignore-like patterns of the sensitive files, e.g. "auth/" or "*.tf".
+	Patterns []string
+
+	// patterns are the parsed Patterns.
+	patterns []gitignore.Pattern
+	// changes are the recorded changes.
+	changes []SensitivePathChange
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// SensitivePathChange is a change of a sensitive file.
+type SensitivePathChange struct {
+	// Commit is the hash of the commit.
+	Commit string
+	// Author is the index of the author; -1 means an unmatched identity.
+	Author int
+	// Tick is the index of the tick of Sampling days.
+	Tick int
+	// File is the path of the file after the change or before the deletion.
+	File string
+	// Churn is the number of added and removed lines; the binary files have zero churn.
+	Churn int
+}
+
+// SensitivePathsTick is the aggregate of the changes of the sensitive files in a tick.
+type SensitivePathsTick struct {
+	// Commits is the number of the commits which changed any sensitive file.
+	Commits int
+	// Changes is the number of the changed sensitive files.
+	Changes int
+	// Churn is the number of added and removed lines.
+	Churn int
+	// Authors is the number of the distinct authors.
+	Authors int
+}
+
+// SensitivePathsResult is returned by SensitivePathsAnalysis.Finalize().
+type SensitivePathsResult struct {
+	// Changes are the changes of the sensitive files in the chronological order.
+	Changes []SensitivePathChange
+	// Sampling is the effective SensitivePathsAnalysis.Sampling.
+	Sampling int
+	// Patterns are the effective SensitivePathsAnalysis.Patterns.
+	Patterns []string
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigSensitivePathsSampling is the name of the option to set
+	// SensitivePathsAnalysis.Sampling.
+	ConfigSensitivePathsSampling = "SensitivePaths.Sampling"
+	// ConfigSensitivePathsPatterns is the name of the option to set
+	// SensitivePathsAnalysis.Patterns.
+	ConfigSensitivePathsPatterns = "SensitivePaths.Patterns"
+	// DefaultSensitivePathsSampling is the default value of SensitivePathsAnalysis.Sampling.
+	DefaultSensitivePathsSampling = 30
+)
+
+// DefaultSensitivePathsPatterns is the default value of SensitivePathsAnalysis.Patterns.
+var DefaultSensitivePathsPatterns = []string{
+	"auth/", "crypto/", "security/", "*.pem", "*.tf", "Dockerfile", ".github/workflows/"}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (sensitive *SensitivePathsAnalysis) Name() string {
+	return "SensitivePaths"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (sensitive *SensitivePathsAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (sensitive *SensitivePathsAnalysis) Requires() []string {
+	arr := [...]string{
+		identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges,
+		items.DependencyFileDiff, items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (sensitive *SensitivePathsAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigSensitivePathsSampling,
+		Description: "How frequently to aggregate the changes of the sensitive files, in days.",
+		Flag:        "sensitive-paths-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultSensitivePathsSampling}, {
+		Name: ConfigSensitivePathsPatterns,
+		Description: "Comma-separated .gitignore-like patterns of the security-sensitive files, " +
+			"e.g. \"auth/\" or \"*.tf\".",
+		Flag:    "sensitive-paths-patterns",
+		Type:    core.StringsConfigurationOption,
+		Default: DefaultSensitivePathsPatterns},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (sensitive *SensitivePathsAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigSensitivePathsSampling].(int); exists {
+		sensitive.Sampling = val
+	}
+	if val, exists := facts[ConfigSensitivePathsPatterns].([]string); exists {
+		sensitive.Patterns = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		sensitive.reversedPeopleDict = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (sensitive *SensitivePathsAnalysis) Flag() string {
+	return "sensitive-paths"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (sensitive *SensitivePathsAnalysis) Description() string {
+	return "Records every change to the security-sensitive files with the author, the time " +
+		"and the churn, and aggregates them over time."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (sensitive *SensitivePathsAnalysis) Initialize(repository *git.Repository) {
+	if sensitive.Sampling <= 0 {
+		log.Printf("Warning: adjusted the sensitive paths sampling to %d days\n",
+			DefaultSensitivePathsSampling)
+		sensitive.Sampling = DefaultSensitivePathsSampling
+	}
+	var patterns []string
+	for _, pattern := range sensitive.Patterns {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	if len(patterns) == 0 {
+		if sensitive.Patterns != nil {
+			log.Printf("Warning: adjusted the sensitive paths patterns to %s\n",
+				strings.Join(DefaultSensitivePathsPatterns, ","))
+		}
+		patterns = DefaultSensitivePathsPatterns
+	}
+	sensitive.Patterns = patterns
+	sensitive.patterns = make([]gitignore.Pattern, len(patterns))
+	for i, pattern := range patterns {
+		sensitive.patterns[i] = gitignore.ParsePattern(pattern, nil)
+	}
+	sensitive.changes = nil
+	sensitive.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (sensitive *SensitivePathsAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !sensitive.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit).Hash.String()
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing {
+		author = -1
+	}
+	tick := deps[items.DependencyDay].(int) / sensitive.Sampling
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	for _, change := range deps[items.DependencyTreeChanges].(object.Changes) {
+		if !sensitive.isSensitive(change.From.Name) && !sensitive.isSensitive(change.To.Name) {
+			continue
+		}
+		name, churn, err := countChangedLines(change, cache, fileDiffs)
+		if err != nil {
+			return nil, err
+		}
+		sensitive.changes = append(sensitive.changes, SensitivePathChange{
+			Commit: commit,
+			Author: author,
+			Tick:   tick,
+			File:   name,
+			Churn:  churn,
+		})
+	}
+	return nil, nil
+}
+
+// isSensitive returns whether the file matches any of the patterns.
+func (sensitive *SensitivePathsAnalysis) isSensitive(name string) bool {
+	if name == "" {
+		return false
+	}
+	parts := strings.Split(name, "/")
+	for _, pattern := range sensitive.patterns {
+		if pattern.Match(parts, false) == gitignore.Exclude {
+			return true
+		}
+	}
+	return false
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (sensitive *SensitivePathsAnalysis) Finalize() interface{} {
+	return SensitivePathsResult{
+		Changes:            sensitive.changes,
+		Sampling:           sensitive.Sampling,
+		Patterns:           sensitive.Patterns,
+		reversedPeopleDict: sensitive.reversedPeopleDict,
+	}
+}
+
+// Fork clones this pipeline item.
+func (sensitive *SensitivePathsAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(sensitive, n)
+}
+
+// Ticks aggregates the changes in each tick.
+func (result SensitivePathsResult) Ticks() []SensitivePathsTick {
+	var ticks []SensitivePathsTick
+	commits := map[int]map[string]bool{}
+	authors := map[int]map[int]bool{}
+	for _, change := range result.Changes {
+		for len(ticks) <= change.Tick {
+			ticks = append(ticks, SensitivePathsTick{})
+		}
+		tick := &ticks[change.Tick]
+		tick.Changes++
+		tick.Churn += change.Churn
+		if commits[change.Tick] == nil {
+			commits[change.Tick] = map[string]bool{}
+			authors[change.Tick] = map[int]bool{}
+		}
+		if !commits[change.Tick][change.Commit] {
+			commits[change.Tick][change.Commit] = true
+			tick.Commits++
+		}
+		if !authors[change.Tick][change.Author] {
+			authors[change.Tick][change.Author] = true
+			tick.Authors++
+		}
+	}
+	return ticks
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (sensitive *SensitivePathsAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	sensitiveResult := result.(SensitivePathsResult)
+	if binary {
+		return sensitive.serializeBinary(&sensitiveResult, writer)
+	}
+	sensitive.serializeText(&sensitiveResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (sensitive *SensitivePathsAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(sensitive, result, &pb.SensitivePathsAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (sensitive *SensitivePathsAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(sensitive, result, &pb.SensitivePathsAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to SensitivePathsResult.
+func (sensitive *SensitivePathsAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.SensitivePathsAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := SensitivePathsResult{
+		Sampling:           int(message.Sampling),
+		Patterns:           message.Patterns,
+		reversedPeopleDict: message.DevIndex,
+	}
+	if len(message.Changes) > 0 {
+		result.Changes = make([]SensitivePathChange, len(message.Changes))
+		for i, change := range message.Changes {
+			result.Changes[i] = SensitivePathChange{
+				Commit: change.Commit,
+				Author: int(change.Author),
+				Tick:   int(change.Tick),
+				File:   change.File,
+				Churn:  int(change.Churn),
+			}
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two SensitivePathsResult-s together. The ticks are regrouped
+// by the larger sampling, the authors are matched by name and the patterns are joined.
+func (sensitive *SensitivePathsAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	sr1 := r1.(SensitivePathsResult)
+	sr2 := r2.(SensitivePathsResult)
+	merged := SensitivePathsResult{Sampling: sr1.Sampling}
+	if sr2.Sampling > merged.Sampling {
+		merged.Sampling = sr2.Sampling
+	}
+	patterns := map[string]bool{}
+	for _, result := range []*SensitivePathsResult{&sr1, &sr2} {
+		for _, pattern := range result.Patterns {
+			if !patterns[pattern] {
+				patterns[pattern] = true
+				merged.Patterns = append(merged.Patterns, pattern)
+			}
+		}
+	}
+	var people map[string][3]int
+	people, merged.reversedPeopleDict = identity.Detector{}.MergeReversedDicts(
+		sr1.reversedPeopleDict, sr2.reversedPeopleDict)
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	add := func(result *SensitivePathsResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for _, change := range result.Changes {
+			change.Tick = (change.Tick*result.Sampling + offset) / merged.Sampling
+			if change.Author >= 0 && change.Author < len(result.reversedPeopleDict) {
+				change.Author = people[result.reversedPeopleDict[change.Author]][0]
+			} else {
+				change.Author = -1
+			}
+			merged.Changes = append(merged.Changes, change)
+		}
+	}
+	add(&sr1, c1)
+	add(&sr2, c2)
+	sort.SliceStable(merged.Changes, func(i, j int) bool {
+		return merged.Changes[i].Tick < merged.Changes[j].Tick
+	})
+	return merged
+}
+
+func (sensitive *SensitivePathsAnalysis) serializeText(result *SensitivePathsResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprint(writer, "  patterns: [")
+	for i, pattern := range result.Patterns {
+		if i > 0 {
+			fmt.Fprint(writer, ", ")
+		}
+		fmt.Fprint(writer, yaml.SafeString(pattern))
+	}
+	fmt.Fprintln(writer, "]")
+	fmt.Fprintln(writer, "  # -1 means an unmatched identity")
+	fmt.Fprintln(writer, "  changes:")
+	for _, change := range result.Changes {
+		fmt.Fprintf(writer, "    - {commit: %s, author: %d, tick: %d, file: %s, churn: %d}\n",
+			change.Commit, change.Author, change.Tick, yaml.SafeString(change.File), change.Churn)
+	}
+	fmt.Fprintln(writer, "  ticks:")
+	for _, tick := range result.Ticks() {
+		fmt.Fprintf(writer, "    - {commits: %d, changes: %d, churn: %d, authors: %d}\n",
+			tick.Commits, tick.Changes, tick.Churn, tick.Authors)
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (sensitive *SensitivePathsAnalysis) serializeBinary(result *SensitivePathsResult, writer io.Writer) error {
+	message := pb.SensitivePathsAnalysisResults{
+		Changes:  make([]*pb.SensitivePathChange, len(result.Changes)),
+		Sampling: int32(result.Sampling),
+		Patterns: result.Patterns,
+		DevIndex: result.reversedPeopleDict,
+	}
+	for i, change := range result.Changes {
+		message.Changes[i] = &pb.SensitivePathChange{
+			Commit: change.Commit,
+			Author: int32(change.Author),
+			Tick:   int32(change.Tick),
+			File:   change.File,
+			Churn:  int32(change.Churn),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&SensitivePathsAnalysis{})
+}
@@ -0,0 +1,202 @@
+package leaves
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureSensitivePaths() *SensitivePathsAnalysis {
+	sensitive := SensitivePathsAnalysis{}
+	sensitive.Configure(map[string]interface{}{
+		ConfigSensitivePathsSampling:                    10,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"alice", "bob"},
+	})
+	sensitive.Initialize(nil)
+	return &sensitive
+}
+
+func TestSensitivePathsMeta(t *testing.T) {
+	sensitive := fixtureSensitivePaths()
+	assert.Equal(t, sensitive.Name(), "SensitivePaths")
+	assert.Len(t, sensitive.Provides(), 0)
+	assert.Equal(t, sensitive.Requires(), []string{
+		identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges,
+		items.DependencyFileDiff, items.DependencyBlobCache})
+	assert.Equal(t, sensitive.Flag(), "sensitive-paths")
+	assert.NotEmpty(t, sensitive.Description())
+	opts := sensitive.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Flag, "sensitive-paths-sampling")
+	assert.Equal(t, opts[1].Flag, "sensitive-paths-patterns")
+	assert.Equal(t, sensitive.Sampling, 10)
+	assert.Equal(t, sensitive.Patterns, DefaultSensitivePathsPatterns)
+	sensitive.Configure(map[string]interface{}{
+		ConfigSensitivePathsPatterns: []string{" secrets/ ", "", "*.key"}})
+	sensitive.Initialize(nil)
+	assert.Equal(t, sensitive.Patterns, []string{"secrets/", "*.key"})
+	sensitive = &SensitivePathsAnalysis{Patterns: []string{" "}}
+	sensitive.Initialize(nil)
+	assert.Equal(t, sensitive.Sampling, DefaultSensitivePathsSampling)
+	assert.Equal(t, sensitive.Patterns, DefaultSensitivePathsPatterns)
+	summoned := core.Registry.Summon(sensitive.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "SensitivePaths")
+}
+
+func TestSensitivePathsIsSensitive(t *testing.T) {
+	sensitive := fixtureSensitivePaths()
+	assert.True(t, sensitive.isSensitive("auth/login.go"))
+	assert.True(t, sensitive.isSensitive("lib/crypto/aes.go"))
+	assert.True(t, sensitive.isSensitive("deploy/main.tf"))
+	assert.True(t, sensitive.isSensitive("docker/Dockerfile"))
+	assert.True(t, sensitive.isSensitive(".github/workflows/ci.yml"))
+	assert.False(t, sensitive.isSensitive("author.go"))
+	assert.False(t, sensitive.isSensitive("Dockerfile.dev"))
+	assert.False(t, sensitive.isSensitive(""))
+}
+
+func fixtureSensitivePathsResult(t *testing.T) SensitivePathsResult {
+	hash, blob := storeExpertiseBlob(t, "one\ntwo\nthree\n")
+	binaryHash, binaryBlob := storeExpertiseBlob(t, "\x00\x01\x02")
+	cache := map[plumbing.Hash]*object.Blob{hash: blob, binaryHash: binaryBlob}
+	entry := func(name string, hash plumbing.Hash) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	sensitive := fixtureSensitivePaths()
+	consume := func(commit string, author, day int, merge bool, changes object.Changes,
+		fileDiffs map[string]items.FileDiffData) {
+		obj := &object.Commit{Hash: plumbing.NewHash(commit)}
+		if merge {
+			obj.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		result, err := sensitive.Consume(map[string]interface{}{
+			core.DependencyCommit:       obj,
+			core.DependencyIsMerge:      merge,
+			identity.DependencyAuthor:   author,
+			items.DependencyDay:         day,
+			items.DependencyTreeChanges: changes,
+			items.DependencyFileDiff:    fileDiffs,
+			items.DependencyBlobCache:   cache,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	consume(strings.Repeat("1", 40), 0, 0, false, object.Changes{
+		{To: entry("auth/login.go", hash)}, {To: entry("main.go", hash)},
+		{To: entry("Dockerfile", hash)}}, nil)
+	consume(strings.Repeat("2", 40), 1, 3, false, object.Changes{
+		{From: entry("auth/login.go", hash), To: entry("auth/login.go", hash)},
+		{From: entry("src/x.go", hash), To: entry("crypto/x.go", hash)},
+		{From: entry("main.go", hash), To: entry("main.go", hash)}},
+		map[string]items.FileDiffData{
+			"auth/login.go": {Diffs: []diffmatchpatch.Diff{
+				{Type: diffmatchpatch.DiffEqual, Text: "abc"},
+				{Type: diffmatchpatch.DiffInsert, Text: "d"}}},
+			"main.go": {Diffs: []diffmatchpatch.Diff{
+				{Type: diffmatchpatch.DiffDelete, Text: "abc"}}}})
+	consume(strings.Repeat("3", 40), 1, 5, true, object.Changes{
+		{From: entry("Dockerfile", hash), To: entry("Dockerfile", hash)}}, nil)
+	consume(strings.Repeat("4", 40), identity.AuthorMissing, 22, false, object.Changes{
+		{From: entry("deploy/main.tf", hash)}, {To: entry("keys/a.pem", binaryHash)}}, nil)
+	return sensitive.Finalize().(SensitivePathsResult)
+}
+
+func TestSensitivePathsConsumeFinalize(t *testing.T) {
+	result := fixtureSensitivePathsResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Patterns, DefaultSensitivePathsPatterns)
+	h1, h2, h4 := strings.Repeat("1", 40), strings.Repeat("2", 40), strings.Repeat("4", 40)
+	assert.Equal(t, result.Changes, []SensitivePathChange{
+		{Commit: h1, Author: 0, Tick: 0, File: "auth/login.go", Churn: 3},
+		{Commit: h1, Author: 0, Tick: 0, File: "Dockerfile", Churn: 3},
+		{Commit: h2, Author: 1, Tick: 0, File: "auth/login.go", Churn: 1},
+		{Commit: h2, Author: 1, Tick: 0, File: "crypto/x.go", Churn: 0},
+		{Commit: h4, Author: -1, Tick: 2, File: "deploy/main.tf", Churn: 3},
+		{Commit: h4, Author: -1, Tick: 2, File: "keys/a.pem", Churn: 0},
+	})
+	assert.Equal(t, result.Ticks(), []SensitivePathsTick{
+		{Commits: 2, Changes: 4, Churn: 7, Authors: 2},
+		{},
+		{Commits: 1, Changes: 2, Churn: 3, Authors: 1},
+	})
+}
+
+func TestSensitivePathsSerialize(t *testing.T) {
+	result := fixtureSensitivePathsResult(t)
+	sensitive := fixtureSensitivePaths()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, sensitive.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  patterns: ["auth/", "crypto/", "security/", "*.pem", "*.tf", "Dockerfile", ".github/workflows/"]
+  # -1 means an unmatched identity
+  changes:
+    - {commit: 1111111111111111111111111111111111111111, author: 0, tick: 0, file: "auth/login.go", churn: 3}
+    - {commit: 1111111111111111111111111111111111111111, author: 0, tick: 0, file: "Dockerfile", churn: 3}
+    - {commit: 2222222222222222222222222222222222222222, author: 1, tick: 0, file: "auth/login.go", churn: 1}
+    - {commit: 2222222222222222222222222222222222222222, author: 1, tick: 0, file: "crypto/x.go", churn: 0}
+    - {commit: 4444444444444444444444444444444444444444, author: -1, tick: 2, file: "deploy/main.tf", churn: 3}
+    - {commit: 4444444444444444444444444444444444444444, author: -1, tick: 2, file: "keys/a.pem", churn: 0}
+  ticks:
+    - {commits: 2, changes: 4, churn: 7, authors: 2}
+    - {commits: 0, changes: 0, churn: 0, authors: 0}
+    - {commits: 1, changes: 2, churn: 3, authors: 1}
+  people:
+  - "alice"
+  - "bob"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, sensitive.Serialize(result, true, buffer))
+	msg := pb.SensitivePathsAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Equal(t, msg.Patterns, DefaultSensitivePathsPatterns)
+	assert.Equal(t, msg.DevIndex, []string{"alice", "bob"})
+	assert.Len(t, msg.Changes, 6)
+	assert.Equal(t, msg.Changes[4].Author, int32(-1))
+	assert.Equal(t, msg.Changes[4].File, "deploy/main.tf")
+	deserialized, err := sensitive.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestSensitivePathsMergeResults(t *testing.T) {
+	r1 := SensitivePathsResult{
+		Changes: []SensitivePathChange{
+			{Commit: "a", Author: 0, Tick: 0, File: "auth/a.go", Churn: 5},
+			{Commit: "b", Author: -1, Tick: 3, File: "auth/a.go", Churn: 2}},
+		Sampling:           10,
+		Patterns:           []string{"auth/"},
+		reversedPeopleDict: []string{"alice"},
+	}
+	r2 := SensitivePathsResult{
+		Changes: []SensitivePathChange{
+			{Commit: "c", Author: 0, Tick: 0, File: "main.tf", Churn: 1}},
+		Sampling:           20,
+		Patterns:           []string{"*.tf", "auth/"},
+		reversedPeopleDict: []string{"bob", "alice"},
+	}
+	sensitive := fixtureSensitivePaths()
+	merged := sensitive.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 20 * 24 * 3600}).(SensitivePathsResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.Patterns, []string{"auth/", "*.tf"})
+	assert.Equal(t, merged.reversedPeopleDict, []string{"alice", "bob"})
+	assert.Equal(t, merged.Changes, []SensitivePathChange{
+		{Commit: "a", Author: 0, Tick: 0, File: "auth/a.go", Churn: 5},
+		{Commit: "b", Author: -1, Tick: 1, File: "auth/a.go", Churn: 2},
+		{Commit: "c", Author: 1, Tick: 1, File: "main.tf", Churn: 1},
+	})
+	assert.Equal(t, r1.Changes[1].Tick, 3)
+}
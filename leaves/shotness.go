@@ -5,6 +5,7 @@ import (
 	"io"
 	"log"
 	"sort"
+	"strings"
 	"unicode/utf8"
 
 	"github.com/gogo/protobuf/proto"
@@ -26,6 +27,16 @@ type ShotnessAnalysis struct {
 	core.OneShotMergeProcessor
 	XpathStruct string
 	XpathName   string
+	// LanguageXpathStruct and LanguageXpathName override XpathStruct and XpathName for
+	// the files in specific languages, e.g. to track the classes in Java or the statements
+	// in SQL. The keys are the lower case language names as detected by enry.
+	LanguageXpathStruct map[string]string
+	LanguageXpathName   map[string]string
+	Fallback            bool
+	// IdentityThreshold is the minimum similarity of the bodies in percent for a unit which
+	// disappeared and a unit which appeared in the same commit to be considered the same
+	// renamed or moved unit. 0 disables the identity tracking.
+	IdentityThreshold int
 
 	nodes map[string]*nodeShotness
 	files map[string]map[string]*nodeShotness
@@ -39,6 +50,25 @@ const (
 	// which sets the UAST XPath to find the name of the nodes chosen by ConfigShotnessXpathStruct.
 	// These XPath-s can be different for some languages.
 	ConfigShotnessXpathName = "Shotness.XpathName"
+	// ConfigShotnessLanguageXpathStruct is the name of the configuration option
+	// (ShotnessAnalysis.Configure()) which overrides ConfigShotnessXpathStruct for specific
+	// languages. The values are "language=xpath".
+	ConfigShotnessLanguageXpathStruct = "Shotness.LanguageXpathStruct"
+	// ConfigShotnessLanguageXpathName is the name of the configuration option
+	// (ShotnessAnalysis.Configure()) which overrides ConfigShotnessXpathName for specific
+	// languages. The values are "language=xpath".
+	ConfigShotnessLanguageXpathName = "Shotness.LanguageXpathName"
+	// ConfigShotnessFallback is the name of the configuration option (ShotnessAnalysis.Configure())
+	// which counts the files without UASTs, e.g. in the languages which the parser does not
+	// support, as single structural units.
+	ConfigShotnessFallback = "Shotness.Fallback"
+	// ConfigShotnessIdentityThreshold is the name of the configuration option
+	// (ShotnessAnalysis.Configure()) which sets the body similarity threshold to track
+	// the renamed and the moved units.
+	ConfigShotnessIdentityThreshold = "Shotness.IdentityThreshold"
+	// ShotnessFallbackRole is NodeSummary.InternalRole of the whole files which are counted
+	// with ConfigShotnessFallback.
+	ShotnessFallbackRole = "File"
 
 	// DefaultShotnessXpathStruct is the default UAST XPath to choose the analysed nodes.
 	// It extracts functions.
@@ -46,6 +76,12 @@ const (
 	// DefaultShotnessXpathName is the default UAST XPath to choose the names of the analysed nodes.
 	// It looks at the current tree level and at the immediate children.
 	DefaultShotnessXpathName = "/*[@roleFunction and @roleIdentifier and @roleName] | /*/*[@roleFunction and @roleIdentifier and @roleName]"
+	// DefaultShotnessIdentityThreshold is the default body similarity in percent to track
+	// the renamed and the moved units.
+	DefaultShotnessIdentityThreshold = 80
+	// shotnessIdentityMinNodes is the minimum number of the UAST nodes in a unit's body
+	// to track its identity. The smaller bodies are too similar to each other.
+	shotnessIdentityMinNodes = 5
 )
 
 type nodeShotness struct {
@@ -111,7 +147,33 @@ func (shotness *ShotnessAnalysis) ListConfigurationOptions() []core.Configuratio
 		Description: "UAST XPath query to determine the names of the filtered nodes.",
 		Flag:        "shotness-xpath-name",
 		Type:        core.StringConfigurationOption,
-		Default:     DefaultShotnessXpathName},
+		Default:     DefaultShotnessXpathName}, {
+		Name: ConfigShotnessLanguageXpathStruct,
+		Description: "UAST XPath queries to use for filtering the nodes in specific languages " +
+			"instead of --shotness-xpath-struct, e.g. \"Java=//*[@roleType and @roleDeclaration]\". " +
+			"Separated with commas \",\"; quote the queries which contain commas.",
+		Flag:    "shotness-xpath-struct-lang",
+		Type:    core.StringsConfigurationOption,
+		Default: []string{}}, {
+		Name: ConfigShotnessLanguageXpathName,
+		Description: "UAST XPath queries to determine the names of the filtered nodes in " +
+			"specific languages instead of --shotness-xpath-name, in the same format as " +
+			"--shotness-xpath-struct-lang.",
+		Flag:    "shotness-xpath-name-lang",
+		Type:    core.StringsConfigurationOption,
+		Default: []string{}}, {
+		Name: ConfigShotnessFallback,
+		Description: "Count the files which could not be parsed as whole structural units " +
+			"instead of skipping them.",
+		Flag:    "shotness-fallback",
+		Type:    core.BoolConfigurationOption,
+		Default: false}, {
+		Name: ConfigShotnessIdentityThreshold,
+		Description: "Minimum similarity of the bodies in percent to keep the history of " +
+			"the renamed and the moved units. 0 disables the tracking.",
+		Flag:    "shotness-identity-threshold",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultShotnessIdentityThreshold},
 	}
 	return opts[:]
 }
@@ -141,11 +203,53 @@ func (shotness *ShotnessAnalysis) Configure(facts map[string]interface{}) {
 	} else {
 		shotness.XpathName = DefaultShotnessXpathName
 	}
+	if val, exists := facts[ConfigShotnessLanguageXpathStruct].([]string); exists {
+		shotness.LanguageXpathStruct = parseLanguageXpaths(val)
+	}
+	if val, exists := facts[ConfigShotnessLanguageXpathName].([]string); exists {
+		shotness.LanguageXpathName = parseLanguageXpaths(val)
+	}
+	if val, exists := facts[ConfigShotnessFallback].(bool); exists {
+		shotness.Fallback = val
+	}
+	if val, exists := facts[ConfigShotnessIdentityThreshold].(int); exists {
+		shotness.IdentityThreshold = val
+	} else {
+		shotness.IdentityThreshold = DefaultShotnessIdentityThreshold
+	}
+}
+
+// parseLanguageXpaths converts the "language=xpath" pairs to the mapping from the lower case
+// languages to the XPath-s. The invalid pairs are skipped with a warning.
+func parseLanguageXpaths(values []string) map[string]string {
+	result := map[string]string{}
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		pos := strings.IndexByte(value, '=')
+		lang := ""
+		if pos > 0 {
+			lang = strings.ToLower(strings.TrimSpace(value[:pos]))
+		}
+		if lang == "" || strings.TrimSpace(value[pos+1:]) == "" {
+			log.Printf("Warning: skipped the invalid language XPath %s, "+
+				"the format is language=xpath\n", value)
+			continue
+		}
+		result[lang] = strings.TrimSpace(value[pos+1:])
+	}
+	return result
 }
 
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (shotness *ShotnessAnalysis) Initialize(repository *git.Repository) {
+	if shotness.IdentityThreshold < 0 || shotness.IdentityThreshold > 100 {
+		log.Printf("Warning: adjusted the shotness identity threshold to %d\n",
+			DefaultShotnessIdentityThreshold)
+		shotness.IdentityThreshold = DefaultShotnessIdentityThreshold
+	}
 	shotness.nodes = map[string]*nodeShotness{}
 	shotness.files = map[string]map[string]*nodeShotness{}
 	shotness.OneShotMergeProcessor.Initialize()
@@ -164,14 +268,12 @@ func (shotness *ShotnessAnalysis) Consume(deps map[string]interface{}) (map[stri
 	changesList := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
 	diffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
 	allNodes := map[string]bool{}
+	// the units which disappeared and appeared in this commit, to track their identity
+	var vanished, appeared []nodeIdentity
+	var deletedFiles []string
 
 	addNode := func(name string, node *uast.Node, fileName string) {
-		nodeSummary := NodeSummary{
-			InternalRole: node.InternalType,
-			Roles:        node.Roles,
-			Name:         name,
-			File:         fileName,
-		}
+		nodeSummary := newNodeSummary(name, node, fileName)
 		key := nodeSummary.String()
 		exists := allNodes[key]
 		allNodes[key] = true
@@ -194,21 +296,28 @@ func (shotness *ShotnessAnalysis) Consume(deps map[string]interface{}) (map[stri
 	}
 
 	for _, change := range changesList {
+		if change.After == nil && change.Change.To.Name != "" {
+			// the file exists but could not be parsed, keep its nodes
+			if shotness.Fallback {
+				addNode("", &uast.Node{InternalType: ShotnessFallbackRole}, change.Change.To.Name)
+			}
+			continue
+		}
 		if change.After == nil {
-			for key, summary := range shotness.files[change.Change.From.Name] {
-				for subkey := range summary.Couples {
-					delete(shotness.nodes[subkey].Couples, key)
+			fromName := change.Change.From.Name
+			if shotness.IdentityThreshold > 0 && change.Before != nil {
+				nodes, err := shotness.extractNodes(change.Before, fromName)
+				if err == nil {
+					vanished = appendNodeIdentities(vanished, nodes, nil, fromName)
 				}
 			}
-			for key := range shotness.files[change.Change.From.Name] {
-				delete(shotness.nodes, key)
-			}
-			delete(shotness.files, change.Change.From.Name)
+			// the units may move to other files, so the file is deleted in the end
+			deletedFiles = append(deletedFiles, fromName)
 			continue
 		}
 		toName := change.Change.To.Name
 		if change.Before == nil {
-			nodes, err := shotness.extractNodes(change.After)
+			nodes, err := shotness.extractNodes(change.After, toName)
 			if err != nil {
 				log.Printf("Shotness: commit %s file %s failed to filter UAST: %s\n",
 					commit.Hash.String(), toName, err.Error())
@@ -217,6 +326,9 @@ func (shotness *ShotnessAnalysis) Consume(deps map[string]interface{}) (map[stri
 			for name, node := range nodes {
 				addNode(name, node, toName)
 			}
+			if shotness.IdentityThreshold > 0 {
+				appeared = appendNodeIdentities(appeared, nodes, nil, toName)
+			}
 			continue
 		}
 		// Before -> After
@@ -244,58 +356,27 @@ func (shotness *ShotnessAnalysis) Consume(deps map[string]interface{}) (map[stri
 		}
 		// pass through old UAST
 		// pass through new UAST
-		nodesBefore, err := shotness.extractNodes(change.Before)
+		nodesBefore, err := shotness.extractNodes(change.Before, change.Change.From.Name)
 		if err != nil {
 			log.Printf("Shotness: commit ^%s file %s failed to filter UAST: %s\n",
 				commit.Hash.String(), change.Change.From.Name, err.Error())
 			continue
 		}
 		reversedNodesBefore := reverseNodeMap(nodesBefore)
-		nodesAfter, err := shotness.extractNodes(change.After)
+		nodesAfter, err := shotness.extractNodes(change.After, toName)
 		if err != nil {
 			log.Printf("Shotness: commit %s file %s failed to filter UAST: %s\n",
 				commit.Hash.String(), toName, err.Error())
 			continue
 		}
 		reversedNodesAfter := reverseNodeMap(nodesAfter)
-		genLine2Node := func(nodes map[string]*uast.Node, linesNum int) [][]*uast.Node {
-			res := make([][]*uast.Node, linesNum)
-			for _, node := range nodes {
-				if node.StartPosition == nil {
-					continue
-				}
-				startLine := node.StartPosition.Line
-				endLine := node.StartPosition.Line
-				if node.EndPosition != nil && node.EndPosition.Line > node.StartPosition.Line {
-					endLine = node.EndPosition.Line
-				} else {
-					// we need to determine node.EndPosition.Line
-					uast_items.VisitEachNode(node, func(child *uast.Node) {
-						if child.StartPosition != nil {
-							candidate := child.StartPosition.Line
-							if child.EndPosition != nil {
-								candidate = child.EndPosition.Line
-							}
-							if candidate > endLine {
-								endLine = candidate
-							}
-						}
-					})
-				}
-				for l := startLine; l <= endLine; l++ {
-					lineNodes := res[l-1]
-					if lineNodes == nil {
-						lineNodes = []*uast.Node{}
-					}
-					lineNodes = append(lineNodes, node)
-					res[l-1] = lineNodes
-				}
-			}
-			return res
+		if shotness.IdentityThreshold > 0 {
+			vanished = appendNodeIdentities(vanished, nodesBefore, nodesAfter, toName)
+			appeared = appendNodeIdentities(appeared, nodesAfter, nodesBefore, toName)
 		}
 		diff := diffs[toName]
-		line2nodeBefore := genLine2Node(nodesBefore, diff.OldLinesOfCode)
-		line2nodeAfter := genLine2Node(nodesAfter, diff.NewLinesOfCode)
+		line2nodeBefore := nodesByLine(nodesBefore, diff.OldLinesOfCode)
+		line2nodeAfter := nodesByLine(nodesAfter, diff.NewLinesOfCode)
 		// Scan through all the edits. Given the line numbers, get the list of active nodes
 		// and add them.
 		var lineNumBefore, lineNumAfter int
@@ -325,6 +406,21 @@ func (shotness *ShotnessAnalysis) Consume(deps map[string]interface{}) (map[stri
 			}
 		}
 	}
+	identities := matchNodeIdentities(vanished, appeared, shotness.IdentityThreshold)
+	for _, oldKey := range sortedNodeIdentities(identities) {
+		shotness.inherit(oldKey, identities[oldKey], allNodes)
+	}
+	for _, name := range deletedFiles {
+		for key, summary := range shotness.files[name] {
+			for subkey := range summary.Couples {
+				delete(shotness.nodes[subkey].Couples, key)
+			}
+		}
+		for key := range shotness.files[name] {
+			delete(shotness.nodes, key)
+		}
+		delete(shotness.files, name)
+	}
 	for keyi := range allNodes {
 		for keyj := range allNodes {
 			if keyi == keyj {
@@ -336,6 +432,48 @@ func (shotness *ShotnessAnalysis) Consume(deps map[string]interface{}) (map[stri
 	return nil, nil
 }
 
+// inherit transfers the history of the unit which was renamed or moved to its new summary.
+// `allNodes` are the units changed in the current commit.
+func (shotness *ShotnessAnalysis) inherit(oldKey string, summary NodeSummary, allNodes map[string]bool) {
+	old := shotness.nodes[oldKey]
+	newKey := summary.String()
+	if old == nil || oldKey == newKey {
+		return
+	}
+	delete(shotness.nodes, oldKey)
+	delete(shotness.files[old.Summary.File], oldKey)
+	delete(old.Couples, newKey)
+	for coupleKey, count := range old.Couples {
+		coupleCouples := shotness.nodes[coupleKey].Couples
+		delete(coupleCouples, oldKey)
+		coupleCouples[newKey] += count
+	}
+	if ns := shotness.nodes[newKey]; ns != nil {
+		delete(ns.Couples, oldKey)
+		ns.Count += old.Count
+		if allNodes[oldKey] && allNodes[newKey] {
+			// the same unit cannot be changed twice in a commit
+			ns.Count--
+		}
+		for coupleKey, count := range old.Couples {
+			ns.Couples[coupleKey] += count
+		}
+	} else {
+		old.Summary = summary
+		shotness.nodes[newKey] = old
+		fmap := shotness.files[summary.File]
+		if fmap == nil {
+			fmap = map[string]*nodeShotness{}
+			shotness.files[summary.File] = fmap
+		}
+		fmap[newKey] = old
+	}
+	if allNodes[oldKey] {
+		delete(allNodes, oldKey)
+		allNodes[newKey] = true
+	}
+}
+
 // Fork clones this PipelineItem.
 func (shotness *ShotnessAnalysis) Fork(n int) []core.PipelineItem {
 	return core.ForkSamePipelineItem(shotness, n)
@@ -382,6 +520,47 @@ func (shotness *ShotnessAnalysis) Serialize(result interface{}, binary bool, wri
 	return nil
 }
 
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (shotness *ShotnessAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(shotness, result, &pb.ShotnessAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (shotness *ShotnessAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(shotness, result, &pb.ShotnessAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to ShotnessResult.
+func (shotness *ShotnessAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.ShotnessAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := ShotnessResult{
+		Nodes:    make([]NodeSummary, len(message.Records)),
+		Counters: make([]map[int]int, len(message.Records)),
+	}
+	for i, record := range message.Records {
+		summary := NodeSummary{
+			InternalRole: record.InternalRole,
+			Roles:        make([]uast.Role, len(record.Roles)),
+			Name:         record.Name,
+			File:         record.File,
+		}
+		for j, r := range record.Roles {
+			summary.Roles[j] = uast.Role(r)
+		}
+		result.Nodes[i] = summary
+		counters := map[int]int{}
+		for key, val := range record.Counters {
+			counters[int(key)] = int(val)
+		}
+		result.Counters[i] = counters
+	}
+	return result, nil
+}
+
 func (shotness *ShotnessAnalysis) serializeText(result *ShotnessResult, writer io.Writer) {
 	for i, summary := range result.Nodes {
 		fmt.Fprintf(writer, "  - name: %s\n    file: %s\n    internal_role: %s\n    roles: [",
@@ -441,8 +620,26 @@ func (shotness *ShotnessAnalysis) serializeBinary(result *ShotnessResult, writer
 	return nil
 }
 
-func (shotness *ShotnessAnalysis) extractNodes(root *uast.Node) (map[string]*uast.Node, error) {
-	structs, err := tools.Filter(root, shotness.XpathStruct)
+// xpaths returns the XPath-s to select the nodes and their names in the specified file.
+func (shotness *ShotnessAnalysis) xpaths(fileName string) (xpathStruct, xpathName string) {
+	xpathStruct, xpathName = shotness.XpathStruct, shotness.XpathName
+	if len(shotness.LanguageXpathStruct) == 0 && len(shotness.LanguageXpathName) == 0 {
+		return
+	}
+	lang := strings.ToLower(items.DetectLanguage(fileName, nil))
+	if val, exists := shotness.LanguageXpathStruct[lang]; exists {
+		xpathStruct = val
+	}
+	if val, exists := shotness.LanguageXpathName[lang]; exists {
+		xpathName = val
+	}
+	return
+}
+
+func (shotness *ShotnessAnalysis) extractNodes(
+	root *uast.Node, fileName string) (map[string]*uast.Node, error) {
+	xpathStruct, xpathName := shotness.xpaths(fileName)
+	structs, err := tools.Filter(root, xpathStruct)
 	if err != nil {
 		return nil, err
 	}
@@ -453,7 +650,7 @@ func (shotness *ShotnessAnalysis) extractNodes(root *uast.Node) (map[string]*uas
 		if internal[mainNode] {
 			continue
 		}
-		subs, err := tools.Filter(mainNode, shotness.XpathStruct)
+		subs, err := tools.Filter(mainNode, xpathStruct)
 		if err != nil {
 			return nil, err
 		}
@@ -468,7 +665,7 @@ func (shotness *ShotnessAnalysis) extractNodes(root *uast.Node) (map[string]*uas
 		if internal[node] {
 			continue
 		}
-		nodeNames, err := tools.Filter(node, shotness.XpathName)
+		nodeNames, err := tools.Filter(node, xpathName)
 		if err != nil {
 			return nil, err
 		}
@@ -480,6 +677,152 @@ func (shotness *ShotnessAnalysis) extractNodes(root *uast.Node) (map[string]*uas
 	return res, nil
 }
 
+// newNodeSummary creates the NodeSummary of the structural unit.
+func newNodeSummary(name string, node *uast.Node, fileName string) NodeSummary {
+	return NodeSummary{
+		InternalRole: node.InternalType,
+		Roles:        node.Roles,
+		Name:         name,
+		File:         fileName,
+	}
+}
+
+// nodeIdentity is a structural unit which disappeared or appeared in a commit.
+type nodeIdentity struct {
+	Summary NodeSummary
+	// Body counts the internal types and the tokens in the subtree except the name.
+	Body map[string]int
+	Size int
+}
+
+// appendNodeIdentities adds the units in `nodes` which are absent in `others` to `identities`.
+func appendNodeIdentities(
+	identities []nodeIdentity, nodes, others map[string]*uast.Node, fileName string) []nodeIdentity {
+	names := make([]string, 0, len(nodes))
+	for name := range nodes {
+		if others[name] == nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		identity := nodeIdentity{Summary: newNodeSummary(name, nodes[name], fileName), Body: map[string]int{}}
+		uast_items.VisitEachNode(nodes[name], func(child *uast.Node) {
+			if child.Token == name && name != "" {
+				return
+			}
+			identity.Body[child.InternalType+":"+child.Token]++
+			identity.Size++
+		})
+		if identity.Size >= shotnessIdentityMinNodes {
+			identities = append(identities, identity)
+		}
+	}
+	return identities
+}
+
+// matchNodeIdentities pairs the units which disappeared with the units which appeared by
+// the similarity of their bodies: the percentage of the common nodes. The most similar pairs
+// win. Returns the mapping from the keys of the disappeared units to the summaries
+// of the appeared ones.
+func matchNodeIdentities(vanished, appeared []nodeIdentity, threshold int) map[string]NodeSummary {
+	result := map[string]NodeSummary{}
+	if threshold <= 0 || len(vanished) == 0 || len(appeared) == 0 {
+		return result
+	}
+	type candidate struct {
+		Old, New   int
+		Similarity int
+	}
+	var candidates []candidate
+	for i, before := range vanished {
+		for j, after := range appeared {
+			if before.Summary.InternalRole != after.Summary.InternalRole {
+				continue
+			}
+			common := 0
+			for item, count := range before.Body {
+				if afterCount := after.Body[item]; afterCount < count {
+					common += afterCount
+				} else {
+					common += count
+				}
+			}
+			similarity := common * 200 / (before.Size + after.Size)
+			if similarity >= threshold {
+				candidates = append(candidates, candidate{Old: i, New: j, Similarity: similarity})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Similarity > candidates[j].Similarity
+	})
+	usedNew := map[int]bool{}
+	for _, c := range candidates {
+		oldKey := vanished[c.Old].Summary.String()
+		if _, exists := result[oldKey]; exists || usedNew[c.New] {
+			continue
+		}
+		result[oldKey] = appeared[c.New].Summary
+		usedNew[c.New] = true
+	}
+	return result
+}
+
+// sortedNodeIdentities returns the keys of the result of matchNodeIdentities() in a stable order.
+func sortedNodeIdentities(identities map[string]NodeSummary) []string {
+	keys := make([]string, 0, len(identities))
+	for key := range identities {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// nodeLines returns the first and the last line of the node. The last line is inferred from
+// the children if the node does not have the end position.
+func nodeLines(node *uast.Node) (uint32, uint32) {
+	startLine := node.StartPosition.Line
+	endLine := node.StartPosition.Line
+	if node.EndPosition != nil && node.EndPosition.Line > node.StartPosition.Line {
+		endLine = node.EndPosition.Line
+	} else {
+		// we need to determine node.EndPosition.Line
+		uast_items.VisitEachNode(node, func(child *uast.Node) {
+			if child.StartPosition != nil {
+				candidate := child.StartPosition.Line
+				if child.EndPosition != nil {
+					candidate = child.EndPosition.Line
+				}
+				if candidate > endLine {
+					endLine = candidate
+				}
+			}
+		})
+	}
+	return startLine, endLine
+}
+
+// nodesByLine maps each line of the file to the nodes which span it.
+func nodesByLine(nodes map[string]*uast.Node, linesNum int) [][]*uast.Node {
+	res := make([][]*uast.Node, linesNum)
+	for _, node := range nodes {
+		if node.StartPosition == nil {
+			continue
+		}
+		startLine, endLine := nodeLines(node)
+		for l := startLine; l <= endLine; l++ {
+			lineNodes := res[l-1]
+			if lineNodes == nil {
+				lineNodes = []*uast.Node{}
+			}
+			lineNodes = append(lineNodes, node)
+			res[l-1] = lineNodes
+		}
+	}
+	return res
+}
+
 func reverseNodeMap(nodes map[string]*uast.Node) map[*uast.Node]string {
 	res := map[*uast.Node]string{}
 	for key, node := range nodes {
@@ -36,23 +36,141 @@ func TestShotnessMeta(t *testing.T) {
 	assert.Equal(t, len(sh.Requires()), 2)
 	assert.Equal(t, sh.Requires()[0], items.DependencyFileDiff)
 	assert.Equal(t, sh.Requires()[1], uast_items.DependencyUastChanges)
-	assert.Len(t, sh.ListConfigurationOptions(), 2)
+	assert.Len(t, sh.ListConfigurationOptions(), 6)
 	assert.Equal(t, sh.ListConfigurationOptions()[0].Name, ConfigShotnessXpathStruct)
 	assert.Equal(t, sh.ListConfigurationOptions()[1].Name, ConfigShotnessXpathName)
+	assert.Equal(t, sh.ListConfigurationOptions()[2].Name, ConfigShotnessLanguageXpathStruct)
+	assert.Equal(t, sh.ListConfigurationOptions()[3].Name, ConfigShotnessLanguageXpathName)
+	assert.Equal(t, sh.ListConfigurationOptions()[4].Name, ConfigShotnessFallback)
+	assert.Equal(t, sh.ListConfigurationOptions()[5].Name, ConfigShotnessIdentityThreshold)
 	sh.Configure(nil)
 	assert.Equal(t, sh.XpathStruct, DefaultShotnessXpathStruct)
 	assert.Equal(t, sh.XpathName, DefaultShotnessXpathName)
+	assert.Equal(t, sh.IdentityThreshold, DefaultShotnessIdentityThreshold)
 	facts := map[string]interface{}{}
 	facts[ConfigShotnessXpathStruct] = "xpath!"
 	facts[ConfigShotnessXpathName] = "another!"
+	facts[ConfigShotnessLanguageXpathStruct] = []string{"Java=//*[@roleType]"}
+	facts[ConfigShotnessLanguageXpathName] = []string{"SQL=/*[@roleIdentifier]"}
+	facts[ConfigShotnessFallback] = true
+	facts[ConfigShotnessIdentityThreshold] = 50
 	sh.Configure(facts)
+	assert.Equal(t, sh.IdentityThreshold, 50)
 	assert.Equal(t, sh.XpathStruct, "xpath!")
 	assert.Equal(t, sh.XpathName, "another!")
+	assert.Equal(t, sh.LanguageXpathStruct, map[string]string{"java": "//*[@roleType]"})
+	assert.Equal(t, sh.LanguageXpathName, map[string]string{"sql": "/*[@roleIdentifier]"})
+	assert.True(t, sh.Fallback)
 	features := sh.Features()
 	assert.Len(t, features, 1)
 	assert.Equal(t, features[0], uast_items.FeatureUast)
 }
 
+func TestShotnessLanguageXpaths(t *testing.T) {
+	assert.Equal(t, parseLanguageXpaths([]string{
+		" Java = //*[@roleType and @roleDeclaration]", "", "=//*", "Go=", "nothing",
+		"Python=//*[@token='a=b']",
+	}), map[string]string{
+		"java":   "//*[@roleType and @roleDeclaration]",
+		"python": "//*[@token='a=b']",
+	})
+	sh := fixtureShotness()
+	xpathStruct, xpathName := sh.xpaths("Main.java")
+	assert.Equal(t, xpathStruct, DefaultShotnessXpathStruct)
+	assert.Equal(t, xpathName, DefaultShotnessXpathName)
+	sh.LanguageXpathStruct = map[string]string{"java": "classes"}
+	sh.LanguageXpathName = map[string]string{"python": "names"}
+	xpathStruct, xpathName = sh.xpaths("src/Main.java")
+	assert.Equal(t, xpathStruct, "classes")
+	assert.Equal(t, xpathName, DefaultShotnessXpathName)
+	xpathStruct, xpathName = sh.xpaths("app.py")
+	assert.Equal(t, xpathStruct, DefaultShotnessXpathStruct)
+	assert.Equal(t, xpathName, "names")
+	xpathStruct, xpathName = sh.xpaths("main.go")
+	assert.Equal(t, xpathStruct, DefaultShotnessXpathStruct)
+	assert.Equal(t, xpathName, DefaultShotnessXpathName)
+}
+
+func fixtureShotnessIdentityNode(name string, tokens ...string) *uast.Node {
+	node := &uast.Node{InternalType: "FunctionDef", Children: []*uast.Node{
+		{InternalType: "Name", Token: name}}}
+	for _, token := range tokens {
+		node.Children = append(node.Children, &uast.Node{InternalType: "Call", Token: token})
+	}
+	return node
+}
+
+func TestShotnessMatchNodeIdentities(t *testing.T) {
+	body := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i"}
+	vanished := appendNodeIdentities(nil, map[string]*uast.Node{
+		"foo":   fixtureShotnessIdentityNode("foo", body...),
+		"bar":   fixtureShotnessIdentityNode("bar", "x", "y", "z", "w"),
+		"small": fixtureShotnessIdentityNode("small"),
+		"same":  fixtureShotnessIdentityNode("same", body...),
+	}, map[string]*uast.Node{"same": fixtureShotnessIdentityNode("same")}, "a.py")
+	assert.Len(t, vanished, 2)
+	assert.Equal(t, vanished[0].Summary, NodeSummary{
+		InternalRole: "FunctionDef", Name: "bar", File: "a.py"})
+	// the name is excluded
+	assert.Equal(t, vanished[0].Size, 5)
+	assert.Equal(t, vanished[1].Body["Call:a"], 1)
+	appeared := appendNodeIdentities(nil, map[string]*uast.Node{
+		// recursive call
+		"foo2": fixtureShotnessIdentityNode("foo2", append(body, "foo2")...),
+		"foo3": fixtureShotnessIdentityNode("foo3", body[:5]...),
+		"baz":  fixtureShotnessIdentityNode("baz", "x", "y", "q", "r"),
+	}, nil, "b.py")
+	assert.Len(t, appeared, 3)
+	identities := matchNodeIdentities(vanished, appeared, 80)
+	assert.Equal(t, identities, map[string]NodeSummary{
+		"FunctionDef_foo_a.py": {InternalRole: "FunctionDef", Name: "foo2", File: "b.py"}})
+	identities = matchNodeIdentities(vanished, appeared, 50)
+	assert.Len(t, identities, 2)
+	assert.Equal(t, identities["FunctionDef_bar_a.py"].Name, "baz")
+	assert.Equal(t, sortedNodeIdentities(identities), []string{
+		"FunctionDef_bar_a.py", "FunctionDef_foo_a.py"})
+	assert.Len(t, matchNodeIdentities(vanished, appeared, 0), 0)
+	assert.Len(t, matchNodeIdentities(nil, appeared, 80), 0)
+}
+
+func TestShotnessInherit(t *testing.T) {
+	sh := fixtureShotness()
+	summary := func(name, file string) NodeSummary {
+		return NodeSummary{InternalRole: "FunctionDef", Name: name, File: file}
+	}
+	add := func(s NodeSummary, count int, couples map[string]int) {
+		ns := &nodeShotness{Summary: s, Count: count, Couples: couples}
+		sh.nodes[s.String()] = ns
+		if sh.files[s.File] == nil {
+			sh.files[s.File] = map[string]*nodeShotness{}
+		}
+		sh.files[s.File][s.String()] = ns
+	}
+	foo, bar, baz := summary("foo", "a.py"), summary("bar", "a.py"), summary("baz", "b.py")
+	add(foo, 3, map[string]int{bar.String(): 2})
+	add(bar, 2, map[string]int{foo.String(): 2})
+	// foo is renamed to foo2 in b.py and changed in the same commit
+	foo2 := summary("foo2", "b.py")
+	add(foo2, 1, map[string]int{})
+	allNodes := map[string]bool{foo.String(): true, foo2.String(): true}
+	sh.inherit(foo.String(), foo2, allNodes)
+	assert.Equal(t, allNodes, map[string]bool{foo2.String(): true})
+	assert.Nil(t, sh.nodes[foo.String()])
+	assert.Len(t, sh.files["a.py"], 1)
+	assert.Equal(t, sh.nodes[foo2.String()].Count, 3)
+	assert.Equal(t, sh.nodes[foo2.String()].Couples, map[string]int{bar.String(): 2})
+	assert.Equal(t, sh.nodes[bar.String()].Couples, map[string]int{foo2.String(): 2})
+	// bar is moved to baz which did not change
+	sh.inherit(bar.String(), baz, allNodes)
+	assert.Len(t, sh.files["a.py"], 0)
+	assert.Equal(t, sh.files["b.py"][baz.String()].Summary, baz)
+	assert.Equal(t, sh.nodes[baz.String()].Count, 2)
+	assert.Equal(t, sh.nodes[foo2.String()].Couples, map[string]int{baz.String(): 2})
+	// nothing to inherit
+	sh.inherit("missing", foo, allNodes)
+	assert.Len(t, sh.nodes, 2)
+}
+
 func TestShotnessRegistration(t *testing.T) {
 	summoned := core.Registry.Summon((&ShotnessAnalysis{}).Name())
 	assert.Len(t, summoned, 1)
@@ -209,6 +327,41 @@ func TestShotnessConsume(t *testing.T) {
 	assert.Len(t, sh.files, 0)
 }
 
+func TestShotnessFallback(t *testing.T) {
+	sh := fixtureShotness()
+	sh.Fallback = true
+	state := map[string]interface{}{
+		core.DependencyCommit:    &object.Commit{},
+		items.DependencyFileDiff: map[string]items.FileDiffData{},
+	}
+	consume := func(changes ...uast_items.Change) {
+		state[uast_items.DependencyUastChanges] = changes
+		result, err := sh.Consume(state)
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	unparsed := func(from, to string) uast_items.Change {
+		return uast_items.Change{Change: &object.Change{
+			From: object.ChangeEntry{Name: from}, To: object.ChangeEntry{Name: to}}}
+	}
+	consume(unparsed("", "a.cob"), unparsed("", "b.cob"))
+	consume(unparsed("a.cob", "a.cob"))
+	result := sh.Finalize().(ShotnessResult)
+	assert.Equal(t, result.Nodes, []NodeSummary{
+		{InternalRole: ShotnessFallbackRole, File: "a.cob"},
+		{InternalRole: ShotnessFallbackRole, File: "b.cob"}})
+	assert.Equal(t, result.Counters, []map[int]int{{0: 2, 1: 1}, {0: 1, 1: 1}})
+	// the deletion removes the node
+	consume(unparsed("b.cob", ""))
+	result = sh.Finalize().(ShotnessResult)
+	assert.Len(t, result.Nodes, 1)
+	// without the fallback the unparsed files are skipped but their nodes are kept
+	sh.Fallback = false
+	consume(unparsed("a.cob", "a.cob"))
+	result = sh.Finalize().(ShotnessResult)
+	assert.Equal(t, result.Counters, []map[int]int{{0: 2}})
+}
+
 func TestShotnessFork(t *testing.T) {
 	sh1 := fixtureShotness()
 	clones := sh1.Fork(1)
@@ -332,3 +485,21 @@ func TestShotnessSerializeBinary(t *testing.T) {
 	assert.Equal(t, message.Records[14].Name, "testUnpackEntryFromStreamToFile")
 	assert.Equal(t, message.Records[14].Counters, map[int32]int32{14: 1, 13: 1})
 }
+
+func TestShotnessDeserialize(t *testing.T) {
+	sh := &ShotnessAnalysis{}
+	result := ShotnessResult{
+		Nodes: []NodeSummary{
+			{InternalRole: "MethodDeclaration", Roles: []uast.Role{111, 59}, Name: "one", File: "a.java"},
+			{InternalRole: "MethodDeclaration", Roles: []uast.Role{111}, Name: "two", File: "a.java"},
+		},
+		Counters: []map[int]int{{0: 3, 1: 2}, {0: 2, 1: 2}},
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, sh.Serialize(result, true, buffer))
+	deserialized, err := sh.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized.(ShotnessResult), result)
+	_, err = sh.Deserialize([]byte("garbage"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,414 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// StewardshipAnalysis tracks, for each developer, how many of the lines they change were
+// written by themselves and how many by the others, in each tick of Sampling days. A line is
+// changed when it is deleted or replaced; the pure insertions do not count. The line owners
+// are tracked by the same machinery as in BurndownAnalysis, so a line belongs to whoever
+// changed it last. A high share of their own lines means that the developer works in a silo,
+// a low share means that they maintain the code of the others.
+type StewardshipAnalysis struct {
+	// Sampling is the number of days in a tick.
+	Sampling int
+	// PeopleNumber is the number of developers.
+	PeopleNumber int
+
+	// lines maintains the lines of the files and their owners; all its histories are disabled.
+	lines *BurndownAnalysis
+	// ticks map the tick indices to the counts of each developer. The files keep their
+	// updaters when they are forked, so the map is shared with the forks.
+	ticks map[int]map[int]StewardshipCounts
+	// historyBoundary is copied from BurndownAnalysis.HistoryBoundary.
+	historyBoundary string
+	// ignoreFormatting is copied from BurndownAnalysis.IgnoreFormatting.
+	ignoreFormatting bool
+	// peopleDict references IdentityDetector.PeopleDict
+	peopleDict map[string]int
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// StewardshipCounts is the number of lines changed by a developer, split by their owners.
+type StewardshipCounts struct {
+	// Self is the number of changed lines which the developer wrote.
+	Self int64
+	// Others is the number of changed lines which the others wrote. The lines which were
+	// written by the unmatched identities or before the history boundary belong here.
+	Others int64
+}
+
+// StewardshipResult is returned by StewardshipAnalysis.Finalize().
+type StewardshipResult struct {
+	// Ticks are the counts of each developer in each tick of Sampling days;
+	// -1 means an unmatched identity.
+	Ticks []map[int]StewardshipCounts
+	// Sampling is the effective StewardshipAnalysis.Sampling.
+	Sampling int
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigStewardshipSampling is the name of the option to set StewardshipAnalysis.Sampling.
+	ConfigStewardshipSampling = "Stewardship.Sampling"
+	// DefaultStewardshipSampling is the default value of StewardshipAnalysis.Sampling.
+	DefaultStewardshipSampling = 30
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (stewardship *StewardshipAnalysis) Name() string {
+	return "Stewardship"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (stewardship *StewardshipAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (stewardship *StewardshipAnalysis) Requires() []string {
+	arr := []string{
+		items.DependencyFileDiff, items.DependencyTreeChanges, items.DependencyBlobCache,
+		items.DependencyDay, identity.DependencyAuthor, identity.DependencyCoAuthors}
+	if stewardship.ignoreFormatting {
+		arr = append(arr, items.DependencyFormattingHunks)
+	}
+	return arr
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (stewardship *StewardshipAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigStewardshipSampling,
+		Description: "How frequently to record the self-vs-others modification counts, in days.",
+		Flag:        "stewardship-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultStewardshipSampling},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (stewardship *StewardshipAnalysis) Configure(facts map[string]interface{}) {
+	stewardship.ConfigureRequirements(facts)
+	if val, exists := facts[ConfigStewardshipSampling].(int); exists {
+		stewardship.Sampling = val
+	}
+	if val, exists := facts[ConfigBurndownHistoryBoundary].(string); exists {
+		stewardship.historyBoundary = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		stewardship.PeopleNumber = val
+		stewardship.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+		stewardship.peopleDict, _ = facts[identity.FactIdentityDetectorPeopleDict].(map[string]int)
+	}
+}
+
+// ConfigureRequirements copies BurndownAnalysis.IgnoreFormatting which adds
+// DependencyFormattingHunks to Requires(). It is a part of core.DynamicPipelineItem.
+func (stewardship *StewardshipAnalysis) ConfigureRequirements(facts map[string]interface{}) {
+	if val, exists := facts[ConfigBurndownIgnoreFormatting].(bool); exists {
+		stewardship.ignoreFormatting = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (stewardship *StewardshipAnalysis) Flag() string {
+	return "stewardship"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (stewardship *StewardshipAnalysis) Description() string {
+	return "Measures which fraction of the lines changed by each developer they wrote " +
+		"themselves and which fraction the others wrote, over time."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (stewardship *StewardshipAnalysis) Initialize(repository *git.Repository) {
+	if stewardship.Sampling <= 0 {
+		log.Printf("Warning: adjusted the stewardship sampling to %d days\n",
+			DefaultStewardshipSampling)
+		stewardship.Sampling = DefaultStewardshipSampling
+	}
+	stewardship.ticks = map[int]map[int]StewardshipCounts{}
+	stewardship.lines = &BurndownAnalysis{
+		Granularity:        DefaultBurndownGranularity,
+		Sampling:           DefaultBurndownGranularity,
+		PeopleNumber:       stewardship.PeopleNumber,
+		HistoryBoundary:    stewardship.historyBoundary,
+		IgnoreFormatting:   stewardship.ignoreFormatting,
+		linesOnly:          true,
+		peopleDict:         stewardship.peopleDict,
+		reversedPeopleDict: stewardship.reversedPeopleDict,
+	}
+	ticks := stewardship.ticks
+	lines := stewardship.lines
+	sampling := stewardship.Sampling
+	stewardship.lines.lineUpdater = func(currentTime, previousTime, delta int) {
+		if delta >= 0 {
+			return
+		}
+		author, day := lines.unpackPersonWithDay(currentTime)
+		owner, _ := lines.unpackPersonWithDay(previousTime)
+		if author < 0 || author >= lines.PeopleNumber {
+			author = -1
+		}
+		people := ticks[day/sampling]
+		if people == nil {
+			people = map[int]StewardshipCounts{}
+			ticks[day/sampling] = people
+		}
+		counts := people[author]
+		if author >= 0 && author == owner {
+			counts.Self -= int64(delta)
+		} else {
+			counts.Others -= int64(delta)
+		}
+		people[author] = counts
+	}
+	stewardship.lines.Initialize(repository)
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (stewardship *StewardshipAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	return stewardship.lines.Consume(deps)
+}
+
+// Fork clones this item. The files are copied by value while the counts are shared.
+func (stewardship *StewardshipAnalysis) Fork(n int) []core.PipelineItem {
+	lines := stewardship.lines.Fork(n)
+	result := make([]core.PipelineItem, n)
+	for i := range result {
+		clone := *stewardship
+		clone.lines = lines[i].(*BurndownAnalysis)
+		result[i] = &clone
+	}
+	return result
+}
+
+// Merge combines several items together, see BurndownAnalysis.Merge().
+func (stewardship *StewardshipAnalysis) Merge(branches []core.PipelineItem) {
+	lines := make([]core.PipelineItem, len(branches))
+	for i, branch := range branches {
+		lines[i] = branch.(*StewardshipAnalysis).lines
+	}
+	stewardship.lines.Merge(lines)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (stewardship *StewardshipAnalysis) Finalize() interface{} {
+	result := StewardshipResult{
+		Sampling:           stewardship.Sampling,
+		reversedPeopleDict: stewardship.reversedPeopleDict,
+	}
+	for tick, people := range stewardship.ticks {
+		for len(result.Ticks) <= tick {
+			result.Ticks = append(result.Ticks, map[int]StewardshipCounts{})
+		}
+		for dev, counts := range people {
+			result.Ticks[tick][dev] = counts
+		}
+	}
+	return result
+}
+
+// SelfRatio returns the share of the changed lines which the developer wrote.
+func (counts StewardshipCounts) SelfRatio() float64 {
+	total := counts.Self + counts.Others
+	if total == 0 {
+		return 0
+	}
+	return float64(counts.Self) / float64(total)
+}
+
+// People returns the counts of each developer over the whole history.
+func (result StewardshipResult) People() map[int]StewardshipCounts {
+	people := map[int]StewardshipCounts{}
+	for _, tick := range result.Ticks {
+		for dev, counts := range tick {
+			total := people[dev]
+			total.Self += counts.Self
+			total.Others += counts.Others
+			people[dev] = total
+		}
+	}
+	return people
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (stewardship *StewardshipAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	stewardshipResult := result.(StewardshipResult)
+	if binary {
+		return stewardship.serializeBinary(&stewardshipResult, writer)
+	}
+	stewardship.serializeText(&stewardshipResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (stewardship *StewardshipAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(stewardship, result, &pb.StewardshipAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (stewardship *StewardshipAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(stewardship, result, &pb.StewardshipAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to StewardshipResult.
+func (stewardship *StewardshipAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.StewardshipAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := StewardshipResult{
+		Sampling:           int(message.Sampling),
+		reversedPeopleDict: message.DevIndex,
+	}
+	if len(message.Ticks) > 0 {
+		result.Ticks = make([]map[int]StewardshipCounts, len(message.Ticks))
+		for i, tick := range message.Ticks {
+			people := map[int]StewardshipCounts{}
+			for dev, counts := range tick.People {
+				people[int(dev)] = StewardshipCounts{Self: counts.Self, Others: counts.Others}
+			}
+			result.Ticks[i] = people
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two StewardshipResult-s together. The ticks are regrouped
+// by the larger sampling and the developers are matched by name.
+func (stewardship *StewardshipAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	sr1 := r1.(StewardshipResult)
+	sr2 := r2.(StewardshipResult)
+	merged := StewardshipResult{Sampling: sr1.Sampling}
+	if sr2.Sampling > merged.Sampling {
+		merged.Sampling = sr2.Sampling
+	}
+	var people map[string][3]int
+	people, merged.reversedPeopleDict = identity.Detector{}.MergeReversedDicts(
+		sr1.reversedPeopleDict, sr2.reversedPeopleDict)
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	add := func(result *StewardshipResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for i, tick := range result.Ticks {
+			index := (i*result.Sampling + offset) / merged.Sampling
+			for len(merged.Ticks) <= index {
+				merged.Ticks = append(merged.Ticks, map[int]StewardshipCounts{})
+			}
+			for dev, counts := range tick {
+				newDev := -1
+				if dev >= 0 && dev < len(result.reversedPeopleDict) {
+					newDev = people[result.reversedPeopleDict[dev]][0]
+				}
+				target := merged.Ticks[index][newDev]
+				target.Self += counts.Self
+				target.Others += counts.Others
+				merged.Ticks[index][newDev] = target
+			}
+		}
+	}
+	add(&sr1, c1)
+	add(&sr2, c2)
+	return merged
+}
+
+// sortedStewardshipDevelopers returns the developers in ascending order.
+func sortedStewardshipDevelopers(people map[int]StewardshipCounts) []int {
+	devs := make([]int, 0, len(people))
+	for dev := range people {
+		devs = append(devs, dev)
+	}
+	sort.Ints(devs)
+	return devs
+}
+
+func (stewardship *StewardshipAnalysis) serializeText(result *StewardshipResult, writer io.Writer) {
+	writeCounts := func(counts StewardshipCounts) {
+		fmt.Fprintf(writer, "{self: %d, others: %d, self_ratio: %.3f}",
+			counts.Self, counts.Others, counts.SelfRatio())
+	}
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  # -1 means an unmatched identity")
+	fmt.Fprintln(writer, "  ticks:")
+	for _, tick := range result.Ticks {
+		fmt.Fprint(writer, "    - {")
+		for i, dev := range sortedStewardshipDevelopers(tick) {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprintf(writer, "%d: ", dev)
+			writeCounts(tick[dev])
+		}
+		fmt.Fprintln(writer, "}")
+	}
+	people := result.People()
+	fmt.Fprintln(writer, "  developers:")
+	for _, dev := range sortedStewardshipDevelopers(people) {
+		fmt.Fprintf(writer, "    %d: ", dev)
+		writeCounts(people[dev])
+		fmt.Fprintln(writer)
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (stewardship *StewardshipAnalysis) serializeBinary(result *StewardshipResult, writer io.Writer) error {
+	message := pb.StewardshipAnalysisResults{
+		Ticks:    make([]*pb.StewardshipTick, len(result.Ticks)),
+		Sampling: int32(result.Sampling),
+		DevIndex: result.reversedPeopleDict,
+	}
+	for i, tick := range result.Ticks {
+		converted := &pb.StewardshipTick{People: map[int32]*pb.StewardshipCounts{}}
+		for dev, counts := range tick {
+			converted.People[int32(dev)] = &pb.StewardshipCounts{
+				Self: counts.Self, Others: counts.Others}
+		}
+		message.Ticks[i] = converted
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&StewardshipAnalysis{})
+}
@@ -0,0 +1,185 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureStewardship() *StewardshipAnalysis {
+	stewardship := StewardshipAnalysis{}
+	stewardship.Configure(map[string]interface{}{
+		ConfigStewardshipSampling:                       10,
+		identity.FactIdentityDetectorPeopleCount:        2,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"alice", "bob"},
+	})
+	stewardship.Initialize(nil)
+	return &stewardship
+}
+
+func TestStewardshipMeta(t *testing.T) {
+	stewardship := fixtureStewardship()
+	assert.Equal(t, stewardship.Name(), "Stewardship")
+	assert.Len(t, stewardship.Provides(), 0)
+	for _, name := range []string{
+		items.DependencyFileDiff, items.DependencyTreeChanges, items.DependencyBlobCache,
+		items.DependencyDay, identity.DependencyAuthor, identity.DependencyCoAuthors} {
+		assert.Contains(t, stewardship.Requires(), name)
+	}
+	assert.NotContains(t, stewardship.Requires(), items.DependencyFormattingHunks)
+	var dynamic core.DynamicPipelineItem = stewardship
+	dynamic.ConfigureRequirements(map[string]interface{}{ConfigBurndownIgnoreFormatting: true})
+	assert.Contains(t, stewardship.Requires(), items.DependencyFormattingHunks)
+	stewardship.ignoreFormatting = false
+	opts := stewardship.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, opts[0].Flag, "stewardship-sampling")
+	assert.Equal(t, stewardship.Flag(), "stewardship")
+	assert.NotEmpty(t, stewardship.Description())
+	assert.Equal(t, stewardship.Sampling, 10)
+	assert.Equal(t, stewardship.PeopleNumber, 2)
+	assert.Equal(t, stewardship.reversedPeopleDict, []string{"alice", "bob"})
+	assert.True(t, stewardship.lines.linesOnly)
+	assert.NotNil(t, stewardship.lines.lineUpdater)
+	stewardship = &StewardshipAnalysis{}
+	stewardship.Initialize(nil)
+	assert.Equal(t, stewardship.Sampling, DefaultStewardshipSampling)
+	stewardship.Configure(map[string]interface{}{ConfigBurndownIgnoreFormatting: true})
+	stewardship.Initialize(nil)
+	assert.True(t, stewardship.lines.IgnoreFormatting)
+	summoned := core.Registry.Summon(stewardship.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Stewardship")
+}
+
+func fixtureStewardshipResult(t *testing.T) StewardshipResult {
+	hash, blob := storeExpertiseBlob(t, "one\ntwo\nthree\n")
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	stewardship := fixtureStewardship()
+	consume := func(item *StewardshipAnalysis, author, day int, changes object.Changes,
+		fileDiffs map[string]items.FileDiffData) {
+		result, err := item.Consume(map[string]interface{}{
+			identity.DependencyAuthor:   author,
+			items.DependencyDay:         day,
+			items.DependencyBlobCache:   map[plumbing.Hash]*object.Blob{hash: blob},
+			items.DependencyTreeChanges: changes,
+			items.DependencyFileDiff:    fileDiffs,
+			core.DependencyCommit:       &object.Commit{},
+			core.DependencyIsMerge:      false,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	consume(stewardship, 0, 0, object.Changes{{To: entry("a.go")}}, nil)
+	// alice replaces her own line
+	consume(stewardship, 0, 2, object.Changes{{From: entry("a.go"), To: entry("a.go")}},
+		map[string]items.FileDiffData{"a.go": {
+			OldLinesOfCode: 3, NewLinesOfCode: 3, Diffs: []diffmatchpatch.Diff{
+				{Type: diffmatchpatch.DiffEqual, Text: "ab"},
+				{Type: diffmatchpatch.DiffDelete, Text: "c"},
+				{Type: diffmatchpatch.DiffInsert, Text: "d"},
+			}}})
+	// bob replaces alice's line
+	consume(stewardship, 1, 12, object.Changes{
+		{From: entry("a.go"), To: entry("a.go")}, {To: entry("b.go")}},
+		map[string]items.FileDiffData{"a.go": {
+			OldLinesOfCode: 3, NewLinesOfCode: 3, Diffs: []diffmatchpatch.Diff{
+				{Type: diffmatchpatch.DiffDelete, Text: "a"},
+				{Type: diffmatchpatch.DiffInsert, Text: "e"},
+				{Type: diffmatchpatch.DiffEqual, Text: "bd"},
+			}}})
+	consume(stewardship, identity.AuthorMissing, 13, object.Changes{{From: entry("a.go")}}, nil)
+	// the counts are shared with the forks
+	fork := stewardship.Fork(1)[0].(*StewardshipAnalysis)
+	consume(fork, 1, 25, object.Changes{{From: entry("b.go"), To: entry("b.go")}},
+		map[string]items.FileDiffData{"b.go": {
+			OldLinesOfCode: 3, NewLinesOfCode: 2, Diffs: []diffmatchpatch.Diff{
+				{Type: diffmatchpatch.DiffEqual, Text: "ab"},
+				{Type: diffmatchpatch.DiffDelete, Text: "c"},
+			}}})
+	assert.Len(t, fork.lines.files, 1)
+	return stewardship.Finalize().(StewardshipResult)
+}
+
+func TestStewardshipConsumeFinalize(t *testing.T) {
+	result := fixtureStewardshipResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.reversedPeopleDict, []string{"alice", "bob"})
+	assert.Equal(t, result.Ticks, []map[int]StewardshipCounts{
+		{0: {Self: 1}},
+		{1: {Others: 1}, -1: {Others: 3}},
+		{1: {Self: 1}},
+	})
+	assert.Equal(t, result.People(), map[int]StewardshipCounts{
+		0: {Self: 1}, 1: {Self: 1, Others: 1}, -1: {Others: 3}})
+	assert.Equal(t, StewardshipCounts{}.SelfRatio(), float64(0))
+	assert.Equal(t, StewardshipCounts{Self: 1, Others: 3}.SelfRatio(), 0.25)
+}
+
+func TestStewardshipSerialize(t *testing.T) {
+	result := fixtureStewardshipResult(t)
+	stewardship := fixtureStewardship()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, stewardship.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  # -1 means an unmatched identity
+  ticks:
+    - {0: {self: 1, others: 0, self_ratio: 1.000}}
+    - {-1: {self: 0, others: 3, self_ratio: 0.000}, 1: {self: 0, others: 1, self_ratio: 0.000}}
+    - {1: {self: 1, others: 0, self_ratio: 1.000}}
+  developers:
+    -1: {self: 0, others: 3, self_ratio: 0.000}
+    0: {self: 1, others: 0, self_ratio: 1.000}
+    1: {self: 1, others: 1, self_ratio: 0.500}
+  people:
+  - "alice"
+  - "bob"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, stewardship.Serialize(result, true, buffer))
+	msg := pb.StewardshipAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Equal(t, msg.DevIndex, []string{"alice", "bob"})
+	assert.Len(t, msg.Ticks, 3)
+	assert.Equal(t, msg.Ticks[1].People[-1].Others, int64(3))
+	deserialized, err := stewardship.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestStewardshipMergeResults(t *testing.T) {
+	r1 := StewardshipResult{
+		Ticks: []map[int]StewardshipCounts{
+			{0: {Self: 5, Others: 1}}, {0: {Self: 1}, -1: {Others: 2}}},
+		Sampling:           10,
+		reversedPeopleDict: []string{"alice"},
+	}
+	r2 := StewardshipResult{
+		Ticks: []map[int]StewardshipCounts{
+			{0: {Others: 4}, 1: {Self: 3, Others: 3}}},
+		Sampling:           20,
+		reversedPeopleDict: []string{"bob", "alice"},
+	}
+	stewardship := fixtureStewardship()
+	merged := stewardship.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 20 * 24 * 3600}).(StewardshipResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.reversedPeopleDict, []string{"alice", "bob"})
+	assert.Equal(t, merged.Ticks, []map[int]StewardshipCounts{
+		{0: {Self: 6, Others: 1}, -1: {Others: 2}},
+		{0: {Self: 3, Others: 3}, 1: {Others: 4}},
+	})
+}
@@ -0,0 +1,475 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// TeamAlignmentAnalysis measures how well the teams map onto the directory structure
+// (Conway's law). It counts the files changed by each team in each directory, the directories
+// being the first Depth components of the paths, in each tick of Sampling days. The team
+// with the most edits owns the directory and the rest of the edits are cross-team; the higher
+// the share of the cross-team edits, the worse the code structure matches the organization.
+// The teams are the identities grouped by IdentityDetector.TeamsFile; without it, each
+// developer is a team of one. The merge commits are skipped.
+type TeamAlignmentAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the number of days in a tick.
+	Sampling int
+	// Depth is the number of the path components which identify a directory.
+	Depth int
+
+	// ticks are the edits in each tick.
+	ticks []map[string]map[int]int
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// TeamAlignmentResult is returned by TeamAlignmentAnalysis.Finalize().
+type TeamAlignmentResult struct {
+	// Ticks map the directories to the number of files changed by each team in each tick
+	// of Sampling days; -1 means an unmatched identity.
+	Ticks []map[string]map[int]int
+	// Sampling is the effective TeamAlignmentAnalysis.Sampling.
+	Sampling int
+	// Depth is the effective TeamAlignmentAnalysis.Depth.
+	Depth int
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// TeamAlignment summarizes the edits of a directory or of several directories.
+type TeamAlignment struct {
+	// Owner is the team with the most edits, the smallest index wins ties. It is -1 if there
+	// are no edits or if they are all by unmatched identities.
+	Owner int
+	// Edits is the number of changed files.
+	Edits int
+	// CrossTeamEdits is the number of the files changed by the teams other than the owners.
+	CrossTeamEdits int
+}
+
+const (
+	// ConfigTeamAlignmentSampling is the name of the option to set TeamAlignmentAnalysis.Sampling.
+	ConfigTeamAlignmentSampling = "TeamAlignment.Sampling"
+	// DefaultTeamAlignmentSampling is the default value of TeamAlignmentAnalysis.Sampling.
+	DefaultTeamAlignmentSampling = 30
+	// ConfigTeamAlignmentDepth is the name of the option to set TeamAlignmentAnalysis.Depth.
+	ConfigTeamAlignmentDepth = "TeamAlignment.Depth"
+	// DefaultTeamAlignmentDepth is the default value of TeamAlignmentAnalysis.Depth.
+	DefaultTeamAlignmentDepth = 1
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (alignment *TeamAlignmentAnalysis) Name() string {
+	return "TeamAlignment"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (alignment *TeamAlignmentAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (alignment *TeamAlignmentAnalysis) Requires() []string {
+	arr := [...]string{identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (alignment *TeamAlignmentAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigTeamAlignmentSampling,
+		Description: "How frequently to record the team alignment, in days.",
+		Flag:        "team-alignment-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultTeamAlignmentSampling}, {
+		Name:        ConfigTeamAlignmentDepth,
+		Description: "How many leading path components identify a directory.",
+		Flag:        "team-alignment-depth",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultTeamAlignmentDepth},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (alignment *TeamAlignmentAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigTeamAlignmentSampling].(int); exists {
+		alignment.Sampling = val
+	}
+	if val, exists := facts[ConfigTeamAlignmentDepth].(int); exists {
+		alignment.Depth = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		alignment.reversedPeopleDict = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (alignment *TeamAlignmentAnalysis) Flag() string {
+	return "team-alignment"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (alignment *TeamAlignmentAnalysis) Description() string {
+	return "Measures how the teams map onto the directories (Conway's law): the share of " +
+		"the edits by the teams other than the owner of each directory, over time. " +
+		"Use together with --teams."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (alignment *TeamAlignmentAnalysis) Initialize(repository *git.Repository) {
+	if alignment.Sampling <= 0 {
+		log.Printf("Warning: adjusted the team alignment sampling to %d days\n",
+			DefaultTeamAlignmentSampling)
+		alignment.Sampling = DefaultTeamAlignmentSampling
+	}
+	if alignment.Depth <= 0 {
+		log.Printf("Warning: adjusted the team alignment depth to %d\n", DefaultTeamAlignmentDepth)
+		alignment.Depth = DefaultTeamAlignmentDepth
+	}
+	alignment.ticks = nil
+	alignment.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (alignment *TeamAlignmentAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !alignment.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	team := deps[identity.DependencyAuthor].(int)
+	if team == identity.AuthorMissing {
+		team = -1
+	}
+	tick := deps[items.DependencyDay].(int) / alignment.Sampling
+	for len(alignment.ticks) <= tick {
+		alignment.ticks = append(alignment.ticks, map[string]map[int]int{})
+	}
+	dirs := alignment.ticks[tick]
+	edit := func(name string) {
+		dir := truncateDirectory(name, alignment.Depth)
+		edits := dirs[dir]
+		if edits == nil {
+			edits = map[int]int{}
+			dirs[dir] = edits
+		}
+		edits[team]++
+	}
+	for _, change := range deps[items.DependencyTreeChanges].(object.Changes) {
+		switch {
+		case change.To.Name == "":
+			edit(change.From.Name)
+		case change.From.Name == "":
+			edit(change.To.Name)
+		default:
+			edit(change.To.Name)
+			// a file moved to another directory changes both
+			if from := change.From.Name; truncateDirectory(from, alignment.Depth) !=
+				truncateDirectory(change.To.Name, alignment.Depth) {
+				edit(from)
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (alignment *TeamAlignmentAnalysis) Finalize() interface{} {
+	return TeamAlignmentResult{
+		Ticks:              alignment.ticks,
+		Sampling:           alignment.Sampling,
+		Depth:              alignment.Depth,
+		reversedPeopleDict: alignment.reversedPeopleDict,
+	}
+}
+
+// Fork clones this pipeline item.
+func (alignment *TeamAlignmentAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(alignment, n)
+}
+
+// newTeamAlignment summarizes the number of edits by each team.
+func newTeamAlignment(edits map[int]int) TeamAlignment {
+	result := TeamAlignment{Owner: -1}
+	for team, count := range edits {
+		result.Edits += count
+		if team < 0 {
+			continue
+		}
+		if result.Owner < 0 || count > edits[result.Owner] ||
+			(count == edits[result.Owner] && team < result.Owner) {
+			result.Owner = team
+		}
+	}
+	result.CrossTeamEdits = result.Edits
+	if result.Owner >= 0 {
+		result.CrossTeamEdits -= edits[result.Owner]
+	}
+	return result
+}
+
+// Ratio returns the share of the cross-team edits.
+func (alignment TeamAlignment) Ratio() float64 {
+	if alignment.Edits == 0 {
+		return 0
+	}
+	return float64(alignment.CrossTeamEdits) / float64(alignment.Edits)
+}
+
+// Directories returns the alignment of each directory over the whole history.
+func (result TeamAlignmentResult) Directories() map[string]TeamAlignment {
+	total := map[string]map[int]int{}
+	for _, dirs := range result.Ticks {
+		for dir, edits := range dirs {
+			sums := total[dir]
+			if sums == nil {
+				sums = map[int]int{}
+				total[dir] = sums
+			}
+			for team, count := range edits {
+				sums[team] += count
+			}
+		}
+	}
+	alignments := map[string]TeamAlignment{}
+	for dir, edits := range total {
+		alignments[dir] = newTeamAlignment(edits)
+	}
+	return alignments
+}
+
+// TickAlignment returns the total alignment of the directories in the tick. Each directory
+// is owned by the team with the most edits in that tick; Owner is -1.
+func (result TeamAlignmentResult) TickAlignment(tick int) TeamAlignment {
+	total := TeamAlignment{Owner: -1}
+	for _, edits := range result.Ticks[tick] {
+		alignment := newTeamAlignment(edits)
+		total.Edits += alignment.Edits
+		total.CrossTeamEdits += alignment.CrossTeamEdits
+	}
+	return total
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (alignment *TeamAlignmentAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	alignmentResult := result.(TeamAlignmentResult)
+	if binary {
+		return alignment.serializeBinary(&alignmentResult, writer)
+	}
+	alignment.serializeText(&alignmentResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (alignment *TeamAlignmentAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(alignment, result, &pb.TeamAlignmentAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (alignment *TeamAlignmentAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(alignment, result, &pb.TeamAlignmentAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to TeamAlignmentResult.
+func (alignment *TeamAlignmentAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.TeamAlignmentAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := TeamAlignmentResult{
+		Sampling:           int(message.Sampling),
+		Depth:              int(message.Depth),
+		reversedPeopleDict: message.DevIndex,
+	}
+	if len(message.Ticks) > 0 {
+		result.Ticks = make([]map[string]map[int]int, len(message.Ticks))
+		for i, tick := range message.Ticks {
+			dirs := map[string]map[int]int{}
+			for _, dir := range tick.Directories {
+				edits := map[int]int{}
+				for team, count := range dir.Edits {
+					edits[int(team)] = int(count)
+				}
+				dirs[dir.Directory] = edits
+			}
+			result.Ticks[i] = dirs
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two TeamAlignmentResult-s together. The ticks are regrouped
+// by the larger sampling and the teams are matched by name. The directories are not
+// truncated further if the depths differ.
+func (alignment *TeamAlignmentAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	ar1 := r1.(TeamAlignmentResult)
+	ar2 := r2.(TeamAlignmentResult)
+	merged := TeamAlignmentResult{Sampling: ar1.Sampling, Depth: ar1.Depth}
+	if ar2.Sampling > merged.Sampling {
+		merged.Sampling = ar2.Sampling
+	}
+	if ar2.Depth < merged.Depth {
+		merged.Depth = ar2.Depth
+	}
+	var people map[string][3]int
+	people, merged.reversedPeopleDict = identity.Detector{}.MergeReversedDicts(
+		ar1.reversedPeopleDict, ar2.reversedPeopleDict)
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	add := func(result *TeamAlignmentResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for i, dirs := range result.Ticks {
+			index := (i*result.Sampling + offset) / merged.Sampling
+			for len(merged.Ticks) <= index {
+				merged.Ticks = append(merged.Ticks, map[string]map[int]int{})
+			}
+			for dir, edits := range dirs {
+				target := merged.Ticks[index][dir]
+				if target == nil {
+					target = map[int]int{}
+					merged.Ticks[index][dir] = target
+				}
+				for team, count := range edits {
+					newTeam := -1
+					if team >= 0 && team < len(result.reversedPeopleDict) {
+						newTeam = people[result.reversedPeopleDict[team]][0]
+					}
+					target[newTeam] += count
+				}
+			}
+		}
+	}
+	add(&ar1, c1)
+	add(&ar2, c2)
+	return merged
+}
+
+// sortedTeamAlignmentDirectories returns the directories in alphabetical order.
+func sortedTeamAlignmentDirectories(dirs map[string]map[int]int) []string {
+	names := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		names = append(names, dir)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (alignment *TeamAlignmentAnalysis) serializeText(result *TeamAlignmentResult, writer io.Writer) {
+	writeAlignment := func(stats TeamAlignment) {
+		fmt.Fprintf(writer, "owner: %d, edits: %d, cross_team_edits: %d, ratio: %.3f",
+			stats.Owner, stats.Edits, stats.CrossTeamEdits, stats.Ratio())
+	}
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  depth:", result.Depth)
+	fmt.Fprintln(writer, "  # -1 means an unmatched identity or no owner")
+	fmt.Fprintln(writer, "  ticks:")
+	for i, dirs := range result.Ticks {
+		total := result.TickAlignment(i)
+		fmt.Fprintf(writer, "    - edits: %d\n      cross_team_edits: %d\n      ratio: %.3f\n",
+			total.Edits, total.CrossTeamEdits, total.Ratio())
+		if len(dirs) == 0 {
+			fmt.Fprintln(writer, "      directories: {}")
+			continue
+		}
+		fmt.Fprintln(writer, "      directories:")
+		for _, dir := range sortedTeamAlignmentDirectories(dirs) {
+			edits := dirs[dir]
+			fmt.Fprintf(writer, "        %s: {", yaml.SafeString(dir))
+			writeAlignment(newTeamAlignment(edits))
+			fmt.Fprint(writer, ", teams: {")
+			teams := make([]int, 0, len(edits))
+			for team := range edits {
+				teams = append(teams, team)
+			}
+			sort.Ints(teams)
+			for j, team := range teams {
+				if j > 0 {
+					fmt.Fprint(writer, ", ")
+				}
+				fmt.Fprintf(writer, "%d: %d", team, edits[team])
+			}
+			fmt.Fprintln(writer, "}}")
+		}
+	}
+	directories := result.Directories()
+	names := make([]string, 0, len(directories))
+	for dir := range directories {
+		names = append(names, dir)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(writer, "  directories:")
+	for _, dir := range names {
+		fmt.Fprintf(writer, "    %s: {", yaml.SafeString(dir))
+		writeAlignment(directories[dir])
+		fmt.Fprintln(writer, "}")
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (alignment *TeamAlignmentAnalysis) serializeBinary(result *TeamAlignmentResult, writer io.Writer) error {
+	message := pb.TeamAlignmentAnalysisResults{
+		Ticks:    make([]*pb.TeamAlignmentTick, len(result.Ticks)),
+		Sampling: int32(result.Sampling),
+		Depth:    int32(result.Depth),
+		DevIndex: result.reversedPeopleDict,
+	}
+	for i, dirs := range result.Ticks {
+		tick := &pb.TeamAlignmentTick{}
+		for _, dir := range sortedTeamAlignmentDirectories(dirs) {
+			edits := dirs[dir]
+			stats := newTeamAlignment(edits)
+			converted := &pb.TeamAlignmentDirectory{
+				Directory:      dir,
+				Edits:          map[int32]int32{},
+				Owner:          int32(stats.Owner),
+				CrossTeamEdits: int32(stats.CrossTeamEdits),
+			}
+			for team, count := range edits {
+				converted.Edits[int32(team)] = int32(count)
+			}
+			tick.Directories = append(tick.Directories, converted)
+		}
+		message.Ticks[i] = tick
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&TeamAlignmentAnalysis{})
+}
@@ -0,0 +1,192 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureTeamAlignment() *TeamAlignmentAnalysis {
+	alignment := TeamAlignmentAnalysis{}
+	alignment.Configure(map[string]interface{}{
+		ConfigTeamAlignmentSampling:                     10,
+		ConfigTeamAlignmentDepth:                        1,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"backend", "frontend"},
+	})
+	alignment.Initialize(nil)
+	return &alignment
+}
+
+func TestTeamAlignmentMeta(t *testing.T) {
+	alignment := fixtureTeamAlignment()
+	assert.Equal(t, alignment.Name(), "TeamAlignment")
+	assert.Len(t, alignment.Provides(), 0)
+	assert.Equal(t, alignment.Requires(), []string{
+		identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges})
+	assert.Equal(t, alignment.Flag(), "team-alignment")
+	assert.NotEmpty(t, alignment.Description())
+	opts := alignment.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Flag, "team-alignment-sampling")
+	assert.Equal(t, opts[1].Flag, "team-alignment-depth")
+	assert.Equal(t, alignment.Sampling, 10)
+	assert.Equal(t, alignment.Depth, 1)
+	assert.Equal(t, alignment.reversedPeopleDict, []string{"backend", "frontend"})
+	alignment = &TeamAlignmentAnalysis{Depth: -1}
+	alignment.Initialize(nil)
+	assert.Equal(t, alignment.Sampling, DefaultTeamAlignmentSampling)
+	assert.Equal(t, alignment.Depth, DefaultTeamAlignmentDepth)
+	summoned := core.Registry.Summon(alignment.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "TeamAlignment")
+}
+
+func TestTeamAlignmentOwner(t *testing.T) {
+	assert.Equal(t, newTeamAlignment(map[int]int{}), TeamAlignment{Owner: -1})
+	assert.Equal(t, newTeamAlignment(map[int]int{-1: 5}),
+		TeamAlignment{Owner: -1, Edits: 5, CrossTeamEdits: 5})
+	assert.Equal(t, newTeamAlignment(map[int]int{2: 3, 1: 3, 0: 1, -1: 4}),
+		TeamAlignment{Owner: 1, Edits: 11, CrossTeamEdits: 8})
+	assert.Equal(t, TeamAlignment{}.Ratio(), float64(0))
+	assert.Equal(t, TeamAlignment{Edits: 4, CrossTeamEdits: 1}.Ratio(), 0.25)
+}
+
+func fixtureTeamAlignmentResult(t *testing.T) TeamAlignmentResult {
+	alignment := fixtureTeamAlignment()
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name}}
+	}
+	consume := func(team, day int, merge bool, changes object.Changes) {
+		commit := &object.Commit{}
+		if merge {
+			commit.Hash = plumbing.NewHash("0123456789012345678901234567890123456789")
+			commit.ParentHashes = make([]plumbing.Hash, 2)
+		}
+		result, err := alignment.Consume(map[string]interface{}{
+			core.DependencyCommit:       commit,
+			core.DependencyIsMerge:      merge,
+			identity.DependencyAuthor:   team,
+			items.DependencyDay:         day,
+			items.DependencyTreeChanges: changes,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	consume(0, 0, false, object.Changes{
+		{To: entry("src/a.go")}, {To: entry("src/b.go")}, {To: entry("README.md")}})
+	consume(1, 3, false, object.Changes{
+		{From: entry("src/a.go"), To: entry("src/a.go")}, {To: entry("web/index.js")}})
+	consume(identity.AuthorMissing, 4, false, object.Changes{
+		{From: entry("web/index.js"), To: entry("web/index.js")}})
+	consume(1, 5, true, object.Changes{{To: entry("web/main.js")}})
+	consume(1, 25, false, object.Changes{{From: entry("src/b.go"), To: entry("web/b.go")}})
+	return alignment.Finalize().(TeamAlignmentResult)
+}
+
+func TestTeamAlignmentConsumeFinalize(t *testing.T) {
+	result := fixtureTeamAlignmentResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Depth, 1)
+	assert.Equal(t, result.Ticks, []map[string]map[int]int{
+		{"src": {0: 2, 1: 1}, ".": {0: 1}, "web": {1: 1, -1: 1}},
+		{},
+		{"src": {1: 1}, "web": {1: 1}},
+	})
+	assert.Equal(t, result.TickAlignment(0), TeamAlignment{Owner: -1, Edits: 6, CrossTeamEdits: 2})
+	assert.Equal(t, result.TickAlignment(1), TeamAlignment{Owner: -1})
+	assert.Equal(t, result.TickAlignment(2), TeamAlignment{Owner: -1, Edits: 2})
+	assert.Equal(t, result.Directories(), map[string]TeamAlignment{
+		".":   {Owner: 0, Edits: 1},
+		"src": {Owner: 0, Edits: 4, CrossTeamEdits: 2},
+		"web": {Owner: 1, Edits: 3, CrossTeamEdits: 1},
+	})
+}
+
+func TestTeamAlignmentSerialize(t *testing.T) {
+	result := fixtureTeamAlignmentResult(t)
+	alignment := fixtureTeamAlignment()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, alignment.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  depth: 1
+  # -1 means an unmatched identity or no owner
+  ticks:
+    - edits: 6
+      cross_team_edits: 2
+      ratio: 0.333
+      directories:
+        ".": {owner: 0, edits: 1, cross_team_edits: 0, ratio: 0.000, teams: {0: 1}}
+        "src": {owner: 0, edits: 3, cross_team_edits: 1, ratio: 0.333, teams: {0: 2, 1: 1}}
+        "web": {owner: 1, edits: 2, cross_team_edits: 1, ratio: 0.500, teams: {-1: 1, 1: 1}}
+    - edits: 0
+      cross_team_edits: 0
+      ratio: 0.000
+      directories: {}
+    - edits: 2
+      cross_team_edits: 0
+      ratio: 0.000
+      directories:
+        "src": {owner: 1, edits: 1, cross_team_edits: 0, ratio: 0.000, teams: {1: 1}}
+        "web": {owner: 1, edits: 1, cross_team_edits: 0, ratio: 0.000, teams: {1: 1}}
+  directories:
+    ".": {owner: 0, edits: 1, cross_team_edits: 0, ratio: 0.000}
+    "src": {owner: 0, edits: 4, cross_team_edits: 2, ratio: 0.500}
+    "web": {owner: 1, edits: 3, cross_team_edits: 1, ratio: 0.333}
+  people:
+  - "backend"
+  - "frontend"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, alignment.Serialize(result, true, buffer))
+	msg := pb.TeamAlignmentAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Equal(t, msg.Depth, int32(1))
+	assert.Equal(t, msg.DevIndex, []string{"backend", "frontend"})
+	assert.Len(t, msg.Ticks, 3)
+	assert.Len(t, msg.Ticks[0].Directories, 3)
+	assert.Equal(t, msg.Ticks[0].Directories[1].Directory, "src")
+	assert.Equal(t, msg.Ticks[0].Directories[1].Owner, int32(0))
+	assert.Equal(t, msg.Ticks[0].Directories[1].CrossTeamEdits, int32(1))
+	assert.Len(t, msg.Ticks[1].Directories, 0)
+	deserialized, err := alignment.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestTeamAlignmentMergeResults(t *testing.T) {
+	r1 := TeamAlignmentResult{
+		Ticks: []map[string]map[int]int{
+			{"src": {0: 2}}, {"src": {0: 1, -1: 1}}},
+		Sampling:           10,
+		Depth:              2,
+		reversedPeopleDict: []string{"backend"},
+	}
+	r2 := TeamAlignmentResult{
+		Ticks: []map[string]map[int]int{
+			{"src": {0: 1, 1: 3}, "web": {0: 1}}},
+		Sampling:           20,
+		Depth:              1,
+		reversedPeopleDict: []string{"frontend", "backend"},
+	}
+	alignment := fixtureTeamAlignment()
+	merged := alignment.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 20 * 24 * 3600}).(TeamAlignmentResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.Depth, 1)
+	assert.Equal(t, merged.reversedPeopleDict, []string{"backend", "frontend"})
+	assert.Equal(t, merged.Ticks, []map[string]map[int]int{
+		{"src": {0: 3, -1: 1}},
+		{"src": {1: 1, 0: 3}, "web": {1: 1}},
+	})
+	assert.Equal(t, r1.Ticks[0], map[string]map[int]int{"src": {0: 2}})
+}
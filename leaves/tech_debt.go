@@ -0,0 +1,636 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// TechDebtAnalysis tracks the self-admitted technical debt: the comments with the markers
+// such as TODO or FIXME. The lines with the markers are followed through the diffs, so it is
+// known when and by whom each marker was introduced and when it was resolved, that is, removed.
+// A marker which is moved inside the same file in a single commit keeps its origin.
+// The merge commits are skipped.
+type TechDebtAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the size of a tick in days.
+	Sampling int
+	// Markers are the words which denote the technical debt.
+	Markers []string
+	// Oldest is the number of the oldest outstanding markers to report.
+	Oldest int
+
+	// markerRE matches the comments with the markers.
+	markerRE *regexp.Regexp
+	// files map the file names to the markers sorted by line.
+	files map[string][]*TechDebtMarker
+	// ticks are the counts of the markers in each tick.
+	ticks []TechDebtTick
+	// lifetimes are the numbers of days which the resolved markers lived.
+	lifetimes []int
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// TechDebtMarker is a comment with a marker.
+type TechDebtMarker struct {
+	// Kind is the marker, e.g. "TODO".
+	Kind string
+	// Text is the whole line with the marker without the surrounding whitespace.
+	Text string
+	File string
+	// Line is the 1-based line number.
+	Line int
+	// Author is the developer who introduced the marker; -1 means an unmatched identity.
+	Author int
+	// Day is the day when the marker was introduced.
+	Day int
+}
+
+// TechDebtTick is the markers' statistics in a single tick.
+type TechDebtTick struct {
+	// Open is the number of the outstanding markers at the end of the tick.
+	Open int
+	// Added is the number of the introduced markers.
+	Added int
+	// Resolved is the number of the removed markers.
+	Resolved int
+}
+
+// TechDebtResult is returned by TechDebtAnalysis.Finalize().
+type TechDebtResult struct {
+	// Ticks are the markers' statistics in each tick.
+	Ticks []TechDebtTick
+	// Kinds map the markers to the number of their outstanding occurrences.
+	Kinds map[string]int
+	// Oldest are the oldest outstanding markers sorted by the day of the introduction.
+	Oldest []TechDebtMarker
+	// Lifetimes are the sorted numbers of days which the resolved markers lived.
+	Lifetimes []int
+	// Sampling is the size of a tick in days.
+	Sampling int
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigTechDebtSampling is the name of the option to set TechDebtAnalysis.Sampling.
+	ConfigTechDebtSampling = "TechDebt.Sampling"
+	// ConfigTechDebtMarkers is the name of the option to set TechDebtAnalysis.Markers.
+	ConfigTechDebtMarkers = "TechDebt.Markers"
+	// ConfigTechDebtOldest is the name of the option to set TechDebtAnalysis.Oldest.
+	ConfigTechDebtOldest = "TechDebt.Oldest"
+	// DefaultTechDebtSampling is the default value of TechDebtAnalysis.Sampling.
+	DefaultTechDebtSampling = 30
+	// DefaultTechDebtOldest is the default value of TechDebtAnalysis.Oldest.
+	DefaultTechDebtOldest = 20
+	// techDebtMaxTextLength is the maximum number of characters in TechDebtMarker.Text.
+	techDebtMaxTextLength = 200
+)
+
+// DefaultTechDebtMarkers is the default value of TechDebtAnalysis.Markers.
+var DefaultTechDebtMarkers = []string{"TODO", "FIXME", "HACK", "XXX"}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (debt *TechDebtAnalysis) Name() string {
+	return "TechDebt"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (debt *TechDebtAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (debt *TechDebtAnalysis) Requires() []string {
+	arr := [...]string{
+		identity.DependencyAuthor, items.DependencyDay, items.DependencyTreeChanges,
+		items.DependencyFileDiff, items.DependencyBlobCache}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (debt *TechDebtAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigTechDebtSampling,
+		Description: "How frequently to record the number of the technical debt markers in days.",
+		Flag:        "tech-debt-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultTechDebtSampling}, {
+		Name:        ConfigTechDebtMarkers,
+		Description: "The words in the comments which denote the technical debt.",
+		Flag:        "tech-debt-markers",
+		Type:        core.StringsConfigurationOption,
+		Default:     DefaultTechDebtMarkers}, {
+		Name:        ConfigTechDebtOldest,
+		Description: "Number of the oldest outstanding technical debt markers to report.",
+		Flag:        "tech-debt-oldest",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultTechDebtOldest},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (debt *TechDebtAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigTechDebtSampling].(int); exists {
+		debt.Sampling = val
+	}
+	if val, exists := facts[ConfigTechDebtMarkers].([]string); exists {
+		debt.Markers = val
+	}
+	if val, exists := facts[ConfigTechDebtOldest].(int); exists {
+		debt.Oldest = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		debt.reversedPeopleDict = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (debt *TechDebtAnalysis) Flag() string {
+	return "tech-debt"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (debt *TechDebtAnalysis) Description() string {
+	return "Tracks the TODO, FIXME, etc. comments: when and by whom they were introduced " +
+		"and when they were resolved."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (debt *TechDebtAnalysis) Initialize(repository *git.Repository) {
+	if debt.Sampling <= 0 {
+		log.Printf("Warning: adjusted the technical debt sampling to %d days\n",
+			DefaultTechDebtSampling)
+		debt.Sampling = DefaultTechDebtSampling
+	}
+	if debt.Oldest < 0 {
+		log.Printf("Warning: adjusted the number of the oldest technical debt markers to %d\n",
+			DefaultTechDebtOldest)
+		debt.Oldest = DefaultTechDebtOldest
+	}
+	markers := make([]string, 0, len(debt.Markers))
+	for _, marker := range debt.Markers {
+		if marker = strings.TrimSpace(marker); marker != "" {
+			markers = append(markers, regexp.QuoteMeta(marker))
+		}
+	}
+	if len(markers) == 0 {
+		if debt.Markers != nil {
+			log.Printf("Warning: adjusted the technical debt markers to %s\n",
+				strings.Join(DefaultTechDebtMarkers, ","))
+		}
+		debt.Markers = DefaultTechDebtMarkers
+		for _, marker := range debt.Markers {
+			markers = append(markers, regexp.QuoteMeta(marker))
+		}
+	}
+	// the marker must follow the beginning of a comment in one of the popular languages
+	debt.markerRE = regexp.MustCompile(
+		`(?://|#|/\*|^\s*\*|--|<!--|;|%|"""|''').*?\b(` + strings.Join(markers, "|") + `)\b`)
+	debt.files = map[string][]*TechDebtMarker{}
+	debt.ticks = []TechDebtTick{}
+	debt.lifetimes = []int{}
+	debt.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (debt *TechDebtAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !debt.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing {
+		author = -1
+	}
+	day := deps[items.DependencyDay].(int)
+	tick := day / debt.Sampling
+	for len(debt.ticks) <= tick {
+		open := 0
+		if len(debt.ticks) > 0 {
+			open = debt.ticks[len(debt.ticks)-1].Open
+		}
+		debt.ticks = append(debt.ticks, TechDebtTick{Open: open})
+	}
+	stats := &debt.ticks[tick]
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	for _, change := range deps[items.DependencyTreeChanges].(object.Changes) {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			lines, err := items.BlobToString(cache[change.To.TreeEntry.Hash])
+			if err != nil {
+				return nil, err
+			}
+			debt.update(stats, day, author, change.To.Name, nil, nil, lines)
+		case merkletrie.Delete:
+			debt.update(stats, day, author, change.From.Name, debt.files[change.From.Name], nil, "")
+			delete(debt.files, change.From.Name)
+		case merkletrie.Modify:
+			if change.From.Name != change.To.Name {
+				markers := debt.files[change.From.Name]
+				for _, marker := range markers {
+					marker.File = change.To.Name
+				}
+				debt.files[change.To.Name] = markers
+				delete(debt.files, change.From.Name)
+			}
+			diff, exists := fileDiffs[change.To.Name]
+			if !exists {
+				continue
+			}
+			lines, err := items.BlobToString(cache[change.To.TreeEntry.Hash])
+			if err != nil {
+				return nil, err
+			}
+			debt.update(stats, day, author, change.To.Name, debt.files[change.To.Name],
+				diff.Diffs, lines)
+		}
+	}
+	return nil, nil
+}
+
+// update follows the markers of the file through the diff. If `diffs` is nil, all the old
+// markers are resolved and all the lines are new.
+func (debt *TechDebtAnalysis) update(
+	stats *TechDebtTick, day, author int, file string, markers []*TechDebtMarker,
+	diffs []diffmatchpatch.Diff, contents string) {
+	if strings.IndexByte(contents, 0) >= 0 {
+		// binary
+		return
+	}
+	lines := strings.Split(contents, "\n")
+	if diffs == nil {
+		diffs = []diffmatchpatch.Diff{}
+		if len(markers) > 0 {
+			diffs = append(diffs, diffmatchpatch.Diff{
+				Type: diffmatchpatch.DiffDelete, Text: strings.Repeat(" ", markers[len(markers)-1].Line)})
+		}
+		if contents != "" {
+			diffs = append(diffs, diffmatchpatch.Diff{
+				Type: diffmatchpatch.DiffInsert, Text: strings.Repeat(" ", len(lines))})
+		}
+	}
+	var kept, deleted, inserted []*TechDebtMarker
+	index, oldLine, newLine := 0, 0, 0
+	for _, edit := range diffs {
+		// FileDiff encodes each line as a single rune
+		size := utf8.RuneCountInString(edit.Text)
+		switch edit.Type {
+		case diffmatchpatch.DiffEqual:
+			for ; index < len(markers) && markers[index].Line <= oldLine+size; index++ {
+				markers[index].Line += newLine - oldLine
+				kept = append(kept, markers[index])
+			}
+			oldLine += size
+			newLine += size
+		case diffmatchpatch.DiffDelete:
+			for ; index < len(markers) && markers[index].Line <= oldLine+size; index++ {
+				deleted = append(deleted, markers[index])
+			}
+			oldLine += size
+		case diffmatchpatch.DiffInsert:
+			for line := newLine; line < newLine+size && line < len(lines); line++ {
+				if marker := debt.parse(lines[line]); marker != nil {
+					marker.File = file
+					marker.Line = line + 1
+					marker.Author = author
+					marker.Day = day
+					inserted = append(inserted, marker)
+				}
+			}
+			newLine += size
+		}
+	}
+	// the markers which were moved keep their origin
+	moved := map[string][]*TechDebtMarker{}
+	for _, marker := range deleted {
+		moved[marker.Text] = append(moved[marker.Text], marker)
+	}
+	for _, marker := range inserted {
+		if origins := moved[marker.Text]; len(origins) > 0 {
+			origin := origins[0]
+			moved[marker.Text] = origins[1:]
+			origin.Line = marker.Line
+			*marker = *origin
+			continue
+		}
+		stats.Added++
+		stats.Open++
+	}
+	for _, origins := range moved {
+		for _, marker := range origins {
+			stats.Resolved++
+			stats.Open--
+			debt.lifetimes = append(debt.lifetimes, day-marker.Day)
+		}
+	}
+	result := append(kept, inserted...)
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Line < result[j].Line
+	})
+	if len(result) > 0 {
+		debt.files[file] = result
+	} else {
+		delete(debt.files, file)
+	}
+}
+
+// parse returns the marker in the line or nil.
+func (debt *TechDebtAnalysis) parse(line string) *TechDebtMarker {
+	match := debt.markerRE.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+	text := strings.TrimSpace(line)
+	if utf8.RuneCountInString(text) > techDebtMaxTextLength {
+		text = string([]rune(text)[:techDebtMaxTextLength])
+	}
+	return &TechDebtMarker{Kind: match[1], Text: text}
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (debt *TechDebtAnalysis) Finalize() interface{} {
+	kinds := map[string]int{}
+	markers := []TechDebtMarker{}
+	for _, fileMarkers := range debt.files {
+		for _, marker := range fileMarkers {
+			kinds[marker.Kind]++
+			markers = append(markers, *marker)
+		}
+	}
+	lifetimes := make([]int, len(debt.lifetimes))
+	copy(lifetimes, debt.lifetimes)
+	sort.Ints(lifetimes)
+	return TechDebtResult{
+		Ticks:              debt.ticks,
+		Kinds:              kinds,
+		Oldest:             oldestTechDebtMarkers(markers, debt.Oldest),
+		Lifetimes:          lifetimes,
+		Sampling:           debt.Sampling,
+		reversedPeopleDict: debt.reversedPeopleDict,
+	}
+}
+
+// oldestTechDebtMarkers sorts the markers by the day of the introduction and keeps
+// the first `size`.
+func oldestTechDebtMarkers(markers []TechDebtMarker, size int) []TechDebtMarker {
+	sort.Slice(markers, func(i, j int) bool {
+		if markers[i].Day != markers[j].Day {
+			return markers[i].Day < markers[j].Day
+		}
+		if markers[i].File != markers[j].File {
+			return markers[i].File < markers[j].File
+		}
+		return markers[i].Line < markers[j].Line
+	})
+	if len(markers) > size {
+		markers = markers[:size]
+	}
+	return markers
+}
+
+// Fork clones this pipeline item.
+func (debt *TechDebtAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(debt, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (debt *TechDebtAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	debtResult := result.(TechDebtResult)
+	if binary {
+		return debt.serializeBinary(&debtResult, writer)
+	}
+	debt.serializeText(&debtResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (debt *TechDebtAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(debt, result, &pb.TechDebtAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (debt *TechDebtAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(debt, result, &pb.TechDebtAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to TechDebtResult.
+func (debt *TechDebtAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.TechDebtAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := TechDebtResult{
+		Ticks:              make([]TechDebtTick, len(message.Ticks)),
+		Kinds:              map[string]int{},
+		Oldest:             make([]TechDebtMarker, len(message.Oldest)),
+		Lifetimes:          make([]int, len(message.Lifetimes)),
+		Sampling:           int(message.Sampling),
+		reversedPeopleDict: message.DevIndex,
+	}
+	for i, tick := range message.Ticks {
+		result.Ticks[i] = TechDebtTick{
+			Open: int(tick.Open), Added: int(tick.Added), Resolved: int(tick.Resolved)}
+	}
+	for kind, count := range message.Kinds {
+		result.Kinds[kind] = int(count)
+	}
+	for i, marker := range message.Oldest {
+		result.Oldest[i] = TechDebtMarker{
+			Kind: marker.Kind, Text: marker.Text, File: marker.File, Line: int(marker.Line),
+			Author: int(marker.Author), Day: int(marker.Day)}
+	}
+	for i, lifetime := range message.Lifetimes {
+		result.Lifetimes[i] = int(lifetime)
+	}
+	return result, nil
+}
+
+// MergeResults combines two TechDebtResult-s together. The ticks are resampled to the bigger
+// sampling of the two.
+func (debt *TechDebtAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	tr1 := r1.(TechDebtResult)
+	tr2 := r2.(TechDebtResult)
+	merged := TechDebtResult{Kinds: map[string]int{}, Sampling: tr1.Sampling}
+	if tr2.Sampling > merged.Sampling {
+		merged.Sampling = tr2.Sampling
+	}
+	var people map[string][3]int
+	people, merged.reversedPeopleDict = identity.Detector{}.MergeReversedDicts(
+		tr1.reversedPeopleDict, tr2.reversedPeopleDict)
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	offsets := [2]int{
+		int((c1.BeginTime - beginTime) / (24 * 3600)),
+		int((c2.BeginTime - beginTime) / (24 * 3600)),
+	}
+	results := [2]*TechDebtResult{&tr1, &tr2}
+	days := 0
+	for i, result := range results {
+		if end := offsets[i] + len(result.Ticks)*result.Sampling; end > days {
+			days = end
+		}
+	}
+	merged.Ticks = make([]TechDebtTick, (days+merged.Sampling-1)/merged.Sampling)
+	for i, result := range results {
+		for j, tick := range result.Ticks {
+			// the merged tick which contains the first day of the tick
+			k := (j*result.Sampling + offsets[i]) / merged.Sampling
+			merged.Ticks[k].Added += tick.Added
+			merged.Ticks[k].Resolved += tick.Resolved
+		}
+		// the number of the open markers at the end of each merged tick
+		for k := range merged.Ticks {
+			day := (k+1)*merged.Sampling - 1 - offsets[i]
+			if day < 0 || len(result.Ticks) == 0 {
+				continue
+			}
+			index := day / result.Sampling
+			if index >= len(result.Ticks) {
+				index = len(result.Ticks) - 1
+			}
+			merged.Ticks[k].Open += result.Ticks[index].Open
+		}
+		for kind, count := range result.Kinds {
+			merged.Kinds[kind] += count
+		}
+		for _, marker := range result.Oldest {
+			marker.Day += offsets[i]
+			if marker.Author >= 0 && marker.Author < len(result.reversedPeopleDict) {
+				marker.Author = people[result.reversedPeopleDict[marker.Author]][0]
+			} else {
+				marker.Author = -1
+			}
+			merged.Oldest = append(merged.Oldest, marker)
+		}
+		merged.Lifetimes = append(merged.Lifetimes, result.Lifetimes...)
+	}
+	size := len(tr1.Oldest)
+	if len(tr2.Oldest) > size {
+		size = len(tr2.Oldest)
+	}
+	merged.Oldest = oldestTechDebtMarkers(merged.Oldest, size)
+	sort.Ints(merged.Lifetimes)
+	return merged
+}
+
+func (debt *TechDebtAnalysis) serializeText(result *TechDebtResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  # [open, added, resolved]")
+	fmt.Fprint(writer, "  ticks: [")
+	for i, tick := range result.Ticks {
+		if i > 0 {
+			fmt.Fprint(writer, ", ")
+		}
+		fmt.Fprintf(writer, "[%d, %d, %d]", tick.Open, tick.Added, tick.Resolved)
+	}
+	fmt.Fprintln(writer, "]")
+	kinds := make([]string, 0, len(result.Kinds))
+	for kind := range result.Kinds {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	fmt.Fprint(writer, "  kinds: {")
+	for i, kind := range kinds {
+		if i > 0 {
+			fmt.Fprint(writer, ", ")
+		}
+		fmt.Fprintf(writer, "%s: %d", yaml.SafeString(kind), result.Kinds[kind])
+	}
+	fmt.Fprintln(writer, "}")
+	fmt.Fprintln(writer, "  oldest:")
+	for _, marker := range result.Oldest {
+		fmt.Fprintf(writer, "    - kind: %s\n", yaml.SafeString(marker.Kind))
+		fmt.Fprintf(writer, "      text: %s\n", yaml.SafeString(marker.Text))
+		fmt.Fprintf(writer, "      file: %s\n", yaml.SafeString(marker.File))
+		fmt.Fprintf(writer, "      line: %d\n", marker.Line)
+		fmt.Fprintf(writer, "      author: %d\n", marker.Author)
+		fmt.Fprintf(writer, "      day: %d\n", marker.Day)
+	}
+	fmt.Fprint(writer, "  lifetimes: [")
+	for i, lifetime := range result.Lifetimes {
+		if i > 0 {
+			fmt.Fprint(writer, ", ")
+		}
+		fmt.Fprint(writer, lifetime)
+	}
+	fmt.Fprintln(writer, "]")
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (debt *TechDebtAnalysis) serializeBinary(result *TechDebtResult, writer io.Writer) error {
+	message := pb.TechDebtAnalysisResults{
+		Sampling:  int32(result.Sampling),
+		Ticks:     make([]*pb.TechDebtTick, len(result.Ticks)),
+		Kinds:     map[string]int32{},
+		Oldest:    make([]*pb.TechDebtMarker, len(result.Oldest)),
+		Lifetimes: make([]int32, len(result.Lifetimes)),
+		DevIndex:  result.reversedPeopleDict,
+	}
+	for i, tick := range result.Ticks {
+		message.Ticks[i] = &pb.TechDebtTick{
+			Open: int32(tick.Open), Added: int32(tick.Added), Resolved: int32(tick.Resolved)}
+	}
+	for kind, count := range result.Kinds {
+		message.Kinds[kind] = int32(count)
+	}
+	for i, marker := range result.Oldest {
+		message.Oldest[i] = &pb.TechDebtMarker{
+			Kind: marker.Kind, Text: marker.Text, File: marker.File, Line: int32(marker.Line),
+			Author: int32(marker.Author), Day: int32(marker.Day)}
+	}
+	for i, lifetime := range result.Lifetimes {
+		message.Lifetimes[i] = int32(lifetime)
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&TechDebtAnalysis{})
+}
@@ -0,0 +1,223 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureTechDebt() *TechDebtAnalysis {
+	debt := TechDebtAnalysis{}
+	debt.Configure(map[string]interface{}{
+		ConfigTechDebtSampling:                          10,
+		ConfigTechDebtOldest:                            5,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"one", "two"},
+	})
+	debt.Initialize(nil)
+	return &debt
+}
+
+func TestTechDebtMeta(t *testing.T) {
+	debt := fixtureTechDebt()
+	assert.Equal(t, debt.Name(), "TechDebt")
+	assert.Len(t, debt.Provides(), 0)
+	assert.Contains(t, debt.Requires(), identity.DependencyAuthor)
+	assert.Contains(t, debt.Requires(), items.DependencyFileDiff)
+	assert.Contains(t, debt.Requires(), items.DependencyBlobCache)
+	assert.Equal(t, debt.Flag(), "tech-debt")
+	assert.NotEmpty(t, debt.Description())
+	opts := debt.ListConfigurationOptions()
+	assert.Len(t, opts, 3)
+	assert.Equal(t, opts[1].Flag, "tech-debt-markers")
+	assert.Equal(t, debt.Sampling, 10)
+	assert.Equal(t, debt.Markers, DefaultTechDebtMarkers)
+	assert.Equal(t, debt.Oldest, 5)
+	debt = &TechDebtAnalysis{Markers: []string{" "}, Oldest: -1}
+	debt.Initialize(nil)
+	assert.Equal(t, debt.Sampling, DefaultTechDebtSampling)
+	assert.Equal(t, debt.Markers, DefaultTechDebtMarkers)
+	assert.Equal(t, debt.Oldest, DefaultTechDebtOldest)
+	summoned := core.Registry.Summon(debt.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "TechDebt")
+}
+
+func TestTechDebtParse(t *testing.T) {
+	debt := TechDebtAnalysis{Markers: []string{"TODO", "FIX+ME"}}
+	debt.Initialize(nil)
+	marker := debt.parse("\tx := 1 // TODO(vadim): remove  ")
+	assert.Equal(t, *marker, TechDebtMarker{Kind: "TODO", Text: "x := 1 // TODO(vadim): remove"})
+	assert.Equal(t, debt.parse("# FIX+ME").Kind, "FIX+ME")
+	assert.Equal(t, debt.parse(" * TODO in a block comment").Kind, "TODO")
+	assert.Nil(t, debt.parse("x := \"TODO\""))
+	assert.Nil(t, debt.parse("// TODOS"))
+	assert.Nil(t, debt.parse("// FIXME"))
+}
+
+func fixtureTechDebtResult(t *testing.T) TechDebtResult {
+	storage := memory.NewStorage()
+	cache := map[plumbing.Hash]*object.Blob{}
+	blob := func(contents string) object.ChangeEntry {
+		encoded := storage.NewEncodedObject()
+		encoded.SetType(plumbing.BlobObject)
+		writer, _ := encoded.Writer()
+		writer.Write([]byte(contents))
+		writer.Close()
+		hash, err := storage.SetEncodedObject(encoded)
+		assert.Nil(t, err)
+		cache[hash], err = object.GetBlob(storage, hash)
+		assert.Nil(t, err)
+		return object.ChangeEntry{TreeEntry: object.TreeEntry{Hash: hash}}
+	}
+	entry := func(name, contents string) object.ChangeEntry {
+		result := blob(contents)
+		result.Name = name
+		result.TreeEntry.Name = name
+		return result
+	}
+	diff := func(before, after string) items.FileDiffData {
+		dmp := diffmatchpatch.New()
+		src, dst, _ := dmp.DiffLinesToRunes(before, after)
+		return items.FileDiffData{
+			OldLinesOfCode: len(src), NewLinesOfCode: len(dst),
+			Diffs: dmp.DiffMainRunes(src, dst, false)}
+	}
+	debt := fixtureTechDebt()
+	consume := func(day, author int, changes object.Changes, diffs map[string]items.FileDiffData) {
+		result, err := debt.Consume(map[string]interface{}{
+			core.DependencyCommit:       &object.Commit{},
+			core.DependencyIsMerge:      false,
+			identity.DependencyAuthor:   author,
+			items.DependencyDay:         day,
+			items.DependencyTreeChanges: changes,
+			items.DependencyFileDiff:    diffs,
+			items.DependencyBlobCache:   cache,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	v1 := "package main\n// TODO: one\nfunc main() {} // FIXME two\nx := \"TODO not a comment\"\n"
+	v2 := "// header\npackage main\n  // TODO: one\nfunc main() {}\nx := \"TODO not a comment\"\n" +
+		"// HACK three\n"
+	consume(0, 0, object.Changes{{To: entry("main.go", v1)}}, nil)
+	consume(12, 1, object.Changes{{From: entry("main.go", v1), To: entry("main.go", v2)}},
+		map[string]items.FileDiffData{"main.go": diff(v1, v2)})
+	consume(25, identity.AuthorMissing, object.Changes{
+		{From: entry("main.go", v2), To: entry("app.go", v2)},
+		{To: entry("lib.py", "# XXX four\n")},
+		{To: entry("image.png", "\x00XXX")},
+	}, map[string]items.FileDiffData{"app.go": diff(v2, v2)})
+	consume(26, 0, object.Changes{{From: entry("lib.py", "# XXX four\n")}}, nil)
+	return debt.Finalize().(TechDebtResult)
+}
+
+func TestTechDebtConsumeFinalize(t *testing.T) {
+	result := fixtureTechDebtResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Ticks, []TechDebtTick{
+		{Open: 2, Added: 2}, {Open: 2, Added: 1, Resolved: 1}, {Open: 2, Added: 1, Resolved: 1}})
+	assert.Equal(t, result.Kinds, map[string]int{"TODO": 1, "HACK": 1})
+	assert.Equal(t, result.Oldest, []TechDebtMarker{
+		{Kind: "TODO", Text: "// TODO: one", File: "app.go", Line: 3, Author: 0, Day: 0},
+		{Kind: "HACK", Text: "// HACK three", File: "app.go", Line: 6, Author: 1, Day: 12},
+	})
+	assert.Equal(t, result.Lifetimes, []int{1, 12})
+	assert.Equal(t, result.reversedPeopleDict, []string{"one", "two"})
+}
+
+func TestTechDebtConsumeMerge(t *testing.T) {
+	debt := fixtureTechDebt()
+	result, err := debt.Consume(map[string]interface{}{
+		core.DependencyCommit: &object.Commit{
+			ParentHashes: make([]plumbing.Hash, 2)},
+		core.DependencyIsMerge: true,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+	assert.Len(t, debt.Finalize().(TechDebtResult).Ticks, 0)
+}
+
+func TestTechDebtSerialize(t *testing.T) {
+	result := fixtureTechDebtResult(t)
+	debt := fixtureTechDebt()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, debt.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  # [open, added, resolved]
+  ticks: [[2, 2, 0], [2, 1, 1], [2, 1, 1]]
+  kinds: {"HACK": 1, "TODO": 1}
+  oldest:
+    - kind: "TODO"
+      text: "// TODO: one"
+      file: "app.go"
+      line: 3
+      author: 0
+      day: 0
+    - kind: "HACK"
+      text: "// HACK three"
+      file: "app.go"
+      line: 6
+      author: 1
+      day: 12
+  lifetimes: [1, 12]
+  people:
+  - "one"
+  - "two"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, debt.Serialize(result, true, buffer))
+	msg := pb.TechDebtAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Ticks, 3)
+	assert.Equal(t, msg.Oldest[1].Text, "// HACK three")
+	assert.Equal(t, msg.DevIndex, []string{"one", "two"})
+	deserialized, err := debt.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestTechDebtMergeResults(t *testing.T) {
+	r1 := TechDebtResult{
+		Ticks:     []TechDebtTick{{Open: 1, Added: 1}, {Open: 2, Added: 1}},
+		Kinds:     map[string]int{"TODO": 2},
+		Oldest:    []TechDebtMarker{{Kind: "TODO", File: "a", Author: 1, Day: 15}},
+		Lifetimes: []int{5},
+		Sampling:  10,
+
+		reversedPeopleDict: []string{"one", "two"},
+	}
+	r2 := TechDebtResult{
+		Ticks: []TechDebtTick{{Open: 3, Added: 4, Resolved: 1}},
+		Kinds: map[string]int{"TODO": 1, "FIXME": 2},
+		Oldest: []TechDebtMarker{
+			{Kind: "FIXME", File: "b", Author: 0, Day: 1}, {Kind: "FIXME", File: "c", Author: -1, Day: 2}},
+		Lifetimes: []int{3, 7},
+		Sampling:  20,
+
+		reversedPeopleDict: []string{"two"},
+	}
+	debt := fixtureTechDebt()
+	merged := debt.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 20 * 24 * 3600}).(TechDebtResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.Ticks, []TechDebtTick{
+		{Open: 2, Added: 2}, {Open: 5, Added: 4, Resolved: 1}})
+	assert.Equal(t, merged.Kinds, map[string]int{"TODO": 3, "FIXME": 2})
+	assert.Equal(t, merged.Oldest, []TechDebtMarker{
+		{Kind: "TODO", File: "a", Author: 1, Day: 15},
+		{Kind: "FIXME", File: "b", Author: 1, Day: 21},
+	})
+	assert.Equal(t, merged.Lifetimes, []int{3, 5, 7})
+	assert.Equal(t, merged.reversedPeopleDict, []string{"one", "two"})
+}
@@ -0,0 +1,477 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// TenureAnalysis tracks when each developer was active: the first and the last commit and
+// the active spans separated by more than InactiveDays without commits. It also counts
+// the new, the active and the departed developers in each tick of Sampling days, which
+// yields the contributor retention curves. A developer departs in the tick of the last commit
+// of a span if the next commit, or the end of the history, comes more than InactiveDays later.
+// The unmatched identities are ignored. It needs only the commit metadata, so it is suitable
+// for the fast mode.
+type TenureAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the number of days in a tick.
+	Sampling int
+	// InactiveDays is the number of days without commits after which a developer is
+	// considered departed.
+	InactiveDays int
+	// PeopleNumber is the number of developers.
+	PeopleNumber int
+
+	// days are the sorted unique days of the commits of each developer.
+	days [][]int
+	// commits is the number of commits of each developer.
+	commits []int
+	// lastDay is the latest day index seen so far.
+	lastDay int
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// TenureSpan is the time interval in which a developer was continuously active.
+type TenureSpan struct {
+	// Begin is the day of the first commit in the span.
+	Begin int
+	// End is the day of the last commit in the span.
+	End int
+}
+
+// TenureDeveloper is the activity record of a developer.
+type TenureDeveloper struct {
+	// Days are the sorted unique days of the commits.
+	Days []int
+	// Commits is the number of commits.
+	Commits int
+	// Spans are the active spans in the chronological order.
+	Spans []TenureSpan
+}
+
+// FirstDay returns the day of the first commit or -1 if there are no commits.
+func (dev TenureDeveloper) FirstDay() int {
+	if len(dev.Days) == 0 {
+		return -1
+	}
+	return dev.Days[0]
+}
+
+// LastDay returns the day of the last commit or -1 if there are no commits.
+func (dev TenureDeveloper) LastDay() int {
+	if len(dev.Days) == 0 {
+		return -1
+	}
+	return dev.Days[len(dev.Days)-1]
+}
+
+// TenureTick is the number of developers who joined, were active and departed in a tick.
+type TenureTick struct {
+	// New is the number of developers who made their first commit.
+	New int
+	// Active is the number of developers who made at least one commit.
+	Active int
+	// Departed is the number of developers who made the last commit of an active span.
+	Departed int
+}
+
+// TenureResult is returned by TenureAnalysis.Finalize().
+type TenureResult struct {
+	// Ticks are the developer counts in each tick of Sampling days.
+	Ticks []TenureTick
+	// People is the activity record of each developer.
+	People []TenureDeveloper
+	// Sampling is the effective TenureAnalysis.Sampling.
+	Sampling int
+	// InactiveDays is the effective TenureAnalysis.InactiveDays.
+	InactiveDays int
+	// LastDay is the last day of the analysed history.
+	LastDay int
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigTenureSampling is the name of the option to set TenureAnalysis.Sampling.
+	ConfigTenureSampling = "Tenure.Sampling"
+	// ConfigTenureInactiveDays is the name of the option to set TenureAnalysis.InactiveDays.
+	ConfigTenureInactiveDays = "Tenure.InactiveDays"
+	// DefaultTenureSampling is the default value of TenureAnalysis.Sampling.
+	DefaultTenureSampling = 30
+	// DefaultTenureInactiveDays is the default value of TenureAnalysis.InactiveDays.
+	DefaultTenureInactiveDays = 90
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (tenure *TenureAnalysis) Name() string {
+	return "Tenure"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (tenure *TenureAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (tenure *TenureAnalysis) Requires() []string {
+	arr := [...]string{identity.DependencyAuthor, items.DependencyDay}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (tenure *TenureAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigTenureSampling,
+		Description: "How frequently to count the new, active and departed developers, in days.",
+		Flag:        "tenure-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultTenureSampling}, {
+		Name: ConfigTenureInactiveDays,
+		Description: "Number of days without commits after which a developer is considered " +
+			"departed.",
+		Flag:    "tenure-inactive",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultTenureInactiveDays},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (tenure *TenureAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigTenureSampling].(int); exists {
+		tenure.Sampling = val
+	}
+	if val, exists := facts[ConfigTenureInactiveDays].(int); exists {
+		tenure.InactiveDays = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		tenure.PeopleNumber = val
+		tenure.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (tenure *TenureAnalysis) Flag() string {
+	return "tenure"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (tenure *TenureAnalysis) Description() string {
+	return "Tracks the first and the last activity and the active spans of each developer " +
+		"and counts the new, active and departed developers over time."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (tenure *TenureAnalysis) Initialize(repository *git.Repository) {
+	if tenure.Sampling <= 0 {
+		log.Printf("Warning: adjusted the tenure sampling to %d days\n",
+			DefaultTenureSampling)
+		tenure.Sampling = DefaultTenureSampling
+	}
+	if tenure.InactiveDays <= 0 {
+		log.Printf("Warning: adjusted the tenure inactivity to %d days\n",
+			DefaultTenureInactiveDays)
+		tenure.InactiveDays = DefaultTenureInactiveDays
+	}
+	tenure.days = make([][]int, tenure.PeopleNumber)
+	tenure.commits = make([]int, tenure.PeopleNumber)
+	tenure.lastDay = 0
+	tenure.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (tenure *TenureAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !tenure.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	day := deps[items.DependencyDay].(int)
+	if day > tenure.lastDay {
+		tenure.lastDay = day
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing || author >= tenure.PeopleNumber {
+		return nil, nil
+	}
+	tenure.commits[author]++
+	days := tenure.days[author]
+	pos := sort.SearchInts(days, day)
+	if pos == len(days) {
+		tenure.days[author] = append(days, day)
+	} else if days[pos] != day {
+		days = append(days, 0)
+		copy(days[pos+1:], days[pos:])
+		days[pos] = day
+		tenure.days[author] = days
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (tenure *TenureAnalysis) Finalize() interface{} {
+	return newTenureResult(tenure.days, tenure.commits, tenure.Sampling, tenure.InactiveDays,
+		tenure.lastDay, tenure.reversedPeopleDict)
+}
+
+// newTenureResult splits the commit days of each developer into the active spans
+// and counts the developers in each tick.
+func newTenureResult(days [][]int, commits []int, sampling, inactiveDays, lastDay int,
+	reversedPeopleDict []string) TenureResult {
+	result := TenureResult{
+		People:             make([]TenureDeveloper, len(days)),
+		Sampling:           sampling,
+		InactiveDays:       inactiveDays,
+		LastDay:            lastDay,
+		reversedPeopleDict: reversedPeopleDict,
+	}
+	tick := func(day int) *TenureTick {
+		index := day / sampling
+		for len(result.Ticks) <= index {
+			result.Ticks = append(result.Ticks, TenureTick{})
+		}
+		return &result.Ticks[index]
+	}
+	for i, devDays := range days {
+		dev := &result.People[i]
+		dev.Days = devDays
+		dev.Commits = commits[i]
+		if len(devDays) == 0 {
+			continue
+		}
+		tick(devDays[0]).New++
+		for j, day := range devDays {
+			if j == 0 || day/sampling != devDays[j-1]/sampling {
+				tick(day).Active++
+			}
+			if j == 0 || day-devDays[j-1] > inactiveDays {
+				dev.Spans = append(dev.Spans, TenureSpan{Begin: day, End: day})
+			} else {
+				dev.Spans[len(dev.Spans)-1].End = day
+			}
+		}
+		for _, span := range dev.Spans {
+			if lastDay-span.End > inactiveDays {
+				tick(span.End).Departed++
+			}
+		}
+	}
+	if len(result.Ticks) > 0 {
+		// the trailing ticks without any activity
+		tick(lastDay)
+	}
+	return result
+}
+
+// Fork clones this pipeline item.
+func (tenure *TenureAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(tenure, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (tenure *TenureAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	tenureResult := result.(TenureResult)
+	if binary {
+		return tenure.serializeBinary(&tenureResult, writer)
+	}
+	tenure.serializeText(&tenureResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (tenure *TenureAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(tenure, result, &pb.TenureAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (tenure *TenureAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(tenure, result, &pb.TenureAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to TenureResult.
+func (tenure *TenureAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.TenureAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := TenureResult{
+		People:             make([]TenureDeveloper, len(message.People)),
+		Sampling:           int(message.Sampling),
+		InactiveDays:       int(message.InactiveDays),
+		LastDay:            int(message.LastDay),
+		reversedPeopleDict: message.DevIndex,
+	}
+	if len(message.Ticks) > 0 {
+		result.Ticks = make([]TenureTick, len(message.Ticks))
+		for i, tick := range message.Ticks {
+			result.Ticks[i] = TenureTick{
+				New: int(tick.New), Active: int(tick.Active), Departed: int(tick.Departed)}
+		}
+	}
+	for i, person := range message.People {
+		dev := &result.People[i]
+		dev.Commits = int(person.Commits)
+		if len(person.Days) > 0 {
+			dev.Days = make([]int, len(person.Days))
+			for j, day := range person.Days {
+				dev.Days[j] = int(day)
+			}
+		}
+		if len(person.Spans) > 0 {
+			dev.Spans = make([]TenureSpan, len(person.Spans))
+			for j, span := range person.Spans {
+				dev.Spans[j] = TenureSpan{Begin: int(span.Begin), End: int(span.End)}
+			}
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two TenureResult-s together. The commit days are joined and
+// the spans and the ticks are calculated again with the larger sampling and the inactivity
+// threshold of the first result.
+func (tenure *TenureAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	tr1 := r1.(TenureResult)
+	tr2 := r2.(TenureResult)
+	sampling := tr1.Sampling
+	if tr2.Sampling > sampling {
+		sampling = tr2.Sampling
+	}
+	people, reversedPeopleDict := identity.Detector{}.MergeReversedDicts(
+		tr1.reversedPeopleDict, tr2.reversedPeopleDict)
+	daySets := make([]map[int]bool, len(reversedPeopleDict))
+	for i := range daySets {
+		daySets[i] = map[int]bool{}
+	}
+	commits := make([]int, len(reversedPeopleDict))
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	lastDay := 0
+	add := func(result *TenureResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		if result.LastDay+offset > lastDay {
+			lastDay = result.LastDay + offset
+		}
+		for i, dev := range result.People {
+			if i >= len(result.reversedPeopleDict) {
+				continue
+			}
+			index := people[result.reversedPeopleDict[i]][0]
+			commits[index] += dev.Commits
+			for _, day := range dev.Days {
+				daySets[index][day+offset] = true
+			}
+		}
+	}
+	add(&tr1, c1)
+	add(&tr2, c2)
+	days := make([][]int, len(daySets))
+	for i, set := range daySets {
+		if len(set) == 0 {
+			continue
+		}
+		days[i] = make([]int, 0, len(set))
+		for day := range set {
+			days[i] = append(days[i], day)
+		}
+		sort.Ints(days[i])
+	}
+	return newTenureResult(days, commits, sampling, tr1.InactiveDays, lastDay, reversedPeopleDict)
+}
+
+func (tenure *TenureAnalysis) serializeText(result *TenureResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  inactive_days:", result.InactiveDays)
+	fmt.Fprintln(writer, "  last_day:", result.LastDay)
+	fmt.Fprintln(writer, "  # [new, active, departed]")
+	fmt.Fprint(writer, "  ticks: [")
+	for i, tick := range result.Ticks {
+		if i > 0 {
+			fmt.Fprint(writer, ", ")
+		}
+		fmt.Fprintf(writer, "[%d, %d, %d]", tick.New, tick.Active, tick.Departed)
+	}
+	fmt.Fprintln(writer, "]")
+	fmt.Fprintln(writer, "  developers:")
+	for _, dev := range result.People {
+		fmt.Fprintf(writer, "    - first: %d\n", dev.FirstDay())
+		fmt.Fprintf(writer, "      last: %d\n", dev.LastDay())
+		fmt.Fprintf(writer, "      commits: %d\n", dev.Commits)
+		fmt.Fprint(writer, "      spans: [")
+		for i, span := range dev.Spans {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprintf(writer, "[%d, %d]", span.Begin, span.End)
+		}
+		fmt.Fprintln(writer, "]")
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (tenure *TenureAnalysis) serializeBinary(result *TenureResult, writer io.Writer) error {
+	message := pb.TenureAnalysisResults{
+		Ticks:        make([]*pb.TenureTick, len(result.Ticks)),
+		People:       make([]*pb.TenureDeveloper, len(result.People)),
+		Sampling:     int32(result.Sampling),
+		InactiveDays: int32(result.InactiveDays),
+		LastDay:      int32(result.LastDay),
+		DevIndex:     result.reversedPeopleDict,
+	}
+	for i, tick := range result.Ticks {
+		message.Ticks[i] = &pb.TenureTick{
+			New: int32(tick.New), Active: int32(tick.Active), Departed: int32(tick.Departed)}
+	}
+	for i, dev := range result.People {
+		person := &pb.TenureDeveloper{
+			Days:    make([]int32, len(dev.Days)),
+			Commits: int32(dev.Commits),
+			Spans:   make([]*pb.TenureSpan, len(dev.Spans)),
+		}
+		for j, day := range dev.Days {
+			person.Days[j] = int32(day)
+		}
+		for j, span := range dev.Spans {
+			person.Spans[j] = &pb.TenureSpan{Begin: int32(span.Begin), End: int32(span.End)}
+		}
+		message.People[i] = person
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&TenureAnalysis{})
+}
@@ -0,0 +1,199 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureTenure() *TenureAnalysis {
+	tenure := TenureAnalysis{}
+	tenure.Configure(map[string]interface{}{
+		ConfigTenureSampling:                            10,
+		ConfigTenureInactiveDays:                        20,
+		identity.FactIdentityDetectorPeopleCount:        3,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"alice", "bob", "carol"},
+	})
+	tenure.Initialize(nil)
+	return &tenure
+}
+
+func TestTenureMeta(t *testing.T) {
+	tenure := fixtureTenure()
+	assert.Equal(t, tenure.Name(), "Tenure")
+	assert.Len(t, tenure.Provides(), 0)
+	assert.Equal(t, tenure.Requires(), []string{identity.DependencyAuthor, items.DependencyDay})
+	assert.Equal(t, tenure.Flag(), "tenure")
+	assert.NotEmpty(t, tenure.Description())
+	opts := tenure.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Flag, "tenure-sampling")
+	assert.Equal(t, opts[1].Flag, "tenure-inactive")
+	assert.Equal(t, tenure.Sampling, 10)
+	assert.Equal(t, tenure.InactiveDays, 20)
+	assert.Equal(t, tenure.PeopleNumber, 3)
+	assert.Equal(t, tenure.reversedPeopleDict, []string{"alice", "bob", "carol"})
+	tenure = &TenureAnalysis{}
+	tenure.Initialize(nil)
+	assert.Equal(t, tenure.Sampling, DefaultTenureSampling)
+	assert.Equal(t, tenure.InactiveDays, DefaultTenureInactiveDays)
+	summoned := core.Registry.Summon(tenure.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Tenure")
+}
+
+func fixtureTenureResult(t *testing.T) TenureResult {
+	tenure := fixtureTenure()
+	consume := func(author, day int) {
+		result, err := tenure.Consume(map[string]interface{}{
+			core.DependencyCommit:     &object.Commit{},
+			core.DependencyIsMerge:    false,
+			identity.DependencyAuthor: author,
+			items.DependencyDay:       day,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	consume(0, 0)
+	consume(1, 3)
+	consume(0, 12)
+	consume(0, 5)
+	consume(0, 5)
+	consume(1, 30)
+	consume(0, 50)
+	consume(0, 55)
+	// the unmatched identities are ignored but extend the history
+	consume(identity.AuthorMissing, 70)
+	return tenure.Finalize().(TenureResult)
+}
+
+func TestTenureConsumeFinalize(t *testing.T) {
+	result := fixtureTenureResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.InactiveDays, 20)
+	assert.Equal(t, result.LastDay, 70)
+	assert.Equal(t, result.People, []TenureDeveloper{
+		{Days: []int{0, 5, 12, 50, 55}, Commits: 6,
+			Spans: []TenureSpan{{Begin: 0, End: 12}, {Begin: 50, End: 55}}},
+		{Days: []int{3, 30}, Commits: 2,
+			Spans: []TenureSpan{{Begin: 3, End: 3}, {Begin: 30, End: 30}}},
+		{},
+	})
+	assert.Equal(t, result.People[0].FirstDay(), 0)
+	assert.Equal(t, result.People[0].LastDay(), 55)
+	assert.Equal(t, result.People[2].FirstDay(), -1)
+	assert.Equal(t, result.People[2].LastDay(), -1)
+	assert.Equal(t, result.Ticks, []TenureTick{
+		{New: 2, Active: 2, Departed: 1},
+		{Active: 1, Departed: 1},
+		{},
+		{Active: 1, Departed: 1},
+		{},
+		{Active: 1},
+		{},
+		{},
+	})
+	assert.Equal(t, result.reversedPeopleDict, []string{"alice", "bob", "carol"})
+}
+
+func TestTenureConsumeMerge(t *testing.T) {
+	tenure := fixtureTenure()
+	result, err := tenure.Consume(map[string]interface{}{
+		core.DependencyCommit: &object.Commit{
+			Hash:         plumbing.NewHash("0123456789012345678901234567890123456789"),
+			ParentHashes: make([]plumbing.Hash, 2)},
+		core.DependencyIsMerge:    true,
+		identity.DependencyAuthor: 0,
+		items.DependencyDay:       4,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, tenure.Finalize().(TenureResult).People[0].Days, []int{4})
+}
+
+func TestTenureSerialize(t *testing.T) {
+	result := fixtureTenureResult(t)
+	tenure := fixtureTenure()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, tenure.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  inactive_days: 20
+  last_day: 70
+  # [new, active, departed]
+  ticks: [[2, 2, 1], [0, 1, 1], [0, 0, 0], [0, 1, 1], [0, 0, 0], [0, 1, 0], [0, 0, 0], [0, 0, 0]]
+  developers:
+    - first: 0
+      last: 55
+      commits: 6
+      spans: [[0, 12], [50, 55]]
+    - first: 3
+      last: 30
+      commits: 2
+      spans: [[3, 3], [30, 30]]
+    - first: -1
+      last: -1
+      commits: 0
+      spans: []
+  people:
+  - "alice"
+  - "bob"
+  - "carol"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, tenure.Serialize(result, true, buffer))
+	msg := pb.TenureAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Ticks, 8)
+	assert.Equal(t, msg.Ticks[0].New, int32(2))
+	assert.Len(t, msg.People, 3)
+	assert.Equal(t, msg.People[0].Days, []int32{0, 5, 12, 50, 55})
+	assert.Len(t, msg.People[1].Spans, 2)
+	deserialized, err := tenure.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestTenureMergeResults(t *testing.T) {
+	r1 := TenureResult{
+		People:             []TenureDeveloper{{Days: []int{0, 10}, Commits: 2}},
+		Sampling:           10,
+		InactiveDays:       20,
+		LastDay:            20,
+		reversedPeopleDict: []string{"alice"},
+	}
+	r2 := TenureResult{
+		People: []TenureDeveloper{
+			{Days: []int{0}, Commits: 1}, {Days: []int{5}, Commits: 1}},
+		Sampling:           20,
+		InactiveDays:       30,
+		LastDay:            30,
+		reversedPeopleDict: []string{"bob", "alice"},
+	}
+	tenure := fixtureTenure()
+	merged := tenure.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 30 * 24 * 3600}).(TenureResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.InactiveDays, 20)
+	assert.Equal(t, merged.LastDay, 60)
+	assert.Equal(t, merged.reversedPeopleDict, []string{"alice", "bob"})
+	assert.Equal(t, merged.People, []TenureDeveloper{
+		{Days: []int{0, 10, 35}, Commits: 3,
+			Spans: []TenureSpan{{Begin: 0, End: 10}, {Begin: 35, End: 35}}},
+		{Days: []int{30}, Commits: 1, Spans: []TenureSpan{{Begin: 30, End: 30}}},
+	})
+	assert.Equal(t, merged.Ticks, []TenureTick{
+		{New: 1, Active: 1, Departed: 1},
+		{New: 1, Active: 2, Departed: 2},
+		{},
+		{},
+	})
+}
@@ -0,0 +1,421 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/format/gitignore"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+// TestRatioAnalysis splits the files into the tests and the production code and measures
+// the size and the churn of both. A file is a test if it matches one of TestPatterns
+// or follows the common naming conventions: it lies in a directory such as "test" or
+// "__tests__" or its name looks like "foo_test.go", "test_foo.py", "FooTest.java"
+// or "foo.spec.js". Every Sampling days, the numbers of lines and the numbers of changed
+// lines of each kind are recorded. The binary files and the merge commits are skipped.
+type TestRatioAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the size of a tick in days.
+	Sampling int
+	// TestPatterns are the additional .gitignore-like patterns of the test files,
+	// e.g. "e2e/" or "*_it.go".
+	TestPatterns []string
+
+	// patterns are the parsed TestPatterns.
+	patterns []gitignore.Pattern
+	// files map the file names to the numbers of lines.
+	files map[string]int
+	ticks []TestRatioTick
+}
+
+// TestRatioTick is the size and the churn of the tests and the production code in a tick.
+type TestRatioTick struct {
+	// TestLines is the number of lines in the tests at the end of the tick.
+	TestLines int
+	// ProductionLines is the number of lines in the production code at the end of the tick.
+	ProductionLines int
+	// TestChurn is the number of added and removed lines in the tests.
+	TestChurn int
+	// ProductionChurn is the number of added and removed lines in the production code.
+	ProductionChurn int
+}
+
+// Ratio returns the number of lines in the tests per line of the production code.
+func (tick TestRatioTick) Ratio() float64 {
+	if tick.ProductionLines == 0 {
+		return 0
+	}
+	return float64(tick.TestLines) / float64(tick.ProductionLines)
+}
+
+// TestRatioResult is returned by TestRatioAnalysis.Finalize().
+type TestRatioResult struct {
+	Ticks []TestRatioTick
+	// Sampling is the size of a tick in days.
+	Sampling int
+}
+
+const (
+	// ConfigTestRatioSampling is the name of the option to set TestRatioAnalysis.Sampling.
+	ConfigTestRatioSampling = "TestRatio.Sampling"
+	// ConfigTestRatioPatterns is the name of the option to set TestRatioAnalysis.TestPatterns.
+	ConfigTestRatioPatterns = "TestRatio.Patterns"
+	// DefaultTestRatioSampling is the default value of TestRatioAnalysis.Sampling.
+	DefaultTestRatioSampling = 30
+)
+
+// testDirectories are the names of the directories which contain the tests by convention.
+var testDirectories = map[string]bool{
+	"test": true, "tests": true, "__tests__": true, "spec": true, "specs": true,
+	"testing": true, "testdata": true,
+}
+
+// testNameSuffixes are the endings of the test file names without the extensions.
+var testNameSuffixes = []string{
+	"_test", "_tests", "_spec", "_unittest", ".test", ".spec", "-test", "-spec", "Test", "Tests",
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (ratio *TestRatioAnalysis) Name() string {
+	return "TestRatio"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (ratio *TestRatioAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (ratio *TestRatioAnalysis) Requires() []string {
+	arr := [...]string{
+		items.DependencyTreeChanges, items.DependencyFileDiff, items.DependencyBlobCache,
+		items.DependencyDay}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (ratio *TestRatioAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigTestRatioSampling,
+		Description: "How frequently to record the size of the tests and the production code in days.",
+		Flag:        "test-ratio-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultTestRatioSampling}, {
+		Name: ConfigTestRatioPatterns,
+		Description: "Additional .gitignore-like patterns of the test files, e.g. \"e2e/\". " +
+			"Separated with commas \",\".",
+		Flag:    "test-ratio-patterns",
+		Type:    core.StringsConfigurationOption,
+		Default: []string{}},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (ratio *TestRatioAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigTestRatioSampling].(int); exists {
+		ratio.Sampling = val
+	}
+	if val, exists := facts[ConfigTestRatioPatterns].([]string); exists {
+		ratio.TestPatterns = val
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (ratio *TestRatioAnalysis) Flag() string {
+	return "test-ratio"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (ratio *TestRatioAnalysis) Description() string {
+	return "Measures the size and the churn of the tests and of the production code over time."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (ratio *TestRatioAnalysis) Initialize(repository *git.Repository) {
+	if ratio.Sampling <= 0 {
+		log.Printf("Warning: adjusted the test ratio sampling to %d days\n",
+			DefaultTestRatioSampling)
+		ratio.Sampling = DefaultTestRatioSampling
+	}
+	ratio.patterns = nil
+	for _, pattern := range ratio.TestPatterns {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			ratio.patterns = append(ratio.patterns, gitignore.ParsePattern(pattern, nil))
+		}
+	}
+	ratio.files = map[string]int{}
+	ratio.ticks = []TestRatioTick{}
+	ratio.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (ratio *TestRatioAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !ratio.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	day := deps[items.DependencyDay].(int)
+	tick := day / ratio.Sampling
+	for len(ratio.ticks) <= tick {
+		next := TestRatioTick{}
+		if len(ratio.ticks) > 0 {
+			last := ratio.ticks[len(ratio.ticks)-1]
+			next.TestLines, next.ProductionLines = last.TestLines, last.ProductionLines
+		}
+		ratio.ticks = append(ratio.ticks, next)
+	}
+	stats := &ratio.ticks[tick]
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*object.Blob)
+	fileDiffs := deps[items.DependencyFileDiff].(map[string]items.FileDiffData)
+	for _, change := range deps[items.DependencyTreeChanges].(object.Changes) {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			lines, err := items.CountLines(cache[change.To.TreeEntry.Hash])
+			if err != nil {
+				if err.Error() == "binary" {
+					continue
+				}
+				return nil, err
+			}
+			ratio.files[change.To.Name] = lines
+			ratio.update(stats, change.To.Name, lines, lines)
+		case merkletrie.Delete:
+			lines, exists := ratio.files[change.From.Name]
+			if !exists {
+				continue
+			}
+			delete(ratio.files, change.From.Name)
+			ratio.update(stats, change.From.Name, -lines, lines)
+		case merkletrie.Modify:
+			lines, exists := ratio.files[change.From.Name]
+			if !exists {
+				continue
+			}
+			delete(ratio.files, change.From.Name)
+			var added, removed int
+			for _, edit := range fileDiffs[change.To.Name].Diffs {
+				// FileDiff encodes each line as a single rune
+				switch edit.Type {
+				case diffmatchpatch.DiffInsert:
+					added += utf8.RuneCountInString(edit.Text)
+				case diffmatchpatch.DiffDelete:
+					removed += utf8.RuneCountInString(edit.Text)
+				}
+			}
+			ratio.files[change.To.Name] = lines + added - removed
+			// the file can move between the tests and the production code
+			ratio.update(stats, change.From.Name, -lines, 0)
+			ratio.update(stats, change.To.Name, lines+added-removed, added+removed)
+		}
+	}
+	return nil, nil
+}
+
+// update adds the lines and the churn of the file to the corresponding kind.
+func (ratio *TestRatioAnalysis) update(stats *TestRatioTick, name string, lines, churn int) {
+	if ratio.isTest(name) {
+		stats.TestLines += lines
+		stats.TestChurn += churn
+	} else {
+		stats.ProductionLines += lines
+		stats.ProductionChurn += churn
+	}
+}
+
+// isTest returns whether the file belongs to the tests.
+func (ratio *TestRatioAnalysis) isTest(name string) bool {
+	parts := strings.Split(name, "/")
+	for _, pattern := range ratio.patterns {
+		if pattern.Match(parts, false) == gitignore.Exclude {
+			return true
+		}
+	}
+	for _, dir := range parts[:len(parts)-1] {
+		if testDirectories[strings.ToLower(dir)] {
+			return true
+		}
+	}
+	base := path.Base(name)
+	stem := strings.TrimSuffix(base, path.Ext(base))
+	if strings.HasPrefix(stem, "test_") || stem == "conftest" {
+		return true
+	}
+	for _, suffix := range testNameSuffixes {
+		if strings.HasSuffix(stem, suffix) && len(stem) > len(suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (ratio *TestRatioAnalysis) Finalize() interface{} {
+	return TestRatioResult{
+		Ticks:    ratio.ticks,
+		Sampling: ratio.Sampling,
+	}
+}
+
+// Fork clones this pipeline item.
+func (ratio *TestRatioAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(ratio, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (ratio *TestRatioAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	ratioResult := result.(TestRatioResult)
+	if binary {
+		return ratio.serializeBinary(&ratioResult, writer)
+	}
+	ratio.serializeText(&ratioResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (ratio *TestRatioAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(ratio, result, &pb.TestRatioAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (ratio *TestRatioAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(ratio, result, &pb.TestRatioAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to TestRatioResult.
+func (ratio *TestRatioAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.TestRatioAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := TestRatioResult{
+		Ticks:    make([]TestRatioTick, len(message.Ticks)),
+		Sampling: int(message.Sampling),
+	}
+	for i, tick := range message.Ticks {
+		result.Ticks[i] = TestRatioTick{
+			TestLines:       int(tick.TestLines),
+			ProductionLines: int(tick.ProductionLines),
+			TestChurn:       int(tick.TestChurn),
+			ProductionChurn: int(tick.ProductionChurn),
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two TestRatioResult-s together. The ticks are resampled to the bigger
+// sampling of the two.
+func (ratio *TestRatioAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	tr1 := r1.(TestRatioResult)
+	tr2 := r2.(TestRatioResult)
+	merged := TestRatioResult{Sampling: tr1.Sampling}
+	if tr2.Sampling > merged.Sampling {
+		merged.Sampling = tr2.Sampling
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	offsets := [2]int{
+		int((c1.BeginTime - beginTime) / (24 * 3600)),
+		int((c2.BeginTime - beginTime) / (24 * 3600)),
+	}
+	results := [2]*TestRatioResult{&tr1, &tr2}
+	days := 0
+	for i, result := range results {
+		if end := offsets[i] + len(result.Ticks)*result.Sampling; end > days {
+			days = end
+		}
+	}
+	merged.Ticks = make([]TestRatioTick, (days+merged.Sampling-1)/merged.Sampling)
+	for i, result := range results {
+		for j, tick := range result.Ticks {
+			// the merged tick which contains the first day of the tick
+			k := (j*result.Sampling + offsets[i]) / merged.Sampling
+			merged.Ticks[k].TestChurn += tick.TestChurn
+			merged.Ticks[k].ProductionChurn += tick.ProductionChurn
+		}
+		// the number of lines at the end of each merged tick
+		for k := range merged.Ticks {
+			day := (k+1)*merged.Sampling - 1 - offsets[i]
+			if day < 0 || len(result.Ticks) == 0 {
+				continue
+			}
+			index := day / result.Sampling
+			if index >= len(result.Ticks) {
+				index = len(result.Ticks) - 1
+			}
+			merged.Ticks[k].TestLines += result.Ticks[index].TestLines
+			merged.Ticks[k].ProductionLines += result.Ticks[index].ProductionLines
+		}
+	}
+	return merged
+}
+
+func (ratio *TestRatioAnalysis) serializeText(result *TestRatioResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  # [test lines, production lines, test churn, production churn]")
+	fmt.Fprint(writer, "  ticks: [")
+	for i, tick := range result.Ticks {
+		if i > 0 {
+			fmt.Fprint(writer, ", ")
+		}
+		fmt.Fprintf(writer, "[%d, %d, %d, %d]",
+			tick.TestLines, tick.ProductionLines, tick.TestChurn, tick.ProductionChurn)
+	}
+	fmt.Fprintln(writer, "]")
+}
+
+func (ratio *TestRatioAnalysis) serializeBinary(result *TestRatioResult, writer io.Writer) error {
+	message := pb.TestRatioAnalysisResults{
+		Sampling: int32(result.Sampling),
+		Ticks:    make([]*pb.TestRatioTick, len(result.Ticks)),
+	}
+	for i, tick := range result.Ticks {
+		message.Ticks[i] = &pb.TestRatioTick{
+			TestLines:       int32(tick.TestLines),
+			ProductionLines: int32(tick.ProductionLines),
+			TestChurn:       int32(tick.TestChurn),
+			ProductionChurn: int32(tick.ProductionChurn),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&TestRatioAnalysis{})
+}
@@ -0,0 +1,186 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+)
+
+func fixtureTestRatio() *TestRatioAnalysis {
+	ratio := TestRatioAnalysis{}
+	ratio.Configure(map[string]interface{}{
+		ConfigTestRatioSampling: 10,
+		ConfigTestRatioPatterns: []string{"e2e/", " ", "*_it.go"},
+	})
+	ratio.Initialize(nil)
+	return &ratio
+}
+
+func TestTestRatioMeta(t *testing.T) {
+	ratio := fixtureTestRatio()
+	assert.Equal(t, ratio.Name(), "TestRatio")
+	assert.Len(t, ratio.Provides(), 0)
+	assert.Equal(t, ratio.Requires(), []string{
+		items.DependencyTreeChanges, items.DependencyFileDiff, items.DependencyBlobCache,
+		items.DependencyDay})
+	assert.Equal(t, ratio.Flag(), "test-ratio")
+	assert.NotEmpty(t, ratio.Description())
+	opts := ratio.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Flag, "test-ratio-sampling")
+	assert.Equal(t, opts[1].Flag, "test-ratio-patterns")
+	assert.Equal(t, ratio.Sampling, 10)
+	assert.Len(t, ratio.patterns, 2)
+	ratio = &TestRatioAnalysis{}
+	ratio.Initialize(nil)
+	assert.Equal(t, ratio.Sampling, DefaultTestRatioSampling)
+	summoned := core.Registry.Summon(ratio.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "TestRatio")
+}
+
+func TestTestRatioIsTest(t *testing.T) {
+	ratio := fixtureTestRatio()
+	for _, name := range []string{
+		"core/pipeline_test.go", "src/test/java/com/foo/Bar.java", "app/__tests__/x.js",
+		"web/button.spec.ts", "lib/parser.test.js", "test_utils.py", "pkg/conftest.py",
+		"FooTest.java", "BarTests.cs", "spec/model_spec.rb", "Tests/Fixtures/a.json",
+		"e2e/login.go", "server_it.go", "base_unittest.cc"} {
+		assert.True(t, ratio.isTest(name), name)
+	}
+	for _, name := range []string{
+		"core/pipeline.go", "Test.java", "latest.go", "contest.py", "testing.go",
+		"attest/x.go", "README.md", "_test.go", "src/e2e.go"} {
+		assert.False(t, ratio.isTest(name), name)
+	}
+}
+
+func fixtureTestRatioResult(t *testing.T) TestRatioResult {
+	storage := memory.NewStorage()
+	cache := map[plumbing.Hash]*object.Blob{}
+	entry := func(name, contents string) object.ChangeEntry {
+		encoded := storage.NewEncodedObject()
+		encoded.SetType(plumbing.BlobObject)
+		writer, _ := encoded.Writer()
+		writer.Write([]byte(contents))
+		writer.Close()
+		hash, err := storage.SetEncodedObject(encoded)
+		assert.Nil(t, err)
+		cache[hash], err = object.GetBlob(storage, hash)
+		assert.Nil(t, err)
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	diff := func(before, after string) items.FileDiffData {
+		dmp := diffmatchpatch.New()
+		src, dst, _ := dmp.DiffLinesToRunes(before, after)
+		return items.FileDiffData{
+			OldLinesOfCode: len(src), NewLinesOfCode: len(dst),
+			Diffs: dmp.DiffMainRunes(src, dst, false)}
+	}
+	ratio := fixtureTestRatio()
+	consume := func(day int, changes object.Changes, diffs map[string]items.FileDiffData) {
+		result, err := ratio.Consume(map[string]interface{}{
+			core.DependencyCommit:       &object.Commit{},
+			core.DependencyIsMerge:      false,
+			items.DependencyDay:         day,
+			items.DependencyTreeChanges: changes,
+			items.DependencyFileDiff:    diffs,
+			items.DependencyBlobCache:   cache,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	v1 := "a\nb\nc\n"
+	v2 := "a\nx\nc\nd\n"
+	helpers := "func helper() {\n}\n"
+	consume(0, object.Changes{
+		{To: entry("main.go", v1)},
+		{To: entry("helpers_test.go", helpers)},
+		{To: entry("image.png", "\x00PNG")},
+	}, nil)
+	consume(5, object.Changes{
+		{From: entry("main.go", v1), To: entry("main.go", v2)},
+		{From: entry("image.png", "\x00PNG"), To: entry("image.png", "\x00GIF")},
+	}, map[string]items.FileDiffData{"main.go": diff(v1, v2)})
+	consume(12, object.Changes{
+		{From: entry("helpers_test.go", helpers), To: entry("helpers.go", helpers)},
+		{From: entry("main.go", v2)},
+	}, map[string]items.FileDiffData{"helpers.go": diff(helpers, helpers)})
+	return ratio.Finalize().(TestRatioResult)
+}
+
+func TestTestRatioConsumeFinalize(t *testing.T) {
+	result := fixtureTestRatioResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Ticks, []TestRatioTick{
+		{TestLines: 2, ProductionLines: 4, TestChurn: 2, ProductionChurn: 6},
+		{TestLines: 0, ProductionLines: 2, TestChurn: 0, ProductionChurn: 4},
+	})
+	assert.InDelta(t, result.Ticks[0].Ratio(), 0.5, 0.001)
+	assert.Equal(t, TestRatioTick{TestLines: 1}.Ratio(), float64(0))
+}
+
+func TestTestRatioConsumeMerge(t *testing.T) {
+	ratio := fixtureTestRatio()
+	result, err := ratio.Consume(map[string]interface{}{
+		core.DependencyCommit: &object.Commit{
+			ParentHashes: make([]plumbing.Hash, 2)},
+		core.DependencyIsMerge: true,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+	assert.Len(t, ratio.Finalize().(TestRatioResult).Ticks, 0)
+}
+
+func TestTestRatioSerialize(t *testing.T) {
+	result := fixtureTestRatioResult(t)
+	ratio := fixtureTestRatio()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, ratio.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  # [test lines, production lines, test churn, production churn]
+  ticks: [[2, 4, 2, 6], [0, 2, 0, 4]]
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, ratio.Serialize(result, true, buffer))
+	msg := pb.TestRatioAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Len(t, msg.Ticks, 2)
+	assert.Equal(t, msg.Ticks[0].ProductionChurn, int32(6))
+	deserialized, err := ratio.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestTestRatioMergeResults(t *testing.T) {
+	r1 := TestRatioResult{
+		Ticks: []TestRatioTick{
+			{TestLines: 1, ProductionLines: 10, TestChurn: 1, ProductionChurn: 10},
+			{TestLines: 5, ProductionLines: 12, TestChurn: 4, ProductionChurn: 2},
+		},
+		Sampling: 10,
+	}
+	r2 := TestRatioResult{
+		Ticks:    []TestRatioTick{{TestLines: 3, ProductionLines: 3, TestChurn: 3, ProductionChurn: 3}},
+		Sampling: 20,
+	}
+	ratio := fixtureTestRatio()
+	merged := ratio.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 20 * 24 * 3600}).(TestRatioResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.Ticks, []TestRatioTick{
+		{TestLines: 5, ProductionLines: 12, TestChurn: 5, ProductionChurn: 12},
+		{TestLines: 8, ProductionLines: 15, TestChurn: 3, ProductionChurn: 3},
+	})
+}
@@ -0,0 +1,517 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fatih/camelcase"
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// VocabularyAnalysis tracks the terms which the identifiers consist of. The identifiers
+// are the tokens of the UAST nodes with the Identifier role; they are split by the non-alphanumeric
+// characters and by the case changes, e.g. "parseHTTPHeader_v2" becomes "parse", "http", "header"
+// and "v". The terms shorter than two characters and the numbers are dropped. The vocabulary is
+// the set of the terms which occur in the current files. Every Sampling days, it records the size
+// of the vocabulary and how many terms appeared and disappeared. Besides, each developer is
+// credited with the term occurrences added in their commits. The files which cannot be parsed
+// keep the previous terms. The merge commits are skipped.
+type VocabularyAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Sampling is the size of a tick in days.
+	Sampling int
+	// TopTerms is the maximum number of the most frequent terms to report in the whole project
+	// and for each developer. 0 means no limit.
+	TopTerms int
+	// PeopleNumber is the number of developers for which to collect the vocabularies.
+	PeopleNumber int
+
+	// files map the file names to the term frequencies.
+	files map[string]map[string]int
+	// terms are the frequencies of the terms in all the files.
+	terms map[string]int
+	// people are the numbers of the added term occurrences of each developer.
+	people []map[string]int
+	ticks  []VocabularyTick
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// VocabularyTick is the evolution of the vocabulary during a tick.
+type VocabularyTick struct {
+	// Terms is the size of the vocabulary at the end of the tick.
+	Terms int
+	// Introduced is the number of the terms which appeared in the vocabulary.
+	Introduced int
+	// Retired is the number of the terms which disappeared from the vocabulary.
+	Retired int
+}
+
+// VocabularyResult is returned by VocabularyAnalysis.Finalize().
+type VocabularyResult struct {
+	Ticks []VocabularyTick
+	// Terms are the frequencies of the terms in the end of the history.
+	Terms map[string]int
+	// People are the numbers of the term occurrences which each developer added.
+	// The last element corresponds to the unmatched identities.
+	People []map[string]int
+	// Sampling is the size of a tick in days.
+	Sampling int
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigVocabularySampling is the name of the option to set VocabularyAnalysis.Sampling.
+	ConfigVocabularySampling = "Vocabulary.Sampling"
+	// ConfigVocabularyTopTerms is the name of the option to set VocabularyAnalysis.TopTerms.
+	ConfigVocabularyTopTerms = "Vocabulary.TopTerms"
+	// DefaultVocabularySampling is the default value of VocabularyAnalysis.Sampling.
+	DefaultVocabularySampling = 30
+	// DefaultVocabularyTopTerms is the default value of VocabularyAnalysis.TopTerms.
+	DefaultVocabularyTopTerms = 100
+	// vocabularyMinTermLength is the minimum number of characters in a term.
+	vocabularyMinTermLength = 2
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (analyser *VocabularyAnalysis) Name() string {
+	return "Vocabulary"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (analyser *VocabularyAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (analyser *VocabularyAnalysis) Requires() []string {
+	arr := [...]string{
+		uast_items.DependencyUastChanges, identity.DependencyAuthor, items.DependencyDay}
+	return arr[:]
+}
+
+// Features which must be enabled for this PipelineItem to be automatically inserted into the DAG.
+func (analyser *VocabularyAnalysis) Features() []string {
+	arr := [...]string{uast_items.FeatureUast}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (analyser *VocabularyAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigVocabularySampling,
+		Description: "How frequently to record the vocabulary size in days.",
+		Flag:        "vocabulary-sampling",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultVocabularySampling}, {
+		Name: ConfigVocabularyTopTerms,
+		Description: "Report this number of the most frequent terms in the project and " +
+			"for each developer. 0 means all.",
+		Flag:    "vocabulary-top",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultVocabularyTopTerms},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (analyser *VocabularyAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigVocabularySampling].(int); exists {
+		analyser.Sampling = val
+	}
+	if val, exists := facts[ConfigVocabularyTopTerms].(int); exists {
+		analyser.TopTerms = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		analyser.PeopleNumber = val
+		analyser.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (analyser *VocabularyAnalysis) Flag() string {
+	return "vocabulary"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (analyser *VocabularyAnalysis) Description() string {
+	return "Splits the identifiers into terms and tracks the vocabulary over time: " +
+		"the introduced and the retired terms and the terms of each developer."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (analyser *VocabularyAnalysis) Initialize(repository *git.Repository) {
+	if analyser.Sampling <= 0 {
+		log.Printf("Warning: adjusted the vocabulary sampling to %d days\n",
+			DefaultVocabularySampling)
+		analyser.Sampling = DefaultVocabularySampling
+	}
+	if analyser.TopTerms < 0 {
+		log.Printf("Warning: adjusted the number of the top vocabulary terms to %d\n",
+			DefaultVocabularyTopTerms)
+		analyser.TopTerms = DefaultVocabularyTopTerms
+	}
+	analyser.files = map[string]map[string]int{}
+	analyser.terms = map[string]int{}
+	analyser.people = make([]map[string]int, analyser.PeopleNumber+1)
+	for i := range analyser.people {
+		analyser.people[i] = map[string]int{}
+	}
+	analyser.ticks = []VocabularyTick{}
+	analyser.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (analyser *VocabularyAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !analyser.ShouldConsumeCommit(deps) || deps[core.DependencyIsMerge].(bool) {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing || author > analyser.PeopleNumber {
+		author = analyser.PeopleNumber
+	}
+	changes := deps[uast_items.DependencyUastChanges].([]uast_items.Change)
+	day := deps[items.DependencyDay].(int)
+	tick := day / analyser.Sampling
+	for len(analyser.ticks) <= tick {
+		analyser.ticks = append(analyser.ticks, VocabularyTick{Terms: len(analyser.terms)})
+	}
+	stats := &analyser.ticks[tick]
+	for _, change := range changes {
+		fromName, toName := change.Change.From.Name, change.Change.To.Name
+		old := analyser.files[fromName]
+		delete(analyser.files, fromName)
+		terms := old
+		if change.After != nil {
+			terms = extractTerms(change.After)
+		} else if toName == "" {
+			terms = nil
+		}
+		for term, count := range terms {
+			delta := count - old[term]
+			if delta <= 0 {
+				continue
+			}
+			if analyser.terms[term] == 0 {
+				stats.Introduced++
+			}
+			analyser.terms[term] += delta
+			analyser.people[author][term] += delta
+		}
+		for term, count := range old {
+			delta := count - terms[term]
+			if delta <= 0 {
+				continue
+			}
+			analyser.terms[term] -= delta
+			if analyser.terms[term] <= 0 {
+				delete(analyser.terms, term)
+				stats.Retired++
+			}
+		}
+		if len(terms) > 0 {
+			analyser.files[toName] = terms
+		}
+	}
+	stats.Terms = len(analyser.terms)
+	return nil, nil
+}
+
+// extractTerms returns the frequencies of the terms in the identifiers of the UAST.
+func extractTerms(root *uast.Node) map[string]int {
+	terms := map[string]int{}
+	uast_items.VisitEachNode(root, func(node *uast.Node) {
+		if node.Token != "" && hasRoles(node, uast.Identifier) {
+			for _, term := range splitIdentifier(node.Token) {
+				terms[term]++
+			}
+		}
+	})
+	return terms
+}
+
+// splitIdentifier splits the identifier into lower case terms by the non-alphanumeric
+// characters and by the case changes.
+func splitIdentifier(identifier string) []string {
+	var result []string
+	parts := strings.FieldsFunc(identifier, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, part := range parts {
+		for _, word := range camelcase.Split(part) {
+			if utf8.RuneCountInString(word) < vocabularyMinTermLength ||
+				strings.IndexFunc(word, unicode.IsLetter) < 0 {
+				continue
+			}
+			result = append(result, strings.ToLower(word))
+		}
+	}
+	return result
+}
+
+// topVocabularyTerms returns the `size` most frequent terms, all if `size` is not positive.
+func topVocabularyTerms(terms map[string]int, size int) map[string]int {
+	keys := make([]string, 0, len(terms))
+	for term := range terms {
+		keys = append(keys, term)
+	}
+	if size > 0 && len(keys) > size {
+		sort.Slice(keys, func(i, j int) bool {
+			if terms[keys[i]] != terms[keys[j]] {
+				return terms[keys[i]] > terms[keys[j]]
+			}
+			return keys[i] < keys[j]
+		})
+		keys = keys[:size]
+	}
+	result := make(map[string]int, len(keys))
+	for _, term := range keys {
+		result[term] = terms[term]
+	}
+	return result
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (analyser *VocabularyAnalysis) Finalize() interface{} {
+	people := make([]map[string]int, len(analyser.people))
+	for i, terms := range analyser.people {
+		people[i] = topVocabularyTerms(terms, analyser.TopTerms)
+	}
+	return VocabularyResult{
+		Ticks:              analyser.ticks,
+		Terms:              topVocabularyTerms(analyser.terms, analyser.TopTerms),
+		People:             people,
+		Sampling:           analyser.Sampling,
+		reversedPeopleDict: analyser.reversedPeopleDict,
+	}
+}
+
+// Fork clones this pipeline item.
+func (analyser *VocabularyAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(analyser, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (analyser *VocabularyAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	vocabularyResult := result.(VocabularyResult)
+	if binary {
+		return analyser.serializeBinary(&vocabularyResult, writer)
+	}
+	analyser.serializeText(&vocabularyResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (analyser *VocabularyAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(analyser, result, &pb.VocabularyAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (analyser *VocabularyAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(analyser, result, &pb.VocabularyAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to VocabularyResult.
+func (analyser *VocabularyAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.VocabularyAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	convert := func(terms map[string]int32) map[string]int {
+		result := make(map[string]int, len(terms))
+		for term, count := range terms {
+			result[term] = int(count)
+		}
+		return result
+	}
+	result := VocabularyResult{
+		Ticks:              make([]VocabularyTick, len(message.Ticks)),
+		Terms:              convert(message.Terms),
+		People:             make([]map[string]int, len(message.People)),
+		Sampling:           int(message.Sampling),
+		reversedPeopleDict: message.DevIndex,
+	}
+	for i, tick := range message.Ticks {
+		result.Ticks[i] = VocabularyTick{
+			Terms: int(tick.Terms), Introduced: int(tick.Introduced), Retired: int(tick.Retired)}
+	}
+	for i, terms := range message.People {
+		result.People[i] = convert(terms.Terms)
+	}
+	return result, nil
+}
+
+// MergeResults combines two VocabularyResult-s together. The ticks are resampled to
+// the bigger sampling of the two. The vocabulary sizes are summed, so the common terms
+// are counted twice.
+func (analyser *VocabularyAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	vr1 := r1.(VocabularyResult)
+	vr2 := r2.(VocabularyResult)
+	merged := VocabularyResult{Terms: map[string]int{}, Sampling: vr1.Sampling}
+	if vr2.Sampling > merged.Sampling {
+		merged.Sampling = vr2.Sampling
+	}
+	var people map[string][3]int
+	people, merged.reversedPeopleDict = identity.Detector{}.MergeReversedDicts(
+		vr1.reversedPeopleDict, vr2.reversedPeopleDict)
+	merged.People = make([]map[string]int, len(merged.reversedPeopleDict)+1)
+	for i := range merged.People {
+		merged.People[i] = map[string]int{}
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	offsets := [2]int{
+		int((c1.BeginTime - beginTime) / (24 * 3600)),
+		int((c2.BeginTime - beginTime) / (24 * 3600)),
+	}
+	results := [2]*VocabularyResult{&vr1, &vr2}
+	days := 0
+	termsSize, peopleSize := 0, 0
+	for i, result := range results {
+		if end := offsets[i] + len(result.Ticks)*result.Sampling; end > days {
+			days = end
+		}
+		if len(result.Terms) > termsSize {
+			termsSize = len(result.Terms)
+		}
+		for _, terms := range result.People {
+			if len(terms) > peopleSize {
+				peopleSize = len(terms)
+			}
+		}
+	}
+	merged.Ticks = make([]VocabularyTick, (days+merged.Sampling-1)/merged.Sampling)
+	for i, result := range results {
+		for j, tick := range result.Ticks {
+			// the merged tick which contains the first day of the tick
+			k := (j*result.Sampling + offsets[i]) / merged.Sampling
+			merged.Ticks[k].Introduced += tick.Introduced
+			merged.Ticks[k].Retired += tick.Retired
+		}
+		// the vocabulary size at the end of each merged tick
+		for k := range merged.Ticks {
+			day := (k+1)*merged.Sampling - 1 - offsets[i]
+			if day < 0 || len(result.Ticks) == 0 {
+				continue
+			}
+			index := day / result.Sampling
+			if index >= len(result.Ticks) {
+				index = len(result.Ticks) - 1
+			}
+			merged.Ticks[k].Terms += result.Ticks[index].Terms
+		}
+		for term, count := range result.Terms {
+			merged.Terms[term] += count
+		}
+		for dev, terms := range result.People {
+			index := len(merged.reversedPeopleDict)
+			if dev < len(result.reversedPeopleDict) {
+				index = people[result.reversedPeopleDict[dev]][0]
+			}
+			for term, count := range terms {
+				merged.People[index][term] += count
+			}
+		}
+	}
+	merged.Terms = topVocabularyTerms(merged.Terms, termsSize)
+	for i, terms := range merged.People {
+		merged.People[i] = topVocabularyTerms(terms, peopleSize)
+	}
+	return merged
+}
+
+func (analyser *VocabularyAnalysis) serializeText(result *VocabularyResult, writer io.Writer) {
+	formatTerms := func(terms map[string]int) string {
+		keys := make([]string, 0, len(terms))
+		for term := range terms {
+			keys = append(keys, term)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, term := range keys {
+			parts[i] = fmt.Sprintf("%s: %d", yaml.SafeString(term), terms[term])
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	}
+	ticks := make([]string, len(result.Ticks))
+	for i, tick := range result.Ticks {
+		ticks[i] = fmt.Sprintf("[%d, %d, %d]", tick.Terms, tick.Introduced, tick.Retired)
+	}
+	fmt.Fprintln(writer, "  sampling:", result.Sampling)
+	fmt.Fprintln(writer, "  # [terms, introduced, retired]")
+	fmt.Fprintf(writer, "  ticks: [%s]\n", strings.Join(ticks, ", "))
+	fmt.Fprintln(writer, "  terms:", formatTerms(result.Terms))
+	fmt.Fprintln(writer, "  people_terms:")
+	for _, terms := range result.People {
+		fmt.Fprintln(writer, "  -", formatTerms(terms))
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (analyser *VocabularyAnalysis) serializeBinary(result *VocabularyResult, writer io.Writer) error {
+	convert := func(terms map[string]int) map[string]int32 {
+		result := make(map[string]int32, len(terms))
+		for term, count := range terms {
+			result[term] = int32(count)
+		}
+		return result
+	}
+	message := pb.VocabularyAnalysisResults{
+		Sampling: int32(result.Sampling),
+		Ticks:    make([]*pb.VocabularyTick, len(result.Ticks)),
+		Terms:    convert(result.Terms),
+		People:   make([]*pb.VocabularyTerms, len(result.People)),
+		DevIndex: result.reversedPeopleDict,
+	}
+	for i, tick := range result.Ticks {
+		message.Ticks[i] = &pb.VocabularyTick{
+			Terms: int32(tick.Terms), Introduced: int32(tick.Introduced),
+			Retired: int32(tick.Retired)}
+	}
+	for i, terms := range result.People {
+		message.People[i] = &pb.VocabularyTerms{Terms: convert(terms)}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&VocabularyAnalysis{})
+}
@@ -0,0 +1,186 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	uast_items "gopkg.in/src-d/hercules.v4/internal/plumbing/uast"
+)
+
+func fixtureVocabulary() *VocabularyAnalysis {
+	analyser := VocabularyAnalysis{}
+	analyser.Configure(map[string]interface{}{
+		ConfigVocabularySampling:                        10,
+		ConfigVocabularyTopTerms:                        3,
+		identity.FactIdentityDetectorPeopleCount:        2,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"one", "two"},
+	})
+	analyser.Initialize(nil)
+	return &analyser
+}
+
+func identifiersNode(tokens ...string) *uast.Node {
+	node := &uast.Node{}
+	for _, token := range tokens {
+		node.Children = append(node.Children, &uast.Node{
+			Roles: []uast.Role{uast.Identifier}, Token: token})
+	}
+	// not an identifier
+	node.Children = append(node.Children, &uast.Node{
+		Roles: []uast.Role{uast.Literal}, Token: "literalValue"})
+	return node
+}
+
+func TestVocabularyMeta(t *testing.T) {
+	analyser := fixtureVocabulary()
+	assert.Equal(t, analyser.Name(), "Vocabulary")
+	assert.Len(t, analyser.Provides(), 0)
+	assert.Equal(t, analyser.Requires(), []string{
+		uast_items.DependencyUastChanges, identity.DependencyAuthor, items.DependencyDay})
+	assert.Equal(t, analyser.Features(), []string{uast_items.FeatureUast})
+	assert.Equal(t, analyser.Flag(), "vocabulary")
+	assert.NotEmpty(t, analyser.Description())
+	opts := analyser.ListConfigurationOptions()
+	assert.Len(t, opts, 2)
+	assert.Equal(t, opts[0].Flag, "vocabulary-sampling")
+	assert.Equal(t, opts[1].Flag, "vocabulary-top")
+	assert.Equal(t, analyser.Sampling, 10)
+	assert.Equal(t, analyser.TopTerms, 3)
+	assert.Equal(t, analyser.PeopleNumber, 2)
+	assert.Equal(t, analyser.reversedPeopleDict, []string{"one", "two"})
+	analyser = &VocabularyAnalysis{TopTerms: -1}
+	analyser.Initialize(nil)
+	assert.Equal(t, analyser.Sampling, DefaultVocabularySampling)
+	assert.Equal(t, analyser.TopTerms, DefaultVocabularyTopTerms)
+	summoned := core.Registry.Summon(analyser.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "Vocabulary")
+}
+
+func TestVocabularySplitIdentifier(t *testing.T) {
+	assert.Equal(t, splitIdentifier("parseHTTPHeader_v2"), []string{"parse", "http", "header"})
+	assert.Equal(t, splitIdentifier("MAX_BUFFER_SIZE"), []string{"max", "buffer", "size"})
+	assert.Equal(t, splitIdentifier("x"), []string(nil))
+	assert.Equal(t, splitIdentifier("__init__"), []string{"init"})
+	assert.Equal(t, splitIdentifier("größeZahl"), []string{"größe", "zahl"})
+	assert.Equal(t, extractTerms(identifiersNode("getName", "name", "i")),
+		map[string]int{"get": 1, "name": 2})
+}
+
+func fixtureVocabularyResult(t *testing.T) VocabularyResult {
+	analyser := fixtureVocabulary()
+	consume := func(day, author int, changes ...uast_items.Change) {
+		result, err := analyser.Consume(map[string]interface{}{
+			core.DependencyCommit:            &object.Commit{},
+			core.DependencyIsMerge:           false,
+			uast_items.DependencyUastChanges: changes,
+			identity.DependencyAuthor:        author,
+			items.DependencyDay:              day,
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	}
+	change := func(from, to string, after *uast.Node) uast_items.Change {
+		return uast_items.Change{After: after, Change: &object.Change{
+			From: object.ChangeEntry{Name: from}, To: object.ChangeEntry{Name: to}}}
+	}
+	consume(0, 0,
+		change("", "a.go", identifiersNode("getName", "setName")),
+		change("", "b.go", identifiersNode("readFile")))
+	consume(3, identity.AuthorMissing,
+		// could not be parsed, keeps the terms
+		change("a.go", "c.go", nil),
+		change("b.go", "b.go", identifiersNode("writeFile", "fileSize")))
+	consume(14, 1, change("c.go", "", nil), change("", "d.go", identifiersNode("name")))
+	return analyser.Finalize().(VocabularyResult)
+}
+
+func TestVocabularyConsumeFinalize(t *testing.T) {
+	result := fixtureVocabularyResult(t)
+	assert.Equal(t, result.Sampling, 10)
+	assert.Equal(t, result.Ticks, []VocabularyTick{
+		{Terms: 6, Introduced: 7, Retired: 1}, {Terms: 4, Introduced: 1, Retired: 3}})
+	assert.Equal(t, result.Terms, map[string]int{"file": 2, "name": 1, "size": 1})
+	assert.Equal(t, result.People, []map[string]int{
+		{"file": 1, "get": 1, "name": 2}, {"name": 1}, {"file": 1, "size": 1, "write": 1}})
+	assert.Equal(t, result.reversedPeopleDict, []string{"one", "two"})
+}
+
+func TestVocabularyConsumeMerge(t *testing.T) {
+	analyser := fixtureVocabulary()
+	result, err := analyser.Consume(map[string]interface{}{
+		core.DependencyCommit: &object.Commit{
+			ParentHashes: make([]plumbing.Hash, 2)},
+		core.DependencyIsMerge: true,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+	assert.Len(t, analyser.Finalize().(VocabularyResult).Ticks, 0)
+}
+
+func TestVocabularySerialize(t *testing.T) {
+	result := fixtureVocabularyResult(t)
+	analyser := fixtureVocabulary()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  sampling: 10
+  # [terms, introduced, retired]
+  ticks: [[6, 7, 1], [4, 1, 3]]
+  terms: {"file": 2, "name": 1, "size": 1}
+  people_terms:
+  - {"file": 1, "get": 1, "name": 2}
+  - {"name": 1}
+  - {"file": 1, "size": 1, "write": 1}
+  people:
+  - "one"
+  - "two"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, analyser.Serialize(result, true, buffer))
+	msg := pb.VocabularyAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Sampling, int32(10))
+	assert.Len(t, msg.Ticks, 2)
+	assert.Len(t, msg.People, 3)
+	assert.Equal(t, msg.Terms["file"], int32(2))
+	deserialized, err := analyser.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestVocabularyMergeResults(t *testing.T) {
+	r1 := VocabularyResult{
+		Ticks:              []VocabularyTick{{Terms: 2, Introduced: 2}, {Terms: 3, Introduced: 2, Retired: 1}},
+		Terms:              map[string]int{"a": 2, "b": 1, "c": 1},
+		People:             []map[string]int{{"a": 2}, {"b": 1, "c": 1}, {}},
+		Sampling:           10,
+		reversedPeopleDict: []string{"one", "two"},
+	}
+	r2 := VocabularyResult{
+		Ticks:              []VocabularyTick{{Terms: 4, Introduced: 4}},
+		Terms:              map[string]int{"a": 1, "d": 5},
+		People:             []map[string]int{{"a": 1, "d": 5}, {}},
+		Sampling:           20,
+		reversedPeopleDict: []string{"two"},
+	}
+	analyser := fixtureVocabulary()
+	merged := analyser.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 20 * 24 * 3600}).(VocabularyResult)
+	assert.Equal(t, merged.Sampling, 20)
+	assert.Equal(t, merged.Ticks, []VocabularyTick{
+		{Terms: 3, Introduced: 4, Retired: 1}, {Terms: 7, Introduced: 4}})
+	assert.Equal(t, merged.Terms, map[string]int{"a": 3, "d": 5, "b": 1})
+	assert.Equal(t, merged.reversedPeopleDict, []string{"one", "two"})
+	assert.Equal(t, merged.People, []map[string]int{
+		{"a": 2}, {"a": 1, "d": 5}, {}})
+}
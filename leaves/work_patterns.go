@@ -0,0 +1,537 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+	"gopkg.in/src-d/hercules.v4/internal/yaml"
+)
+
+// WorkPatternsAnalysis builds the hour-of-day and day-of-week histograms of the commits
+// of each developer and flags the sustained work outside of the working hours: the series
+// of consecutive weeks in which the developer committed on the weekends or after hours.
+// It needs only the commit metadata, so it is suitable for the fast mode.
+type WorkPatternsAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Timezone defines the time zone of the commit timestamps: "author" means the author's
+	// own time zone as recorded in the commit, otherwise it is a name from the IANA database,
+	// e.g. "UTC" or "Europe/Madrid".
+	Timezone string
+	// WorkDayStart is the hour when the working day starts.
+	WorkDayStart int
+	// WorkDayEnd is the hour when the working day ends.
+	WorkDayEnd int
+	// SustainedWeeks is the minimum number of consecutive weeks with the off-hours commits
+	// which is reported as a streak.
+	SustainedWeeks int
+	// PeopleNumber is the number of developers.
+	PeopleNumber int
+
+	// people is the work pattern of each developer.
+	people []WorkPattern
+	// location is the loaded Timezone or nil for "author".
+	location *time.Location
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+// WorkPattern is the commit time distribution of a developer.
+type WorkPattern struct {
+	// Hours is the number of commits made in each hour of the day.
+	Hours [24]int
+	// Weekdays is the number of commits made on each day of the week, starting from Sunday.
+	Weekdays [7]int
+	// OffHours is the number of commits made on the weekends or outside of the working hours.
+	OffHours int
+	// OffHoursDays are the sorted unique day indices of the off-hours commits.
+	OffHoursDays []int
+}
+
+// WorkPatternsStreak is a series of consecutive weeks in which a developer made
+// the off-hours commits.
+type WorkPatternsStreak struct {
+	// Developer is the index in the people dictionary.
+	Developer int
+	// BeginDay is the day of the first off-hours commit in the series.
+	BeginDay int
+	// EndDay is the day of the last off-hours commit in the series.
+	EndDay int
+	// Weeks is the length of the series.
+	Weeks int
+}
+
+// WorkPatternsResult is returned by WorkPatternsAnalysis.Finalize().
+type WorkPatternsResult struct {
+	// People is the work pattern of each developer. The last element corresponds
+	// to the unmatched identities.
+	People []WorkPattern
+	// Streaks are the sustained off-hours series of SustainedWeeks or more weeks,
+	// sorted by the developer and BeginDay.
+	Streaks []WorkPatternsStreak
+	// Timezone is the effective WorkPatternsAnalysis.Timezone.
+	Timezone string
+	// WorkDayStart is the effective WorkPatternsAnalysis.WorkDayStart.
+	WorkDayStart int
+	// WorkDayEnd is the effective WorkPatternsAnalysis.WorkDayEnd.
+	WorkDayEnd int
+	// SustainedWeeks is the effective WorkPatternsAnalysis.SustainedWeeks.
+	SustainedWeeks int
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
+	reversedPeopleDict []string
+}
+
+const (
+	// ConfigWorkPatternsTimezone is the name of the option to set WorkPatternsAnalysis.Timezone.
+	ConfigWorkPatternsTimezone = "WorkPatterns.Timezone"
+	// ConfigWorkPatternsWorkDayStart is the name of the option to set
+	// WorkPatternsAnalysis.WorkDayStart.
+	ConfigWorkPatternsWorkDayStart = "WorkPatterns.WorkDayStart"
+	// ConfigWorkPatternsWorkDayEnd is the name of the option to set WorkPatternsAnalysis.WorkDayEnd.
+	ConfigWorkPatternsWorkDayEnd = "WorkPatterns.WorkDayEnd"
+	// ConfigWorkPatternsSustainedWeeks is the name of the option to set
+	// WorkPatternsAnalysis.SustainedWeeks.
+	ConfigWorkPatternsSustainedWeeks = "WorkPatterns.SustainedWeeks"
+	// DefaultWorkPatternsTimezone is the default value of WorkPatternsAnalysis.Timezone.
+	DefaultWorkPatternsTimezone = "author"
+	// DefaultWorkPatternsWorkDayStart is the default value of WorkPatternsAnalysis.WorkDayStart.
+	DefaultWorkPatternsWorkDayStart = 9
+	// DefaultWorkPatternsWorkDayEnd is the default value of WorkPatternsAnalysis.WorkDayEnd.
+	DefaultWorkPatternsWorkDayEnd = 18
+	// DefaultWorkPatternsSustainedWeeks is the default value of WorkPatternsAnalysis.SustainedWeeks.
+	DefaultWorkPatternsSustainedWeeks = 4
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (patterns *WorkPatternsAnalysis) Name() string {
+	return "WorkPatterns"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (patterns *WorkPatternsAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (patterns *WorkPatternsAnalysis) Requires() []string {
+	arr := [...]string{identity.DependencyAuthor, items.DependencyDay}
+	return arr[:]
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (patterns *WorkPatternsAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigWorkPatternsTimezone,
+		Description: "Time zone of the commit timestamps: \"author\" means the author's own " +
+			"time zone, otherwise it is an IANA name such as \"UTC\" or \"Europe/Madrid\".",
+		Flag:    "work-patterns-timezone",
+		Type:    core.StringConfigurationOption,
+		Default: DefaultWorkPatternsTimezone}, {
+		Name:        ConfigWorkPatternsWorkDayStart,
+		Description: "Hour when the working day starts.",
+		Flag:        "work-patterns-start",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultWorkPatternsWorkDayStart}, {
+		Name:        ConfigWorkPatternsWorkDayEnd,
+		Description: "Hour when the working day ends.",
+		Flag:        "work-patterns-end",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultWorkPatternsWorkDayEnd}, {
+		Name: ConfigWorkPatternsSustainedWeeks,
+		Description: "Minimum number of consecutive weeks with the weekend or after-hours " +
+			"commits which are reported as sustained off-hours work.",
+		Flag:    "work-patterns-weeks",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultWorkPatternsSustainedWeeks},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (patterns *WorkPatternsAnalysis) Configure(facts map[string]interface{}) {
+	if val, exists := facts[ConfigWorkPatternsTimezone].(string); exists {
+		patterns.Timezone = val
+	}
+	if val, exists := facts[ConfigWorkPatternsWorkDayStart].(int); exists {
+		patterns.WorkDayStart = val
+	}
+	if val, exists := facts[ConfigWorkPatternsWorkDayEnd].(int); exists {
+		patterns.WorkDayEnd = val
+	}
+	if val, exists := facts[ConfigWorkPatternsSustainedWeeks].(int); exists {
+		patterns.SustainedWeeks = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorPeopleCount].(int); exists {
+		patterns.PeopleNumber = val
+		patterns.reversedPeopleDict = facts[identity.FactIdentityDetectorReversedPeopleDict].([]string)
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (patterns *WorkPatternsAnalysis) Flag() string {
+	return "work-patterns"
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (patterns *WorkPatternsAnalysis) Description() string {
+	return "Builds the hour-of-day and day-of-week histograms of the commits of each developer " +
+		"and flags the sustained weekend and after-hours work."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (patterns *WorkPatternsAnalysis) Initialize(repository *git.Repository) {
+	if patterns.Timezone == "" {
+		patterns.Timezone = DefaultWorkPatternsTimezone
+	}
+	patterns.location = nil
+	if patterns.Timezone != DefaultWorkPatternsTimezone {
+		location, err := time.LoadLocation(patterns.Timezone)
+		if err != nil {
+			log.Printf("Warning: adjusted the work patterns time zone to %s: %v\n",
+				DefaultWorkPatternsTimezone, err)
+			patterns.Timezone = DefaultWorkPatternsTimezone
+		} else {
+			patterns.location = location
+		}
+	}
+	if patterns.WorkDayStart == 0 && patterns.WorkDayEnd == 0 {
+		patterns.WorkDayStart = DefaultWorkPatternsWorkDayStart
+		patterns.WorkDayEnd = DefaultWorkPatternsWorkDayEnd
+	}
+	if patterns.WorkDayStart < 0 || patterns.WorkDayEnd > 24 ||
+		patterns.WorkDayStart >= patterns.WorkDayEnd {
+		log.Printf("Warning: adjusted the working hours to %d-%d\n",
+			DefaultWorkPatternsWorkDayStart, DefaultWorkPatternsWorkDayEnd)
+		patterns.WorkDayStart = DefaultWorkPatternsWorkDayStart
+		patterns.WorkDayEnd = DefaultWorkPatternsWorkDayEnd
+	}
+	if patterns.SustainedWeeks <= 0 {
+		log.Printf("Warning: adjusted the sustained off-hours weeks to %d\n",
+			DefaultWorkPatternsSustainedWeeks)
+		patterns.SustainedWeeks = DefaultWorkPatternsSustainedWeeks
+	}
+	patterns.people = make([]WorkPattern, patterns.PeopleNumber+1)
+	patterns.OneShotMergeProcessor.Initialize()
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (patterns *WorkPatternsAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !patterns.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author == identity.AuthorMissing || author > patterns.PeopleNumber {
+		author = patterns.PeopleNumber
+	}
+	when := deps[core.DependencyCommit].(*object.Commit).Author.When
+	if patterns.location != nil {
+		when = when.In(patterns.location)
+	}
+	pattern := &patterns.people[author]
+	pattern.Hours[when.Hour()]++
+	pattern.Weekdays[when.Weekday()]++
+	if !patterns.isWorkingTime(when) {
+		pattern.OffHours++
+		day := deps[items.DependencyDay].(int)
+		if n := len(pattern.OffHoursDays); n == 0 || pattern.OffHoursDays[n-1] < day {
+			pattern.OffHoursDays = append(pattern.OffHoursDays, day)
+		} else if pattern.OffHoursDays[n-1] > day {
+			pos := sort.SearchInts(pattern.OffHoursDays, day)
+			if pattern.OffHoursDays[pos] != day {
+				pattern.OffHoursDays = append(pattern.OffHoursDays, 0)
+				copy(pattern.OffHoursDays[pos+1:], pattern.OffHoursDays[pos:])
+				pattern.OffHoursDays[pos] = day
+			}
+		}
+	}
+	return nil, nil
+}
+
+// isWorkingTime returns true if the time belongs to the working hours of a weekday.
+func (patterns *WorkPatternsAnalysis) isWorkingTime(when time.Time) bool {
+	if weekday := when.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+		return false
+	}
+	hour := when.Hour()
+	return hour >= patterns.WorkDayStart && hour < patterns.WorkDayEnd
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (patterns *WorkPatternsAnalysis) Finalize() interface{} {
+	return WorkPatternsResult{
+		People:             patterns.people,
+		Streaks:            workPatternsStreaks(patterns.people, patterns.SustainedWeeks),
+		Timezone:           patterns.Timezone,
+		WorkDayStart:       patterns.WorkDayStart,
+		WorkDayEnd:         patterns.WorkDayEnd,
+		SustainedWeeks:     patterns.SustainedWeeks,
+		reversedPeopleDict: patterns.reversedPeopleDict,
+	}
+}
+
+// workPatternsStreaks finds the series of at least `minWeeks` consecutive weeks
+// with the off-hours commits. The weeks are counted from the beginning of the history.
+func workPatternsStreaks(people []WorkPattern, minWeeks int) []WorkPatternsStreak {
+	var streaks []WorkPatternsStreak
+	for dev, pattern := range people {
+		days := pattern.OffHoursDays
+		for begin := 0; begin < len(days); {
+			end := begin + 1
+			weeks := 1
+			for ; end < len(days); end++ {
+				week, prevWeek := days[end]/7, days[end-1]/7
+				if week == prevWeek {
+					continue
+				}
+				if week != prevWeek+1 {
+					break
+				}
+				weeks++
+			}
+			if weeks >= minWeeks {
+				streaks = append(streaks, WorkPatternsStreak{
+					Developer: dev, BeginDay: days[begin], EndDay: days[end-1], Weeks: weeks})
+			}
+			begin = end
+		}
+	}
+	return streaks
+}
+
+// Fork clones this pipeline item.
+func (patterns *WorkPatternsAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(patterns, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (patterns *WorkPatternsAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	patternsResult := result.(WorkPatternsResult)
+	if binary {
+		return patterns.serializeBinary(&patternsResult, writer)
+	}
+	patterns.serializeText(&patternsResult, writer)
+	return nil
+}
+
+// SerializeJSON converts the analysis result as returned by Finalize() to JSON.
+func (patterns *WorkPatternsAnalysis) SerializeJSON(result interface{}, writer io.Writer) error {
+	return core.SerializeJSON(patterns, result, &pb.WorkPatternsAnalysisResults{}, writer)
+}
+
+// ExportCSV converts the analysis result as returned by Finalize() to the tidy tables.
+func (patterns *WorkPatternsAnalysis) ExportCSV(result interface{}) ([]core.CSVTable, error) {
+	return core.ExportCSV(patterns, result, &pb.WorkPatternsAnalysisResults{})
+}
+
+// Deserialize converts the specified protobuf bytes to WorkPatternsResult.
+func (patterns *WorkPatternsAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.WorkPatternsAnalysisResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := WorkPatternsResult{
+		People:             make([]WorkPattern, len(message.People)),
+		Timezone:           message.Timezone,
+		WorkDayStart:       int(message.WorkDayStart),
+		WorkDayEnd:         int(message.WorkDayEnd),
+		SustainedWeeks:     int(message.SustainedWeeks),
+		reversedPeopleDict: message.DevIndex,
+	}
+	for i, person := range message.People {
+		pattern := &result.People[i]
+		for hour, val := range person.Hours {
+			pattern.Hours[hour] = int(val)
+		}
+		for weekday, val := range person.Weekdays {
+			pattern.Weekdays[weekday] = int(val)
+		}
+		pattern.OffHours = int(person.OffHours)
+		if len(person.OffHoursDays) > 0 {
+			pattern.OffHoursDays = make([]int, len(person.OffHoursDays))
+			for j, day := range person.OffHoursDays {
+				pattern.OffHoursDays[j] = int(day)
+			}
+		}
+	}
+	if len(message.Streaks) > 0 {
+		result.Streaks = make([]WorkPatternsStreak, len(message.Streaks))
+		for i, streak := range message.Streaks {
+			result.Streaks[i] = WorkPatternsStreak{
+				Developer: int(streak.Developer),
+				BeginDay:  int(streak.BeginDay),
+				EndDay:    int(streak.EndDay),
+				Weeks:     int(streak.Weeks),
+			}
+		}
+	}
+	return result, nil
+}
+
+// MergeResults combines two WorkPatternsResult-s together. The settings of the first result
+// are kept and the streaks are found again in the merged off-hours days.
+func (patterns *WorkPatternsAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	wr1 := r1.(WorkPatternsResult)
+	wr2 := r2.(WorkPatternsResult)
+	merged := WorkPatternsResult{
+		Timezone:       wr1.Timezone,
+		WorkDayStart:   wr1.WorkDayStart,
+		WorkDayEnd:     wr1.WorkDayEnd,
+		SustainedWeeks: wr1.SustainedWeeks,
+	}
+	var people map[string][3]int
+	people, merged.reversedPeopleDict = identity.Detector{}.MergeReversedDicts(
+		wr1.reversedPeopleDict, wr2.reversedPeopleDict)
+	merged.People = make([]WorkPattern, len(merged.reversedPeopleDict)+1)
+	days := make([]map[int]bool, len(merged.People))
+	for i := range days {
+		days[i] = map[int]bool{}
+	}
+	beginTime := c1.BeginTime
+	if c2.BeginTime < beginTime {
+		beginTime = c2.BeginTime
+	}
+	add := func(result *WorkPatternsResult, c *core.CommonAnalysisResult) {
+		offset := int((c.BeginTime - beginTime) / (24 * 3600))
+		for dev, pattern := range result.People {
+			index := len(merged.reversedPeopleDict)
+			if dev < len(result.reversedPeopleDict) {
+				index = people[result.reversedPeopleDict[dev]][0]
+			}
+			target := &merged.People[index]
+			for hour, val := range pattern.Hours {
+				target.Hours[hour] += val
+			}
+			for weekday, val := range pattern.Weekdays {
+				target.Weekdays[weekday] += val
+			}
+			target.OffHours += pattern.OffHours
+			for _, day := range pattern.OffHoursDays {
+				days[index][day+offset] = true
+			}
+		}
+	}
+	add(&wr1, c1)
+	add(&wr2, c2)
+	for i, set := range days {
+		if len(set) == 0 {
+			continue
+		}
+		list := make([]int, 0, len(set))
+		for day := range set {
+			list = append(list, day)
+		}
+		sort.Ints(list)
+		merged.People[i].OffHoursDays = list
+	}
+	merged.Streaks = workPatternsStreaks(merged.People, merged.SustainedWeeks)
+	return merged
+}
+
+func (patterns *WorkPatternsAnalysis) serializeText(result *WorkPatternsResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  timezone:", yaml.SafeString(result.Timezone))
+	fmt.Fprintf(writer, "  working_hours: [%d, %d]\n", result.WorkDayStart, result.WorkDayEnd)
+	fmt.Fprintln(writer, "  sustained_weeks:", result.SustainedWeeks)
+	fmt.Fprintln(writer, "  people_hours:")
+	for _, pattern := range result.People {
+		fmt.Fprint(writer, "  - [")
+		writeIntList(writer, pattern.Hours[:])
+		fmt.Fprintln(writer, "]")
+	}
+	fmt.Fprintln(writer, "  # starting from Sunday")
+	fmt.Fprintln(writer, "  people_weekdays:")
+	for _, pattern := range result.People {
+		fmt.Fprint(writer, "  - [")
+		writeIntList(writer, pattern.Weekdays[:])
+		fmt.Fprintln(writer, "]")
+	}
+	fmt.Fprint(writer, "  people_off_hours: [")
+	for i, pattern := range result.People {
+		if i > 0 {
+			fmt.Fprint(writer, ", ")
+		}
+		fmt.Fprint(writer, pattern.OffHours)
+	}
+	fmt.Fprintln(writer, "]")
+	fmt.Fprintln(writer, "  streaks:")
+	for _, streak := range result.Streaks {
+		fmt.Fprintf(writer, "    - developer: %d\n", streak.Developer)
+		fmt.Fprintf(writer, "      begin: %d\n", streak.BeginDay)
+		fmt.Fprintf(writer, "      end: %d\n", streak.EndDay)
+		fmt.Fprintf(writer, "      weeks: %d\n", streak.Weeks)
+	}
+	fmt.Fprintln(writer, "  people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (patterns *WorkPatternsAnalysis) serializeBinary(result *WorkPatternsResult, writer io.Writer) error {
+	message := pb.WorkPatternsAnalysisResults{
+		Timezone:       result.Timezone,
+		WorkDayStart:   int32(result.WorkDayStart),
+		WorkDayEnd:     int32(result.WorkDayEnd),
+		SustainedWeeks: int32(result.SustainedWeeks),
+		People:         make([]*pb.WorkPattern, len(result.People)),
+		Streaks:        make([]*pb.WorkPatternsStreak, len(result.Streaks)),
+		DevIndex:       result.reversedPeopleDict,
+	}
+	for i, pattern := range result.People {
+		person := &pb.WorkPattern{
+			Hours:        make([]int32, len(pattern.Hours)),
+			Weekdays:     make([]int32, len(pattern.Weekdays)),
+			OffHours:     int32(pattern.OffHours),
+			OffHoursDays: make([]int32, len(pattern.OffHoursDays)),
+		}
+		for hour, val := range pattern.Hours {
+			person.Hours[hour] = int32(val)
+		}
+		for weekday, val := range pattern.Weekdays {
+			person.Weekdays[weekday] = int32(val)
+		}
+		for j, day := range pattern.OffHoursDays {
+			person.OffHoursDays[j] = int32(day)
+		}
+		message.People[i] = person
+	}
+	for i, streak := range result.Streaks {
+		message.Streaks[i] = &pb.WorkPatternsStreak{
+			Developer: int32(streak.Developer),
+			BeginDay:  int32(streak.BeginDay),
+			EndDay:    int32(streak.EndDay),
+			Weeks:     int32(streak.Weeks),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	writer.Write(serialized)
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&WorkPatternsAnalysis{})
+}
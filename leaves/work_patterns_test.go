@@ -0,0 +1,255 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/hercules.v4/internal/core"
+	"gopkg.in/src-d/hercules.v4/internal/pb"
+	items "gopkg.in/src-d/hercules.v4/internal/plumbing"
+	"gopkg.in/src-d/hercules.v4/internal/plumbing/identity"
+)
+
+func fixtureWorkPatterns(timezone string) *WorkPatternsAnalysis {
+	patterns := WorkPatternsAnalysis{}
+	patterns.Configure(map[string]interface{}{
+		ConfigWorkPatternsTimezone:                      timezone,
+		ConfigWorkPatternsWorkDayStart:                  9,
+		ConfigWorkPatternsWorkDayEnd:                    18,
+		ConfigWorkPatternsSustainedWeeks:                3,
+		identity.FactIdentityDetectorPeopleCount:        2,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"alice", "bob"},
+	})
+	patterns.Initialize(nil)
+	return &patterns
+}
+
+func TestWorkPatternsMeta(t *testing.T) {
+	patterns := fixtureWorkPatterns("UTC")
+	assert.Equal(t, patterns.Name(), "WorkPatterns")
+	assert.Len(t, patterns.Provides(), 0)
+	assert.Equal(t, patterns.Requires(), []string{identity.DependencyAuthor, items.DependencyDay})
+	assert.Equal(t, patterns.Flag(), "work-patterns")
+	assert.NotEmpty(t, patterns.Description())
+	opts := patterns.ListConfigurationOptions()
+	assert.Len(t, opts, 4)
+	assert.Equal(t, opts[0].Flag, "work-patterns-timezone")
+	assert.Equal(t, opts[1].Flag, "work-patterns-start")
+	assert.Equal(t, opts[2].Flag, "work-patterns-end")
+	assert.Equal(t, opts[3].Flag, "work-patterns-weeks")
+	assert.Equal(t, patterns.Timezone, "UTC")
+	assert.Equal(t, patterns.location, time.UTC)
+	assert.Equal(t, patterns.SustainedWeeks, 3)
+	assert.Equal(t, patterns.PeopleNumber, 2)
+	assert.Equal(t, patterns.reversedPeopleDict, []string{"alice", "bob"})
+	patterns = &WorkPatternsAnalysis{Timezone: "Mars/Olympus", WorkDayStart: 20, WorkDayEnd: 8}
+	patterns.Initialize(nil)
+	assert.Equal(t, patterns.Timezone, DefaultWorkPatternsTimezone)
+	assert.Nil(t, patterns.location)
+	assert.Equal(t, patterns.WorkDayStart, DefaultWorkPatternsWorkDayStart)
+	assert.Equal(t, patterns.WorkDayEnd, DefaultWorkPatternsWorkDayEnd)
+	assert.Equal(t, patterns.SustainedWeeks, DefaultWorkPatternsSustainedWeeks)
+	patterns = &WorkPatternsAnalysis{}
+	patterns.Initialize(nil)
+	assert.Equal(t, patterns.Timezone, DefaultWorkPatternsTimezone)
+	assert.Equal(t, patterns.WorkDayStart, DefaultWorkPatternsWorkDayStart)
+	assert.Equal(t, patterns.WorkDayEnd, DefaultWorkPatternsWorkDayEnd)
+	summoned := core.Registry.Summon(patterns.Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, summoned[0].Name(), "WorkPatterns")
+}
+
+func consumeWorkPatterns(t *testing.T, patterns *WorkPatternsAnalysis, author, day int, when string) {
+	timestamp, err := time.Parse(time.RFC3339, when)
+	assert.Nil(t, err)
+	result, err := patterns.Consume(map[string]interface{}{
+		core.DependencyCommit:     &object.Commit{Author: object.Signature{When: timestamp}},
+		core.DependencyIsMerge:    false,
+		identity.DependencyAuthor: author,
+		items.DependencyDay:       day,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func fixtureWorkPatternsResult(t *testing.T, timezone string) WorkPatternsResult {
+	patterns := fixtureWorkPatterns(timezone)
+	// Monday, 08:00 UTC
+	consumeWorkPatterns(t, patterns, 0, 0, "2018-01-01T10:00:00+02:00")
+	// Saturday
+	consumeWorkPatterns(t, patterns, 0, 5, "2018-01-06T11:00:00+02:00")
+	consumeWorkPatterns(t, patterns, 0, 8, "2018-01-09T22:30:00+02:00")
+	consumeWorkPatterns(t, patterns, 0, 8, "2018-01-09T23:00:00+02:00")
+	consumeWorkPatterns(t, patterns, 0, 15, "2018-01-16T07:00:00+02:00")
+	consumeWorkPatterns(t, patterns, 1, 2, "2018-01-03T14:00:00-05:00")
+	// Sunday
+	consumeWorkPatterns(t, patterns, 1, 20, "2018-01-21T12:00:00-05:00")
+	consumeWorkPatterns(t, patterns, identity.AuthorMissing, 1, "2018-01-02T19:00:00Z")
+	return patterns.Finalize().(WorkPatternsResult)
+}
+
+func TestWorkPatternsConsumeFinalize(t *testing.T) {
+	result := fixtureWorkPatternsResult(t, "author")
+	assert.Equal(t, result.Timezone, "author")
+	assert.Equal(t, result.WorkDayStart, 9)
+	assert.Equal(t, result.WorkDayEnd, 18)
+	assert.Equal(t, result.SustainedWeeks, 3)
+	assert.Len(t, result.People, 3)
+	alice := WorkPattern{OffHours: 4, OffHoursDays: []int{5, 8, 15}}
+	alice.Hours[7], alice.Hours[10], alice.Hours[11], alice.Hours[22], alice.Hours[23] = 1, 1, 1, 1, 1
+	alice.Weekdays[time.Monday], alice.Weekdays[time.Tuesday], alice.Weekdays[time.Saturday] = 1, 3, 1
+	assert.Equal(t, result.People[0], alice)
+	bob := WorkPattern{OffHours: 1, OffHoursDays: []int{20}}
+	bob.Hours[12], bob.Hours[14] = 1, 1
+	bob.Weekdays[time.Sunday], bob.Weekdays[time.Wednesday] = 1, 1
+	assert.Equal(t, result.People[1], bob)
+	assert.Equal(t, result.People[2].Hours[19], 1)
+	assert.Equal(t, result.People[2].OffHoursDays, []int{1})
+	assert.Equal(t, result.Streaks, []WorkPatternsStreak{
+		{Developer: 0, BeginDay: 5, EndDay: 15, Weeks: 3}})
+	assert.Equal(t, result.reversedPeopleDict, []string{"alice", "bob"})
+
+	result = fixtureWorkPatternsResult(t, "UTC")
+	assert.Equal(t, result.People[0].Hours[8], 1)
+	assert.Equal(t, result.People[0].Hours[10], 0)
+	assert.Equal(t, result.People[0].OffHours, 5)
+	assert.Equal(t, result.People[0].OffHoursDays, []int{0, 5, 8, 15})
+	assert.Equal(t, result.Streaks, []WorkPatternsStreak{
+		{Developer: 0, BeginDay: 0, EndDay: 15, Weeks: 3}})
+	// 19:00 UTC on Wednesday
+	assert.Equal(t, result.People[1].Hours[19], 1)
+	assert.Equal(t, result.People[1].OffHours, 2)
+}
+
+func TestWorkPatternsConsumeOutOfOrder(t *testing.T) {
+	patterns := fixtureWorkPatterns("author")
+	consumeWorkPatterns(t, patterns, 0, 10, "2018-01-06T11:00:00Z")
+	consumeWorkPatterns(t, patterns, 0, 3, "2018-01-06T11:00:00Z")
+	consumeWorkPatterns(t, patterns, 0, 10, "2018-01-06T11:00:00Z")
+	consumeWorkPatterns(t, patterns, 0, 7, "2018-01-06T11:00:00Z")
+	assert.Equal(t, patterns.people[0].OffHoursDays, []int{3, 7, 10})
+	assert.Equal(t, patterns.people[0].OffHours, 4)
+}
+
+func TestWorkPatternsConsumeMerge(t *testing.T) {
+	patterns := fixtureWorkPatterns("author")
+	result, err := patterns.Consume(map[string]interface{}{
+		core.DependencyCommit: &object.Commit{
+			Hash:         plumbing.NewHash("0123456789012345678901234567890123456789"),
+			ParentHashes: make([]plumbing.Hash, 2),
+			Author: object.Signature{
+				When: time.Date(2018, 1, 6, 11, 0, 0, 0, time.UTC)}},
+		core.DependencyIsMerge:    true,
+		identity.DependencyAuthor: 1,
+		items.DependencyDay:       0,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+	// the merges are work too
+	assert.Equal(t, patterns.people[1].OffHours, 1)
+}
+
+func TestWorkPatternsStreaks(t *testing.T) {
+	people := []WorkPattern{
+		{OffHoursDays: []int{0, 6, 7, 22, 23, 28, 35, 50}},
+		{OffHoursDays: []int{1, 8}},
+		{},
+	}
+	assert.Equal(t, workPatternsStreaks(people, 2), []WorkPatternsStreak{
+		{Developer: 0, BeginDay: 0, EndDay: 7, Weeks: 2},
+		{Developer: 0, BeginDay: 22, EndDay: 35, Weeks: 3},
+		{Developer: 1, BeginDay: 1, EndDay: 8, Weeks: 2},
+	})
+	assert.Equal(t, workPatternsStreaks(people, 3), []WorkPatternsStreak{
+		{Developer: 0, BeginDay: 22, EndDay: 35, Weeks: 3},
+	})
+	assert.Nil(t, workPatternsStreaks(people, 4))
+}
+
+func TestWorkPatternsSerialize(t *testing.T) {
+	result := fixtureWorkPatternsResult(t, "author")
+	patterns := fixtureWorkPatterns("author")
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, patterns.Serialize(result, false, buffer))
+	assert.Equal(t, buffer.String(), `  timezone: "author"
+  working_hours: [9, 18]
+  sustained_weeks: 3
+  people_hours:
+  - [0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1]
+  - [0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0]
+  - [0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0]
+  # starting from Sunday
+  people_weekdays:
+  - [0, 1, 3, 0, 0, 0, 1]
+  - [1, 0, 0, 1, 0, 0, 0]
+  - [0, 0, 1, 0, 0, 0, 0]
+  people_off_hours: [4, 1, 1]
+  streaks:
+    - developer: 0
+      begin: 5
+      end: 15
+      weeks: 3
+  people:
+  - "alice"
+  - "bob"
+`)
+	buffer = &bytes.Buffer{}
+	assert.Nil(t, patterns.Serialize(result, true, buffer))
+	msg := pb.WorkPatternsAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Equal(t, msg.Timezone, "author")
+	assert.Len(t, msg.People, 3)
+	assert.Len(t, msg.People[0].Hours, 24)
+	assert.Equal(t, msg.People[0].OffHoursDays, []int32{5, 8, 15})
+	assert.Len(t, msg.Streaks, 1)
+	deserialized, err := patterns.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, deserialized, result)
+}
+
+func TestWorkPatternsMergeResults(t *testing.T) {
+	r1 := WorkPatternsResult{
+		People: []WorkPattern{
+			{OffHours: 2, OffHoursDays: []int{0, 8}}, {OffHours: 1, OffHoursDays: []int{3}}},
+		Timezone:           "UTC",
+		WorkDayStart:       9,
+		WorkDayEnd:         18,
+		SustainedWeeks:     3,
+		reversedPeopleDict: []string{"alice"},
+	}
+	r1.People[0].Hours[20] = 2
+	r1.People[0].Weekdays[time.Sunday] = 2
+	r2 := WorkPatternsResult{
+		People:             []WorkPattern{{OffHours: 1, OffHoursDays: []int{1}}, {}},
+		Timezone:           "author",
+		WorkDayStart:       10,
+		WorkDayEnd:         19,
+		SustainedWeeks:     2,
+		reversedPeopleDict: []string{"alice"},
+	}
+	r2.People[0].Hours[20] = 1
+	r2.People[0].Weekdays[time.Saturday] = 1
+	patterns := fixtureWorkPatterns("author")
+	merged := patterns.MergeResults(r1, r2,
+		&core.CommonAnalysisResult{BeginTime: 0},
+		&core.CommonAnalysisResult{BeginTime: 14 * 24 * 3600}).(WorkPatternsResult)
+	assert.Equal(t, merged.Timezone, "UTC")
+	assert.Equal(t, merged.WorkDayStart, 9)
+	assert.Equal(t, merged.WorkDayEnd, 18)
+	assert.Equal(t, merged.SustainedWeeks, 3)
+	assert.Equal(t, merged.reversedPeopleDict, []string{"alice"})
+	assert.Len(t, merged.People, 2)
+	assert.Equal(t, merged.People[0].Hours[20], 3)
+	assert.Equal(t, merged.People[0].Weekdays[time.Sunday], 2)
+	assert.Equal(t, merged.People[0].Weekdays[time.Saturday], 1)
+	assert.Equal(t, merged.People[0].OffHours, 3)
+	assert.Equal(t, merged.People[0].OffHoursDays, []int{0, 8, 15})
+	assert.Equal(t, merged.People[1].OffHoursDays, []int{3})
+	assert.Equal(t, merged.Streaks, []WorkPatternsStreak{
+		{Developer: 0, BeginDay: 0, EndDay: 15, Weeks: 3}})
+}